@@ -0,0 +1,8668 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: v1/user-svc.proto
+
+package pb
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// User message - represents a user in the system
+type User struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email    string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Username string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// Organization the user is a staff member of, empty for none
+	OrganizationId string `protobuf:"bytes,4,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// "active", "banned", or "invited" until the user sets a password
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// "customer", "staff" or "admin"
+	Role string `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	// Residency region the user's data is stored in, empty while residency is disabled; logins and
+	// token refreshes of the user pass it in the x-residency metadata
+	Residency     string `protobuf:"bytes,7,opt,name=residency,proto3" json:"residency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_v1_user_svc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *User) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetResidency() string {
+	if x != nil {
+		return x.Residency
+	}
+	return ""
+}
+
+// Register request message - used for user registration
+type RegisterRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Email    string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// Registered client (e.g. "web", "mobile", "kiosk") whose token policy applies
+	ClientId string `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Optional organization to join; the email must match its allowed domains
+	OrganizationId string `protobuf:"bytes,5,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// Invite code, required while the invite code registration gate is enabled
+	InviteCode    string `protobuf:"bytes,6,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisterRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+// Register response message - returned after successful registration
+type RegisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResponse) ProtoMessage() {}
+
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *RegisterResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RegisterResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// Login request message - used for user authentication
+type LoginRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Email    string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// Registered client (e.g. "web", "mobile", "kiosk") whose token policy applies
+	ClientId string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Long-lived session; without it the refresh token expires after jwt.short_refresh_token_duration
+	RememberMe    bool `protobuf:"varint,4,opt,name=remember_me,json=rememberMe,proto3" json:"remember_me,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetRememberMe() bool {
+	if x != nil {
+		return x.RememberMe
+	}
+	return false
+}
+
+// Login response message - returned after successful login
+type LoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LoginResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// Refresh token request message - used for refreshing access tokens
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// Refresh token response message - returned after successful token refresh
+type RefreshTokenResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// Replacement refresh token, set only when the client's policy rotates refresh tokens
+	RefreshToken  string `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RefreshTokenResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// Get quota usage request message - the subject is taken from the caller's credentials
+type GetQuotaUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaUsageRequest) Reset() {
+	*x = GetQuotaUsageRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaUsageRequest) ProtoMessage() {}
+
+func (x *GetQuotaUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{7}
+}
+
+// Quota usage message - consumption of a single quota bucket
+type QuotaUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Method is the full gRPC method name, or "*" for the subject-wide quota
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Used   int64  `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
+	// Limit is 0 when the bucket is not limited
+	Limit         int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	WindowStart   int64 `protobuf:"varint,4,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     int64 `protobuf:"varint,5,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotaUsage) Reset() {
+	*x = QuotaUsage{}
+	mi := &file_v1_user_svc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotaUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaUsage) ProtoMessage() {}
+
+func (x *QuotaUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaUsage.ProtoReflect.Descriptor instead.
+func (*QuotaUsage) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QuotaUsage) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *QuotaUsage) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+// Get quota usage response message - returned with the caller's quota buckets
+type GetQuotaUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Usages        []*QuotaUsage          `protobuf:"bytes,2,rep,name=usages,proto3" json:"usages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaUsageResponse) Reset() {
+	*x = GetQuotaUsageResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaUsageResponse) ProtoMessage() {}
+
+func (x *GetQuotaUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetQuotaUsageResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetQuotaUsageResponse) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *GetQuotaUsageResponse) GetUsages() []*QuotaUsage {
+	if x != nil {
+		return x.Usages
+	}
+	return nil
+}
+
+// Get SLO status request message - an empty method returns every tracked method
+type GetSLOStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSLOStatusRequest) Reset() {
+	*x = GetSLOStatusRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSLOStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSLOStatusRequest) ProtoMessage() {}
+
+func (x *GetSLOStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSLOStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetSLOStatusRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetSLOStatusRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+// SLO status message - rolling compliance of a single method
+type SLOStatus struct {
+	state                            protoimpl.MessageState `protogen:"open.v1"`
+	Method                           string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	TotalRequests                    int64                  `protobuf:"varint,2,opt,name=total_requests,json=totalRequests,proto3" json:"total_requests,omitempty"`
+	FailedRequests                   int64                  `protobuf:"varint,3,opt,name=failed_requests,json=failedRequests,proto3" json:"failed_requests,omitempty"`
+	SlowRequests                     int64                  `protobuf:"varint,4,opt,name=slow_requests,json=slowRequests,proto3" json:"slow_requests,omitempty"`
+	Availability                     float64                `protobuf:"fixed64,5,opt,name=availability,proto3" json:"availability,omitempty"`
+	AvailabilityObjective            float64                `protobuf:"fixed64,6,opt,name=availability_objective,json=availabilityObjective,proto3" json:"availability_objective,omitempty"`
+	AvailabilityErrorBudgetRemaining float64                `protobuf:"fixed64,7,opt,name=availability_error_budget_remaining,json=availabilityErrorBudgetRemaining,proto3" json:"availability_error_budget_remaining,omitempty"`
+	LatencyCompliance                float64                `protobuf:"fixed64,8,opt,name=latency_compliance,json=latencyCompliance,proto3" json:"latency_compliance,omitempty"`
+	LatencyObjective                 float64                `protobuf:"fixed64,9,opt,name=latency_objective,json=latencyObjective,proto3" json:"latency_objective,omitempty"`
+	LatencyThresholdMs               int64                  `protobuf:"varint,10,opt,name=latency_threshold_ms,json=latencyThresholdMs,proto3" json:"latency_threshold_ms,omitempty"`
+	LatencyErrorBudgetRemaining      float64                `protobuf:"fixed64,11,opt,name=latency_error_budget_remaining,json=latencyErrorBudgetRemaining,proto3" json:"latency_error_budget_remaining,omitempty"`
+	Met                              bool                   `protobuf:"varint,12,opt,name=met,proto3" json:"met,omitempty"`
+	unknownFields                    protoimpl.UnknownFields
+	sizeCache                        protoimpl.SizeCache
+}
+
+func (x *SLOStatus) Reset() {
+	*x = SLOStatus{}
+	mi := &file_v1_user_svc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOStatus) ProtoMessage() {}
+
+func (x *SLOStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLOStatus.ProtoReflect.Descriptor instead.
+func (*SLOStatus) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SLOStatus) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *SLOStatus) GetTotalRequests() int64 {
+	if x != nil {
+		return x.TotalRequests
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetFailedRequests() int64 {
+	if x != nil {
+		return x.FailedRequests
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetSlowRequests() int64 {
+	if x != nil {
+		return x.SlowRequests
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetAvailability() float64 {
+	if x != nil {
+		return x.Availability
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetAvailabilityObjective() float64 {
+	if x != nil {
+		return x.AvailabilityObjective
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetAvailabilityErrorBudgetRemaining() float64 {
+	if x != nil {
+		return x.AvailabilityErrorBudgetRemaining
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetLatencyCompliance() float64 {
+	if x != nil {
+		return x.LatencyCompliance
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetLatencyObjective() float64 {
+	if x != nil {
+		return x.LatencyObjective
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetLatencyThresholdMs() int64 {
+	if x != nil {
+		return x.LatencyThresholdMs
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetLatencyErrorBudgetRemaining() float64 {
+	if x != nil {
+		return x.LatencyErrorBudgetRemaining
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetMet() bool {
+	if x != nil {
+		return x.Met
+	}
+	return false
+}
+
+// Get SLO status response message - returned with the per-method SLO statuses
+type GetSLOStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WindowSeconds int64                  `protobuf:"varint,1,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	GeneratedAt   int64                  `protobuf:"varint,2,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	Statuses      []*SLOStatus           `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSLOStatusResponse) Reset() {
+	*x = GetSLOStatusResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSLOStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSLOStatusResponse) ProtoMessage() {}
+
+func (x *GetSLOStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSLOStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetSLOStatusResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetSLOStatusResponse) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *GetSLOStatusResponse) GetGeneratedAt() int64 {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return 0
+}
+
+func (x *GetSLOStatusResponse) GetStatuses() []*SLOStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+// Revoke all user tokens request message - must match the caller's access token
+type RevokeAllUserTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllUserTokensRequest) Reset() {
+	*x = RevokeAllUserTokensRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllUserTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllUserTokensRequest) ProtoMessage() {}
+
+func (x *RevokeAllUserTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllUserTokensRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllUserTokensRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RevokeAllUserTokensRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Revoke all user tokens response message - returned with the number of revoked refresh tokens
+type RevokeAllUserTokensResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RevokedRefreshTokens int64                  `protobuf:"varint,1,opt,name=revoked_refresh_tokens,json=revokedRefreshTokens,proto3" json:"revoked_refresh_tokens,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RevokeAllUserTokensResponse) Reset() {
+	*x = RevokeAllUserTokensResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllUserTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllUserTokensResponse) ProtoMessage() {}
+
+func (x *RevokeAllUserTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllUserTokensResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAllUserTokensResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RevokeAllUserTokensResponse) GetRevokedRefreshTokens() int64 {
+	if x != nil {
+		return x.RevokedRefreshTokens
+	}
+	return 0
+}
+
+// Get account activity summary request message - the user is taken from the caller's access token
+type GetAccountActivitySummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountActivitySummaryRequest) Reset() {
+	*x = GetAccountActivitySummaryRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountActivitySummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountActivitySummaryRequest) ProtoMessage() {}
+
+func (x *GetAccountActivitySummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountActivitySummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountActivitySummaryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{15}
+}
+
+// Device activity message - logins from a single device, identified by its user agent
+type DeviceActivity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserAgent     string                 `protobuf:"bytes,1,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Logins        int64                  `protobuf:"varint,3,opt,name=logins,proto3" json:"logins,omitempty"`
+	LastSeenAt    int64                  `protobuf:"varint,4,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceActivity) Reset() {
+	*x = DeviceActivity{}
+	mi := &file_v1_user_svc_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceActivity) ProtoMessage() {}
+
+func (x *DeviceActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceActivity.ProtoReflect.Descriptor instead.
+func (*DeviceActivity) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeviceActivity) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *DeviceActivity) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *DeviceActivity) GetLogins() int64 {
+	if x != nil {
+		return x.Logins
+	}
+	return 0
+}
+
+func (x *DeviceActivity) GetLastSeenAt() int64 {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return 0
+}
+
+// Get account activity summary response message - activity between from and to (unix millis)
+type GetAccountActivitySummaryResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	From             int64                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To               int64                  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Logins           int64                  `protobuf:"varint,3,opt,name=logins,proto3" json:"logins,omitempty"`
+	LastLoginAt      int64                  `protobuf:"varint,4,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
+	Devices          []*DeviceActivity      `protobuf:"bytes,5,rep,name=devices,proto3" json:"devices,omitempty"`
+	PasswordChanges  int64                  `protobuf:"varint,6,opt,name=password_changes,json=passwordChanges,proto3" json:"password_changes,omitempty"`
+	EmailChanges     int64                  `protobuf:"varint,7,opt,name=email_changes,json=emailChanges,proto3" json:"email_changes,omitempty"`
+	TokenRevocations int64                  `protobuf:"varint,8,opt,name=token_revocations,json=tokenRevocations,proto3" json:"token_revocations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetAccountActivitySummaryResponse) Reset() {
+	*x = GetAccountActivitySummaryResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountActivitySummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountActivitySummaryResponse) ProtoMessage() {}
+
+func (x *GetAccountActivitySummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountActivitySummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountActivitySummaryResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetAccountActivitySummaryResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetAccountActivitySummaryResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetAccountActivitySummaryResponse) GetLogins() int64 {
+	if x != nil {
+		return x.Logins
+	}
+	return 0
+}
+
+func (x *GetAccountActivitySummaryResponse) GetLastLoginAt() int64 {
+	if x != nil {
+		return x.LastLoginAt
+	}
+	return 0
+}
+
+func (x *GetAccountActivitySummaryResponse) GetDevices() []*DeviceActivity {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *GetAccountActivitySummaryResponse) GetPasswordChanges() int64 {
+	if x != nil {
+		return x.PasswordChanges
+	}
+	return 0
+}
+
+func (x *GetAccountActivitySummaryResponse) GetEmailChanges() int64 {
+	if x != nil {
+		return x.EmailChanges
+	}
+	return 0
+}
+
+func (x *GetAccountActivitySummaryResponse) GetTokenRevocations() int64 {
+	if x != nil {
+		return x.TokenRevocations
+	}
+	return 0
+}
+
+// Preview email template request message - data is a JSON object, the template's sample data is used when empty
+type PreviewEmailTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewEmailTemplateRequest) Reset() {
+	*x = PreviewEmailTemplateRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewEmailTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewEmailTemplateRequest) ProtoMessage() {}
+
+func (x *PreviewEmailTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewEmailTemplateRequest.ProtoReflect.Descriptor instead.
+func (*PreviewEmailTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PreviewEmailTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PreviewEmailTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PreviewEmailTemplateRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+// Preview email template response message - locale is the variant rendered after fallback
+type PreviewEmailTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locale        string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Html          string                 `protobuf:"bytes,3,opt,name=html,proto3" json:"html,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewEmailTemplateResponse) Reset() {
+	*x = PreviewEmailTemplateResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewEmailTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewEmailTemplateResponse) ProtoMessage() {}
+
+func (x *PreviewEmailTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewEmailTemplateResponse.ProtoReflect.Descriptor instead.
+func (*PreviewEmailTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PreviewEmailTemplateResponse) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PreviewEmailTemplateResponse) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *PreviewEmailTemplateResponse) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *PreviewEmailTemplateResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Notification preference message - whether an event type is sent on a channel (email, sms, push)
+type NotificationPreference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Channel       string                 `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreference) Reset() {
+	*x = NotificationPreference{}
+	mi := &file_v1_user_svc_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreference) ProtoMessage() {}
+
+func (x *NotificationPreference) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreference.ProtoReflect.Descriptor instead.
+func (*NotificationPreference) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NotificationPreference) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *NotificationPreference) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *NotificationPreference) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// Get notification preferences request message - the user is taken from the caller's access token
+type GetNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationPreferencesRequest) Reset() {
+	*x = GetNotificationPreferencesRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *GetNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{21}
+}
+
+// Update notification preferences request message - preferences not listed are left unchanged
+type UpdateNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Preferences   []*NotificationPreference `protobuf:"bytes,1,rep,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationPreferencesRequest) Reset() {
+	*x = UpdateNotificationPreferencesRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *UpdateNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateNotificationPreferencesRequest) GetPreferences() []*NotificationPreference {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// Notification preferences response message - the effective preference of every event type and channel
+type NotificationPreferencesResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Preferences   []*NotificationPreference `protobuf:"bytes,1,rep,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferencesResponse) Reset() {
+	*x = NotificationPreferencesResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferencesResponse) ProtoMessage() {}
+
+func (x *NotificationPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*NotificationPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *NotificationPreferencesResponse) GetPreferences() []*NotificationPreference {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// Get user stats request message - days of daily buckets ending today, 30 when 0, at most 90
+type GetUserStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          int32                  `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStatsRequest) Reset() {
+	*x = GetUserStatsRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsRequest) ProtoMessage() {}
+
+func (x *GetUserStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserStatsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUserStatsRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+// Daily user stats message - activity of the UTC day starting at day (unix millis)
+type DailyUserStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           int64                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	Registrations int64                  `protobuf:"varint,2,opt,name=registrations,proto3" json:"registrations,omitempty"`
+	Logins        int64                  `protobuf:"varint,3,opt,name=logins,proto3" json:"logins,omitempty"`
+	ActiveUsers   int64                  `protobuf:"varint,4,opt,name=active_users,json=activeUsers,proto3" json:"active_users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyUserStats) Reset() {
+	*x = DailyUserStats{}
+	mi := &file_v1_user_svc_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyUserStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyUserStats) ProtoMessage() {}
+
+func (x *DailyUserStats) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyUserStats.ProtoReflect.Descriptor instead.
+func (*DailyUserStats) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DailyUserStats) GetDay() int64 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *DailyUserStats) GetRegistrations() int64 {
+	if x != nil {
+		return x.Registrations
+	}
+	return 0
+}
+
+func (x *DailyUserStats) GetLogins() int64 {
+	if x != nil {
+		return x.Logins
+	}
+	return 0
+}
+
+func (x *DailyUserStats) GetActiveUsers() int64 {
+	if x != nil {
+		return x.ActiveUsers
+	}
+	return 0
+}
+
+// Get user stats response message - daily buckets cover [from, to) (unix millis)
+type GetUserStatsResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	GeneratedAt             int64                  `protobuf:"varint,1,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	From                    int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                      int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	TotalUsers              int64                  `protobuf:"varint,4,opt,name=total_users,json=totalUsers,proto3" json:"total_users,omitempty"`
+	ActiveSessions          int64                  `protobuf:"varint,5,opt,name=active_sessions,json=activeSessions,proto3" json:"active_sessions,omitempty"`
+	UsersWithActiveSessions int64                  `protobuf:"varint,6,opt,name=users_with_active_sessions,json=usersWithActiveSessions,proto3" json:"users_with_active_sessions,omitempty"`
+	Daily                   []*DailyUserStats      `protobuf:"bytes,7,rep,name=daily,proto3" json:"daily,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *GetUserStatsResponse) Reset() {
+	*x = GetUserStatsResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsResponse) ProtoMessage() {}
+
+func (x *GetUserStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserStatsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetUserStatsResponse) GetGeneratedAt() int64 {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetTotalUsers() int64 {
+	if x != nil {
+		return x.TotalUsers
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetActiveSessions() int64 {
+	if x != nil {
+		return x.ActiveSessions
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetUsersWithActiveSessions() int64 {
+	if x != nil {
+		return x.UsersWithActiveSessions
+	}
+	return 0
+}
+
+func (x *GetUserStatsResponse) GetDaily() []*DailyUserStats {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+// Export users request message - created_from and created_to bound created_at as [from, to) (unix millis, 0 = unbounded),
+// format is "proto" (default) or "ndjson", fields selects the exported fields (all when empty),
+// rows_per_second throttles the stream (the configured maximum when 0), organization_id limits it to the staff of an organization
+type ExportUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CreatedFrom    int64                  `protobuf:"varint,1,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo      int64                  `protobuf:"varint,2,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Format         string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	Fields         []string               `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	RowsPerSecond  int32                  `protobuf:"varint,5,opt,name=rows_per_second,json=rowsPerSecond,proto3" json:"rows_per_second,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,6,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExportUsersRequest) Reset() {
+	*x = ExportUsersRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsersRequest) ProtoMessage() {}
+
+func (x *ExportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ExportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ExportUsersRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *ExportUsersRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+func (x *ExportUsersRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportUsersRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ExportUsersRequest) GetRowsPerSecond() int32 {
+	if x != nil {
+		return x.RowsPerSecond
+	}
+	return 0
+}
+
+func (x *ExportUsersRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+// Exported user message - fields that were not selected are left empty
+type ExportedUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportedUser) Reset() {
+	*x = ExportedUser{}
+	mi := &file_v1_user_svc_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedUser) ProtoMessage() {}
+
+func (x *ExportedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedUser.ProtoReflect.Descriptor instead.
+func (*ExportedUser) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ExportedUser) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExportedUser) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ExportedUser) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ExportedUser) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ExportedUser) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Export users chunk message - users for the "proto" format, one JSON object per line in ndjson for "ndjson"
+type ExportUsersChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*ExportedUser        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Ndjson        []byte                 `protobuf:"bytes,2,opt,name=ndjson,proto3" json:"ndjson,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsersChunk) Reset() {
+	*x = ExportUsersChunk{}
+	mi := &file_v1_user_svc_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsersChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsersChunk) ProtoMessage() {}
+
+func (x *ExportUsersChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsersChunk.ProtoReflect.Descriptor instead.
+func (*ExportUsersChunk) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportUsersChunk) GetUsers() []*ExportedUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ExportUsersChunk) GetNdjson() []byte {
+	if x != nil {
+		return x.Ndjson
+	}
+	return nil
+}
+
+// Legal hold request message - reason is recorded in the audit trail
+type LegalHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegalHoldRequest) Reset() {
+	*x = LegalHoldRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegalHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegalHoldRequest) ProtoMessage() {}
+
+func (x *LegalHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegalHoldRequest.ProtoReflect.Descriptor instead.
+func (*LegalHoldRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *LegalHoldRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LegalHoldRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Legal hold response message - changed is false when the user already was in the requested state
+type LegalHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LegalHold     bool                   `protobuf:"varint,2,opt,name=legal_hold,json=legalHold,proto3" json:"legal_hold,omitempty"`
+	Changed       bool                   `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegalHoldResponse) Reset() {
+	*x = LegalHoldResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegalHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegalHoldResponse) ProtoMessage() {}
+
+func (x *LegalHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegalHoldResponse.ProtoReflect.Descriptor instead.
+func (*LegalHoldResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *LegalHoldResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LegalHoldResponse) GetLegalHold() bool {
+	if x != nil {
+		return x.LegalHold
+	}
+	return false
+}
+
+func (x *LegalHoldResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+// Get risk signals request message
+type GetRiskSignalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRiskSignalsRequest) Reset() {
+	*x = GetRiskSignalsRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRiskSignalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRiskSignalsRequest) ProtoMessage() {}
+
+func (x *GetRiskSignalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRiskSignalsRequest.ProtoReflect.Descriptor instead.
+func (*GetRiskSignalsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetRiskSignalsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Get risk signals response message - timestamps in unix millis, device_count covers the configured device window
+type GetRiskSignalsResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	UserId                   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt                int64                  `protobuf:"varint,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	AccountAgeDays           int64                  `protobuf:"varint,3,opt,name=account_age_days,json=accountAgeDays,proto3" json:"account_age_days,omitempty"`
+	DeviceCount              int64                  `protobuf:"varint,4,opt,name=device_count,json=deviceCount,proto3" json:"device_count,omitempty"`
+	DisposableEmail          bool                   `protobuf:"varint,5,opt,name=disposable_email,json=disposableEmail,proto3" json:"disposable_email,omitempty"`
+	RegistrationIpReuseCount int64                  `protobuf:"varint,6,opt,name=registration_ip_reuse_count,json=registrationIpReuseCount,proto3" json:"registration_ip_reuse_count,omitempty"`
+	GeneratedAt              int64                  `protobuf:"varint,7,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	// Sessions refreshed from two countries within minutes or at impossible rates within risk.device_window
+	SessionAnomalyCount int64 `protobuf:"varint,8,opt,name=session_anomaly_count,json=sessionAnomalyCount,proto3" json:"session_anomaly_count,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetRiskSignalsResponse) Reset() {
+	*x = GetRiskSignalsResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRiskSignalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRiskSignalsResponse) ProtoMessage() {}
+
+func (x *GetRiskSignalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRiskSignalsResponse.ProtoReflect.Descriptor instead.
+func (*GetRiskSignalsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetRiskSignalsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRiskSignalsResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *GetRiskSignalsResponse) GetAccountAgeDays() int64 {
+	if x != nil {
+		return x.AccountAgeDays
+	}
+	return 0
+}
+
+func (x *GetRiskSignalsResponse) GetDeviceCount() int64 {
+	if x != nil {
+		return x.DeviceCount
+	}
+	return 0
+}
+
+func (x *GetRiskSignalsResponse) GetDisposableEmail() bool {
+	if x != nil {
+		return x.DisposableEmail
+	}
+	return false
+}
+
+func (x *GetRiskSignalsResponse) GetRegistrationIpReuseCount() int64 {
+	if x != nil {
+		return x.RegistrationIpReuseCount
+	}
+	return 0
+}
+
+func (x *GetRiskSignalsResponse) GetGeneratedAt() int64 {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return 0
+}
+
+func (x *GetRiskSignalsResponse) GetSessionAnomalyCount() int64 {
+	if x != nil {
+		return x.SessionAnomalyCount
+	}
+	return 0
+}
+
+// Get user by username request message - the username is matched exactly
+type GetUserByUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByUsernameRequest) Reset() {
+	*x = GetUserByUsernameRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByUsernameRequest) ProtoMessage() {}
+
+func (x *GetUserByUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByUsernameRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetUserByUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// Batch get users request message - repeated IDs are looked up once
+type BatchGetUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersRequest) Reset() {
+	*x = BatchGetUsersRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersRequest) ProtoMessage() {}
+
+func (x *BatchGetUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BatchGetUsersRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// Batch get users response message - the users found, keyed by ID
+type BatchGetUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         map[string]*User       `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetUsersResponse) Reset() {
+	*x = BatchGetUsersResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetUsersResponse) ProtoMessage() {}
+
+func (x *BatchGetUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetUsersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BatchGetUsersResponse) GetUsers() map[string]*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// Exchange token request message - the fields of an RFC 8693 token exchange request. grant_type is
+// urn:ietf:params:oauth:grant-type:token-exchange and the token types are
+// urn:ietf:params:oauth:token-type:access_token; requested_token_type is optional and an empty scope
+// requests every scope allowed at the audience.
+type ExchangeTokenRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	GrantType          string                 `protobuf:"bytes,1,opt,name=grant_type,json=grantType,proto3" json:"grant_type,omitempty"`
+	SubjectToken       string                 `protobuf:"bytes,2,opt,name=subject_token,json=subjectToken,proto3" json:"subject_token,omitempty"`
+	SubjectTokenType   string                 `protobuf:"bytes,3,opt,name=subject_token_type,json=subjectTokenType,proto3" json:"subject_token_type,omitempty"`
+	Audience           string                 `protobuf:"bytes,4,opt,name=audience,proto3" json:"audience,omitempty"`
+	Scope              []string               `protobuf:"bytes,5,rep,name=scope,proto3" json:"scope,omitempty"`
+	RequestedTokenType string                 `protobuf:"bytes,6,opt,name=requested_token_type,json=requestedTokenType,proto3" json:"requested_token_type,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExchangeTokenRequest) Reset() {
+	*x = ExchangeTokenRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeTokenRequest) ProtoMessage() {}
+
+func (x *ExchangeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeTokenRequest.ProtoReflect.Descriptor instead.
+func (*ExchangeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ExchangeTokenRequest) GetGrantType() string {
+	if x != nil {
+		return x.GrantType
+	}
+	return ""
+}
+
+func (x *ExchangeTokenRequest) GetSubjectToken() string {
+	if x != nil {
+		return x.SubjectToken
+	}
+	return ""
+}
+
+func (x *ExchangeTokenRequest) GetSubjectTokenType() string {
+	if x != nil {
+		return x.SubjectTokenType
+	}
+	return ""
+}
+
+func (x *ExchangeTokenRequest) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *ExchangeTokenRequest) GetScope() []string {
+	if x != nil {
+		return x.Scope
+	}
+	return nil
+}
+
+func (x *ExchangeTokenRequest) GetRequestedTokenType() string {
+	if x != nil {
+		return x.RequestedTokenType
+	}
+	return ""
+}
+
+// Exchange token response message - access_token carries the aud, scope and act claims; expires_in is in seconds
+type ExchangeTokenResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken     string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	IssuedTokenType string                 `protobuf:"bytes,2,opt,name=issued_token_type,json=issuedTokenType,proto3" json:"issued_token_type,omitempty"`
+	TokenType       string                 `protobuf:"bytes,3,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	ExpiresIn       int64                  `protobuf:"varint,4,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	Scope           []string               `protobuf:"bytes,5,rep,name=scope,proto3" json:"scope,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExchangeTokenResponse) Reset() {
+	*x = ExchangeTokenResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeTokenResponse) ProtoMessage() {}
+
+func (x *ExchangeTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeTokenResponse.ProtoReflect.Descriptor instead.
+func (*ExchangeTokenResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ExchangeTokenResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ExchangeTokenResponse) GetIssuedTokenType() string {
+	if x != nil {
+		return x.IssuedTokenType
+	}
+	return ""
+}
+
+func (x *ExchangeTokenResponse) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *ExchangeTokenResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *ExchangeTokenResponse) GetScope() []string {
+	if x != nil {
+		return x.Scope
+	}
+	return nil
+}
+
+// Verify token request message
+type VerifyTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyTokenRequest) Reset() {
+	*x = VerifyTokenRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTokenRequest) ProtoMessage() {}
+
+func (x *VerifyTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTokenRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTokenRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *VerifyTokenRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// Verify token response message - the claims of a valid access token; times are in Unix seconds.
+// jkt is set for tokens bound to a DPoP key, which must then be presented with a proof of that key;
+// scope is only set on delegation tokens, audience on delegation tokens and, once jwt.audience is
+// configured, on other tokens.
+type VerifyTokenResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TokenId        string                 `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username       string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	IssuedAt       int64                  `protobuf:"varint,4,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt      int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Role           string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,7,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	ClientId       string                 `protobuf:"bytes,8,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Residency      string                 `protobuf:"bytes,9,opt,name=residency,proto3" json:"residency,omitempty"`
+	Jkt            string                 `protobuf:"bytes,10,opt,name=jkt,proto3" json:"jkt,omitempty"`
+	Audience       string                 `protobuf:"bytes,11,opt,name=audience,proto3" json:"audience,omitempty"`
+	Scope          []string               `protobuf:"bytes,12,rep,name=scope,proto3" json:"scope,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyTokenResponse) Reset() {
+	*x = VerifyTokenResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTokenResponse) ProtoMessage() {}
+
+func (x *VerifyTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTokenResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTokenResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *VerifyTokenResponse) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetIssuedAt() int64 {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return 0
+}
+
+func (x *VerifyTokenResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *VerifyTokenResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetResidency() string {
+	if x != nil {
+		return x.Residency
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetJkt() string {
+	if x != nil {
+		return x.Jkt
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetScope() []string {
+	if x != nil {
+		return x.Scope
+	}
+	return nil
+}
+
+// Claim guest activity request message
+type ClaimGuestActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimGuestActivityRequest) Reset() {
+	*x = ClaimGuestActivityRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimGuestActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimGuestActivityRequest) ProtoMessage() {}
+
+func (x *ClaimGuestActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimGuestActivityRequest.ProtoReflect.Descriptor instead.
+func (*ClaimGuestActivityRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ClaimGuestActivityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ClaimGuestActivityRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// Claim guest activity response message - times are Unix milliseconds; verified_by is registration_code or
+// invitation, claimed_by the service key ID of the service that claimed the activity first
+type ClaimGuestActivityResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email          string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	VerifiedBy     string                 `protobuf:"bytes,3,opt,name=verified_by,json=verifiedBy,proto3" json:"verified_by,omitempty"`
+	VerifiedAt     int64                  `protobuf:"varint,4,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
+	ClaimedAt      int64                  `protobuf:"varint,5,opt,name=claimed_at,json=claimedAt,proto3" json:"claimed_at,omitempty"`
+	ClaimedBy      string                 `protobuf:"bytes,6,opt,name=claimed_by,json=claimedBy,proto3" json:"claimed_by,omitempty"`
+	AlreadyClaimed bool                   `protobuf:"varint,7,opt,name=already_claimed,json=alreadyClaimed,proto3" json:"already_claimed,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClaimGuestActivityResponse) Reset() {
+	*x = ClaimGuestActivityResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimGuestActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimGuestActivityResponse) ProtoMessage() {}
+
+func (x *ClaimGuestActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimGuestActivityResponse.ProtoReflect.Descriptor instead.
+func (*ClaimGuestActivityResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ClaimGuestActivityResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ClaimGuestActivityResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ClaimGuestActivityResponse) GetVerifiedBy() string {
+	if x != nil {
+		return x.VerifiedBy
+	}
+	return ""
+}
+
+func (x *ClaimGuestActivityResponse) GetVerifiedAt() int64 {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return 0
+}
+
+func (x *ClaimGuestActivityResponse) GetClaimedAt() int64 {
+	if x != nil {
+		return x.ClaimedAt
+	}
+	return 0
+}
+
+func (x *ClaimGuestActivityResponse) GetClaimedBy() string {
+	if x != nil {
+		return x.ClaimedBy
+	}
+	return ""
+}
+
+func (x *ClaimGuestActivityResponse) GetAlreadyClaimed() bool {
+	if x != nil {
+		return x.AlreadyClaimed
+	}
+	return false
+}
+
+// Organization message - staff accounts must use one of allowed_email_domains (or a subdomain), any domain when empty
+type Organization struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AllowedEmailDomains []string               `protobuf:"bytes,3,rep,name=allowed_email_domains,json=allowedEmailDomains,proto3" json:"allowed_email_domains,omitempty"`
+	CreatedAt           int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Branding            *OrganizationBranding  `protobuf:"bytes,6,opt,name=branding,proto3" json:"branding,omitempty"`
+	// Residency region members register into, empty for the region of their country
+	Residency string `protobuf:"bytes,7,opt,name=residency,proto3" json:"residency,omitempty"`
+	// Sessions of members unused for longer end on their next refresh, 0 for none
+	SessionIdleTimeoutSeconds int64 `protobuf:"varint,8,opt,name=session_idle_timeout_seconds,json=sessionIdleTimeoutSeconds,proto3" json:"session_idle_timeout_seconds,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_v1_user_svc_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Organization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetAllowedEmailDomains() []string {
+	if x != nil {
+		return x.AllowedEmailDomains
+	}
+	return nil
+}
+
+func (x *Organization) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Organization) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Organization) GetBranding() *OrganizationBranding {
+	if x != nil {
+		return x.Branding
+	}
+	return nil
+}
+
+func (x *Organization) GetResidency() string {
+	if x != nil {
+		return x.Residency
+	}
+	return ""
+}
+
+func (x *Organization) GetSessionIdleTimeoutSeconds() int64 {
+	if x != nil {
+		return x.SessionIdleTimeoutSeconds
+	}
+	return 0
+}
+
+// Organization branding message - shown in the emails sent to members of the organization, empty fields fall
+// back to the service's own branding. logo_url must be an https URL and color a hex color such as #1a73e8.
+type OrganizationBranding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LogoUrl       string                 `protobuf:"bytes,2,opt,name=logo_url,json=logoUrl,proto3" json:"logo_url,omitempty"`
+	SupportEmail  string                 `protobuf:"bytes,3,opt,name=support_email,json=supportEmail,proto3" json:"support_email,omitempty"`
+	Color         string                 `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationBranding) Reset() {
+	*x = OrganizationBranding{}
+	mi := &file_v1_user_svc_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationBranding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationBranding) ProtoMessage() {}
+
+func (x *OrganizationBranding) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationBranding.ProtoReflect.Descriptor instead.
+func (*OrganizationBranding) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *OrganizationBranding) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrganizationBranding) GetLogoUrl() string {
+	if x != nil {
+		return x.LogoUrl
+	}
+	return ""
+}
+
+func (x *OrganizationBranding) GetSupportEmail() string {
+	if x != nil {
+		return x.SupportEmail
+	}
+	return ""
+}
+
+func (x *OrganizationBranding) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+// Create organization request message
+type CreateOrganizationRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AllowedEmailDomains []string               `protobuf:"bytes,2,rep,name=allowed_email_domains,json=allowedEmailDomains,proto3" json:"allowed_email_domains,omitempty"`
+	// Residency region members register into, one of residency.home_region and residency.regions;
+	// empty for the region of their country
+	Residency     string `protobuf:"bytes,3,opt,name=residency,proto3" json:"residency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrganizationRequest) GetAllowedEmailDomains() []string {
+	if x != nil {
+		return x.AllowedEmailDomains
+	}
+	return nil
+}
+
+func (x *CreateOrganizationRequest) GetResidency() string {
+	if x != nil {
+		return x.Residency
+	}
+	return ""
+}
+
+// Get organization request message
+type GetOrganizationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetOrganizationRequest) Reset() {
+	*x = GetOrganizationRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationRequest) ProtoMessage() {}
+
+func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetOrganizationRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+// Get organization by name request message
+type GetOrganizationByNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganizationByNameRequest) Reset() {
+	*x = GetOrganizationByNameRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationByNameRequest) ProtoMessage() {}
+
+func (x *GetOrganizationByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationByNameRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetOrganizationByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Set organization email domains request message - replaces the current domains, an empty list lifts the restriction
+type SetOrganizationEmailDomainsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId      string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	AllowedEmailDomains []string               `protobuf:"bytes,2,rep,name=allowed_email_domains,json=allowedEmailDomains,proto3" json:"allowed_email_domains,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SetOrganizationEmailDomainsRequest) Reset() {
+	*x = SetOrganizationEmailDomainsRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrganizationEmailDomainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrganizationEmailDomainsRequest) ProtoMessage() {}
+
+func (x *SetOrganizationEmailDomainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrganizationEmailDomainsRequest.ProtoReflect.Descriptor instead.
+func (*SetOrganizationEmailDomainsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetOrganizationEmailDomainsRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *SetOrganizationEmailDomainsRequest) GetAllowedEmailDomains() []string {
+	if x != nil {
+		return x.AllowedEmailDomains
+	}
+	return nil
+}
+
+// Set organization branding request message - replaces the current branding, an empty branding removes it
+type SetOrganizationBrandingRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Branding       *OrganizationBranding  `protobuf:"bytes,2,opt,name=branding,proto3" json:"branding,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetOrganizationBrandingRequest) Reset() {
+	*x = SetOrganizationBrandingRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrganizationBrandingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrganizationBrandingRequest) ProtoMessage() {}
+
+func (x *SetOrganizationBrandingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrganizationBrandingRequest.ProtoReflect.Descriptor instead.
+func (*SetOrganizationBrandingRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SetOrganizationBrandingRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *SetOrganizationBrandingRequest) GetBranding() *OrganizationBranding {
+	if x != nil {
+		return x.Branding
+	}
+	return nil
+}
+
+// Set organization session policy request message - session_idle_timeout_seconds is 0 for no idle
+// timeout or at least jwt.access_token_duration, as sessions are only seen active when they refresh
+type SetOrganizationSessionPolicyRequest struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId            string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	SessionIdleTimeoutSeconds int64                  `protobuf:"varint,2,opt,name=session_idle_timeout_seconds,json=sessionIdleTimeoutSeconds,proto3" json:"session_idle_timeout_seconds,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *SetOrganizationSessionPolicyRequest) Reset() {
+	*x = SetOrganizationSessionPolicyRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOrganizationSessionPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOrganizationSessionPolicyRequest) ProtoMessage() {}
+
+func (x *SetOrganizationSessionPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOrganizationSessionPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetOrganizationSessionPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SetOrganizationSessionPolicyRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *SetOrganizationSessionPolicyRequest) GetSessionIdleTimeoutSeconds() int64 {
+	if x != nil {
+		return x.SessionIdleTimeoutSeconds
+	}
+	return 0
+}
+
+// Import user record message - organization_id is optional and the email must match its allowed domains
+type ImportUserRecord struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Email          string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,3,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// Imports an active user migrated from the legacy ticketing system instead of an invited one
+	LegacyPassword *LegacyPasswordHash `protobuf:"bytes,4,opt,name=legacy_password,json=legacyPassword,proto3" json:"legacy_password,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ImportUserRecord) Reset() {
+	*x = ImportUserRecord{}
+	mi := &file_v1_user_svc_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUserRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUserRecord) ProtoMessage() {}
+
+func (x *ImportUserRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUserRecord.ProtoReflect.Descriptor instead.
+func (*ImportUserRecord) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ImportUserRecord) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportUserRecord) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ImportUserRecord) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *ImportUserRecord) GetLegacyPassword() *LegacyPasswordHash {
+	if x != nil {
+		return x.LegacyPassword
+	}
+	return nil
+}
+
+// Legacy password hash message - a salted SHA-1 of the legacy ticketing system, format is
+// "sha1-salt-password" or "sha1-password-salt" and hash the hex digest. It is replaced with a
+// bcrypt hash on the user's first login.
+type LegacyPasswordHash struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Salt          string                 `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	Hash          string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegacyPasswordHash) Reset() {
+	*x = LegacyPasswordHash{}
+	mi := &file_v1_user_svc_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegacyPasswordHash) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegacyPasswordHash) ProtoMessage() {}
+
+func (x *LegacyPasswordHash) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegacyPasswordHash.ProtoReflect.Descriptor instead.
+func (*LegacyPasswordHash) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *LegacyPasswordHash) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *LegacyPasswordHash) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *LegacyPasswordHash) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+// Import users request message - one batch of records, at most the configured batch size
+type ImportUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*ImportUserRecord    `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersRequest) Reset() {
+	*x = ImportUsersRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersRequest) ProtoMessage() {}
+
+func (x *ImportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ImportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ImportUsersRequest) GetUsers() []*ImportUserRecord {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// Import user result message - row is the position of the record across the stream, status is
+// "created", "duplicate", "invalid" or "failed", user_id is set when created and error otherwise
+type ImportUserResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int64                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUserResult) Reset() {
+	*x = ImportUserResult{}
+	mi := &file_v1_user_svc_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUserResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUserResult) ProtoMessage() {}
+
+func (x *ImportUserResult) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUserResult.ProtoReflect.Descriptor instead.
+func (*ImportUserResult) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ImportUserResult) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportUserResult) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportUserResult) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ImportUserResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportUserResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Import users response message - the results of one request batch, in order
+type ImportUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ImportUserResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersResponse) Reset() {
+	*x = ImportUsersResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersResponse) ProtoMessage() {}
+
+func (x *ImportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ImportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ImportUsersResponse) GetResults() []*ImportUserResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Complete password setup request message - token is the one from the invitation link
+type CompletePasswordSetupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompletePasswordSetupRequest) Reset() {
+	*x = CompletePasswordSetupRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompletePasswordSetupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompletePasswordSetupRequest) ProtoMessage() {}
+
+func (x *CompletePasswordSetupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompletePasswordSetupRequest.ProtoReflect.Descriptor instead.
+func (*CompletePasswordSetupRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CompletePasswordSetupRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CompletePasswordSetupRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CompletePasswordSetupRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+// Login with ID token request message - provider is the name of a configured provider; nonce
+// is the one the client sent the provider, which the ID token must carry, or empty to skip the
+// check
+type LoginWithIDTokenRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Provider string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	IdToken  string                 `protobuf:"bytes,2,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"`
+	Nonce    string                 `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// Registered client whose token policy applies; it must allow the id_token grant
+	ClientId string `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Long-lived session; without it the refresh token expires after jwt.short_refresh_token_duration
+	RememberMe    bool `protobuf:"varint,5,opt,name=remember_me,json=rememberMe,proto3" json:"remember_me,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginWithIDTokenRequest) Reset() {
+	*x = LoginWithIDTokenRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginWithIDTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginWithIDTokenRequest) ProtoMessage() {}
+
+func (x *LoginWithIDTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginWithIDTokenRequest.ProtoReflect.Descriptor instead.
+func (*LoginWithIDTokenRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *LoginWithIDTokenRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LoginWithIDTokenRequest) GetIdToken() string {
+	if x != nil {
+		return x.IdToken
+	}
+	return ""
+}
+
+func (x *LoginWithIDTokenRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *LoginWithIDTokenRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *LoginWithIDTokenRequest) GetRememberMe() bool {
+	if x != nil {
+		return x.RememberMe
+	}
+	return false
+}
+
+// Batch assign role request message - role is "customer", "staff" or "admin", reason is recorded in the audit trail,
+// dry_run reports the results without changing any user
+type BatchAssignRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchAssignRoleRequest) Reset() {
+	*x = BatchAssignRoleRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAssignRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAssignRoleRequest) ProtoMessage() {}
+
+func (x *BatchAssignRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAssignRoleRequest.ProtoReflect.Descriptor instead.
+func (*BatchAssignRoleRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *BatchAssignRoleRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *BatchAssignRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *BatchAssignRoleRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BatchAssignRoleRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// Batch update status request message - status is "active" (unban) or "banned", reason is recorded in the audit trail,
+// dry_run reports the results without changing any user
+type BatchUpdateStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateStatusRequest) Reset() {
+	*x = BatchUpdateStatusRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateStatusRequest) ProtoMessage() {}
+
+func (x *BatchUpdateStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateStatusRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateStatusRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *BatchUpdateStatusRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *BatchUpdateStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchUpdateStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BatchUpdateStatusRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// Batch user result message - status is "updated", "unchanged", "not_found" or "invalid", error explains the last two
+type BatchUserResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUserResult) Reset() {
+	*x = BatchUserResult{}
+	mi := &file_v1_user_svc_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUserResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUserResult) ProtoMessage() {}
+
+func (x *BatchUserResult) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUserResult.ProtoReflect.Descriptor instead.
+func (*BatchUserResult) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *BatchUserResult) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BatchUserResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchUserResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Batch user results response message - one result per requested user ID, in request order
+type BatchUserResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchUserResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUserResultsResponse) Reset() {
+	*x = BatchUserResultsResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUserResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUserResultsResponse) ProtoMessage() {}
+
+func (x *BatchUserResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUserResultsResponse.ProtoReflect.Descriptor instead.
+func (*BatchUserResultsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *BatchUserResultsResponse) GetResults() []*BatchUserResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Get user history request message - after_version 0 starts at the first event, limit 0 returns up to 100 events (max 1000)
+type GetUserHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AfterVersion  int64                  `protobuf:"varint,2,opt,name=after_version,json=afterVersion,proto3" json:"after_version,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserHistoryRequest) Reset() {
+	*x = GetUserHistoryRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserHistoryRequest) ProtoMessage() {}
+
+func (x *GetUserHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetUserHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetUserHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserHistoryRequest) GetAfterVersion() int64 {
+	if x != nil {
+		return x.AfterVersion
+	}
+	return 0
+}
+
+func (x *GetUserHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// User event message - data is the JSON payload of the event type, actor is "self" or e.g. "admin:ops"
+type UserEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Data          string                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	OccurredAt    int64                  `protobuf:"varint,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEvent) Reset() {
+	*x = UserEvent{}
+	mi := &file_v1_user_svc_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEvent) ProtoMessage() {}
+
+func (x *UserEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEvent.ProtoReflect.Descriptor instead.
+func (*UserEvent) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *UserEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UserEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UserEvent) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *UserEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *UserEvent) GetOccurredAt() int64 {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return 0
+}
+
+// Get user history response message - next_after_version continues the history, 0 when there are no more events
+type GetUserHistoryResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Events           []*UserEvent           `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextAfterVersion int64                  `protobuf:"varint,2,opt,name=next_after_version,json=nextAfterVersion,proto3" json:"next_after_version,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetUserHistoryResponse) Reset() {
+	*x = GetUserHistoryResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserHistoryResponse) ProtoMessage() {}
+
+func (x *GetUserHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetUserHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetUserHistoryResponse) GetEvents() []*UserEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetUserHistoryResponse) GetNextAfterVersion() int64 {
+	if x != nil {
+		return x.NextAfterVersion
+	}
+	return 0
+}
+
+// List users request message - page_size 0 returns up to 50 users (max 500), page_token is the next_page_token
+// of the previous page, and organization_id optionally limits the users to the staff of an organization
+type ListUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PageSize       int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,3,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+// List users response message - next_page_token is empty on the last page
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Promote signing key request message
+type PromoteSigningKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteSigningKeyRequest) Reset() {
+	*x = PromoteSigningKeyRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteSigningKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteSigningKeyRequest) ProtoMessage() {}
+
+func (x *PromoteSigningKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteSigningKeyRequest.ProtoReflect.Descriptor instead.
+func (*PromoteSigningKeyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{67}
+}
+
+// Promote signing key response message - key IDs are the first 16 hex characters of the SHA-256 of the secrets
+type PromoteSigningKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrimaryKeyId  string                 `protobuf:"bytes,1,opt,name=primary_key_id,json=primaryKeyId,proto3" json:"primary_key_id,omitempty"`
+	PreviousKeyId string                 `protobuf:"bytes,2,opt,name=previous_key_id,json=previousKeyId,proto3" json:"previous_key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteSigningKeyResponse) Reset() {
+	*x = PromoteSigningKeyResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteSigningKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteSigningKeyResponse) ProtoMessage() {}
+
+func (x *PromoteSigningKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteSigningKeyResponse.ProtoReflect.Descriptor instead.
+func (*PromoteSigningKeyResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *PromoteSigningKeyResponse) GetPrimaryKeyId() string {
+	if x != nil {
+		return x.PrimaryKeyId
+	}
+	return ""
+}
+
+func (x *PromoteSigningKeyResponse) GetPreviousKeyId() string {
+	if x != nil {
+		return x.PreviousKeyId
+	}
+	return ""
+}
+
+// Set user metadata request message - keys are namespace.name, a key cannot be both set and removed
+type SetUserMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Set           map[string]string      `protobuf:"bytes,2,rep,name=set,proto3" json:"set,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Remove        []string               `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserMetadataRequest) Reset() {
+	*x = SetUserMetadataRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserMetadataRequest) ProtoMessage() {}
+
+func (x *SetUserMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserMetadataRequest.ProtoReflect.Descriptor instead.
+func (*SetUserMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SetUserMetadataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserMetadataRequest) GetSet() map[string]string {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *SetUserMetadataRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+// Set user metadata response message - the user's metadata in the namespaces of the request
+type SetUserMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      map[string]string      `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserMetadataResponse) Reset() {
+	*x = SetUserMetadataResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserMetadataResponse) ProtoMessage() {}
+
+func (x *SetUserMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserMetadataResponse.ProtoReflect.Descriptor instead.
+func (*SetUserMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SetUserMetadataResponse) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Request avatar upload URL request message - content_type is e.g. image/png
+type RequestAvatarUploadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentType   string                 `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestAvatarUploadURLRequest) Reset() {
+	*x = RequestAvatarUploadURLRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAvatarUploadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAvatarUploadURLRequest) ProtoMessage() {}
+
+func (x *RequestAvatarUploadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAvatarUploadURLRequest.ProtoReflect.Descriptor instead.
+func (*RequestAvatarUploadURLRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *RequestAvatarUploadURLRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// Request avatar upload URL response message - the upload must send content_type as its Content-Type header
+type RequestAvatarUploadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadUrl     string                 `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	ObjectKey     string                 `protobuf:"bytes,3,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestAvatarUploadURLResponse) Reset() {
+	*x = RequestAvatarUploadURLResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAvatarUploadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAvatarUploadURLResponse) ProtoMessage() {}
+
+func (x *RequestAvatarUploadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAvatarUploadURLResponse.ProtoReflect.Descriptor instead.
+func (*RequestAvatarUploadURLResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *RequestAvatarUploadURLResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *RequestAvatarUploadURLResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *RequestAvatarUploadURLResponse) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *RequestAvatarUploadURLResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Confirm avatar request message - object_key is the key returned with the upload URL
+type ConfirmAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectKey     string                 `protobuf:"bytes,1,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmAvatarRequest) Reset() {
+	*x = ConfirmAvatarRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmAvatarRequest) ProtoMessage() {}
+
+func (x *ConfirmAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmAvatarRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ConfirmAvatarRequest) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+// Confirm avatar response message - avatar_url is empty unless the storage has a public base URL
+type ConfirmAvatarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectKey     string                 `protobuf:"bytes,1,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,2,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmAvatarResponse) Reset() {
+	*x = ConfirmAvatarResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmAvatarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmAvatarResponse) ProtoMessage() {}
+
+func (x *ConfirmAvatarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmAvatarResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmAvatarResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ConfirmAvatarResponse) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *ConfirmAvatarResponse) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+// Export snapshot request message - the passphrase must be at least 16 characters
+type ExportSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passphrase    string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSnapshotRequest) Reset() {
+	*x = ExportSnapshotRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSnapshotRequest) ProtoMessage() {}
+
+func (x *ExportSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*ExportSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ExportSnapshotRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+// Snapshot chunk message - the archive is the concatenation of the chunks
+type SnapshotChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotChunk) Reset() {
+	*x = SnapshotChunk{}
+	mi := &file_v1_user_svc_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotChunk) ProtoMessage() {}
+
+func (x *SnapshotChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotChunk.ProtoReflect.Descriptor instead.
+func (*SnapshotChunk) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SnapshotChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Restore snapshot request message - passphrase is only read from the first message
+type RestoreSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passphrase    string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreSnapshotRequest) Reset() {
+	*x = RestoreSnapshotRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotRequest) ProtoMessage() {}
+
+func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RestoreSnapshotRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *RestoreSnapshotRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Restore snapshot response message - the number of rows restored
+type RestoreSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations int64                  `protobuf:"varint,1,opt,name=organizations,proto3" json:"organizations,omitempty"`
+	Users         int64                  `protobuf:"varint,2,opt,name=users,proto3" json:"users,omitempty"`
+	Sessions      int64                  `protobuf:"varint,3,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreSnapshotResponse) Reset() {
+	*x = RestoreSnapshotResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotResponse) ProtoMessage() {}
+
+func (x *RestoreSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *RestoreSnapshotResponse) GetOrganizations() int64 {
+	if x != nil {
+		return x.Organizations
+	}
+	return 0
+}
+
+func (x *RestoreSnapshotResponse) GetUsers() int64 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *RestoreSnapshotResponse) GetSessions() int64 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+// Watch user request message - up to the configured number of user IDs, repeated IDs are watched once
+type WatchUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchUserRequest) Reset() {
+	*x = WatchUserRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchUserRequest) ProtoMessage() {}
+
+func (x *WatchUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchUserRequest.ProtoReflect.Descriptor instead.
+func (*WatchUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *WatchUserRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// User update message - the state of a user as of the event with version; user is unset for users
+// that do not exist. The first update of every user is initial, in request order.
+type UserUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Initial       bool                   `protobuf:"varint,4,opt,name=initial,proto3" json:"initial,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserUpdate) Reset() {
+	*x = UserUpdate{}
+	mi := &file_v1_user_svc_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserUpdate) ProtoMessage() {}
+
+func (x *UserUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserUpdate.ProtoReflect.Descriptor instead.
+func (*UserUpdate) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *UserUpdate) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserUpdate) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UserUpdate) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UserUpdate) GetInitial() bool {
+	if x != nil {
+		return x.Initial
+	}
+	return false
+}
+
+// Cleanup refresh tokens request message - deletes the tokens that expired or were revoked at least
+// older_than_seconds ago; revoked_only keeps expired tokens that were never revoked, an empty
+// user_id cleans up every user. batch_size defaults to 1000, at most 10000; max_tokens of 0 deletes
+// every matching token.
+type CleanupRefreshTokensRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OlderThanSeconds int64                  `protobuf:"varint,2,opt,name=older_than_seconds,json=olderThanSeconds,proto3" json:"older_than_seconds,omitempty"`
+	RevokedOnly      bool                   `protobuf:"varint,3,opt,name=revoked_only,json=revokedOnly,proto3" json:"revoked_only,omitempty"`
+	BatchSize        int32                  `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	MaxTokens        int64                  `protobuf:"varint,5,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CleanupRefreshTokensRequest) Reset() {
+	*x = CleanupRefreshTokensRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupRefreshTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupRefreshTokensRequest) ProtoMessage() {}
+
+func (x *CleanupRefreshTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupRefreshTokensRequest.ProtoReflect.Descriptor instead.
+func (*CleanupRefreshTokensRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *CleanupRefreshTokensRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CleanupRefreshTokensRequest) GetOlderThanSeconds() int64 {
+	if x != nil {
+		return x.OlderThanSeconds
+	}
+	return 0
+}
+
+func (x *CleanupRefreshTokensRequest) GetRevokedOnly() bool {
+	if x != nil {
+		return x.RevokedOnly
+	}
+	return false
+}
+
+func (x *CleanupRefreshTokensRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *CleanupRefreshTokensRequest) GetMaxTokens() int64 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+// Cleanup refresh tokens progress message - the tokens deleted so far, sent after every batch; the
+// last message is done
+type CleanupRefreshTokensProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Batches       int64                  `protobuf:"varint,2,opt,name=batches,proto3" json:"batches,omitempty"`
+	Done          bool                   `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupRefreshTokensProgress) Reset() {
+	*x = CleanupRefreshTokensProgress{}
+	mi := &file_v1_user_svc_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupRefreshTokensProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupRefreshTokensProgress) ProtoMessage() {}
+
+func (x *CleanupRefreshTokensProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupRefreshTokensProgress.ProtoReflect.Descriptor instead.
+func (*CleanupRefreshTokensProgress) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *CleanupRefreshTokensProgress) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *CleanupRefreshTokensProgress) GetBatches() int64 {
+	if x != nil {
+		return x.Batches
+	}
+	return 0
+}
+
+func (x *CleanupRefreshTokensProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+// Register push token request message - the user is taken from the caller's access token; platform
+// is "apns" or "fcm", device_id identifies the app installation, at most 128 characters
+type RegisterPushTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPushTokenRequest) Reset() {
+	*x = RegisterPushTokenRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPushTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPushTokenRequest) ProtoMessage() {}
+
+func (x *RegisterPushTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPushTokenRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPushTokenRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *RegisterPushTokenRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RegisterPushTokenRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *RegisterPushTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Register push token response message - the registered device
+type RegisterPushTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Platform      string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPushTokenResponse) Reset() {
+	*x = RegisterPushTokenResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPushTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPushTokenResponse) ProtoMessage() {}
+
+func (x *RegisterPushTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPushTokenResponse.ProtoReflect.Descriptor instead.
+func (*RegisterPushTokenResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *RegisterPushTokenResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RegisterPushTokenResponse) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *RegisterPushTokenResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Unregister push token request message - the user is taken from the caller's access token
+type UnregisterPushTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterPushTokenRequest) Reset() {
+	*x = UnregisterPushTokenRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterPushTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterPushTokenRequest) ProtoMessage() {}
+
+func (x *UnregisterPushTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterPushTokenRequest.ProtoReflect.Descriptor instead.
+func (*UnregisterPushTokenRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *UnregisterPushTokenRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+// Unregister push token response message - unregistered is false when the device had no token
+type UnregisterPushTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Unregistered  bool                   `protobuf:"varint,1,opt,name=unregistered,proto3" json:"unregistered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnregisterPushTokenResponse) Reset() {
+	*x = UnregisterPushTokenResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnregisterPushTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterPushTokenResponse) ProtoMessage() {}
+
+func (x *UnregisterPushTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterPushTokenResponse.ProtoReflect.Descriptor instead.
+func (*UnregisterPushTokenResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *UnregisterPushTokenResponse) GetUnregistered() bool {
+	if x != nil {
+		return x.Unregistered
+	}
+	return false
+}
+
+// Login schedule subject message - exactly one of a user ID and a role ("customer", "staff" or "admin")
+type LoginScheduleSubject struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginScheduleSubject) Reset() {
+	*x = LoginScheduleSubject{}
+	mi := &file_v1_user_svc_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginScheduleSubject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginScheduleSubject) ProtoMessage() {}
+
+func (x *LoginScheduleSubject) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginScheduleSubject.ProtoReflect.Descriptor instead.
+func (*LoginScheduleSubject) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *LoginScheduleSubject) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoginScheduleSubject) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// Login window message - a span of local time starting on each of days ("mon" to "sun"); start and
+// end are HH:MM, end may be "24:00", and an end before the start spans midnight
+type LoginWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []string               `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	Start         string                 `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginWindow) Reset() {
+	*x = LoginWindow{}
+	mi := &file_v1_user_svc_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginWindow) ProtoMessage() {}
+
+func (x *LoginWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginWindow.ProtoReflect.Descriptor instead.
+func (*LoginWindow) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *LoginWindow) GetDays() []string {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *LoginWindow) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *LoginWindow) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+// Set login schedule request message - timezone is an IANA time zone, e.g. "Europe/Berlin"; 1 to 28 windows
+type SetLoginScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       *LoginScheduleSubject  `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Timezone      string                 `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Windows       []*LoginWindow         `protobuf:"bytes,3,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLoginScheduleRequest) Reset() {
+	*x = SetLoginScheduleRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLoginScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLoginScheduleRequest) ProtoMessage() {}
+
+func (x *SetLoginScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLoginScheduleRequest.ProtoReflect.Descriptor instead.
+func (*SetLoginScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *SetLoginScheduleRequest) GetSubject() *LoginScheduleSubject {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *SetLoginScheduleRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *SetLoginScheduleRequest) GetWindows() []*LoginWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+// Login schedule message - the windows a user or role may authenticate in
+type LoginSchedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       *LoginScheduleSubject  `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Timezone      string                 `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Windows       []*LoginWindow         `protobuf:"bytes,3,rep,name=windows,proto3" json:"windows,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginSchedule) Reset() {
+	*x = LoginSchedule{}
+	mi := &file_v1_user_svc_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginSchedule) ProtoMessage() {}
+
+func (x *LoginSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginSchedule.ProtoReflect.Descriptor instead.
+func (*LoginSchedule) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *LoginSchedule) GetSubject() *LoginScheduleSubject {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *LoginSchedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *LoginSchedule) GetWindows() []*LoginWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+func (x *LoginSchedule) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Delete login schedule response message - deleted is false when there was no schedule
+type DeleteLoginScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLoginScheduleResponse) Reset() {
+	*x = DeleteLoginScheduleResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLoginScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLoginScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteLoginScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLoginScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLoginScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *DeleteLoginScheduleResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Set velocity rule request message - entity is ip, email or device, action is register or
+// login, response is alert, captcha or lock; name is 1 to 64 lowercase letters, digits, dashes
+// or underscores
+type SetVelocityRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Entity        string                 `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Threshold     int32                  `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	WindowSeconds int64                  `protobuf:"varint,5,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	Response      string                 `protobuf:"bytes,6,opt,name=response,proto3" json:"response,omitempty"`
+	Enabled       bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetVelocityRuleRequest) Reset() {
+	*x = SetVelocityRuleRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVelocityRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVelocityRuleRequest) ProtoMessage() {}
+
+func (x *SetVelocityRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVelocityRuleRequest.ProtoReflect.Descriptor instead.
+func (*SetVelocityRuleRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *SetVelocityRuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetVelocityRuleRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *SetVelocityRuleRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *SetVelocityRuleRequest) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *SetVelocityRuleRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *SetVelocityRuleRequest) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *SetVelocityRuleRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// Velocity rule message - an anti-automation rule of the abuse engine; timestamps are in Unix
+// milliseconds
+type VelocityRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Entity        string                 `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Threshold     int32                  `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	WindowSeconds int64                  `protobuf:"varint,5,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	Response      string                 `protobuf:"bytes,6,opt,name=response,proto3" json:"response,omitempty"`
+	Enabled       bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// updated_by is the admin API key that last set the rule
+	UpdatedBy     string `protobuf:"bytes,8,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	CreatedAt     int64  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64  `protobuf:"varint,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VelocityRule) Reset() {
+	*x = VelocityRule{}
+	mi := &file_v1_user_svc_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VelocityRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VelocityRule) ProtoMessage() {}
+
+func (x *VelocityRule) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VelocityRule.ProtoReflect.Descriptor instead.
+func (*VelocityRule) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *VelocityRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VelocityRule) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *VelocityRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *VelocityRule) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *VelocityRule) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *VelocityRule) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *VelocityRule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *VelocityRule) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *VelocityRule) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *VelocityRule) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// List velocity rules request message
+type ListVelocityRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVelocityRulesRequest) Reset() {
+	*x = ListVelocityRulesRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVelocityRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVelocityRulesRequest) ProtoMessage() {}
+
+func (x *ListVelocityRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVelocityRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListVelocityRulesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{94}
+}
+
+// List velocity rules response message
+type ListVelocityRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*VelocityRule        `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVelocityRulesResponse) Reset() {
+	*x = ListVelocityRulesResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVelocityRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVelocityRulesResponse) ProtoMessage() {}
+
+func (x *ListVelocityRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVelocityRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListVelocityRulesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ListVelocityRulesResponse) GetRules() []*VelocityRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// Delete velocity rule request message
+type DeleteVelocityRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVelocityRuleRequest) Reset() {
+	*x = DeleteVelocityRuleRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVelocityRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVelocityRuleRequest) ProtoMessage() {}
+
+func (x *DeleteVelocityRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVelocityRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVelocityRuleRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *DeleteVelocityRuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Delete velocity rule response message - deleted is false when there was no rule of the name
+type DeleteVelocityRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVelocityRuleResponse) Reset() {
+	*x = DeleteVelocityRuleResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVelocityRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVelocityRuleResponse) ProtoMessage() {}
+
+func (x *DeleteVelocityRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVelocityRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVelocityRuleResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *DeleteVelocityRuleResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Set canary account request message - note tells responders where the account's credentials
+// were planted, at most 500 characters
+type SetCanaryAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	Tarpit        bool                   `protobuf:"varint,3,opt,name=tarpit,proto3" json:"tarpit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCanaryAccountRequest) Reset() {
+	*x = SetCanaryAccountRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCanaryAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCanaryAccountRequest) ProtoMessage() {}
+
+func (x *SetCanaryAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCanaryAccountRequest.ProtoReflect.Descriptor instead.
+func (*SetCanaryAccountRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *SetCanaryAccountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetCanaryAccountRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *SetCanaryAccountRequest) GetTarpit() bool {
+	if x != nil {
+		return x.Tarpit
+	}
+	return false
+}
+
+// Canary account message - an account seeded for breach detection; created_at is in Unix
+// milliseconds
+type CanaryAccount struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Note   string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	Tarpit bool                   `protobuf:"varint,3,opt,name=tarpit,proto3" json:"tarpit,omitempty"`
+	// created_by is the admin API key that flagged the account
+	CreatedBy     string `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     int64  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CanaryAccount) Reset() {
+	*x = CanaryAccount{}
+	mi := &file_v1_user_svc_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CanaryAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryAccount) ProtoMessage() {}
+
+func (x *CanaryAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryAccount.ProtoReflect.Descriptor instead.
+func (*CanaryAccount) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *CanaryAccount) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CanaryAccount) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *CanaryAccount) GetTarpit() bool {
+	if x != nil {
+		return x.Tarpit
+	}
+	return false
+}
+
+func (x *CanaryAccount) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *CanaryAccount) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// List canary accounts request message
+type ListCanaryAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCanaryAccountsRequest) Reset() {
+	*x = ListCanaryAccountsRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCanaryAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCanaryAccountsRequest) ProtoMessage() {}
+
+func (x *ListCanaryAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCanaryAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListCanaryAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{100}
+}
+
+// List canary accounts response message
+type ListCanaryAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*CanaryAccount       `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCanaryAccountsResponse) Reset() {
+	*x = ListCanaryAccountsResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCanaryAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCanaryAccountsResponse) ProtoMessage() {}
+
+func (x *ListCanaryAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCanaryAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListCanaryAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *ListCanaryAccountsResponse) GetAccounts() []*CanaryAccount {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+// Delete canary account request message
+type DeleteCanaryAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCanaryAccountRequest) Reset() {
+	*x = DeleteCanaryAccountRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCanaryAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCanaryAccountRequest) ProtoMessage() {}
+
+func (x *DeleteCanaryAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCanaryAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCanaryAccountRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *DeleteCanaryAccountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Delete canary account response message - deleted is false when the user was no canary account
+type DeleteCanaryAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCanaryAccountResponse) Reset() {
+	*x = DeleteCanaryAccountResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCanaryAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCanaryAccountResponse) ProtoMessage() {}
+
+func (x *DeleteCanaryAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCanaryAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCanaryAccountResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *DeleteCanaryAccountResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Global logout request message - cutoff is in Unix milliseconds, 0 for the time of the request,
+// and must not be in the future
+type GlobalLogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cutoff        int64                  `protobuf:"varint,1,opt,name=cutoff,proto3" json:"cutoff,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Confirmation  string                 `protobuf:"bytes,3,opt,name=confirmation,proto3" json:"confirmation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GlobalLogoutRequest) Reset() {
+	*x = GlobalLogoutRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalLogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalLogoutRequest) ProtoMessage() {}
+
+func (x *GlobalLogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalLogoutRequest.ProtoReflect.Descriptor instead.
+func (*GlobalLogoutRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *GlobalLogoutRequest) GetCutoff() int64 {
+	if x != nil {
+		return x.Cutoff
+	}
+	return 0
+}
+
+func (x *GlobalLogoutRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GlobalLogoutRequest) GetConfirmation() string {
+	if x != nil {
+		return x.Confirmation
+	}
+	return ""
+}
+
+// Global logout response message - the recorded logout, once every refresh token up to the
+// cutoff is revoked
+type GlobalLogoutResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cutoff               int64                  `protobuf:"varint,2,opt,name=cutoff,proto3" json:"cutoff,omitempty"`
+	RefreshTokensRevoked int64                  `protobuf:"varint,3,opt,name=refresh_tokens_revoked,json=refreshTokensRevoked,proto3" json:"refresh_tokens_revoked,omitempty"`
+	CompletedAt          int64                  `protobuf:"varint,4,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GlobalLogoutResponse) Reset() {
+	*x = GlobalLogoutResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalLogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalLogoutResponse) ProtoMessage() {}
+
+func (x *GlobalLogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalLogoutResponse.ProtoReflect.Descriptor instead.
+func (*GlobalLogoutResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *GlobalLogoutResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GlobalLogoutResponse) GetCutoff() int64 {
+	if x != nil {
+		return x.Cutoff
+	}
+	return 0
+}
+
+func (x *GlobalLogoutResponse) GetRefreshTokensRevoked() int64 {
+	if x != nil {
+		return x.RefreshTokensRevoked
+	}
+	return 0
+}
+
+func (x *GlobalLogoutResponse) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+// List authorized clients request message - the user is taken from the caller's access token
+type ListAuthorizedClientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorizedClientsRequest) Reset() {
+	*x = ListAuthorizedClientsRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorizedClientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorizedClientsRequest) ProtoMessage() {}
+
+func (x *ListAuthorizedClientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorizedClientsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuthorizedClientsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{106}
+}
+
+// Authorized client message - an application the user is signed in with; timestamps are in Unix
+// milliseconds, platform is empty for applications that are no longer registered
+type AuthorizedClient struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ClientId          string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Platform          string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	FirstAuthorizedAt int64                  `protobuf:"varint,3,opt,name=first_authorized_at,json=firstAuthorizedAt,proto3" json:"first_authorized_at,omitempty"`
+	LastUsedAt        int64                  `protobuf:"varint,4,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	ActiveSessions    int64                  `protobuf:"varint,5,opt,name=active_sessions,json=activeSessions,proto3" json:"active_sessions,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AuthorizedClient) Reset() {
+	*x = AuthorizedClient{}
+	mi := &file_v1_user_svc_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizedClient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizedClient) ProtoMessage() {}
+
+func (x *AuthorizedClient) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizedClient.ProtoReflect.Descriptor instead.
+func (*AuthorizedClient) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *AuthorizedClient) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *AuthorizedClient) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *AuthorizedClient) GetFirstAuthorizedAt() int64 {
+	if x != nil {
+		return x.FirstAuthorizedAt
+	}
+	return 0
+}
+
+func (x *AuthorizedClient) GetLastUsedAt() int64 {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return 0
+}
+
+func (x *AuthorizedClient) GetActiveSessions() int64 {
+	if x != nil {
+		return x.ActiveSessions
+	}
+	return 0
+}
+
+// List authorized clients response message
+type ListAuthorizedClientsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clients       []*AuthorizedClient    `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuthorizedClientsResponse) Reset() {
+	*x = ListAuthorizedClientsResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuthorizedClientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuthorizedClientsResponse) ProtoMessage() {}
+
+func (x *ListAuthorizedClientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuthorizedClientsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuthorizedClientsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *ListAuthorizedClientsResponse) GetClients() []*AuthorizedClient {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+// Revoke client access request message - the user is taken from the caller's access token
+type RevokeClientAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeClientAccessRequest) Reset() {
+	*x = RevokeClientAccessRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeClientAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeClientAccessRequest) ProtoMessage() {}
+
+func (x *RevokeClientAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeClientAccessRequest.ProtoReflect.Descriptor instead.
+func (*RevokeClientAccessRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *RevokeClientAccessRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+// Revoke client access response message - revoked_refresh_tokens is 0 when the user had no
+// session of the client
+type RevokeClientAccessResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RevokedRefreshTokens int64                  `protobuf:"varint,1,opt,name=revoked_refresh_tokens,json=revokedRefreshTokens,proto3" json:"revoked_refresh_tokens,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RevokeClientAccessResponse) Reset() {
+	*x = RevokeClientAccessResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeClientAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeClientAccessResponse) ProtoMessage() {}
+
+func (x *RevokeClientAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeClientAccessResponse.ProtoReflect.Descriptor instead.
+func (*RevokeClientAccessResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *RevokeClientAccessResponse) GetRevokedRefreshTokens() int64 {
+	if x != nil {
+		return x.RevokedRefreshTokens
+	}
+	return 0
+}
+
+// Export organization audit log request message - from is inclusive and to exclusive (unix
+// millis), at most org_audit.max_export_range apart
+type ExportOrgAuditLogRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	From           int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To             int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExportOrgAuditLogRequest) Reset() {
+	*x = ExportOrgAuditLogRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportOrgAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportOrgAuditLogRequest) ProtoMessage() {}
+
+func (x *ExportOrgAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportOrgAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*ExportOrgAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *ExportOrgAuditLogRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *ExportOrgAuditLogRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ExportOrgAuditLogRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// Audit log entry message - metadata is a JSON object depending on the action
+type AuditLogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Metadata      string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLogEntry) Reset() {
+	*x = AuditLogEntry{}
+	mi := &file_v1_user_svc_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogEntry) ProtoMessage() {}
+
+func (x *AuditLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogEntry.ProtoReflect.Descriptor instead.
+func (*AuditLogEntry) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *AuditLogEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Export organization audit log chunk message
+type ExportOrgAuditLogChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditLogEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportOrgAuditLogChunk) Reset() {
+	*x = ExportOrgAuditLogChunk{}
+	mi := &file_v1_user_svc_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportOrgAuditLogChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportOrgAuditLogChunk) ProtoMessage() {}
+
+func (x *ExportOrgAuditLogChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportOrgAuditLogChunk.ProtoReflect.Descriptor instead.
+func (*ExportOrgAuditLogChunk) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *ExportOrgAuditLogChunk) GetEntries() []*AuditLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Add user note request message - visibility is "internal" (default) or "disclosable", and
+// author names the support agent writing the note
+type AddUserNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Visibility    string                 `protobuf:"bytes,3,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Author        string                 `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddUserNoteRequest) Reset() {
+	*x = AddUserNoteRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddUserNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddUserNoteRequest) ProtoMessage() {}
+
+func (x *AddUserNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddUserNoteRequest.ProtoReflect.Descriptor instead.
+func (*AddUserNoteRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *AddUserNoteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddUserNoteRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *AddUserNoteRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *AddUserNoteRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+// User note message - created_by is the admin API key the note was added with; created_at is
+// in Unix milliseconds
+type UserNote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	Visibility    string                 `protobuf:"bytes,4,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Author        string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserNote) Reset() {
+	*x = UserNote{}
+	mi := &file_v1_user_svc_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserNote) ProtoMessage() {}
+
+func (x *UserNote) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserNote.ProtoReflect.Descriptor instead.
+func (*UserNote) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *UserNote) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserNote) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserNote) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *UserNote) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *UserNote) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *UserNote) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *UserNote) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// List user notes request message - visibility optionally limits the notes to "internal" or
+// "disclosable"; page_size 0 returns up to 50 notes (max 500), page_token is the
+// next_page_token of the previous page
+type ListUserNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Visibility    string                 `protobuf:"bytes,2,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserNotesRequest) Reset() {
+	*x = ListUserNotesRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserNotesRequest) ProtoMessage() {}
+
+func (x *ListUserNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserNotesRequest.ProtoReflect.Descriptor instead.
+func (*ListUserNotesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ListUserNotesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUserNotesRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *ListUserNotesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUserNotesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// List user notes response message - next_page_token is empty on the last page
+type ListUserNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notes         []*UserNote            `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserNotesResponse) Reset() {
+	*x = ListUserNotesResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserNotesResponse) ProtoMessage() {}
+
+func (x *ListUserNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserNotesResponse.ProtoReflect.Descriptor instead.
+func (*ListUserNotesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ListUserNotesResponse) GetNotes() []*UserNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *ListUserNotesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Get registration gates request message
+type GetRegistrationGatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRegistrationGatesRequest) Reset() {
+	*x = GetRegistrationGatesRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRegistrationGatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegistrationGatesRequest) ProtoMessage() {}
+
+func (x *GetRegistrationGatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegistrationGatesRequest.ProtoReflect.Descriptor instead.
+func (*GetRegistrationGatesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{118}
+}
+
+// Set registration gates request message - invite codes are 4 to 64 letters, digits, dashes
+// or underscores, matched ignoring case; daily_cap 0 leaves sign-ups uncapped; allowlist
+// entries are email addresses or domains, a domain also matching its subdomains
+type SetRegistrationGatesRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	InviteCodeRequired bool                   `protobuf:"varint,1,opt,name=invite_code_required,json=inviteCodeRequired,proto3" json:"invite_code_required,omitempty"`
+	InviteCodes        []string               `protobuf:"bytes,2,rep,name=invite_codes,json=inviteCodes,proto3" json:"invite_codes,omitempty"`
+	DailyCap           int32                  `protobuf:"varint,3,opt,name=daily_cap,json=dailyCap,proto3" json:"daily_cap,omitempty"`
+	AllowlistOnly      bool                   `protobuf:"varint,4,opt,name=allowlist_only,json=allowlistOnly,proto3" json:"allowlist_only,omitempty"`
+	Allowlist          []string               `protobuf:"bytes,5,rep,name=allowlist,proto3" json:"allowlist,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SetRegistrationGatesRequest) Reset() {
+	*x = SetRegistrationGatesRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRegistrationGatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRegistrationGatesRequest) ProtoMessage() {}
+
+func (x *SetRegistrationGatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRegistrationGatesRequest.ProtoReflect.Descriptor instead.
+func (*SetRegistrationGatesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *SetRegistrationGatesRequest) GetInviteCodeRequired() bool {
+	if x != nil {
+		return x.InviteCodeRequired
+	}
+	return false
+}
+
+func (x *SetRegistrationGatesRequest) GetInviteCodes() []string {
+	if x != nil {
+		return x.InviteCodes
+	}
+	return nil
+}
+
+func (x *SetRegistrationGatesRequest) GetDailyCap() int32 {
+	if x != nil {
+		return x.DailyCap
+	}
+	return 0
+}
+
+func (x *SetRegistrationGatesRequest) GetAllowlistOnly() bool {
+	if x != nil {
+		return x.AllowlistOnly
+	}
+	return false
+}
+
+func (x *SetRegistrationGatesRequest) GetAllowlist() []string {
+	if x != nil {
+		return x.Allowlist
+	}
+	return nil
+}
+
+// Registration gates message - signups_today counts the registrations of the current UTC
+// day; updated_by is the admin API key that last set the gates; updated_at is in Unix
+// milliseconds
+type RegistrationGates struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	InviteCodeRequired bool                   `protobuf:"varint,1,opt,name=invite_code_required,json=inviteCodeRequired,proto3" json:"invite_code_required,omitempty"`
+	InviteCodes        []string               `protobuf:"bytes,2,rep,name=invite_codes,json=inviteCodes,proto3" json:"invite_codes,omitempty"`
+	DailyCap           int32                  `protobuf:"varint,3,opt,name=daily_cap,json=dailyCap,proto3" json:"daily_cap,omitempty"`
+	AllowlistOnly      bool                   `protobuf:"varint,4,opt,name=allowlist_only,json=allowlistOnly,proto3" json:"allowlist_only,omitempty"`
+	Allowlist          []string               `protobuf:"bytes,5,rep,name=allowlist,proto3" json:"allowlist,omitempty"`
+	SignupsToday       int32                  `protobuf:"varint,6,opt,name=signups_today,json=signupsToday,proto3" json:"signups_today,omitempty"`
+	UpdatedBy          string                 `protobuf:"bytes,7,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt          int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RegistrationGates) Reset() {
+	*x = RegistrationGates{}
+	mi := &file_v1_user_svc_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationGates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationGates) ProtoMessage() {}
+
+func (x *RegistrationGates) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationGates.ProtoReflect.Descriptor instead.
+func (*RegistrationGates) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *RegistrationGates) GetInviteCodeRequired() bool {
+	if x != nil {
+		return x.InviteCodeRequired
+	}
+	return false
+}
+
+func (x *RegistrationGates) GetInviteCodes() []string {
+	if x != nil {
+		return x.InviteCodes
+	}
+	return nil
+}
+
+func (x *RegistrationGates) GetDailyCap() int32 {
+	if x != nil {
+		return x.DailyCap
+	}
+	return 0
+}
+
+func (x *RegistrationGates) GetAllowlistOnly() bool {
+	if x != nil {
+		return x.AllowlistOnly
+	}
+	return false
+}
+
+func (x *RegistrationGates) GetAllowlist() []string {
+	if x != nil {
+		return x.Allowlist
+	}
+	return nil
+}
+
+func (x *RegistrationGates) GetSignupsToday() int32 {
+	if x != nil {
+		return x.SignupsToday
+	}
+	return 0
+}
+
+func (x *RegistrationGates) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *RegistrationGates) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Get login history request message - user_id is required with an admin API key and may be
+// left empty by users; page_size 0 returns up to 50 attempts (max 500), page_token is the
+// next_page_token of the previous page
+type GetLoginHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginHistoryRequest) Reset() {
+	*x = GetLoginHistoryRequest{}
+	mi := &file_v1_user_svc_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginHistoryRequest) ProtoMessage() {}
+
+func (x *GetLoginHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetLoginHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *GetLoginHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLoginHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetLoginHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Login attempt message - failure_reason is empty for successful attempts; created_at is in
+// Unix milliseconds
+type LoginAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	FailureReason string                 `protobuf:"bytes,4,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	ClientId      string                 `protobuf:"bytes,5,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,6,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,7,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginAttempt) Reset() {
+	*x = LoginAttempt{}
+	mi := &file_v1_user_svc_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginAttempt) ProtoMessage() {}
+
+func (x *LoginAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginAttempt.ProtoReflect.Descriptor instead.
+func (*LoginAttempt) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *LoginAttempt) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LoginAttempt) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *LoginAttempt) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Get login history response message - next_page_token is empty on the last page
+type GetLoginHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attempts      []*LoginAttempt        `protobuf:"bytes,1,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginHistoryResponse) Reset() {
+	*x = GetLoginHistoryResponse{}
+	mi := &file_v1_user_svc_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginHistoryResponse) ProtoMessage() {}
+
+func (x *GetLoginHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_user_svc_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetLoginHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_v1_user_svc_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *GetLoginHistoryResponse) GetAttempts() []*LoginAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+func (x *GetLoginHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_v1_user_svc_proto protoreflect.FileDescriptor
+
+const file_v1_user_svc_proto_rawDesc = "" +
+	"\n" +
+	"\x11v1/user-svc.proto\x12\x04user\"\xbb\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12'\n" +
+	"\x0forganization_id\x18\x04 \x01(\tR\x0eorganizationId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x12\x1c\n" +
+	"\tresidency\x18\a \x01(\tR\tresidency\"\xc6\x01\n" +
+	"\x0fRegisterRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12'\n" +
+	"\x0forganization_id\x18\x05 \x01(\tR\x0eorganizationId\x12\x1f\n" +
+	"\vinvite_code\x18\x06 \x01(\tR\n" +
+	"inviteCode\"z\n" +
+	"\x10RegisterResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"~\n" +
+	"\fLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1f\n" +
+	"\vremember_me\x18\x04 \x01(\bR\n" +
+	"rememberMe\"w\n" +
+	"\rLoginResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\":\n" +
+	"\x13RefreshTokenRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"^\n" +
+	"\x14RefreshTokenResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"\x16\n" +
+	"\x14GetQuotaUsageRequest\"\x90\x01\n" +
+	"\n" +
+	"QuotaUsage\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x12\n" +
+	"\x04used\x18\x02 \x01(\x03R\x04used\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12!\n" +
+	"\fwindow_start\x18\x04 \x01(\x03R\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\x05 \x01(\x03R\twindowEnd\"[\n" +
+	"\x15GetQuotaUsageResponse\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12(\n" +
+	"\x06usages\x18\x02 \x03(\v2\x10.user.QuotaUsageR\x06usages\"-\n" +
+	"\x13GetSLOStatusRequest\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\"\xa7\x04\n" +
+	"\tSLOStatus\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12%\n" +
+	"\x0etotal_requests\x18\x02 \x01(\x03R\rtotalRequests\x12'\n" +
+	"\x0ffailed_requests\x18\x03 \x01(\x03R\x0efailedRequests\x12#\n" +
+	"\rslow_requests\x18\x04 \x01(\x03R\fslowRequests\x12\"\n" +
+	"\favailability\x18\x05 \x01(\x01R\favailability\x125\n" +
+	"\x16availability_objective\x18\x06 \x01(\x01R\x15availabilityObjective\x12M\n" +
+	"#availability_error_budget_remaining\x18\a \x01(\x01R availabilityErrorBudgetRemaining\x12-\n" +
+	"\x12latency_compliance\x18\b \x01(\x01R\x11latencyCompliance\x12+\n" +
+	"\x11latency_objective\x18\t \x01(\x01R\x10latencyObjective\x120\n" +
+	"\x14latency_threshold_ms\x18\n" +
+	" \x01(\x03R\x12latencyThresholdMs\x12C\n" +
+	"\x1elatency_error_budget_remaining\x18\v \x01(\x01R\x1blatencyErrorBudgetRemaining\x12\x10\n" +
+	"\x03met\x18\f \x01(\bR\x03met\"\x8d\x01\n" +
+	"\x14GetSLOStatusResponse\x12%\n" +
+	"\x0ewindow_seconds\x18\x01 \x01(\x03R\rwindowSeconds\x12!\n" +
+	"\fgenerated_at\x18\x02 \x01(\x03R\vgeneratedAt\x12+\n" +
+	"\bstatuses\x18\x03 \x03(\v2\x0f.user.SLOStatusR\bstatuses\"5\n" +
+	"\x1aRevokeAllUserTokensRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"S\n" +
+	"\x1bRevokeAllUserTokensResponse\x124\n" +
+	"\x16revoked_refresh_tokens\x18\x01 \x01(\x03R\x14revokedRefreshTokens\"\"\n" +
+	" GetAccountActivitySummaryRequest\"\x88\x01\n" +
+	"\x0eDeviceActivity\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x01 \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x02 \x01(\tR\tipAddress\x12\x16\n" +
+	"\x06logins\x18\x03 \x01(\x03R\x06logins\x12 \n" +
+	"\flast_seen_at\x18\x04 \x01(\x03R\n" +
+	"lastSeenAt\"\xb0\x02\n" +
+	"!GetAccountActivitySummaryResponse\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x12\x16\n" +
+	"\x06logins\x18\x03 \x01(\x03R\x06logins\x12\"\n" +
+	"\rlast_login_at\x18\x04 \x01(\x03R\vlastLoginAt\x12.\n" +
+	"\adevices\x18\x05 \x03(\v2\x14.user.DeviceActivityR\adevices\x12)\n" +
+	"\x10password_changes\x18\x06 \x01(\x03R\x0fpasswordChanges\x12#\n" +
+	"\remail_changes\x18\a \x01(\x03R\femailChanges\x12+\n" +
+	"\x11token_revocations\x18\b \x01(\x03R\x10tokenRevocations\"]\n" +
+	"\x1bPreviewEmailTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\"x\n" +
+	"\x1cPreviewEmailTemplateResponse\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x12\n" +
+	"\x04html\x18\x03 \x01(\tR\x04html\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\"k\n" +
+	"\x16NotificationPreference\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x01 \x01(\tR\teventType\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\"#\n" +
+	"!GetNotificationPreferencesRequest\"f\n" +
+	"$UpdateNotificationPreferencesRequest\x12>\n" +
+	"\vpreferences\x18\x01 \x03(\v2\x1c.user.NotificationPreferenceR\vpreferences\"a\n" +
+	"\x1fNotificationPreferencesResponse\x12>\n" +
+	"\vpreferences\x18\x01 \x03(\v2\x1c.user.NotificationPreferenceR\vpreferences\")\n" +
+	"\x13GetUserStatsRequest\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\"\x83\x01\n" +
+	"\x0eDailyUserStats\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x03R\x03day\x12$\n" +
+	"\rregistrations\x18\x02 \x01(\x03R\rregistrations\x12\x16\n" +
+	"\x06logins\x18\x03 \x01(\x03R\x06logins\x12!\n" +
+	"\factive_users\x18\x04 \x01(\x03R\vactiveUsers\"\x90\x02\n" +
+	"\x14GetUserStatsResponse\x12!\n" +
+	"\fgenerated_at\x18\x01 \x01(\x03R\vgeneratedAt\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x12\x1f\n" +
+	"\vtotal_users\x18\x04 \x01(\x03R\n" +
+	"totalUsers\x12'\n" +
+	"\x0factive_sessions\x18\x05 \x01(\x03R\x0eactiveSessions\x12;\n" +
+	"\x1ausers_with_active_sessions\x18\x06 \x01(\x03R\x17usersWithActiveSessions\x12*\n" +
+	"\x05daily\x18\a \x03(\v2\x14.user.DailyUserStatsR\x05daily\"\xd7\x01\n" +
+	"\x12ExportUsersRequest\x12!\n" +
+	"\fcreated_from\x18\x01 \x01(\x03R\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x02 \x01(\x03R\tcreatedTo\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\x12&\n" +
+	"\x0frows_per_second\x18\x05 \x01(\x05R\rrowsPerSecond\x12'\n" +
+	"\x0forganization_id\x18\x06 \x01(\tR\x0eorganizationId\"\x8e\x01\n" +
+	"\fExportedUser\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\"T\n" +
+	"\x10ExportUsersChunk\x12(\n" +
+	"\x05users\x18\x01 \x03(\v2\x12.user.ExportedUserR\x05users\x12\x16\n" +
+	"\x06ndjson\x18\x02 \x01(\fR\x06ndjson\"C\n" +
+	"\x10LegalHoldRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"e\n" +
+	"\x11LegalHoldResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"legal_hold\x18\x02 \x01(\bR\tlegalHold\x12\x18\n" +
+	"\achanged\x18\x03 \x01(\bR\achanged\"0\n" +
+	"\x15GetRiskSignalsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xde\x02\n" +
+	"\x16GetRiskSignalsResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\x03R\tcreatedAt\x12(\n" +
+	"\x10account_age_days\x18\x03 \x01(\x03R\x0eaccountAgeDays\x12!\n" +
+	"\fdevice_count\x18\x04 \x01(\x03R\vdeviceCount\x12)\n" +
+	"\x10disposable_email\x18\x05 \x01(\bR\x0fdisposableEmail\x12=\n" +
+	"\x1bregistration_ip_reuse_count\x18\x06 \x01(\x03R\x18registrationIpReuseCount\x12!\n" +
+	"\fgenerated_at\x18\a \x01(\x03R\vgeneratedAt\x122\n" +
+	"\x15session_anomaly_count\x18\b \x01(\x03R\x13sessionAnomalyCount\"6\n" +
+	"\x18GetUserByUsernameRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"1\n" +
+	"\x14BatchGetUsersRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"\x9b\x01\n" +
+	"\x15BatchGetUsersResponse\x12<\n" +
+	"\x05users\x18\x01 \x03(\v2&.user.BatchGetUsersResponse.UsersEntryR\x05users\x1aD\n" +
+	"\n" +
+	"UsersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\x05value\x18\x02 \x01(\v2\n" +
+	".user.UserR\x05value:\x028\x01\"\xec\x01\n" +
+	"\x14ExchangeTokenRequest\x12\x1d\n" +
+	"\n" +
+	"grant_type\x18\x01 \x01(\tR\tgrantType\x12#\n" +
+	"\rsubject_token\x18\x02 \x01(\tR\fsubjectToken\x12,\n" +
+	"\x12subject_token_type\x18\x03 \x01(\tR\x10subjectTokenType\x12\x1a\n" +
+	"\baudience\x18\x04 \x01(\tR\baudience\x12\x14\n" +
+	"\x05scope\x18\x05 \x03(\tR\x05scope\x120\n" +
+	"\x14requested_token_type\x18\x06 \x01(\tR\x12requestedTokenType\"\xba\x01\n" +
+	"\x15ExchangeTokenResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12*\n" +
+	"\x11issued_token_type\x18\x02 \x01(\tR\x0fissuedTokenType\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x03 \x01(\tR\ttokenType\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x04 \x01(\x03R\texpiresIn\x12\x14\n" +
+	"\x05scope\x18\x05 \x03(\tR\x05scope\"7\n" +
+	"\x12VerifyTokenRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\xdd\x02\n" +
+	"\x13VerifyTokenResponse\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\tR\atokenId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1b\n" +
+	"\tissued_at\x18\x04 \x01(\x03R\bissuedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x12'\n" +
+	"\x0forganization_id\x18\a \x01(\tR\x0eorganizationId\x12\x1b\n" +
+	"\tclient_id\x18\b \x01(\tR\bclientId\x12\x1c\n" +
+	"\tresidency\x18\t \x01(\tR\tresidency\x12\x10\n" +
+	"\x03jkt\x18\n" +
+	" \x01(\tR\x03jkt\x12\x1a\n" +
+	"\baudience\x18\v \x01(\tR\baudience\x12\x14\n" +
+	"\x05scope\x18\f \x03(\tR\x05scope\"J\n" +
+	"\x19ClaimGuestActivityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"\xf4\x01\n" +
+	"\x1aClaimGuestActivityResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1f\n" +
+	"\vverified_by\x18\x03 \x01(\tR\n" +
+	"verifiedBy\x12\x1f\n" +
+	"\vverified_at\x18\x04 \x01(\x03R\n" +
+	"verifiedAt\x12\x1d\n" +
+	"\n" +
+	"claimed_at\x18\x05 \x01(\x03R\tclaimedAt\x12\x1d\n" +
+	"\n" +
+	"claimed_by\x18\x06 \x01(\tR\tclaimedBy\x12'\n" +
+	"\x0falready_claimed\x18\a \x01(\bR\x0ealreadyClaimed\"\xbb\x02\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
+	"\x15allowed_email_domains\x18\x03 \x03(\tR\x13allowedEmailDomains\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x126\n" +
+	"\bbranding\x18\x06 \x01(\v2\x1a.user.OrganizationBrandingR\bbranding\x12\x1c\n" +
+	"\tresidency\x18\a \x01(\tR\tresidency\x12?\n" +
+	"\x1csession_idle_timeout_seconds\x18\b \x01(\x03R\x19sessionIdleTimeoutSeconds\"\x80\x01\n" +
+	"\x14OrganizationBranding\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\blogo_url\x18\x02 \x01(\tR\alogoUrl\x12#\n" +
+	"\rsupport_email\x18\x03 \x01(\tR\fsupportEmail\x12\x14\n" +
+	"\x05color\x18\x04 \x01(\tR\x05color\"\x81\x01\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x122\n" +
+	"\x15allowed_email_domains\x18\x02 \x03(\tR\x13allowedEmailDomains\x12\x1c\n" +
+	"\tresidency\x18\x03 \x01(\tR\tresidency\"A\n" +
+	"\x16GetOrganizationRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"2\n" +
+	"\x1cGetOrganizationByNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x81\x01\n" +
+	"\"SetOrganizationEmailDomainsRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x122\n" +
+	"\x15allowed_email_domains\x18\x02 \x03(\tR\x13allowedEmailDomains\"\x81\x01\n" +
+	"\x1eSetOrganizationBrandingRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x126\n" +
+	"\bbranding\x18\x02 \x01(\v2\x1a.user.OrganizationBrandingR\bbranding\"\x8f\x01\n" +
+	"#SetOrganizationSessionPolicyRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12?\n" +
+	"\x1csession_idle_timeout_seconds\x18\x02 \x01(\x03R\x19sessionIdleTimeoutSeconds\"\xb0\x01\n" +
+	"\x10ImportUserRecord\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12'\n" +
+	"\x0forganization_id\x18\x03 \x01(\tR\x0eorganizationId\x12A\n" +
+	"\x0flegacy_password\x18\x04 \x01(\v2\x18.user.LegacyPasswordHashR\x0elegacyPassword\"T\n" +
+	"\x12LegacyPasswordHash\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04salt\x18\x02 \x01(\tR\x04salt\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\tR\x04hash\"B\n" +
+	"\x12ImportUsersRequest\x12,\n" +
+	"\x05users\x18\x01 \x03(\v2\x16.user.ImportUserRecordR\x05users\"\x81\x01\n" +
+	"\x10ImportUserResult\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"G\n" +
+	"\x13ImportUsersResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.user.ImportUserResultR\aresults\"m\n" +
+	"\x1cCompletePasswordSetupRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\"\xa4\x01\n" +
+	"\x17LoginWithIDTokenRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x19\n" +
+	"\bid_token\x18\x02 \x01(\tR\aidToken\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\tR\x05nonce\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x1f\n" +
+	"\vremember_me\x18\x05 \x01(\bR\n" +
+	"rememberMe\"x\n" +
+	"\x16BatchAssignRoleRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"~\n" +
+	"\x18BatchUpdateStatusRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"X\n" +
+	"\x0fBatchUserResult\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"K\n" +
+	"\x18BatchUserResultsResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.user.BatchUserResultR\aresults\"k\n" +
+	"\x15GetUserHistoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rafter_version\x18\x02 \x01(\x03R\fafterVersion\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x94\x01\n" +
+	"\tUserEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\tR\x04data\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x1f\n" +
+	"\voccurred_at\x18\x06 \x01(\x03R\n" +
+	"occurredAt\"o\n" +
+	"\x16GetUserHistoryResponse\x12'\n" +
+	"\x06events\x18\x01 \x03(\v2\x0f.user.UserEventR\x06events\x12,\n" +
+	"\x12next_after_version\x18\x02 \x01(\x03R\x10nextAfterVersion\"w\n" +
+	"\x10ListUsersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12'\n" +
+	"\x0forganization_id\x18\x03 \x01(\tR\x0eorganizationId\"]\n" +
+	"\x11ListUsersResponse\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".user.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x1a\n" +
+	"\x18PromoteSigningKeyRequest\"i\n" +
+	"\x19PromoteSigningKeyResponse\x12$\n" +
+	"\x0eprimary_key_id\x18\x01 \x01(\tR\fprimaryKeyId\x12&\n" +
+	"\x0fprevious_key_id\x18\x02 \x01(\tR\rpreviousKeyId\"\xba\x01\n" +
+	"\x16SetUserMetadataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x127\n" +
+	"\x03set\x18\x02 \x03(\v2%.user.SetUserMetadataRequest.SetEntryR\x03set\x12\x16\n" +
+	"\x06remove\x18\x03 \x03(\tR\x06remove\x1a6\n" +
+	"\bSetEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9f\x01\n" +
+	"\x17SetUserMetadataResponse\x12G\n" +
+	"\bmetadata\x18\x01 \x03(\v2+.user.SetUserMetadataResponse.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"B\n" +
+	"\x1dRequestAvatarUploadURLRequest\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\"\xa0\x01\n" +
+	"\x1eRequestAvatarUploadURLResponse\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x01 \x01(\tR\tuploadUrl\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x03 \x01(\tR\tobjectKey\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"5\n" +
+	"\x14ConfirmAvatarRequest\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x01 \x01(\tR\tobjectKey\"U\n" +
+	"\x15ConfirmAvatarResponse\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x01 \x01(\tR\tobjectKey\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x02 \x01(\tR\tavatarUrl\"7\n" +
+	"\x15ExportSnapshotRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\"#\n" +
+	"\rSnapshotChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"L\n" +
+	"\x16RestoreSnapshotRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"q\n" +
+	"\x17RestoreSnapshotResponse\x12$\n" +
+	"\rorganizations\x18\x01 \x01(\x03R\rorganizations\x12\x14\n" +
+	"\x05users\x18\x02 \x01(\x03R\x05users\x12\x1a\n" +
+	"\bsessions\x18\x03 \x01(\x03R\bsessions\"-\n" +
+	"\x10WatchUserRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"y\n" +
+	"\n" +
+	"UserUpdate\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\x04user\x18\x02 \x01(\v2\n" +
+	".user.UserR\x04user\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12\x18\n" +
+	"\ainitial\x18\x04 \x01(\bR\ainitial\"\xc5\x01\n" +
+	"\x1bCleanupRefreshTokensRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12,\n" +
+	"\x12older_than_seconds\x18\x02 \x01(\x03R\x10olderThanSeconds\x12!\n" +
+	"\frevoked_only\x18\x03 \x01(\bR\vrevokedOnly\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\x12\x1d\n" +
+	"\n" +
+	"max_tokens\x18\x05 \x01(\x03R\tmaxTokens\"f\n" +
+	"\x1cCleanupRefreshTokensProgress\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted\x12\x18\n" +
+	"\abatches\x18\x02 \x01(\x03R\abatches\x12\x12\n" +
+	"\x04done\x18\x03 \x01(\bR\x04done\"i\n" +
+	"\x18RegisterPushTokenRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"s\n" +
+	"\x19RegisterPushTokenResponse\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\"9\n" +
+	"\x1aUnregisterPushTokenRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"A\n" +
+	"\x1bUnregisterPushTokenResponse\x12\"\n" +
+	"\funregistered\x18\x01 \x01(\bR\funregistered\"C\n" +
+	"\x14LoginScheduleSubject\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"I\n" +
+	"\vLoginWindow\x12\x12\n" +
+	"\x04days\x18\x01 \x03(\tR\x04days\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\tR\x03end\"\x98\x01\n" +
+	"\x17SetLoginScheduleRequest\x124\n" +
+	"\asubject\x18\x01 \x01(\v2\x1a.user.LoginScheduleSubjectR\asubject\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\x12+\n" +
+	"\awindows\x18\x03 \x03(\v2\x11.user.LoginWindowR\awindows\"\xad\x01\n" +
+	"\rLoginSchedule\x124\n" +
+	"\asubject\x18\x01 \x01(\v2\x1a.user.LoginScheduleSubjectR\asubject\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\x12+\n" +
+	"\awindows\x18\x03 \x03(\v2\x11.user.LoginWindowR\awindows\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\"7\n" +
+	"\x1bDeleteLoginScheduleResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\xd7\x01\n" +
+	"\x16SetVelocityRuleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06entity\x18\x02 \x01(\tR\x06entity\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1c\n" +
+	"\tthreshold\x18\x04 \x01(\x05R\tthreshold\x12%\n" +
+	"\x0ewindow_seconds\x18\x05 \x01(\x03R\rwindowSeconds\x12\x1a\n" +
+	"\bresponse\x18\x06 \x01(\tR\bresponse\x12\x18\n" +
+	"\aenabled\x18\a \x01(\bR\aenabled\"\xaa\x02\n" +
+	"\fVelocityRule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06entity\x18\x02 \x01(\tR\x06entity\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1c\n" +
+	"\tthreshold\x18\x04 \x01(\x05R\tthreshold\x12%\n" +
+	"\x0ewindow_seconds\x18\x05 \x01(\x03R\rwindowSeconds\x12\x1a\n" +
+	"\bresponse\x18\x06 \x01(\tR\bresponse\x12\x18\n" +
+	"\aenabled\x18\a \x01(\bR\aenabled\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\b \x01(\tR\tupdatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\x03R\tupdatedAt\"\x1a\n" +
+	"\x18ListVelocityRulesRequest\"E\n" +
+	"\x19ListVelocityRulesResponse\x12(\n" +
+	"\x05rules\x18\x01 \x03(\v2\x12.user.VelocityRuleR\x05rules\"/\n" +
+	"\x19DeleteVelocityRuleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"6\n" +
+	"\x1aDeleteVelocityRuleResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"^\n" +
+	"\x17SetCanaryAccountRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12\x16\n" +
+	"\x06tarpit\x18\x03 \x01(\bR\x06tarpit\"\x92\x01\n" +
+	"\rCanaryAccount\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12\x16\n" +
+	"\x06tarpit\x18\x03 \x01(\bR\x06tarpit\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"\x1b\n" +
+	"\x19ListCanaryAccountsRequest\"M\n" +
+	"\x1aListCanaryAccountsResponse\x12/\n" +
+	"\baccounts\x18\x01 \x03(\v2\x13.user.CanaryAccountR\baccounts\"5\n" +
+	"\x1aDeleteCanaryAccountRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"7\n" +
+	"\x1bDeleteCanaryAccountResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"i\n" +
+	"\x13GlobalLogoutRequest\x12\x16\n" +
+	"\x06cutoff\x18\x01 \x01(\x03R\x06cutoff\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\"\n" +
+	"\fconfirmation\x18\x03 \x01(\tR\fconfirmation\"\x97\x01\n" +
+	"\x14GlobalLogoutResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06cutoff\x18\x02 \x01(\x03R\x06cutoff\x124\n" +
+	"\x16refresh_tokens_revoked\x18\x03 \x01(\x03R\x14refreshTokensRevoked\x12!\n" +
+	"\fcompleted_at\x18\x04 \x01(\x03R\vcompletedAt\"\x1e\n" +
+	"\x1cListAuthorizedClientsRequest\"\xc6\x01\n" +
+	"\x10AuthorizedClient\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\x12.\n" +
+	"\x13first_authorized_at\x18\x03 \x01(\x03R\x11firstAuthorizedAt\x12 \n" +
+	"\flast_used_at\x18\x04 \x01(\x03R\n" +
+	"lastUsedAt\x12'\n" +
+	"\x0factive_sessions\x18\x05 \x01(\x03R\x0eactiveSessions\"Q\n" +
+	"\x1dListAuthorizedClientsResponse\x120\n" +
+	"\aclients\x18\x01 \x03(\v2\x16.user.AuthorizedClientR\aclients\"8\n" +
+	"\x19RevokeClientAccessRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\"R\n" +
+	"\x1aRevokeClientAccessResponse\x124\n" +
+	"\x16revoked_refresh_tokens\x18\x01 \x01(\x03R\x14revokedRefreshTokens\"g\n" +
+	"\x18ExportOrgAuditLogRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\"\x8b\x01\n" +
+	"\rAuditLogEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"G\n" +
+	"\x16ExportOrgAuditLogChunk\x12-\n" +
+	"\aentries\x18\x01 \x03(\v2\x13.user.AuditLogEntryR\aentries\"y\n" +
+	"\x12AddUserNoteRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x03 \x01(\tR\n" +
+	"visibility\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\"\xbd\x01\n" +
+	"\bUserNote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\tR\n" +
+	"visibility\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"\x8b\x01\n" +
+	"\x14ListUserNotesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x02 \x01(\tR\n" +
+	"visibility\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"e\n" +
+	"\x15ListUserNotesResponse\x12$\n" +
+	"\x05notes\x18\x01 \x03(\v2\x0e.user.UserNoteR\x05notes\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x1d\n" +
+	"\x1bGetRegistrationGatesRequest\"\xd4\x01\n" +
+	"\x1bSetRegistrationGatesRequest\x120\n" +
+	"\x14invite_code_required\x18\x01 \x01(\bR\x12inviteCodeRequired\x12!\n" +
+	"\finvite_codes\x18\x02 \x03(\tR\vinviteCodes\x12\x1b\n" +
+	"\tdaily_cap\x18\x03 \x01(\x05R\bdailyCap\x12%\n" +
+	"\x0eallowlist_only\x18\x04 \x01(\bR\rallowlistOnly\x12\x1c\n" +
+	"\tallowlist\x18\x05 \x03(\tR\tallowlist\"\xad\x02\n" +
+	"\x11RegistrationGates\x120\n" +
+	"\x14invite_code_required\x18\x01 \x01(\bR\x12inviteCodeRequired\x12!\n" +
+	"\finvite_codes\x18\x02 \x03(\tR\vinviteCodes\x12\x1b\n" +
+	"\tdaily_cap\x18\x03 \x01(\x05R\bdailyCap\x12%\n" +
+	"\x0eallowlist_only\x18\x04 \x01(\bR\rallowlistOnly\x12\x1c\n" +
+	"\tallowlist\x18\x05 \x03(\tR\tallowlist\x12#\n" +
+	"\rsignups_today\x18\x06 \x01(\x05R\fsignupsToday\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\a \x01(\tR\tupdatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\"m\n" +
+	"\x16GetLoginHistoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xf2\x01\n" +
+	"\fLoginAttempt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12%\n" +
+	"\x0efailure_reason\x18\x04 \x01(\tR\rfailureReason\x12\x1b\n" +
+	"\tclient_id\x18\x05 \x01(\tR\bclientId\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x06 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\a \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\"q\n" +
+	"\x17GetLoginHistoryResponse\x12.\n" +
+	"\battempts\x18\x01 \x03(\v2\x12.user.LoginAttemptR\battempts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\x8c(\n" +
+	"\vUserService\x12>\n" +
+	"\bRegister\x12\x15.user.RegisterRequest\x1a\x16.user.RegisterResponse\"\x03\x88\x02\x01\x125\n" +
+	"\x05Login\x12\x12.user.LoginRequest\x1a\x13.user.LoginResponse\"\x03\x88\x02\x01\x12J\n" +
+	"\fRefreshToken\x12\x19.user.RefreshTokenRequest\x1a\x1a.user.RefreshTokenResponse\"\x03\x88\x02\x01\x12M\n" +
+	"\rGetQuotaUsage\x12\x1a.user.GetQuotaUsageRequest\x1a\x1b.user.GetQuotaUsageResponse\"\x03\x90\x02\x01\x12J\n" +
+	"\fGetSLOStatus\x12\x19.user.GetSLOStatusRequest\x1a\x1a.user.GetSLOStatusResponse\"\x03\x90\x02\x01\x12_\n" +
+	"\x13RevokeAllUserTokens\x12 .user.RevokeAllUserTokensRequest\x1a!.user.RevokeAllUserTokensResponse\"\x03\x90\x02\x02\x12q\n" +
+	"\x19GetAccountActivitySummary\x12&.user.GetAccountActivitySummaryRequest\x1a'.user.GetAccountActivitySummaryResponse\"\x03\x90\x02\x01\x12b\n" +
+	"\x14PreviewEmailTemplate\x12!.user.PreviewEmailTemplateRequest\x1a\".user.PreviewEmailTemplateResponse\"\x03\x90\x02\x01\x12q\n" +
+	"\x1aGetNotificationPreferences\x12'.user.GetNotificationPreferencesRequest\x1a%.user.NotificationPreferencesResponse\"\x03\x90\x02\x01\x12w\n" +
+	"\x1dUpdateNotificationPreferences\x12*.user.UpdateNotificationPreferencesRequest\x1a%.user.NotificationPreferencesResponse\"\x03\x90\x02\x02\x12J\n" +
+	"\fGetUserStats\x12\x19.user.GetUserStatsRequest\x1a\x1a.user.GetUserStatsResponse\"\x03\x90\x02\x01\x12A\n" +
+	"\vExportUsers\x12\x18.user.ExportUsersRequest\x1a\x16.user.ExportUsersChunk0\x01\x12F\n" +
+	"\x0ePlaceLegalHold\x12\x16.user.LegalHoldRequest\x1a\x17.user.LegalHoldResponse\"\x03\x90\x02\x02\x12H\n" +
+	"\x10ReleaseLegalHold\x12\x16.user.LegalHoldRequest\x1a\x17.user.LegalHoldResponse\"\x03\x90\x02\x02\x12P\n" +
+	"\x0eGetRiskSignals\x12\x1b.user.GetRiskSignalsRequest\x1a\x1c.user.GetRiskSignalsResponse\"\x03\x90\x02\x01\x12D\n" +
+	"\x11GetUserByUsername\x12\x1e.user.GetUserByUsernameRequest\x1a\n" +
+	".user.User\"\x03\x90\x02\x01\x12M\n" +
+	"\rBatchGetUsers\x12\x1a.user.BatchGetUsersRequest\x1a\x1b.user.BatchGetUsersResponse\"\x03\x90\x02\x01\x12H\n" +
+	"\rExchangeToken\x12\x1a.user.ExchangeTokenRequest\x1a\x1b.user.ExchangeTokenResponse\x12G\n" +
+	"\vVerifyToken\x12\x18.user.VerifyTokenRequest\x1a\x19.user.VerifyTokenResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x12ClaimGuestActivity\x12\x1f.user.ClaimGuestActivityRequest\x1a .user.ClaimGuestActivityResponse\"\x03\x90\x02\x02\x12I\n" +
+	"\x12CreateOrganization\x12\x1f.user.CreateOrganizationRequest\x1a\x12.user.Organization\x12H\n" +
+	"\x0fGetOrganization\x12\x1c.user.GetOrganizationRequest\x1a\x12.user.Organization\"\x03\x90\x02\x01\x12T\n" +
+	"\x15GetOrganizationByName\x12\".user.GetOrganizationByNameRequest\x1a\x12.user.Organization\"\x03\x90\x02\x01\x12`\n" +
+	"\x1bSetOrganizationEmailDomains\x12(.user.SetOrganizationEmailDomainsRequest\x1a\x12.user.Organization\"\x03\x90\x02\x02\x12X\n" +
+	"\x17SetOrganizationBranding\x12$.user.SetOrganizationBrandingRequest\x1a\x12.user.Organization\"\x03\x90\x02\x02\x12b\n" +
+	"\x1cSetOrganizationSessionPolicy\x12).user.SetOrganizationSessionPolicyRequest\x1a\x12.user.Organization\"\x03\x90\x02\x02\x12F\n" +
+	"\vImportUsers\x12\x18.user.ImportUsersRequest\x1a\x19.user.ImportUsersResponse(\x010\x01\x12P\n" +
+	"\x15CompletePasswordSetup\x12\".user.CompletePasswordSetupRequest\x1a\x13.user.LoginResponse\x12F\n" +
+	"\x10LoginWithIDToken\x12\x1d.user.LoginWithIDTokenRequest\x1a\x13.user.LoginResponse\x12T\n" +
+	"\x0fBatchAssignRole\x12\x1c.user.BatchAssignRoleRequest\x1a\x1e.user.BatchUserResultsResponse\"\x03\x90\x02\x02\x12X\n" +
+	"\x11BatchUpdateStatus\x12\x1e.user.BatchUpdateStatusRequest\x1a\x1e.user.BatchUserResultsResponse\"\x03\x90\x02\x02\x12P\n" +
+	"\x0eGetUserHistory\x12\x1b.user.GetUserHistoryRequest\x1a\x1c.user.GetUserHistoryResponse\"\x03\x90\x02\x01\x12A\n" +
+	"\tListUsers\x12\x16.user.ListUsersRequest\x1a\x17.user.ListUsersResponse\"\x03\x90\x02\x01\x12T\n" +
+	"\x11PromoteSigningKey\x12\x1e.user.PromoteSigningKeyRequest\x1a\x1f.user.PromoteSigningKeyResponse\x12S\n" +
+	"\x0fSetUserMetadata\x12\x1c.user.SetUserMetadataRequest\x1a\x1d.user.SetUserMetadataResponse\"\x03\x90\x02\x02\x12h\n" +
+	"\x16RequestAvatarUploadURL\x12#.user.RequestAvatarUploadURLRequest\x1a$.user.RequestAvatarUploadURLResponse\"\x03\x90\x02\x01\x12M\n" +
+	"\rConfirmAvatar\x12\x1a.user.ConfirmAvatarRequest\x1a\x1b.user.ConfirmAvatarResponse\"\x03\x90\x02\x02\x12I\n" +
+	"\x0eExportSnapshot\x12\x1b.user.ExportSnapshotRequest\x1a\x13.user.SnapshotChunk\"\x03\x90\x02\x010\x01\x12P\n" +
+	"\x0fRestoreSnapshot\x12\x1c.user.RestoreSnapshotRequest\x1a\x1d.user.RestoreSnapshotResponse(\x01\x12<\n" +
+	"\tWatchUser\x12\x16.user.WatchUserRequest\x1a\x10.user.UserUpdate\"\x03\x90\x02\x010\x01\x12d\n" +
+	"\x14CleanupRefreshTokens\x12!.user.CleanupRefreshTokensRequest\x1a\".user.CleanupRefreshTokensProgress\"\x03\x90\x02\x020\x01\x12Y\n" +
+	"\x11RegisterPushToken\x12\x1e.user.RegisterPushTokenRequest\x1a\x1f.user.RegisterPushTokenResponse\"\x03\x90\x02\x02\x12_\n" +
+	"\x13UnregisterPushToken\x12 .user.UnregisterPushTokenRequest\x1a!.user.UnregisterPushTokenResponse\"\x03\x90\x02\x02\x12K\n" +
+	"\x10SetLoginSchedule\x12\x1d.user.SetLoginScheduleRequest\x1a\x13.user.LoginSchedule\"\x03\x90\x02\x02\x12H\n" +
+	"\x10GetLoginSchedule\x12\x1a.user.LoginScheduleSubject\x1a\x13.user.LoginSchedule\"\x03\x90\x02\x01\x12Y\n" +
+	"\x13DeleteLoginSchedule\x12\x1a.user.LoginScheduleSubject\x1a!.user.DeleteLoginScheduleResponse\"\x03\x90\x02\x02\x12H\n" +
+	"\x0fSetVelocityRule\x12\x1c.user.SetVelocityRuleRequest\x1a\x12.user.VelocityRule\"\x03\x90\x02\x02\x12Y\n" +
+	"\x11ListVelocityRules\x12\x1e.user.ListVelocityRulesRequest\x1a\x1f.user.ListVelocityRulesResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x12DeleteVelocityRule\x12\x1f.user.DeleteVelocityRuleRequest\x1a .user.DeleteVelocityRuleResponse\"\x03\x90\x02\x02\x12K\n" +
+	"\x10SetCanaryAccount\x12\x1d.user.SetCanaryAccountRequest\x1a\x13.user.CanaryAccount\"\x03\x90\x02\x02\x12\\\n" +
+	"\x12ListCanaryAccounts\x12\x1f.user.ListCanaryAccountsRequest\x1a .user.ListCanaryAccountsResponse\"\x03\x90\x02\x01\x12_\n" +
+	"\x13DeleteCanaryAccount\x12 .user.DeleteCanaryAccountRequest\x1a!.user.DeleteCanaryAccountResponse\"\x03\x90\x02\x02\x12E\n" +
+	"\fGlobalLogout\x12\x19.user.GlobalLogoutRequest\x1a\x1a.user.GlobalLogoutResponse\x12e\n" +
+	"\x15ListAuthorizedClients\x12\".user.ListAuthorizedClientsRequest\x1a#.user.ListAuthorizedClientsResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x12RevokeClientAccess\x12\x1f.user.RevokeClientAccessRequest\x1a .user.RevokeClientAccessResponse\"\x03\x90\x02\x02\x12S\n" +
+	"\x11ExportOrgAuditLog\x12\x1e.user.ExportOrgAuditLogRequest\x1a\x1c.user.ExportOrgAuditLogChunk0\x01\x127\n" +
+	"\vAddUserNote\x12\x18.user.AddUserNoteRequest\x1a\x0e.user.UserNote\x12M\n" +
+	"\rListUserNotes\x12\x1a.user.ListUserNotesRequest\x1a\x1b.user.ListUserNotesResponse\"\x03\x90\x02\x01\x12W\n" +
+	"\x14GetRegistrationGates\x12!.user.GetRegistrationGatesRequest\x1a\x17.user.RegistrationGates\"\x03\x90\x02\x01\x12W\n" +
+	"\x14SetRegistrationGates\x12!.user.SetRegistrationGatesRequest\x1a\x17.user.RegistrationGates\"\x03\x90\x02\x02\x12S\n" +
+	"\x0fGetLoginHistory\x12\x1c.user.GetLoginHistoryRequest\x1a\x1d.user.GetLoginHistoryResponse\"\x03\x90\x02\x01B\x13Z\x11user-svc/pb/v1;pbb\x06proto3"
+
+var (
+	file_v1_user_svc_proto_rawDescOnce sync.Once
+	file_v1_user_svc_proto_rawDescData []byte
+)
+
+func file_v1_user_svc_proto_rawDescGZIP() []byte {
+	file_v1_user_svc_proto_rawDescOnce.Do(func() {
+		file_v1_user_svc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v1_user_svc_proto_rawDesc), len(file_v1_user_svc_proto_rawDesc)))
+	})
+	return file_v1_user_svc_proto_rawDescData
+}
+
+var file_v1_user_svc_proto_msgTypes = make([]protoimpl.MessageInfo, 127)
+var file_v1_user_svc_proto_goTypes = []any{
+	(*User)(nil),                                 // 0: user.User
+	(*RegisterRequest)(nil),                      // 1: user.RegisterRequest
+	(*RegisterResponse)(nil),                     // 2: user.RegisterResponse
+	(*LoginRequest)(nil),                         // 3: user.LoginRequest
+	(*LoginResponse)(nil),                        // 4: user.LoginResponse
+	(*RefreshTokenRequest)(nil),                  // 5: user.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),                 // 6: user.RefreshTokenResponse
+	(*GetQuotaUsageRequest)(nil),                 // 7: user.GetQuotaUsageRequest
+	(*QuotaUsage)(nil),                           // 8: user.QuotaUsage
+	(*GetQuotaUsageResponse)(nil),                // 9: user.GetQuotaUsageResponse
+	(*GetSLOStatusRequest)(nil),                  // 10: user.GetSLOStatusRequest
+	(*SLOStatus)(nil),                            // 11: user.SLOStatus
+	(*GetSLOStatusResponse)(nil),                 // 12: user.GetSLOStatusResponse
+	(*RevokeAllUserTokensRequest)(nil),           // 13: user.RevokeAllUserTokensRequest
+	(*RevokeAllUserTokensResponse)(nil),          // 14: user.RevokeAllUserTokensResponse
+	(*GetAccountActivitySummaryRequest)(nil),     // 15: user.GetAccountActivitySummaryRequest
+	(*DeviceActivity)(nil),                       // 16: user.DeviceActivity
+	(*GetAccountActivitySummaryResponse)(nil),    // 17: user.GetAccountActivitySummaryResponse
+	(*PreviewEmailTemplateRequest)(nil),          // 18: user.PreviewEmailTemplateRequest
+	(*PreviewEmailTemplateResponse)(nil),         // 19: user.PreviewEmailTemplateResponse
+	(*NotificationPreference)(nil),               // 20: user.NotificationPreference
+	(*GetNotificationPreferencesRequest)(nil),    // 21: user.GetNotificationPreferencesRequest
+	(*UpdateNotificationPreferencesRequest)(nil), // 22: user.UpdateNotificationPreferencesRequest
+	(*NotificationPreferencesResponse)(nil),      // 23: user.NotificationPreferencesResponse
+	(*GetUserStatsRequest)(nil),                  // 24: user.GetUserStatsRequest
+	(*DailyUserStats)(nil),                       // 25: user.DailyUserStats
+	(*GetUserStatsResponse)(nil),                 // 26: user.GetUserStatsResponse
+	(*ExportUsersRequest)(nil),                   // 27: user.ExportUsersRequest
+	(*ExportedUser)(nil),                         // 28: user.ExportedUser
+	(*ExportUsersChunk)(nil),                     // 29: user.ExportUsersChunk
+	(*LegalHoldRequest)(nil),                     // 30: user.LegalHoldRequest
+	(*LegalHoldResponse)(nil),                    // 31: user.LegalHoldResponse
+	(*GetRiskSignalsRequest)(nil),                // 32: user.GetRiskSignalsRequest
+	(*GetRiskSignalsResponse)(nil),               // 33: user.GetRiskSignalsResponse
+	(*GetUserByUsernameRequest)(nil),             // 34: user.GetUserByUsernameRequest
+	(*BatchGetUsersRequest)(nil),                 // 35: user.BatchGetUsersRequest
+	(*BatchGetUsersResponse)(nil),                // 36: user.BatchGetUsersResponse
+	(*ExchangeTokenRequest)(nil),                 // 37: user.ExchangeTokenRequest
+	(*ExchangeTokenResponse)(nil),                // 38: user.ExchangeTokenResponse
+	(*VerifyTokenRequest)(nil),                   // 39: user.VerifyTokenRequest
+	(*VerifyTokenResponse)(nil),                  // 40: user.VerifyTokenResponse
+	(*ClaimGuestActivityRequest)(nil),            // 41: user.ClaimGuestActivityRequest
+	(*ClaimGuestActivityResponse)(nil),           // 42: user.ClaimGuestActivityResponse
+	(*Organization)(nil),                         // 43: user.Organization
+	(*OrganizationBranding)(nil),                 // 44: user.OrganizationBranding
+	(*CreateOrganizationRequest)(nil),            // 45: user.CreateOrganizationRequest
+	(*GetOrganizationRequest)(nil),               // 46: user.GetOrganizationRequest
+	(*GetOrganizationByNameRequest)(nil),         // 47: user.GetOrganizationByNameRequest
+	(*SetOrganizationEmailDomainsRequest)(nil),   // 48: user.SetOrganizationEmailDomainsRequest
+	(*SetOrganizationBrandingRequest)(nil),       // 49: user.SetOrganizationBrandingRequest
+	(*SetOrganizationSessionPolicyRequest)(nil),  // 50: user.SetOrganizationSessionPolicyRequest
+	(*ImportUserRecord)(nil),                     // 51: user.ImportUserRecord
+	(*LegacyPasswordHash)(nil),                   // 52: user.LegacyPasswordHash
+	(*ImportUsersRequest)(nil),                   // 53: user.ImportUsersRequest
+	(*ImportUserResult)(nil),                     // 54: user.ImportUserResult
+	(*ImportUsersResponse)(nil),                  // 55: user.ImportUsersResponse
+	(*CompletePasswordSetupRequest)(nil),         // 56: user.CompletePasswordSetupRequest
+	(*LoginWithIDTokenRequest)(nil),              // 57: user.LoginWithIDTokenRequest
+	(*BatchAssignRoleRequest)(nil),               // 58: user.BatchAssignRoleRequest
+	(*BatchUpdateStatusRequest)(nil),             // 59: user.BatchUpdateStatusRequest
+	(*BatchUserResult)(nil),                      // 60: user.BatchUserResult
+	(*BatchUserResultsResponse)(nil),             // 61: user.BatchUserResultsResponse
+	(*GetUserHistoryRequest)(nil),                // 62: user.GetUserHistoryRequest
+	(*UserEvent)(nil),                            // 63: user.UserEvent
+	(*GetUserHistoryResponse)(nil),               // 64: user.GetUserHistoryResponse
+	(*ListUsersRequest)(nil),                     // 65: user.ListUsersRequest
+	(*ListUsersResponse)(nil),                    // 66: user.ListUsersResponse
+	(*PromoteSigningKeyRequest)(nil),             // 67: user.PromoteSigningKeyRequest
+	(*PromoteSigningKeyResponse)(nil),            // 68: user.PromoteSigningKeyResponse
+	(*SetUserMetadataRequest)(nil),               // 69: user.SetUserMetadataRequest
+	(*SetUserMetadataResponse)(nil),              // 70: user.SetUserMetadataResponse
+	(*RequestAvatarUploadURLRequest)(nil),        // 71: user.RequestAvatarUploadURLRequest
+	(*RequestAvatarUploadURLResponse)(nil),       // 72: user.RequestAvatarUploadURLResponse
+	(*ConfirmAvatarRequest)(nil),                 // 73: user.ConfirmAvatarRequest
+	(*ConfirmAvatarResponse)(nil),                // 74: user.ConfirmAvatarResponse
+	(*ExportSnapshotRequest)(nil),                // 75: user.ExportSnapshotRequest
+	(*SnapshotChunk)(nil),                        // 76: user.SnapshotChunk
+	(*RestoreSnapshotRequest)(nil),               // 77: user.RestoreSnapshotRequest
+	(*RestoreSnapshotResponse)(nil),              // 78: user.RestoreSnapshotResponse
+	(*WatchUserRequest)(nil),                     // 79: user.WatchUserRequest
+	(*UserUpdate)(nil),                           // 80: user.UserUpdate
+	(*CleanupRefreshTokensRequest)(nil),          // 81: user.CleanupRefreshTokensRequest
+	(*CleanupRefreshTokensProgress)(nil),         // 82: user.CleanupRefreshTokensProgress
+	(*RegisterPushTokenRequest)(nil),             // 83: user.RegisterPushTokenRequest
+	(*RegisterPushTokenResponse)(nil),            // 84: user.RegisterPushTokenResponse
+	(*UnregisterPushTokenRequest)(nil),           // 85: user.UnregisterPushTokenRequest
+	(*UnregisterPushTokenResponse)(nil),          // 86: user.UnregisterPushTokenResponse
+	(*LoginScheduleSubject)(nil),                 // 87: user.LoginScheduleSubject
+	(*LoginWindow)(nil),                          // 88: user.LoginWindow
+	(*SetLoginScheduleRequest)(nil),              // 89: user.SetLoginScheduleRequest
+	(*LoginSchedule)(nil),                        // 90: user.LoginSchedule
+	(*DeleteLoginScheduleResponse)(nil),          // 91: user.DeleteLoginScheduleResponse
+	(*SetVelocityRuleRequest)(nil),               // 92: user.SetVelocityRuleRequest
+	(*VelocityRule)(nil),                         // 93: user.VelocityRule
+	(*ListVelocityRulesRequest)(nil),             // 94: user.ListVelocityRulesRequest
+	(*ListVelocityRulesResponse)(nil),            // 95: user.ListVelocityRulesResponse
+	(*DeleteVelocityRuleRequest)(nil),            // 96: user.DeleteVelocityRuleRequest
+	(*DeleteVelocityRuleResponse)(nil),           // 97: user.DeleteVelocityRuleResponse
+	(*SetCanaryAccountRequest)(nil),              // 98: user.SetCanaryAccountRequest
+	(*CanaryAccount)(nil),                        // 99: user.CanaryAccount
+	(*ListCanaryAccountsRequest)(nil),            // 100: user.ListCanaryAccountsRequest
+	(*ListCanaryAccountsResponse)(nil),           // 101: user.ListCanaryAccountsResponse
+	(*DeleteCanaryAccountRequest)(nil),           // 102: user.DeleteCanaryAccountRequest
+	(*DeleteCanaryAccountResponse)(nil),          // 103: user.DeleteCanaryAccountResponse
+	(*GlobalLogoutRequest)(nil),                  // 104: user.GlobalLogoutRequest
+	(*GlobalLogoutResponse)(nil),                 // 105: user.GlobalLogoutResponse
+	(*ListAuthorizedClientsRequest)(nil),         // 106: user.ListAuthorizedClientsRequest
+	(*AuthorizedClient)(nil),                     // 107: user.AuthorizedClient
+	(*ListAuthorizedClientsResponse)(nil),        // 108: user.ListAuthorizedClientsResponse
+	(*RevokeClientAccessRequest)(nil),            // 109: user.RevokeClientAccessRequest
+	(*RevokeClientAccessResponse)(nil),           // 110: user.RevokeClientAccessResponse
+	(*ExportOrgAuditLogRequest)(nil),             // 111: user.ExportOrgAuditLogRequest
+	(*AuditLogEntry)(nil),                        // 112: user.AuditLogEntry
+	(*ExportOrgAuditLogChunk)(nil),               // 113: user.ExportOrgAuditLogChunk
+	(*AddUserNoteRequest)(nil),                   // 114: user.AddUserNoteRequest
+	(*UserNote)(nil),                             // 115: user.UserNote
+	(*ListUserNotesRequest)(nil),                 // 116: user.ListUserNotesRequest
+	(*ListUserNotesResponse)(nil),                // 117: user.ListUserNotesResponse
+	(*GetRegistrationGatesRequest)(nil),          // 118: user.GetRegistrationGatesRequest
+	(*SetRegistrationGatesRequest)(nil),          // 119: user.SetRegistrationGatesRequest
+	(*RegistrationGates)(nil),                    // 120: user.RegistrationGates
+	(*GetLoginHistoryRequest)(nil),               // 121: user.GetLoginHistoryRequest
+	(*LoginAttempt)(nil),                         // 122: user.LoginAttempt
+	(*GetLoginHistoryResponse)(nil),              // 123: user.GetLoginHistoryResponse
+	nil,                                          // 124: user.BatchGetUsersResponse.UsersEntry
+	nil,                                          // 125: user.SetUserMetadataRequest.SetEntry
+	nil,                                          // 126: user.SetUserMetadataResponse.MetadataEntry
+}
+var file_v1_user_svc_proto_depIdxs = []int32{
+	0,   // 0: user.RegisterResponse.user:type_name -> user.User
+	0,   // 1: user.LoginResponse.user:type_name -> user.User
+	8,   // 2: user.GetQuotaUsageResponse.usages:type_name -> user.QuotaUsage
+	11,  // 3: user.GetSLOStatusResponse.statuses:type_name -> user.SLOStatus
+	16,  // 4: user.GetAccountActivitySummaryResponse.devices:type_name -> user.DeviceActivity
+	20,  // 5: user.UpdateNotificationPreferencesRequest.preferences:type_name -> user.NotificationPreference
+	20,  // 6: user.NotificationPreferencesResponse.preferences:type_name -> user.NotificationPreference
+	25,  // 7: user.GetUserStatsResponse.daily:type_name -> user.DailyUserStats
+	28,  // 8: user.ExportUsersChunk.users:type_name -> user.ExportedUser
+	124, // 9: user.BatchGetUsersResponse.users:type_name -> user.BatchGetUsersResponse.UsersEntry
+	44,  // 10: user.Organization.branding:type_name -> user.OrganizationBranding
+	44,  // 11: user.SetOrganizationBrandingRequest.branding:type_name -> user.OrganizationBranding
+	52,  // 12: user.ImportUserRecord.legacy_password:type_name -> user.LegacyPasswordHash
+	51,  // 13: user.ImportUsersRequest.users:type_name -> user.ImportUserRecord
+	54,  // 14: user.ImportUsersResponse.results:type_name -> user.ImportUserResult
+	60,  // 15: user.BatchUserResultsResponse.results:type_name -> user.BatchUserResult
+	63,  // 16: user.GetUserHistoryResponse.events:type_name -> user.UserEvent
+	0,   // 17: user.ListUsersResponse.users:type_name -> user.User
+	125, // 18: user.SetUserMetadataRequest.set:type_name -> user.SetUserMetadataRequest.SetEntry
+	126, // 19: user.SetUserMetadataResponse.metadata:type_name -> user.SetUserMetadataResponse.MetadataEntry
+	0,   // 20: user.UserUpdate.user:type_name -> user.User
+	87,  // 21: user.SetLoginScheduleRequest.subject:type_name -> user.LoginScheduleSubject
+	88,  // 22: user.SetLoginScheduleRequest.windows:type_name -> user.LoginWindow
+	87,  // 23: user.LoginSchedule.subject:type_name -> user.LoginScheduleSubject
+	88,  // 24: user.LoginSchedule.windows:type_name -> user.LoginWindow
+	93,  // 25: user.ListVelocityRulesResponse.rules:type_name -> user.VelocityRule
+	99,  // 26: user.ListCanaryAccountsResponse.accounts:type_name -> user.CanaryAccount
+	107, // 27: user.ListAuthorizedClientsResponse.clients:type_name -> user.AuthorizedClient
+	112, // 28: user.ExportOrgAuditLogChunk.entries:type_name -> user.AuditLogEntry
+	115, // 29: user.ListUserNotesResponse.notes:type_name -> user.UserNote
+	122, // 30: user.GetLoginHistoryResponse.attempts:type_name -> user.LoginAttempt
+	0,   // 31: user.BatchGetUsersResponse.UsersEntry.value:type_name -> user.User
+	1,   // 32: user.UserService.Register:input_type -> user.RegisterRequest
+	3,   // 33: user.UserService.Login:input_type -> user.LoginRequest
+	5,   // 34: user.UserService.RefreshToken:input_type -> user.RefreshTokenRequest
+	7,   // 35: user.UserService.GetQuotaUsage:input_type -> user.GetQuotaUsageRequest
+	10,  // 36: user.UserService.GetSLOStatus:input_type -> user.GetSLOStatusRequest
+	13,  // 37: user.UserService.RevokeAllUserTokens:input_type -> user.RevokeAllUserTokensRequest
+	15,  // 38: user.UserService.GetAccountActivitySummary:input_type -> user.GetAccountActivitySummaryRequest
+	18,  // 39: user.UserService.PreviewEmailTemplate:input_type -> user.PreviewEmailTemplateRequest
+	21,  // 40: user.UserService.GetNotificationPreferences:input_type -> user.GetNotificationPreferencesRequest
+	22,  // 41: user.UserService.UpdateNotificationPreferences:input_type -> user.UpdateNotificationPreferencesRequest
+	24,  // 42: user.UserService.GetUserStats:input_type -> user.GetUserStatsRequest
+	27,  // 43: user.UserService.ExportUsers:input_type -> user.ExportUsersRequest
+	30,  // 44: user.UserService.PlaceLegalHold:input_type -> user.LegalHoldRequest
+	30,  // 45: user.UserService.ReleaseLegalHold:input_type -> user.LegalHoldRequest
+	32,  // 46: user.UserService.GetRiskSignals:input_type -> user.GetRiskSignalsRequest
+	34,  // 47: user.UserService.GetUserByUsername:input_type -> user.GetUserByUsernameRequest
+	35,  // 48: user.UserService.BatchGetUsers:input_type -> user.BatchGetUsersRequest
+	37,  // 49: user.UserService.ExchangeToken:input_type -> user.ExchangeTokenRequest
+	39,  // 50: user.UserService.VerifyToken:input_type -> user.VerifyTokenRequest
+	41,  // 51: user.UserService.ClaimGuestActivity:input_type -> user.ClaimGuestActivityRequest
+	45,  // 52: user.UserService.CreateOrganization:input_type -> user.CreateOrganizationRequest
+	46,  // 53: user.UserService.GetOrganization:input_type -> user.GetOrganizationRequest
+	47,  // 54: user.UserService.GetOrganizationByName:input_type -> user.GetOrganizationByNameRequest
+	48,  // 55: user.UserService.SetOrganizationEmailDomains:input_type -> user.SetOrganizationEmailDomainsRequest
+	49,  // 56: user.UserService.SetOrganizationBranding:input_type -> user.SetOrganizationBrandingRequest
+	50,  // 57: user.UserService.SetOrganizationSessionPolicy:input_type -> user.SetOrganizationSessionPolicyRequest
+	53,  // 58: user.UserService.ImportUsers:input_type -> user.ImportUsersRequest
+	56,  // 59: user.UserService.CompletePasswordSetup:input_type -> user.CompletePasswordSetupRequest
+	57,  // 60: user.UserService.LoginWithIDToken:input_type -> user.LoginWithIDTokenRequest
+	58,  // 61: user.UserService.BatchAssignRole:input_type -> user.BatchAssignRoleRequest
+	59,  // 62: user.UserService.BatchUpdateStatus:input_type -> user.BatchUpdateStatusRequest
+	62,  // 63: user.UserService.GetUserHistory:input_type -> user.GetUserHistoryRequest
+	65,  // 64: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	67,  // 65: user.UserService.PromoteSigningKey:input_type -> user.PromoteSigningKeyRequest
+	69,  // 66: user.UserService.SetUserMetadata:input_type -> user.SetUserMetadataRequest
+	71,  // 67: user.UserService.RequestAvatarUploadURL:input_type -> user.RequestAvatarUploadURLRequest
+	73,  // 68: user.UserService.ConfirmAvatar:input_type -> user.ConfirmAvatarRequest
+	75,  // 69: user.UserService.ExportSnapshot:input_type -> user.ExportSnapshotRequest
+	77,  // 70: user.UserService.RestoreSnapshot:input_type -> user.RestoreSnapshotRequest
+	79,  // 71: user.UserService.WatchUser:input_type -> user.WatchUserRequest
+	81,  // 72: user.UserService.CleanupRefreshTokens:input_type -> user.CleanupRefreshTokensRequest
+	83,  // 73: user.UserService.RegisterPushToken:input_type -> user.RegisterPushTokenRequest
+	85,  // 74: user.UserService.UnregisterPushToken:input_type -> user.UnregisterPushTokenRequest
+	89,  // 75: user.UserService.SetLoginSchedule:input_type -> user.SetLoginScheduleRequest
+	87,  // 76: user.UserService.GetLoginSchedule:input_type -> user.LoginScheduleSubject
+	87,  // 77: user.UserService.DeleteLoginSchedule:input_type -> user.LoginScheduleSubject
+	92,  // 78: user.UserService.SetVelocityRule:input_type -> user.SetVelocityRuleRequest
+	94,  // 79: user.UserService.ListVelocityRules:input_type -> user.ListVelocityRulesRequest
+	96,  // 80: user.UserService.DeleteVelocityRule:input_type -> user.DeleteVelocityRuleRequest
+	98,  // 81: user.UserService.SetCanaryAccount:input_type -> user.SetCanaryAccountRequest
+	100, // 82: user.UserService.ListCanaryAccounts:input_type -> user.ListCanaryAccountsRequest
+	102, // 83: user.UserService.DeleteCanaryAccount:input_type -> user.DeleteCanaryAccountRequest
+	104, // 84: user.UserService.GlobalLogout:input_type -> user.GlobalLogoutRequest
+	106, // 85: user.UserService.ListAuthorizedClients:input_type -> user.ListAuthorizedClientsRequest
+	109, // 86: user.UserService.RevokeClientAccess:input_type -> user.RevokeClientAccessRequest
+	111, // 87: user.UserService.ExportOrgAuditLog:input_type -> user.ExportOrgAuditLogRequest
+	114, // 88: user.UserService.AddUserNote:input_type -> user.AddUserNoteRequest
+	116, // 89: user.UserService.ListUserNotes:input_type -> user.ListUserNotesRequest
+	118, // 90: user.UserService.GetRegistrationGates:input_type -> user.GetRegistrationGatesRequest
+	119, // 91: user.UserService.SetRegistrationGates:input_type -> user.SetRegistrationGatesRequest
+	121, // 92: user.UserService.GetLoginHistory:input_type -> user.GetLoginHistoryRequest
+	2,   // 93: user.UserService.Register:output_type -> user.RegisterResponse
+	4,   // 94: user.UserService.Login:output_type -> user.LoginResponse
+	6,   // 95: user.UserService.RefreshToken:output_type -> user.RefreshTokenResponse
+	9,   // 96: user.UserService.GetQuotaUsage:output_type -> user.GetQuotaUsageResponse
+	12,  // 97: user.UserService.GetSLOStatus:output_type -> user.GetSLOStatusResponse
+	14,  // 98: user.UserService.RevokeAllUserTokens:output_type -> user.RevokeAllUserTokensResponse
+	17,  // 99: user.UserService.GetAccountActivitySummary:output_type -> user.GetAccountActivitySummaryResponse
+	19,  // 100: user.UserService.PreviewEmailTemplate:output_type -> user.PreviewEmailTemplateResponse
+	23,  // 101: user.UserService.GetNotificationPreferences:output_type -> user.NotificationPreferencesResponse
+	23,  // 102: user.UserService.UpdateNotificationPreferences:output_type -> user.NotificationPreferencesResponse
+	26,  // 103: user.UserService.GetUserStats:output_type -> user.GetUserStatsResponse
+	29,  // 104: user.UserService.ExportUsers:output_type -> user.ExportUsersChunk
+	31,  // 105: user.UserService.PlaceLegalHold:output_type -> user.LegalHoldResponse
+	31,  // 106: user.UserService.ReleaseLegalHold:output_type -> user.LegalHoldResponse
+	33,  // 107: user.UserService.GetRiskSignals:output_type -> user.GetRiskSignalsResponse
+	0,   // 108: user.UserService.GetUserByUsername:output_type -> user.User
+	36,  // 109: user.UserService.BatchGetUsers:output_type -> user.BatchGetUsersResponse
+	38,  // 110: user.UserService.ExchangeToken:output_type -> user.ExchangeTokenResponse
+	40,  // 111: user.UserService.VerifyToken:output_type -> user.VerifyTokenResponse
+	42,  // 112: user.UserService.ClaimGuestActivity:output_type -> user.ClaimGuestActivityResponse
+	43,  // 113: user.UserService.CreateOrganization:output_type -> user.Organization
+	43,  // 114: user.UserService.GetOrganization:output_type -> user.Organization
+	43,  // 115: user.UserService.GetOrganizationByName:output_type -> user.Organization
+	43,  // 116: user.UserService.SetOrganizationEmailDomains:output_type -> user.Organization
+	43,  // 117: user.UserService.SetOrganizationBranding:output_type -> user.Organization
+	43,  // 118: user.UserService.SetOrganizationSessionPolicy:output_type -> user.Organization
+	55,  // 119: user.UserService.ImportUsers:output_type -> user.ImportUsersResponse
+	4,   // 120: user.UserService.CompletePasswordSetup:output_type -> user.LoginResponse
+	4,   // 121: user.UserService.LoginWithIDToken:output_type -> user.LoginResponse
+	61,  // 122: user.UserService.BatchAssignRole:output_type -> user.BatchUserResultsResponse
+	61,  // 123: user.UserService.BatchUpdateStatus:output_type -> user.BatchUserResultsResponse
+	64,  // 124: user.UserService.GetUserHistory:output_type -> user.GetUserHistoryResponse
+	66,  // 125: user.UserService.ListUsers:output_type -> user.ListUsersResponse
+	68,  // 126: user.UserService.PromoteSigningKey:output_type -> user.PromoteSigningKeyResponse
+	70,  // 127: user.UserService.SetUserMetadata:output_type -> user.SetUserMetadataResponse
+	72,  // 128: user.UserService.RequestAvatarUploadURL:output_type -> user.RequestAvatarUploadURLResponse
+	74,  // 129: user.UserService.ConfirmAvatar:output_type -> user.ConfirmAvatarResponse
+	76,  // 130: user.UserService.ExportSnapshot:output_type -> user.SnapshotChunk
+	78,  // 131: user.UserService.RestoreSnapshot:output_type -> user.RestoreSnapshotResponse
+	80,  // 132: user.UserService.WatchUser:output_type -> user.UserUpdate
+	82,  // 133: user.UserService.CleanupRefreshTokens:output_type -> user.CleanupRefreshTokensProgress
+	84,  // 134: user.UserService.RegisterPushToken:output_type -> user.RegisterPushTokenResponse
+	86,  // 135: user.UserService.UnregisterPushToken:output_type -> user.UnregisterPushTokenResponse
+	90,  // 136: user.UserService.SetLoginSchedule:output_type -> user.LoginSchedule
+	90,  // 137: user.UserService.GetLoginSchedule:output_type -> user.LoginSchedule
+	91,  // 138: user.UserService.DeleteLoginSchedule:output_type -> user.DeleteLoginScheduleResponse
+	93,  // 139: user.UserService.SetVelocityRule:output_type -> user.VelocityRule
+	95,  // 140: user.UserService.ListVelocityRules:output_type -> user.ListVelocityRulesResponse
+	97,  // 141: user.UserService.DeleteVelocityRule:output_type -> user.DeleteVelocityRuleResponse
+	99,  // 142: user.UserService.SetCanaryAccount:output_type -> user.CanaryAccount
+	101, // 143: user.UserService.ListCanaryAccounts:output_type -> user.ListCanaryAccountsResponse
+	103, // 144: user.UserService.DeleteCanaryAccount:output_type -> user.DeleteCanaryAccountResponse
+	105, // 145: user.UserService.GlobalLogout:output_type -> user.GlobalLogoutResponse
+	108, // 146: user.UserService.ListAuthorizedClients:output_type -> user.ListAuthorizedClientsResponse
+	110, // 147: user.UserService.RevokeClientAccess:output_type -> user.RevokeClientAccessResponse
+	113, // 148: user.UserService.ExportOrgAuditLog:output_type -> user.ExportOrgAuditLogChunk
+	115, // 149: user.UserService.AddUserNote:output_type -> user.UserNote
+	117, // 150: user.UserService.ListUserNotes:output_type -> user.ListUserNotesResponse
+	120, // 151: user.UserService.GetRegistrationGates:output_type -> user.RegistrationGates
+	120, // 152: user.UserService.SetRegistrationGates:output_type -> user.RegistrationGates
+	123, // 153: user.UserService.GetLoginHistory:output_type -> user.GetLoginHistoryResponse
+	93,  // [93:154] is the sub-list for method output_type
+	32,  // [32:93] is the sub-list for method input_type
+	32,  // [32:32] is the sub-list for extension type_name
+	32,  // [32:32] is the sub-list for extension extendee
+	0,   // [0:32] is the sub-list for field type_name
+}
+
+func init() { file_v1_user_svc_proto_init() }
+func file_v1_user_svc_proto_init() {
+	if File_v1_user_svc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_user_svc_proto_rawDesc), len(file_v1_user_svc_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   127,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v1_user_svc_proto_goTypes,
+		DependencyIndexes: file_v1_user_svc_proto_depIdxs,
+		MessageInfos:      file_v1_user_svc_proto_msgTypes,
+	}.Build()
+	File_v1_user_svc_proto = out.File
+	file_v1_user_svc_proto_goTypes = nil
+	file_v1_user_svc_proto_depIdxs = nil
+}
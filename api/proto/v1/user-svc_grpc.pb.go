@@ -0,0 +1,2748 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: v1/user-svc.proto
+
+package pb
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UserService_Register_FullMethodName                      = "/user.UserService/Register"
+	UserService_Login_FullMethodName                         = "/user.UserService/Login"
+	UserService_RefreshToken_FullMethodName                  = "/user.UserService/RefreshToken"
+	UserService_GetQuotaUsage_FullMethodName                 = "/user.UserService/GetQuotaUsage"
+	UserService_GetSLOStatus_FullMethodName                  = "/user.UserService/GetSLOStatus"
+	UserService_RevokeAllUserTokens_FullMethodName           = "/user.UserService/RevokeAllUserTokens"
+	UserService_GetAccountActivitySummary_FullMethodName     = "/user.UserService/GetAccountActivitySummary"
+	UserService_PreviewEmailTemplate_FullMethodName          = "/user.UserService/PreviewEmailTemplate"
+	UserService_GetNotificationPreferences_FullMethodName    = "/user.UserService/GetNotificationPreferences"
+	UserService_UpdateNotificationPreferences_FullMethodName = "/user.UserService/UpdateNotificationPreferences"
+	UserService_GetUserStats_FullMethodName                  = "/user.UserService/GetUserStats"
+	UserService_ExportUsers_FullMethodName                   = "/user.UserService/ExportUsers"
+	UserService_PlaceLegalHold_FullMethodName                = "/user.UserService/PlaceLegalHold"
+	UserService_ReleaseLegalHold_FullMethodName              = "/user.UserService/ReleaseLegalHold"
+	UserService_GetRiskSignals_FullMethodName                = "/user.UserService/GetRiskSignals"
+	UserService_GetUserByUsername_FullMethodName             = "/user.UserService/GetUserByUsername"
+	UserService_BatchGetUsers_FullMethodName                 = "/user.UserService/BatchGetUsers"
+	UserService_ExchangeToken_FullMethodName                 = "/user.UserService/ExchangeToken"
+	UserService_VerifyToken_FullMethodName                   = "/user.UserService/VerifyToken"
+	UserService_ClaimGuestActivity_FullMethodName            = "/user.UserService/ClaimGuestActivity"
+	UserService_CreateOrganization_FullMethodName            = "/user.UserService/CreateOrganization"
+	UserService_GetOrganization_FullMethodName               = "/user.UserService/GetOrganization"
+	UserService_GetOrganizationByName_FullMethodName         = "/user.UserService/GetOrganizationByName"
+	UserService_SetOrganizationEmailDomains_FullMethodName   = "/user.UserService/SetOrganizationEmailDomains"
+	UserService_SetOrganizationBranding_FullMethodName       = "/user.UserService/SetOrganizationBranding"
+	UserService_SetOrganizationSessionPolicy_FullMethodName  = "/user.UserService/SetOrganizationSessionPolicy"
+	UserService_ImportUsers_FullMethodName                   = "/user.UserService/ImportUsers"
+	UserService_CompletePasswordSetup_FullMethodName         = "/user.UserService/CompletePasswordSetup"
+	UserService_LoginWithIDToken_FullMethodName              = "/user.UserService/LoginWithIDToken"
+	UserService_BatchAssignRole_FullMethodName               = "/user.UserService/BatchAssignRole"
+	UserService_BatchUpdateStatus_FullMethodName             = "/user.UserService/BatchUpdateStatus"
+	UserService_GetUserHistory_FullMethodName                = "/user.UserService/GetUserHistory"
+	UserService_ListUsers_FullMethodName                     = "/user.UserService/ListUsers"
+	UserService_PromoteSigningKey_FullMethodName             = "/user.UserService/PromoteSigningKey"
+	UserService_SetUserMetadata_FullMethodName               = "/user.UserService/SetUserMetadata"
+	UserService_RequestAvatarUploadURL_FullMethodName        = "/user.UserService/RequestAvatarUploadURL"
+	UserService_ConfirmAvatar_FullMethodName                 = "/user.UserService/ConfirmAvatar"
+	UserService_ExportSnapshot_FullMethodName                = "/user.UserService/ExportSnapshot"
+	UserService_RestoreSnapshot_FullMethodName               = "/user.UserService/RestoreSnapshot"
+	UserService_WatchUser_FullMethodName                     = "/user.UserService/WatchUser"
+	UserService_CleanupRefreshTokens_FullMethodName          = "/user.UserService/CleanupRefreshTokens"
+	UserService_RegisterPushToken_FullMethodName             = "/user.UserService/RegisterPushToken"
+	UserService_UnregisterPushToken_FullMethodName           = "/user.UserService/UnregisterPushToken"
+	UserService_SetLoginSchedule_FullMethodName              = "/user.UserService/SetLoginSchedule"
+	UserService_GetLoginSchedule_FullMethodName              = "/user.UserService/GetLoginSchedule"
+	UserService_DeleteLoginSchedule_FullMethodName           = "/user.UserService/DeleteLoginSchedule"
+	UserService_SetVelocityRule_FullMethodName               = "/user.UserService/SetVelocityRule"
+	UserService_ListVelocityRules_FullMethodName             = "/user.UserService/ListVelocityRules"
+	UserService_DeleteVelocityRule_FullMethodName            = "/user.UserService/DeleteVelocityRule"
+	UserService_SetCanaryAccount_FullMethodName              = "/user.UserService/SetCanaryAccount"
+	UserService_ListCanaryAccounts_FullMethodName            = "/user.UserService/ListCanaryAccounts"
+	UserService_DeleteCanaryAccount_FullMethodName           = "/user.UserService/DeleteCanaryAccount"
+	UserService_GlobalLogout_FullMethodName                  = "/user.UserService/GlobalLogout"
+	UserService_ListAuthorizedClients_FullMethodName         = "/user.UserService/ListAuthorizedClients"
+	UserService_RevokeClientAccess_FullMethodName            = "/user.UserService/RevokeClientAccess"
+	UserService_ExportOrgAuditLog_FullMethodName             = "/user.UserService/ExportOrgAuditLog"
+	UserService_AddUserNote_FullMethodName                   = "/user.UserService/AddUserNote"
+	UserService_ListUserNotes_FullMethodName                 = "/user.UserService/ListUserNotes"
+	UserService_GetRegistrationGates_FullMethodName          = "/user.UserService/GetRegistrationGates"
+	UserService_SetRegistrationGates_FullMethodName          = "/user.UserService/SetRegistrationGates"
+	UserService_GetLoginHistory_FullMethodName               = "/user.UserService/GetLoginHistory"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// UserService provides user authentication and management functionality.
+//
+// Retries: clients should dial with the service config published in the user-svc/pkg/client
+// package. It retries the methods marked NO_SIDE_EFFECTS or IDEMPOTENT below on UNAVAILABLE,
+// and hedges GetRiskSignals, which sits on the purchase path. Methods without an idempotency
+// level, e.g. Login or RefreshToken, must not be retried blindly: a retry may create a second
+// session or spend a refresh token that was already rotated.
+type UserServiceClient interface {
+	// Deprecated: Do not use.
+	// Register creates a new user account
+	// Returns user information, access token, and refresh token on success
+	// Superseded by user.v2.UserService/Register
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	// Deprecated: Do not use.
+	// Login authenticates an existing user
+	// Returns user information, access token, and refresh token on success
+	// Superseded by user.v2.UserService/Login
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// Deprecated: Do not use.
+	// RefreshToken exchanges a refresh token for a new access token and refresh token pair
+	// Returns new access token and refresh token on success
+	// Superseded by user.v2.UserService/RefreshToken
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	// GetQuotaUsage returns the calling API key's or user's quota consumption
+	// for the current quota window
+	GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error)
+	// GetSLOStatus returns the rolling SLO compliance and remaining error budget per method
+	GetSLOStatus(ctx context.Context, in *GetSLOStatusRequest, opts ...grpc.CallOption) (*GetSLOStatusResponse, error)
+	// RevokeAllUserTokens revokes every refresh and access token of the calling user
+	// on all replicas
+	RevokeAllUserTokens(ctx context.Context, in *RevokeAllUserTokensRequest, opts ...grpc.CallOption) (*RevokeAllUserTokensResponse, error)
+	// GetAccountActivitySummary summarizes the calling user's logins, devices and
+	// credential changes over the last 30 days
+	GetAccountActivitySummary(ctx context.Context, in *GetAccountActivitySummaryRequest, opts ...grpc.CallOption) (*GetAccountActivitySummaryResponse, error)
+	// PreviewEmailTemplate renders a transactional email template for a locale without
+	// sending it. Requires an admin API key in the x-admin-key metadata.
+	PreviewEmailTemplate(ctx context.Context, in *PreviewEmailTemplateRequest, opts ...grpc.CallOption) (*PreviewEmailTemplateResponse, error)
+	// GetNotificationPreferences returns whether the calling user receives each event type
+	// on each channel
+	GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferencesResponse, error)
+	// UpdateNotificationPreferences opts the calling user in or out of channels per event type
+	UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferencesResponse, error)
+	// GetUserStats returns user totals and daily registrations and logins for the ops
+	// dashboard. Requires an admin API key in the x-admin-key metadata.
+	GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*GetUserStatsResponse, error)
+	// ExportUsers streams the users matching the filters in chunks, as messages or NDJSON,
+	// for analytics. Requires an admin API key in the x-admin-key metadata.
+	ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUsersChunk], error)
+	// PlaceLegalHold keeps a user from being deleted or anonymized until the hold is released.
+	// Requires an admin API key in the x-admin-key metadata.
+	PlaceLegalHold(ctx context.Context, in *LegalHoldRequest, opts ...grpc.CallOption) (*LegalHoldResponse, error)
+	// ReleaseLegalHold releases a user's legal hold. Requires an admin API key in the x-admin-key metadata.
+	ReleaseLegalHold(ctx context.Context, in *LegalHoldRequest, opts ...grpc.CallOption) (*LegalHoldResponse, error)
+	// GetRiskSignals returns aggregated fraud signals of a user for scoring purchasers.
+	// Requires a service API key with the risk_signals scope in the x-service-key metadata.
+	GetRiskSignals(ctx context.Context, in *GetRiskSignalsRequest, opts ...grpc.CallOption) (*GetRiskSignalsResponse, error)
+	// GetUserByUsername returns the user with a username, FAILED_PRECONDITION when more than one
+	// user has it. Requires a service API key with the users:read scope in the x-service-key metadata.
+	GetUserByUsername(ctx context.Context, in *GetUserByUsernameRequest, opts ...grpc.CallOption) (*User, error)
+	// BatchGetUsers returns up to 500 users by ID in one call, keyed by ID; IDs of users that do not
+	// exist are left out. Requires a service API key with the users:read scope in the x-service-key
+	// metadata.
+	BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error)
+	// ExchangeToken trades the access token of a user for an RFC 8693 delegation token the calling
+	// service presents to the audience service on behalf of the user. Requires a service API key
+	// with the tokens:exchange scope in the x-service-key metadata.
+	ExchangeToken(ctx context.Context, in *ExchangeTokenRequest, opts ...grpc.CallOption) (*ExchangeTokenResponse, error)
+	// VerifyToken verifies the access token of a user, revocations included, and returns its claims,
+	// UNAUTHENTICATED when it is invalid, expired or revoked. Delegation tokens are only verified for
+	// the service of their audience. Verifying a token marks its session active, so users working on
+	// short-lived access tokens are not logged out by the session idle timeout. Requires a service
+	// API key with the tokens:verify scope in the x-service-key metadata.
+	VerifyToken(ctx context.Context, in *VerifyTokenRequest, opts ...grpc.CallOption) (*VerifyTokenResponse, error)
+	// ClaimGuestActivity confirms that a user verified owning an email, with a registration code or an
+	// invitation, so guest activity under the email, e.g. bookings, can be attached to the account, and
+	// marks it claimed; later calls return already_claimed. NOT_FOUND when the user did not verify the
+	// email. Requires a service API key with the guest_activity:claim scope in the x-service-key metadata.
+	ClaimGuestActivity(ctx context.Context, in *ClaimGuestActivityRequest, opts ...grpc.CallOption) (*ClaimGuestActivityResponse, error)
+	// CreateOrganization creates an organization for staff accounts.
+	// Requires an admin API key in the x-admin-key metadata.
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	// GetOrganization returns an organization. Requires an admin API key in the x-admin-key metadata.
+	GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	// GetOrganizationByName returns the oldest organization with a name, NOT_FOUND when none has it.
+	// Requires an admin API key in the x-admin-key metadata.
+	GetOrganizationByName(ctx context.Context, in *GetOrganizationByNameRequest, opts ...grpc.CallOption) (*Organization, error)
+	// SetOrganizationEmailDomains replaces the email domains staff accounts of an organization
+	// must register with. Requires an admin API key in the x-admin-key metadata.
+	SetOrganizationEmailDomains(ctx context.Context, in *SetOrganizationEmailDomainsRequest, opts ...grpc.CallOption) (*Organization, error)
+	// SetOrganizationBranding replaces the name, logo, support email and color the emails sent
+	// to members of an organization are branded with. Requires an admin API key in the
+	// x-admin-key metadata.
+	SetOrganizationBranding(ctx context.Context, in *SetOrganizationBrandingRequest, opts ...grpc.CallOption) (*Organization, error)
+	// SetOrganizationSessionPolicy replaces the idle timeout ending the sessions of members unused
+	// for longer, checked on refresh. Requires an admin API key in the x-admin-key metadata.
+	SetOrganizationSessionPolicy(ctx context.Context, in *SetOrganizationSessionPolicyRequest, opts ...grpc.CallOption) (*Organization, error)
+	// ImportUsers creates invited users from batches of records and streams back the result of
+	// every record. Invited users are emailed a link to set their password before logging in.
+	// Requires an admin API key in the x-admin-key metadata.
+	ImportUsers(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ImportUsersRequest, ImportUsersResponse], error)
+	// CompletePasswordSetup sets the first password of an invited user with the token from the
+	// invitation email and logs the user in
+	CompletePasswordSetup(ctx context.Context, in *CompletePasswordSetupRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// LoginWithIDToken logs a user in with the ID token of an OpenID Connect provider configured
+	// under oidc.providers, e.g. Apple, Google or a corporate SSO. Identities not linked to an
+	// account yet fail with FAILED_PRECONDITION, unless the provider links them to the account
+	// with the email it verified.
+	LoginWithIDToken(ctx context.Context, in *LoginWithIDTokenRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// BatchAssignRole gives up to the configured number of users a role in one transaction and
+	// reports the outcome per user. With dry_run the outcomes are computed in a transaction that
+	// is rolled back. Requires an admin API key in the x-admin-key metadata.
+	BatchAssignRole(ctx context.Context, in *BatchAssignRoleRequest, opts ...grpc.CallOption) (*BatchUserResultsResponse, error)
+	// BatchUpdateStatus bans or unbans up to the configured number of users in one transaction
+	// and reports the outcome per user. Banned users lose their sessions. With dry_run the outcomes
+	// are computed in a transaction that is rolled back.
+	// Requires an admin API key in the x-admin-key metadata.
+	BatchUpdateStatus(ctx context.Context, in *BatchUpdateStatusRequest, opts ...grpc.CallOption) (*BatchUserResultsResponse, error)
+	// GetUserHistory returns a page of the append-only event history of a user, oldest first.
+	// Deleted users keep their history. Requires an admin API key in the x-admin-key metadata.
+	GetUserHistory(ctx context.Context, in *GetUserHistoryRequest, opts ...grpc.CallOption) (*GetUserHistoryResponse, error)
+	// ListUsers returns a page of users ordered by creation, oldest first. Pages are keyed by the
+	// last user of the previous page, so they neither skip nor repeat users while others register.
+	// Requires an admin API key in the x-admin-key metadata.
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// PromoteSigningKey signs new tokens with the secondary JWT secret on every replica. Tokens
+	// signed with the previous secret stay valid while it is configured. Calling it again
+	// switches back, so it is never retried. Requires an admin API key in the x-admin-key metadata.
+	PromoteSigningKey(ctx context.Context, in *PromoteSigningKeyRequest, opts ...grpc.CallOption) (*PromoteSigningKeyResponse, error)
+	// SetUserMetadata sets and removes namespaced metadata keys of a user, e.g. crm.segment.
+	// Requires a service API key with the metadata:<namespace> scope of every key in the
+	// x-service-key metadata.
+	SetUserMetadata(ctx context.Context, in *SetUserMetadataRequest, opts ...grpc.CallOption) (*SetUserMetadataResponse, error)
+	// RequestAvatarUploadURL returns a pre-signed URL the calling user uploads a profile
+	// picture to with a PUT. The avatar changes once the upload is confirmed with ConfirmAvatar.
+	RequestAvatarUploadURL(ctx context.Context, in *RequestAvatarUploadURLRequest, opts ...grpc.CallOption) (*RequestAvatarUploadURLResponse, error)
+	// ConfirmAvatar makes an uploaded image the calling user's avatar once its content type and
+	// size are checked, and deletes the avatar it replaces
+	ConfirmAvatar(ctx context.Context, in *ConfirmAvatarRequest, opts ...grpc.CallOption) (*ConfirmAvatarResponse, error)
+	// ExportSnapshot streams a consistent snapshot of the organizations, users and sessions as
+	// an archive encrypted with the passphrase, for cloning environments and recovery drills.
+	// Requires an admin API key in the x-admin-key metadata.
+	ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SnapshotChunk], error)
+	// RestoreSnapshot restores an archive of ExportSnapshot into a database without users, in
+	// one transaction. The first message carries the passphrase. Requires an admin API key in
+	// the x-admin-key metadata.
+	RestoreSnapshot(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RestoreSnapshotRequest, RestoreSnapshotResponse], error)
+	// WatchUser streams the current state of the users, then their state each time it changes,
+	// until the caller cancels. Requires a service key with the users:watch scope in the
+	// x-service-key metadata.
+	WatchUser(ctx context.Context, in *WatchUserRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserUpdate], error)
+	// CleanupRefreshTokens deletes refresh tokens that expired or were revoked, of every user or
+	// of one, in batches and streams the progress after every batch. Cancelling keeps the batches
+	// deleted so far. Requires an admin API key in the x-admin-key metadata.
+	CleanupRefreshTokens(ctx context.Context, in *CleanupRefreshTokensRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CleanupRefreshTokensProgress], error)
+	// RegisterPushToken stores the APNs or FCM token a device of the calling user receives push
+	// notifications with, replacing the earlier token of the device, and announces it to
+	// notification-svc. The token is removed from any other user or device it was registered by.
+	RegisterPushToken(ctx context.Context, in *RegisterPushTokenRequest, opts ...grpc.CallOption) (*RegisterPushTokenResponse, error)
+	// UnregisterPushToken removes the push token of a device of the calling user, e.g. on logout.
+	// RevokeAllUserTokens removes the push tokens of every device of the user.
+	UnregisterPushToken(ctx context.Context, in *UnregisterPushTokenRequest, opts ...grpc.CallOption) (*UnregisterPushTokenResponse, error)
+	// SetLoginSchedule restricts when a user, or every user of a role, may log in and refresh
+	// tokens, replacing the earlier schedule. A user's own schedule takes precedence over their
+	// role's. Requires an admin API key in the x-admin-key metadata.
+	SetLoginSchedule(ctx context.Context, in *SetLoginScheduleRequest, opts ...grpc.CallOption) (*LoginSchedule, error)
+	// GetLoginSchedule returns the login schedule of a user or a role. Requires an admin API key
+	// in the x-admin-key metadata.
+	GetLoginSchedule(ctx context.Context, in *LoginScheduleSubject, opts ...grpc.CallOption) (*LoginSchedule, error)
+	// DeleteLoginSchedule lifts the login schedule of a user or a role. Requires an admin API key
+	// in the x-admin-key metadata.
+	DeleteLoginSchedule(ctx context.Context, in *LoginScheduleSubject, opts ...grpc.CallOption) (*DeleteLoginScheduleResponse, error)
+	// SetVelocityRule creates an anti-automation velocity rule of Register or Login, or replaces
+	// the rule of the same name. Once more than threshold attempts were made by the same IP, email
+	// or device within the window, further attempts are let through with an alert, only with a
+	// solved captcha, or refused until the window has passed. Every replica applies the rule within
+	// seconds. Requires an admin API key in the x-admin-key metadata.
+	SetVelocityRule(ctx context.Context, in *SetVelocityRuleRequest, opts ...grpc.CallOption) (*VelocityRule, error)
+	// ListVelocityRules returns every velocity rule, enabled or not, by name. Requires an admin
+	// API key in the x-admin-key metadata.
+	ListVelocityRules(ctx context.Context, in *ListVelocityRulesRequest, opts ...grpc.CallOption) (*ListVelocityRulesResponse, error)
+	// DeleteVelocityRule deletes a velocity rule. Requires an admin API key in the x-admin-key
+	// metadata.
+	DeleteVelocityRule(ctx context.Context, in *DeleteVelocityRuleRequest, opts ...grpc.CallOption) (*DeleteVelocityRuleResponse, error)
+	// SetCanaryAccount flags a user as a canary account for breach detection, or changes its note
+	// and tarpit. Any login attempt, refresh or access token use of the account then raises a
+	// critical alert, and logins and refreshes are held for a while when tarpit is set. Requires an
+	// admin API key in the x-admin-key metadata.
+	SetCanaryAccount(ctx context.Context, in *SetCanaryAccountRequest, opts ...grpc.CallOption) (*CanaryAccount, error)
+	// ListCanaryAccounts returns every canary account. Requires an admin API key in the
+	// x-admin-key metadata.
+	ListCanaryAccounts(ctx context.Context, in *ListCanaryAccountsRequest, opts ...grpc.CallOption) (*ListCanaryAccountsResponse, error)
+	// DeleteCanaryAccount removes the canary flag of a user. Requires an admin API key in the
+	// x-admin-key metadata.
+	DeleteCanaryAccount(ctx context.Context, in *DeleteCanaryAccountRequest, opts ...grpc.CallOption) (*DeleteCanaryAccountResponse, error)
+	// GlobalLogout is the kill switch for incidents such as a leaked signing key: every access
+	// and refresh token issued up to the cutoff is rejected on every replica within seconds, then
+	// the stored refresh tokens are revoked. The confirmation must be "log out every user" and the
+	// reason is recorded with the logout. Requires an admin API key in the x-admin-key metadata.
+	GlobalLogout(ctx context.Context, in *GlobalLogoutRequest, opts ...grpc.CallOption) (*GlobalLogoutResponse, error)
+	// ListAuthorizedClients lists the applications the calling user is signed in with, i.e.
+	// holds an active refresh token of, the most recently used first.
+	ListAuthorizedClients(ctx context.Context, in *ListAuthorizedClientsRequest, opts ...grpc.CallOption) (*ListAuthorizedClientsResponse, error)
+	// RevokeClientAccess signs the calling user out of every session of an application: its
+	// refresh tokens and, on every replica, its access tokens are revoked and a
+	// client_access_revoked event is published. Sessions of other applications are kept.
+	RevokeClientAccess(ctx context.Context, in *RevokeClientAccessRequest, opts ...grpc.CallOption) (*RevokeClientAccessResponse, error)
+	// ExportOrgAuditLog streams the audit trail of an organization's staff accounts in chunks,
+	// oldest first. Requires an admin API key in the x-admin-key metadata or the access token
+	// of an admin of the organization.
+	ExportOrgAuditLog(ctx context.Context, in *ExportOrgAuditLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportOrgAuditLogChunk], error)
+	// AddUserNote leaves a note of a support agent on a user, e.g. "refund promised on 5/3". Notes
+	// are never shown to the user; disclosable ones are handed out on subject access requests.
+	// Every call adds a note, so it is never retried. Requires an admin API key in the x-admin-key
+	// metadata.
+	AddUserNote(ctx context.Context, in *AddUserNoteRequest, opts ...grpc.CallOption) (*UserNote, error)
+	// ListUserNotes returns a page of the notes on a user, newest first. Requires an admin API key
+	// in the x-admin-key metadata.
+	ListUserNotes(ctx context.Context, in *ListUserNotesRequest, opts ...grpc.CallOption) (*ListUserNotesResponse, error)
+	// GetRegistrationGates returns the registration gates and the sign-ups of the current UTC day.
+	// Requires an admin API key in the x-admin-key metadata.
+	GetRegistrationGates(ctx context.Context, in *GetRegistrationGatesRequest, opts ...grpc.CallOption) (*RegistrationGates, error)
+	// SetRegistrationGates limits sign-ups during a soft launch, replacing the earlier gates:
+	// registrations can require one of the invite codes, be capped per UTC day, or be limited to
+	// allowlisted emails and domains. Every replica applies the gates from the next registration.
+	// Requires an admin API key in the x-admin-key metadata.
+	SetRegistrationGates(ctx context.Context, in *SetRegistrationGatesRequest, opts ...grpc.CallOption) (*RegistrationGates, error)
+	// GetLoginHistory returns a page of the password login attempts of a user, newest first,
+	// successful or not, with the IP address and user agent they came from. Users read their own
+	// history with their access token and may leave user_id empty; admins name the user and
+	// authenticate with an admin API key in the x-admin-key metadata.
+	GetLoginHistory(ctx context.Context, in *GetLoginHistoryRequest, opts ...grpc.CallOption) (*GetLoginHistoryResponse, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+// Deprecated: Do not use.
+func (c *userServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, UserService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Deprecated: Do not use.
+func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Deprecated: Do not use.
+func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetQuotaUsage(ctx context.Context, in *GetQuotaUsageRequest, opts ...grpc.CallOption) (*GetQuotaUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuotaUsageResponse)
+	err := c.cc.Invoke(ctx, UserService_GetQuotaUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetSLOStatus(ctx context.Context, in *GetSLOStatusRequest, opts ...grpc.CallOption) (*GetSLOStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSLOStatusResponse)
+	err := c.cc.Invoke(ctx, UserService_GetSLOStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeAllUserTokens(ctx context.Context, in *RevokeAllUserTokensRequest, opts ...grpc.CallOption) (*RevokeAllUserTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAllUserTokensResponse)
+	err := c.cc.Invoke(ctx, UserService_RevokeAllUserTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetAccountActivitySummary(ctx context.Context, in *GetAccountActivitySummaryRequest, opts ...grpc.CallOption) (*GetAccountActivitySummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccountActivitySummaryResponse)
+	err := c.cc.Invoke(ctx, UserService_GetAccountActivitySummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) PreviewEmailTemplate(ctx context.Context, in *PreviewEmailTemplateRequest, opts ...grpc.CallOption) (*PreviewEmailTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewEmailTemplateResponse)
+	err := c.cc.Invoke(ctx, UserService_PreviewEmailTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferencesResponse)
+	err := c.cc.Invoke(ctx, UserService_GetNotificationPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferencesResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateNotificationPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserStats(ctx context.Context, in *GetUserStatsRequest, opts ...grpc.CallOption) (*GetUserStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserStatsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUsersChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_ExportUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportUsersRequest, ExportUsersChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportUsersClient = grpc.ServerStreamingClient[ExportUsersChunk]
+
+func (c *userServiceClient) PlaceLegalHold(ctx context.Context, in *LegalHoldRequest, opts ...grpc.CallOption) (*LegalHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LegalHoldResponse)
+	err := c.cc.Invoke(ctx, UserService_PlaceLegalHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReleaseLegalHold(ctx context.Context, in *LegalHoldRequest, opts ...grpc.CallOption) (*LegalHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LegalHoldResponse)
+	err := c.cc.Invoke(ctx, UserService_ReleaseLegalHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetRiskSignals(ctx context.Context, in *GetRiskSignalsRequest, opts ...grpc.CallOption) (*GetRiskSignalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRiskSignalsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetRiskSignals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserByUsername(ctx context.Context, in *GetUserByUsernameRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GetUserByUsername_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchGetUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExchangeToken(ctx context.Context, in *ExchangeTokenRequest, opts ...grpc.CallOption) (*ExchangeTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExchangeTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_ExchangeToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) VerifyToken(ctx context.Context, in *VerifyTokenRequest, opts ...grpc.CallOption) (*VerifyTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_VerifyToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ClaimGuestActivity(ctx context.Context, in *ClaimGuestActivityRequest, opts ...grpc.CallOption) (*ClaimGuestActivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimGuestActivityResponse)
+	err := c.cc.Invoke(ctx, UserService_ClaimGuestActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, UserService_CreateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, UserService_GetOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetOrganizationByName(ctx context.Context, in *GetOrganizationByNameRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, UserService_GetOrganizationByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetOrganizationEmailDomains(ctx context.Context, in *SetOrganizationEmailDomainsRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, UserService_SetOrganizationEmailDomains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetOrganizationBranding(ctx context.Context, in *SetOrganizationBrandingRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, UserService_SetOrganizationBranding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetOrganizationSessionPolicy(ctx context.Context, in *SetOrganizationSessionPolicyRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, UserService_SetOrganizationSessionPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ImportUsers(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ImportUsersRequest, ImportUsersResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[1], UserService_ImportUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportUsersRequest, ImportUsersResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ImportUsersClient = grpc.BidiStreamingClient[ImportUsersRequest, ImportUsersResponse]
+
+func (c *userServiceClient) CompletePasswordSetup(ctx context.Context, in *CompletePasswordSetupRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_CompletePasswordSetup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) LoginWithIDToken(ctx context.Context, in *LoginWithIDTokenRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_LoginWithIDToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BatchAssignRole(ctx context.Context, in *BatchAssignRoleRequest, opts ...grpc.CallOption) (*BatchUserResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUserResultsResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchAssignRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BatchUpdateStatus(ctx context.Context, in *BatchUpdateStatusRequest, opts ...grpc.CallOption) (*BatchUserResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUserResultsResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchUpdateStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserHistory(ctx context.Context, in *GetUserHistoryRequest, opts ...grpc.CallOption) (*GetUserHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserHistoryResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) PromoteSigningKey(ctx context.Context, in *PromoteSigningKeyRequest, opts ...grpc.CallOption) (*PromoteSigningKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoteSigningKeyResponse)
+	err := c.cc.Invoke(ctx, UserService_PromoteSigningKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetUserMetadata(ctx context.Context, in *SetUserMetadataRequest, opts ...grpc.CallOption) (*SetUserMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserMetadataResponse)
+	err := c.cc.Invoke(ctx, UserService_SetUserMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RequestAvatarUploadURL(ctx context.Context, in *RequestAvatarUploadURLRequest, opts ...grpc.CallOption) (*RequestAvatarUploadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestAvatarUploadURLResponse)
+	err := c.cc.Invoke(ctx, UserService_RequestAvatarUploadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ConfirmAvatar(ctx context.Context, in *ConfirmAvatarRequest, opts ...grpc.CallOption) (*ConfirmAvatarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmAvatarResponse)
+	err := c.cc.Invoke(ctx, UserService_ConfirmAvatar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SnapshotChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[2], UserService_ExportSnapshot_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportSnapshotRequest, SnapshotChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportSnapshotClient = grpc.ServerStreamingClient[SnapshotChunk]
+
+func (c *userServiceClient) RestoreSnapshot(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RestoreSnapshotRequest, RestoreSnapshotResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[3], UserService_RestoreSnapshot_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RestoreSnapshotRequest, RestoreSnapshotResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_RestoreSnapshotClient = grpc.ClientStreamingClient[RestoreSnapshotRequest, RestoreSnapshotResponse]
+
+func (c *userServiceClient) WatchUser(ctx context.Context, in *WatchUserRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[4], UserService_WatchUser_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchUserRequest, UserUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_WatchUserClient = grpc.ServerStreamingClient[UserUpdate]
+
+func (c *userServiceClient) CleanupRefreshTokens(ctx context.Context, in *CleanupRefreshTokensRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CleanupRefreshTokensProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[5], UserService_CleanupRefreshTokens_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CleanupRefreshTokensRequest, CleanupRefreshTokensProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_CleanupRefreshTokensClient = grpc.ServerStreamingClient[CleanupRefreshTokensProgress]
+
+func (c *userServiceClient) RegisterPushToken(ctx context.Context, in *RegisterPushTokenRequest, opts ...grpc.CallOption) (*RegisterPushTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterPushTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_RegisterPushToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnregisterPushToken(ctx context.Context, in *UnregisterPushTokenRequest, opts ...grpc.CallOption) (*UnregisterPushTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnregisterPushTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_UnregisterPushToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetLoginSchedule(ctx context.Context, in *SetLoginScheduleRequest, opts ...grpc.CallOption) (*LoginSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginSchedule)
+	err := c.cc.Invoke(ctx, UserService_SetLoginSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetLoginSchedule(ctx context.Context, in *LoginScheduleSubject, opts ...grpc.CallOption) (*LoginSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginSchedule)
+	err := c.cc.Invoke(ctx, UserService_GetLoginSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteLoginSchedule(ctx context.Context, in *LoginScheduleSubject, opts ...grpc.CallOption) (*DeleteLoginScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteLoginScheduleResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteLoginSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetVelocityRule(ctx context.Context, in *SetVelocityRuleRequest, opts ...grpc.CallOption) (*VelocityRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VelocityRule)
+	err := c.cc.Invoke(ctx, UserService_SetVelocityRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListVelocityRules(ctx context.Context, in *ListVelocityRulesRequest, opts ...grpc.CallOption) (*ListVelocityRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVelocityRulesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListVelocityRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteVelocityRule(ctx context.Context, in *DeleteVelocityRuleRequest, opts ...grpc.CallOption) (*DeleteVelocityRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVelocityRuleResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteVelocityRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetCanaryAccount(ctx context.Context, in *SetCanaryAccountRequest, opts ...grpc.CallOption) (*CanaryAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CanaryAccount)
+	err := c.cc.Invoke(ctx, UserService_SetCanaryAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListCanaryAccounts(ctx context.Context, in *ListCanaryAccountsRequest, opts ...grpc.CallOption) (*ListCanaryAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCanaryAccountsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListCanaryAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteCanaryAccount(ctx context.Context, in *DeleteCanaryAccountRequest, opts ...grpc.CallOption) (*DeleteCanaryAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCanaryAccountResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteCanaryAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GlobalLogout(ctx context.Context, in *GlobalLogoutRequest, opts ...grpc.CallOption) (*GlobalLogoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GlobalLogoutResponse)
+	err := c.cc.Invoke(ctx, UserService_GlobalLogout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListAuthorizedClients(ctx context.Context, in *ListAuthorizedClientsRequest, opts ...grpc.CallOption) (*ListAuthorizedClientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuthorizedClientsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListAuthorizedClients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeClientAccess(ctx context.Context, in *RevokeClientAccessRequest, opts ...grpc.CallOption) (*RevokeClientAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeClientAccessResponse)
+	err := c.cc.Invoke(ctx, UserService_RevokeClientAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportOrgAuditLog(ctx context.Context, in *ExportOrgAuditLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportOrgAuditLogChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[6], UserService_ExportOrgAuditLog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportOrgAuditLogRequest, ExportOrgAuditLogChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportOrgAuditLogClient = grpc.ServerStreamingClient[ExportOrgAuditLogChunk]
+
+func (c *userServiceClient) AddUserNote(ctx context.Context, in *AddUserNoteRequest, opts ...grpc.CallOption) (*UserNote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserNote)
+	err := c.cc.Invoke(ctx, UserService_AddUserNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUserNotes(ctx context.Context, in *ListUserNotesRequest, opts ...grpc.CallOption) (*ListUserNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserNotesResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUserNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetRegistrationGates(ctx context.Context, in *GetRegistrationGatesRequest, opts ...grpc.CallOption) (*RegistrationGates, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationGates)
+	err := c.cc.Invoke(ctx, UserService_GetRegistrationGates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetRegistrationGates(ctx context.Context, in *SetRegistrationGatesRequest, opts ...grpc.CallOption) (*RegistrationGates, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationGates)
+	err := c.cc.Invoke(ctx, UserService_SetRegistrationGates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetLoginHistory(ctx context.Context, in *GetLoginHistoryRequest, opts ...grpc.CallOption) (*GetLoginHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLoginHistoryResponse)
+	err := c.cc.Invoke(ctx, UserService_GetLoginHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+//
+// UserService provides user authentication and management functionality.
+//
+// Retries: clients should dial with the service config published in the user-svc/pkg/client
+// package. It retries the methods marked NO_SIDE_EFFECTS or IDEMPOTENT below on UNAVAILABLE,
+// and hedges GetRiskSignals, which sits on the purchase path. Methods without an idempotency
+// level, e.g. Login or RefreshToken, must not be retried blindly: a retry may create a second
+// session or spend a refresh token that was already rotated.
+type UserServiceServer interface {
+	// Deprecated: Do not use.
+	// Register creates a new user account
+	// Returns user information, access token, and refresh token on success
+	// Superseded by user.v2.UserService/Register
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	// Deprecated: Do not use.
+	// Login authenticates an existing user
+	// Returns user information, access token, and refresh token on success
+	// Superseded by user.v2.UserService/Login
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// Deprecated: Do not use.
+	// RefreshToken exchanges a refresh token for a new access token and refresh token pair
+	// Returns new access token and refresh token on success
+	// Superseded by user.v2.UserService/RefreshToken
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	// GetQuotaUsage returns the calling API key's or user's quota consumption
+	// for the current quota window
+	GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error)
+	// GetSLOStatus returns the rolling SLO compliance and remaining error budget per method
+	GetSLOStatus(context.Context, *GetSLOStatusRequest) (*GetSLOStatusResponse, error)
+	// RevokeAllUserTokens revokes every refresh and access token of the calling user
+	// on all replicas
+	RevokeAllUserTokens(context.Context, *RevokeAllUserTokensRequest) (*RevokeAllUserTokensResponse, error)
+	// GetAccountActivitySummary summarizes the calling user's logins, devices and
+	// credential changes over the last 30 days
+	GetAccountActivitySummary(context.Context, *GetAccountActivitySummaryRequest) (*GetAccountActivitySummaryResponse, error)
+	// PreviewEmailTemplate renders a transactional email template for a locale without
+	// sending it. Requires an admin API key in the x-admin-key metadata.
+	PreviewEmailTemplate(context.Context, *PreviewEmailTemplateRequest) (*PreviewEmailTemplateResponse, error)
+	// GetNotificationPreferences returns whether the calling user receives each event type
+	// on each channel
+	GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferencesResponse, error)
+	// UpdateNotificationPreferences opts the calling user in or out of channels per event type
+	UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*NotificationPreferencesResponse, error)
+	// GetUserStats returns user totals and daily registrations and logins for the ops
+	// dashboard. Requires an admin API key in the x-admin-key metadata.
+	GetUserStats(context.Context, *GetUserStatsRequest) (*GetUserStatsResponse, error)
+	// ExportUsers streams the users matching the filters in chunks, as messages or NDJSON,
+	// for analytics. Requires an admin API key in the x-admin-key metadata.
+	ExportUsers(*ExportUsersRequest, grpc.ServerStreamingServer[ExportUsersChunk]) error
+	// PlaceLegalHold keeps a user from being deleted or anonymized until the hold is released.
+	// Requires an admin API key in the x-admin-key metadata.
+	PlaceLegalHold(context.Context, *LegalHoldRequest) (*LegalHoldResponse, error)
+	// ReleaseLegalHold releases a user's legal hold. Requires an admin API key in the x-admin-key metadata.
+	ReleaseLegalHold(context.Context, *LegalHoldRequest) (*LegalHoldResponse, error)
+	// GetRiskSignals returns aggregated fraud signals of a user for scoring purchasers.
+	// Requires a service API key with the risk_signals scope in the x-service-key metadata.
+	GetRiskSignals(context.Context, *GetRiskSignalsRequest) (*GetRiskSignalsResponse, error)
+	// GetUserByUsername returns the user with a username, FAILED_PRECONDITION when more than one
+	// user has it. Requires a service API key with the users:read scope in the x-service-key metadata.
+	GetUserByUsername(context.Context, *GetUserByUsernameRequest) (*User, error)
+	// BatchGetUsers returns up to 500 users by ID in one call, keyed by ID; IDs of users that do not
+	// exist are left out. Requires a service API key with the users:read scope in the x-service-key
+	// metadata.
+	BatchGetUsers(context.Context, *BatchGetUsersRequest) (*BatchGetUsersResponse, error)
+	// ExchangeToken trades the access token of a user for an RFC 8693 delegation token the calling
+	// service presents to the audience service on behalf of the user. Requires a service API key
+	// with the tokens:exchange scope in the x-service-key metadata.
+	ExchangeToken(context.Context, *ExchangeTokenRequest) (*ExchangeTokenResponse, error)
+	// VerifyToken verifies the access token of a user, revocations included, and returns its claims,
+	// UNAUTHENTICATED when it is invalid, expired or revoked. Delegation tokens are only verified for
+	// the service of their audience. Verifying a token marks its session active, so users working on
+	// short-lived access tokens are not logged out by the session idle timeout. Requires a service
+	// API key with the tokens:verify scope in the x-service-key metadata.
+	VerifyToken(context.Context, *VerifyTokenRequest) (*VerifyTokenResponse, error)
+	// ClaimGuestActivity confirms that a user verified owning an email, with a registration code or an
+	// invitation, so guest activity under the email, e.g. bookings, can be attached to the account, and
+	// marks it claimed; later calls return already_claimed. NOT_FOUND when the user did not verify the
+	// email. Requires a service API key with the guest_activity:claim scope in the x-service-key metadata.
+	ClaimGuestActivity(context.Context, *ClaimGuestActivityRequest) (*ClaimGuestActivityResponse, error)
+	// CreateOrganization creates an organization for staff accounts.
+	// Requires an admin API key in the x-admin-key metadata.
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*Organization, error)
+	// GetOrganization returns an organization. Requires an admin API key in the x-admin-key metadata.
+	GetOrganization(context.Context, *GetOrganizationRequest) (*Organization, error)
+	// GetOrganizationByName returns the oldest organization with a name, NOT_FOUND when none has it.
+	// Requires an admin API key in the x-admin-key metadata.
+	GetOrganizationByName(context.Context, *GetOrganizationByNameRequest) (*Organization, error)
+	// SetOrganizationEmailDomains replaces the email domains staff accounts of an organization
+	// must register with. Requires an admin API key in the x-admin-key metadata.
+	SetOrganizationEmailDomains(context.Context, *SetOrganizationEmailDomainsRequest) (*Organization, error)
+	// SetOrganizationBranding replaces the name, logo, support email and color the emails sent
+	// to members of an organization are branded with. Requires an admin API key in the
+	// x-admin-key metadata.
+	SetOrganizationBranding(context.Context, *SetOrganizationBrandingRequest) (*Organization, error)
+	// SetOrganizationSessionPolicy replaces the idle timeout ending the sessions of members unused
+	// for longer, checked on refresh. Requires an admin API key in the x-admin-key metadata.
+	SetOrganizationSessionPolicy(context.Context, *SetOrganizationSessionPolicyRequest) (*Organization, error)
+	// ImportUsers creates invited users from batches of records and streams back the result of
+	// every record. Invited users are emailed a link to set their password before logging in.
+	// Requires an admin API key in the x-admin-key metadata.
+	ImportUsers(grpc.BidiStreamingServer[ImportUsersRequest, ImportUsersResponse]) error
+	// CompletePasswordSetup sets the first password of an invited user with the token from the
+	// invitation email and logs the user in
+	CompletePasswordSetup(context.Context, *CompletePasswordSetupRequest) (*LoginResponse, error)
+	// LoginWithIDToken logs a user in with the ID token of an OpenID Connect provider configured
+	// under oidc.providers, e.g. Apple, Google or a corporate SSO. Identities not linked to an
+	// account yet fail with FAILED_PRECONDITION, unless the provider links them to the account
+	// with the email it verified.
+	LoginWithIDToken(context.Context, *LoginWithIDTokenRequest) (*LoginResponse, error)
+	// BatchAssignRole gives up to the configured number of users a role in one transaction and
+	// reports the outcome per user. With dry_run the outcomes are computed in a transaction that
+	// is rolled back. Requires an admin API key in the x-admin-key metadata.
+	BatchAssignRole(context.Context, *BatchAssignRoleRequest) (*BatchUserResultsResponse, error)
+	// BatchUpdateStatus bans or unbans up to the configured number of users in one transaction
+	// and reports the outcome per user. Banned users lose their sessions. With dry_run the outcomes
+	// are computed in a transaction that is rolled back.
+	// Requires an admin API key in the x-admin-key metadata.
+	BatchUpdateStatus(context.Context, *BatchUpdateStatusRequest) (*BatchUserResultsResponse, error)
+	// GetUserHistory returns a page of the append-only event history of a user, oldest first.
+	// Deleted users keep their history. Requires an admin API key in the x-admin-key metadata.
+	GetUserHistory(context.Context, *GetUserHistoryRequest) (*GetUserHistoryResponse, error)
+	// ListUsers returns a page of users ordered by creation, oldest first. Pages are keyed by the
+	// last user of the previous page, so they neither skip nor repeat users while others register.
+	// Requires an admin API key in the x-admin-key metadata.
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// PromoteSigningKey signs new tokens with the secondary JWT secret on every replica. Tokens
+	// signed with the previous secret stay valid while it is configured. Calling it again
+	// switches back, so it is never retried. Requires an admin API key in the x-admin-key metadata.
+	PromoteSigningKey(context.Context, *PromoteSigningKeyRequest) (*PromoteSigningKeyResponse, error)
+	// SetUserMetadata sets and removes namespaced metadata keys of a user, e.g. crm.segment.
+	// Requires a service API key with the metadata:<namespace> scope of every key in the
+	// x-service-key metadata.
+	SetUserMetadata(context.Context, *SetUserMetadataRequest) (*SetUserMetadataResponse, error)
+	// RequestAvatarUploadURL returns a pre-signed URL the calling user uploads a profile
+	// picture to with a PUT. The avatar changes once the upload is confirmed with ConfirmAvatar.
+	RequestAvatarUploadURL(context.Context, *RequestAvatarUploadURLRequest) (*RequestAvatarUploadURLResponse, error)
+	// ConfirmAvatar makes an uploaded image the calling user's avatar once its content type and
+	// size are checked, and deletes the avatar it replaces
+	ConfirmAvatar(context.Context, *ConfirmAvatarRequest) (*ConfirmAvatarResponse, error)
+	// ExportSnapshot streams a consistent snapshot of the organizations, users and sessions as
+	// an archive encrypted with the passphrase, for cloning environments and recovery drills.
+	// Requires an admin API key in the x-admin-key metadata.
+	ExportSnapshot(*ExportSnapshotRequest, grpc.ServerStreamingServer[SnapshotChunk]) error
+	// RestoreSnapshot restores an archive of ExportSnapshot into a database without users, in
+	// one transaction. The first message carries the passphrase. Requires an admin API key in
+	// the x-admin-key metadata.
+	RestoreSnapshot(grpc.ClientStreamingServer[RestoreSnapshotRequest, RestoreSnapshotResponse]) error
+	// WatchUser streams the current state of the users, then their state each time it changes,
+	// until the caller cancels. Requires a service key with the users:watch scope in the
+	// x-service-key metadata.
+	WatchUser(*WatchUserRequest, grpc.ServerStreamingServer[UserUpdate]) error
+	// CleanupRefreshTokens deletes refresh tokens that expired or were revoked, of every user or
+	// of one, in batches and streams the progress after every batch. Cancelling keeps the batches
+	// deleted so far. Requires an admin API key in the x-admin-key metadata.
+	CleanupRefreshTokens(*CleanupRefreshTokensRequest, grpc.ServerStreamingServer[CleanupRefreshTokensProgress]) error
+	// RegisterPushToken stores the APNs or FCM token a device of the calling user receives push
+	// notifications with, replacing the earlier token of the device, and announces it to
+	// notification-svc. The token is removed from any other user or device it was registered by.
+	RegisterPushToken(context.Context, *RegisterPushTokenRequest) (*RegisterPushTokenResponse, error)
+	// UnregisterPushToken removes the push token of a device of the calling user, e.g. on logout.
+	// RevokeAllUserTokens removes the push tokens of every device of the user.
+	UnregisterPushToken(context.Context, *UnregisterPushTokenRequest) (*UnregisterPushTokenResponse, error)
+	// SetLoginSchedule restricts when a user, or every user of a role, may log in and refresh
+	// tokens, replacing the earlier schedule. A user's own schedule takes precedence over their
+	// role's. Requires an admin API key in the x-admin-key metadata.
+	SetLoginSchedule(context.Context, *SetLoginScheduleRequest) (*LoginSchedule, error)
+	// GetLoginSchedule returns the login schedule of a user or a role. Requires an admin API key
+	// in the x-admin-key metadata.
+	GetLoginSchedule(context.Context, *LoginScheduleSubject) (*LoginSchedule, error)
+	// DeleteLoginSchedule lifts the login schedule of a user or a role. Requires an admin API key
+	// in the x-admin-key metadata.
+	DeleteLoginSchedule(context.Context, *LoginScheduleSubject) (*DeleteLoginScheduleResponse, error)
+	// SetVelocityRule creates an anti-automation velocity rule of Register or Login, or replaces
+	// the rule of the same name. Once more than threshold attempts were made by the same IP, email
+	// or device within the window, further attempts are let through with an alert, only with a
+	// solved captcha, or refused until the window has passed. Every replica applies the rule within
+	// seconds. Requires an admin API key in the x-admin-key metadata.
+	SetVelocityRule(context.Context, *SetVelocityRuleRequest) (*VelocityRule, error)
+	// ListVelocityRules returns every velocity rule, enabled or not, by name. Requires an admin
+	// API key in the x-admin-key metadata.
+	ListVelocityRules(context.Context, *ListVelocityRulesRequest) (*ListVelocityRulesResponse, error)
+	// DeleteVelocityRule deletes a velocity rule. Requires an admin API key in the x-admin-key
+	// metadata.
+	DeleteVelocityRule(context.Context, *DeleteVelocityRuleRequest) (*DeleteVelocityRuleResponse, error)
+	// SetCanaryAccount flags a user as a canary account for breach detection, or changes its note
+	// and tarpit. Any login attempt, refresh or access token use of the account then raises a
+	// critical alert, and logins and refreshes are held for a while when tarpit is set. Requires an
+	// admin API key in the x-admin-key metadata.
+	SetCanaryAccount(context.Context, *SetCanaryAccountRequest) (*CanaryAccount, error)
+	// ListCanaryAccounts returns every canary account. Requires an admin API key in the
+	// x-admin-key metadata.
+	ListCanaryAccounts(context.Context, *ListCanaryAccountsRequest) (*ListCanaryAccountsResponse, error)
+	// DeleteCanaryAccount removes the canary flag of a user. Requires an admin API key in the
+	// x-admin-key metadata.
+	DeleteCanaryAccount(context.Context, *DeleteCanaryAccountRequest) (*DeleteCanaryAccountResponse, error)
+	// GlobalLogout is the kill switch for incidents such as a leaked signing key: every access
+	// and refresh token issued up to the cutoff is rejected on every replica within seconds, then
+	// the stored refresh tokens are revoked. The confirmation must be "log out every user" and the
+	// reason is recorded with the logout. Requires an admin API key in the x-admin-key metadata.
+	GlobalLogout(context.Context, *GlobalLogoutRequest) (*GlobalLogoutResponse, error)
+	// ListAuthorizedClients lists the applications the calling user is signed in with, i.e.
+	// holds an active refresh token of, the most recently used first.
+	ListAuthorizedClients(context.Context, *ListAuthorizedClientsRequest) (*ListAuthorizedClientsResponse, error)
+	// RevokeClientAccess signs the calling user out of every session of an application: its
+	// refresh tokens and, on every replica, its access tokens are revoked and a
+	// client_access_revoked event is published. Sessions of other applications are kept.
+	RevokeClientAccess(context.Context, *RevokeClientAccessRequest) (*RevokeClientAccessResponse, error)
+	// ExportOrgAuditLog streams the audit trail of an organization's staff accounts in chunks,
+	// oldest first. Requires an admin API key in the x-admin-key metadata or the access token
+	// of an admin of the organization.
+	ExportOrgAuditLog(*ExportOrgAuditLogRequest, grpc.ServerStreamingServer[ExportOrgAuditLogChunk]) error
+	// AddUserNote leaves a note of a support agent on a user, e.g. "refund promised on 5/3". Notes
+	// are never shown to the user; disclosable ones are handed out on subject access requests.
+	// Every call adds a note, so it is never retried. Requires an admin API key in the x-admin-key
+	// metadata.
+	AddUserNote(context.Context, *AddUserNoteRequest) (*UserNote, error)
+	// ListUserNotes returns a page of the notes on a user, newest first. Requires an admin API key
+	// in the x-admin-key metadata.
+	ListUserNotes(context.Context, *ListUserNotesRequest) (*ListUserNotesResponse, error)
+	// GetRegistrationGates returns the registration gates and the sign-ups of the current UTC day.
+	// Requires an admin API key in the x-admin-key metadata.
+	GetRegistrationGates(context.Context, *GetRegistrationGatesRequest) (*RegistrationGates, error)
+	// SetRegistrationGates limits sign-ups during a soft launch, replacing the earlier gates:
+	// registrations can require one of the invite codes, be capped per UTC day, or be limited to
+	// allowlisted emails and domains. Every replica applies the gates from the next registration.
+	// Requires an admin API key in the x-admin-key metadata.
+	SetRegistrationGates(context.Context, *SetRegistrationGatesRequest) (*RegistrationGates, error)
+	// GetLoginHistory returns a page of the password login attempts of a user, newest first,
+	// successful or not, with the IP address and user agent they came from. Users read their own
+	// history with their access token and may leave user_id empty; admins name the user and
+	// authenticate with an admin API key in the x-admin-key metadata.
+	GetLoginHistory(context.Context, *GetLoginHistoryRequest) (*GetLoginHistoryResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedUserServiceServer) GetQuotaUsage(context.Context, *GetQuotaUsageRequest) (*GetQuotaUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuotaUsage not implemented")
+}
+func (UnimplementedUserServiceServer) GetSLOStatus(context.Context, *GetSLOStatusRequest) (*GetSLOStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSLOStatus not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeAllUserTokens(context.Context, *RevokeAllUserTokensRequest) (*RevokeAllUserTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllUserTokens not implemented")
+}
+func (UnimplementedUserServiceServer) GetAccountActivitySummary(context.Context, *GetAccountActivitySummaryRequest) (*GetAccountActivitySummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountActivitySummary not implemented")
+}
+func (UnimplementedUserServiceServer) PreviewEmailTemplate(context.Context, *PreviewEmailTemplateRequest) (*PreviewEmailTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewEmailTemplate not implemented")
+}
+func (UnimplementedUserServiceServer) GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationPreferences not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*NotificationPreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotificationPreferences not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserStats(context.Context, *GetUserStatsRequest) (*GetUserStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserStats not implemented")
+}
+func (UnimplementedUserServiceServer) ExportUsers(*ExportUsersRequest, grpc.ServerStreamingServer[ExportUsersChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsers not implemented")
+}
+func (UnimplementedUserServiceServer) PlaceLegalHold(context.Context, *LegalHoldRequest) (*LegalHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceLegalHold not implemented")
+}
+func (UnimplementedUserServiceServer) ReleaseLegalHold(context.Context, *LegalHoldRequest) (*LegalHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLegalHold not implemented")
+}
+func (UnimplementedUserServiceServer) GetRiskSignals(context.Context, *GetRiskSignalsRequest) (*GetRiskSignalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRiskSignals not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserByUsername(context.Context, *GetUserByUsernameRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByUsername not implemented")
+}
+func (UnimplementedUserServiceServer) BatchGetUsers(context.Context, *BatchGetUsersRequest) (*BatchGetUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetUsers not implemented")
+}
+func (UnimplementedUserServiceServer) ExchangeToken(context.Context, *ExchangeTokenRequest) (*ExchangeTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeToken not implemented")
+}
+func (UnimplementedUserServiceServer) VerifyToken(context.Context, *VerifyTokenRequest) (*VerifyTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyToken not implemented")
+}
+func (UnimplementedUserServiceServer) ClaimGuestActivity(context.Context, *ClaimGuestActivityRequest) (*ClaimGuestActivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimGuestActivity not implemented")
+}
+func (UnimplementedUserServiceServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (UnimplementedUserServiceServer) GetOrganization(context.Context, *GetOrganizationRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrganization not implemented")
+}
+func (UnimplementedUserServiceServer) GetOrganizationByName(context.Context, *GetOrganizationByNameRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrganizationByName not implemented")
+}
+func (UnimplementedUserServiceServer) SetOrganizationEmailDomains(context.Context, *SetOrganizationEmailDomainsRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOrganizationEmailDomains not implemented")
+}
+func (UnimplementedUserServiceServer) SetOrganizationBranding(context.Context, *SetOrganizationBrandingRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOrganizationBranding not implemented")
+}
+func (UnimplementedUserServiceServer) SetOrganizationSessionPolicy(context.Context, *SetOrganizationSessionPolicyRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOrganizationSessionPolicy not implemented")
+}
+func (UnimplementedUserServiceServer) ImportUsers(grpc.BidiStreamingServer[ImportUsersRequest, ImportUsersResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (UnimplementedUserServiceServer) CompletePasswordSetup(context.Context, *CompletePasswordSetupRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompletePasswordSetup not implemented")
+}
+func (UnimplementedUserServiceServer) LoginWithIDToken(context.Context, *LoginWithIDTokenRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginWithIDToken not implemented")
+}
+func (UnimplementedUserServiceServer) BatchAssignRole(context.Context, *BatchAssignRoleRequest) (*BatchUserResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchAssignRole not implemented")
+}
+func (UnimplementedUserServiceServer) BatchUpdateStatus(context.Context, *BatchUpdateStatusRequest) (*BatchUserResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateStatus not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserHistory(context.Context, *GetUserHistoryRequest) (*GetUserHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserHistory not implemented")
+}
+func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedUserServiceServer) PromoteSigningKey(context.Context, *PromoteSigningKeyRequest) (*PromoteSigningKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteSigningKey not implemented")
+}
+func (UnimplementedUserServiceServer) SetUserMetadata(context.Context, *SetUserMetadataRequest) (*SetUserMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserMetadata not implemented")
+}
+func (UnimplementedUserServiceServer) RequestAvatarUploadURL(context.Context, *RequestAvatarUploadURLRequest) (*RequestAvatarUploadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAvatarUploadURL not implemented")
+}
+func (UnimplementedUserServiceServer) ConfirmAvatar(context.Context, *ConfirmAvatarRequest) (*ConfirmAvatarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmAvatar not implemented")
+}
+func (UnimplementedUserServiceServer) ExportSnapshot(*ExportSnapshotRequest, grpc.ServerStreamingServer[SnapshotChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportSnapshot not implemented")
+}
+func (UnimplementedUserServiceServer) RestoreSnapshot(grpc.ClientStreamingServer[RestoreSnapshotRequest, RestoreSnapshotResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method RestoreSnapshot not implemented")
+}
+func (UnimplementedUserServiceServer) WatchUser(*WatchUserRequest, grpc.ServerStreamingServer[UserUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUser not implemented")
+}
+func (UnimplementedUserServiceServer) CleanupRefreshTokens(*CleanupRefreshTokensRequest, grpc.ServerStreamingServer[CleanupRefreshTokensProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method CleanupRefreshTokens not implemented")
+}
+func (UnimplementedUserServiceServer) RegisterPushToken(context.Context, *RegisterPushTokenRequest) (*RegisterPushTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterPushToken not implemented")
+}
+func (UnimplementedUserServiceServer) UnregisterPushToken(context.Context, *UnregisterPushTokenRequest) (*UnregisterPushTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnregisterPushToken not implemented")
+}
+func (UnimplementedUserServiceServer) SetLoginSchedule(context.Context, *SetLoginScheduleRequest) (*LoginSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLoginSchedule not implemented")
+}
+func (UnimplementedUserServiceServer) GetLoginSchedule(context.Context, *LoginScheduleSubject) (*LoginSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoginSchedule not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteLoginSchedule(context.Context, *LoginScheduleSubject) (*DeleteLoginScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLoginSchedule not implemented")
+}
+func (UnimplementedUserServiceServer) SetVelocityRule(context.Context, *SetVelocityRuleRequest) (*VelocityRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVelocityRule not implemented")
+}
+func (UnimplementedUserServiceServer) ListVelocityRules(context.Context, *ListVelocityRulesRequest) (*ListVelocityRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVelocityRules not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteVelocityRule(context.Context, *DeleteVelocityRuleRequest) (*DeleteVelocityRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVelocityRule not implemented")
+}
+func (UnimplementedUserServiceServer) SetCanaryAccount(context.Context, *SetCanaryAccountRequest) (*CanaryAccount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCanaryAccount not implemented")
+}
+func (UnimplementedUserServiceServer) ListCanaryAccounts(context.Context, *ListCanaryAccountsRequest) (*ListCanaryAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCanaryAccounts not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteCanaryAccount(context.Context, *DeleteCanaryAccountRequest) (*DeleteCanaryAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCanaryAccount not implemented")
+}
+func (UnimplementedUserServiceServer) GlobalLogout(context.Context, *GlobalLogoutRequest) (*GlobalLogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GlobalLogout not implemented")
+}
+func (UnimplementedUserServiceServer) ListAuthorizedClients(context.Context, *ListAuthorizedClientsRequest) (*ListAuthorizedClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuthorizedClients not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeClientAccess(context.Context, *RevokeClientAccessRequest) (*RevokeClientAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeClientAccess not implemented")
+}
+func (UnimplementedUserServiceServer) ExportOrgAuditLog(*ExportOrgAuditLogRequest, grpc.ServerStreamingServer[ExportOrgAuditLogChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportOrgAuditLog not implemented")
+}
+func (UnimplementedUserServiceServer) AddUserNote(context.Context, *AddUserNoteRequest) (*UserNote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserNote not implemented")
+}
+func (UnimplementedUserServiceServer) ListUserNotes(context.Context, *ListUserNotesRequest) (*ListUserNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserNotes not implemented")
+}
+func (UnimplementedUserServiceServer) GetRegistrationGates(context.Context, *GetRegistrationGatesRequest) (*RegistrationGates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegistrationGates not implemented")
+}
+func (UnimplementedUserServiceServer) SetRegistrationGates(context.Context, *SetRegistrationGatesRequest) (*RegistrationGates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRegistrationGates not implemented")
+}
+func (UnimplementedUserServiceServer) GetLoginHistory(context.Context, *GetLoginHistoryRequest) (*GetLoginHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoginHistory not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetQuotaUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuotaUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetQuotaUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetQuotaUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetQuotaUsage(ctx, req.(*GetQuotaUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetSLOStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSLOStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetSLOStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetSLOStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetSLOStatus(ctx, req.(*GetSLOStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeAllUserTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAllUserTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeAllUserTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeAllUserTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeAllUserTokens(ctx, req.(*RevokeAllUserTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetAccountActivitySummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountActivitySummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetAccountActivitySummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetAccountActivitySummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetAccountActivitySummary(ctx, req.(*GetAccountActivitySummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_PreviewEmailTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewEmailTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PreviewEmailTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_PreviewEmailTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PreviewEmailTemplate(ctx, req.(*PreviewEmailTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetNotificationPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetNotificationPreferences(ctx, req.(*GetNotificationPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNotificationPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateNotificationPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateNotificationPreferences(ctx, req.(*UpdateNotificationPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserStats(ctx, req.(*GetUserStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ExportUsers(m, &grpc.GenericServerStream[ExportUsersRequest, ExportUsersChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportUsersServer = grpc.ServerStreamingServer[ExportUsersChunk]
+
+func _UserService_PlaceLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PlaceLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_PlaceLegalHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PlaceLegalHold(ctx, req.(*LegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReleaseLegalHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LegalHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReleaseLegalHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReleaseLegalHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReleaseLegalHold(ctx, req.(*LegalHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetRiskSignals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRiskSignalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetRiskSignals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetRiskSignals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetRiskSignals(ctx, req.(*GetRiskSignalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserByUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserByUsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserByUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserByUsername_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserByUsername(ctx, req.(*GetUserByUsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BatchGetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchGetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchGetUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchGetUsers(ctx, req.(*BatchGetUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExchangeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExchangeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExchangeToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExchangeToken(ctx, req.(*ExchangeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_VerifyToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).VerifyToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_VerifyToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).VerifyToken(ctx, req.(*VerifyTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ClaimGuestActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimGuestActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ClaimGuestActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ClaimGuestActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ClaimGuestActivity(ctx, req.(*ClaimGuestActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetOrganization(ctx, req.(*GetOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetOrganizationByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizationByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetOrganizationByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetOrganizationByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetOrganizationByName(ctx, req.(*GetOrganizationByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetOrganizationEmailDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrganizationEmailDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetOrganizationEmailDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetOrganizationEmailDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetOrganizationEmailDomains(ctx, req.(*SetOrganizationEmailDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetOrganizationBranding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrganizationBrandingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetOrganizationBranding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetOrganizationBranding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetOrganizationBranding(ctx, req.(*SetOrganizationBrandingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetOrganizationSessionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOrganizationSessionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetOrganizationSessionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetOrganizationSessionPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetOrganizationSessionPolicy(ctx, req.(*SetOrganizationSessionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ImportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UserServiceServer).ImportUsers(&grpc.GenericServerStream[ImportUsersRequest, ImportUsersResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ImportUsersServer = grpc.BidiStreamingServer[ImportUsersRequest, ImportUsersResponse]
+
+func _UserService_CompletePasswordSetup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompletePasswordSetupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CompletePasswordSetup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CompletePasswordSetup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CompletePasswordSetup(ctx, req.(*CompletePasswordSetupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_LoginWithIDToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginWithIDTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).LoginWithIDToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_LoginWithIDToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).LoginWithIDToken(ctx, req.(*LoginWithIDTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BatchAssignRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAssignRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchAssignRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchAssignRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchAssignRole(ctx, req.(*BatchAssignRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BatchUpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchUpdateStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchUpdateStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchUpdateStatus(ctx, req.(*BatchUpdateStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserHistory(ctx, req.(*GetUserHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_PromoteSigningKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteSigningKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PromoteSigningKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_PromoteSigningKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PromoteSigningKey(ctx, req.(*PromoteSigningKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetUserMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetUserMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetUserMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetUserMetadata(ctx, req.(*SetUserMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RequestAvatarUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAvatarUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RequestAvatarUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RequestAvatarUploadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RequestAvatarUploadURL(ctx, req.(*RequestAvatarUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ConfirmAvatar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmAvatarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ConfirmAvatar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ConfirmAvatar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ConfirmAvatar(ctx, req.(*ConfirmAvatarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExportSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ExportSnapshot(m, &grpc.GenericServerStream[ExportSnapshotRequest, SnapshotChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportSnapshotServer = grpc.ServerStreamingServer[SnapshotChunk]
+
+func _UserService_RestoreSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UserServiceServer).RestoreSnapshot(&grpc.GenericServerStream[RestoreSnapshotRequest, RestoreSnapshotResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_RestoreSnapshotServer = grpc.ClientStreamingServer[RestoreSnapshotRequest, RestoreSnapshotResponse]
+
+func _UserService_WatchUser_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUserRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).WatchUser(m, &grpc.GenericServerStream[WatchUserRequest, UserUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_WatchUserServer = grpc.ServerStreamingServer[UserUpdate]
+
+func _UserService_CleanupRefreshTokens_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CleanupRefreshTokensRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).CleanupRefreshTokens(m, &grpc.GenericServerStream[CleanupRefreshTokensRequest, CleanupRefreshTokensProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_CleanupRefreshTokensServer = grpc.ServerStreamingServer[CleanupRefreshTokensProgress]
+
+func _UserService_RegisterPushToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterPushTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RegisterPushToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RegisterPushToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RegisterPushToken(ctx, req.(*RegisterPushTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UnregisterPushToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterPushTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnregisterPushToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnregisterPushToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnregisterPushToken(ctx, req.(*UnregisterPushTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetLoginSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLoginScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetLoginSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetLoginSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetLoginSchedule(ctx, req.(*SetLoginScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetLoginSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginScheduleSubject)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetLoginSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetLoginSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetLoginSchedule(ctx, req.(*LoginScheduleSubject))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteLoginSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginScheduleSubject)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteLoginSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteLoginSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteLoginSchedule(ctx, req.(*LoginScheduleSubject))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetVelocityRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVelocityRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetVelocityRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetVelocityRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetVelocityRule(ctx, req.(*SetVelocityRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListVelocityRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVelocityRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListVelocityRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListVelocityRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListVelocityRules(ctx, req.(*ListVelocityRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteVelocityRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVelocityRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteVelocityRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteVelocityRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteVelocityRule(ctx, req.(*DeleteVelocityRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetCanaryAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCanaryAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetCanaryAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetCanaryAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetCanaryAccount(ctx, req.(*SetCanaryAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListCanaryAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCanaryAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListCanaryAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListCanaryAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListCanaryAccounts(ctx, req.(*ListCanaryAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteCanaryAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCanaryAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteCanaryAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteCanaryAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteCanaryAccount(ctx, req.(*DeleteCanaryAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GlobalLogout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GlobalLogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GlobalLogout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GlobalLogout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GlobalLogout(ctx, req.(*GlobalLogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListAuthorizedClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuthorizedClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListAuthorizedClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListAuthorizedClients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListAuthorizedClients(ctx, req.(*ListAuthorizedClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeClientAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeClientAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeClientAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeClientAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeClientAccess(ctx, req.(*RevokeClientAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExportOrgAuditLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportOrgAuditLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ExportOrgAuditLog(m, &grpc.GenericServerStream[ExportOrgAuditLogRequest, ExportOrgAuditLogChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportOrgAuditLogServer = grpc.ServerStreamingServer[ExportOrgAuditLogChunk]
+
+func _UserService_AddUserNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddUserNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddUserNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddUserNote(ctx, req.(*AddUserNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUserNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUserNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserNotes(ctx, req.(*ListUserNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetRegistrationGates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegistrationGatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetRegistrationGates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetRegistrationGates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetRegistrationGates(ctx, req.(*GetRegistrationGatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetRegistrationGates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRegistrationGatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetRegistrationGates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetRegistrationGates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetRegistrationGates(ctx, req.(*SetRegistrationGatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetLoginHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLoginHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetLoginHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetLoginHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetLoginHistory(ctx, req.(*GetLoginHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "user.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _UserService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _UserService_Login_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _UserService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "GetQuotaUsage",
+			Handler:    _UserService_GetQuotaUsage_Handler,
+		},
+		{
+			MethodName: "GetSLOStatus",
+			Handler:    _UserService_GetSLOStatus_Handler,
+		},
+		{
+			MethodName: "RevokeAllUserTokens",
+			Handler:    _UserService_RevokeAllUserTokens_Handler,
+		},
+		{
+			MethodName: "GetAccountActivitySummary",
+			Handler:    _UserService_GetAccountActivitySummary_Handler,
+		},
+		{
+			MethodName: "PreviewEmailTemplate",
+			Handler:    _UserService_PreviewEmailTemplate_Handler,
+		},
+		{
+			MethodName: "GetNotificationPreferences",
+			Handler:    _UserService_GetNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "UpdateNotificationPreferences",
+			Handler:    _UserService_UpdateNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "GetUserStats",
+			Handler:    _UserService_GetUserStats_Handler,
+		},
+		{
+			MethodName: "PlaceLegalHold",
+			Handler:    _UserService_PlaceLegalHold_Handler,
+		},
+		{
+			MethodName: "ReleaseLegalHold",
+			Handler:    _UserService_ReleaseLegalHold_Handler,
+		},
+		{
+			MethodName: "GetRiskSignals",
+			Handler:    _UserService_GetRiskSignals_Handler,
+		},
+		{
+			MethodName: "GetUserByUsername",
+			Handler:    _UserService_GetUserByUsername_Handler,
+		},
+		{
+			MethodName: "BatchGetUsers",
+			Handler:    _UserService_BatchGetUsers_Handler,
+		},
+		{
+			MethodName: "ExchangeToken",
+			Handler:    _UserService_ExchangeToken_Handler,
+		},
+		{
+			MethodName: "VerifyToken",
+			Handler:    _UserService_VerifyToken_Handler,
+		},
+		{
+			MethodName: "ClaimGuestActivity",
+			Handler:    _UserService_ClaimGuestActivity_Handler,
+		},
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _UserService_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "GetOrganization",
+			Handler:    _UserService_GetOrganization_Handler,
+		},
+		{
+			MethodName: "GetOrganizationByName",
+			Handler:    _UserService_GetOrganizationByName_Handler,
+		},
+		{
+			MethodName: "SetOrganizationEmailDomains",
+			Handler:    _UserService_SetOrganizationEmailDomains_Handler,
+		},
+		{
+			MethodName: "SetOrganizationBranding",
+			Handler:    _UserService_SetOrganizationBranding_Handler,
+		},
+		{
+			MethodName: "SetOrganizationSessionPolicy",
+			Handler:    _UserService_SetOrganizationSessionPolicy_Handler,
+		},
+		{
+			MethodName: "CompletePasswordSetup",
+			Handler:    _UserService_CompletePasswordSetup_Handler,
+		},
+		{
+			MethodName: "LoginWithIDToken",
+			Handler:    _UserService_LoginWithIDToken_Handler,
+		},
+		{
+			MethodName: "BatchAssignRole",
+			Handler:    _UserService_BatchAssignRole_Handler,
+		},
+		{
+			MethodName: "BatchUpdateStatus",
+			Handler:    _UserService_BatchUpdateStatus_Handler,
+		},
+		{
+			MethodName: "GetUserHistory",
+			Handler:    _UserService_GetUserHistory_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _UserService_ListUsers_Handler,
+		},
+		{
+			MethodName: "PromoteSigningKey",
+			Handler:    _UserService_PromoteSigningKey_Handler,
+		},
+		{
+			MethodName: "SetUserMetadata",
+			Handler:    _UserService_SetUserMetadata_Handler,
+		},
+		{
+			MethodName: "RequestAvatarUploadURL",
+			Handler:    _UserService_RequestAvatarUploadURL_Handler,
+		},
+		{
+			MethodName: "ConfirmAvatar",
+			Handler:    _UserService_ConfirmAvatar_Handler,
+		},
+		{
+			MethodName: "RegisterPushToken",
+			Handler:    _UserService_RegisterPushToken_Handler,
+		},
+		{
+			MethodName: "UnregisterPushToken",
+			Handler:    _UserService_UnregisterPushToken_Handler,
+		},
+		{
+			MethodName: "SetLoginSchedule",
+			Handler:    _UserService_SetLoginSchedule_Handler,
+		},
+		{
+			MethodName: "GetLoginSchedule",
+			Handler:    _UserService_GetLoginSchedule_Handler,
+		},
+		{
+			MethodName: "DeleteLoginSchedule",
+			Handler:    _UserService_DeleteLoginSchedule_Handler,
+		},
+		{
+			MethodName: "SetVelocityRule",
+			Handler:    _UserService_SetVelocityRule_Handler,
+		},
+		{
+			MethodName: "ListVelocityRules",
+			Handler:    _UserService_ListVelocityRules_Handler,
+		},
+		{
+			MethodName: "DeleteVelocityRule",
+			Handler:    _UserService_DeleteVelocityRule_Handler,
+		},
+		{
+			MethodName: "SetCanaryAccount",
+			Handler:    _UserService_SetCanaryAccount_Handler,
+		},
+		{
+			MethodName: "ListCanaryAccounts",
+			Handler:    _UserService_ListCanaryAccounts_Handler,
+		},
+		{
+			MethodName: "DeleteCanaryAccount",
+			Handler:    _UserService_DeleteCanaryAccount_Handler,
+		},
+		{
+			MethodName: "GlobalLogout",
+			Handler:    _UserService_GlobalLogout_Handler,
+		},
+		{
+			MethodName: "ListAuthorizedClients",
+			Handler:    _UserService_ListAuthorizedClients_Handler,
+		},
+		{
+			MethodName: "RevokeClientAccess",
+			Handler:    _UserService_RevokeClientAccess_Handler,
+		},
+		{
+			MethodName: "AddUserNote",
+			Handler:    _UserService_AddUserNote_Handler,
+		},
+		{
+			MethodName: "ListUserNotes",
+			Handler:    _UserService_ListUserNotes_Handler,
+		},
+		{
+			MethodName: "GetRegistrationGates",
+			Handler:    _UserService_GetRegistrationGates_Handler,
+		},
+		{
+			MethodName: "SetRegistrationGates",
+			Handler:    _UserService_SetRegistrationGates_Handler,
+		},
+		{
+			MethodName: "GetLoginHistory",
+			Handler:    _UserService_GetLoginHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUsers",
+			Handler:       _UserService_ExportUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportUsers",
+			Handler:       _UserService_ImportUsers_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportSnapshot",
+			Handler:       _UserService_ExportSnapshot_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RestoreSnapshot",
+			Handler:       _UserService_RestoreSnapshot_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchUser",
+			Handler:       _UserService_WatchUser_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CleanupRefreshTokens",
+			Handler:       _UserService_CleanupRefreshTokens_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportOrgAuditLog",
+			Handler:       _UserService_ExportOrgAuditLog_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "v1/user-svc.proto",
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"user-svc/internal/app/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+// runConfigCommand runs `user-svc-api config schema`, which prints every configuration key
+// with its type, default, environment variable and whether it is required
+func runConfigCommand(args []string) error {
+	if len(args) == 0 || args[0] != "schema" {
+		return fmt.Errorf("usage: user-svc-api config schema [-format yaml|markdown]")
+	}
+
+	flags := flag.NewFlagSet("config schema", flag.ContinueOnError)
+	format := flags.String("format", "yaml", "output format, yaml or markdown")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	fields := config.Schema()
+	switch *format {
+	case "yaml":
+		return writeSchemaYAML(os.Stdout, fields)
+	case "markdown":
+		return writeSchemaMarkdown(os.Stdout, fields)
+	default:
+		return fmt.Errorf("unknown format %q, use yaml or markdown", *format)
+	}
+}
+
+func writeSchemaYAML(w io.Writer, fields []config.SchemaField) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(fields); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func writeSchemaMarkdown(w io.Writer, fields []config.SchemaField) error {
+	var b strings.Builder
+	b.WriteString("| Key | Type | Default | Environment | Required |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, field := range fields {
+		required := ""
+		if field.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n",
+			field.Key, markdownCell(field.Type), markdownCode(field.Default), markdownCode(field.Env), required)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCode formats a non-empty value as inline code
+func markdownCode(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + markdownCell(value) + "`"
+}
+
+func markdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
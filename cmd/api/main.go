@@ -2,34 +2,82 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
 	"sync"
 	"syscall"
 	"time"
 
-	pb "user-svc/api/proto"
+	pb "user-svc/api/proto/v1"
+	pbv2 "user-svc/api/proto/v2"
+	"user-svc/internal/app/abuse"
+	"user-svc/internal/app/canary"
 	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/hashing"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/graphql"
 	"user-svc/internal/app/handler"
+	"user-svc/internal/app/keyrotation"
+	"user-svc/internal/app/notifier"
 	"user-svc/internal/app/repository"
+	"user-svc/internal/app/revocation"
 	"user-svc/internal/app/service"
+	"user-svc/internal/app/siem"
+	"user-svc/internal/app/userwatch"
 	"user-svc/internal/db"
 	"user-svc/internal/workers"
+	"user-svc/pkg/utils/admission"
+	"user-svc/pkg/utils/breaker"
+	"user-svc/pkg/utils/captcha"
+	"user-svc/pkg/utils/crypt/dpop"
+	"user-svc/pkg/utils/crypt/oidc"
 	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/email"
 	grpcutils "user-svc/pkg/utils/grpc"
+	"user-svc/pkg/utils/grpc/zstd"
+	"user-svc/pkg/utils/locks"
 	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+	"user-svc/pkg/utils/nonces"
+	"user-svc/pkg/utils/pipeline"
+	"user-svc/pkg/utils/preflight"
+	"user-svc/pkg/utils/retry"
+	"user-svc/pkg/utils/slo"
+	"user-svc/pkg/utils/storage"
 	"user-svc/pkg/utils/tx"
+	"user-svc/pkg/utils/webhook"
 
 	"github.com/hibiken/asynq"
-	"github.com/sirupsen/logrus"
+	"github.com/redis/go-redis/v9"
+	"github.com/samber/lo"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if err := runConfigCommand(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		if err := runSeedCommand(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Initialize logger
 	if err := logutils.InitLogger(); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
@@ -46,49 +94,590 @@ func main() {
 	if err := cfg.Validate(); err != nil {
 		logger.Fatalf("Configuration validation failed: %v", err)
 	}
+	if err := logutils.Configure(cfg.Log.Level, cfg.Log.Format); err != nil {
+		logger.Fatalf("Failed to configure logger: %v", err)
+	}
 
-	// Get interceptors for exception handling
-	unaryInterceptors := grpcutils.GetUnaryInterceptors(logger)
-	streamInterceptors := grpcutils.GetStreamInterceptors(logger)
+	// Write logs to a rotated file instead of stdout
+	if cfg.Log.File.Enabled {
+		err := logutils.UseFile(logutils.FileOptions{
+			Path:       cfg.Log.File.Path,
+			MaxSize:    cfg.Log.File.MaxSize,
+			MaxAge:     cfg.Log.File.MaxAge,
+			MaxBackups: cfg.Log.File.MaxBackups,
+			Compress:   cfg.Log.File.Compress,
+		})
+		if err != nil {
+			logger.Fatalf("Failed to open log file: %v", err)
+		}
+	}
 
-	// Create gRPC server with interceptors
-	serverOptions := append(unaryInterceptors, streamInterceptors...)
-	grpcServer := grpc.NewServer(serverOptions...)
+	// Get interceptors for exception handling
+	loggingOpts := grpcutils.DefaultLoggingOptions()
+	loggingOpts.SuccessSampleRate = cfg.Log.Sampling.SuccessRate
+	loggingOpts.ErrorSampleRate = cfg.Log.Sampling.ErrorRate
+	loggingOpts.CapturePayloads = cfg.Log.CapturePayloads
 
-	db, err := db.NewStore(&cfg.Database)
+	store, err := db.NewStore(&cfg.Database)
 	if err != nil {
 		logger.Fatalf("Failed to create database store: %v", err)
 	}
-	userRepo := repository.NewUserRepository(db)
-	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
-	txManager := tx.NewTransactionManager(db.DB())
-	tokenMaker := token.NewJWTTokenMaker(cfg.JWT.SecretKey)
-	notificationEventLogRepo := repository.NewNotificationEventLogRepository(db)
+	// Organizations with a schema of their own are routed to it by the tenant interceptors
+	var tenantRouter *db.TenantRouter
+	var txBeginner tx.Beginner = store.DB()
+	if cfg.Tenancy.SchemaIsolation() {
+		tenantRouter = db.NewTenantRouter(store, &cfg.Database, cfg.Tenancy)
+		store, txBeginner = tenantRouter, tenantRouter
+		logger.Info("Tenant schema isolation enabled")
+	}
+	// Read-only methods read from the replica, mutating ones return consistency tokens
+	var replicaRouter *db.ReplicaRouter
+	if cfg.Database.Replica.Enabled {
+		replicaRouter, err = db.NewReplicaRouter(store, &cfg.Database)
+		if err != nil {
+			logger.Fatalf("Failed to create replica router: %v", err)
+		}
+		store, txBeginner = replicaRouter, replicaRouter
+		logger.WithField("replica", cfg.Database.Replica.Host).Info("Read replica enabled")
+	}
+	// User data is stored in the database of the user's residency region; shared data, e.g.
+	// organizations and clients, stays in the home database
+	residencyRouter, err := db.NewResidencyRouter(store, &cfg.Database, cfg.Residency)
+	if err != nil {
+		logger.Fatalf("Failed to create residency router: %v", err)
+	}
+	var userStore db.Store = store
+	if len(cfg.Residency.Regions) > 0 {
+		userStore, txBeginner = residencyRouter, residencyRouter
+		logger.WithField("regions", len(cfg.Residency.Regions)).Info("Regional user databases enabled")
+	}
+	retryPolicy := retry.Policy{
+		MaxAttempts:    cfg.Database.Retry.MaxAttempts,
+		InitialBackoff: cfg.Database.Retry.InitialBackoff,
+		MaxBackoff:     cfg.Database.Retry.MaxBackoff,
+		Multiplier:     2,
+	}
+	userRepo := repository.NewCachingUserRepository(
+		repository.NewRetryingUserRepository(repository.NewUserRepository(userStore), retryPolicy),
+		cfg.Cache.UserTTL,
+	)
+	refreshTokenRepo := repository.NewRetryingRefreshTokenRepository(repository.NewRefreshTokenRepository(userStore), retryPolicy)
+	clientRepo := repository.NewRetryingClientRepository(repository.NewClientRepository(store), retryPolicy)
+	txManager := tx.NewTransactionManager(txBeginner)
+
+	// Revoked access tokens are rejected on every replica; revoking a user also drops its cached data
+	revocationCache := revocation.NewCache()
+	revocationCache.OnUserRevoked(userRepo.Evict)
+	var secondarySecretKeys []string
+	if cfg.JWT.SecondarySecretKey != "" {
+		secondarySecretKeys = append(secondarySecretKeys, cfg.JWT.SecondarySecretKey)
+	}
+	jwtMaker := token.NewJWTTokenMaker(cfg.JWT.SecretKey, secondarySecretKeys...)
+	if cfg.JWT.EncryptionKey != "" {
+		var secondaryEncryptionKeys []string
+		if cfg.JWT.SecondaryEncryptionKey != "" {
+			secondaryEncryptionKeys = append(secondaryEncryptionKeys, cfg.JWT.SecondaryEncryptionKey)
+		}
+		jwtMaker.EnableEncryption(cfg.JWT.EncryptionKey, secondaryEncryptionKeys...)
+	}
 
+	// Tokens of every accepted format verify while new ones are created in the configured format,
+	// so the format can change without invalidating the tokens in flight
+	formatMakers := map[token.TokenFormat]token.RegisteredMaker{
+		token.TokenFormatJWT: {Format: token.TokenFormatJWT, Maker: jwtMaker, Recognizes: token.IsJWT},
+		token.TokenFormatPASETOv4: {
+			Format:     token.TokenFormatPASETOv4,
+			Maker:      token.NewPasetoTokenMaker(cfg.JWT.SecretKey, secondarySecretKeys...),
+			Recognizes: token.IsPasetoV4Public,
+		},
+	}
+	acceptedMakers := make([]token.RegisteredMaker, 0, len(cfg.JWT.AcceptedFormats))
+	for _, format := range cfg.JWT.AcceptedFormats {
+		acceptedMakers = append(acceptedMakers, formatMakers[token.TokenFormat(format)])
+	}
+	formatMaker, err := token.NewCompositeTokenMaker(token.TokenFormat(cfg.JWT.TokenFormat), acceptedMakers...)
+	if err != nil {
+		logger.Fatalf("Failed to create token maker: %v", err)
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.GetRedisAddr(),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	defer redisClient.Close()
+	notificationEventLogRepo := repository.NewNotificationEventLogRepository(store)
+	auditLogRepo := repository.NewAuditLogRepository(store)
+
+	// Async pipelines keep audit writes, login attempts and event publishing off the request path.
+	// They use their own context so they are flushed only after the gRPC server has drained.
+	pipelineCtx, pipelineCancel := context.WithCancel(context.Background())
+	defer pipelineCancel()
+	var pipelineWg sync.WaitGroup
+
+	eventPipeline := pipeline.New(
+		pipelineConfig("events", cfg.Pipeline.Events),
+		notificationEventLogRepo.CreateBatch,
+		logger,
+	)
+	eventPipeline.Start(pipelineCtx, &pipelineWg)
+
+	auditPipeline := pipeline.New[*models.AuditLog](
+		pipelineConfig("audit", cfg.Pipeline.Audit),
+		auditLogRepo.CreateBatch,
+		logger,
+	)
+	auditPipeline.Start(pipelineCtx, &pipelineWg)
+
+	loginAttemptRepo := repository.NewLoginAttemptRepository(store)
+	loginAttemptPipeline := pipeline.New[*models.LoginAttempt](
+		pipelineConfig("login_attempts", cfg.Pipeline.LoginAttempts),
+		loginAttemptRepo.CreateBatch,
+		logger,
+	)
+	loginAttemptPipeline.Start(pipelineCtx, &pipelineWg)
+
+	// Security events go to the SIEM's own sink, apart from the application logs
+	securityStream, err := siem.NewStream(cfg.Log.Security, logger)
+	if err != nil {
+		logger.Fatalf("Failed to create security event stream: %v", err)
+	}
+	securityStream.Start(pipelineCtx, &pipelineWg)
+
+	revocationPropagator := revocation.NewPropagator(
+		revocationCache,
+		repository.NewTokenRevocationRepository(store),
+		redisClient,
+		cfg.Revocation.Channel,
+		cfg.Revocation.ResyncInterval,
+		cfg.JWT.AccessTokenDuration,
+		logger,
+	)
+	revocationPropagator.Start(pipelineCtx, &pipelineWg)
+
+	// Every replica signs new tokens with the promoted key, see PromoteSigningKey
+	keyRotator := keyrotation.NewRotator(
+		formatMaker,
+		repository.NewSigningKeyRepository(store),
+		redisClient,
+		cfg.JWT.KeyChannel,
+		cfg.JWT.KeyResyncInterval,
+		logger,
+	)
+	keyRotator.Start(pipelineCtx, &pipelineWg)
+
+	// Access tokens of canary accounts raise an alert wherever they are verified
+	canaryAccountRepo := repository.NewCanaryAccountRepository(store)
+	canaryMonitor := canary.NewMonitor(
+		canaryAccountRepo,
+		eventPipeline,
+		cfg.Canary.ResyncInterval,
+		cfg.Canary.TarpitDelay,
+		logger,
+	)
+	canaryMonitor.Start(pipelineCtx, &pipelineWg)
+	// Tokens carry the service's issuer and audience; in strict mode tokens without them, e.g.
+	// minted by other services sharing the secret, are reported or rejected
+	claimPolicyMaker := token.NewClaimPolicyTokenMaker(formatMaker, token.ClaimPolicy{
+		Issuer:   cfg.JWT.Issuer,
+		Audience: cfg.JWT.Audience,
+		Mode:     token.ClaimsMode(cfg.JWT.ClaimsMode),
+	})
+	tokenMaker := canary.NewTokenMaker(revocation.NewTokenMaker(claimPolicyMaker, revocationCache), canaryMonitor)
+
+	orgRepo := repository.NewOrganizationRepository(store)
+	passwordSetupRepo := repository.NewPasswordSetupTokenRepository(userStore)
+	userEventRepo := repository.NewUserEventRepository(userStore)
+	workflowTimerRepo := repository.NewWorkflowTimerRepository(store)
+	registrationCodeRepo := repository.NewRegistrationCodeRepository(store)
+	emailClaimRepo := repository.NewEmailClaimRepository(userStore)
+	passwordResetRepo := repository.NewPasswordResetTokenRepository(userStore)
+	emailVerificationRepo := repository.NewEmailVerificationTokenRepository(userStore)
+	registrationGateRepo := repository.NewRegistrationGateRepository(store)
+	pushTokenService := service.NewPushTokenService(
+		repository.NewPushTokenRepository(userStore),
+		notificationEventLogRepo,
+		txManager,
+		tokenMaker,
+	)
+	loginScheduleService := service.NewLoginScheduleService(cfg, repository.NewLoginScheduleRepository(store))
+	sessionTracker := service.NewSessionTracker(cfg, repository.NewSessionUsageRepository(userStore), notificationEventLogRepo)
+
+	// Velocity rules changed through the admin RPCs are applied on every replica within seconds
+	velocityRuleRepo := repository.NewVelocityRuleRepository(store)
+	abuseEngine := abuse.NewEngine(
+		velocityRuleRepo,
+		abuse.NewRedisCounter(redisClient, cfg.Abuse.KeyPrefix),
+		newCaptchaVerifier(cfg.Abuse.Captcha),
+		redisClient,
+		cfg.Abuse.Channel,
+		cfg.Abuse.ResyncInterval,
+		logger,
+	)
+	abuseEngine.Start(pipelineCtx, &pipelineWg)
+	velocityRuleService := service.NewVelocityRuleService(cfg, velocityRuleRepo, abuseEngine)
+	// Hashing decisions, the bcrypt cost, the pepper and the algorithms, are the hashers' own
+	var peppers []string
+	if cfg.PasswordHashing.Pepper != "" {
+		peppers = append(peppers, cfg.PasswordHashing.Pepper)
+	}
+	if cfg.PasswordHashing.SecondaryPepper != "" {
+		peppers = append(peppers, cfg.PasswordHashing.SecondaryPepper)
+	}
+	passwordHasher := hashing.NewPasswordHasher(cfg.PasswordHashing.BcryptCost, peppers...)
+	tokenHasher := hashing.NewTokenHasher()
+	// Emails are unique across regions through the directory in the home database
+	emailDirectory := repository.NewEmailDirectoryRepository(store)
+	// Emailed links are redeemed, and the sessions of verified tokens marked active, in the
+	// schema of their user's organization
+	var tenants service.TenantRouter
+	if tenantRouter != nil {
+		tenants = tenantRouter
+	}
 	userService := service.NewUserService(
 		cfg,
 		userRepo,
 		refreshTokenRepo,
+		clientRepo,
+		orgRepo,
+		passwordSetupRepo,
+		userEventRepo,
 		txManager,
 		tokenMaker,
+		eventPipeline,
+		auditPipeline,
+		revocationPropagator,
+		pushTokenService,
+		loginScheduleService,
+		abuseEngine,
+		canaryMonitor,
+		revocationCache,
+		sessionTracker,
+		securityStream,
+		residencyRouter,
+		registrationCodeRepo,
+		emailClaimRepo,
+		passwordResetRepo,
+		emailVerificationRepo,
+		registrationGateRepo,
+		repository.NewInviteCodeRepository(store),
+		loginAttemptPipeline,
+		passwordHasher,
+		tokenHasher,
+		repository.NewUserIdentityRepository(userStore),
+		newIDTokenVerifier(cfg.OIDC, newNonceStore(cfg.Nonces, store, redisClient)),
+		emailDirectory,
+		tenants,
+	)
+	canaryAccountService := service.NewCanaryAccountService(cfg, userRepo, canaryAccountRepo, canaryMonitor)
+	quotaService := service.NewQuotaService(
+		cfg.Quota,
+		repository.NewQuotaRepository(store),
+		tokenMaker,
+		eventPipeline,
+		pb.UserService_GetQuotaUsage_FullMethodName,
+		pb.UserService_GetSLOStatus_FullMethodName,
+	)
+
+	// SLO tracking only keeps counters for methods with configured targets
+	var sloTargets []slo.Target
+	if cfg.SLO.Enabled {
+		sloTargets = lo.Map(cfg.SLO.Targets, func(target config.SLOTargetConfig, _ int) slo.Target {
+			return slo.Target{
+				Method:                target.Method,
+				LatencyThreshold:      target.LatencyThreshold,
+				LatencyObjective:      target.LatencyObjective,
+				AvailabilityObjective: target.AvailabilityObjective,
+			}
+		})
+	}
+	sloTracker := slo.NewTracker(cfg.SLO.Window, sloTargets)
+	if cfg.SLO.Enabled {
+		sloTracker.Start(pipelineCtx, &pipelineWg, cfg.SLO.ReportInterval, logger)
+	}
+
+	activityService := service.NewActivityService(auditLogRepo, userRepo, tokenMaker, eventPipeline)
+
+	emailTemplates, err := email.NewRegistry(cfg.Email.TemplatesDir, cfg.Email.DefaultLocale)
+	if err != nil {
+		logger.Fatalf("Failed to load email templates: %v", err)
+	}
+	if cfg.Email.ReloadInterval > 0 {
+		emailTemplates.Watch(pipelineCtx, logger, &pipelineWg, cfg.Email.ReloadInterval)
+	}
+	logger.WithField("templates", emailTemplates.Names()).Info("Email templates loaded")
+
+	emailTemplateService := service.NewEmailTemplateService(cfg, emailTemplates)
+
+	notificationRoutes := make(map[string][]models.NotificationChannel, len(cfg.Notifier.Routes))
+	for eventType, channels := range cfg.Notifier.Routes {
+		for _, channel := range channels {
+			notificationRoutes[eventType] = append(notificationRoutes[eventType], models.NotificationChannel(channel))
+		}
+	}
+	notificationPreferenceRepo := repository.NewNotificationPreferenceRepository(userStore)
+	notificationService := service.NewNotificationService(notificationPreferenceRepo, tokenMaker, notificationRoutes)
+
+	statsService := service.NewStatsService(cfg, repository.NewStatsRepository(store))
+	exportService := service.NewExportService(cfg, repository.NewUserRepository(userStore))
+	legalHoldService := service.NewLegalHoldService(cfg, repository.NewUserRepository(userStore), auditLogRepo, userEventRepo, txManager)
+	riskService := service.NewRiskService(cfg, userRepo, repository.NewRiskRepository(store))
+	userLookupService := service.NewUserLookupService(cfg, repository.NewUserRepository(userStore))
+	tokenExchangeService := service.NewTokenExchangeService(cfg, tokenMaker)
+	tokenVerificationService := service.NewTokenVerificationService(cfg, tokenMaker, sessionTracker, residencyRouter, tenants)
+	guestActivityService := service.NewGuestActivityService(cfg, emailClaimRepo)
+	registrationGateService := service.NewRegistrationGateService(cfg, registrationGateRepo)
+	loginHistoryService := service.NewLoginHistoryService(cfg, tokenMaker, loginAttemptRepo)
+	inactivityService := service.NewInactivityService(cfg, repository.NewUserRepository(userStore), residencyRouter, eventPipeline)
+	userNoteService := service.NewUserNoteService(cfg, repository.NewUserRepository(userStore), repository.NewUserNoteRepository(userStore))
+	organizationService := service.NewOrganizationService(cfg, orgRepo, repository.NewOrganizationRepository(residencyRouter), residencyRouter)
+	importService := service.NewImportService(
+		cfg,
+		userRepo,
+		orgRepo,
+		passwordSetupRepo,
 		notificationEventLogRepo,
+		auditLogRepo,
+		userEventRepo,
+		workflowTimerRepo,
+		txManager,
+		residencyRouter,
+		tokenHasher,
+		emailDirectory,
+	)
+
+	bulkService := service.NewBulkService(
+		cfg,
+		userRepo,
+		refreshTokenRepo,
+		auditLogRepo,
+		userEventRepo,
+		txManager,
+		revocationPropagator,
+		pushTokenService,
 	)
-	userHandler := handler.NewUserHandler(userService)
 
-	// Register services
+	historyService := service.NewUserHistoryService(cfg, repository.NewUserRepository(userStore), userEventRepo)
+	signingKeyService := service.NewSigningKeyService(cfg, keyRotator)
+	metadataService := service.NewUserMetadataService(cfg, repository.NewUserRepository(userStore), auditLogRepo, userEventRepo, txManager)
+	avatarStorage, err := newAvatarStorage(cfg.Storage)
+	if err != nil {
+		logger.Fatalf("Failed to configure avatar storage: %v", err)
+	}
+	avatarService := service.NewAvatarService(cfg, avatarStorage, repository.NewUserRepository(userStore), tokenMaker)
+	snapshotService := service.NewSnapshotService(cfg, repository.NewSnapshotRepository(store), txManager, db.SchemaVersion)
+
+	var watchHub *userwatch.Hub
+	if cfg.Watch.Enabled {
+		listener, err := db.NewListener(&cfg.Database, userwatch.Channel, logger)
+		if err != nil {
+			logger.Fatalf("Failed to listen for user events: %v", err)
+		}
+		defer listener.Close()
+
+		watchHub = userwatch.NewHub(logger)
+		watchHub.Start(pipelineCtx, &pipelineWg, listener, cfg.Watch.ResyncInterval)
+	}
+	watchService := service.NewUserWatchService(cfg, repository.NewUserRepository(userStore), watchHub)
+	maintenanceService := service.NewMaintenanceService(cfg, repository.NewRefreshTokenRepository(userStore))
+	globalLogoutService := service.NewGlobalLogoutService(
+		cfg,
+		revocationPropagator,
+		repository.NewRefreshTokenRepository(userStore),
+		repository.NewGlobalLogoutRepository(store),
+	)
+	authorizedClientService := service.NewAuthorizedClientService(
+		repository.NewRefreshTokenRepository(userStore),
+		notificationEventLogRepo,
+		txManager,
+		revocationPropagator,
+		tokenMaker,
+	)
+
+	adminUserService := service.NewAdminUserService(cfg, repository.NewUserRepository(userStore))
+
+	orgAuditService := service.NewOrgAuditService(
+		cfg,
+		auditLogRepo,
+		repository.NewUserRepository(userStore),
+		repository.NewOrgAuditWebhookRepository(store),
+		webhook.NewClient(&http.Client{Timeout: cfg.OrgAudit.Webhooks.Timeout}),
+		tokenMaker,
+	)
+
+	userHandler := handler.NewUserHandler(
+		userService,
+		quotaService,
+		activityService,
+		emailTemplateService,
+		notificationService,
+		statsService,
+		exportService,
+		legalHoldService,
+		riskService,
+		organizationService,
+		importService,
+		bulkService,
+		historyService,
+		signingKeyService,
+		metadataService,
+		avatarService,
+		snapshotService,
+		watchService,
+		maintenanceService,
+		pushTokenService,
+		loginScheduleService,
+		globalLogoutService,
+		authorizedClientService,
+		orgAuditService,
+		velocityRuleService,
+		canaryAccountService,
+		adminUserService,
+		userLookupService,
+		tokenExchangeService,
+		tokenVerificationService,
+		guestActivityService,
+		userNoteService,
+		registrationGateService,
+		loginHistoryService,
+		sloTracker,
+	)
+
+	// Interceptors run in the order of their stage, whatever the order they are added in
+	builtinInterceptors := grpcutils.BuiltinInterceptors{
+		Metrics:       cfg.Server.Interceptors.Metrics,
+		Recovery:      cfg.Server.Interceptors.Recovery,
+		Logging:       cfg.Server.Interceptors.Logging,
+		ErrorHandling: cfg.Server.Interceptors.ErrorHandling,
+	}
+	unaryChain := grpcutils.NewUnaryChain(logger, loggingOpts, sloTracker, builtinInterceptors)
+	streamChain := grpcutils.NewStreamChain(logger, builtinInterceptors)
+	// Usage per API version tells when the deprecated v1 methods can be removed
+	apiMethods := grpcutils.NewAPIMethods(
+		pb.File_v1_user_svc_proto.Services().ByName("UserService"),
+		pbv2.File_v2_user_svc_proto.Services().ByName("UserService"),
+	)
+	unaryChain.Use("api_version", grpcutils.StageMetrics, grpcutils.APIVersionInterceptor(logger, apiMethods))
+	streamChain.Use("api_version", grpcutils.StageMetrics, grpcutils.APIVersionStreamInterceptor(logger, apiMethods))
+	// Every line logged for a request carries its request ID, method, caller and trace ID
+	unaryChain.Use("log_context", grpcutils.StageMetrics, grpcutils.LogContextInterceptor(logger, tokenMaker))
+	streamChain.Use("log_context", grpcutils.StageMetrics, grpcutils.LogContextStreamInterceptor(logger, tokenMaker))
+	unaryChain.Use("timeout", grpcutils.StageDeadline, grpcutils.TimeoutInterceptor(logger, timeoutOptions(cfg.Server)))
+	if cfg.Server.Compression.Enabled {
+		if err := grpcutils.SetGzipLevel(cfg.Server.Compression.GzipLevel); err != nil {
+			logger.Fatalf("Invalid gzip level: %v", err)
+		}
+		compressionOptions := grpcutils.CompressionOptions{
+			Algorithms: cfg.Server.Compression.Algorithms,
+			Methods:    cfg.Server.Compression.Methods,
+		}
+		unaryChain.Use("compression", grpcutils.StageTransport, grpcutils.CompressionInterceptor(logger, compressionOptions))
+		streamChain.Use("compression", grpcutils.StageTransport, grpcutils.CompressionStreamInterceptor(logger, compressionOptions))
+	}
+	if cfg.GRPCWeb.Enabled && cfg.GRPCWeb.TokenCookies() {
+		unaryChain.Use("session_cookies", grpcutils.StageTransport, grpcutils.SessionCookieInterceptor(logger, grpcutils.SessionCookieOptions{
+			AccessTokens:       cfg.GRPCWeb.SessionCookies,
+			AccessTokenMaxAge:  cfg.JWT.AccessTokenDuration,
+			RefreshTokenMaxAge: cfg.JWT.RefreshTokenDuration,
+			Domain:             cfg.GRPCWeb.Cookie.Domain,
+			Path:               cfg.GRPCWeb.Cookie.Path,
+			SameSite:           cfg.GRPCWeb.Cookie.GetSameSite(),
+		}))
+	}
+	if cfg.Capture.Enabled && !cfg.App.IsProduction() {
+		captureWriter, err := grpcutils.NewCaptureWriter(cfg.Capture.Dir)
+		if err != nil {
+			logger.Fatalf("Failed to create capture file: %v", err)
+		}
+		defer captureWriter.Close()
+		unaryChain.Use("capture", grpcutils.StageDiagnostics, grpcutils.CaptureInterceptor(logger, captureWriter, grpcutils.CaptureOptions{
+			Methods:        cfg.Capture.Methods,
+			RedactedFields: grpcutils.DefaultRedactedFields,
+			Headers:        grpcutils.DefaultCapturedHeaders,
+		}))
+		logger.WithField("file", captureWriter.Path()).Warn("Request capture enabled")
+	}
+	var faultListener *grpcutils.FaultListener
+	if cfg.FaultInjection.Enabled && !cfg.App.IsProduction() {
+		faultRules, err := faultRules(cfg.FaultInjection.Rules)
+		if err != nil {
+			logger.Fatalf("Invalid fault injection rules: %v", err)
+		}
+		faultListener = grpcutils.NewFaultListener()
+		unaryChain.Use("fault_injection", grpcutils.StageDiagnostics, grpcutils.FaultInjectionInterceptor(logger, faultRules, faultListener))
+		logger.WithField("rules", len(faultRules)).Warn("Fault injection enabled")
+	}
+	if cfg.DPoP.Enabled {
+		dpopVerifier := dpop.NewVerifier(cfg.DPoP.ProofMaxAge, cfg.DPoP.ClockSkew)
+		unaryChain.Use("dpop", grpcutils.StageAuth, grpcutils.DPoPInterceptor(logger, dpopVerifier, tokenMaker, cfg.DPoP.RequiredMethods))
+	}
+	if cfg.LoginQueue.Enabled {
+		capacity := cfg.LoginQueue.Capacity
+		if capacity == 0 {
+			// Password hashing is CPU-bound, more concurrent logins only wait longer
+			capacity = runtime.GOMAXPROCS(0)
+		}
+		loginQueue := admission.New(admission.Config{
+			Capacity:        capacity,
+			MaxQueued:       cfg.LoginQueue.MaxQueued,
+			MaxQueuedPerKey: cfg.LoginQueue.MaxQueuedPerCaller,
+		})
+		unaryChain.Use("login_queue", grpcutils.StageAdmission, grpcutils.AdmissionInterceptor(logger, loginQueue, grpcutils.AdmissionOptions{
+			Methods: cfg.LoginQueue.Methods,
+			MaxWait: cfg.LoginQueue.MaxWait,
+		}))
+		logger.WithField("capacity", capacity).Info("Login queue enabled")
+	}
+	if cfg.Quota.Enabled {
+		unaryChain.Use("quota", grpcutils.StageLimits, grpcutils.QuotaInterceptor(logger, quotaService))
+	}
+	if replicaRouter != nil {
+		unaryChain.Use("consistency", grpcutils.StageRouting, grpcutils.ConsistencyInterceptor(logger, replicaRouter, apiMethods))
+	}
+	if len(cfg.Residency.Regions) > 0 {
+		unaryChain.Use("residency", grpcutils.StageRouting, grpcutils.ResidencyInterceptor(residencyRouter, tokenMaker))
+		streamChain.Use("residency", grpcutils.StageRouting, grpcutils.ResidencyStreamInterceptor(residencyRouter, tokenMaker))
+	}
+	if tenantRouter != nil {
+		unaryChain.Use("tenant", grpcutils.StageRouting, grpcutils.TenantInterceptor(tenantRouter, tokenMaker))
+		streamChain.Use("tenant", grpcutils.StageRouting, grpcutils.TenantStreamInterceptor(tenantRouter, tokenMaker))
+	}
+	logger.WithFields(logutils.Fields{
+		"unary":  unaryChain.Names(),
+		"stream": streamChain.Names(),
+	}).Info("gRPC interceptors configured")
+
+	// Create gRPC server with interceptors
+	serverOptions := grpcutils.ServerOptions(unaryChain, streamChain)
+	serverOptions = append(serverOptions, keepaliveOptions(cfg.Server.Keepalive)...)
+	serverOptions = append(serverOptions, grpc.MaxRecvMsgSize(zstd.MaxMessageSize))
+	grpcServer := grpc.NewServer(serverOptions...)
+
+	// Register services; v1 and v2 share the same services and differ only in their messages
 	pb.RegisterUserServiceServer(grpcServer, userHandler)
+	pbv2.RegisterUserServiceServer(grpcServer, handler.NewUserHandlerV2(userService))
 
 	// Enable reflection for development
 	reflection.Register(grpcServer)
 
+	// Report NOT_SERVING until the preflight checks have passed
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus(pb.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus(pbv2.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
 	// Start gRPC server
 	grpcAddr := cfg.Server.GetServerAddr()
 	lis, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
 		logger.Fatalf("Failed to listen: %v", err)
 	}
+	if faultListener != nil {
+		lis = faultListener.Wrap(lis)
+	}
 
-	logger.WithFields(logrus.Fields{
+	logger.WithFields(logutils.Fields{
 		"address":              grpcAddr,
 		"port":                 cfg.Server.Port,
 		"host":                 cfg.Server.Host,
@@ -97,6 +686,7 @@ func main() {
 		"jwt_access_duration":  cfg.JWT.AccessTokenDuration,
 		"jwt_refresh_duration": cfg.JWT.RefreshTokenDuration,
 		"log_level":            cfg.Log.Level,
+		"quota":                cfg.Quota.Enabled,
 		"reflection":           "enabled",
 	}).Info("gRPC server starting")
 
@@ -114,22 +704,47 @@ func main() {
 		})
 		defer asyncQClient.Close()
 
+		eventBusBreaker := breaker.New(breakerConfig("event_bus", cfg.CircuitBreaker.EventBus))
+
+		// Without the notifier, user events are published as a single task for the email service
+		var notificationRouter workers.Notifier
+		if cfg.Notifier.Enabled {
+			router := notifier.NewRouter(
+				logger,
+				notificationPreferenceRepo,
+				repository.NewNotificationDeliveryRepository(store),
+				notificationRoutes,
+				retry.Policy{
+					MaxAttempts:    cfg.Notifier.MaxAttempts,
+					InitialBackoff: cfg.Notifier.InitialBackoff,
+					MaxBackoff:     cfg.Notifier.MaxBackoff,
+					Multiplier:     2,
+				},
+				cfg.Notifier.Lease,
+				cfg.Notifier.BatchSize,
+				notificationSenders(cfg.Notifier.Channels, asyncQClient, eventBusBreaker, cfg.Worker.Notification.MaxRetries)...,
+			)
+			router.Start(appCtx, &wg, cfg.Notifier.RetryInterval)
+			notificationRouter = router
+		}
+
 		notificationWorker = workers.NewNotificationWorker(
 			logger,
 			asyncQClient,
+			eventBusBreaker,
 			notificationEventLogRepo,
+			emailTemplates,
+			notificationRouter,
 			&wg,
 			cfg.Worker.Notification.Interval,
 			cfg.Worker.Notification.MaxRetries,
 			cfg.Worker.Notification.BatchSize,
 		)
 
-		// Start worker with application context
-		go func() {
-			notificationWorker.Start(appCtx)
-		}()
+		// Start registers the worker with wg, so it must not run on a goroutine of its own
+		notificationWorker.Start(appCtx)
 
-		logger.WithFields(logrus.Fields{
+		logger.WithFields(logutils.Fields{
 			"interval":    cfg.Worker.Notification.Interval,
 			"max_retries": cfg.Worker.Notification.MaxRetries,
 			"batch_size":  cfg.Worker.Notification.BatchSize,
@@ -138,10 +753,160 @@ func main() {
 		logger.Info("Notification worker disabled")
 	}
 
+	// Start job scheduler if enabled and there is a job to run
+	var scheduledJobs []workers.Job
+	if cfg.Worker.SecurityDigest.Enabled {
+		scheduledJobs = append(scheduledJobs, workers.Job{
+			Name:   "security_digest",
+			Period: workers.PreviousMonth,
+			Run:    activityService.SendSecurityDigests,
+		})
+	}
+	if cfg.Nonces.Backend == "postgres" {
+		scheduledJobs = append(scheduledJobs, workers.Job{
+			Name:   "nonce_purge",
+			Period: workers.PreviousDay,
+			Run:    nonces.NewPostgresStore(store.DB().DB).Purge,
+		})
+	}
+	if cfg.Registration.RequiresEmailOTP() {
+		scheduledJobs = append(scheduledJobs, workers.Job{
+			Name:   "registration_code_purge",
+			Period: workers.PreviousDay,
+			Run:    registrationCodeRepo.Purge,
+		})
+	}
+	scheduledJobs = append(scheduledJobs, workers.Job{
+		Name:   "login_attempt_purge",
+		Period: workers.PreviousDay,
+		Run:    loginHistoryService.PurgeLoginAttempts,
+	})
+	if cfg.Inactivity.Enabled {
+		scheduledJobs = append(scheduledJobs, workers.Job{
+			Name:   "inactive_user_scan",
+			Period: workers.PreviousDay,
+			Run:    inactivityService.FlagInactiveUsers,
+		})
+	}
+	if cfg.OrgAudit.Webhooks.Enabled {
+		scheduledJobs = append(scheduledJobs, workers.Job{
+			Name:   "org_audit_webhooks",
+			Period: workers.Every(cfg.OrgAudit.Webhooks.Interval),
+			Run:    orgAuditService.DeliverWebhooks,
+		})
+	}
+	if cfg.Worker.Scheduler.Enabled && len(scheduledJobs) > 0 {
+		workers.NewScheduler(
+			logger,
+			repository.NewJobRunRepository(store),
+			newLocker(cfg.Locks, store, redisClient),
+			&wg,
+			cfg.Worker.Scheduler.Interval,
+			cfg.Worker.Scheduler.Lease,
+			scheduledJobs...,
+		).Start(appCtx)
+	}
+
+	// Start workflow timer worker so pending workflow steps fire across restarts
+	if cfg.Worker.Timers.Enabled {
+		workers.NewTimerWorker(
+			logger,
+			workflowTimerRepo,
+			txManager,
+			&wg,
+			cfg.Worker.Timers.Interval,
+			cfg.Worker.Timers.BatchSize,
+			retry.Policy{
+				InitialBackoff: cfg.Worker.Timers.InitialBackoff,
+				MaxBackoff:     cfg.Worker.Timers.MaxBackoff,
+				Multiplier:     2,
+			},
+			workers.Workflow{
+				Name: models.WorkflowInvitationReminder,
+				Fire: importService.SendInvitationReminder,
+			},
+		).Start(appCtx)
+	}
+
+	// Start ops HTTP server exposing metrics
+	var opsServer *http.Server
+	if cfg.Ops.Enabled {
+		mux := http.NewServeMux()
+		mux.Handle(cfg.Ops.MetricsPath, metrics.Handler())
+		mux.Handle(cfg.Ops.SLOPath, slo.Handler(sloTracker))
+		mux.HandleFunc(cfg.Ops.HealthPath, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		mux.Handle(cfg.Ops.ReadyPath, readinessHandler(healthServer))
+		if cfg.GraphQL.Enabled {
+			graphqlHandler, err := graphql.NewHandler(logger, adminUserService, bulkService)
+			if err != nil {
+				logger.Fatalf("Failed to build GraphQL schema: %v", err)
+			}
+			mux.Handle(cfg.GraphQL.Path, graphqlHandler)
+		}
+		opsServer = &http.Server{
+			Addr:    cfg.Ops.GetOpsAddr(),
+			Handler: mux,
+		}
+
+		go func() {
+			if err := opsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.WithError(err).Error("Ops server error")
+			}
+		}()
+
+		logger.WithField("address", cfg.Ops.GetOpsAddr()).Info("Ops server started")
+	}
+
+	// Start the gRPC-Web server for browsers, served by the gRPC server and its interceptors
+	var webServer *http.Server
+	if cfg.GRPCWeb.Enabled {
+		webServer = &http.Server{
+			Addr: cfg.GRPCWeb.GetAddr(),
+			Handler: grpcutils.NewWebHandler(grpcServer, grpcutils.WebOptions{
+				AllowedOrigins: cfg.GRPCWeb.AllowedOrigins,
+				AllowedHeaders: cfg.GRPCWeb.AllowedHeaders,
+				MaxAge:         cfg.GRPCWeb.MaxAge,
+				Credentials:    cfg.GRPCWeb.TokenCookies(),
+			}),
+			ReadHeaderTimeout: cfg.GRPCWeb.ReadHeaderTimeout,
+			IdleTimeout:       cfg.GRPCWeb.IdleTimeout,
+		}
+
+		go func() {
+			if err := webServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.WithError(err).Error("gRPC-Web server error")
+			}
+		}()
+
+		logger.WithField("address", cfg.GRPCWeb.GetAddr()).Info("gRPC-Web server started")
+	}
+
 	// Create a channel to receive OS signals
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	// Reopen log files on SIGHUP once logrotate moved them aside
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-appCtx.Done():
+				return
+			case <-hupChan:
+				if err := logutils.Reopen(); err != nil {
+					logger.WithError(err).Error("Failed to reopen log file")
+				}
+				if err := securityStream.Reopen(); err != nil {
+					logger.WithError(err).Error("Failed to reopen security event file")
+				}
+				logger.Info("Reopened log files")
+			}
+		}
+	}()
+
 	// Start the server in a goroutine
 	serverErrChan := make(chan error, 1)
 	go func() {
@@ -151,6 +916,35 @@ func main() {
 		}
 	}()
 
+	// Verify dependencies and warm up before accepting traffic
+	checks := []preflight.Check{
+		{Name: "schema_version", Run: func(ctx context.Context) error {
+			return db.CheckSchemaVersion(ctx, store)
+		}},
+		{Name: "signing_keys", Run: func(context.Context) error {
+			return token.SelfTest(tokenMaker)
+		}},
+		{Name: "warm_connections", Run: func(ctx context.Context) error {
+			return db.WarmConnections(ctx, store, cfg.Preflight.WarmConnections)
+		}},
+	}
+	if tenantRouter != nil {
+		checks = append(checks, preflight.Check{Name: "tenant_schemas", Run: func(ctx context.Context) error {
+			return db.CheckTenantSchemas(ctx, store)
+		}})
+	}
+	if len(cfg.Residency.Regions) > 0 {
+		checks = append(checks, preflight.Check{Name: "residency_regions", Run: residencyRouter.CheckSchemaVersions})
+	}
+	err = preflight.Run(appCtx, logger, cfg.Preflight.Timeout, checks...)
+	if err != nil {
+		logger.Fatalf("Preflight failed: %v", err)
+	}
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(pb.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(pbv2.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
 	logger.Info("gRPC server is running and ready to accept connections")
 
 	// Wait for either shutdown signal or server error
@@ -172,11 +966,20 @@ func main() {
 	// Wait for all components to finish with timeout
 	shutdownDone := make(chan struct{})
 	go func() {
-		// Wait for notification worker to finish
-		if cfg.Worker.Notification.Enabled {
-			logger.Info("Waiting for notification worker to stop...")
-			wg.Wait()
-			logger.Info("Notification worker stopped")
+		// Wait for the notification worker, job scheduler and timer worker to finish
+		logger.Info("Waiting for background workers to stop...")
+		wg.Wait()
+		logger.Info("Background workers stopped")
+
+		// Stop advertising readiness so load balancers drain this instance
+		healthServer.Shutdown()
+
+		// Let browser calls finish before the gRPC server that serves them stops
+		if webServer != nil {
+			logger.Info("Stopping gRPC-Web server...")
+			if err := webServer.Shutdown(shutdownCtx); err != nil {
+				logger.WithError(err).Warn("Failed to stop gRPC-Web server")
+			}
 		}
 
 		// Gracefully stop the gRPC server
@@ -184,6 +987,21 @@ func main() {
 		grpcServer.GracefulStop()
 		logger.Info("gRPC server stopped")
 
+		// Requests may have left work submitting to the pipelines running
+		userService.Wait()
+
+		// Flush async pipelines once no more requests can submit to them
+		logger.Info("Flushing async pipelines...")
+		pipelineCancel()
+		pipelineWg.Wait()
+		logger.Info("Async pipelines flushed")
+
+		if opsServer != nil {
+			if err := opsServer.Shutdown(shutdownCtx); err != nil {
+				logger.WithError(err).Warn("Failed to stop ops server")
+			}
+		}
+
 		close(shutdownDone)
 	}()
 
@@ -198,3 +1016,174 @@ func main() {
 		logger.Info("Forced shutdown completed")
 	}
 }
+
+// pipelineConfig converts stream configuration into pipeline configuration
+func pipelineConfig(name string, cfg config.PipelineStreamConfig) pipeline.Config {
+	return pipeline.Config{
+		Name:          name,
+		BufferSize:    cfg.BufferSize,
+		BatchSize:     cfg.BatchSize,
+		FlushInterval: cfg.FlushInterval,
+		FlushTimeout:  cfg.FlushTimeout,
+		Policy:        pipeline.Policy(cfg.Policy),
+	}
+}
+
+// readinessHandler reports 200 only while the gRPC health status is SERVING
+func readinessHandler(healthServer *health.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := healthServer.Check(r.Context(), &healthpb.HealthCheckRequest{})
+		if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// notificationSenders creates the task sender of every configured notification channel
+func notificationSenders(channels []string, client *asynq.Client, eventBus *breaker.Breaker, maxRetries int) []notifier.Sender {
+	senders := make([]notifier.Sender, 0, len(channels))
+	for _, channel := range channels {
+		switch models.NotificationChannel(channel) {
+		case models.NotificationChannelEmail:
+			senders = append(senders, notifier.NewEmailSender(client, eventBus, maxRetries))
+		case models.NotificationChannelSMS:
+			senders = append(senders, notifier.NewSMSSender(client, eventBus, maxRetries))
+		case models.NotificationChannelPush:
+			senders = append(senders, notifier.NewPushSender(client, eventBus, maxRetries))
+		}
+	}
+	return senders
+}
+
+// breakerConfig converts dependency configuration into circuit breaker configuration
+func breakerConfig(name string, cfg config.BreakerConfig) breaker.Config {
+	return breaker.Config{
+		Name:             name,
+		FailureThreshold: cfg.FailureThreshold,
+		OpenTimeout:      cfg.OpenTimeout,
+		HalfOpenMaxCalls: cfg.HalfOpenMaxCalls,
+	}
+}
+
+// timeoutOptions converts the configured request timeouts
+func timeoutOptions(cfg config.ServerConfig) grpcutils.TimeoutOptions {
+	opts := grpcutils.TimeoutOptions{
+		Default: cfg.RequestTimeout,
+		Methods: make(map[string]time.Duration, len(cfg.MethodTimeouts)),
+	}
+	for _, methodTimeout := range cfg.MethodTimeouts {
+		opts.Methods[methodTimeout.Method] = methodTimeout.Timeout
+	}
+	return opts
+}
+
+// faultRules converts fault injection configuration into interceptor rules
+func faultRules(rules []config.FaultRuleConfig) ([]grpcutils.FaultRule, error) {
+	result := make([]grpcutils.FaultRule, 0, len(rules))
+	for _, rule := range rules {
+		code := codes.OK
+		if rule.ErrorCode != "" {
+			parsed, err := grpcutils.ParseCode(rule.ErrorCode)
+			if err != nil {
+				return nil, err
+			}
+			code = parsed
+		}
+
+		result = append(result, grpcutils.FaultRule{
+			Method:          rule.Method,
+			Probability:     rule.Probability,
+			Latency:         rule.Latency,
+			ErrorCode:       code,
+			ResetConnection: rule.ResetConnection,
+		})
+	}
+
+	return result, nil
+}
+
+// newLocker creates the distributed locker of the configured backend
+func newLocker(cfg config.LocksConfig, store db.Store, redisClient *redis.Client) locks.Locker {
+	switch cfg.Backend {
+	case "postgres":
+		return locks.NewPostgresLocker(store.DB().DB, cfg.TTL/3)
+	case "local":
+		return locks.NewLocalLocker()
+	default:
+		return locks.NewRedisLocker(redisClient, cfg.KeyPrefix, cfg.TTL)
+	}
+}
+
+// newNonceStore creates the one-time nonce store of the configured backend
+func newNonceStore(cfg config.NoncesConfig, store db.Store, redisClient *redis.Client) nonces.Store {
+	switch cfg.Backend {
+	case "postgres":
+		return nonces.NewPostgresStore(store.DB().DB)
+	case "local":
+		return nonces.NewLocalStore()
+	default:
+		return nonces.NewRedisStore(redisClient, cfg.KeyPrefix)
+	}
+}
+
+// newIDTokenVerifier verifies the ID tokens of the configured OpenID Connect providers, each
+// accepted once
+func newIDTokenVerifier(cfg config.OIDCConfig, replays nonces.Store) *oidc.Registry {
+	providers := lo.Map(cfg.Providers, func(provider config.OIDCProviderConfig, _ int) oidc.Provider {
+		return oidc.Provider{
+			Name:      provider.Name,
+			Issuer:    provider.Issuer,
+			ClientIDs: provider.ClientIDs,
+			JWKSURL:   provider.JWKSURL,
+		}
+	})
+
+	return oidc.NewRegistry(&http.Client{Timeout: cfg.HTTPTimeout}, cfg.JWKSCacheTTL, replays, providers...)
+}
+
+// newAvatarStorage connects to the storage bucket, nil if no bucket is configured
+func newAvatarStorage(cfg config.StorageConfig) (service.AvatarStorage, error) {
+	if cfg.Bucket == "" {
+		return nil, nil
+	}
+
+	return storage.NewClient(storage.Config{
+		Endpoint:        cfg.Endpoint,
+		Region:          cfg.Region,
+		Bucket:          cfg.Bucket,
+		AccessKeyID:     cfg.AccessKeyID,
+		SecretAccessKey: cfg.SecretAccessKey,
+		PathStyle:       cfg.PathStyle,
+	}, &http.Client{Timeout: cfg.Timeout})
+}
+
+// newCaptchaVerifier checks captchas with the configured siteverify endpoint, nil if none is
+// configured
+func newCaptchaVerifier(cfg config.AbuseCaptchaConfig) abuse.CaptchaVerifier {
+	if cfg.VerifyURL == "" {
+		return nil
+	}
+
+	return captcha.NewVerifier(&http.Client{Timeout: cfg.Timeout}, cfg.VerifyURL, cfg.Secret)
+}
+
+// keepaliveOptions limits the age of client connections, so clients reconnect through the
+// load balancer and reach the pods of a rolling restart. gRPC adds a +/-10% jitter to the
+// age, so clients do not all reconnect at once.
+func keepaliveOptions(cfg config.KeepaliveConfig) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     cfg.MaxConnectionIdle,
+			MaxConnectionAge:      cfg.MaxConnectionAge,
+			MaxConnectionAgeGrace: cfg.MaxConnectionAgeGrace,
+			Time:                  cfg.Time,
+			Timeout:               cfg.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.MinTime,
+			PermitWithoutStream: cfg.PermitWithoutStream,
+		}),
+	}
+}
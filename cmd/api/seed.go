@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+
+	pb "user-svc/api/proto/v1"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/pkg/client"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// seedBatchSize is the most users per BatchAssignRole call, admin.max_batch_users by default
+const seedBatchSize = 500
+
+// seedDomains are the email domains of users outside organizations; like the domains of
+// organizations, they are reserved for examples, so no email reaches a real inbox
+var seedDomains = []string{"example.com", "example.net", "example.org"}
+
+// seedOptions are the flags of the seed command
+type seedOptions struct {
+	Orgs        int
+	Users       int
+	StaffRatio  float64
+	Admins      int
+	Sessions    int
+	Password    string
+	Seed        int64
+	Concurrency int
+}
+
+// runSeedCommand runs `user-svc-api seed`, which fills a running service with fake
+// organizations, users, roles and sessions for development and demos. It goes through the
+// RPCs, so the users are validated and their events, audit logs and notifications are
+// recorded as for real users.
+func runSeedCommand(args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	target := flags.String("target", "localhost:50051", "address of the user-svc to seed")
+	adminKey := flags.String("admin-key", os.Getenv("USER_SVC_ADMIN_KEY"), "admin API key, USER_SVC_ADMIN_KEY by default")
+	var opts seedOptions
+	flags.IntVar(&opts.Orgs, "orgs", 5, "organizations to create")
+	flags.IntVar(&opts.Users, "users", 100, "users to register")
+	flags.Float64Var(&opts.StaffRatio, "staff", 0.2, "share of the users registered as staff of an organization")
+	flags.IntVar(&opts.Admins, "admins", 2, "users outside organizations granted the admin role")
+	flags.IntVar(&opts.Sessions, "sessions", 2, "logins per user after registering, each a session")
+	flags.StringVar(&opts.Password, "password", "Seed-passw0rd", "password of every user, so demo users can log in")
+	flags.Int64Var(&opts.Seed, "seed", 0, "seed of the fake data, 0 for a random one")
+	flags.IntVar(&opts.Concurrency, "concurrency", 8, "concurrent registrations and logins")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *adminKey == "" && (opts.Orgs > 0 || opts.Admins > 0) {
+		return fmt.Errorf("-admin-key is required to create organizations and grant roles")
+	}
+	if opts.Users < 0 || opts.Orgs < 0 || opts.Admins < 0 || opts.Sessions < 0 || opts.Concurrency < 1 {
+		return fmt.Errorf("counts must not be negative and -concurrency must be at least 1")
+	}
+	if opts.StaffRatio < 0 || opts.StaffRatio > 1 {
+		return fmt.Errorf("-staff must be between 0 and 1")
+	}
+
+	userClient, conn, err := client.New(*target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", *target, err)
+	}
+	defer conn.Close()
+
+	plan := newSeedPlan(opts)
+	counts, err := seed(context.Background(), userClient, *adminKey, plan, opts)
+	fmt.Printf("organizations created %d, existing %d, users created %d, existing %d, failed %d, staff %d, admins %d, sessions %d\n",
+		counts.orgs, counts.existingOrgs, counts.created, counts.existing, counts.failed, counts.staff, counts.admins, counts.sessions)
+	if err != nil {
+		return err
+	}
+	if counts.created > 0 {
+		fmt.Printf("users log in with the password %q\n", opts.Password)
+	}
+	return nil
+}
+
+// seedPlan is the fake data of a seed run, generated before any of it is sent
+type seedPlan struct {
+	Orgs  []seedOrg
+	Users []seedUser
+}
+
+type seedOrg struct {
+	Name   string
+	Domain string
+}
+
+type seedUser struct {
+	Email    string
+	Username string
+	// Org is the index of the organization the user is staff of, -1 for none
+	Org      int
+	Role     string
+	ClientID string
+	Sessions []seedSession
+}
+
+type seedSession struct {
+	ClientID   string
+	RememberMe bool
+}
+
+// newSeedPlan generates the organizations and users of a seed run; the same options and
+// seed generate the same plan
+func newSeedPlan(opts seedOptions) seedPlan {
+	faker := gofakeit.New(opts.Seed)
+	var plan seedPlan
+
+	names := make(map[string]int)
+	for i := 0; i < opts.Orgs; i++ {
+		name := faker.Company()
+		if names[name]++; names[name] > 1 {
+			name = fmt.Sprintf("%s %d", name, names[name])
+		}
+		plan.Orgs = append(plan.Orgs, seedOrg{Name: name, Domain: slug(name, "-") + ".example.com"})
+	}
+
+	admins := 0
+	for i := 0; i < opts.Users; i++ {
+		first, last := slug(faker.FirstName(), ""), slug(faker.LastName(), "")
+		user := seedUser{Org: -1, Role: "customer", ClientID: faker.RandomString([]string{"web", "mobile"})}
+
+		domain := faker.RandomString(seedDomains)
+		switch {
+		case len(plan.Orgs) > 0 && faker.Float64Range(0, 1) < opts.StaffRatio:
+			user.Org = faker.IntRange(0, len(plan.Orgs)-1)
+			user.Role = "staff"
+			domain = plan.Orgs[user.Org].Domain
+		case admins < opts.Admins:
+			user.Role = "admin"
+			admins++
+		}
+
+		// The index keeps emails and usernames unique within the run
+		suffix := fmt.Sprintf("%d", i+1)
+		user.Email = fmt.Sprintf("%s.%s%s@%s", first, last, suffix, domain)
+		username := first + "_" + last
+		if maxLength := 30 - len(suffix); len(username) > maxLength {
+			username = username[:maxLength]
+		}
+		user.Username = username + suffix
+
+		for j := 0; j < opts.Sessions; j++ {
+			user.Sessions = append(user.Sessions, seedSession{
+				ClientID:   faker.RandomString([]string{"web", "mobile", "kiosk"}),
+				RememberMe: faker.Bool(),
+			})
+		}
+		plan.Users = append(plan.Users, user)
+	}
+	return plan
+}
+
+// slug lowercases s and keeps its ASCII letters and digits, joining words with sep
+func slug(s, sep string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "user"
+	}
+	return strings.Join(words, sep)
+}
+
+type seedCounts struct {
+	orgs, existingOrgs, created, existing, failed, staff, admins, sessions int
+}
+
+// seed creates the organizations of a plan, registers its users into them, grants their
+// roles and logs them in. Organizations and users that already exist, e.g. of an earlier run
+// with the same seed, are reused and skipped; users that fail to register are printed and
+// counted.
+func seed(ctx context.Context, userClient pb.UserServiceClient, adminKey string, plan seedPlan, opts seedOptions) (seedCounts, error) {
+	var counts seedCounts
+	adminCtx := metadata.AppendToOutgoingContext(ctx, "x-admin-key", adminKey)
+
+	orgIDs := make([]string, len(plan.Orgs))
+	for i, org := range plan.Orgs {
+		existing, err := userClient.GetOrganizationByName(adminCtx, &pb.GetOrganizationByNameRequest{Name: org.Name})
+		switch {
+		case err == nil:
+			orgIDs[i] = existing.GetId()
+			counts.existingOrgs++
+			continue
+		case status.Code(err) != codes.NotFound:
+			return counts, fmt.Errorf("failed to look up organization %s: %w", org.Name, err)
+		}
+
+		resp, err := userClient.CreateOrganization(adminCtx, &pb.CreateOrganizationRequest{
+			Name:                org.Name,
+			AllowedEmailDomains: []string{org.Domain},
+		})
+		if err != nil {
+			return counts, fmt.Errorf("failed to create organization %s: %w", org.Name, err)
+		}
+		orgIDs[i] = resp.GetId()
+		counts.orgs++
+	}
+
+	var mu sync.Mutex
+	userIDs := make([]string, len(plan.Users))
+	parallel(len(plan.Users), opts.Concurrency, func(i int) {
+		user := plan.Users[i]
+		req := &pb.RegisterRequest{
+			Email:    user.Email,
+			Username: user.Username,
+			Password: opts.Password,
+			ClientId: user.ClientID,
+		}
+		if user.Org >= 0 {
+			req.OrganizationId = orgIDs[user.Org]
+		}
+		resp, err := userClient.Register(ctx, req)
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case err == nil:
+			userIDs[i] = resp.GetUser().GetId()
+			counts.created++
+		case status.Code(err) == codes.AlreadyExists:
+			counts.existing++
+		default:
+			fmt.Printf("%s: %v\n", user.Email, err)
+			counts.failed++
+		}
+	})
+
+	byRole := map[string][]string{}
+	for i, user := range plan.Users {
+		if userIDs[i] != "" && user.Role != "customer" {
+			byRole[user.Role] = append(byRole[user.Role], userIDs[i])
+		}
+	}
+	for _, role := range []string{"staff", "admin"} {
+		granted, err := assignRole(adminCtx, userClient, byRole[role], role)
+		if role == "staff" {
+			counts.staff = granted
+		} else {
+			counts.admins = granted
+		}
+		if err != nil {
+			return counts, err
+		}
+	}
+
+	var loginErr error
+	parallel(len(plan.Users), opts.Concurrency, func(i int) {
+		if userIDs[i] == "" {
+			return
+		}
+		for _, session := range plan.Users[i].Sessions {
+			_, err := userClient.Login(ctx, &pb.LoginRequest{
+				Email:      plan.Users[i].Email,
+				Password:   opts.Password,
+				ClientId:   session.ClientID,
+				RememberMe: session.RememberMe,
+			})
+
+			mu.Lock()
+			if err == nil {
+				counts.sessions++
+			} else {
+				loginErr = errors.Join(loginErr, fmt.Errorf("failed to log in %s: %w", plan.Users[i].Email, err))
+			}
+			mu.Unlock()
+		}
+	})
+	return counts, loginErr
+}
+
+// assignRole grants role to users in batches and returns how many were granted it
+func assignRole(ctx context.Context, userClient pb.UserServiceClient, userIDs []string, role string) (int, error) {
+	granted := 0
+	for start := 0; start < len(userIDs); start += seedBatchSize {
+		end := min(start+seedBatchSize, len(userIDs))
+		resp, err := userClient.BatchAssignRole(ctx, &pb.BatchAssignRoleRequest{
+			UserIds: userIDs[start:end],
+			Role:    role,
+			Reason:  "seed data",
+		})
+		if err != nil {
+			return granted, fmt.Errorf("failed to grant the %s role: %w", role, err)
+		}
+		for _, result := range resp.GetResults() {
+			// Users of an earlier run may already have the role
+			if result.GetStatus() == string(dto.BatchItemStatusUpdated) || result.GetStatus() == string(dto.BatchItemStatusUnchanged) {
+				granted++
+			}
+		}
+	}
+	return granted, nil
+}
+
+// parallel calls fn with 0 to n-1 from at most concurrency goroutines and waits for them
+func parallel(n, concurrency int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	pb "user-svc/api/proto/v1"
+	"user-svc/internal/app/domains/dto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func testSeedOptions() seedOptions {
+	return seedOptions{
+		Orgs:        3,
+		Users:       50,
+		StaffRatio:  0.3,
+		Admins:      2,
+		Sessions:    2,
+		Password:    "Seed-passw0rd",
+		Seed:        42,
+		Concurrency: 4,
+	}
+}
+
+func TestNewSeedPlan(t *testing.T) {
+	opts := testSeedOptions()
+	plan := newSeedPlan(opts)
+
+	if !reflect.DeepEqual(plan, newSeedPlan(opts)) {
+		t.Errorf("Expected the same seed to generate the same plan")
+	}
+	if len(plan.Orgs) != opts.Orgs || len(plan.Users) != opts.Users {
+		t.Fatalf("Expected %d orgs and %d users, got %d and %d", opts.Orgs, opts.Users, len(plan.Orgs), len(plan.Users))
+	}
+
+	emails, usernames := map[string]bool{}, map[string]bool{}
+	roles := map[string]int{}
+	for _, user := range plan.Users {
+		req := dto.RegisterReq{Email: user.Email, Username: user.Username, Password: opts.Password, ClientID: user.ClientID}
+		if err := req.Validate(); err != nil {
+			t.Errorf("Expected %s to be a valid registration, got %v", user.Email, err)
+		}
+		if emails[user.Email] || usernames[user.Username] {
+			t.Errorf("Expected unique emails and usernames, got %s twice", user.Email)
+		}
+		emails[user.Email], usernames[user.Username] = true, true
+
+		if user.Org >= 0 && !strings.HasSuffix(user.Email, "@"+plan.Orgs[user.Org].Domain) {
+			t.Errorf("Expected staff email %s in the domain of %s", user.Email, plan.Orgs[user.Org].Name)
+		}
+		if len(user.Sessions) != opts.Sessions {
+			t.Errorf("Expected %d sessions, got %d", opts.Sessions, len(user.Sessions))
+		}
+		roles[user.Role]++
+	}
+	if roles["admin"] != opts.Admins || roles["staff"] == 0 {
+		t.Errorf("Expected %d admins and some staff, got %v", opts.Admins, roles)
+	}
+}
+
+// fakeSeedClient records the calls of a seed run
+type fakeSeedClient struct {
+	pb.UserServiceClient
+
+	mu       sync.Mutex
+	orgs     map[string]*pb.Organization
+	existing map[string]bool
+	users    map[string]*pb.RegisterRequest
+	roles    map[string]string
+	logins   int
+	adminKey string
+}
+
+func (c *fakeSeedClient) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest, _ ...grpc.CallOption) (*pb.Organization, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.adminKey = strings.Join(md.Get("x-admin-key"), ",")
+	org := &pb.Organization{Id: fmt.Sprintf("org-%d", len(c.orgs)+1), Name: req.GetName(), AllowedEmailDomains: req.GetAllowedEmailDomains()}
+	c.orgs[req.GetName()] = org
+	return org, nil
+}
+
+func (c *fakeSeedClient) GetOrganizationByName(_ context.Context, req *pb.GetOrganizationByNameRequest, _ ...grpc.CallOption) (*pb.Organization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	org, ok := c.orgs[req.GetName()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "organization not found")
+	}
+	return org, nil
+}
+
+func (c *fakeSeedClient) Register(_ context.Context, req *pb.RegisterRequest, _ ...grpc.CallOption) (*pb.RegisterResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.existing[req.GetEmail()] {
+		return nil, status.Error(codes.AlreadyExists, "user already exists")
+	}
+	id := fmt.Sprintf("user-%d", len(c.users)+1)
+	c.users[id] = req
+	return &pb.RegisterResponse{User: &pb.User{Id: id, Email: req.GetEmail()}}, nil
+}
+
+func (c *fakeSeedClient) BatchAssignRole(_ context.Context, req *pb.BatchAssignRoleRequest, _ ...grpc.CallOption) (*pb.BatchUserResultsResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resp := &pb.BatchUserResultsResponse{}
+	for _, id := range req.GetUserIds() {
+		c.roles[id] = req.GetRole()
+		resp.Results = append(resp.Results, &pb.BatchUserResult{UserId: id, Status: string(dto.BatchItemStatusUpdated)})
+	}
+	return resp, nil
+}
+
+func (c *fakeSeedClient) Login(_ context.Context, req *pb.LoginRequest, _ ...grpc.CallOption) (*pb.LoginResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logins++
+	return &pb.LoginResponse{}, nil
+}
+
+func TestSeed(t *testing.T) {
+	opts := testSeedOptions()
+	plan := newSeedPlan(opts)
+	// The first organization is left over from an earlier run
+	reused := &pb.Organization{Id: "org-earlier", Name: plan.Orgs[0].Name}
+	fake := &fakeSeedClient{
+		orgs:     map[string]*pb.Organization{reused.Name: reused},
+		existing: map[string]bool{plan.Users[0].Email: true},
+		users:    map[string]*pb.RegisterRequest{},
+		roles:    map[string]string{},
+	}
+
+	counts, err := seed(context.Background(), fake, "admin-key", plan, opts)
+	if err != nil {
+		t.Fatalf("Expected seed to succeed, got %v", err)
+	}
+	if len(fake.orgs) != opts.Orgs {
+		t.Errorf("Expected %d organizations, got %d", opts.Orgs, len(fake.orgs))
+	}
+	for _, req := range fake.users {
+		if strings.HasSuffix(req.GetEmail(), "@"+plan.Orgs[0].Domain) && req.GetOrganizationId() != reused.Id {
+			t.Errorf("Expected %s to join the existing organization, got %q", req.GetEmail(), req.GetOrganizationId())
+		}
+	}
+
+	want := seedCounts{orgs: opts.Orgs - 1, existingOrgs: 1, created: opts.Users - 1, existing: 1, sessions: (opts.Users - 1) * opts.Sessions}
+	for _, role := range fake.roles {
+		if role == "staff" {
+			want.staff++
+		} else {
+			want.admins++
+		}
+	}
+	if counts != want {
+		t.Errorf("Expected counts %+v, got %+v", want, counts)
+	}
+	if fake.adminKey != "admin-key" {
+		t.Errorf("Expected admin calls to send the admin key, got %q", fake.adminKey)
+	}
+
+	staff := 0
+	for id, req := range fake.users {
+		if req.GetOrganizationId() != "" {
+			staff++
+			if fake.roles[id] != "staff" {
+				t.Errorf("Expected %s of %s to be granted staff, got %q", req.GetEmail(), req.GetOrganizationId(), fake.roles[id])
+			}
+		}
+	}
+	if staff == 0 || staff != counts.staff {
+		t.Errorf("Expected every organization member to be staff, got %d members and %d staff", staff, counts.staff)
+	}
+}
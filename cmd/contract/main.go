@@ -0,0 +1,124 @@
+// Command contract updates the API contract snapshots of the service, see package contract: the
+// descriptor image and domain errors under api/contract, and the wire bytes of new fixtures.
+// Run it through make contract after changing the API. It refuses to record a breaking change
+// unless -allow-breaking is set, which is meant for deliberate breaks announced to clients.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"user-svc/internal/contract"
+)
+
+func main() {
+	out := flag.String("out", "api/contract", "directory to write the snapshots to")
+	errsDir := flag.String("errs", "internal/app/domains/errs", "directory of the errs package")
+	fixturesDir := flag.String("fixtures", "internal/contract/testdata/fixtures", "directory of the fixtures")
+	allowBreaking := flag.Bool("allow-breaking", false, "record breaking changes")
+	flag.Parse()
+
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		log.Fatalf("Failed to create %s: %v", *out, err)
+	}
+
+	breaking, err := writeImage(filepath.Join(*out, contract.ImageFile))
+	if err != nil {
+		log.Fatalf("Failed to write image: %v", err)
+	}
+	changed, err := writeErrors(filepath.Join(*out, contract.ErrorsFile), *errsDir)
+	if err != nil {
+		log.Fatalf("Failed to write errors: %v", err)
+	}
+	breaking = append(breaking, changed...)
+	if len(breaking) > 0 && !*allowBreaking {
+		for _, change := range breaking {
+			log.Printf("Breaking change: %s", change)
+		}
+		log.Fatalf("Refusing to record %d breaking changes, rerun with -allow-breaking if they are deliberate", len(breaking))
+	}
+
+	if err := recordFixtures(*fixturesDir); err != nil {
+		log.Fatalf("Failed to record fixtures: %v", err)
+	}
+	log.Printf("Contract written to %s", *out)
+}
+
+// writeImage writes the image unless it breaks the one at path; it returns the breaking changes
+func writeImage(path string) ([]string, error) {
+	image := contract.Image()
+	data, err := contract.RenderImage(image)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := os.ReadFile(path)
+	if err == nil {
+		previousImage, err := contract.ParseImage(previous)
+		if err != nil {
+			return nil, err
+		}
+		breaking, err := contract.Breaking(previousImage, image)
+		if err != nil || len(breaking) > 0 {
+			return breaking, err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return nil, os.WriteFile(path, data, 0644)
+}
+
+// writeErrors writes the domain errors unless they change the ones at path; it returns the changes
+func writeErrors(path, errsDir string) ([]string, error) {
+	domainErrors, err := contract.DomainErrors(errsDir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := contract.RenderErrors(domainErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := os.ReadFile(path)
+	if err == nil {
+		previousErrors, err := contract.ParseErrors(previous)
+		if err != nil {
+			return nil, err
+		}
+		if changed := contract.ChangedErrors(previousErrors, domainErrors); len(changed) > 0 {
+			return changed, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return nil, os.WriteFile(path, data, 0644)
+}
+
+// recordFixtures records the wire bytes of the fixtures that have none yet
+func recordFixtures(dir string) error {
+	fixtures, err := contract.LoadFixtures(dir)
+	if err != nil {
+		return err
+	}
+	for name, fixture := range fixtures {
+		if fixture.Recorded() {
+			continue
+		}
+		if err := fixture.Record(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		data, err := fixture.Render()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return err
+		}
+		log.Printf("Recorded fixture %s", name)
+	}
+	return nil
+}
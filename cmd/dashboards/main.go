@@ -0,0 +1,20 @@
+// Command dashboards writes the generated Grafana dashboards of the service, see package
+// observability. Run it through make dashboards so the checked-in JSON stays in sync.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"user-svc/internal/observability"
+)
+
+func main() {
+	out := flag.String("out", "internal/observability/dashboards", "directory to write the dashboards to")
+	flag.Parse()
+
+	if err := observability.Write(*out); err != nil {
+		log.Fatalf("Failed to write dashboards: %v", err)
+	}
+	log.Printf("Dashboards written to %s", *out)
+}
@@ -0,0 +1,200 @@
+// Command import-legacy-users migrates the accounts of the legacy ticketing system by
+// streaming a CSV export to the ImportUsers RPC of a running user-svc. Users are created
+// active with their salted SHA-1 password hash, which the service replaces with a bcrypt
+// hash the first time each user logs in.
+//
+// The CSV file has a header row with the columns email, username, salt and hash, and
+// optionally hash_format and organization_id. hash is the hex SHA-1 digest; rows without a
+// hash_format use -hash-format. Records that were not created are printed with their CSV
+// line, and the command exits with 1 if any failed, so the export can be fixed and
+// imported again: users created by an earlier run are reported as duplicates.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	pb "user-svc/api/proto/v1"
+	"user-svc/pkg/utils/crypt/password"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// requiredColumns must be in the header of the CSV file
+var requiredColumns = []string{"email", "username", "salt", "hash"}
+
+func main() {
+	file := flag.String("file", "", "CSV export of the legacy users")
+	target := flag.String("target", "localhost:50051", "address of the user-svc to import into")
+	adminKey := flag.String("admin-key", os.Getenv("USER_SVC_ADMIN_KEY"), "admin API key, USER_SVC_ADMIN_KEY by default")
+	hashFormat := flag.String("hash-format", password.LegacySHA1SaltPassword,
+		"hash format of rows without one: "+password.LegacySHA1SaltPassword+" or "+password.LegacySHA1PasswordSalt)
+	batchSize := flag.Int("batch", 500, "records per request, at most import.max_batch_size of the service")
+	flag.Parse()
+
+	if *file == "" || *adminKey == "" {
+		log.Fatal("-file and -admin-key are required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", *file, err)
+	}
+	defer f.Close()
+
+	records, err := newRecordReader(f, *hashFormat)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *file, err)
+	}
+
+	conn, err := grpc.NewClient(*target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to %s: %v", *target, err)
+	}
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-admin-key", *adminKey)
+	counts, err := importUsers(ctx, pb.NewUserServiceClient(conn), records, *batchSize)
+	fmt.Printf("created %d, duplicate %d, invalid %d, failed %d\n",
+		counts["created"], counts["duplicate"], counts["invalid"], counts["failed"])
+	if err != nil {
+		log.Fatalf("Import stopped: %v", err)
+	}
+	if counts["failed"] > 0 {
+		os.Exit(1)
+	}
+}
+
+// importUsers sends the records in batches while receiving the results, and prints every
+// record that was not created
+func importUsers(ctx context.Context, client pb.UserServiceClient, records *recordReader, batchSize int) (map[string]int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.ImportUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sendErr := make(chan error, 1)
+	go func() {
+		sendErr <- sendBatches(stream, records, batchSize)
+	}()
+
+	counts := make(map[string]int)
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return counts, err
+		}
+
+		for _, result := range resp.Results {
+			counts[result.Status]++
+			if result.Status != "created" {
+				// The header is line 1 and rows count from 0
+				fmt.Printf("line %d %s: %s %s\n", result.Row+2, result.Email, result.Status, result.Error)
+			}
+		}
+	}
+
+	return counts, <-sendErr
+}
+
+func sendBatches(stream pb.UserService_ImportUsersClient, records *recordReader, batchSize int) error {
+	for {
+		batch, err := records.next(batchSize)
+		if len(batch) > 0 {
+			if err := stream.Send(&pb.ImportUsersRequest{Users: batch}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return stream.CloseSend()
+		}
+		if err != nil {
+			_ = stream.CloseSend()
+			return err
+		}
+	}
+}
+
+// recordReader reads the CSV file one batch at a time, so exports of millions of users
+// are never held in memory
+type recordReader struct {
+	csv        *csv.Reader
+	columns    map[string]int
+	hashFormat string
+}
+
+func newRecordReader(r io.Reader, hashFormat string) (*recordReader, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	return &recordReader{csv: reader, columns: columns, hashFormat: hashFormat}, nil
+}
+
+// next returns up to n records, and io.EOF along with the last ones
+func (r *recordReader) next(n int) ([]*pb.ImportUserRecord, error) {
+	batch := make([]*pb.ImportUserRecord, 0, n)
+	for len(batch) < n {
+		row, err := r.csv.Read()
+		if err != nil {
+			return batch, err
+		}
+
+		hashFormat := r.column(row, "hash_format")
+		if hashFormat == "" {
+			hashFormat = r.hashFormat
+		}
+		batch = append(batch, &pb.ImportUserRecord{
+			Email:          r.column(row, "email"),
+			Username:       r.column(row, "username"),
+			OrganizationId: r.column(row, "organization_id"),
+			LegacyPassword: &pb.LegacyPasswordHash{
+				Format: hashFormat,
+				Salt:   r.column(row, "salt"),
+				Hash:   r.column(row, "hash"),
+			},
+		})
+	}
+	return batch, nil
+}
+
+// column returns the value of a column of the row, empty if the file has no such column.
+// The salt is kept as it is, as its whitespace is part of the hash.
+func (r *recordReader) column(row []string, name string) string {
+	i, ok := r.columns[name]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	if name == "salt" {
+		return row[i]
+	}
+	return strings.TrimSpace(row[i])
+}
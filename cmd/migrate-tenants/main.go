@@ -0,0 +1,104 @@
+// Command migrate-tenants manages the schemas of organizations isolated with
+// tenancy.mode "schema". Run it after init.sql has been applied to the public schema.
+//
+// With -provision it creates the schema of an organization, which must not have users in
+// the shared schema yet. Without it, every registered tenant schema is brought up to the
+// public schema, so the service's schema check passes again. With -dry-run the statements
+// are run and rolled back, and printed either way.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/db"
+	logutils "user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "path to the service configuration")
+	provision := flag.String("provision", "", "ID of an organization to create a schema for")
+	dryRun := flag.Bool("dry-run", false, "print the statements and roll them back")
+	flag.Parse()
+
+	if err := logutils.InitLogger(); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	logger := logutils.GetLogger()
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		logger.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	store, err := db.NewStore(&cfg.Database)
+	if err != nil {
+		logger.Fatalf("Failed to create database store: %v", err)
+	}
+	defer store.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := db.CheckSchemaVersion(ctx, store); err != nil {
+		logger.Fatalf("Apply init.sql to the public schema first: %v", err)
+	}
+
+	migrator := db.NewTenantMigrator(store.DB(), cfg.Tenancy.SchemaPrefix)
+
+	if *provision != "" {
+		organizationID, err := uuid.Parse(*provision)
+		if err != nil {
+			logger.Fatalf("Invalid organization ID %q: %v", *provision, err)
+		}
+
+		schema, statements, err := migrator.Provision(ctx, organizationID, *dryRun)
+		printStatements(schema, statements)
+		if errors.Is(err, db.ErrTenantHasSharedUsers) {
+			logger.Fatalf("Organization %s already has users in the shared schema", organizationID)
+		}
+		if err != nil {
+			logger.Fatalf("Failed to provision %s: %v", schema, err)
+		}
+		if !cfg.Tenancy.SchemaIsolation() {
+			logger.Warn("tenancy.mode is not schema, the service keeps using the shared schema")
+		}
+		logger.Infof("Provisioned %s for organization %s", schema, organizationID)
+		return
+	}
+
+	schemas, err := migrator.Schemas(ctx)
+	if err != nil {
+		logger.Fatalf("Failed to list tenant schemas: %v", err)
+	}
+
+	failed := 0
+	for _, schema := range schemas {
+		statements, err := migrator.Migrate(ctx, schema, *dryRun)
+		printStatements(schema, statements)
+		if err != nil {
+			logger.WithError(err).Errorf("Failed to migrate %s", schema)
+			failed++
+		}
+	}
+
+	logger.Infof("Migrated %d tenant schemas: %d failed", len(schemas)-failed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func printStatements(schema string, statements []string) {
+	for _, statement := range statements {
+		fmt.Printf("%s: %s;\n", schema, statement)
+	}
+}
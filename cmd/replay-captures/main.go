@@ -0,0 +1,236 @@
+// Command replay-captures replays the requests of a capture file against a local user-svc,
+// e.g. to reproduce a client's failing Register sequence. Captures are recorded by the
+// capture interceptor (capture.enabled, development only).
+//
+// Passwords are masked in captures and are replayed with -password. Masked tokens are
+// replaced with the tokens the replayed calls returned, and calls that were authenticated
+// are sent with the latest replayed access token, so sequences such as Register followed
+// by RefreshToken replay as a session. Credentials that are never captured, e.g. the admin
+// key, are passed with -H.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	_ "user-svc/api/proto/v1"
+	grpcutils "user-svc/pkg/utils/grpc"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// redactedValue is the placeholder of masked fields in captures
+const redactedValue = "[REDACTED]"
+
+// passwordFields are the masked fields replayed with -password
+var passwordFields = map[protoreflect.Name]bool{"password": true, "new_password": true}
+
+type headerFlags []string
+
+func (h *headerFlags) String() string { return strings.Join(*h, ",") }
+
+func (h *headerFlags) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return fmt.Errorf("header %q must be key=value", value)
+	}
+	*h = append(*h, value)
+	return nil
+}
+
+// session carries the tokens returned by the replayed calls
+type session struct {
+	values map[protoreflect.Name]string
+}
+
+func main() {
+	file := flag.String("file", "", "capture file to replay")
+	target := flag.String("target", "localhost:50051", "address of the user-svc to replay against")
+	method := flag.String("method", "", "only replay methods containing this string")
+	password := flag.String("password", "Str0ng!Passw0rd", "password replayed for masked password fields")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of each replayed call")
+	var headers headerFlags
+	flag.Var(&headers, "H", "metadata sent with every call as key=value, repeatable")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("-file is required")
+	}
+
+	records, err := readCaptures(*file)
+	if err != nil {
+		log.Fatalf("Failed to read captures: %v", err)
+	}
+
+	conn, err := grpc.NewClient(*target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to %s: %v", *target, err)
+	}
+	defer conn.Close()
+
+	s := &session{values: make(map[protoreflect.Name]string)}
+	mismatches := 0
+	for i, record := range records {
+		if !strings.Contains(record.Method, *method) {
+			continue
+		}
+
+		code, err := s.replay(conn, record, *password, headers, *timeout)
+		if err != nil {
+			log.Fatalf("Failed to replay record %d (%s): %v", i+1, record.Method, err)
+		}
+
+		outcome := "same"
+		if code != record.Code {
+			outcome = "DIFFERENT"
+			mismatches++
+		}
+		fmt.Printf("%4d %-50s captured %-18s replayed %-18s %s\n", i+1, record.Method, record.Code, code, outcome)
+	}
+
+	if mismatches > 0 {
+		fmt.Printf("%d calls returned a different code than captured\n", mismatches)
+		os.Exit(1)
+	}
+}
+
+func readCaptures(path string) ([]*grpcutils.CaptureRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []*grpcutils.CaptureRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record grpcutils.CaptureRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", len(records)+1, err)
+		}
+		records = append(records, &record)
+	}
+
+	return records, scanner.Err()
+}
+
+// replay sends the captured request and returns the status code of the call
+func (s *session) replay(
+	conn *grpc.ClientConn,
+	record *grpcutils.CaptureRecord,
+	password string,
+	headers []string,
+	timeout time.Duration,
+) (string, error) {
+	input, output, err := methodTypes(record.Method)
+	if err != nil {
+		return "", err
+	}
+
+	req := dynamicpb.NewMessage(input)
+	if len(record.Request) > 0 {
+		if err := protojson.Unmarshal(record.Request, req); err != nil {
+			return "", fmt.Errorf("failed to decode request: %w", err)
+		}
+	}
+	s.unmask(req, password)
+
+	md := metadata.MD{}
+	for key, values := range record.Metadata {
+		md.Append(key, values...)
+	}
+	for _, header := range headers {
+		key, value, _ := strings.Cut(header, "=")
+		md.Set(key, value)
+	}
+	if accessToken := s.values["access_token"]; record.Authenticated && accessToken != "" {
+		md.Set("authorization", "Bearer "+accessToken)
+	}
+
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(context.Background(), md), timeout)
+	defer cancel()
+
+	resp := dynamicpb.NewMessage(output)
+	if err := conn.Invoke(ctx, record.Method, req, resp); err != nil {
+		return status.Code(err).String(), nil
+	}
+	s.remember(resp)
+
+	return "OK", nil
+}
+
+// methodTypes looks up the request and response types of a full method name
+func methodTypes(fullMethod string) (protoreflect.MessageDescriptor, protoreflect.MessageDescriptor, error) {
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid method %q", fullMethod)
+	}
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unknown service %q", serviceName)
+	}
+	service, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, nil, fmt.Errorf("%q is not a service", serviceName)
+	}
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, nil, fmt.Errorf("unknown method %q", fullMethod)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, nil, fmt.Errorf("streaming method %q cannot be replayed", fullMethod)
+	}
+
+	return method.Input(), method.Output(), nil
+}
+
+// unmask replaces masked string fields with the replay password or the value the session
+// last received for a field of the same name
+func (s *session) unmask(m protoreflect.Message, password string) {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap():
+			s.unmask(v.Message(), password)
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() && v.String() == redactedValue:
+			fields = append(fields, fd)
+		}
+		return true
+	})
+
+	for _, fd := range fields {
+		value := s.values[fd.Name()]
+		if passwordFields[fd.Name()] {
+			value = password
+		}
+		m.Set(fd, protoreflect.ValueOfString(value))
+	}
+}
+
+// remember records the string fields of a response, e.g. the tokens of a login
+func (s *session) remember(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap():
+			s.remember(v.Message())
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap():
+			s.values[fd.Name()] = v.String()
+		}
+		return true
+	})
+}
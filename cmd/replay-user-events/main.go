@@ -0,0 +1,74 @@
+// Command replay-user-events rebuilds the users table from the user_events streams.
+//
+// With -backfill it first starts a stream for every user created before events were
+// recorded, so the replay covers all users. With -dry-run it only reports the users whose
+// stored state drifted from their stream.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/repository"
+	"user-svc/internal/app/service"
+	"user-svc/internal/db"
+	logutils "user-svc/pkg/utils/log"
+)
+
+// backfillActor is the actor of the user.backfilled events
+const backfillActor = "system:replay-user-events"
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "path to the service configuration")
+	dryRun := flag.Bool("dry-run", false, "report drifted users without changing them")
+	backfill := flag.Bool("backfill", false, "start a stream for users without events before replaying")
+	flag.Parse()
+
+	if err := logutils.InitLogger(); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	logger := logutils.GetLogger()
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		logger.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	store, err := db.NewStore(&cfg.Database)
+	if err != nil {
+		logger.Fatalf("Failed to create database store: %v", err)
+	}
+	defer store.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	userEventRepo := repository.NewUserEventRepository(store)
+
+	if *backfill {
+		if *dryRun {
+			logger.Fatal("-backfill writes events and cannot be combined with -dry-run")
+		}
+		started, err := userEventRepo.Backfill(ctx, backfillActor)
+		if err != nil {
+			logger.Fatalf("Failed to backfill user events: %v", err)
+		}
+		logger.Infof("Started %d user event streams", started)
+	}
+
+	historyService := service.NewUserHistoryService(cfg, repository.NewUserRepository(store), userEventRepo)
+	report, err := historyService.RebuildUserProjections(ctx, *dryRun)
+	if err != nil {
+		logger.Fatalf("Failed to rebuild user projections: %v", err)
+	}
+
+	logger.Infof("Replayed %d users: %d drifted, %d failed", report.Users, report.Drifted, report.Failed)
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,58 @@
+package abuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Counter counts the attempts of keys in sliding windows shared by all replicas
+type Counter interface {
+	// Incr records an attempt of the key and returns the attempts within the last window
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+// RedisCounter approximates a sliding window with two fixed windows: the attempts of the
+// previous window count in proportion to how much of it still overlaps the sliding one. That
+// takes two keys per counted key instead of one entry per attempt.
+type RedisCounter struct {
+	client redis.UniversalClient
+	prefix string
+	now    func() time.Time
+}
+
+// NewRedisCounter creates a counter keeping its windows under keys starting with prefix
+func NewRedisCounter(client redis.UniversalClient, prefix string) *RedisCounter {
+	return &RedisCounter{
+		client: client,
+		prefix: prefix,
+		now:    time.Now,
+	}
+}
+
+// Incr increments the current fixed window of the key and weighs in the previous one
+func (c *RedisCounter) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	now := c.now().UnixMilli()
+	size := window.Milliseconds()
+	current := now / size
+
+	pipe := c.client.TxPipeline()
+	incr := pipe.Incr(ctx, c.key(key, current))
+	// The window is still needed as the previous one of the next window
+	pipe.PExpire(ctx, c.key(key, current), 2*window)
+	previous := pipe.Get(ctx, c.key(key, current-1))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, fmt.Errorf("failed to count attempt: %w", err)
+	}
+
+	previousCount, _ := previous.Int64()
+	overlap := float64(size-now%size) / float64(size)
+	return incr.Val() + int64(float64(previousCount)*overlap), nil
+}
+
+func (c *RedisCounter) key(key string, window int64) string {
+	return fmt.Sprintf("%s%s:%d", c.prefix, key, window)
+}
@@ -0,0 +1,204 @@
+// Package abuse evaluates registrations and logins against the velocity rules the fraud team
+// manages at runtime, e.g. to put logins from an IP behind a captcha during a bot attack.
+package abuse
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RuleRepository loads the velocity rules
+type RuleRepository interface {
+	List(ctx context.Context) ([]*models.VelocityRule, error)
+}
+
+// CaptchaVerifier checks captchas solved by clients
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// Engine evaluates attempts against the velocity rules. Every replica keeps the rules in
+// memory; a change is broadcast over Redis pub/sub, and replicas also resync from the database
+// periodically and on startup. The engine fails open: attempts whose counters or captcha
+// cannot be checked are let through, so an outage of Redis or of the captcha provider does
+// not stop every login.
+type Engine struct {
+	repo           RuleRepository
+	counter        Counter
+	captcha        CaptchaVerifier
+	client         *redis.Client
+	channel        string
+	resyncInterval time.Duration
+	logger         *logutils.Logger
+	rules          atomic.Pointer[[]*models.VelocityRule]
+}
+
+// NewEngine creates an engine without rules until Start or Reload. Without a captcha verifier,
+// attempts answered with a captcha are refused.
+func NewEngine(
+	repo RuleRepository,
+	counter Counter,
+	captcha CaptchaVerifier,
+	client *redis.Client,
+	channel string,
+	resyncInterval time.Duration,
+	logger *logutils.Logger,
+) *Engine {
+	e := &Engine{
+		repo:           repo,
+		counter:        counter,
+		captcha:        captcha,
+		client:         client,
+		channel:        channel,
+		resyncInterval: resyncInterval,
+		logger:         logger,
+	}
+	e.rules.Store(&[]*models.VelocityRule{})
+	return e
+}
+
+// CheckVelocity counts the attempt for every enabled rule of its action and applies the
+// strictest response of the rules it exceeds: ErrTooManyAttempts for lock, ErrCaptchaRequired
+// for captcha unless the attempt carries a solved captcha, and nothing but a metric and a
+// log entry for alert.
+func (e *Engine) CheckVelocity(ctx context.Context, attempt models.VelocityAttempt) error {
+	var triggered *models.VelocityRule
+	for _, rule := range *e.rules.Load() {
+		if !rule.Enabled || rule.Action != attempt.Action {
+			continue
+		}
+		subject := attempt.Subject(rule.Entity)
+		if subject == "" {
+			continue
+		}
+
+		// Subjects are hashed so emails and device IDs are not kept in Redis
+		count, err := e.counter.Incr(ctx, rule.Name+":"+token.HashToken(subject), rule.Window)
+		if err != nil {
+			metrics.VelocityCheckErrors.Inc()
+			e.logger.WithError(err).WithField("rule", rule.Name).Warn("Failed to count attempt, letting it through")
+			continue
+		}
+		if count <= int64(rule.Threshold) {
+			continue
+		}
+
+		metrics.VelocityRuleTriggered.WithLabelValues(rule.Name, string(rule.Response)).Inc()
+		e.logger.WithFields(logutils.Fields{
+			"rule":     rule.Name,
+			"entity":   rule.Entity,
+			"subject":  subject,
+			"action":   attempt.Action,
+			"attempts": count,
+			"response": rule.Response,
+		}).Warn("Velocity rule triggered")
+
+		if triggered == nil || rule.Response.Severity() > triggered.Response.Severity() {
+			triggered = rule
+		}
+	}
+	if triggered == nil {
+		return nil
+	}
+
+	switch triggered.Response {
+	case models.VelocityResponseLock:
+		return errs.ErrTooManyAttempts
+	case models.VelocityResponseCaptcha:
+		return e.checkCaptcha(ctx, attempt)
+	}
+	return nil
+}
+
+// checkCaptcha fails with ErrCaptchaRequired unless the attempt carries a solved captcha
+func (e *Engine) checkCaptcha(ctx context.Context, attempt models.VelocityAttempt) error {
+	if e.captcha == nil || attempt.CaptchaToken == "" {
+		return errs.ErrCaptchaRequired
+	}
+
+	solved, err := e.captcha.Verify(ctx, attempt.CaptchaToken, attempt.IP)
+	if err != nil {
+		metrics.VelocityCheckErrors.Inc()
+		e.logger.WithError(err).Warn("Failed to verify captcha, letting the attempt through")
+		return nil
+	}
+	if !solved {
+		return errs.ErrCaptchaRequired
+	}
+	return nil
+}
+
+// Reload loads the rules from the database
+func (e *Engine) Reload(ctx context.Context) error {
+	rules, err := e.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	e.rules.Store(&rules)
+	return nil
+}
+
+// PublishRules reloads the rules on this replica and tells the other replicas to reload them.
+// A failed broadcast is only logged since other replicas pick the rules up on resync.
+func (e *Engine) PublishRules(ctx context.Context) error {
+	if err := e.Reload(ctx); err != nil {
+		return err
+	}
+
+	if err := e.client.Publish(ctx, e.channel, "reload").Err(); err != nil {
+		e.logger.WithError(err).Warn("Failed to broadcast velocity rules, replicas will pick them up on resync")
+	}
+	return nil
+}
+
+// Start subscribes to rule changes and resyncs from the database until ctx is cancelled
+func (e *Engine) Start(ctx context.Context, wg *sync.WaitGroup) {
+	e.logger.WithField("channel", e.channel).Info("Starting abuse engine")
+
+	pubsub := e.client.Subscribe(ctx, e.channel)
+	e.resync(ctx)
+
+	wg.Add(1)
+	go func() {
+		defer func() {
+			_ = pubsub.Close()
+			wg.Done()
+			e.logger.Info("Abuse engine stopped")
+		}()
+
+		ticker := time.NewTicker(e.resyncInterval)
+		defer ticker.Stop()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-messages:
+				if !ok {
+					return
+				}
+				e.resync(ctx)
+			case <-ticker.C:
+				e.resync(ctx)
+			}
+		}
+	}()
+}
+
+// resync reloads the rules, keeping the loaded ones if the database is unavailable
+func (e *Engine) resync(ctx context.Context) {
+	if err := e.Reload(ctx); err != nil {
+		e.logger.WithError(err).Warn("Failed to reload velocity rules")
+	}
+}
@@ -0,0 +1,152 @@
+package abuse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	logutils "user-svc/pkg/utils/log"
+)
+
+type fakeRules []*models.VelocityRule
+
+func (r fakeRules) List(context.Context) ([]*models.VelocityRule, error) {
+	return r, nil
+}
+
+// fakeCounter counts attempts without windows
+type fakeCounter struct {
+	counts map[string]int64
+	err    error
+}
+
+func (c *fakeCounter) Incr(_ context.Context, key string, _ time.Duration) (int64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	c.counts[key]++
+	return c.counts[key], nil
+}
+
+type fakeCaptcha struct {
+	solved string
+	err    error
+}
+
+func (c fakeCaptcha) Verify(_ context.Context, token, _ string) (bool, error) {
+	return token == c.solved, c.err
+}
+
+func newTestEngine(t *testing.T, counter Counter, captcha CaptchaVerifier, rules ...*models.VelocityRule) *Engine {
+	t.Helper()
+	logger := logutils.New(io.Discard)
+	engine := NewEngine(fakeRules(rules), counter, captcha, nil, "velocity-rules", time.Minute, logger)
+	if err := engine.Reload(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return engine
+}
+
+func rule(name string, entity models.VelocityEntity, threshold int, response models.VelocityResponse) *models.VelocityRule {
+	return &models.VelocityRule{
+		Name:      name,
+		Entity:    entity,
+		Action:    models.VelocityActionLogin,
+		Threshold: threshold,
+		Window:    time.Minute,
+		Response:  response,
+		Enabled:   true,
+	}
+}
+
+func TestEngine_CheckVelocity(t *testing.T) {
+	engine := newTestEngine(t, &fakeCounter{counts: map[string]int64{}}, fakeCaptcha{solved: "solved"},
+		rule("login-ip-captcha", models.VelocityEntityIP, 2, models.VelocityResponseCaptcha),
+		rule("login-ip-lock", models.VelocityEntityIP, 5, models.VelocityResponseLock),
+		rule("login-email-alert", models.VelocityEntityEmail, 1, models.VelocityResponseAlert),
+	)
+	ctx := context.Background()
+	attempt := models.VelocityAttempt{Action: models.VelocityActionLogin, IP: "203.0.113.7", Email: "Fan@example.com"}
+
+	for i := 0; i < 2; i++ {
+		if err := engine.CheckVelocity(ctx, attempt); err != nil {
+			t.Fatalf("Expected attempt %d to pass, got %v", i+1, err)
+		}
+	}
+
+	// Over the captcha threshold, the alert rule of the email is exceeded as well
+	if err := engine.CheckVelocity(ctx, attempt); !errors.Is(err, errs.ErrCaptchaRequired) {
+		t.Errorf("Expected ErrCaptchaRequired, got %v", err)
+	}
+	attempt.CaptchaToken = "forged"
+	if err := engine.CheckVelocity(ctx, attempt); !errors.Is(err, errs.ErrCaptchaRequired) {
+		t.Errorf("Expected a forged captcha to be refused, got %v", err)
+	}
+	attempt.CaptchaToken = "solved"
+	if err := engine.CheckVelocity(ctx, attempt); err != nil {
+		t.Errorf("Expected a solved captcha to pass, got %v", err)
+	}
+
+	// The lock rule is stricter than the captcha rule, so a captcha no longer helps
+	if err := engine.CheckVelocity(ctx, attempt); !errors.Is(err, errs.ErrTooManyAttempts) {
+		t.Errorf("Expected ErrTooManyAttempts, got %v", err)
+	}
+
+	// Registrations are not counted by login rules
+	attempt.Action = models.VelocityActionRegister
+	if err := engine.CheckVelocity(ctx, attempt); err != nil {
+		t.Errorf("Expected a registration to pass, got %v", err)
+	}
+}
+
+func TestEngine_CheckVelocitySkippedRules(t *testing.T) {
+	counter := &fakeCounter{counts: map[string]int64{}}
+	disabled := rule("login-ip-lock", models.VelocityEntityIP, 1, models.VelocityResponseLock)
+	disabled.Enabled = false
+	engine := newTestEngine(t, counter, nil,
+		disabled,
+		rule("login-device-lock", models.VelocityEntityDevice, 1, models.VelocityResponseLock),
+	)
+	ctx := context.Background()
+	attempt := models.VelocityAttempt{Action: models.VelocityActionLogin, IP: "203.0.113.7"}
+
+	for i := 0; i < 3; i++ {
+		if err := engine.CheckVelocity(ctx, attempt); err != nil {
+			t.Fatalf("Expected attempt %d to pass, got %v", i+1, err)
+		}
+	}
+	if len(counter.counts) != 0 {
+		t.Errorf("Expected disabled rules and attempts without a device not to be counted, got %v", counter.counts)
+	}
+}
+
+func TestEngine_CheckVelocityFailsOpen(t *testing.T) {
+	ctx := context.Background()
+	attempt := models.VelocityAttempt{Action: models.VelocityActionLogin, IP: "203.0.113.7", CaptchaToken: "solved"}
+
+	engine := newTestEngine(t, &fakeCounter{err: errors.New("redis unavailable")}, nil,
+		rule("login-ip-lock", models.VelocityEntityIP, 0, models.VelocityResponseLock),
+	)
+	if err := engine.CheckVelocity(ctx, attempt); err != nil {
+		t.Errorf("Expected attempts to pass when they cannot be counted, got %v", err)
+	}
+
+	engine = newTestEngine(t, &fakeCounter{counts: map[string]int64{}}, fakeCaptcha{err: errors.New("provider unavailable")},
+		rule("login-ip-captcha", models.VelocityEntityIP, 0, models.VelocityResponseCaptcha),
+	)
+	if err := engine.CheckVelocity(ctx, attempt); err != nil {
+		t.Errorf("Expected attempts to pass when the captcha cannot be verified, got %v", err)
+	}
+
+	// Without a captcha provider, a captcha can never be solved
+	engine = newTestEngine(t, &fakeCounter{counts: map[string]int64{}}, nil,
+		rule("login-ip-captcha", models.VelocityEntityIP, 0, models.VelocityResponseCaptcha),
+	)
+	if err := engine.CheckVelocity(ctx, attempt); !errors.Is(err, errs.ErrCaptchaRequired) {
+		t.Errorf("Expected ErrCaptchaRequired, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+// Package canary watches canary accounts, accounts seeded for breach detection whose any login
+// or token use raises a high-severity alert.
+package canary
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/google/uuid"
+)
+
+// Repository loads the canary accounts
+type Repository interface {
+	List(ctx context.Context) ([]*models.CanaryAccount, error)
+}
+
+// EventPipeline persists the alerts for the notification worker to publish
+type EventPipeline interface {
+	Submit(ctx context.Context, event *repository.NotificationEventLog) error
+}
+
+// Monitor keeps the canary accounts in memory, so checking every login and token against them
+// costs no query, and raises the alerts of their accesses. Every replica resyncs the accounts
+// from the database periodically and on startup, and after changing them itself.
+type Monitor struct {
+	repo           Repository
+	events         EventPipeline
+	resyncInterval time.Duration
+	tarpitDelay    time.Duration
+	logger         *logutils.Logger
+	accounts       atomic.Pointer[map[uuid.UUID]*models.CanaryAccount]
+}
+
+// NewMonitor creates a monitor without canary accounts until Start or Reload
+func NewMonitor(
+	repo Repository,
+	events EventPipeline,
+	resyncInterval time.Duration,
+	tarpitDelay time.Duration,
+	logger *logutils.Logger,
+) *Monitor {
+	m := &Monitor{
+		repo:           repo,
+		events:         events,
+		resyncInterval: resyncInterval,
+		tarpitDelay:    tarpitDelay,
+		logger:         logger,
+	}
+	m.accounts.Store(&map[uuid.UUID]*models.CanaryAccount{})
+	return m
+}
+
+// Lookup returns the canary account of the user, if the user is one
+func (m *Monitor) Lookup(userID uuid.UUID) (*models.CanaryAccount, bool) {
+	account, ok := (*m.accounts.Load())[userID]
+	return account, ok
+}
+
+// Alert raises a high-severity alert for the access: a metric, an error log entry and an event
+// for security monitoring. Failing to publish the event is only logged, so the access looks
+// like any other to the attacker.
+func (m *Monitor) Alert(ctx context.Context, account *models.CanaryAccount, access models.CanaryAccess) {
+	metrics.CanaryAccountAccesses.WithLabelValues(string(access.Kind)).Inc()
+
+	logger := m.logger.WithFields(logutils.Fields{
+		"severity":       events.CanarySeverity,
+		"user_id":        account.UserID.String(),
+		"kind":           access.Kind,
+		"client_id":      access.ClientID,
+		"password_valid": access.PasswordValid,
+		"ip_address":     access.IPAddress,
+		"user_agent":     access.UserAgent,
+		"note":           account.Note,
+	})
+	logger.Error("Canary account accessed")
+
+	payload, err := json.Marshal(dto.SendCanaryAccessParams{
+		UserID:        account.UserID.String(),
+		Kind:          string(access.Kind),
+		ClientID:      access.ClientID,
+		PasswordValid: access.PasswordValid,
+		IPAddress:     access.IPAddress,
+		UserAgent:     access.UserAgent,
+		Note:          account.Note,
+		AccessedAt:    access.At,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal canary alert payload")
+		return
+	}
+
+	if err := m.events.Submit(ctx, &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(events.CanaryAccountAccessedEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	}); err != nil {
+		logger.WithError(err).Error("Failed to submit canary alert event")
+	}
+}
+
+// Tarpit holds the request for the tarpit delay if the account is tarpitted, slowing down the
+// attacker while responders react. It returns early when ctx is done.
+func (m *Monitor) Tarpit(ctx context.Context, account *models.CanaryAccount) {
+	if !account.Tarpit || m.tarpitDelay <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(m.tarpitDelay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// Reload loads the canary accounts from the database
+func (m *Monitor) Reload(ctx context.Context) error {
+	list, err := m.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	accounts := make(map[uuid.UUID]*models.CanaryAccount, len(list))
+	for _, account := range list {
+		accounts[account.UserID] = account
+	}
+	m.accounts.Store(&accounts)
+	return nil
+}
+
+// Start resyncs the canary accounts from the database until ctx is cancelled
+func (m *Monitor) Start(ctx context.Context, wg *sync.WaitGroup) {
+	m.logger.WithField("resync_interval", m.resyncInterval.String()).Info("Starting canary monitor")
+
+	m.resync(ctx)
+
+	wg.Add(1)
+	go func() {
+		defer func() {
+			wg.Done()
+			m.logger.Info("Canary monitor stopped")
+		}()
+
+		ticker := time.NewTicker(m.resyncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.resync(ctx)
+			}
+		}
+	}()
+}
+
+// resync reloads the canary accounts, keeping the loaded ones if the database is unavailable
+func (m *Monitor) resync(ctx context.Context) {
+	if err := m.Reload(ctx); err != nil {
+		m.logger.WithError(err).Warn("Failed to reload canary accounts")
+	}
+}
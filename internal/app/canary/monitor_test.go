@@ -0,0 +1,129 @@
+package canary
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/crypt/token"
+	logutils "user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+type fakeAccounts []*models.CanaryAccount
+
+func (r fakeAccounts) List(context.Context) ([]*models.CanaryAccount, error) {
+	return r, nil
+}
+
+type fakePipeline struct {
+	submitted []*repository.NotificationEventLog
+}
+
+func (p *fakePipeline) Submit(_ context.Context, event *repository.NotificationEventLog) error {
+	p.submitted = append(p.submitted, event)
+	return nil
+}
+
+func newTestMonitor(t *testing.T, pipeline EventPipeline, tarpitDelay time.Duration, accounts ...*models.CanaryAccount) *Monitor {
+	t.Helper()
+	logger := logutils.New(io.Discard)
+	monitor := NewMonitor(fakeAccounts(accounts), pipeline, time.Minute, tarpitDelay, logger)
+	if err := monitor.Reload(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return monitor
+}
+
+func TestMonitor_Alert(t *testing.T) {
+	canary := &models.CanaryAccount{UserID: uuid.New(), Note: "credentials planted in the staging wiki"}
+	pipeline := &fakePipeline{}
+	monitor := newTestMonitor(t, pipeline, 0, canary)
+
+	if _, ok := monitor.Lookup(uuid.New()); ok {
+		t.Errorf("Expected a regular user not to be a canary account")
+	}
+	account, ok := monitor.Lookup(canary.UserID)
+	if !ok {
+		t.Fatalf("Expected the canary account to be found")
+	}
+
+	monitor.Alert(context.Background(), account, models.CanaryAccess{
+		Kind:          models.CanaryAccessLogin,
+		PasswordValid: true,
+		IPAddress:     "203.0.113.7",
+		At:            1700000000000,
+	})
+
+	if len(pipeline.submitted) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(pipeline.submitted))
+	}
+	event := pipeline.submitted[0]
+	if event.EventName != string(events.CanaryAccountAccessedEventType) {
+		t.Errorf("Expected event %s, got %s", events.CanaryAccountAccessedEventType, event.EventName)
+	}
+	var params dto.SendCanaryAccessParams
+	if err := json.Unmarshal(event.Payload, &params); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if params.UserID != canary.UserID.String() || params.Kind != "login" || !params.PasswordValid || params.Note != canary.Note {
+		t.Errorf("Expected the access of the canary account, got %+v", params)
+	}
+}
+
+func TestMonitor_Tarpit(t *testing.T) {
+	monitor := newTestMonitor(t, &fakePipeline{}, time.Hour)
+
+	start := time.Now()
+	monitor.Tarpit(context.Background(), &models.CanaryAccount{UserID: uuid.New()})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected accounts without tarpit not to be delayed, got %v", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	monitor.Tarpit(ctx, &models.CanaryAccount{UserID: uuid.New(), Tarpit: true})
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond || elapsed > time.Second {
+		t.Errorf("Expected the tarpit to last until the request is cancelled, got %v", elapsed)
+	}
+}
+
+func TestTokenMaker_VerifyAccessToken(t *testing.T) {
+	canary := &models.CanaryAccount{UserID: uuid.New()}
+	pipeline := &fakePipeline{}
+	maker := NewTokenMaker(token.NewJWTTokenMaker("canary-test-secret-key-0123456789"), newTestMonitor(t, pipeline, 0, canary))
+
+	regular, err := maker.CreateAccessToken(uuid.New().String(), "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := maker.VerifyAccessToken(regular); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pipeline.submitted) != 0 {
+		t.Errorf("Expected no alert for a regular user, got %d", len(pipeline.submitted))
+	}
+
+	leaked, err := maker.CreateAccessToken(canary.UserID.String(), "canary", 60, token.WithClient("web"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	payload, err := maker.VerifyAccessToken(leaked)
+	if err != nil {
+		t.Fatalf("Expected the token of a canary account to be accepted, got %v", err)
+	}
+	if payload.UserID != canary.UserID.String() {
+		t.Errorf("Expected user %s, got %s", canary.UserID, payload.UserID)
+	}
+	if len(pipeline.submitted) != 1 {
+		t.Errorf("Expected 1 alert for the canary account, got %d", len(pipeline.submitted))
+	}
+}
@@ -0,0 +1,49 @@
+package canary
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+
+	"github.com/google/uuid"
+)
+
+// TokenMaker decorates a token maker so that verifying an access token of a canary account
+// raises an alert, wherever in the service the token is presented
+type TokenMaker struct {
+	token.TokenMaker
+	monitor *Monitor
+}
+
+func NewTokenMaker(next token.TokenMaker, monitor *Monitor) *TokenMaker {
+	return &TokenMaker{
+		TokenMaker: next,
+		monitor:    monitor,
+	}
+}
+
+// VerifyAccessToken verifies the token as the decorated maker does. The token is not refused,
+// so the attacker does not learn the account is a canary.
+func (m *TokenMaker) VerifyAccessToken(accessToken string) (*token.Payload, error) {
+	payload, err := m.TokenMaker.VerifyAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return payload, nil
+	}
+	if account, ok := m.monitor.Lookup(userID); ok {
+		// Verification has no request context, and the alert must not be cut short by one anyway
+		m.monitor.Alert(context.Background(), account, models.CanaryAccess{
+			Kind:     models.CanaryAccessAccessToken,
+			ClientID: payload.ClientID,
+			At:       time.Now().UnixMilli(),
+		})
+	}
+
+	return payload, nil
+}
@@ -2,46 +2,220 @@ package config
 
 import (
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	metautils "user-svc/pkg/utils/metadata"
+
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 )
 
-// Config holds all configuration for the application
+// Config holds all configuration for the application. Keys Validate always requires are
+// tagged required, see Schema.
 type Config struct {
+	App      AppConfig      `mapstructure:"app"`
 	Server   ServerConfig   `mapstructure:"server"`
 	Database DatabaseConfig `mapstructure:"database"`
 	JWT      JWTConfig      `mapstructure:"jwt"`
 	Redis    RedisConfig    `mapstructure:"redis"`
 	Log      LogConfig      `mapstructure:"log"`
 	Worker   WorkerConfig   `mapstructure:"worker"`
+	Pipeline PipelineConfig `mapstructure:"pipeline"`
+	Ops      OpsConfig      `mapstructure:"ops"`
+	GRPCWeb  GRPCWebConfig  `mapstructure:"grpc_web"`
+	GraphQL  GraphQLConfig  `mapstructure:"graphql"`
+	Quota    QuotaConfig    `mapstructure:"quota"`
+	SLO      SLOConfig      `mapstructure:"slo"`
+
+	FaultInjection    FaultInjectionConfig    `mapstructure:"fault_injection"`
+	Capture           CaptureConfig           `mapstructure:"capture"`
+	CircuitBreaker    CircuitBreakerConfig    `mapstructure:"circuit_breaker"`
+	LoginQueue        LoginQueueConfig        `mapstructure:"login_queue"`
+	Preflight         PreflightConfig         `mapstructure:"preflight"`
+	Revocation        RevocationConfig        `mapstructure:"revocation"`
+	Cache             CacheConfig             `mapstructure:"cache"`
+	DPoP              DPoPConfig              `mapstructure:"dpop"`
+	Admin             AdminConfig             `mapstructure:"admin"`
+	Email             EmailConfig             `mapstructure:"email"`
+	Notifier          NotifierConfig          `mapstructure:"notifier"`
+	Export            ExportConfig            `mapstructure:"export"`
+	OrgAudit          OrgAuditConfig          `mapstructure:"org_audit"`
+	Services          ServicesConfig          `mapstructure:"services"`
+	TokenExchange     TokenExchangeConfig     `mapstructure:"token_exchange"`
+	OIDC              OIDCConfig              `mapstructure:"oidc"`
+	Risk              RiskConfig              `mapstructure:"risk"`
+	Abuse             AbuseConfig             `mapstructure:"abuse"`
+	Canary            CanaryConfig            `mapstructure:"canary"`
+	UserMetadata      UserMetadataConfig      `mapstructure:"user_metadata"`
+	Storage           StorageConfig           `mapstructure:"storage"`
+	Import            ImportConfig            `mapstructure:"import"`
+	Registration      RegistrationConfig      `mapstructure:"registration"`
+	PasswordHashing   PasswordHashingConfig   `mapstructure:"password_hashing"`
+	PasswordReset     PasswordResetConfig     `mapstructure:"password_reset"`
+	EmailVerification EmailVerificationConfig `mapstructure:"email_verification"`
+	Referral          ReferralConfig          `mapstructure:"referral"`
+	LoginHistory      LoginHistoryConfig      `mapstructure:"login_history"`
+	Inactivity        InactivityConfig        `mapstructure:"inactivity"`
+	Locks             LocksConfig             `mapstructure:"locks"`
+	Nonces            NoncesConfig            `mapstructure:"nonces"`
+	Tenancy           TenancyConfig           `mapstructure:"tenancy"`
+	Residency         ResidencyConfig         `mapstructure:"residency"`
+	Watch             WatchConfig             `mapstructure:"watch"`
+	Maintenance       MaintenanceConfig       `mapstructure:"maintenance"`
+}
+
+// AppConfig holds general application configuration
+type AppConfig struct {
+	Environment string `mapstructure:"environment"`
+}
+
+// IsProduction reports whether the service runs in the production environment
+func (c *AppConfig) IsProduction() bool {
+	return c.Environment == "production"
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port         string        `mapstructure:"port"`
+	Port         string        `mapstructure:"port" required:"true"`
 	Host         string        `mapstructure:"host"`
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
 	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`
+
+	Keepalive    KeepaliveConfig    `mapstructure:"keepalive"`
+	Interceptors InterceptorsConfig `mapstructure:"interceptors"`
+
+	// RequestTimeout bounds unary handlers without a method timeout; 0 leaves them unbounded
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+	// MethodTimeouts override RequestTimeout per method, e.g. longer for bcrypt-bound calls
+	MethodTimeouts []MethodTimeoutConfig `mapstructure:"method_timeouts"`
+
+	Compression CompressionConfig `mapstructure:"compression"`
+}
+
+// CompressionConfig holds the compression of gRPC responses. Clients get the first algorithm
+// they accept and uncompressed responses if they accept none.
+type CompressionConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Algorithms in order of preference: gzip and zstd
+	Algorithms []string `mapstructure:"algorithms"`
+	// GzipLevel from 1 (fastest) to 9 (smallest), or -1 for the gzip default
+	GzipLevel int `mapstructure:"gzip_level"`
+	// Methods are the full method names whose responses are compressed; empty compresses all
+	Methods []string `mapstructure:"methods"`
+}
+
+// MethodTimeoutConfig holds the timeout of one gRPC method
+type MethodTimeoutConfig struct {
+	// Method is the full gRPC method name, e.g. /user.UserService/Register
+	Method string `mapstructure:"method"`
+	// Timeout of 0 leaves the method unbounded
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// InterceptorsConfig enables the built-in gRPC interceptors; the others are enabled by their
+// feature, e.g. dpop.enabled. Disabling one is meant for debugging, not production.
+type InterceptorsConfig struct {
+	Metrics  bool `mapstructure:"metrics"`
+	Recovery bool `mapstructure:"recovery"`
+	Logging  bool `mapstructure:"logging"`
+	// ErrorHandling converts domain errors to gRPC status codes; without it clients get Unknown
+	ErrorHandling bool `mapstructure:"error_handling"`
+}
+
+// KeepaliveConfig holds the gRPC connection keepalive and age limits. Zero ages are unlimited.
+type KeepaliveConfig struct {
+	// MaxConnectionIdle closes connections without RPCs for this long
+	MaxConnectionIdle time.Duration `mapstructure:"max_connection_idle"`
+	// MaxConnectionAge makes clients reconnect periodically, so their connections spread
+	// over the pods started by a rolling restart instead of staying on the old ones
+	MaxConnectionAge time.Duration `mapstructure:"max_connection_age"`
+	// MaxConnectionAgeGrace lets in-flight RPCs finish on connections past their age
+	MaxConnectionAgeGrace time.Duration `mapstructure:"max_connection_age_grace"`
+	// Time and Timeout ping idle clients and close the connection if they do not answer
+	Time    time.Duration `mapstructure:"time"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MinTime is the shortest ping interval allowed to clients
+	MinTime             time.Duration `mapstructure:"min_time"`
+	PermitWithoutStream bool          `mapstructure:"permit_without_stream"`
 }
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
-	Host     string `mapstructure:"host"`
+	Host     string `mapstructure:"host" required:"true"`
 	Port     int    `mapstructure:"port"`
 	User     string `mapstructure:"user"`
 	Password string `mapstructure:"password"`
 	DBName   string `mapstructure:"db_name"`
 	SSLMode  string `mapstructure:"ssl_mode"`
+
+	Retry   DatabaseRetryConfig   `mapstructure:"retry"`
+	Replica DatabaseReplicaConfig `mapstructure:"replica"`
+}
+
+// DatabaseReplicaConfig holds the read replica serving the reads of read-only methods. It
+// shares the user, password, database name and SSL mode of the primary.
+type DatabaseReplicaConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Host    string `mapstructure:"host"`
+	Port    int    `mapstructure:"port"`
+	// WaitTimeout is how long a read waits for the replica to replay the writes of its
+	// consistency token before the primary serves it
+	WaitTimeout time.Duration `mapstructure:"wait_timeout"`
+	// PollInterval is how often the replica's replay position is checked while waiting
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+// DatabaseRetryConfig holds the retry policy for idempotent database reads
+type DatabaseRetryConfig struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	SecretKey            string        `mapstructure:"secret_key"`
+	SecretKey string `mapstructure:"secret_key" required:"true"`
+	// SecondarySecretKey also verifies tokens, until PromoteSigningKey makes it the signing key
+	SecondarySecretKey   string        `mapstructure:"secondary_secret_key"`
 	AccessTokenDuration  time.Duration `mapstructure:"access_token_duration"`
 	RefreshTokenDuration time.Duration `mapstructure:"refresh_token_duration"`
+	// ShortRefreshTokenDuration caps refresh tokens of logins without remember_me
+	ShortRefreshTokenDuration time.Duration `mapstructure:"short_refresh_token_duration"`
+	// SessionIdleTimeout ends sessions unused for longer on their next refresh, unless a client
+	// or organization sets a shorter one; 0 sets none
+	SessionIdleTimeout time.Duration `mapstructure:"session_idle_timeout"`
+	// SessionActivityInterval is how often verifying the access tokens of a session marks the
+	// session used, at most, so sessions active between refreshes are not idled out
+	SessionActivityInterval time.Duration `mapstructure:"session_activity_interval"`
+	// KeyChannel is the Redis pub/sub channel signing key promotions are broadcast on
+	KeyChannel string `mapstructure:"key_channel" required:"true"`
+	// KeyResyncInterval bounds how long a replica signs with the old key when a broadcast is missed
+	KeyResyncInterval time.Duration `mapstructure:"key_resync_interval"`
+	// ClaimProfile is the claim profile of clients without one: minimal, standard or internal
+	ClaimProfile string `mapstructure:"claim_profile"`
+	// EncryptionKey encrypts the access tokens of clients with encrypt_access_tokens; empty
+	// disables encryption
+	EncryptionKey string `mapstructure:"encryption_key"`
+	// SecondaryEncryptionKey still decrypts tokens after EncryptionKey was replaced
+	SecondaryEncryptionKey string `mapstructure:"secondary_encryption_key"`
+	// TokenFormat is the format new tokens are created in: jwt or paseto_v4
+	TokenFormat string `mapstructure:"token_format"`
+	// AcceptedFormats are the formats tokens are verified in, tried in this order; it must
+	// include TokenFormat
+	AcceptedFormats []string `mapstructure:"accepted_formats"`
+	// Issuer is the iss of created tokens
+	Issuer string `mapstructure:"issuer"`
+	// Audience is the aud of created tokens other than delegation tokens; empty leaves it out
+	Audience string `mapstructure:"audience"`
+	// ClaimsMode is how verified tokens missing the issuer, audience or jti, or carrying
+	// claims or critical extensions the service does not issue, are handled: off, report
+	// (counted in user_svc_token_claim_violations_total) or enforce (rejected)
+	ClaimsMode string `mapstructure:"claims_mode"`
 }
 
 // RedisConfig holds Redis configuration
@@ -54,13 +228,93 @@ type RedisConfig struct {
 
 // LogConfig holds logging configuration
 type LogConfig struct {
-	Level  string `mapstructure:"level"`
+	Level           string            `mapstructure:"level"`
+	Format          string            `mapstructure:"format"`
+	Sampling        LogSamplingConfig `mapstructure:"sampling"`
+	CapturePayloads bool              `mapstructure:"capture_payloads"`
+	File            LogFileConfig     `mapstructure:"file"`
+	Security        SecurityLogConfig `mapstructure:"security"`
+}
+
+// LogFileConfig holds the log file written instead of stdout and its rotation
+type LogFileConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`
+	// MaxSize is the size in megabytes at which the file is rotated
+	MaxSize int `mapstructure:"max_size"`
+	// MaxAge is the number of days rotated files are kept, 0 for no limit
+	MaxAge int `mapstructure:"max_age"`
+	// MaxBackups is the number of rotated files kept, 0 for no limit
+	MaxBackups int  `mapstructure:"max_backups"`
+	Compress   bool `mapstructure:"compress"`
+}
+
+// LogSamplingConfig holds the fraction of requests logged by the logging interceptor
+type LogSamplingConfig struct {
+	SuccessRate float64 `mapstructure:"success_rate"`
+	ErrorRate   float64 `mapstructure:"error_rate"`
+}
+
+// SecurityLogConfig holds the stream of normalized security events for the SIEM, written to
+// its own sink apart from the application logs
+type SecurityLogConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Format is ecs (Elastic Common Schema) or ocsf (Open Cybersecurity Schema Framework)
 	Format string `mapstructure:"format"`
+	// Sink is file, syslog or http
+	Sink     string                 `mapstructure:"sink"`
+	File     string                 `mapstructure:"file"`
+	Syslog   SecuritySyslogConfig   `mapstructure:"syslog"`
+	HTTP     SecurityHTTPSinkConfig `mapstructure:"http"`
+	Pipeline PipelineStreamConfig   `mapstructure:"pipeline"`
 }
 
-// WorkerConfig holds notification worker configuration
+// SecuritySyslogConfig holds the syslog receiver of security events
+type SecuritySyslogConfig struct {
+	// Network is udp or tcp
+	Network string `mapstructure:"network"`
+	Address string `mapstructure:"address"`
+}
+
+// SecurityHTTPSinkConfig holds the HTTP collector of security events, e.g. a SIEM's HTTP
+// event collector receiving newline-delimited JSON
+type SecurityHTTPSinkConfig struct {
+	URL string `mapstructure:"url"`
+	// Token is sent as bearer token, none if empty
+	Token   string        `mapstructure:"token"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// WorkerConfig holds background worker configuration
 type WorkerConfig struct {
-	Notification NotificationWorkerConfig `mapstructure:"notification"`
+	Notification   NotificationWorkerConfig `mapstructure:"notification"`
+	Scheduler      SchedulerConfig          `mapstructure:"scheduler"`
+	Timers         TimerWorkerConfig        `mapstructure:"timers"`
+	SecurityDigest SecurityDigestConfig     `mapstructure:"security_digest"`
+}
+
+// SchedulerConfig holds configuration for the periodic job scheduler
+type SchedulerConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	// Lease is how long a replica may run a job before another replica may take it over
+	Lease time.Duration `mapstructure:"lease"`
+}
+
+// TimerWorkerConfig holds configuration for the worker firing durable workflow timers
+type TimerWorkerConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	// BatchSize is the most timers fired per interval
+	BatchSize int `mapstructure:"batch_size"`
+	// InitialBackoff and MaxBackoff bound the delay before a failed step is retried
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+}
+
+// SecurityDigestConfig holds configuration for the monthly security digest email
+type SecurityDigestConfig struct {
+	Enabled bool `mapstructure:"enabled"`
 }
 
 // NotificationWorkerConfig holds notification worker specific configuration
@@ -72,6 +326,649 @@ type NotificationWorkerConfig struct {
 	Concurrency int           `mapstructure:"concurrency"`
 }
 
+// PipelineConfig holds configuration for the async audit, event and login attempt pipelines
+type PipelineConfig struct {
+	Audit         PipelineStreamConfig `mapstructure:"audit"`
+	Events        PipelineStreamConfig `mapstructure:"events"`
+	LoginAttempts PipelineStreamConfig `mapstructure:"login_attempts"`
+}
+
+// PipelineStreamConfig holds configuration for a single async pipeline stream
+type PipelineStreamConfig struct {
+	BufferSize    int           `mapstructure:"buffer_size"`
+	BatchSize     int           `mapstructure:"batch_size"`
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+	FlushTimeout  time.Duration `mapstructure:"flush_timeout"`
+	Policy        string        `mapstructure:"policy"`
+}
+
+// OpsConfig holds configuration for the operational HTTP server (metrics, diagnostics)
+type OpsConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	Host        string `mapstructure:"host"`
+	Port        string `mapstructure:"port"`
+	MetricsPath string `mapstructure:"metrics_path"`
+	SLOPath     string `mapstructure:"slo_path"`
+	HealthPath  string `mapstructure:"health_path"`
+	ReadyPath   string `mapstructure:"ready_path"`
+}
+
+// GRPCWebConfig holds the HTTP listener browsers call the gRPC service on with gRPC-Web
+type GRPCWebConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Host    string `mapstructure:"host"`
+	Port    string `mapstructure:"port"`
+	// AllowedOrigins may call from a browser, e.g. https://checkout.tickets.example.com; "*"
+	// allows any origin outside production
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+	// AllowedHeaders are request headers browsers may send in addition to the gRPC-Web ones
+	AllowedHeaders []string `mapstructure:"allowed_headers"`
+	// MaxAge is how long browsers cache a CORS preflight response
+	MaxAge            time.Duration `mapstructure:"max_age"`
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+	IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
+	// RefreshTokenCookie delivers refresh tokens to browsers in an HttpOnly cookie guarded
+	// by a double-submit CSRF token instead of the response
+	RefreshTokenCookie bool `mapstructure:"refresh_token_cookie"`
+	// SessionCookies also delivers access tokens in an HttpOnly cookie that authorizes calls
+	// without an authorization header, so web apps keep no tokens; implies RefreshTokenCookie
+	SessionCookies bool                `mapstructure:"session_cookies"`
+	Cookie         GRPCWebCookieConfig `mapstructure:"cookie"`
+}
+
+// GRPCWebCookieConfig holds the scope of the token cookies
+type GRPCWebCookieConfig struct {
+	// Domain shares the cookies with subdomains, e.g. tickets.example.com; empty limits them
+	// to the gateway host
+	Domain string `mapstructure:"domain"`
+	Path   string `mapstructure:"path"`
+	// SameSite is strict, lax or none
+	SameSite string `mapstructure:"same_site"`
+}
+
+// GraphQLConfig holds the GraphQL endpoint of the admin console, served on the ops server
+type GraphQLConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`
+}
+
+// LoginQueueConfig holds configuration for the admission queue of password-checking calls
+type LoginQueueConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Methods []string `mapstructure:"methods"`
+	// Capacity is the number of calls served at once, 0 means one per CPU as bcrypt is CPU-bound
+	Capacity int `mapstructure:"capacity"`
+	// MaxQueued bounds the waiting calls, MaxQueuedPerCaller those of one client address
+	MaxQueued          int `mapstructure:"max_queued"`
+	MaxQueuedPerCaller int `mapstructure:"max_queued_per_caller"`
+	// MaxWait is how long a call waits before it fails with Unavailable and a retry-after header
+	MaxWait time.Duration `mapstructure:"max_wait"`
+}
+
+// QuotaConfig holds configuration for per-subject call quotas
+type QuotaConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Period  string `mapstructure:"period"`
+	// FailOpen lets calls through when usage cannot be recorded
+	FailOpen bool `mapstructure:"fail_open"`
+	// UserLimit is the number of calls per window for authenticated users, 0 means unlimited
+	UserLimit int64 `mapstructure:"user_limit"`
+	// Thresholds are usage percentages of a limit that publish billing events
+	Thresholds []int `mapstructure:"thresholds"`
+	// WarningPercent is the usage percentage of a limit from which responses carry the
+	// ratelimit headers, so clients back off before being rejected; 0 sends them always
+	WarningPercent int                      `mapstructure:"warning_percent"`
+	ExemptMethods  []string                 `mapstructure:"exempt_methods"`
+	MethodLimits   []QuotaMethodLimitConfig `mapstructure:"method_limits"`
+	APIKeys        []QuotaAPIKeyConfig      `mapstructure:"api_keys"`
+}
+
+// QuotaMethodLimitConfig limits the calls per window each subject may make to a single method
+type QuotaMethodLimitConfig struct {
+	Method string `mapstructure:"method"`
+	Limit  int64  `mapstructure:"limit"`
+}
+
+// QuotaAPIKeyConfig registers a partner API key by the SHA-256 hex digest of the key
+type QuotaAPIKeyConfig struct {
+	ID      string `mapstructure:"id"`
+	KeyHash string `mapstructure:"key_hash"`
+	Limit   int64  `mapstructure:"limit"`
+}
+
+// SLOConfig holds per-method service level objectives and the rolling window they are computed over
+type SLOConfig struct {
+	Enabled        bool              `mapstructure:"enabled"`
+	Window         time.Duration     `mapstructure:"window"`
+	ReportInterval time.Duration     `mapstructure:"report_interval"`
+	Targets        []SLOTargetConfig `mapstructure:"targets"`
+}
+
+// SLOTargetConfig holds the objectives of a single gRPC method
+type SLOTargetConfig struct {
+	Method                string        `mapstructure:"method"`
+	LatencyThreshold      time.Duration `mapstructure:"latency_threshold"`
+	LatencyObjective      float64       `mapstructure:"latency_objective"`
+	AvailabilityObjective float64       `mapstructure:"availability_objective"`
+}
+
+// FaultInjectionConfig holds fault injection rules for resiliency testing (non-production only)
+type FaultInjectionConfig struct {
+	Enabled bool              `mapstructure:"enabled"`
+	Rules   []FaultRuleConfig `mapstructure:"rules"`
+}
+
+// CaptureConfig holds the request capture used to replay client sessions locally (non-production only)
+type CaptureConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Dir     string `mapstructure:"dir"`
+	// Methods limits capturing to these full method names; empty captures every unary method
+	Methods []string `mapstructure:"methods"`
+}
+
+// FaultRuleConfig describes a fault injected into a fraction of the calls to a method
+type FaultRuleConfig struct {
+	Method          string        `mapstructure:"method"`
+	Probability     float64       `mapstructure:"probability"`
+	Latency         time.Duration `mapstructure:"latency"`
+	ErrorCode       string        `mapstructure:"error_code"`
+	ResetConnection bool          `mapstructure:"reset_connection"`
+}
+
+// CircuitBreakerConfig holds circuit breaker configuration per external dependency
+type CircuitBreakerConfig struct {
+	EventBus BreakerConfig `mapstructure:"event_bus"`
+}
+
+// BreakerConfig holds the configuration of a single circuit breaker
+type BreakerConfig struct {
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	OpenTimeout      time.Duration `mapstructure:"open_timeout"`
+	HalfOpenMaxCalls int           `mapstructure:"half_open_max_calls"`
+}
+
+// PreflightConfig holds configuration for the startup checks run before reporting SERVING
+type PreflightConfig struct {
+	Timeout         time.Duration `mapstructure:"timeout"`
+	WarmConnections int           `mapstructure:"warm_connections"`
+}
+
+// RevocationConfig holds configuration for propagating token revocations across replicas
+type RevocationConfig struct {
+	// Channel is the Redis pub/sub channel revocations are broadcast on
+	Channel string `mapstructure:"channel" required:"true"`
+	// ResyncInterval bounds staleness when a broadcast is missed
+	ResyncInterval time.Duration `mapstructure:"resync_interval"`
+}
+
+// CacheConfig holds in-memory cache configuration
+type CacheConfig struct {
+	// UserTTL is how long users looked up by ID are cached, 0 disables the cache
+	UserTTL time.Duration `mapstructure:"user_ttl"`
+}
+
+// DPoPConfig holds configuration for sender-constrained (DPoP) access tokens
+type DPoPConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// ProofMaxAge is how old a proof's iat may be before it is rejected
+	ProofMaxAge time.Duration `mapstructure:"proof_max_age"`
+	// ClockSkew tolerates clients whose clocks run ahead of the server
+	ClockSkew time.Duration `mapstructure:"clock_skew"`
+	// RequiredMethods are gRPC full method names that refuse callers without a DPoP proof
+	RequiredMethods []string `mapstructure:"required_methods"`
+}
+
+// AdminConfig holds the credentials of operators allowed to call admin RPCs
+type AdminConfig struct {
+	APIKeys []AdminAPIKeyConfig `mapstructure:"api_keys"`
+	// MaxBatchUsers is the most user IDs a batch RPC may change at once
+	MaxBatchUsers int `mapstructure:"max_batch_users"`
+}
+
+// AdminAPIKeyConfig registers an operator API key by the SHA-256 hex digest of the key
+type AdminAPIKeyConfig struct {
+	ID      string `mapstructure:"id"`
+	KeyHash string `mapstructure:"key_hash"`
+}
+
+// ServicesConfig holds the credentials of internal services allowed to call service-scoped RPCs
+type ServicesConfig struct {
+	APIKeys []ServiceAPIKeyConfig `mapstructure:"api_keys"`
+}
+
+// ServiceAPIKeyConfig registers a service API key by the SHA-256 hex digest of the key and
+// the scopes it grants
+type ServiceAPIKeyConfig struct {
+	ID      string   `mapstructure:"id"`
+	KeyHash string   `mapstructure:"key_hash"`
+	Scopes  []string `mapstructure:"scopes"`
+}
+
+// WatchConfig holds the WatchUser subscriptions of internal services, fed by Postgres
+// notifications of committed user events
+type WatchConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxUsersPerStream is the most users one WatchUser stream may watch
+	MaxUsersPerStream int `mapstructure:"max_users_per_stream"`
+	// ResyncInterval is how often streams reload their users to recover notifications lost
+	// while the listener reconnected
+	ResyncInterval time.Duration `mapstructure:"resync_interval"`
+}
+
+// MaintenanceConfig holds the admin cleanup RPCs
+type MaintenanceConfig struct {
+	// BatchPause is the pause between two cleanup batches, so a cleanup never saturates the
+	// database during an incident
+	BatchPause time.Duration `mapstructure:"batch_pause"`
+}
+
+// UserMetadataConfig limits the metadata internal services attach to a user
+type UserMetadataConfig struct {
+	MaxKeys       int `mapstructure:"max_keys"`
+	MaxValueBytes int `mapstructure:"max_value_bytes"`
+	// MaxTotalBytes caps the stored JSON of a user's metadata
+	MaxTotalBytes int `mapstructure:"max_total_bytes"`
+}
+
+// StorageConfig holds the S3-compatible object storage of user uploads: Amazon S3, Google
+// Cloud Storage with HMAC keys or MinIO. Avatars are disabled without a bucket.
+type StorageConfig struct {
+	// Endpoint is the storage API, e.g. https://s3.eu-central-1.amazonaws.com or https://storage.googleapis.com
+	Endpoint        string `mapstructure:"endpoint"`
+	Region          string `mapstructure:"region"`
+	Bucket          string `mapstructure:"bucket"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	// PathStyle addresses the bucket in the URL path instead of the host name, e.g. for MinIO
+	PathStyle bool `mapstructure:"path_style"`
+	// PublicBaseURL serves stored objects, e.g. a CDN in front of the bucket; avatar URLs are
+	// left empty without it
+	PublicBaseURL string        `mapstructure:"public_base_url"`
+	Timeout       time.Duration `mapstructure:"timeout"`
+
+	Avatars AvatarConfig `mapstructure:"avatars"`
+}
+
+// AvatarConfig holds configuration for profile picture uploads
+type AvatarConfig struct {
+	// KeyPrefix is prepended to the object keys of avatars, which are <prefix><user id>/<upload id>
+	KeyPrefix string `mapstructure:"key_prefix"`
+	// UploadURLTTL is how long a pre-signed upload URL stays valid, at most 7 days
+	UploadURLTTL time.Duration `mapstructure:"upload_url_ttl"`
+	MaxBytes     int64         `mapstructure:"max_bytes"`
+	ContentTypes []string      `mapstructure:"content_types"`
+}
+
+// TokenExchangeConfig holds the delegation tokens internal services obtain with ExchangeToken
+// to call other services on behalf of a user
+type TokenExchangeConfig struct {
+	// TTL caps the lifetime of delegation tokens, which never outlive the token they replace
+	TTL time.Duration `mapstructure:"ttl"`
+	// MaxDelegationDepth is the most services in the act chain of a delegation token
+	MaxDelegationDepth int `mapstructure:"max_delegation_depth"`
+	// Audiences lists the services delegation tokens are issued for
+	Audiences []TokenExchangeAudienceConfig `mapstructure:"audiences"`
+}
+
+// TokenExchangeAudienceConfig lets services obtain delegation tokens for an audience
+type TokenExchangeAudienceConfig struct {
+	// Audience is the service the tokens are for, their "aud" claim
+	Audience string `mapstructure:"audience"`
+	// Actors are the IDs of the service API keys that may act on behalf of users at the audience
+	Actors []string `mapstructure:"actors"`
+	// Scopes are the most the tokens for the audience may grant
+	Scopes []string `mapstructure:"scopes"`
+}
+
+// FindAudience returns the configuration of an audience, nil if no tokens are issued for it
+func (c *TokenExchangeConfig) FindAudience(audience string) *TokenExchangeAudienceConfig {
+	for i := range c.Audiences {
+		if c.Audiences[i].Audience == audience {
+			return &c.Audiences[i]
+		}
+	}
+	return nil
+}
+
+// OIDCConfig holds the OpenID Connect providers whose ID tokens users log in with, see
+// LoginWithIDToken
+type OIDCConfig struct {
+	// Providers are the accepted providers; none disables logins with ID tokens
+	Providers []OIDCProviderConfig `mapstructure:"providers"`
+	// JWKSCacheTTL is how long the signing keys of a provider are cached
+	JWKSCacheTTL time.Duration `mapstructure:"jwks_cache_ttl"`
+	// HTTPTimeout bounds fetching the discovery document and keys of a provider
+	HTTPTimeout time.Duration `mapstructure:"http_timeout"`
+}
+
+// OIDCProviderConfig is an OpenID Connect provider, e.g. Apple, Google or a corporate SSO
+type OIDCProviderConfig struct {
+	// Name identifies the provider in LoginWithIDToken requests
+	Name string `mapstructure:"name"`
+	// Issuer is the "iss" of the ID tokens, whose discovery document lists the keys
+	Issuer string `mapstructure:"issuer"`
+	// ClientIDs are the client IDs of the apps at the provider, one of which must be in the
+	// "aud" of an ID token
+	ClientIDs []string `mapstructure:"client_ids"`
+	// JWKSURL overrides the jwks_uri of the discovery document
+	JWKSURL string `mapstructure:"jwks_url"`
+	// LinkVerifiedEmail links the first login of an identity to the account with the email
+	// the provider verified; otherwise only identities linked already can log in
+	LinkVerifiedEmail bool `mapstructure:"link_verified_email"`
+}
+
+// FindProvider returns the configuration of a provider, nil if it is not configured
+func (c *OIDCConfig) FindProvider(name string) *OIDCProviderConfig {
+	for i := range c.Providers {
+		if c.Providers[i].Name == name {
+			return &c.Providers[i]
+		}
+	}
+	return nil
+}
+
+// RiskConfig holds configuration for the fraud signals served to other services
+type RiskConfig struct {
+	// DisposableEmailDomains are flagged in addition to the disposable_email_domains table
+	DisposableEmailDomains []string `mapstructure:"disposable_email_domains"`
+	// DeviceWindow is how far back logins are counted for the device count
+	DeviceWindow time.Duration `mapstructure:"device_window"`
+	// CountryMetadataKey is the request header in which the edge proxy passes the ISO country
+	// of the caller; empty disables the country change check
+	CountryMetadataKey string `mapstructure:"country_metadata_key"`
+	// CountryChangeWindow flags a refresh from another country than the previous refresh of
+	// the session within this window
+	CountryChangeWindow time.Duration `mapstructure:"country_change_window"`
+	// MaxRefreshesPerWindow refreshes of a session are allowed per RefreshRateWindow, more
+	// are flagged as an impossible refresh rate
+	RefreshRateWindow     time.Duration `mapstructure:"refresh_rate_window"`
+	MaxRefreshesPerWindow int64         `mapstructure:"max_refreshes_per_window"`
+}
+
+// AbuseConfig holds configuration for the abuse engine evaluating the velocity rules of
+// registrations and logins. The rules themselves are managed at runtime through admin RPCs.
+type AbuseConfig struct {
+	// Channel is the Redis pub/sub channel that tells replicas to reload the rules
+	Channel string `mapstructure:"channel"`
+	// ResyncInterval is how often replicas reload the rules in case they missed a broadcast
+	ResyncInterval time.Duration `mapstructure:"resync_interval"`
+	// KeyPrefix is prepended to the Redis keys of the attempt counters
+	KeyPrefix string `mapstructure:"key_prefix"`
+	// DeviceMetadataKey is the request header in which clients pass their device ID
+	DeviceMetadataKey string `mapstructure:"device_metadata_key"`
+	// CaptchaMetadataKey is the request header in which clients pass a solved captcha
+	CaptchaMetadataKey string             `mapstructure:"captcha_metadata_key"`
+	Captcha            AbuseCaptchaConfig `mapstructure:"captcha"`
+}
+
+// AbuseCaptchaConfig holds the siteverify endpoint solved captchas are checked with, as
+// offered by reCAPTCHA, hCaptcha and Turnstile. Without a VerifyURL, attempts answered with
+// a captcha are refused.
+type AbuseCaptchaConfig struct {
+	VerifyURL string        `mapstructure:"verify_url"`
+	Secret    string        `mapstructure:"secret"`
+	Timeout   time.Duration `mapstructure:"timeout"`
+}
+
+// CanaryConfig holds configuration for the monitoring of canary accounts, which are flagged
+// through admin RPCs
+type CanaryConfig struct {
+	// ResyncInterval is how often replicas reload the canary accounts
+	ResyncInterval time.Duration `mapstructure:"resync_interval"`
+	// TarpitDelay is how long logins and refreshes of tarpitted canary accounts are held
+	TarpitDelay time.Duration `mapstructure:"tarpit_delay"`
+}
+
+// EmailConfig holds configuration for transactional email templates
+type EmailConfig struct {
+	// TemplatesDir contains one directory per template with one subdirectory per locale
+	TemplatesDir  string `mapstructure:"templates_dir" required:"true"`
+	DefaultLocale string `mapstructure:"default_locale" required:"true"`
+	// ReloadInterval is how often edited templates are picked up, 0 disables reloading
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+// NotifierConfig holds configuration for fanning user notifications out to email, SMS and push
+type NotifierConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Routes are the default channels of each routed event type; users may opt in or out per channel
+	Routes map[string][]string `mapstructure:"routes"`
+	// Channels are the channels with a delivery service consuming their tasks
+	Channels       []string      `mapstructure:"channels"`
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	// RetryInterval is how often failed deliveries are checked for a due retry
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
+	// Lease hides a delivery being attempted from other replicas
+	Lease     time.Duration `mapstructure:"lease"`
+	BatchSize int           `mapstructure:"batch_size"`
+}
+
+// ExportConfig holds configuration for the user export stream
+type ExportConfig struct {
+	// ChunkSize is the number of users per streamed chunk
+	ChunkSize int `mapstructure:"chunk_size"`
+	// MaxRowsPerSecond caps the export rate; requests may ask for less
+	MaxRowsPerSecond int `mapstructure:"max_rows_per_second"`
+}
+
+// OrgAuditConfig holds configuration for the audit trails of organizations
+type OrgAuditConfig struct {
+	// MaxExportRange is the longest period one ExportOrgAuditLog call may cover
+	MaxExportRange time.Duration          `mapstructure:"max_export_range"`
+	Webhooks       OrgAuditWebhooksConfig `mapstructure:"webhooks"`
+}
+
+// OrgAuditWebhooksConfig holds configuration for delivering the security events of
+// organizations to their webhook endpoints
+type OrgAuditWebhooksConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is how often new events are delivered
+	Interval time.Duration `mapstructure:"interval"`
+	// Settle holds back events younger than this, so entries written late by the audit
+	// pipeline are not skipped; it must exceed pipeline.audit.flush_interval
+	Settle    time.Duration `mapstructure:"settle"`
+	Timeout   time.Duration `mapstructure:"timeout"`
+	BatchSize int           `mapstructure:"batch_size"`
+	// Endpoints lists the organizations that receive their events
+	Endpoints []OrgAuditWebhookConfig `mapstructure:"endpoints"`
+}
+
+// OrgAuditWebhookConfig registers the webhook endpoint of an organization and the secret
+// its deliveries are signed with
+type OrgAuditWebhookConfig struct {
+	OrganizationID string `mapstructure:"organization_id"`
+	URL            string `mapstructure:"url"`
+	Secret         string `mapstructure:"secret"`
+}
+
+// ImportConfig holds configuration for importing invited users
+type ImportConfig struct {
+	// MaxBatchSize is the most users one ImportUsers message may carry
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+	// SetupTokenTTL is how long an invited user may take to set a password
+	SetupTokenTTL time.Duration `mapstructure:"setup_token_ttl"`
+	// SetupURL is the link sent to invited users; {token} is replaced with their setup token
+	SetupURL string `mapstructure:"setup_url"`
+	// ReminderBefore is how long before the setup token expires an invited user who has not
+	// set a password is sent a new link, 0 disables reminders
+	ReminderBefore time.Duration `mapstructure:"reminder_before"`
+}
+
+// RegistrationConfig holds configuration for the sign-up of users
+type RegistrationConfig struct {
+	// Mode is "direct", where Register creates the account at once, or "email_otp", where
+	// StartRegistration emails a one-time code and CompleteRegistration creates the account
+	// with it, so no account exists with an unverified email
+	Mode string `mapstructure:"mode"`
+	// CodeTTL is how long an emailed registration code can be used
+	CodeTTL time.Duration `mapstructure:"code_ttl"`
+	// MaxAttempts is how many codes may be tried against one emailed code
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// ResendInterval is how long StartRegistration refuses to email another code to an address
+	ResendInterval time.Duration `mapstructure:"resend_interval"`
+}
+
+// RequiresEmailOTP reports whether emails are verified with a code before accounts are created
+func (c *RegistrationConfig) RequiresEmailOTP() bool {
+	return c.Mode == "email_otp"
+}
+
+// PasswordHashingConfig holds configuration for hashing passwords. Changes apply to new
+// hashes; existing hashes keep verifying and are rehashed on the user's next login.
+type PasswordHashingConfig struct {
+	// BcryptCost is the bcrypt cost of new hashes; hashes of a lower cost are rehashed
+	BcryptCost int `mapstructure:"bcrypt_cost"`
+	// Pepper keys new hashes with a secret kept out of the database; empty disables it
+	Pepper string `mapstructure:"pepper"`
+	// SecondaryPepper still verifies hashes after Pepper was replaced, until their users
+	// logged in again
+	SecondaryPepper string `mapstructure:"secondary_pepper"`
+}
+
+// PasswordResetConfig holds configuration for resetting forgotten passwords
+type PasswordResetConfig struct {
+	// TokenTTL is how long an emailed reset link can be used
+	TokenTTL time.Duration `mapstructure:"token_ttl"`
+	// ResendInterval is how long RequestPasswordReset emails no other link to a user
+	ResendInterval time.Duration `mapstructure:"resend_interval"`
+	// URL is the link sent to users; {token} is replaced with their reset token
+	URL string `mapstructure:"url"`
+}
+
+// EmailVerificationConfig holds configuration for the links verifying the email of accounts
+// created without verifying it
+type EmailVerificationConfig struct {
+	// TokenTTL is how long an emailed verification link can be used
+	TokenTTL time.Duration `mapstructure:"token_ttl"`
+	// URL is the link sent to users; {token} is replaced with their verification token
+	URL string `mapstructure:"url"`
+	// ResendCooldown is how long ResendVerificationEmail refuses to email another link to a user
+	ResendCooldown time.Duration `mapstructure:"resend_cooldown"`
+	// DailyLimit is how many links a user may be emailed in 24 hours
+	DailyLimit int `mapstructure:"daily_limit"`
+}
+
+// ReferralConfig holds configuration for the invite codes users refer others with
+type ReferralConfig struct {
+	// MaxRedemptions is how many users may register with an invite code, and the default
+	// of CreateInviteCode
+	MaxRedemptions int `mapstructure:"max_redemptions"`
+	// MaxActiveCodes is how many invite codes with redemptions left a user may have
+	MaxActiveCodes int `mapstructure:"max_active_codes"`
+}
+
+// LoginHistoryConfig holds configuration for the recorded login attempts of users
+type LoginHistoryConfig struct {
+	// Retention is how long login attempts are kept before the daily purge deletes them
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// InactivityConfig holds configuration for the detection of inactive accounts
+type InactivityConfig struct {
+	// Enabled runs the daily inactive_user_scan job flagging inactive customers
+	Enabled bool `mapstructure:"enabled"`
+	// After is how long customers go without logging in before they are flagged inactive
+	After time.Duration `mapstructure:"after"`
+	// ReverifyAfter is how long an absence makes users verify the email again before they
+	// may log in, 0 to never require it
+	ReverifyAfter time.Duration `mapstructure:"reverify_after"`
+	// BatchSize is how many users are flagged per statement
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// LocksConfig holds configuration for the distributed locks of single-runner jobs
+type LocksConfig struct {
+	// Backend is "redis", "postgres" or "local" for a single replica
+	Backend string `mapstructure:"backend"`
+	// TTL is how long a Redis lock outlives a crashed owner and the Postgres connection
+	// keepalive interval; held locks are renewed every third of it
+	TTL       time.Duration `mapstructure:"ttl"`
+	KeyPrefix string        `mapstructure:"key_prefix"`
+}
+
+// NoncesConfig holds configuration for the one-time nonces of external callbacks
+type NoncesConfig struct {
+	// Backend is "redis", "postgres" or "local" for a single replica
+	Backend   string `mapstructure:"backend"`
+	KeyPrefix string `mapstructure:"key_prefix"`
+}
+
+// TenancyConfig holds the data isolation of organizations
+type TenancyConfig struct {
+	// Mode is "shared", all organizations in the public schema, or "schema", where requests of
+	// organizations provisioned with their own schema are routed to it
+	Mode string `mapstructure:"mode"`
+	// SchemaPrefix is prepended to the organization ID to name provisioned schemas
+	SchemaPrefix string `mapstructure:"schema_prefix"`
+	// MaxOpenConnsPerSchema bounds the connection pool opened for each tenant schema
+	MaxOpenConnsPerSchema int `mapstructure:"max_open_conns_per_schema"`
+	// CacheTTL is how long the schema of an organization is cached, including organizations without one
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
+
+// schemaPrefixPattern keeps tenant schema names, the prefix and 32 hex digits of the
+// organization ID, within the 63 bytes of a Postgres identifier
+var schemaPrefixPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,29}$`)
+
+// SchemaIsolation reports whether organizations may have their own schema
+func (c *TenancyConfig) SchemaIsolation() bool {
+	return c.Mode == "schema"
+}
+
+// ResidencyConfig holds where the data of users is stored. Users are tagged with a region at
+// registration, and the data of users of a region with a database of its own is kept there.
+type ResidencyConfig struct {
+	// HomeRegion is the region of the primary database and of users no organization or
+	// country assigns to another region; empty disables residency tagging
+	HomeRegion string `mapstructure:"home_region"`
+	// Regions are the other regions, each with a database of its own
+	Regions []ResidencyRegionConfig `mapstructure:"regions"`
+}
+
+// ResidencyRegionConfig is a region storing the data of its users in a database of its own.
+// The database shares the user, password, database name and SSL mode of the primary.
+type ResidencyRegionConfig struct {
+	Name string `mapstructure:"name"`
+	// Countries are the ISO 3166 alpha-2 codes of the countries whose users register into the region
+	Countries []string `mapstructure:"countries"`
+	Host      string   `mapstructure:"host"`
+	Port      int      `mapstructure:"port"`
+}
+
+// regionNamePattern keeps region names short and safe in tokens, metadata and labels
+var regionNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,15}$`)
+
+// countryCodePattern matches ISO 3166 alpha-2 codes in upper case
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Enabled reports whether users are tagged with a region
+func (c *ResidencyConfig) Enabled() bool {
+	return c.HomeRegion != ""
+}
+
+// IsRegion reports whether name is the home region or one of the other regions
+func (c *ResidencyConfig) IsRegion(name string) bool {
+	if name == "" {
+		return false
+	}
+	return name == c.HomeRegion || slices.ContainsFunc(c.Regions, func(region ResidencyRegionConfig) bool {
+		return region.Name == name
+	})
+}
+
+// RegionOfCountry returns the region users of a country register into, the home region for
+// countries of no region
+func (c *ResidencyConfig) RegionOfCountry(country string) string {
+	for _, region := range c.Regions {
+		if slices.Contains(region.Countries, country) {
+			return region.Name
+		}
+	}
+	return c.HomeRegion
+}
+
 // LoadConfig loads configuration using Viper
 func LoadConfig(configPath string) (*Config, error) {
 	v := viper.New()
@@ -102,12 +999,37 @@ func LoadConfig(configPath string) (*Config, error) {
 
 // setDefaults sets default configuration values
 func setDefaults(v *viper.Viper) {
+	// App defaults
+	v.SetDefault("app.environment", "development")
+
 	// Server defaults
 	v.SetDefault("server.port", "50051")
 	v.SetDefault("server.host", "0.0.0.0")
 	v.SetDefault("server.read_timeout", "30s")
 	v.SetDefault("server.write_timeout", "30s")
 	v.SetDefault("server.idle_timeout", "60s")
+	v.SetDefault("server.keepalive.max_connection_idle", "15m")
+	v.SetDefault("server.keepalive.max_connection_age", "5m")
+	v.SetDefault("server.keepalive.max_connection_age_grace", "30s")
+	v.SetDefault("server.keepalive.time", "1m")
+	v.SetDefault("server.keepalive.timeout", "20s")
+	v.SetDefault("server.keepalive.min_time", "10s")
+	v.SetDefault("server.keepalive.permit_without_stream", true)
+	v.SetDefault("server.request_timeout", "0s")
+	v.SetDefault("server.interceptors.metrics", true)
+	v.SetDefault("server.interceptors.recovery", true)
+	v.SetDefault("server.interceptors.logging", true)
+	v.SetDefault("server.interceptors.error_handling", true)
+	v.SetDefault("server.compression.enabled", true)
+	v.SetDefault("server.compression.algorithms", []string{"gzip"})
+	v.SetDefault("server.compression.gzip_level", -1)
+	v.SetDefault("server.compression.methods", []string{
+		"/user.UserService/ExportUsers",
+		"/user.UserService/ExportSnapshot",
+		"/user.UserService/ExportOrgAuditLog",
+		"/user.UserService/BatchAssignRole",
+		"/user.UserService/BatchUpdateStatus",
+	})
 
 	// Database defaults
 	v.SetDefault("database.host", "localhost")
@@ -116,11 +1038,33 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.password", "password")
 	v.SetDefault("database.db_name", "user_svc")
 	v.SetDefault("database.ssl_mode", "disable")
+	v.SetDefault("database.retry.max_attempts", 3)
+	v.SetDefault("database.retry.initial_backoff", "50ms")
+	v.SetDefault("database.retry.max_backoff", "1s")
+	v.SetDefault("database.replica.enabled", false)
+	v.SetDefault("database.replica.host", "")
+	v.SetDefault("database.replica.port", 5432)
+	v.SetDefault("database.replica.wait_timeout", "200ms")
+	v.SetDefault("database.replica.poll_interval", "10ms")
 
 	// JWT defaults
 	v.SetDefault("jwt.secret_key", "your-secret-key-change-in-production")
 	v.SetDefault("jwt.access_token_duration", "15m")
 	v.SetDefault("jwt.refresh_token_duration", "168h") // 7 days
+	v.SetDefault("jwt.short_refresh_token_duration", "12h")
+	v.SetDefault("jwt.session_idle_timeout", "0s")
+	v.SetDefault("jwt.session_activity_interval", "1m")
+	v.SetDefault("jwt.secondary_secret_key", "")
+	v.SetDefault("jwt.key_channel", "user-svc:jwt-signing-keys")
+	v.SetDefault("jwt.key_resync_interval", "30s")
+	v.SetDefault("jwt.claim_profile", "standard")
+	v.SetDefault("jwt.encryption_key", "")
+	v.SetDefault("jwt.secondary_encryption_key", "")
+	v.SetDefault("jwt.token_format", "jwt")
+	v.SetDefault("jwt.accepted_formats", []string{"jwt", "paseto_v4"})
+	v.SetDefault("jwt.issuer", "user-svc")
+	v.SetDefault("jwt.audience", "")
+	v.SetDefault("jwt.claims_mode", "off")
 
 	// Redis defaults
 	v.SetDefault("redis.host", "localhost")
@@ -131,6 +1075,29 @@ func setDefaults(v *viper.Viper) {
 	// Log defaults
 	v.SetDefault("log.level", "info")
 	v.SetDefault("log.format", "json")
+	v.SetDefault("log.sampling.success_rate", 1.0)
+	v.SetDefault("log.sampling.error_rate", 1.0)
+	v.SetDefault("log.capture_payloads", false)
+	v.SetDefault("log.file.enabled", false)
+	v.SetDefault("log.file.path", "logs/user-svc.log")
+	v.SetDefault("log.file.max_size", 100)
+	v.SetDefault("log.file.max_age", 28)
+	v.SetDefault("log.file.max_backups", 7)
+	v.SetDefault("log.file.compress", true)
+	v.SetDefault("log.security.enabled", false)
+	v.SetDefault("log.security.format", "ecs")
+	v.SetDefault("log.security.sink", "file")
+	v.SetDefault("log.security.file", "logs/security-events.ndjson")
+	v.SetDefault("log.security.syslog.network", "udp")
+	v.SetDefault("log.security.syslog.address", "localhost:514")
+	v.SetDefault("log.security.http.url", "")
+	v.SetDefault("log.security.http.token", "")
+	v.SetDefault("log.security.http.timeout", "5s")
+	v.SetDefault("log.security.pipeline.buffer_size", 10000)
+	v.SetDefault("log.security.pipeline.batch_size", 100)
+	v.SetDefault("log.security.pipeline.flush_interval", "1s")
+	v.SetDefault("log.security.pipeline.flush_timeout", "10s")
+	v.SetDefault("log.security.pipeline.policy", "drop_oldest")
 
 	// Worker defaults
 	v.SetDefault("worker.notification.enabled", true)
@@ -138,6 +1105,252 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("worker.notification.max_retries", 5)
 	v.SetDefault("worker.notification.batch_size", 1000)
 	v.SetDefault("worker.notification.concurrency", 1)
+	v.SetDefault("worker.scheduler.enabled", true)
+	v.SetDefault("worker.scheduler.interval", "1m")
+	v.SetDefault("worker.scheduler.lease", "30m")
+	v.SetDefault("worker.timers.enabled", true)
+	v.SetDefault("worker.timers.interval", "10s")
+	v.SetDefault("worker.timers.batch_size", 100)
+	v.SetDefault("worker.timers.initial_backoff", "30s")
+	v.SetDefault("worker.timers.max_backoff", "1h")
+	v.SetDefault("worker.security_digest.enabled", false)
+
+	// Pipeline defaults
+	v.SetDefault("pipeline.audit.buffer_size", 10000)
+	v.SetDefault("pipeline.audit.batch_size", 500)
+	v.SetDefault("pipeline.audit.flush_interval", "1s")
+	v.SetDefault("pipeline.audit.flush_timeout", "10s")
+	v.SetDefault("pipeline.audit.policy", "drop_oldest")
+	v.SetDefault("pipeline.events.buffer_size", 10000)
+	v.SetDefault("pipeline.events.batch_size", 500)
+	v.SetDefault("pipeline.events.flush_interval", "500ms")
+	v.SetDefault("pipeline.events.flush_timeout", "10s")
+	v.SetDefault("pipeline.events.policy", "block")
+	v.SetDefault("pipeline.login_attempts.buffer_size", 10000)
+	v.SetDefault("pipeline.login_attempts.batch_size", 500)
+	v.SetDefault("pipeline.login_attempts.flush_interval", "1s")
+	v.SetDefault("pipeline.login_attempts.flush_timeout", "10s")
+	v.SetDefault("pipeline.login_attempts.policy", "drop_oldest")
+
+	// Ops server defaults
+	v.SetDefault("ops.enabled", true)
+	v.SetDefault("ops.host", "0.0.0.0")
+	v.SetDefault("ops.port", "9090")
+	v.SetDefault("ops.metrics_path", "/metrics")
+	v.SetDefault("ops.slo_path", "/slo")
+	v.SetDefault("ops.health_path", "/healthz")
+	v.SetDefault("ops.ready_path", "/readyz")
+
+	// GraphQL defaults
+	v.SetDefault("graphql.enabled", false)
+	v.SetDefault("graphql.path", "/graphql")
+
+	// gRPC-Web defaults
+	v.SetDefault("grpc_web.enabled", false)
+	v.SetDefault("grpc_web.host", "0.0.0.0")
+	v.SetDefault("grpc_web.port", "8080")
+	v.SetDefault("grpc_web.allowed_origins", []string{})
+	v.SetDefault("grpc_web.allowed_headers", []string{"authorization", "dpop", metautils.LocaleKey, metautils.RequestIDKey, metautils.ClientIDKey, "x-consistency-token", "x-consistency-mode"})
+	v.SetDefault("grpc_web.max_age", "10m")
+	v.SetDefault("grpc_web.read_header_timeout", "10s")
+	v.SetDefault("grpc_web.idle_timeout", "2m")
+	v.SetDefault("grpc_web.refresh_token_cookie", false)
+	v.SetDefault("grpc_web.session_cookies", false)
+	v.SetDefault("grpc_web.cookie.domain", "")
+	v.SetDefault("grpc_web.cookie.path", "/")
+	v.SetDefault("grpc_web.cookie.same_site", "strict")
+
+	// Quota defaults
+	v.SetDefault("quota.enabled", false)
+	v.SetDefault("quota.period", "monthly")
+	v.SetDefault("quota.fail_open", true)
+	v.SetDefault("quota.user_limit", 0)
+	v.SetDefault("quota.thresholds", []int{80, 100})
+	v.SetDefault("quota.warning_percent", 80)
+
+	// Login queue defaults
+	v.SetDefault("login_queue.enabled", false)
+	v.SetDefault("login_queue.methods", []string{"/user.UserService/Login", "/user.v2.UserService/Login"})
+	v.SetDefault("login_queue.capacity", 0)
+	v.SetDefault("login_queue.max_queued", 10000)
+	v.SetDefault("login_queue.max_queued_per_caller", 5)
+	v.SetDefault("login_queue.max_wait", "10s")
+
+	// Revocation defaults
+	v.SetDefault("revocation.channel", "user-svc:token-revocations")
+	v.SetDefault("revocation.resync_interval", "30s")
+
+	// Cache defaults
+	v.SetDefault("cache.user_ttl", "30s")
+
+	// DPoP defaults
+	v.SetDefault("dpop.enabled", true)
+	v.SetDefault("dpop.proof_max_age", "60s")
+	v.SetDefault("dpop.clock_skew", "5s")
+
+	// Email defaults
+	v.SetDefault("email.templates_dir", "templates/email")
+	v.SetDefault("email.default_locale", "en")
+	v.SetDefault("email.reload_interval", "30s")
+
+	// Notifier defaults
+	v.SetDefault("notifier.enabled", false)
+	v.SetDefault("notifier.routes", map[string][]string{
+		"login":           {"email", "push"},
+		"security_digest": {"email"},
+	})
+	v.SetDefault("notifier.channels", []string{"email"})
+	v.SetDefault("notifier.max_attempts", 5)
+	v.SetDefault("notifier.initial_backoff", "30s")
+	v.SetDefault("notifier.max_backoff", "1h")
+	v.SetDefault("notifier.retry_interval", "30s")
+	v.SetDefault("notifier.lease", "1m")
+	v.SetDefault("notifier.batch_size", 100)
+
+	// Export defaults
+	v.SetDefault("export.chunk_size", 500)
+	v.SetDefault("export.max_rows_per_second", 2000)
+
+	// Organization audit defaults
+	v.SetDefault("org_audit.max_export_range", "2160h")
+	v.SetDefault("org_audit.webhooks.enabled", false)
+	v.SetDefault("org_audit.webhooks.interval", "1m")
+	v.SetDefault("org_audit.webhooks.settle", "1m")
+	v.SetDefault("org_audit.webhooks.timeout", "10s")
+	v.SetDefault("org_audit.webhooks.batch_size", 100)
+
+	// Token exchange defaults
+	v.SetDefault("token_exchange.ttl", "5m")
+	v.SetDefault("token_exchange.max_delegation_depth", 3)
+
+	// OIDC defaults
+	v.SetDefault("oidc.jwks_cache_ttl", "1h")
+	v.SetDefault("oidc.http_timeout", "5s")
+
+	// Risk defaults
+	v.SetDefault("risk.disposable_email_domains", []string{})
+	v.SetDefault("risk.device_window", "720h")
+	v.SetDefault("risk.country_metadata_key", "x-client-country")
+	v.SetDefault("risk.country_change_window", "30m")
+	v.SetDefault("risk.refresh_rate_window", "1m")
+	v.SetDefault("risk.max_refreshes_per_window", 10)
+
+	// Abuse defaults
+	v.SetDefault("abuse.channel", "user-svc:velocity-rules")
+	v.SetDefault("abuse.resync_interval", "30s")
+	v.SetDefault("abuse.key_prefix", "user-svc:velocity:")
+	v.SetDefault("abuse.device_metadata_key", metautils.DeviceFingerprintKey)
+	v.SetDefault("abuse.captcha_metadata_key", "x-captcha-token")
+	v.SetDefault("abuse.captcha.timeout", "5s")
+
+	// Canary defaults
+	v.SetDefault("canary.resync_interval", "30s")
+	v.SetDefault("canary.tarpit_delay", "10s")
+
+	// User metadata defaults
+	v.SetDefault("user_metadata.max_keys", 50)
+	v.SetDefault("user_metadata.max_value_bytes", 256)
+	v.SetDefault("user_metadata.max_total_bytes", 8192)
+
+	// Storage defaults
+	v.SetDefault("storage.endpoint", "https://s3.amazonaws.com")
+	v.SetDefault("storage.region", "us-east-1")
+	v.SetDefault("storage.bucket", "")
+	v.SetDefault("storage.access_key_id", "")
+	v.SetDefault("storage.secret_access_key", "")
+	v.SetDefault("storage.path_style", false)
+	v.SetDefault("storage.public_base_url", "")
+	v.SetDefault("storage.timeout", "5s")
+	v.SetDefault("storage.avatars.key_prefix", "avatars/")
+	v.SetDefault("storage.avatars.upload_url_ttl", "15m")
+	v.SetDefault("storage.avatars.max_bytes", 5*1024*1024)
+	v.SetDefault("storage.avatars.content_types", []string{"image/jpeg", "image/png", "image/webp"})
+
+	// Admin defaults
+	v.SetDefault("admin.max_batch_users", 500)
+
+	// Import defaults
+	v.SetDefault("import.max_batch_size", 500)
+	v.SetDefault("import.setup_token_ttl", "168h")
+	v.SetDefault("import.setup_url", "https://tickets.example.com/setup-password?token={token}")
+	v.SetDefault("import.reminder_before", "48h")
+	v.SetDefault("registration.mode", "direct")
+	v.SetDefault("registration.code_ttl", "10m")
+	v.SetDefault("registration.max_attempts", 5)
+	v.SetDefault("registration.resend_interval", "1m")
+
+	// Password hashing defaults
+	v.SetDefault("password_hashing.bcrypt_cost", 10)
+	v.SetDefault("password_hashing.pepper", "")
+	v.SetDefault("password_hashing.secondary_pepper", "")
+
+	// Password reset defaults
+	v.SetDefault("password_reset.token_ttl", "1h")
+	v.SetDefault("password_reset.resend_interval", "1m")
+	v.SetDefault("password_reset.url", "https://tickets.example.com/reset-password?token={token}")
+
+	// Email verification defaults
+	v.SetDefault("email_verification.token_ttl", "24h")
+	v.SetDefault("email_verification.url", "https://tickets.example.com/verify?token={token}")
+	v.SetDefault("email_verification.resend_cooldown", "1m")
+	v.SetDefault("email_verification.daily_limit", 5)
+
+	// Referral defaults
+	v.SetDefault("referral.max_redemptions", 10)
+	v.SetDefault("referral.max_active_codes", 5)
+
+	// Login history defaults
+	v.SetDefault("login_history.retention", "2160h")
+
+	// Inactivity defaults
+	v.SetDefault("inactivity.enabled", false)
+	v.SetDefault("inactivity.after", "4320h")
+	v.SetDefault("inactivity.reverify_after", "0s")
+	v.SetDefault("inactivity.batch_size", 500)
+
+	// Locks defaults
+	v.SetDefault("locks.backend", "redis")
+	v.SetDefault("locks.ttl", "30s")
+	v.SetDefault("locks.key_prefix", "user-svc:lock:")
+
+	// Nonces defaults
+	v.SetDefault("nonces.backend", "redis")
+	v.SetDefault("nonces.key_prefix", "user-svc:nonce:")
+
+	// Tenancy defaults
+	v.SetDefault("tenancy.mode", "shared")
+	v.SetDefault("tenancy.schema_prefix", "tenant_")
+	v.SetDefault("tenancy.max_open_conns_per_schema", 5)
+	v.SetDefault("tenancy.cache_ttl", "1m")
+
+	// Watch defaults
+	v.SetDefault("watch.enabled", false)
+	v.SetDefault("watch.max_users_per_stream", 1000)
+	v.SetDefault("watch.resync_interval", "5m")
+
+	// Maintenance defaults
+	v.SetDefault("maintenance.batch_pause", "100ms")
+
+	// Preflight defaults
+	v.SetDefault("preflight.timeout", "30s")
+	v.SetDefault("preflight.warm_connections", 2)
+
+	// Circuit breaker defaults
+	v.SetDefault("circuit_breaker.event_bus.failure_threshold", 5)
+	v.SetDefault("circuit_breaker.event_bus.open_timeout", "30s")
+	v.SetDefault("circuit_breaker.event_bus.half_open_max_calls", 1)
+
+	// Fault injection defaults
+	v.SetDefault("fault_injection.enabled", false)
+
+	// Request capture defaults
+	v.SetDefault("capture.enabled", false)
+	v.SetDefault("capture.dir", "captures")
+
+	// SLO defaults
+	v.SetDefault("slo.enabled", true)
+	v.SetDefault("slo.window", "1h")
+	v.SetDefault("slo.report_interval", "15s")
 }
 
 // GetDSN returns the database connection string
@@ -156,17 +1369,503 @@ func (c *ServerConfig) GetServerAddr() string {
 	return fmt.Sprintf("%s:%s", c.Host, c.Port)
 }
 
+// GetOpsAddr returns the ops server address
+func (c *OpsConfig) GetOpsAddr() string {
+	return fmt.Sprintf("%s:%s", c.Host, c.Port)
+}
+
+// GetAddr returns the gRPC-Web listener address
+func (c *GRPCWebConfig) GetAddr() string {
+	return fmt.Sprintf("%s:%s", c.Host, c.Port)
+}
+
+// TokenCookies reports whether tokens are delivered to browsers in cookies
+func (c *GRPCWebConfig) TokenCookies() bool {
+	return c.RefreshTokenCookie || c.SessionCookies
+}
+
+// GetSameSite returns the SameSite attribute of the token cookies
+func (c *GRPCWebCookieConfig) GetSameSite() http.SameSite {
+	switch strings.ToLower(c.SameSite) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	if c.Server.Port == "" {
 		return fmt.Errorf("server port is required")
 	}
+	if keepalive := c.Server.Keepalive; keepalive.MaxConnectionIdle < 0 || keepalive.MaxConnectionAge < 0 ||
+		keepalive.MaxConnectionAgeGrace < 0 || keepalive.Time < 0 || keepalive.Timeout < 0 || keepalive.MinTime < 0 {
+		return fmt.Errorf("server keepalive durations must not be negative")
+	}
+	if c.Server.RequestTimeout < 0 {
+		return fmt.Errorf("server request timeout must not be negative")
+	}
+	for _, methodTimeout := range c.Server.MethodTimeouts {
+		if !strings.HasPrefix(methodTimeout.Method, "/") || methodTimeout.Timeout < 0 {
+			return fmt.Errorf("server method timeouts need a full method name and a non-negative timeout, got %q", methodTimeout.Method)
+		}
+	}
+	if compression := c.Server.Compression; compression.Enabled {
+		if len(compression.Algorithms) == 0 {
+			return fmt.Errorf("server compression needs at least one algorithm")
+		}
+		for _, algorithm := range compression.Algorithms {
+			if algorithm != "gzip" && algorithm != "zstd" {
+				return fmt.Errorf("server compression algorithm must be gzip or zstd, got %q", algorithm)
+			}
+		}
+		if compression.GzipLevel < -1 || compression.GzipLevel > 9 || compression.GzipLevel == 0 {
+			return fmt.Errorf("server compression gzip level must be -1 or between 1 and 9")
+		}
+	}
 	if c.Database.Host == "" {
 		return fmt.Errorf("database host is required")
 	}
+	if replica := c.Database.Replica; replica.Enabled && (replica.Host == "" || replica.WaitTimeout < 0 || replica.PollInterval <= 0) {
+		return fmt.Errorf("database replica needs a host, a non-negative wait timeout and a positive poll interval")
+	}
 	if c.JWT.SecretKey == "" {
 		return fmt.Errorf("JWT secret key is required")
 	}
+	if c.JWT.SecondarySecretKey != "" && (len(c.JWT.SecondarySecretKey) < 32 || c.JWT.SecondarySecretKey == c.JWT.SecretKey) {
+		return fmt.Errorf("JWT secondary secret key must be at least 32 characters and differ from the secret key")
+	}
+	if c.JWT.KeyChannel == "" || c.JWT.KeyResyncInterval <= 0 {
+		return fmt.Errorf("JWT key channel and a positive key resync interval are required")
+	}
+	if c.JWT.ShortRefreshTokenDuration <= 0 {
+		return fmt.Errorf("JWT short refresh token duration must be positive")
+	}
+	// Sessions whose access tokens are never verified are only seen active when they refresh,
+	// once per access token lifetime
+	if c.JWT.SessionIdleTimeout < 0 || (c.JWT.SessionIdleTimeout > 0 && c.JWT.SessionIdleTimeout < c.JWT.AccessTokenDuration) {
+		return fmt.Errorf("JWT session idle timeout must be 0 or at least the access token duration")
+	}
+	if c.JWT.SessionActivityInterval <= 0 {
+		return fmt.Errorf("JWT session activity interval must be positive")
+	}
+	if !slices.Contains([]string{"minimal", "standard", "internal"}, c.JWT.ClaimProfile) {
+		return fmt.Errorf("JWT claim profile must be minimal, standard or internal")
+	}
+	if c.JWT.EncryptionKey != "" && len(c.JWT.EncryptionKey) < 32 {
+		return fmt.Errorf("JWT encryption key must be at least 32 characters")
+	}
+	if c.JWT.SecondaryEncryptionKey != "" && (c.JWT.EncryptionKey == "" ||
+		len(c.JWT.SecondaryEncryptionKey) < 32 || c.JWT.SecondaryEncryptionKey == c.JWT.EncryptionKey) {
+		return fmt.Errorf("JWT secondary encryption key requires an encryption key, must be at least 32 characters and differ from it")
+	}
+	tokenFormats := []string{"jwt", "paseto_v4"}
+	if !slices.Contains(tokenFormats, c.JWT.TokenFormat) || !slices.Contains(c.JWT.AcceptedFormats, c.JWT.TokenFormat) {
+		return fmt.Errorf("JWT token format must be jwt or paseto_v4 and one of the accepted formats")
+	}
+	for i, format := range c.JWT.AcceptedFormats {
+		if !slices.Contains(tokenFormats, format) || slices.Contains(c.JWT.AcceptedFormats[:i], format) {
+			return fmt.Errorf("JWT accepted formats must be distinct formats out of jwt and paseto_v4, got %q", format)
+		}
+	}
+	if !slices.Contains([]string{"off", "report", "enforce"}, c.JWT.ClaimsMode) {
+		return fmt.Errorf("JWT claims mode must be off, report or enforce")
+	}
+	// Strict claims check the issuer and audience, so tokens must be created with both
+	if c.JWT.ClaimsMode != "off" && (c.JWT.Issuer == "" || c.JWT.Audience == "") {
+		return fmt.Errorf("JWT claims mode %s requires an issuer and an audience", c.JWT.ClaimsMode)
+	}
+	switch c.Log.Level {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		return fmt.Errorf("invalid log level: %q", c.Log.Level)
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		return fmt.Errorf("invalid log format: %q", c.Log.Format)
+	}
+	if c.Log.Sampling.SuccessRate < 0 || c.Log.Sampling.SuccessRate > 1 ||
+		c.Log.Sampling.ErrorRate < 0 || c.Log.Sampling.ErrorRate > 1 {
+		return fmt.Errorf("log sampling rates must be between 0 and 1")
+	}
+	if c.Log.CapturePayloads && c.App.IsProduction() {
+		return fmt.Errorf("log payload capture must not be enabled in production")
+	}
+	if file := c.Log.File; file.Enabled {
+		if file.Path == "" {
+			return fmt.Errorf("log file requires a path")
+		}
+		if file.MaxSize <= 0 {
+			return fmt.Errorf("log file max size must be positive")
+		}
+		if file.MaxAge < 0 || file.MaxBackups < 0 {
+			return fmt.Errorf("log file max age and max backups must not be negative")
+		}
+	}
+	if security := c.Log.Security; security.Enabled {
+		if security.Format != "ecs" && security.Format != "ocsf" {
+			return fmt.Errorf("invalid security log format: %q", security.Format)
+		}
+		switch security.Sink {
+		case "file":
+			if security.File == "" {
+				return fmt.Errorf("security log file sink requires a file")
+			}
+		case "syslog":
+			if (security.Syslog.Network != "udp" && security.Syslog.Network != "tcp") || security.Syslog.Address == "" {
+				return fmt.Errorf("security log syslog sink requires a udp or tcp network and an address")
+			}
+		case "http":
+			if !strings.HasPrefix(security.HTTP.URL, "https://") && !strings.HasPrefix(security.HTTP.URL, "http://") {
+				return fmt.Errorf("security log http sink requires an http(s) URL")
+			}
+			if security.HTTP.Timeout <= 0 {
+				return fmt.Errorf("security log http timeout must be positive")
+			}
+		default:
+			return fmt.Errorf("invalid security log sink: %q", security.Sink)
+		}
+	}
+	for name, stream := range map[string]PipelineStreamConfig{
+		"audit":          c.Pipeline.Audit,
+		"events":         c.Pipeline.Events,
+		"login_attempts": c.Pipeline.LoginAttempts,
+		"security":       c.Log.Security.Pipeline,
+	} {
+		switch stream.Policy {
+		case "block", "drop_newest", "drop_oldest":
+		default:
+			return fmt.Errorf("invalid %s pipeline policy: %q", name, stream.Policy)
+		}
+	}
+	if c.Revocation.Channel == "" || c.Revocation.ResyncInterval <= 0 {
+		return fmt.Errorf("revocation channel and a positive resync interval are required")
+	}
+	if c.Worker.Scheduler.Enabled && (c.Worker.Scheduler.Interval <= 0 || c.Worker.Scheduler.Lease <= 0) {
+		return fmt.Errorf("scheduler interval and lease must be positive")
+	}
+	if c.DPoP.Enabled && (c.DPoP.ProofMaxAge <= 0 || c.DPoP.ClockSkew < 0) {
+		return fmt.Errorf("dpop proof max age must be positive and clock skew must not be negative")
+	}
+	if c.Email.TemplatesDir == "" || c.Email.DefaultLocale == "" || c.Email.ReloadInterval < 0 {
+		return fmt.Errorf("email templates dir and default locale are required and reload interval must not be negative")
+	}
+	for _, key := range c.Admin.APIKeys {
+		if key.ID == "" || len(key.KeyHash) != 64 {
+			return fmt.Errorf("admin API keys require an id and a SHA-256 hex key_hash")
+		}
+	}
+	for _, key := range c.Services.APIKeys {
+		if key.ID == "" || len(key.KeyHash) != 64 || len(key.Scopes) == 0 {
+			return fmt.Errorf("service API keys require an id, a SHA-256 hex key_hash and scopes")
+		}
+	}
+	if c.TokenExchange.TTL <= 0 || c.TokenExchange.MaxDelegationDepth <= 0 {
+		return fmt.Errorf("token exchange TTL and max delegation depth must be positive")
+	}
+	for _, audience := range c.TokenExchange.Audiences {
+		if audience.Audience == "" || len(audience.Actors) == 0 || len(audience.Scopes) == 0 {
+			return fmt.Errorf("token exchange audiences require an audience, actors and scopes")
+		}
+	}
+	providers := make(map[string]bool, len(c.OIDC.Providers))
+	for _, provider := range c.OIDC.Providers {
+		if provider.Name == "" || providers[provider.Name] {
+			return fmt.Errorf("oidc providers require a unique name")
+		}
+		providers[provider.Name] = true
+		if !strings.HasPrefix(provider.Issuer, "https://") || len(provider.ClientIDs) == 0 {
+			return fmt.Errorf("oidc provider %s needs an https issuer and client IDs", provider.Name)
+		}
+		if provider.JWKSURL != "" && !strings.HasPrefix(provider.JWKSURL, "https://") {
+			return fmt.Errorf("oidc provider %s jwks url must be https", provider.Name)
+		}
+	}
+	if c.OIDC.JWKSCacheTTL <= 0 || c.OIDC.HTTPTimeout <= 0 {
+		return fmt.Errorf("oidc jwks cache ttl and http timeout must be positive")
+	}
+	if c.Risk.DeviceWindow <= 0 {
+		return fmt.Errorf("risk device window must be positive")
+	}
+	if c.Risk.CountryChangeWindow <= 0 || c.Risk.RefreshRateWindow <= 0 || c.Risk.MaxRefreshesPerWindow <= 0 {
+		return fmt.Errorf("risk country change window, refresh rate window and max refreshes per window must be positive")
+	}
+	if c.Abuse.Channel == "" || c.Abuse.KeyPrefix == "" || c.Abuse.ResyncInterval <= 0 {
+		return fmt.Errorf("abuse channel and key prefix are required and resync interval must be positive")
+	}
+	if c.Abuse.DeviceMetadataKey == "" || c.Abuse.CaptchaMetadataKey == "" {
+		return fmt.Errorf("abuse device and captcha metadata keys are required")
+	}
+	if c.Abuse.Captcha.VerifyURL != "" {
+		if !strings.HasPrefix(c.Abuse.Captcha.VerifyURL, "https://") || c.Abuse.Captcha.Secret == "" {
+			return fmt.Errorf("abuse captcha needs an https verify URL and a secret")
+		}
+		if c.Abuse.Captcha.Timeout <= 0 {
+			return fmt.Errorf("abuse captcha timeout must be positive")
+		}
+	}
+	if c.Canary.ResyncInterval <= 0 || c.Canary.TarpitDelay <= 0 {
+		return fmt.Errorf("canary resync interval and tarpit delay must be positive")
+	}
+	if c.Export.ChunkSize <= 0 || c.Export.MaxRowsPerSecond <= 0 {
+		return fmt.Errorf("export chunk size and max rows per second must be positive")
+	}
+	if c.OrgAudit.MaxExportRange <= 0 {
+		return fmt.Errorf("org audit max export range must be positive")
+	}
+	if c.OrgAudit.Webhooks.Enabled {
+		if c.OrgAudit.Webhooks.Interval <= 0 || c.OrgAudit.Webhooks.Timeout <= 0 || c.OrgAudit.Webhooks.BatchSize <= 0 {
+			return fmt.Errorf("org audit webhook interval, timeout and batch size must be positive")
+		}
+		if c.OrgAudit.Webhooks.Settle <= c.Pipeline.Audit.FlushInterval {
+			return fmt.Errorf("org audit webhook settle must exceed the audit pipeline flush interval")
+		}
+		for _, endpoint := range c.OrgAudit.Webhooks.Endpoints {
+			if _, err := uuid.Parse(endpoint.OrganizationID); err != nil {
+				return fmt.Errorf("org audit webhook organization ID %q must be a UUID", endpoint.OrganizationID)
+			}
+			if !strings.HasPrefix(endpoint.URL, "https://") || endpoint.Secret == "" {
+				return fmt.Errorf("org audit webhook of organization %s needs an https URL and a secret", endpoint.OrganizationID)
+			}
+		}
+	}
+	if c.Admin.MaxBatchUsers <= 0 {
+		return fmt.Errorf("admin max batch users must be positive")
+	}
+	if c.Import.MaxBatchSize <= 0 || c.Import.SetupTokenTTL <= 0 {
+		return fmt.Errorf("import max batch size and setup token TTL must be positive")
+	}
+	if !strings.Contains(c.Import.SetupURL, "{token}") {
+		return fmt.Errorf("import setup URL must contain {token}")
+	}
+	if c.Import.ReminderBefore < 0 || c.Import.ReminderBefore >= c.Import.SetupTokenTTL {
+		return fmt.Errorf("import reminder must be sent before the setup token expires")
+	}
+	if c.Registration.Mode != "direct" && c.Registration.Mode != "email_otp" {
+		return fmt.Errorf("registration mode must be direct or email_otp")
+	}
+	if c.Registration.CodeTTL <= 0 || c.Registration.MaxAttempts <= 0 {
+		return fmt.Errorf("registration code TTL and max attempts must be positive")
+	}
+	if c.Registration.ResendInterval < 0 || c.Registration.ResendInterval >= c.Registration.CodeTTL {
+		return fmt.Errorf("registration resend interval must be shorter than the code TTL")
+	}
+	if c.PasswordHashing.BcryptCost < 10 || c.PasswordHashing.BcryptCost > 31 {
+		return fmt.Errorf("password hashing bcrypt cost must be between 10 and 31")
+	}
+	if c.PasswordHashing.Pepper != "" && len(c.PasswordHashing.Pepper) < 32 {
+		return fmt.Errorf("password hashing pepper must be at least 32 characters")
+	}
+	if c.PasswordHashing.SecondaryPepper != "" && (c.PasswordHashing.Pepper == "" ||
+		len(c.PasswordHashing.SecondaryPepper) < 32 || c.PasswordHashing.SecondaryPepper == c.PasswordHashing.Pepper) {
+		return fmt.Errorf("password hashing secondary pepper requires a pepper, must be at least 32 characters and differ from it")
+	}
+	if c.PasswordReset.TokenTTL <= 0 {
+		return fmt.Errorf("password reset token TTL must be positive")
+	}
+	if c.PasswordReset.ResendInterval < 0 || c.PasswordReset.ResendInterval >= c.PasswordReset.TokenTTL {
+		return fmt.Errorf("password reset resend interval must be shorter than the token TTL")
+	}
+	if !strings.Contains(c.PasswordReset.URL, "{token}") {
+		return fmt.Errorf("password reset URL must contain {token}")
+	}
+	if c.EmailVerification.TokenTTL <= 0 {
+		return fmt.Errorf("email verification token TTL must be positive")
+	}
+	if !strings.Contains(c.EmailVerification.URL, "{token}") {
+		return fmt.Errorf("email verification URL must contain {token}")
+	}
+	if c.EmailVerification.ResendCooldown < 0 {
+		return fmt.Errorf("email verification resend cooldown must not be negative")
+	}
+	if c.EmailVerification.DailyLimit <= 0 {
+		return fmt.Errorf("email verification daily limit must be positive")
+	}
+	if c.Referral.MaxRedemptions <= 0 || c.Referral.MaxActiveCodes <= 0 {
+		return fmt.Errorf("referral max redemptions and max active codes must be positive")
+	}
+	if c.LoginHistory.Retention < 24*time.Hour {
+		return fmt.Errorf("login history retention must be at least 24h")
+	}
+	if c.Inactivity.After < 24*time.Hour {
+		return fmt.Errorf("inactivity period must be at least 24h")
+	}
+	if c.Inactivity.ReverifyAfter != 0 && c.Inactivity.ReverifyAfter < 24*time.Hour {
+		return fmt.Errorf("inactivity reverify_after must be 0 or at least 24h")
+	}
+	if c.Inactivity.BatchSize <= 0 {
+		return fmt.Errorf("inactivity batch size must be positive")
+	}
+	if c.Locks.Backend != "redis" && c.Locks.Backend != "postgres" && c.Locks.Backend != "local" {
+		return fmt.Errorf("locks backend must be redis, postgres or local")
+	}
+	if c.Locks.TTL < time.Second {
+		return fmt.Errorf("locks TTL must be at least 1s")
+	}
+	if c.Nonces.Backend != "redis" && c.Nonces.Backend != "postgres" && c.Nonces.Backend != "local" {
+		return fmt.Errorf("nonces backend must be redis, postgres or local")
+	}
+	if c.Tenancy.Mode != "shared" && c.Tenancy.Mode != "schema" {
+		return fmt.Errorf("tenancy mode must be shared or schema")
+	}
+	if !schemaPrefixPattern.MatchString(c.Tenancy.SchemaPrefix) {
+		return fmt.Errorf("tenancy schema prefix must be 1-30 lower case letters, digits or underscores starting with a letter")
+	}
+	if c.Tenancy.MaxOpenConnsPerSchema <= 0 || c.Tenancy.CacheTTL <= 0 {
+		return fmt.Errorf("tenancy connections per schema and cache TTL must be positive")
+	}
+	if c.Residency.HomeRegion == "" && len(c.Residency.Regions) > 0 {
+		return fmt.Errorf("residency regions require a home region")
+	}
+	if c.Residency.Enabled() && !regionNamePattern.MatchString(c.Residency.HomeRegion) {
+		return fmt.Errorf("residency home region must be 1-16 lower case letters, digits or dashes starting with a letter")
+	}
+	regions, countries := map[string]bool{c.Residency.HomeRegion: true}, map[string]string{}
+	for _, region := range c.Residency.Regions {
+		if !regionNamePattern.MatchString(region.Name) || regions[region.Name] {
+			return fmt.Errorf("residency region %q must be a distinct name of 1-16 lower case letters, digits or dashes", region.Name)
+		}
+		regions[region.Name] = true
+		if region.Host == "" || region.Port <= 0 {
+			return fmt.Errorf("residency region %s needs a database host and port", region.Name)
+		}
+		for _, country := range region.Countries {
+			if !countryCodePattern.MatchString(country) {
+				return fmt.Errorf("residency region %s has country %q, expected an upper case ISO 3166 alpha-2 code", region.Name, country)
+			}
+			if other, ok := countries[country]; ok {
+				return fmt.Errorf("residency country %s is in regions %s and %s", country, other, region.Name)
+			}
+			countries[country] = region.Name
+		}
+	}
+	if c.Worker.Timers.Enabled && (c.Worker.Timers.Interval <= 0 || c.Worker.Timers.BatchSize <= 0 ||
+		c.Worker.Timers.InitialBackoff <= 0 || c.Worker.Timers.MaxBackoff < c.Worker.Timers.InitialBackoff) {
+		return fmt.Errorf("timer worker interval, batch size and backoff must be positive")
+	}
+	if c.Notifier.Enabled {
+		if c.Notifier.MaxAttempts <= 0 || c.Notifier.InitialBackoff <= 0 || c.Notifier.RetryInterval <= 0 ||
+			c.Notifier.Lease <= 0 || c.Notifier.BatchSize <= 0 {
+			return fmt.Errorf("notifier max attempts, backoff, retry interval, lease and batch size must be positive")
+		}
+		channels := append([]string{}, c.Notifier.Channels...)
+		for _, routed := range c.Notifier.Routes {
+			channels = append(channels, routed...)
+		}
+		for _, channel := range channels {
+			switch channel {
+			case "email", "sms", "push":
+			default:
+				return fmt.Errorf("invalid notifier channel: %q", channel)
+			}
+		}
+	}
+	if c.FaultInjection.Enabled {
+		if c.App.IsProduction() {
+			return fmt.Errorf("fault injection must not be enabled in production")
+		}
+		for _, rule := range c.FaultInjection.Rules {
+			if rule.Method == "" {
+				return fmt.Errorf("fault injection rules require a method")
+			}
+			if rule.Probability < 0 || rule.Probability > 1 {
+				return fmt.Errorf("fault injection probability of %s must be between 0 and 1", rule.Method)
+			}
+		}
+	}
+	if c.Capture.Enabled {
+		if c.App.IsProduction() {
+			return fmt.Errorf("request capture must not be enabled in production")
+		}
+		if c.Capture.Dir == "" {
+			return fmt.Errorf("request capture requires a directory")
+		}
+	}
+	if c.Watch.Enabled && (c.Watch.MaxUsersPerStream <= 0 || c.Watch.ResyncInterval <= 0) {
+		return fmt.Errorf("watch max users per stream and resync interval must be positive")
+	}
+	if c.Maintenance.BatchPause < 0 {
+		return fmt.Errorf("maintenance batch pause must not be negative")
+	}
+	if c.UserMetadata.MaxKeys <= 0 || c.UserMetadata.MaxValueBytes <= 0 || c.UserMetadata.MaxTotalBytes <= 0 {
+		return fmt.Errorf("user metadata limits must be positive")
+	}
+	if c.GraphQL.Enabled && (!c.Ops.Enabled || !strings.HasPrefix(c.GraphQL.Path, "/")) {
+		return fmt.Errorf("GraphQL requires the ops server and a path starting with /")
+	}
+	if c.GRPCWeb.Enabled {
+		if c.GRPCWeb.Port == "" || len(c.GRPCWeb.AllowedOrigins) == 0 {
+			return fmt.Errorf("gRPC-Web requires a port and at least one allowed origin")
+		}
+		if c.App.IsProduction() && slices.Contains(c.GRPCWeb.AllowedOrigins, "*") {
+			return fmt.Errorf("gRPC-Web must not allow any origin in production")
+		}
+		if c.GRPCWeb.TokenCookies() && slices.Contains(c.GRPCWeb.AllowedOrigins, "*") {
+			return fmt.Errorf("gRPC-Web token cookies require explicit allowed origins")
+		}
+		if !slices.Contains([]string{"strict", "lax", "none"}, strings.ToLower(c.GRPCWeb.Cookie.SameSite)) {
+			return fmt.Errorf("gRPC-Web cookie same_site must be strict, lax or none")
+		}
+		if !strings.HasPrefix(c.GRPCWeb.Cookie.Path, "/") {
+			return fmt.Errorf("gRPC-Web cookie path must start with /")
+		}
+	}
+	if c.Storage.Bucket != "" {
+		if c.Storage.Endpoint == "" || c.Storage.Region == "" || c.Storage.AccessKeyID == "" || c.Storage.SecretAccessKey == "" {
+			return fmt.Errorf("storage requires an endpoint, region and access key")
+		}
+		if c.Storage.Timeout <= 0 {
+			return fmt.Errorf("storage timeout must be positive")
+		}
+		if avatars := c.Storage.Avatars; avatars.UploadURLTTL <= 0 || avatars.UploadURLTTL > 7*24*time.Hour {
+			return fmt.Errorf("avatar upload URL TTL must be positive and at most 168h")
+		}
+		if c.Storage.Avatars.MaxBytes <= 0 || len(c.Storage.Avatars.ContentTypes) == 0 {
+			return fmt.Errorf("avatars require a positive max_bytes and at least one content type")
+		}
+	}
+	if c.SLO.Enabled {
+		if c.SLO.Window <= 0 || c.SLO.ReportInterval <= 0 {
+			return fmt.Errorf("SLO window and report interval must be positive")
+		}
+		for _, target := range c.SLO.Targets {
+			if target.Method == "" || target.LatencyThreshold <= 0 {
+				return fmt.Errorf("SLO targets require a method and a positive latency threshold")
+			}
+			if target.LatencyObjective <= 0 || target.LatencyObjective >= 1 ||
+				target.AvailabilityObjective <= 0 || target.AvailabilityObjective >= 1 {
+				return fmt.Errorf("SLO objectives of %s must be between 0 and 1 (exclusive)", target.Method)
+			}
+		}
+	}
+	if c.LoginQueue.Enabled {
+		if c.LoginQueue.Capacity < 0 || c.LoginQueue.MaxQueued <= 0 || c.LoginQueue.MaxQueuedPerCaller < 0 {
+			return fmt.Errorf("login queue needs a non-negative capacity and a positive max queued")
+		}
+		if c.LoginQueue.MaxWait <= 0 {
+			return fmt.Errorf("login queue max wait must be positive")
+		}
+	}
+	if c.Quota.Enabled {
+		if c.Quota.Period != "daily" && c.Quota.Period != "monthly" {
+			return fmt.Errorf("invalid quota period: %q", c.Quota.Period)
+		}
+		for _, threshold := range c.Quota.Thresholds {
+			if threshold <= 0 || threshold > 100 {
+				return fmt.Errorf("quota thresholds must be between 1 and 100")
+			}
+		}
+		if c.Quota.WarningPercent < 0 || c.Quota.WarningPercent > 100 {
+			return fmt.Errorf("quota warning_percent must be between 0 and 100")
+		}
+		for _, key := range c.Quota.APIKeys {
+			if key.ID == "" || len(key.KeyHash) != 64 {
+				return fmt.Errorf("quota API keys require an id and a SHA-256 hex key_hash")
+			}
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// SchemaField describes one configuration key, see Schema
+type SchemaField struct {
+	// Key is the dotted key, fields of list entries are under <list key>[]
+	Key  string `yaml:"key"`
+	Type string `yaml:"type"`
+	// Default is empty for keys without a default
+	Default string `yaml:"default,omitempty"`
+	// Env is the environment variable overriding the key, empty for fields of list entries
+	Env      string `yaml:"env,omitempty"`
+	Required bool   `yaml:"required"`
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Schema returns every configuration key in declaration order, derived from the mapstructure
+// and required tags of Config and the defaults of LoadConfig, so the keys of deployment
+// manifests can be checked against the code
+func Schema() []SchemaField {
+	v := viper.New()
+	setDefaults(v)
+
+	var fields []SchemaField
+	appendSchemaFields(&fields, v, reflect.TypeOf(Config{}), "", false)
+	return fields
+}
+
+func appendSchemaFields(fields *[]SchemaField, v *viper.Viper, t reflect.Type, prefix string, inList bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := prefix + name
+
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			appendSchemaFields(fields, v, field.Type, key+".", inList)
+			continue
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			// Lists of entries can only be set in the config file
+			*fields = append(*fields, SchemaField{
+				Key:      key,
+				Type:     "list",
+				Required: field.Tag.Get("required") == "true",
+			})
+			appendSchemaFields(fields, v, field.Type.Elem(), key+"[].", true)
+			continue
+		}
+
+		schemaField := SchemaField{
+			Key:      key,
+			Type:     schemaType(field.Type),
+			Env:      schemaEnv(key, inList),
+			Required: field.Tag.Get("required") == "true",
+		}
+		if !inList && v.IsSet(key) {
+			schemaField.Default = schemaDefault(v.Get(key))
+		}
+		*fields = append(*fields, schemaField)
+	}
+}
+
+// schemaEnv returns the environment variable of a key; viper only reads it for keys with a
+// default or in the config file
+func schemaEnv(key string, inList bool) string {
+	if inList {
+		return ""
+	}
+	return strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func schemaType(t reflect.Type) string {
+	if t == durationType {
+		return "duration"
+	}
+	return t.String()
+}
+
+// schemaDefault formats a default; lists and maps are JSON, which is also YAML flow syntax
+func schemaDefault(value interface{}) string {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Slice, reflect.Map:
+		encoded, err := json.Marshal(value)
+		if err == nil {
+			return string(encoded)
+		}
+	}
+	return fmt.Sprint(value)
+}
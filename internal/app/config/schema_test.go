@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema(t *testing.T) {
+	fields := make(map[string]SchemaField)
+	for _, field := range Schema() {
+		if _, ok := fields[field.Key]; ok {
+			t.Errorf("Expected %s once, got it twice", field.Key)
+		}
+		fields[field.Key] = field
+	}
+
+	tests := []SchemaField{
+		{Key: "server.port", Type: "string", Default: "50051", Env: "SERVER_PORT", Required: true},
+		{Key: "watch.resync_interval", Type: "duration", Default: "5m", Env: "WATCH_RESYNC_INTERVAL"},
+		{Key: "jwt.secret_key", Type: "string", Default: "your-secret-key-change-in-production", Env: "JWT_SECRET_KEY", Required: true},
+		{Key: "storage.bucket", Type: "string", Env: "STORAGE_BUCKET"},
+		{Key: "admin.api_keys", Type: "list"},
+		{Key: "admin.api_keys[].key_hash", Type: "string"},
+		{
+			Key: "notifier.routes", Type: "map[string][]string", Env: "NOTIFIER_ROUTES",
+			Default: `{"login":["email","push"],"security_digest":["email"]}`,
+		},
+	}
+	for _, expected := range tests {
+		if field := fields[expected.Key]; field != expected {
+			t.Errorf("Expected %+v, got %+v", expected, field)
+		}
+	}
+}
+
+// TestSchema_ConfigFile detects keys of config.yaml the code does not read
+func TestSchema_ConfigFile(t *testing.T) {
+	data, err := os.ReadFile("../../../config.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read config.yaml: %v", err)
+	}
+	var file map[string]interface{}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		t.Fatalf("Failed to parse config.yaml: %v", err)
+	}
+
+	types := make(map[string]string)
+	for _, field := range Schema() {
+		types[field.Key] = field.Type
+	}
+
+	for _, key := range configFileKeys(file, "", types) {
+		if _, ok := types[key]; !ok {
+			t.Errorf("Expected config.yaml key %s in the schema", key)
+		}
+	}
+}
+
+// configFileKeys returns the dotted keys of a parsed config file, not descending into keys
+// the schema knows as maps
+func configFileKeys(value interface{}, key string, types map[string]string) []string {
+	if key != "" && strings.HasPrefix(types[key], "map[") {
+		return []string{key}
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		var keys []string
+		for name, child := range value {
+			keys = append(keys, configFileKeys(child, strings.TrimPrefix(key+"."+name, "."), types)...)
+		}
+		return keys
+	case []interface{}:
+		keys := []string{key}
+		for _, entry := range value {
+			if _, ok := entry.(map[string]interface{}); ok {
+				keys = append(keys, configFileKeys(entry, key+"[]", types)[1:]...)
+			}
+		}
+		return keys
+	default:
+		return []string{key}
+	}
+}
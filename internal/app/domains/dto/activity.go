@@ -0,0 +1,10 @@
+package dto
+
+import "user-svc/internal/app/domains/models"
+
+type SendSecurityDigestParams struct {
+	UserID   string                  `json:"userID"`
+	Email    string                  `json:"email"`
+	Username string                  `json:"username"`
+	Activity *models.ActivitySummary `json:"activity"`
+}
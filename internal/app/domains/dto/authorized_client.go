@@ -0,0 +1,33 @@
+package dto
+
+import "user-svc/internal/app/domains/errs"
+
+// MaxClientIDLength is the length of the client_id column
+const MaxClientIDLength = 64
+
+// RevokeClientAccessReq revokes the access of a client to the caller
+type RevokeClientAccessReq struct {
+	ClientID string
+}
+
+// Validate validates the revoke client access request
+func (req RevokeClientAccessReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.ClientID == "" {
+		verrs.Add("client_id", errs.ErrClientIDIsRequired)
+	} else if len(req.ClientID) > MaxClientIDLength {
+		verrs.Add("client_id", errs.ErrInvalidClientID)
+	}
+
+	return verrs.Err()
+}
+
+// SendClientAccessRevokedParams is the outbox payload of revoked client access
+type SendClientAccessRevokedParams struct {
+	UserID               string `json:"userID"`
+	ClientID             string `json:"clientId"`
+	RevokedRefreshTokens int64  `json:"revokedRefreshTokens"`
+	// RevokedAt is a Unix timestamp in milliseconds
+	RevokedAt int64 `json:"revokedAt"`
+}
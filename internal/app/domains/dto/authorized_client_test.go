@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestRevokeClientAccessReq_Validate(t *testing.T) {
+	tests := []struct {
+		clientID string
+		expected error
+	}{
+		{clientID: "kiosk"},
+		{clientID: "", expected: errs.ErrClientIDIsRequired},
+		{clientID: strings.Repeat("c", MaxClientIDLength+1), expected: errs.ErrInvalidClientID},
+	}
+
+	for _, tt := range tests {
+		err := RevokeClientAccessReq{ClientID: tt.clientID}.Validate()
+		if tt.expected == nil && err != nil {
+			t.Errorf("Expected client %q to be valid, got %v", tt.clientID, err)
+		}
+		if tt.expected != nil && !errors.Is(err, tt.expected) {
+			t.Errorf("Expected %v, got %v", tt.expected, err)
+		}
+	}
+}
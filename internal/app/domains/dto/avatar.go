@@ -0,0 +1,19 @@
+package dto
+
+// AvatarUploadResp represents a pre-signed avatar upload
+type AvatarUploadResp struct {
+	// UploadURL accepts a PUT of the image until ExpiresAt
+	UploadURL string
+	// ContentType must be sent as the Content-Type header of the upload
+	ContentType string
+	// ObjectKey identifies the upload in ConfirmAvatar
+	ObjectKey string
+	ExpiresAt int64
+}
+
+// AvatarResp represents the avatar of a user
+type AvatarResp struct {
+	ObjectKey string
+	// AvatarURL serves the avatar, empty without a public base URL
+	AvatarURL string
+}
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"strings"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+// BatchAssignRoleReq represents a request to give many users the same role
+type BatchAssignRoleReq struct {
+	UserIDs []string
+	Role    string
+	// Reason is recorded in the audit trail of every changed user
+	Reason string
+	// DryRun reports the results without changing any user
+	DryRun bool
+}
+
+// Validate validates the batch role assignment; user IDs are validated one by one
+func (req BatchAssignRoleReq) Validate(maxUsers int) error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("user_ids", validateBatchUserIDs(req.UserIDs, maxUsers))
+	if !models.UserRole(req.Role).IsValid() {
+		verrs.Add("role", errs.ErrInvalidUserRole)
+	}
+	verrs.Add("reason", validateBatchReason(req.Reason))
+
+	return verrs.Err()
+}
+
+// BatchUpdateStatusReq represents a request to ban or unban many users
+type BatchUpdateStatusReq struct {
+	UserIDs []string
+	// Status is "active" or "banned"
+	Status string
+	// Reason is recorded in the audit trail of every changed user
+	Reason string
+	// DryRun reports the results without changing any user
+	DryRun bool
+}
+
+// Validate validates the batch status update; user IDs are validated one by one
+func (req BatchUpdateStatusReq) Validate(maxUsers int) error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("user_ids", validateBatchUserIDs(req.UserIDs, maxUsers))
+	if !models.UserStatus(req.Status).IsAssignable() {
+		verrs.Add("status", errs.ErrInvalidUserStatus)
+	}
+	verrs.Add("reason", validateBatchReason(req.Reason))
+
+	return verrs.Err()
+}
+
+// BatchItemStatus is the outcome of a batch operation for one user
+type BatchItemStatus string
+
+const (
+	BatchItemStatusUpdated BatchItemStatus = "updated"
+	// BatchItemStatusUnchanged users already had the requested role or status
+	BatchItemStatusUnchanged BatchItemStatus = "unchanged"
+	BatchItemStatusNotFound  BatchItemStatus = "not_found"
+	// BatchItemStatusInvalid items are malformed or repeat an earlier user ID
+	BatchItemStatusInvalid BatchItemStatus = "invalid"
+)
+
+// BatchUserResult reports the outcome of a batch operation for one user ID, in request order
+type BatchUserResult struct {
+	UserID string
+	Status BatchItemStatus
+	Err    error
+}
+
+func validateBatchUserIDs(userIDs []string, maxUsers int) error {
+	switch {
+	case len(userIDs) == 0:
+		return errs.ErrUserIDsAreRequired
+	case len(userIDs) > maxUsers:
+		return errs.ErrTooManyUserIDs
+	}
+	return nil
+}
+
+func validateBatchReason(reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return errs.ErrBatchReasonIsRequired
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestBatchAssignRoleReq_Validate(t *testing.T) {
+	req := BatchAssignRoleReq{UserIDs: []string{uuid.NewString(), "not-a-uuid"}, Role: "staff", Reason: "INC-1042"}
+	if err := req.Validate(2); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+
+	err := BatchAssignRoleReq{UserIDs: req.UserIDs, Role: "root"}.Validate(1)
+	if !errors.Is(err, errs.ErrTooManyUserIDs) || !errors.Is(err, errs.ErrInvalidUserRole) ||
+		!errors.Is(err, errs.ErrBatchReasonIsRequired) {
+		t.Errorf("Expected user ids, role and reason violations, got %v", err)
+	}
+}
+
+func TestBatchUpdateStatusReq_Validate(t *testing.T) {
+	for _, status := range []string{"active", "banned"} {
+		req := BatchUpdateStatusReq{UserIDs: []string{uuid.NewString()}, Status: status, Reason: "INC-1042"}
+		if err := req.Validate(10); err != nil {
+			t.Errorf("Expected status %q to be valid, got %v", status, err)
+		}
+	}
+
+	err := BatchUpdateStatusReq{Status: "invited", Reason: "INC-1042"}.Validate(10)
+	if !errors.Is(err, errs.ErrUserIDsAreRequired) || !errors.Is(err, errs.ErrInvalidUserStatus) {
+		t.Errorf("Expected user ids and status violations, got %v", err)
+	}
+}
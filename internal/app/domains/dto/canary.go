@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+// MaxCanaryNoteLength is the length of canary account notes
+const MaxCanaryNoteLength = 500
+
+// SetCanaryAccountReq flags a user as a canary account or changes its note and tarpit
+type SetCanaryAccountReq struct {
+	UserID string
+	Note   string
+	Tarpit bool
+}
+
+// Validate validates the request and returns the user ID
+func (req SetCanaryAccountReq) Validate() (uuid.UUID, error) {
+	var verrs errs.ValidationErrors
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+	if len(req.Note) > MaxCanaryNoteLength {
+		verrs.Add("note", errs.ErrCanaryNoteTooLong)
+	}
+
+	return userID, verrs.Err()
+}
+
+// DeleteCanaryAccountReq removes the canary flag of a user
+type DeleteCanaryAccountReq struct {
+	UserID string
+}
+
+// Validate validates the request and returns the user ID
+func (req DeleteCanaryAccountReq) Validate() (uuid.UUID, error) {
+	var verrs errs.ValidationErrors
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+
+	return userID, verrs.Err()
+}
+
+// SendCanaryAccessParams is the outbox payload of accesses to canary accounts
+type SendCanaryAccessParams struct {
+	UserID        string `json:"userID"`
+	Kind          string `json:"kind"`
+	ClientID      string `json:"clientId,omitempty"`
+	PasswordValid bool   `json:"passwordValid"`
+	IPAddress     string `json:"ipAddress,omitempty"`
+	UserAgent     string `json:"userAgent,omitempty"`
+	Note          string `json:"note"`
+	// AccessedAt is a Unix timestamp in milliseconds
+	AccessedAt int64 `json:"accessedAt"`
+}
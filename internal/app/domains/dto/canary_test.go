@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestSetCanaryAccountReq_Validate(t *testing.T) {
+	userID := uuid.New()
+	valid := SetCanaryAccountReq{UserID: userID.String(), Note: "credentials planted in the staging wiki", Tarpit: true}
+
+	got, err := valid.Validate()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got != userID {
+		t.Errorf("Expected user %s, got %s", userID, got)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *SetCanaryAccountReq)
+		want   error
+	}{
+		{name: "invalid user ID", modify: func(req *SetCanaryAccountReq) { req.UserID = "canary" }, want: errs.ErrInvalidUserID},
+		{name: "note too long", modify: func(req *SetCanaryAccountReq) { req.Note = strings.Repeat("n", MaxCanaryNoteLength+1) }, want: errs.ErrCanaryNoteTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			if _, err := req.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestDeleteCanaryAccountReq_Validate(t *testing.T) {
+	if _, err := (DeleteCanaryAccountReq{UserID: uuid.NewString()}).Validate(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if _, err := (DeleteCanaryAccountReq{UserID: ""}).Validate(); !errors.Is(err, errs.ErrInvalidUserID) {
+		t.Errorf("Expected ErrInvalidUserID, got %v", err)
+	}
+}
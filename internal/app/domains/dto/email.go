@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"strings"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+// PreviewEmailTemplateReq represents a request to render an email template without sending it
+type PreviewEmailTemplateReq struct {
+	Name   string
+	Locale string
+	// Data is a JSON object; the template's sample data is used when empty
+	Data string
+}
+
+// Validate validates the preview email template request
+func (req PreviewEmailTemplateReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if strings.TrimSpace(req.Name) == "" {
+		verrs.Add("name", errs.ErrTemplateNameIsRequired)
+	}
+
+	return verrs.Err()
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+// ResendVerificationEmailResp tells when the emailed link expires
+type ResendVerificationEmailResp struct {
+	// ExpiresAt is a Unix timestamp in milliseconds
+	ExpiresAt int64
+}
+
+// VerifyEmailReq verifies the email of an account with the token of an emailed link
+type VerifyEmailReq struct {
+	Token string
+}
+
+// Validate validates the email verification
+func (req VerifyEmailReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.Token == "" {
+		verrs.Add("token", errs.ErrInvalidEmailVerification)
+	}
+
+	return verrs.Err()
+}
+
+type SendEmailVerificationParams struct {
+	UserID   string `json:"userID"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	// VerificationURL carries the email verification token
+	VerificationURL string    `json:"verificationUrl"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+	// Locale is the language the link was requested in, used to localize the email
+	Locale string `json:"locale,omitempty"`
+	// Branding is the branding of the user's organization, null outside organizations
+	Branding *models.OrganizationBranding `json:"branding"`
+}
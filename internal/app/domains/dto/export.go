@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// ExportUsersReq represents a request to stream the users matching a filter
+type ExportUsersReq struct {
+	// CreatedFrom and CreatedTo bound created_at in Unix milliseconds, 0 is unbounded
+	CreatedFrom int64
+	CreatedTo   int64
+	// Format is "proto" or "ndjson", "proto" when empty
+	Format string
+	// Fields selects the exported fields, all of them when empty
+	Fields []string
+	// RowsPerSecond throttles the stream, the configured maximum when zero
+	RowsPerSecond int
+	// OrganizationID limits the export to the staff of an organization, optional
+	OrganizationID string
+}
+
+// Validate validates the export users request
+func (req ExportUsersReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.CreatedFrom < 0 || req.CreatedTo < 0 || (req.CreatedTo != 0 && req.CreatedTo <= req.CreatedFrom) {
+		verrs.Add("created_to", errs.ErrInvalidExportRange)
+	}
+
+	switch models.UserExportFormat(req.Format) {
+	case "", models.UserExportFormatProto, models.UserExportFormatNDJSON:
+	default:
+		verrs.Add("format", errs.ErrInvalidExportFormat)
+	}
+
+	for i, field := range req.Fields {
+		if !models.UserExportField(field).IsValid() {
+			verrs.Add(fmt.Sprintf("fields[%d]", i), errs.ErrInvalidExportField)
+		}
+	}
+
+	if req.RowsPerSecond < 0 {
+		verrs.Add("rows_per_second", errs.ErrInvalidExportRowsPerSecond)
+	}
+
+	if req.OrganizationID != "" {
+		verrs.Add("organization_id", validateOrganizationID(req.OrganizationID))
+	}
+
+	return verrs.Err()
+}
+
+// ExportFormat returns the requested format, defaulting to proto
+func (req ExportUsersReq) ExportFormat() models.UserExportFormat {
+	if req.Format == "" {
+		return models.UserExportFormatProto
+	}
+	return models.UserExportFormat(req.Format)
+}
+
+// ExportFields returns the selected fields in export order, every field when none is selected
+func (req ExportUsersReq) ExportFields() []models.UserExportField {
+	if len(req.Fields) == 0 {
+		return models.UserExportFields
+	}
+
+	fields := make([]models.UserExportField, 0, len(req.Fields))
+	for _, field := range models.UserExportFields {
+		for _, selected := range req.Fields {
+			if string(field) == selected {
+				fields = append(fields, field)
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// Filter returns the users the request selects
+func (req ExportUsersReq) Filter() models.UserExportFilter {
+	filter := models.UserExportFilter{
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+	if req.OrganizationID != "" {
+		filter.OrganizationID, _ = uuid.Parse(req.OrganizationID)
+	}
+	return filter
+}
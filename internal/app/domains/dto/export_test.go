@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+func TestExportUsersReq_Validate(t *testing.T) {
+	if err := (ExportUsersReq{}).Validate(); err != nil {
+		t.Errorf("Expected an empty request to be valid, got %v", err)
+	}
+
+	err := ExportUsersReq{
+		CreatedFrom:   2000,
+		CreatedTo:     1000,
+		Format:        "csv",
+		Fields:        []string{"email", "password_hash"},
+		RowsPerSecond: -1,
+	}.Validate()
+
+	for _, expected := range []error{
+		errs.ErrInvalidExportRange,
+		errs.ErrInvalidExportFormat,
+		errs.ErrInvalidExportField,
+		errs.ErrInvalidExportRowsPerSecond,
+	} {
+		if !errors.Is(err, expected) {
+			t.Errorf("Expected %v, got %v", expected, err)
+		}
+	}
+}
+
+func TestExportUsersReq_ExportFields(t *testing.T) {
+	if fields := (ExportUsersReq{}).ExportFields(); !slices.Equal(fields, models.UserExportFields) {
+		t.Errorf("Expected every field when none is selected, got %v", fields)
+	}
+
+	fields := ExportUsersReq{Fields: []string{"created_at", "id", "id"}}.ExportFields()
+	expected := []models.UserExportField{models.UserExportFieldID, models.UserExportFieldCreatedAt}
+	if !slices.Equal(fields, expected) {
+		t.Errorf("Expected %v, got %v", expected, fields)
+	}
+}
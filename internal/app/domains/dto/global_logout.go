@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+// GlobalLogoutConfirmation must be repeated in GlobalLogoutReq.Confirmation, so a global
+// logout cannot be triggered by a mistyped or replayed call of another admin RPC
+const GlobalLogoutConfirmation = "log out every user"
+
+// GlobalLogoutReq represents a request to revoke the tokens of every user issued up to a cutoff
+type GlobalLogoutReq struct {
+	// Cutoff is in Unix milliseconds, 0 for the time of the request
+	Cutoff int64
+	// Reason is recorded with the logout, e.g. the incident reference
+	Reason       string
+	Confirmation string
+}
+
+// Validate validates the request and returns the cutoff
+func (req GlobalLogoutReq) Validate(now time.Time) (time.Time, error) {
+	var verrs errs.ValidationErrors
+
+	if req.Confirmation != GlobalLogoutConfirmation {
+		verrs.Add("confirmation", errs.ErrGlobalLogoutNotConfirmed)
+	}
+	verrs.Add("reason", validateBatchReason(req.Reason))
+	if req.Cutoff < 0 || req.Cutoff > now.UnixMilli() {
+		verrs.Add("cutoff", errs.ErrInvalidGlobalLogoutCutoff)
+	}
+	if err := verrs.Err(); err != nil {
+		return time.Time{}, err
+	}
+
+	if req.Cutoff == 0 {
+		return now, nil
+	}
+	return time.UnixMilli(req.Cutoff), nil
+}
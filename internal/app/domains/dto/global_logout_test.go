@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestGlobalLogoutReq_Validate(t *testing.T) {
+	now := time.UnixMilli(1760616000000)
+	valid := GlobalLogoutReq{Reason: "INC-4711 signing key leaked", Confirmation: GlobalLogoutConfirmation}
+
+	cutoff, err := valid.Validate(now)
+	if err != nil || !cutoff.Equal(now) {
+		t.Errorf("Expected the cutoff to default to now, got %v, %v", cutoff, err)
+	}
+	earlier := valid
+	earlier.Cutoff = now.Add(-time.Hour).UnixMilli()
+	if cutoff, err := earlier.Validate(now); err != nil || cutoff.UnixMilli() != earlier.Cutoff {
+		t.Errorf("Expected cutoff %d, got %v, %v", earlier.Cutoff, cutoff, err)
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(req *GlobalLogoutReq)
+		expected error
+	}{
+		{name: "no confirmation", modify: func(req *GlobalLogoutReq) { req.Confirmation = "" }, expected: errs.ErrGlobalLogoutNotConfirmed},
+		{name: "wrong confirmation", modify: func(req *GlobalLogoutReq) { req.Confirmation = "LOG OUT EVERY USER" }, expected: errs.ErrGlobalLogoutNotConfirmed},
+		{name: "no reason", modify: func(req *GlobalLogoutReq) { req.Reason = "  " }, expected: errs.ErrBatchReasonIsRequired},
+		{name: "future cutoff", modify: func(req *GlobalLogoutReq) { req.Cutoff = now.Add(time.Second).UnixMilli() }, expected: errs.ErrInvalidGlobalLogoutCutoff},
+		{name: "negative cutoff", modify: func(req *GlobalLogoutReq) { req.Cutoff = -1 }, expected: errs.ErrInvalidGlobalLogoutCutoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			if _, err := req.Validate(now); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
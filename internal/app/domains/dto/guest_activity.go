@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// ClaimGuestActivityReq represents a request of a service to attach the guest activity under
+// an email to the user that verified owning it
+type ClaimGuestActivityReq struct {
+	UserID string
+	Email  string
+}
+
+// Validate validates the guest activity claim request
+func (req ClaimGuestActivityReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+	verrs.Add("email", models.Email(req.Email).Validate())
+
+	return verrs.Err()
+}
+
+// ClaimGuestActivityResp represents a claimed email
+type ClaimGuestActivityResp struct {
+	Claim *models.EmailClaim
+	// AlreadyClaimed is set when an earlier call claimed the activity, so a caller retrying
+	// the claim does not attach the activity twice
+	AlreadyClaimed bool
+}
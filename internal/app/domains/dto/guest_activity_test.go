@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestClaimGuestActivityReq_Validate(t *testing.T) {
+	if err := (ClaimGuestActivityReq{UserID: uuid.NewString(), Email: "guest@example.com"}).Validate(); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+
+	err := ClaimGuestActivityReq{UserID: "guest", Email: "guest"}.Validate()
+	if !errors.Is(err, errs.ErrInvalidUserID) || !errors.Is(err, errs.ErrInvalidEmail) {
+		t.Errorf("Expected user id and email violations, got %v", err)
+	}
+}
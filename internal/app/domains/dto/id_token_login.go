@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+)
+
+// LoginWithIDTokenReq logs a user in with the ID token of an OpenID Connect provider
+type LoginWithIDTokenReq struct {
+	// Provider is the name of a configured provider, e.g. "apple"
+	Provider string
+	IDToken  string
+	// Nonce is the nonce the client sent the provider, which the ID token must carry; empty
+	// skips the check
+	Nonce    string
+	ClientID string
+	// RememberMe selects a long-lived session instead of a short one
+	RememberMe bool
+}
+
+// Validate validates the login request, reporting every invalid field at once
+func (req LoginWithIDTokenReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.Provider == "" {
+		verrs.Add("provider", errs.ErrIdentityProviderIsRequired)
+	}
+	if req.IDToken == "" {
+		verrs.Add("id_token", errs.ErrIDTokenIsRequired)
+	}
+	verrs.Add("client_id", validateClientID(req.ClientID))
+
+	return verrs.Err()
+}
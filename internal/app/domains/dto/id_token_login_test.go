@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestLoginWithIDTokenReq_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      LoginWithIDTokenReq
+		expected error
+	}{
+		{name: "valid", req: LoginWithIDTokenReq{Provider: "apple", IDToken: "id-token", ClientID: "web"}},
+		{name: "missing provider", req: LoginWithIDTokenReq{IDToken: "id-token", ClientID: "web"}, expected: errs.ErrIdentityProviderIsRequired},
+		{name: "missing ID token", req: LoginWithIDTokenReq{Provider: "apple", ClientID: "web"}, expected: errs.ErrIDTokenIsRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
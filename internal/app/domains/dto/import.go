@@ -0,0 +1,115 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+// ImportUserRecord is one user of an import batch
+type ImportUserRecord struct {
+	Email    string
+	Username string
+	// OrganizationID makes the user a staff member of the organization, optional
+	OrganizationID string
+	// LegacyPassword imports an active user who logs in with their password of the legacy
+	// ticketing system instead of an invited user, optional
+	LegacyPassword *LegacyPasswordHash
+}
+
+// LegacyPasswordHash is a salted SHA-1 password hash of the legacy ticketing system
+type LegacyPasswordHash struct {
+	// Format is password.LegacySHA1SaltPassword or password.LegacySHA1PasswordSalt
+	Format string
+	Salt   string
+	// Hash is the hex SHA-1 digest
+	Hash string
+}
+
+// PasswordHash returns the hash tagged with its format
+func (h LegacyPasswordHash) PasswordHash() (models.PasswordHash, error) {
+	return models.NewLegacyPasswordHash(h.Format, h.Salt, h.Hash)
+}
+
+// Validate validates the import record, reporting every invalid field at once
+func (rec ImportUserRecord) Validate() error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("email", validateEmail(rec.Email))
+
+	_, err := models.NewUsername(rec.Username)
+	verrs.Add("username", err)
+
+	if rec.OrganizationID != "" {
+		verrs.Add("organization_id", validateOrganizationID(rec.OrganizationID))
+	}
+
+	if rec.LegacyPassword != nil {
+		_, err := rec.LegacyPassword.PasswordHash()
+		verrs.Add("legacy_password", err)
+	}
+
+	return verrs.Err()
+}
+
+// ImportUsersReq represents one batch of an import stream
+type ImportUsersReq struct {
+	Users []ImportUserRecord
+}
+
+// Validate checks the size of the batch; records are validated one by one
+func (req ImportUsersReq) Validate(maxBatchSize int) error {
+	var verrs errs.ValidationErrors
+
+	switch {
+	case len(req.Users) == 0:
+		verrs.Add("users", errs.ErrImportBatchIsEmpty)
+	case len(req.Users) > maxBatchSize:
+		verrs.Add("users", errs.ErrImportBatchTooLarge)
+	}
+
+	return verrs.Err()
+}
+
+// ImportUserStatus is the outcome of importing one record
+type ImportUserStatus string
+
+const (
+	ImportUserStatusCreated ImportUserStatus = "created"
+	// ImportUserStatusDuplicate records share an email with an existing user or an earlier record
+	ImportUserStatusDuplicate ImportUserStatus = "duplicate"
+	ImportUserStatusInvalid   ImportUserStatus = "invalid"
+	ImportUserStatusFailed    ImportUserStatus = "failed"
+)
+
+// ImportUserResult reports the outcome of one record
+type ImportUserResult struct {
+	// Row is the zero-based position of the record across the whole stream
+	Row    int
+	Email  string
+	User   *models.User
+	Status ImportUserStatus
+	Err    error
+}
+
+// CompletePasswordSetupReq represents an invited user setting their first password
+type CompletePasswordSetupReq struct {
+	Token    string
+	Password string
+	ClientID string
+}
+
+// Validate validates the complete password setup request
+func (req CompletePasswordSetupReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.Token == "" {
+		verrs.Add("token", errs.ErrInvalidPasswordSetup)
+	}
+
+	_, err := models.NewPassword(req.Password)
+	verrs.Add("password", err)
+
+	verrs.Add("client_id", validateClientID(req.ClientID))
+
+	return verrs.Err()
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestImportUserRecord_Validate(t *testing.T) {
+	if err := (ImportUserRecord{Email: "jane@tickets.example", Username: "jane"}).Validate(); err != nil {
+		t.Errorf("Expected a valid record, got %v", err)
+	}
+
+	err := ImportUserRecord{Email: "", Username: "jane", OrganizationID: "not-a-uuid"}.Validate()
+	if !errors.Is(err, errs.ErrEmailIsRequired) || !errors.Is(err, errs.ErrInvalidOrganizationID) {
+		t.Errorf("Expected email and organization id violations, got %v", err)
+	}
+}
+
+func TestImportUserRecord_ValidateLegacyPassword(t *testing.T) {
+	rec := ImportUserRecord{
+		Email:    "jane@tickets.example",
+		Username: "jane",
+		LegacyPassword: &LegacyPasswordHash{
+			Format: "sha1-salt-password",
+			Salt:   "x9",
+			Hash:   "a9993e364706816aba3e25717850c26c9cd0d89d",
+		},
+	}
+	if err := rec.Validate(); err != nil {
+		t.Errorf("Expected a valid record, got %v", err)
+	}
+
+	rec.LegacyPassword.Format = "md5"
+	if err := rec.Validate(); !errors.Is(err, errs.ErrInvalidLegacyPasswordHash) {
+		t.Errorf("Expected ErrInvalidLegacyPasswordHash, got %v", err)
+	}
+}
+
+func TestImportUsersReq_Validate(t *testing.T) {
+	batch := ImportUsersReq{Users: make([]ImportUserRecord, 3)}
+	if err := batch.Validate(3); err != nil {
+		t.Errorf("Expected a valid batch, got %v", err)
+	}
+	if err := batch.Validate(2); !errors.Is(err, errs.ErrImportBatchTooLarge) {
+		t.Errorf("Expected ErrImportBatchTooLarge, got %v", err)
+	}
+	if err := (ImportUsersReq{}).Validate(2); !errors.Is(err, errs.ErrImportBatchIsEmpty) {
+		t.Errorf("Expected ErrImportBatchIsEmpty, got %v", err)
+	}
+}
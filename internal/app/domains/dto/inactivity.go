@@ -0,0 +1,13 @@
+package dto
+
+// SendUserInactiveParams is the outbox payload of an account flagged inactive
+type SendUserInactiveParams struct {
+	UserID   string `json:"userID"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	// LastSeenAt is a Unix timestamp in milliseconds
+	LastSeenAt int64 `json:"lastSeenAt"`
+	// InactiveSince is a Unix timestamp in milliseconds
+	InactiveSince int64  `json:"inactiveSince"`
+	Residency     string `json:"residency,omitempty"`
+}
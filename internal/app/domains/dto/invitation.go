@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"time"
+
+	"user-svc/internal/app/domains/models"
+)
+
+type SendUserInvitationParams struct {
+	UserID   string `json:"userID"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	// SetupURL carries the password setup token
+	SetupURL  string    `json:"setupUrl"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Reminder is set when the user has not used the first invitation yet
+	Reminder bool `json:"reminder"`
+	// Branding is the branding of the user's organization, null outside organizations
+	Branding *models.OrganizationBranding `json:"branding"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+)
+
+// CreateInviteCodeReq creates an invite code of the caller
+type CreateInviteCodeReq struct {
+	// MaxRedemptions is how many users may register with the code, 0 for the default
+	MaxRedemptions int
+}
+
+// Redemptions validates the request against the most redemptions a code may have, and
+// returns the redemptions of the code
+func (req CreateInviteCodeReq) Redemptions(limit int) (int, error) {
+	var verrs errs.ValidationErrors
+
+	if req.MaxRedemptions < 0 || req.MaxRedemptions > limit {
+		verrs.Add("max_redemptions", errs.ErrInvalidMaxRedemptions)
+	}
+	if err := verrs.Err(); err != nil {
+		return 0, err
+	}
+
+	if req.MaxRedemptions == 0 {
+		return limit, nil
+	}
+	return req.MaxRedemptions, nil
+}
+
+// SendReferralRedeemedParams is the outbox payload of a registration with a user's invite code
+type SendReferralRedeemedParams struct {
+	ReferrerID string `json:"referrerID"`
+	RefereeID  string `json:"refereeID"`
+	InviteCode string `json:"inviteCode"`
+	// RedeemedAt is a Unix timestamp in milliseconds
+	RedeemedAt int64 `json:"redeemedAt"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestCreateInviteCodeReq_Redemptions(t *testing.T) {
+	tests := []struct {
+		maxRedemptions int
+		expected       int
+		err            error
+	}{
+		{maxRedemptions: 0, expected: 10},
+		{maxRedemptions: 3, expected: 3},
+		{maxRedemptions: 10, expected: 10},
+		{maxRedemptions: 11, err: errs.ErrInvalidMaxRedemptions},
+		{maxRedemptions: -1, err: errs.ErrInvalidMaxRedemptions},
+	}
+
+	for _, tt := range tests {
+		redemptions, err := CreateInviteCodeReq{MaxRedemptions: tt.maxRedemptions}.Redemptions(10)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Expected error %v for %d, got %v", tt.err, tt.maxRedemptions, err)
+		}
+		if redemptions != tt.expected {
+			t.Errorf("Expected %d redemptions for %d, got %d", tt.expected, tt.maxRedemptions, redemptions)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"strings"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+// LegalHoldReq represents a request to place a user under legal hold or release it
+type LegalHoldReq struct {
+	UserID string
+	// Reason is recorded in the audit trail, typically the case reference
+	Reason string
+}
+
+// Validate validates the legal hold request
+func (req LegalHoldReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		verrs.Add("reason", errs.ErrLegalHoldReasonIsRequired)
+	}
+
+	return verrs.Err()
+}
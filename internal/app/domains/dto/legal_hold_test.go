@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestLegalHoldReq_Validate(t *testing.T) {
+	if err := (LegalHoldReq{UserID: uuid.NewString(), Reason: "case 4711"}).Validate(); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+
+	err := LegalHoldReq{UserID: "not-a-uuid", Reason: "  "}.Validate()
+	if !errors.Is(err, errs.ErrInvalidUserID) || !errors.Is(err, errs.ErrLegalHoldReasonIsRequired) {
+		t.Errorf("Expected user id and reason violations, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLoginHistoryPageSize is the page size of requests that do not ask for one
+const DefaultLoginHistoryPageSize = 50
+
+// GetLoginHistoryReq represents a request for a page of the login attempts of a user, newest
+// first
+type GetLoginHistoryReq struct {
+	// UserID is the user whose attempts are listed; required for admins, users may leave it
+	// empty for their own
+	UserID string
+	// PageSize is the most attempts returned, DefaultLoginHistoryPageSize when zero
+	PageSize int
+	// PageToken continues after the last attempt of the previous page, empty for the first page
+	PageToken string
+}
+
+// Validate validates the get login history request
+func (req GetLoginHistoryReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.UserID != "" {
+		if _, err := uuid.Parse(req.UserID); err != nil {
+			verrs.Add("user_id", errs.ErrInvalidUserID)
+		}
+	}
+	if req.PageSize < 0 || req.PageSize > MaxListUsersPageSize {
+		verrs.Add("page_size", errs.ErrInvalidPageSize)
+	}
+	if _, err := req.Cursor(); err != nil {
+		verrs.Add("page_token", err)
+	}
+
+	return verrs.Err()
+}
+
+// Limit returns the requested page size, defaulting to DefaultLoginHistoryPageSize
+func (req GetLoginHistoryReq) Limit() int {
+	if req.PageSize == 0 {
+		return DefaultLoginHistoryPageSize
+	}
+	return req.PageSize
+}
+
+// Cursor returns the cursor after the last attempt of the previous page, the zero cursor for
+// the first page
+func (req GetLoginHistoryReq) Cursor() (models.LoginAttemptCursor, error) {
+	if req.PageToken == "" {
+		return models.LoginAttemptCursor{}, nil
+	}
+
+	createdAt, id, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return models.LoginAttemptCursor{}, err
+	}
+
+	return models.LoginAttemptCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// GetLoginHistoryResp represents a page of the login attempts of a user
+type GetLoginHistoryResp struct {
+	Attempts []*models.LoginAttempt
+	// NextPageToken requests the next page, empty on the last page
+	NextPageToken string
+}
+
+// LoginAttemptPageToken returns the page token that continues after the attempt
+func LoginAttemptPageToken(attempt *models.LoginAttempt) string {
+	return encodePageToken(attempt.CreatedAt, attempt.ID)
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+func TestGetLoginHistoryReq(t *testing.T) {
+	attempt := &models.LoginAttempt{ID: uuid.New(), UserID: uuid.New(), CreatedAt: 1700000360000}
+	req := GetLoginHistoryReq{PageToken: LoginAttemptPageToken(attempt)}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Expected a valid request without a user ID, got %v", err)
+	}
+	if req.Limit() != DefaultLoginHistoryPageSize {
+		t.Errorf("Expected default page size %d, got %d", DefaultLoginHistoryPageSize, req.Limit())
+	}
+	if cursor, _ := req.Cursor(); cursor.CreatedAt != attempt.CreatedAt || cursor.ID != attempt.ID {
+		t.Errorf("Expected the cursor after %d/%s, got %+v", attempt.CreatedAt, attempt.ID, cursor)
+	}
+
+	err := GetLoginHistoryReq{UserID: "not-a-uuid", PageSize: MaxListUsersPageSize + 1, PageToken: "not a token"}.Validate()
+	for _, want := range []error{errs.ErrInvalidUserID, errs.ErrInvalidPageSize, errs.ErrInvalidPageToken} {
+		if !errors.Is(err, want) {
+			t.Errorf("Expected error %v, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// MaxLoginWindows is the number of windows a login schedule can have
+const MaxLoginWindows = 28
+
+// LoginScheduleSubjectReq names the user or the role a login schedule applies to
+type LoginScheduleSubjectReq struct {
+	UserID string
+	Role   string
+}
+
+// Validate validates that exactly one of the user ID and the role is set, and returns them
+func (req LoginScheduleSubjectReq) Validate() (uuid.UUID, models.UserRole, error) {
+	var verrs errs.ValidationErrors
+	userID, role := req.validate(&verrs)
+	return userID, role, verrs.Err()
+}
+
+func (req LoginScheduleSubjectReq) validate(verrs *errs.ValidationErrors) (uuid.UUID, models.UserRole) {
+	switch {
+	case (req.UserID == "") == (req.Role == ""):
+		verrs.Add("user_id", errs.ErrInvalidLoginScheduleSubject)
+	case req.UserID != "":
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			verrs.Add("user_id", errs.ErrInvalidUserID)
+		}
+		return userID, ""
+	case !models.UserRole(req.Role).IsValid():
+		verrs.Add("role", errs.ErrInvalidUserRole)
+	}
+	return uuid.Nil, models.UserRole(req.Role)
+}
+
+// LoginWindowReq is a login window with three-letter day names and HH:MM times
+type LoginWindowReq struct {
+	Days  []string
+	Start string
+	End   string
+}
+
+// SetLoginScheduleReq sets the login schedule of a user or a role
+type SetLoginScheduleReq struct {
+	LoginScheduleSubjectReq
+	Timezone string
+	Windows  []LoginWindowReq
+}
+
+// Schedule validates the request, reporting every invalid field at once, and returns the schedule
+func (req SetLoginScheduleReq) Schedule(now time.Time) (*models.LoginSchedule, error) {
+	var verrs errs.ValidationErrors
+
+	userID, role := req.LoginScheduleSubjectReq.validate(&verrs)
+	if _, err := time.LoadLocation(req.Timezone); err != nil || req.Timezone == "" || req.Timezone == "Local" {
+		verrs.Add("timezone", errs.ErrInvalidTimezone)
+	}
+	if len(req.Windows) == 0 || len(req.Windows) > MaxLoginWindows {
+		verrs.Add("windows", errs.ErrInvalidLoginWindowCount)
+	}
+
+	windows := make([]models.LoginWindow, 0, len(req.Windows))
+	for i, windowReq := range req.Windows {
+		window, ok := windowReq.window()
+		if !ok {
+			verrs.Add(fmt.Sprintf("windows[%d]", i), errs.ErrInvalidLoginWindow)
+			continue
+		}
+		windows = append(windows, window)
+	}
+	if err := verrs.Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.LoginSchedule{
+		UserID:    userID,
+		Role:      role,
+		Timezone:  req.Timezone,
+		Windows:   windows,
+		UpdatedAt: now.UnixMilli(),
+	}, nil
+}
+
+// window converts the window, or reports false if its days or times are invalid
+func (req LoginWindowReq) window() (models.LoginWindow, bool) {
+	start, startOK := models.ParseClock(req.Start)
+	end, endOK := models.ParseClock(req.End)
+	if !startOK || !endOK || start == end || start == models.MinutesPerDay || len(req.Days) == 0 {
+		return models.LoginWindow{}, false
+	}
+
+	window := models.LoginWindow{Start: start, End: end}
+	for _, name := range req.Days {
+		day, ok := models.ParseWeekday(name)
+		if !ok {
+			return models.LoginWindow{}, false
+		}
+		window.Days = append(window.Days, day)
+	}
+	return window, true
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+func TestLoginScheduleSubjectReq_Validate(t *testing.T) {
+	id := uuid.New()
+	userID, role, err := LoginScheduleSubjectReq{UserID: id.String()}.Validate()
+	if err != nil || userID != id || role != "" {
+		t.Errorf("Expected user %s, got %s, %q, %v", id, userID, role, err)
+	}
+	userID, role, err = LoginScheduleSubjectReq{Role: "staff"}.Validate()
+	if err != nil || userID != uuid.Nil || role != models.UserRoleStaff {
+		t.Errorf("Expected the staff role, got %s, %q, %v", userID, role, err)
+	}
+
+	tests := []struct {
+		name     string
+		req      LoginScheduleSubjectReq
+		expected error
+	}{
+		{name: "neither", req: LoginScheduleSubjectReq{}, expected: errs.ErrInvalidLoginScheduleSubject},
+		{name: "both", req: LoginScheduleSubjectReq{UserID: id.String(), Role: "staff"}, expected: errs.ErrInvalidLoginScheduleSubject},
+		{name: "invalid user id", req: LoginScheduleSubjectReq{UserID: "not-a-uuid"}, expected: errs.ErrInvalidUserID},
+		{name: "unknown role", req: LoginScheduleSubjectReq{Role: "usher"}, expected: errs.ErrInvalidUserRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.req.Validate(); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestSetLoginScheduleReq_Schedule(t *testing.T) {
+	now := time.Now()
+	valid := SetLoginScheduleReq{
+		LoginScheduleSubjectReq: LoginScheduleSubjectReq{Role: "staff"},
+		Timezone:                "America/New_York",
+		Windows: []LoginWindowReq{
+			{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "08:00", End: "18:00"},
+			{Days: []string{"sat"}, Start: "20:00", End: "01:00"},
+		},
+	}
+	schedule, err := valid.Schedule(now)
+	if err != nil {
+		t.Fatalf("Expected a valid schedule, got %v", err)
+	}
+	if schedule.Role != models.UserRoleStaff || len(schedule.Windows) != 2 || schedule.UpdatedAt != now.UnixMilli() {
+		t.Errorf("Expected the staff schedule, got %+v", schedule)
+	}
+	if window := schedule.Windows[1]; window.Days[0] != time.Saturday || window.Start != 20*60 || window.End != 60 {
+		t.Errorf("Expected saturday 20:00 to 01:00, got %+v", window)
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(req *SetLoginScheduleReq)
+		expected error
+	}{
+		{name: "no timezone", modify: func(req *SetLoginScheduleReq) { req.Timezone = "" }, expected: errs.ErrInvalidTimezone},
+		{name: "local timezone", modify: func(req *SetLoginScheduleReq) { req.Timezone = "Local" }, expected: errs.ErrInvalidTimezone},
+		{name: "unknown timezone", modify: func(req *SetLoginScheduleReq) { req.Timezone = "Europe/Atlantis" }, expected: errs.ErrInvalidTimezone},
+		{name: "no windows", modify: func(req *SetLoginScheduleReq) { req.Windows = nil }, expected: errs.ErrInvalidLoginWindowCount},
+		{name: "too many windows", modify: func(req *SetLoginScheduleReq) {
+			req.Windows = make([]LoginWindowReq, MaxLoginWindows+1)
+			for i := range req.Windows {
+				req.Windows[i] = valid.Windows[0]
+			}
+		}, expected: errs.ErrInvalidLoginWindowCount},
+		{name: "unknown day", modify: func(req *SetLoginScheduleReq) {
+			req.Windows = []LoginWindowReq{{Days: []string{"monday"}, Start: "08:00", End: "18:00"}}
+		}, expected: errs.ErrInvalidLoginWindow},
+		{name: "no days", modify: func(req *SetLoginScheduleReq) {
+			req.Windows = []LoginWindowReq{{Start: "08:00", End: "18:00"}}
+		}, expected: errs.ErrInvalidLoginWindow},
+		{name: "empty window", modify: func(req *SetLoginScheduleReq) {
+			req.Windows = []LoginWindowReq{{Days: []string{"mon"}, Start: "08:00", End: "08:00"}}
+		}, expected: errs.ErrInvalidLoginWindow},
+		{name: "starts at end of day", modify: func(req *SetLoginScheduleReq) {
+			req.Windows = []LoginWindowReq{{Days: []string{"mon"}, Start: "24:00", End: "08:00"}}
+		}, expected: errs.ErrInvalidLoginWindow},
+		{name: "invalid time", modify: func(req *SetLoginScheduleReq) {
+			req.Windows = []LoginWindowReq{{Days: []string{"mon"}, Start: "8am", End: "18:00"}}
+		}, expected: errs.ErrInvalidLoginWindow},
+		{name: "no subject", modify: func(req *SetLoginScheduleReq) { req.Role = "" }, expected: errs.ErrInvalidLoginScheduleSubject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			if _, err := req.Schedule(now); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
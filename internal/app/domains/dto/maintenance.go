@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultCleanupBatchSize is the number of refresh tokens deleted per batch when no batch
+	// size is given
+	DefaultCleanupBatchSize = 1000
+	// MaxCleanupBatchSize is the most refresh tokens deleted per batch
+	MaxCleanupBatchSize = 10000
+)
+
+// CleanupRefreshTokensReq represents a request to delete expired and revoked refresh tokens
+type CleanupRefreshTokensReq struct {
+	// UserID limits the cleanup to the tokens of one user, empty cleans up every user
+	UserID string
+	// OlderThanSeconds keeps the tokens that expired or were revoked more recently
+	OlderThanSeconds int64
+	// RevokedOnly keeps the tokens that expired but were never revoked
+	RevokedOnly bool
+	// BatchSize is the number of tokens deleted per statement, 0 uses DefaultCleanupBatchSize
+	BatchSize int
+	// MaxTokens stops the cleanup after deleting this many tokens, 0 deletes all of them
+	MaxTokens int64
+}
+
+// Validate validates the cleanup request
+func (req CleanupRefreshTokensReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.UserID != "" {
+		if _, err := uuid.Parse(req.UserID); err != nil {
+			verrs.Add("user_id", errs.ErrInvalidUserID)
+		}
+	}
+	if req.OlderThanSeconds < 0 {
+		verrs.Add("older_than_seconds", errs.ErrInvalidCleanupAge)
+	}
+	if req.BatchSize < 0 || req.BatchSize > MaxCleanupBatchSize {
+		verrs.Add("batch_size", errs.ErrInvalidCleanupBatchSize)
+	}
+	if req.MaxTokens < 0 {
+		verrs.Add("max_tokens", errs.ErrInvalidCleanupMaxTokens)
+	}
+
+	return verrs.Err()
+}
+
+// Cleanup returns the tokens to delete as of now; the request must be valid
+func (req CleanupRefreshTokensReq) Cleanup(now time.Time) models.RefreshTokenCleanup {
+	cleanup := models.RefreshTokenCleanup{
+		RevokedOnly: req.RevokedOnly,
+		Before:      now.Add(-time.Duration(req.OlderThanSeconds) * time.Second).UnixMilli(),
+	}
+	if req.UserID != "" {
+		cleanup.UserID = uuid.MustParse(req.UserID)
+	}
+	return cleanup
+}
+
+// Limit returns the number of tokens to delete in the next batch after deleted ones
+func (req CleanupRefreshTokensReq) Limit(deleted int64) int {
+	size := req.BatchSize
+	if size == 0 {
+		size = DefaultCleanupBatchSize
+	}
+	if req.MaxTokens > 0 && req.MaxTokens-deleted < int64(size) {
+		return int(req.MaxTokens - deleted)
+	}
+	return size
+}
+
+// CleanupRefreshTokensProgress reports the progress of a cleanup after a batch
+type CleanupRefreshTokensProgress struct {
+	Deleted int64
+	Batches int64
+	// Done is set on the last batch
+	Done bool
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestCleanupRefreshTokensReq_Validate(t *testing.T) {
+	if err := (CleanupRefreshTokensReq{}).Validate(); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+
+	err := CleanupRefreshTokensReq{
+		UserID:           "not-a-uuid",
+		OlderThanSeconds: -1,
+		BatchSize:        MaxCleanupBatchSize + 1,
+		MaxTokens:        -1,
+	}.Validate()
+	for _, expected := range []error{
+		errs.ErrInvalidUserID, errs.ErrInvalidCleanupAge, errs.ErrInvalidCleanupBatchSize, errs.ErrInvalidCleanupMaxTokens,
+	} {
+		if !errors.Is(err, expected) {
+			t.Errorf("Expected %v, got %v", expected, err)
+		}
+	}
+}
+
+func TestCleanupRefreshTokensReq_Cleanup(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	userID := uuid.New()
+
+	cleanup := CleanupRefreshTokensReq{UserID: userID.String(), OlderThanSeconds: 3600, RevokedOnly: true}.Cleanup(now)
+	if cleanup.UserID != userID || !cleanup.RevokedOnly {
+		t.Errorf("Expected revoked tokens of %s, got %+v", userID, cleanup)
+	}
+	if expected := now.Add(-time.Hour).UnixMilli(); cleanup.Before != expected {
+		t.Errorf("Expected before %d, got %d", expected, cleanup.Before)
+	}
+
+	if cleanup := (CleanupRefreshTokensReq{}).Cleanup(now); cleanup.UserID != uuid.Nil || cleanup.Before != now.UnixMilli() {
+		t.Errorf("Expected every user's tokens dead by now, got %+v", cleanup)
+	}
+}
+
+func TestCleanupRefreshTokensReq_Limit(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      CleanupRefreshTokensReq
+		deleted  int64
+		expected int
+	}{
+		{name: "default batch size", req: CleanupRefreshTokensReq{}, expected: DefaultCleanupBatchSize},
+		{name: "batch size", req: CleanupRefreshTokensReq{BatchSize: 50}, deleted: 500, expected: 50},
+		{name: "below max tokens", req: CleanupRefreshTokensReq{BatchSize: 50, MaxTokens: 120}, deleted: 50, expected: 50},
+		{name: "rest of max tokens", req: CleanupRefreshTokensReq{BatchSize: 50, MaxTokens: 120}, deleted: 100, expected: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if limit := tt.req.Limit(tt.deleted); limit != tt.expected {
+				t.Errorf("Expected limit %d, got %d", tt.expected, limit)
+			}
+		})
+	}
+}
@@ -3,8 +3,14 @@ package dto
 import "time"
 
 type SendLoginNotificationParams struct {
-	UserID   string    `json:"userID"`
-	Email    string    `json:"email"`
-	Username string    `json:"username"`
-	LoginAt  time.Time `json:"loginAt"`
+	UserID    string    `json:"userID"`
+	Email     string    `json:"email"`
+	Username  string    `json:"username"`
+	LoginAt   time.Time `json:"loginAt"`
+	UserAgent string    `json:"userAgent,omitempty"`
+	IPAddress string    `json:"ipAddress,omitempty"`
+	// Locale is the language the user signed in with, used to localize the email
+	Locale string `json:"locale,omitempty"`
+	// Residency is the region the user's data is stored in
+	Residency string `json:"residency,omitempty"`
 }
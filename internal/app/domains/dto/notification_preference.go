@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+// NotificationPreferenceReq opts the caller in or out of a channel for an event type
+type NotificationPreferenceReq struct {
+	EventType string
+	Channel   string
+	Enabled   bool
+}
+
+// UpdateNotificationPreferencesReq represents a notification preferences update request
+type UpdateNotificationPreferencesReq struct {
+	Preferences []NotificationPreferenceReq
+}
+
+// Validate validates the update notification preferences request, reporting every invalid field at once
+func (req UpdateNotificationPreferencesReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if len(req.Preferences) == 0 {
+		verrs.Add("preferences", errs.ErrPreferencesAreRequired)
+	}
+
+	for i, pref := range req.Preferences {
+		if !models.NotificationChannel(pref.Channel).IsValid() {
+			verrs.Add(fmt.Sprintf("preferences[%d].channel", i), errs.ErrInvalidNotificationChannel)
+		}
+	}
+
+	return verrs.Err()
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// ExportOrgAuditLogReq represents a request to stream the audit trail of an organization
+type ExportOrgAuditLogReq struct {
+	OrganizationID string
+	// From and To bound created_at in Unix milliseconds, From inclusive and To exclusive
+	From int64
+	To   int64
+}
+
+// Validate validates the export organization audit log request against the longest range
+// one call may cover
+func (req ExportOrgAuditLogReq) Validate(maxRange time.Duration) error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("organization_id", validateOrganizationID(req.OrganizationID))
+
+	switch {
+	case req.From < 0 || req.To <= req.From:
+		verrs.Add("to", errs.ErrInvalidAuditExportRange)
+	case req.To-req.From > maxRange.Milliseconds():
+		verrs.Add("to", errs.ErrAuditExportRangeTooLong)
+	}
+
+	return verrs.Err()
+}
+
+// Filter returns the audit trail selected by the request
+func (req ExportOrgAuditLogReq) Filter() models.OrgAuditFilter {
+	return models.OrgAuditFilter{
+		OrganizationID: uuid.MustParse(req.OrganizationID),
+		From:           req.From,
+		To:             req.To,
+	}
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestExportOrgAuditLogReq_Validate(t *testing.T) {
+	day := 24 * time.Hour
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	tests := []struct {
+		name string
+		req  ExportOrgAuditLogReq
+		want error
+	}{
+		{
+			name: "valid",
+			req:  ExportOrgAuditLogReq{OrganizationID: "550e8400-e29b-41d4-a716-446655440000", From: from, To: from + day.Milliseconds()},
+		},
+		{
+			name: "invalid organization",
+			req:  ExportOrgAuditLogReq{OrganizationID: "acme", From: from, To: from + day.Milliseconds()},
+			want: errs.ErrInvalidOrganizationID,
+		},
+		{
+			name: "missing to",
+			req:  ExportOrgAuditLogReq{OrganizationID: "550e8400-e29b-41d4-a716-446655440000", From: from},
+			want: errs.ErrInvalidAuditExportRange,
+		},
+		{
+			name: "too long",
+			req:  ExportOrgAuditLogReq{OrganizationID: "550e8400-e29b-41d4-a716-446655440000", From: from, To: from + 31*day.Milliseconds()},
+			want: errs.ErrAuditExportRangeTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate(30 * day)
+			if tt.want == nil && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package dto
+
+import (
+	"strings"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// CreateOrganizationReq represents a request to create an organization
+type CreateOrganizationReq struct {
+	Name string
+	// AllowedEmailDomains restricts member emails, any domain is allowed when empty
+	AllowedEmailDomains []string
+	// Residency is the region members register into, "" for the region of their country;
+	// the service checks it against the configured regions
+	Residency string
+}
+
+// Validate validates the create organization request
+func (req CreateOrganizationReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if strings.TrimSpace(req.Name) == "" {
+		verrs.Add("name", errs.ErrOrganizationNameIsRequired)
+	}
+
+	_, err := models.NormalizeEmailDomains(req.AllowedEmailDomains)
+	verrs.Add("allowed_email_domains", err)
+
+	return verrs.Err()
+}
+
+// GetOrganizationReq represents a request for an organization
+type GetOrganizationReq struct {
+	OrganizationID string
+}
+
+// Validate validates the get organization request
+func (req GetOrganizationReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("organization_id", validateOrganizationID(req.OrganizationID))
+
+	return verrs.Err()
+}
+
+// GetOrganizationByNameReq represents a request for an organization by its name
+type GetOrganizationByNameReq struct {
+	Name string
+}
+
+// Validate validates the get organization by name request
+func (req GetOrganizationByNameReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if strings.TrimSpace(req.Name) == "" {
+		verrs.Add("name", errs.ErrOrganizationNameIsRequired)
+	}
+
+	return verrs.Err()
+}
+
+// SetOrganizationEmailDomainsReq represents a request to replace the allowed email domains of an organization
+type SetOrganizationEmailDomainsReq struct {
+	OrganizationID string
+	// AllowedEmailDomains replaces the current domains; an empty list lifts the restriction
+	AllowedEmailDomains []string
+}
+
+// Validate validates the set organization email domains request
+func (req SetOrganizationEmailDomainsReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("organization_id", validateOrganizationID(req.OrganizationID))
+
+	_, err := models.NormalizeEmailDomains(req.AllowedEmailDomains)
+	verrs.Add("allowed_email_domains", err)
+
+	return verrs.Err()
+}
+
+// SetOrganizationBrandingReq represents a request to replace the email branding of an organization
+type SetOrganizationBrandingReq struct {
+	OrganizationID string
+	// Branding replaces the current branding; an empty branding removes it
+	Branding models.OrganizationBranding
+}
+
+// Validate validates the set organization branding request
+func (req SetOrganizationBrandingReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("organization_id", validateOrganizationID(req.OrganizationID))
+
+	_, err := models.NewOrganizationBranding(req.Branding.Name, req.Branding.LogoURL, req.Branding.SupportEmail, req.Branding.Color)
+	verrs.Add("branding", err)
+
+	return verrs.Err()
+}
+
+// SetOrganizationSessionPolicyReq represents a request to replace the session policy of an organization
+type SetOrganizationSessionPolicyReq struct {
+	OrganizationID string
+	// SessionIdleTimeoutSeconds ends the sessions of members unused for longer; 0 sets none.
+	// The service checks it against the access token lifetime.
+	SessionIdleTimeoutSeconds int64
+}
+
+// Validate validates the set organization session policy request
+func (req SetOrganizationSessionPolicyReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("organization_id", validateOrganizationID(req.OrganizationID))
+
+	if req.SessionIdleTimeoutSeconds < 0 {
+		verrs.Add("session_idle_timeout_seconds", errs.ErrInvalidSessionIdleTimeout)
+	}
+
+	return verrs.Err()
+}
+
+// validateOrganizationID checks that an organization ID is a UUID
+func validateOrganizationID(organizationID string) error {
+	if _, err := uuid.Parse(organizationID); err != nil {
+		return errs.ErrInvalidOrganizationID
+	}
+	return nil
+}
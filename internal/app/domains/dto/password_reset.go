@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+// RequestPasswordResetReq asks for a password reset link to be emailed to the user of an email
+type RequestPasswordResetReq struct {
+	Email string
+}
+
+// Validate validates the password reset request
+func (req RequestPasswordResetReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("email", validateEmail(req.Email))
+
+	return verrs.Err()
+}
+
+// ResetPasswordReq sets a new password with the token of an emailed reset link
+type ResetPasswordReq struct {
+	Token    string
+	Password string
+}
+
+// Validate validates the password reset, reporting every invalid field at once
+func (req ResetPasswordReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.Token == "" {
+		verrs.Add("token", errs.ErrInvalidPasswordReset)
+	}
+
+	_, err := models.NewPassword(req.Password)
+	verrs.Add("password", err)
+
+	return verrs.Err()
+}
+
+type SendPasswordResetParams struct {
+	UserID   string `json:"userID"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	// ResetURL carries the password reset token
+	ResetURL  string    `json:"resetUrl"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Locale is the language the reset was requested in, used to localize the email
+	Locale string `json:"locale,omitempty"`
+	// Branding is the branding of the user's organization, null outside organizations
+	Branding *models.OrganizationBranding `json:"branding"`
+}
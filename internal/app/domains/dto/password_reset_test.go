@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestResetPasswordReq_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      ResetPasswordReq
+		expected error
+	}{
+		{name: "valid", req: ResetPasswordReq{Token: "reset-token", Password: "Valid123!"}},
+		{name: "missing token", req: ResetPasswordReq{Password: "Valid123!"}, expected: errs.ErrInvalidPasswordReset},
+		{name: "weak password", req: ResetPasswordReq{Token: "reset-token", Password: "short"}, expected: errs.ErrInvalidPassword},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+// Sizes of push token registrations; APNs tokens are 64 hex characters and FCM tokens
+// around 160
+const (
+	MaxPushDeviceIDLength = 128
+	MaxPushTokenLength    = 512
+)
+
+// RegisterPushTokenReq registers the push token of a device of the caller
+type RegisterPushTokenReq struct {
+	DeviceID string
+	Platform string
+	Token    string
+}
+
+// Validate validates the register push token request, reporting every invalid field at once
+func (req RegisterPushTokenReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.DeviceID == "" || len(req.DeviceID) > MaxPushDeviceIDLength {
+		verrs.Add("device_id", errs.ErrInvalidPushDeviceID)
+	}
+	if !models.PushPlatform(req.Platform).IsValid() {
+		verrs.Add("platform", errs.ErrInvalidPushPlatform)
+	}
+	if req.Token == "" || len(req.Token) > MaxPushTokenLength {
+		verrs.Add("token", errs.ErrInvalidPushToken)
+	}
+
+	return verrs.Err()
+}
+
+// UnregisterPushTokenReq removes the push token of a device of the caller
+type UnregisterPushTokenReq struct {
+	DeviceID string
+}
+
+// Validate validates the unregister push token request
+func (req UnregisterPushTokenReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.DeviceID == "" || len(req.DeviceID) > MaxPushDeviceIDLength {
+		verrs.Add("device_id", errs.ErrInvalidPushDeviceID)
+	}
+
+	return verrs.Err()
+}
+
+// SendPushTokenParams is the outbox payload of push token registrations and removals
+type SendPushTokenParams struct {
+	UserID   string `json:"userID"`
+	DeviceID string `json:"deviceId"`
+	Platform string `json:"platform"`
+	Token    string `json:"token"`
+	// Reason is set for removals
+	Reason string `json:"reason,omitempty"`
+	// ChangedAt is a Unix timestamp in milliseconds
+	ChangedAt int64 `json:"changedAt"`
+}
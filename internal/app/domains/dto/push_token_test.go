@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestRegisterPushTokenReq_Validate(t *testing.T) {
+	valid := RegisterPushTokenReq{DeviceID: "ios-7f3a", Platform: "apns", Token: strings.Repeat("a", 64)}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Expected a valid registration, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(req *RegisterPushTokenReq)
+		expected error
+	}{
+		{name: "no device id", modify: func(req *RegisterPushTokenReq) { req.DeviceID = "" }, expected: errs.ErrInvalidPushDeviceID},
+		{name: "long device id", modify: func(req *RegisterPushTokenReq) { req.DeviceID = strings.Repeat("d", MaxPushDeviceIDLength+1) }, expected: errs.ErrInvalidPushDeviceID},
+		{name: "unknown platform", modify: func(req *RegisterPushTokenReq) { req.Platform = "webpush" }, expected: errs.ErrInvalidPushPlatform},
+		{name: "no token", modify: func(req *RegisterPushTokenReq) { req.Token = "" }, expected: errs.ErrInvalidPushToken},
+		{name: "long token", modify: func(req *RegisterPushTokenReq) { req.Token = strings.Repeat("t", MaxPushTokenLength+1) }, expected: errs.ErrInvalidPushToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			if err := req.Validate(); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestUnregisterPushTokenReq_Validate(t *testing.T) {
+	if err := (UnregisterPushTokenReq{DeviceID: "ios-7f3a"}).Validate(); err != nil {
+		t.Errorf("Expected a valid removal, got %v", err)
+	}
+	if err := (UnregisterPushTokenReq{}).Validate(); !errors.Is(err, errs.ErrInvalidPushDeviceID) {
+		t.Errorf("Expected %v, got %v", errs.ErrInvalidPushDeviceID, err)
+	}
+}
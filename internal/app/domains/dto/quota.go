@@ -0,0 +1,18 @@
+package dto
+
+import "user-svc/internal/app/domains/models"
+
+type SendQuotaThresholdParams struct {
+	Subject     string `json:"subject"`
+	Method      string `json:"method"`
+	Used        int64  `json:"used"`
+	Limit       int64  `json:"limit"`
+	Threshold   int    `json:"threshold"`
+	WindowStart int64  `json:"windowStart"`
+	WindowEnd   int64  `json:"windowEnd"`
+}
+
+type GetQuotaUsageResp struct {
+	Subject string
+	Usages  []*models.QuotaUsage
+}
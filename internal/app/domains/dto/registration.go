@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+// StartRegistrationReq asks for a code verifying an email to be emailed before registering
+type StartRegistrationReq struct {
+	Email    string
+	ClientID string
+}
+
+// Validate validates the start of a registration, reporting every invalid field at once
+func (req StartRegistrationReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("email", validateEmail(req.Email))
+	verrs.Add("client_id", validateClientID(req.ClientID))
+
+	return verrs.Err()
+}
+
+// StartRegistrationResp tells when the emailed code expires
+type StartRegistrationResp struct {
+	// ExpiresAt is a Unix timestamp in milliseconds
+	ExpiresAt int64
+}
+
+// CompleteRegistrationReq registers a user with the code emailed by StartRegistration
+type CompleteRegistrationReq struct {
+	RegisterReq
+	Code string
+}
+
+// Validate validates the registration and its code, reporting every invalid field at once
+func (req CompleteRegistrationReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	req.RegisterReq.validate(&verrs)
+	verrs.Add("code", validateRegistrationCode(req.Code))
+
+	return verrs.Err()
+}
+
+// validateRegistrationCode checks that a code of the emailed format is provided
+func validateRegistrationCode(code string) error {
+	if code == "" {
+		return errs.ErrRegistrationCodeIsRequired
+	}
+	if len(code) != models.RegistrationCodeLength {
+		return errs.ErrInvalidRegistrationCode
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return errs.ErrInvalidRegistrationCode
+		}
+	}
+	return nil
+}
+
+type SendRegistrationCodeParams struct {
+	Email     string    `json:"email"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Locale is the language the registration was started in, used to localize the email
+	Locale string `json:"locale,omitempty"`
+}
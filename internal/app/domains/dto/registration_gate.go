@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+var inviteCodePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{4,64}$`)
+
+// SetRegistrationGatesReq replaces the registration gates
+type SetRegistrationGatesReq struct {
+	InviteCodeRequired bool
+	InviteCodes        []string
+	DailyCap           int
+	AllowlistOnly      bool
+	Allowlist          []string
+}
+
+// Gates validates the request, reporting every invalid field at once, and returns the gates
+// set by admin
+func (req SetRegistrationGatesReq) Gates(admin string, now time.Time) (*models.RegistrationGates, error) {
+	var verrs errs.ValidationErrors
+
+	for i, code := range req.InviteCodes {
+		if !inviteCodePattern.MatchString(code) {
+			verrs.Add(fmt.Sprintf("invite_codes[%d]", i), errs.ErrInvalidInviteCodes)
+		}
+	}
+	if req.DailyCap < 0 {
+		verrs.Add("daily_cap", errs.ErrInvalidDailySignupCap)
+	}
+	allowlist, err := models.NormalizeAllowlist(req.Allowlist)
+	verrs.Add("allowlist", err)
+	if err := verrs.Err(); err != nil {
+		return nil, err
+	}
+
+	codes := slices.Clone(req.InviteCodes)
+	slices.Sort(codes)
+
+	return &models.RegistrationGates{
+		InviteCodeRequired: req.InviteCodeRequired,
+		InviteCodes:        slices.Compact(codes),
+		DailyCap:           req.DailyCap,
+		AllowlistOnly:      req.AllowlistOnly,
+		Allowlist:          allowlist,
+		UpdatedBy:          admin,
+		UpdatedAt:          now.UnixMilli(),
+	}, nil
+}
+
+// RegistrationGatesResp is the registration gates with the registrations counted today
+type RegistrationGatesResp struct {
+	Gates        *models.RegistrationGates
+	SignupsToday int
+}
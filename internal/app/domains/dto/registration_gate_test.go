@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestSetRegistrationGatesReq_Gates(t *testing.T) {
+	now := time.Now()
+	valid := SetRegistrationGatesReq{
+		InviteCodeRequired: true,
+		InviteCodes:        []string{"launch-2026", "BETA_1", "launch-2026"},
+		DailyCap:           500,
+		AllowlistOnly:      true,
+		Allowlist:          []string{" Example.com", "ops@partner.io", "example.com"},
+	}
+	gates, err := valid.Gates("admin:ops", now)
+	if err != nil {
+		t.Fatalf("Expected valid gates, got %v", err)
+	}
+	if !slices.Equal(gates.InviteCodes, []string{"BETA_1", "launch-2026"}) {
+		t.Errorf("Expected the codes deduplicated, got %v", gates.InviteCodes)
+	}
+	if !slices.Equal(gates.Allowlist, []string{"example.com", "ops@partner.io"}) {
+		t.Errorf("Expected the allowlist normalized, got %v", gates.Allowlist)
+	}
+	if gates.UpdatedBy != "admin:ops" || gates.UpdatedAt != now.UnixMilli() || gates.DailyCap != 500 {
+		t.Errorf("Expected the gates set by admin:ops, got %+v", gates)
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(req *SetRegistrationGatesReq)
+		expected error
+	}{
+		{name: "short code", modify: func(req *SetRegistrationGatesReq) { req.InviteCodes = []string{"abc"} }, expected: errs.ErrInvalidInviteCodes},
+		{name: "code with spaces", modify: func(req *SetRegistrationGatesReq) { req.InviteCodes = []string{"launch 2026"} }, expected: errs.ErrInvalidInviteCodes},
+		{name: "negative cap", modify: func(req *SetRegistrationGatesReq) { req.DailyCap = -1 }, expected: errs.ErrInvalidDailySignupCap},
+		{name: "invalid email", modify: func(req *SetRegistrationGatesReq) { req.Allowlist = []string{"ops@"} }, expected: errs.ErrInvalidAllowlistEntry},
+		{name: "invalid domain", modify: func(req *SetRegistrationGatesReq) { req.Allowlist = []string{"*.example.com"} }, expected: errs.ErrInvalidAllowlistEntry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			if _, err := req.Gates("admin:ops", now); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestCompleteRegistrationReq_Validate(t *testing.T) {
+	valid := RegisterReq{Email: "jane@tickets.example", Username: "jane_doe", Password: "Valid123!", ClientID: "web"}
+
+	tests := []struct {
+		name     string
+		req      CompleteRegistrationReq
+		expected error
+	}{
+		{name: "valid", req: CompleteRegistrationReq{RegisterReq: valid, Code: "042137"}},
+		{name: "missing code", req: CompleteRegistrationReq{RegisterReq: valid}, expected: errs.ErrRegistrationCodeIsRequired},
+		{name: "short code", req: CompleteRegistrationReq{RegisterReq: valid, Code: "4213"}, expected: errs.ErrInvalidRegistrationCode},
+		{name: "non-numeric code", req: CompleteRegistrationReq{RegisterReq: valid, Code: "42a137"}, expected: errs.ErrInvalidRegistrationCode},
+		{
+			name:     "invalid registration",
+			req:      CompleteRegistrationReq{RegisterReq: RegisterReq{Email: "jane", Username: "jane_doe", Password: "Valid123!", ClientID: "web"}, Code: "042137"},
+			expected: errs.ErrInvalidEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+// GetRiskSignalsReq represents a request for the fraud signals of a user
+type GetRiskSignalsReq struct {
+	UserID string
+}
+
+// Validate validates the risk signals request
+func (req GetRiskSignalsReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+
+	return verrs.Err()
+}
@@ -0,0 +1,15 @@
+package dto
+
+// SendSessionAnomalyParams is the outbox payload of session anomalies
+type SendSessionAnomalyParams struct {
+	UserID          string `json:"userID"`
+	SessionID       string `json:"sessionId"`
+	ClientID        string `json:"clientId"`
+	Kind            string `json:"kind"`
+	IPAddress       string `json:"ipAddress"`
+	Country         string `json:"country"`
+	PreviousCountry string `json:"previousCountry,omitempty"`
+	Refreshes       int64  `json:"refreshes"`
+	// DetectedAt is a Unix timestamp in milliseconds
+	DetectedAt int64 `json:"detectedAt"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// PromoteSigningKeyResp represents the JWT signing keys after a promotion
+type PromoteSigningKeyResp struct {
+	// PrimaryKeyID identifies the key new tokens are signed with
+	PrimaryKeyID string
+	// PreviousKeyID identifies the former primary key, which keeps verifying tokens
+	PreviousKeyID string
+}
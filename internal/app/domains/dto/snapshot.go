@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"unicode/utf8"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+// snapshotPassphraseMinLength keeps snapshot archives, which hold password hashes, out of
+// reach of offline guessing
+const snapshotPassphraseMinLength = 16
+
+// ExportSnapshotReq represents a request to export an encrypted snapshot
+type ExportSnapshotReq struct {
+	Passphrase string
+}
+
+// Validate validates the export snapshot request
+func (req ExportSnapshotReq) Validate() error {
+	var verrs errs.ValidationErrors
+	verrs.Add("passphrase", validateSnapshotPassphrase(req.Passphrase))
+	return verrs.Err()
+}
+
+// RestoreSnapshotChunk is one message of a snapshot restore; the passphrase is only read
+// from the first one
+type RestoreSnapshotChunk struct {
+	Passphrase string
+	Data       []byte
+}
+
+// Validate validates the first chunk of a snapshot restore
+func (req RestoreSnapshotChunk) Validate() error {
+	var verrs errs.ValidationErrors
+	verrs.Add("passphrase", validateSnapshotPassphrase(req.Passphrase))
+	return verrs.Err()
+}
+
+// RestoreSnapshotResp represents the rows restored from a snapshot
+type RestoreSnapshotResp struct {
+	Organizations int64
+	Users         int64
+	Sessions      int64
+}
+
+func validateSnapshotPassphrase(passphrase string) error {
+	if utf8.RuneCountInString(passphrase) < snapshotPassphraseMinLength {
+		return errs.ErrInvalidSnapshotPassphrase
+	}
+	return nil
+}
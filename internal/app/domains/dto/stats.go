@@ -0,0 +1,27 @@
+package dto
+
+import "user-svc/internal/app/domains/errs"
+
+const (
+	// DefaultStatsDays is the number of daily buckets returned when none is requested
+	DefaultStatsDays = 30
+	// MaxStatsDays bounds the daily buckets of a single request
+	MaxStatsDays = 90
+)
+
+// GetUserStatsReq represents a user statistics request
+type GetUserStatsReq struct {
+	// Days is the number of daily buckets ending today, DefaultStatsDays when zero
+	Days int
+}
+
+// Validate validates the user statistics request
+func (req GetUserStatsReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.Days < 0 || req.Days > MaxStatsDays {
+		verrs.Add("days", errs.ErrInvalidStatsDays)
+	}
+
+	return verrs.Err()
+}
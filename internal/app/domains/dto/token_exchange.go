@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"strings"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+// RFC 8693 identifiers of the token exchange grant and the tokens it trades
+const (
+	TokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	AccessTokenType        = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// ExchangeTokenReq represents a request of a service for a delegation token
+type ExchangeTokenReq struct {
+	GrantType string
+	// SubjectToken is the access token of the user, or a delegation token issued to the
+	// caller, that the caller acts on behalf of
+	SubjectToken     string
+	SubjectTokenType string
+	// Audience is the service the delegation token is for
+	Audience string
+	// Scopes narrow the token, all scopes allowed at the audience when empty
+	Scopes []string
+	// RequestedTokenType is optional, an access token is issued
+	RequestedTokenType string
+}
+
+// Validate validates the token exchange request
+func (req ExchangeTokenReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.GrantType != TokenExchangeGrantType {
+		verrs.Add("grant_type", errs.ErrUnsupportedGrantType)
+	}
+	if req.SubjectToken == "" {
+		verrs.Add("subject_token", errs.ErrSubjectTokenIsRequired)
+	}
+	if req.SubjectTokenType != AccessTokenType {
+		verrs.Add("subject_token_type", errs.ErrUnsupportedTokenType)
+	}
+	if req.RequestedTokenType != "" && req.RequestedTokenType != AccessTokenType {
+		verrs.Add("requested_token_type", errs.ErrUnsupportedTokenType)
+	}
+	if strings.TrimSpace(req.Audience) == "" {
+		verrs.Add("audience", errs.ErrAudienceIsRequired)
+	}
+	for _, scope := range req.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \t\n") {
+			verrs.Add("scope", errs.ErrInvalidScope)
+			break
+		}
+	}
+
+	return verrs.Err()
+}
+
+// ExchangeTokenResp represents an issued delegation token
+type ExchangeTokenResp struct {
+	AccessToken     string
+	IssuedTokenType string
+	// TokenType is how the token is presented, "Bearer"
+	TokenType string
+	// ExpiresIn is the lifetime of the token in seconds
+	ExpiresIn int64
+	Scopes    []string
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestExchangeTokenReq_Validate(t *testing.T) {
+	req := ExchangeTokenReq{
+		GrantType:        TokenExchangeGrantType,
+		SubjectToken:     "token",
+		SubjectTokenType: AccessTokenType,
+		Audience:         "payment-svc",
+		Scopes:           []string{"payments:create"},
+	}
+	if err := req.Validate(); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+
+	err := ExchangeTokenReq{
+		GrantType:          "client_credentials",
+		SubjectTokenType:   "urn:ietf:params:oauth:token-type:id_token",
+		RequestedTokenType: "urn:ietf:params:oauth:token-type:refresh_token",
+		Scopes:             []string{"payments:create payments:refund"},
+	}.Validate()
+	for _, expected := range []error{
+		errs.ErrUnsupportedGrantType,
+		errs.ErrSubjectTokenIsRequired,
+		errs.ErrUnsupportedTokenType,
+		errs.ErrAudienceIsRequired,
+		errs.ErrInvalidScope,
+	} {
+		if !errors.Is(err, expected) {
+			t.Errorf("Expected %v among the violations, got %v", expected, err)
+		}
+	}
+}
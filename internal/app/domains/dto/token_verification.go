@@ -0,0 +1,19 @@
+package dto
+
+import "user-svc/internal/app/domains/errs"
+
+// VerifyTokenReq represents a request of a service to verify the access token of a user
+type VerifyTokenReq struct {
+	AccessToken string
+}
+
+// Validate validates the token verification request
+func (req VerifyTokenReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.AccessToken == "" {
+		verrs.Add("access_token", errs.ErrTokenIsRequired)
+	}
+
+	return verrs.Err()
+}
@@ -3,6 +3,8 @@ package dto
 import (
 	"user-svc/internal/app/domains/errs"
 	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
 )
 
 // RegisterReq represents a user registration request
@@ -10,30 +12,55 @@ type RegisterReq struct {
 	Email    string
 	Username string
 	Password string
+	ClientID string
+	// OrganizationID makes the user a staff member of the organization, optional
+	OrganizationID string
+	// InviteCode is required while the invite code registration gate is enabled
+	InviteCode string
 }
 
-// Validate validates the registration request
+// Validate validates the registration request, reporting every invalid field at once
 func (req RegisterReq) Validate() error {
-	// Check if email is provided
-	if req.Email == "" {
-		return errs.ErrEmailIsRequired
-	}
+	var verrs errs.ValidationErrors
+	req.validate(&verrs)
+	return verrs.Err()
+}
 
+// validate adds the violations of the registration fields to verrs
+func (req RegisterReq) validate(verrs *errs.ValidationErrors) {
 	// Validate email using the Email type
-	if _, err := models.NewEmail(req.Email); err != nil {
-		return err
-	}
+	verrs.Add("email", validateEmail(req.Email))
 
 	// Validate username using the Username type
-	if _, err := models.NewUsername(req.Username); err != nil {
-		return err
-	}
+	_, err := models.NewUsername(req.Username)
+	verrs.Add("username", err)
 
 	// Validate password using the Password type
-	if _, err := models.NewPassword(req.Password); err != nil {
-		return err
+	_, err = models.NewPassword(req.Password)
+	verrs.Add("password", err)
+
+	verrs.Add("client_id", validateClientID(req.ClientID))
+
+	if req.OrganizationID != "" {
+		verrs.Add("organization_id", validateOrganizationID(req.OrganizationID))
+	}
+}
+
+// validateEmail checks that an email is provided and well-formed
+func validateEmail(email string) error {
+	if email == "" {
+		return errs.ErrEmailIsRequired
 	}
 
+	_, err := models.NewEmail(email)
+	return err
+}
+
+// validateClientID checks that the calling client identified itself
+func validateClientID(clientID string) error {
+	if clientID == "" {
+		return errs.ErrClientIDIsRequired
+	}
 	return nil
 }
 
@@ -42,32 +69,33 @@ type RegisterResp struct {
 	User         *models.User
 	AccessToken  string
 	RefreshToken string
+	// RefreshTokenExpiresAt is a Unix timestamp in milliseconds, 0 without a refresh token
+	RefreshTokenExpiresAt int64
 }
 
 // LoginReq represents a user login request
 type LoginReq struct {
 	Email    string
 	Password string
+	ClientID string
+	// RememberMe selects a long-lived session instead of a short one
+	RememberMe bool
 }
 
-// Validate validates the login request
+// Validate validates the login request, reporting every invalid field at once
 func (req LoginReq) Validate() error {
-	// Check if email is provided
-	if req.Email == "" {
-		return errs.ErrEmailIsRequired
-	}
+	var verrs errs.ValidationErrors
 
-	// Validate email using the Email type
-	if _, err := models.NewEmail(req.Email); err != nil {
-		return err
-	}
+	verrs.Add("email", validateEmail(req.Email))
 
 	// Validate password
 	if req.Password == "" {
-		return errs.ErrInvalidPassword
+		verrs.Add("password", errs.ErrInvalidPassword)
 	}
 
-	return nil
+	verrs.Add("client_id", validateClientID(req.ClientID))
+
+	return verrs.Err()
 }
 
 // LoginResp represents a user login response
@@ -75,6 +103,8 @@ type LoginResp struct {
 	User         *models.User
 	AccessToken  string
 	RefreshToken string
+	// RefreshTokenExpiresAt is a Unix timestamp in milliseconds, 0 without a refresh token
+	RefreshTokenExpiresAt int64
 }
 
 // RefreshTokenReq represents a refresh token request
@@ -84,19 +114,62 @@ type RefreshTokenReq struct {
 
 // Validate validates the refresh token request
 func (req RefreshTokenReq) Validate() error {
+	var verrs errs.ValidationErrors
+
 	if req.RefreshToken == "" {
-		return errs.ErrTokenIsRequired
+		verrs.Add("refresh_token", errs.ErrTokenIsRequired)
 	}
 
-	return nil
+	return verrs.Err()
 }
 
-// RefreshTokenResp represents a refresh token response
+// RefreshTokenResp represents a refresh token response. RefreshToken is only set when
+// the client's policy rotates refresh tokens and replaces the presented one.
 type RefreshTokenResp struct {
-	AccessToken string
+	AccessToken  string
+	RefreshToken string
+	// RefreshTokenExpiresAt is a Unix timestamp in milliseconds, 0 without a new refresh token
+	RefreshTokenExpiresAt int64
 }
 
 // RevokeTokenReq represents a token revocation request
 type RevokeTokenReq struct {
 	RefreshToken string
 }
+
+// LogoutReq represents a request of the caller to end the session of a refresh token
+type LogoutReq struct {
+	RefreshToken string
+}
+
+// Validate validates the logout request
+func (req LogoutReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.RefreshToken == "" {
+		verrs.Add("refresh_token", errs.ErrTokenIsRequired)
+	}
+
+	return verrs.Err()
+}
+
+// RevokeAllUserTokensReq represents a request to revoke every token of a user
+type RevokeAllUserTokensReq struct {
+	UserID string
+}
+
+// Validate validates the revoke all user tokens request
+func (req RevokeAllUserTokensReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+
+	return verrs.Err()
+}
+
+// RevokeAllUserTokensResp represents a revoke all user tokens response
+type RevokeAllUserTokensResp struct {
+	RevokedRefreshTokens int64
+}
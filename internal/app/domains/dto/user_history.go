@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultUserHistoryLimit is the page size of GetUserHistory when no limit is given
+	DefaultUserHistoryLimit = 100
+	// MaxUserHistoryLimit is the largest page of events GetUserHistory returns
+	MaxUserHistoryLimit = 1000
+)
+
+// GetUserHistoryReq represents a request for a page of a user's event history
+type GetUserHistoryReq struct {
+	UserID string
+	// AfterVersion returns the events after this version, 0 starts at the first event
+	AfterVersion int64
+	// Limit caps the page size, 0 uses DefaultUserHistoryLimit
+	Limit int
+}
+
+// Validate validates the user history request
+func (req GetUserHistoryReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+	if req.AfterVersion < 0 {
+		verrs.Add("after_version", errs.ErrInvalidHistoryVersion)
+	}
+	if req.Limit < 0 || req.Limit > MaxUserHistoryLimit {
+		verrs.Add("limit", errs.ErrInvalidHistoryLimit)
+	}
+
+	return verrs.Err()
+}
+
+// PageLimit returns the number of events to return
+func (req GetUserHistoryReq) PageLimit() int {
+	if req.Limit == 0 {
+		return DefaultUserHistoryLimit
+	}
+	return req.Limit
+}
+
+// GetUserHistoryResp represents a page of a user's event history
+type GetUserHistoryResp struct {
+	Events []*models.UserEvent
+	// NextAfterVersion continues the history, 0 when there are no more events
+	NextAfterVersion int64
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestGetUserHistoryReq_Validate(t *testing.T) {
+	req := GetUserHistoryReq{UserID: uuid.NewString()}
+	if err := req.Validate(); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+	if req.PageLimit() != DefaultUserHistoryLimit {
+		t.Errorf("Expected default limit %d, got %d", DefaultUserHistoryLimit, req.PageLimit())
+	}
+
+	err := GetUserHistoryReq{UserID: "not-a-uuid", AfterVersion: -1, Limit: MaxUserHistoryLimit + 1}.Validate()
+	if !errors.Is(err, errs.ErrInvalidUserID) || !errors.Is(err, errs.ErrInvalidHistoryVersion) || !errors.Is(err, errs.ErrInvalidHistoryLimit) {
+		t.Errorf("Expected user id, version and limit violations, got %v", err)
+	}
+}
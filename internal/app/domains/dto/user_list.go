@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultListUsersPageSize is the page size of requests that do not ask for one
+	DefaultListUsersPageSize = 50
+	MaxListUsersPageSize     = 500
+)
+
+// ListUsersReq represents a request for a page of users in creation order
+type ListUsersReq struct {
+	// PageSize is the most users returned, DefaultListUsersPageSize when zero
+	PageSize int
+	// PageToken continues after the last user of the previous page, empty for the first page
+	PageToken string
+	// OrganizationID limits the users to the staff of an organization, optional
+	OrganizationID string
+}
+
+// Validate validates the list users request
+func (req ListUsersReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if req.PageSize < 0 || req.PageSize > MaxListUsersPageSize {
+		verrs.Add("page_size", errs.ErrInvalidPageSize)
+	}
+	if _, err := req.Cursor(); err != nil {
+		verrs.Add("page_token", err)
+	}
+	if req.OrganizationID != "" {
+		verrs.Add("organization_id", validateOrganizationID(req.OrganizationID))
+	}
+
+	return verrs.Err()
+}
+
+// Limit returns the requested page size, defaulting to DefaultListUsersPageSize
+func (req ListUsersReq) Limit() int {
+	if req.PageSize == 0 {
+		return DefaultListUsersPageSize
+	}
+	return req.PageSize
+}
+
+// Filter returns the filter of the listed users
+func (req ListUsersReq) Filter() models.UserExportFilter {
+	var filter models.UserExportFilter
+	if req.OrganizationID != "" {
+		filter.OrganizationID = uuid.MustParse(req.OrganizationID)
+	}
+	return filter
+}
+
+// Cursor returns the cursor after the last user of the previous page, the zero cursor for
+// the first page
+func (req ListUsersReq) Cursor() (models.UserCursor, error) {
+	if req.PageToken == "" {
+		return models.UserCursor{}, nil
+	}
+
+	afterCreatedAt, afterID, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return models.UserCursor{}, err
+	}
+
+	return models.UserCursor{CreatedAt: afterCreatedAt, ID: afterID}, nil
+}
+
+// ListUsersResp represents a page of users
+type ListUsersResp struct {
+	Users []*models.User
+	// NextPageToken requests the next page, empty on the last page
+	NextPageToken string
+}
+
+// UserPageToken returns the page token that continues after the user
+func UserPageToken(user *models.User) string {
+	return encodePageToken(user.CreatedAt, user.ID)
+}
+
+// encodePageToken returns the page token of a position in rows ordered by creation and ID
+func encodePageToken(createdAt int64, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(createdAt, 10) + "/" + id.String()))
+}
+
+// decodePageToken returns the creation time and ID of the position of a page token
+func decodePageToken(token string) (int64, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, uuid.Nil, errs.ErrInvalidPageToken
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "/")
+	if !ok {
+		return 0, uuid.Nil, errs.ErrInvalidPageToken
+	}
+	atCreatedAt, err := strconv.ParseInt(createdAt, 10, 64)
+	if err != nil {
+		return 0, uuid.Nil, errs.ErrInvalidPageToken
+	}
+	atID, err := uuid.Parse(id)
+	if err != nil {
+		return 0, uuid.Nil, errs.ErrInvalidPageToken
+	}
+
+	return atCreatedAt, atID, nil
+}
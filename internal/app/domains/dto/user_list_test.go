@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+func TestListUsersReq_Cursor(t *testing.T) {
+	cursor, err := ListUsersReq{}.Cursor()
+	if err != nil || cursor != (models.UserCursor{}) {
+		t.Errorf("Expected the zero cursor for the first page, got %+v, %v", cursor, err)
+	}
+
+	user := &models.User{ID: uuid.New(), CreatedAt: 1700000360000}
+	cursor, err = ListUsersReq{PageToken: UserPageToken(user)}.Cursor()
+	if err != nil {
+		t.Fatalf("Expected a valid page token, got %v", err)
+	}
+	if cursor.CreatedAt != user.CreatedAt || cursor.ID != user.ID {
+		t.Errorf("Expected the cursor after %d/%s, got %+v", user.CreatedAt, user.ID, cursor)
+	}
+}
+
+func TestListUsersReq_Validate(t *testing.T) {
+	req := ListUsersReq{}
+	if err := req.Validate(); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+	if req.Limit() != DefaultListUsersPageSize {
+		t.Errorf("Expected default page size %d, got %d", DefaultListUsersPageSize, req.Limit())
+	}
+
+	err := ListUsersReq{PageSize: MaxListUsersPageSize + 1, PageToken: "not a token", OrganizationID: "acme"}.Validate()
+	if !errors.Is(err, errs.ErrInvalidPageSize) || !errors.Is(err, errs.ErrInvalidPageToken) || !errors.Is(err, errs.ErrInvalidOrganizationID) {
+		t.Errorf("Expected page size, page token and organization violations, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// MaxBatchGetUsers is the most user IDs one BatchGetUsers call may look up
+const MaxBatchGetUsers = 500
+
+// GetUserByUsernameReq represents a request for the user with a username
+type GetUserByUsernameReq struct {
+	Username string
+}
+
+// Validate validates the get user by username request
+func (req GetUserByUsernameReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("username", models.Username(req.Username).Validate())
+
+	return verrs.Err()
+}
+
+// BatchGetUsersReq represents a request for many users by ID
+type BatchGetUsersReq struct {
+	UserIDs []string
+}
+
+// Validate validates the batch lookup and returns the distinct user IDs to look up
+func (req BatchGetUsersReq) Validate() ([]uuid.UUID, error) {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("user_ids", validateBatchUserIDs(req.UserIDs, MaxBatchGetUsers))
+
+	ids := make([]uuid.UUID, 0, len(req.UserIDs))
+	seen := make(map[uuid.UUID]struct{}, len(req.UserIDs))
+	for _, userID := range req.UserIDs {
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			verrs.Add("user_ids", errs.ErrInvalidUserID)
+			break
+		}
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	if err := verrs.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
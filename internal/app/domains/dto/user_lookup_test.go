@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestGetUserByUsernameReq_Validate(t *testing.T) {
+	if err := (GetUserByUsernameReq{Username: "jane_doe"}).Validate(); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+
+	for _, username := range []string{"", "jd", "jane doe", "_jane"} {
+		if err := (GetUserByUsernameReq{Username: username}).Validate(); !errors.Is(err, errs.ErrInvalidUsername) {
+			t.Errorf("Expected %q to be rejected, got %v", username, err)
+		}
+	}
+}
+
+func TestBatchGetUsersReq_Validate(t *testing.T) {
+	id := uuid.New()
+	ids, err := (BatchGetUsersReq{UserIDs: []string{id.String(), id.String()}}).Validate()
+	if err != nil {
+		t.Fatalf("Expected a valid request, got %v", err)
+	}
+	if len(ids) != 1 || ids[0] != id {
+		t.Errorf("Expected the repeated ID to be looked up once, got %v", ids)
+	}
+
+	tooMany := make([]string, MaxBatchGetUsers+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+
+	tests := []struct {
+		name    string
+		userIDs []string
+		want    error
+	}{
+		{name: "no ids", userIDs: nil, want: errs.ErrUserIDsAreRequired},
+		{name: "too many ids", userIDs: tooMany, want: errs.ErrTooManyUserIDs},
+		{name: "invalid id", userIDs: []string{id.String(), "not-a-uuid"}, want: errs.ErrInvalidUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := (BatchGetUsersReq{UserIDs: tt.userIDs}).Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"fmt"
+	"sort"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// SetUserMetadataReq represents a request to set and remove metadata keys of a user
+type SetUserMetadataReq struct {
+	UserID string
+	// Set maps namespaced keys, e.g. crm.segment, to their new values
+	Set map[string]string
+	// Remove lists namespaced keys to delete
+	Remove []string
+}
+
+// Validate validates the metadata request against the largest allowed value in bytes
+func (req SetUserMetadataReq) Validate(maxValueBytes int) error {
+	var verrs errs.ValidationErrors
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+	if len(req.Set) == 0 && len(req.Remove) == 0 {
+		verrs.Add("set", errs.ErrMetadataIsRequired)
+	}
+
+	for _, key := range req.sortedSetKeys() {
+		field := fmt.Sprintf("set[%s]", key)
+		if !models.ValidMetadataKey(key) {
+			verrs.Add(field, errs.ErrInvalidMetadataKey)
+		} else if len(req.Set[key]) > maxValueBytes {
+			verrs.Add(field, errs.ErrMetadataValueTooLarge)
+		}
+	}
+	for i, key := range req.Remove {
+		field := fmt.Sprintf("remove[%d]", i)
+		if !models.ValidMetadataKey(key) {
+			verrs.Add(field, errs.ErrInvalidMetadataKey)
+		} else if _, ok := req.Set[key]; ok {
+			verrs.Add(field, errs.ErrMetadataKeyConflict)
+		}
+	}
+
+	return verrs.Err()
+}
+
+// Namespaces returns the namespaces of every key the request sets or removes
+func (req SetUserMetadataReq) Namespaces() map[string]struct{} {
+	namespaces := make(map[string]struct{})
+	for key := range req.Set {
+		namespaces[models.MetadataNamespace(key)] = struct{}{}
+	}
+	for _, key := range req.Remove {
+		namespaces[models.MetadataNamespace(key)] = struct{}{}
+	}
+	return namespaces
+}
+
+// sortedSetKeys orders the keys to set, so validation errors are reported deterministically
+func (req SetUserMetadataReq) sortedSetKeys() []string {
+	keys := make([]string, 0, len(req.Set))
+	for key := range req.Set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
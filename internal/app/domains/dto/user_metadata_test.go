@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestSetUserMetadataReq_Validate(t *testing.T) {
+	valid := SetUserMetadataReq{
+		UserID: uuid.NewString(),
+		Set:    map[string]string{"crm.segment": "vip"},
+		Remove: []string{"fraud.flag"},
+	}
+	if err := valid.Validate(16); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+
+	err := SetUserMetadataReq{UserID: uuid.NewString()}.Validate(16)
+	if !errors.Is(err, errs.ErrMetadataIsRequired) {
+		t.Errorf("Expected ErrMetadataIsRequired, got %v", err)
+	}
+
+	err = SetUserMetadataReq{
+		UserID: uuid.NewString(),
+		Set:    map[string]string{"segment": "vip", "crm.note": "a value that is too long"},
+		Remove: []string{"crm.note"},
+	}.Validate(16)
+	for _, want := range []error{errs.ErrInvalidMetadataKey, errs.ErrMetadataValueTooLarge, errs.ErrMetadataKeyConflict} {
+		if !errors.Is(err, want) {
+			t.Errorf("Expected %v, got %v", want, err)
+		}
+	}
+}
+
+func TestSetUserMetadataReq_Namespaces(t *testing.T) {
+	req := SetUserMetadataReq{
+		Set:    map[string]string{"crm.segment": "vip", "crm.tier": "gold"},
+		Remove: []string{"fraud.flag"},
+	}
+
+	namespaces := req.Namespaces()
+	if len(namespaces) != 2 {
+		t.Errorf("Expected 2 namespaces, got %v", namespaces)
+	}
+	for _, namespace := range []string{"crm", "fraud"} {
+		if _, ok := namespaces[namespace]; !ok {
+			t.Errorf("Expected namespace %s, got %v", namespace, namespaces)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package dto
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxUserNoteLength is the length of note bodies in characters
+	MaxUserNoteLength = 2000
+	// MaxUserNoteAuthorLength is the length of the author column
+	MaxUserNoteAuthorLength = 255
+	// DefaultListUserNotesPageSize is the page size of requests that do not ask for one
+	DefaultListUserNotesPageSize = 50
+)
+
+// AddUserNoteReq represents a request to leave a note on a user
+type AddUserNoteReq struct {
+	UserID string
+	Body   string
+	// Visibility defaults to internal
+	Visibility string
+	// Author is the support agent writing the note
+	Author string
+}
+
+// Validate validates the add user note request
+func (req AddUserNoteReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		verrs.Add("body", errs.ErrNoteBodyIsRequired)
+	} else if utf8.RuneCountInString(req.Body) > MaxUserNoteLength {
+		verrs.Add("body", errs.ErrNoteBodyTooLong)
+	}
+	if req.Visibility != "" && !models.UserNoteVisibility(req.Visibility).IsValid() {
+		verrs.Add("visibility", errs.ErrInvalidNoteVisibility)
+	}
+	if strings.TrimSpace(req.Author) == "" {
+		verrs.Add("author", errs.ErrNoteAuthorIsRequired)
+	} else if len(req.Author) > MaxUserNoteAuthorLength {
+		verrs.Add("author", errs.ErrNoteAuthorTooLong)
+	}
+
+	return verrs.Err()
+}
+
+// NoteVisibility returns the visibility of the note, internal unless asked otherwise
+func (req AddUserNoteReq) NoteVisibility() models.UserNoteVisibility {
+	if req.Visibility == "" {
+		return models.UserNoteVisibilityInternal
+	}
+	return models.UserNoteVisibility(req.Visibility)
+}
+
+// ListUserNotesReq represents a request for a page of the notes of a user, newest first
+type ListUserNotesReq struct {
+	UserID string
+	// Visibility limits the notes to a visibility, optional; disclosable lists the notes to
+	// hand out on a subject access request
+	Visibility string
+	// PageSize is the most notes returned, DefaultListUserNotesPageSize when zero
+	PageSize int
+	// PageToken continues after the last note of the previous page, empty for the first page
+	PageToken string
+}
+
+// Validate validates the list user notes request
+func (req ListUserNotesReq) Validate() error {
+	var verrs errs.ValidationErrors
+
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		verrs.Add("user_id", errs.ErrInvalidUserID)
+	}
+	if req.Visibility != "" && !models.UserNoteVisibility(req.Visibility).IsValid() {
+		verrs.Add("visibility", errs.ErrInvalidNoteVisibility)
+	}
+	if req.PageSize < 0 || req.PageSize > MaxListUsersPageSize {
+		verrs.Add("page_size", errs.ErrInvalidPageSize)
+	}
+	if _, err := req.Cursor(); err != nil {
+		verrs.Add("page_token", err)
+	}
+
+	return verrs.Err()
+}
+
+// Limit returns the requested page size, defaulting to DefaultListUserNotesPageSize
+func (req ListUserNotesReq) Limit() int {
+	if req.PageSize == 0 {
+		return DefaultListUserNotesPageSize
+	}
+	return req.PageSize
+}
+
+// Cursor returns the cursor after the last note of the previous page, the zero cursor for
+// the first page
+func (req ListUserNotesReq) Cursor() (models.UserNoteCursor, error) {
+	if req.PageToken == "" {
+		return models.UserNoteCursor{}, nil
+	}
+
+	createdAt, id, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return models.UserNoteCursor{}, err
+	}
+
+	return models.UserNoteCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// ListUserNotesResp represents a page of the notes of a user
+type ListUserNotesResp struct {
+	Notes []*models.UserNote
+	// NextPageToken requests the next page, empty on the last page
+	NextPageToken string
+}
+
+// UserNotePageToken returns the page token that continues after the note
+func UserNotePageToken(note *models.UserNote) string {
+	return encodePageToken(note.CreatedAt, note.ID)
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+func TestAddUserNoteReq_Validate(t *testing.T) {
+	req := AddUserNoteReq{UserID: uuid.NewString(), Body: "refund promised on 5/3", Author: "agent@tickets.example"}
+	if err := req.Validate(); err != nil {
+		t.Errorf("Expected a valid request, got %v", err)
+	}
+	if req.NoteVisibility() != models.UserNoteVisibilityInternal {
+		t.Errorf("Expected internal notes by default, got %s", req.NoteVisibility())
+	}
+
+	err := AddUserNoteReq{UserID: "not-a-uuid", Body: strings.Repeat("é", MaxUserNoteLength+1), Visibility: "public", Author: " "}.Validate()
+	for _, want := range []error{errs.ErrInvalidUserID, errs.ErrNoteBodyTooLong, errs.ErrInvalidNoteVisibility, errs.ErrNoteAuthorIsRequired} {
+		if !errors.Is(err, want) {
+			t.Errorf("Expected error %v, got %v", want, err)
+		}
+	}
+}
+
+func TestListUserNotesReq(t *testing.T) {
+	note := models.NewUserNote(uuid.New(), "refund promised on 5/3", models.UserNoteVisibilityDisclosable, "agent", "admin:ops", 1700000360000)
+	req := ListUserNotesReq{UserID: note.UserID.String(), Visibility: "disclosable", PageToken: UserNotePageToken(note)}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Expected a valid request, got %v", err)
+	}
+	if req.Limit() != DefaultListUserNotesPageSize {
+		t.Errorf("Expected default page size %d, got %d", DefaultListUserNotesPageSize, req.Limit())
+	}
+	if cursor, _ := req.Cursor(); cursor.CreatedAt != note.CreatedAt || cursor.ID != note.ID {
+		t.Errorf("Expected the cursor after %d/%s, got %+v", note.CreatedAt, note.ID, cursor)
+	}
+
+	err := ListUserNotesReq{UserID: note.UserID.String(), Visibility: "public", PageToken: "not a token"}.Validate()
+	if !errors.Is(err, errs.ErrInvalidNoteVisibility) || !errors.Is(err, errs.ErrInvalidPageToken) {
+		t.Errorf("Expected visibility and page token violations, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegisterReq_ValidateReportsAllViolations(t *testing.T) {
+	err := RegisterReq{Email: "not-an-email", Username: "a", Password: "short"}.Validate()
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	wrapper, ok := errs.As(err)
+	if !ok {
+		t.Fatalf("Expected ErrorWrapper, got %T", err)
+	}
+
+	fields := map[string]bool{}
+	for _, violation := range wrapper.GetViolations() {
+		fields[violation.Field] = true
+	}
+
+	for _, field := range []string{"email", "username", "password", "client_id"} {
+		if !fields[field] {
+			t.Errorf("Expected violation for field '%s', got %v", field, wrapper.GetViolations())
+		}
+	}
+
+	if !errors.Is(err, errs.ErrInvalidEmail) || !errors.Is(err, errs.ErrInvalidPassword) {
+		t.Error("Expected validation error to match the underlying field errors")
+	}
+}
+
+func TestRegisterReq_ValidateRendersBadRequest(t *testing.T) {
+	err := RegisterReq{Email: "", Username: "valid_user", Password: "Valid123!", ClientID: "web"}.Validate()
+
+	st, ok := status.FromError(errs.ToGRPCError(err))
+	if !ok {
+		t.Fatal("Expected gRPC status error")
+	}
+
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("Expected code %v, got %v", codes.InvalidArgument, st.Code())
+	}
+
+	if len(st.Details()) != 1 {
+		t.Fatalf("Expected 1 status detail, got %d", len(st.Details()))
+	}
+
+	br, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok {
+		t.Fatalf("Expected BadRequest detail, got %T", st.Details()[0])
+	}
+
+	if len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != "email" {
+		t.Errorf("Expected a single email violation, got %v", br.FieldViolations)
+	}
+
+	if br.FieldViolations[0].Description != errs.ErrEmailIsRequired.Message {
+		t.Errorf("Expected description '%s', got '%s'", errs.ErrEmailIsRequired.Message, br.FieldViolations[0].Description)
+	}
+}
+
+func TestRegisterReq_ValidateAcceptsValidRequest(t *testing.T) {
+	if err := (RegisterReq{Email: "user@example.com", Username: "valid_user", Password: "Valid123!", ClientID: "web"}).Validate(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestRegisterReq_ValidatePasswordLength(t *testing.T) {
+	passphrase := "Correct horse battery staple 1! " + strings.Repeat("x", 64)
+	if err := (RegisterReq{Email: "user@example.com", Username: "valid_user", Password: passphrase, ClientID: "web"}).Validate(); err != nil {
+		t.Errorf("Expected a passphrase beyond bcrypt's 72 bytes to be accepted, got %v", err)
+	}
+
+	err := RegisterReq{Email: "user@example.com", Username: "valid_user", Password: "Valid123!" + strings.Repeat("x", 256), ClientID: "web"}.Validate()
+	if !errors.Is(err, errs.ErrPasswordTooLong) {
+		t.Errorf("Expected ErrPasswordTooLong, got %v", err)
+	}
+}
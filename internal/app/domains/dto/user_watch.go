@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// WatchUserReq represents a subscription to the changes of users
+type WatchUserReq struct {
+	UserIDs []string
+}
+
+// WatchUserUpdate is the state of a watched user sent to a stream. Initial marks the states
+// sent when the stream starts.
+type WatchUserUpdate struct {
+	*models.WatchedUser
+	Initial bool
+}
+
+// Validate validates the subscription and returns the distinct user IDs to watch
+func (req WatchUserReq) Validate(maxUsers int) ([]uuid.UUID, error) {
+	var verrs errs.ValidationErrors
+
+	verrs.Add("user_ids", validateBatchUserIDs(req.UserIDs, maxUsers))
+
+	ids := make([]uuid.UUID, 0, len(req.UserIDs))
+	seen := make(map[uuid.UUID]struct{}, len(req.UserIDs))
+	for _, userID := range req.UserIDs {
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			verrs.Add("user_ids", errs.ErrInvalidUserID)
+			break
+		}
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	if err := verrs.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestWatchUserReq_Validate(t *testing.T) {
+	id := uuid.New()
+
+	ids, err := WatchUserReq{UserIDs: []string{id.String(), id.String()}}.Validate(10)
+	if err != nil {
+		t.Fatalf("Expected repeated user ids to be valid, got %v", err)
+	}
+	if len(ids) != 1 || ids[0] != id {
+		t.Errorf("Expected the distinct user id, got %v", ids)
+	}
+
+	tests := []struct {
+		name     string
+		userIDs  []string
+		expected error
+	}{
+		{name: "no user ids", expected: errs.ErrUserIDsAreRequired},
+		{name: "too many user ids", userIDs: []string{id.String(), uuid.NewString(), uuid.NewString()}, expected: errs.ErrTooManyUserIDs},
+		{name: "malformed user id", userIDs: []string{id.String(), "42"}, expected: errs.ErrInvalidUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := (WatchUserReq{UserIDs: tt.userIDs}).Validate(2); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
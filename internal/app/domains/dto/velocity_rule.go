@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"regexp"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+// MaxVelocityWindow is the longest window a velocity rule can count attempts in
+const MaxVelocityWindow = 24 * time.Hour
+
+// velocityRuleNamePattern matches names such as login-ip-burst
+var velocityRuleNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// SetVelocityRuleReq creates a velocity rule or replaces the rule of the same name
+type SetVelocityRuleReq struct {
+	Name          string
+	Entity        string
+	Action        string
+	Threshold     int
+	WindowSeconds int64
+	Response      string
+	Enabled       bool
+}
+
+// Rule validates the request, reporting every invalid field at once, and returns the rule
+func (req SetVelocityRuleReq) Rule(now time.Time) (*models.VelocityRule, error) {
+	var verrs errs.ValidationErrors
+
+	if !velocityRuleNamePattern.MatchString(req.Name) {
+		verrs.Add("name", errs.ErrInvalidVelocityRuleName)
+	}
+	if !models.VelocityEntity(req.Entity).IsValid() {
+		verrs.Add("entity", errs.ErrInvalidVelocityEntity)
+	}
+	if !models.VelocityAction(req.Action).IsValid() {
+		verrs.Add("action", errs.ErrInvalidVelocityAction)
+	}
+	if req.Threshold <= 0 {
+		verrs.Add("threshold", errs.ErrInvalidVelocityLimit)
+	}
+	window := time.Duration(req.WindowSeconds) * time.Second
+	if req.WindowSeconds <= 0 || window > MaxVelocityWindow {
+		verrs.Add("window_seconds", errs.ErrInvalidVelocityWindow)
+	}
+	if !models.VelocityResponse(req.Response).IsValid() {
+		verrs.Add("response", errs.ErrInvalidVelocityResponse)
+	}
+	if err := verrs.Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.VelocityRule{
+		Name:      req.Name,
+		Entity:    models.VelocityEntity(req.Entity),
+		Action:    models.VelocityAction(req.Action),
+		Threshold: req.Threshold,
+		Window:    window,
+		Response:  models.VelocityResponse(req.Response),
+		Enabled:   req.Enabled,
+		CreatedAt: now.UnixMilli(),
+		UpdatedAt: now.UnixMilli(),
+	}, nil
+}
+
+// DeleteVelocityRuleReq deletes a velocity rule by name
+type DeleteVelocityRuleReq struct {
+	Name string
+}
+
+// Validate validates the rule name
+func (req DeleteVelocityRuleReq) Validate() error {
+	var verrs errs.ValidationErrors
+	if !velocityRuleNamePattern.MatchString(req.Name) {
+		verrs.Add("name", errs.ErrInvalidVelocityRuleName)
+	}
+	return verrs.Err()
+}
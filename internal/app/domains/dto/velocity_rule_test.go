@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+func TestSetVelocityRuleReq_Rule(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	valid := SetVelocityRuleReq{
+		Name:          "login-ip-burst",
+		Entity:        "ip",
+		Action:        "login",
+		Threshold:     20,
+		WindowSeconds: 60,
+		Response:      "captcha",
+		Enabled:       true,
+	}
+
+	rule, err := valid.Rule(now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rule.Window != time.Minute {
+		t.Errorf("Expected a one minute window, got %v", rule.Window)
+	}
+	if rule.Entity != models.VelocityEntityIP || rule.Response != models.VelocityResponseCaptcha {
+		t.Errorf("Expected an ip captcha rule, got %s %s", rule.Entity, rule.Response)
+	}
+	if rule.UpdatedAt != now.UnixMilli() {
+		t.Errorf("Expected UpdatedAt %d, got %d", now.UnixMilli(), rule.UpdatedAt)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *SetVelocityRuleReq)
+		want   error
+	}{
+		{name: "uppercase name", modify: func(req *SetVelocityRuleReq) { req.Name = "Login IP" }, want: errs.ErrInvalidVelocityRuleName},
+		{name: "unknown entity", modify: func(req *SetVelocityRuleReq) { req.Entity = "asn" }, want: errs.ErrInvalidVelocityEntity},
+		{name: "unknown action", modify: func(req *SetVelocityRuleReq) { req.Action = "refresh" }, want: errs.ErrInvalidVelocityAction},
+		{name: "zero threshold", modify: func(req *SetVelocityRuleReq) { req.Threshold = 0 }, want: errs.ErrInvalidVelocityLimit},
+		{name: "window too long", modify: func(req *SetVelocityRuleReq) { req.WindowSeconds = 86401 }, want: errs.ErrInvalidVelocityWindow},
+		{name: "unknown response", modify: func(req *SetVelocityRuleReq) { req.Response = "ban" }, want: errs.ErrInvalidVelocityResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			if _, err := req.Rule(now); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// DetailField is the detail key holding the name of the offending request field
+const DetailField = "field"
+
 // ErrorWrapper is a customizable error wrapper with rich metadata
 type ErrorWrapper struct {
 	Code       codes.Code
@@ -20,6 +23,7 @@ type ErrorWrapper struct {
 	Operation  string
 	Err        error
 	StackTrace string
+	Violations []FieldViolation
 }
 
 // Error implements the error interface
@@ -35,42 +39,87 @@ func (e *ErrorWrapper) Unwrap() error {
 	return e.Err
 }
 
-// GRPCStatus returns the gRPC status
+// GRPCStatus returns the gRPC status, including field violations as BadRequest details
 func (e *ErrorWrapper) GRPCStatus() *status.Status {
-	return status.New(e.Code, e.Message)
+	st := status.New(e.Code, e.Message)
+	if len(e.Violations) == 0 {
+		return st
+	}
+
+	withDetails, err := st.WithDetails(e.badRequest())
+	if err != nil {
+		return st
+	}
+	return withDetails
 }
 
-// WithDetail adds a key-value detail to the error
-func (e *ErrorWrapper) WithDetail(key string, value interface{}) *ErrorWrapper {
-	if e.Details == nil {
-		e.Details = make(map[string]interface{})
+// Is reports whether target is the same domain error. Errors derived from a
+// sentinel through the With* helpers or Wrap keep matching that sentinel, and
+// validation errors also match the errors behind each of their violations.
+func (e *ErrorWrapper) Is(target error) bool {
+	if t, ok := target.(*ErrorWrapper); ok && e.Code == t.Code && e.Message == t.Message {
+		return true
+	}
+	return e.matchesViolation(target)
+}
+
+// clone returns a shallow copy of the error with its own details map, so that
+// decorating a shared sentinel never mutates it
+func (e *ErrorWrapper) clone() *ErrorWrapper {
+	c := *e
+	c.Timestamp = time.Now()
+	c.Details = make(map[string]interface{}, len(e.Details))
+	for k, v := range e.Details {
+		c.Details[k] = v
 	}
-	e.Details[key] = value
-	return e
+	return &c
 }
 
-// WithRequestID adds a request ID to the error
+// Wrap returns a copy of the error wrapping the given cause
+func (e *ErrorWrapper) Wrap(err error) *ErrorWrapper {
+	c := e.clone()
+	c.Err = err
+	return c
+}
+
+// WithDetail returns a copy of the error with a key-value detail added
+func (e *ErrorWrapper) WithDetail(key string, value interface{}) *ErrorWrapper {
+	c := e.clone()
+	c.Details[key] = value
+	return c
+}
+
+// WithField returns a copy of the error annotated with the offending request field
+func (e *ErrorWrapper) WithField(field string) *ErrorWrapper {
+	return e.WithDetail(DetailField, field)
+}
+
+// WithRequestID returns a copy of the error with a request ID added
 func (e *ErrorWrapper) WithRequestID(requestID string) *ErrorWrapper {
-	e.RequestID = requestID
-	return e
+	c := e.clone()
+	c.RequestID = requestID
+	return c
 }
 
-// WithUserID adds a user ID to the error
+// WithUserID returns a copy of the error with a user ID added
 func (e *ErrorWrapper) WithUserID(userID string) *ErrorWrapper {
-	e.UserID = userID
-	return e
+	c := e.clone()
+	c.UserID = userID
+	return c
 }
 
-// WithOperation adds an operation name to the error
+// WithOperation returns a copy of the error with an operation name added
 func (e *ErrorWrapper) WithOperation(operation string) *ErrorWrapper {
-	e.Operation = operation
-	return e
+	c := e.clone()
+	c.Operation = operation
+	return c
 }
 
-// WithStackTrace adds stack trace information
+// WithStackTrace returns a copy of the error with stack trace information added
 func (e *ErrorWrapper) WithStackTrace(stackTrace string) *ErrorWrapper {
-	e.StackTrace = stackTrace
-	return e
+	c := e.clone()
+	c.StackTrace = stackTrace
+	return c
 }
 
 // GetDetail retrieves a detail value by key
@@ -115,6 +164,7 @@ var (
 	ErrInvalidPassword    = NewError(codes.InvalidArgument, "invalid password")
 	ErrUserNotFound       = NewError(codes.NotFound, "user not found")
 	ErrUserExists         = NewError(codes.AlreadyExists, "user already exists")
+	ErrInvalidUserID      = NewError(codes.InvalidArgument, "invalid user id")
 	ErrInvalidToken       = NewError(codes.InvalidArgument, "invalid token")
 	ErrTokenExpired       = NewError(codes.Unauthenticated, "token expired")
 	ErrTokenRevoked       = NewError(codes.Unauthenticated, "token revoked")
@@ -122,6 +172,204 @@ var (
 	ErrTokenIsRequired    = NewError(codes.InvalidArgument, "token is required")
 	ErrInvalidCredentials = NewError(codes.Unauthenticated, "invalid credentials")
 	ErrEmailIsRequired    = NewError(codes.InvalidArgument, "email is required")
+	ErrUsernameNotUnique  = NewError(codes.FailedPrecondition, "username belongs to more than one user")
+
+	ErrDatabaseUnavailable = NewError(codes.Unavailable, "database temporarily unavailable")
+	ErrDatabaseConflict    = NewError(codes.Aborted, "concurrent update conflict, please retry")
+
+	ErrQuotaExceeded      = NewError(codes.ResourceExhausted, "quota exceeded")
+	ErrAdmissionQueueFull = NewError(codes.ResourceExhausted, "too many requests are waiting, retry later")
+	ErrAdmissionTimeout   = NewError(codes.Unavailable, "request waited too long for admission, retry later")
+	ErrQuotaDisabled      = NewError(codes.FailedPrecondition, "quota accounting is disabled")
+	ErrInvalidAPIKey      = NewError(codes.Unauthenticated, "invalid API key")
+	ErrMissingCredentials = NewError(codes.Unauthenticated, "missing credentials")
+	ErrInvalidAccessToken = NewError(codes.Unauthenticated, "invalid access token")
+	ErrPermissionDenied   = NewError(codes.PermissionDenied, "permission denied")
+
+	ErrInvalidDPoPProof  = NewError(codes.Unauthenticated, "invalid DPoP proof")
+	ErrDPoPProofRequired = NewError(codes.Unauthenticated, "DPoP proof required")
+	ErrDPoPKeyMismatch   = NewError(codes.Unauthenticated, "DPoP proof key does not match the token binding")
+
+	ErrInvalidCSRFToken = NewError(codes.PermissionDenied, "missing or invalid CSRF token")
+
+	ErrClientIDIsRequired = NewError(codes.InvalidArgument, "client id is required")
+	ErrInvalidClientID    = NewError(codes.InvalidArgument, "client id is too long")
+	ErrClientNotFound     = NewError(codes.NotFound, "client not found")
+	ErrInvalidClient      = NewError(codes.Unauthenticated, "invalid client")
+	ErrGrantNotAllowed    = NewError(codes.PermissionDenied, "grant type not allowed for client")
+
+	ErrInvalidAdminKey     = NewError(codes.Unauthenticated, "invalid admin key")
+	ErrInvalidServiceKey   = NewError(codes.Unauthenticated, "invalid service key")
+	ErrMissingServiceScope = NewError(codes.PermissionDenied, "service key lacks the required scope")
+
+	ErrOrganizationNotFound       = NewError(codes.NotFound, "organization not found")
+	ErrOrganizationNameIsRequired = NewError(codes.InvalidArgument, "organization name is required")
+	ErrInvalidOrganizationID      = NewError(codes.InvalidArgument, "invalid organization id")
+	ErrInvalidEmailDomain         = NewError(codes.InvalidArgument, "invalid email domain")
+	ErrEmailDomainNotAllowed      = NewError(codes.PermissionDenied, "email domain is not allowed by the organization")
+	ErrBrandingNameTooLong        = NewError(codes.InvalidArgument, "branding name is too long")
+	ErrInvalidBrandingLogoURL     = NewError(codes.InvalidArgument, "branding logo url must be an absolute https url")
+	ErrInvalidBrandingColor       = NewError(codes.InvalidArgument, "branding color must be a hex color such as #1a73e8")
+
+	ErrPasswordSetupRequired     = NewError(codes.FailedPrecondition, "password setup required")
+	ErrInvalidPasswordSetup      = NewError(codes.InvalidArgument, "invalid or expired password setup token")
+	ErrDuplicateImportRow        = NewError(codes.AlreadyExists, "email appears earlier in the import")
+	ErrImportBatchIsEmpty        = NewError(codes.InvalidArgument, "import batch has no users")
+	ErrImportBatchTooLarge       = NewError(codes.InvalidArgument, "import batch has too many users")
+	ErrInvalidLegacyPasswordHash = NewError(codes.InvalidArgument, "legacy password hash must be a hex SHA-1 digest in a known format")
+
+	ErrTemplateNameIsRequired = NewError(codes.InvalidArgument, "template name is required")
+	ErrTemplateNotFound       = NewError(codes.NotFound, "email template not found")
+	ErrInvalidTemplateData    = NewError(codes.InvalidArgument, "invalid template data")
+
+	ErrPreferencesAreRequired     = NewError(codes.InvalidArgument, "at least one preference is required")
+	ErrInvalidNotificationChannel = NewError(codes.InvalidArgument, "invalid notification channel")
+	ErrUnknownNotificationEvent   = NewError(codes.InvalidArgument, "event type does not send notifications")
+
+	ErrInvalidStatsDays = NewError(codes.InvalidArgument, "days must be between 1 and 90")
+
+	ErrInvalidExportRange         = NewError(codes.InvalidArgument, "created_to must be after created_from")
+	ErrInvalidExportFormat        = NewError(codes.InvalidArgument, "format must be proto or ndjson")
+	ErrInvalidExportField         = NewError(codes.InvalidArgument, "unknown export field")
+	ErrInvalidExportRowsPerSecond = NewError(codes.InvalidArgument, "rows_per_second must not be negative")
+
+	ErrInvalidAuditExportRange = NewError(codes.InvalidArgument, "to must be after from")
+	ErrAuditExportRangeTooLong = NewError(codes.InvalidArgument, "audit export range is too long")
+	ErrNotOrganizationOwner    = NewError(codes.PermissionDenied, "caller is not an owner of the organization")
+
+	ErrUserUnderLegalHold        = NewError(codes.FailedPrecondition, "user is under legal hold")
+	ErrLegalHoldReasonIsRequired = NewError(codes.InvalidArgument, "legal hold reason is required")
+
+	ErrUserBanned            = NewError(codes.PermissionDenied, "user is banned")
+	ErrInvalidUserRole       = NewError(codes.InvalidArgument, "role must be customer, staff or admin")
+	ErrInvalidUserStatus     = NewError(codes.InvalidArgument, "status must be active or banned")
+	ErrUserIDsAreRequired    = NewError(codes.InvalidArgument, "at least one user id is required")
+	ErrTooManyUserIDs        = NewError(codes.InvalidArgument, "too many user ids")
+	ErrDuplicateBatchUserID  = NewError(codes.InvalidArgument, "user id appears earlier in the batch")
+	ErrBatchReasonIsRequired = NewError(codes.InvalidArgument, "reason is required")
+
+	ErrInvalidUserEventStream = NewError(codes.DataLoss, "user event stream is inconsistent")
+	ErrInvalidHistoryLimit    = NewError(codes.InvalidArgument, "limit must be between 0 and 1000")
+	ErrInvalidHistoryVersion  = NewError(codes.InvalidArgument, "after_version must not be negative")
+
+	ErrNoSecondarySigningKey = NewError(codes.FailedPrecondition, "no secondary signing key is configured")
+
+	ErrMetadataIsRequired    = NewError(codes.InvalidArgument, "at least one metadata key to set or remove is required")
+	ErrInvalidMetadataKey    = NewError(codes.InvalidArgument, "metadata keys must look like namespace.name")
+	ErrMetadataValueTooLarge = NewError(codes.InvalidArgument, "metadata value is too large")
+	ErrMetadataKeyConflict   = NewError(codes.InvalidArgument, "metadata key is both set and removed")
+	ErrMetadataLimitExceeded = NewError(codes.FailedPrecondition, "user metadata would exceed its size limits")
+
+	ErrAvatarsNotConfigured     = NewError(codes.FailedPrecondition, "avatar storage is not configured")
+	ErrUnsupportedAvatarType    = NewError(codes.InvalidArgument, "avatar content type is not supported")
+	ErrInvalidAvatarKey         = NewError(codes.InvalidArgument, "object key is not an avatar upload of the caller")
+	ErrAvatarNotUploaded        = NewError(codes.FailedPrecondition, "avatar has not been uploaded")
+	ErrAvatarTooLarge           = NewError(codes.InvalidArgument, "avatar is too large")
+	ErrAvatarStorageUnavailable = NewError(codes.Unavailable, "avatar storage temporarily unavailable")
+
+	ErrInvalidSnapshotPassphrase = NewError(codes.InvalidArgument, "passphrase must be at least 16 characters")
+	ErrInvalidSnapshot           = NewError(codes.InvalidArgument, "snapshot is corrupt, truncated or encrypted with another passphrase")
+	ErrSnapshotSchemaMismatch    = NewError(codes.FailedPrecondition, "snapshot was taken at another schema version")
+	ErrSnapshotTargetNotEmpty    = NewError(codes.FailedPrecondition, "snapshots can only be restored into a database without users")
+
+	ErrUserWatchDisabled = NewError(codes.FailedPrecondition, "user watch is disabled")
+
+	ErrInvalidCleanupBatchSize = NewError(codes.InvalidArgument, "batch_size must be between 0 and 10000")
+	ErrInvalidCleanupAge       = NewError(codes.InvalidArgument, "older_than_seconds must not be negative")
+	ErrInvalidCleanupMaxTokens = NewError(codes.InvalidArgument, "max_tokens must not be negative")
+
+	ErrInvalidPushDeviceID = NewError(codes.InvalidArgument, "device_id is required and must be at most 128 characters")
+	ErrInvalidPushPlatform = NewError(codes.InvalidArgument, "platform must be apns or fcm")
+	ErrInvalidPushToken    = NewError(codes.InvalidArgument, "token is required and must be at most 512 characters")
+
+	ErrOutsideLoginSchedule        = NewError(codes.PermissionDenied, "login is not allowed at this time")
+	ErrLoginScheduleNotFound       = NewError(codes.NotFound, "login schedule not found")
+	ErrInvalidLoginScheduleSubject = NewError(codes.InvalidArgument, "exactly one of user_id and role is required")
+	ErrInvalidTimezone             = NewError(codes.InvalidArgument, "timezone must be an IANA time zone, e.g. Europe/Berlin")
+	ErrInvalidLoginWindowCount     = NewError(codes.InvalidArgument, "a login schedule needs between 1 and 28 windows")
+	ErrInvalidLoginWindow          = NewError(codes.InvalidArgument, "login windows need days such as mon and different start and end times as HH:MM")
+
+	ErrGlobalLogoutNotConfirmed  = NewError(codes.InvalidArgument, `confirmation must be "log out every user"`)
+	ErrInvalidGlobalLogoutCutoff = NewError(codes.InvalidArgument, "cutoff must not be negative or in the future")
+
+	ErrSessionUsageNotFound      = NewError(codes.NotFound, "session usage not found")
+	ErrSessionIdleTimeout        = NewError(codes.Unauthenticated, "session ended after inactivity, log in again")
+	ErrInvalidSessionIdleTimeout = NewError(codes.InvalidArgument, "session idle timeout must be zero or at least the access token lifetime")
+
+	ErrInvalidPageSize  = NewError(codes.InvalidArgument, "page_size must be between 0 and 500")
+	ErrInvalidPageToken = NewError(codes.InvalidArgument, "page_token is invalid")
+
+	ErrTooManyAttempts         = NewError(codes.ResourceExhausted, "too many attempts, retry later")
+	ErrCaptchaRequired         = NewError(codes.FailedPrecondition, "captcha required")
+	ErrInvalidVelocityRuleName = NewError(codes.InvalidArgument, "name must be 1 to 64 lowercase letters, digits, dashes or underscores")
+	ErrInvalidVelocityEntity   = NewError(codes.InvalidArgument, "entity must be ip, email or device")
+	ErrInvalidVelocityAction   = NewError(codes.InvalidArgument, "action must be register or login")
+	ErrInvalidVelocityResponse = NewError(codes.InvalidArgument, "response must be alert, captcha or lock")
+	ErrInvalidVelocityLimit    = NewError(codes.InvalidArgument, "threshold must be positive")
+	ErrInvalidVelocityWindow   = NewError(codes.InvalidArgument, "window_seconds must be between 1 and 86400")
+
+	ErrCanaryNoteTooLong = NewError(codes.InvalidArgument, "note must be at most 500 characters")
+
+	ErrInvalidConsistencyToken = NewError(codes.InvalidArgument, "consistency token is invalid")
+
+	ErrPasswordTooLong = NewError(codes.InvalidArgument, "password must be at most 256 bytes")
+
+	ErrAccountPending              = NewError(codes.FailedPrecondition, "account is not activated yet, set a password with the invitation sent to your email")
+	ErrInvalidUserStatusTransition = NewError(codes.FailedPrecondition, "user status cannot change to the requested status")
+
+	ErrUnsupportedGrantType   = NewError(codes.InvalidArgument, "grant_type must be urn:ietf:params:oauth:grant-type:token-exchange")
+	ErrUnsupportedTokenType   = NewError(codes.InvalidArgument, "token type must be urn:ietf:params:oauth:token-type:access_token")
+	ErrSubjectTokenIsRequired = NewError(codes.InvalidArgument, "subject token is required")
+	ErrAudienceIsRequired     = NewError(codes.InvalidArgument, "audience is required")
+	ErrInvalidSubjectToken    = NewError(codes.InvalidArgument, "subject token is invalid, expired or revoked")
+	ErrAudienceNotAllowed     = NewError(codes.PermissionDenied, "caller may not obtain tokens for the audience")
+	ErrInvalidScope           = NewError(codes.InvalidArgument, "requested scope exceeds what the audience and subject token allow")
+	ErrDelegationTooDeep      = NewError(codes.FailedPrecondition, "delegation chain is too long")
+
+	ErrUnknownResidencyRegion     = NewError(codes.InvalidArgument, "unknown residency region")
+	ErrRegionalImportNotSupported = NewError(codes.FailedPrecondition, "users of regions with a database of their own cannot be imported")
+
+	ErrEmailVerificationRequired    = NewError(codes.FailedPrecondition, "registration requires verifying the email with StartRegistration")
+	ErrEmailOTPRegistrationDisabled = NewError(codes.FailedPrecondition, "registration with an emailed code is not enabled")
+	ErrRegistrationCodeIsRequired   = NewError(codes.InvalidArgument, "registration code is required")
+	ErrInvalidRegistrationCode      = NewError(codes.InvalidArgument, "invalid or expired registration code")
+	ErrRegistrationCodeRecentlySent = NewError(codes.ResourceExhausted, "a registration code was sent recently, retry later")
+
+	ErrEmailNotClaimed = NewError(codes.NotFound, "user did not verify owning the email")
+
+	ErrNoteBodyIsRequired    = NewError(codes.InvalidArgument, "note body is required")
+	ErrNoteBodyTooLong       = NewError(codes.InvalidArgument, "note body must be at most 2000 characters")
+	ErrNoteAuthorIsRequired  = NewError(codes.InvalidArgument, "note author is required")
+	ErrNoteAuthorTooLong     = NewError(codes.InvalidArgument, "note author must be at most 255 characters")
+	ErrInvalidNoteVisibility = NewError(codes.InvalidArgument, "visibility must be internal or disclosable")
+
+	ErrInvalidPasswordReset = NewError(codes.InvalidArgument, "invalid or expired password reset token")
+
+	ErrInvalidEmailVerification    = NewError(codes.InvalidArgument, "invalid or expired email verification token")
+	ErrEmailAlreadyVerified        = NewError(codes.FailedPrecondition, "email is already verified")
+	ErrVerificationEmailThrottled  = NewError(codes.ResourceExhausted, "too many verification emails were requested, retry later")
+	ErrEmailReverificationRequired = NewError(codes.FailedPrecondition, "verify the email again with the emailed link to log in after a long absence")
+
+	ErrEmailNotAllowlisted   = NewError(codes.PermissionDenied, "registration is limited to allowlisted emails")
+	ErrInviteCodeRequired    = NewError(codes.FailedPrecondition, "registration requires an invite code")
+	ErrInvalidInviteCode     = NewError(codes.InvalidArgument, "invalid invite code")
+	ErrDailySignupCapReached = NewError(codes.ResourceExhausted, "the daily sign-up cap is reached, retry tomorrow")
+	ErrInvalidAllowlistEntry = NewError(codes.InvalidArgument, "allowlist entries must be email addresses or domains")
+	ErrInvalidInviteCodes    = NewError(codes.InvalidArgument, "invite codes must be 4 to 64 letters, digits, dashes or underscores")
+	ErrInvalidDailySignupCap = NewError(codes.InvalidArgument, "daily sign-up cap must not be negative")
+
+	ErrInviteCodeExhausted    = NewError(codes.ResourceExhausted, "invite code has no redemptions left")
+	ErrSelfReferral           = NewError(codes.PermissionDenied, "invite codes cannot be redeemed by their owner")
+	ErrInviteCodeLimitReached = NewError(codes.ResourceExhausted, "invite code limit reached")
+	ErrInvalidMaxRedemptions  = NewError(codes.InvalidArgument, "max redemptions must not be negative or above the limit")
+
+	ErrIdentityProviderIsRequired  = NewError(codes.InvalidArgument, "identity provider is required")
+	ErrIDTokenIsRequired           = NewError(codes.InvalidArgument, "ID token is required")
+	ErrUnknownIdentityProvider     = NewError(codes.InvalidArgument, "identity provider is not configured")
+	ErrInvalidIDToken              = NewError(codes.Unauthenticated, "invalid or expired ID token")
+	ErrIdentityProviderUnavailable = NewError(codes.Unavailable, "identity provider is unavailable, retry later")
+	ErrIdentityNotLinked           = NewError(codes.FailedPrecondition, "no account is linked to the identity")
+	ErrIdentityNotFound            = NewError(codes.NotFound, "identity not found")
 )
 
 // Legacy error variables for backward compatibility
@@ -140,14 +388,38 @@ var (
 	ErrEmailIsRequiredLegacy    = errors.New("email is required")
 )
 
+// As finds the first ErrorWrapper in err's chain
+func As(err error) (*ErrorWrapper, bool) {
+	var wrapper *ErrorWrapper
+	if errors.As(err, &wrapper) {
+		return wrapper, true
+	}
+	return nil, false
+}
+
+// Code returns the gRPC code of the first ErrorWrapper in err's chain,
+// codes.OK for nil and codes.Internal for errors outside the taxonomy
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if wrapper, ok := As(err); ok {
+		return wrapper.Code
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Code()
+	}
+	return codes.Internal
+}
+
 // ToGRPCError converts any error to a gRPC error
 func ToGRPCError(err error) error {
 	if err == nil {
 		return nil
 	}
 
-	// Check if it's already an error wrapper with gRPC status
-	if wrapper, ok := err.(*ErrorWrapper); ok {
+	// Domain errors anywhere in the chain carry their own gRPC status
+	if wrapper, ok := As(err); ok {
 		return wrapper.GRPCStatus().Err()
 	}
 
@@ -157,15 +429,17 @@ func ToGRPCError(err error) error {
 	}
 
 	// Map common errors to appropriate gRPC status codes
-	switch err {
-	case ErrInvalidEmailLegacy, ErrInvalidUsernameLegacy, ErrInvalidPasswordLegacy,
-		ErrInvalidTokenLegacy, ErrTokenIsRequiredLegacy, ErrEmailIsRequiredLegacy:
+	switch {
+	case errors.Is(err, ErrInvalidEmailLegacy), errors.Is(err, ErrInvalidUsernameLegacy),
+		errors.Is(err, ErrInvalidPasswordLegacy), errors.Is(err, ErrInvalidTokenLegacy),
+		errors.Is(err, ErrTokenIsRequiredLegacy), errors.Is(err, ErrEmailIsRequiredLegacy):
 		return status.Error(codes.InvalidArgument, err.Error())
-	case ErrUserNotFoundLegacy, ErrTokenNotFoundLegacy:
+	case errors.Is(err, ErrUserNotFoundLegacy), errors.Is(err, ErrTokenNotFoundLegacy):
 		return status.Error(codes.NotFound, err.Error())
-	case ErrUserExistsLegacy:
+	case errors.Is(err, ErrUserExistsLegacy):
 		return status.Error(codes.AlreadyExists, err.Error())
-	case ErrTokenExpiredLegacy, ErrTokenRevokedLegacy, ErrInvalidCredentialsLegacy:
+	case errors.Is(err, ErrTokenExpiredLegacy), errors.Is(err, ErrTokenRevokedLegacy),
+		errors.Is(err, ErrInvalidCredentialsLegacy):
 		return status.Error(codes.Unauthenticated, err.Error())
 	default:
 		// For unknown errors, return internal error
@@ -1,6 +1,8 @@
 package errs
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -221,3 +223,95 @@ func TestPredefinedErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorWrapper_IsThroughWrapping(t *testing.T) {
+	wrapped := fmt.Errorf("repository: %w", ErrUserNotFound.WithOperation("GetByID"))
+
+	if !errors.Is(wrapped, ErrUserNotFound) {
+		t.Error("Expected wrapped error to match ErrUserNotFound")
+	}
+
+	if errors.Is(wrapped, ErrTokenNotFound) {
+		t.Error("Expected wrapped error not to match ErrTokenNotFound")
+	}
+
+	wrapper, ok := As(wrapped)
+	if !ok {
+		t.Fatal("Expected As to find the ErrorWrapper")
+	}
+
+	if wrapper.Operation != "GetByID" {
+		t.Errorf("Expected Operation 'GetByID', got '%s'", wrapper.Operation)
+	}
+}
+
+func TestErrorWrapper_WithHelpersDoNotMutateSentinel(t *testing.T) {
+	_ = ErrInvalidEmail.WithDetail("provided_email", "bad").WithUserID("user-1")
+
+	if len(ErrInvalidEmail.GetDetails()) != 0 {
+		t.Errorf("Expected sentinel details to stay empty, got %v", ErrInvalidEmail.GetDetails())
+	}
+
+	if ErrInvalidEmail.UserID != "" {
+		t.Errorf("Expected sentinel UserID to stay empty, got '%s'", ErrInvalidEmail.UserID)
+	}
+}
+
+func TestErrorWrapper_Wrap(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := ErrDatabaseUnavailable.Wrap(cause)
+
+	if !errors.Is(err, ErrDatabaseUnavailable) {
+		t.Error("Expected wrapped error to match its sentinel")
+	}
+
+	if !errors.Is(err, cause) {
+		t.Error("Expected wrapped error to match its cause")
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{"Nil", nil, codes.OK},
+		{"Wrapper", ErrUserExists, codes.AlreadyExists},
+		{"WrappedWrapper", fmt.Errorf("create: %w", ErrUserExists), codes.AlreadyExists},
+		{"Status", status.Error(codes.Unavailable, "down"), codes.Unavailable},
+		{"Unknown", errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(tt.err); got != tt.expected {
+				t.Errorf("Expected code %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestToGRPCError_WrappedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{"WrappedDomainError", fmt.Errorf("failed to get user: %w", ErrUserNotFound), codes.NotFound},
+		{"WrappedLegacyError", fmt.Errorf("failed to get token: %w", ErrTokenNotFoundLegacy), codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(ToGRPCError(tt.err))
+			if !ok {
+				t.Fatal("Expected gRPC status error")
+			}
+
+			if st.Code() != tt.expected {
+				t.Errorf("Expected code %v, got %v", tt.expected, st.Code())
+			}
+		})
+	}
+}
@@ -110,7 +110,7 @@ func ExampleHandlerMethod(ctx context.Context, req interface{}) (interface{}, er
 func ExampleErrorRecovery(err error) {
 	fmt.Println("\n=== Error Recovery Example ===")
 
-	if wrapper, ok := err.(*ErrorWrapper); ok {
+	if wrapper, ok := As(err); ok {
 		fmt.Printf("Error Code: %s\n", wrapper.Code)
 		fmt.Printf("Error Message: %s\n", wrapper.Message)
 		fmt.Printf("Timestamp: %s\n", wrapper.Timestamp)
@@ -0,0 +1,86 @@
+package errs
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// ErrValidationFailed is returned when a request has one or more invalid fields
+var ErrValidationFailed = NewError(codes.InvalidArgument, "validation failed")
+
+// FieldViolation describes a single invalid request field
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+	Err         error  `json:"-"`
+}
+
+// ValidationErrors collects field violations so that every problem with a
+// request can be reported at once instead of stopping at the first one
+type ValidationErrors struct {
+	violations []FieldViolation
+}
+
+// Add records a violation for field if err is not nil
+func (v *ValidationErrors) Add(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	description := err.Error()
+	if wrapper, ok := As(err); ok {
+		description = wrapper.Message
+	}
+
+	v.violations = append(v.violations, FieldViolation{
+		Field:       field,
+		Description: description,
+		Err:         err,
+	})
+}
+
+// Err returns nil if no violations were recorded, otherwise a single
+// ErrValidationFailed carrying all of them
+func (v *ValidationErrors) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	return ErrValidationFailed.WithViolations(v.violations...)
+}
+
+// WithViolations returns a copy of the error carrying the given field violations
+func (e *ErrorWrapper) WithViolations(violations ...FieldViolation) *ErrorWrapper {
+	c := e.clone()
+	c.Violations = append(append([]FieldViolation(nil), e.Violations...), violations...)
+	return c
+}
+
+// GetViolations returns the field violations attached to the error
+func (e *ErrorWrapper) GetViolations() []FieldViolation {
+	return e.Violations
+}
+
+// matchesViolation reports whether any violation was caused by target
+func (e *ErrorWrapper) matchesViolation(target error) bool {
+	for _, violation := range e.Violations {
+		if violation.Err != nil && errors.Is(violation.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// badRequest renders the violations as a google.rpc.BadRequest detail
+func (e *ErrorWrapper) badRequest() *errdetails.BadRequest {
+	br := &errdetails.BadRequest{}
+	for _, violation := range e.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+	return br
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+)
+
+// CanarySeverity is the severity of every canary alert: a canary account is never used
+// legitimately, so an access to one means its planted credentials or tokens leaked
+const CanarySeverity = "critical"
+
+// CanaryAccountAccessedEvent is published for security monitoring when a canary account is
+// accessed, see models.CanaryAccount
+type CanaryAccountAccessedEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	Severity      string        `json:"severity"`
+	UserID        string        `json:"userId"`
+	// Kind is login, refresh_token or access_token
+	Kind          string `json:"kind"`
+	ClientID      string `json:"clientId,omitempty"`
+	PasswordValid bool   `json:"passwordValid"`
+	IPAddress     string `json:"ipAddress,omitempty"`
+	UserAgent     string `json:"userAgent,omitempty"`
+	Note          string `json:"note"`
+	// AccessedAt is a Unix timestamp in milliseconds
+	AccessedAt int64 `json:"accessedAt"`
+}
+
+func (e *CanaryAccountAccessedEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(CanaryAccountAccessedEventType), payload), nil
+}
@@ -0,0 +1,27 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+)
+
+// ClientAccessRevokedEvent is published when a user revokes the access of a connected app, so
+// the app's backend can drop what it holds for the user, e.g. cached profile data
+type ClientAccessRevokedEvent struct {
+	EventMetadata        EventMetadata `json:"eventMetadata"`
+	UserID               string        `json:"userId"`
+	ClientID             string        `json:"clientId"`
+	RevokedRefreshTokens int64         `json:"revokedRefreshTokens"`
+	// RevokedAt is a Unix timestamp in milliseconds
+	RevokedAt int64 `json:"revokedAt"`
+}
+
+func (e *ClientAccessRevokedEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(ClientAccessRevokedEventType), payload), nil
+}
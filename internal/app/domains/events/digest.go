@@ -0,0 +1,31 @@
+package events
+
+import (
+	"encoding/json"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/email"
+
+	"github.com/hibiken/asynq"
+)
+
+// SecurityDigestEvent is published once per month for every user with account activity,
+// for the email service to send the security digest
+type SecurityDigestEvent struct {
+	EventMetadata EventMetadata           `json:"eventMetadata"`
+	UserID        string                  `json:"userId"`
+	Email         string                  `json:"email"`
+	Username      string                  `json:"username"`
+	Activity      *models.ActivitySummary `json:"activity"`
+	// Message is the rendered digest email, absent when it could not be rendered
+	Message *email.Message `json:"message,omitempty"`
+}
+
+func (e *SecurityDigestEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(SecurityDigestEventType), payload), nil
+}
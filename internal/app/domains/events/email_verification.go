@@ -0,0 +1,32 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"user-svc/pkg/utils/email"
+
+	"github.com/hibiken/asynq"
+)
+
+// EmailVerificationRequestedEvent is published when a user asks for a link verifying the
+// email of the account, for the email service to send it
+type EmailVerificationRequestedEvent struct {
+	EventMetadata   EventMetadata `json:"eventMetadata"`
+	UserID          string        `json:"userId"`
+	Email           string        `json:"email"`
+	Username        string        `json:"username"`
+	VerificationURL string        `json:"verificationUrl"`
+	ExpiresAt       time.Time     `json:"expiresAt"`
+	// Message is the rendered verification email, absent when it could not be rendered
+	Message *email.Message `json:"message,omitempty"`
+}
+
+func (e *EmailVerificationRequestedEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(EmailVerificationRequestedEventType), payload), nil
+}
@@ -9,7 +9,20 @@ type EventMetadata struct {
 type EventType string
 
 const (
-	OrderCreatedEventType       EventType = "order_created"
-	OrderCreatedFailedEventType EventType = "order_created_failed"
-	LoginEventType              EventType = "login"
+	OrderCreatedEventType               EventType = "order_created"
+	OrderCreatedFailedEventType         EventType = "order_created_failed"
+	LoginEventType                      EventType = "login"
+	QuotaThresholdEventType             EventType = "quota_threshold_reached"
+	SecurityDigestEventType             EventType = "security_digest"
+	UserInvitedEventType                EventType = "user_invited"
+	PushTokenRegisteredEventType        EventType = "push_token_registered"
+	PushTokenUnregisteredEventType      EventType = "push_token_unregistered"
+	SessionAnomalyDetectedEventType     EventType = "session_anomaly_detected"
+	ClientAccessRevokedEventType        EventType = "client_access_revoked"
+	CanaryAccountAccessedEventType      EventType = "canary_account_accessed"
+	RegistrationCodeSentEventType       EventType = "registration_code_sent"
+	PasswordResetRequestedEventType     EventType = "password_reset_requested"
+	EmailVerificationRequestedEventType EventType = "email_verification_requested"
+	ReferralRedeemedEventType           EventType = "referral_redeemed"
+	UserInactiveEventType               EventType = "user.inactive"
 )
@@ -0,0 +1,33 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"user-svc/pkg/utils/email"
+
+	"github.com/hibiken/asynq"
+)
+
+// UserInvitedEvent is published for every imported user, for the email service to send the
+// link the user sets a password with
+type UserInvitedEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	UserID        string        `json:"userId"`
+	Email         string        `json:"email"`
+	Username      string        `json:"username"`
+	SetupURL      string        `json:"setupUrl"`
+	ExpiresAt     time.Time     `json:"expiresAt"`
+	Reminder      bool          `json:"reminder,omitempty"`
+	// Message is the rendered invitation email, absent when it could not be rendered
+	Message *email.Message `json:"message,omitempty"`
+}
+
+func (e *UserInvitedEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(UserInvitedEventType), payload), nil
+}
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"user-svc/pkg/utils/email"
+
+	"github.com/hibiken/asynq"
+)
+
+// PasswordResetRequestedEvent is published when a user asks to reset a forgotten password,
+// for the email service to send the link the user chooses a new password with
+type PasswordResetRequestedEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	UserID        string        `json:"userId"`
+	Email         string        `json:"email"`
+	Username      string        `json:"username"`
+	ResetURL      string        `json:"resetUrl"`
+	ExpiresAt     time.Time     `json:"expiresAt"`
+	// Message is the rendered reset email, absent when it could not be rendered
+	Message *email.Message `json:"message,omitempty"`
+}
+
+func (e *PasswordResetRequestedEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(PasswordResetRequestedEventType), payload), nil
+}
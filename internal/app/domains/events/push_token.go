@@ -0,0 +1,53 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+)
+
+// PushTokenRegisteredEvent is published when a device registers a push token, for
+// notification-svc to send the user's push notifications to it. Registration and removal
+// events may arrive out of order, so consumers keep the latest change of each token by
+// ChangedAt.
+type PushTokenRegisteredEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	UserID        string        `json:"userId"`
+	DeviceID      string        `json:"deviceId"`
+	Platform      string        `json:"platform"`
+	Token         string        `json:"token"`
+	// ChangedAt is a Unix timestamp in milliseconds
+	ChangedAt int64 `json:"changedAt"`
+}
+
+func (e *PushTokenRegisteredEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(PushTokenRegisteredEventType), payload), nil
+}
+
+// PushTokenUnregisteredEvent is published when a push token is removed, for notification-svc
+// to stop sending to it
+type PushTokenUnregisteredEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	UserID        string        `json:"userId"`
+	DeviceID      string        `json:"deviceId"`
+	Platform      string        `json:"platform"`
+	Token         string        `json:"token"`
+	// Reason is unregistered, reassigned or sessions_revoked
+	Reason string `json:"reason"`
+	// ChangedAt is a Unix timestamp in milliseconds
+	ChangedAt int64 `json:"changedAt"`
+}
+
+func (e *PushTokenUnregisteredEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(PushTokenUnregisteredEventType), payload), nil
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+)
+
+// QuotaThresholdEvent is published when a subject's usage crosses a billing threshold
+type QuotaThresholdEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	Subject       string        `json:"subject"`
+	Method        string        `json:"method"`
+	Used          int64         `json:"used"`
+	Limit         int64         `json:"limit"`
+	Threshold     int           `json:"threshold"`
+	WindowStart   int64         `json:"windowStart"`
+	WindowEnd     int64         `json:"windowEnd"`
+}
+
+func (e *QuotaThresholdEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(QuotaThresholdEventType), payload), nil
+}
@@ -0,0 +1,27 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+)
+
+// ReferralRedeemedEvent is published when a user registers with the invite code of another,
+// so the rewards engine can credit the referrer
+type ReferralRedeemedEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	ReferrerID    string        `json:"referrerId"`
+	RefereeID     string        `json:"refereeId"`
+	InviteCode    string        `json:"inviteCode"`
+	// RedeemedAt is a Unix timestamp in milliseconds
+	RedeemedAt int64 `json:"redeemedAt"`
+}
+
+func (e *ReferralRedeemedEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(ReferralRedeemedEventType), payload), nil
+}
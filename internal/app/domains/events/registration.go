@@ -0,0 +1,30 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"user-svc/pkg/utils/email"
+
+	"github.com/hibiken/asynq"
+)
+
+// RegistrationCodeSentEvent is published when a registration is started in the email_otp
+// registration mode, for the email service to send the code verifying the email
+type RegistrationCodeSentEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	Email         string        `json:"email"`
+	Code          string        `json:"code"`
+	ExpiresAt     time.Time     `json:"expiresAt"`
+	// Message is the rendered code email, absent when it could not be rendered
+	Message *email.Message `json:"message,omitempty"`
+}
+
+func (e *RegistrationCodeSentEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(RegistrationCodeSentEventType), payload), nil
+}
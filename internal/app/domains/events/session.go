@@ -0,0 +1,35 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+)
+
+// SessionAnomalyEvent is published when the refreshes of a session look suspicious, e.g. a
+// refresh from another country minutes after the previous one, for the risk engine and
+// security monitoring
+type SessionAnomalyEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	UserID        string        `json:"userId"`
+	SessionID     string        `json:"sessionId"`
+	ClientID      string        `json:"clientId"`
+	// Kind is country_change or refresh_rate
+	Kind            string `json:"kind"`
+	IPAddress       string `json:"ipAddress"`
+	Country         string `json:"country"`
+	PreviousCountry string `json:"previousCountry,omitempty"`
+	// Refreshes is the number of refreshes in the current rate window
+	Refreshes int64 `json:"refreshes"`
+	// DetectedAt is a Unix timestamp in milliseconds
+	DetectedAt int64 `json:"detectedAt"`
+}
+
+func (e *SessionAnomalyEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(SessionAnomalyDetectedEventType), payload), nil
+}
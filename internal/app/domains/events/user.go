@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"time"
 
+	"user-svc/pkg/utils/email"
+
 	"github.com/hibiken/asynq"
 )
 
@@ -13,6 +15,10 @@ type LoginEvent struct {
 	Email         string        `json:"email"`
 	Username      string        `json:"username"`
 	LoginAt       time.Time     `json:"loginAt"`
+	// Residency is the region the user's data is stored in, for consumers to keep it there
+	Residency string `json:"residency,omitempty"`
+	// Message is the rendered notification email, absent when it could not be rendered
+	Message *email.Message `json:"message,omitempty"`
 }
 
 func (e *LoginEvent) ToTask() (*asynq.Task, error) {
@@ -0,0 +1,31 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+)
+
+// UserInactiveEvent is published when a customer who has not logged in for
+// inactivity.after is flagged inactive, so the marketing pipeline can try to re-engage them
+type UserInactiveEvent struct {
+	EventMetadata EventMetadata `json:"eventMetadata"`
+	UserID        string        `json:"userId"`
+	Email         string        `json:"email"`
+	Username      string        `json:"username"`
+	// LastSeenAt is the last login, or the registration of users who never logged in, as a
+	// Unix timestamp in milliseconds
+	LastSeenAt int64 `json:"lastSeenAt"`
+	// InactiveSince is a Unix timestamp in milliseconds
+	InactiveSince int64  `json:"inactiveSince"`
+	Residency     string `json:"residency,omitempty"`
+}
+
+func (e *UserInactiveEvent) ToTask() (*asynq.Task, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(string(UserInactiveEventType), payload), nil
+}
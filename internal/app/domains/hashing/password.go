@@ -0,0 +1,39 @@
+package hashing
+
+import (
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/password"
+)
+
+// PasswordHasher hashes passwords with bcrypt at the configured cost over their HMAC-SHA384
+// keyed with the pepper, or their SHA-384 without one. Hashes of a lower cost, without the
+// current pepper or imported from the legacy system are rehashed on the next login.
+type PasswordHasher struct {
+	hasher *password.Hasher
+}
+
+// NewPasswordHasher creates a hasher of the given bcrypt cost. New hashes are keyed with the
+// first of the peppers, if any, while hashes keyed with the others still verify.
+func NewPasswordHasher(cost int, peppers ...string) *PasswordHasher {
+	return &PasswordHasher{hasher: password.NewHasher(cost, peppers...)}
+}
+
+func (h *PasswordHasher) Hash(plainPassword string) (models.PasswordHash, error) {
+	hash, err := h.hasher.HashPassword(plainPassword)
+	if err != nil {
+		return "", err
+	}
+	return models.PasswordHash(hash), nil
+}
+
+func (h *PasswordHasher) Verify(hash models.PasswordHash, plainPassword string) bool {
+	return h.hasher.VerifyPassword(hash.String(), plainPassword)
+}
+
+func (h *PasswordHasher) NeedsRehash(hash models.PasswordHash) bool {
+	return h.hasher.NeedsRehash(hash.String())
+}
+
+func (h *PasswordHasher) Scheme(hash models.PasswordHash) string {
+	return password.Scheme(hash.String())
+}
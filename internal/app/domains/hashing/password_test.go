@@ -0,0 +1,94 @@
+package hashing
+
+import (
+	"testing"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/password"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	testPepper     = "pepper-0123456789abcdef0123456789ab"
+	previousPepper = "previous-pepper-0123456789abcdef0123"
+)
+
+func TestPasswordHasher_HashAndVerify(t *testing.T) {
+	hasher := NewPasswordHasher(bcrypt.MinCost)
+
+	hash, err := hasher.Hash("testPassword123!")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	if err := hash.Validate(); err != nil {
+		t.Errorf("Expected a valid hash, got %v", err)
+	}
+	if !hasher.Verify(hash, "testPassword123!") {
+		t.Error("Expected the password to verify")
+	}
+	if hasher.Verify(hash, "wrongPassword") {
+		t.Error("Expected another password not to verify")
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Error("Expected a new hash not to need a rehash")
+	}
+	if scheme := hasher.Scheme(hash); scheme != password.SchemePreHashedBcrypt {
+		t.Errorf("Expected scheme %s, got %s", password.SchemePreHashedBcrypt, scheme)
+	}
+}
+
+func TestPasswordHasher_Rehash(t *testing.T) {
+	cheap, err := NewPasswordHasher(bcrypt.MinCost).Hash("testPassword123!")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	oldPeppered, err := NewPasswordHasher(bcrypt.MinCost, previousPepper).Hash("testPassword123!")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	legacy, err := models.NewLegacyPasswordHash(password.LegacySHA1PasswordSalt, "salt",
+		"3a7c1e5b6c0b9c3b0d0c3c1d56f1d87a4b0b8e55")
+	if err != nil {
+		t.Fatalf("Failed to create legacy hash: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		hasher   *PasswordHasher
+		hash     models.PasswordHash
+		verifies bool
+		rehash   bool
+	}{
+		{name: "raised cost", hasher: NewPasswordHasher(bcrypt.MinCost + 1), hash: cheap, verifies: true, rehash: true},
+		{name: "pepper introduced", hasher: NewPasswordHasher(bcrypt.MinCost, testPepper), hash: cheap, verifies: true, rehash: true},
+		{name: "pepper replaced", hasher: NewPasswordHasher(bcrypt.MinCost, testPepper, previousPepper), hash: oldPeppered, verifies: true, rehash: true},
+		{name: "pepper current", hasher: NewPasswordHasher(bcrypt.MinCost, previousPepper), hash: oldPeppered, verifies: true},
+		{name: "pepper dropped", hasher: NewPasswordHasher(bcrypt.MinCost, testPepper), hash: oldPeppered, rehash: true},
+		{name: "legacy", hasher: NewPasswordHasher(bcrypt.MinCost), hash: legacy, rehash: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.Verify(tt.hash, "testPassword123!"); got != tt.verifies {
+				t.Errorf("Expected Verify %v, got %v", tt.verifies, got)
+			}
+			if got := tt.hasher.NeedsRehash(tt.hash); got != tt.rehash {
+				t.Errorf("Expected NeedsRehash %v, got %v", tt.rehash, got)
+			}
+		})
+	}
+
+	// The rehash of a verified password is a current hash
+	hasher := NewPasswordHasher(bcrypt.MinCost+1, testPepper)
+	rehashed, err := hasher.Hash("testPassword123!")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	if !hasher.Verify(rehashed, "testPassword123!") || hasher.NeedsRehash(rehashed) {
+		t.Error("Expected the rehashed password to verify without a further rehash")
+	}
+	if scheme := hasher.Scheme(rehashed); scheme != password.SchemePepperedBcrypt {
+		t.Errorf("Expected scheme %s, got %s", password.SchemePepperedBcrypt, scheme)
+	}
+}
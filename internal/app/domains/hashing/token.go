@@ -0,0 +1,19 @@
+package hashing
+
+import (
+	"user-svc/pkg/utils/crypt/token"
+)
+
+// TokenHasher hashes stored secrets with SHA-256. The tokens are random and registration
+// codes expire after a few attempts, so a fast unsalted hash suffices and lets them be
+// looked up by their hash.
+type TokenHasher struct{}
+
+// NewTokenHasher creates a SHA-256 token hasher
+func NewTokenHasher() *TokenHasher {
+	return &TokenHasher{}
+}
+
+func (h *TokenHasher) Hash(secret string) string {
+	return token.HashToken(secret)
+}
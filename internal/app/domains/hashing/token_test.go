@@ -0,0 +1,43 @@
+package hashing
+
+import (
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+func TestTokenHasher_Hash(t *testing.T) {
+	hasher := NewTokenHasher()
+
+	hash := hasher.Hash("reset-token")
+	if len(hash) != 64 || hash != hasher.Hash("reset-token") {
+		t.Errorf("Expected a stable hex SHA-256, got %q", hash)
+	}
+	if hasher.Hash("other-token") == hash {
+		t.Error("Expected distinct tokens to hash differently")
+	}
+}
+
+func TestTokenHasher_StoredSecrets(t *testing.T) {
+	hasher := NewTokenHasher()
+
+	resetToken, record, err := models.NewPasswordResetToken(uuid.New(), 0, time.Now(), hasher)
+	if err != nil {
+		t.Fatalf("Failed to create reset token: %v", err)
+	}
+	if record.TokenHash != hasher.Hash(resetToken) || record.TokenHash == resetToken {
+		t.Error("Expected only the hash of the reset token to be stored")
+	}
+
+	email, _ := models.NewEmail("jane@tickets.example")
+	code, registration, err := models.NewRegistrationCode(email, 0, time.Now(), hasher)
+	if err != nil {
+		t.Fatalf("Failed to create registration code: %v", err)
+	}
+	if !registration.Matches(code, hasher) {
+		t.Error("Expected the registration code to match its record")
+	}
+}
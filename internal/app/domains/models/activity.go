@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActivityWindow is the period covered by an account activity summary
+const ActivityWindow = 30 * 24 * time.Hour
+
+// Audit metadata keys describing the device an action was performed from
+const (
+	AuditMetadataUserAgent = "user_agent"
+	AuditMetadataIPAddress = "ip_address"
+)
+
+// unknownDevice groups logins that did not report a user agent
+const unknownDevice = "unknown"
+
+// DeviceActivity summarizes the logins from a single device, identified by its user agent
+type DeviceActivity struct {
+	UserAgent  string `json:"userAgent"`
+	IPAddress  string `json:"ipAddress"`
+	Logins     int64  `json:"logins"`
+	LastSeenAt int64  `json:"lastSeenAt"`
+}
+
+// ActivitySummary summarizes the security-relevant activity of an account in [From, To)
+type ActivitySummary struct {
+	UserID           uuid.UUID         `json:"userId"`
+	From             int64             `json:"from"`
+	To               int64             `json:"to"`
+	Logins           int64             `json:"logins"`
+	LastLoginAt      int64             `json:"lastLoginAt"`
+	Devices          []*DeviceActivity `json:"devices"`
+	PasswordChanges  int64             `json:"passwordChanges"`
+	EmailChanges     int64             `json:"emailChanges"`
+	TokenRevocations int64             `json:"tokenRevocations"`
+}
+
+// HasActivity reports whether anything happened on the account during the period
+func (s *ActivitySummary) HasActivity() bool {
+	return s.Logins > 0 || s.PasswordChanges > 0 || s.EmailChanges > 0 || s.TokenRevocations > 0
+}
+
+// SummarizeActivity builds the activity summary of a user from their audit log entries.
+// Entries outside [from, to) are ignored. Devices are ordered by most recent use.
+func SummarizeActivity(userID uuid.UUID, from, to int64, entries []*AuditLog) *ActivitySummary {
+	summary := &ActivitySummary{
+		UserID:  userID,
+		From:    from,
+		To:      to,
+		Devices: make([]*DeviceActivity, 0),
+	}
+
+	devices := make(map[string]*DeviceActivity)
+	for _, entry := range entries {
+		if entry.CreatedAt < from || entry.CreatedAt >= to {
+			continue
+		}
+
+		switch entry.Action {
+		case AuditActionUserLoggedIn:
+			summary.Logins++
+			summary.LastLoginAt = max(summary.LastLoginAt, entry.CreatedAt)
+
+			var metadata map[string]interface{}
+			_ = json.Unmarshal(entry.Metadata, &metadata)
+
+			userAgent, _ := metadata[AuditMetadataUserAgent].(string)
+			if userAgent == "" {
+				userAgent = unknownDevice
+			}
+
+			device, ok := devices[userAgent]
+			if !ok {
+				device = &DeviceActivity{UserAgent: userAgent}
+				devices[userAgent] = device
+				summary.Devices = append(summary.Devices, device)
+			}
+			device.Logins++
+			if entry.CreatedAt >= device.LastSeenAt {
+				device.LastSeenAt = entry.CreatedAt
+				device.IPAddress, _ = metadata[AuditMetadataIPAddress].(string)
+			}
+		case AuditActionPasswordChanged:
+			summary.PasswordChanges++
+		case AuditActionEmailChanged:
+			summary.EmailChanges++
+		case AuditActionTokensRevoked:
+			summary.TokenRevocations++
+		}
+	}
+
+	sort.SliceStable(summary.Devices, func(i, j int) bool {
+		return summary.Devices[i].LastSeenAt > summary.Devices[j].LastSeenAt
+	})
+
+	return summary
+}
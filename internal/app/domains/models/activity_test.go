@@ -0,0 +1,74 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func auditEntry(t *testing.T, userID uuid.UUID, action AuditAction, createdAt int64, metadata map[string]interface{}) *AuditLog {
+	t.Helper()
+
+	entry, err := NewAuditLog(userID, action, metadata)
+	if err != nil {
+		t.Fatalf("Failed to create audit log: %v", err)
+	}
+	entry.CreatedAt = createdAt
+	return entry
+}
+
+func TestSummarizeActivity(t *testing.T) {
+	userID := uuid.New()
+	phone := map[string]interface{}{AuditMetadataUserAgent: "ticket-app/3.1 ios", AuditMetadataIPAddress: "10.0.0.1"}
+	laptop := map[string]interface{}{AuditMetadataUserAgent: "Mozilla/5.0", AuditMetadataIPAddress: "10.0.0.2"}
+
+	entries := []*AuditLog{
+		auditEntry(t, userID, AuditActionUserLoggedIn, 1500, laptop),
+		auditEntry(t, userID, AuditActionUserLoggedIn, 1200, phone),
+		auditEntry(t, userID, AuditActionUserLoggedIn, 1100, map[string]interface{}{AuditMetadataUserAgent: "ticket-app/3.1 ios", AuditMetadataIPAddress: "10.0.0.9"}),
+		auditEntry(t, userID, AuditActionUserLoggedIn, 1050, nil),
+		auditEntry(t, userID, AuditActionPasswordChanged, 1300, nil),
+		auditEntry(t, userID, AuditActionTokensRevoked, 1400, nil),
+		auditEntry(t, userID, AuditActionUserLoggedIn, 999, laptop),  // before the period
+		auditEntry(t, userID, AuditActionUserLoggedIn, 2000, laptop), // end is exclusive
+	}
+
+	summary := SummarizeActivity(userID, 1000, 2000, entries)
+
+	if summary.Logins != 4 {
+		t.Errorf("Expected 4 logins, got %d", summary.Logins)
+	}
+	if summary.LastLoginAt != 1500 {
+		t.Errorf("Expected last login at 1500, got %d", summary.LastLoginAt)
+	}
+	if summary.PasswordChanges != 1 || summary.EmailChanges != 0 || summary.TokenRevocations != 1 {
+		t.Errorf("Unexpected change counters: %+v", summary)
+	}
+
+	if len(summary.Devices) != 3 {
+		t.Fatalf("Expected 3 devices, got %d", len(summary.Devices))
+	}
+	if summary.Devices[0].UserAgent != "Mozilla/5.0" {
+		t.Errorf("Expected most recent device first, got %s", summary.Devices[0].UserAgent)
+	}
+	if phone := summary.Devices[1]; phone.Logins != 2 || phone.IPAddress != "10.0.0.1" || phone.LastSeenAt != 1200 {
+		t.Errorf("Expected phone with 2 logins last seen from 10.0.0.1 at 1200, got %+v", phone)
+	}
+	if summary.Devices[2].UserAgent != unknownDevice {
+		t.Errorf("Expected login without user agent to be grouped as %s, got %s", unknownDevice, summary.Devices[2].UserAgent)
+	}
+	if !summary.HasActivity() {
+		t.Error("Expected summary to report activity")
+	}
+}
+
+func TestSummarizeActivityEmpty(t *testing.T) {
+	summary := SummarizeActivity(uuid.New(), 1000, 2000, nil)
+
+	if summary.HasActivity() {
+		t.Error("Expected no activity")
+	}
+	if summary.Devices == nil {
+		t.Error("Expected an empty device list, got nil")
+	}
+}
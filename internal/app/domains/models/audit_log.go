@@ -0,0 +1,104 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionUserRegistered  AuditAction = "user.registered"
+	AuditActionUserLoggedIn    AuditAction = "user.logged_in"
+	AuditActionUserLoggedOut   AuditAction = "user.logged_out"
+	AuditActionTokensRevoked   AuditAction = "user.tokens_revoked"
+	AuditActionPasswordChanged AuditAction = "user.password_changed"
+	AuditActionEmailChanged    AuditAction = "user.email_changed"
+	// Legal hold actions are recorded with the admin and the reason in the metadata
+	AuditActionLegalHoldPlaced   AuditAction = "user.legal_hold_placed"
+	AuditActionLegalHoldReleased AuditAction = "user.legal_hold_released"
+	// AuditActionUserImported is recorded with the importing admin in the metadata
+	AuditActionUserImported AuditAction = "user.imported"
+	// AuditActionPasswordSetUp is recorded when an invited user sets the first password
+	AuditActionPasswordSetUp AuditAction = "user.password_set_up"
+	// AuditActionPasswordReset is recorded when a user resets a forgotten password with an
+	// emailed link
+	AuditActionPasswordReset AuditAction = "user.password_reset"
+	// AuditActionPasswordUpgraded is recorded with the scheme of the replaced hash when an
+	// outdated password hash, e.g. the legacy hash of a migrated user, is rehashed on login
+	AuditActionPasswordUpgraded AuditAction = "user.password_upgraded"
+	// AuditActionIdentityLinked is recorded with the provider when the first login with an
+	// OpenID Connect identity links it to the account with its verified email
+	AuditActionIdentityLinked AuditAction = "user.identity_linked"
+	// Batch admin actions are recorded with the admin, the reason and the previous value in the metadata
+	AuditActionRoleAssigned  AuditAction = "user.role_assigned"
+	AuditActionStatusChanged AuditAction = "user.status_changed"
+	// AuditActionMetadataUpdated is recorded with the service and the keys set and removed
+	AuditActionMetadataUpdated AuditAction = "user.metadata_updated"
+)
+
+// SecurityAuditActions are the actions that are security events, e.g. logins and bans; they
+// are delivered to the webhooks of organizations
+var SecurityAuditActions = []AuditAction{
+	AuditActionUserLoggedIn,
+	AuditActionUserLoggedOut,
+	AuditActionTokensRevoked,
+	AuditActionPasswordChanged,
+	AuditActionEmailChanged,
+	AuditActionPasswordSetUp,
+	AuditActionPasswordReset,
+	AuditActionPasswordUpgraded,
+	AuditActionIdentityLinked,
+	AuditActionRoleAssigned,
+	AuditActionStatusChanged,
+}
+
+// AuditLog represents a single audit trail entry
+type AuditLog struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"userId"`
+	// OrganizationID is the organization of the user when the entry was written, uuid.Nil
+	// for none. Entries written without it take the user's organization when stored.
+	OrganizationID uuid.UUID       `json:"organizationId"`
+	Action         AuditAction     `json:"action"`
+	Metadata       json.RawMessage `json:"metadata"`
+	CreatedAt      int64           `json:"createdAt"`
+}
+
+// OrgAuditFilter selects the audit trail of an organization
+type OrgAuditFilter struct {
+	OrganizationID uuid.UUID
+	// From and To bound created_at in Unix milliseconds, From inclusive and To exclusive
+	From int64
+	To   int64
+	// Actions limits the entries to these actions, all of them when empty
+	Actions []AuditAction
+}
+
+// NewAuditLog creates a new audit log entry for the given user and action
+func NewAuditLog(userID uuid.UUID, action AuditAction, metadata map[string]interface{}) (*AuditLog, error) {
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditLog{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Action:    action,
+		Metadata:  raw,
+		CreatedAt: time.Now().UnixMilli(),
+	}, nil
+}
+
+// AuditCursor is the position of an entry in an audit trail ordered by creation
+type AuditCursor struct {
+	CreatedAt int64
+	ID        uuid.UUID
+}
@@ -0,0 +1,15 @@
+package models
+
+// AuthorizedClient is an application a user granted access to by signing in with it, as long
+// as it holds an active refresh token of the user
+type AuthorizedClient struct {
+	ClientID string
+	// Platform is empty for clients that are no longer registered
+	Platform ClientPlatform
+	// FirstAuthorizedAt and LastUsedAt are Unix timestamps in milliseconds of the oldest active
+	// session's login and the latest login or refresh of any session
+	FirstAuthorizedAt int64
+	LastUsedAt        int64
+	// ActiveSessions is the number of sessions of the client with an active refresh token
+	ActiveSessions int64
+}
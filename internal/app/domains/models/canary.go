@@ -0,0 +1,43 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// CanaryAccount is an account seeded for breach detection, e.g. with credentials planted in a
+// honeypot. It is never used legitimately, so any login attempt or token use on it raises a
+// high-severity alert.
+type CanaryAccount struct {
+	UserID uuid.UUID `json:"userId"`
+	// Note tells responders where the account's credentials were planted
+	Note string `json:"note"`
+	// Tarpit delays the responses of logins and refreshes of the account, slowing down the
+	// attacker while responders react
+	Tarpit    bool   `json:"tarpit"`
+	CreatedBy string `json:"createdBy"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// CanaryAccessKind is how a canary account was accessed
+type CanaryAccessKind string
+
+const (
+	// CanaryAccessLogin is a login attempt, whether the password was right or not
+	CanaryAccessLogin CanaryAccessKind = "login"
+	// CanaryAccessRefreshToken is a refresh token of the account presented to RefreshToken
+	CanaryAccessRefreshToken CanaryAccessKind = "refresh_token"
+	// CanaryAccessAccessToken is a valid access token of the account presented to any call
+	CanaryAccessAccessToken CanaryAccessKind = "access_token"
+)
+
+// CanaryAccess describes an access to a canary account for the alert
+type CanaryAccess struct {
+	Kind     CanaryAccessKind
+	ClientID string
+	// PasswordValid is set for logins; a valid password means the planted credentials leaked
+	PasswordValid bool
+	IPAddress     string
+	UserAgent     string
+	// At is a Unix timestamp in milliseconds
+	At int64
+}
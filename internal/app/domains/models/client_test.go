@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"user-svc/pkg/utils/crypt/token"
+)
+
+func TestClientAllows(t *testing.T) {
+	client := &Client{
+		ID:                "kiosk",
+		Platform:          ClientPlatformKiosk,
+		AllowedGrantTypes: []GrantType{GrantTypePassword},
+	}
+
+	if !client.Allows(GrantTypePassword) {
+		t.Error("Expected password grant to be allowed")
+	}
+	if client.Allows(GrantTypeRefreshToken) {
+		t.Error("Expected refresh token grant to be denied")
+	}
+}
+
+func TestClientTokenDurations(t *testing.T) {
+	defaults := &Client{}
+	if got := defaults.AccessTokenDuration(15 * time.Minute); got != 15*time.Minute {
+		t.Errorf("Expected default access token duration, got %v", got)
+	}
+	if got := defaults.RefreshTokenDuration(7 * 24 * time.Hour); got != 7*24*time.Hour {
+		t.Errorf("Expected default refresh token duration, got %v", got)
+	}
+
+	mobile := &Client{AccessTokenTTL: time.Hour, RefreshTokenTTL: 30 * 24 * time.Hour}
+	if got := mobile.AccessTokenDuration(15 * time.Minute); got != time.Hour {
+		t.Errorf("Expected access token override, got %v", got)
+	}
+	if got := mobile.RefreshTokenDuration(7 * 24 * time.Hour); got != 30*24*time.Hour {
+		t.Errorf("Expected refresh token override, got %v", got)
+	}
+}
+
+func TestClientTokenClaimProfile(t *testing.T) {
+	defaults := &Client{}
+	if got := defaults.TokenClaimProfile(token.ClaimProfileStandard); got != token.ClaimProfileStandard {
+		t.Errorf("Expected default claim profile, got %q", got)
+	}
+
+	web := &Client{ClaimProfile: token.ClaimProfileMinimal}
+	if got := web.TokenClaimProfile(token.ClaimProfileStandard); got != token.ClaimProfileMinimal {
+		t.Errorf("Expected claim profile override, got %q", got)
+	}
+}
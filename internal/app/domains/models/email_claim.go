@@ -0,0 +1,48 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// EmailVerificationMethod is how a user proved owning the email of an account
+type EmailVerificationMethod string
+
+const (
+	// EmailVerifiedByRegistrationCode accounts were registered with the code emailed by
+	// StartRegistration
+	EmailVerifiedByRegistrationCode EmailVerificationMethod = "registration_code"
+	// EmailVerifiedByInvitation accounts were activated with the token of an invitation email
+	EmailVerifiedByInvitation EmailVerificationMethod = "invitation"
+	// EmailVerifiedByEmailLink accounts opened the link of a verification email after they
+	// were created
+	EmailVerifiedByEmailLink EmailVerificationMethod = "email_link"
+)
+
+// EmailClaim records that a user proved owning the email of the account, so guest activity
+// under the email, e.g. bookings made at checkout without an account, can be attached to the
+// user. The activity is claimed once, by the first service that asks.
+type EmailClaim struct {
+	UserID     uuid.UUID               `json:"userId"`
+	Email      Email                   `json:"email"`
+	VerifiedBy EmailVerificationMethod `json:"verifiedBy"`
+	VerifiedAt int64                   `json:"verifiedAt"`
+	// ActivityClaimedAt is when the guest activity was claimed, 0 until it is
+	ActivityClaimedAt int64 `json:"activityClaimedAt,omitempty"`
+	// ActivityClaimedBy is the service key ID of the service that claimed the activity
+	ActivityClaimedBy string `json:"activityClaimedBy,omitempty"`
+}
+
+// NewEmailClaim creates the claim of a user on the email of the account
+func NewEmailClaim(userID uuid.UUID, email Email, method EmailVerificationMethod, verifiedAt int64) *EmailClaim {
+	return &EmailClaim{
+		UserID:     userID,
+		Email:      email,
+		VerifiedBy: method,
+		VerifiedAt: verifiedAt,
+	}
+}
+
+// IsActivityClaimed reports whether the guest activity under the email was claimed
+func (c *EmailClaim) IsActivityClaimed() bool {
+	return c.ActivityClaimedAt != 0
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationToken lets a user whose account was created without verifying the email,
+// e.g. by Register, verify it later. A user has at most one; emailing another replaces it.
+// Only the hash of the token is stored, see TokenHasher; the token itself is emailed.
+type EmailVerificationToken struct {
+	UserID    uuid.UUID `json:"userId"`
+	TokenHash string    `json:"-"`
+	ExpiresAt int64     `json:"expiresAt"`
+	CreatedAt int64     `json:"createdAt"`
+}
+
+// NewEmailVerificationToken generates a verification token for the user valid for ttl and
+// returns it along with the record to store
+func NewEmailVerificationToken(userID uuid.UUID, ttl time.Duration, now time.Time, tokens TokenHasher) (string, *EmailVerificationToken, error) {
+	verificationToken, err := newSecretToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate email verification token: %w", err)
+	}
+
+	return verificationToken, &EmailVerificationToken{
+		UserID:    userID,
+		TokenHash: tokens.Hash(verificationToken),
+		ExpiresAt: now.Add(ttl).UnixMilli(),
+		CreatedAt: now.UnixMilli(),
+	}, nil
+}
@@ -0,0 +1,18 @@
+package models
+
+import "github.com/google/uuid"
+
+// GlobalLogout records an emergency logout of every user, e.g. after a signing key leaked.
+// Access and refresh tokens issued up to Cutoff are rejected from the moment it is recorded;
+// RefreshTokensRevoked counts the stored refresh tokens revoked afterwards.
+type GlobalLogout struct {
+	ID uuid.UUID `json:"id"`
+	// Admin is the ID of the admin API key that triggered the logout
+	Admin                string `json:"admin"`
+	Reason               string `json:"reason"`
+	Cutoff               int64  `json:"cutoff"`
+	RefreshTokensRevoked int64  `json:"refreshTokensRevoked"`
+	CreatedAt            int64  `json:"createdAt"`
+	// CompletedAt is 0 until every refresh token up to Cutoff is revoked
+	CompletedAt int64 `json:"completedAt"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+// inviteCodeBytes is the entropy of an invite code, 13 base32 characters
+const inviteCodeBytes = 8
+
+// InviteCode is a code a user hands out to refer others. Redeeming it at registration records
+// the referral, until MaxRedemptions users registered with it.
+type InviteCode struct {
+	Code   string    `json:"code"`
+	UserID uuid.UUID `json:"userId"`
+	// EmailHash is the hash of the canonical email of the user, see CanonicalEmailHash
+	EmailHash      string `json:"-"`
+	MaxRedemptions int    `json:"maxRedemptions"`
+	Redemptions    int    `json:"redemptions"`
+	CreatedAt      int64  `json:"createdAt"`
+}
+
+// NewInviteCode generates an invite code of the user that can be redeemed maxRedemptions times
+func NewInviteCode(user *User, maxRedemptions int, now time.Time, tokens TokenHasher) (*InviteCode, error) {
+	raw := make([]byte, inviteCodeBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate invite code: %w", err)
+	}
+
+	return &InviteCode{
+		Code:           base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw),
+		UserID:         user.ID,
+		EmailHash:      CanonicalEmailHash(user.Email, tokens),
+		MaxRedemptions: maxRedemptions,
+		CreatedAt:      now.UnixMilli(),
+	}, nil
+}
+
+// CheckRedeemableBy fails with ErrSelfReferral when the email is the code owner's, including
+// plus-addressed variants of it such as jane+2@tickets.example
+func (c *InviteCode) CheckRedeemableBy(email Email, tokens TokenHasher) error {
+	if CanonicalEmailHash(email, tokens) == c.EmailHash {
+		return errs.ErrSelfReferral
+	}
+	return nil
+}
+
+// CanonicalEmailHash hashes the email lowercased and without a +tag in its local part, so
+// the variants of an address that reach the same mailbox hash the same
+func CanonicalEmailHash(email Email, tokens TokenHasher) string {
+	address := strings.ToLower(strings.TrimSpace(email.String()))
+	if local, domain, found := strings.Cut(address, "@"); found {
+		local, _, _ = strings.Cut(local, "+")
+		address = local + "@" + domain
+	}
+	return tokens.Hash(address)
+}
+
+// Referral records that a user registered with the invite code of another
+type Referral struct {
+	RefereeID  uuid.UUID `json:"refereeId"`
+	ReferrerID uuid.UUID `json:"referrerId"`
+	Code       string    `json:"code"`
+	CreatedAt  int64     `json:"createdAt"`
+}
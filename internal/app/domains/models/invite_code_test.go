@@ -0,0 +1,38 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func TestInviteCode_CheckRedeemableBy(t *testing.T) {
+	owner := &User{ID: uuid.New(), Email: "Jane@tickets.example"}
+	code, err := NewInviteCode(owner, 10, time.Now(), sha256Tokens{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(code.Code) != 13 || code.UserID != owner.ID || code.MaxRedemptions != 10 {
+		t.Errorf("Expected a 13 character code of the owner, got %+v", code)
+	}
+
+	tests := []struct {
+		email    Email
+		expected error
+	}{
+		{email: "jane@tickets.example", expected: errs.ErrSelfReferral},
+		{email: "jane+friends@Tickets.example", expected: errs.ErrSelfReferral},
+		{email: "john@tickets.example"},
+		{email: "jane@partner.example"},
+	}
+
+	for _, tt := range tests {
+		if err := code.CheckRedeemableBy(tt.email, sha256Tokens{}); !errors.Is(err, tt.expected) {
+			t.Errorf("Expected %v for %s, got %v", tt.expected, tt.email, err)
+		}
+	}
+}
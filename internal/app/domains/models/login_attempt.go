@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// LoginAttempt records a password login, whether it succeeded or not, for the user to review
+// the recent activity on the account
+type LoginAttempt struct {
+	ID uuid.UUID `json:"id"`
+	// UserID is uuid.Nil for attempts with the email of no user
+	UserID  uuid.UUID `json:"userId"`
+	Success bool      `json:"success"`
+	// FailureReason is the message of the error that refused a failed attempt, e.g. "invalid
+	// credentials"; internal errors are recorded as "internal error"
+	FailureReason string `json:"failureReason,omitempty"`
+	ClientID      string `json:"clientId"`
+	IPAddress     string `json:"ipAddress"`
+	UserAgent     string `json:"userAgent"`
+	CreatedAt     int64  `json:"createdAt"`
+}
+
+// LoginAttemptCursor is a position in the login attempts of a user, newest first: before the
+// attempt made at CreatedAt with ID. The zero cursor is before the newest attempt.
+type LoginAttemptCursor struct {
+	CreatedAt int64
+	ID        uuid.UUID
+}
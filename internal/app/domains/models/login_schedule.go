@@ -0,0 +1,114 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MinutesPerDay is the end of a login window lasting until midnight
+const MinutesPerDay = 24 * 60
+
+// weekdayNames are the names of the days of LoginWindow.Days, indexed by time.Weekday
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// ParseWeekday parses a three-letter day name such as mon
+func ParseWeekday(name string) (time.Weekday, bool) {
+	i := slices.Index(weekdayNames, strings.ToLower(name))
+	return time.Weekday(i), i >= 0
+}
+
+// FormatWeekday returns the three-letter name of a day
+func FormatWeekday(day time.Weekday) string {
+	return weekdayNames[day]
+}
+
+// ParseClock parses a time of day as HH:MM into minutes after midnight; 24:00 is the end of the day
+func ParseClock(clock string) (int, bool) {
+	if len(clock) != 5 || clock[2] != ':' || strings.ContainsAny(clock, "+-") {
+		return 0, false
+	}
+	hours, err := strconv.Atoi(clock[:2])
+	if err != nil {
+		return 0, false
+	}
+	minutes, err := strconv.Atoi(clock[3:])
+	if err != nil || minutes > 59 || hours*60+minutes > MinutesPerDay {
+		return 0, false
+	}
+	return hours*60 + minutes, true
+}
+
+// FormatClock formats minutes after midnight as HH:MM
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// LoginWindow is a span of local time a user may authenticate in, starting on each of Days.
+// End before Start spans midnight, e.g. 18:00 to 02:00 for a late shift.
+type LoginWindow struct {
+	Days []time.Weekday `json:"days"`
+	// Start and End are minutes after midnight; End is at most MinutesPerDay
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// contains reports whether the local day and minute of the day are in the window
+func (w LoginWindow) contains(day time.Weekday, minute int) bool {
+	if w.Start < w.End {
+		return slices.Contains(w.Days, day) && minute >= w.Start && minute < w.End
+	}
+
+	previous := (day + 6) % 7
+	return (slices.Contains(w.Days, day) && minute >= w.Start) ||
+		(slices.Contains(w.Days, previous) && minute < w.End)
+}
+
+// LoginSchedule restricts when a user, or every user of a role, may log in or refresh tokens.
+// A schedule of the user takes precedence over one of their role; users without either are
+// not restricted.
+type LoginSchedule struct {
+	// UserID is uuid.Nil for schedules of a role
+	UserID uuid.UUID `json:"userId"`
+	// Role is empty for schedules of a user
+	Role UserRole `json:"role"`
+	// Timezone is the IANA time zone of the windows, e.g. Europe/Berlin
+	Timezone  string        `json:"timezone"`
+	Windows   []LoginWindow `json:"windows"`
+	UpdatedAt int64         `json:"updatedAt"`
+}
+
+// Allows reports whether the schedule allows authenticating at t, in the local time of the
+// schedule's time zone, so the windows follow daylight saving time
+func (s *LoginSchedule) Allows(t time.Time) (bool, error) {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false, err
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	for _, window := range s.Windows {
+		if window.contains(local.Weekday(), minute) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EffectiveLoginSchedule returns the schedule of the user among the schedules of the user and
+// their role, or nil if neither has one
+func EffectiveLoginSchedule(schedules []*LoginSchedule) *LoginSchedule {
+	var effective *LoginSchedule
+	for _, schedule := range schedules {
+		if schedule.UserID != uuid.Nil {
+			return schedule
+		}
+		effective = schedule
+	}
+	return effective
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestLoginSchedule_Allows(t *testing.T) {
+	schedule := &LoginSchedule{
+		Role:     UserRoleStaff,
+		Timezone: "Europe/Berlin",
+		Windows: []LoginWindow{
+			{Days: []time.Weekday{time.Monday, time.Tuesday}, Start: 8 * 60, End: 17 * 60},
+			// A late shift on Fridays, ending on Saturday
+			{Days: []time.Weekday{time.Friday}, Start: 18 * 60, End: 2 * 60},
+		},
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Time zone database unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{name: "monday morning", at: time.Date(2026, 10, 12, 8, 0, 0, 0, berlin), expected: true},
+		{name: "monday before start", at: time.Date(2026, 10, 12, 7, 59, 0, 0, berlin), expected: false},
+		{name: "monday at end", at: time.Date(2026, 10, 12, 17, 0, 0, 0, berlin), expected: false},
+		{name: "wednesday", at: time.Date(2026, 10, 14, 12, 0, 0, 0, berlin), expected: false},
+		{name: "friday night", at: time.Date(2026, 10, 16, 23, 30, 0, 0, berlin), expected: true},
+		{name: "after midnight into saturday", at: time.Date(2026, 10, 17, 1, 59, 0, 0, berlin), expected: true},
+		{name: "saturday evening", at: time.Date(2026, 10, 17, 19, 0, 0, 0, berlin), expected: false},
+		{name: "thursday after midnight", at: time.Date(2026, 10, 15, 1, 0, 0, 0, berlin), expected: false},
+		// 07:30 UTC is 08:30 in winter and 09:30 in summer in Berlin
+		{name: "winter time in utc", at: time.Date(2026, 1, 5, 7, 30, 0, 0, time.UTC), expected: true},
+		{name: "summer time in utc", at: time.Date(2026, 7, 6, 6, 30, 0, 0, time.UTC), expected: true},
+		{name: "before start in summer utc", at: time.Date(2026, 7, 6, 5, 30, 0, 0, time.UTC), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := schedule.Allows(tt.at)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if allowed != tt.expected {
+				t.Errorf("Expected %v at %s, got %v", tt.expected, tt.at, allowed)
+			}
+		})
+	}
+}
+
+func TestLoginSchedule_AllowsUnknownTimezone(t *testing.T) {
+	schedule := &LoginSchedule{Timezone: "Mars/Olympus_Mons"}
+	if _, err := schedule.Allows(time.Now()); err == nil {
+		t.Error("Expected an error for an unknown time zone")
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		clock    string
+		expected int
+		ok       bool
+	}{
+		{clock: "00:00", expected: 0, ok: true},
+		{clock: "09:30", expected: 570, ok: true},
+		{clock: "23:59", expected: 1439, ok: true},
+		{clock: "24:00", expected: MinutesPerDay, ok: true},
+		{clock: "24:01"},
+		{clock: "12:60"},
+		{clock: "9:30"},
+		{clock: "+9:30"},
+		{clock: "09-30"},
+		{clock: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.clock, func(t *testing.T) {
+			minutes, ok := ParseClock(tt.clock)
+			if ok != tt.ok || minutes != tt.expected {
+				t.Errorf("Expected %d, %v, got %d, %v", tt.expected, tt.ok, minutes, ok)
+			}
+			if ok && FormatClock(minutes) != tt.clock {
+				t.Errorf("Expected %s, got %s", tt.clock, FormatClock(minutes))
+			}
+		})
+	}
+}
+
+func TestEffectiveLoginSchedule(t *testing.T) {
+	role := &LoginSchedule{Role: UserRoleStaff}
+	user := &LoginSchedule{UserID: uuid.New()}
+
+	if schedule := EffectiveLoginSchedule(nil); schedule != nil {
+		t.Errorf("Expected no schedule, got %+v", schedule)
+	}
+	if schedule := EffectiveLoginSchedule([]*LoginSchedule{role}); schedule != role {
+		t.Errorf("Expected the role schedule, got %+v", schedule)
+	}
+	if schedule := EffectiveLoginSchedule([]*LoginSchedule{role, user}); schedule != user {
+		t.Errorf("Expected the user schedule, got %+v", schedule)
+	}
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationChannel is a way of reaching a user
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelSMS   NotificationChannel = "sms"
+	NotificationChannelPush  NotificationChannel = "push"
+)
+
+// NotificationChannels lists every channel in display order
+var NotificationChannels = []NotificationChannel{
+	NotificationChannelEmail,
+	NotificationChannelSMS,
+	NotificationChannelPush,
+}
+
+// IsValid reports whether the channel is known
+func (c NotificationChannel) IsValid() bool {
+	return slices.Contains(NotificationChannels, c)
+}
+
+// DeliveryStatus is the state of a notification delivery on one channel
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending deliveries are being sent; they are retried if the sender never reported back
+	DeliveryStatusPending DeliveryStatus = "pending"
+	DeliveryStatusSent    DeliveryStatus = "sent"
+	// DeliveryStatusFailed deliveries are retried at NextAttemptAt
+	DeliveryStatusFailed DeliveryStatus = "failed"
+	// DeliveryStatusDeadLetter deliveries exhausted their attempts and are kept for inspection
+	DeliveryStatusDeadLetter DeliveryStatus = "dead_letter"
+)
+
+// NotificationPreference opts a user in or out of a channel for an event type
+type NotificationPreference struct {
+	UserID    uuid.UUID           `json:"userId"`
+	EventType string              `json:"eventType"`
+	Channel   NotificationChannel `json:"channel"`
+	Enabled   bool                `json:"enabled"`
+	UpdatedAt int64               `json:"updatedAt"`
+}
+
+// ResolveChannels returns the channels an event is sent on: the default channels of the
+// event type, minus those the user opted out of, plus those they opted in to
+func ResolveChannels(defaults []NotificationChannel, prefs []*NotificationPreference) []NotificationChannel {
+	enabled := make(map[NotificationChannel]bool, len(NotificationChannels))
+	for _, channel := range defaults {
+		enabled[channel] = true
+	}
+	for _, pref := range prefs {
+		enabled[pref.Channel] = pref.Enabled
+	}
+
+	channels := make([]NotificationChannel, 0, len(enabled))
+	for _, channel := range NotificationChannels {
+		if enabled[channel] {
+			channels = append(channels, channel)
+		}
+	}
+
+	return channels
+}
+
+// EventPreferences returns the preferences that apply to the event type
+func EventPreferences(prefs []*NotificationPreference, eventType string) []*NotificationPreference {
+	result := make([]*NotificationPreference, 0, len(prefs))
+	for _, pref := range prefs {
+		if pref.EventType == eventType {
+			result = append(result, pref)
+		}
+	}
+	return result
+}
+
+// NotificationDelivery is a notification sent to a user on a single channel
+type NotificationDelivery struct {
+	ID        uuid.UUID           `json:"id"`
+	EventID   string              `json:"eventId"`
+	EventType string              `json:"eventType"`
+	UserID    uuid.UUID           `json:"userId"`
+	Channel   NotificationChannel `json:"channel"`
+	Status    DeliveryStatus      `json:"status"`
+	// Payload is handed to the channel's sender on every attempt
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"lastError"`
+	NextAttemptAt int64           `json:"nextAttemptAt"`
+	CreatedAt     int64           `json:"createdAt"`
+	UpdatedAt     int64           `json:"updatedAt"`
+}
+
+// MarkSent records a successful attempt
+func (d *NotificationDelivery) MarkSent() {
+	d.Attempts++
+	d.Status = DeliveryStatusSent
+	d.LastError = ""
+	d.NextAttemptAt = 0
+}
+
+// MarkFailed records a failed attempt. The delivery is retried after backoff, or moved to
+// the dead letter state once maxAttempts attempts have failed.
+func (d *NotificationDelivery) MarkFailed(err error, maxAttempts int, backoff time.Duration, now time.Time) {
+	d.Attempts++
+	d.LastError = err.Error()
+
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryStatusDeadLetter
+		d.NextAttemptAt = 0
+		return
+	}
+
+	d.Status = DeliveryStatusFailed
+	d.NextAttemptAt = now.Add(backoff).UnixMilli()
+}
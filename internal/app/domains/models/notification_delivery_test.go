@@ -0,0 +1,59 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestResolveChannels(t *testing.T) {
+	defaults := []NotificationChannel{NotificationChannelEmail, NotificationChannelPush}
+
+	got := ResolveChannels(defaults, nil)
+	if !slices.Equal(got, defaults) {
+		t.Errorf("Expected default channels %v, got %v", defaults, got)
+	}
+
+	got = ResolveChannels(defaults, []*NotificationPreference{
+		{Channel: NotificationChannelPush, Enabled: false},
+		{Channel: NotificationChannelSMS, Enabled: true},
+	})
+	expected := []NotificationChannel{NotificationChannelEmail, NotificationChannelSMS}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Expected channels %v, got %v", expected, got)
+	}
+}
+
+func TestNotificationDeliveryMarkFailed(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	delivery := &NotificationDelivery{Status: DeliveryStatusPending}
+
+	delivery.MarkFailed(errors.New("smtp timeout"), 2, time.Minute, now)
+	if delivery.Status != DeliveryStatusFailed {
+		t.Errorf("Expected status %s, got %s", DeliveryStatusFailed, delivery.Status)
+	}
+	if delivery.NextAttemptAt != now.Add(time.Minute).UnixMilli() {
+		t.Errorf("Expected next attempt after backoff, got %d", delivery.NextAttemptAt)
+	}
+	if delivery.LastError != "smtp timeout" {
+		t.Errorf("Expected last error to be recorded, got %q", delivery.LastError)
+	}
+
+	delivery.MarkFailed(errors.New("smtp timeout"), 2, time.Minute, now)
+	if delivery.Status != DeliveryStatusDeadLetter {
+		t.Errorf("Expected status %s, got %s", DeliveryStatusDeadLetter, delivery.Status)
+	}
+	if delivery.Attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", delivery.Attempts)
+	}
+}
+
+func TestNotificationDeliveryMarkSent(t *testing.T) {
+	delivery := &NotificationDelivery{Status: DeliveryStatusFailed, Attempts: 1, LastError: "smtp timeout", NextAttemptAt: 1}
+
+	delivery.MarkSent()
+	if delivery.Status != DeliveryStatusSent || delivery.Attempts != 2 || delivery.LastError != "" || delivery.NextAttemptAt != 0 {
+		t.Errorf("Expected a sent delivery after 2 attempts, got %+v", delivery)
+	}
+}
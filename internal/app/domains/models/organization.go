@@ -0,0 +1,171 @@
+package models
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+// Organization groups the staff accounts of a company
+type Organization struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// AllowedEmailDomains restricts member emails to these domains and their subdomains;
+	// any domain is allowed when empty
+	AllowedEmailDomains []string `json:"allowedEmailDomains"`
+	// Branding is how the emails sent to members of the organization are branded
+	Branding OrganizationBranding `json:"branding"`
+	// Residency is the region members register into, whatever their country; "" assigns
+	// members the region of their country
+	Residency string `json:"residency"`
+	// SessionIdleTimeout ends the sessions of members unused for longer, whatever their
+	// client; 0 sets none
+	SessionIdleTimeout time.Duration `json:"sessionIdleTimeout"`
+	CreatedAt          int64         `json:"createdAt"`
+	UpdatedAt          int64         `json:"updatedAt"`
+}
+
+// OrganizationBranding is the name, logo, support contact and color the emails sent to
+// members of an organization show; empty fields fall back to the service's own branding.
+// It is part of the template data of those emails as "branding", with every field present.
+type OrganizationBranding struct {
+	Name         string `json:"name"`
+	LogoURL      string `json:"logoUrl"`
+	SupportEmail string `json:"supportEmail"`
+	Color        string `json:"color"`
+}
+
+// maxBrandingLogoURLLength keeps logo URLs within what mail clients reliably load
+const maxBrandingLogoURLLength = 2048
+
+// NewOrganizationBranding trims and validates the branding of an organization. The logo must
+// be served over https, as mail clients block mixed content, and the color is lowercased.
+func NewOrganizationBranding(name, logoURL, supportEmail, color string) (OrganizationBranding, error) {
+	branding := OrganizationBranding{
+		Name:         strings.TrimSpace(name),
+		LogoURL:      strings.TrimSpace(logoURL),
+		SupportEmail: strings.TrimSpace(supportEmail),
+		Color:        strings.ToLower(strings.TrimSpace(color)),
+	}
+
+	if len(branding.Name) > 255 {
+		return OrganizationBranding{}, errs.ErrBrandingNameTooLong
+	}
+	if branding.LogoURL != "" && !isHTTPSURL(branding.LogoURL) {
+		return OrganizationBranding{}, errs.ErrInvalidBrandingLogoURL
+	}
+	if branding.SupportEmail != "" {
+		if err := Email(branding.SupportEmail).Validate(); err != nil {
+			return OrganizationBranding{}, err
+		}
+	}
+	if branding.Color != "" && !isHexColor(branding.Color) {
+		return OrganizationBranding{}, errs.ErrInvalidBrandingColor
+	}
+
+	return branding, nil
+}
+
+// IsZero reports whether the organization has no branding of its own
+func (b OrganizationBranding) IsZero() bool {
+	return b == OrganizationBranding{}
+}
+
+func isHTTPSURL(raw string) bool {
+	if len(raw) > maxBrandingLogoURLLength {
+		return false
+	}
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "https" && u.Host != "" && u.User == nil
+}
+
+// isHexColor accepts CSS hex colors of the form #rgb or #rrggbb
+func isHexColor(color string) bool {
+	if len(color) != 4 && len(color) != 7 || color[0] != '#' {
+		return false
+	}
+	for _, char := range color[1:] {
+		if (char < '0' || char > '9') && (char < 'a' || char > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// NewOrganization creates a new organization with generated ID and timestamps
+func NewOrganization(name string, allowedEmailDomains []string) (*Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errs.ErrOrganizationNameIsRequired
+	}
+
+	domains, err := NormalizeEmailDomains(allowedEmailDomains)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	return &Organization{
+		ID:                  uuid.New(),
+		Name:                name,
+		AllowedEmailDomains: domains,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}, nil
+}
+
+// AllowsEmail reports whether the email may belong to a member of the organization
+func (o *Organization) AllowsEmail(email Email) bool {
+	if len(o.AllowedEmailDomains) == 0 {
+		return true
+	}
+
+	for _, domain := range EmailDomainCandidates(email.String()) {
+		if slices.Contains(o.AllowedEmailDomains, domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizeEmailDomains lowercases, deduplicates and sorts email domains, rejecting
+// anything that is not a plain domain name such as "@example.com" or "localhost"
+func NormalizeEmailDomains(domains []string) ([]string, error) {
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if !isDomainName(domain) {
+			return nil, errs.ErrInvalidEmailDomain.WithDetail("domain", domain)
+		}
+		normalized = append(normalized, domain)
+	}
+
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
+}
+
+func isDomainName(domain string) bool {
+	if len(domain) > 253 || !strings.Contains(domain, ".") {
+		return false
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, char := range label {
+			if (char < 'a' || char > 'z') && (char < '0' || char > '9') && char != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
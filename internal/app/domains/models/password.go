@@ -2,6 +2,10 @@ package models
 
 import "user-svc/internal/app/domains/errs"
 
+// MaxPasswordLength bounds passwords in bytes. Passwords are pre-hashed, so every byte counts
+// even past bcrypt's 72-byte limit, and the bound leaves room for passphrases.
+const MaxPasswordLength = 256
+
 // Password represents a validated password
 type Password string
 
@@ -30,8 +34,8 @@ func (p Password) Validate() error {
 	}
 
 	// Check maximum length (reasonable limit)
-	if len(password) > 32 {
-		return errs.ErrInvalidPassword
+	if len(password) > MaxPasswordLength {
+		return errs.ErrPasswordTooLong
 	}
 
 	// Check for at least one uppercase letter
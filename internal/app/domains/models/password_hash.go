@@ -17,14 +17,26 @@ func NewPasswordHash(hash string) (PasswordHash, error) {
 	return ph, nil
 }
 
-// NewPasswordHashFromPlain creates a new PasswordHash from a plain text password
-func NewPasswordHashFromPlain(plainPassword string) (PasswordHash, error) {
-	hasher := password.DefaultHasher()
-	hashedPassword, err := hasher.HashPassword(plainPassword)
+// PasswordHasher is the domain service hashing passwords. The algorithm, its cost and the
+// pepper are its own decisions, so changing them touches no caller.
+type PasswordHasher interface {
+	Hash(plainPassword string) (PasswordHash, error)
+	Verify(hash PasswordHash, plainPassword string) bool
+	// NeedsRehash reports whether a verified hash should be replaced with a new hash of the
+	// password, as it was computed differently than new hashes are
+	NeedsRehash(hash PasswordHash) bool
+	// Scheme names how the hash was computed, e.g. for the audit of a rehash
+	Scheme(hash PasswordHash) string
+}
+
+// NewLegacyPasswordHash creates a PasswordHash from a salted SHA-1 digest of the legacy
+// ticketing system, tagged with its hash format so it can be verified until it is upgraded
+func NewLegacyPasswordHash(format, salt, digest string) (PasswordHash, error) {
+	hash, err := password.LegacyHash(format, salt, digest)
 	if err != nil {
-		return "", err
+		return "", errs.ErrInvalidLegacyPasswordHash
 	}
-	return PasswordHash(hashedPassword), nil
+	return PasswordHash(hash), nil
 }
 
 // Validate checks if the password hash is valid (non-empty)
@@ -39,9 +51,3 @@ func (ph PasswordHash) Validate() error {
 func (ph PasswordHash) String() string {
 	return string(ph)
 }
-
-// VerifyPassword checks if the password hash matches the provided password
-func (ph PasswordHash) VerifyPassword(plainPassword string) bool {
-	hasher := password.DefaultHasher()
-	return hasher.VerifyPassword(string(ph), plainPassword)
-}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken lets a user who forgot the password choose a new one. A user has at most
+// one, replaced when another reset is requested. Only the hash of the token is stored, see
+// TokenHasher; the token itself is emailed to the user.
+type PasswordResetToken struct {
+	UserID    uuid.UUID `json:"userId"`
+	TokenHash string    `json:"-"`
+	ExpiresAt int64     `json:"expiresAt"`
+	CreatedAt int64     `json:"createdAt"`
+}
+
+// NewPasswordResetToken generates a reset token for the user valid for ttl and returns it
+// along with the record to store
+func NewPasswordResetToken(userID uuid.UUID, ttl time.Duration, now time.Time, tokens TokenHasher) (string, *PasswordResetToken, error) {
+	resetToken, err := newSecretToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+
+	return resetToken, &PasswordResetToken{
+		UserID:    userID,
+		TokenHash: tokens.Hash(resetToken),
+		ExpiresAt: now.Add(ttl).UnixMilli(),
+		CreatedAt: now.UnixMilli(),
+	}, nil
+}
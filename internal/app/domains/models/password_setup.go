@@ -0,0 +1,47 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// secretTokenBytes is the entropy of the tokens emailed to users, e.g. password setup tokens
+const secretTokenBytes = 32
+
+// PasswordSetupToken lets an invited user set the first password. Only the hash of the token
+// is stored, see TokenHasher; the token itself is sent to the user.
+type PasswordSetupToken struct {
+	UserID    uuid.UUID `json:"userId"`
+	TokenHash string    `json:"-"`
+	ExpiresAt int64     `json:"expiresAt"`
+	CreatedAt int64     `json:"createdAt"`
+}
+
+// NewPasswordSetupToken generates a setup token for the user valid for ttl and returns it
+// along with the record to store
+func NewPasswordSetupToken(userID uuid.UUID, ttl time.Duration, now time.Time, tokens TokenHasher) (string, *PasswordSetupToken, error) {
+	setupToken, err := newSecretToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate password setup token: %w", err)
+	}
+
+	return setupToken, &PasswordSetupToken{
+		UserID:    userID,
+		TokenHash: tokens.Hash(setupToken),
+		ExpiresAt: now.Add(ttl).UnixMilli(),
+		CreatedAt: now.UnixMilli(),
+	}, nil
+}
+
+// newSecretToken generates a random URL-safe token to email to a user
+func newSecretToken() (string, error) {
+	raw := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"user-svc/pkg/utils/crypt/token"
+
+	"github.com/google/uuid"
+)
+
+// sha256Tokens hashes secrets like the TokenHasher of the service
+type sha256Tokens struct{}
+
+func (sha256Tokens) Hash(secret string) string {
+	return token.HashToken(secret)
+}
+
+func TestNewPasswordSetupToken(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	userID := uuid.New()
+
+	setupToken, record, err := NewPasswordSetupToken(userID, time.Hour, now, sha256Tokens{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if record.TokenHash != token.HashToken(setupToken) {
+		t.Errorf("Expected the hash of the token to be stored")
+	}
+	if record.UserID != userID {
+		t.Errorf("Expected user ID %s, got %s", userID, record.UserID)
+	}
+	if record.ExpiresAt != now.Add(time.Hour).UnixMilli() {
+		t.Errorf("Expected expiry %d, got %d", now.Add(time.Hour).UnixMilli(), record.ExpiresAt)
+	}
+
+	other, _, _ := NewPasswordSetupToken(userID, time.Hour, now, sha256Tokens{})
+	if other == setupToken {
+		t.Errorf("Expected distinct tokens")
+	}
+}
+
+func TestNewInvitedUser(t *testing.T) {
+	user, err := NewInvitedUser("jane@tickets.example", "jane")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if user.Status != UserStatusInvited {
+		t.Errorf("Expected status %s, got %s", UserStatusInvited, user.Status)
+	}
+	if user.PasswordHash != "" {
+		t.Errorf("Expected invited user to have no usable password")
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// PushPlatform is the push notification service a device token belongs to
+type PushPlatform string
+
+const (
+	PushPlatformAPNs PushPlatform = "apns"
+	PushPlatformFCM  PushPlatform = "fcm"
+)
+
+// IsValid reports whether the platform is a known push notification service
+func (p PushPlatform) IsValid() bool {
+	return p == PushPlatformAPNs || p == PushPlatformFCM
+}
+
+// PushToken is the token a device of a user receives push notifications with. A user has at
+// most one token per device, and a token belongs to at most one device.
+type PushToken struct {
+	UserID    uuid.UUID    `json:"userId"`
+	DeviceID  string       `json:"deviceId"`
+	Platform  PushPlatform `json:"platform"`
+	Token     string       `json:"token"`
+	CreatedAt int64        `json:"createdAt"`
+	UpdatedAt int64        `json:"updatedAt"`
+}
+
+// PushTokenRemovalReason tells notification-svc why a push token was removed
+type PushTokenRemovalReason string
+
+const (
+	// PushTokenUnregistered is a device that unregistered, e.g. on logout
+	PushTokenUnregistered PushTokenRemovalReason = "unregistered"
+	// PushTokenReassigned is a token registered again by another user or device, e.g. after
+	// another user signed in on a shared device
+	PushTokenReassigned PushTokenRemovalReason = "reassigned"
+	// PushTokenSessionsRevoked is a token of a user whose sessions were all revoked
+	PushTokenSessionsRevoked PushTokenRemovalReason = "sessions_revoked"
+)
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+)
+
+// QuotaPeriod is the length of a quota accounting window
+type QuotaPeriod string
+
+const (
+	QuotaPeriodDaily   QuotaPeriod = "daily"
+	QuotaPeriodMonthly QuotaPeriod = "monthly"
+)
+
+// QuotaAllMethods is the method name of the bucket counting every call of a subject
+const QuotaAllMethods = "*"
+
+// QuotaUsage represents the number of calls a subject made in a quota window
+type QuotaUsage struct {
+	Subject     string `json:"subject"`
+	Method      string `json:"method"`
+	Used        int64  `json:"used"`
+	Limit       int64  `json:"limit"`
+	WindowStart int64  `json:"windowStart"`
+	WindowEnd   int64  `json:"windowEnd"`
+}
+
+// Exceeded reports whether the usage is over a non-zero limit
+func (u *QuotaUsage) Exceeded() bool {
+	return u.Limit > 0 && u.Used > u.Limit
+}
+
+// Remaining returns the calls left in the window of a limited usage
+func (u *QuotaUsage) Remaining() int64 {
+	return max(u.Limit-u.Used, 0)
+}
+
+// Reached reports whether the usage reached percent of a non-zero limit
+func (u *QuotaUsage) Reached(percent int) bool {
+	return u.Limit > 0 && u.Used*100 >= u.Limit*int64(percent)
+}
+
+// QuotaWindow returns the UTC calendar window of the given period containing t
+func QuotaWindow(period QuotaPeriod, t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	if period == QuotaPeriodDaily {
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1)
+	}
+
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// CrossedThresholds returns the thresholds (in percent of limit) crossed by the
+// call that raised usage from used-1 to used
+func CrossedThresholds(used, limit int64, thresholds []int) []int {
+	if limit <= 0 {
+		return nil
+	}
+
+	crossed := make([]int, 0)
+	for _, threshold := range thresholds {
+		mark := limit * int64(threshold)
+		if (used-1)*100 < mark && used*100 >= mark {
+			crossed = append(crossed, threshold)
+		}
+	}
+
+	return crossed
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQuotaWindow(t *testing.T) {
+	now := time.Date(2024, time.February, 29, 23, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+
+	start, end := QuotaWindow(QuotaPeriodMonthly, now)
+	if !start.Equal(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected monthly window to start on Feb 1, got %v", start)
+	}
+	if !end.Equal(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected monthly window to end on Mar 1, got %v", end)
+	}
+
+	start, end = QuotaWindow(QuotaPeriodDaily, now)
+	if !start.Equal(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected daily window to start on Feb 29, got %v", start)
+	}
+	if end.Sub(start) != 24*time.Hour {
+		t.Errorf("Expected daily window to last 24h, got %v", end.Sub(start))
+	}
+}
+
+func TestCrossedThresholds(t *testing.T) {
+	thresholds := []int{50, 80, 100}
+
+	tests := []struct {
+		name  string
+		used  int64
+		limit int64
+		want  []int
+	}{
+		{"below first threshold", 4, 10, []int{}},
+		{"exactly at threshold", 5, 10, []int{50}},
+		{"past threshold", 6, 10, []int{}},
+		{"reaching limit", 10, 10, []int{100}},
+		{"over limit", 11, 10, []int{}},
+		{"several thresholds at once", 1, 1, []int{50, 80, 100}},
+		{"unlimited", 1000, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CrossedThresholds(tt.used, tt.limit, thresholds)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQuotaUsageExceeded(t *testing.T) {
+	if (&QuotaUsage{Used: 10, Limit: 10}).Exceeded() {
+		t.Error("Expected usage at the limit not to be exceeded")
+	}
+	if !(&QuotaUsage{Used: 11, Limit: 10}).Exceeded() {
+		t.Error("Expected usage over the limit to be exceeded")
+	}
+	if (&QuotaUsage{Used: 11}).Exceeded() {
+		t.Error("Expected unlimited usage never to be exceeded")
+	}
+}
+
+func TestQuotaUsageReached(t *testing.T) {
+	usage := &QuotaUsage{Used: 8, Limit: 10}
+	if !usage.Reached(80) || usage.Reached(90) {
+		t.Errorf("Expected 8 of 10 calls to reach 80%% but not 90%%")
+	}
+	if usage.Remaining() != 2 {
+		t.Errorf("Expected 2 remaining calls, got %d", usage.Remaining())
+	}
+	if remaining := (&QuotaUsage{Used: 11, Limit: 10}).Remaining(); remaining != 0 {
+		t.Errorf("Expected no remaining calls over the limit, got %d", remaining)
+	}
+	if (&QuotaUsage{Used: 11}).Reached(0) {
+		t.Error("Expected unlimited usage never to reach a warning")
+	}
+}
@@ -10,11 +10,16 @@ import (
 
 // RefreshToken represents a refresh token domain model
 type RefreshToken struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"userId"`
-	Token     string    `json:"token"`
-	ExpiresAt int64     `json:"expiresAt"`
-	IsRevoked bool      `json:"isRevoked"`
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"userId"`
+	Token      string    `json:"token"`
+	ExpiresAt  int64     `json:"expiresAt"`
+	IsRevoked  bool      `json:"isRevoked"`
+	ClientID   string    `json:"clientId,omitempty"`
+	DPoPJKT    string    `json:"dpopJkt,omitempty"`
+	RememberMe bool      `json:"rememberMe"`
+	// SessionID is kept by the tokens rotated from the token that started the session
+	SessionID uuid.UUID `json:"sessionId"`
 	CreatedAt int64     `json:"createdAt"`
 	UpdatedAt int64     `json:"updatedAt"`
 }
@@ -33,8 +38,10 @@ func NewRefreshToken(userID uuid.UUID, tokenHash string, expiresAt int64) (*Refr
 		return nil, errs.ErrTokenExpired
 	}
 
+	id := uuid.New()
 	return &RefreshToken{
-		ID:        uuid.New(),
+		ID:        id,
+		SessionID: id,
 		UserID:    userID,
 		Token:     tokenHash,
 		ExpiresAt: expiresAt,
@@ -44,6 +51,15 @@ func NewRefreshToken(userID uuid.UUID, tokenHash string, expiresAt int64) (*Refr
 	}, nil
 }
 
+// Session returns the ID of the session the token belongs to; tokens issued before sessions
+// were tracked start their own
+func (rt *RefreshToken) Session() uuid.UUID {
+	if rt.SessionID == uuid.Nil {
+		return rt.ID
+	}
+	return rt.SessionID
+}
+
 // IsValid checks if the refresh token is valid
 func (rt *RefreshToken) IsValid() error {
 	if rt.ID == uuid.Nil {
@@ -68,3 +84,31 @@ func (rt *RefreshToken) IsValid() error {
 
 	return nil
 }
+
+// CheckBinding verifies that a refresh presented with the given DPoP key thumbprint may use
+// this token. Sessions started with a DPoP proof stay bound to that key for their lifetime.
+func (rt *RefreshToken) CheckBinding(jkt string) error {
+	if rt.DPoPJKT == "" {
+		return nil
+	}
+
+	if jkt == "" {
+		return errs.ErrDPoPProofRequired
+	}
+
+	if jkt != rt.DPoPJKT {
+		return errs.ErrDPoPKeyMismatch
+	}
+
+	return nil
+}
+
+// RefreshTokenCleanup selects the refresh tokens a cleanup deletes: those that expired or
+// were revoked before Before, only the revoked ones with RevokedOnly, and only those of
+// UserID unless it is uuid.Nil
+type RefreshTokenCleanup struct {
+	UserID      uuid.UUID
+	RevokedOnly bool
+	// Before is a Unix timestamp in milliseconds
+	Before int64
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// RegistrationCodeLength is the number of digits of an emailed registration code
+const RegistrationCodeLength = 6
+
+// RegistrationCode verifies the email of a registration before its account is created. Only
+// the hash of the code, salted with the email, is stored; the code itself is emailed.
+type RegistrationCode struct {
+	Email     Email  `json:"email"`
+	CodeHash  string `json:"-"`
+	Attempts  int    `json:"attempts"`
+	ExpiresAt int64  `json:"expiresAt"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// NewRegistrationCode generates a code for the email valid for ttl and returns it along with
+// the record to store
+func NewRegistrationCode(email Email, ttl time.Duration, now time.Time, tokens TokenHasher) (string, *RegistrationCode, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(RegistrationCodeLength), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate registration code: %w", err)
+	}
+	code := fmt.Sprintf("%0*d", RegistrationCodeLength, n)
+
+	return code, &RegistrationCode{
+		Email:     email,
+		CodeHash:  HashRegistrationCode(email, code, tokens),
+		ExpiresAt: now.Add(ttl).UnixMilli(),
+		CreatedAt: now.UnixMilli(),
+	}, nil
+}
+
+// HashRegistrationCode returns the stored form of the code emailed to email
+func HashRegistrationCode(email Email, code string, tokens TokenHasher) string {
+	return tokens.Hash(email.String() + ":" + code)
+}
+
+// Matches reports whether code is the one emailed, in constant time
+func (c *RegistrationCode) Matches(code string, tokens TokenHasher) bool {
+	return subtle.ConstantTimeCompare([]byte(c.CodeHash), []byte(HashRegistrationCode(c.Email, code, tokens))) == 1
+}
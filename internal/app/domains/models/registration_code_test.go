@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRegistrationCode(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	email, _ := NewEmail("jane@tickets.example")
+
+	code, record, err := NewRegistrationCode(email, 10*time.Minute, now, sha256Tokens{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(code) != RegistrationCodeLength {
+		t.Errorf("Expected a code of %d digits, got %q", RegistrationCodeLength, code)
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			t.Fatalf("Expected a numeric code, got %q", code)
+		}
+	}
+	if !record.Matches(code, sha256Tokens{}) {
+		t.Errorf("Expected the code to match its record")
+	}
+	if record.Matches("abcdef", sha256Tokens{}) {
+		t.Errorf("Expected another code not to match")
+	}
+	if record.ExpiresAt != now.Add(10*time.Minute).UnixMilli() {
+		t.Errorf("Expected expiry %d, got %d", now.Add(10*time.Minute).UnixMilli(), record.ExpiresAt)
+	}
+
+	other, _ := NewEmail("john@tickets.example")
+	if HashRegistrationCode(other, code, sha256Tokens{}) == record.CodeHash {
+		t.Errorf("Expected the hash to depend on the email")
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestRegistrationGates_Check(t *testing.T) {
+	gates := RegistrationGates{
+		InviteCodeRequired: true,
+		InviteCodes:        []string{"LAUNCH-2026"},
+		AllowlistOnly:      true,
+		Allowlist:          []string{"tickets.example", "jane@partner.example"},
+	}
+
+	tests := []struct {
+		name       string
+		email      Email
+		inviteCode string
+		referred   bool
+		expected   error
+	}{
+		{name: "allowlisted domain", email: "ops@tickets.example", inviteCode: "launch-2026"},
+		{name: "allowlisted subdomain", email: "ops@eu.tickets.example", inviteCode: "LAUNCH-2026"},
+		{name: "allowlisted address", email: "Jane@partner.example", inviteCode: "LAUNCH-2026"},
+		{name: "other address of the domain", email: "john@partner.example", inviteCode: "LAUNCH-2026", expected: errs.ErrEmailNotAllowlisted},
+		{name: "lookalike domain", email: "ops@nottickets.example", inviteCode: "LAUNCH-2026", expected: errs.ErrEmailNotAllowlisted},
+		{name: "no invite code", email: "ops@tickets.example", expected: errs.ErrInviteCodeRequired},
+		{name: "unknown invite code", email: "ops@tickets.example", inviteCode: "LAUNCH-2025", expected: errs.ErrInvalidInviteCode},
+		{name: "referral", email: "ops@tickets.example", inviteCode: "MFRGGZDFMZTWQ", referred: true},
+		{name: "referral not allowlisted", email: "ops@elsewhere.example", inviteCode: "MFRGGZDFMZTWQ", referred: true, expected: errs.ErrEmailNotAllowlisted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := gates.Check(tt.email, tt.inviteCode, tt.referred); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	if err := (&RegistrationGates{}).Check("anyone@elsewhere.example", "", false); err != nil {
+		t.Errorf("Expected registration to be open without gates, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// RiskSignals are aggregated account signals other services use to score a user, e.g. the
+// anti-scalping engine of booking-svc
+type RiskSignals struct {
+	UserID    string `json:"userId"`
+	CreatedAt int64  `json:"createdAt"`
+	// AccountAgeDays is the number of whole days since registration
+	AccountAgeDays int64 `json:"accountAgeDays"`
+	// DeviceCount is the number of distinct user agents the user logged in from recently
+	DeviceCount     int64 `json:"deviceCount"`
+	DisposableEmail bool  `json:"disposableEmail"`
+	// RegistrationIPReuseCount is the number of other users registered from the same IP address
+	RegistrationIPReuseCount int64 `json:"registrationIpReuseCount"`
+	// SessionAnomalyCount is the number of sessions of the user refreshed from two countries
+	// within minutes or at impossible rates recently
+	SessionAnomalyCount int64 `json:"sessionAnomalyCount"`
+	GeneratedAt         int64 `json:"generatedAt"`
+}
+
+// AccountAgeDays returns the number of whole days between createdAt and now, both in Unix milliseconds
+func AccountAgeDays(createdAt int64, now time.Time) int64 {
+	age := now.Sub(time.UnixMilli(createdAt))
+	if age < 0 {
+		return 0
+	}
+	return int64(age / Day)
+}
+
+// EmailDomainCandidates returns the domain of an email address and its parent domains, so a
+// listed domain also matches its subdomains: "a@x.mailinator.com" yields x.mailinator.com and mailinator.com
+func EmailDomainCandidates(email string) []string {
+	_, domain, found := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if !found || domain == "" {
+		return nil
+	}
+
+	candidates := []string{domain}
+	for {
+		_, parent, found := strings.Cut(domain, ".")
+		if !found || !strings.Contains(parent, ".") {
+			return candidates
+		}
+		candidates = append(candidates, parent)
+		domain = parent
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAccountAgeDays(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	if age := AccountAgeDays(now.Add(-49*time.Hour).UnixMilli(), now); age != 2 {
+		t.Errorf("Expected 2 days, got %d", age)
+	}
+	if age := AccountAgeDays(now.Add(time.Hour).UnixMilli(), now); age != 0 {
+		t.Errorf("Expected accounts from the future to be 0 days old, got %d", age)
+	}
+}
+
+func TestEmailDomainCandidates(t *testing.T) {
+	candidates := EmailDomainCandidates("Jane@X.Mailinator.com")
+	expected := []string{"x.mailinator.com", "mailinator.com"}
+	if !slices.Equal(candidates, expected) {
+		t.Errorf("Expected %v, got %v", expected, candidates)
+	}
+
+	if candidates := EmailDomainCandidates("not-an-email"); candidates != nil {
+		t.Errorf("Expected no candidates, got %v", candidates)
+	}
+}
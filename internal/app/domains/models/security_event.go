@@ -0,0 +1,82 @@
+package models
+
+// SecurityAction is an authentication or account event streamed to the SIEM
+type SecurityAction string
+
+const (
+	// SecurityActionLogin is a password login
+	SecurityActionLogin SecurityAction = "login"
+	// SecurityActionRegister is a self-service registration
+	SecurityActionRegister SecurityAction = "register"
+	// SecurityActionTokenRefresh is a refresh token exchanged for new tokens
+	SecurityActionTokenRefresh SecurityAction = "token_refresh"
+	// SecurityActionLogout is the end of a session by its user
+	SecurityActionLogout SecurityAction = "logout"
+	// SecurityActionTokensRevoked is the revocation of every session of a user
+	SecurityActionTokensRevoked SecurityAction = "tokens_revoked"
+	// SecurityActionPasswordSetUp is the first password set by an invited user
+	SecurityActionPasswordSetUp SecurityAction = "password_set_up"
+	// SecurityActionPasswordReset is a forgotten password replaced with an emailed reset link
+	SecurityActionPasswordReset SecurityAction = "password_reset"
+	// SecurityActionCanaryAccess is a login or refresh on a canary account
+	SecurityActionCanaryAccess SecurityAction = "canary_access"
+	// SecurityActionIDTokenLogin is a login with the ID token of an OpenID Connect provider
+	SecurityActionIDTokenLogin SecurityAction = "id_token_login"
+)
+
+// SecurityOutcome is whether the attempt of a security event succeeded
+type SecurityOutcome string
+
+const (
+	SecurityOutcomeSuccess SecurityOutcome = "success"
+	SecurityOutcomeFailure SecurityOutcome = "failure"
+	// SecurityOutcomeUnknown is an event raised before the attempt completes, e.g. a canary access
+	SecurityOutcomeUnknown SecurityOutcome = "unknown"
+)
+
+// SecuritySeverity ranks security events for triage, numbered as the OCSF severity_id
+type SecuritySeverity int
+
+const (
+	SecuritySeverityInformational SecuritySeverity = 1
+	SecuritySeverityLow           SecuritySeverity = 2
+	SecuritySeverityMedium        SecuritySeverity = 3
+	SecuritySeverityHigh          SecuritySeverity = 4
+	SecuritySeverityCritical      SecuritySeverity = 5
+)
+
+func (s SecuritySeverity) String() string {
+	switch s {
+	case SecuritySeverityInformational:
+		return "Informational"
+	case SecuritySeverityLow:
+		return "Low"
+	case SecuritySeverityMedium:
+		return "Medium"
+	case SecuritySeverityHigh:
+		return "High"
+	case SecuritySeverityCritical:
+		return "Critical"
+	}
+	return "Unknown"
+}
+
+// SecurityEvent is an authentication or account event in the normalized form the SIEM
+// stream formats as ECS or OCSF
+type SecurityEvent struct {
+	Action   SecurityAction
+	Outcome  SecurityOutcome
+	Severity SecuritySeverity
+	// Reason tells why a failed attempt failed, e.g. "invalid credentials"
+	Reason string
+	// UserID is empty when the attempt could not be tied to a user, e.g. an unknown email
+	UserID string
+	// Email is the identity the attempt was made for
+	Email          string
+	OrganizationID string
+	ClientID       string
+	IPAddress      string
+	UserAgent      string
+	// At is a Unix timestamp in milliseconds
+	At int64
+}
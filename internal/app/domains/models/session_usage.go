@@ -0,0 +1,132 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionAnomalyKind is a pattern of refresh token use that suggests a stolen session
+type SessionAnomalyKind string
+
+const (
+	// SessionAnomalyCountryChange is a session used from another country shortly after its
+	// previous use, faster than anyone can travel
+	SessionAnomalyCountryChange SessionAnomalyKind = "country_change"
+	// SessionAnomalyRefreshRate is a session refreshed more often than any client needs to
+	SessionAnomalyRefreshRate SessionAnomalyKind = "refresh_rate"
+)
+
+// SessionAnomalyRules decide which refreshes are anomalies
+type SessionAnomalyRules struct {
+	// CountryChangeWindow is how soon after the previous use a use from another country is flagged
+	CountryChangeWindow time.Duration
+	// RateWindow and MaxRefreshesPerWindow flag more than MaxRefreshesPerWindow refreshes
+	// within RateWindow, once per window
+	RateWindow            time.Duration
+	MaxRefreshesPerWindow int64
+}
+
+// SessionIdleTimeout returns the strictest of the idle timeouts set by the service, the client
+// and the organization of a session, the shortest positive one; 0 when none is set
+func SessionIdleTimeout(timeouts ...time.Duration) time.Duration {
+	var strictest time.Duration
+	for _, timeout := range timeouts {
+		if timeout > 0 && (strictest == 0 || timeout < strictest) {
+			strictest = timeout
+		}
+	}
+	return strictest
+}
+
+// SessionUse is a login or a refresh of a session
+type SessionUse struct {
+	At        time.Time
+	IPAddress string
+	// Country is the ISO 3166 code of the caller reported by the edge proxy, "" if unknown
+	Country string
+}
+
+// SessionAnomaly is an anomaly found by SessionUsage.RecordRefresh
+type SessionAnomaly struct {
+	Kind SessionAnomalyKind
+	// PreviousCountry is set for SessionAnomalyCountryChange
+	PreviousCountry string
+	// Refreshes is the number of refreshes in the current rate window
+	Refreshes int64
+}
+
+// SessionUsage tracks how a session, a refresh token and the tokens rotated from it, is used
+type SessionUsage struct {
+	SessionID    uuid.UUID `json:"sessionId"`
+	UserID       uuid.UUID `json:"userId"`
+	ClientID     string    `json:"clientId"`
+	StartedAt    int64     `json:"startedAt"`
+	RefreshCount int64     `json:"refreshCount"`
+	// LastUsedAt is the time of the login or the latest refresh
+	LastUsedAt  int64  `json:"lastUsedAt"`
+	LastIP      string `json:"lastIp"`
+	LastCountry string `json:"lastCountry"`
+	// LastActiveAt is the time of the latest verification of an access token of the session,
+	// 0 until one; unlike refreshes, it carries no address to detect anomalies with
+	LastActiveAt int64 `json:"lastActiveAt"`
+	// WindowStart and WindowRefreshes count the refreshes of the current rate window
+	WindowStart     int64 `json:"windowStart"`
+	WindowRefreshes int64 `json:"windowRefreshes"`
+	AnomalyCount    int64 `json:"anomalyCount"`
+	// LastAnomaly is empty and LastAnomalyAt 0 until an anomaly is found
+	LastAnomaly   SessionAnomalyKind `json:"lastAnomaly"`
+	LastAnomalyAt int64              `json:"lastAnomalyAt"`
+}
+
+// NewSessionUsage starts tracking a session at its first use
+func NewSessionUsage(sessionID, userID uuid.UUID, clientID string, use SessionUse) *SessionUsage {
+	return &SessionUsage{
+		SessionID:   sessionID,
+		UserID:      userID,
+		ClientID:    clientID,
+		StartedAt:   use.At.UnixMilli(),
+		LastUsedAt:  use.At.UnixMilli(),
+		LastIP:      use.IPAddress,
+		LastCountry: use.Country,
+	}
+}
+
+// IsIdle reports whether the session went unused and its access tokens unverified for longer
+// than timeout at now; a zero timeout never idles a session out
+func (u *SessionUsage) IsIdle(now time.Time, timeout time.Duration) bool {
+	return timeout > 0 && now.UnixMilli()-max(u.LastUsedAt, u.LastActiveAt) > timeout.Milliseconds()
+}
+
+// RecordRefresh counts a refresh of the session and returns the time since its previous use
+// along with the anomalies the refresh shows
+func (u *SessionUsage) RecordRefresh(use SessionUse, rules SessionAnomalyRules) (time.Duration, []SessionAnomaly) {
+	at := use.At.UnixMilli()
+	interval := time.Duration(at-u.LastUsedAt) * time.Millisecond
+
+	var anomalies []SessionAnomaly
+	if use.Country != "" && u.LastCountry != "" && use.Country != u.LastCountry && interval <= rules.CountryChangeWindow {
+		anomalies = append(anomalies, SessionAnomaly{Kind: SessionAnomalyCountryChange, PreviousCountry: u.LastCountry})
+	}
+
+	if u.WindowStart == 0 || at-u.WindowStart >= rules.RateWindow.Milliseconds() {
+		u.WindowStart, u.WindowRefreshes = at, 0
+	}
+	u.WindowRefreshes++
+	if u.WindowRefreshes == rules.MaxRefreshesPerWindow+1 {
+		anomalies = append(anomalies, SessionAnomaly{Kind: SessionAnomalyRefreshRate, Refreshes: u.WindowRefreshes})
+	}
+
+	u.RefreshCount++
+	u.LastUsedAt = at
+	u.LastIP = use.IPAddress
+	if use.Country != "" {
+		u.LastCountry = use.Country
+	}
+	for _, anomaly := range anomalies {
+		u.AnomalyCount++
+		u.LastAnomaly, u.LastAnomalyAt = anomaly.Kind, at
+	}
+
+	return interval, anomalies
+}
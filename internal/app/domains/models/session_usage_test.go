@@ -0,0 +1,130 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var testSessionRules = SessionAnomalyRules{
+	CountryChangeWindow:   30 * time.Minute,
+	RateWindow:            time.Minute,
+	MaxRefreshesPerWindow: 3,
+}
+
+func TestSessionUsage_CountryChange(t *testing.T) {
+	start := time.UnixMilli(1760616000000)
+	usage := NewSessionUsage(uuid.New(), uuid.New(), "web", SessionUse{At: start, IPAddress: "198.51.100.7", Country: "DE"})
+
+	tests := []struct {
+		name     string
+		after    time.Duration
+		country  string
+		expected []SessionAnomalyKind
+	}{
+		{name: "same country", after: 15 * time.Minute, country: "DE"},
+		{name: "unknown country", after: 16 * time.Minute, country: ""},
+		{name: "other country within the window", after: 20 * time.Minute, country: "BR", expected: []SessionAnomalyKind{SessionAnomalyCountryChange}},
+		{name: "other country after the window", after: 60 * time.Minute, country: "DE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, anomalies := usage.RecordRefresh(SessionUse{At: start.Add(tt.after), Country: tt.country}, testSessionRules)
+			if len(anomalies) != len(tt.expected) {
+				t.Fatalf("Expected anomalies %v, got %+v", tt.expected, anomalies)
+			}
+			for i, anomaly := range anomalies {
+				if anomaly.Kind != tt.expected[i] {
+					t.Errorf("Expected anomaly %s, got %s", tt.expected[i], anomaly.Kind)
+				}
+			}
+		})
+	}
+
+	if usage.RefreshCount != 4 || usage.AnomalyCount != 1 || usage.LastCountry != "DE" {
+		t.Errorf("Expected 4 refreshes, 1 anomaly and DE as last country, got %+v", usage)
+	}
+	if usage.LastAnomaly != SessionAnomalyCountryChange || usage.LastAnomalyAt != start.Add(20*time.Minute).UnixMilli() {
+		t.Errorf("Expected the country change as last anomaly, got %s at %d", usage.LastAnomaly, usage.LastAnomalyAt)
+	}
+}
+
+func TestSessionUsage_RefreshRate(t *testing.T) {
+	start := time.UnixMilli(1760616000000)
+	usage := NewSessionUsage(uuid.New(), uuid.New(), "mobile", SessionUse{At: start})
+
+	var flagged []int
+	for i := 1; i <= 6; i++ {
+		interval, anomalies := usage.RecordRefresh(SessionUse{At: start.Add(time.Duration(i) * 5 * time.Second)}, testSessionRules)
+		if interval != 5*time.Second {
+			t.Errorf("Expected 5s since the previous use, got %v", interval)
+		}
+		if len(anomalies) > 0 {
+			if anomalies[0].Kind != SessionAnomalyRefreshRate || anomalies[0].Refreshes != 4 {
+				t.Errorf("Expected a refresh rate anomaly at 4 refreshes, got %+v", anomalies[0])
+			}
+			flagged = append(flagged, i)
+		}
+	}
+	// Flagged once per window
+	if len(flagged) != 1 || flagged[0] != 4 {
+		t.Errorf("Expected only the 4th refresh to be flagged, got %v", flagged)
+	}
+
+	// A new window starts counting again
+	if _, anomalies := usage.RecordRefresh(SessionUse{At: start.Add(2 * time.Minute)}, testSessionRules); len(anomalies) != 0 {
+		t.Errorf("Expected no anomaly in a new window, got %+v", anomalies)
+	}
+	if usage.WindowRefreshes != 1 || usage.RefreshCount != 7 {
+		t.Errorf("Expected 1 refresh in the window and 7 in total, got %d and %d", usage.WindowRefreshes, usage.RefreshCount)
+	}
+}
+
+func TestSessionUsage_IsIdle(t *testing.T) {
+	start := time.UnixMilli(1760616000000)
+	usage := NewSessionUsage(uuid.New(), uuid.New(), "web", SessionUse{At: start})
+	usage.RecordRefresh(SessionUse{At: start.Add(20 * time.Minute)}, testSessionRules)
+
+	tests := []struct {
+		name     string
+		after    time.Duration
+		timeout  time.Duration
+		expected bool
+	}{
+		{name: "no timeout", after: 30 * 24 * time.Hour},
+		{name: "used within the timeout", after: 50 * time.Minute, timeout: 30 * time.Minute},
+		{name: "unused for exactly the timeout", after: 50 * time.Minute, timeout: 30 * time.Minute},
+		{name: "unused for longer than the timeout", after: 51 * time.Minute, timeout: 30 * time.Minute, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if idle := usage.IsIdle(start.Add(tt.after), tt.timeout); idle != tt.expected {
+				t.Errorf("Expected idle %v, got %v", tt.expected, idle)
+			}
+		})
+	}
+}
+
+func TestSessionIdleTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeouts []time.Duration
+		expected time.Duration
+	}{
+		{name: "none set", timeouts: []time.Duration{0, 0, 0}},
+		{name: "service default only", timeouts: []time.Duration{8 * time.Hour, 0, 0}, expected: 8 * time.Hour},
+		{name: "organization stricter than the client", timeouts: []time.Duration{0, time.Hour, 30 * time.Minute}, expected: 30 * time.Minute},
+		{name: "client stricter than the service", timeouts: []time.Duration{8 * time.Hour, 15 * time.Minute, 0}, expected: 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if timeout := SessionIdleTimeout(tt.timeouts...); timeout != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, timeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import "encoding/json"
+
+// SnapshotFormatVersion is the version of the record format of snapshots
+const SnapshotFormatVersion = 1
+
+// SnapshotTables are the tables of a snapshot in restore order: roles are a column of
+// users, and sessions are their refresh tokens
+var SnapshotTables = []string{"organizations", "users", "refresh_tokens"}
+
+// SnapshotRecord is one line of a snapshot: the header, a row of a table or the trailer
+type SnapshotRecord struct {
+	Header  *SnapshotHeader  `json:"header,omitempty"`
+	Table   string           `json:"table,omitempty"`
+	Row     json.RawMessage  `json:"row,omitempty"`
+	Trailer *SnapshotTrailer `json:"trailer,omitempty"`
+}
+
+// SnapshotHeader starts a snapshot. Rows carry every column, so they can only be restored
+// into a database at the same schema version.
+type SnapshotHeader struct {
+	FormatVersion int   `json:"format_version"`
+	SchemaVersion int   `json:"schema_version"`
+	CreatedAt     int64 `json:"created_at"`
+}
+
+// SnapshotTrailer ends a snapshot with the number of rows of every table
+type SnapshotTrailer struct {
+	Rows map[string]int64 `json:"rows"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Day is the length of a daily statistics bucket; buckets start at UTC midnight
+const Day = 24 * time.Hour
+
+// DailyCount is an aggregate of one UTC day, keyed by the day's start in Unix milliseconds
+type DailyCount struct {
+	Day   int64 `json:"day"`
+	Count int64 `json:"count"`
+}
+
+// DailyUserStats holds the activity of a single UTC day
+type DailyUserStats struct {
+	// Day is the start of the day in Unix milliseconds
+	Day           int64 `json:"day"`
+	Registrations int64 `json:"registrations"`
+	Logins        int64 `json:"logins"`
+	// ActiveUsers is the number of distinct users who logged in that day
+	ActiveUsers int64 `json:"activeUsers"`
+}
+
+// UserStats summarizes the user base for the ops dashboard
+type UserStats struct {
+	GeneratedAt int64 `json:"generatedAt"`
+	// From and To bound the daily buckets, [From, To) in Unix milliseconds
+	From       int64 `json:"from"`
+	To         int64 `json:"to"`
+	TotalUsers int64 `json:"totalUsers"`
+	// ActiveSessions counts unrevoked, unexpired refresh tokens
+	ActiveSessions          int64             `json:"activeSessions"`
+	UsersWithActiveSessions int64             `json:"usersWithActiveSessions"`
+	Daily                   []*DailyUserStats `json:"daily"`
+}
+
+// StatsWindow returns the bounds of the last days UTC days, including today
+func StatsWindow(now time.Time, days int) (time.Time, time.Time) {
+	today := now.UTC().Truncate(Day)
+	return today.AddDate(0, 0, -(days - 1)), today.Add(Day)
+}
+
+// DailyUserStatsBuckets returns one bucket per day in [from, to), filled from the daily
+// aggregates; days without activity are zero
+func DailyUserStatsBuckets(from, to time.Time, registrations, logins, activeUsers []*DailyCount) []*DailyUserStats {
+	buckets := make([]*DailyUserStats, 0, int(to.Sub(from)/Day))
+	byDay := make(map[int64]*DailyUserStats)
+	for day := from; day.Before(to); day = day.Add(Day) {
+		bucket := &DailyUserStats{Day: day.UnixMilli()}
+		buckets = append(buckets, bucket)
+		byDay[bucket.Day] = bucket
+	}
+
+	for _, count := range registrations {
+		if bucket, ok := byDay[count.Day]; ok {
+			bucket.Registrations = count.Count
+		}
+	}
+	for _, count := range logins {
+		if bucket, ok := byDay[count.Day]; ok {
+			bucket.Logins = count.Count
+		}
+	}
+	for _, count := range activeUsers {
+		if bucket, ok := byDay[count.Day]; ok {
+			bucket.ActiveUsers = count.Count
+		}
+	}
+
+	return buckets
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsWindow(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 4, 5, 0, time.UTC)
+
+	from, to := StatsWindow(now, 7)
+	if !from.Equal(time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the window to start 6 days before today, got %v", from)
+	}
+	if !to.Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the window to end after today, got %v", to)
+	}
+}
+
+func TestDailyUserStatsBuckets(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * Day)
+	second := from.Add(Day).UnixMilli()
+
+	buckets := DailyUserStatsBuckets(from, to,
+		[]*DailyCount{{Day: second, Count: 4}},
+		[]*DailyCount{{Day: second, Count: 10}, {Day: to.UnixMilli(), Count: 99}},
+		[]*DailyCount{{Day: second, Count: 3}},
+	)
+
+	if len(buckets) != 3 {
+		t.Fatalf("Expected 3 daily buckets, got %d", len(buckets))
+	}
+	if buckets[0].Day != from.UnixMilli() || buckets[0].Registrations != 0 || buckets[0].Logins != 0 {
+		t.Errorf("Expected an empty first day, got %+v", buckets[0])
+	}
+	if buckets[1].Registrations != 4 || buckets[1].Logins != 10 || buckets[1].ActiveUsers != 3 {
+		t.Errorf("Expected the second day to be filled, got %+v", buckets[1])
+	}
+	if buckets[2].Logins != 0 {
+		t.Errorf("Expected counts outside the window to be ignored, got %+v", buckets[2])
+	}
+}
@@ -0,0 +1,9 @@
+package models
+
+// TokenHasher is the domain service hashing the secrets the service only stores hashed: the
+// password reset, email verification and password setup tokens, the registration codes, the
+// canonical emails of invite codes and the configured API keys. Stored hashes are looked up by the hash of the secret
+// presented, so changing the algorithm invalidates the secrets outstanding at the time.
+type TokenHasher interface {
+	Hash(secret string) string
+}
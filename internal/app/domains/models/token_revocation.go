@@ -0,0 +1,34 @@
+package models
+
+// TokenRevocationKind is what a token revocation applies to
+type TokenRevocationKind string
+
+const (
+	// TokenRevocationKindUser revokes every access token issued to a user up to RevokedAt
+	TokenRevocationKindUser TokenRevocationKind = "user"
+	// TokenRevocationKindJTI revokes a single access token by its ID
+	TokenRevocationKindJTI TokenRevocationKind = "jti"
+	// TokenRevocationKindClient revokes every access token issued to a user for a client up to
+	// RevokedAt, with ClientRevocationSubject as the subject
+	TokenRevocationKindClient TokenRevocationKind = "client"
+	// TokenRevocationKindGlobal revokes every access and refresh token issued up to RevokedAt,
+	// with TokenRevocationSubjectAll as the subject
+	TokenRevocationKindGlobal TokenRevocationKind = "global"
+)
+
+// TokenRevocationSubjectAll is the subject of global revocations
+const TokenRevocationSubjectAll = "all"
+
+// ClientRevocationSubject is the subject of the revocation of a client's access to a user
+func ClientRevocationSubject(userID, clientID string) string {
+	return userID + ":" + clientID
+}
+
+// TokenRevocation represents a revocation that every replica must honor until ExpiresAt,
+// after which no affected access token can be valid anymore
+type TokenRevocation struct {
+	Kind      TokenRevocationKind `json:"kind"`
+	Subject   string              `json:"subject"`
+	RevokedAt int64               `json:"revokedAt"`
+	ExpiresAt int64               `json:"expiresAt"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"user-svc/internal/app/domains/errs"
@@ -8,14 +9,79 @@ import (
 	"github.com/google/uuid"
 )
 
+// UserStatus is the lifecycle state of an account
+type UserStatus string
+
+const (
+	UserStatusActive UserStatus = "active"
+	// UserStatusPending accounts exist without credentials and have not been invited yet, e.g.
+	// staff added to an organization or imported users before their invitation goes out
+	UserStatusPending UserStatus = "pending"
+	// UserStatusInvited accounts were created for the user, who must set a password before logging in
+	UserStatusInvited UserStatus = "invited"
+	// UserStatusBanned accounts cannot log in or refresh their sessions
+	UserStatusBanned UserStatus = "banned"
+)
+
+// userStatusTransitions lists the statuses each status may change to. Accounts without
+// credentials only become active by setting a password, and never go back to pending.
+var userStatusTransitions = map[UserStatus][]UserStatus{
+	UserStatusPending: {UserStatusInvited, UserStatusActive, UserStatusBanned},
+	UserStatusInvited: {UserStatusActive, UserStatusBanned},
+	UserStatusActive:  {UserStatusBanned},
+	UserStatusBanned:  {UserStatusActive, UserStatusInvited},
+}
+
+// IsAssignable reports whether admins may set the status; pending and invited are only set
+// by invitations and imports
+func (s UserStatus) IsAssignable() bool {
+	return s == UserStatusActive || s == UserStatusBanned
+}
+
+// CanTransitionTo reports whether the status may change to next; keeping a status is allowed
+func (s UserStatus) CanTransitionTo(next UserStatus) bool {
+	return s == next || slices.Contains(userStatusTransitions[s], next)
+}
+
+// UserStatusChange is the status of a user before and after an update
+type UserStatusChange struct {
+	Previous UserStatus
+	Current  UserStatus
+}
+
+// UserRole is the role of a user, carried in access tokens for other services to authorize with
+type UserRole string
+
+const (
+	UserRoleCustomer UserRole = "customer"
+	UserRoleStaff    UserRole = "staff"
+	UserRoleAdmin    UserRole = "admin"
+)
+
+// IsValid reports whether the role is known
+func (r UserRole) IsValid() bool {
+	return r == UserRoleCustomer || r == UserRoleStaff || r == UserRoleAdmin
+}
+
 // User represents a user in the authentication system
 type User struct {
 	ID           uuid.UUID    `json:"id" `
 	Email        Email        `json:"email" `
 	Username     Username     `json:"username" `
 	PasswordHash PasswordHash `json:"-" `
-	CreatedAt    int64        `json:"created_at" `
-	UpdatedAt    int64        `json:"updated_at" `
+	Status       UserStatus   `json:"status" `
+	Role         UserRole     `json:"role" `
+	// OrganizationID is the organization the user is a staff member of, uuid.Nil for none
+	OrganizationID uuid.UUID `json:"organization_id" `
+	// Residency is the region the user's data is stored in, set at registration; "" for users
+	// registered before residency was enabled, whose data stays in the home region
+	Residency string `json:"residency" `
+	CreatedAt int64  `json:"created_at" `
+	UpdatedAt int64  `json:"updated_at" `
+	// LastLoginAt is when the user last logged in with a password, 0 for never
+	LastLoginAt int64 `json:"last_login_at" `
+	// InactiveSince is when the account was flagged inactive, 0 while the user is active
+	InactiveSince int64 `json:"inactive_since" `
 }
 
 // NewUser creates a new user with generated ID and timestamps
@@ -47,13 +113,16 @@ func NewUser(email, passwordHash, username string) (*User, error) {
 		Email:        emailObj,
 		PasswordHash: passwordHashObj,
 		Username:     usernameObj,
+		Status:       UserStatusActive,
+		Role:         UserRoleCustomer,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}, nil
 }
 
-// NewUserWithPassword creates a new user with password validation
-func NewUserWithPassword(email, password, username string) (*User, error) {
+// NewUserWithPassword creates a new user with password validation, hashing the password with
+// passwords
+func NewUserWithPassword(email, password, username string, passwords PasswordHasher) (*User, error) {
 	if email == "" {
 		return nil, errs.ErrEmailIsRequired
 	}
@@ -76,7 +145,7 @@ func NewUserWithPassword(email, password, username string) (*User, error) {
 	}
 
 	// Hash the password
-	passwordHash, err := NewPasswordHashFromPlain(string(pwd))
+	passwordHash, err := passwords.Hash(string(pwd))
 	if err != nil {
 		return nil, err
 	}
@@ -88,11 +157,120 @@ func NewUserWithPassword(email, password, username string) (*User, error) {
 		Email:        emailObj,
 		PasswordHash: passwordHash,
 		Username:     usernameObj,
+		Status:       UserStatusActive,
+		Role:         UserRoleCustomer,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}, nil
 }
 
+// NewInvitedUser creates a user without a password, who must set one with a password setup
+// token before the first login
+func NewInvitedUser(email, username string) (*User, error) {
+	user, err := NewPendingUser(email, username)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.TransitionTo(UserStatusInvited); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// NewPendingUser creates a user without a password who is not invited yet, so the account
+// can be set up, e.g. given its organization and role, before the invitation goes out
+func NewPendingUser(email, username string) (*User, error) {
+	if email == "" {
+		return nil, errs.ErrEmailIsRequired
+	}
+
+	emailObj, err := NewEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	usernameObj, err := NewUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	return &User{
+		ID:        uuid.New(),
+		Email:     emailObj,
+		Username:  usernameObj,
+		Status:    UserStatusPending,
+		Role:      UserRoleCustomer,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// TransitionTo changes the status of the user, failing with ErrInvalidUserStatusTransition
+// for a change its current status does not allow
+func (u *User) TransitionTo(status UserStatus) error {
+	if !u.Status.CanTransitionTo(status) {
+		return errs.ErrInvalidUserStatusTransition
+	}
+	if u.Status != status {
+		u.Status = status
+		u.UpdatedAt = time.Now().UnixMilli()
+	}
+	return nil
+}
+
+// Activate sets the first password of a user without credentials and makes the account active
+func (u *User) Activate(passwordHash PasswordHash) error {
+	if u.Status != UserStatusPending && u.Status != UserStatusInvited {
+		return errs.ErrInvalidUserStatusTransition
+	}
+	if err := passwordHash.Validate(); err != nil {
+		return err
+	}
+	u.PasswordHash = passwordHash
+	return u.TransitionTo(UserStatusActive)
+}
+
+// CheckCredentials fails with an error telling the user how to go on if the account has no
+// password to log in with yet
+func (u *User) CheckCredentials() error {
+	switch u.Status {
+	case UserStatusPending:
+		return errs.ErrAccountPending
+	case UserStatusInvited:
+		return errs.ErrPasswordSetupRequired
+	}
+	return nil
+}
+
+// CheckCanAuthenticate fails if the user may not log in or refresh a session, because the
+// account has no credentials yet or is banned
+func (u *User) CheckCanAuthenticate() error {
+	if err := u.CheckCredentials(); err != nil {
+		return err
+	}
+	if u.Status == UserStatusBanned {
+		return errs.ErrUserBanned
+	}
+	return nil
+}
+
+// LastSeenAt is when the user last logged in, or registered for users who never logged in
+func (u *User) LastSeenAt() int64 {
+	return max(u.LastLoginAt, u.CreatedAt)
+}
+
+// NeedsEmailReverification reports whether the user returns after an absence longer than
+// absence, 0 for none, without having verified the email within it. claim is the user's
+// claim on the email, nil if it was never verified.
+func (u *User) NeedsEmailReverification(claim *EmailClaim, absence time.Duration, now time.Time) bool {
+	if absence <= 0 || now.Sub(time.UnixMilli(u.LastSeenAt())) <= absence {
+		return false
+	}
+	return claim == nil || now.Sub(time.UnixMilli(claim.VerifiedAt)) > absence
+}
+
 // IsValid checks if the user data is valid
 func (u *User) IsValid() error {
 	if u.Email == "" {
@@ -110,3 +288,10 @@ func (u *User) IsValid() error {
 
 	return nil
 }
+
+// UserCursor is a position in the users ordered by creation: after the user created at
+// CreatedAt with ID. The zero cursor is before the first user.
+type UserCursor struct {
+	CreatedAt int64
+	ID        uuid.UUID
+}
@@ -0,0 +1,244 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+// UserEventType is a change that happened to a user
+type UserEventType string
+
+const (
+	// UserEventRegistered and UserEventImported start a user's stream and carry UserCreatedData
+	UserEventRegistered UserEventType = "user.registered"
+	UserEventImported   UserEventType = "user.imported"
+	// UserEventBackfilled starts the stream of a user created before events were recorded
+	UserEventBackfilled  UserEventType = "user.backfilled"
+	UserEventPasswordSet UserEventType = "user.password_set_up"
+	// UserEventPasswordReset is a forgotten password replaced with an emailed reset link
+	UserEventPasswordReset UserEventType = "user.password_reset"
+	// UserEventRoleGranted carries RoleGrantedData
+	UserEventRoleGranted UserEventType = "user.role_granted"
+	// UserEventBanned and UserEventUnbanned carry StatusChangedData
+	UserEventBanned   UserEventType = "user.banned"
+	UserEventUnbanned UserEventType = "user.unbanned"
+	// UserEventLegalHoldPlaced and UserEventLegalHoldReleased carry LegalHoldData
+	UserEventLegalHoldPlaced   UserEventType = "user.legal_hold_placed"
+	UserEventLegalHoldReleased UserEventType = "user.legal_hold_released"
+	// UserEventMetadataUpdated carries MetadataUpdatedData
+	UserEventMetadataUpdated UserEventType = "user.metadata_updated"
+	// UserEventEmailClaimed carries EmailClaimedData; it follows the creation of users who
+	// verified owning their email, for services to attach guest activity under it
+	UserEventEmailClaimed UserEventType = "user.email_claimed"
+)
+
+// UserEventActorSelf is the actor of changes users made to their own account
+const UserEventActorSelf = "self"
+
+// UserEvent is one entry of the append-only history of a user. Version numbers the events of
+// a user from 1 and is assigned when the event is stored.
+type UserEvent struct {
+	ID      uuid.UUID       `json:"id"`
+	UserID  uuid.UUID       `json:"userId"`
+	Version int64           `json:"version"`
+	Type    UserEventType   `json:"type"`
+	Data    json.RawMessage `json:"data"`
+	// Actor is UserEventActorSelf or the admin or service that made the change, e.g. "admin:ops"
+	Actor      string `json:"actor"`
+	OccurredAt int64  `json:"occurredAt"`
+}
+
+// UserCreatedData is the state a user was created with
+type UserCreatedData struct {
+	Email          string     `json:"email"`
+	Username       string     `json:"username"`
+	Status         UserStatus `json:"status"`
+	Role           UserRole   `json:"role"`
+	OrganizationID string     `json:"organizationId,omitempty"`
+	// Residency is the region the user's data is stored in, see User.Residency
+	Residency string `json:"residency,omitempty"`
+	LegalHold bool   `json:"legalHold,omitempty"`
+	// Metadata is only set by backfilled streams, new users start without metadata
+	Metadata UserMetadata `json:"metadata,omitempty"`
+}
+
+// RoleGrantedData is the data of UserEventRoleGranted
+type RoleGrantedData struct {
+	Role         UserRole `json:"role"`
+	PreviousRole UserRole `json:"previousRole"`
+	Reason       string   `json:"reason,omitempty"`
+}
+
+// StatusChangedData is the data of UserEventBanned and UserEventUnbanned
+type StatusChangedData struct {
+	Status         UserStatus `json:"status"`
+	PreviousStatus UserStatus `json:"previousStatus"`
+	Reason         string     `json:"reason,omitempty"`
+}
+
+// LegalHoldData is the data of the legal hold events
+type LegalHoldData struct {
+	Reason string `json:"reason"`
+}
+
+// MetadataUpdatedData is the data of UserEventMetadataUpdated
+type MetadataUpdatedData struct {
+	Set     map[string]string `json:"set,omitempty"`
+	Removed []string          `json:"removed,omitempty"`
+}
+
+// EmailClaimedData is the data of UserEventEmailClaimed
+type EmailClaimedData struct {
+	Email      string                  `json:"email"`
+	VerifiedBy EmailVerificationMethod `json:"verifiedBy"`
+}
+
+// NewUserEvent creates an event of the user with the given data
+func NewUserEvent(userID uuid.UUID, eventType UserEventType, actor string, data interface{}) (*UserEvent, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user event data: %w", err)
+	}
+
+	return &UserEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Type:       eventType,
+		Data:       raw,
+		Actor:      actor,
+		OccurredAt: time.Now().UnixMilli(),
+	}, nil
+}
+
+// NewUserCreatedEvent creates the first event of a new user's stream
+func NewUserCreatedEvent(user *User, eventType UserEventType, actor string) (*UserEvent, error) {
+	data := UserCreatedData{
+		Email:     user.Email.String(),
+		Username:  user.Username.String(),
+		Status:    user.Status,
+		Role:      user.Role,
+		Residency: user.Residency,
+	}
+	if user.OrganizationID != uuid.Nil {
+		data.OrganizationID = user.OrganizationID.String()
+	}
+
+	event, err := NewUserEvent(user.ID, eventType, actor, data)
+	if err != nil {
+		return nil, err
+	}
+	event.OccurredAt = user.CreatedAt
+
+	return event, nil
+}
+
+// UserProjection is the state of a user rebuilt from the user's events
+type UserProjection struct {
+	ID             uuid.UUID
+	Email          string
+	Username       string
+	Status         UserStatus
+	Role           UserRole
+	OrganizationID uuid.UUID
+	LegalHold      bool
+	Metadata       UserMetadata
+	// Version is the version of the last applied event
+	Version   int64
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// Apply folds the next event of the user's stream into the projection
+func (p *UserProjection) Apply(event *UserEvent) error {
+	if event.Version != p.Version+1 {
+		return errs.ErrInvalidUserEventStream.WithDetail("version", event.Version)
+	}
+
+	switch event.Type {
+	case UserEventRegistered, UserEventImported, UserEventBackfilled:
+		if p.Version != 0 {
+			return errs.ErrInvalidUserEventStream.WithDetail("version", event.Version)
+		}
+
+		var data UserCreatedData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", event.Type, err)
+		}
+
+		p.ID = event.UserID
+		p.Email, p.Username = data.Email, data.Username
+		p.Status, p.Role, p.LegalHold = data.Status, data.Role, data.LegalHold
+		p.Metadata = data.Metadata
+		if data.OrganizationID != "" {
+			if p.OrganizationID, _ = uuid.Parse(data.OrganizationID); p.OrganizationID == uuid.Nil {
+				return errs.ErrInvalidUserEventStream.WithDetail("organization_id", data.OrganizationID)
+			}
+		}
+		p.CreatedAt = event.OccurredAt
+	default:
+		// Every other event changes an existing user
+		if p.Version == 0 {
+			return errs.ErrInvalidUserEventStream.WithDetail("version", event.Version)
+		}
+		if err := p.applyChange(event); err != nil {
+			return err
+		}
+	}
+
+	p.Version = event.Version
+	p.UpdatedAt = event.OccurredAt
+
+	return nil
+}
+
+func (p *UserProjection) applyChange(event *UserEvent) error {
+	switch event.Type {
+	case UserEventPasswordSet:
+		p.Status = UserStatusActive
+	case UserEventRoleGranted:
+		var data RoleGrantedData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", event.Type, err)
+		}
+		p.Role = data.Role
+	case UserEventBanned, UserEventUnbanned:
+		var data StatusChangedData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", event.Type, err)
+		}
+		p.Status = data.Status
+	case UserEventLegalHoldPlaced:
+		p.LegalHold = true
+	case UserEventLegalHoldReleased:
+		p.LegalHold = false
+	case UserEventMetadataUpdated:
+		var data MetadataUpdatedData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return fmt.Errorf("failed to decode %s event: %w", event.Type, err)
+		}
+		p.Metadata, _ = p.Metadata.Apply(data.Set, data.Removed)
+	case UserEventEmailClaimed:
+		// Claims are kept in email_claims and do not change the user
+	case UserEventPasswordReset:
+		// Passwords are not part of the projection
+	default:
+		return errs.ErrInvalidUserEventStream.WithDetail("event_type", string(event.Type))
+	}
+	return nil
+}
+
+// ReplayUserEvents rebuilds a user from the user's complete stream, in version order
+func ReplayUserEvents(events []*UserEvent) (*UserProjection, error) {
+	projection := &UserProjection{}
+	for _, event := range events {
+		if err := projection.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return projection, nil
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+)
+
+func userEventStream(t *testing.T, user *User, changes ...*UserEvent) []*UserEvent {
+	t.Helper()
+
+	created, err := NewUserCreatedEvent(user, UserEventRegistered, UserEventActorSelf)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	events := append([]*UserEvent{created}, changes...)
+	for i, event := range events {
+		event.Version = int64(i + 1)
+	}
+	return events
+}
+
+func mustUserEvent(t *testing.T, userID uuid.UUID, eventType UserEventType, data interface{}) *UserEvent {
+	t.Helper()
+
+	event, err := NewUserEvent(userID, eventType, "admin:ops", data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return event
+}
+
+func TestReplayUserEvents(t *testing.T) {
+	user, err := NewInvitedUser("jane@tickets.example", "jane")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	user.OrganizationID = uuid.New()
+
+	events := userEventStream(t, user,
+		&UserEvent{UserID: user.ID, Type: UserEventPasswordSet, Data: []byte(`{}`), OccurredAt: 10},
+		mustUserEvent(t, user.ID, UserEventRoleGranted, RoleGrantedData{Role: UserRoleStaff, PreviousRole: UserRoleCustomer}),
+		mustUserEvent(t, user.ID, UserEventLegalHoldPlaced, LegalHoldData{Reason: "case 4711"}),
+		mustUserEvent(t, user.ID, UserEventBanned, StatusChangedData{Status: UserStatusBanned, PreviousStatus: UserStatusActive}),
+	)
+
+	projection, err := ReplayUserEvents(events)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if projection.ID != user.ID || projection.Email != user.Email.String() || projection.Username != user.Username.String() {
+		t.Errorf("Expected the identity of the created user, got %+v", projection)
+	}
+	if projection.OrganizationID != user.OrganizationID {
+		t.Errorf("Expected organization %s, got %s", user.OrganizationID, projection.OrganizationID)
+	}
+	if projection.Status != UserStatusBanned {
+		t.Errorf("Expected status %s, got %s", UserStatusBanned, projection.Status)
+	}
+	if projection.Role != UserRoleStaff {
+		t.Errorf("Expected role %s, got %s", UserRoleStaff, projection.Role)
+	}
+	if !projection.LegalHold {
+		t.Errorf("Expected the legal hold to be placed")
+	}
+	if projection.Version != 5 {
+		t.Errorf("Expected version 5, got %d", projection.Version)
+	}
+	if projection.CreatedAt != user.CreatedAt {
+		t.Errorf("Expected created at %d, got %d", user.CreatedAt, projection.CreatedAt)
+	}
+}
+
+func TestReplayUserEvents_MetadataUpdated(t *testing.T) {
+	user, _ := NewInvitedUser("jane@tickets.example", "jane")
+	events := userEventStream(t, user,
+		mustUserEvent(t, user.ID, UserEventMetadataUpdated, MetadataUpdatedData{Set: map[string]string{"crm.segment": "vip", "fraud.flag": "review"}}),
+		mustUserEvent(t, user.ID, UserEventMetadataUpdated, MetadataUpdatedData{Removed: []string{"fraud.flag"}}),
+	)
+
+	projection, err := ReplayUserEvents(events)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(projection.Metadata) != 1 || projection.Metadata["crm.segment"] != "vip" {
+		t.Errorf("Expected only crm.segment=vip, got %v", projection.Metadata)
+	}
+}
+
+func TestReplayUserEvents_PasswordSetActivates(t *testing.T) {
+	user, _ := NewInvitedUser("jane@tickets.example", "jane")
+	events := userEventStream(t, user, mustUserEvent(t, user.ID, UserEventPasswordSet, struct{}{}))
+
+	projection, err := ReplayUserEvents(events)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if projection.Status != UserStatusActive {
+		t.Errorf("Expected status %s, got %s", UserStatusActive, projection.Status)
+	}
+}
+
+func TestReplayUserEvents_EmailClaimed(t *testing.T) {
+	user, _ := NewInvitedUser("jane@tickets.example", "jane")
+	events := userEventStream(t, user,
+		mustUserEvent(t, user.ID, UserEventPasswordSet, struct{}{}),
+		mustUserEvent(t, user.ID, UserEventEmailClaimed, EmailClaimedData{Email: user.Email.String(), VerifiedBy: EmailVerifiedByInvitation}),
+	)
+
+	projection, err := ReplayUserEvents(events)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if projection.Status != UserStatusActive || projection.Version != 3 {
+		t.Errorf("Expected the claim to only advance the version, got status %s and version %d", projection.Status, projection.Version)
+	}
+}
+
+func TestReplayUserEvents_RejectsInconsistentStreams(t *testing.T) {
+	user, _ := NewInvitedUser("jane@tickets.example", "jane")
+	hold := func() *UserEvent {
+		return mustUserEvent(t, user.ID, UserEventLegalHoldPlaced, LegalHoldData{Reason: "case 4711"})
+	}
+
+	gap := userEventStream(t, user, hold())
+	gap[1].Version = 3
+
+	missingCreation := []*UserEvent{hold()}
+	missingCreation[0].Version = 1
+
+	recreated := userEventStream(t, user)
+	again, _ := NewUserCreatedEvent(user, UserEventImported, "admin:ops")
+	again.Version = 2
+	recreated = append(recreated, again)
+
+	unknown := userEventStream(t, user, mustUserEvent(t, user.ID, "user.renamed", struct{}{}))
+
+	for name, events := range map[string][]*UserEvent{
+		"version gap":      gap,
+		"missing creation": missingCreation,
+		"created twice":    recreated,
+		"unknown type":     unknown,
+	} {
+		if _, err := ReplayUserEvents(events); !errors.Is(err, errs.ErrInvalidUserEventStream) {
+			t.Errorf("%s: expected ErrInvalidUserEventStream, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// UserExportField is a user attribute that can be selected for export
+type UserExportField string
+
+const (
+	UserExportFieldID        UserExportField = "id"
+	UserExportFieldEmail     UserExportField = "email"
+	UserExportFieldUsername  UserExportField = "username"
+	UserExportFieldCreatedAt UserExportField = "created_at"
+	UserExportFieldUpdatedAt UserExportField = "updated_at"
+)
+
+// UserExportFields lists every exportable field in export order. Credentials are never exported.
+var UserExportFields = []UserExportField{
+	UserExportFieldID,
+	UserExportFieldEmail,
+	UserExportFieldUsername,
+	UserExportFieldCreatedAt,
+	UserExportFieldUpdatedAt,
+}
+
+// IsValid reports whether the field can be exported
+func (f UserExportField) IsValid() bool {
+	return slices.Contains(UserExportFields, f)
+}
+
+// UserExportFormat is the encoding of an export stream
+type UserExportFormat string
+
+const (
+	UserExportFormatProto  UserExportFormat = "proto"
+	UserExportFormatNDJSON UserExportFormat = "ndjson"
+)
+
+// UserExportFilter selects the users to export; zero bounds are unbounded
+type UserExportFilter struct {
+	// CreatedFrom and CreatedTo bound created_at as [CreatedFrom, CreatedTo) in Unix milliseconds
+	CreatedFrom int64
+	CreatedTo   int64
+	// OrganizationID limits the export to the staff of an organization when set
+	OrganizationID uuid.UUID
+}
+
+// UserExportChunk is a batch of exported users, ordered by creation
+type UserExportChunk struct {
+	Users []*User
+	// NDJSON holds one JSON object per user when exporting in the NDJSON format
+	NDJSON []byte
+}
+
+// ExportRecord returns the selected fields of a user keyed by field name
+func ExportRecord(user *User, fields []UserExportField) map[string]interface{} {
+	record := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		switch field {
+		case UserExportFieldID:
+			record[string(field)] = user.ID.String()
+		case UserExportFieldEmail:
+			record[string(field)] = user.Email.String()
+		case UserExportFieldUsername:
+			record[string(field)] = user.Username.String()
+		case UserExportFieldCreatedAt:
+			record[string(field)] = user.CreatedAt
+		case UserExportFieldUpdatedAt:
+			record[string(field)] = user.UpdatedAt
+		}
+	}
+	return record
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestExportRecord(t *testing.T) {
+	user := &User{
+		ID:           uuid.New(),
+		Email:        Email("jane@example.com"),
+		Username:     Username("jane"),
+		PasswordHash: PasswordHash("hash"),
+		CreatedAt:    1000,
+		UpdatedAt:    2000,
+	}
+
+	record := ExportRecord(user, []UserExportField{UserExportFieldEmail, UserExportFieldCreatedAt})
+	if len(record) != 2 {
+		t.Fatalf("Expected only the selected fields, got %v", record)
+	}
+	if record["email"] != "jane@example.com" || record["created_at"] != int64(1000) {
+		t.Errorf("Expected the selected values, got %v", record)
+	}
+
+	for _, field := range UserExportFields {
+		if !field.IsValid() {
+			t.Errorf("Expected %s to be exportable", field)
+		}
+	}
+	if UserExportField("password_hash").IsValid() {
+		t.Error("Expected password hashes not to be exportable")
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// UserIdentity links a user to an identity at an OpenID Connect provider, so ID tokens of
+// the provider for the identity log the user in
+type UserIdentity struct {
+	Provider string `json:"provider"`
+	// Subject is the provider's stable ID of the identity, the "sub" of its ID tokens
+	Subject   string    `json:"subject"`
+	UserID    uuid.UUID `json:"userId"`
+	CreatedAt int64     `json:"createdAt"`
+}
+
+// NewUserIdentity creates the link of a user to an identity of a provider
+func NewUserIdentity(provider, subject string, userID uuid.UUID, createdAt int64) *UserIdentity {
+	return &UserIdentity{
+		Provider:  provider,
+		Subject:   subject,
+		UserID:    userID,
+		CreatedAt: createdAt,
+	}
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// MaxMetadataKeyLength is the longest metadata key, namespace included
+const MaxMetadataKeyLength = 128
+
+// metadataKeyPattern matches keys such as crm.segment or fraud.flag: a namespace naming the
+// owning service, a dot and the name within the namespace
+var metadataKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*\.[a-z0-9_][a-z0-9_.-]*$`)
+
+// UserMetadata is the namespaced key/value bag internal services attach to a user
+type UserMetadata map[string]string
+
+// ValidMetadataKey reports whether key is a namespaced metadata key
+func ValidMetadataKey(key string) bool {
+	return len(key) <= MaxMetadataKeyLength && metadataKeyPattern.MatchString(key)
+}
+
+// MetadataNamespace returns the namespace of a metadata key, e.g. crm for crm.segment
+func MetadataNamespace(key string) string {
+	namespace, _, _ := strings.Cut(key, ".")
+	return namespace
+}
+
+// Apply returns the metadata with the keys set and removed, and whether anything changed.
+// The receiver is not modified.
+func (m UserMetadata) Apply(set map[string]string, remove []string) (UserMetadata, bool) {
+	updated := make(UserMetadata, len(m)+len(set))
+	for key, value := range m {
+		updated[key] = value
+	}
+
+	changed := false
+	for key, value := range set {
+		if current, ok := updated[key]; !ok || current != value {
+			updated[key] = value
+			changed = true
+		}
+	}
+	for _, key := range remove {
+		if _, ok := updated[key]; ok {
+			delete(updated, key)
+			changed = true
+		}
+	}
+
+	return updated, changed
+}
+
+// Namespaces returns the entries of the given namespaces
+func (m UserMetadata) Namespaces(namespaces map[string]struct{}) UserMetadata {
+	filtered := make(UserMetadata)
+	for key, value := range m {
+		if _, ok := namespaces[MetadataNamespace(key)]; ok {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// Size returns the size of the metadata as stored, in bytes of JSON
+func (m UserMetadata) Size() int {
+	raw, _ := json.Marshal(m)
+	return len(raw)
+}
+
+// JSON returns the metadata as a JSON object, {} when empty
+func (m UserMetadata) JSON() []byte {
+	if len(m) == 0 {
+		return []byte("{}")
+	}
+	raw, _ := json.Marshal(m)
+	return raw
+}
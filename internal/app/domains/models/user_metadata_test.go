@@ -0,0 +1,54 @@
+package models
+
+import "testing"
+
+func TestValidMetadataKey(t *testing.T) {
+	for _, key := range []string{"crm.segment", "fraud.flag", "crm.tier.v2", "risk_engine.score-1"} {
+		if !ValidMetadataKey(key) {
+			t.Errorf("Expected %q to be valid", key)
+		}
+	}
+	for _, key := range []string{"", "segment", "Crm.segment", "crm.", ".segment", "1crm.segment", "crm.Segment"} {
+		if ValidMetadataKey(key) {
+			t.Errorf("Expected %q to be invalid", key)
+		}
+	}
+}
+
+func TestUserMetadata_Apply(t *testing.T) {
+	current := UserMetadata{"crm.segment": "vip", "fraud.flag": "review"}
+
+	updated, changed := current.Apply(map[string]string{"crm.segment": "vip"}, []string{"crm.missing"})
+	if changed {
+		t.Errorf("Expected no change, got %v", updated)
+	}
+
+	updated, changed = current.Apply(map[string]string{"crm.segment": "regular"}, []string{"fraud.flag"})
+	if !changed {
+		t.Fatalf("Expected a change")
+	}
+	if len(updated) != 1 || updated["crm.segment"] != "regular" {
+		t.Errorf("Expected only crm.segment=regular, got %v", updated)
+	}
+	if current["crm.segment"] != "vip" || current["fraud.flag"] != "review" {
+		t.Errorf("Expected the receiver to be unchanged, got %v", current)
+	}
+}
+
+func TestUserMetadata_Namespaces(t *testing.T) {
+	metadata := UserMetadata{"crm.segment": "vip", "crm.tier": "gold", "fraud.flag": "review"}
+
+	filtered := metadata.Namespaces(map[string]struct{}{"crm": {}})
+	if len(filtered) != 2 || filtered["crm.segment"] != "vip" || filtered["crm.tier"] != "gold" {
+		t.Errorf("Expected the crm entries only, got %v", filtered)
+	}
+}
+
+func TestUserMetadata_JSON(t *testing.T) {
+	if got := string(UserMetadata(nil).JSON()); got != "{}" {
+		t.Errorf("Expected {}, got %s", got)
+	}
+	if got := string(UserMetadata{"crm.segment": "vip"}.JSON()); got != `{"crm.segment":"vip"}` {
+		t.Errorf("Expected the JSON object, got %s", got)
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// UserNoteVisibility tells who a note about a user may be shown to
+type UserNoteVisibility string
+
+const (
+	// UserNoteVisibilityInternal notes are only read by staff
+	UserNoteVisibilityInternal UserNoteVisibility = "internal"
+	// UserNoteVisibilityDisclosable notes hold personal data the user has a right to access, e.g.
+	// a promised refund, and are handed out with the user's data on a subject access request
+	UserNoteVisibilityDisclosable UserNoteVisibility = "disclosable"
+)
+
+// IsValid reports whether the visibility is known
+func (v UserNoteVisibility) IsValid() bool {
+	return v == UserNoteVisibilityInternal || v == UserNoteVisibilityDisclosable
+}
+
+// UserNote is a note left on an account by support staff, e.g. "refund promised on 5/3".
+// Notes are kept apart from the user row and are never shown to the user through the API.
+type UserNote struct {
+	ID         uuid.UUID          `json:"id"`
+	UserID     uuid.UUID          `json:"userId"`
+	Body       string             `json:"body"`
+	Visibility UserNoteVisibility `json:"visibility"`
+	// Author is the support agent who wrote the note
+	Author string `json:"author"`
+	// CreatedBy is the admin API key the note was added with, e.g. admin:support-console
+	CreatedBy string `json:"createdBy"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// NewUserNote creates a note on the user
+func NewUserNote(userID uuid.UUID, body string, visibility UserNoteVisibility, author, createdBy string, createdAt int64) *UserNote {
+	return &UserNote{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Body:       body,
+		Visibility: visibility,
+		Author:     author,
+		CreatedBy:  createdBy,
+		CreatedAt:  createdAt,
+	}
+}
+
+// IsDisclosable reports whether the note must be handed out on a subject access request
+func (n *UserNote) IsDisclosable() bool {
+	return n.Visibility == UserNoteVisibilityDisclosable
+}
+
+// UserNoteCursor is a position in the notes of a user, newest first: before the note created
+// at CreatedAt with ID. The zero cursor is before the newest note.
+type UserNoteCursor struct {
+	CreatedAt int64
+	ID        uuid.UUID
+}
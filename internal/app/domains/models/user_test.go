@@ -0,0 +1,114 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestNewPendingUser(t *testing.T) {
+	user, err := NewPendingUser("jane@tickets.example", "jane")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if user.Status != UserStatusPending {
+		t.Errorf("Expected status %s, got %s", UserStatusPending, user.Status)
+	}
+	if user.PasswordHash != "" {
+		t.Errorf("Expected pending user to have no password, got %q", user.PasswordHash)
+	}
+}
+
+func TestUserStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from    UserStatus
+		to      UserStatus
+		allowed bool
+	}{
+		{from: UserStatusPending, to: UserStatusInvited, allowed: true},
+		{from: UserStatusPending, to: UserStatusActive, allowed: true},
+		{from: UserStatusInvited, to: UserStatusActive, allowed: true},
+		{from: UserStatusActive, to: UserStatusBanned, allowed: true},
+		{from: UserStatusBanned, to: UserStatusActive, allowed: true},
+		{from: UserStatusActive, to: UserStatusActive, allowed: true},
+		{from: UserStatusInvited, to: UserStatusPending},
+		{from: UserStatusActive, to: UserStatusPending},
+		{from: UserStatusActive, to: UserStatusInvited},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.allowed {
+			t.Errorf("Expected %s to %s allowed %v, got %v", tt.from, tt.to, tt.allowed, got)
+		}
+	}
+}
+
+func TestUser_Activate(t *testing.T) {
+	user, err := NewPendingUser("jane@tickets.example", "jane")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := user.Activate(""); !errors.Is(err, errs.ErrInvalidPassword) {
+		t.Errorf("Expected ErrInvalidPassword for an empty hash, got %v", err)
+	}
+	if err := user.Activate("$2a$04$hash"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Status != UserStatusActive || user.PasswordHash != "$2a$04$hash" {
+		t.Errorf("Expected an active user with the password hash, got %s %q", user.Status, user.PasswordHash)
+	}
+	if err := user.Activate("$2a$04$other"); !errors.Is(err, errs.ErrInvalidUserStatusTransition) {
+		t.Errorf("Expected ErrInvalidUserStatusTransition for an active user, got %v", err)
+	}
+}
+
+func TestUser_CheckCanAuthenticate(t *testing.T) {
+	tests := []struct {
+		status UserStatus
+		err    error
+	}{
+		{status: UserStatusActive},
+		{status: UserStatusPending, err: errs.ErrAccountPending},
+		{status: UserStatusInvited, err: errs.ErrPasswordSetupRequired},
+		{status: UserStatusBanned, err: errs.ErrUserBanned},
+	}
+
+	for _, tt := range tests {
+		user := &User{Status: tt.status}
+		if err := user.CheckCanAuthenticate(); !errors.Is(err, tt.err) {
+			t.Errorf("Expected %v for a %s user, got %v", tt.err, tt.status, err)
+		}
+	}
+}
+
+func TestUser_NeedsEmailReverification(t *testing.T) {
+	now := time.UnixMilli(1760616000000)
+	year := 365 * 24 * time.Hour
+	longAgo := now.Add(-2 * year).UnixMilli()
+	recently := now.Add(-time.Hour).UnixMilli()
+
+	tests := []struct {
+		name    string
+		user    *User
+		claim   *EmailClaim
+		absence time.Duration
+		want    bool
+	}{
+		{name: "recent login", user: &User{CreatedAt: longAgo, LastLoginAt: recently}, absence: year},
+		{name: "recent registration", user: &User{CreatedAt: recently}, absence: year},
+		{name: "long absence", user: &User{CreatedAt: longAgo, LastLoginAt: longAgo}, claim: &EmailClaim{VerifiedAt: longAgo}, absence: year, want: true},
+		{name: "never verified", user: &User{CreatedAt: longAgo}, absence: year, want: true},
+		{name: "reverified", user: &User{CreatedAt: longAgo, LastLoginAt: longAgo}, claim: &EmailClaim{VerifiedAt: recently}, absence: year},
+		{name: "disabled", user: &User{CreatedAt: longAgo}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.user.NeedsEmailReverification(tt.claim, tt.absence, now); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
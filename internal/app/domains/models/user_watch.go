@@ -0,0 +1,13 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// WatchedUser is the state of a watched user as of the event with Version, the last one
+// of the user's stream. User is nil for users that do not exist.
+type WatchedUser struct {
+	UserID  uuid.UUID
+	User    *User
+	Version int64
+}
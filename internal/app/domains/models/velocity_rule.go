@@ -0,0 +1,104 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// VelocityEntity is what the attempts of a velocity rule are counted per
+type VelocityEntity string
+
+const (
+	VelocityEntityIP    VelocityEntity = "ip"
+	VelocityEntityEmail VelocityEntity = "email"
+	// VelocityEntityDevice is the device ID clients send in the abuse.device_metadata_key metadata
+	VelocityEntityDevice VelocityEntity = "device"
+)
+
+// IsValid reports whether the entity is known
+func (e VelocityEntity) IsValid() bool {
+	return e == VelocityEntityIP || e == VelocityEntityEmail || e == VelocityEntityDevice
+}
+
+// VelocityAction is the kind of attempt a velocity rule counts
+type VelocityAction string
+
+const (
+	VelocityActionRegister VelocityAction = "register"
+	VelocityActionLogin    VelocityAction = "login"
+)
+
+// IsValid reports whether the action is known
+func (a VelocityAction) IsValid() bool {
+	return a == VelocityActionRegister || a == VelocityActionLogin
+}
+
+// VelocityResponse is what happens to attempts over the threshold of a velocity rule
+type VelocityResponse string
+
+const (
+	// VelocityResponseAlert lets the attempt through and reports it to the fraud team
+	VelocityResponseAlert VelocityResponse = "alert"
+	// VelocityResponseCaptcha lets the attempt through only with a solved captcha
+	VelocityResponseCaptcha VelocityResponse = "captcha"
+	// VelocityResponseLock refuses the attempt until the window has passed
+	VelocityResponseLock VelocityResponse = "lock"
+)
+
+// IsValid reports whether the response is known
+func (r VelocityResponse) IsValid() bool {
+	return r == VelocityResponseAlert || r == VelocityResponseCaptcha || r == VelocityResponseLock
+}
+
+// Severity orders the responses from alert to lock, so the strictest of several triggered
+// rules applies
+func (r VelocityResponse) Severity() int {
+	switch r {
+	case VelocityResponseLock:
+		return 3
+	case VelocityResponseCaptcha:
+		return 2
+	case VelocityResponseAlert:
+		return 1
+	}
+	return 0
+}
+
+// VelocityRule triggers its response once more than Threshold attempts of Action were made by
+// the same entity within Window. Rules are managed at runtime by the fraud team.
+type VelocityRule struct {
+	Name      string           `json:"name"`
+	Entity    VelocityEntity   `json:"entity"`
+	Action    VelocityAction   `json:"action"`
+	Threshold int              `json:"threshold"`
+	Window    time.Duration    `json:"window"`
+	Response  VelocityResponse `json:"response"`
+	Enabled   bool             `json:"enabled"`
+	UpdatedBy string           `json:"updatedBy"`
+	CreatedAt int64            `json:"createdAt"`
+	UpdatedAt int64            `json:"updatedAt"`
+}
+
+// VelocityAttempt is a register or login attempt evaluated against the velocity rules
+type VelocityAttempt struct {
+	Action VelocityAction
+	IP     string
+	Email  string
+	Device string
+	// CaptchaToken is the captcha the client solved, if any
+	CaptchaToken string
+}
+
+// Subject returns the value of the attempt the entity counts, or "" if the attempt has none
+func (a VelocityAttempt) Subject(entity VelocityEntity) string {
+	switch entity {
+	case VelocityEntityIP:
+		return a.IP
+	case VelocityEntityEmail:
+		// Attempts with different casing of an address count together
+		return strings.ToLower(strings.TrimSpace(a.Email))
+	case VelocityEntityDevice:
+		return a.Device
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkflowInvitationReminder re-sends the invitation of an imported user who has not set a
+// password shortly before the setup token expires. Its key is the user ID.
+const WorkflowInvitationReminder = "invitation.reminder"
+
+// WorkflowTimer is a durable timer firing the next step of a workflow at FireAt. A workflow
+// has at most one pending timer per key; scheduling it again moves the timer.
+type WorkflowTimer struct {
+	ID       uuid.UUID
+	Workflow string
+	Key      string
+	Payload  json.RawMessage
+	FireAt   int64
+	// Attempts counts the failed attempts to fire the timer
+	Attempts  int
+	CreatedAt int64
+}
+
+// NewWorkflowTimer creates a timer of the workflow firing at fireAt with the given payload
+func NewWorkflowTimer(workflow, key string, fireAt time.Time, payload interface{}) (*WorkflowTimer, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal workflow timer payload: %w", err)
+	}
+
+	return &WorkflowTimer{
+		ID:        uuid.New(),
+		Workflow:  workflow,
+		Key:       key,
+		Payload:   raw,
+		FireAt:    fireAt.UnixMilli(),
+		CreatedAt: time.Now().UnixMilli(),
+	}, nil
+}
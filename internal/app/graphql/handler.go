@@ -0,0 +1,101 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/service"
+	logutils "user-svc/pkg/utils/log"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxRequestBytes bounds the size of a GraphQL request body
+const maxRequestBytes = 1 << 20
+
+// request is a GraphQL request sent as JSON
+type request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// Handler serves GraphQL requests over HTTP POST. The admin API key is sent in the
+// X-Admin-Key header and handed to the services the way the gRPC server does.
+type Handler struct {
+	schema gql.Schema
+	logger *logutils.Logger
+}
+
+// NewHandler creates the GraphQL handler of the admin schema
+func NewHandler(logger *logutils.Logger, users UserReader, admin UserAdmin) (*Handler, error) {
+	schema, err := NewSchema(users, admin)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{schema: schema, logger: logger}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "GraphQL requests must be sent with POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil || req.Query == "" {
+		http.Error(w, "body must be a JSON GraphQL request with a query", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if key := r.Header.Get(service.AdminKeyMetadataKey); key != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(service.AdminKeyMetadataKey, key))
+	}
+
+	result := gql.Do(gql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+	for i, err := range result.Errors {
+		result.Errors[i] = h.mapError(req.OperationName, err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.WithError(err).Warn("Failed to write GraphQL response")
+	}
+}
+
+// mapError converts the service errors of resolvers the way the gRPC error interceptor does
+// and adds their gRPC code to the extensions, e.g. {"code": "NotFound"}. Query syntax and
+// validation errors are returned as they are.
+func (h *Handler) mapError(operation string, err gqlerrors.FormattedError) gqlerrors.FormattedError {
+	original := err.OriginalError()
+	if located, ok := original.(*gqlerrors.Error); ok {
+		original = located.OriginalError
+	}
+	if original == nil {
+		return err
+	}
+
+	h.logger.WithFields(logutils.Fields{
+		"operation": operation,
+		"path":      err.Path,
+		"error":     original.Error(),
+	}).Error("GraphQL error occurred")
+
+	st := status.Convert(errs.ToGRPCError(original))
+	err.Message = st.Message()
+	err.Extensions = map[string]interface{}{"code": st.Code().String()}
+	return err
+}
@@ -0,0 +1,174 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/service"
+	logutils "user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeUsers authorizes like the services: the admin key must arrive as incoming metadata
+type fakeUsers struct {
+	user         *models.User
+	statusReqs   []dto.BatchUpdateStatusReq
+	roleReqs     []dto.BatchAssignRoleReq
+	resultStatus dto.BatchItemStatus
+}
+
+func (f *fakeUsers) authorize(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(service.AdminKeyMetadataKey); len(values) == 0 || values[0] != "ops-key" {
+		return errs.ErrInvalidAdminKey
+	}
+	return nil
+}
+
+func (f *fakeUsers) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	if err := f.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if userID != f.user.ID.String() {
+		return nil, errs.ErrUserNotFound
+	}
+	return f.user, nil
+}
+
+func (f *fakeUsers) ListUsers(ctx context.Context, req dto.ListUsersReq) (*dto.ListUsersResp, error) {
+	if err := f.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return &dto.ListUsersResp{Users: []*models.User{f.user}, NextPageToken: dto.UserPageToken(f.user)}, nil
+}
+
+func (f *fakeUsers) BatchAssignRole(ctx context.Context, req dto.BatchAssignRoleReq) ([]*dto.BatchUserResult, error) {
+	f.roleReqs = append(f.roleReqs, req)
+	f.user.Role = models.UserRole(req.Role)
+	return []*dto.BatchUserResult{{UserID: req.UserIDs[0], Status: f.resultStatus}}, f.authorize(ctx)
+}
+
+func (f *fakeUsers) BatchUpdateStatus(ctx context.Context, req dto.BatchUpdateStatusReq) ([]*dto.BatchUserResult, error) {
+	f.statusReqs = append(f.statusReqs, req)
+	f.user.Status = models.UserStatus(req.Status)
+	return []*dto.BatchUserResult{{UserID: req.UserIDs[0], Status: f.resultStatus}}, f.authorize(ctx)
+}
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func newTestHandler(t *testing.T) (*fakeUsers, *httptest.Server) {
+	t.Helper()
+
+	user, err := models.NewUser("jane@tickets.example", "hash", "jane")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	users := &fakeUsers{user: user, resultStatus: dto.BatchItemStatusUpdated}
+
+	logger := logutils.New(io.Discard)
+	handler, err := NewHandler(logger, users, users)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return users, server
+}
+
+func query(t *testing.T, url, adminKey, body string) *response {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if adminKey != "" {
+		req.Header.Set("X-Admin-Key", adminKey)
+	}
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return &resp
+}
+
+func TestHandler_User(t *testing.T) {
+	users, server := newTestHandler(t)
+
+	resp := query(t, server.URL, "ops-key", `{"query": "query($id: ID!) { user(id: $id) { id email role status } }", "variables": {"id": "`+users.user.ID.String()+`"}}`)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Expected no errors, got %+v", resp.Errors)
+	}
+
+	var got struct {
+		ID, Email, Role, Status string
+	}
+	if err := json.Unmarshal(resp.Data["user"], &got); err != nil {
+		t.Fatalf("Failed to decode user: %v", err)
+	}
+	if got.ID != users.user.ID.String() || got.Email != "jane@tickets.example" || got.Role != "CUSTOMER" || got.Status != "ACTIVE" {
+		t.Errorf("Expected the user, got %+v", got)
+	}
+}
+
+func TestHandler_ErrorMapping(t *testing.T) {
+	_, server := newTestHandler(t)
+
+	resp := query(t, server.URL, "ops-key", `{"query": "{ user(id: \"`+uuid.NewString()+`\") { id } }"}`)
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "NotFound" || resp.Errors[0].Message != "user not found" {
+		t.Errorf("Expected a NotFound error, got %+v", resp.Errors)
+	}
+
+	resp = query(t, server.URL, "", `{"query": "{ users { nextPageToken } }"}`)
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "Unauthenticated" {
+		t.Errorf("Expected an Unauthenticated error without an admin key, got %+v", resp.Errors)
+	}
+}
+
+func TestHandler_Mutations(t *testing.T) {
+	users, server := newTestHandler(t)
+	id := users.user.ID.String()
+
+	resp := query(t, server.URL, "ops-key", `{"query": "mutation { banUser(id: \"`+id+`\", reason: \"INC-1042\") { status } }"}`)
+	if len(resp.Errors) > 0 || string(resp.Data["banUser"]) != `{"status":"BANNED"}` {
+		t.Errorf("Expected the banned user, got %s %+v", resp.Data["banUser"], resp.Errors)
+	}
+	if len(users.statusReqs) != 1 || users.statusReqs[0].Reason != "INC-1042" {
+		t.Errorf("Expected one status update with the reason, got %+v", users.statusReqs)
+	}
+
+	resp = query(t, server.URL, "ops-key", `{"query": "mutation { assignRole(id: \"`+id+`\", role: STAFF, reason: \"onboarding\") { role } }"}`)
+	if len(resp.Errors) > 0 || string(resp.Data["assignRole"]) != `{"role":"STAFF"}` {
+		t.Errorf("Expected the staff user, got %s %+v", resp.Data["assignRole"], resp.Errors)
+	}
+	if len(users.roleReqs) != 1 || users.roleReqs[0].Role != "staff" {
+		t.Errorf("Expected one role assignment to staff, got %+v", users.roleReqs)
+	}
+
+	users.resultStatus = dto.BatchItemStatusNotFound
+	resp = query(t, server.URL, "ops-key", `{"query": "mutation { banUser(id: \"`+id+`\", reason: \"INC-1042\") { status } }"}`)
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "NotFound" {
+		t.Errorf("Expected a NotFound error, got %+v", resp.Errors)
+	}
+}
@@ -0,0 +1,223 @@
+// Package graphql exposes the user admin operations of the service layer as a single GraphQL
+// endpoint for the admin console. Resolvers call the same services as the gRPC handlers, so
+// they are authorized with the same admin API keys and fail with the same error codes.
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+	gql "github.com/graphql-go/graphql"
+)
+
+// UserReader looks up and pages through users
+type UserReader interface {
+	GetUser(ctx context.Context, userID string) (*models.User, error)
+	ListUsers(ctx context.Context, req dto.ListUsersReq) (*dto.ListUsersResp, error)
+}
+
+// UserAdmin changes the role and status of users
+type UserAdmin interface {
+	BatchAssignRole(ctx context.Context, req dto.BatchAssignRoleReq) ([]*dto.BatchUserResult, error)
+	BatchUpdateStatus(ctx context.Context, req dto.BatchUpdateStatusReq) ([]*dto.BatchUserResult, error)
+}
+
+var roleEnum = gql.NewEnum(gql.EnumConfig{
+	Name: "Role",
+	Values: gql.EnumValueConfigMap{
+		"CUSTOMER": {Value: string(models.UserRoleCustomer)},
+		"STAFF":    {Value: string(models.UserRoleStaff)},
+		"ADMIN":    {Value: string(models.UserRoleAdmin)},
+	},
+})
+
+var statusEnum = gql.NewEnum(gql.EnumConfig{
+	Name: "UserStatus",
+	Values: gql.EnumValueConfigMap{
+		"ACTIVE":  {Value: string(models.UserStatusActive)},
+		"PENDING": {Value: string(models.UserStatusPending)},
+		"INVITED": {Value: string(models.UserStatusInvited)},
+		"BANNED":  {Value: string(models.UserStatusBanned)},
+	},
+})
+
+var userType = gql.NewObject(gql.ObjectConfig{
+	Name: "User",
+	Fields: gql.Fields{
+		"id":             {Type: gql.NewNonNull(gql.ID)},
+		"email":          {Type: gql.NewNonNull(gql.String)},
+		"username":       {Type: gql.NewNonNull(gql.String)},
+		"status":         {Type: gql.NewNonNull(statusEnum)},
+		"role":           {Type: gql.NewNonNull(roleEnum)},
+		"organizationId": {Type: gql.ID, Description: "The organization the user is a staff member of"},
+		"createdAt":      {Type: gql.NewNonNull(gql.String), Description: "RFC 3339 timestamp"},
+		"updatedAt":      {Type: gql.NewNonNull(gql.String), Description: "RFC 3339 timestamp"},
+	},
+})
+
+var userPageType = gql.NewObject(gql.ObjectConfig{
+	Name: "UserPage",
+	Fields: gql.Fields{
+		"users":         {Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(userType)))},
+		"nextPageToken": {Type: gql.String, Description: "Requests the next page, null on the last page"},
+	},
+})
+
+// NewSchema builds the admin schema resolved against the services
+func NewSchema(users UserReader, admin UserAdmin) (gql.Schema, error) {
+	r := &resolver{users: users, admin: admin}
+
+	return gql.NewSchema(gql.SchemaConfig{
+		Query: gql.NewObject(gql.ObjectConfig{
+			Name: "Query",
+			Fields: gql.Fields{
+				"user": {
+					Type: userType,
+					Args: gql.FieldConfigArgument{
+						"id": {Type: gql.NewNonNull(gql.ID)},
+					},
+					Resolve: r.user,
+				},
+				"users": {
+					Type:        gql.NewNonNull(userPageType),
+					Description: "Users in creation order",
+					Args: gql.FieldConfigArgument{
+						"first":          {Type: gql.Int, Description: "Page size, 50 by default and at most 500"},
+						"after":          {Type: gql.String, Description: "nextPageToken of the previous page"},
+						"organizationId": {Type: gql.ID},
+					},
+					Resolve: r.listUsers,
+				},
+			},
+		}),
+		Mutation: gql.NewObject(gql.ObjectConfig{
+			Name: "Mutation",
+			Fields: gql.Fields{
+				"banUser": {
+					Type:        gql.NewNonNull(userType),
+					Description: "Bans a user and revokes their sessions",
+					Args: gql.FieldConfigArgument{
+						"id":     {Type: gql.NewNonNull(gql.ID)},
+						"reason": {Type: gql.NewNonNull(gql.String)},
+					},
+					Resolve: r.banUser,
+				},
+				"assignRole": {
+					Type: gql.NewNonNull(userType),
+					Args: gql.FieldConfigArgument{
+						"id":     {Type: gql.NewNonNull(gql.ID)},
+						"role":   {Type: gql.NewNonNull(roleEnum)},
+						"reason": {Type: gql.NewNonNull(gql.String)},
+					},
+					Resolve: r.assignRole,
+				},
+			},
+		}),
+	})
+}
+
+type resolver struct {
+	users UserReader
+	admin UserAdmin
+}
+
+func (r *resolver) user(p gql.ResolveParams) (interface{}, error) {
+	user, err := r.users.GetUser(p.Context, p.Args["id"].(string))
+	if err != nil {
+		return nil, err
+	}
+	return userObject(user), nil
+}
+
+func (r *resolver) listUsers(p gql.ResolveParams) (interface{}, error) {
+	req := dto.ListUsersReq{}
+	if first, ok := p.Args["first"].(int); ok {
+		req.PageSize = first
+	}
+	if after, ok := p.Args["after"].(string); ok {
+		req.PageToken = after
+	}
+	if organizationID, ok := p.Args["organizationId"].(string); ok {
+		req.OrganizationID = organizationID
+	}
+
+	page, err := r.users.ListUsers(p.Context, req)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]map[string]interface{}, 0, len(page.Users))
+	for _, user := range page.Users {
+		users = append(users, userObject(user))
+	}
+
+	var nextPageToken interface{}
+	if page.NextPageToken != "" {
+		nextPageToken = page.NextPageToken
+	}
+	return map[string]interface{}{"users": users, "nextPageToken": nextPageToken}, nil
+}
+
+func (r *resolver) banUser(p gql.ResolveParams) (interface{}, error) {
+	id := p.Args["id"].(string)
+	results, err := r.admin.BatchUpdateStatus(p.Context, dto.BatchUpdateStatusReq{
+		UserIDs: []string{id},
+		Status:  string(models.UserStatusBanned),
+		Reason:  p.Args["reason"].(string),
+	})
+	if err := batchError(results, err); err != nil {
+		return nil, err
+	}
+
+	return r.user(p)
+}
+
+func (r *resolver) assignRole(p gql.ResolveParams) (interface{}, error) {
+	id := p.Args["id"].(string)
+	results, err := r.admin.BatchAssignRole(p.Context, dto.BatchAssignRoleReq{
+		UserIDs: []string{id},
+		Role:    p.Args["role"].(string),
+		Reason:  p.Args["reason"].(string),
+	})
+	if err := batchError(results, err); err != nil {
+		return nil, err
+	}
+
+	return r.user(p)
+}
+
+// batchError returns the error of a single-user batch operation
+func batchError(results []*dto.BatchUserResult, err error) error {
+	switch {
+	case err != nil:
+		return err
+	case results[0].Err != nil:
+		return results[0].Err
+	case results[0].Status == dto.BatchItemStatusNotFound:
+		return errs.ErrUserNotFound
+	}
+	return nil
+}
+
+func userObject(user *models.User) map[string]interface{} {
+	var organizationID interface{}
+	if user.OrganizationID != uuid.Nil {
+		organizationID = user.OrganizationID.String()
+	}
+
+	return map[string]interface{}{
+		"id":             user.ID.String(),
+		"email":          user.Email.String(),
+		"username":       user.Username.String(),
+		"status":         string(user.Status),
+		"role":           string(user.Role),
+		"organizationId": organizationID,
+		"createdAt":      time.UnixMilli(user.CreatedAt).UTC().Format(time.RFC3339),
+		"updatedAt":      time.UnixMilli(user.UpdatedAt).UTC().Format(time.RFC3339),
+	}
+}
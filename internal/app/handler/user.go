@@ -2,15 +2,57 @@ package handler
 
 import (
 	"context"
+	"slices"
 
-	pb "user-svc/api/proto"
+	pb "user-svc/api/proto/v1"
 	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/mapper"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/email"
+	"user-svc/pkg/utils/slo"
+
+	"github.com/google/uuid"
 )
 
 // UserHandler handles gRPC requests for user operations
 type UserHandler struct {
 	pb.UnimplementedUserServiceServer
-	userService UserService
+	userService          UserService
+	quotaService         QuotaService
+	activityService      ActivityService
+	emailService         EmailTemplateService
+	notifyService        NotificationService
+	statsService         StatsService
+	exportService        ExportService
+	holdService          LegalHoldService
+	riskService          RiskService
+	orgService           OrganizationService
+	importService        ImportService
+	bulkService          BulkService
+	historyService       UserHistoryService
+	signingKeyService    SigningKeyService
+	metadataService      UserMetadataService
+	avatarService        AvatarService
+	snapshotService      SnapshotService
+	watchService         UserWatchService
+	maintenanceService   MaintenanceService
+	pushTokenService     PushTokenService
+	loginScheduleService LoginScheduleService
+	globalLogoutService  GlobalLogoutService
+	clientService        AuthorizedClientService
+	orgAuditService      OrgAuditService
+	velocityRuleService  VelocityRuleService
+	canaryService        CanaryAccountService
+	adminUserService     AdminUserService
+	lookupService        UserLookupService
+	exchangeService      TokenExchangeService
+	verificationService  TokenVerificationService
+	guestActivityService GuestActivityService
+	noteService          UserNoteService
+	gateService          RegistrationGateService
+	loginHistoryService  LoginHistoryService
+	sloReporter          SLOReporter
 }
 
 // UserServiceInterface defines the methods that the user service should implement
@@ -18,68 +60,923 @@ type UserService interface {
 	Register(ctx context.Context, req dto.RegisterReq) (*dto.RegisterResp, error)
 	Login(ctx context.Context, req dto.LoginReq) (*dto.LoginResp, error)
 	RefreshToken(ctx context.Context, req dto.RefreshTokenReq) (*dto.RefreshTokenResp, error)
+	RevokeAllUserTokens(ctx context.Context, req dto.RevokeAllUserTokensReq) (*dto.RevokeAllUserTokensResp, error)
+	CompletePasswordSetup(ctx context.Context, req dto.CompletePasswordSetupReq) (*dto.LoginResp, error)
+	LoginWithIDToken(ctx context.Context, req dto.LoginWithIDTokenReq) (*dto.LoginResp, error)
+	StartRegistration(ctx context.Context, req dto.StartRegistrationReq) (*dto.StartRegistrationResp, error)
+	CompleteRegistration(ctx context.Context, req dto.CompleteRegistrationReq) (*dto.RegisterResp, error)
+	Logout(ctx context.Context, req dto.LogoutReq) error
+	RequestPasswordReset(ctx context.Context, req dto.RequestPasswordResetReq) error
+	ResetPassword(ctx context.Context, req dto.ResetPasswordReq) error
+	ResendVerificationEmail(ctx context.Context) (*dto.ResendVerificationEmailResp, error)
+	VerifyEmail(ctx context.Context, req dto.VerifyEmailReq) error
+	CreateInviteCode(ctx context.Context, req dto.CreateInviteCodeReq) (*models.InviteCode, error)
+}
+
+// QuotaService defines the quota methods exposed over gRPC
+type QuotaService interface {
+	GetUsage(ctx context.Context) (*dto.GetQuotaUsageResp, error)
+}
+
+// ActivityService defines the account activity methods exposed over gRPC
+type ActivityService interface {
+	GetAccountActivitySummary(ctx context.Context) (*models.ActivitySummary, error)
+}
+
+// EmailTemplateService defines the email template admin methods exposed over gRPC
+type EmailTemplateService interface {
+	PreviewEmailTemplate(ctx context.Context, req dto.PreviewEmailTemplateReq) (*email.Message, error)
+}
+
+// NotificationService defines the notification preference methods exposed over gRPC
+type NotificationService interface {
+	GetNotificationPreferences(ctx context.Context) ([]*models.NotificationPreference, error)
+	UpdateNotificationPreferences(ctx context.Context, req dto.UpdateNotificationPreferencesReq) ([]*models.NotificationPreference, error)
+}
+
+// StatsService defines the ops dashboard statistics methods exposed over gRPC
+type StatsService interface {
+	GetUserStats(ctx context.Context, req dto.GetUserStatsReq) (*models.UserStats, error)
+}
+
+// ExportService defines the user export methods exposed over gRPC
+type ExportService interface {
+	ExportUsers(ctx context.Context, req dto.ExportUsersReq, send func(chunk *models.UserExportChunk) error) error
+}
+
+// LegalHoldService defines the legal hold methods exposed over gRPC
+type LegalHoldService interface {
+	PlaceLegalHold(ctx context.Context, req dto.LegalHoldReq) (bool, error)
+	ReleaseLegalHold(ctx context.Context, req dto.LegalHoldReq) (bool, error)
+}
+
+// RiskService defines the fraud signal methods exposed over gRPC
+type RiskService interface {
+	GetRiskSignals(ctx context.Context, req dto.GetRiskSignalsReq) (*models.RiskSignals, error)
+}
+
+// UserLookupService defines the user lookup methods exposed over gRPC
+type UserLookupService interface {
+	GetUserByUsername(ctx context.Context, req dto.GetUserByUsernameReq) (*models.User, error)
+	BatchGetUsers(ctx context.Context, req dto.BatchGetUsersReq) (map[uuid.UUID]*models.User, error)
+}
+
+// TokenExchangeService defines the token exchange methods exposed over gRPC
+type TokenExchangeService interface {
+	ExchangeToken(ctx context.Context, req dto.ExchangeTokenReq) (*dto.ExchangeTokenResp, error)
+}
+
+// TokenVerificationService defines the access token verification methods exposed over gRPC
+type TokenVerificationService interface {
+	VerifyToken(ctx context.Context, req dto.VerifyTokenReq) (*token.Payload, error)
+}
+
+// GuestActivityService defines the guest activity methods exposed over gRPC
+type GuestActivityService interface {
+	ClaimGuestActivity(ctx context.Context, req dto.ClaimGuestActivityReq) (*dto.ClaimGuestActivityResp, error)
+}
+
+// OrganizationService defines the organization management methods exposed over gRPC
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, req dto.CreateOrganizationReq) (*models.Organization, error)
+	GetOrganization(ctx context.Context, req dto.GetOrganizationReq) (*models.Organization, error)
+	GetOrganizationByName(ctx context.Context, req dto.GetOrganizationByNameReq) (*models.Organization, error)
+	SetOrganizationEmailDomains(ctx context.Context, req dto.SetOrganizationEmailDomainsReq) (*models.Organization, error)
+	SetOrganizationBranding(ctx context.Context, req dto.SetOrganizationBrandingReq) (*models.Organization, error)
+	SetOrganizationSessionPolicy(ctx context.Context, req dto.SetOrganizationSessionPolicyReq) (*models.Organization, error)
+}
+
+// ImportService defines the user import methods exposed over gRPC
+type ImportService interface {
+	ImportUsers(
+		ctx context.Context,
+		recv func() (*dto.ImportUsersReq, error),
+		send func(results []*dto.ImportUserResult) error,
+	) error
+}
+
+// BulkService defines the batch admin methods exposed over gRPC
+type BulkService interface {
+	BatchAssignRole(ctx context.Context, req dto.BatchAssignRoleReq) ([]*dto.BatchUserResult, error)
+	BatchUpdateStatus(ctx context.Context, req dto.BatchUpdateStatusReq) ([]*dto.BatchUserResult, error)
+}
+
+// UserHistoryService defines the user history methods exposed over gRPC
+type UserHistoryService interface {
+	GetUserHistory(ctx context.Context, req dto.GetUserHistoryReq) (*dto.GetUserHistoryResp, error)
+}
+
+// SigningKeyService defines the JWT signing key rotation methods exposed over gRPC
+type SigningKeyService interface {
+	PromoteSigningKey(ctx context.Context) (*dto.PromoteSigningKeyResp, error)
+}
+
+// UserMetadataService defines the user metadata methods exposed over gRPC
+type UserMetadataService interface {
+	SetUserMetadata(ctx context.Context, req dto.SetUserMetadataReq) (models.UserMetadata, error)
+}
+
+// AvatarService defines the profile picture methods exposed over gRPC
+type AvatarService interface {
+	RequestAvatarUploadURL(ctx context.Context, contentType string) (*dto.AvatarUploadResp, error)
+	ConfirmAvatar(ctx context.Context, objectKey string) (*dto.AvatarResp, error)
+}
+
+// SnapshotService defines the snapshot export and restore methods exposed over gRPC
+type SnapshotService interface {
+	ExportSnapshot(ctx context.Context, req dto.ExportSnapshotReq, send func(data []byte) error) error
+	RestoreSnapshot(ctx context.Context, recv func() (*dto.RestoreSnapshotChunk, error)) (*dto.RestoreSnapshotResp, error)
+}
+
+// UserWatchService defines the user watch methods exposed over gRPC
+type UserWatchService interface {
+	WatchUser(ctx context.Context, req dto.WatchUserReq, send func(update *dto.WatchUserUpdate) error) error
+}
+
+// MaintenanceService defines the admin cleanup methods exposed over gRPC
+type MaintenanceService interface {
+	CleanupRefreshTokens(
+		ctx context.Context,
+		req dto.CleanupRefreshTokensReq,
+		send func(progress *dto.CleanupRefreshTokensProgress) error,
+	) error
+}
+
+// PushTokenService defines the push token registry methods exposed over gRPC
+type PushTokenService interface {
+	RegisterPushToken(ctx context.Context, req dto.RegisterPushTokenReq) (*models.PushToken, error)
+	UnregisterPushToken(ctx context.Context, req dto.UnregisterPushTokenReq) (bool, error)
+}
+
+// LoginScheduleService defines the login schedule administration methods exposed over gRPC
+type LoginScheduleService interface {
+	SetLoginSchedule(ctx context.Context, req dto.SetLoginScheduleReq) (*models.LoginSchedule, error)
+	GetLoginSchedule(ctx context.Context, req dto.LoginScheduleSubjectReq) (*models.LoginSchedule, error)
+	DeleteLoginSchedule(ctx context.Context, req dto.LoginScheduleSubjectReq) (bool, error)
+}
+
+// GlobalLogoutService defines the kill switch exposed over gRPC
+type GlobalLogoutService interface {
+	GlobalLogout(ctx context.Context, req dto.GlobalLogoutReq) (*models.GlobalLogout, error)
+}
+
+// AuthorizedClientService defines the connected apps methods exposed over gRPC
+type AuthorizedClientService interface {
+	ListAuthorizedClients(ctx context.Context) ([]*models.AuthorizedClient, error)
+	RevokeClientAccess(ctx context.Context, req dto.RevokeClientAccessReq) (int64, error)
+}
+
+// OrgAuditService defines the organization audit trail methods exposed over gRPC
+type OrgAuditService interface {
+	ExportOrgAuditLog(ctx context.Context, req dto.ExportOrgAuditLogReq, send func(entries []*models.AuditLog) error) error
+}
+
+// VelocityRuleService defines the velocity rule administration methods exposed over gRPC
+type VelocityRuleService interface {
+	SetVelocityRule(ctx context.Context, req dto.SetVelocityRuleReq) (*models.VelocityRule, error)
+	ListVelocityRules(ctx context.Context) ([]*models.VelocityRule, error)
+	DeleteVelocityRule(ctx context.Context, req dto.DeleteVelocityRuleReq) (bool, error)
+}
+
+// CanaryAccountService defines the canary account administration methods exposed over gRPC
+type CanaryAccountService interface {
+	SetCanaryAccount(ctx context.Context, req dto.SetCanaryAccountReq) (*models.CanaryAccount, error)
+	ListCanaryAccounts(ctx context.Context) ([]*models.CanaryAccount, error)
+	DeleteCanaryAccount(ctx context.Context, req dto.DeleteCanaryAccountReq) (bool, error)
+}
+
+// AdminUserService defines the admin user lookup methods exposed over gRPC
+type AdminUserService interface {
+	ListUsers(ctx context.Context, req dto.ListUsersReq) (*dto.ListUsersResp, error)
+}
+
+// UserNoteService defines the user note methods exposed over gRPC
+type UserNoteService interface {
+	AddUserNote(ctx context.Context, req dto.AddUserNoteReq) (*models.UserNote, error)
+	ListUserNotes(ctx context.Context, req dto.ListUserNotesReq) (*dto.ListUserNotesResp, error)
+}
+
+// RegistrationGateService defines the registration gate methods exposed over gRPC
+type RegistrationGateService interface {
+	GetRegistrationGates(ctx context.Context) (*dto.RegistrationGatesResp, error)
+	SetRegistrationGates(ctx context.Context, req dto.SetRegistrationGatesReq) (*dto.RegistrationGatesResp, error)
+}
+
+// LoginHistoryService defines the login history methods exposed over gRPC
+type LoginHistoryService interface {
+	GetLoginHistory(ctx context.Context, req dto.GetLoginHistoryReq) (*dto.GetLoginHistoryResp, error)
+}
+
+// SLOReporter provides the rolling SLO compliance report
+type SLOReporter interface {
+	Report() *slo.Report
 }
 
 // NewUserHandler creates a new UserHandler instance
-func NewUserHandler(userService UserService) *UserHandler {
+func NewUserHandler(
+	userService UserService,
+	quotaService QuotaService,
+	activityService ActivityService,
+	emailService EmailTemplateService,
+	notifyService NotificationService,
+	statsService StatsService,
+	exportService ExportService,
+	holdService LegalHoldService,
+	riskService RiskService,
+	orgService OrganizationService,
+	importService ImportService,
+	bulkService BulkService,
+	historyService UserHistoryService,
+	signingKeyService SigningKeyService,
+	metadataService UserMetadataService,
+	avatarService AvatarService,
+	snapshotService SnapshotService,
+	watchService UserWatchService,
+	maintenanceService MaintenanceService,
+	pushTokenService PushTokenService,
+	loginScheduleService LoginScheduleService,
+	globalLogoutService GlobalLogoutService,
+	clientService AuthorizedClientService,
+	orgAuditService OrgAuditService,
+	velocityRuleService VelocityRuleService,
+	canaryService CanaryAccountService,
+	adminUserService AdminUserService,
+	lookupService UserLookupService,
+	exchangeService TokenExchangeService,
+	verificationService TokenVerificationService,
+	guestActivityService GuestActivityService,
+	noteService UserNoteService,
+	gateService RegistrationGateService,
+	loginHistoryService LoginHistoryService,
+	sloReporter SLOReporter,
+) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:          userService,
+		quotaService:         quotaService,
+		activityService:      activityService,
+		emailService:         emailService,
+		notifyService:        notifyService,
+		statsService:         statsService,
+		exportService:        exportService,
+		holdService:          holdService,
+		riskService:          riskService,
+		orgService:           orgService,
+		importService:        importService,
+		bulkService:          bulkService,
+		historyService:       historyService,
+		signingKeyService:    signingKeyService,
+		metadataService:      metadataService,
+		avatarService:        avatarService,
+		snapshotService:      snapshotService,
+		watchService:         watchService,
+		maintenanceService:   maintenanceService,
+		pushTokenService:     pushTokenService,
+		loginScheduleService: loginScheduleService,
+		globalLogoutService:  globalLogoutService,
+		clientService:        clientService,
+		orgAuditService:      orgAuditService,
+		velocityRuleService:  velocityRuleService,
+		canaryService:        canaryService,
+		adminUserService:     adminUserService,
+		lookupService:        lookupService,
+		exchangeService:      exchangeService,
+		verificationService:  verificationService,
+		guestActivityService: guestActivityService,
+		noteService:          noteService,
+		gateService:          gateService,
+		loginHistoryService:  loginHistoryService,
+		sloReporter:          sloReporter,
 	}
 }
 
 // Register handles user registration
 func (h *UserHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
-	resp, err := h.userService.Register(ctx, dto.RegisterReq{
-		Email:    req.Email,
-		Username: req.Username,
-		Password: req.Password,
-	})
+	resp, err := h.userService.Register(ctx, mapper.RegisterReq(req))
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.RegisterResponse{
-		User: &pb.User{
-			Id:       resp.User.ID.String(),
-			Email:    resp.User.Email.String(),
-			Username: resp.User.Username.String(),
-		},
-		AccessToken:  resp.AccessToken,
-		RefreshToken: resp.RefreshToken,
-	}, nil
+	return mapper.RegisterResp(resp), nil
 }
 
 // Login handles user login
 func (h *UserHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	resp, err := h.userService.Login(ctx, dto.LoginReq{
-		Email:    req.Email,
-		Password: req.Password,
-	})
+	resp, err := h.userService.Login(ctx, mapper.LoginReq(req))
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.LoginResponse{
-		User: &pb.User{
-			Id:       resp.User.ID.String(),
-			Email:    resp.User.Email.String(),
-			Username: resp.User.Username.String(),
-		},
-		AccessToken:  resp.AccessToken,
-		RefreshToken: resp.RefreshToken,
-	}, nil
+	return mapper.LoginResp(resp), nil
+}
+
+// CompletePasswordSetup handles an invited user setting the first password
+func (h *UserHandler) CompletePasswordSetup(ctx context.Context, req *pb.CompletePasswordSetupRequest) (*pb.LoginResponse, error) {
+	resp, err := h.userService.CompletePasswordSetup(ctx, mapper.CompletePasswordSetupReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.LoginResp(resp), nil
+}
+
+// LoginWithIDToken handles a login with the ID token of an OpenID Connect provider
+func (h *UserHandler) LoginWithIDToken(ctx context.Context, req *pb.LoginWithIDTokenRequest) (*pb.LoginResponse, error) {
+	resp, err := h.userService.LoginWithIDToken(ctx, mapper.LoginWithIDTokenReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.LoginResp(resp), nil
 }
 
 // RefreshToken handles token refresh
 func (h *UserHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
-	resp, err := h.userService.RefreshToken(ctx, dto.RefreshTokenReq{
-		RefreshToken: req.RefreshToken,
+	resp, err := h.userService.RefreshToken(ctx, mapper.RefreshTokenReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.RefreshTokenResp(resp), nil
+}
+
+// RevokeAllUserTokens handles revocation of every token of the calling user
+func (h *UserHandler) RevokeAllUserTokens(ctx context.Context, req *pb.RevokeAllUserTokensRequest) (*pb.RevokeAllUserTokensResponse, error) {
+	resp, err := h.userService.RevokeAllUserTokens(ctx, mapper.RevokeAllUserTokensReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.RevokeAllUserTokensResp(resp), nil
+}
+
+// GetQuotaUsage handles quota usage lookups for the calling API key or user
+func (h *UserHandler) GetQuotaUsage(ctx context.Context, _ *pb.GetQuotaUsageRequest) (*pb.GetQuotaUsageResponse, error) {
+	resp, err := h.quotaService.GetUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.QuotaUsageResp(resp), nil
+}
+
+// GetSLOStatus handles SLO compliance lookups
+func (h *UserHandler) GetSLOStatus(_ context.Context, req *pb.GetSLOStatusRequest) (*pb.GetSLOStatusResponse, error) {
+	report := h.sloReporter.Report()
+	if req.Method != "" {
+		report.Statuses = slices.DeleteFunc(report.Statuses, func(status slo.Status) bool {
+			return status.Method != req.Method
+		})
+	}
+
+	return mapper.SLOReport(report), nil
+}
+
+// GetAccountActivitySummary handles retrieval of the caller's account activity summary
+func (h *UserHandler) GetAccountActivitySummary(ctx context.Context, _ *pb.GetAccountActivitySummaryRequest) (*pb.GetAccountActivitySummaryResponse, error) {
+	summary, err := h.activityService.GetAccountActivitySummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ActivitySummary(summary), nil
+}
+
+// PreviewEmailTemplate handles rendering an email template for an admin
+func (h *UserHandler) PreviewEmailTemplate(ctx context.Context, req *pb.PreviewEmailTemplateRequest) (*pb.PreviewEmailTemplateResponse, error) {
+	msg, err := h.emailService.PreviewEmailTemplate(ctx, mapper.PreviewEmailTemplateReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.EmailMessage(msg), nil
+}
+
+// GetNotificationPreferences handles retrieval of the caller's notification preferences
+func (h *UserHandler) GetNotificationPreferences(ctx context.Context, _ *pb.GetNotificationPreferencesRequest) (*pb.NotificationPreferencesResponse, error) {
+	prefs, err := h.notifyService.GetNotificationPreferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.NotificationPreferences(prefs), nil
+}
+
+// UpdateNotificationPreferences handles updates of the caller's notification preferences
+func (h *UserHandler) UpdateNotificationPreferences(ctx context.Context, req *pb.UpdateNotificationPreferencesRequest) (*pb.NotificationPreferencesResponse, error) {
+	prefs, err := h.notifyService.UpdateNotificationPreferences(ctx, mapper.UpdateNotificationPreferencesReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.NotificationPreferences(prefs), nil
+}
+
+// GetUserStats handles retrieval of the ops dashboard statistics
+func (h *UserHandler) GetUserStats(ctx context.Context, req *pb.GetUserStatsRequest) (*pb.GetUserStatsResponse, error) {
+	stats, err := h.statsService.GetUserStats(ctx, mapper.GetUserStatsReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.UserStats(stats), nil
+}
+
+// ExportUsers handles streaming the users matching the request's filters
+func (h *UserHandler) ExportUsers(req *pb.ExportUsersRequest, stream pb.UserService_ExportUsersServer) error {
+	exportReq := mapper.ExportUsersReq(req)
+	fields := exportReq.ExportFields()
+
+	return h.exportService.ExportUsers(stream.Context(), exportReq, func(chunk *models.UserExportChunk) error {
+		if chunk.NDJSON != nil {
+			return stream.Send(&pb.ExportUsersChunk{Ndjson: chunk.NDJSON})
+		}
+
+		users := make([]*pb.ExportedUser, 0, len(chunk.Users))
+		for _, user := range chunk.Users {
+			users = append(users, mapper.ExportedUser(user, fields))
+		}
+		return stream.Send(&pb.ExportUsersChunk{Users: users})
 	})
+}
+
+// PlaceLegalHold handles placing a user under legal hold
+func (h *UserHandler) PlaceLegalHold(ctx context.Context, req *pb.LegalHoldRequest) (*pb.LegalHoldResponse, error) {
+	changed, err := h.holdService.PlaceLegalHold(ctx, mapper.LegalHoldReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LegalHoldResponse{UserId: req.UserId, LegalHold: true, Changed: changed}, nil
+}
+
+// ReleaseLegalHold handles releasing a user's legal hold
+func (h *UserHandler) ReleaseLegalHold(ctx context.Context, req *pb.LegalHoldRequest) (*pb.LegalHoldResponse, error) {
+	changed, err := h.holdService.ReleaseLegalHold(ctx, mapper.LegalHoldReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LegalHoldResponse{UserId: req.UserId, LegalHold: false, Changed: changed}, nil
+}
+
+// GetRiskSignals handles retrieval of a user's fraud signals
+func (h *UserHandler) GetRiskSignals(ctx context.Context, req *pb.GetRiskSignalsRequest) (*pb.GetRiskSignalsResponse, error) {
+	signals, err := h.riskService.GetRiskSignals(ctx, mapper.GetRiskSignalsReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.RiskSignals(signals), nil
+}
+
+// GetUserByUsername handles resolving a user by username
+func (h *UserHandler) GetUserByUsername(ctx context.Context, req *pb.GetUserByUsernameRequest) (*pb.User, error) {
+	user, err := h.lookupService.GetUserByUsername(ctx, mapper.GetUserByUsernameReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.User(user), nil
+}
+
+// BatchGetUsers handles resolving many users by ID
+func (h *UserHandler) BatchGetUsers(ctx context.Context, req *pb.BatchGetUsersRequest) (*pb.BatchGetUsersResponse, error) {
+	users, err := h.lookupService.BatchGetUsers(ctx, mapper.BatchGetUsersReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.BatchGetUsersResp(users), nil
+}
+
+// ExchangeToken handles trading a user's access token for a delegation token
+func (h *UserHandler) ExchangeToken(ctx context.Context, req *pb.ExchangeTokenRequest) (*pb.ExchangeTokenResponse, error) {
+	resp, err := h.exchangeService.ExchangeToken(ctx, mapper.ExchangeTokenReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ExchangeTokenResp(resp), nil
+}
+
+// VerifyToken handles verifying an access token for another service
+func (h *UserHandler) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*pb.VerifyTokenResponse, error) {
+	payload, err := h.verificationService.VerifyToken(ctx, mapper.VerifyTokenReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.VerifyTokenResp(payload), nil
+}
+
+// ClaimGuestActivity handles claiming the guest activity under a verified email
+func (h *UserHandler) ClaimGuestActivity(ctx context.Context, req *pb.ClaimGuestActivityRequest) (*pb.ClaimGuestActivityResponse, error) {
+	resp, err := h.guestActivityService.ClaimGuestActivity(ctx, mapper.ClaimGuestActivityReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ClaimGuestActivityResp(resp), nil
+}
+
+// CreateOrganization handles organization creation
+func (h *UserHandler) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.Organization, error) {
+	org, err := h.orgService.CreateOrganization(ctx, mapper.CreateOrganizationReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.Organization(org), nil
+}
+
+// GetOrganization handles organization retrieval
+func (h *UserHandler) GetOrganization(ctx context.Context, req *pb.GetOrganizationRequest) (*pb.Organization, error) {
+	org, err := h.orgService.GetOrganization(ctx, mapper.GetOrganizationReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.Organization(org), nil
+}
+
+// GetOrganizationByName handles organization retrieval by name
+func (h *UserHandler) GetOrganizationByName(ctx context.Context, req *pb.GetOrganizationByNameRequest) (*pb.Organization, error) {
+	org, err := h.orgService.GetOrganizationByName(ctx, mapper.GetOrganizationByNameReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.Organization(org), nil
+}
+
+// SetOrganizationEmailDomains handles replacing the allowed email domains of an organization
+func (h *UserHandler) SetOrganizationEmailDomains(ctx context.Context, req *pb.SetOrganizationEmailDomainsRequest) (*pb.Organization, error) {
+	org, err := h.orgService.SetOrganizationEmailDomains(ctx, mapper.SetOrganizationEmailDomainsReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.Organization(org), nil
+}
+
+// SetOrganizationBranding handles replacing the email branding of an organization
+func (h *UserHandler) SetOrganizationBranding(ctx context.Context, req *pb.SetOrganizationBrandingRequest) (*pb.Organization, error) {
+	org, err := h.orgService.SetOrganizationBranding(ctx, mapper.SetOrganizationBrandingReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.Organization(org), nil
+}
+
+// SetOrganizationSessionPolicy handles replacing the session policy of an organization
+func (h *UserHandler) SetOrganizationSessionPolicy(ctx context.Context, req *pb.SetOrganizationSessionPolicyRequest) (*pb.Organization, error) {
+	org, err := h.orgService.SetOrganizationSessionPolicy(ctx, mapper.SetOrganizationSessionPolicyReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.Organization(org), nil
+}
+
+// ImportUsers handles the user import stream, answering every request batch with its results
+func (h *UserHandler) ImportUsers(stream pb.UserService_ImportUsersServer) error {
+	recv := func() (*dto.ImportUsersReq, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		batch := mapper.ImportUsersReq(req)
+		return &batch, nil
+	}
+
+	return h.importService.ImportUsers(stream.Context(), recv, func(results []*dto.ImportUserResult) error {
+		return stream.Send(mapper.ImportUsersResp(results))
+	})
+}
+
+// BatchAssignRole handles assigning a role to many users
+func (h *UserHandler) BatchAssignRole(ctx context.Context, req *pb.BatchAssignRoleRequest) (*pb.BatchUserResultsResponse, error) {
+	results, err := h.bulkService.BatchAssignRole(ctx, mapper.BatchAssignRoleReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.BatchUserResults(results), nil
+}
+
+// BatchUpdateStatus handles banning or unbanning many users
+func (h *UserHandler) BatchUpdateStatus(ctx context.Context, req *pb.BatchUpdateStatusRequest) (*pb.BatchUserResultsResponse, error) {
+	results, err := h.bulkService.BatchUpdateStatus(ctx, mapper.BatchUpdateStatusReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.BatchUserResults(results), nil
+}
+
+// GetUserHistory handles listing the event history of a user
+func (h *UserHandler) GetUserHistory(ctx context.Context, req *pb.GetUserHistoryRequest) (*pb.GetUserHistoryResponse, error) {
+	resp, err := h.historyService.GetUserHistory(ctx, mapper.GetUserHistoryReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.UserHistoryResp(resp), nil
+}
+
+// ListUsers handles listing a page of users in creation order
+func (h *UserHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	resp, err := h.adminUserService.ListUsers(ctx, mapper.ListUsersReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ListUsersResp(resp), nil
+}
+
+// PromoteSigningKey signs new tokens with the secondary JWT secret
+func (h *UserHandler) PromoteSigningKey(ctx context.Context, _ *pb.PromoteSigningKeyRequest) (*pb.PromoteSigningKeyResponse, error) {
+	resp, err := h.signingKeyService.PromoteSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.PromoteSigningKeyResp(resp), nil
+}
+
+// SetUserMetadata sets and removes namespaced metadata keys of a user
+func (h *UserHandler) SetUserMetadata(ctx context.Context, req *pb.SetUserMetadataRequest) (*pb.SetUserMetadataResponse, error) {
+	metadata, err := h.metadataService.SetUserMetadata(ctx, mapper.SetUserMetadataReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetUserMetadataResponse{Metadata: metadata}, nil
+}
+
+// RequestAvatarUploadURL returns a pre-signed upload URL for the caller's avatar
+func (h *UserHandler) RequestAvatarUploadURL(ctx context.Context, req *pb.RequestAvatarUploadURLRequest) (*pb.RequestAvatarUploadURLResponse, error) {
+	upload, err := h.avatarService.RequestAvatarUploadURL(ctx, req.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.AvatarUploadResp(upload), nil
+}
+
+// ConfirmAvatar makes an uploaded image the caller's avatar
+func (h *UserHandler) ConfirmAvatar(ctx context.Context, req *pb.ConfirmAvatarRequest) (*pb.ConfirmAvatarResponse, error) {
+	avatar, err := h.avatarService.ConfirmAvatar(ctx, req.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.AvatarResp(avatar), nil
+}
+
+// ExportSnapshot streams an encrypted snapshot of the users, roles and sessions
+func (h *UserHandler) ExportSnapshot(req *pb.ExportSnapshotRequest, stream pb.UserService_ExportSnapshotServer) error {
+	return h.snapshotService.ExportSnapshot(stream.Context(), mapper.ExportSnapshotReq(req), func(data []byte) error {
+		return stream.Send(&pb.SnapshotChunk{Data: data})
+	})
+}
+
+// RestoreSnapshot restores a streamed snapshot into an empty database
+func (h *UserHandler) RestoreSnapshot(stream pb.UserService_RestoreSnapshotServer) error {
+	recv := func() (*dto.RestoreSnapshotChunk, error) {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		chunk := mapper.RestoreSnapshotReq(req)
+		return &chunk, nil
+	}
+
+	resp, err := h.snapshotService.RestoreSnapshot(stream.Context(), recv)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(mapper.RestoreSnapshotResp(resp))
+}
+
+// WatchUser streams the state of users whenever it changes
+func (h *UserHandler) WatchUser(req *pb.WatchUserRequest, stream pb.UserService_WatchUserServer) error {
+	return h.watchService.WatchUser(stream.Context(), mapper.WatchUserReq(req), func(update *dto.WatchUserUpdate) error {
+		return stream.Send(mapper.UserUpdate(update))
+	})
+}
+
+// CleanupRefreshTokens deletes expired and revoked refresh tokens, streaming the progress
+func (h *UserHandler) CleanupRefreshTokens(
+	req *pb.CleanupRefreshTokensRequest,
+	stream pb.UserService_CleanupRefreshTokensServer,
+) error {
+	return h.maintenanceService.CleanupRefreshTokens(
+		stream.Context(),
+		mapper.CleanupRefreshTokensReq(req),
+		func(progress *dto.CleanupRefreshTokensProgress) error {
+			return stream.Send(mapper.CleanupRefreshTokensProgress(progress))
+		},
+	)
+}
+
+// RegisterPushToken registers the push token of a device of the caller
+func (h *UserHandler) RegisterPushToken(ctx context.Context, req *pb.RegisterPushTokenRequest) (*pb.RegisterPushTokenResponse, error) {
+	pushToken, err := h.pushTokenService.RegisterPushToken(ctx, mapper.RegisterPushTokenReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.PushTokenResp(pushToken), nil
+}
+
+// UnregisterPushToken removes the push token of a device of the caller
+func (h *UserHandler) UnregisterPushToken(ctx context.Context, req *pb.UnregisterPushTokenRequest) (*pb.UnregisterPushTokenResponse, error) {
+	unregistered, err := h.pushTokenService.UnregisterPushToken(ctx, mapper.UnregisterPushTokenReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UnregisterPushTokenResponse{Unregistered: unregistered}, nil
+}
+
+// SetLoginSchedule replaces the login time windows of a user or role
+func (h *UserHandler) SetLoginSchedule(ctx context.Context, req *pb.SetLoginScheduleRequest) (*pb.LoginSchedule, error) {
+	schedule, err := h.loginScheduleService.SetLoginSchedule(ctx, mapper.SetLoginScheduleReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.LoginSchedule(schedule), nil
+}
+
+// GetLoginSchedule returns the login time windows of a user or role
+func (h *UserHandler) GetLoginSchedule(ctx context.Context, req *pb.LoginScheduleSubject) (*pb.LoginSchedule, error) {
+	schedule, err := h.loginScheduleService.GetLoginSchedule(ctx, mapper.LoginScheduleSubjectReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.LoginSchedule(schedule), nil
+}
+
+// DeleteLoginSchedule removes the login time windows of a user or role
+func (h *UserHandler) DeleteLoginSchedule(ctx context.Context, req *pb.LoginScheduleSubject) (*pb.DeleteLoginScheduleResponse, error) {
+	deleted, err := h.loginScheduleService.DeleteLoginSchedule(ctx, mapper.LoginScheduleSubjectReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteLoginScheduleResponse{Deleted: deleted}, nil
+}
+
+// SetVelocityRule creates or replaces an anti-automation velocity rule
+func (h *UserHandler) SetVelocityRule(ctx context.Context, req *pb.SetVelocityRuleRequest) (*pb.VelocityRule, error) {
+	rule, err := h.velocityRuleService.SetVelocityRule(ctx, mapper.SetVelocityRuleReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.VelocityRule(rule), nil
+}
+
+// ListVelocityRules returns every anti-automation velocity rule
+func (h *UserHandler) ListVelocityRules(ctx context.Context, req *pb.ListVelocityRulesRequest) (*pb.ListVelocityRulesResponse, error) {
+	rules, err := h.velocityRuleService.ListVelocityRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.VelocityRulesResp(rules), nil
+}
+
+// DeleteVelocityRule deletes an anti-automation velocity rule
+func (h *UserHandler) DeleteVelocityRule(ctx context.Context, req *pb.DeleteVelocityRuleRequest) (*pb.DeleteVelocityRuleResponse, error) {
+	deleted, err := h.velocityRuleService.DeleteVelocityRule(ctx, mapper.DeleteVelocityRuleReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteVelocityRuleResponse{Deleted: deleted}, nil
+}
+
+// SetCanaryAccount flags a user as a canary account
+func (h *UserHandler) SetCanaryAccount(ctx context.Context, req *pb.SetCanaryAccountRequest) (*pb.CanaryAccount, error) {
+	account, err := h.canaryService.SetCanaryAccount(ctx, mapper.SetCanaryAccountReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.CanaryAccount(account), nil
+}
+
+// ListCanaryAccounts returns every canary account
+func (h *UserHandler) ListCanaryAccounts(ctx context.Context, req *pb.ListCanaryAccountsRequest) (*pb.ListCanaryAccountsResponse, error) {
+	accounts, err := h.canaryService.ListCanaryAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.CanaryAccountsResp(accounts), nil
+}
+
+// DeleteCanaryAccount removes the canary flag of a user
+func (h *UserHandler) DeleteCanaryAccount(ctx context.Context, req *pb.DeleteCanaryAccountRequest) (*pb.DeleteCanaryAccountResponse, error) {
+	deleted, err := h.canaryService.DeleteCanaryAccount(ctx, mapper.DeleteCanaryAccountReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteCanaryAccountResponse{Deleted: deleted}, nil
+}
+
+// GlobalLogout revokes every token issued up to a cutoff
+func (h *UserHandler) GlobalLogout(ctx context.Context, req *pb.GlobalLogoutRequest) (*pb.GlobalLogoutResponse, error) {
+	logout, err := h.globalLogoutService.GlobalLogout(ctx, mapper.GlobalLogoutReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.GlobalLogoutResp(logout), nil
+}
+
+// ListAuthorizedClients lists the applications the caller is signed in with
+func (h *UserHandler) ListAuthorizedClients(ctx context.Context, req *pb.ListAuthorizedClientsRequest) (*pb.ListAuthorizedClientsResponse, error) {
+	clients, err := h.clientService.ListAuthorizedClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.AuthorizedClientsResp(clients), nil
+}
+
+// RevokeClientAccess signs the caller out of every session of an application
+func (h *UserHandler) RevokeClientAccess(ctx context.Context, req *pb.RevokeClientAccessRequest) (*pb.RevokeClientAccessResponse, error) {
+	revoked, err := h.clientService.RevokeClientAccess(ctx, mapper.RevokeClientAccessReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RevokeClientAccessResponse{RevokedRefreshTokens: revoked}, nil
+}
+
+// ExportOrgAuditLog streams the audit trail of an organization
+func (h *UserHandler) ExportOrgAuditLog(req *pb.ExportOrgAuditLogRequest, stream pb.UserService_ExportOrgAuditLogServer) error {
+	return h.orgAuditService.ExportOrgAuditLog(stream.Context(), mapper.ExportOrgAuditLogReq(req), func(entries []*models.AuditLog) error {
+		return stream.Send(mapper.OrgAuditLogChunk(entries))
+	})
+}
+
+// AddUserNote handles leaving a note on a user
+func (h *UserHandler) AddUserNote(ctx context.Context, req *pb.AddUserNoteRequest) (*pb.UserNote, error) {
+	note, err := h.noteService.AddUserNote(ctx, mapper.AddUserNoteReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.UserNote(note), nil
+}
+
+// ListUserNotes handles listing a page of the notes on a user
+func (h *UserHandler) ListUserNotes(ctx context.Context, req *pb.ListUserNotesRequest) (*pb.ListUserNotesResponse, error) {
+	resp, err := h.noteService.ListUserNotes(ctx, mapper.ListUserNotesReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ListUserNotesResp(resp), nil
+}
+
+// GetRegistrationGates handles retrieving the registration gates
+func (h *UserHandler) GetRegistrationGates(ctx context.Context, _ *pb.GetRegistrationGatesRequest) (*pb.RegistrationGates, error) {
+	resp, err := h.gateService.GetRegistrationGates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.RegistrationGatesResp(resp), nil
+}
+
+// SetRegistrationGates handles replacing the registration gates
+func (h *UserHandler) SetRegistrationGates(ctx context.Context, req *pb.SetRegistrationGatesRequest) (*pb.RegistrationGates, error) {
+	resp, err := h.gateService.SetRegistrationGates(ctx, mapper.SetRegistrationGatesReq(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.RegistrationGatesResp(resp), nil
+}
+
+// GetLoginHistory handles listing a page of the login attempts of a user
+func (h *UserHandler) GetLoginHistory(ctx context.Context, req *pb.GetLoginHistoryRequest) (*pb.GetLoginHistoryResponse, error) {
+	resp, err := h.loginHistoryService.GetLoginHistory(ctx, mapper.GetLoginHistoryReq(req))
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.RefreshTokenResponse{
-		AccessToken: resp.AccessToken,
-	}, nil
+	return mapper.GetLoginHistoryResp(resp), nil
 }
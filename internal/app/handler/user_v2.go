@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+
+	pbv2 "user-svc/api/proto/v2"
+	"user-svc/internal/app/mapper"
+)
+
+// UserHandlerV2 handles gRPC requests of the v2 user service. It serves the same user service
+// as UserHandler, only with the v2 messages.
+type UserHandlerV2 struct {
+	pbv2.UnimplementedUserServiceServer
+	userService UserService
+}
+
+// NewUserHandlerV2 creates a new UserHandlerV2 instance
+func NewUserHandlerV2(userService UserService) *UserHandlerV2 {
+	return &UserHandlerV2{
+		userService: userService,
+	}
+}
+
+// Register handles user registration
+func (h *UserHandlerV2) Register(ctx context.Context, req *pbv2.RegisterRequest) (*pbv2.RegisterResponse, error) {
+	resp, err := h.userService.Register(ctx, mapper.RegisterReqV2(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.RegisterRespV2(resp), nil
+}
+
+// StartRegistration handles emailing the code verifying a registration
+func (h *UserHandlerV2) StartRegistration(ctx context.Context, req *pbv2.StartRegistrationRequest) (*pbv2.StartRegistrationResponse, error) {
+	resp, err := h.userService.StartRegistration(ctx, mapper.StartRegistrationReqV2(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.StartRegistrationRespV2(resp), nil
+}
+
+// CompleteRegistration handles a registration with its emailed code
+func (h *UserHandlerV2) CompleteRegistration(ctx context.Context, req *pbv2.CompleteRegistrationRequest) (*pbv2.RegisterResponse, error) {
+	resp, err := h.userService.CompleteRegistration(ctx, mapper.CompleteRegistrationReqV2(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.RegisterRespV2(resp), nil
+}
+
+// Login handles user login
+func (h *UserHandlerV2) Login(ctx context.Context, req *pbv2.LoginRequest) (*pbv2.LoginResponse, error) {
+	resp, err := h.userService.Login(ctx, mapper.LoginReqV2(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.LoginRespV2(resp), nil
+}
+
+// RefreshToken handles token refresh
+func (h *UserHandlerV2) RefreshToken(ctx context.Context, req *pbv2.RefreshTokenRequest) (*pbv2.RefreshTokenResponse, error) {
+	resp, err := h.userService.RefreshToken(ctx, mapper.RefreshTokenReqV2(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.RefreshTokenRespV2(resp), nil
+}
+
+// Logout handles ending the session of a refresh token
+func (h *UserHandlerV2) Logout(ctx context.Context, req *pbv2.LogoutRequest) (*pbv2.LogoutResponse, error) {
+	if err := h.userService.Logout(ctx, mapper.LogoutReqV2(req)); err != nil {
+		return nil, err
+	}
+
+	return &pbv2.LogoutResponse{}, nil
+}
+
+// RequestPasswordReset handles emailing a password reset link
+func (h *UserHandlerV2) RequestPasswordReset(ctx context.Context, req *pbv2.RequestPasswordResetRequest) (*pbv2.RequestPasswordResetResponse, error) {
+	if err := h.userService.RequestPasswordReset(ctx, mapper.RequestPasswordResetReqV2(req)); err != nil {
+		return nil, err
+	}
+
+	return &pbv2.RequestPasswordResetResponse{}, nil
+}
+
+// ResetPassword handles setting a new password with an emailed reset token
+func (h *UserHandlerV2) ResetPassword(ctx context.Context, req *pbv2.ResetPasswordRequest) (*pbv2.ResetPasswordResponse, error) {
+	if err := h.userService.ResetPassword(ctx, mapper.ResetPasswordReqV2(req)); err != nil {
+		return nil, err
+	}
+
+	return &pbv2.ResetPasswordResponse{}, nil
+}
+
+// ResendVerificationEmail handles emailing the caller a link verifying the email
+func (h *UserHandlerV2) ResendVerificationEmail(ctx context.Context, _ *pbv2.ResendVerificationEmailRequest) (*pbv2.ResendVerificationEmailResponse, error) {
+	resp, err := h.userService.ResendVerificationEmail(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ResendVerificationEmailRespV2(resp), nil
+}
+
+// VerifyEmail handles verifying an email with an emailed token
+func (h *UserHandlerV2) VerifyEmail(ctx context.Context, req *pbv2.VerifyEmailRequest) (*pbv2.VerifyEmailResponse, error) {
+	if err := h.userService.VerifyEmail(ctx, mapper.VerifyEmailReqV2(req)); err != nil {
+		return nil, err
+	}
+
+	return &pbv2.VerifyEmailResponse{}, nil
+}
+
+// CreateInviteCode handles creating an invite code of the caller
+func (h *UserHandlerV2) CreateInviteCode(ctx context.Context, req *pbv2.CreateInviteCodeRequest) (*pbv2.InviteCode, error) {
+	inviteCode, err := h.userService.CreateInviteCode(ctx, mapper.CreateInviteCodeReqV2(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.InviteCodeV2(inviteCode), nil
+}
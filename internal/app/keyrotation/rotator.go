@@ -0,0 +1,166 @@
+package keyrotation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	logutils "user-svc/pkg/utils/log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyRing is the set of configured token signing keys, see token.CompositeTokenMaker
+type KeyRing interface {
+	PrimaryKeyID() string
+	KeyIDs() []string
+	SetPrimaryKey(id string) error
+}
+
+type Repository interface {
+	GetPrimary(ctx context.Context) (string, error)
+	SetPrimary(ctx context.Context, keyID string, promotedAt int64) error
+}
+
+// Rotator promotes the secondary JWT signing key on every replica. Every replica verifies
+// tokens with both configured keys all along, so a replica that learns about a promotion
+// late keeps accepting the tokens signed with the new key. The promotion is persisted and
+// broadcast over Redis pub/sub; replicas also resync from the database periodically and
+// on startup, so restarted replicas keep signing with the promoted key.
+type Rotator struct {
+	keys           KeyRing
+	repo           Repository
+	client         *redis.Client
+	channel        string
+	resyncInterval time.Duration
+	logger         *logutils.Logger
+}
+
+func NewRotator(
+	keys KeyRing,
+	repo Repository,
+	client *redis.Client,
+	channel string,
+	resyncInterval time.Duration,
+	logger *logutils.Logger,
+) *Rotator {
+	return &Rotator{
+		keys:           keys,
+		repo:           repo,
+		client:         client,
+		channel:        channel,
+		resyncInterval: resyncInterval,
+		logger:         logger,
+	}
+}
+
+// PromoteSecondary signs new tokens with the configured key that is not the primary one and
+// returns the IDs of the new and the previous primary key. Promoting twice switches back.
+func (r *Rotator) PromoteSecondary(ctx context.Context) (string, string, error) {
+	// The persisted key is authoritative: this replica may not have seen the last promotion yet
+	previous, err := r.repo.GetPrimary(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if !r.configured(previous) {
+		previous = r.keys.PrimaryKeyID()
+	}
+
+	var promoted string
+	for _, id := range r.keys.KeyIDs() {
+		if id != previous {
+			promoted = id
+			break
+		}
+	}
+	if promoted == "" {
+		return "", "", errs.ErrNoSecondarySigningKey
+	}
+
+	if err := r.repo.SetPrimary(ctx, promoted, time.Now().UnixMilli()); err != nil {
+		return "", "", err
+	}
+	if err := r.keys.SetPrimaryKey(promoted); err != nil {
+		return "", "", err
+	}
+
+	// A failed broadcast is only logged since other replicas pick the key up on resync
+	if err := r.client.Publish(ctx, r.channel, promoted).Err(); err != nil {
+		r.logger.WithError(err).WithField("key_id", promoted).
+			Warn("Failed to broadcast signing key promotion, replicas will pick it up on resync")
+	}
+
+	return promoted, previous, nil
+}
+
+// Start subscribes to promotions and resyncs from the database until ctx is cancelled
+func (r *Rotator) Start(ctx context.Context, wg *sync.WaitGroup) {
+	r.logger.WithField("channel", r.channel).Info("Starting signing key rotator")
+
+	pubsub := r.client.Subscribe(ctx, r.channel)
+	r.resync(ctx)
+
+	wg.Add(1)
+	go func() {
+		defer func() {
+			_ = pubsub.Close()
+			wg.Done()
+			r.logger.Info("Signing key rotator stopped")
+		}()
+
+		ticker := time.NewTicker(r.resyncInterval)
+		defer ticker.Stop()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				r.apply(msg.Payload)
+			case <-ticker.C:
+				r.resync(ctx)
+			}
+		}
+	}()
+}
+
+// resync applies the persisted primary key
+func (r *Rotator) resync(ctx context.Context) {
+	keyID, err := r.repo.GetPrimary(ctx)
+	if err != nil {
+		r.logger.WithError(err).Warn("Failed to resync primary signing key")
+		return
+	}
+	if keyID != "" {
+		r.apply(keyID)
+	}
+}
+
+// apply signs with the key unless this replica does not have it. That happens once the
+// promoted secret was moved to secret_key and the old one removed, so the configured
+// primary key is kept.
+func (r *Rotator) apply(keyID string) {
+	if keyID == r.keys.PrimaryKeyID() {
+		return
+	}
+	if err := r.keys.SetPrimaryKey(keyID); err != nil {
+		r.logger.WithField("key_id", keyID).Warn("Promoted signing key is not configured on this replica")
+		return
+	}
+
+	r.logger.WithField("key_id", keyID).Info("Signing new tokens with promoted key")
+}
+
+func (r *Rotator) configured(keyID string) bool {
+	for _, id := range r.keys.KeyIDs() {
+		if id == keyID {
+			return true
+		}
+	}
+	return false
+}
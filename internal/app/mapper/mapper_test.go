@@ -0,0 +1,831 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pb "user-svc/api/proto/v1"
+	pbv2 "user-svc/api/proto/v2"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/email"
+	"user-svc/pkg/utils/slo"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// roundTrip converts a message with every field set to the other layer and back. A field
+// the converter under test drops comes back empty and fails the comparison.
+type roundTrip struct {
+	name string
+	run  func(t *testing.T)
+}
+
+// requestRoundTrip checks a request converter against its inverse. The DTO must also have
+// every field set, except the ones named in unmapped that no request carries.
+func requestRoundTrip[P proto.Message, D any](toDTO func(P) D, fromDTO func(D) P, unmapped ...string) roundTrip {
+	var zero P
+	return roundTrip{
+		name: string(zero.ProtoReflect().Descriptor().FullName()),
+		run: func(t *testing.T) {
+			msg := filledMessage[P]()
+			converted := toDTO(msg)
+
+			for _, field := range emptyFields(reflect.ValueOf(converted), "") {
+				if !slices.Contains(unmapped, field) {
+					t.Errorf("Expected %s to be set from the request", field)
+				}
+			}
+			if back := fromDTO(converted); !proto.Equal(msg, back) {
+				t.Errorf("Expected round trip to keep every field,\nsent     %v\nreturned %v", msg, back)
+			}
+		},
+	}
+}
+
+// responseRoundTrip checks a response converter against its inverse
+func responseRoundTrip[P proto.Message, S any](toProto func(S) P, fromProto func(P) S) roundTrip {
+	var zero P
+	return roundTrip{
+		name: string(zero.ProtoReflect().Descriptor().FullName()),
+		run: func(t *testing.T) {
+			msg := filledMessage[P]()
+			if back := toProto(fromProto(msg)); !proto.Equal(msg, back) {
+				t.Errorf("Expected round trip to keep every field,\nsent     %v\nreturned %v", msg, back)
+			}
+		},
+	}
+}
+
+func TestRoundTrips(t *testing.T) {
+	cases := []roundTrip{
+		requestRoundTrip(RegisterReq, func(req dto.RegisterReq) *pb.RegisterRequest {
+			return &pb.RegisterRequest{Email: req.Email, Username: req.Username, Password: req.Password, ClientId: req.ClientID, OrganizationId: req.OrganizationID, InviteCode: req.InviteCode}
+		}),
+		requestRoundTrip(LoginReq, func(req dto.LoginReq) *pb.LoginRequest {
+			return &pb.LoginRequest{Email: req.Email, Password: req.Password, ClientId: req.ClientID, RememberMe: req.RememberMe}
+		}),
+		requestRoundTrip(CompletePasswordSetupReq, func(req dto.CompletePasswordSetupReq) *pb.CompletePasswordSetupRequest {
+			return &pb.CompletePasswordSetupRequest{Token: req.Token, Password: req.Password, ClientId: req.ClientID}
+		}),
+		requestRoundTrip(LoginWithIDTokenReq, func(req dto.LoginWithIDTokenReq) *pb.LoginWithIDTokenRequest {
+			return &pb.LoginWithIDTokenRequest{Provider: req.Provider, IdToken: req.IDToken, Nonce: req.Nonce, ClientId: req.ClientID, RememberMe: req.RememberMe}
+		}),
+		requestRoundTrip(RefreshTokenReq, func(req dto.RefreshTokenReq) *pb.RefreshTokenRequest {
+			return &pb.RefreshTokenRequest{RefreshToken: req.RefreshToken}
+		}),
+		requestRoundTrip(RevokeAllUserTokensReq, func(req dto.RevokeAllUserTokensReq) *pb.RevokeAllUserTokensRequest {
+			return &pb.RevokeAllUserTokensRequest{UserId: req.UserID}
+		}),
+		requestRoundTrip(PreviewEmailTemplateReq, func(req dto.PreviewEmailTemplateReq) *pb.PreviewEmailTemplateRequest {
+			return &pb.PreviewEmailTemplateRequest{Name: req.Name, Locale: req.Locale, Data: req.Data}
+		}),
+		requestRoundTrip(UpdateNotificationPreferencesReq, func(req dto.UpdateNotificationPreferencesReq) *pb.UpdateNotificationPreferencesRequest {
+			resp := &pb.UpdateNotificationPreferencesRequest{}
+			for _, pref := range req.Preferences {
+				resp.Preferences = append(resp.Preferences, &pb.NotificationPreference{EventType: pref.EventType, Channel: pref.Channel, Enabled: pref.Enabled})
+			}
+			return resp
+		}),
+		requestRoundTrip(GetUserStatsReq, func(req dto.GetUserStatsReq) *pb.GetUserStatsRequest {
+			return &pb.GetUserStatsRequest{Days: int32(req.Days)}
+		}),
+		requestRoundTrip(ExportUsersReq, func(req dto.ExportUsersReq) *pb.ExportUsersRequest {
+			return &pb.ExportUsersRequest{
+				CreatedFrom: req.CreatedFrom, CreatedTo: req.CreatedTo, Format: req.Format, Fields: req.Fields,
+				RowsPerSecond: int32(req.RowsPerSecond), OrganizationId: req.OrganizationID,
+			}
+		}),
+		requestRoundTrip(LegalHoldReq, func(req dto.LegalHoldReq) *pb.LegalHoldRequest {
+			return &pb.LegalHoldRequest{UserId: req.UserID, Reason: req.Reason}
+		}),
+		requestRoundTrip(GetRiskSignalsReq, func(req dto.GetRiskSignalsReq) *pb.GetRiskSignalsRequest {
+			return &pb.GetRiskSignalsRequest{UserId: req.UserID}
+		}),
+		requestRoundTrip(GetUserByUsernameReq, func(req dto.GetUserByUsernameReq) *pb.GetUserByUsernameRequest {
+			return &pb.GetUserByUsernameRequest{Username: req.Username}
+		}),
+		requestRoundTrip(BatchGetUsersReq, func(req dto.BatchGetUsersReq) *pb.BatchGetUsersRequest {
+			return &pb.BatchGetUsersRequest{UserIds: req.UserIDs}
+		}),
+		requestRoundTrip(VerifyTokenReq, func(req dto.VerifyTokenReq) *pb.VerifyTokenRequest {
+			return &pb.VerifyTokenRequest{AccessToken: req.AccessToken}
+		}),
+		requestRoundTrip(ClaimGuestActivityReq, func(req dto.ClaimGuestActivityReq) *pb.ClaimGuestActivityRequest {
+			return &pb.ClaimGuestActivityRequest{UserId: req.UserID, Email: req.Email}
+		}),
+		requestRoundTrip(ExchangeTokenReq, func(req dto.ExchangeTokenReq) *pb.ExchangeTokenRequest {
+			return &pb.ExchangeTokenRequest{
+				GrantType: req.GrantType, SubjectToken: req.SubjectToken, SubjectTokenType: req.SubjectTokenType,
+				Audience: req.Audience, Scope: req.Scopes, RequestedTokenType: req.RequestedTokenType,
+			}
+		}),
+		requestRoundTrip(CreateOrganizationReq, func(req dto.CreateOrganizationReq) *pb.CreateOrganizationRequest {
+			return &pb.CreateOrganizationRequest{Name: req.Name, AllowedEmailDomains: req.AllowedEmailDomains, Residency: req.Residency}
+		}),
+		requestRoundTrip(GetOrganizationReq, func(req dto.GetOrganizationReq) *pb.GetOrganizationRequest {
+			return &pb.GetOrganizationRequest{OrganizationId: req.OrganizationID}
+		}),
+		requestRoundTrip(GetOrganizationByNameReq, func(req dto.GetOrganizationByNameReq) *pb.GetOrganizationByNameRequest {
+			return &pb.GetOrganizationByNameRequest{Name: req.Name}
+		}),
+		requestRoundTrip(SetOrganizationEmailDomainsReq, func(req dto.SetOrganizationEmailDomainsReq) *pb.SetOrganizationEmailDomainsRequest {
+			return &pb.SetOrganizationEmailDomainsRequest{OrganizationId: req.OrganizationID, AllowedEmailDomains: req.AllowedEmailDomains}
+		}),
+		requestRoundTrip(SetOrganizationBrandingReq, func(req dto.SetOrganizationBrandingReq) *pb.SetOrganizationBrandingRequest {
+			return &pb.SetOrganizationBrandingRequest{OrganizationId: req.OrganizationID, Branding: &pb.OrganizationBranding{
+				Name: req.Branding.Name, LogoUrl: req.Branding.LogoURL, SupportEmail: req.Branding.SupportEmail, Color: req.Branding.Color,
+			}}
+		}),
+		requestRoundTrip(SetOrganizationSessionPolicyReq, func(req dto.SetOrganizationSessionPolicyReq) *pb.SetOrganizationSessionPolicyRequest {
+			return &pb.SetOrganizationSessionPolicyRequest{OrganizationId: req.OrganizationID, SessionIdleTimeoutSeconds: req.SessionIdleTimeoutSeconds}
+		}),
+		requestRoundTrip(ImportUsersReq, func(req dto.ImportUsersReq) *pb.ImportUsersRequest {
+			resp := &pb.ImportUsersRequest{}
+			for _, user := range req.Users {
+				rec := &pb.ImportUserRecord{Email: user.Email, Username: user.Username, OrganizationId: user.OrganizationID}
+				if legacy := user.LegacyPassword; legacy != nil {
+					rec.LegacyPassword = &pb.LegacyPasswordHash{Format: legacy.Format, Salt: legacy.Salt, Hash: legacy.Hash}
+				}
+				resp.Users = append(resp.Users, rec)
+			}
+			return resp
+		}),
+		requestRoundTrip(BatchAssignRoleReq, func(req dto.BatchAssignRoleReq) *pb.BatchAssignRoleRequest {
+			return &pb.BatchAssignRoleRequest{UserIds: req.UserIDs, Role: req.Role, Reason: req.Reason, DryRun: req.DryRun}
+		}),
+		requestRoundTrip(BatchUpdateStatusReq, func(req dto.BatchUpdateStatusReq) *pb.BatchUpdateStatusRequest {
+			return &pb.BatchUpdateStatusRequest{UserIds: req.UserIDs, Status: req.Status, Reason: req.Reason, DryRun: req.DryRun}
+		}),
+		requestRoundTrip(GetUserHistoryReq, func(req dto.GetUserHistoryReq) *pb.GetUserHistoryRequest {
+			return &pb.GetUserHistoryRequest{UserId: req.UserID, AfterVersion: req.AfterVersion, Limit: int32(req.Limit)}
+		}),
+		requestRoundTrip(ListUsersReq, func(req dto.ListUsersReq) *pb.ListUsersRequest {
+			return &pb.ListUsersRequest{PageSize: int32(req.PageSize), PageToken: req.PageToken, OrganizationId: req.OrganizationID}
+		}),
+		requestRoundTrip(AddUserNoteReq, func(req dto.AddUserNoteReq) *pb.AddUserNoteRequest {
+			return &pb.AddUserNoteRequest{UserId: req.UserID, Body: req.Body, Visibility: req.Visibility, Author: req.Author}
+		}),
+		requestRoundTrip(ListUserNotesReq, func(req dto.ListUserNotesReq) *pb.ListUserNotesRequest {
+			return &pb.ListUserNotesRequest{UserId: req.UserID, Visibility: req.Visibility, PageSize: int32(req.PageSize), PageToken: req.PageToken}
+		}),
+		requestRoundTrip(SetRegistrationGatesReq, func(req dto.SetRegistrationGatesReq) *pb.SetRegistrationGatesRequest {
+			return &pb.SetRegistrationGatesRequest{
+				InviteCodeRequired: req.InviteCodeRequired, InviteCodes: req.InviteCodes, DailyCap: int32(req.DailyCap),
+				AllowlistOnly: req.AllowlistOnly, Allowlist: req.Allowlist,
+			}
+		}),
+		requestRoundTrip(GetLoginHistoryReq, func(req dto.GetLoginHistoryReq) *pb.GetLoginHistoryRequest {
+			return &pb.GetLoginHistoryRequest{UserId: req.UserID, PageSize: int32(req.PageSize), PageToken: req.PageToken}
+		}),
+		requestRoundTrip(SetUserMetadataReq, func(req dto.SetUserMetadataReq) *pb.SetUserMetadataRequest {
+			return &pb.SetUserMetadataRequest{UserId: req.UserID, Set: req.Set, Remove: req.Remove}
+		}),
+		requestRoundTrip(ExportSnapshotReq, func(req dto.ExportSnapshotReq) *pb.ExportSnapshotRequest {
+			return &pb.ExportSnapshotRequest{Passphrase: req.Passphrase}
+		}),
+		requestRoundTrip(RestoreSnapshotReq, func(req dto.RestoreSnapshotChunk) *pb.RestoreSnapshotRequest {
+			return &pb.RestoreSnapshotRequest{Passphrase: req.Passphrase, Data: req.Data}
+		}),
+		requestRoundTrip(WatchUserReq, func(req dto.WatchUserReq) *pb.WatchUserRequest {
+			return &pb.WatchUserRequest{UserIds: req.UserIDs}
+		}),
+		requestRoundTrip(CleanupRefreshTokensReq, func(req dto.CleanupRefreshTokensReq) *pb.CleanupRefreshTokensRequest {
+			return &pb.CleanupRefreshTokensRequest{
+				UserId: req.UserID, OlderThanSeconds: req.OlderThanSeconds, RevokedOnly: req.RevokedOnly,
+				BatchSize: int32(req.BatchSize), MaxTokens: req.MaxTokens,
+			}
+		}),
+		requestRoundTrip(RegisterPushTokenReq, func(req dto.RegisterPushTokenReq) *pb.RegisterPushTokenRequest {
+			return &pb.RegisterPushTokenRequest{DeviceId: req.DeviceID, Platform: req.Platform, Token: req.Token}
+		}),
+		requestRoundTrip(UnregisterPushTokenReq, func(req dto.UnregisterPushTokenReq) *pb.UnregisterPushTokenRequest {
+			return &pb.UnregisterPushTokenRequest{DeviceId: req.DeviceID}
+		}),
+		requestRoundTrip(LoginScheduleSubjectReq, loginScheduleSubjectFromDTO),
+		requestRoundTrip(GlobalLogoutReq, func(req dto.GlobalLogoutReq) *pb.GlobalLogoutRequest {
+			return &pb.GlobalLogoutRequest{Cutoff: req.Cutoff, Reason: req.Reason, Confirmation: req.Confirmation}
+		}),
+		requestRoundTrip(RevokeClientAccessReq, func(req dto.RevokeClientAccessReq) *pb.RevokeClientAccessRequest {
+			return &pb.RevokeClientAccessRequest{ClientId: req.ClientID}
+		}),
+		requestRoundTrip(ExportOrgAuditLogReq, func(req dto.ExportOrgAuditLogReq) *pb.ExportOrgAuditLogRequest {
+			return &pb.ExportOrgAuditLogRequest{OrganizationId: req.OrganizationID, From: req.From, To: req.To}
+		}),
+		requestRoundTrip(SetVelocityRuleReq, func(req dto.SetVelocityRuleReq) *pb.SetVelocityRuleRequest {
+			return &pb.SetVelocityRuleRequest{
+				Name: req.Name, Entity: req.Entity, Action: req.Action, Threshold: int32(req.Threshold),
+				WindowSeconds: req.WindowSeconds, Response: req.Response, Enabled: req.Enabled,
+			}
+		}),
+		requestRoundTrip(DeleteVelocityRuleReq, func(req dto.DeleteVelocityRuleReq) *pb.DeleteVelocityRuleRequest {
+			return &pb.DeleteVelocityRuleRequest{Name: req.Name}
+		}),
+		requestRoundTrip(SetCanaryAccountReq, func(req dto.SetCanaryAccountReq) *pb.SetCanaryAccountRequest {
+			return &pb.SetCanaryAccountRequest{UserId: req.UserID, Note: req.Note, Tarpit: req.Tarpit}
+		}),
+		requestRoundTrip(DeleteCanaryAccountReq, func(req dto.DeleteCanaryAccountReq) *pb.DeleteCanaryAccountRequest {
+			return &pb.DeleteCanaryAccountRequest{UserId: req.UserID}
+		}),
+		requestRoundTrip(SetLoginScheduleReq, func(req dto.SetLoginScheduleReq) *pb.SetLoginScheduleRequest {
+			windows := make([]*pb.LoginWindow, 0, len(req.Windows))
+			for _, window := range req.Windows {
+				windows = append(windows, &pb.LoginWindow{Days: window.Days, Start: window.Start, End: window.End})
+			}
+			return &pb.SetLoginScheduleRequest{
+				Subject:  loginScheduleSubjectFromDTO(req.LoginScheduleSubjectReq),
+				Timezone: req.Timezone,
+				Windows:  windows,
+			}
+		}),
+
+		responseRoundTrip(User, userFromProto),
+		responseRoundTrip(RegisterResp, func(resp *pb.RegisterResponse) *dto.RegisterResp {
+			return &dto.RegisterResp{User: userFromProto(resp.User), AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken}
+		}),
+		responseRoundTrip(LoginResp, func(resp *pb.LoginResponse) *dto.LoginResp {
+			return &dto.LoginResp{User: userFromProto(resp.User), AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken}
+		}),
+		responseRoundTrip(RefreshTokenResp, func(resp *pb.RefreshTokenResponse) *dto.RefreshTokenResp {
+			return &dto.RefreshTokenResp{AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken}
+		}),
+		requestRoundTrip(RegisterReqV2, func(req dto.RegisterReq) *pbv2.RegisterRequest {
+			return &pbv2.RegisterRequest{Email: req.Email, Username: req.Username, Password: req.Password, ClientId: req.ClientID, OrganizationId: req.OrganizationID, InviteCode: req.InviteCode}
+		}),
+		requestRoundTrip(StartRegistrationReqV2, func(req dto.StartRegistrationReq) *pbv2.StartRegistrationRequest {
+			return &pbv2.StartRegistrationRequest{Email: req.Email, ClientId: req.ClientID}
+		}),
+		requestRoundTrip(CompleteRegistrationReqV2, func(req dto.CompleteRegistrationReq) *pbv2.CompleteRegistrationRequest {
+			return &pbv2.CompleteRegistrationRequest{
+				Email: req.Email, Code: req.Code, Username: req.Username, Password: req.Password,
+				ClientId: req.ClientID, OrganizationId: req.OrganizationID, InviteCode: req.InviteCode,
+			}
+		}),
+		responseRoundTrip(StartRegistrationRespV2, func(resp *pbv2.StartRegistrationResponse) *dto.StartRegistrationResp {
+			return &dto.StartRegistrationResp{ExpiresAt: resp.ExpiresAt}
+		}),
+		requestRoundTrip(LoginReqV2, func(req dto.LoginReq) *pbv2.LoginRequest {
+			return &pbv2.LoginRequest{Email: req.Email, Password: req.Password, ClientId: req.ClientID, RememberMe: req.RememberMe}
+		}),
+		requestRoundTrip(RefreshTokenReqV2, func(req dto.RefreshTokenReq) *pbv2.RefreshTokenRequest {
+			return &pbv2.RefreshTokenRequest{RefreshToken: req.RefreshToken}
+		}),
+		requestRoundTrip(LogoutReqV2, func(req dto.LogoutReq) *pbv2.LogoutRequest {
+			return &pbv2.LogoutRequest{RefreshToken: req.RefreshToken}
+		}),
+		requestRoundTrip(RequestPasswordResetReqV2, func(req dto.RequestPasswordResetReq) *pbv2.RequestPasswordResetRequest {
+			return &pbv2.RequestPasswordResetRequest{Email: req.Email}
+		}),
+		requestRoundTrip(ResetPasswordReqV2, func(req dto.ResetPasswordReq) *pbv2.ResetPasswordRequest {
+			return &pbv2.ResetPasswordRequest{Token: req.Token, Password: req.Password}
+		}),
+		requestRoundTrip(VerifyEmailReqV2, func(req dto.VerifyEmailReq) *pbv2.VerifyEmailRequest {
+			return &pbv2.VerifyEmailRequest{Token: req.Token}
+		}),
+		requestRoundTrip(CreateInviteCodeReqV2, func(req dto.CreateInviteCodeReq) *pbv2.CreateInviteCodeRequest {
+			return &pbv2.CreateInviteCodeRequest{MaxRedemptions: int32(req.MaxRedemptions)}
+		}),
+		responseRoundTrip(InviteCodeV2, func(resp *pbv2.InviteCode) *models.InviteCode {
+			return &models.InviteCode{
+				Code: resp.Code, MaxRedemptions: int(resp.MaxRedemptions), Redemptions: int(resp.Redemptions), CreatedAt: resp.CreatedAt,
+			}
+		}),
+		responseRoundTrip(ResendVerificationEmailRespV2, func(resp *pbv2.ResendVerificationEmailResponse) *dto.ResendVerificationEmailResp {
+			return &dto.ResendVerificationEmailResp{ExpiresAt: resp.ExpiresAt}
+		}),
+		responseRoundTrip(RegisterRespV2, func(resp *pbv2.RegisterResponse) *dto.RegisterResp {
+			return &dto.RegisterResp{
+				User: userFromProtoV2(resp.User), AccessToken: resp.AccessToken,
+				RefreshToken: resp.RefreshToken.Token, RefreshTokenExpiresAt: resp.RefreshToken.ExpiresAt,
+			}
+		}),
+		responseRoundTrip(LoginRespV2, func(resp *pbv2.LoginResponse) *dto.LoginResp {
+			return &dto.LoginResp{
+				User: userFromProtoV2(resp.User), AccessToken: resp.AccessToken,
+				RefreshToken: resp.RefreshToken.Token, RefreshTokenExpiresAt: resp.RefreshToken.ExpiresAt,
+			}
+		}),
+		responseRoundTrip(RefreshTokenRespV2, func(resp *pbv2.RefreshTokenResponse) *dto.RefreshTokenResp {
+			return &dto.RefreshTokenResp{
+				AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken.Token, RefreshTokenExpiresAt: resp.RefreshToken.ExpiresAt,
+			}
+		}),
+		responseRoundTrip(RevokeAllUserTokensResp, func(resp *pb.RevokeAllUserTokensResponse) *dto.RevokeAllUserTokensResp {
+			return &dto.RevokeAllUserTokensResp{RevokedRefreshTokens: resp.RevokedRefreshTokens}
+		}),
+		responseRoundTrip(QuotaUsageResp, func(resp *pb.GetQuotaUsageResponse) *dto.GetQuotaUsageResp {
+			usage := &dto.GetQuotaUsageResp{Subject: resp.Subject}
+			for _, u := range resp.Usages {
+				usage.Usages = append(usage.Usages, &models.QuotaUsage{Method: u.Method, Used: u.Used, Limit: u.Limit, WindowStart: u.WindowStart, WindowEnd: u.WindowEnd})
+			}
+			return usage
+		}),
+		responseRoundTrip(SLOReport, func(resp *pb.GetSLOStatusResponse) *slo.Report {
+			report := &slo.Report{Window: time.Duration(resp.WindowSeconds) * time.Second, GeneratedAt: time.UnixMilli(resp.GeneratedAt)}
+			for _, s := range resp.Statuses {
+				report.Statuses = append(report.Statuses, slo.Status{
+					Method: s.Method, TotalRequests: s.TotalRequests, FailedRequests: s.FailedRequests, SlowRequests: s.SlowRequests,
+					Availability: s.Availability, AvailabilityObjective: s.AvailabilityObjective,
+					AvailabilityErrorBudgetRemaining: s.AvailabilityErrorBudgetRemaining, LatencyCompliance: s.LatencyCompliance,
+					LatencyObjective: s.LatencyObjective, LatencyThreshold: time.Duration(s.LatencyThresholdMs) * time.Millisecond,
+					LatencyErrorBudgetRemaining: s.LatencyErrorBudgetRemaining, Met: s.Met,
+				})
+			}
+			return report
+		}),
+		responseRoundTrip(ActivitySummary, func(resp *pb.GetAccountActivitySummaryResponse) *models.ActivitySummary {
+			summary := &models.ActivitySummary{
+				From: resp.From, To: resp.To, Logins: resp.Logins, LastLoginAt: resp.LastLoginAt,
+				PasswordChanges: resp.PasswordChanges, EmailChanges: resp.EmailChanges, TokenRevocations: resp.TokenRevocations,
+			}
+			for _, d := range resp.Devices {
+				summary.Devices = append(summary.Devices, &models.DeviceActivity{UserAgent: d.UserAgent, IPAddress: d.IpAddress, Logins: d.Logins, LastSeenAt: d.LastSeenAt})
+			}
+			return summary
+		}),
+		responseRoundTrip(EmailMessage, func(resp *pb.PreviewEmailTemplateResponse) *email.Message {
+			return &email.Message{Locale: resp.Locale, Subject: resp.Subject, HTML: resp.Html, Text: resp.Text}
+		}),
+		responseRoundTrip(NotificationPreferences, func(resp *pb.NotificationPreferencesResponse) []*models.NotificationPreference {
+			var prefs []*models.NotificationPreference
+			for _, p := range resp.Preferences {
+				prefs = append(prefs, &models.NotificationPreference{EventType: p.EventType, Channel: models.NotificationChannel(p.Channel), Enabled: p.Enabled})
+			}
+			return prefs
+		}),
+		responseRoundTrip(UserStats, func(resp *pb.GetUserStatsResponse) *models.UserStats {
+			stats := &models.UserStats{
+				GeneratedAt: resp.GeneratedAt, From: resp.From, To: resp.To, TotalUsers: resp.TotalUsers,
+				ActiveSessions: resp.ActiveSessions, UsersWithActiveSessions: resp.UsersWithActiveSessions,
+			}
+			for _, d := range resp.Daily {
+				stats.Daily = append(stats.Daily, &models.DailyUserStats{Day: d.Day, Registrations: d.Registrations, Logins: d.Logins, ActiveUsers: d.ActiveUsers})
+			}
+			return stats
+		}),
+		responseRoundTrip(func(user *models.User) *pb.ExportedUser {
+			return ExportedUser(user, models.UserExportFields)
+		}, func(resp *pb.ExportedUser) *models.User {
+			return &models.User{
+				ID: uuid.MustParse(resp.Id), Email: models.Email(resp.Email), Username: models.Username(resp.Username),
+				CreatedAt: resp.CreatedAt, UpdatedAt: resp.UpdatedAt,
+			}
+		}),
+		responseRoundTrip(RiskSignals, func(resp *pb.GetRiskSignalsResponse) *models.RiskSignals {
+			return &models.RiskSignals{
+				UserID: resp.UserId, CreatedAt: resp.CreatedAt, AccountAgeDays: resp.AccountAgeDays, DeviceCount: resp.DeviceCount,
+				DisposableEmail: resp.DisposableEmail, RegistrationIPReuseCount: resp.RegistrationIpReuseCount,
+				SessionAnomalyCount: resp.SessionAnomalyCount, GeneratedAt: resp.GeneratedAt,
+			}
+		}),
+		responseRoundTrip(ExchangeTokenResp, func(resp *pb.ExchangeTokenResponse) *dto.ExchangeTokenResp {
+			return &dto.ExchangeTokenResp{
+				AccessToken: resp.AccessToken, IssuedTokenType: resp.IssuedTokenType, TokenType: resp.TokenType,
+				ExpiresIn: resp.ExpiresIn, Scopes: resp.Scope,
+			}
+		}),
+		responseRoundTrip(VerifyTokenResp, func(resp *pb.VerifyTokenResponse) *token.Payload {
+			return &token.Payload{
+				ID: uuid.MustParse(resp.TokenId), UserID: resp.UserId, Username: resp.Username,
+				IssuedAt: resp.IssuedAt, ExpiredAt: resp.ExpiresAt, Role: resp.Role, OrganizationID: resp.OrganizationId,
+				ClientID: resp.ClientId, Residency: resp.Residency, Confirmation: &token.Confirmation{JKT: resp.Jkt},
+				Audience: resp.Audience, Scope: strings.Join(resp.Scope, " "),
+			}
+		}),
+		responseRoundTrip(ClaimGuestActivityResp, func(resp *pb.ClaimGuestActivityResponse) *dto.ClaimGuestActivityResp {
+			return &dto.ClaimGuestActivityResp{
+				Claim: &models.EmailClaim{
+					UserID: uuid.MustParse(resp.UserId), Email: models.Email(resp.Email), VerifiedBy: models.EmailVerificationMethod(resp.VerifiedBy),
+					VerifiedAt: resp.VerifiedAt, ActivityClaimedAt: resp.ClaimedAt, ActivityClaimedBy: resp.ClaimedBy,
+				},
+				AlreadyClaimed: resp.AlreadyClaimed,
+			}
+		}),
+		responseRoundTrip(Organization, func(resp *pb.Organization) *models.Organization {
+			return &models.Organization{
+				ID: uuid.MustParse(resp.Id), Name: resp.Name, AllowedEmailDomains: resp.AllowedEmailDomains,
+				Branding: brandingFromProto(resp.Branding), Residency: resp.Residency,
+				SessionIdleTimeout: time.Duration(resp.SessionIdleTimeoutSeconds) * time.Second, CreatedAt: resp.CreatedAt, UpdatedAt: resp.UpdatedAt,
+			}
+		}),
+		responseRoundTrip(ImportUsersResp, func(resp *pb.ImportUsersResponse) []*dto.ImportUserResult {
+			var results []*dto.ImportUserResult
+			for _, r := range resp.Results {
+				results = append(results, &dto.ImportUserResult{
+					Row: int(r.Row), Email: r.Email, Status: dto.ImportUserStatus(r.Status),
+					User: &models.User{ID: uuid.MustParse(r.UserId)}, Err: errs.NewError(codes.InvalidArgument, r.Error),
+				})
+			}
+			return results
+		}),
+		responseRoundTrip(BatchUserResults, func(resp *pb.BatchUserResultsResponse) []*dto.BatchUserResult {
+			var results []*dto.BatchUserResult
+			for _, r := range resp.Results {
+				results = append(results, &dto.BatchUserResult{
+					UserID: r.UserId, Status: dto.BatchItemStatus(r.Status), Err: errs.NewError(codes.InvalidArgument, r.Error),
+				})
+			}
+			return results
+		}),
+		responseRoundTrip(UserHistoryResp, func(resp *pb.GetUserHistoryResponse) *dto.GetUserHistoryResp {
+			history := &dto.GetUserHistoryResp{NextAfterVersion: resp.NextAfterVersion}
+			for _, e := range resp.Events {
+				history.Events = append(history.Events, &models.UserEvent{
+					ID: uuid.MustParse(e.Id), Version: e.Version, Type: models.UserEventType(e.Type),
+					Data: json.RawMessage(e.Data), Actor: e.Actor, OccurredAt: e.OccurredAt,
+				})
+			}
+			return history
+		}),
+		responseRoundTrip(ListUsersResp, func(resp *pb.ListUsersResponse) *dto.ListUsersResp {
+			page := &dto.ListUsersResp{NextPageToken: resp.NextPageToken}
+			for _, u := range resp.Users {
+				page.Users = append(page.Users, userFromProto(u))
+			}
+			return page
+		}),
+		responseRoundTrip(RegistrationGatesResp, func(resp *pb.RegistrationGates) *dto.RegistrationGatesResp {
+			return &dto.RegistrationGatesResp{
+				Gates: &models.RegistrationGates{
+					InviteCodeRequired: resp.InviteCodeRequired, InviteCodes: resp.InviteCodes, DailyCap: int(resp.DailyCap),
+					AllowlistOnly: resp.AllowlistOnly, Allowlist: resp.Allowlist, UpdatedBy: resp.UpdatedBy, UpdatedAt: resp.UpdatedAt,
+				},
+				SignupsToday: int(resp.SignupsToday),
+			}
+		}),
+		responseRoundTrip(ListUserNotesResp, func(resp *pb.ListUserNotesResponse) *dto.ListUserNotesResp {
+			page := &dto.ListUserNotesResp{NextPageToken: resp.NextPageToken}
+			for _, n := range resp.Notes {
+				page.Notes = append(page.Notes, &models.UserNote{
+					ID: uuid.MustParse(n.Id), UserID: uuid.MustParse(n.UserId), Body: n.Body,
+					Visibility: models.UserNoteVisibility(n.Visibility), Author: n.Author,
+					CreatedBy: n.CreatedBy, CreatedAt: n.CreatedAt,
+				})
+			}
+			return page
+		}),
+		responseRoundTrip(GetLoginHistoryResp, func(resp *pb.GetLoginHistoryResponse) *dto.GetLoginHistoryResp {
+			page := &dto.GetLoginHistoryResp{NextPageToken: resp.NextPageToken}
+			for _, a := range resp.Attempts {
+				page.Attempts = append(page.Attempts, &models.LoginAttempt{
+					ID: uuid.MustParse(a.Id), UserID: uuid.MustParse(a.UserId), Success: a.Success,
+					FailureReason: a.FailureReason, ClientID: a.ClientId, IPAddress: a.IpAddress,
+					UserAgent: a.UserAgent, CreatedAt: a.CreatedAt,
+				})
+			}
+			return page
+		}),
+		responseRoundTrip(PromoteSigningKeyResp, func(resp *pb.PromoteSigningKeyResponse) *dto.PromoteSigningKeyResp {
+			return &dto.PromoteSigningKeyResp{PrimaryKeyID: resp.PrimaryKeyId, PreviousKeyID: resp.PreviousKeyId}
+		}),
+		responseRoundTrip(AvatarUploadResp, func(resp *pb.RequestAvatarUploadURLResponse) *dto.AvatarUploadResp {
+			return &dto.AvatarUploadResp{UploadURL: resp.UploadUrl, ContentType: resp.ContentType, ObjectKey: resp.ObjectKey, ExpiresAt: resp.ExpiresAt}
+		}),
+		responseRoundTrip(AvatarResp, func(resp *pb.ConfirmAvatarResponse) *dto.AvatarResp {
+			return &dto.AvatarResp{ObjectKey: resp.ObjectKey, AvatarURL: resp.AvatarUrl}
+		}),
+		responseRoundTrip(RestoreSnapshotResp, func(resp *pb.RestoreSnapshotResponse) *dto.RestoreSnapshotResp {
+			return &dto.RestoreSnapshotResp{Organizations: resp.Organizations, Users: resp.Users, Sessions: resp.Sessions}
+		}),
+		responseRoundTrip(UserUpdate, func(resp *pb.UserUpdate) *dto.WatchUserUpdate {
+			return &dto.WatchUserUpdate{
+				WatchedUser: &models.WatchedUser{UserID: uuid.MustParse(resp.UserId), User: userFromProto(resp.User), Version: resp.Version},
+				Initial:     resp.Initial,
+			}
+		}),
+		responseRoundTrip(CleanupRefreshTokensProgress, func(resp *pb.CleanupRefreshTokensProgress) *dto.CleanupRefreshTokensProgress {
+			return &dto.CleanupRefreshTokensProgress{Deleted: resp.Deleted, Batches: resp.Batches, Done: resp.Done}
+		}),
+		responseRoundTrip(PushTokenResp, func(resp *pb.RegisterPushTokenResponse) *models.PushToken {
+			return &models.PushToken{DeviceID: resp.DeviceId, Platform: models.PushPlatform(resp.Platform), UpdatedAt: resp.UpdatedAt}
+		}),
+		responseRoundTrip(GlobalLogoutResp, func(resp *pb.GlobalLogoutResponse) *models.GlobalLogout {
+			return &models.GlobalLogout{
+				ID:                   uuid.MustParse(resp.Id),
+				Cutoff:               resp.Cutoff,
+				RefreshTokensRevoked: resp.RefreshTokensRevoked,
+				CompletedAt:          resp.CompletedAt,
+			}
+		}),
+		responseRoundTrip(AuthorizedClientsResp, func(resp *pb.ListAuthorizedClientsResponse) []*models.AuthorizedClient {
+			clients := make([]*models.AuthorizedClient, 0, len(resp.Clients))
+			for _, client := range resp.Clients {
+				clients = append(clients, &models.AuthorizedClient{
+					ClientID:          client.ClientId,
+					Platform:          models.ClientPlatform(client.Platform),
+					FirstAuthorizedAt: client.FirstAuthorizedAt,
+					LastUsedAt:        client.LastUsedAt,
+					ActiveSessions:    client.ActiveSessions,
+				})
+			}
+			return clients
+		}),
+		responseRoundTrip(VelocityRulesResp, func(resp *pb.ListVelocityRulesResponse) []*models.VelocityRule {
+			rules := make([]*models.VelocityRule, 0, len(resp.Rules))
+			for _, r := range resp.Rules {
+				rules = append(rules, &models.VelocityRule{
+					Name: r.Name, Entity: models.VelocityEntity(r.Entity), Action: models.VelocityAction(r.Action),
+					Threshold: int(r.Threshold), Window: time.Duration(r.WindowSeconds) * time.Second,
+					Response: models.VelocityResponse(r.Response), Enabled: r.Enabled, UpdatedBy: r.UpdatedBy,
+					CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
+				})
+			}
+			return rules
+		}),
+		responseRoundTrip(CanaryAccountsResp, func(resp *pb.ListCanaryAccountsResponse) []*models.CanaryAccount {
+			accounts := make([]*models.CanaryAccount, 0, len(resp.Accounts))
+			for _, a := range resp.Accounts {
+				accounts = append(accounts, &models.CanaryAccount{
+					UserID: uuid.MustParse(a.UserId), Note: a.Note, Tarpit: a.Tarpit,
+					CreatedBy: a.CreatedBy, CreatedAt: a.CreatedAt,
+				})
+			}
+			return accounts
+		}),
+		responseRoundTrip(OrgAuditLogChunk, func(chunk *pb.ExportOrgAuditLogChunk) []*models.AuditLog {
+			entries := make([]*models.AuditLog, 0, len(chunk.Entries))
+			for _, e := range chunk.Entries {
+				entries = append(entries, &models.AuditLog{
+					ID: uuid.MustParse(e.Id), UserID: uuid.MustParse(e.UserId), Action: models.AuditAction(e.Action),
+					Metadata: json.RawMessage(e.Metadata), CreatedAt: e.CreatedAt,
+				})
+			}
+			return entries
+		}),
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, tc.run)
+	}
+}
+
+func TestUser_WithoutOrganization(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: "jane@tickets.example", Username: "jane"}
+
+	if resp := User(user); resp.OrganizationId != "" {
+		t.Errorf("Expected no organization id, got %q", resp.OrganizationId)
+	}
+}
+
+func TestBatchGetUsersResp(t *testing.T) {
+	users := map[uuid.UUID]*models.User{}
+	for range 2 {
+		user := userFromProto(filledMessage[*pb.User]())
+		users[user.ID] = user
+	}
+
+	resp := BatchGetUsersResp(users)
+	if len(resp.Users) != len(users) {
+		t.Fatalf("Expected %d users, got %d", len(users), len(resp.Users))
+	}
+	for id, user := range users {
+		if got := resp.Users[id.String()]; !proto.Equal(got, User(user)) {
+			t.Errorf("Expected %v under %s, got %v", User(user), id, got)
+		}
+	}
+}
+
+func TestRefreshTokenRespV2_NotRotated(t *testing.T) {
+	resp := RefreshTokenRespV2(&dto.RefreshTokenResp{AccessToken: "access"})
+
+	if resp.RefreshToken != nil {
+		t.Errorf("Expected no refresh token, got %v", resp.RefreshToken)
+	}
+}
+
+func TestUserUpdate_WithoutUser(t *testing.T) {
+	update := &dto.WatchUserUpdate{WatchedUser: &models.WatchedUser{UserID: uuid.New()}, Initial: true}
+
+	if resp := UserUpdate(update); resp.User != nil {
+		t.Errorf("Expected no user, got %v", resp.User)
+	}
+}
+
+func TestResultError(t *testing.T) {
+	var verrs errs.ValidationErrors
+	verrs.Add("email", errs.ErrInvalidEmail)
+	verrs.Add("username", errs.ErrInvalidUsername)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "service error", err: errs.ErrUserNotFound, expected: "user not found"},
+		{name: "validation errors", err: verrs.Err(), expected: "email: invalid email; username: invalid username"},
+		{name: "internal error", err: fmt.Errorf("connection reset"), expected: "internal error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResultError(tt.err); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func loginScheduleSubjectFromDTO(req dto.LoginScheduleSubjectReq) *pb.LoginScheduleSubject {
+	return &pb.LoginScheduleSubject{UserId: req.UserID, Role: req.Role}
+}
+
+func userFromProto(resp *pb.User) *models.User {
+	return &models.User{
+		ID:             uuid.MustParse(resp.Id),
+		Email:          models.Email(resp.Email),
+		Username:       models.Username(resp.Username),
+		Status:         models.UserStatus(resp.Status),
+		Role:           models.UserRole(resp.Role),
+		OrganizationID: uuid.MustParse(resp.OrganizationId),
+		Residency:      resp.Residency,
+	}
+}
+
+func userFromProtoV2(resp *pbv2.User) *models.User {
+	return &models.User{
+		ID:             uuid.MustParse(resp.Id),
+		Email:          models.Email(resp.Email),
+		Username:       models.Username(resp.Username),
+		Status:         models.UserStatus(resp.Status),
+		Role:           models.UserRole(resp.Role),
+		OrganizationID: uuid.MustParse(resp.OrganizationId),
+		Residency:      resp.Residency,
+		CreatedAt:      resp.CreatedAt,
+		UpdatedAt:      resp.UpdatedAt,
+	}
+}
+
+func brandingFromProto(resp *pb.OrganizationBranding) models.OrganizationBranding {
+	return models.OrganizationBranding{Name: resp.Name, LogoURL: resp.LogoUrl, SupportEmail: resp.SupportEmail, Color: resp.Color}
+}
+
+// filledMessage returns a message of type P with every field set to a distinct value
+func filledMessage[P proto.Message]() P {
+	var zero P
+	msg := zero.ProtoReflect().Type().New()
+	seq := 0
+	fill(msg, &seq)
+	return msg.Interface().(P)
+}
+
+// fill sets every field, recursively, with two elements in every list and map. Fields named
+// id or ending in _id get UUIDs and email fields addresses, so converters can parse them.
+func fill(msg protoreflect.Message, seq *int) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if oneof := field.ContainingOneof(); oneof != nil && msg.WhichOneof(oneof) != nil {
+			continue
+		}
+
+		switch {
+		case field.IsList():
+			list := msg.Mutable(field).List()
+			for range 2 {
+				if field.Kind() == protoreflect.MessageKind {
+					elem := list.NewElement()
+					fill(elem.Message(), seq)
+					list.Append(elem)
+				} else {
+					list.Append(scalar(field, seq))
+				}
+			}
+		case field.IsMap():
+			m := msg.Mutable(field).Map()
+			for range 2 {
+				m.Set(scalar(field.MapKey(), seq).MapKey(), scalar(field.MapValue(), seq))
+			}
+		case field.Kind() == protoreflect.MessageKind:
+			fill(msg.Mutable(field).Message(), seq)
+		default:
+			msg.Set(field, scalar(field, seq))
+		}
+	}
+}
+
+func scalar(field protoreflect.FieldDescriptor, seq *int) protoreflect.Value {
+	*seq++
+	n := *seq
+	name := string(field.Name())
+
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		switch {
+		case name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "_ids"):
+			return protoreflect.ValueOfString(uuid.NewString())
+		case name == "email":
+			return protoreflect.ValueOfString(fmt.Sprintf("user%d@tickets.example", n))
+		}
+		return protoreflect.ValueOfString(fmt.Sprintf("%s-%d", name, n))
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(n))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(n))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(float64(n) + 0.5)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(n) + 0.5)
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(fmt.Sprintf("%s-%d", name, n)))
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(field.Enum().Values().Get(field.Enum().Values().Len() - 1).Number())
+	}
+	panic("unsupported field kind " + field.Kind().String())
+}
+
+// emptyFields lists the exported struct fields left at their zero value, recursively
+func emptyFields(v reflect.Value, path string) []string {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return []string{path}
+		}
+		return emptyFields(v.Elem(), path)
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return []string{path}
+		}
+		if v.Type().Elem().Kind() != reflect.Struct && v.Type().Elem().Kind() != reflect.Pointer {
+			return nil
+		}
+		var empty []string
+		for i := 0; i < v.Len(); i++ {
+			empty = append(empty, emptyFields(v.Index(i), path)...)
+		}
+		return empty
+	case reflect.Struct:
+		var empty []string
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if path != "" {
+				name = path + "." + field.Name
+			}
+			empty = append(empty, emptyFields(v.Field(i), name)...)
+		}
+		return empty
+	}
+
+	if v.IsZero() {
+		return []string{path}
+	}
+	return nil
+}
+
+func TestLoginSchedule(t *testing.T) {
+	schedule := &models.LoginSchedule{
+		Role:     models.UserRoleStaff,
+		Timezone: "Europe/Berlin",
+		Windows: []models.LoginWindow{
+			{Days: []time.Weekday{time.Friday, time.Saturday}, Start: 18 * 60, End: 2 * 60},
+			{Days: []time.Weekday{time.Sunday}, Start: 9*60 + 30, End: models.MinutesPerDay},
+		},
+		UpdatedAt: 1760616000000,
+	}
+
+	resp := LoginSchedule(schedule)
+	if resp.Subject.UserId != "" || resp.Subject.Role != "staff" {
+		t.Errorf("Expected the staff role as subject, got %v", resp.Subject)
+	}
+	expected := []*pb.LoginWindow{
+		{Days: []string{"fri", "sat"}, Start: "18:00", End: "02:00"},
+		{Days: []string{"sun"}, Start: "09:30", End: "24:00"},
+	}
+	if len(resp.Windows) != len(expected) {
+		t.Fatalf("Expected %d windows, got %d", len(expected), len(resp.Windows))
+	}
+	for i, window := range resp.Windows {
+		if !proto.Equal(window, expected[i]) {
+			t.Errorf("Expected window %v, got %v", expected[i], window)
+		}
+	}
+
+	// The request of the response sets the same schedule
+	set := SetLoginScheduleReq(&pb.SetLoginScheduleRequest{Subject: resp.Subject, Timezone: resp.Timezone, Windows: resp.Windows})
+	again, err := set.Schedule(time.UnixMilli(schedule.UpdatedAt))
+	if err != nil {
+		t.Fatalf("Expected a valid schedule, got %v", err)
+	}
+	if !reflect.DeepEqual(again, schedule) {
+		t.Errorf("Expected %+v, got %+v", schedule, again)
+	}
+}
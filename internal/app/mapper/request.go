@@ -0,0 +1,403 @@
+// Package mapper converts between the protobuf messages of the gRPC API and the DTOs and
+// domain models of the service layer, so handlers only call services. Requests are
+// converted to DTOs and results to responses; every converter copies all fields, which
+// the round-trip tests check for every message, so a field added to one side cannot be
+// dropped silently.
+package mapper
+
+import (
+	pb "user-svc/api/proto/v1"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+)
+
+// RegisterReq converts a registration request
+func RegisterReq(req *pb.RegisterRequest) dto.RegisterReq {
+	return dto.RegisterReq{
+		Email:          req.Email,
+		Username:       req.Username,
+		Password:       req.Password,
+		ClientID:       req.ClientId,
+		OrganizationID: req.OrganizationId,
+		InviteCode:     req.InviteCode,
+	}
+}
+
+// LoginReq converts a login request
+func LoginReq(req *pb.LoginRequest) dto.LoginReq {
+	return dto.LoginReq{
+		Email:      req.Email,
+		Password:   req.Password,
+		ClientID:   req.ClientId,
+		RememberMe: req.RememberMe,
+	}
+}
+
+// CompletePasswordSetupReq converts a password setup request
+func CompletePasswordSetupReq(req *pb.CompletePasswordSetupRequest) dto.CompletePasswordSetupReq {
+	return dto.CompletePasswordSetupReq{
+		Token:    req.Token,
+		Password: req.Password,
+		ClientID: req.ClientId,
+	}
+}
+
+// LoginWithIDTokenReq converts a login with an ID token
+func LoginWithIDTokenReq(req *pb.LoginWithIDTokenRequest) dto.LoginWithIDTokenReq {
+	return dto.LoginWithIDTokenReq{
+		Provider:   req.Provider,
+		IDToken:    req.IdToken,
+		Nonce:      req.Nonce,
+		ClientID:   req.ClientId,
+		RememberMe: req.RememberMe,
+	}
+}
+
+// RefreshTokenReq converts a token refresh request
+func RefreshTokenReq(req *pb.RefreshTokenRequest) dto.RefreshTokenReq {
+	return dto.RefreshTokenReq{RefreshToken: req.RefreshToken}
+}
+
+// RevokeAllUserTokensReq converts a token revocation request
+func RevokeAllUserTokensReq(req *pb.RevokeAllUserTokensRequest) dto.RevokeAllUserTokensReq {
+	return dto.RevokeAllUserTokensReq{UserID: req.UserId}
+}
+
+// PreviewEmailTemplateReq converts an email template preview request
+func PreviewEmailTemplateReq(req *pb.PreviewEmailTemplateRequest) dto.PreviewEmailTemplateReq {
+	return dto.PreviewEmailTemplateReq{
+		Name:   req.Name,
+		Locale: req.Locale,
+		Data:   req.Data,
+	}
+}
+
+// UpdateNotificationPreferencesReq converts a notification preference update
+func UpdateNotificationPreferencesReq(req *pb.UpdateNotificationPreferencesRequest) dto.UpdateNotificationPreferencesReq {
+	updates := make([]dto.NotificationPreferenceReq, 0, len(req.Preferences))
+	for _, pref := range req.Preferences {
+		updates = append(updates, dto.NotificationPreferenceReq{
+			EventType: pref.EventType,
+			Channel:   pref.Channel,
+			Enabled:   pref.Enabled,
+		})
+	}
+	return dto.UpdateNotificationPreferencesReq{Preferences: updates}
+}
+
+// GetUserStatsReq converts a user statistics request
+func GetUserStatsReq(req *pb.GetUserStatsRequest) dto.GetUserStatsReq {
+	return dto.GetUserStatsReq{Days: int(req.Days)}
+}
+
+// ExportUsersReq converts a user export request
+func ExportUsersReq(req *pb.ExportUsersRequest) dto.ExportUsersReq {
+	return dto.ExportUsersReq{
+		CreatedFrom:    req.CreatedFrom,
+		CreatedTo:      req.CreatedTo,
+		Format:         req.Format,
+		Fields:         req.Fields,
+		RowsPerSecond:  int(req.RowsPerSecond),
+		OrganizationID: req.OrganizationId,
+	}
+}
+
+// LegalHoldReq converts a legal hold request
+func LegalHoldReq(req *pb.LegalHoldRequest) dto.LegalHoldReq {
+	return dto.LegalHoldReq{UserID: req.UserId, Reason: req.Reason}
+}
+
+// GetRiskSignalsReq converts a fraud signal request
+func GetRiskSignalsReq(req *pb.GetRiskSignalsRequest) dto.GetRiskSignalsReq {
+	return dto.GetRiskSignalsReq{UserID: req.UserId}
+}
+
+// GetUserByUsernameReq converts a user lookup by username
+func GetUserByUsernameReq(req *pb.GetUserByUsernameRequest) dto.GetUserByUsernameReq {
+	return dto.GetUserByUsernameReq{Username: req.Username}
+}
+
+// BatchGetUsersReq converts a batch user lookup
+func BatchGetUsersReq(req *pb.BatchGetUsersRequest) dto.BatchGetUsersReq {
+	return dto.BatchGetUsersReq{UserIDs: req.UserIds}
+}
+
+// ExchangeTokenReq converts a token exchange request
+func ExchangeTokenReq(req *pb.ExchangeTokenRequest) dto.ExchangeTokenReq {
+	return dto.ExchangeTokenReq{
+		GrantType:          req.GrantType,
+		SubjectToken:       req.SubjectToken,
+		SubjectTokenType:   req.SubjectTokenType,
+		Audience:           req.Audience,
+		Scopes:             req.Scope,
+		RequestedTokenType: req.RequestedTokenType,
+	}
+}
+
+// VerifyTokenReq converts an access token verification request
+func VerifyTokenReq(req *pb.VerifyTokenRequest) dto.VerifyTokenReq {
+	return dto.VerifyTokenReq{AccessToken: req.AccessToken}
+}
+
+// ClaimGuestActivityReq converts a guest activity claim
+func ClaimGuestActivityReq(req *pb.ClaimGuestActivityRequest) dto.ClaimGuestActivityReq {
+	return dto.ClaimGuestActivityReq{UserID: req.UserId, Email: req.Email}
+}
+
+// CreateOrganizationReq converts an organization creation request
+func CreateOrganizationReq(req *pb.CreateOrganizationRequest) dto.CreateOrganizationReq {
+	return dto.CreateOrganizationReq{
+		Name:                req.Name,
+		AllowedEmailDomains: req.AllowedEmailDomains,
+		Residency:           req.Residency,
+	}
+}
+
+// GetOrganizationReq converts an organization lookup
+func GetOrganizationReq(req *pb.GetOrganizationRequest) dto.GetOrganizationReq {
+	return dto.GetOrganizationReq{OrganizationID: req.OrganizationId}
+}
+
+// GetOrganizationByNameReq converts an organization lookup by name
+func GetOrganizationByNameReq(req *pb.GetOrganizationByNameRequest) dto.GetOrganizationByNameReq {
+	return dto.GetOrganizationByNameReq{Name: req.Name}
+}
+
+// SetOrganizationEmailDomainsReq converts an update of the allowed email domains
+func SetOrganizationEmailDomainsReq(req *pb.SetOrganizationEmailDomainsRequest) dto.SetOrganizationEmailDomainsReq {
+	return dto.SetOrganizationEmailDomainsReq{
+		OrganizationID:      req.OrganizationId,
+		AllowedEmailDomains: req.AllowedEmailDomains,
+	}
+}
+
+// SetOrganizationBrandingReq converts an update of the email branding
+func SetOrganizationBrandingReq(req *pb.SetOrganizationBrandingRequest) dto.SetOrganizationBrandingReq {
+	return dto.SetOrganizationBrandingReq{
+		OrganizationID: req.OrganizationId,
+		Branding: models.OrganizationBranding{
+			Name:         req.GetBranding().GetName(),
+			LogoURL:      req.GetBranding().GetLogoUrl(),
+			SupportEmail: req.GetBranding().GetSupportEmail(),
+			Color:        req.GetBranding().GetColor(),
+		},
+	}
+}
+
+// SetOrganizationSessionPolicyReq converts an update of the session policy
+func SetOrganizationSessionPolicyReq(req *pb.SetOrganizationSessionPolicyRequest) dto.SetOrganizationSessionPolicyReq {
+	return dto.SetOrganizationSessionPolicyReq{
+		OrganizationID:            req.OrganizationId,
+		SessionIdleTimeoutSeconds: req.SessionIdleTimeoutSeconds,
+	}
+}
+
+// ImportUsersReq converts one batch of an import stream
+func ImportUsersReq(req *pb.ImportUsersRequest) dto.ImportUsersReq {
+	users := make([]dto.ImportUserRecord, 0, len(req.Users))
+	for _, user := range req.Users {
+		rec := dto.ImportUserRecord{
+			Email:          user.Email,
+			Username:       user.Username,
+			OrganizationID: user.OrganizationId,
+		}
+		if legacy := user.LegacyPassword; legacy != nil {
+			rec.LegacyPassword = &dto.LegacyPasswordHash{
+				Format: legacy.Format,
+				Salt:   legacy.Salt,
+				Hash:   legacy.Hash,
+			}
+		}
+		users = append(users, rec)
+	}
+	return dto.ImportUsersReq{Users: users}
+}
+
+// BatchAssignRoleReq converts a batch role assignment
+func BatchAssignRoleReq(req *pb.BatchAssignRoleRequest) dto.BatchAssignRoleReq {
+	return dto.BatchAssignRoleReq{
+		UserIDs: req.UserIds,
+		Role:    req.Role,
+		Reason:  req.Reason,
+		DryRun:  req.DryRun,
+	}
+}
+
+// BatchUpdateStatusReq converts a batch status change
+func BatchUpdateStatusReq(req *pb.BatchUpdateStatusRequest) dto.BatchUpdateStatusReq {
+	return dto.BatchUpdateStatusReq{
+		UserIDs: req.UserIds,
+		Status:  req.Status,
+		Reason:  req.Reason,
+		DryRun:  req.DryRun,
+	}
+}
+
+// GetUserHistoryReq converts a user history request
+func GetUserHistoryReq(req *pb.GetUserHistoryRequest) dto.GetUserHistoryReq {
+	return dto.GetUserHistoryReq{
+		UserID:       req.UserId,
+		AfterVersion: req.AfterVersion,
+		Limit:        int(req.Limit),
+	}
+}
+
+// ListUsersReq converts a request for a page of users
+func ListUsersReq(req *pb.ListUsersRequest) dto.ListUsersReq {
+	return dto.ListUsersReq{
+		PageSize:       int(req.PageSize),
+		PageToken:      req.PageToken,
+		OrganizationID: req.OrganizationId,
+	}
+}
+
+// SetUserMetadataReq converts a user metadata update
+func SetUserMetadataReq(req *pb.SetUserMetadataRequest) dto.SetUserMetadataReq {
+	return dto.SetUserMetadataReq{
+		UserID: req.UserId,
+		Set:    req.Set,
+		Remove: req.Remove,
+	}
+}
+
+// ExportSnapshotReq converts a snapshot export request
+func ExportSnapshotReq(req *pb.ExportSnapshotRequest) dto.ExportSnapshotReq {
+	return dto.ExportSnapshotReq{Passphrase: req.Passphrase}
+}
+
+// RestoreSnapshotReq converts one message of a snapshot restore stream
+func RestoreSnapshotReq(req *pb.RestoreSnapshotRequest) dto.RestoreSnapshotChunk {
+	return dto.RestoreSnapshotChunk{Passphrase: req.Passphrase, Data: req.Data}
+}
+
+// WatchUserReq converts a user watch request
+func WatchUserReq(req *pb.WatchUserRequest) dto.WatchUserReq {
+	return dto.WatchUserReq{UserIDs: req.UserIds}
+}
+
+// CleanupRefreshTokensReq converts a refresh token cleanup request
+func CleanupRefreshTokensReq(req *pb.CleanupRefreshTokensRequest) dto.CleanupRefreshTokensReq {
+	return dto.CleanupRefreshTokensReq{
+		UserID:           req.UserId,
+		OlderThanSeconds: req.OlderThanSeconds,
+		RevokedOnly:      req.RevokedOnly,
+		BatchSize:        int(req.BatchSize),
+		MaxTokens:        req.MaxTokens,
+	}
+}
+
+// RegisterPushTokenReq converts a push token registration
+func RegisterPushTokenReq(req *pb.RegisterPushTokenRequest) dto.RegisterPushTokenReq {
+	return dto.RegisterPushTokenReq{DeviceID: req.DeviceId, Platform: req.Platform, Token: req.Token}
+}
+
+// UnregisterPushTokenReq converts a push token removal
+func UnregisterPushTokenReq(req *pb.UnregisterPushTokenRequest) dto.UnregisterPushTokenReq {
+	return dto.UnregisterPushTokenReq{DeviceID: req.DeviceId}
+}
+
+// LoginScheduleSubjectReq converts the user or role a login schedule applies to
+func LoginScheduleSubjectReq(req *pb.LoginScheduleSubject) dto.LoginScheduleSubjectReq {
+	return dto.LoginScheduleSubjectReq{UserID: req.GetUserId(), Role: req.GetRole()}
+}
+
+// SetLoginScheduleReq converts a login schedule change
+func SetLoginScheduleReq(req *pb.SetLoginScheduleRequest) dto.SetLoginScheduleReq {
+	windows := make([]dto.LoginWindowReq, 0, len(req.Windows))
+	for _, window := range req.Windows {
+		windows = append(windows, dto.LoginWindowReq{Days: window.Days, Start: window.Start, End: window.End})
+	}
+
+	return dto.SetLoginScheduleReq{
+		LoginScheduleSubjectReq: LoginScheduleSubjectReq(req.Subject),
+		Timezone:                req.Timezone,
+		Windows:                 windows,
+	}
+}
+
+// GlobalLogoutReq converts a global logout request
+func GlobalLogoutReq(req *pb.GlobalLogoutRequest) dto.GlobalLogoutReq {
+	return dto.GlobalLogoutReq{Cutoff: req.Cutoff, Reason: req.Reason, Confirmation: req.Confirmation}
+}
+
+// RevokeClientAccessReq converts a client access revocation request
+func RevokeClientAccessReq(req *pb.RevokeClientAccessRequest) dto.RevokeClientAccessReq {
+	return dto.RevokeClientAccessReq{ClientID: req.ClientId}
+}
+
+// ExportOrgAuditLogReq converts an organization audit export request
+func ExportOrgAuditLogReq(req *pb.ExportOrgAuditLogRequest) dto.ExportOrgAuditLogReq {
+	return dto.ExportOrgAuditLogReq{OrganizationID: req.OrganizationId, From: req.From, To: req.To}
+}
+
+// SetVelocityRuleReq converts a velocity rule change
+func SetVelocityRuleReq(req *pb.SetVelocityRuleRequest) dto.SetVelocityRuleReq {
+	return dto.SetVelocityRuleReq{
+		Name:          req.Name,
+		Entity:        req.Entity,
+		Action:        req.Action,
+		Threshold:     int(req.Threshold),
+		WindowSeconds: req.WindowSeconds,
+		Response:      req.Response,
+		Enabled:       req.Enabled,
+	}
+}
+
+// DeleteVelocityRuleReq converts a velocity rule deletion
+func DeleteVelocityRuleReq(req *pb.DeleteVelocityRuleRequest) dto.DeleteVelocityRuleReq {
+	return dto.DeleteVelocityRuleReq{Name: req.Name}
+}
+
+// SetCanaryAccountReq converts a canary account change
+func SetCanaryAccountReq(req *pb.SetCanaryAccountRequest) dto.SetCanaryAccountReq {
+	return dto.SetCanaryAccountReq{
+		UserID: req.UserId,
+		Note:   req.Note,
+		Tarpit: req.Tarpit,
+	}
+}
+
+// DeleteCanaryAccountReq converts a canary account deletion
+func DeleteCanaryAccountReq(req *pb.DeleteCanaryAccountRequest) dto.DeleteCanaryAccountReq {
+	return dto.DeleteCanaryAccountReq{UserID: req.UserId}
+}
+
+// AddUserNoteReq converts a note left on a user
+func AddUserNoteReq(req *pb.AddUserNoteRequest) dto.AddUserNoteReq {
+	return dto.AddUserNoteReq{
+		UserID:     req.UserId,
+		Body:       req.Body,
+		Visibility: req.Visibility,
+		Author:     req.Author,
+	}
+}
+
+// ListUserNotesReq converts a request for a page of the notes on a user
+func ListUserNotesReq(req *pb.ListUserNotesRequest) dto.ListUserNotesReq {
+	return dto.ListUserNotesReq{
+		UserID:     req.UserId,
+		Visibility: req.Visibility,
+		PageSize:   int(req.PageSize),
+		PageToken:  req.PageToken,
+	}
+}
+
+// SetRegistrationGatesReq converts new registration gates
+func SetRegistrationGatesReq(req *pb.SetRegistrationGatesRequest) dto.SetRegistrationGatesReq {
+	return dto.SetRegistrationGatesReq{
+		InviteCodeRequired: req.InviteCodeRequired,
+		InviteCodes:        req.InviteCodes,
+		DailyCap:           int(req.DailyCap),
+		AllowlistOnly:      req.AllowlistOnly,
+		Allowlist:          req.Allowlist,
+	}
+}
+
+// GetLoginHistoryReq converts a request for a page of the login attempts of a user
+func GetLoginHistoryReq(req *pb.GetLoginHistoryRequest) dto.GetLoginHistoryReq {
+	return dto.GetLoginHistoryReq{
+		UserID:    req.UserId,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	}
+}
@@ -0,0 +1,604 @@
+package mapper
+
+import (
+	"strings"
+	"time"
+
+	pb "user-svc/api/proto/v1"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/email"
+	"user-svc/pkg/utils/slo"
+
+	"github.com/google/uuid"
+)
+
+// User converts a user; the organization is left empty for users outside one
+func User(user *models.User) *pb.User {
+	resp := &pb.User{
+		Id:        user.ID.String(),
+		Email:     user.Email.String(),
+		Username:  user.Username.String(),
+		Status:    string(user.Status),
+		Role:      string(user.Role),
+		Residency: user.Residency,
+	}
+	if user.OrganizationID != uuid.Nil {
+		resp.OrganizationId = user.OrganizationID.String()
+	}
+	return resp
+}
+
+// RegisterResp converts the result of a registration
+func RegisterResp(resp *dto.RegisterResp) *pb.RegisterResponse {
+	return &pb.RegisterResponse{
+		User:         User(resp.User),
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+	}
+}
+
+// LoginResp converts the result of a login or password setup
+func LoginResp(resp *dto.LoginResp) *pb.LoginResponse {
+	return &pb.LoginResponse{
+		User:         User(resp.User),
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+	}
+}
+
+// RefreshTokenResp converts the result of a token refresh
+func RefreshTokenResp(resp *dto.RefreshTokenResp) *pb.RefreshTokenResponse {
+	return &pb.RefreshTokenResponse{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+	}
+}
+
+// RevokeAllUserTokensResp converts the result of a token revocation
+func RevokeAllUserTokensResp(resp *dto.RevokeAllUserTokensResp) *pb.RevokeAllUserTokensResponse {
+	return &pb.RevokeAllUserTokensResponse{RevokedRefreshTokens: resp.RevokedRefreshTokens}
+}
+
+// QuotaUsageResp converts the quota usage of a caller
+func QuotaUsageResp(resp *dto.GetQuotaUsageResp) *pb.GetQuotaUsageResponse {
+	usages := make([]*pb.QuotaUsage, 0, len(resp.Usages))
+	for _, usage := range resp.Usages {
+		usages = append(usages, &pb.QuotaUsage{
+			Method:      usage.Method,
+			Used:        usage.Used,
+			Limit:       usage.Limit,
+			WindowStart: usage.WindowStart,
+			WindowEnd:   usage.WindowEnd,
+		})
+	}
+
+	return &pb.GetQuotaUsageResponse{
+		Subject: resp.Subject,
+		Usages:  usages,
+	}
+}
+
+// SLOReport converts an SLO compliance report
+func SLOReport(report *slo.Report) *pb.GetSLOStatusResponse {
+	statuses := make([]*pb.SLOStatus, 0, len(report.Statuses))
+	for _, status := range report.Statuses {
+		statuses = append(statuses, sloStatus(status))
+	}
+
+	return &pb.GetSLOStatusResponse{
+		WindowSeconds: int64(report.Window.Seconds()),
+		GeneratedAt:   report.GeneratedAt.UnixMilli(),
+		Statuses:      statuses,
+	}
+}
+
+func sloStatus(status slo.Status) *pb.SLOStatus {
+	return &pb.SLOStatus{
+		Method:                           status.Method,
+		TotalRequests:                    status.TotalRequests,
+		FailedRequests:                   status.FailedRequests,
+		SlowRequests:                     status.SlowRequests,
+		Availability:                     status.Availability,
+		AvailabilityObjective:            status.AvailabilityObjective,
+		AvailabilityErrorBudgetRemaining: status.AvailabilityErrorBudgetRemaining,
+		LatencyCompliance:                status.LatencyCompliance,
+		LatencyObjective:                 status.LatencyObjective,
+		LatencyThresholdMs:               status.LatencyThreshold.Milliseconds(),
+		LatencyErrorBudgetRemaining:      status.LatencyErrorBudgetRemaining,
+		Met:                              status.Met,
+	}
+}
+
+// ActivitySummary converts the account activity summary of a user
+func ActivitySummary(summary *models.ActivitySummary) *pb.GetAccountActivitySummaryResponse {
+	devices := make([]*pb.DeviceActivity, 0, len(summary.Devices))
+	for _, device := range summary.Devices {
+		devices = append(devices, &pb.DeviceActivity{
+			UserAgent:  device.UserAgent,
+			IpAddress:  device.IPAddress,
+			Logins:     device.Logins,
+			LastSeenAt: device.LastSeenAt,
+		})
+	}
+
+	return &pb.GetAccountActivitySummaryResponse{
+		From:             summary.From,
+		To:               summary.To,
+		Logins:           summary.Logins,
+		LastLoginAt:      summary.LastLoginAt,
+		Devices:          devices,
+		PasswordChanges:  summary.PasswordChanges,
+		EmailChanges:     summary.EmailChanges,
+		TokenRevocations: summary.TokenRevocations,
+	}
+}
+
+// EmailMessage converts a rendered email template
+func EmailMessage(msg *email.Message) *pb.PreviewEmailTemplateResponse {
+	return &pb.PreviewEmailTemplateResponse{
+		Locale:  msg.Locale,
+		Subject: msg.Subject,
+		Html:    msg.HTML,
+		Text:    msg.Text,
+	}
+}
+
+// NotificationPreferences converts the notification preferences of a user
+func NotificationPreferences(prefs []*models.NotificationPreference) *pb.NotificationPreferencesResponse {
+	result := make([]*pb.NotificationPreference, 0, len(prefs))
+	for _, pref := range prefs {
+		result = append(result, &pb.NotificationPreference{
+			EventType: pref.EventType,
+			Channel:   string(pref.Channel),
+			Enabled:   pref.Enabled,
+		})
+	}
+
+	return &pb.NotificationPreferencesResponse{Preferences: result}
+}
+
+// UserStats converts the ops dashboard statistics
+func UserStats(stats *models.UserStats) *pb.GetUserStatsResponse {
+	daily := make([]*pb.DailyUserStats, 0, len(stats.Daily))
+	for _, day := range stats.Daily {
+		daily = append(daily, &pb.DailyUserStats{
+			Day:           day.Day,
+			Registrations: day.Registrations,
+			Logins:        day.Logins,
+			ActiveUsers:   day.ActiveUsers,
+		})
+	}
+
+	return &pb.GetUserStatsResponse{
+		GeneratedAt:             stats.GeneratedAt,
+		From:                    stats.From,
+		To:                      stats.To,
+		TotalUsers:              stats.TotalUsers,
+		ActiveSessions:          stats.ActiveSessions,
+		UsersWithActiveSessions: stats.UsersWithActiveSessions,
+		Daily:                   daily,
+	}
+}
+
+// ExportedUser copies the selected fields of a user into its protobuf representation
+func ExportedUser(user *models.User, fields []models.UserExportField) *pb.ExportedUser {
+	exported := &pb.ExportedUser{}
+	for _, field := range fields {
+		switch field {
+		case models.UserExportFieldID:
+			exported.Id = user.ID.String()
+		case models.UserExportFieldEmail:
+			exported.Email = user.Email.String()
+		case models.UserExportFieldUsername:
+			exported.Username = user.Username.String()
+		case models.UserExportFieldCreatedAt:
+			exported.CreatedAt = user.CreatedAt
+		case models.UserExportFieldUpdatedAt:
+			exported.UpdatedAt = user.UpdatedAt
+		}
+	}
+	return exported
+}
+
+// RiskSignals converts the fraud signals of a user
+func RiskSignals(signals *models.RiskSignals) *pb.GetRiskSignalsResponse {
+	return &pb.GetRiskSignalsResponse{
+		UserId:                   signals.UserID,
+		CreatedAt:                signals.CreatedAt,
+		AccountAgeDays:           signals.AccountAgeDays,
+		DeviceCount:              signals.DeviceCount,
+		DisposableEmail:          signals.DisposableEmail,
+		RegistrationIpReuseCount: signals.RegistrationIPReuseCount,
+		SessionAnomalyCount:      signals.SessionAnomalyCount,
+		GeneratedAt:              signals.GeneratedAt,
+	}
+}
+
+// BatchGetUsersResp converts the users of a batch lookup, keyed by ID
+func BatchGetUsersResp(users map[uuid.UUID]*models.User) *pb.BatchGetUsersResponse {
+	resp := &pb.BatchGetUsersResponse{Users: make(map[string]*pb.User, len(users))}
+	for id, user := range users {
+		resp.Users[id.String()] = User(user)
+	}
+	return resp
+}
+
+// ExchangeTokenResp converts an issued delegation token
+func ExchangeTokenResp(resp *dto.ExchangeTokenResp) *pb.ExchangeTokenResponse {
+	return &pb.ExchangeTokenResponse{
+		AccessToken:     resp.AccessToken,
+		IssuedTokenType: resp.IssuedTokenType,
+		TokenType:       resp.TokenType,
+		ExpiresIn:       resp.ExpiresIn,
+		Scope:           resp.Scopes,
+	}
+}
+
+// VerifyTokenResp converts the payload of a verified access token
+func VerifyTokenResp(payload *token.Payload) *pb.VerifyTokenResponse {
+	return &pb.VerifyTokenResponse{
+		TokenId:        payload.ID.String(),
+		UserId:         payload.UserID,
+		Username:       payload.Username,
+		IssuedAt:       payload.IssuedAt,
+		ExpiresAt:      payload.ExpiredAt,
+		Role:           payload.Role,
+		OrganizationId: payload.OrganizationID,
+		ClientId:       payload.ClientID,
+		Residency:      payload.Residency,
+		Jkt:            payload.BoundKey(),
+		Audience:       payload.Audience,
+		Scope:          payload.Scopes(),
+	}
+}
+
+// ClaimGuestActivityResp converts a claimed email
+func ClaimGuestActivityResp(resp *dto.ClaimGuestActivityResp) *pb.ClaimGuestActivityResponse {
+	return &pb.ClaimGuestActivityResponse{
+		UserId:         resp.Claim.UserID.String(),
+		Email:          resp.Claim.Email.String(),
+		VerifiedBy:     string(resp.Claim.VerifiedBy),
+		VerifiedAt:     resp.Claim.VerifiedAt,
+		ClaimedAt:      resp.Claim.ActivityClaimedAt,
+		ClaimedBy:      resp.Claim.ActivityClaimedBy,
+		AlreadyClaimed: resp.AlreadyClaimed,
+	}
+}
+
+// Organization converts an organization
+func Organization(org *models.Organization) *pb.Organization {
+	return &pb.Organization{
+		Id:                  org.ID.String(),
+		Name:                org.Name,
+		AllowedEmailDomains: org.AllowedEmailDomains,
+		Branding: &pb.OrganizationBranding{
+			Name:         org.Branding.Name,
+			LogoUrl:      org.Branding.LogoURL,
+			SupportEmail: org.Branding.SupportEmail,
+			Color:        org.Branding.Color,
+		},
+		Residency:                 org.Residency,
+		SessionIdleTimeoutSeconds: int64(org.SessionIdleTimeout / time.Second),
+		CreatedAt:                 org.CreatedAt,
+		UpdatedAt:                 org.UpdatedAt,
+	}
+}
+
+// ImportUsersResp converts the results of one import batch
+func ImportUsersResp(results []*dto.ImportUserResult) *pb.ImportUsersResponse {
+	resp := &pb.ImportUsersResponse{Results: make([]*pb.ImportUserResult, 0, len(results))}
+	for _, result := range results {
+		item := &pb.ImportUserResult{
+			Row:    int64(result.Row),
+			Email:  result.Email,
+			Status: string(result.Status),
+		}
+		if result.User != nil {
+			item.UserId = result.User.ID.String()
+		}
+		if result.Err != nil {
+			item.Error = ResultError(result.Err)
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	return resp
+}
+
+// BatchUserResults converts the results of a batch admin operation
+func BatchUserResults(results []*dto.BatchUserResult) *pb.BatchUserResultsResponse {
+	resp := &pb.BatchUserResultsResponse{Results: make([]*pb.BatchUserResult, 0, len(results))}
+	for _, result := range results {
+		item := &pb.BatchUserResult{UserId: result.UserID, Status: string(result.Status)}
+		if result.Err != nil {
+			item.Error = ResultError(result.Err)
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	return resp
+}
+
+// ResultError describes why an item of a batch failed without exposing internal errors
+func ResultError(err error) string {
+	wrapper, ok := errs.As(err)
+	if !ok {
+		return "internal error"
+	}
+
+	violations := wrapper.GetViolations()
+	if len(violations) == 0 {
+		return wrapper.Message
+	}
+
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.Field+": "+violation.Description)
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// UserHistoryResp converts a page of a user's event history
+func UserHistoryResp(resp *dto.GetUserHistoryResp) *pb.GetUserHistoryResponse {
+	events := make([]*pb.UserEvent, 0, len(resp.Events))
+	for _, event := range resp.Events {
+		events = append(events, &pb.UserEvent{
+			Id:         event.ID.String(),
+			Version:    event.Version,
+			Type:       string(event.Type),
+			Data:       string(event.Data),
+			Actor:      event.Actor,
+			OccurredAt: event.OccurredAt,
+		})
+	}
+
+	return &pb.GetUserHistoryResponse{Events: events, NextAfterVersion: resp.NextAfterVersion}
+}
+
+// ListUsersResp converts a page of users
+func ListUsersResp(resp *dto.ListUsersResp) *pb.ListUsersResponse {
+	users := make([]*pb.User, 0, len(resp.Users))
+	for _, user := range resp.Users {
+		users = append(users, User(user))
+	}
+
+	return &pb.ListUsersResponse{Users: users, NextPageToken: resp.NextPageToken}
+}
+
+// PromoteSigningKeyResp converts the result of a signing key promotion
+func PromoteSigningKeyResp(resp *dto.PromoteSigningKeyResp) *pb.PromoteSigningKeyResponse {
+	return &pb.PromoteSigningKeyResponse{
+		PrimaryKeyId:  resp.PrimaryKeyID,
+		PreviousKeyId: resp.PreviousKeyID,
+	}
+}
+
+// AvatarUploadResp converts a pre-signed avatar upload
+func AvatarUploadResp(upload *dto.AvatarUploadResp) *pb.RequestAvatarUploadURLResponse {
+	return &pb.RequestAvatarUploadURLResponse{
+		UploadUrl:   upload.UploadURL,
+		ContentType: upload.ContentType,
+		ObjectKey:   upload.ObjectKey,
+		ExpiresAt:   upload.ExpiresAt,
+	}
+}
+
+// AvatarResp converts a confirmed avatar
+func AvatarResp(avatar *dto.AvatarResp) *pb.ConfirmAvatarResponse {
+	return &pb.ConfirmAvatarResponse{ObjectKey: avatar.ObjectKey, AvatarUrl: avatar.AvatarURL}
+}
+
+// RestoreSnapshotResp converts the rows restored from a snapshot
+func RestoreSnapshotResp(resp *dto.RestoreSnapshotResp) *pb.RestoreSnapshotResponse {
+	return &pb.RestoreSnapshotResponse{
+		Organizations: resp.Organizations,
+		Users:         resp.Users,
+		Sessions:      resp.Sessions,
+	}
+}
+
+// UserUpdate converts the state of a watched user. Users that do not exist have no user.
+func UserUpdate(update *dto.WatchUserUpdate) *pb.UserUpdate {
+	resp := &pb.UserUpdate{
+		UserId:  update.UserID.String(),
+		Version: update.Version,
+		Initial: update.Initial,
+	}
+	if update.User != nil {
+		resp.User = User(update.User)
+	}
+	return resp
+}
+
+// CleanupRefreshTokensProgress converts the progress of a refresh token cleanup
+func CleanupRefreshTokensProgress(progress *dto.CleanupRefreshTokensProgress) *pb.CleanupRefreshTokensProgress {
+	return &pb.CleanupRefreshTokensProgress{
+		Deleted: progress.Deleted,
+		Batches: progress.Batches,
+		Done:    progress.Done,
+	}
+}
+
+// PushTokenResp converts a registered push token, leaving out the token itself
+func PushTokenResp(pushToken *models.PushToken) *pb.RegisterPushTokenResponse {
+	return &pb.RegisterPushTokenResponse{
+		DeviceId:  pushToken.DeviceID,
+		Platform:  string(pushToken.Platform),
+		UpdatedAt: pushToken.UpdatedAt,
+	}
+}
+
+// LoginSchedule converts a login schedule, with days by name and times as HH:MM
+func LoginSchedule(schedule *models.LoginSchedule) *pb.LoginSchedule {
+	subject := &pb.LoginScheduleSubject{Role: string(schedule.Role)}
+	if schedule.UserID != uuid.Nil {
+		subject.UserId = schedule.UserID.String()
+	}
+
+	windows := make([]*pb.LoginWindow, 0, len(schedule.Windows))
+	for _, window := range schedule.Windows {
+		days := make([]string, 0, len(window.Days))
+		for _, day := range window.Days {
+			days = append(days, models.FormatWeekday(day))
+		}
+		windows = append(windows, &pb.LoginWindow{
+			Days:  days,
+			Start: models.FormatClock(window.Start),
+			End:   models.FormatClock(window.End),
+		})
+	}
+
+	return &pb.LoginSchedule{
+		Subject:   subject,
+		Timezone:  schedule.Timezone,
+		Windows:   windows,
+		UpdatedAt: schedule.UpdatedAt,
+	}
+}
+
+// GlobalLogoutResp converts a completed global logout
+func GlobalLogoutResp(logout *models.GlobalLogout) *pb.GlobalLogoutResponse {
+	return &pb.GlobalLogoutResponse{
+		Id:                   logout.ID.String(),
+		Cutoff:               logout.Cutoff,
+		RefreshTokensRevoked: logout.RefreshTokensRevoked,
+		CompletedAt:          logout.CompletedAt,
+	}
+}
+
+// AuthorizedClientsResp converts the clients a user is signed in with
+func AuthorizedClientsResp(clients []*models.AuthorizedClient) *pb.ListAuthorizedClientsResponse {
+	resp := &pb.ListAuthorizedClientsResponse{Clients: make([]*pb.AuthorizedClient, 0, len(clients))}
+	for _, client := range clients {
+		resp.Clients = append(resp.Clients, &pb.AuthorizedClient{
+			ClientId:          client.ClientID,
+			Platform:          string(client.Platform),
+			FirstAuthorizedAt: client.FirstAuthorizedAt,
+			LastUsedAt:        client.LastUsedAt,
+			ActiveSessions:    client.ActiveSessions,
+		})
+	}
+	return resp
+}
+
+// OrgAuditLogChunk converts a chunk of an organization's audit trail
+func OrgAuditLogChunk(entries []*models.AuditLog) *pb.ExportOrgAuditLogChunk {
+	chunk := &pb.ExportOrgAuditLogChunk{Entries: make([]*pb.AuditLogEntry, 0, len(entries))}
+	for _, entry := range entries {
+		chunk.Entries = append(chunk.Entries, &pb.AuditLogEntry{
+			Id:        entry.ID.String(),
+			UserId:    entry.UserID.String(),
+			Action:    string(entry.Action),
+			Metadata:  string(entry.Metadata),
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+	return chunk
+}
+
+// VelocityRule converts a velocity rule of the abuse engine
+func VelocityRule(rule *models.VelocityRule) *pb.VelocityRule {
+	return &pb.VelocityRule{
+		Name:          rule.Name,
+		Entity:        string(rule.Entity),
+		Action:        string(rule.Action),
+		Threshold:     int32(rule.Threshold),
+		WindowSeconds: int64(rule.Window / time.Second),
+		Response:      string(rule.Response),
+		Enabled:       rule.Enabled,
+		UpdatedBy:     rule.UpdatedBy,
+		CreatedAt:     rule.CreatedAt,
+		UpdatedAt:     rule.UpdatedAt,
+	}
+}
+
+// VelocityRulesResp converts the velocity rules of the abuse engine
+func VelocityRulesResp(rules []*models.VelocityRule) *pb.ListVelocityRulesResponse {
+	resp := &pb.ListVelocityRulesResponse{Rules: make([]*pb.VelocityRule, 0, len(rules))}
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, VelocityRule(rule))
+	}
+	return resp
+}
+
+// CanaryAccount converts a canary account
+func CanaryAccount(account *models.CanaryAccount) *pb.CanaryAccount {
+	return &pb.CanaryAccount{
+		UserId:    account.UserID.String(),
+		Note:      account.Note,
+		Tarpit:    account.Tarpit,
+		CreatedBy: account.CreatedBy,
+		CreatedAt: account.CreatedAt,
+	}
+}
+
+// CanaryAccountsResp converts the canary accounts
+func CanaryAccountsResp(accounts []*models.CanaryAccount) *pb.ListCanaryAccountsResponse {
+	resp := &pb.ListCanaryAccountsResponse{Accounts: make([]*pb.CanaryAccount, 0, len(accounts))}
+	for _, account := range accounts {
+		resp.Accounts = append(resp.Accounts, CanaryAccount(account))
+	}
+	return resp
+}
+
+// UserNote converts a note on a user
+func UserNote(note *models.UserNote) *pb.UserNote {
+	return &pb.UserNote{
+		Id:         note.ID.String(),
+		UserId:     note.UserID.String(),
+		Body:       note.Body,
+		Visibility: string(note.Visibility),
+		Author:     note.Author,
+		CreatedBy:  note.CreatedBy,
+		CreatedAt:  note.CreatedAt,
+	}
+}
+
+// ListUserNotesResp converts a page of the notes on a user
+func ListUserNotesResp(resp *dto.ListUserNotesResp) *pb.ListUserNotesResponse {
+	notes := make([]*pb.UserNote, 0, len(resp.Notes))
+	for _, note := range resp.Notes {
+		notes = append(notes, UserNote(note))
+	}
+
+	return &pb.ListUserNotesResponse{Notes: notes, NextPageToken: resp.NextPageToken}
+}
+
+// RegistrationGatesResp converts the registration gates with the sign-ups of today
+func RegistrationGatesResp(resp *dto.RegistrationGatesResp) *pb.RegistrationGates {
+	return &pb.RegistrationGates{
+		InviteCodeRequired: resp.Gates.InviteCodeRequired,
+		InviteCodes:        resp.Gates.InviteCodes,
+		DailyCap:           int32(resp.Gates.DailyCap),
+		AllowlistOnly:      resp.Gates.AllowlistOnly,
+		Allowlist:          resp.Gates.Allowlist,
+		SignupsToday:       int32(resp.SignupsToday),
+		UpdatedBy:          resp.Gates.UpdatedBy,
+		UpdatedAt:          resp.Gates.UpdatedAt,
+	}
+}
+
+// LoginAttempt converts a login attempt of a user
+func LoginAttempt(attempt *models.LoginAttempt) *pb.LoginAttempt {
+	return &pb.LoginAttempt{
+		Id:            attempt.ID.String(),
+		UserId:        attempt.UserID.String(),
+		Success:       attempt.Success,
+		FailureReason: attempt.FailureReason,
+		ClientId:      attempt.ClientID,
+		IpAddress:     attempt.IPAddress,
+		UserAgent:     attempt.UserAgent,
+		CreatedAt:     attempt.CreatedAt,
+	}
+}
+
+// GetLoginHistoryResp converts a page of the login attempts of a user
+func GetLoginHistoryResp(resp *dto.GetLoginHistoryResp) *pb.GetLoginHistoryResponse {
+	attempts := make([]*pb.LoginAttempt, 0, len(resp.Attempts))
+	for _, attempt := range resp.Attempts {
+		attempts = append(attempts, LoginAttempt(attempt))
+	}
+
+	return &pb.GetLoginHistoryResponse{Attempts: attempts, NextPageToken: resp.NextPageToken}
+}
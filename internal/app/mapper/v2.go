@@ -0,0 +1,153 @@
+package mapper
+
+import (
+	pbv2 "user-svc/api/proto/v2"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+
+	"github.com/google/uuid"
+)
+
+// RegisterReqV2 converts a v2 registration request
+func RegisterReqV2(req *pbv2.RegisterRequest) dto.RegisterReq {
+	return dto.RegisterReq{
+		Email:          req.Email,
+		Username:       req.Username,
+		Password:       req.Password,
+		ClientID:       req.ClientId,
+		OrganizationID: req.OrganizationId,
+		InviteCode:     req.InviteCode,
+	}
+}
+
+// LoginReqV2 converts a v2 login request
+func LoginReqV2(req *pbv2.LoginRequest) dto.LoginReq {
+	return dto.LoginReq{
+		Email:      req.Email,
+		Password:   req.Password,
+		ClientID:   req.ClientId,
+		RememberMe: req.RememberMe,
+	}
+}
+
+// RefreshTokenReqV2 converts a v2 token refresh request
+func RefreshTokenReqV2(req *pbv2.RefreshTokenRequest) dto.RefreshTokenReq {
+	return dto.RefreshTokenReq{RefreshToken: req.RefreshToken}
+}
+
+// LogoutReqV2 converts a logout request
+func LogoutReqV2(req *pbv2.LogoutRequest) dto.LogoutReq {
+	return dto.LogoutReq{RefreshToken: req.RefreshToken}
+}
+
+// RequestPasswordResetReqV2 converts a request for a password reset link
+func RequestPasswordResetReqV2(req *pbv2.RequestPasswordResetRequest) dto.RequestPasswordResetReq {
+	return dto.RequestPasswordResetReq{Email: req.Email}
+}
+
+// ResetPasswordReqV2 converts a password reset with its emailed token
+func ResetPasswordReqV2(req *pbv2.ResetPasswordRequest) dto.ResetPasswordReq {
+	return dto.ResetPasswordReq{Token: req.Token, Password: req.Password}
+}
+
+// VerifyEmailReqV2 converts an email verification with its emailed token
+func VerifyEmailReqV2(req *pbv2.VerifyEmailRequest) dto.VerifyEmailReq {
+	return dto.VerifyEmailReq{Token: req.Token}
+}
+
+// CreateInviteCodeReqV2 converts a request for an invite code of the caller
+func CreateInviteCodeReqV2(req *pbv2.CreateInviteCodeRequest) dto.CreateInviteCodeReq {
+	return dto.CreateInviteCodeReq{MaxRedemptions: int(req.MaxRedemptions)}
+}
+
+// StartRegistrationReqV2 converts a request for a registration code
+func StartRegistrationReqV2(req *pbv2.StartRegistrationRequest) dto.StartRegistrationReq {
+	return dto.StartRegistrationReq{Email: req.Email, ClientID: req.ClientId}
+}
+
+// CompleteRegistrationReqV2 converts a registration with its emailed code
+func CompleteRegistrationReqV2(req *pbv2.CompleteRegistrationRequest) dto.CompleteRegistrationReq {
+	return dto.CompleteRegistrationReq{
+		RegisterReq: dto.RegisterReq{
+			Email:          req.Email,
+			Username:       req.Username,
+			Password:       req.Password,
+			ClientID:       req.ClientId,
+			OrganizationID: req.OrganizationId,
+			InviteCode:     req.InviteCode,
+		},
+		Code: req.Code,
+	}
+}
+
+// UserV2 converts a user with its timestamps
+func UserV2(user *models.User) *pbv2.User {
+	resp := &pbv2.User{
+		Id:        user.ID.String(),
+		Email:     user.Email.String(),
+		Username:  user.Username.String(),
+		Status:    string(user.Status),
+		Role:      string(user.Role),
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Residency: user.Residency,
+	}
+	if user.OrganizationID != uuid.Nil {
+		resp.OrganizationId = user.OrganizationID.String()
+	}
+	return resp
+}
+
+// RefreshTokenV2 converts a refresh token and its expiry, nil for no refresh token
+func RefreshTokenV2(token string, expiresAt int64) *pbv2.RefreshToken {
+	if token == "" {
+		return nil
+	}
+	return &pbv2.RefreshToken{Token: token, ExpiresAt: expiresAt}
+}
+
+// RegisterRespV2 converts the result of a registration
+func RegisterRespV2(resp *dto.RegisterResp) *pbv2.RegisterResponse {
+	return &pbv2.RegisterResponse{
+		User:         UserV2(resp.User),
+		AccessToken:  resp.AccessToken,
+		RefreshToken: RefreshTokenV2(resp.RefreshToken, resp.RefreshTokenExpiresAt),
+	}
+}
+
+// StartRegistrationRespV2 converts the expiry of an emailed registration code
+func StartRegistrationRespV2(resp *dto.StartRegistrationResp) *pbv2.StartRegistrationResponse {
+	return &pbv2.StartRegistrationResponse{ExpiresAt: resp.ExpiresAt}
+}
+
+// ResendVerificationEmailRespV2 converts the expiry of an emailed verification link
+func ResendVerificationEmailRespV2(resp *dto.ResendVerificationEmailResp) *pbv2.ResendVerificationEmailResponse {
+	return &pbv2.ResendVerificationEmailResponse{ExpiresAt: resp.ExpiresAt}
+}
+
+// InviteCodeV2 converts an invite code of a user
+func InviteCodeV2(inviteCode *models.InviteCode) *pbv2.InviteCode {
+	return &pbv2.InviteCode{
+		Code:           inviteCode.Code,
+		MaxRedemptions: int32(inviteCode.MaxRedemptions),
+		Redemptions:    int32(inviteCode.Redemptions),
+		CreatedAt:      inviteCode.CreatedAt,
+	}
+}
+
+// LoginRespV2 converts the result of a login
+func LoginRespV2(resp *dto.LoginResp) *pbv2.LoginResponse {
+	return &pbv2.LoginResponse{
+		User:         UserV2(resp.User),
+		AccessToken:  resp.AccessToken,
+		RefreshToken: RefreshTokenV2(resp.RefreshToken, resp.RefreshTokenExpiresAt),
+	}
+}
+
+// RefreshTokenRespV2 converts the result of a token refresh
+func RefreshTokenRespV2(resp *dto.RefreshTokenResp) *pbv2.RefreshTokenResponse {
+	return &pbv2.RefreshTokenResponse{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: RefreshTokenV2(resp.RefreshToken, resp.RefreshTokenExpiresAt),
+	}
+}
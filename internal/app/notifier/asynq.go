@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/breaker"
+
+	"github.com/hibiken/asynq"
+)
+
+// TaskTypePrefix prefixes the asynq task type of each channel, e.g. "notification:email"
+const TaskTypePrefix = "notification:"
+
+// TaskSender hands deliveries to the delivery service of a channel as asynq tasks,
+// published through the event bus circuit breaker
+type TaskSender struct {
+	channel    models.NotificationChannel
+	address    func(recipient *Recipient) (string, bool)
+	client     *asynq.Client
+	breaker    *breaker.Breaker
+	maxRetries int
+}
+
+// NewEmailSender sends to the recipient's email address
+func NewEmailSender(client *asynq.Client, breaker *breaker.Breaker, maxRetries int) *TaskSender {
+	return newTaskSender(models.NotificationChannelEmail, client, breaker, maxRetries, func(recipient *Recipient) (string, bool) {
+		return recipient.Email, recipient.Email != ""
+	})
+}
+
+// NewSMSSender sends to the recipient's phone number
+func NewSMSSender(client *asynq.Client, breaker *breaker.Breaker, maxRetries int) *TaskSender {
+	return newTaskSender(models.NotificationChannelSMS, client, breaker, maxRetries, func(recipient *Recipient) (string, bool) {
+		return recipient.Phone, recipient.Phone != ""
+	})
+}
+
+// NewPushSender sends to every device the recipient registered for push notifications
+func NewPushSender(client *asynq.Client, breaker *breaker.Breaker, maxRetries int) *TaskSender {
+	return newTaskSender(models.NotificationChannelPush, client, breaker, maxRetries, func(recipient *Recipient) (string, bool) {
+		return "", recipient.PushDevices > 0
+	})
+}
+
+func newTaskSender(
+	channel models.NotificationChannel,
+	client *asynq.Client,
+	breaker *breaker.Breaker,
+	maxRetries int,
+	address func(recipient *Recipient) (string, bool),
+) *TaskSender {
+	return &TaskSender{
+		channel:    channel,
+		address:    address,
+		client:     client,
+		breaker:    breaker,
+		maxRetries: maxRetries,
+	}
+}
+
+func (s *TaskSender) Channel() models.NotificationChannel {
+	return s.channel
+}
+
+func (s *TaskSender) Address(recipient *Recipient) (string, bool) {
+	return s.address(recipient)
+}
+
+// Send enqueues the delivery. The delivery ID is the task ID, so a delivery retried after
+// its task was enqueued but before its status was stored is not queued twice.
+func (s *TaskSender) Send(ctx context.Context, delivery *models.NotificationDelivery) error {
+	task := asynq.NewTask(TaskTypePrefix+string(s.channel), delivery.Payload)
+
+	return s.breaker.Execute(ctx, func(ctx context.Context) error {
+		_, err := s.client.EnqueueContext(ctx, task, asynq.TaskID(delivery.ID.String()), asynq.MaxRetry(s.maxRetries))
+		if errors.Is(err, asynq.ErrTaskIDConflict) {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,278 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/email"
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+	"user-svc/pkg/utils/retry"
+
+	"github.com/google/uuid"
+)
+
+// Recipient is the user a notification is sent to and the addresses they can be reached at
+type Recipient struct {
+	UserID uuid.UUID
+	Email  string
+	Phone  string
+	// PushDevices is the number of devices registered for push notifications
+	PushDevices int
+}
+
+// Notification is a user event to deliver on the channels the user wants it on
+type Notification struct {
+	// EventID identifies the event; a notification is delivered at most once per event and channel
+	EventID   string
+	EventType string
+	Recipient Recipient
+	// Message is the rendered email, also the source of the SMS and push texts
+	Message *email.Message
+	// Data is the event payload, passed through to the channel's delivery service
+	Data interface{}
+}
+
+// Delivery is the payload a sender hands to the delivery service of its channel
+type Delivery struct {
+	DeliveryID string                     `json:"deliveryId"`
+	EventID    string                     `json:"eventId"`
+	EventType  string                     `json:"eventType"`
+	Channel    models.NotificationChannel `json:"channel"`
+	UserID     string                     `json:"userId"`
+	// Address is the email address or phone number, empty for push
+	Address string          `json:"address,omitempty"`
+	Message *email.Message  `json:"message,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Sender delivers notifications on one channel
+type Sender interface {
+	Channel() models.NotificationChannel
+	// Address returns where the recipient is reached on the channel, and false if they cannot be
+	Address(recipient *Recipient) (string, bool)
+	Send(ctx context.Context, delivery *models.NotificationDelivery) error
+}
+
+// PreferenceRepository reads the channels users opted in to or out of
+type PreferenceRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.NotificationPreference, error)
+}
+
+// DeliveryRepository persists deliveries and their status
+type DeliveryRepository interface {
+	Create(ctx context.Context, delivery *models.NotificationDelivery) (bool, error)
+	UpdateStatus(ctx context.Context, delivery *models.NotificationDelivery) error
+	ClaimDue(ctx context.Context, now, lease int64, limit int) ([]*models.NotificationDelivery, error)
+}
+
+// Router fans notifications out to the channels each user wants them on. Every delivery is
+// persisted before it is sent; failed deliveries are retried with backoff and moved to the
+// dead letter state once their attempts are exhausted.
+type Router struct {
+	logger     *logutils.Logger
+	prefs      PreferenceRepository
+	deliveries DeliveryRepository
+	senders    map[models.NotificationChannel]Sender
+	routes     map[string][]models.NotificationChannel
+	policy     retry.Policy
+	// lease is how long a delivery being attempted is hidden from other attempts
+	lease     time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+func NewRouter(
+	logger *logutils.Logger,
+	prefs PreferenceRepository,
+	deliveries DeliveryRepository,
+	routes map[string][]models.NotificationChannel,
+	policy retry.Policy,
+	lease time.Duration,
+	batchSize int,
+	senders ...Sender,
+) *Router {
+	bySender := make(map[models.NotificationChannel]Sender, len(senders))
+	for _, sender := range senders {
+		bySender[sender.Channel()] = sender
+	}
+
+	return &Router{
+		logger:     logger,
+		prefs:      prefs,
+		deliveries: deliveries,
+		senders:    bySender,
+		routes:     routes,
+		policy:     policy,
+		lease:      lease,
+		batchSize:  batchSize,
+		now:        time.Now,
+	}
+}
+
+// Routes reports whether the router delivers the event type
+func (r *Router) Routes(eventType string) bool {
+	_, ok := r.routes[eventType]
+	return ok
+}
+
+// Notify persists a delivery for every channel the recipient wants the event on and can be
+// reached on, then attempts them. Only persistence errors are returned; failed attempts
+// are retried later.
+func (r *Router) Notify(ctx context.Context, notification *Notification) error {
+	logger := r.logger.WithFields(logutils.Fields{
+		"event_id":   notification.EventID,
+		"event_type": notification.EventType,
+		"user_id":    notification.Recipient.UserID,
+	})
+
+	prefs, err := r.prefs.ListByUser(ctx, notification.Recipient.UserID)
+	if err != nil {
+		return err
+	}
+	channels := models.ResolveChannels(r.routes[notification.EventType], models.EventPreferences(prefs, notification.EventType))
+
+	data, err := json.Marshal(notification.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification data: %w", err)
+	}
+
+	for _, channel := range channels {
+		sender, ok := r.senders[channel]
+		if !ok {
+			continue
+		}
+
+		address, ok := sender.Address(&notification.Recipient)
+		if !ok {
+			logger.WithField("channel", channel).Debug("Recipient cannot be reached on channel")
+			metrics.NotificationDeliveries.WithLabelValues(string(channel), "unreachable").Inc()
+			continue
+		}
+
+		delivery, err := r.newDelivery(notification, channel, address, data)
+		if err != nil {
+			return err
+		}
+
+		created, err := r.deliveries.Create(ctx, delivery)
+		if err != nil {
+			return err
+		}
+		if !created {
+			continue
+		}
+
+		r.attempt(ctx, sender, delivery)
+	}
+
+	return nil
+}
+
+// Start retries due deliveries every interval until ctx is cancelled
+func (r *Router) Start(ctx context.Context, wg *sync.WaitGroup, interval time.Duration) {
+	r.logger.WithField("channels", len(r.senders)).Info("Starting notification router")
+
+	wg.Add(1)
+	go func() {
+		defer func() {
+			wg.Done()
+			r.logger.Info("Notification router stopped")
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.retryDue(ctx)
+			}
+		}
+	}()
+}
+
+func (r *Router) retryDue(ctx context.Context) {
+	deliveries, err := r.deliveries.ClaimDue(ctx, r.now().UnixMilli(), r.lease.Milliseconds(), r.batchSize)
+	if err != nil {
+		r.logger.WithError(err).Error("Could not load due notification deliveries")
+		return
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+
+		sender, ok := r.senders[delivery.Channel]
+		if !ok {
+			// The channel was disabled since the delivery was created
+			delivery.MarkFailed(fmt.Errorf("no sender for channel %s", delivery.Channel), 0, 0, r.now())
+			r.saveStatus(ctx, delivery)
+			continue
+		}
+
+		r.attempt(ctx, sender, delivery)
+	}
+}
+
+// attempt sends a delivery once and stores the outcome
+func (r *Router) attempt(ctx context.Context, sender Sender, delivery *models.NotificationDelivery) {
+	if err := sender.Send(ctx, delivery); err != nil {
+		delivery.MarkFailed(err, r.policy.MaxAttempts, r.policy.Backoff(delivery.Attempts), r.now())
+		r.logger.WithError(err).WithFields(logutils.Fields{
+			"delivery_id": delivery.ID,
+			"channel":     delivery.Channel,
+			"attempts":    delivery.Attempts,
+			"status":      delivery.Status,
+		}).Warn("Notification delivery failed")
+	} else {
+		delivery.MarkSent()
+	}
+
+	r.saveStatus(ctx, delivery)
+}
+
+func (r *Router) saveStatus(ctx context.Context, delivery *models.NotificationDelivery) {
+	metrics.NotificationDeliveries.WithLabelValues(string(delivery.Channel), string(delivery.Status)).Inc()
+
+	if err := r.deliveries.UpdateStatus(ctx, delivery); err != nil {
+		r.logger.WithError(err).WithField("delivery_id", delivery.ID).Error("Could not store notification delivery status")
+	}
+}
+
+func (r *Router) newDelivery(notification *Notification, channel models.NotificationChannel, address string, data json.RawMessage) (*models.NotificationDelivery, error) {
+	id := uuid.New()
+
+	payload, err := json.Marshal(Delivery{
+		DeliveryID: id.String(),
+		EventID:    notification.EventID,
+		EventType:  notification.EventType,
+		Channel:    channel,
+		UserID:     notification.Recipient.UserID.String(),
+		Address:    address,
+		Message:    notification.Message,
+		Data:       data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification delivery: %w", err)
+	}
+
+	return &models.NotificationDelivery{
+		ID:        id,
+		EventID:   notification.EventID,
+		EventType: notification.EventType,
+		UserID:    notification.Recipient.UserID,
+		Channel:   channel,
+		Status:    models.DeliveryStatusPending,
+		Payload:   payload,
+		// Picked up by the retry loop if this replica stops before the attempt is stored
+		NextAttemptAt: r.now().Add(r.lease).UnixMilli(),
+	}, nil
+}
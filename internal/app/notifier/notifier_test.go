@@ -0,0 +1,159 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/retry"
+
+	"github.com/google/uuid"
+)
+
+type fakePreferences struct {
+	prefs []*models.NotificationPreference
+}
+
+func (f *fakePreferences) ListByUser(_ context.Context, _ uuid.UUID) ([]*models.NotificationPreference, error) {
+	return f.prefs, nil
+}
+
+type fakeDeliveries struct {
+	byKey map[string]*models.NotificationDelivery
+}
+
+func (f *fakeDeliveries) Create(_ context.Context, delivery *models.NotificationDelivery) (bool, error) {
+	key := delivery.EventID + "/" + string(delivery.Channel)
+	if _, ok := f.byKey[key]; ok {
+		return false, nil
+	}
+	f.byKey[key] = delivery
+	return true, nil
+}
+
+func (f *fakeDeliveries) UpdateStatus(_ context.Context, _ *models.NotificationDelivery) error {
+	return nil
+}
+
+func (f *fakeDeliveries) ClaimDue(_ context.Context, now, lease int64, _ int) ([]*models.NotificationDelivery, error) {
+	due := make([]*models.NotificationDelivery, 0)
+	for _, delivery := range f.byKey {
+		if (delivery.Status == models.DeliveryStatusPending || delivery.Status == models.DeliveryStatusFailed) &&
+			delivery.NextAttemptAt <= now {
+			delivery.NextAttemptAt = now + lease
+			due = append(due, delivery)
+		}
+	}
+	return due, nil
+}
+
+type fakeSender struct {
+	channel models.NotificationChannel
+	err     error
+	sent    int
+}
+
+func (s *fakeSender) Channel() models.NotificationChannel {
+	return s.channel
+}
+
+func (s *fakeSender) Address(recipient *Recipient) (string, bool) {
+	if s.channel == models.NotificationChannelSMS {
+		return recipient.Phone, recipient.Phone != ""
+	}
+	return recipient.Email, true
+}
+
+func (s *fakeSender) Send(_ context.Context, _ *models.NotificationDelivery) error {
+	s.sent++
+	return s.err
+}
+
+func newTestRouter(prefs []*models.NotificationPreference, senders ...Sender) (*Router, *fakeDeliveries, *time.Time) {
+	logger := logutils.New(io.Discard)
+
+	deliveries := &fakeDeliveries{byKey: make(map[string]*models.NotificationDelivery)}
+	router := NewRouter(
+		logger,
+		&fakePreferences{prefs: prefs},
+		deliveries,
+		map[string][]models.NotificationChannel{
+			"login": {models.NotificationChannelEmail, models.NotificationChannelSMS},
+		},
+		retry.Policy{MaxAttempts: 2, InitialBackoff: time.Minute, MaxBackoff: time.Minute, Multiplier: 1},
+		time.Minute,
+		10,
+		senders...,
+	)
+
+	now := time.UnixMilli(1_700_000_000_000)
+	router.now = func() time.Time { return now }
+
+	return router, deliveries, &now
+}
+
+func testNotification() *Notification {
+	return &Notification{
+		EventID:   "event-1",
+		EventType: "login",
+		Recipient: Recipient{UserID: uuid.New(), Email: "jane@example.com"},
+	}
+}
+
+func TestRouterNotifyRoutesToReachableChannels(t *testing.T) {
+	email := &fakeSender{channel: models.NotificationChannelEmail}
+	sms := &fakeSender{channel: models.NotificationChannelSMS}
+	push := &fakeSender{channel: models.NotificationChannelPush}
+	router, deliveries, _ := newTestRouter([]*models.NotificationPreference{
+		{EventType: "login", Channel: models.NotificationChannelPush, Enabled: true},
+		{EventType: "security_digest", Channel: models.NotificationChannelEmail, Enabled: false},
+	}, email, sms, push)
+
+	if err := router.Notify(context.Background(), testNotification()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// SMS is a default channel, but the recipient has no phone number
+	if email.sent != 1 || sms.sent != 0 || push.sent != 1 {
+		t.Errorf("Expected email and push to be sent once, got email=%d sms=%d push=%d", email.sent, sms.sent, push.sent)
+	}
+	if len(deliveries.byKey) != 2 {
+		t.Errorf("Expected 2 deliveries, got %d", len(deliveries.byKey))
+	}
+
+	// Replayed events are not delivered again
+	if err := router.Notify(context.Background(), testNotification()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if email.sent != 1 {
+		t.Errorf("Expected the replayed event not to be resent, got %d sends", email.sent)
+	}
+}
+
+func TestRouterRetriesAndDeadLetters(t *testing.T) {
+	email := &fakeSender{channel: models.NotificationChannelEmail, err: errors.New("queue unavailable")}
+	router, deliveries, now := newTestRouter(nil, email)
+
+	if err := router.Notify(context.Background(), testNotification()); err != nil {
+		t.Fatalf("Expected send failures not to be returned, got %v", err)
+	}
+
+	delivery := deliveries.byKey["event-1/email"]
+	if delivery.Status != models.DeliveryStatusFailed {
+		t.Fatalf("Expected status %s, got %s", models.DeliveryStatusFailed, delivery.Status)
+	}
+
+	// The jittered backoff is at most a minute
+	*now = now.Add(time.Minute)
+	router.retryDue(context.Background())
+	if email.sent != 2 {
+		t.Errorf("Expected a retry after the backoff, got %d sends", email.sent)
+	}
+	if delivery.Status != models.DeliveryStatusDeadLetter {
+		t.Errorf("Expected status %s, got %s", models.DeliveryStatusDeadLetter, delivery.Status)
+	}
+}
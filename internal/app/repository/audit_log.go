@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+)
+
+type AuditLog struct {
+	ID     string `db:"id"`
+	UserID string `db:"user_id"`
+	// OrganizationID is NULL for users outside any organization; NULL on insert takes the
+	// organization of the user
+	OrganizationID sql.NullString  `db:"organization_id"`
+	Action         string          `db:"action"`
+	Metadata       json.RawMessage `db:"metadata"`
+	CreatedAt      int64           `db:"created_at"`
+}
+
+func (a *AuditLog) ToDomain() *models.AuditLog {
+	var organizationID uuid.UUID
+	if a.OrganizationID.Valid {
+		organizationID, _ = uuid.Parse(a.OrganizationID.String)
+	}
+
+	return &models.AuditLog{
+		ID:             uuid.MustParse(a.ID),
+		UserID:         uuid.MustParse(a.UserID),
+		OrganizationID: organizationID,
+		Action:         models.AuditAction(a.Action),
+		Metadata:       a.Metadata,
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+type AuditLogRepository struct {
+	db db.Store
+}
+
+func NewAuditLogRepository(db db.Store) *AuditLogRepository {
+	return &AuditLogRepository{
+		db: db,
+	}
+}
+
+// CreateBatch inserts a batch of audit log entries in a single statement
+func (r *AuditLogRepository) CreateBatch(ctx context.Context, entries []*models.AuditLog) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO audit_logs (id, user_id, organization_id, action, metadata, created_at)
+		VALUES (:id, :user_id, :organization_id, :action, :metadata, :created_at)
+	`
+
+	rows := lo.Map(entries, func(entry *models.AuditLog, _ int) *AuditLog {
+		return &AuditLog{
+			ID:     entry.ID.String(),
+			UserID: entry.UserID.String(),
+			OrganizationID: sql.NullString{
+				String: entry.OrganizationID.String(),
+				Valid:  entry.OrganizationID != uuid.Nil,
+			},
+			Action:    string(entry.Action),
+			Metadata:  entry.Metadata,
+			CreatedAt: entry.CreatedAt,
+		}
+	})
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		if _, err := tx.NamedExecContext(ctx, query, rows); err != nil {
+			return fmt.Errorf("failed to create audit logs: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := r.db.NamedExecContext(ctx, query, rows); err != nil {
+		return fmt.Errorf("failed to create audit logs: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser returns up to limit audit log entries of the user created in [from, to), newest first
+func (r *AuditLogRepository) ListByUser(ctx context.Context, userID uuid.UUID, from, to int64, limit int) ([]*models.AuditLog, error) {
+	query := `
+		SELECT id, user_id, action, metadata, created_at
+		FROM audit_logs
+		WHERE user_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at DESC
+		LIMIT $4
+	`
+
+	rows := make([]*AuditLog, 0)
+	if err := r.db.SelectContext(ctx, &rows, query, userID, from, to, limit); err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return lo.Map(rows, func(row *AuditLog, _ int) *models.AuditLog {
+		return row.ToDomain()
+	}), nil
+}
+
+// ListActiveUserIDs returns up to limit IDs of users with audit log entries created in
+// [from, to), ordered by ID and starting after afterID so callers can page through them
+func (r *AuditLogRepository) ListActiveUserIDs(ctx context.Context, from, to int64, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT DISTINCT user_id
+		FROM audit_logs
+		WHERE created_at >= $1 AND created_at < $2 AND user_id > $3
+		ORDER BY user_id
+		LIMIT $4
+	`
+
+	userIDs := make([]uuid.UUID, 0, limit)
+	if err := r.db.SelectContext(ctx, &userIDs, query, from, to, afterID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list active users: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// ListByOrganization returns up to limit audit log entries of the organization matching the
+// filter, oldest first, starting after the entry created at afterCreatedAt with afterID so
+// callers can page through them
+func (r *AuditLogRepository) ListByOrganization(
+	ctx context.Context,
+	filter models.OrgAuditFilter,
+	afterCreatedAt int64,
+	afterID uuid.UUID,
+	limit int,
+) ([]*models.AuditLog, error) {
+	query := `
+		SELECT id, user_id, organization_id, action, metadata, created_at
+		FROM audit_logs
+		WHERE organization_id = $1
+			AND created_at >= $2 AND created_at < $3
+			AND (cardinality($4::text[]) = 0 OR action = ANY($4))
+			AND (created_at, id) > ($5, $6)
+		ORDER BY created_at, id
+		LIMIT $7
+	`
+
+	actions := lo.Map(filter.Actions, func(action models.AuditAction, _ int) string {
+		return string(action)
+	})
+
+	rows := make([]*AuditLog, 0, limit)
+	if err := r.db.SelectContext(ctx, &rows, query,
+		filter.OrganizationID, filter.From, filter.To, pq.Array(actions), afterCreatedAt, afterID, limit,
+	); err != nil {
+		return nil, fmt.Errorf("failed to list organization audit logs: %w", err)
+	}
+
+	return lo.Map(rows, func(row *AuditLog, _ int) *models.AuditLog {
+		return row.ToDomain()
+	}), nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+)
+
+// maxCachedUsers bounds the memory used by the user cache
+const maxCachedUsers = 10000
+
+type cachedUser struct {
+	user      *models.User
+	expiresAt time.Time
+}
+
+// CachingUserRepository decorates the user repository with a short-lived in-memory
+// cache for lookups by ID. Entries are evicted on delete and on token revocation.
+// A zero TTL disables caching. Users of tenant schemas are not cached, as entries are
+// keyed by ID alone.
+type CachingUserRepository struct {
+	next *RetryingUserRepository
+	ttl  time.Duration
+
+	mu    sync.RWMutex
+	users map[uuid.UUID]cachedUser
+}
+
+func NewCachingUserRepository(next *RetryingUserRepository, ttl time.Duration) *CachingUserRepository {
+	return &CachingUserRepository{
+		next:  next,
+		ttl:   ttl,
+		users: make(map[uuid.UUID]cachedUser),
+	}
+}
+
+func (r *CachingUserRepository) Create(ctx context.Context, user *models.User) error {
+	return r.next.Create(ctx, user)
+}
+
+func (r *CachingUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	if r.ttl <= 0 || db.TenantFromContext(ctx) != "" {
+		return r.next.GetByID(ctx, id)
+	}
+
+	r.mu.RLock()
+	cached, ok := r.users[id]
+	r.mu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.user, nil
+	}
+
+	user, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if len(r.users) >= maxCachedUsers {
+		r.purgeExpired()
+	}
+	if len(r.users) < maxCachedUsers {
+		r.users[id] = cachedUser{user: user, expiresAt: time.Now().Add(r.ttl)}
+	}
+	r.mu.Unlock()
+
+	return user, nil
+}
+
+func (r *CachingUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.next.GetByEmail(ctx, email)
+}
+
+func (r *CachingUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.evict(id)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *CachingUserRepository) Activate(ctx context.Context, id uuid.UUID, passwordHash models.PasswordHash) error {
+	r.evict(id)
+	return r.next.Activate(ctx, id, passwordHash)
+}
+
+func (r *CachingUserRepository) SetPassword(ctx context.Context, id uuid.UUID, passwordHash models.PasswordHash) error {
+	r.evict(id)
+	return r.next.SetPassword(ctx, id, passwordHash)
+}
+
+func (r *CachingUserRepository) RecordLogin(ctx context.Context, id uuid.UUID, loginAt int64) error {
+	r.evict(id)
+	return r.next.RecordLogin(ctx, id, loginAt)
+}
+
+func (r *CachingUserRepository) UpgradePasswordHash(ctx context.Context, id uuid.UUID, previous, passwordHash models.PasswordHash) (bool, error) {
+	r.evict(id)
+	return r.next.UpgradePasswordHash(ctx, id, previous, passwordHash)
+}
+
+func (r *CachingUserRepository) SetStatuses(ctx context.Context, ids []uuid.UUID, status models.UserStatus) (map[uuid.UUID]models.UserStatusChange, error) {
+	for _, id := range ids {
+		r.evict(id)
+	}
+	return r.next.SetStatuses(ctx, ids, status)
+}
+
+func (r *CachingUserRepository) SetRoles(ctx context.Context, ids []uuid.UUID, role models.UserRole) (map[uuid.UUID]models.UserRole, error) {
+	for _, id := range ids {
+		r.evict(id)
+	}
+	return r.next.SetRoles(ctx, ids, role)
+}
+
+// Evict drops the cached user with the given ID
+func (r *CachingUserRepository) Evict(userID string) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+	r.evict(id)
+}
+
+// purgeExpired drops expired entries. The caller must hold the write lock.
+func (r *CachingUserRepository) purgeExpired() {
+	now := time.Now()
+	for id, cached := range r.users {
+		if now.After(cached.expiresAt) {
+			delete(r.users, id)
+		}
+	}
+}
+
+func (r *CachingUserRepository) evict(id uuid.UUID) {
+	r.mu.Lock()
+	delete(r.users, id)
+	r.mu.Unlock()
+}
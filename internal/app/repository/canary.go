@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+)
+
+type CanaryAccount struct {
+	UserID    uuid.UUID `db:"user_id"`
+	Note      string    `db:"note"`
+	Tarpit    bool      `db:"tarpit"`
+	CreatedBy string    `db:"created_by"`
+	CreatedAt int64     `db:"created_at"`
+}
+
+func (r *CanaryAccount) ToDomain() *models.CanaryAccount {
+	return &models.CanaryAccount{
+		UserID:    r.UserID,
+		Note:      r.Note,
+		Tarpit:    r.Tarpit,
+		CreatedBy: r.CreatedBy,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+type CanaryAccountRepository struct {
+	db db.Store
+}
+
+func NewCanaryAccountRepository(db db.Store) *CanaryAccountRepository {
+	return &CanaryAccountRepository{
+		db: db,
+	}
+}
+
+// List returns every canary account, oldest first
+func (r *CanaryAccountRepository) List(ctx context.Context) ([]*models.CanaryAccount, error) {
+	query := `
+		SELECT user_id, note, tarpit, created_by, created_at
+		FROM canary_accounts
+		ORDER BY created_at, user_id
+	`
+
+	var rows []*CanaryAccount
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to list canary accounts: %w", err)
+	}
+
+	accounts := make([]*models.CanaryAccount, 0, len(rows))
+	for _, row := range rows {
+		accounts = append(accounts, row.ToDomain())
+	}
+	return accounts, nil
+}
+
+// Upsert stores the account, replacing the note and tarpit of an existing canary account but
+// keeping who flagged it and when, which are set on the account
+func (r *CanaryAccountRepository) Upsert(ctx context.Context, account *models.CanaryAccount) error {
+	query := `
+		INSERT INTO canary_accounts (user_id, note, tarpit, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET note = EXCLUDED.note,
+			tarpit = EXCLUDED.tarpit
+		RETURNING created_by, created_at
+	`
+
+	var row CanaryAccount
+	err := r.db.GetContext(ctx, &row, query,
+		account.UserID, account.Note, account.Tarpit, account.CreatedBy, account.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert canary account: %w", err)
+	}
+
+	account.CreatedBy = row.CreatedBy
+	account.CreatedAt = row.CreatedAt
+	return nil
+}
+
+// Delete removes the canary flag of the user and reports whether there was one
+func (r *CanaryAccountRepository) Delete(ctx context.Context, userID uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM canary_accounts WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete canary account: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
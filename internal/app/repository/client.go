@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type Client struct {
+	ID                   string         `db:"client_id"`
+	Platform             string         `db:"platform"`
+	AllowedGrantTypes    pq.StringArray `db:"allowed_grant_types"`
+	AccessTokenTTLMs     int64          `db:"access_token_ttl_ms"`
+	RefreshTokenTTLMs    int64          `db:"refresh_token_ttl_ms"`
+	RotateRefreshTokens  bool           `db:"rotate_refresh_tokens"`
+	ClaimProfile         string         `db:"claim_profile"`
+	EncryptAccessTokens  bool           `db:"encrypt_access_tokens"`
+	SessionIdleTimeoutMs int64          `db:"session_idle_timeout_ms"`
+	CreatedAt            int64          `db:"created_at"`
+	UpdatedAt            int64          `db:"updated_at"`
+}
+
+func (c *Client) ToDomain() *models.Client {
+	grants := make([]models.GrantType, 0, len(c.AllowedGrantTypes))
+	for _, grant := range c.AllowedGrantTypes {
+		grants = append(grants, models.GrantType(grant))
+	}
+
+	return &models.Client{
+		ID:                  c.ID,
+		Platform:            models.ClientPlatform(c.Platform),
+		AllowedGrantTypes:   grants,
+		AccessTokenTTL:      time.Duration(c.AccessTokenTTLMs) * time.Millisecond,
+		RefreshTokenTTL:     time.Duration(c.RefreshTokenTTLMs) * time.Millisecond,
+		RotateRefreshTokens: c.RotateRefreshTokens,
+		ClaimProfile:        token.ClaimProfile(c.ClaimProfile),
+		EncryptAccessTokens: c.EncryptAccessTokens,
+		SessionIdleTimeout:  time.Duration(c.SessionIdleTimeoutMs) * time.Millisecond,
+		CreatedAt:           c.CreatedAt,
+		UpdatedAt:           c.UpdatedAt,
+	}
+}
+
+type ClientRepository struct {
+	db db.Store
+}
+
+func NewClientRepository(db db.Store) *ClientRepository {
+	return &ClientRepository{
+		db: db,
+	}
+}
+
+// GetByID retrieves a registered client by its client ID
+func (r *ClientRepository) GetByID(ctx context.Context, clientID string) (*models.Client, error) {
+	query := `
+		SELECT client_id, platform, allowed_grant_types, access_token_ttl_ms, refresh_token_ttl_ms,
+			rotate_refresh_tokens, claim_profile, encrypt_access_tokens, session_idle_timeout_ms,
+			created_at, updated_at
+		FROM clients
+		WHERE client_id = $1
+	`
+
+	var (
+		client Client
+		err    error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &client, query, clientID)
+	} else {
+		err = r.db.GetContext(ctx, &client, query, clientID)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrClientNotFound
+		}
+		return nil, fmt.Errorf("failed to get client by id: %w", err)
+	}
+
+	return client.ToDomain(), nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type EmailClaim struct {
+	UserID            uuid.UUID     `db:"user_id"`
+	Email             string        `db:"email"`
+	VerifiedBy        string        `db:"verified_by"`
+	VerifiedAt        int64         `db:"verified_at"`
+	ActivityClaimedAt sql.NullInt64 `db:"activity_claimed_at"`
+	ActivityClaimedBy string        `db:"activity_claimed_by"`
+}
+
+func (c *EmailClaim) ToDomain() *models.EmailClaim {
+	return &models.EmailClaim{
+		UserID:            c.UserID,
+		Email:             models.Email(c.Email),
+		VerifiedBy:        models.EmailVerificationMethod(c.VerifiedBy),
+		VerifiedAt:        c.VerifiedAt,
+		ActivityClaimedAt: c.ActivityClaimedAt.Int64,
+		ActivityClaimedBy: c.ActivityClaimedBy,
+	}
+}
+
+type EmailClaimRepository struct {
+	db db.Store
+}
+
+func NewEmailClaimRepository(db db.Store) *EmailClaimRepository {
+	return &EmailClaimRepository{
+		db: db,
+	}
+}
+
+// Create stores the claim of a user on the email of the account
+func (r *EmailClaimRepository) Create(ctx context.Context, claim *models.EmailClaim) error {
+	query := `
+		INSERT INTO email_claims (user_id, email, verified_by, verified_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	args := []interface{}{claim.UserID, claim.Email.String(), string(claim.VerifiedBy), claim.VerifiedAt}
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create email claim: %w", err)
+	}
+
+	return nil
+}
+
+// Reverify records that a user who claimed the email of the account proved owning it again
+func (r *EmailClaimRepository) Reverify(ctx context.Context, userID uuid.UUID, method models.EmailVerificationMethod, verifiedAt int64) error {
+	query := `UPDATE email_claims SET verified_by = $2, verified_at = $3 WHERE user_id = $1`
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, userID, string(method), verifiedAt)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, userID, string(method), verifiedAt)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reverify email claim: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserID returns the claim of a user on the email of the account
+func (r *EmailClaimRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.EmailClaim, error) {
+	query := `
+		SELECT user_id, email, verified_by, verified_at, activity_claimed_at, activity_claimed_by
+		FROM email_claims
+		WHERE user_id = $1
+	`
+
+	var claim EmailClaim
+	if err := r.db.GetContext(ctx, &claim, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrEmailNotClaimed
+		}
+		return nil, fmt.Errorf("failed to get email claim: %w", err)
+	}
+
+	return claim.ToDomain(), nil
+}
+
+// ClaimActivity marks the guest activity of the claim of the user on email as claimed by
+// caller, unless it was claimed already, and returns the claim; it reports whether this call
+// claimed the activity
+func (r *EmailClaimRepository) ClaimActivity(ctx context.Context, userID uuid.UUID, email models.Email, caller string, now int64) (*models.EmailClaim, bool, error) {
+	query := `
+		UPDATE email_claims
+		SET activity_claimed_at = $4, activity_claimed_by = $3
+		WHERE user_id = $1 AND email = $2 AND activity_claimed_at IS NULL
+		RETURNING user_id, email, verified_by, verified_at, activity_claimed_at, activity_claimed_by
+	`
+
+	var claim EmailClaim
+	err := r.db.GetContext(ctx, &claim, query, userID, email.String(), caller, now)
+	if err == nil {
+		return claim.ToDomain(), true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, fmt.Errorf("failed to claim guest activity: %w", err)
+	}
+
+	// The activity was claimed already, or the user has no claim on the email
+	query = `
+		SELECT user_id, email, verified_by, verified_at, activity_claimed_at, activity_claimed_by
+		FROM email_claims
+		WHERE user_id = $1 AND email = $2
+	`
+	if err := r.db.GetContext(ctx, &claim, query, userID, email.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, errs.ErrEmailNotClaimed
+		}
+		return nil, false, fmt.Errorf("failed to get email claim: %w", err)
+	}
+
+	return claim.ToDomain(), false, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+)
+
+// EmailDirectoryRepository maps the emails of the users of every region to the region their
+// data is stored in. It is built on the home store and runs outside the transaction of the
+// request, which may be in the database of another region.
+type EmailDirectoryRepository struct {
+	db db.Store
+}
+
+func NewEmailDirectoryRepository(db db.Store) *EmailDirectoryRepository {
+	return &EmailDirectoryRepository{
+		db: db,
+	}
+}
+
+// Claim enters the email of a new user with the user's region and reports whether it was
+// free; false means a user of some region has the email already
+func (r *EmailDirectoryRepository) Claim(ctx context.Context, user *models.User) (bool, error) {
+	query := `
+		INSERT INTO user_email_regions (email, user_id, region, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (email) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, user.Email.String(), user.ID, user.Residency, user.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim email: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows == 1, nil
+}
+
+// Release gives back the email claimed for a user whose registration failed
+func (r *EmailDirectoryRepository) Release(ctx context.Context, user *models.User) error {
+	query := `DELETE FROM user_email_regions WHERE email = $1 AND user_id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, user.Email.String(), user.ID); err != nil {
+		return fmt.Errorf("failed to release email: %w", err)
+	}
+
+	return nil
+}
+
+// Region returns the region of the user with the email, "" for users tagged before
+// residency was enabled
+func (r *EmailDirectoryRepository) Region(ctx context.Context, email string) (string, error) {
+	query := `SELECT region FROM user_email_regions WHERE email = $1`
+
+	var region string
+	if err := r.db.GetContext(ctx, &region, query, email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to get email region: %w", err)
+	}
+
+	return region, nil
+}
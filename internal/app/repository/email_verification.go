@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type EmailVerificationTokenRepository struct {
+	db db.Store
+}
+
+func NewEmailVerificationTokenRepository(db db.Store) *EmailVerificationTokenRepository {
+	return &EmailVerificationTokenRepository{
+		db: db,
+	}
+}
+
+// Create stores the verification token of a user, replacing an earlier one, unless it was
+// created after sentBefore or dailyLimit tokens were created since the user's 24 hour window
+// started after windowStartedBefore; it reports whether the token was stored
+func (r *EmailVerificationTokenRepository) Create(
+	ctx context.Context,
+	verificationToken *models.EmailVerificationToken,
+	sentBefore int64,
+	windowStartedBefore int64,
+	dailyLimit int,
+) (bool, error) {
+	query := `
+		INSERT INTO email_verification_tokens (user_id, token_hash, expires_at, created_at, sends, window_started_at)
+		VALUES ($1, $2, $3, $4, 1, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at,
+			sends = CASE WHEN email_verification_tokens.window_started_at <= $6 THEN 1 ELSE email_verification_tokens.sends + 1 END,
+			window_started_at = CASE WHEN email_verification_tokens.window_started_at <= $6 THEN EXCLUDED.created_at ELSE email_verification_tokens.window_started_at END
+		WHERE email_verification_tokens.created_at <= $5
+			AND (email_verification_tokens.window_started_at <= $6 OR email_verification_tokens.sends < $7)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, verificationToken.UserID, verificationToken.TokenHash, verificationToken.ExpiresAt,
+		verificationToken.CreatedAt, sentBefore, windowStartedBefore, dailyLimit)
+	if err != nil {
+		return false, fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Delete clears a verification token whose link could not be emailed and gives its send back,
+// so neither the resend cooldown nor the daily limit hold back the next request
+func (r *EmailVerificationTokenRepository) Delete(ctx context.Context, tokenHash string) error {
+	query := `
+		UPDATE email_verification_tokens
+		SET token_hash = NULL, created_at = 0, sends = GREATEST(sends - 1, 0)
+		WHERE token_hash = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, tokenHash); err != nil {
+		return fmt.Errorf("failed to delete email verification token: %w", err)
+	}
+
+	return nil
+}
+
+// Consume clears an unexpired verification token and returns the user it belongs to, so a
+// token can only be used once. The row is kept to go on capping the emails of the user.
+func (r *EmailVerificationTokenRepository) Consume(ctx context.Context, tokenHash string, now int64) (uuid.UUID, error) {
+	query := `
+		UPDATE email_verification_tokens
+		SET token_hash = NULL
+		WHERE token_hash = $1 AND expires_at > $2
+		RETURNING user_id
+	`
+
+	var userID uuid.UUID
+	var err error
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &userID, query, tokenHash, now)
+	} else {
+		err = r.db.GetContext(ctx, &userID, query, tokenHash, now)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errs.ErrInvalidEmailVerification
+		}
+		return uuid.Nil, fmt.Errorf("failed to consume email verification token: %w", err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+)
+
+type GlobalLogoutRepository struct {
+	db db.Store
+}
+
+func NewGlobalLogoutRepository(db db.Store) *GlobalLogoutRepository {
+	return &GlobalLogoutRepository{
+		db: db,
+	}
+}
+
+// Create records a global logout before any token is revoked, so even an interrupted logout
+// leaves a trace
+func (r *GlobalLogoutRepository) Create(ctx context.Context, logout *models.GlobalLogout) error {
+	query := `
+		INSERT INTO global_logouts (id, admin, reason, cutoff, refresh_tokens_revoked, created_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		logout.ID,
+		logout.Admin,
+		logout.Reason,
+		logout.Cutoff,
+		logout.RefreshTokensRevoked,
+		logout.CreatedAt,
+		logout.CompletedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create global logout: %w", err)
+	}
+
+	return nil
+}
+
+// Complete records how many refresh tokens the global logout revoked
+func (r *GlobalLogoutRepository) Complete(ctx context.Context, id uuid.UUID, refreshTokensRevoked, completedAt int64) error {
+	query := `
+		UPDATE global_logouts
+		SET refresh_tokens_revoked = $2, completed_at = $3
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id, refreshTokensRevoked, completedAt); err != nil {
+		return fmt.Errorf("failed to complete global logout: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+)
+
+type InviteCode struct {
+	Code           string    `db:"code"`
+	UserID         uuid.UUID `db:"user_id"`
+	EmailHash      string    `db:"email_hash"`
+	MaxRedemptions int       `db:"max_redemptions"`
+	Redemptions    int       `db:"redemptions"`
+	CreatedAt      int64     `db:"created_at"`
+}
+
+func (c *InviteCode) ToDomain() *models.InviteCode {
+	return &models.InviteCode{
+		Code:           c.Code,
+		UserID:         c.UserID,
+		EmailHash:      c.EmailHash,
+		MaxRedemptions: c.MaxRedemptions,
+		Redemptions:    c.Redemptions,
+		CreatedAt:      c.CreatedAt,
+	}
+}
+
+type InviteCodeRepository struct {
+	db db.Store
+}
+
+func NewInviteCodeRepository(db db.Store) *InviteCodeRepository {
+	return &InviteCodeRepository{
+		db: db,
+	}
+}
+
+// Create stores an invite code unless its user already has maxActive codes with redemptions
+// left. It reports whether the code was stored.
+func (r *InviteCodeRepository) Create(ctx context.Context, code *models.InviteCode, maxActive int) (bool, error) {
+	query := `
+		INSERT INTO invite_codes (code, user_id, email_hash, max_redemptions, redemptions, created_at)
+		SELECT $1, $2, $3, $4, 0, $5
+		WHERE (SELECT COUNT(*) FROM invite_codes WHERE user_id = $2 AND redemptions < max_redemptions) < $6
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		code.Code, code.UserID, code.EmailHash, code.MaxRedemptions, code.CreatedAt, maxActive,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create invite code: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create invite code: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Get returns an invite code, failing with ErrInvalidInviteCode if there is none
+func (r *InviteCodeRepository) Get(ctx context.Context, code string) (*models.InviteCode, error) {
+	query := `
+		SELECT code, user_id, email_hash, max_redemptions, redemptions, created_at
+		FROM invite_codes
+		WHERE code = $1
+	`
+
+	var inviteCode InviteCode
+	if err := r.db.GetContext(ctx, &inviteCode, query, code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrInvalidInviteCode
+		}
+		return nil, fmt.Errorf("failed to get invite code: %w", err)
+	}
+
+	return inviteCode.ToDomain(), nil
+}
+
+// Redeem counts a redemption of the invite code and records the referral in one statement,
+// unless the code has no redemptions left. It reports whether the code was redeemed.
+func (r *InviteCodeRepository) Redeem(ctx context.Context, referral *models.Referral) (bool, error) {
+	query := `
+		WITH redeemed AS (
+			UPDATE invite_codes
+			SET redemptions = redemptions + 1
+			WHERE code = $1 AND user_id = $2 AND redemptions < max_redemptions
+			RETURNING code
+		)
+		INSERT INTO referrals (referee_id, referrer_id, code, created_at)
+		SELECT $3, $2, code, $4 FROM redeemed
+	`
+
+	result, err := r.db.ExecContext(ctx, query, referral.Code, referral.ReferrerID, referral.RefereeID, referral.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to redeem invite code: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to redeem invite code: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Unredeem deletes the referral of a registration that failed after Redeem and gives the
+// redemption back to its invite code
+func (r *InviteCodeRepository) Unredeem(ctx context.Context, refereeID uuid.UUID) error {
+	query := `
+		WITH deleted AS (
+			DELETE FROM referrals WHERE referee_id = $1 RETURNING code
+		)
+		UPDATE invite_codes
+		SET redemptions = redemptions - 1
+		WHERE code IN (SELECT code FROM deleted) AND redemptions > 0
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, refereeID); err != nil {
+		return fmt.Errorf("failed to unredeem invite code: %w", err)
+	}
+	return nil
+}
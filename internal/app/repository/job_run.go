@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-svc/internal/db"
+)
+
+type JobRunRepository struct {
+	db db.Store
+}
+
+func NewJobRunRepository(db db.Store) *JobRunRepository {
+	return &JobRunRepository{
+		db: db,
+	}
+}
+
+// Claim records a run of the job for the period under the fencing token of the job's lock. A
+// period can be claimed again once a previous, uncompleted claim is older than the lease, and
+// only under a later token, so an owner that lost its lock cannot claim it back.
+func (r *JobRunRepository) Claim(ctx context.Context, job string, periodStart int64, lease time.Duration, fence int64) (bool, error) {
+	query := `
+		INSERT INTO scheduled_job_runs (job, period_start, claimed_at, fence)
+		VALUES ($1, $2, $3, $5)
+		ON CONFLICT (job, period_start) DO UPDATE
+		SET claimed_at = EXCLUDED.claimed_at, fence = EXCLUDED.fence
+		WHERE scheduled_job_runs.completed_at IS NULL AND scheduled_job_runs.claimed_at < $4
+			AND scheduled_job_runs.fence < EXCLUDED.fence
+	`
+
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, query, job, periodStart, now.UnixMilli(), now.Add(-lease).UnixMilli(), fence)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim job run: %w", err)
+	}
+
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim job run: %w", err)
+	}
+
+	return claimed > 0, nil
+}
+
+// Complete marks the job run of the period as done if it is still claimed under the fencing
+// token, and reports whether it was; a run claimed again under a later token is left to its
+// new owner
+func (r *JobRunRepository) Complete(ctx context.Context, job string, periodStart int64, fence int64) (bool, error) {
+	query := `
+		UPDATE scheduled_job_runs
+		SET completed_at = $3
+		WHERE job = $1 AND period_start = $2 AND fence = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, job, periodStart, time.Now().UnixMilli(), fence)
+	if err != nil {
+		return false, fmt.Errorf("failed to complete job run: %w", err)
+	}
+
+	completed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to complete job run: %w", err)
+	}
+
+	return completed > 0, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type LoginAttempt struct {
+	ID uuid.UUID `db:"id"`
+	// UserID is NULL for attempts with the email of no user
+	UserID        uuid.NullUUID `db:"user_id"`
+	Success       bool          `db:"success"`
+	FailureReason string        `db:"failure_reason"`
+	ClientID      string        `db:"client_id"`
+	IPAddress     string        `db:"ip_address"`
+	UserAgent     string        `db:"user_agent"`
+	CreatedAt     int64         `db:"created_at"`
+}
+
+func (a *LoginAttempt) ToDomain() *models.LoginAttempt {
+	return &models.LoginAttempt{
+		ID:            a.ID,
+		UserID:        a.UserID.UUID,
+		Success:       a.Success,
+		FailureReason: a.FailureReason,
+		ClientID:      a.ClientID,
+		IPAddress:     a.IPAddress,
+		UserAgent:     a.UserAgent,
+		CreatedAt:     a.CreatedAt,
+	}
+}
+
+type LoginAttemptRepository struct {
+	db db.Store
+}
+
+func NewLoginAttemptRepository(db db.Store) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		db: db,
+	}
+}
+
+// CreateBatch inserts a batch of login attempts in a single statement
+func (r *LoginAttemptRepository) CreateBatch(ctx context.Context, attempts []*models.LoginAttempt) error {
+	if len(attempts) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO login_attempts (id, user_id, success, failure_reason, client_id, ip_address, user_agent, created_at)
+		VALUES (:id, :user_id, :success, :failure_reason, :client_id, :ip_address, :user_agent, :created_at)
+	`
+
+	rows := lo.Map(attempts, func(attempt *models.LoginAttempt, _ int) *LoginAttempt {
+		return &LoginAttempt{
+			ID:            attempt.ID,
+			UserID:        uuid.NullUUID{UUID: attempt.UserID, Valid: attempt.UserID != uuid.Nil},
+			Success:       attempt.Success,
+			FailureReason: attempt.FailureReason,
+			ClientID:      attempt.ClientID,
+			IPAddress:     attempt.IPAddress,
+			UserAgent:     attempt.UserAgent,
+			CreatedAt:     attempt.CreatedAt,
+		}
+	})
+
+	if _, err := r.db.NamedExecContext(ctx, query, rows); err != nil {
+		return fmt.Errorf("failed to create login attempts: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser returns up to limit login attempts of the user before the cursor, newest first
+func (r *LoginAttemptRepository) ListByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	cursor models.LoginAttemptCursor,
+	limit int,
+) ([]*models.LoginAttempt, error) {
+	query := `
+		SELECT id, user_id, success, failure_reason, client_id, ip_address, user_agent, created_at
+		FROM login_attempts
+		WHERE user_id = $1
+			AND ($2 = 0 OR (created_at, id) < ($2, $3))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`
+
+	rows := make([]*LoginAttempt, 0, limit)
+	if err := r.db.SelectContext(ctx, &rows, query, userID, cursor.CreatedAt, cursor.ID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list login attempts: %w", err)
+	}
+
+	return lo.Map(rows, func(row *LoginAttempt, _ int) *models.LoginAttempt {
+		return row.ToDomain()
+	}), nil
+}
+
+// Purge deletes the login attempts made before the time in Unix milliseconds
+func (r *LoginAttemptRepository) Purge(ctx context.Context, before int64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM login_attempts WHERE created_at < $1`, before); err != nil {
+		return fmt.Errorf("failed to purge login attempts: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type LoginSchedule struct {
+	UserID    sql.NullString  `db:"user_id"`
+	Role      sql.NullString  `db:"role"`
+	Timezone  string          `db:"timezone"`
+	Windows   json.RawMessage `db:"windows"`
+	UpdatedAt int64           `db:"updated_at"`
+}
+
+func (s *LoginSchedule) ToDomain() (*models.LoginSchedule, error) {
+	schedule := &models.LoginSchedule{
+		Role:      models.UserRole(s.Role.String),
+		Timezone:  s.Timezone,
+		UpdatedAt: s.UpdatedAt,
+	}
+	if s.UserID.Valid {
+		userID, err := uuid.Parse(s.UserID.String)
+		if err != nil {
+			return nil, err
+		}
+		schedule.UserID = userID
+	}
+	if err := json.Unmarshal(s.Windows, &schedule.Windows); err != nil {
+		return nil, fmt.Errorf("failed to decode login windows: %w", err)
+	}
+	return schedule, nil
+}
+
+type LoginScheduleRepository struct {
+	db db.Store
+}
+
+func NewLoginScheduleRepository(db db.Store) *LoginScheduleRepository {
+	return &LoginScheduleRepository{
+		db: db,
+	}
+}
+
+// Upsert stores the schedule, replacing the earlier schedule of the same user or role
+func (r *LoginScheduleRepository) Upsert(ctx context.Context, schedule *models.LoginSchedule) error {
+	windows, err := json.Marshal(schedule.Windows)
+	if err != nil {
+		return err
+	}
+
+	conflict := "user_id"
+	if schedule.UserID == uuid.Nil {
+		conflict = "role"
+	}
+	query := fmt.Sprintf(`
+		INSERT INTO login_schedules (user_id, role, timezone, windows, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (%s) DO UPDATE
+		SET timezone = EXCLUDED.timezone, windows = EXCLUDED.windows, updated_at = EXCLUDED.updated_at
+	`, conflict)
+	userID, role := scheduleSubject(schedule.UserID, schedule.Role)
+	args := []interface{}{userID, role, schedule.Timezone, windows, schedule.UpdatedAt}
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upsert login schedule: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the schedule of the user, or of the role when userID is uuid.Nil
+func (r *LoginScheduleRepository) Get(ctx context.Context, userID uuid.UUID, role models.UserRole) (*models.LoginSchedule, error) {
+	query := `
+		SELECT user_id, role, timezone, windows, updated_at
+		FROM login_schedules
+		WHERE user_id = $1 OR role = $2
+	`
+	subjectUserID, subjectRole := scheduleSubject(userID, role)
+
+	var (
+		schedule LoginSchedule
+		err      error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &schedule, query, subjectUserID, subjectRole)
+	} else {
+		err = r.db.GetContext(ctx, &schedule, query, subjectUserID, subjectRole)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrLoginScheduleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login schedule: %w", err)
+	}
+
+	return schedule.ToDomain()
+}
+
+// ListForUser returns the schedules of the user and of their role
+func (r *LoginScheduleRepository) ListForUser(ctx context.Context, userID uuid.UUID, role models.UserRole) ([]*models.LoginSchedule, error) {
+	query := `
+		SELECT user_id, role, timezone, windows, updated_at
+		FROM login_schedules
+		WHERE user_id = $1 OR role = $2
+	`
+
+	var (
+		schedules []*LoginSchedule
+		err       error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.SelectContext(ctx, &schedules, query, userID, string(role))
+	} else {
+		err = r.db.SelectContext(ctx, &schedules, query, userID, string(role))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login schedules: %w", err)
+	}
+
+	result := make([]*models.LoginSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		converted, err := schedule.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+
+	return result, nil
+}
+
+// Delete deletes the schedule of the user, or of the role when userID is uuid.Nil, and
+// reports whether there was one
+func (r *LoginScheduleRepository) Delete(ctx context.Context, userID uuid.UUID, role models.UserRole) (bool, error) {
+	query := `DELETE FROM login_schedules WHERE user_id = $1 OR role = $2`
+	subjectUserID, subjectRole := scheduleSubject(userID, role)
+
+	var (
+		result sql.Result
+		err    error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		result, err = tx.ExecContext(ctx, query, subjectUserID, subjectRole)
+	} else {
+		result, err = r.db.ExecContext(ctx, query, subjectUserID, subjectRole)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to delete login schedule: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
+
+// scheduleSubject returns the user_id and role columns of the schedule of a user, or of a role
+// when userID is uuid.Nil
+func scheduleSubject(userID uuid.UUID, role models.UserRole) (sql.NullString, sql.NullString) {
+	if userID != uuid.Nil {
+		return sql.NullString{String: userID.String(), Valid: true}, sql.NullString{}
+	}
+	return sql.NullString{}, sql.NullString{String: string(role), Valid: true}
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+)
+
+type NotificationDelivery struct {
+	ID            uuid.UUID       `db:"id"`
+	EventID       string          `db:"event_id"`
+	EventType     string          `db:"event_type"`
+	UserID        uuid.UUID       `db:"user_id"`
+	Channel       string          `db:"channel"`
+	Status        string          `db:"status"`
+	Payload       json.RawMessage `db:"payload"`
+	Attempts      int             `db:"attempts"`
+	LastError     string          `db:"last_error"`
+	NextAttemptAt int64           `db:"next_attempt_at"`
+	CreatedAt     int64           `db:"created_at"`
+	UpdatedAt     int64           `db:"updated_at"`
+}
+
+func (d *NotificationDelivery) ToDomain() *models.NotificationDelivery {
+	return &models.NotificationDelivery{
+		ID:            d.ID,
+		EventID:       d.EventID,
+		EventType:     d.EventType,
+		UserID:        d.UserID,
+		Channel:       models.NotificationChannel(d.Channel),
+		Status:        models.DeliveryStatus(d.Status),
+		Payload:       d.Payload,
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+type NotificationDeliveryRepository struct {
+	db db.Store
+}
+
+func NewNotificationDeliveryRepository(db db.Store) *NotificationDeliveryRepository {
+	return &NotificationDeliveryRepository{
+		db: db,
+	}
+}
+
+// Create stores a new delivery and reports whether it was stored. A delivery of the same
+// event on the same channel is never stored twice, so replayed events are not resent.
+func (r *NotificationDeliveryRepository) Create(ctx context.Context, delivery *models.NotificationDelivery) (bool, error) {
+	query := `
+		INSERT INTO notification_deliveries (id, event_id, event_type, user_id, channel, status, payload, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (event_id, channel) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		delivery.ID, delivery.EventID, delivery.EventType, delivery.UserID,
+		delivery.Channel, delivery.Status, delivery.Payload, delivery.NextAttemptAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create notification delivery: %w", err)
+	}
+
+	created, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create notification delivery: %w", err)
+	}
+
+	return created > 0, nil
+}
+
+// UpdateStatus stores the outcome of a delivery attempt
+func (r *NotificationDeliveryRepository) UpdateStatus(ctx context.Context, delivery *models.NotificationDelivery) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status = $2, attempts = $3, last_error = $4, next_attempt_at = $5
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.LastError, delivery.NextAttemptAt,
+	); err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue returns up to limit deliveries whose next attempt is due and postpones them by
+// lease, so that concurrent replicas do not attempt the same delivery
+func (r *NotificationDeliveryRepository) ClaimDue(ctx context.Context, now, lease int64, limit int) ([]*models.NotificationDelivery, error) {
+	query := `
+		UPDATE notification_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM notification_deliveries
+			WHERE status IN ('pending', 'failed') AND next_attempt_at <= $1
+			ORDER BY next_attempt_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_id, event_type, user_id, channel, status, payload, attempts, last_error,
+			next_attempt_at, created_at, updated_at
+	`
+
+	deliveries := make([]*NotificationDelivery, 0)
+	if err := r.db.SelectContext(ctx, &deliveries, query, now, now+lease, limit); err != nil {
+		return nil, fmt.Errorf("failed to claim due notification deliveries: %w", err)
+	}
+
+	result := make([]*models.NotificationDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, delivery.ToDomain())
+	}
+
+	return result, nil
+}
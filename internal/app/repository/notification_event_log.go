@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"user-svc/internal/app/domains/models"
 	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/samber/lo"
 )
 
@@ -46,11 +48,34 @@ func NewNotificationEventLogRepository(store db.Store) *NotificationEventLogRepo
 }
 
 func (r *NotificationEventLogRepository) Create(ctx context.Context, event *NotificationEventLog) error {
-	_, err := r.store.ExecContext(
+	query := `INSERT INTO notification_event_logs (id, event_name, payload, status) 
+		VALUES ($1, $2, $3, $4) RETURNING id`
+	args := []interface{}{event.ID, event.EventName, event.Payload, event.Status}
+
+	// Check if we're in a transaction, so events can be written along with the change they announce
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err := tx.ExecContext(ctx, query, args...)
+		return err
+	}
+
+	_, err := r.store.ExecContext(ctx, query, args...)
+
+	return err
+}
+
+// CreateBatch inserts a batch of notification events in a single statement. Events whose
+// ID already exists are skipped, so producers can use deterministic IDs to publish at most once.
+func (r *NotificationEventLogRepository) CreateBatch(ctx context.Context, events []*NotificationEventLog) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := r.store.NamedExecContext(
 		ctx,
-		`INSERT INTO notification_event_logs (id, event_name, payload, status) 
-		VALUES ($1, $2, $3, $4) RETURNING id`,
-		event.ID, event.EventName, event.Payload, event.Status,
+		`INSERT INTO notification_event_logs (id, event_name, payload, status)
+		VALUES (:id, :event_name, :payload, :status)
+		ON CONFLICT (id) DO NOTHING`,
+		events,
 	)
 
 	return err
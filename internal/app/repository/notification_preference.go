@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationPreference struct {
+	UserID    uuid.UUID `db:"user_id"`
+	EventType string    `db:"event_type"`
+	Channel   string    `db:"channel"`
+	Enabled   bool      `db:"enabled"`
+	UpdatedAt int64     `db:"updated_at"`
+}
+
+func (p *NotificationPreference) ToDomain() *models.NotificationPreference {
+	return &models.NotificationPreference{
+		UserID:    p.UserID,
+		EventType: p.EventType,
+		Channel:   models.NotificationChannel(p.Channel),
+		Enabled:   p.Enabled,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+type NotificationPreferenceRepository struct {
+	db db.Store
+}
+
+func NewNotificationPreferenceRepository(db db.Store) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{
+		db: db,
+	}
+}
+
+// ListByUser returns the explicit notification preferences of a user
+func (r *NotificationPreferenceRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.NotificationPreference, error) {
+	query := `
+		SELECT user_id, event_type, channel, enabled, updated_at
+		FROM notification_preferences
+		WHERE user_id = $1
+		ORDER BY event_type, channel
+	`
+
+	var (
+		prefs []*NotificationPreference
+		err   error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.SelectContext(ctx, &prefs, query, userID)
+	} else {
+		err = r.db.SelectContext(ctx, &prefs, query, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	result := make([]*models.NotificationPreference, 0, len(prefs))
+	for _, pref := range prefs {
+		result = append(result, pref.ToDomain())
+	}
+
+	return result, nil
+}
+
+// Upsert stores the given preferences, replacing earlier choices for the same event type and channel
+func (r *NotificationPreferenceRepository) Upsert(ctx context.Context, prefs []*models.NotificationPreference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO notification_preferences (user_id, event_type, channel, enabled, updated_at)
+		VALUES (:user_id, :event_type, :channel, :enabled, :updated_at)
+		ON CONFLICT (user_id, event_type, channel) DO UPDATE
+		SET enabled = EXCLUDED.enabled, updated_at = EXCLUDED.updated_at
+	`
+
+	rows := make([]*NotificationPreference, 0, len(prefs))
+	for _, pref := range prefs {
+		rows = append(rows, &NotificationPreference{
+			UserID:    pref.UserID,
+			EventType: pref.EventType,
+			Channel:   string(pref.Channel),
+			Enabled:   pref.Enabled,
+			UpdatedAt: pref.UpdatedAt,
+		})
+	}
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.NamedExecContext(ctx, query, rows)
+	} else {
+		_, err = r.db.NamedExecContext(ctx, query, rows)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upsert notification preferences: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+)
+
+type OrgAuditWebhookRepository struct {
+	db db.Store
+}
+
+func NewOrgAuditWebhookRepository(db db.Store) *OrgAuditWebhookRepository {
+	return &OrgAuditWebhookRepository{
+		db: db,
+	}
+}
+
+// GetCursor returns the last entry delivered to the webhook of the organization, nil if
+// nothing was delivered yet
+func (r *OrgAuditWebhookRepository) GetCursor(ctx context.Context, organizationID uuid.UUID) (*models.AuditCursor, error) {
+	query := `
+		SELECT delivered_created_at, delivered_id
+		FROM org_audit_webhook_cursors
+		WHERE organization_id = $1
+	`
+
+	var row struct {
+		CreatedAt int64     `db:"delivered_created_at"`
+		ID        uuid.UUID `db:"delivered_id"`
+	}
+	if err := r.db.GetContext(ctx, &row, query, organizationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get audit webhook cursor: %w", err)
+	}
+
+	return &models.AuditCursor{CreatedAt: row.CreatedAt, ID: row.ID}, nil
+}
+
+// SaveCursor records the last entry delivered to the webhook of the organization
+func (r *OrgAuditWebhookRepository) SaveCursor(ctx context.Context, organizationID uuid.UUID, cursor models.AuditCursor) error {
+	query := `
+		INSERT INTO org_audit_webhook_cursors (organization_id, delivered_created_at, delivered_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (organization_id) DO UPDATE
+		SET delivered_created_at = EXCLUDED.delivered_created_at,
+			delivered_id = EXCLUDED.delivered_id,
+			updated_at = EXTRACT(EPOCH FROM NOW()) * 1000
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, organizationID, cursor.CreatedAt, cursor.ID); err != nil {
+		return fmt.Errorf("failed to save audit webhook cursor: %w", err)
+	}
+
+	return nil
+}
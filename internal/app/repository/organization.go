@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type Organization struct {
+	ID                   string         `db:"id"`
+	Name                 string         `db:"name"`
+	AllowedEmailDomains  pq.StringArray `db:"allowed_email_domains"`
+	BrandName            string         `db:"brand_name"`
+	BrandLogoURL         string         `db:"brand_logo_url"`
+	BrandSupportEmail    string         `db:"brand_support_email"`
+	BrandColor           string         `db:"brand_color"`
+	Residency            string         `db:"residency"`
+	SessionIdleTimeoutMs int64          `db:"session_idle_timeout_ms"`
+	CreatedAt            int64          `db:"created_at"`
+	UpdatedAt            int64          `db:"updated_at"`
+}
+
+func (o *Organization) ToDomain() *models.Organization {
+	return &models.Organization{
+		ID:                  uuid.MustParse(o.ID),
+		Name:                o.Name,
+		AllowedEmailDomains: []string(o.AllowedEmailDomains),
+		Branding: models.OrganizationBranding{
+			Name:         o.BrandName,
+			LogoURL:      o.BrandLogoURL,
+			SupportEmail: o.BrandSupportEmail,
+			Color:        o.BrandColor,
+		},
+		Residency:          o.Residency,
+		SessionIdleTimeout: time.Duration(o.SessionIdleTimeoutMs) * time.Millisecond,
+		CreatedAt:          o.CreatedAt,
+		UpdatedAt:          o.UpdatedAt,
+	}
+}
+
+// organizationColumns are the columns of Organization
+const organizationColumns = "id, name, allowed_email_domains, " +
+	"brand_name, brand_logo_url, brand_support_email, brand_color, residency, session_idle_timeout_ms, created_at, updated_at"
+
+type OrganizationRepository struct {
+	db db.Store
+}
+
+func NewOrganizationRepository(db db.Store) *OrganizationRepository {
+	return &OrganizationRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new organization
+func (r *OrganizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	query := `
+		INSERT INTO organizations (
+			id, name, allowed_email_domains, brand_name, brand_logo_url, brand_support_email, brand_color,
+			residency, session_idle_timeout_ms, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		org.ID, org.Name, pq.Array(org.AllowedEmailDomains),
+		org.Branding.Name, org.Branding.LogoURL, org.Branding.SupportEmail, org.Branding.Color,
+		org.Residency, org.SessionIdleTimeout.Milliseconds(), org.CreatedAt, org.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an organization by its ID
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	query := `
+		SELECT ` + organizationColumns + `
+		FROM organizations
+		WHERE id = $1
+	`
+
+	var (
+		org Organization
+		err error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &org, query, id)
+	} else {
+		err = r.db.GetContext(ctx, &org, query, id)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization by id: %w", err)
+	}
+
+	return org.ToDomain(), nil
+}
+
+// GetByName retrieves the oldest organization with a name, since names are not unique
+func (r *OrganizationRepository) GetByName(ctx context.Context, name string) (*models.Organization, error) {
+	query := `
+		SELECT ` + organizationColumns + `
+		FROM organizations
+		WHERE name = $1
+		ORDER BY created_at, id
+		LIMIT 1
+	`
+
+	var org Organization
+	if err := r.db.GetContext(ctx, &org, query, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization by name: %w", err)
+	}
+
+	return org.ToDomain(), nil
+}
+
+// SetAllowedEmailDomains replaces the email domains of an organization and returns the updated organization
+func (r *OrganizationRepository) SetAllowedEmailDomains(ctx context.Context, id uuid.UUID, domains []string) (*models.Organization, error) {
+	query := `
+		UPDATE organizations
+		SET allowed_email_domains = $2
+		WHERE id = $1
+		RETURNING ` + organizationColumns + `
+	`
+
+	var org Organization
+	if err := r.db.GetContext(ctx, &org, query, id, pq.Array(domains)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to set organization email domains: %w", err)
+	}
+
+	return org.ToDomain(), nil
+}
+
+// SetBranding replaces the email branding of an organization and returns the updated organization
+func (r *OrganizationRepository) SetBranding(ctx context.Context, id uuid.UUID, branding models.OrganizationBranding) (*models.Organization, error) {
+	query := `
+		UPDATE organizations
+		SET brand_name = $2, brand_logo_url = $3, brand_support_email = $4, brand_color = $5
+		WHERE id = $1
+		RETURNING ` + organizationColumns + `
+	`
+
+	var org Organization
+	if err := r.db.GetContext(ctx, &org, query,
+		id, branding.Name, branding.LogoURL, branding.SupportEmail, branding.Color,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to set organization branding: %w", err)
+	}
+
+	return org.ToDomain(), nil
+}
+
+// SetSessionIdleTimeout replaces the session idle timeout of an organization and returns the updated organization
+func (r *OrganizationRepository) SetSessionIdleTimeout(ctx context.Context, id uuid.UUID, timeout time.Duration) (*models.Organization, error) {
+	query := `
+		UPDATE organizations
+		SET session_idle_timeout_ms = $2
+		WHERE id = $1
+		RETURNING ` + organizationColumns + `
+	`
+
+	var org Organization
+	if err := r.db.GetContext(ctx, &org, query, id, timeout.Milliseconds()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to set organization session idle timeout: %w", err)
+	}
+
+	return org.ToDomain(), nil
+}
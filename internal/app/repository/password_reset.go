@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type PasswordResetTokenRepository struct {
+	db db.Store
+}
+
+func NewPasswordResetTokenRepository(db db.Store) *PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{
+		db: db,
+	}
+}
+
+// Create stores the reset token of a user, replacing an earlier one unless it is unexpired and
+// was created after sentBefore; it reports whether the token was stored
+func (r *PasswordResetTokenRepository) Create(ctx context.Context, resetToken *models.PasswordResetToken, sentBefore int64) (bool, error) {
+	query := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at
+		WHERE password_reset_tokens.created_at <= $5 OR password_reset_tokens.expires_at <= EXCLUDED.created_at
+	`
+
+	result, err := r.db.ExecContext(ctx, query, resetToken.UserID, resetToken.TokenHash, resetToken.ExpiresAt, resetToken.CreatedAt, sentBefore)
+	if err != nil {
+		return false, fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Consume deletes an unexpired reset token and returns the user it belongs to, so a token
+// can only be used once
+func (r *PasswordResetTokenRepository) Consume(ctx context.Context, tokenHash string, now int64) (uuid.UUID, error) {
+	query := `
+		DELETE FROM password_reset_tokens
+		WHERE token_hash = $1 AND expires_at > $2
+		RETURNING user_id
+	`
+
+	var userID uuid.UUID
+	var err error
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &userID, query, tokenHash, now)
+	} else {
+		err = r.db.GetContext(ctx, &userID, query, tokenHash, now)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errs.ErrInvalidPasswordReset
+		}
+		return uuid.Nil, fmt.Errorf("failed to consume password reset token: %w", err)
+	}
+
+	return userID, nil
+}
+
+// Delete deletes a reset token whose link could not be emailed
+func (r *PasswordResetTokenRepository) Delete(ctx context.Context, tokenHash string) error {
+	query := `DELETE FROM password_reset_tokens WHERE token_hash = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, tokenHash); err != nil {
+		return fmt.Errorf("failed to delete password reset token: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type PasswordSetupTokenRepository struct {
+	db db.Store
+}
+
+func NewPasswordSetupTokenRepository(db db.Store) *PasswordSetupTokenRepository {
+	return &PasswordSetupTokenRepository{
+		db: db,
+	}
+}
+
+// Create stores the setup token of a user, replacing any previous one
+func (r *PasswordSetupTokenRepository) Create(ctx context.Context, setupToken *models.PasswordSetupToken) error {
+	query := `
+		INSERT INTO password_setup_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at
+	`
+
+	args := []interface{}{setupToken.UserID, setupToken.TokenHash, setupToken.ExpiresAt, setupToken.CreatedAt}
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create password setup token: %w", err)
+	}
+
+	return nil
+}
+
+// Consume deletes an unexpired setup token and returns the user it belongs to, so a token
+// can only be used once
+func (r *PasswordSetupTokenRepository) Consume(ctx context.Context, tokenHash string, now int64) (uuid.UUID, error) {
+	query := `
+		DELETE FROM password_setup_tokens
+		WHERE token_hash = $1 AND expires_at > $2
+		RETURNING user_id
+	`
+
+	var userID uuid.UUID
+	var err error
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &userID, query, tokenHash, now)
+	} else {
+		err = r.db.GetContext(ctx, &userID, query, tokenHash, now)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errs.ErrInvalidPasswordSetup
+		}
+		return uuid.Nil, fmt.Errorf("failed to consume password setup token: %w", err)
+	}
+
+	return userID, nil
+}
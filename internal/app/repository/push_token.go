@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type PushToken struct {
+	UserID    uuid.UUID `db:"user_id"`
+	DeviceID  string    `db:"device_id"`
+	Platform  string    `db:"platform"`
+	Token     string    `db:"token"`
+	CreatedAt int64     `db:"created_at"`
+	UpdatedAt int64     `db:"updated_at"`
+}
+
+func (t *PushToken) ToDomain() *models.PushToken {
+	return &models.PushToken{
+		UserID:    t.UserID,
+		DeviceID:  t.DeviceID,
+		Platform:  models.PushPlatform(t.Platform),
+		Token:     t.Token,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+type PushTokenRepository struct {
+	db db.Store
+}
+
+func NewPushTokenRepository(db db.Store) *PushTokenRepository {
+	return &PushTokenRepository{
+		db: db,
+	}
+}
+
+// Upsert stores the token of a device, replacing the token the device registered before
+func (r *PushTokenRepository) Upsert(ctx context.Context, pushToken *models.PushToken) error {
+	query := `
+		INSERT INTO push_tokens (user_id, device_id, platform, token, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, device_id) DO UPDATE
+		SET platform = EXCLUDED.platform, token = EXCLUDED.token, updated_at = EXCLUDED.updated_at
+	`
+	args := []interface{}{
+		pushToken.UserID,
+		pushToken.DeviceID,
+		string(pushToken.Platform),
+		pushToken.Token,
+		pushToken.CreatedAt,
+		pushToken.UpdatedAt,
+	}
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upsert push token: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOtherDevices deletes the token from every device but the given one and returns what
+// was deleted
+func (r *PushTokenRepository) DeleteOtherDevices(ctx context.Context, pushToken *models.PushToken) ([]*models.PushToken, error) {
+	query := `
+		DELETE FROM push_tokens
+		WHERE platform = $1 AND token = $2 AND NOT (user_id = $3 AND device_id = $4)
+		RETURNING user_id, device_id, platform, token, created_at, updated_at
+	`
+
+	return r.deleteMany(ctx, query, string(pushToken.Platform), pushToken.Token, pushToken.UserID, pushToken.DeviceID)
+}
+
+// DeleteByUserID deletes every token of the user and returns what was deleted
+func (r *PushTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) ([]*models.PushToken, error) {
+	query := `
+		DELETE FROM push_tokens
+		WHERE user_id = $1
+		RETURNING user_id, device_id, platform, token, created_at, updated_at
+	`
+
+	return r.deleteMany(ctx, query, userID)
+}
+
+// Delete deletes the token of a device of the user and returns it, or nil if the device had none
+func (r *PushTokenRepository) Delete(ctx context.Context, userID uuid.UUID, deviceID string) (*models.PushToken, error) {
+	query := `
+		DELETE FROM push_tokens
+		WHERE user_id = $1 AND device_id = $2
+		RETURNING user_id, device_id, platform, token, created_at, updated_at
+	`
+
+	var (
+		pushToken PushToken
+		err       error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &pushToken, query, userID, deviceID)
+	} else {
+		err = r.db.GetContext(ctx, &pushToken, query, userID, deviceID)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete push token: %w", err)
+	}
+
+	return pushToken.ToDomain(), nil
+}
+
+func (r *PushTokenRepository) deleteMany(ctx context.Context, query string, args ...interface{}) ([]*models.PushToken, error) {
+	var (
+		pushTokens []*PushToken
+		err        error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.SelectContext(ctx, &pushTokens, query, args...)
+	} else {
+		err = r.db.SelectContext(ctx, &pushTokens, query, args...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete push tokens: %w", err)
+	}
+
+	result := make([]*models.PushToken, 0, len(pushTokens))
+	for _, pushToken := range pushTokens {
+		result = append(result, pushToken.ToDomain())
+	}
+
+	return result, nil
+}
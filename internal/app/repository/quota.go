@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/lib/pq"
+)
+
+type QuotaUsage struct {
+	Subject     string `db:"subject"`
+	Method      string `db:"method"`
+	WindowStart int64  `db:"window_start"`
+	Used        int64  `db:"used"`
+}
+
+func (u *QuotaUsage) ToDomain() *models.QuotaUsage {
+	return &models.QuotaUsage{
+		Subject:     u.Subject,
+		Method:      u.Method,
+		Used:        u.Used,
+		WindowStart: u.WindowStart,
+	}
+}
+
+type QuotaRepository struct {
+	db db.Store
+}
+
+func NewQuotaRepository(db db.Store) *QuotaRepository {
+	return &QuotaRepository{
+		db: db,
+	}
+}
+
+// Increment atomically adds one call to each of the subject's method buckets in
+// the given window and returns the updated counters keyed by method
+func (r *QuotaRepository) Increment(
+	ctx context.Context,
+	subject string,
+	methods []string,
+	windowStart int64,
+) (map[string]int64, error) {
+	query := `
+		INSERT INTO quota_usage (subject, method, window_start, used)
+		SELECT $1, method, $3, 1 FROM UNNEST($2::text[]) AS method
+		ON CONFLICT (subject, method, window_start)
+		DO UPDATE SET used = quota_usage.used + 1, updated_at = EXTRACT(EPOCH FROM NOW()) * 1000
+		RETURNING subject, method, window_start, used
+	`
+
+	rows := make([]*QuotaUsage, 0, len(methods))
+	if err := r.db.SelectContext(ctx, &rows, query, subject, pq.Array(methods), windowStart); err != nil {
+		return nil, fmt.Errorf("failed to increment quota usage: %w", err)
+	}
+
+	used := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		used[row.Method] = row.Used
+	}
+
+	return used, nil
+}
+
+// ListBySubject returns all of the subject's buckets in the given window
+func (r *QuotaRepository) ListBySubject(ctx context.Context, subject string, windowStart int64) ([]*models.QuotaUsage, error) {
+	query := `
+		SELECT subject, method, window_start, used
+		FROM quota_usage
+		WHERE subject = $1 AND window_start = $2
+		ORDER BY method ASC
+	`
+
+	rows := make([]*QuotaUsage, 0)
+	if err := r.db.SelectContext(ctx, &rows, query, subject, windowStart); err != nil {
+		return nil, fmt.Errorf("failed to list quota usage: %w", err)
+	}
+
+	usages := make([]*models.QuotaUsage, 0, len(rows))
+	for _, row := range rows {
+		usages = append(usages, row.ToDomain())
+	}
+
+	return usages, nil
+}
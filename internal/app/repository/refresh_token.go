@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
 	"user-svc/internal/app/domains/errs"
 	"user-svc/internal/app/domains/models"
@@ -15,24 +17,32 @@ import (
 )
 
 type RefreshToken struct {
-	ID        uuid.UUID `db:"id"`
-	UserID    uuid.UUID `db:"user_id"`
-	Token     string    `db:"token"`
-	ExpiresAt int64     `db:"expires_at"`
-	IsRevoked bool      `db:"is_revoked"`
-	CreatedAt int64     `db:"created_at"`
-	UpdatedAt int64     `db:"updated_at"`
+	ID         uuid.UUID `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	Token      string    `db:"token"`
+	ExpiresAt  int64     `db:"expires_at"`
+	IsRevoked  bool      `db:"is_revoked"`
+	ClientID   string    `db:"client_id"`
+	DPoPJKT    string    `db:"dpop_jkt"`
+	RememberMe bool      `db:"remember_me"`
+	SessionID  uuid.UUID `db:"session_id"`
+	CreatedAt  int64     `db:"created_at"`
+	UpdatedAt  int64     `db:"updated_at"`
 }
 
 func (rt *RefreshToken) ToDomain() *models.RefreshToken {
 	return &models.RefreshToken{
-		ID:        rt.ID,
-		UserID:    rt.UserID,
-		Token:     rt.Token,
-		ExpiresAt: rt.ExpiresAt,
-		IsRevoked: rt.IsRevoked,
-		CreatedAt: rt.CreatedAt,
-		UpdatedAt: rt.UpdatedAt,
+		ID:         rt.ID,
+		UserID:     rt.UserID,
+		Token:      rt.Token,
+		ExpiresAt:  rt.ExpiresAt,
+		IsRevoked:  rt.IsRevoked,
+		ClientID:   rt.ClientID,
+		DPoPJKT:    rt.DPoPJKT,
+		RememberMe: rt.RememberMe,
+		SessionID:  rt.SessionID,
+		CreatedAt:  rt.CreatedAt,
+		UpdatedAt:  rt.UpdatedAt,
 	}
 }
 
@@ -49,18 +59,22 @@ func NewRefreshTokenRepository(db db.Store) *RefreshTokenRepository {
 // Create creates a new refresh token
 func (r *RefreshTokenRepository) Create(ctx context.Context, refreshToken *models.RefreshToken) error {
 	query := `
-		INSERT INTO refresh_tokens (id, user_id, token, expires_at, is_revoked, created_at, updated_at)
-		VALUES (:id, :user_id, :token, :expires_at, :is_revoked, :created_at, :updated_at)
+		INSERT INTO refresh_tokens (id, user_id, token, expires_at, is_revoked, client_id, dpop_jkt, remember_me, session_id, created_at, updated_at)
+		VALUES (:id, :user_id, :token, :expires_at, :is_revoked, :client_id, :dpop_jkt, :remember_me, :session_id, :created_at, :updated_at)
 	`
 
 	repoRefreshToken := &RefreshToken{
-		ID:        refreshToken.ID,
-		UserID:    refreshToken.UserID,
-		Token:     refreshToken.Token,
-		ExpiresAt: refreshToken.ExpiresAt,
-		IsRevoked: refreshToken.IsRevoked,
-		CreatedAt: refreshToken.CreatedAt,
-		UpdatedAt: refreshToken.UpdatedAt,
+		ID:         refreshToken.ID,
+		UserID:     refreshToken.UserID,
+		Token:      refreshToken.Token,
+		ExpiresAt:  refreshToken.ExpiresAt,
+		IsRevoked:  refreshToken.IsRevoked,
+		ClientID:   refreshToken.ClientID,
+		DPoPJKT:    refreshToken.DPoPJKT,
+		RememberMe: refreshToken.RememberMe,
+		SessionID:  refreshToken.SessionID,
+		CreatedAt:  refreshToken.CreatedAt,
+		UpdatedAt:  refreshToken.UpdatedAt,
 	}
 
 	// Check if we're in a transaction
@@ -85,7 +99,7 @@ func (r *RefreshTokenRepository) Create(ctx context.Context, refreshToken *model
 // GetByTokenHash retrieves a refresh token by token hash
 func (r *RefreshTokenRepository) GetByToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
 	query := `
-		SELECT id, user_id, token, expires_at, is_revoked, created_at, updated_at
+		SELECT id, user_id, token, expires_at, is_revoked, client_id, dpop_jkt, remember_me, session_id, created_at, updated_at
 		FROM refresh_tokens 
 		WHERE token = $1
 	`
@@ -95,9 +109,9 @@ func (r *RefreshTokenRepository) GetByToken(ctx context.Context, tokenHash strin
 	// Check if we're in a transaction
 	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
 		// Use transaction
-		err := tx.QueryRowContext(ctx, query, tokenHash).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.Token, &refreshToken.ExpiresAt, &refreshToken.IsRevoked, &refreshToken.CreatedAt, &refreshToken.UpdatedAt)
+		err := tx.QueryRowContext(ctx, query, tokenHash).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.Token, &refreshToken.ExpiresAt, &refreshToken.IsRevoked, &refreshToken.ClientID, &refreshToken.DPoPJKT, &refreshToken.RememberMe, &refreshToken.SessionID, &refreshToken.CreatedAt, &refreshToken.UpdatedAt)
 		if err != nil {
-			if err == sql.ErrNoRows {
+			if errors.Is(err, sql.ErrNoRows) {
 				return nil, errs.ErrTokenNotFound
 			}
 			return nil, fmt.Errorf("failed to get refresh token by token: %w", err)
@@ -106,9 +120,9 @@ func (r *RefreshTokenRepository) GetByToken(ctx context.Context, tokenHash strin
 	}
 
 	// Use main database connection
-	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.Token, &refreshToken.ExpiresAt, &refreshToken.IsRevoked, &refreshToken.CreatedAt, &refreshToken.UpdatedAt)
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.Token, &refreshToken.ExpiresAt, &refreshToken.IsRevoked, &refreshToken.ClientID, &refreshToken.DPoPJKT, &refreshToken.RememberMe, &refreshToken.SessionID, &refreshToken.CreatedAt, &refreshToken.UpdatedAt)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errs.ErrTokenNotFound
 		}
 		return nil, fmt.Errorf("failed to get refresh token by token: %w", err)
@@ -116,3 +130,216 @@ func (r *RefreshTokenRepository) GetByToken(ctx context.Context, tokenHash strin
 
 	return refreshToken.ToDomain(), nil
 }
+
+// Revoke revokes a single refresh token and reports whether it was still active, so
+// concurrent uses of a rotated token cannot both succeed
+func (r *RefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET is_revoked = TRUE
+		WHERE id = $1 AND is_revoked = FALSE
+	`
+
+	var (
+		result sql.Result
+		err    error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		result, err = tx.ExecContext(ctx, query, id)
+	} else {
+		result, err = r.db.ExecContext(ctx, query, id)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	return revoked > 0, nil
+}
+
+// RevokeAllByUserID revokes every active refresh token of the user and returns how many were revoked
+func (r *RefreshTokenRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET is_revoked = TRUE
+		WHERE user_id = $1 AND is_revoked = FALSE
+	`
+
+	var (
+		result sql.Result
+		err    error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		result, err = tx.ExecContext(ctx, query, userID)
+	} else {
+		result, err = r.db.ExecContext(ctx, query, userID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// RevokeByClient revokes every active refresh token the user holds for the client and returns
+// how many were revoked
+func (r *RefreshTokenRepository) RevokeByClient(ctx context.Context, userID uuid.UUID, clientID string) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET is_revoked = TRUE
+		WHERE user_id = $1 AND client_id = $2 AND is_revoked = FALSE
+	`
+
+	var (
+		result sql.Result
+		err    error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		result, err = tx.ExecContext(ctx, query, userID, clientID)
+	} else {
+		result, err = r.db.ExecContext(ctx, query, userID, clientID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens of client: %w", err)
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens of client: %w", err)
+	}
+
+	return revoked, nil
+}
+
+type authorizedClient struct {
+	ClientID          string `db:"client_id"`
+	Platform          string `db:"platform"`
+	FirstAuthorizedAt int64  `db:"first_authorized_at"`
+	LastUsedAt        int64  `db:"last_used_at"`
+	ActiveSessions    int64  `db:"active_sessions"`
+}
+
+// ListAuthorizedClients returns the clients the user holds active refresh tokens for as of now,
+// in milliseconds, the most recently used first. Tokens issued before clients were registered
+// belong to no client and are left out. Only active tokens are read, through a partial index,
+// however many rotated and revoked tokens the user accumulated.
+func (r *RefreshTokenRepository) ListAuthorizedClients(ctx context.Context, userID uuid.UUID, now int64) ([]*models.AuthorizedClient, error) {
+	query := `
+		SELECT
+			rt.client_id,
+			COALESCE(c.platform, '') AS platform,
+			MIN(rt.created_at) AS first_authorized_at,
+			GREATEST(MAX(rt.created_at), COALESCE(MAX(s.last_used_at), 0)) AS last_used_at,
+			COUNT(DISTINCT COALESCE(rt.session_id, rt.id)) AS active_sessions
+		FROM refresh_tokens rt
+		LEFT JOIN clients c ON c.client_id = rt.client_id
+		LEFT JOIN refresh_sessions s ON s.session_id = rt.session_id
+		WHERE rt.user_id = $1 AND rt.client_id <> '' AND rt.is_revoked = FALSE AND rt.expires_at > $2
+		GROUP BY rt.client_id, c.platform
+		ORDER BY last_used_at DESC, rt.client_id
+	`
+
+	var rows []authorizedClient
+	if err := r.db.SelectContext(ctx, &rows, query, userID, now); err != nil {
+		return nil, fmt.Errorf("failed to list authorized clients: %w", err)
+	}
+
+	clients := make([]*models.AuthorizedClient, 0, len(rows))
+	for _, row := range rows {
+		clients = append(clients, &models.AuthorizedClient{
+			ClientID:          row.ClientID,
+			Platform:          models.ClientPlatform(row.Platform),
+			FirstAuthorizedAt: row.FirstAuthorizedAt,
+			LastUsedAt:        row.LastUsedAt,
+			ActiveSessions:    row.ActiveSessions,
+		})
+	}
+
+	return clients, nil
+}
+
+// RevokeIssuedBeforeBatch revokes up to limit active refresh tokens of any user created up to
+// cutoff and returns how many were revoked
+func (r *RefreshTokenRepository) RevokeIssuedBeforeBatch(ctx context.Context, cutoff int64, limit int) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET is_revoked = TRUE
+		WHERE id IN (
+			SELECT id FROM refresh_tokens
+			WHERE is_revoked = FALSE AND created_at <= $1
+			LIMIT $2
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, cutoff, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// DeleteBatch deletes up to limit refresh tokens selected by the cleanup and returns how
+// many were deleted. Revoked tokens count as revoked when last updated.
+func (r *RefreshTokenRepository) DeleteBatch(ctx context.Context, cleanup models.RefreshTokenCleanup, limit int) (int64, error) {
+	conditions := []string{"(expires_at < $1 OR (is_revoked = TRUE AND updated_at < $1))"}
+	if cleanup.RevokedOnly {
+		conditions = []string{"is_revoked = TRUE AND updated_at < $1"}
+	}
+	args := []interface{}{cleanup.Before, limit}
+	if cleanup.UserID != uuid.Nil {
+		conditions = append(conditions, "user_id = $3")
+		args = append(args, cleanup.UserID)
+	}
+
+	query := fmt.Sprintf(`
+		DELETE FROM refresh_tokens
+		WHERE id IN (
+			SELECT id FROM refresh_tokens
+			WHERE %s
+			LIMIT $2
+		)
+	`, strings.Join(conditions, " AND "))
+
+	var (
+		result sql.Result
+		err    error
+	)
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		result, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		result, err = r.db.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete refresh tokens: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete refresh tokens: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+)
+
+type RegistrationCode struct {
+	Email     string `db:"email"`
+	CodeHash  string `db:"code_hash"`
+	Attempts  int    `db:"attempts"`
+	ExpiresAt int64  `db:"expires_at"`
+	CreatedAt int64  `db:"created_at"`
+}
+
+func (c *RegistrationCode) ToDomain() *models.RegistrationCode {
+	return &models.RegistrationCode{
+		Email:     models.Email(c.Email),
+		CodeHash:  c.CodeHash,
+		Attempts:  c.Attempts,
+		ExpiresAt: c.ExpiresAt,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+type RegistrationCodeRepository struct {
+	db db.Store
+}
+
+func NewRegistrationCodeRepository(db db.Store) *RegistrationCodeRepository {
+	return &RegistrationCodeRepository{
+		db: db,
+	}
+}
+
+// Create stores the code of an email, replacing an earlier one unless it is unexpired and was
+// created after sentBefore; it reports whether the code was stored
+func (r *RegistrationCodeRepository) Create(ctx context.Context, code *models.RegistrationCode, sentBefore int64) (bool, error) {
+	query := `
+		INSERT INTO registration_codes (email, code_hash, attempts, expires_at, created_at)
+		VALUES ($1, $2, 0, $3, $4)
+		ON CONFLICT (email) DO UPDATE
+		SET code_hash = EXCLUDED.code_hash, attempts = 0, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at
+		WHERE registration_codes.created_at <= $5 OR registration_codes.expires_at <= EXCLUDED.created_at
+	`
+
+	result, err := r.db.ExecContext(ctx, query, code.Email.String(), code.CodeHash, code.ExpiresAt, code.CreatedAt, sentBefore)
+	if err != nil {
+		return false, fmt.Errorf("failed to create registration code: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create registration code: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Attempt counts an attempt at the unexpired code of an email and returns the code, unless
+// maxAttempts were made already. Attempts are counted before the code is compared, so
+// concurrent guesses cannot exceed the limit.
+func (r *RegistrationCodeRepository) Attempt(ctx context.Context, email models.Email, now int64, maxAttempts int) (*models.RegistrationCode, error) {
+	query := `
+		UPDATE registration_codes
+		SET attempts = attempts + 1
+		WHERE email = $1 AND expires_at > $2 AND attempts < $3
+		RETURNING email, code_hash, attempts, expires_at, created_at
+	`
+
+	var code RegistrationCode
+	if err := r.db.GetContext(ctx, &code, query, email.String(), now, maxAttempts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrInvalidRegistrationCode
+		}
+		return nil, fmt.Errorf("failed to attempt registration code: %w", err)
+	}
+
+	return code.ToDomain(), nil
+}
+
+// Delete removes the code of an email once its account is created
+func (r *RegistrationCodeRepository) Delete(ctx context.Context, email models.Email) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM registration_codes WHERE email = $1`, email.String()); err != nil {
+		return fmt.Errorf("failed to delete registration code: %w", err)
+	}
+	return nil
+}
+
+// Purge deletes the codes expired by end, so abandoned registrations leave nothing behind
+func (r *RegistrationCodeRepository) Purge(ctx context.Context, _, end time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM registration_codes WHERE expires_at <= $1`, end.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to purge expired registration codes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/lib/pq"
+)
+
+type RegistrationGates struct {
+	InviteCodeRequired bool           `db:"invite_code_required"`
+	InviteCodes        pq.StringArray `db:"invite_codes"`
+	DailyCap           int            `db:"daily_cap"`
+	AllowlistOnly      bool           `db:"allowlist_only"`
+	Allowlist          pq.StringArray `db:"allowlist"`
+	UpdatedBy          string         `db:"updated_by"`
+	UpdatedAt          int64          `db:"updated_at"`
+}
+
+func (g *RegistrationGates) ToDomain() *models.RegistrationGates {
+	return &models.RegistrationGates{
+		InviteCodeRequired: g.InviteCodeRequired,
+		InviteCodes:        []string(g.InviteCodes),
+		DailyCap:           g.DailyCap,
+		AllowlistOnly:      g.AllowlistOnly,
+		Allowlist:          []string(g.Allowlist),
+		UpdatedBy:          g.UpdatedBy,
+		UpdatedAt:          g.UpdatedAt,
+	}
+}
+
+type RegistrationGateRepository struct {
+	db db.Store
+}
+
+func NewRegistrationGateRepository(db db.Store) *RegistrationGateRepository {
+	return &RegistrationGateRepository{
+		db: db,
+	}
+}
+
+// Get returns the registration gates, all open until an admin first sets them
+func (r *RegistrationGateRepository) Get(ctx context.Context) (*models.RegistrationGates, error) {
+	query := `
+		SELECT invite_code_required, invite_codes, daily_cap, allowlist_only, allowlist, updated_by, updated_at
+		FROM registration_gates
+		WHERE id = 1
+	`
+
+	var gates RegistrationGates
+	if err := r.db.GetContext(ctx, &gates, query); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &models.RegistrationGates{}, nil
+		}
+		return nil, fmt.Errorf("failed to get registration gates: %w", err)
+	}
+
+	return gates.ToDomain(), nil
+}
+
+// Set replaces the registration gates
+func (r *RegistrationGateRepository) Set(ctx context.Context, gates *models.RegistrationGates) error {
+	query := `
+		INSERT INTO registration_gates (id, invite_code_required, invite_codes, daily_cap, allowlist_only, allowlist, updated_by, updated_at)
+		VALUES (1, $1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE
+		SET invite_code_required = EXCLUDED.invite_code_required, invite_codes = EXCLUDED.invite_codes,
+			daily_cap = EXCLUDED.daily_cap, allowlist_only = EXCLUDED.allowlist_only, allowlist = EXCLUDED.allowlist,
+			updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		gates.InviteCodeRequired, pq.Array(gates.InviteCodes), gates.DailyCap,
+		gates.AllowlistOnly, pq.Array(gates.Allowlist), gates.UpdatedBy, gates.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to set registration gates: %w", err)
+	}
+
+	return nil
+}
+
+// ReserveSignup counts a registration on its day, unless dailyCap registrations were counted
+// already; a dailyCap of 0 never refuses. It reports whether the registration was counted.
+func (r *RegistrationGateRepository) ReserveSignup(ctx context.Context, day time.Time, dailyCap int) (bool, error) {
+	query := `
+		INSERT INTO daily_signups (day, signups)
+		VALUES ($1, 1)
+		ON CONFLICT (day) DO UPDATE
+		SET signups = daily_signups.signups + 1
+		WHERE $2 = 0 OR daily_signups.signups < $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, day.UTC().Format(time.DateOnly), dailyCap)
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve signup: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve signup: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ReleaseSignup gives back the count of a registration that failed after ReserveSignup
+func (r *RegistrationGateRepository) ReleaseSignup(ctx context.Context, day time.Time) error {
+	query := `UPDATE daily_signups SET signups = signups - 1 WHERE day = $1 AND signups > 0`
+	if _, err := r.db.ExecContext(ctx, query, day.UTC().Format(time.DateOnly)); err != nil {
+		return fmt.Errorf("failed to release signup: %w", err)
+	}
+	return nil
+}
+
+// CountSignups returns the registrations counted on a day
+func (r *RegistrationGateRepository) CountSignups(ctx context.Context, day time.Time) (int, error) {
+	var signups int
+	err := r.db.GetContext(ctx, &signups, `SELECT signups FROM daily_signups WHERE day = $1`, day.UTC().Format(time.DateOnly))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to count signups: %w", err)
+	}
+	return signups, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/retry"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// withReadRetry runs an idempotent read, retrying transient database errors with
+// jittered backoff. Reads inside a transaction are never retried because a failed
+// statement aborts the whole transaction.
+func withReadRetry(ctx context.Context, policy retry.Policy, fn func(ctx context.Context) error) error {
+	if _, ok := tx.GetTxFromContext(ctx); ok {
+		return db.ClassifyError(fn(ctx))
+	}
+
+	return db.ClassifyError(retry.Do(ctx, policy, db.IsTransient, fn))
+}
+
+// RetryingUserRepository decorates UserRepository with retries for idempotent reads.
+// Writes are not retried and surface immediately with a classified error.
+type RetryingUserRepository struct {
+	next   *UserRepository
+	policy retry.Policy
+}
+
+func NewRetryingUserRepository(next *UserRepository, policy retry.Policy) *RetryingUserRepository {
+	return &RetryingUserRepository{
+		next:   next,
+		policy: policy,
+	}
+}
+
+func (r *RetryingUserRepository) Create(ctx context.Context, user *models.User) error {
+	return db.ClassifyError(r.next.Create(ctx, user))
+}
+
+func (r *RetryingUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	var user *models.User
+	err := withReadRetry(ctx, r.policy, func(ctx context.Context) error {
+		var err error
+		user, err = r.next.GetByID(ctx, id)
+		return err
+	})
+	return user, err
+}
+
+func (r *RetryingUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user *models.User
+	err := withReadRetry(ctx, r.policy, func(ctx context.Context) error {
+		var err error
+		user, err = r.next.GetByEmail(ctx, email)
+		return err
+	})
+	return user, err
+}
+
+func (r *RetryingUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return db.ClassifyError(r.next.Delete(ctx, id))
+}
+
+func (r *RetryingUserRepository) Activate(ctx context.Context, id uuid.UUID, passwordHash models.PasswordHash) error {
+	return db.ClassifyError(r.next.Activate(ctx, id, passwordHash))
+}
+
+func (r *RetryingUserRepository) SetPassword(ctx context.Context, id uuid.UUID, passwordHash models.PasswordHash) error {
+	return db.ClassifyError(r.next.SetPassword(ctx, id, passwordHash))
+}
+
+func (r *RetryingUserRepository) RecordLogin(ctx context.Context, id uuid.UUID, loginAt int64) error {
+	return db.ClassifyError(r.next.RecordLogin(ctx, id, loginAt))
+}
+
+func (r *RetryingUserRepository) UpgradePasswordHash(ctx context.Context, id uuid.UUID, previous, passwordHash models.PasswordHash) (bool, error) {
+	upgraded, err := r.next.UpgradePasswordHash(ctx, id, previous, passwordHash)
+	return upgraded, db.ClassifyError(err)
+}
+
+func (r *RetryingUserRepository) SetStatuses(ctx context.Context, ids []uuid.UUID, status models.UserStatus) (map[uuid.UUID]models.UserStatusChange, error) {
+	previous, err := r.next.SetStatuses(ctx, ids, status)
+	return previous, db.ClassifyError(err)
+}
+
+func (r *RetryingUserRepository) SetRoles(ctx context.Context, ids []uuid.UUID, role models.UserRole) (map[uuid.UUID]models.UserRole, error) {
+	previous, err := r.next.SetRoles(ctx, ids, role)
+	return previous, db.ClassifyError(err)
+}
+
+// RetryingRefreshTokenRepository decorates RefreshTokenRepository with retries for idempotent reads.
+// Writes are not retried and surface immediately with a classified error.
+type RetryingRefreshTokenRepository struct {
+	next   *RefreshTokenRepository
+	policy retry.Policy
+}
+
+func NewRetryingRefreshTokenRepository(next *RefreshTokenRepository, policy retry.Policy) *RetryingRefreshTokenRepository {
+	return &RetryingRefreshTokenRepository{
+		next:   next,
+		policy: policy,
+	}
+}
+
+func (r *RetryingRefreshTokenRepository) Create(ctx context.Context, refreshToken *models.RefreshToken) error {
+	return db.ClassifyError(r.next.Create(ctx, refreshToken))
+}
+
+func (r *RetryingRefreshTokenRepository) GetByToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+	var refreshToken *models.RefreshToken
+	err := withReadRetry(ctx, r.policy, func(ctx context.Context) error {
+		var err error
+		refreshToken, err = r.next.GetByToken(ctx, token)
+		return err
+	})
+	return refreshToken, err
+}
+
+func (r *RetryingRefreshTokenRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	revoked, err := r.next.RevokeAllByUserID(ctx, userID)
+	return revoked, db.ClassifyError(err)
+}
+
+func (r *RetryingRefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	revoked, err := r.next.Revoke(ctx, id)
+	return revoked, db.ClassifyError(err)
+}
+
+// RetryingClientRepository decorates ClientRepository with retries for idempotent reads.
+type RetryingClientRepository struct {
+	next   *ClientRepository
+	policy retry.Policy
+}
+
+func NewRetryingClientRepository(next *ClientRepository, policy retry.Policy) *RetryingClientRepository {
+	return &RetryingClientRepository{
+		next:   next,
+		policy: policy,
+	}
+}
+
+func (r *RetryingClientRepository) GetByID(ctx context.Context, clientID string) (*models.Client, error) {
+	var client *models.Client
+	err := withReadRetry(ctx, r.policy, func(ctx context.Context) error {
+		var err error
+		client, err = r.next.GetByID(ctx, clientID)
+		return err
+	})
+	return client, err
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// RiskRepository aggregates the fraud signals of users from the audit trail and session usage
+type RiskRepository struct {
+	db db.Store
+}
+
+func NewRiskRepository(db db.Store) *RiskRepository {
+	return &RiskRepository{
+		db: db,
+	}
+}
+
+// CountDevices returns the number of distinct user agents the user logged in from since since
+func (r *RiskRepository) CountDevices(ctx context.Context, userID uuid.UUID, since int64) (int64, error) {
+	query := `
+		SELECT COUNT(DISTINCT metadata->>'user_agent')
+		FROM audit_logs
+		WHERE user_id = $1 AND action = $2 AND created_at >= $3
+	`
+
+	var count int64
+	if err := r.db.GetContext(ctx, &count, query, userID, models.AuditActionUserLoggedIn, since); err != nil {
+		return 0, fmt.Errorf("failed to count devices: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountSessionAnomalies returns the number of sessions of the user with an anomaly since since
+func (r *RiskRepository) CountSessionAnomalies(ctx context.Context, userID uuid.UUID, since int64) (int64, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM refresh_sessions
+		WHERE user_id = $1 AND last_anomaly_at >= $2
+	`
+
+	var count int64
+	if err := r.db.GetContext(ctx, &count, query, userID, since); err != nil {
+		return 0, fmt.Errorf("failed to count session anomalies: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountRegistrationIPReuse returns the number of other users registered from the IP address
+// the user registered from
+func (r *RiskRepository) CountRegistrationIPReuse(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+		SELECT COUNT(DISTINCT other.user_id)
+		FROM audit_logs own
+		JOIN audit_logs other
+			ON other.action = $2
+			AND other.metadata->>'ip_address' = own.metadata->>'ip_address'
+			AND other.user_id <> own.user_id
+		WHERE own.user_id = $1 AND own.action = $2
+	`
+
+	var count int64
+	if err := r.db.GetContext(ctx, &count, query, userID, models.AuditActionUserRegistered); err != nil {
+		return 0, fmt.Errorf("failed to count registration IP reuse: %w", err)
+	}
+
+	return count, nil
+}
+
+// IsDisposableDomain reports whether any of the domains is listed in disposable_email_domains
+func (r *RiskRepository) IsDisposableDomain(ctx context.Context, domains []string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM disposable_email_domains WHERE domain = ANY($1))`
+
+	var disposable bool
+	if err := r.db.GetContext(ctx, &disposable, query, pq.Array(domains)); err != nil {
+		return false, fmt.Errorf("failed to check disposable email domain: %w", err)
+	}
+
+	return disposable, nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+)
+
+type SessionUsage struct {
+	SessionID       uuid.UUID `db:"session_id"`
+	UserID          uuid.UUID `db:"user_id"`
+	ClientID        string    `db:"client_id"`
+	StartedAt       int64     `db:"started_at"`
+	RefreshCount    int64     `db:"refresh_count"`
+	LastUsedAt      int64     `db:"last_used_at"`
+	LastIP          string    `db:"last_ip"`
+	LastCountry     string    `db:"last_country"`
+	LastActiveAt    int64     `db:"last_active_at"`
+	WindowStart     int64     `db:"window_start"`
+	WindowRefreshes int64     `db:"window_refreshes"`
+	AnomalyCount    int64     `db:"anomaly_count"`
+	LastAnomaly     string    `db:"last_anomaly"`
+	LastAnomalyAt   int64     `db:"last_anomaly_at"`
+}
+
+func (u *SessionUsage) ToDomain() *models.SessionUsage {
+	return &models.SessionUsage{
+		SessionID:       u.SessionID,
+		UserID:          u.UserID,
+		ClientID:        u.ClientID,
+		StartedAt:       u.StartedAt,
+		RefreshCount:    u.RefreshCount,
+		LastUsedAt:      u.LastUsedAt,
+		LastIP:          u.LastIP,
+		LastCountry:     u.LastCountry,
+		LastActiveAt:    u.LastActiveAt,
+		WindowStart:     u.WindowStart,
+		WindowRefreshes: u.WindowRefreshes,
+		AnomalyCount:    u.AnomalyCount,
+		LastAnomaly:     models.SessionAnomalyKind(u.LastAnomaly),
+		LastAnomalyAt:   u.LastAnomalyAt,
+	}
+}
+
+type SessionUsageRepository struct {
+	db db.Store
+}
+
+func NewSessionUsageRepository(db db.Store) *SessionUsageRepository {
+	return &SessionUsageRepository{
+		db: db,
+	}
+}
+
+// Get returns the usage of a session
+func (r *SessionUsageRepository) Get(ctx context.Context, sessionID uuid.UUID) (*models.SessionUsage, error) {
+	query := `
+		SELECT session_id, user_id, client_id, started_at, refresh_count, last_used_at, last_ip,
+			last_country, last_active_at, window_start, window_refreshes, anomaly_count, last_anomaly,
+			last_anomaly_at
+		FROM refresh_sessions
+		WHERE session_id = $1
+	`
+
+	var usage SessionUsage
+	if err := r.db.GetContext(ctx, &usage, query, sessionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrSessionUsageNotFound
+		}
+		return nil, fmt.Errorf("failed to get session usage: %w", err)
+	}
+
+	return usage.ToDomain(), nil
+}
+
+// Upsert stores the usage of a session, but for its last activity, which only Touch moves
+func (r *SessionUsageRepository) Upsert(ctx context.Context, usage *models.SessionUsage) error {
+	query := `
+		INSERT INTO refresh_sessions (session_id, user_id, client_id, started_at, refresh_count,
+			last_used_at, last_ip, last_country, window_start, window_refreshes, anomaly_count,
+			last_anomaly, last_anomaly_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (session_id) DO UPDATE
+		SET refresh_count = EXCLUDED.refresh_count,
+			last_used_at = EXCLUDED.last_used_at,
+			last_ip = EXCLUDED.last_ip,
+			last_country = EXCLUDED.last_country,
+			window_start = EXCLUDED.window_start,
+			window_refreshes = EXCLUDED.window_refreshes,
+			anomaly_count = EXCLUDED.anomaly_count,
+			last_anomaly = EXCLUDED.last_anomaly,
+			last_anomaly_at = EXCLUDED.last_anomaly_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		usage.SessionID,
+		usage.UserID,
+		usage.ClientID,
+		usage.StartedAt,
+		usage.RefreshCount,
+		usage.LastUsedAt,
+		usage.LastIP,
+		usage.LastCountry,
+		usage.WindowStart,
+		usage.WindowRefreshes,
+		usage.AnomalyCount,
+		string(usage.LastAnomaly),
+		usage.LastAnomalyAt,
+	); err != nil {
+		return fmt.Errorf("failed to upsert session usage: %w", err)
+	}
+
+	return nil
+}
+
+// Touch records an activity of a session at a time, unless a later one is recorded. Sessions
+// without usage are left alone; they are tracked from their next refresh.
+func (r *SessionUsageRepository) Touch(ctx context.Context, sessionID uuid.UUID, at int64) error {
+	query := `
+		UPDATE refresh_sessions
+		SET last_active_at = $2
+		WHERE session_id = $1 AND last_active_at < $2
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, sessionID, at); err != nil {
+		return fmt.Errorf("failed to touch session usage: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/db"
+)
+
+type SigningKeyRepository struct {
+	db db.Store
+}
+
+func NewSigningKeyRepository(db db.Store) *SigningKeyRepository {
+	return &SigningKeyRepository{
+		db: db,
+	}
+}
+
+// GetPrimary returns the ID of the promoted JWT signing key, or "" if none was promoted yet
+func (r *SigningKeyRepository) GetPrimary(ctx context.Context) (string, error) {
+	query := `SELECT primary_key_id FROM jwt_signing_key WHERE id = 1`
+
+	var keyID string
+	if err := r.db.GetContext(ctx, &keyID, query); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get primary signing key: %w", err)
+	}
+
+	return keyID, nil
+}
+
+// SetPrimary records the promoted JWT signing key
+func (r *SigningKeyRepository) SetPrimary(ctx context.Context, keyID string, promotedAt int64) error {
+	query := `
+		INSERT INTO jwt_signing_key (id, primary_key_id, promoted_at)
+		VALUES (1, $1, $2)
+		ON CONFLICT (id) DO UPDATE
+		SET primary_key_id = EXCLUDED.primary_key_id, promoted_at = EXCLUDED.promoted_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, keyID, promotedAt); err != nil {
+		return fmt.Errorf("failed to set primary signing key: %w", err)
+	}
+
+	return nil
+}
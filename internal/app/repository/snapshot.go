@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SnapshotRepository reads and restores whole snapshot tables as JSON rows, so snapshots
+// carry every column without mapping them. Run it in a transaction to get a consistent view.
+type SnapshotRepository struct {
+	db db.Store
+}
+
+func NewSnapshotRepository(db db.Store) *SnapshotRepository {
+	return &SnapshotRepository{
+		db: db,
+	}
+}
+
+// ScanTable hands every row of a snapshot table to fn as a JSON object, stopping at the first error
+func (r *SnapshotRepository) ScanTable(ctx context.Context, table string, fn func(row json.RawMessage) error) error {
+	if !slices.Contains(models.SnapshotTables, table) {
+		return fmt.Errorf("%s is not a snapshot table", table)
+	}
+	query := fmt.Sprintf(`SELECT row_to_json(t)::text FROM %s t`, table)
+
+	var rows *sql.Rows
+	var err error
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		rows, err = tx.QueryContext(ctx, query)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return fmt.Errorf("failed to scan %s row: %w", table, err)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	return nil
+}
+
+// InsertRows inserts JSON rows of ScanTable into a snapshot table and returns the number
+// inserted; rows whose key exists already are skipped
+func (r *SnapshotRepository) InsertRows(ctx context.Context, table string, rows []json.RawMessage) (int64, error) {
+	if !slices.Contains(models.SnapshotTables, table) {
+		return 0, fmt.Errorf("%s is not a snapshot table", table)
+	}
+	query := fmt.Sprintf(`
+		INSERT INTO %s
+		SELECT * FROM json_populate_recordset(NULL::%s, $1::json)
+		ON CONFLICT DO NOTHING
+	`, table, table)
+
+	payload, err := json.Marshal(rows)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s rows: %w", table, err)
+	}
+
+	var result sql.Result
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		result, err = tx.ExecContext(ctx, query, string(payload))
+	} else {
+		result, err = r.db.ExecContext(ctx, query, string(payload))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert %s rows: %w", table, err)
+	}
+
+	return result.RowsAffected()
+}
+
+// HasUsers reports whether any user exists
+func (r *SnapshotRepository) HasUsers(ctx context.Context) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM users)`
+
+	var exists bool
+	var err error
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &exists, query)
+	} else {
+		err = r.db.GetContext(ctx, &exists, query)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check for users: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/samber/lo"
+)
+
+// dayMillis buckets millisecond timestamps by UTC day
+const dayMillis = int64(models.Day / time.Millisecond)
+
+type DailyCount struct {
+	Day   int64 `db:"day"`
+	Count int64 `db:"count"`
+}
+
+func (c *DailyCount) ToDomain() *models.DailyCount {
+	return &models.DailyCount{
+		Day:   c.Day,
+		Count: c.Count,
+	}
+}
+
+// StatsRepository computes aggregate statistics over users, sessions and the audit trail
+type StatsRepository struct {
+	db db.Store
+}
+
+func NewStatsRepository(db db.Store) *StatsRepository {
+	return &StatsRepository{
+		db: db,
+	}
+}
+
+// CountUsers returns the number of registered users
+func (r *StatsRepository) CountUsers(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM users`); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountActiveSessions returns the number of unrevoked refresh tokens that have not expired
+// at now, and the number of distinct users holding one
+func (r *StatsRepository) CountActiveSessions(ctx context.Context, now int64) (int64, int64, error) {
+	query := `
+		SELECT COUNT(*) AS sessions, COUNT(DISTINCT user_id) AS users
+		FROM refresh_tokens
+		WHERE is_revoked = FALSE AND expires_at > $1
+	`
+
+	var counts struct {
+		Sessions int64 `db:"sessions"`
+		Users    int64 `db:"users"`
+	}
+	if err := r.db.GetContext(ctx, &counts, query, now); err != nil {
+		return 0, 0, fmt.Errorf("failed to count active sessions: %w", err)
+	}
+
+	return counts.Sessions, counts.Users, nil
+}
+
+// CountRegistrationsByDay returns the number of users created per UTC day in [from, to)
+func (r *StatsRepository) CountRegistrationsByDay(ctx context.Context, from, to int64) ([]*models.DailyCount, error) {
+	query := `
+		SELECT (created_at / $3) * $3 AS day, COUNT(*) AS count
+		FROM users
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY day
+	`
+
+	rows := make([]*DailyCount, 0)
+	if err := r.db.SelectContext(ctx, &rows, query, from, to, dayMillis); err != nil {
+		return nil, fmt.Errorf("failed to count registrations: %w", err)
+	}
+
+	return lo.Map(rows, func(row *DailyCount, _ int) *models.DailyCount {
+		return row.ToDomain()
+	}), nil
+}
+
+// CountLoginsByDay returns the number of logins and of distinct users logging in per UTC day in [from, to)
+func (r *StatsRepository) CountLoginsByDay(ctx context.Context, from, to int64) ([]*models.DailyCount, []*models.DailyCount, error) {
+	query := `
+		SELECT (created_at / $4) * $4 AS day, COUNT(*) AS logins, COUNT(DISTINCT user_id) AS users
+		FROM audit_logs
+		WHERE action = $1 AND created_at >= $2 AND created_at < $3
+		GROUP BY day
+	`
+
+	rows := make([]struct {
+		Day    int64 `db:"day"`
+		Logins int64 `db:"logins"`
+		Users  int64 `db:"users"`
+	}, 0)
+	if err := r.db.SelectContext(ctx, &rows, query, models.AuditActionUserLoggedIn, from, to, dayMillis); err != nil {
+		return nil, nil, fmt.Errorf("failed to count logins: %w", err)
+	}
+
+	logins := make([]*models.DailyCount, 0, len(rows))
+	users := make([]*models.DailyCount, 0, len(rows))
+	for _, row := range rows {
+		logins = append(logins, &models.DailyCount{Day: row.Day, Count: row.Logins})
+		users = append(users, &models.DailyCount{Day: row.Day, Count: row.Users})
+	}
+
+	return logins, users, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+)
+
+type TokenRevocation struct {
+	Kind      string `db:"kind"`
+	Subject   string `db:"subject"`
+	RevokedAt int64  `db:"revoked_at"`
+	ExpiresAt int64  `db:"expires_at"`
+}
+
+func (r *TokenRevocation) ToDomain() *models.TokenRevocation {
+	return &models.TokenRevocation{
+		Kind:      models.TokenRevocationKind(r.Kind),
+		Subject:   r.Subject,
+		RevokedAt: r.RevokedAt,
+		ExpiresAt: r.ExpiresAt,
+	}
+}
+
+type TokenRevocationRepository struct {
+	db db.Store
+}
+
+func NewTokenRevocationRepository(db db.Store) *TokenRevocationRepository {
+	return &TokenRevocationRepository{
+		db: db,
+	}
+}
+
+// Upsert stores a revocation, keeping the latest revocation time per subject
+func (r *TokenRevocationRepository) Upsert(ctx context.Context, revocation *models.TokenRevocation) error {
+	query := `
+		INSERT INTO token_revocations (kind, subject, revoked_at, expires_at)
+		VALUES (:kind, :subject, :revoked_at, :expires_at)
+		ON CONFLICT (kind, subject) DO UPDATE
+		SET revoked_at = GREATEST(token_revocations.revoked_at, EXCLUDED.revoked_at),
+			expires_at = GREATEST(token_revocations.expires_at, EXCLUDED.expires_at)
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, &TokenRevocation{
+		Kind:      string(revocation.Kind),
+		Subject:   revocation.Subject,
+		RevokedAt: revocation.RevokedAt,
+		ExpiresAt: revocation.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert token revocation: %w", err)
+	}
+
+	return nil
+}
+
+// ListActive returns the revocations that have not expired at the given time
+func (r *TokenRevocationRepository) ListActive(ctx context.Context, now int64) ([]*models.TokenRevocation, error) {
+	query := `
+		SELECT kind, subject, revoked_at, expires_at
+		FROM token_revocations
+		WHERE expires_at > $1
+	`
+
+	rows := make([]*TokenRevocation, 0)
+	if err := r.db.SelectContext(ctx, &rows, query, now); err != nil {
+		return nil, fmt.Errorf("failed to list token revocations: %w", err)
+	}
+
+	revocations := make([]*models.TokenRevocation, 0, len(rows))
+	for _, row := range rows {
+		revocations = append(revocations, row.ToDomain())
+	}
+
+	return revocations, nil
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"user-svc/internal/app/domains/errs"
@@ -12,6 +14,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
 )
 
 // User domain model
@@ -20,8 +24,15 @@ type User struct {
 	Email        string `db:"email"`
 	Username     string `db:"username"`
 	PasswordHash string `db:"password_hash"`
-	CreatedAt    int64  `db:"created_at"`
-	UpdatedAt    int64  `db:"updated_at"`
+	Status       string `db:"status"`
+	Role         string `db:"role"`
+	// OrganizationID is NULL for users outside any organization
+	OrganizationID sql.NullString `db:"organization_id"`
+	Residency      string         `db:"residency"`
+	CreatedAt      int64          `db:"created_at"`
+	UpdatedAt      int64          `db:"updated_at"`
+	LastLoginAt    int64          `db:"last_login_at"`
+	InactiveSince  int64          `db:"inactive_since"`
 }
 
 func (u *User) ToDomain() *models.User {
@@ -44,13 +55,24 @@ func (u *User) ToDomain() *models.User {
 		id = uuid.Nil
 	}
 
+	organizationID := uuid.Nil
+	if u.OrganizationID.Valid {
+		organizationID, _ = uuid.Parse(u.OrganizationID.String)
+	}
+
 	return &models.User{
-		ID:           id,
-		Email:        email,
-		Username:     username,
-		PasswordHash: models.PasswordHash(u.PasswordHash),
-		CreatedAt:    u.CreatedAt,
-		UpdatedAt:    u.UpdatedAt,
+		ID:             id,
+		Email:          email,
+		Username:       username,
+		PasswordHash:   models.PasswordHash(u.PasswordHash),
+		Status:         models.UserStatus(u.Status),
+		Role:           models.UserRole(u.Role),
+		OrganizationID: organizationID,
+		Residency:      u.Residency,
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
+		LastLoginAt:    u.LastLoginAt,
+		InactiveSince:  u.InactiveSince,
 	}
 }
 
@@ -66,8 +88,8 @@ func NewUserRepository(db db.Store) *UserRepository {
 
 func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 	query := `
-		INSERT INTO users (id, email, username, password_hash, created_at, updated_at)
-		VALUES (:id, :email, :username, :password_hash, :created_at, :updated_at)
+		INSERT INTO users (id, email, username, password_hash, status, role, organization_id, residency, created_at, updated_at)
+		VALUES (:id, :email, :username, :password_hash, :status, :role, :organization_id, :residency, :created_at, :updated_at)
 	`
 
 	// Convert domain user to repository user
@@ -76,8 +98,15 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 		Email:        user.Email.String(),
 		Username:     user.Username.String(),
 		PasswordHash: user.PasswordHash.String(),
-		CreatedAt:    user.CreatedAt,
-		UpdatedAt:    user.UpdatedAt,
+		Status:       string(user.Status),
+		Role:         string(user.Role),
+		OrganizationID: sql.NullString{
+			String: user.OrganizationID.String(),
+			Valid:  user.OrganizationID != uuid.Nil,
+		},
+		Residency: user.Residency,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
 	}
 
 	// Check if we're in a transaction
@@ -85,6 +114,9 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 		// Use transaction
 		_, err := tx.NamedExecContext(ctx, query, repoUser)
 		if err != nil {
+			if db.IsUniqueViolation(err) {
+				return errs.ErrUserExists.Wrap(err)
+			}
 			return fmt.Errorf("failed to create user: %w", err)
 		}
 		return nil
@@ -93,6 +125,9 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 	// Use main database connection
 	_, err := r.db.NamedExecContext(ctx, query, repoUser)
 	if err != nil {
+		if db.IsUniqueViolation(err) {
+			return errs.ErrUserExists.Wrap(err)
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -101,7 +136,8 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	query := `
-		SELECT id, email, username, password_hash, created_at, updated_at
+		SELECT id, email, username, password_hash, status, role, organization_id, residency, created_at, updated_at,
+			last_login_at, inactive_since
 		FROM users 
 		WHERE id = $1
 	`
@@ -113,7 +149,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 		// Use transaction
 		err := tx.GetContext(ctx, &user, query, id.String())
 		if err != nil {
-			if err == sql.ErrNoRows {
+			if errors.Is(err, sql.ErrNoRows) {
 				return nil, errs.ErrUserNotFound
 			}
 			return nil, fmt.Errorf("failed to get user by ID: %w", err)
@@ -124,7 +160,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 	// Use main database connection
 	err := r.db.GetContext(ctx, &user, query, id.String())
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errs.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
@@ -135,7 +171,8 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, email, username, password_hash, created_at, updated_at
+		SELECT id, email, username, password_hash, status, role, organization_id, residency, created_at, updated_at,
+			last_login_at, inactive_since
 		FROM users 
 		WHERE email = $1
 	`
@@ -146,7 +183,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 		// Use transaction
 		err := tx.GetContext(ctx, &user, query, email)
 		if err != nil {
-			if err == sql.ErrNoRows {
+			if errors.Is(err, sql.ErrNoRows) {
 				return nil, errs.ErrUserNotFound
 			}
 			return nil, fmt.Errorf("failed to get user by email: %w", err)
@@ -158,7 +195,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 	// Use main database connection
 	err := r.db.GetContext(ctx, &user, query, email)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errs.ErrUserNotFound
 		}
 
@@ -168,8 +205,58 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 	return user.ToDomain(), nil
 }
 
+// GetByUsername retrieves the user with a username. Usernames are not unique, so
+// ErrUsernameNotUnique is returned when more than one user has it.
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	query := `
+		SELECT id, email, username, password_hash, status, role, organization_id, residency, created_at, updated_at,
+			last_login_at, inactive_since
+		FROM users
+		WHERE username = $1
+		LIMIT 2
+	`
+
+	var users []User
+	if err := r.db.SelectContext(ctx, &users, query, username); err != nil {
+		return nil, fmt.Errorf("failed to get user by username: %w", err)
+	}
+
+	switch len(users) {
+	case 0:
+		return nil, errs.ErrUserNotFound
+	case 1:
+		return users[0].ToDomain(), nil
+	default:
+		return nil, errs.ErrUsernameNotUnique
+	}
+}
+
+// GetByIDs returns the users with the given IDs, keyed by ID; IDs of users that do not exist
+// are left out
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.User, error) {
+	query := `
+		SELECT id, email, username, password_hash, status, role, organization_id, residency, created_at, updated_at,
+			last_login_at, inactive_since
+		FROM users
+		WHERE id = ANY($1::uuid[])
+	`
+
+	var rows []User
+	if err := r.db.SelectContext(ctx, &rows, query, uuidArray(ids)); err != nil {
+		return nil, fmt.Errorf("failed to get users by ids: %w", err)
+	}
+
+	users := make(map[uuid.UUID]*models.User, len(rows))
+	for _, row := range rows {
+		user := row.ToDomain()
+		users[user.ID] = user
+	}
+	return users, nil
+}
+
+// Delete removes a user. Users under legal hold are kept and ErrUserUnderLegalHold is returned.
 func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM users WHERE id = $1`
+	query := `DELETE FROM users WHERE id = $1 AND NOT legal_hold`
 
 	var result sql.Result
 	var err error
@@ -193,8 +280,505 @@ func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if rowsAffected == 0 {
+		held, err := r.isUnderLegalHold(ctx, id)
+		if err != nil {
+			return err
+		}
+		if held {
+			return errs.ErrUserUnderLegalHold
+		}
 		return errs.ErrUserNotFound
 	}
 
 	return nil
 }
+
+// SetLegalHold places a user under legal hold or releases it, and returns whether the user
+// was under hold before
+func (r *UserRepository) SetLegalHold(ctx context.Context, id uuid.UUID, hold bool) (bool, error) {
+	query := `
+		UPDATE users u
+		SET legal_hold = $2
+		FROM (SELECT id, legal_hold FROM users WHERE id = $1 FOR UPDATE) previous
+		WHERE u.id = previous.id
+		RETURNING previous.legal_hold
+	`
+
+	var wasHeld bool
+	var err error
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &wasHeld, query, id.String(), hold)
+	} else {
+		err = r.db.GetContext(ctx, &wasHeld, query, id.String(), hold)
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, errs.ErrUserNotFound
+		}
+		return false, fmt.Errorf("failed to set legal hold: %w", err)
+	}
+
+	return wasHeld, nil
+}
+
+// GetMetadataForUpdate returns the metadata of a user and, in a transaction, locks the user
+// until the transaction ends
+func (r *UserRepository) GetMetadataForUpdate(ctx context.Context, id uuid.UUID) (models.UserMetadata, error) {
+	query := `SELECT metadata FROM users WHERE id = $1 FOR UPDATE`
+
+	var raw []byte
+	var err error
+
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &raw, query, id.String())
+	} else {
+		err = r.db.GetContext(ctx, &raw, query, id.String())
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user metadata: %w", err)
+	}
+
+	metadata := models.UserMetadata{}
+	if err := json.Unmarshal(raw, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode user metadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// SetMetadata replaces the metadata of a user
+func (r *UserRepository) SetMetadata(ctx context.Context, id uuid.UUID, metadata models.UserMetadata) error {
+	query := `UPDATE users SET metadata = $2::jsonb WHERE id = $1`
+
+	var err error
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, id.String(), string(metadata.JSON()))
+	} else {
+		_, err = r.db.ExecContext(ctx, query, id.String(), string(metadata.JSON()))
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to set user metadata: %w", err)
+	}
+
+	return nil
+}
+
+// SetAvatarKey stores the object key of a user's avatar and returns the key it replaced,
+// empty if the user had no avatar
+func (r *UserRepository) SetAvatarKey(ctx context.Context, id uuid.UUID, key string) (string, error) {
+	query := `
+		UPDATE users u
+		SET avatar_key = $2
+		FROM (SELECT id, avatar_key FROM users WHERE id = $1 FOR UPDATE) previous
+		WHERE u.id = previous.id
+		RETURNING previous.avatar_key
+	`
+
+	var previous string
+	var err error
+
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &previous, query, id.String(), key)
+	} else {
+		err = r.db.GetContext(ctx, &previous, query, id.String(), key)
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to set avatar key: %w", err)
+	}
+
+	return previous, nil
+}
+
+// previousValue is a user changed by a batch update and the value the column had before and after
+type previousValue struct {
+	ID       uuid.UUID `db:"id"`
+	Previous string    `db:"previous"`
+	Current  string    `db:"current"`
+}
+
+// SetStatuses sets the status of the users with the given IDs and returns the status change
+// of every user found. Unbanned users that never set a password go back to invited.
+func (r *UserRepository) SetStatuses(ctx context.Context, ids []uuid.UUID, status models.UserStatus) (map[uuid.UUID]models.UserStatusChange, error) {
+	query := `
+		UPDATE users u
+		SET status = CASE WHEN $2::text = 'active' AND u.password_hash = '' THEN 'invited' ELSE $2::text END
+		FROM (SELECT id, status FROM users WHERE id = ANY($1::uuid[]) ORDER BY id FOR UPDATE) previous
+		WHERE u.id = previous.id
+		RETURNING u.id, previous.status AS previous, u.status AS current
+	`
+
+	rows, err := r.batchUpdate(ctx, query, uuidArray(ids), string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set user statuses: %w", err)
+	}
+
+	changes := make(map[uuid.UUID]models.UserStatusChange, len(rows))
+	for _, row := range rows {
+		changes[row.ID] = models.UserStatusChange{
+			Previous: models.UserStatus(row.Previous),
+			Current:  models.UserStatus(row.Current),
+		}
+	}
+	return changes, nil
+}
+
+// SetRoles sets the role of the users with the given IDs and returns the previous role of
+// every user found
+func (r *UserRepository) SetRoles(ctx context.Context, ids []uuid.UUID, role models.UserRole) (map[uuid.UUID]models.UserRole, error) {
+	query := `
+		UPDATE users u
+		SET role = $2
+		FROM (SELECT id, role FROM users WHERE id = ANY($1::uuid[]) ORDER BY id FOR UPDATE) previous
+		WHERE u.id = previous.id
+		RETURNING u.id, previous.role AS previous, u.role AS current
+	`
+
+	rows, err := r.batchUpdate(ctx, query, uuidArray(ids), string(role))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set user roles: %w", err)
+	}
+
+	previous := make(map[uuid.UUID]models.UserRole, len(rows))
+	for _, row := range rows {
+		previous[row.ID] = models.UserRole(row.Previous)
+	}
+	return previous, nil
+}
+
+// watchedUser is a user with the version of its last event
+type watchedUser struct {
+	User
+	Version int64 `db:"version"`
+}
+
+// GetWatched returns the state of the users with the given IDs and the version of their last
+// event, users that do not exist without a user
+func (r *UserRepository) GetWatched(ctx context.Context, ids []uuid.UUID) ([]*models.WatchedUser, error) {
+	query := `
+		SELECT id, email, username, password_hash, status, role, organization_id, residency, created_at, updated_at,
+			COALESCE((SELECT MAX(version) FROM user_events e WHERE e.user_id = u.id), 0) AS version
+		FROM users u
+		WHERE id = ANY($1::uuid[])
+	`
+
+	var rows []*watchedUser
+	if err := r.db.SelectContext(ctx, &rows, query, uuidArray(ids)); err != nil {
+		return nil, fmt.Errorf("failed to get watched users: %w", err)
+	}
+
+	found := make(map[uuid.UUID]*watchedUser, len(rows))
+	for _, row := range rows {
+		found[uuid.MustParse(row.ID)] = row
+	}
+
+	watched := make([]*models.WatchedUser, 0, len(ids))
+	for _, id := range ids {
+		state := &models.WatchedUser{UserID: id}
+		if row, ok := found[id]; ok {
+			state.User, state.Version = row.ToDomain(), row.Version
+		}
+		watched = append(watched, state)
+	}
+
+	return watched, nil
+}
+
+func uuidArray(ids []uuid.UUID) interface{} {
+	return pq.Array(lo.Map(ids, func(id uuid.UUID, _ int) string { return id.String() }))
+}
+
+// batchUpdate runs an update returning previous values. Rows are locked in ID order so
+// concurrent batches over overlapping users cannot deadlock.
+func (r *UserRepository) batchUpdate(ctx context.Context, query string, args ...interface{}) ([]*previousValue, error) {
+	var rows []*previousValue
+	var err error
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.SelectContext(ctx, &rows, query, args...)
+	} else {
+		err = r.db.SelectContext(ctx, &rows, query, args...)
+	}
+
+	return rows, err
+}
+
+// projectionDrift matches a user whose stored state differs from the projection in $2..$8
+const projectionDrift = `
+	id = $1 AND (email, username, status, role, organization_id, legal_hold, metadata)
+		IS DISTINCT FROM ($2, $3, $4, $5, $6::uuid, $7, $8::jsonb)
+`
+
+func projectionArgs(p *models.UserProjection) []interface{} {
+	organizationID := sql.NullString{String: p.OrganizationID.String(), Valid: p.OrganizationID != uuid.Nil}
+	return []interface{}{
+		p.ID.String(), p.Email, p.Username, string(p.Status), string(p.Role), organizationID, p.LegalHold, string(p.Metadata.JSON()),
+	}
+}
+
+// ProjectionDiffers reports whether the stored user differs from the state rebuilt from events.
+// Deleted users never differ.
+func (r *UserRepository) ProjectionDiffers(ctx context.Context, p *models.UserProjection) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM users WHERE ` + projectionDrift + `)`
+
+	var differs bool
+	if err := r.db.GetContext(ctx, &differs, query, projectionArgs(p)...); err != nil {
+		return false, fmt.Errorf("failed to compare user projection: %w", err)
+	}
+
+	return differs, nil
+}
+
+// SaveProjection overwrites the stored user with the state rebuilt from events and reports
+// whether it differed. Password hashes are not part of the history and are kept.
+func (r *UserRepository) SaveProjection(ctx context.Context, p *models.UserProjection) (bool, error) {
+	query := `
+		UPDATE users
+		SET email = $2, username = $3, status = $4, role = $5, organization_id = $6::uuid, legal_hold = $7, metadata = $8::jsonb
+		WHERE ` + projectionDrift
+
+	result, err := r.db.ExecContext(ctx, query, projectionArgs(p)...)
+	if err != nil {
+		return false, fmt.Errorf("failed to save user projection: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// isUnderLegalHold reports whether an existing user is under legal hold
+func (r *UserRepository) isUnderLegalHold(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND legal_hold)`
+
+	var held bool
+	var err error
+
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, &held, query, id.String())
+	} else {
+		err = r.db.GetContext(ctx, &held, query, id.String())
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to check legal hold: %w", err)
+	}
+
+	return held, nil
+}
+
+// ListForExport returns up to limit users matching the filter that were created after the
+// (afterCreatedAt, afterID) cursor, ordered by creation. Password hashes are not read.
+func (r *UserRepository) ListForExport(
+	ctx context.Context,
+	filter models.UserExportFilter,
+	afterCreatedAt int64,
+	afterID uuid.UUID,
+	limit int,
+) ([]*models.User, error) {
+	query := `
+		SELECT id, email, username, status, role, organization_id, residency, created_at, updated_at
+		FROM users
+		WHERE ($1 = 0 OR created_at >= $1) AND ($2 = 0 OR created_at < $2)
+			AND ($6::uuid IS NULL OR organization_id = $6)
+			AND (created_at, id) > ($3, $4)
+		ORDER BY created_at, id
+		LIMIT $5
+	`
+
+	organizationID := sql.NullString{String: filter.OrganizationID.String(), Valid: filter.OrganizationID != uuid.Nil}
+
+	rows := make([]*User, 0, limit)
+	if err := r.db.SelectContext(ctx, &rows, query,
+		filter.CreatedFrom, filter.CreatedTo, afterCreatedAt, afterID, limit, organizationID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to list users for export: %w", err)
+	}
+
+	return lo.Map(rows, func(row *User, _ int) *models.User {
+		return row.ToDomain()
+	}), nil
+}
+
+// List returns up to limit users created after the cursor, ordered by creation and ID. The
+// cursor is a key rather than an offset, so each page is an index range scan and pages
+// neither skip nor repeat users while others register. Password hashes are not read.
+func (r *UserRepository) List(ctx context.Context, limit int, cursor models.UserCursor) ([]*models.User, error) {
+	query := `
+		SELECT id, email, username, status, role, organization_id, residency, created_at, updated_at
+		FROM users
+		WHERE (created_at, id) > ($1, $2)
+		ORDER BY created_at, id
+		LIMIT $3
+	`
+
+	rows := make([]*User, 0, limit)
+	if err := r.db.SelectContext(ctx, &rows, query, cursor.CreatedAt, cursor.ID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return lo.Map(rows, func(row *User, _ int) *models.User {
+		return row.ToDomain()
+	}), nil
+}
+
+// Activate sets the first password of a pending or invited user and makes the account active
+func (r *UserRepository) Activate(ctx context.Context, id uuid.UUID, passwordHash models.PasswordHash) error {
+	query := `
+		UPDATE users
+		SET password_hash = $2, status = $3
+		WHERE id = $1 AND status IN ($4, $5)
+	`
+
+	var result sql.Result
+	var err error
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		result, err = tx.ExecContext(ctx, query, id.String(), passwordHash.String(), models.UserStatusActive, models.UserStatusPending, models.UserStatusInvited)
+	} else {
+		result, err = r.db.ExecContext(ctx, query, id.String(), passwordHash.String(), models.UserStatusActive, models.UserStatusPending, models.UserStatusInvited)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to activate user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errs.ErrInvalidPasswordSetup
+	}
+
+	return nil
+}
+
+// SetPassword replaces the password of an active user, e.g. with a password reset
+func (r *UserRepository) SetPassword(ctx context.Context, id uuid.UUID, passwordHash models.PasswordHash) error {
+	query := `
+		UPDATE users
+		SET password_hash = $2
+		WHERE id = $1 AND status = $3
+	`
+
+	var result sql.Result
+	var err error
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		result, err = tx.ExecContext(ctx, query, id.String(), passwordHash.String(), models.UserStatusActive)
+	} else {
+		result, err = r.db.ExecContext(ctx, query, id.String(), passwordHash.String(), models.UserStatusActive)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errs.ErrInvalidPasswordReset
+	}
+
+	return nil
+}
+
+// UpgradePasswordHash replaces a user's password hash with a stronger one, unless the hash
+// changed since it was read, e.g. by a concurrent password reset. It reports whether the
+// hash was replaced.
+func (r *UserRepository) UpgradePasswordHash(ctx context.Context, id uuid.UUID, previous, passwordHash models.PasswordHash) (bool, error) {
+	query := `
+		UPDATE users
+		SET password_hash = $3
+		WHERE id = $1 AND password_hash = $2
+	`
+
+	var result sql.Result
+	var err error
+
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		result, err = tx.ExecContext(ctx, query, id.String(), previous.String(), passwordHash.String())
+	} else {
+		result, err = r.db.ExecContext(ctx, query, id.String(), previous.String(), passwordHash.String())
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to upgrade password hash: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RecordLogin stamps the login of a user and clears the inactive flag of the account
+func (r *UserRepository) RecordLogin(ctx context.Context, id uuid.UUID, loginAt int64) error {
+	query := `UPDATE users SET last_login_at = $2, inactive_since = 0 WHERE id = $1`
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, id.String(), loginAt)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, id.String(), loginAt)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record login: %w", err)
+	}
+
+	return nil
+}
+
+// FlagInactive flags up to limit active customers last seen before lastSeenBefore, and not
+// flagged yet, as inactive since now and returns them. Replicas running it concurrently skip
+// each other's users.
+func (r *UserRepository) FlagInactive(ctx context.Context, lastSeenBefore, now int64, limit int) ([]*models.User, error) {
+	query := `
+		UPDATE users
+		SET inactive_since = $2
+		WHERE id IN (
+			SELECT id FROM users
+			WHERE inactive_since = 0 AND status = 'active' AND role = 'customer'
+				AND GREATEST(last_login_at, created_at) < $1
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, email, username, status, role, organization_id, residency, created_at, updated_at,
+			last_login_at, inactive_since
+	`
+
+	rows := make([]*User, 0, limit)
+	if err := r.db.SelectContext(ctx, &rows, query, lastSeenBefore, now, limit); err != nil {
+		return nil, fmt.Errorf("failed to flag inactive users: %w", err)
+	}
+
+	return lo.Map(rows, func(row *User, _ int) *models.User {
+		return row.ToDomain()
+	}), nil
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+)
+
+// UserEvent domain model
+type UserEvent struct {
+	ID         uuid.UUID       `db:"id"`
+	UserID     uuid.UUID       `db:"user_id"`
+	Version    int64           `db:"version"`
+	EventType  string          `db:"event_type"`
+	Data       json.RawMessage `db:"data"`
+	Actor      string          `db:"actor"`
+	OccurredAt int64           `db:"occurred_at"`
+}
+
+func (e *UserEvent) ToDomain() *models.UserEvent {
+	return &models.UserEvent{
+		ID:         e.ID,
+		UserID:     e.UserID,
+		Version:    e.Version,
+		Type:       models.UserEventType(e.EventType),
+		Data:       e.Data,
+		Actor:      e.Actor,
+		OccurredAt: e.OccurredAt,
+	}
+}
+
+type UserEventRepository struct {
+	db db.Store
+}
+
+func NewUserEventRepository(db db.Store) *UserEventRepository {
+	return &UserEventRepository{
+		db: db,
+	}
+}
+
+// Append adds events to the streams of their users, numbering them after the last stored
+// version of each user in the order given. Run it in the transaction making the change, so
+// the history never misses or invents a change; a concurrent append to the same stream fails
+// on the unique version.
+func (r *UserEventRepository) Append(ctx context.Context, events ...*models.UserEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO user_events (id, user_id, version, event_type, data, actor, occurred_at)
+		SELECT e.id, e.user_id,
+			COALESCE((SELECT MAX(version) FROM user_events WHERE user_id = e.user_id), 0)
+				+ ROW_NUMBER() OVER (PARTITION BY e.user_id ORDER BY e.ord),
+			e.event_type, e.data, e.actor, e.occurred_at
+		FROM unnest($1::uuid[], $2::uuid[], $3::text[], $4::jsonb[], $5::text[], $6::bigint[])
+			WITH ORDINALITY AS e(id, user_id, event_type, data, actor, occurred_at, ord)
+	`
+
+	ids := make([]string, 0, len(events))
+	userIDs := make([]string, 0, len(events))
+	types := make([]string, 0, len(events))
+	data := make([]string, 0, len(events))
+	actors := make([]string, 0, len(events))
+	occurredAt := make([]int64, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID.String())
+		userIDs = append(userIDs, event.UserID.String())
+		types = append(types, string(event.Type))
+		data = append(data, string(event.Data))
+		actors = append(actors, event.Actor)
+		occurredAt = append(occurredAt, event.OccurredAt)
+	}
+	args := []interface{}{
+		pq.Array(ids), pq.Array(userIDs), pq.Array(types), pq.Array(data), pq.Array(actors), pq.Array(occurredAt),
+	}
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to append user events: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser returns up to limit events of the user after the given version, oldest first
+func (r *UserEventRepository) ListByUser(ctx context.Context, userID uuid.UUID, afterVersion int64, limit int) ([]*models.UserEvent, error) {
+	query := `
+		SELECT id, user_id, version, event_type, data, actor, occurred_at
+		FROM user_events
+		WHERE user_id = $1 AND version > $2
+		ORDER BY version
+		LIMIT $3
+	`
+
+	var rows []*UserEvent
+	if err := r.db.SelectContext(ctx, &rows, query, userID, afterVersion, limit); err != nil {
+		return nil, fmt.Errorf("failed to list user events: %w", err)
+	}
+
+	return lo.Map(rows, func(row *UserEvent, _ int) *models.UserEvent {
+		return row.ToDomain()
+	}), nil
+}
+
+// ListUserIDs returns up to limit IDs of users with events, after afterID in ID order
+func (r *UserEventRepository) ListUserIDs(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT DISTINCT user_id
+		FROM user_events
+		WHERE user_id > $1
+		ORDER BY user_id
+		LIMIT $2
+	`
+
+	var ids []uuid.UUID
+	if err := r.db.SelectContext(ctx, &ids, query, afterID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list user event streams: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Backfill starts the stream of every user without events with a user.backfilled event
+// holding the user's current state, and returns the number of streams started
+func (r *UserEventRepository) Backfill(ctx context.Context, actor string) (int64, error) {
+	query := `
+		INSERT INTO user_events (id, user_id, version, event_type, data, actor, occurred_at)
+		SELECT gen_random_uuid(), u.id, 1, $1,
+			jsonb_strip_nulls(jsonb_build_object(
+				'email', u.email,
+				'username', u.username,
+				'status', u.status,
+				'role', u.role,
+				'organizationId', u.organization_id,
+				'residency', NULLIF(u.residency, ''),
+				'legalHold', u.legal_hold,
+				'metadata', NULLIF(u.metadata, '{}'::jsonb)
+			)),
+			$2, u.created_at
+		FROM users u
+		WHERE NOT EXISTS (SELECT 1 FROM user_events e WHERE e.user_id = u.id)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, string(models.UserEventBackfilled), actor)
+	if err != nil {
+		return 0, fmt.Errorf("failed to backfill user events: %w", err)
+	}
+
+	started, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to backfill user events: %w", err)
+	}
+
+	return started, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type UserIdentity struct {
+	Provider  string    `db:"provider"`
+	Subject   string    `db:"subject"`
+	UserID    uuid.UUID `db:"user_id"`
+	CreatedAt int64     `db:"created_at"`
+}
+
+func (i *UserIdentity) ToDomain() *models.UserIdentity {
+	return &models.UserIdentity{
+		Provider:  i.Provider,
+		Subject:   i.Subject,
+		UserID:    i.UserID,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+type UserIdentityRepository struct {
+	db db.Store
+}
+
+func NewUserIdentityRepository(db db.Store) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		db: db,
+	}
+}
+
+// Create links a user to an identity of a provider. An identity linked already, e.g. by a
+// concurrent first login, is left to the user it was linked to.
+func (r *UserIdentityRepository) Create(ctx context.Context, identity *models.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (provider, subject, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, subject) DO NOTHING
+	`
+
+	args := []interface{}{identity.Provider, identity.Subject, identity.UserID, identity.CreatedAt}
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create user identity: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the identity with the subject at the provider
+func (r *UserIdentityRepository) Get(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	query := `
+		SELECT provider, subject, user_id, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	var identity UserIdentity
+	if err := r.db.GetContext(ctx, &identity, query, provider, subject); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+
+	return identity.ToDomain(), nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+
+	"github.com/google/uuid"
+)
+
+type UserNote struct {
+	ID         uuid.UUID `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	Body       string    `db:"body"`
+	Visibility string    `db:"visibility"`
+	Author     string    `db:"author"`
+	CreatedBy  string    `db:"created_by"`
+	CreatedAt  int64     `db:"created_at"`
+}
+
+func (r *UserNote) ToDomain() *models.UserNote {
+	return &models.UserNote{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		Body:       r.Body,
+		Visibility: models.UserNoteVisibility(r.Visibility),
+		Author:     r.Author,
+		CreatedBy:  r.CreatedBy,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+type UserNoteRepository struct {
+	db db.Store
+}
+
+func NewUserNoteRepository(db db.Store) *UserNoteRepository {
+	return &UserNoteRepository{
+		db: db,
+	}
+}
+
+// Create stores a note on a user
+func (r *UserNoteRepository) Create(ctx context.Context, note *models.UserNote) error {
+	query := `
+		INSERT INTO user_notes (id, user_id, body, visibility, author, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		note.ID, note.UserID, note.Body, string(note.Visibility), note.Author, note.CreatedBy, note.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create user note: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser returns up to limit notes of the user before the cursor, newest first, of the
+// visibility or of any visibility when it is empty
+func (r *UserNoteRepository) ListByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	visibility models.UserNoteVisibility,
+	cursor models.UserNoteCursor,
+	limit int,
+) ([]*models.UserNote, error) {
+	query := `
+		SELECT id, user_id, body, visibility, author, created_by, created_at
+		FROM user_notes
+		WHERE user_id = $1
+			AND ($2 = '' OR visibility = $2)
+			AND ($3 = 0 OR (created_at, id) < ($3, $4))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5
+	`
+
+	rows := make([]*UserNote, 0, limit)
+	if err := r.db.SelectContext(ctx, &rows, query, userID, string(visibility), cursor.CreatedAt, cursor.ID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list user notes: %w", err)
+	}
+
+	notes := make([]*models.UserNote, 0, len(rows))
+	for _, row := range rows {
+		notes = append(notes, row.ToDomain())
+	}
+	return notes, nil
+}
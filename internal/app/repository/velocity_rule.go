@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+)
+
+type VelocityRule struct {
+	Name      string `db:"name"`
+	Entity    string `db:"entity"`
+	Action    string `db:"action"`
+	Threshold int    `db:"threshold"`
+	WindowMs  int64  `db:"window_ms"`
+	Response  string `db:"response"`
+	Enabled   bool   `db:"enabled"`
+	UpdatedBy string `db:"updated_by"`
+	CreatedAt int64  `db:"created_at"`
+	UpdatedAt int64  `db:"updated_at"`
+}
+
+func (r *VelocityRule) ToDomain() *models.VelocityRule {
+	return &models.VelocityRule{
+		Name:      r.Name,
+		Entity:    models.VelocityEntity(r.Entity),
+		Action:    models.VelocityAction(r.Action),
+		Threshold: r.Threshold,
+		Window:    time.Duration(r.WindowMs) * time.Millisecond,
+		Response:  models.VelocityResponse(r.Response),
+		Enabled:   r.Enabled,
+		UpdatedBy: r.UpdatedBy,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+type VelocityRuleRepository struct {
+	db db.Store
+}
+
+func NewVelocityRuleRepository(db db.Store) *VelocityRuleRepository {
+	return &VelocityRuleRepository{
+		db: db,
+	}
+}
+
+// List returns every velocity rule, enabled or not, by name
+func (r *VelocityRuleRepository) List(ctx context.Context) ([]*models.VelocityRule, error) {
+	query := `
+		SELECT name, entity, action, threshold, window_ms, response, enabled, updated_by, created_at, updated_at
+		FROM velocity_rules
+		ORDER BY name
+	`
+
+	var rows []*VelocityRule
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to list velocity rules: %w", err)
+	}
+
+	rules := make([]*models.VelocityRule, 0, len(rows))
+	for _, row := range rows {
+		rules = append(rules, row.ToDomain())
+	}
+	return rules, nil
+}
+
+// Upsert stores the rule, replacing the rule of the same name but keeping its creation time,
+// which is set on the rule
+func (r *VelocityRuleRepository) Upsert(ctx context.Context, rule *models.VelocityRule) error {
+	query := `
+		INSERT INTO velocity_rules
+			(name, entity, action, threshold, window_ms, response, enabled, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (name) DO UPDATE
+		SET entity = EXCLUDED.entity,
+			action = EXCLUDED.action,
+			threshold = EXCLUDED.threshold,
+			window_ms = EXCLUDED.window_ms,
+			response = EXCLUDED.response,
+			enabled = EXCLUDED.enabled,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	err := r.db.GetContext(ctx, &rule.CreatedAt, query,
+		rule.Name, string(rule.Entity), string(rule.Action), rule.Threshold, rule.Window.Milliseconds(),
+		string(rule.Response), rule.Enabled, rule.UpdatedBy, rule.CreatedAt, rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert velocity rule: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the rule and reports whether there was one
+func (r *VelocityRuleRepository) Delete(ctx context.Context, name string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM velocity_rules WHERE name = $1`, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete velocity rule: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
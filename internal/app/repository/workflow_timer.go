@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/db"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// WorkflowTimer domain model
+type WorkflowTimer struct {
+	ID        uuid.UUID       `db:"id"`
+	Workflow  string          `db:"workflow"`
+	Key       string          `db:"key"`
+	Payload   json.RawMessage `db:"payload"`
+	FireAt    int64           `db:"fire_at"`
+	Attempts  int             `db:"attempts"`
+	CreatedAt int64           `db:"created_at"`
+}
+
+func (t *WorkflowTimer) ToDomain() *models.WorkflowTimer {
+	return &models.WorkflowTimer{
+		ID:        t.ID,
+		Workflow:  t.Workflow,
+		Key:       t.Key,
+		Payload:   t.Payload,
+		FireAt:    t.FireAt,
+		Attempts:  t.Attempts,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+type WorkflowTimerRepository struct {
+	db db.Store
+}
+
+func NewWorkflowTimerRepository(db db.Store) *WorkflowTimerRepository {
+	return &WorkflowTimerRepository{
+		db: db,
+	}
+}
+
+// Schedule stores the timer, replacing the pending timer of the same workflow and key. Run it
+// in the transaction starting the workflow step, so the timer exists exactly when the step does.
+func (r *WorkflowTimerRepository) Schedule(ctx context.Context, timer *models.WorkflowTimer) error {
+	query := `
+		INSERT INTO workflow_timers (id, workflow, key, payload, fire_at, attempts, created_at)
+		VALUES ($1, $2, $3, $4, $5, 0, $6)
+		ON CONFLICT (workflow, key) DO UPDATE
+		SET id = EXCLUDED.id, payload = EXCLUDED.payload, fire_at = EXCLUDED.fire_at,
+			attempts = 0, last_error = NULL, created_at = EXCLUDED.created_at
+	`
+
+	args := []interface{}{timer.ID, timer.Workflow, timer.Key, timer.Payload, timer.FireAt, timer.CreatedAt}
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, args...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to schedule workflow timer: %w", err)
+	}
+
+	return nil
+}
+
+// LockDue returns the earliest timer due at now and locks it until the transaction in ctx
+// ends, skipping timers locked by other replicas. It returns nil when no timer is due.
+func (r *WorkflowTimerRepository) LockDue(ctx context.Context, now int64) (*models.WorkflowTimer, error) {
+	txn, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx)
+	if !ok {
+		return nil, errors.New("failed to lock due workflow timer: no transaction in context")
+	}
+
+	query := `
+		SELECT id, workflow, key, payload, fire_at, attempts, created_at
+		FROM workflow_timers
+		WHERE fire_at <= $1
+		ORDER BY fire_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	var timer WorkflowTimer
+	if err := txn.GetContext(ctx, &timer, query, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock due workflow timer: %w", err)
+	}
+
+	return timer.ToDomain(), nil
+}
+
+// Delete removes a fired timer
+func (r *WorkflowTimerRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM workflow_timers WHERE id = $1`
+
+	var err error
+	// Check if we're in a transaction
+	if tx, ok := ctx.Value(tx.TransactionContextKey).(*sqlx.Tx); ok {
+		_, err = tx.ExecContext(ctx, query, id)
+	} else {
+		_, err = r.db.ExecContext(ctx, query, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete workflow timer: %w", err)
+	}
+
+	return nil
+}
+
+// Retry records a failed attempt to fire the timer and moves it to fireAt
+func (r *WorkflowTimerRepository) Retry(ctx context.Context, id uuid.UUID, fireAt int64, lastError string) error {
+	query := `
+		UPDATE workflow_timers
+		SET attempts = attempts + 1, fire_at = $2, last_error = $3
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id, fireAt, lastError); err != nil {
+		return fmt.Errorf("failed to reschedule workflow timer: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package revocation
+
+import (
+	"sync"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+)
+
+// Cache holds the revocations every access token verification is checked against
+type Cache struct {
+	mu      sync.RWMutex
+	users   map[string]*models.TokenRevocation
+	jtis    map[string]*models.TokenRevocation
+	clients map[string]*models.TokenRevocation
+	// global is the latest global logout, nil if none is in effect
+	global *models.TokenRevocation
+
+	// evictors are notified when a user's tokens are revoked, e.g. to drop cached user data
+	evictors []func(userID string)
+}
+
+// NewCache creates an empty revocation cache
+func NewCache() *Cache {
+	return &Cache{
+		users:   make(map[string]*models.TokenRevocation),
+		jtis:    make(map[string]*models.TokenRevocation),
+		clients: make(map[string]*models.TokenRevocation),
+	}
+}
+
+// OnUserRevoked registers a callback invoked for every applied user revocation
+func (c *Cache) OnUserRevoked(evict func(userID string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evictors = append(c.evictors, evict)
+}
+
+// Apply records a revocation. Applying the same revocation twice is a no-op,
+// so local, pub/sub and resync deliveries can overlap.
+func (c *Cache) Apply(revocation *models.TokenRevocation) {
+	c.mu.Lock()
+
+	// A global logout keeps the latest cutoff; cached user data is still current
+	if revocation.Kind == models.TokenRevocationKindGlobal {
+		if c.global == nil || c.global.RevokedAt < revocation.RevokedAt {
+			c.global = revocation
+		}
+		c.mu.Unlock()
+		return
+	}
+
+	entries := c.jtis
+	switch revocation.Kind {
+	case models.TokenRevocationKindUser:
+		entries = c.users
+	case models.TokenRevocationKindClient:
+		entries = c.clients
+	}
+
+	if current, ok := entries[revocation.Subject]; ok && current.RevokedAt >= revocation.RevokedAt {
+		c.mu.Unlock()
+		return
+	}
+	entries[revocation.Subject] = revocation
+
+	var evictors []func(string)
+	if revocation.Kind == models.TokenRevocationKindUser {
+		evictors = c.evictors
+	}
+	c.mu.Unlock()
+
+	for _, evict := range evictors {
+		evict(revocation.Subject)
+	}
+}
+
+// IsRevoked reports whether the access token was revoked by ID or by a revocation of its user
+// or of its client's access to the user
+func (c *Cache) IsRevoked(payload *token.Payload) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if _, ok := c.jtis[payload.ID.String()]; ok {
+		return true
+	}
+
+	if c.global != nil && payload.IssuedAt <= c.global.RevokedAt/1000 {
+		return true
+	}
+
+	// Token issue times have second precision, so a token issued in the same second is revoked too
+	if revocation, ok := c.users[payload.UserID]; ok && payload.IssuedAt <= revocation.RevokedAt/1000 {
+		return true
+	}
+	if payload.ClientID != "" {
+		subject := models.ClientRevocationSubject(payload.UserID, payload.ClientID)
+		if revocation, ok := c.clients[subject]; ok && payload.IssuedAt <= revocation.RevokedAt/1000 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsBeforeGlobalCutoff reports whether a global logout revoked the refresh tokens issued at
+// createdAt, in milliseconds, so they are rejected before their rows are revoked
+func (c *Cache) IsBeforeGlobalCutoff(createdAt int64) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.global != nil && createdAt <= c.global.RevokedAt
+}
+
+// Prune drops revocations that no longer affect any valid token
+func (c *Cache) Prune(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, entries := range []map[string]*models.TokenRevocation{c.users, c.jtis, c.clients} {
+		for subject, revocation := range entries {
+			if revocation.ExpiresAt <= now.UnixMilli() {
+				delete(entries, subject)
+			}
+		}
+	}
+	if c.global != nil && c.global.ExpiresAt <= now.UnixMilli() {
+		c.global = nil
+	}
+}
+
+// Len returns the number of cached revocations
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	n := len(c.users) + len(c.jtis) + len(c.clients)
+	if c.global != nil {
+		n++
+	}
+	return n
+}
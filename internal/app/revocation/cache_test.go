@@ -0,0 +1,157 @@
+package revocation
+
+import (
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+
+	"github.com/google/uuid"
+)
+
+func TestCache_UserRevocation(t *testing.T) {
+	cache := NewCache()
+	now := time.Now()
+
+	var evicted []string
+	cache.OnUserRevoked(func(userID string) { evicted = append(evicted, userID) })
+
+	before := &token.Payload{ID: uuid.New(), UserID: "user-1", IssuedAt: now.Add(-time.Minute).Unix()}
+	after := &token.Payload{ID: uuid.New(), UserID: "user-1", IssuedAt: now.Add(time.Minute).Unix()}
+	other := &token.Payload{ID: uuid.New(), UserID: "user-2", IssuedAt: now.Add(-time.Minute).Unix()}
+
+	revocation := &models.TokenRevocation{
+		Kind:      models.TokenRevocationKindUser,
+		Subject:   "user-1",
+		RevokedAt: now.UnixMilli(),
+		ExpiresAt: now.Add(15 * time.Minute).UnixMilli(),
+	}
+	cache.Apply(revocation)
+	// Duplicate deliveries from pub/sub and resync are ignored
+	cache.Apply(revocation)
+
+	if !cache.IsRevoked(before) {
+		t.Error("Expected token issued before the revocation to be revoked")
+	}
+	if cache.IsRevoked(after) {
+		t.Error("Expected token issued after the revocation to be valid")
+	}
+	if cache.IsRevoked(other) {
+		t.Error("Expected tokens of other users to be valid")
+	}
+	if len(evicted) != 1 || evicted[0] != "user-1" {
+		t.Errorf("Expected user-1 to be evicted once, got %v", evicted)
+	}
+}
+
+func TestCache_ClientRevocation(t *testing.T) {
+	cache := NewCache()
+	now := time.Now()
+
+	var evicted []string
+	cache.OnUserRevoked(func(userID string) { evicted = append(evicted, userID) })
+
+	revoked := &token.Payload{ID: uuid.New(), UserID: "user-1", ClientID: "kiosk", IssuedAt: now.Add(-time.Minute).Unix()}
+	otherClient := &token.Payload{ID: uuid.New(), UserID: "user-1", ClientID: "web", IssuedAt: now.Add(-time.Minute).Unix()}
+	otherUser := &token.Payload{ID: uuid.New(), UserID: "user-2", ClientID: "kiosk", IssuedAt: now.Add(-time.Minute).Unix()}
+	// Tokens issued before clients were embedded cannot be attributed to a client
+	legacy := &token.Payload{ID: uuid.New(), UserID: "user-1", IssuedAt: now.Add(-time.Minute).Unix()}
+
+	cache.Apply(&models.TokenRevocation{
+		Kind:      models.TokenRevocationKindClient,
+		Subject:   models.ClientRevocationSubject("user-1", "kiosk"),
+		RevokedAt: now.UnixMilli(),
+		ExpiresAt: now.Add(15 * time.Minute).UnixMilli(),
+	})
+
+	if !cache.IsRevoked(revoked) {
+		t.Error("Expected token of the revoked client to be revoked")
+	}
+	for _, payload := range []*token.Payload{otherClient, otherUser, legacy} {
+		if cache.IsRevoked(payload) {
+			t.Errorf("Expected token of %s for client %q to be valid", payload.UserID, payload.ClientID)
+		}
+	}
+	if len(evicted) != 0 {
+		t.Errorf("Expected no cached user data to be evicted, got %v", evicted)
+	}
+
+	cache.Prune(now.Add(time.Hour))
+	if cache.Len() != 0 {
+		t.Errorf("Expected the expired client revocation to be pruned, got %d", cache.Len())
+	}
+}
+
+func TestCache_JTIRevocationAndPrune(t *testing.T) {
+	cache := NewCache()
+	now := time.Now()
+
+	payload := &token.Payload{ID: uuid.New(), UserID: "user-1", IssuedAt: now.Unix()}
+	cache.Apply(&models.TokenRevocation{
+		Kind:      models.TokenRevocationKindJTI,
+		Subject:   payload.ID.String(),
+		RevokedAt: now.UnixMilli(),
+		ExpiresAt: now.Add(time.Minute).UnixMilli(),
+	})
+
+	if !cache.IsRevoked(payload) {
+		t.Error("Expected denylisted token to be revoked")
+	}
+
+	cache.Prune(now)
+	if cache.Len() != 1 {
+		t.Errorf("Expected active revocation to be kept, got %d entries", cache.Len())
+	}
+
+	cache.Prune(now.Add(2 * time.Minute))
+	if cache.Len() != 0 {
+		t.Errorf("Expected expired revocation to be pruned, got %d entries", cache.Len())
+	}
+}
+
+func TestCache_GlobalRevocation(t *testing.T) {
+	cache := NewCache()
+	now := time.Now()
+
+	var evicted []string
+	cache.OnUserRevoked(func(userID string) { evicted = append(evicted, userID) })
+
+	cutoff := now.Add(-time.Minute)
+	cache.Apply(&models.TokenRevocation{
+		Kind:      models.TokenRevocationKindGlobal,
+		Subject:   models.TokenRevocationSubjectAll,
+		RevokedAt: cutoff.UnixMilli(),
+		ExpiresAt: now.Add(time.Hour).UnixMilli(),
+	})
+	// An earlier cutoff delivered late does not lower the floor
+	cache.Apply(&models.TokenRevocation{
+		Kind:      models.TokenRevocationKindGlobal,
+		Subject:   models.TokenRevocationSubjectAll,
+		RevokedAt: now.Add(-time.Hour).UnixMilli(),
+		ExpiresAt: now.Add(time.Hour).UnixMilli(),
+	})
+
+	before := &token.Payload{ID: uuid.New(), UserID: "user-1", IssuedAt: cutoff.Add(-time.Second).Unix()}
+	after := &token.Payload{ID: uuid.New(), UserID: "user-2", IssuedAt: now.Unix()}
+	if !cache.IsRevoked(before) {
+		t.Error("Expected token issued before the cutoff to be revoked")
+	}
+	if cache.IsRevoked(after) {
+		t.Error("Expected token issued after the cutoff to be valid")
+	}
+	if !cache.IsBeforeGlobalCutoff(cutoff.UnixMilli()) {
+		t.Error("Expected refresh token issued at the cutoff to be revoked")
+	}
+	if cache.IsBeforeGlobalCutoff(now.UnixMilli()) {
+		t.Error("Expected refresh token issued after the cutoff to be valid")
+	}
+	if len(evicted) != 0 {
+		t.Errorf("Expected no cached users to be evicted, got %v", evicted)
+	}
+
+	cache.Prune(now.Add(2 * time.Hour))
+	if cache.IsRevoked(before) || cache.Len() != 0 {
+		t.Errorf("Expected expired global revocation to be pruned, got %d entries", cache.Len())
+	}
+}
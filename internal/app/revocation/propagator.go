@@ -0,0 +1,188 @@
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type Repository interface {
+	Upsert(ctx context.Context, revocation *models.TokenRevocation) error
+	ListActive(ctx context.Context, now int64) ([]*models.TokenRevocation, error)
+}
+
+// Propagator persists revocations and broadcasts them to every replica over Redis pub/sub.
+// Replicas also resync from the database periodically, which bounds staleness to the
+// resync interval if a pub/sub message is lost.
+type Propagator struct {
+	cache          *Cache
+	repo           Repository
+	client         *redis.Client
+	channel        string
+	resyncInterval time.Duration
+	maxTokenAge    time.Duration
+	logger         *logutils.Logger
+}
+
+// NewPropagator creates a propagator. maxTokenAge is the access token lifetime,
+// after which a revocation no longer needs to be remembered.
+func NewPropagator(
+	cache *Cache,
+	repo Repository,
+	client *redis.Client,
+	channel string,
+	resyncInterval time.Duration,
+	maxTokenAge time.Duration,
+	logger *logutils.Logger,
+) *Propagator {
+	return &Propagator{
+		cache:          cache,
+		repo:           repo,
+		client:         client,
+		channel:        channel,
+		resyncInterval: resyncInterval,
+		maxTokenAge:    maxTokenAge,
+		logger:         logger,
+	}
+}
+
+// RevokeUser revokes every access token issued to the user so far
+func (p *Propagator) RevokeUser(ctx context.Context, userID string) error {
+	now := time.Now()
+	return p.revoke(ctx, &models.TokenRevocation{
+		Kind:      models.TokenRevocationKindUser,
+		Subject:   userID,
+		RevokedAt: now.UnixMilli(),
+		ExpiresAt: now.Add(p.maxTokenAge).UnixMilli(),
+	})
+}
+
+// RevokeClient revokes every access token issued to the user for the client so far
+func (p *Propagator) RevokeClient(ctx context.Context, userID, clientID string) error {
+	now := time.Now()
+	return p.revoke(ctx, &models.TokenRevocation{
+		Kind:      models.TokenRevocationKindClient,
+		Subject:   models.ClientRevocationSubject(userID, clientID),
+		RevokedAt: now.UnixMilli(),
+		ExpiresAt: now.Add(p.maxTokenAge).UnixMilli(),
+	})
+}
+
+// RevokeJTI revokes a single access token until it expires
+func (p *Propagator) RevokeJTI(ctx context.Context, jti string, expiresAt time.Time) error {
+	return p.revoke(ctx, &models.TokenRevocation{
+		Kind:      models.TokenRevocationKindJTI,
+		Subject:   jti,
+		RevokedAt: time.Now().UnixMilli(),
+		ExpiresAt: expiresAt.UnixMilli(),
+	})
+}
+
+// RevokeAll revokes every access and refresh token issued up to cutoff. The revocation is kept
+// until expiresAt, by when every token it applies to must have expired.
+func (p *Propagator) RevokeAll(ctx context.Context, cutoff, expiresAt time.Time) error {
+	return p.revoke(ctx, &models.TokenRevocation{
+		Kind:      models.TokenRevocationKindGlobal,
+		Subject:   models.TokenRevocationSubjectAll,
+		RevokedAt: cutoff.UnixMilli(),
+		ExpiresAt: expiresAt.UnixMilli(),
+	})
+}
+
+// revoke persists the revocation, applies it locally and broadcasts it. A failed
+// broadcast is only logged since other replicas pick it up on their next resync.
+func (p *Propagator) revoke(ctx context.Context, revocation *models.TokenRevocation) error {
+	if err := p.repo.Upsert(ctx, revocation); err != nil {
+		return err
+	}
+
+	p.cache.Apply(revocation)
+	metrics.RevocationsApplied.WithLabelValues("local").Inc()
+
+	payload, err := json.Marshal(revocation)
+	if err != nil {
+		return err
+	}
+
+	if err := p.client.Publish(ctx, p.channel, payload).Err(); err != nil {
+		metrics.RevocationPublishErrors.Inc()
+		p.logger.WithError(err).WithFields(logutils.Fields{
+			"kind":    revocation.Kind,
+			"subject": revocation.Subject,
+		}).Warn("Failed to broadcast token revocation, replicas will pick it up on resync")
+	}
+
+	return nil
+}
+
+// Start subscribes to revocation broadcasts and resyncs from the database until ctx is cancelled
+func (p *Propagator) Start(ctx context.Context, wg *sync.WaitGroup) {
+	p.logger.WithField("channel", p.channel).Info("Starting token revocation propagator")
+
+	pubsub := p.client.Subscribe(ctx, p.channel)
+	p.resync(ctx)
+
+	wg.Add(1)
+	go func() {
+		defer func() {
+			_ = pubsub.Close()
+			wg.Done()
+			p.logger.Info("Token revocation propagator stopped")
+		}()
+
+		ticker := time.NewTicker(p.resyncInterval)
+		defer ticker.Stop()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				p.handleMessage(msg)
+			case <-ticker.C:
+				p.resync(ctx)
+			}
+		}
+	}()
+}
+
+func (p *Propagator) handleMessage(msg *redis.Message) {
+	var revocation models.TokenRevocation
+	if err := json.Unmarshal([]byte(msg.Payload), &revocation); err != nil {
+		p.logger.WithError(err).Warn("Could not unmarshal token revocation message")
+		return
+	}
+
+	p.cache.Apply(&revocation)
+	metrics.RevocationsApplied.WithLabelValues("pubsub").Inc()
+}
+
+// resync reloads every active revocation and prunes the expired ones
+func (p *Propagator) resync(ctx context.Context) {
+	now := time.Now()
+
+	revocations, err := p.repo.ListActive(ctx, now.UnixMilli())
+	if err != nil {
+		p.logger.WithError(err).Warn("Failed to resync token revocations")
+		return
+	}
+
+	for _, revocation := range revocations {
+		p.cache.Apply(revocation)
+	}
+	p.cache.Prune(now)
+
+	metrics.RevocationsApplied.WithLabelValues("resync").Add(float64(len(revocations)))
+	metrics.RevocationCacheSize.Set(float64(p.cache.Len()))
+}
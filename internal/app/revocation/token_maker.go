@@ -0,0 +1,31 @@
+package revocation
+
+import (
+	"user-svc/pkg/utils/crypt/token"
+)
+
+// TokenMaker decorates a token maker so that verification rejects revoked access tokens
+type TokenMaker struct {
+	token.TokenMaker
+	cache *Cache
+}
+
+func NewTokenMaker(next token.TokenMaker, cache *Cache) *TokenMaker {
+	return &TokenMaker{
+		TokenMaker: next,
+		cache:      cache,
+	}
+}
+
+func (m *TokenMaker) VerifyAccessToken(accessToken string) (*token.Payload, error) {
+	payload, err := m.TokenMaker.VerifyAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.cache.IsRevoked(payload) {
+		return nil, token.ErrRevokedToken
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// maxActivityEntries bounds the audit entries summarized per user and period
+	maxActivityEntries = 1000
+	// digestPageSize is the number of users loaded at once when sending digests
+	digestPageSize = 500
+)
+
+// ActivityRepository reads the audit trail of user accounts
+type ActivityRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID, from, to int64, limit int) ([]*models.AuditLog, error)
+	ListActiveUserIDs(ctx context.Context, from, to int64, afterID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
+
+// ActivityService summarizes account activity from the audit trail
+type ActivityService struct {
+	auditRepo     ActivityRepository
+	userRepo      UserRepository
+	tokenMaker    token.TokenMaker
+	eventPipeline EventPipeline
+	now           func() time.Time
+}
+
+// NewActivityService creates a new ActivityService instance
+func NewActivityService(
+	auditRepo ActivityRepository,
+	userRepo UserRepository,
+	tokenMaker token.TokenMaker,
+	eventPipeline EventPipeline,
+) *ActivityService {
+	log.Info("Initializing ActivityService")
+
+	return &ActivityService{
+		auditRepo:     auditRepo,
+		userRepo:      userRepo,
+		tokenMaker:    tokenMaker,
+		eventPipeline: eventPipeline,
+		now:           time.Now,
+	}
+}
+
+// GetAccountActivitySummary summarizes the calling user's activity over the last 30 days
+func (s *ActivityService) GetAccountActivitySummary(ctx context.Context) (*models.ActivitySummary, error) {
+	logger := log.FromContext(ctx).WithField("method", "GetAccountActivitySummary")
+
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(caller.UserID)
+	if err != nil {
+		logger.WithError(err).Warn("Access token carries an invalid user id")
+		return nil, errs.ErrInvalidAccessToken
+	}
+
+	now := s.now()
+	summary, err := s.summarize(ctx, userID, now.Add(-models.ActivityWindow).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		logger.WithError(err).WithField("user_id", caller.UserID).Error("Failed to summarize account activity")
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// SendSecurityDigests publishes a security digest event for every user with activity in
+// [start, end). Event IDs are derived from the user and period, so a rerun after a
+// partial failure does not send a digest twice.
+func (s *ActivityService) SendSecurityDigests(ctx context.Context, start, end time.Time) error {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":       "SendSecurityDigests",
+		"period_start": start,
+		"period_end":   end,
+	})
+
+	from, to := start.UnixMilli(), end.UnixMilli()
+	sent := 0
+	after := uuid.Nil
+	for {
+		userIDs, err := s.auditRepo.ListActiveUserIDs(ctx, from, to, after, digestPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, userID := range userIDs {
+			ok, err := s.sendDigest(ctx, userID, from, to)
+			if err != nil {
+				return fmt.Errorf("failed to send security digest to %s: %w", userID, err)
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if len(userIDs) < digestPageSize {
+			break
+		}
+		after = userIDs[len(userIDs)-1]
+	}
+
+	logger.WithField("sent", sent).Info("Security digests published")
+	return nil
+}
+
+// sendDigest publishes the digest of a single user and reports whether one was published
+func (s *ActivityService) sendDigest(ctx context.Context, userID uuid.UUID, from, to int64) (bool, error) {
+	summary, err := s.summarize(ctx, userID, from, to)
+	if err != nil {
+		return false, err
+	}
+	if !summary.HasActivity() {
+		return false, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	payload, err := json.Marshal(dto.SendSecurityDigestParams{
+		UserID:   user.ID.String(),
+		Email:    user.Email.String(),
+		Username: user.Username.String(),
+		Activity: summary,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	eventID := uuid.NewSHA1(uuid.NameSpaceOID, fmt.Appendf(nil, "%s:%s:%d", events.SecurityDigestEventType, userID, from))
+	if err := s.eventPipeline.Submit(ctx, &repository.NotificationEventLog{
+		ID:        eventID.String(),
+		EventName: string(events.SecurityDigestEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *ActivityService) summarize(ctx context.Context, userID uuid.UUID, from, to int64) (*models.ActivitySummary, error) {
+	entries, err := s.auditRepo.ListByUser(ctx, userID, from, to, maxActivityEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.SummarizeActivity(userID, from, to, entries), nil
+}
+
+// deviceMetadata describes the device a request came from for the audit trail
+func deviceMetadata(ctx context.Context) map[string]interface{} {
+	device := map[string]interface{}{}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if userAgents := md.Get("user-agent"); len(userAgents) > 0 {
+			device[models.AuditMetadataUserAgent] = userAgents[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip := p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		device[models.AuditMetadataIPAddress] = ip
+	}
+
+	return device
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// AdminUserRepository reads users for admin tooling
+type AdminUserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	List(ctx context.Context, limit int, cursor models.UserCursor) ([]*models.User, error)
+	ListForExport(
+		ctx context.Context,
+		filter models.UserExportFilter,
+		afterCreatedAt int64,
+		afterID uuid.UUID,
+		limit int,
+	) ([]*models.User, error)
+}
+
+// AdminUserService lets admin tooling look up and page through users
+type AdminUserService struct {
+	adminKeys []config.AdminAPIKeyConfig
+	userRepo  AdminUserRepository
+}
+
+// NewAdminUserService creates a new AdminUserService instance
+func NewAdminUserService(cfg *config.Config, userRepo AdminUserRepository) *AdminUserService {
+	log.Info("Initializing AdminUserService")
+
+	return &AdminUserService{
+		adminKeys: cfg.Admin.APIKeys,
+		userRepo:  userRepo,
+	}
+}
+
+// GetUser returns a user by ID
+func (s *AdminUserService) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "GetUser",
+		"user_id": userID,
+	})
+
+	if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+
+	return s.userRepo.GetByID(ctx, id)
+}
+
+// ListUsers returns a page of users in creation order
+func (s *AdminUserService) ListUsers(ctx context.Context, req dto.ListUsersReq) (*dto.ListUsersResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":    "ListUsers",
+		"page_size": req.PageSize,
+	})
+
+	if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid list users request")
+		return nil, err
+	}
+
+	cursor, _ := req.Cursor()
+	limit := req.Limit()
+
+	// One extra user tells whether there is a next page
+	var users []*models.User
+	var err error
+	if req.OrganizationID == "" {
+		users, err = s.userRepo.List(ctx, limit+1, cursor)
+	} else {
+		users, err = s.userRepo.ListForExport(ctx, req.Filter(), cursor.CreatedAt, cursor.ID, limit+1)
+	}
+	if err != nil {
+		logger.WithError(err).Error("Failed to list users")
+		return nil, err
+	}
+
+	resp := &dto.ListUsersResp{Users: users}
+	if len(users) > limit {
+		resp.Users = users[:limit]
+		resp.NextPageToken = dto.UserPageToken(resp.Users[limit-1])
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"strings"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/hashing"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// apiKeyHasher hashes the API keys callers present, which the configuration only holds the
+// hashes of like the service stores its other secrets
+var apiKeyHasher models.TokenHasher = hashing.NewTokenHasher()
+
+// matchKeyHash returns the configured key whose hex-encoded hash the presented key hashes to
+func matchKeyHash[K any](presented string, keys []K, keyHash func(K) string) (K, bool) {
+	hash := apiKeyHasher.Hash(presented)
+	for _, key := range keys {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(strings.ToLower(keyHash(key)))) == 1 {
+			return key, true
+		}
+	}
+
+	var none K
+	return none, false
+}
+
+// AdminKeyMetadataKey is the incoming metadata key carrying an operator API key
+const AdminKeyMetadataKey = "x-admin-key"
+
+// authorizeAdmin checks the caller's admin API key and returns the id of the key
+func authorizeAdmin(ctx context.Context, keys []config.AdminAPIKeyConfig) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errs.ErrMissingCredentials
+	}
+
+	values := md.Get(AdminKeyMetadataKey)
+	if len(values) == 0 {
+		return "", errs.ErrMissingCredentials
+	}
+
+	key, ok := matchKeyHash(values[0], keys, func(key config.AdminAPIKeyConfig) string { return key.KeyHash })
+	if !ok {
+		return "", errs.ErrInvalidAdminKey
+	}
+
+	return key.ID, nil
+}
+
+// adminActor names an admin key as the actor of user events
+func adminActor(admin string) string {
+	return "admin:" + admin
+}
+
+// serviceActor names a service key as the actor of user events
+func serviceActor(service string) string {
+	return "service:" + service
+}
+
+// ServiceKeyMetadataKey is the incoming metadata key carrying an internal service API key
+const ServiceKeyMetadataKey = "x-service-key"
+
+// authorizeService checks the caller's service API key grants scope and returns the id of the key
+func authorizeService(ctx context.Context, keys []config.ServiceAPIKeyConfig, scope string) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errs.ErrMissingCredentials
+	}
+
+	values := md.Get(ServiceKeyMetadataKey)
+	if len(values) == 0 {
+		return "", errs.ErrMissingCredentials
+	}
+
+	key, ok := matchKeyHash(values[0], keys, func(key config.ServiceAPIKeyConfig) string { return key.KeyHash })
+	if !ok {
+		return "", errs.ErrInvalidServiceKey
+	}
+	if !slices.Contains(key.Scopes, scope) {
+		return "", errs.ErrMissingServiceScope
+	}
+
+	return key.ID, nil
+}
+
+// bearerToken extracts the access token from the incoming authorization metadata. Tokens
+// presented with the DPoP scheme are accepted too; their proof is checked by the DPoP interceptor.
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	values := md.Get(AuthorizationMetadataKey)
+	if len(values) == 0 {
+		return "", false
+	}
+
+	if accessToken, ok := strings.CutPrefix(values[0], "DPoP "); ok {
+		return accessToken, true
+	}
+
+	return strings.CutPrefix(values[0], "Bearer ")
+}
+
+// authenticate verifies the caller's bearer access token
+func authenticate(ctx context.Context, tokenMaker token.TokenMaker) (*token.Payload, error) {
+	accessToken, ok := bearerToken(ctx)
+	if !ok {
+		return nil, errs.ErrMissingCredentials
+	}
+
+	payload, err := verifyAccessToken(tokenMaker, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delegation tokens are only valid at the service they were exchanged for
+	if payload.IsDelegation() {
+		return nil, errs.ErrInvalidAccessToken
+	}
+
+	return payload, nil
+}
+
+// verifyAccessToken verifies an access token, mapping its failures to domain errors
+func verifyAccessToken(tokenMaker token.TokenMaker, accessToken string) (*token.Payload, error) {
+	payload, err := tokenMaker.VerifyAccessToken(accessToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, token.ErrExpiredToken):
+			return nil, errs.ErrTokenExpired
+		case errors.Is(err, token.ErrRevokedToken):
+			return nil, errs.ErrTokenRevoked
+		default:
+			return nil, errs.ErrInvalidAccessToken
+		}
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/pkg/utils/crypt/token"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthorizeService(t *testing.T) {
+	keys := []config.ServiceAPIKeyConfig{
+		{ID: "billing", KeyHash: strings.ToUpper(token.HashToken("billing-key")), Scopes: []string{ReadUsersScope}},
+		{ID: "search", KeyHash: token.HashToken("search-key"), Scopes: []string{WatchUsersScope}},
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		wantID  string
+		wantErr error
+	}{
+		{name: "upper-case configured hash", key: "billing-key", wantID: "billing"},
+		{name: "missing scope", key: "search-key", wantErr: errs.ErrMissingServiceScope},
+		{name: "unknown key", key: "other-key", wantErr: errs.ErrInvalidServiceKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ServiceKeyMetadataKey, tt.key))
+
+			id, err := authorizeService(ctx, keys, ReadUsersScope)
+			if !errors.Is(err, tt.wantErr) || id != tt.wantID {
+				t.Errorf("Expected %q, %v, got %q, %v", tt.wantID, tt.wantErr, id, err)
+			}
+		})
+	}
+}
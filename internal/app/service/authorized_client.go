@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// AuthorizedClientRepository finds and revokes the refresh tokens users hold for clients
+type AuthorizedClientRepository interface {
+	ListAuthorizedClients(ctx context.Context, userID uuid.UUID, now int64) ([]*models.AuthorizedClient, error)
+	RevokeByClient(ctx context.Context, userID uuid.UUID, clientID string) (int64, error)
+}
+
+// AuthorizedClientEventRepository writes revoked client access to the notification outbox
+type AuthorizedClientEventRepository interface {
+	Create(ctx context.Context, event *repository.NotificationEventLog) error
+}
+
+// ClientTokenRevoker revokes the access tokens a user holds for a client on every replica
+type ClientTokenRevoker interface {
+	RevokeClient(ctx context.Context, userID, clientID string) error
+}
+
+// AuthorizedClientService is the "connected apps" view of users: the applications they are
+// signed in with, and revoking the access of one of them without signing out of the others
+type AuthorizedClientService struct {
+	refreshTokenRepo AuthorizedClientRepository
+	eventRepo        AuthorizedClientEventRepository
+	txManager        TxManager
+	tokenRevoker     ClientTokenRevoker
+	tokenMaker       token.TokenMaker
+}
+
+// NewAuthorizedClientService creates a new AuthorizedClientService instance
+func NewAuthorizedClientService(
+	refreshTokenRepo AuthorizedClientRepository,
+	eventRepo AuthorizedClientEventRepository,
+	txManager TxManager,
+	tokenRevoker ClientTokenRevoker,
+	tokenMaker token.TokenMaker,
+) *AuthorizedClientService {
+	log.Info("Initializing AuthorizedClientService")
+
+	return &AuthorizedClientService{
+		refreshTokenRepo: refreshTokenRepo,
+		eventRepo:        eventRepo,
+		txManager:        txManager,
+		tokenRevoker:     tokenRevoker,
+		tokenMaker:       tokenMaker,
+	}
+}
+
+// ListAuthorizedClients returns the clients the caller holds active refresh tokens for, the
+// most recently used first
+func (s *AuthorizedClientService) ListAuthorizedClients(ctx context.Context) ([]*models.AuthorizedClient, error) {
+	logger := log.FromContext(ctx).WithField("method", "ListAuthorizedClients")
+
+	userID, err := s.caller(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+	logger = logger.WithField("user_id", userID)
+
+	clients, err := s.refreshTokenRepo.ListAuthorizedClients(ctx, userID, time.Now().UnixMilli())
+	if err != nil {
+		logger.WithError(err).Error("Failed to list authorized clients")
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// RevokeClientAccess signs the caller out of every session of a client: its refresh tokens
+// are revoked along with an outbox event for the client's backend, then its access tokens on
+// every replica. It returns the number of revoked refresh tokens; revoking a client without
+// sessions still revokes its access tokens.
+func (s *AuthorizedClientService) RevokeClientAccess(ctx context.Context, req dto.RevokeClientAccessReq) (int64, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":    "RevokeClientAccess",
+		"client_id": req.ClientID,
+	})
+
+	userID, err := s.caller(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return 0, err
+	}
+	logger = logger.WithField("user_id", userID)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid client access revocation")
+		return 0, err
+	}
+
+	var revoked int64
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		revoked, err = s.refreshTokenRepo.RevokeByClient(txCtx, userID, req.ClientID)
+		if err != nil {
+			return err
+		}
+		return s.publish(txCtx, userID, req.ClientID, revoked)
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to revoke refresh tokens of client")
+		return 0, err
+	}
+
+	if err := s.tokenRevoker.RevokeClient(ctx, userID.String(), req.ClientID); err != nil {
+		logger.WithError(err).Error("Failed to revoke access tokens of client")
+		return 0, err
+	}
+
+	logger.WithField("revoked_refresh_tokens", revoked).Info("Client access revoked")
+
+	return revoked, nil
+}
+
+// caller returns the ID of the authenticated user
+func (s *AuthorizedClientService) caller(ctx context.Context) (uuid.UUID, error) {
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	userID, err := uuid.Parse(caller.UserID)
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidAccessToken
+	}
+	return userID, nil
+}
+
+// publish writes the revocation of a client's access to the outbox
+func (s *AuthorizedClientService) publish(ctx context.Context, userID uuid.UUID, clientID string, revoked int64) error {
+	payload, err := json.Marshal(dto.SendClientAccessRevokedParams{
+		UserID:               userID.String(),
+		ClientID:             clientID,
+		RevokedRefreshTokens: revoked,
+		RevokedAt:            time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.eventRepo.Create(ctx, &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(events.ClientAccessRevokedEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	})
+}
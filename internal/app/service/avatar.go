@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"slices"
+	"strings"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/storage"
+
+	"github.com/google/uuid"
+)
+
+// AvatarStorage pre-signs avatar uploads and inspects and deletes the uploaded objects
+type AvatarStorage interface {
+	PresignPut(key, contentType string, ttl time.Duration) (string, error)
+	Head(ctx context.Context, key string) (*storage.ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// AvatarRepository stores the avatar object key of users
+type AvatarRepository interface {
+	SetAvatarKey(ctx context.Context, id uuid.UUID, key string) (string, error)
+}
+
+// AvatarService lets users upload a profile picture straight to object storage. The service
+// only hands out pre-signed upload URLs and checks the uploaded object before storing its key.
+type AvatarService struct {
+	storage       AvatarStorage
+	userRepo      AvatarRepository
+	tokenMaker    token.TokenMaker
+	cfg           config.AvatarConfig
+	publicBaseURL string
+}
+
+// NewAvatarService creates a new AvatarService instance. A nil storage disables avatars.
+func NewAvatarService(
+	cfg *config.Config,
+	avatarStorage AvatarStorage,
+	userRepo AvatarRepository,
+	tokenMaker token.TokenMaker,
+) *AvatarService {
+	log.Info("Initializing AvatarService")
+
+	return &AvatarService{
+		storage:       avatarStorage,
+		userRepo:      userRepo,
+		tokenMaker:    tokenMaker,
+		cfg:           cfg.Storage.Avatars,
+		publicBaseURL: strings.TrimRight(cfg.Storage.PublicBaseURL, "/"),
+	}
+}
+
+// RequestAvatarUploadURL returns a pre-signed URL the caller uploads an image of the given
+// content type to. The avatar only changes once the upload is confirmed with ConfirmAvatar.
+func (s *AvatarService) RequestAvatarUploadURL(ctx context.Context, contentType string) (*dto.AvatarUploadResp, error) {
+	logger := log.FromContext(ctx).WithField("method", "RequestAvatarUploadURL")
+
+	userID, err := s.caller(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+	logger = logger.WithField("user_id", userID)
+
+	if s.storage == nil {
+		return nil, errs.ErrAvatarsNotConfigured
+	}
+	if !s.allowedContentType(contentType) {
+		logger.WithField("content_type", contentType).Warn("Unsupported avatar content type")
+		return nil, errs.ErrUnsupportedAvatarType
+	}
+
+	key := s.keyPrefix(userID) + uuid.NewString()
+	expiresAt := time.Now().Add(s.cfg.UploadURLTTL)
+	uploadURL, err := s.storage.PresignPut(key, contentType, s.cfg.UploadURLTTL)
+	if err != nil {
+		logger.WithError(err).Error("Failed to pre-sign avatar upload")
+		return nil, err
+	}
+
+	logger.WithField("object_key", key).Info("Avatar upload URL issued")
+
+	return &dto.AvatarUploadResp{
+		UploadURL:   uploadURL,
+		ContentType: contentType,
+		ObjectKey:   key,
+		ExpiresAt:   expiresAt.UnixMilli(),
+	}, nil
+}
+
+// ConfirmAvatar makes an uploaded object the caller's avatar once its content type and size
+// are allowed, and deletes the avatar it replaces. Rejected uploads are deleted.
+func (s *AvatarService) ConfirmAvatar(ctx context.Context, objectKey string) (*dto.AvatarResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":     "ConfirmAvatar",
+		"object_key": objectKey,
+	})
+
+	userID, err := s.caller(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+	logger = logger.WithField("user_id", userID)
+
+	if s.storage == nil {
+		return nil, errs.ErrAvatarsNotConfigured
+	}
+
+	// Only keys handed out to the caller by RequestAvatarUploadURL are accepted
+	uploadID, ok := strings.CutPrefix(objectKey, s.keyPrefix(userID))
+	if _, err := uuid.Parse(uploadID); !ok || err != nil {
+		logger.Warn("Object key is not an avatar upload of the caller")
+		return nil, errs.ErrInvalidAvatarKey
+	}
+
+	info, err := s.storage.Head(ctx, objectKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, errs.ErrAvatarNotUploaded
+		}
+		logger.WithError(err).Error("Failed to inspect avatar upload")
+		return nil, errs.ErrAvatarStorageUnavailable
+	}
+
+	if err := s.checkUpload(info); err != nil {
+		logger.WithError(err).WithFields(log.Fields{
+			"content_type": info.ContentType,
+			"size":         info.Size,
+		}).Warn("Avatar upload rejected")
+		s.deleteObject(ctx, logger, objectKey)
+		return nil, err
+	}
+
+	previous, err := s.userRepo.SetAvatarKey(ctx, userID, objectKey)
+	if err != nil {
+		logger.WithError(err).Error("Failed to store avatar key")
+		return nil, err
+	}
+	if previous != "" && previous != objectKey {
+		s.deleteObject(ctx, logger, previous)
+	}
+
+	logger.Info("Avatar updated")
+
+	return &dto.AvatarResp{ObjectKey: objectKey, AvatarURL: s.avatarURL(objectKey)}, nil
+}
+
+// checkUpload rejects uploads that are not an allowed image or larger than allowed
+func (s *AvatarService) checkUpload(info *storage.ObjectInfo) error {
+	if !s.allowedContentType(info.ContentType) {
+		return errs.ErrUnsupportedAvatarType
+	}
+	if info.Size <= 0 || info.Size > s.cfg.MaxBytes {
+		return errs.ErrAvatarTooLarge
+	}
+	return nil
+}
+
+func (s *AvatarService) allowedContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && slices.Contains(s.cfg.ContentTypes, mediaType)
+}
+
+// deleteObject deletes an avatar object; a failure only leaves an orphaned object behind, so
+// it is logged
+func (s *AvatarService) deleteObject(ctx context.Context, logger *log.Logger, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		logger.WithError(err).WithField("deleted_key", key).Warn("Failed to delete avatar object")
+	}
+}
+
+func (s *AvatarService) keyPrefix(userID uuid.UUID) string {
+	return s.cfg.KeyPrefix + userID.String() + "/"
+}
+
+func (s *AvatarService) avatarURL(key string) string {
+	if s.publicBaseURL == "" {
+		return ""
+	}
+	return s.publicBaseURL + "/" + key
+}
+
+func (s *AvatarService) caller(ctx context.Context) (uuid.UUID, error) {
+	payload, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidAccessToken
+	}
+
+	return userID, nil
+}
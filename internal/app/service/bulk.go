@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// BulkUserRepository changes the role or status of many users at once
+type BulkUserRepository interface {
+	SetStatuses(ctx context.Context, ids []uuid.UUID, status models.UserStatus) (map[uuid.UUID]models.UserStatusChange, error)
+	SetRoles(ctx context.Context, ids []uuid.UUID, role models.UserRole) (map[uuid.UUID]models.UserRole, error)
+}
+
+// SessionRevoker revokes the refresh tokens of a user
+type SessionRevoker interface {
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// BulkService lets admins change the role or status of many users in one transaction,
+// e.g. to lock compromised accounts during an incident
+type BulkService struct {
+	adminKeys        []config.AdminAPIKeyConfig
+	maxUsers         int
+	userRepo         BulkUserRepository
+	refreshTokenRepo SessionRevoker
+	auditRepo        LegalHoldAuditRepository
+	userEvents       UserEventAppender
+	txManager        TxManager
+	tokenRevoker     TokenRevoker
+	pushTokens       PushTokenPruner
+}
+
+// NewBulkService creates a new BulkService instance
+func NewBulkService(
+	cfg *config.Config,
+	userRepo BulkUserRepository,
+	refreshTokenRepo SessionRevoker,
+	auditRepo LegalHoldAuditRepository,
+	userEvents UserEventAppender,
+	txManager TxManager,
+	tokenRevoker TokenRevoker,
+	pushTokens PushTokenPruner,
+) *BulkService {
+	log.Info("Initializing BulkService")
+
+	return &BulkService{
+		adminKeys:        cfg.Admin.APIKeys,
+		maxUsers:         cfg.Admin.MaxBatchUsers,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		auditRepo:        auditRepo,
+		userEvents:       userEvents,
+		txManager:        txManager,
+		tokenRevoker:     tokenRevoker,
+		pushTokens:       pushTokens,
+	}
+}
+
+// BatchAssignRole gives every listed user the role and reports the outcome per user ID. A dry
+// run rolls the transaction back.
+func (s *BulkService) BatchAssignRole(ctx context.Context, req dto.BatchAssignRoleReq) ([]*dto.BatchUserResult, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "BatchAssignRole",
+		"role":    req.Role,
+		"users":   len(req.UserIDs),
+		"dry_run": req.DryRun,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(s.maxUsers); err != nil {
+		logger.WithError(err).Warn("Invalid batch role assignment")
+		return nil, err
+	}
+
+	role := models.UserRole(req.Role)
+	results, ids := batchUserIDs(req.UserIDs)
+
+	var changed []uuid.UUID
+	err = s.txManager.WithDryRunTransaction(ctx, req.DryRun, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		previous, err := s.userRepo.SetRoles(txCtx, ids, role)
+		if err != nil {
+			return err
+		}
+
+		var entries []*models.AuditLog
+		var events []*models.UserEvent
+		for id, previousRole := range previous {
+			if previousRole == role {
+				continue
+			}
+			changed = append(changed, id)
+
+			event, err := models.NewUserEvent(id, models.UserEventRoleGranted, adminActor(admin), models.RoleGrantedData{
+				Role:         role,
+				PreviousRole: previousRole,
+				Reason:       req.Reason,
+			})
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+
+			entry, err := models.NewAuditLog(id, models.AuditActionRoleAssigned, map[string]interface{}{
+				"admin":         admin,
+				"reason":        req.Reason,
+				"role":          role,
+				"previous_role": previousRole,
+			})
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+
+		setBatchOutcomes(results, previous, changed)
+		if err := s.userEvents.Append(txCtx, events...); err != nil {
+			return err
+		}
+		return s.auditRepo.CreateBatch(txCtx, entries)
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to assign roles")
+		return nil, err
+	}
+
+	if req.DryRun {
+		logger.WithField("changed", len(changed)).Info("Role assignment dry run")
+		return results, nil
+	}
+
+	logger.WithField("changed", len(changed)).Info("Roles assigned")
+
+	return results, nil
+}
+
+// BatchUpdateStatus bans or unbans every listed user and reports the outcome per user ID.
+// Banned users lose their sessions: refresh tokens are revoked in the same transaction and
+// access tokens on every replica once it committed. A dry run rolls the transaction back.
+func (s *BulkService) BatchUpdateStatus(ctx context.Context, req dto.BatchUpdateStatusReq) ([]*dto.BatchUserResult, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "BatchUpdateStatus",
+		"status":  req.Status,
+		"users":   len(req.UserIDs),
+		"dry_run": req.DryRun,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(s.maxUsers); err != nil {
+		logger.WithError(err).Warn("Invalid batch status update")
+		return nil, err
+	}
+
+	status := models.UserStatus(req.Status)
+	results, ids := batchUserIDs(req.UserIDs)
+
+	var changed []uuid.UUID
+	err = s.txManager.WithDryRunTransaction(ctx, req.DryRun, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		statusChanges, err := s.userRepo.SetStatuses(txCtx, ids, status)
+		if err != nil {
+			return err
+		}
+
+		eventType := models.UserEventUnbanned
+		if status == models.UserStatusBanned {
+			eventType = models.UserEventBanned
+		}
+
+		var entries []*models.AuditLog
+		var events []*models.UserEvent
+		for id, change := range statusChanges {
+			// Unbanning leaves users that were not banned as they were
+			if change.Current == change.Previous {
+				continue
+			}
+			changed = append(changed, id)
+
+			if status == models.UserStatusBanned {
+				if _, err := s.refreshTokenRepo.RevokeAllByUserID(txCtx, id); err != nil {
+					return err
+				}
+				if _, err := s.pushTokens.PruneUser(txCtx, id); err != nil {
+					return err
+				}
+			}
+
+			event, err := models.NewUserEvent(id, eventType, adminActor(admin), models.StatusChangedData{
+				Status:         change.Current,
+				PreviousStatus: change.Previous,
+				Reason:         req.Reason,
+			})
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+
+			entry, err := models.NewAuditLog(id, models.AuditActionStatusChanged, map[string]interface{}{
+				"admin":           admin,
+				"reason":          req.Reason,
+				"status":          change.Current,
+				"previous_status": change.Previous,
+			})
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+
+		setBatchOutcomes(results, statusChanges, changed)
+		if err := s.userEvents.Append(txCtx, events...); err != nil {
+			return err
+		}
+		return s.auditRepo.CreateBatch(txCtx, entries)
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to update statuses")
+		return nil, err
+	}
+
+	if req.DryRun {
+		logger.WithField("changed", len(changed)).Info("Status update dry run")
+		return results, nil
+	}
+
+	if status == models.UserStatusBanned {
+		for _, id := range changed {
+			// The ban and the revoked refresh tokens already keep the user out once the
+			// access tokens expire, so failures are only logged
+			if err := s.tokenRevoker.RevokeUser(ctx, id.String()); err != nil {
+				logger.WithError(err).WithField("user_id", id.String()).Error("Failed to revoke access tokens of banned user")
+			}
+		}
+	}
+
+	logger.WithField("changed", len(changed)).Info("Statuses updated")
+
+	return results, nil
+}
+
+// batchUserIDs returns a result for every requested user ID, with malformed and repeated IDs
+// already marked invalid, and the distinct valid IDs to update
+func batchUserIDs(userIDs []string) ([]*dto.BatchUserResult, []uuid.UUID) {
+	results := make([]*dto.BatchUserResult, 0, len(userIDs))
+	ids := make([]uuid.UUID, 0, len(userIDs))
+	seen := make(map[uuid.UUID]struct{}, len(userIDs))
+
+	for _, userID := range userIDs {
+		result := &dto.BatchUserResult{UserID: userID}
+		results = append(results, result)
+
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			result.Status, result.Err = dto.BatchItemStatusInvalid, errs.ErrInvalidUserID
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			result.Status, result.Err = dto.BatchItemStatusInvalid, errs.ErrDuplicateBatchUserID
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	return results, ids
+}
+
+// setBatchOutcomes completes the results of the valid IDs from the users the update found
+// and the ones it changed
+func setBatchOutcomes[V any](results []*dto.BatchUserResult, found map[uuid.UUID]V, changed []uuid.UUID) {
+	updated := make(map[uuid.UUID]struct{}, len(changed))
+	for _, id := range changed {
+		updated[id] = struct{}{}
+	}
+
+	for _, result := range results {
+		if result.Status == dto.BatchItemStatusInvalid {
+			continue
+		}
+
+		id := uuid.MustParse(result.UserID)
+		if _, ok := updated[id]; ok {
+			result.Status, result.Err = dto.BatchItemStatusUpdated, nil
+		} else if _, ok := found[id]; ok {
+			result.Status, result.Err = dto.BatchItemStatusUnchanged, nil
+		} else {
+			result.Status, result.Err = dto.BatchItemStatusNotFound, errs.ErrUserNotFound
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// CanaryMonitor raises the alerts of accesses to canary accounts
+type CanaryMonitor interface {
+	Lookup(userID uuid.UUID) (*models.CanaryAccount, bool)
+	Alert(ctx context.Context, account *models.CanaryAccount, access models.CanaryAccess)
+	Tarpit(ctx context.Context, account *models.CanaryAccount)
+}
+
+// CanaryAccountRepository stores the canary accounts
+type CanaryAccountRepository interface {
+	List(ctx context.Context) ([]*models.CanaryAccount, error)
+	Upsert(ctx context.Context, account *models.CanaryAccount) error
+	Delete(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// CanaryReloader applies changed canary accounts on this replica; other replicas pick them
+// up on resync
+type CanaryReloader interface {
+	Reload(ctx context.Context) error
+}
+
+// CanaryAccountService lets the security team flag accounts as canaries for breach detection
+type CanaryAccountService struct {
+	adminKeys  []config.AdminAPIKeyConfig
+	userRepo   UserRepository
+	canaryRepo CanaryAccountRepository
+	reloader   CanaryReloader
+}
+
+// NewCanaryAccountService creates a new CanaryAccountService instance
+func NewCanaryAccountService(
+	cfg *config.Config,
+	userRepo UserRepository,
+	canaryRepo CanaryAccountRepository,
+	reloader CanaryReloader,
+) *CanaryAccountService {
+	log.Info("Initializing CanaryAccountService")
+
+	return &CanaryAccountService{
+		adminKeys:  cfg.Admin.APIKeys,
+		userRepo:   userRepo,
+		canaryRepo: canaryRepo,
+		reloader:   reloader,
+	}
+}
+
+// SetCanaryAccount flags the user as a canary account or changes its note and tarpit
+func (s *CanaryAccountService) SetCanaryAccount(ctx context.Context, req dto.SetCanaryAccountReq) (*models.CanaryAccount, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "SetCanaryAccount",
+		"user_id": req.UserID,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	userID, err := req.Validate()
+	if err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if !errors.Is(err, errs.ErrUserNotFound) {
+			logger.WithError(err).Error("Failed to retrieve user by ID")
+		}
+		return nil, err
+	}
+
+	account := &models.CanaryAccount{
+		UserID:    userID,
+		Note:      req.Note,
+		Tarpit:    req.Tarpit,
+		CreatedBy: adminActor(admin),
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	if err := s.canaryRepo.Upsert(ctx, account); err != nil {
+		logger.WithError(err).Error("Failed to store canary account")
+		return nil, err
+	}
+	s.reload(ctx, logger)
+
+	logger.WithField("tarpit", account.Tarpit).Info("Canary account set")
+
+	return account, nil
+}
+
+// ListCanaryAccounts returns every canary account
+func (s *CanaryAccountService) ListCanaryAccounts(ctx context.Context) ([]*models.CanaryAccount, error) {
+	logger := log.FromContext(ctx).WithField("method", "ListCanaryAccounts")
+
+	if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+
+	accounts, err := s.canaryRepo.List(ctx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list canary accounts")
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// DeleteCanaryAccount removes the canary flag of the user and reports whether there was one
+func (s *CanaryAccountService) DeleteCanaryAccount(ctx context.Context, req dto.DeleteCanaryAccountReq) (bool, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "DeleteCanaryAccount",
+		"user_id": req.UserID,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return false, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	userID, err := req.Validate()
+	if err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return false, err
+	}
+
+	deleted, err := s.canaryRepo.Delete(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to delete canary account")
+		return false, err
+	}
+	if deleted {
+		s.reload(ctx, logger)
+	}
+
+	logger.WithField("deleted", deleted).Info("Canary account deleted")
+
+	return deleted, nil
+}
+
+// reload applies the stored canary accounts on this replica. A failure is only logged since
+// the change is stored and picked up on resync.
+func (s *CanaryAccountService) reload(ctx context.Context, logger *log.Logger) {
+	if err := s.reloader.Reload(ctx); err != nil {
+		logger.WithError(err).Warn("Failed to reload canary accounts, they are picked up on resync")
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/google/uuid"
+)
+
+// ClientRepository looks up registered clients and their token policies
+type ClientRepository interface {
+	GetByID(ctx context.Context, clientID string) (*models.Client, error)
+}
+
+// legacyClient is the policy of refresh tokens issued before clients were registered:
+// service default lifetimes and no rotation
+var legacyClient = &models.Client{
+	AllowedGrantTypes: []models.GrantType{models.GrantTypeRefreshToken},
+}
+
+// resolveClient loads a registered client and checks that it may use the grant type
+func (s *UserService) resolveClient(ctx context.Context, clientID string, grant models.GrantType) (*models.Client, error) {
+	client, err := s.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, errs.ErrClientNotFound) {
+			return nil, errs.ErrInvalidClient
+		}
+		return nil, err
+	}
+
+	if !client.Allows(grant) {
+		return nil, errs.ErrGrantNotAllowed.WithDetail("grant_type", string(grant))
+	}
+
+	return client, nil
+}
+
+// createAccessToken issues an access token of the client with its lifetime in the refresh token
+// session, if any, bound to the DPoP key if any and encrypted if the client's policy asks for it
+func (s *UserService) createAccessToken(user *models.User, client *models.Client, jkt string, sessionID uuid.UUID) (string, error) {
+	ttl := client.AccessTokenDuration(s.config.JWT.AccessTokenDuration)
+
+	accessToken, err := s.tokenMaker.CreateAccessToken(
+		user.ID.String(),
+		user.Username.String(),
+		int64(ttl/time.Second),
+		token.WithClaimProfile(s.claimProfile(client)),
+		token.WithConfirmation(jkt),
+		token.WithRole(string(user.Role)),
+		token.WithOrganization(user.OrganizationID),
+		token.WithResidency(user.Residency),
+		token.WithClient(client.ID),
+		token.WithSession(sessionID),
+		token.WithEmail(user.Email.String()),
+		token.WithStatus(string(user.Status)),
+		token.WithEncryption(client.EncryptAccessTokens),
+	)
+	if err != nil {
+		return "", err
+	}
+	metrics.TokensIssued.WithLabelValues("access", client.ID).Inc()
+
+	return accessToken, nil
+}
+
+// claimProfile returns the claim profile of the client's tokens
+func (s *UserService) claimProfile(client *models.Client) token.ClaimProfile {
+	return client.TokenClaimProfile(token.ClaimProfile(s.config.JWT.ClaimProfile))
+}
+
+// refreshTokenDuration returns the refresh token lifetime of a session: the client's
+// lifetime, capped by the short session lifetime unless the user asked to be remembered
+func (s *UserService) refreshTokenDuration(client *models.Client, rememberMe bool) time.Duration {
+	ttl := client.RefreshTokenDuration(s.config.JWT.RefreshTokenDuration)
+	if !rememberMe && s.config.JWT.ShortRefreshTokenDuration < ttl {
+		return s.config.JWT.ShortRefreshTokenDuration
+	}
+	return ttl
+}
+
+// createRefreshToken issues a refresh token with the session's lifetime. The returned
+// model still has to be stored by the caller.
+func (s *UserService) createRefreshToken(user *models.User, client *models.Client, jkt string, rememberMe bool) (*models.RefreshToken, error) {
+	ttl := s.refreshTokenDuration(client, rememberMe)
+
+	refreshToken, err := s.tokenMaker.CreateRefreshToken(
+		user.ID.String(),
+		user.Username.String(),
+		int64(ttl/time.Second),
+		token.WithClaimProfile(s.claimProfile(client)),
+		token.WithConfirmation(jkt),
+		token.WithResidency(user.Residency),
+	)
+	if err != nil {
+		return nil, err
+	}
+	metrics.TokensIssued.WithLabelValues("refresh", client.ID).Inc()
+
+	model, err := models.NewRefreshToken(user.ID, refreshToken, time.Now().Add(ttl).UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	model.ClientID = client.ID
+	model.DPoPJKT = jkt
+	model.RememberMe = rememberMe
+
+	return model, nil
+}
+
+// createSessionTokens issues the tokens of a new session, the access token in the session of the
+// refresh token. Clients that may not use the refresh token grant only get an access token and a
+// nil refresh token model.
+func (s *UserService) createSessionTokens(user *models.User, client *models.Client, jkt string, rememberMe bool) (string, *models.RefreshToken, error) {
+	var refreshToken *models.RefreshToken
+	sessionID := uuid.Nil
+	if client.Allows(models.GrantTypeRefreshToken) {
+		var err error
+		if refreshToken, err = s.createRefreshToken(user, client, jkt, rememberMe); err != nil {
+			return "", nil, err
+		}
+		sessionID = refreshToken.Session()
+	}
+
+	accessToken, err := s.createAccessToken(user, client, jkt, sessionID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// refreshTokenValue returns the token string of an optional refresh token model
+func refreshTokenValue(refreshToken *models.RefreshToken) string {
+	if refreshToken == nil {
+		return ""
+	}
+	return refreshToken.Token
+}
+
+// refreshTokenExpiry returns the expiry of an optional refresh token model
+func refreshTokenExpiry(refreshToken *models.RefreshToken) int64 {
+	if refreshToken == nil {
+		return 0
+	}
+	return refreshToken.ExpiresAt
+}
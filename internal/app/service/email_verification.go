@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// emailVerificationWindow is the period email_verification.daily_limit caps the links of
+const emailVerificationWindow = 24 * time.Hour
+
+// EmailVerificationTokenRepository stores the tokens of the emailed email verification links
+type EmailVerificationTokenRepository interface {
+	Create(ctx context.Context, verificationToken *models.EmailVerificationToken, sentBefore, windowStartedBefore int64, dailyLimit int) (bool, error)
+	Consume(ctx context.Context, tokenHash string, now int64) (uuid.UUID, error)
+	Delete(ctx context.Context, tokenHash string) error
+}
+
+// ResendVerificationEmail emails the caller a new link verifying the email of the account,
+// voiding the links sent before. Users are emailed at most one link per
+// email_verification.resend_cooldown and email_verification.daily_limit links a day.
+func (s *UserService) ResendVerificationEmail(ctx context.Context) (*dto.ResendVerificationEmailResp, error) {
+	logger := log.FromContext(ctx).WithField("method", "ResendVerificationEmail")
+
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+	logger = logger.WithField("user_id", caller.UserID)
+
+	user, err := s.userRepo.GetByID(ctx, uuid.MustParse(caller.UserID))
+	if err != nil {
+		logger.WithError(err).Warn("Failed to retrieve user")
+		return nil, err
+	}
+
+	if _, err := s.emailClaims.GetByUserID(ctx, user.ID); err == nil {
+		logger.Info("Email is already verified")
+		return nil, errs.ErrEmailAlreadyVerified
+	} else if !errors.Is(err, errs.ErrEmailNotClaimed) {
+		logger.WithError(err).Error("Failed to look up email claim")
+		return nil, err
+	}
+
+	record, err := s.sendVerificationEmail(ctx, user, time.Now())
+	if err != nil {
+		if errors.Is(err, errs.ErrVerificationEmailThrottled) {
+			logger.Warn("Verification emails are throttled")
+		} else {
+			logger.WithError(err).Error("Failed to send verification email")
+		}
+		return nil, err
+	}
+
+	logger.Info("Verification email sent")
+
+	return &dto.ResendVerificationEmailResp{ExpiresAt: record.ExpiresAt}, nil
+}
+
+// sendVerificationEmail emails the user a new link verifying the email of the account, voiding
+// the links sent before, unless email_verification.resend_cooldown or daily_limit throttle it.
+// A token whose email cannot be submitted is deleted again and does not count as a send.
+func (s *UserService) sendVerificationEmail(ctx context.Context, user *models.User, now time.Time) (_ *models.EmailVerificationToken, err error) {
+	cfg := s.config.EmailVerification
+	verificationToken, record, err := models.NewEmailVerificationToken(user.ID, cfg.TokenTTL, now, s.tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.verifications.Create(
+		ctx,
+		record,
+		now.Add(-cfg.ResendCooldown).UnixMilli(),
+		now.Add(-emailVerificationWindow).UnixMilli(),
+		cfg.DailyLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !stored {
+		return nil, errs.ErrVerificationEmailThrottled
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if deleteErr := s.verifications.Delete(ctx, record.TokenHash); deleteErr != nil {
+			log.FromContext(ctx).WithError(deleteErr).WithField("user_id", user.ID.String()).Error("Failed to delete unsent email verification token")
+		}
+	}()
+
+	branding, err := organizationBranding(ctx, s.orgRepo, user.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(dto.SendEmailVerificationParams{
+		UserID:          user.ID.String(),
+		Email:           user.Email.String(),
+		Username:        user.Username.String(),
+		VerificationURL: strings.ReplaceAll(cfg.URL, "{token}", url.QueryEscape(linkToken(verificationToken, user))),
+		ExpiresAt:       time.UnixMilli(record.ExpiresAt),
+		Locale:          requestLocale(ctx),
+		Branding:        branding,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventPipeline.Submit(ctx, &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(events.EmailVerificationRequestedEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	}); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// VerifyEmail verifies the email of an account with the token of a link emailed by
+// ResendVerificationEmail, claiming the email for the user like a registration code would.
+// The token is redeemed in the region and organization of the user it names.
+func (s *UserService) VerifyEmail(ctx context.Context, req dto.VerifyEmailReq) error {
+	logger := log.FromContext(ctx).WithField("method", "VerifyEmail")
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return err
+	}
+
+	ctx, secret, err := withLinkRoute(ctx, s.regions, s.tenants, req.Token, errs.ErrInvalidEmailVerification)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to route email verification to the region of its user")
+		return err
+	}
+
+	// The token is consumed and the email claimed together, so a token is never spent
+	// without verifying the email
+	var userID uuid.UUID
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		var err error
+		userID, err = s.verifications.Consume(txCtx, s.tokens.Hash(secret), time.Now().UnixMilli())
+		if err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.GetByID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		// Users returning after a long absence verify the email they claimed before again
+		if _, err := s.emailClaims.GetByUserID(txCtx, user.ID); err == nil {
+			return s.emailClaims.Reverify(txCtx, user.ID, models.EmailVerifiedByEmailLink, time.Now().UnixMilli())
+		} else if !errors.Is(err, errs.ErrEmailNotClaimed) {
+			return err
+		}
+		claimed, err := s.claimEmail(txCtx, user, models.EmailVerifiedByEmailLink)
+		if err != nil {
+			return err
+		}
+
+		return s.userEvents.Append(txCtx, claimed)
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Email verification failed")
+		return err
+	}
+
+	logger.WithField("user_id", userID.String()).Info("Email verified successfully")
+
+	return nil
+}
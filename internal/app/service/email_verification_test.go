@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/hashing"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+
+	"github.com/google/uuid"
+)
+
+// verificationTokens keeps the verification tokens in a map per route, like the databases
+// and schemas of the regions and organizations
+type verificationTokens struct {
+	tokens map[string]uuid.UUID
+	sends  int
+}
+
+func (r *verificationTokens) Create(ctx context.Context, token *models.EmailVerificationToken, _, _ int64, dailyLimit int) (bool, error) {
+	if r.sends >= dailyLimit {
+		return false, nil
+	}
+	r.tokens[routeOf(ctx)+" "+token.TokenHash] = token.UserID
+	r.sends++
+	return true, nil
+}
+
+func (r *verificationTokens) Delete(ctx context.Context, tokenHash string) error {
+	delete(r.tokens, routeOf(ctx)+" "+tokenHash)
+	r.sends--
+	return nil
+}
+
+func (r *verificationTokens) Consume(ctx context.Context, tokenHash string, _ int64) (uuid.UUID, error) {
+	key := routeOf(ctx) + " " + tokenHash
+	userID, ok := r.tokens[key]
+	if !ok {
+		return uuid.Nil, errs.ErrInvalidEmailVerification
+	}
+	delete(r.tokens, key)
+	return userID, nil
+}
+
+// routedClaims records the route of the claimed emails
+type routedClaims struct {
+	routes []string
+}
+
+func (c *routedClaims) Create(ctx context.Context, _ *models.EmailClaim) error {
+	c.routes = append(c.routes, routeOf(ctx))
+	return nil
+}
+
+func (c *routedClaims) GetByUserID(context.Context, uuid.UUID) (*models.EmailClaim, error) {
+	return nil, errs.ErrEmailNotClaimed
+}
+
+func (c *routedClaims) Reverify(context.Context, uuid.UUID, models.EmailVerificationMethod, int64) error {
+	return nil
+}
+
+// verificationLinks captures the emailed verification links
+type verificationLinks struct {
+	urls []string
+}
+
+func (l *verificationLinks) Submit(_ context.Context, event *repository.NotificationEventLog) error {
+	var params dto.SendEmailVerificationParams
+	if err := json.Unmarshal(event.Payload, &params); err != nil {
+		return err
+	}
+	l.urls = append(l.urls, params.VerificationURL)
+	return nil
+}
+
+func TestVerifyEmail_RoutesToTheRegionOfTheUser(t *testing.T) {
+	user, err := models.NewUser("jane@tickets.example", "hash", "jane_doe")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	user.Residency = "eu"
+	user.OrganizationID = uuid.New()
+	verifications := &verificationTokens{tokens: make(map[string]uuid.UUID)}
+	claims := &routedClaims{}
+	links := &verificationLinks{}
+
+	s := &UserService{
+		config: &config.Config{EmailVerification: config.EmailVerificationConfig{
+			URL:            "https://tickets.example/verify?token={token}",
+			TokenTTL:       time.Hour,
+			ResendCooldown: time.Minute,
+			DailyLimit:     5,
+		}},
+		userRepo:      &benchUsers{user: user},
+		orgRepo:       resetOrgs{},
+		userEvents:    benchSink{},
+		txManager:     benchTx{},
+		verifications: verifications,
+		emailClaims:   claims,
+		eventPipeline: links,
+		regions:       taggingRegions{},
+		tenants:       taggingTenants{},
+		tokens:        hashing.NewTokenHasher(),
+	}
+
+	// The link is sent from a request routed by the user's access token
+	ctx := context.WithValue(context.WithValue(context.Background(), regionKey{}, "eu"), tenantKey{}, user.OrganizationID)
+	if _, err := s.sendVerificationEmail(ctx, user, time.Now()); err != nil {
+		t.Fatalf("Expected the link to be sent, got %v", err)
+	}
+	if len(links.urls) != 1 {
+		t.Fatalf("Expected a verification link, got %v", links.urls)
+	}
+	link, err := url.Parse(links.urls[0])
+	if err != nil {
+		t.Fatalf("Failed to parse verification link: %v", err)
+	}
+
+	// The link page sends nothing but the token
+	if err := s.VerifyEmail(context.Background(), dto.VerifyEmailReq{Token: link.Query().Get("token")}); err != nil {
+		t.Fatalf("Expected the email to be verified, got %v", err)
+	}
+	if want := routeOf(ctx); len(claims.routes) != 1 || claims.routes[0] != want {
+		t.Errorf("Expected the email to be claimed in %s, got %v", want, claims.routes)
+	}
+}
+
+func TestSendVerificationEmail_DeletesUnsentToken(t *testing.T) {
+	user, err := models.NewUser("jane@tickets.example", "hash", "jane_doe")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	verifications := &verificationTokens{tokens: make(map[string]uuid.UUID)}
+
+	s := &UserService{
+		config: &config.Config{EmailVerification: config.EmailVerificationConfig{
+			URL:            "https://tickets.example/verify?token={token}",
+			TokenTTL:       time.Hour,
+			ResendCooldown: time.Minute,
+			DailyLimit:     1,
+		}},
+		verifications: verifications,
+		eventPipeline: failingSink{},
+		tokens:        hashing.NewTokenHasher(),
+	}
+
+	if _, err := s.sendVerificationEmail(context.Background(), user, time.Now()); err == nil {
+		t.Fatal("Expected the unsubmitted link to fail")
+	}
+	if len(verifications.tokens) != 0 || verifications.sends != 0 {
+		t.Errorf("Expected the unsent token to be deleted and its send given back, got %d tokens and %d sends", len(verifications.tokens), verifications.sends)
+	}
+
+	// The retry is not throttled by the send that never happened
+	s.eventPipeline = &verificationLinks{}
+	if _, err := s.sendVerificationEmail(context.Background(), user, time.Now()); err != nil {
+		t.Errorf("Expected the retry to be sent, got %v", err)
+	}
+}
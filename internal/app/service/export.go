@@ -0,0 +1,140 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+)
+
+// UserExportRepository pages through users in creation order
+type UserExportRepository interface {
+	ListForExport(
+		ctx context.Context,
+		filter models.UserExportFilter,
+		afterCreatedAt int64,
+		afterID uuid.UUID,
+		limit int,
+	) ([]*models.User, error)
+}
+
+// ExportService streams users to analytics consumers holding an admin API key
+type ExportService struct {
+	adminKeys        []config.AdminAPIKeyConfig
+	userRepo         UserExportRepository
+	chunkSize        int
+	maxRowsPerSecond int
+}
+
+// NewExportService creates a new ExportService instance
+func NewExportService(cfg *config.Config, userRepo UserExportRepository) *ExportService {
+	log.Info("Initializing ExportService")
+
+	return &ExportService{
+		adminKeys:        cfg.Admin.APIKeys,
+		userRepo:         userRepo,
+		chunkSize:        cfg.Export.ChunkSize,
+		maxRowsPerSecond: cfg.Export.MaxRowsPerSecond,
+	}
+}
+
+// ExportUsers pages through the users matching the request and hands them to send in chunks,
+// no faster than the requested or configured rate. It stops at the first send error.
+func (s *ExportService) ExportUsers(
+	ctx context.Context,
+	req dto.ExportUsersReq,
+	send func(chunk *models.UserExportChunk) error,
+) error {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":       "ExportUsers",
+		"created_from": req.CreatedFrom,
+		"created_to":   req.CreatedTo,
+		"format":       req.Format,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid export request")
+		return err
+	}
+
+	rowsPerSecond := s.maxRowsPerSecond
+	if req.RowsPerSecond > 0 && req.RowsPerSecond < rowsPerSecond {
+		rowsPerSecond = req.RowsPerSecond
+	}
+	// A chunk is released at once, so it must fit in the limiter's burst
+	chunkSize := min(s.chunkSize, rowsPerSecond)
+	limiter := rate.NewLimiter(rate.Limit(rowsPerSecond), chunkSize)
+
+	format := req.ExportFormat()
+	fields := req.ExportFields()
+	filter := req.Filter()
+
+	var exported int
+	afterCreatedAt, afterID := int64(-1), uuid.Nil
+	for {
+		users, err := s.userRepo.ListForExport(ctx, filter, afterCreatedAt, afterID, chunkSize)
+		if err != nil {
+			logger.WithError(err).Error("Failed to list users for export")
+			return err
+		}
+		if len(users) == 0 {
+			break
+		}
+
+		if err := limiter.WaitN(ctx, len(users)); err != nil {
+			return err
+		}
+
+		chunk := &models.UserExportChunk{Users: users}
+		if format == models.UserExportFormatNDJSON {
+			if chunk.NDJSON, err = encodeNDJSON(users, fields); err != nil {
+				return err
+			}
+		}
+
+		if err := send(chunk); err != nil {
+			logger.WithError(err).WithField("exported", exported).Warn("Export stream interrupted")
+			return err
+		}
+		exported += len(users)
+		metrics.ExportedUsers.WithLabelValues(string(format)).Add(float64(len(users)))
+
+		last := users[len(users)-1]
+		afterCreatedAt, afterID = last.CreatedAt, last.ID
+		if len(users) < chunkSize {
+			break
+		}
+	}
+
+	logger.WithField("exported", exported).Info("Users exported")
+
+	return nil
+}
+
+// encodeNDJSON writes the selected fields of each user as one JSON object per line
+func encodeNDJSON(users []*models.User, fields []models.UserExportField) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, user := range users {
+		if err := encoder.Encode(models.ExportRecord(user, fields)); err != nil {
+			return nil, fmt.Errorf("failed to encode exported user: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
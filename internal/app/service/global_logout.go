@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// globalLogoutBatchSize bounds the refresh tokens revoked per statement, so a global logout
+// never locks the whole refresh token table
+const globalLogoutBatchSize = 1000
+
+// GlobalRevoker revokes every token issued up to a cutoff on every replica
+type GlobalRevoker interface {
+	RevokeAll(ctx context.Context, cutoff, expiresAt time.Time) error
+}
+
+// GlobalLogoutRefreshTokenRepository revokes the refresh tokens of every user in batches
+type GlobalLogoutRefreshTokenRepository interface {
+	RevokeIssuedBeforeBatch(ctx context.Context, cutoff int64, limit int) (int64, error)
+}
+
+// GlobalLogoutRepository records global logouts
+type GlobalLogoutRepository interface {
+	Create(ctx context.Context, logout *models.GlobalLogout) error
+	Complete(ctx context.Context, id uuid.UUID, refreshTokensRevoked, completedAt int64) error
+}
+
+// GlobalLogoutService is the kill switch for incidents such as a leaked signing key: it logs
+// out every user by rejecting all tokens issued up to a cutoff
+type GlobalLogoutService struct {
+	adminKeys []config.AdminAPIKeyConfig
+	// tokenLifetime is how long any token can be valid, and so how long a cutoff applies
+	tokenLifetime    time.Duration
+	revoker          GlobalRevoker
+	refreshTokenRepo GlobalLogoutRefreshTokenRepository
+	logoutRepo       GlobalLogoutRepository
+}
+
+// NewGlobalLogoutService creates a new GlobalLogoutService instance
+func NewGlobalLogoutService(
+	cfg *config.Config,
+	revoker GlobalRevoker,
+	refreshTokenRepo GlobalLogoutRefreshTokenRepository,
+	logoutRepo GlobalLogoutRepository,
+) *GlobalLogoutService {
+	log.Info("Initializing GlobalLogoutService")
+
+	return &GlobalLogoutService{
+		adminKeys:        cfg.Admin.APIKeys,
+		tokenLifetime:    max(cfg.JWT.AccessTokenDuration, cfg.JWT.RefreshTokenDuration, cfg.JWT.ShortRefreshTokenDuration),
+		revoker:          revoker,
+		refreshTokenRepo: refreshTokenRepo,
+		logoutRepo:       logoutRepo,
+	}
+}
+
+// GlobalLogout revokes every access and refresh token issued up to the cutoff. The logout is
+// recorded first, then the cutoff is broadcast so every replica rejects the tokens within
+// seconds, and finally the stored refresh tokens are revoked in batches. The batches run to
+// completion even if the caller goes away.
+func (s *GlobalLogoutService) GlobalLogout(ctx context.Context, req dto.GlobalLogoutReq) (*models.GlobalLogout, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "GlobalLogout",
+		"cutoff": req.Cutoff,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	now := time.Now()
+	cutoff, err := req.Validate(now)
+	if err != nil {
+		logger.WithError(err).Warn("Invalid global logout request")
+		return nil, err
+	}
+
+	logout := &models.GlobalLogout{
+		ID:        uuid.New(),
+		Admin:     admin,
+		Reason:    req.Reason,
+		Cutoff:    cutoff.UnixMilli(),
+		CreatedAt: now.UnixMilli(),
+	}
+	logger = logger.WithFields(log.Fields{
+		"logout_id": logout.ID.String(),
+		"cutoff":    logout.Cutoff,
+		"reason":    logout.Reason,
+	})
+	if err := s.logoutRepo.Create(ctx, logout); err != nil {
+		logger.WithError(err).Error("Failed to record global logout")
+		return nil, err
+	}
+	logger.Warn("Global logout started")
+
+	if err := s.revoker.RevokeAll(ctx, cutoff, cutoff.Add(s.tokenLifetime)); err != nil {
+		logger.WithError(err).Error("Failed to revoke tokens issued before the cutoff")
+		return nil, err
+	}
+
+	// The cutoff already rejects the remaining refresh tokens, revoking them is bookkeeping
+	ctx = context.WithoutCancel(ctx)
+	for {
+		revoked, err := s.refreshTokenRepo.RevokeIssuedBeforeBatch(ctx, logout.Cutoff, globalLogoutBatchSize)
+		if err != nil {
+			logger.WithError(err).WithField("revoked", logout.RefreshTokensRevoked).Error("Failed to revoke refresh tokens")
+			return nil, err
+		}
+		logout.RefreshTokensRevoked += revoked
+		if revoked < globalLogoutBatchSize {
+			break
+		}
+	}
+
+	logout.CompletedAt = time.Now().UnixMilli()
+	if err := s.logoutRepo.Complete(ctx, logout.ID, logout.RefreshTokensRevoked, logout.CompletedAt); err != nil {
+		logger.WithError(err).Error("Failed to record global logout completion")
+		return nil, err
+	}
+
+	logger.WithField("refresh_tokens_revoked", logout.RefreshTokensRevoked).Warn("Global logout completed")
+
+	return logout, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// ClaimGuestActivityScope is the service key scope required to claim guest activity
+const ClaimGuestActivityScope = "guest_activity:claim"
+
+// EmailClaimCreator stores the claims of users on the emails they verified
+type EmailClaimCreator interface {
+	Create(ctx context.Context, claim *models.EmailClaim) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*models.EmailClaim, error)
+	Reverify(ctx context.Context, userID uuid.UUID, method models.EmailVerificationMethod, verifiedAt int64) error
+}
+
+// EmailClaimRepository claims the guest activity under verified emails
+type EmailClaimRepository interface {
+	ClaimActivity(ctx context.Context, userID uuid.UUID, email models.Email, caller string, now int64) (*models.EmailClaim, bool, error)
+}
+
+// claimEmail stores, in the transaction of txCtx, that the user verified owning the email of
+// the account, and returns the user.email_claimed event to append with it
+func (s *UserService) claimEmail(txCtx context.Context, user *models.User, method models.EmailVerificationMethod) (*models.UserEvent, error) {
+	claim := models.NewEmailClaim(user.ID, user.Email, method, time.Now().UnixMilli())
+	if err := s.emailClaims.Create(txCtx, claim); err != nil {
+		return nil, err
+	}
+
+	return models.NewUserEvent(user.ID, models.UserEventEmailClaimed, models.UserEventActorSelf, models.EmailClaimedData{
+		Email:      claim.Email.String(),
+		VerifiedBy: method,
+	})
+}
+
+// GuestActivityService lets internal services attach activity made as a guest, e.g. bookings
+// of a checkout without an account, to the account later created with the same email. Only
+// accounts that verified owning the email can claim it.
+type GuestActivityService struct {
+	serviceKeys []config.ServiceAPIKeyConfig
+	claims      EmailClaimRepository
+}
+
+// NewGuestActivityService creates a new GuestActivityService instance
+func NewGuestActivityService(cfg *config.Config, claims EmailClaimRepository) *GuestActivityService {
+	log.Info("Initializing GuestActivityService")
+
+	return &GuestActivityService{
+		serviceKeys: cfg.Services.APIKeys,
+		claims:      claims,
+	}
+}
+
+// ClaimGuestActivity confirms that the user verified owning the email and marks the guest
+// activity under it as claimed. Only the first call claims it; later ones, e.g. retries,
+// return the claim marked as already claimed, so the activity is attached once.
+func (s *GuestActivityService) ClaimGuestActivity(ctx context.Context, req dto.ClaimGuestActivityReq) (*dto.ClaimGuestActivityResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "ClaimGuestActivity",
+		"user_id": req.UserID,
+	})
+
+	caller, err := authorizeService(ctx, s.serviceKeys, ClaimGuestActivityScope)
+	if err != nil {
+		logger.WithError(err).Warn("Service authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("caller", caller)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid guest activity claim request")
+		return nil, err
+	}
+
+	claim, claimed, err := s.claims.ClaimActivity(ctx, uuid.MustParse(req.UserID), models.Email(req.Email), caller, time.Now().UnixMilli())
+	if err != nil {
+		logger.WithError(err).Warn("Failed to claim guest activity")
+		return nil, err
+	}
+
+	if claimed {
+		logger.Info("Guest activity claimed")
+	}
+
+	return &dto.ClaimGuestActivityResp{Claim: claim, AlreadyClaimed: !claimed}, nil
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/dpop"
+	"user-svc/pkg/utils/crypt/oidc"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+)
+
+// IDTokenVerifier verifies the ID tokens of the configured OpenID Connect providers
+type IDTokenVerifier interface {
+	Verify(ctx context.Context, provider, rawIDToken, nonce string) (*oidc.Claims, error)
+}
+
+// UserIdentityRepository links users to their identities at OpenID Connect providers
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *models.UserIdentity) error
+	Get(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+}
+
+// LoginWithIDToken logs a user in with the ID token of a configured OpenID Connect provider.
+// The identity must be linked to an account, or the provider must link it on its first login
+// to the account whose email the provider verified and the user proved owning.
+func (s *UserService) LoginWithIDToken(ctx context.Context, req dto.LoginWithIDTokenReq) (resp *dto.LoginResp, err error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":      "LoginWithIDToken",
+		"provider":    req.Provider,
+		"remember_me": req.RememberMe,
+	})
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return nil, err
+	}
+
+	// Every attempt past validation is streamed to the SIEM and kept in the login history,
+	// whatever stops it
+	var user *models.User
+	defer func() {
+		s.recordSecurityEvent(ctx, s.securityEvent(ctx, models.SecurityActionIDTokenLogin, user, "", req.ClientID), err)
+		s.recordLoginAttempt(ctx, user, req.ClientID, err)
+	}()
+
+	client, err := s.resolveClient(ctx, req.ClientID, models.GrantTypeIDToken)
+	if err != nil {
+		logger.WithError(err).Warn("Client is not allowed to log users in with ID tokens")
+		return nil, err
+	}
+
+	claims, err := s.idTokens.Verify(ctx, req.Provider, req.IDToken, req.Nonce)
+	if err != nil {
+		logger.WithError(err).Warn("ID token verification failed")
+		switch {
+		case errors.Is(err, oidc.ErrUnknownProvider):
+			return nil, errs.ErrUnknownIdentityProvider
+		case errors.Is(err, oidc.ErrUnavailable):
+			return nil, errs.ErrIdentityProviderUnavailable
+		case errors.Is(err, oidc.ErrInvalidIDToken):
+			return nil, errs.ErrInvalidIDToken
+		}
+		return nil, err
+	}
+
+	// The identity and the account are in the database of the region of the provider's email
+	if claims.Email != "" {
+		if ctx, err = withEmailRegion(ctx, s.regions, s.emailDirectory, claims.Email); err != nil {
+			logger.WithError(err).Error("Failed to route login to the region of the email")
+			return nil, err
+		}
+	}
+
+	user, err = s.identityUser(ctx, logger, req.Provider, claims)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID.String())
+
+	// Canary accounts alert on every attempt, but the login then goes on as for any account
+	if canary, ok := s.canaries.Lookup(user.ID); ok {
+		access := s.canaryAccess(ctx, models.CanaryAccessLogin, req.ClientID)
+		s.canaries.Alert(ctx, canary, access)
+		s.recordCanaryAccess(ctx, canary, access)
+		s.canaries.Tarpit(ctx, canary)
+	}
+
+	if err := user.CheckCanAuthenticate(); err != nil {
+		logger.WithField("status", user.Status).Warn("User may not log in")
+		return nil, err
+	}
+
+	if err := s.loginSchedules.CheckLoginSchedule(ctx, user, time.Now()); err != nil {
+		logger.WithError(err).Warn("Login outside the login schedule")
+		return nil, err
+	}
+
+	if err := s.checkEmailReverification(ctx, logger, user); err != nil {
+		return nil, err
+	}
+
+	jkt, _ := dpop.FromContext(ctx)
+	accessToken, refreshTokenModel, err := s.createSessionTokens(user, client, jkt, req.RememberMe)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create session tokens")
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		if err := s.userRepo.RecordLogin(txCtx, user.ID, time.Now().UnixMilli()); err != nil {
+			return err
+		}
+		if refreshTokenModel == nil {
+			return nil
+		}
+		return s.refreshTokenRepo.Create(txCtx, refreshTokenModel)
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to record login")
+		return nil, err
+	}
+
+	logger.Info("User login with ID token completed successfully")
+
+	s.sessions.StartSession(ctx, refreshTokenModel)
+
+	device := deviceMetadata(ctx)
+	if err := s.submitLoginNotification(ctx, logger, user, device); err != nil {
+		return nil, err
+	}
+
+	device["provider"] = req.Provider
+	s.recordAudit(ctx, logger, user.ID, user.OrganizationID, models.AuditActionUserLoggedIn, device)
+
+	return &dto.LoginResp{
+		User:                  user,
+		AccessToken:           accessToken,
+		RefreshToken:          refreshTokenValue(refreshTokenModel),
+		RefreshTokenExpiresAt: refreshTokenExpiry(refreshTokenModel),
+	}, nil
+}
+
+// identityUser returns the user linked to the identity of the claims. An unlinked identity is
+// linked to the account with the email the provider verified if the provider is configured to,
+// and the user proved owning the email; otherwise a preregistered account could capture it.
+func (s *UserService) identityUser(ctx context.Context, logger *log.Logger, provider string, claims *oidc.Claims) (*models.User, error) {
+	identity, err := s.identities.Get(ctx, provider, claims.Subject)
+	if err == nil {
+		return s.userRepo.GetByID(ctx, identity.UserID)
+	}
+	if !errors.Is(err, errs.ErrIdentityNotFound) {
+		logger.WithError(err).Error("Failed to look up identity")
+		return nil, err
+	}
+
+	providerCfg := s.config.OIDC.FindProvider(provider)
+	if providerCfg == nil || !providerCfg.LinkVerifiedEmail || !claims.EmailVerified || claims.Email == "" {
+		logger.Warn("Identity is not linked to an account")
+		return nil, errs.ErrIdentityNotLinked
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, claims.Email)
+	if err != nil {
+		if errors.Is(err, errs.ErrUserNotFound) {
+			logger.Warn("No account has the verified email of the identity")
+			return nil, errs.ErrIdentityNotLinked
+		}
+		logger.WithError(err).Error("Failed to retrieve user by email")
+		return nil, err
+	}
+
+	claim, err := s.emailClaims.GetByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, errs.ErrEmailNotClaimed) {
+		logger.WithError(err).Error("Failed to look up email claim")
+		return nil, err
+	}
+	if claim == nil || claim.Email != user.Email {
+		logger.WithField("user_id", user.ID.String()).Warn("Account email is not verified, identity not linked")
+		return nil, errs.ErrIdentityNotLinked
+	}
+
+	if err := s.identities.Create(ctx, models.NewUserIdentity(provider, claims.Subject, user.ID, time.Now().UnixMilli())); err != nil {
+		logger.WithError(err).Error("Failed to link identity")
+		return nil, err
+	}
+
+	// A concurrent first login may have linked the identity to another account
+	identity, err = s.identities.Get(ctx, provider, claims.Subject)
+	if err != nil {
+		logger.WithError(err).Error("Failed to look up linked identity")
+		return nil, err
+	}
+	if identity.UserID != user.ID {
+		return s.userRepo.GetByID(ctx, identity.UserID)
+	}
+
+	logger.WithField("user_id", user.ID.String()).Info("Identity linked to the account with its verified email")
+	s.recordAudit(ctx, logger, user.ID, user.OrganizationID, models.AuditActionIdentityLinked, map[string]interface{}{
+		"provider": provider,
+	})
+
+	return user, nil
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// ImportUserRepository creates imported users
+type ImportUserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+}
+
+// PasswordSetupTokenRepository stores the tokens invited users set their first password with
+type PasswordSetupTokenRepository interface {
+	Create(ctx context.Context, setupToken *models.PasswordSetupToken) error
+	Consume(ctx context.Context, tokenHash string, now int64) (uuid.UUID, error)
+}
+
+// InvitationEventRepository writes invitation events to the notification outbox
+type InvitationEventRepository interface {
+	Create(ctx context.Context, event *repository.NotificationEventLog) error
+}
+
+// WorkflowTimerScheduler schedules the durable timers of multi-step flows
+type WorkflowTimerScheduler interface {
+	Schedule(ctx context.Context, timer *models.WorkflowTimer) error
+}
+
+// invitationReminder is the payload of the invitation reminder timer
+type invitationReminder struct {
+	UserID string `json:"userId"`
+	Email  string `json:"email"`
+}
+
+// ImportService lets admins bulk-create invited users who set their password on first login,
+// and migrate the accounts of the legacy ticketing system with their password hashes
+type ImportService struct {
+	adminKeys      []config.AdminAPIKeyConfig
+	cfg            config.ImportConfig
+	userRepo       ImportUserRepository
+	orgRepo        OrganizationReader
+	setupRepo      PasswordSetupTokenRepository
+	eventRepo      InvitationEventRepository
+	auditRepo      LegalHoldAuditRepository
+	userEvents     UserEventAppender
+	timers         WorkflowTimerScheduler
+	txManager      TxManager
+	residency      config.ResidencyConfig
+	regions        RegionRouter
+	tokens         models.TokenHasher
+	emailDirectory EmailDirectory
+	now            func() time.Time
+}
+
+// NewImportService creates a new ImportService instance
+func NewImportService(
+	cfg *config.Config,
+	userRepo ImportUserRepository,
+	orgRepo OrganizationReader,
+	setupRepo PasswordSetupTokenRepository,
+	eventRepo InvitationEventRepository,
+	auditRepo LegalHoldAuditRepository,
+	userEvents UserEventAppender,
+	timers WorkflowTimerScheduler,
+	txManager TxManager,
+	regions RegionRouter,
+	tokens models.TokenHasher,
+	emailDirectory EmailDirectory,
+) *ImportService {
+	log.Info("Initializing ImportService")
+
+	return &ImportService{
+		adminKeys:      cfg.Admin.APIKeys,
+		cfg:            cfg.Import,
+		userRepo:       userRepo,
+		orgRepo:        orgRepo,
+		setupRepo:      setupRepo,
+		eventRepo:      eventRepo,
+		auditRepo:      auditRepo,
+		userEvents:     userEvents,
+		timers:         timers,
+		txManager:      txManager,
+		residency:      cfg.Residency,
+		regions:        regions,
+		tokens:         tokens,
+		emailDirectory: emailDirectory,
+		now:            time.Now,
+	}
+}
+
+// ImportUsers reads batches from recv until it returns io.EOF and hands the result of every
+// record of a batch to send. Records are imported independently: an invalid or duplicate
+// record is reported in its result and does not stop the import.
+func (s *ImportService) ImportUsers(
+	ctx context.Context,
+	recv func() (*dto.ImportUsersReq, error),
+	send func(results []*dto.ImportUserResult) error,
+) error {
+	logger := log.FromContext(ctx).WithField("method", "ImportUsers")
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return err
+	}
+	logger = logger.WithField("admin", admin)
+
+	// Emails seen earlier in the stream, so a repeated record is reported instead of failing on insert
+	seen := make(map[models.Email]struct{})
+	counts := make(map[dto.ImportUserStatus]int)
+	row := 0
+	for {
+		req, err := recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := req.Validate(s.cfg.MaxBatchSize); err != nil {
+			logger.WithError(err).Warn("Invalid import batch")
+			return err
+		}
+
+		results := make([]*dto.ImportUserResult, 0, len(req.Users))
+		for _, rec := range req.Users {
+			result := s.importUser(ctx, admin, seen, rec)
+			result.Row = row
+			row++
+
+			if result.Status == dto.ImportUserStatusFailed {
+				logger.WithError(result.Err).WithField("row", result.Row).Error("Failed to import user")
+			}
+			counts[result.Status]++
+			metrics.ImportedUsers.WithLabelValues(string(result.Status)).Inc()
+			results = append(results, result)
+		}
+
+		if err := send(results); err != nil {
+			logger.WithError(err).WithField("rows", row).Warn("Import stream interrupted")
+			return err
+		}
+	}
+
+	logger.WithFields(log.Fields{
+		"rows":      row,
+		"created":   counts[dto.ImportUserStatusCreated],
+		"duplicate": counts[dto.ImportUserStatusDuplicate],
+		"invalid":   counts[dto.ImportUserStatusInvalid],
+		"failed":    counts[dto.ImportUserStatusFailed],
+	}).Info("Users imported")
+
+	return nil
+}
+
+// importUser creates one user along with the audit entry in a single transaction. Invited
+// users also get the setup token, the invitation event and the reminder timer.
+func (s *ImportService) importUser(
+	ctx context.Context,
+	admin string,
+	seen map[models.Email]struct{},
+	rec dto.ImportUserRecord,
+) *dto.ImportUserResult {
+	result := &dto.ImportUserResult{Email: rec.Email}
+
+	if err := rec.Validate(); err != nil {
+		return failImport(result, err)
+	}
+
+	user, err := newImportedUser(rec)
+	if err != nil {
+		return failImport(result, err)
+	}
+	result.Email = user.Email.String()
+
+	if _, ok := seen[user.Email]; ok {
+		return failImport(result, errs.ErrDuplicateImportRow)
+	}
+	seen[user.Email] = struct{}{}
+
+	org, err := joinOrganization(ctx, s.orgRepo, rec.OrganizationID, user)
+	if err != nil {
+		return failImport(result, err)
+	}
+
+	// Imports run without the user's country, so users are tagged with the region of their
+	// organization or the home region. The invitation and audit entries of a user are written
+	// in one transaction with the user, which cannot span two databases.
+	user.Residency = residencyOf(s.residency, org, "")
+	if s.regions.HasDatabase(user.Residency) {
+		return failImport(result, errs.ErrRegionalImportNotSupported.WithDetail("region", user.Residency))
+	}
+
+	// Migrated users log in with their legacy password, only invited users get a setup link
+	var setupTokenModel *models.PasswordSetupToken
+	var invitation *repository.NotificationEventLog
+	details := map[string]interface{}{"admin": admin}
+	if rec.LegacyPassword != nil {
+		details["legacy_hash_format"] = rec.LegacyPassword.Format
+	} else {
+		var setupToken string
+		setupToken, setupTokenModel, err = models.NewPasswordSetupToken(user.ID, s.cfg.SetupTokenTTL, s.now(), s.tokens)
+		if err != nil {
+			return failImport(result, err)
+		}
+
+		invitation, err = s.invitationEvent(ctx, user, setupToken, setupTokenModel, false)
+		if err != nil {
+			return failImport(result, err)
+		}
+	}
+
+	entry, err := models.NewAuditLog(user.ID, models.AuditActionUserImported, details)
+	if err != nil {
+		return failImport(result, err)
+	}
+
+	event, err := models.NewUserCreatedEvent(user, models.UserEventImported, adminActor(admin))
+	if err != nil {
+		return failImport(result, err)
+	}
+
+	// Like registrations, imports claim the email in the directory of every region's users
+	claimed, err := s.emailDirectory.Claim(ctx, user)
+	if err != nil {
+		return failImport(result, err)
+	}
+	if !claimed {
+		return failImport(result, errs.ErrUserExists)
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		if err := s.userRepo.Create(txCtx, user); err != nil {
+			return err
+		}
+		if err := s.userEvents.Append(txCtx, event); err != nil {
+			return err
+		}
+		if err := s.auditRepo.CreateBatch(txCtx, []*models.AuditLog{entry}); err != nil {
+			return err
+		}
+		if invitation == nil {
+			return nil
+		}
+
+		if err := s.setupRepo.Create(txCtx, setupTokenModel); err != nil {
+			return err
+		}
+		if err := s.eventRepo.Create(txCtx, invitation); err != nil {
+			return err
+		}
+		return s.scheduleReminder(txCtx, user, setupTokenModel)
+	})
+	if err != nil {
+		if releaseErr := s.emailDirectory.Release(ctx, user); releaseErr != nil {
+			log.FromContext(ctx).WithError(releaseErr).WithField("user_id", user.ID.String()).Error("Failed to release email")
+		}
+		return failImport(result, err)
+	}
+
+	result.User = user
+	result.Status = dto.ImportUserStatusCreated
+
+	return result
+}
+
+// newImportedUser creates an invited user, or an active user with the legacy password hash
+// of a migrated account, which is upgraded to bcrypt on the user's first login
+func newImportedUser(rec dto.ImportUserRecord) (*models.User, error) {
+	if rec.LegacyPassword == nil {
+		return models.NewInvitedUser(rec.Email, rec.Username)
+	}
+
+	passwordHash, err := rec.LegacyPassword.PasswordHash()
+	if err != nil {
+		return nil, err
+	}
+	return models.NewUser(rec.Email, passwordHash.String(), rec.Username)
+}
+
+// SendInvitationReminder fires the invitation reminder timer: an imported user who still has
+// not set a password gets a new setup link, replacing the one about to expire. Users who
+// set a password or were deleted in the meantime are left alone.
+func (s *ImportService) SendInvitationReminder(ctx context.Context, timer *models.WorkflowTimer) error {
+	var reminder invitationReminder
+	if err := json.Unmarshal(timer.Payload, &reminder); err != nil {
+		return fmt.Errorf("failed to decode invitation reminder: %w", err)
+	}
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "SendInvitationReminder",
+		"user_id": reminder.UserID,
+	})
+
+	user, err := s.userRepo.GetByEmail(ctx, reminder.Email)
+	if errors.Is(err, errs.ErrUserNotFound) || (err == nil && (user.ID.String() != reminder.UserID || user.Status != models.UserStatusInvited)) {
+		logger.Debug("Invitation no longer pending, no reminder sent")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	setupToken, setupTokenModel, err := models.NewPasswordSetupToken(user.ID, s.cfg.SetupTokenTTL, s.now(), s.tokens)
+	if err != nil {
+		return err
+	}
+	invitation, err := s.invitationEvent(ctx, user, setupToken, setupTokenModel, true)
+	if err != nil {
+		return err
+	}
+
+	// ctx carries the timer's transaction, so the new token and email replace the old ones
+	// only if the timer fires
+	if err := s.setupRepo.Create(ctx, setupTokenModel); err != nil {
+		return err
+	}
+	if err := s.eventRepo.Create(ctx, invitation); err != nil {
+		return err
+	}
+
+	logger.Info("Invitation reminder sent")
+
+	return nil
+}
+
+// invitationEvent builds the outbox event emailing the setup link to an invited user, branded
+// for the user's organization
+func (s *ImportService) invitationEvent(
+	ctx context.Context,
+	user *models.User,
+	setupToken string,
+	setupTokenModel *models.PasswordSetupToken,
+	reminder bool,
+) (*repository.NotificationEventLog, error) {
+	branding, err := organizationBranding(ctx, s.orgRepo, user.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(dto.SendUserInvitationParams{
+		UserID:    user.ID.String(),
+		Email:     user.Email.String(),
+		Username:  user.Username.String(),
+		SetupURL:  strings.ReplaceAll(s.cfg.SetupURL, "{token}", url.QueryEscape(setupToken)),
+		ExpiresAt: time.UnixMilli(setupTokenModel.ExpiresAt),
+		Reminder:  reminder,
+		Branding:  branding,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(events.UserInvitedEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	}, nil
+}
+
+// scheduleReminder starts the reminder timer of a new invitation, unless reminders are disabled
+func (s *ImportService) scheduleReminder(ctx context.Context, user *models.User, setupTokenModel *models.PasswordSetupToken) error {
+	if s.cfg.ReminderBefore <= 0 {
+		return nil
+	}
+
+	fireAt := time.UnixMilli(setupTokenModel.ExpiresAt).Add(-s.cfg.ReminderBefore)
+	timer, err := models.NewWorkflowTimer(models.WorkflowInvitationReminder, user.ID.String(), fireAt, invitationReminder{
+		UserID: user.ID.String(),
+		Email:  user.Email.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.timers.Schedule(ctx, timer)
+}
+
+// failImport records why a record was not imported. Records that are already there are
+// duplicates, records the service rejects are invalid, anything else is a failure.
+func failImport(result *dto.ImportUserResult, err error) *dto.ImportUserResult {
+	result.Err = err
+
+	wrapper, ok := errs.As(err)
+	switch {
+	case errors.Is(err, errs.ErrDuplicateImportRow), errors.Is(err, errs.ErrUserExists):
+		result.Status = dto.ImportUserStatusDuplicate
+	case !ok, wrapper.Code == codes.Internal, wrapper.Code == codes.Unknown, wrapper.Code == codes.Unavailable:
+		result.Status = dto.ImportUserStatusFailed
+	default:
+		result.Status = dto.ImportUserStatusInvalid
+	}
+
+	return result
+}
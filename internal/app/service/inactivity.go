@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// InactiveUserRepository flags the accounts of customers who stopped logging in
+type InactiveUserRepository interface {
+	FlagInactive(ctx context.Context, lastSeenBefore, now int64, limit int) ([]*models.User, error)
+}
+
+// InactivityService flags customers who have not logged in for inactivity.after and tells the
+// marketing pipeline, which tries to re-engage them
+type InactivityService struct {
+	config        config.InactivityConfig
+	userRepo      InactiveUserRepository
+	regions       RegionRouter
+	eventPipeline EventPipeline
+	// databases are the regions whose databases are scanned, "" for the home database
+	databases []string
+}
+
+// NewInactivityService creates a new InactivityService instance
+func NewInactivityService(
+	cfg *config.Config,
+	userRepo InactiveUserRepository,
+	regions RegionRouter,
+	eventPipeline EventPipeline,
+) *InactivityService {
+	log.Info("Initializing InactivityService")
+
+	databases := []string{""}
+	for _, region := range cfg.Residency.Regions {
+		if regions.HasDatabase(region.Name) {
+			databases = append(databases, region.Name)
+		}
+	}
+
+	return &InactivityService{
+		config:        cfg.Inactivity,
+		userRepo:      userRepo,
+		regions:       regions,
+		eventPipeline: eventPipeline,
+		databases:     databases,
+	}
+}
+
+// FlagInactiveUsers flags the active customers of every region last seen inactivity.after
+// before the end of the period and publishes a user.inactive event for each. Users are flagged
+// once per absence, as a login clears the flag; run daily by the scheduler.
+func (s *InactivityService) FlagInactiveUsers(ctx context.Context, _, end time.Time) error {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":     "FlagInactiveUsers",
+		"period_end": end,
+	})
+
+	lastSeenBefore := end.Add(-s.config.After).UnixMilli()
+	flagged := 0
+	for _, region := range s.databases {
+		regionCtx, err := s.regions.WithRegion(ctx, region)
+		if err != nil {
+			return err
+		}
+
+		for {
+			users, err := s.userRepo.FlagInactive(regionCtx, lastSeenBefore, time.Now().UnixMilli(), s.config.BatchSize)
+			if err != nil {
+				return fmt.Errorf("failed to flag inactive users of region %q: %w", region, err)
+			}
+
+			for _, user := range users {
+				s.publishInactive(ctx, logger, user)
+			}
+			flagged += len(users)
+
+			if len(users) < s.config.BatchSize {
+				break
+			}
+		}
+	}
+
+	logger.WithField("flagged", flagged).Info("Inactive users flagged")
+	return nil
+}
+
+// publishInactive writes the user.inactive event of a flagged user to the outbox. The user
+// stays flagged, so a failure is logged rather than retried with the next batch.
+func (s *InactivityService) publishInactive(ctx context.Context, logger *log.Logger, user *models.User) {
+	logger = logger.WithField("user_id", user.ID.String())
+
+	payload, err := json.Marshal(dto.SendUserInactiveParams{
+		UserID:        user.ID.String(),
+		Email:         user.Email.String(),
+		Username:      user.Username.String(),
+		LastSeenAt:    user.LastSeenAt(),
+		InactiveSince: user.InactiveSince,
+		Residency:     user.Residency,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal user inactive payload")
+		return
+	}
+
+	if err := s.eventPipeline.Submit(ctx, &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(events.UserInactiveEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	}); err != nil {
+		logger.WithError(err).Error("Failed to submit user inactive event")
+	}
+}
+
+// checkEmailReverification refuses the login of a user returning after an absence longer
+// than inactivity.reverify_after until the email is verified again, emailing a link to do so
+func (s *UserService) checkEmailReverification(ctx context.Context, logger *log.Logger, user *models.User) error {
+	absence := s.config.Inactivity.ReverifyAfter
+	now := time.Now()
+	// Without a claim, only the absence is checked, sparing recent users the claim lookup
+	if !user.NeedsEmailReverification(nil, absence, now) {
+		return nil
+	}
+
+	claim, err := s.emailClaims.GetByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, errs.ErrEmailNotClaimed) {
+		logger.WithError(err).Error("Failed to look up email claim")
+		return err
+	}
+	if !user.NeedsEmailReverification(claim, absence, now) {
+		return nil
+	}
+
+	// A throttled link leaves the one sent before valid
+	if _, err := s.sendVerificationEmail(ctx, user, now); err != nil && !errors.Is(err, errs.ErrVerificationEmailThrottled) {
+		logger.WithError(err).Error("Failed to send verification email")
+		return err
+	}
+
+	logger.WithField("last_seen_at", user.LastSeenAt()).Warn("Email must be verified again after a long absence")
+	return errs.ErrEmailReverificationRequired
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// InviteCodeRepository stores the invite codes of users and the referrals made with them
+type InviteCodeRepository interface {
+	Create(ctx context.Context, code *models.InviteCode, maxActive int) (bool, error)
+	Get(ctx context.Context, code string) (*models.InviteCode, error)
+	Redeem(ctx context.Context, referral *models.Referral) (bool, error)
+	Unredeem(ctx context.Context, refereeID uuid.UUID) error
+}
+
+// CreateInviteCode creates an invite code of the caller to refer others with. Users have at
+// most referral.max_active_codes codes with redemptions left.
+func (s *UserService) CreateInviteCode(ctx context.Context, req dto.CreateInviteCodeReq) (*models.InviteCode, error) {
+	logger := log.FromContext(ctx).WithField("method", "CreateInviteCode")
+
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+	logger = logger.WithField("user_id", caller.UserID)
+
+	maxRedemptions, err := req.Redemptions(s.config.Referral.MaxRedemptions)
+	if err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, uuid.MustParse(caller.UserID))
+	if err != nil {
+		logger.WithError(err).Warn("Failed to retrieve user")
+		return nil, err
+	}
+	if user.Status == models.UserStatusBanned {
+		logger.Warn("Banned user cannot refer others")
+		return nil, errs.ErrUserBanned
+	}
+
+	inviteCode, err := models.NewInviteCode(user, maxRedemptions, time.Now(), s.tokens)
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate invite code")
+		return nil, err
+	}
+
+	stored, err := s.inviteCodes.Create(ctx, inviteCode, s.config.Referral.MaxActiveCodes)
+	if err != nil {
+		logger.WithError(err).Error("Failed to store invite code")
+		return nil, err
+	}
+	if !stored {
+		logger.Warn("User has too many active invite codes")
+		return nil, errs.ErrInviteCodeLimitReached
+	}
+
+	logger.WithField("max_redemptions", maxRedemptions).Info("Invite code created")
+
+	return inviteCode, nil
+}
+
+// redeemInviteCode records that the user registers with the invite code of another user,
+// refusing codes of the user's own email and codes without redemptions left
+func (s *UserService) redeemInviteCode(ctx context.Context, code string, user *models.User) (*models.Referral, error) {
+	// Generated codes are upper case, but codes are matched ignoring case like shared ones
+	inviteCode, err := s.inviteCodes.Get(ctx, strings.ToUpper(code))
+	if err != nil {
+		return nil, err
+	}
+	if err := inviteCode.CheckRedeemableBy(user.Email, s.tokens); err != nil {
+		return nil, err
+	}
+
+	referral := &models.Referral{
+		RefereeID:  user.ID,
+		ReferrerID: inviteCode.UserID,
+		Code:       inviteCode.Code,
+		CreatedAt:  time.Now().UnixMilli(),
+	}
+	redeemed, err := s.inviteCodes.Redeem(ctx, referral)
+	if err != nil {
+		return nil, err
+	}
+	if !redeemed {
+		return nil, errs.ErrInviteCodeExhausted
+	}
+
+	return referral, nil
+}
+
+// publishReferral tells the rewards engine about a registration with a user's invite code.
+// The user is registered by then, so a failure is logged rather than failing the registration.
+func (s *UserService) publishReferral(ctx context.Context, logger *log.Logger, referral *models.Referral) {
+	logger = logger.WithField("referrer_id", referral.ReferrerID.String())
+
+	payload, err := json.Marshal(dto.SendReferralRedeemedParams{
+		ReferrerID: referral.ReferrerID.String(),
+		RefereeID:  referral.RefereeID.String(),
+		InviteCode: referral.Code,
+		RedeemedAt: referral.CreatedAt,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal referral redeemed payload")
+		return
+	}
+
+	if err := s.eventPipeline.Submit(ctx, &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(events.ReferralRedeemedEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	}); err != nil {
+		logger.WithError(err).Error("Failed to submit referral redeemed event")
+		return
+	}
+
+	logger.Info("Referral recorded")
+}
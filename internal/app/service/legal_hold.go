@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// LegalHoldRepository sets the legal hold flag of users
+type LegalHoldRepository interface {
+	SetLegalHold(ctx context.Context, id uuid.UUID, hold bool) (bool, error)
+}
+
+// LegalHoldAuditRepository records legal hold changes in the audit trail
+type LegalHoldAuditRepository interface {
+	CreateBatch(ctx context.Context, entries []*models.AuditLog) error
+}
+
+// LegalHoldService places users under legal hold so retention and deletion skip them
+type LegalHoldService struct {
+	adminKeys  []config.AdminAPIKeyConfig
+	userRepo   LegalHoldRepository
+	auditRepo  LegalHoldAuditRepository
+	userEvents UserEventAppender
+	txManager  TxManager
+}
+
+// NewLegalHoldService creates a new LegalHoldService instance
+func NewLegalHoldService(
+	cfg *config.Config,
+	userRepo LegalHoldRepository,
+	auditRepo LegalHoldAuditRepository,
+	userEvents UserEventAppender,
+	txManager TxManager,
+) *LegalHoldService {
+	log.Info("Initializing LegalHoldService")
+
+	return &LegalHoldService{
+		adminKeys:  cfg.Admin.APIKeys,
+		userRepo:   userRepo,
+		auditRepo:  auditRepo,
+		userEvents: userEvents,
+		txManager:  txManager,
+	}
+}
+
+// PlaceLegalHold places a user under legal hold and reports whether the hold was newly placed
+func (s *LegalHoldService) PlaceLegalHold(ctx context.Context, req dto.LegalHoldReq) (bool, error) {
+	return s.setLegalHold(ctx, "PlaceLegalHold", req, true)
+}
+
+// ReleaseLegalHold releases a user's legal hold and reports whether the user was under hold
+func (s *LegalHoldService) ReleaseLegalHold(ctx context.Context, req dto.LegalHoldReq) (bool, error) {
+	return s.setLegalHold(ctx, "ReleaseLegalHold", req, false)
+}
+
+// setLegalHold changes the flag and records the change in the audit trail in one transaction,
+// so a hold is never placed or released without its audit entry
+func (s *LegalHoldService) setLegalHold(ctx context.Context, method string, req dto.LegalHoldReq, hold bool) (bool, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  method,
+		"user_id": req.UserID,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return false, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid legal hold request")
+		return false, err
+	}
+	userID := uuid.MustParse(req.UserID)
+
+	action, eventType := models.AuditActionLegalHoldReleased, models.UserEventLegalHoldReleased
+	if hold {
+		action, eventType = models.AuditActionLegalHoldPlaced, models.UserEventLegalHoldPlaced
+	}
+
+	var changed bool
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		wasHeld, err := s.userRepo.SetLegalHold(txCtx, userID, hold)
+		if err != nil {
+			return err
+		}
+		changed = wasHeld != hold
+		if !changed {
+			return nil
+		}
+
+		event, err := models.NewUserEvent(userID, eventType, adminActor(admin), models.LegalHoldData{Reason: req.Reason})
+		if err != nil {
+			return err
+		}
+		if err := s.userEvents.Append(txCtx, event); err != nil {
+			return err
+		}
+
+		entry, err := models.NewAuditLog(userID, action, map[string]interface{}{
+			"admin":  admin,
+			"reason": req.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.auditRepo.CreateBatch(txCtx, []*models.AuditLog{entry})
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to change legal hold")
+		return false, err
+	}
+
+	logger.WithField("changed", changed).Info("Legal hold updated")
+
+	return changed, nil
+}
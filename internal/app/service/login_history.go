@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// LoginAttemptPipeline asynchronously persists login attempts off the request path
+type LoginAttemptPipeline interface {
+	Submit(ctx context.Context, attempt *models.LoginAttempt) error
+}
+
+// LoginAttemptRepository reads and purges the recorded login attempts of users
+type LoginAttemptRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID, cursor models.LoginAttemptCursor, limit int) ([]*models.LoginAttempt, error)
+	Purge(ctx context.Context, before int64) error
+}
+
+// LoginHistoryService lets users and admins review the recent login attempts of an account
+type LoginHistoryService struct {
+	adminKeys   []config.AdminAPIKeyConfig
+	tokenMaker  token.TokenMaker
+	attemptRepo LoginAttemptRepository
+	retention   time.Duration
+}
+
+// NewLoginHistoryService creates a new LoginHistoryService instance
+func NewLoginHistoryService(cfg *config.Config, tokenMaker token.TokenMaker, attemptRepo LoginAttemptRepository) *LoginHistoryService {
+	log.Info("Initializing LoginHistoryService")
+
+	return &LoginHistoryService{
+		adminKeys:   cfg.Admin.APIKeys,
+		tokenMaker:  tokenMaker,
+		attemptRepo: attemptRepo,
+		retention:   cfg.LoginHistory.Retention,
+	}
+}
+
+// GetLoginHistory returns a page of the login attempts of a user, newest first. Admins name
+// the user; users only read their own attempts.
+func (s *LoginHistoryService) GetLoginHistory(ctx context.Context, req dto.GetLoginHistoryReq) (*dto.GetLoginHistoryResp, error) {
+	logger := log.FromContext(ctx).WithField("method", "GetLoginHistory")
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return nil, err
+	}
+
+	userID, err := s.authorizeReader(ctx, req.UserID)
+	if err != nil {
+		logger.WithError(err).Warn("Caller may not read the login history")
+		return nil, err
+	}
+	logger = logger.WithField("user_id", userID.String())
+
+	cursor, _ := req.Cursor()
+	limit := req.Limit()
+
+	// One extra attempt tells whether there is a next page
+	attempts, err := s.attemptRepo.ListByUser(ctx, userID, cursor, limit+1)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list login attempts")
+		return nil, err
+	}
+
+	resp := &dto.GetLoginHistoryResp{Attempts: attempts}
+	if len(attempts) > limit {
+		resp.Attempts = attempts[:limit]
+		resp.NextPageToken = dto.LoginAttemptPageToken(resp.Attempts[limit-1])
+	}
+
+	return resp, nil
+}
+
+// authorizeReader returns the user whose login history the caller may read: the requested
+// user for an admin API key in the metadata, otherwise the caller, who may only name itself
+func (s *LoginHistoryService) authorizeReader(ctx context.Context, requestedID string) (uuid.UUID, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(AdminKeyMetadataKey)) > 0 {
+		if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+			return uuid.Nil, err
+		}
+		if requestedID == "" {
+			return uuid.Nil, errs.ErrInvalidUserID
+		}
+		return uuid.MustParse(requestedID), nil
+	}
+
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	userID, err := uuid.Parse(caller.UserID)
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidAccessToken
+	}
+	if requestedID != "" && uuid.MustParse(requestedID) != userID {
+		return uuid.Nil, errs.ErrPermissionDenied
+	}
+
+	return userID, nil
+}
+
+// PurgeLoginAttempts deletes the login attempts older than login_history.retention at the end
+// of the period, run daily by the scheduler
+func (s *LoginHistoryService) PurgeLoginAttempts(ctx context.Context, _, end time.Time) error {
+	return s.attemptRepo.Purge(ctx, end.Add(-s.retention).UnixMilli())
+}
+
+// recordLoginAttempt submits a login attempt of the user, nil for an unknown email, to the
+// login history, a success for a nil err. Failures are logged and never propagated to the caller.
+func (s *UserService) recordLoginAttempt(ctx context.Context, user *models.User, clientID string, err error) {
+	device := deviceMetadata(ctx)
+	attempt := &models.LoginAttempt{
+		ID:        uuid.New(),
+		Success:   err == nil,
+		ClientID:  clientID,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	attempt.IPAddress, _ = device[models.AuditMetadataIPAddress].(string)
+	attempt.UserAgent, _ = device[models.AuditMetadataUserAgent].(string)
+	if user != nil {
+		attempt.UserID = user.ID
+	}
+	if err != nil {
+		attempt.FailureReason, _ = securityFailure(err)
+	}
+
+	if submitErr := s.loginAttempts.Submit(ctx, attempt); submitErr != nil {
+		log.FromContext(ctx).WithError(submitErr).Warn("Failed to submit login attempt")
+	}
+}
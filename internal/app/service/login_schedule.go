@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// LoginScheduleRepository stores the login time windows of users and roles
+type LoginScheduleRepository interface {
+	Upsert(ctx context.Context, schedule *models.LoginSchedule) error
+	Get(ctx context.Context, userID uuid.UUID, role models.UserRole) (*models.LoginSchedule, error)
+	ListForUser(ctx context.Context, userID uuid.UUID, role models.UserRole) ([]*models.LoginSchedule, error)
+	Delete(ctx context.Context, userID uuid.UUID, role models.UserRole) (bool, error)
+}
+
+// LoginScheduleChecker enforces the login time windows of users and roles
+type LoginScheduleChecker interface {
+	CheckLoginSchedule(ctx context.Context, user *models.User, now time.Time) error
+}
+
+// LoginScheduleService restricts when accounts may authenticate, e.g. venue staff to the
+// operating hours of their venue
+type LoginScheduleService struct {
+	adminKeys    []config.AdminAPIKeyConfig
+	scheduleRepo LoginScheduleRepository
+}
+
+// NewLoginScheduleService creates a new LoginScheduleService instance
+func NewLoginScheduleService(cfg *config.Config, scheduleRepo LoginScheduleRepository) *LoginScheduleService {
+	log.Info("Initializing LoginScheduleService")
+
+	return &LoginScheduleService{
+		adminKeys:    cfg.Admin.APIKeys,
+		scheduleRepo: scheduleRepo,
+	}
+}
+
+// CheckLoginSchedule fails with ErrOutsideLoginSchedule if the schedule of the user, or else
+// of their role, does not allow authenticating at now
+func (s *LoginScheduleService) CheckLoginSchedule(ctx context.Context, user *models.User, now time.Time) error {
+	schedules, err := s.scheduleRepo.ListForUser(ctx, user.ID, user.Role)
+	if err != nil {
+		return err
+	}
+
+	schedule := models.EffectiveLoginSchedule(schedules)
+	if schedule == nil {
+		return nil
+	}
+
+	allowed, err := schedule.Allows(now)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errs.ErrOutsideLoginSchedule
+	}
+	return nil
+}
+
+// SetLoginSchedule sets the login schedule of a user or a role, replacing the earlier one.
+// Sessions of affected users are checked on their next refresh.
+func (s *LoginScheduleService) SetLoginSchedule(ctx context.Context, req dto.SetLoginScheduleReq) (*models.LoginSchedule, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "SetLoginSchedule",
+		"user_id": req.UserID,
+		"role":    req.Role,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	schedule, err := req.Schedule(time.Now())
+	if err != nil {
+		logger.WithError(err).Warn("Invalid login schedule")
+		return nil, err
+	}
+
+	if err := s.scheduleRepo.Upsert(ctx, schedule); err != nil {
+		logger.WithError(err).Error("Failed to store login schedule")
+		return nil, err
+	}
+
+	logger.WithFields(log.Fields{
+		"timezone": schedule.Timezone,
+		"windows":  len(schedule.Windows),
+	}).Info("Login schedule set")
+
+	return schedule, nil
+}
+
+// GetLoginSchedule returns the login schedule of a user or a role
+func (s *LoginScheduleService) GetLoginSchedule(ctx context.Context, req dto.LoginScheduleSubjectReq) (*models.LoginSchedule, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "GetLoginSchedule",
+		"user_id": req.UserID,
+		"role":    req.Role,
+	})
+
+	if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+
+	userID, role, err := req.Validate()
+	if err != nil {
+		logger.WithError(err).Warn("Invalid login schedule subject")
+		return nil, err
+	}
+
+	return s.scheduleRepo.Get(ctx, userID, role)
+}
+
+// DeleteLoginSchedule removes the login schedule of a user or a role and reports whether
+// there was one; a user without a schedule of their own falls back to their role's
+func (s *LoginScheduleService) DeleteLoginSchedule(ctx context.Context, req dto.LoginScheduleSubjectReq) (bool, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "DeleteLoginSchedule",
+		"user_id": req.UserID,
+		"role":    req.Role,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return false, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	userID, role, err := req.Validate()
+	if err != nil {
+		logger.WithError(err).Warn("Invalid login schedule subject")
+		return false, err
+	}
+
+	deleted, err := s.scheduleRepo.Delete(ctx, userID, role)
+	if err != nil {
+		logger.WithError(err).Error("Failed to delete login schedule")
+		return false, err
+	}
+
+	logger.WithField("deleted", deleted).Info("Login schedule deleted")
+
+	return deleted, nil
+}
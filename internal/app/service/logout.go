@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/dpop"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// Logout ends the session of a refresh token of the caller: the refresh token is revoked,
+// then the access token the caller authenticated with, on every replica. A refresh token that
+// was revoked already, e.g. rotated by a concurrent refresh, still logs the access token out.
+func (s *UserService) Logout(ctx context.Context, req dto.LogoutReq) (err error) {
+	logger := log.FromContext(ctx).WithField("method", "Logout")
+
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return err
+	}
+	logger = logger.WithField("user_id", caller.UserID)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return err
+	}
+
+	// Every attempt past validation is streamed to the SIEM, whatever stops it
+	defer func() {
+		event := s.securityEvent(ctx, models.SecurityActionLogout, nil, "", caller.ClientID)
+		event.UserID = caller.UserID
+		event.OrganizationID = caller.OrganizationID
+		s.recordSecurityEvent(ctx, event, err)
+	}()
+
+	refreshToken, err := s.refreshTokenRepo.GetByToken(ctx, req.RefreshToken)
+	if err != nil {
+		if !errors.Is(err, errs.ErrTokenNotFound) {
+			logger.WithError(err).Error("Failed to retrieve refresh token from database")
+		}
+		return err
+	}
+	logger = logger.WithField("token_id", refreshToken.ID.String())
+
+	if refreshToken.UserID.String() != caller.UserID {
+		logger.WithField("owner_id", refreshToken.UserID.String()).Warn("Caller may not end a session of another user")
+		return errs.ErrPermissionDenied
+	}
+
+	jkt, _ := dpop.FromContext(ctx)
+	if err := refreshToken.CheckBinding(jkt); err != nil {
+		logger.Warn("Refresh token presented without its DPoP key")
+		return err
+	}
+
+	if _, err := s.refreshTokenRepo.Revoke(ctx, refreshToken.ID); err != nil {
+		logger.WithError(err).Error("Failed to revoke refresh token")
+		return err
+	}
+
+	if err := s.tokenRevoker.RevokeJTI(ctx, caller.ID.String(), time.Unix(caller.ExpiredAt, 0)); err != nil {
+		logger.WithError(err).Error("Failed to revoke access token")
+		return err
+	}
+
+	logger.Info("Logout completed successfully")
+
+	// Tokens without an organization claim leave it to the user's organization when stored
+	organizationID, _ := uuid.Parse(caller.OrganizationID)
+	s.recordAudit(ctx, logger, refreshToken.UserID, organizationID, models.AuditActionUserLoggedOut, deviceMetadata(ctx))
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+)
+
+// RefreshTokenCleaner deletes dead refresh tokens in batches
+type RefreshTokenCleaner interface {
+	DeleteBatch(ctx context.Context, cleanup models.RefreshTokenCleanup, limit int) (int64, error)
+}
+
+// MaintenanceService lets admins run targeted cleanups, e.g. of one user's sessions during
+// an incident, without scanning whole tables
+type MaintenanceService struct {
+	adminKeys        []config.AdminAPIKeyConfig
+	batchPause       time.Duration
+	refreshTokenRepo RefreshTokenCleaner
+}
+
+// NewMaintenanceService creates a new MaintenanceService instance
+func NewMaintenanceService(cfg *config.Config, refreshTokenRepo RefreshTokenCleaner) *MaintenanceService {
+	log.Info("Initializing MaintenanceService")
+
+	return &MaintenanceService{
+		adminKeys:        cfg.Admin.APIKeys,
+		batchPause:       cfg.Maintenance.BatchPause,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+// CleanupRefreshTokens deletes the refresh tokens that were dead as of the call in batches,
+// each committed on its own, and sends the progress after every batch. It stops after the
+// current batch once ctx is done, keeping the batches deleted so far.
+func (s *MaintenanceService) CleanupRefreshTokens(
+	ctx context.Context,
+	req dto.CleanupRefreshTokensReq,
+	send func(progress *dto.CleanupRefreshTokensProgress) error,
+) error {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":       "CleanupRefreshTokens",
+		"user_id":      req.UserID,
+		"older_than":   req.OlderThanSeconds,
+		"revoked_only": req.RevokedOnly,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid refresh token cleanup request")
+		return err
+	}
+
+	cleanup := req.Cleanup(time.Now())
+	progress := dto.CleanupRefreshTokensProgress{}
+	for !progress.Done {
+		limit := req.Limit(progress.Deleted)
+		deleted, err := s.refreshTokenRepo.DeleteBatch(ctx, cleanup, limit)
+		if err != nil {
+			logger.WithError(err).WithField("deleted", progress.Deleted).Error("Refresh token cleanup failed")
+			return err
+		}
+
+		progress.Deleted += deleted
+		progress.Batches++
+		progress.Done = deleted < int64(limit) || (req.MaxTokens > 0 && progress.Deleted >= req.MaxTokens)
+		sent := progress
+		if err := send(&sent); err != nil {
+			return err
+		}
+
+		if !progress.Done {
+			select {
+			case <-ctx.Done():
+				logger.WithField("deleted", progress.Deleted).Warn("Refresh token cleanup cancelled")
+				return ctx.Err()
+			case <-time.After(s.batchPause):
+			}
+		}
+	}
+
+	logger.WithFields(log.Fields{
+		"deleted": progress.Deleted,
+		"batches": progress.Batches,
+	}).Info("Refresh tokens cleaned up")
+
+	return nil
+}
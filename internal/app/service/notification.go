@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// NotificationPreferenceRepository stores the channels users opted in to or out of
+type NotificationPreferenceRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.NotificationPreference, error)
+	Upsert(ctx context.Context, prefs []*models.NotificationPreference) error
+}
+
+// NotificationService manages the notification channel preferences of users
+type NotificationService struct {
+	prefsRepo  NotificationPreferenceRepository
+	tokenMaker token.TokenMaker
+	// routes are the default channels of every event type that sends notifications
+	routes map[string][]models.NotificationChannel
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService(
+	prefsRepo NotificationPreferenceRepository,
+	tokenMaker token.TokenMaker,
+	routes map[string][]models.NotificationChannel,
+) *NotificationService {
+	log.Info("Initializing NotificationService")
+
+	return &NotificationService{
+		prefsRepo:  prefsRepo,
+		tokenMaker: tokenMaker,
+		routes:     routes,
+	}
+}
+
+// GetNotificationPreferences returns the caller's effective preference for every event type and channel
+func (s *NotificationService) GetNotificationPreferences(ctx context.Context) ([]*models.NotificationPreference, error) {
+	logger := log.FromContext(ctx).WithField("method", "GetNotificationPreferences")
+
+	userID, err := s.caller(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+
+	return s.effectivePreferences(ctx, userID)
+}
+
+// UpdateNotificationPreferences opts the caller in or out of channels and returns the resulting preferences
+func (s *NotificationService) UpdateNotificationPreferences(
+	ctx context.Context,
+	req dto.UpdateNotificationPreferencesReq,
+) ([]*models.NotificationPreference, error) {
+	logger := log.FromContext(ctx).WithField("method", "UpdateNotificationPreferences")
+
+	userID, err := s.caller(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid notification preferences")
+		return nil, err
+	}
+
+	var verrs errs.ValidationErrors
+	now := time.Now().UnixMilli()
+	prefs := make([]*models.NotificationPreference, 0, len(req.Preferences))
+	for i, pref := range req.Preferences {
+		if _, ok := s.routes[pref.EventType]; !ok {
+			verrs.Add(fmt.Sprintf("preferences[%d].event_type", i), errs.ErrUnknownNotificationEvent)
+			continue
+		}
+
+		prefs = append(prefs, &models.NotificationPreference{
+			UserID:    userID,
+			EventType: pref.EventType,
+			Channel:   models.NotificationChannel(pref.Channel),
+			Enabled:   pref.Enabled,
+			UpdatedAt: now,
+		})
+	}
+	if err := verrs.Err(); err != nil {
+		logger.WithError(err).Warn("Invalid notification preferences")
+		return nil, err
+	}
+
+	if err := s.prefsRepo.Upsert(ctx, prefs); err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to store notification preferences")
+		return nil, err
+	}
+
+	return s.effectivePreferences(ctx, userID)
+}
+
+func (s *NotificationService) caller(ctx context.Context) (uuid.UUID, error) {
+	payload, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidAccessToken
+	}
+
+	return userID, nil
+}
+
+// effectivePreferences combines the defaults of every event type with the user's explicit choices
+func (s *NotificationService) effectivePreferences(ctx context.Context, userID uuid.UUID) ([]*models.NotificationPreference, error) {
+	explicit, err := s.prefsRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes := make([]string, 0, len(s.routes))
+	for eventType := range s.routes {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+
+	prefs := make([]*models.NotificationPreference, 0, len(eventTypes)*len(models.NotificationChannels))
+	for _, eventType := range eventTypes {
+		enabled := models.ResolveChannels(s.routes[eventType], models.EventPreferences(explicit, eventType))
+		for _, channel := range models.NotificationChannels {
+			prefs = append(prefs, &models.NotificationPreference{
+				UserID:    userID,
+				EventType: eventType,
+				Channel:   channel,
+				Enabled:   slices.Contains(enabled, channel),
+			})
+		}
+	}
+
+	return prefs, nil
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/metadata"
+)
+
+// OrgAuditRepository pages through the audit trails of organizations
+type OrgAuditRepository interface {
+	ListByOrganization(
+		ctx context.Context,
+		filter models.OrgAuditFilter,
+		afterCreatedAt int64,
+		afterID uuid.UUID,
+		limit int,
+	) ([]*models.AuditLog, error)
+}
+
+// OrgAuditCursorRepository records the last entry delivered to the webhook of each organization
+type OrgAuditCursorRepository interface {
+	GetCursor(ctx context.Context, organizationID uuid.UUID) (*models.AuditCursor, error)
+	SaveCursor(ctx context.Context, organizationID uuid.UUID, cursor models.AuditCursor) error
+}
+
+// OrgOwnerReader looks up callers to check whether they own an organization
+type OrgOwnerReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// WebhookSender posts signed deliveries to webhook endpoints
+type WebhookSender interface {
+	Post(ctx context.Context, url, secret, id string, body []byte) error
+}
+
+// orgAuditWebhookType names the deliveries of security events to organizations
+const orgAuditWebhookType = "organization.security_events"
+
+// orgAuditWebhookPayload is the body of a webhook delivery
+type orgAuditWebhookPayload struct {
+	Type           string             `json:"type"`
+	OrganizationID string             `json:"organizationId"`
+	Events         []*models.AuditLog `json:"events"`
+}
+
+// OrgAuditService gives organization owners the audit trail of their staff accounts: as an
+// export on request, and as security events delivered to the organization's webhook
+type OrgAuditService struct {
+	adminKeys        []config.AdminAPIKeyConfig
+	auditRepo        OrgAuditRepository
+	userRepo         OrgOwnerReader
+	cursors          OrgAuditCursorRepository
+	webhooks         WebhookSender
+	tokenMaker       token.TokenMaker
+	chunkSize        int
+	maxRowsPerSecond int
+	maxExportRange   time.Duration
+	webhookCfg       config.OrgAuditWebhooksConfig
+}
+
+// NewOrgAuditService creates a new OrgAuditService instance
+func NewOrgAuditService(
+	cfg *config.Config,
+	auditRepo OrgAuditRepository,
+	userRepo OrgOwnerReader,
+	cursors OrgAuditCursorRepository,
+	webhooks WebhookSender,
+	tokenMaker token.TokenMaker,
+) *OrgAuditService {
+	log.Info("Initializing OrgAuditService")
+
+	return &OrgAuditService{
+		adminKeys:        cfg.Admin.APIKeys,
+		auditRepo:        auditRepo,
+		userRepo:         userRepo,
+		cursors:          cursors,
+		webhooks:         webhooks,
+		tokenMaker:       tokenMaker,
+		chunkSize:        cfg.Export.ChunkSize,
+		maxRowsPerSecond: cfg.Export.MaxRowsPerSecond,
+		maxExportRange:   cfg.OrgAudit.MaxExportRange,
+		webhookCfg:       cfg.OrgAudit.Webhooks,
+	}
+}
+
+// ExportOrgAuditLog pages through the audit trail of an organization in the requested range,
+// oldest first, and hands it to send in chunks at no more than export.max_rows_per_second.
+// It stops at the first send error.
+func (s *OrgAuditService) ExportOrgAuditLog(
+	ctx context.Context,
+	req dto.ExportOrgAuditLogReq,
+	send func(entries []*models.AuditLog) error,
+) error {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":          "ExportOrgAuditLog",
+		"organization_id": req.OrganizationID,
+		"from":            req.From,
+		"to":              req.To,
+	})
+
+	if err := req.Validate(s.maxExportRange); err != nil {
+		logger.WithError(err).Warn("Invalid audit export request")
+		return err
+	}
+	filter := req.Filter()
+
+	caller, err := s.authorizeOwner(ctx, filter.OrganizationID)
+	if err != nil {
+		logger.WithError(err).Warn("Organization owner authorization failed")
+		return err
+	}
+	logger = logger.WithField("caller", caller)
+
+	// A chunk is released at once, so it must fit in the limiter's burst
+	chunkSize := min(s.chunkSize, s.maxRowsPerSecond)
+	limiter := rate.NewLimiter(rate.Limit(s.maxRowsPerSecond), chunkSize)
+
+	var exported int
+	afterCreatedAt, afterID := int64(-1), uuid.Nil
+	for {
+		entries, err := s.auditRepo.ListByOrganization(ctx, filter, afterCreatedAt, afterID, chunkSize)
+		if err != nil {
+			logger.WithError(err).Error("Failed to list organization audit logs")
+			return err
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		if err := limiter.WaitN(ctx, len(entries)); err != nil {
+			return err
+		}
+		if err := send(entries); err != nil {
+			logger.WithError(err).WithField("exported", exported).Warn("Audit export stream interrupted")
+			return err
+		}
+		exported += len(entries)
+
+		last := entries[len(entries)-1]
+		afterCreatedAt, afterID = last.CreatedAt, last.ID
+		if len(entries) < chunkSize {
+			break
+		}
+	}
+
+	logger.WithField("exported", exported).Info("Organization audit log exported")
+
+	return nil
+}
+
+// authorizeOwner checks that the caller holds an admin key or is an admin of the
+// organization, and returns who the caller is
+func (s *OrgAuditService) authorizeOwner(ctx context.Context, organizationID uuid.UUID) (string, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(AdminKeyMetadataKey)) > 0 {
+		admin, err := authorizeAdmin(ctx, s.adminKeys)
+		if err != nil {
+			return "", err
+		}
+		return adminActor(admin), nil
+	}
+
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		return "", err
+	}
+	userID, err := uuid.Parse(caller.UserID)
+	if err != nil {
+		return "", errs.ErrInvalidAccessToken
+	}
+
+	// The role is read from the user, not the token, which may be older than a role change
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user.Role != models.UserRoleAdmin || user.OrganizationID != organizationID {
+		return "", errs.ErrNotOrganizationOwner
+	}
+
+	return user.ID.String(), nil
+}
+
+// DeliverWebhooks delivers the security events of every organization with a webhook endpoint
+// that are older than org_audit.webhooks.settle at end. It has the signature of a scheduled
+// job; organizations whose endpoint fails are retried from their last delivered event on the
+// next run.
+func (s *OrgAuditService) DeliverWebhooks(ctx context.Context, _, end time.Time) error {
+	until := end.Add(-s.webhookCfg.Settle).UnixMilli()
+
+	var errList []error
+	for _, endpoint := range s.webhookCfg.Endpoints {
+		if err := s.deliverWebhook(ctx, endpoint, until); err != nil {
+			errList = append(errList, fmt.Errorf("organization %s: %w", endpoint.OrganizationID, err))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+// deliverWebhook posts the organization's security events created before until in batches,
+// recording the last delivered event after every batch
+func (s *OrgAuditService) deliverWebhook(ctx context.Context, endpoint config.OrgAuditWebhookConfig, until int64) error {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":          "DeliverWebhooks",
+		"organization_id": endpoint.OrganizationID,
+	})
+	organizationID := uuid.MustParse(endpoint.OrganizationID)
+
+	cursor, err := s.cursors.GetCursor(ctx, organizationID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get audit webhook cursor")
+		return err
+	}
+	// A new endpoint receives the events from now on, not the organization's whole history
+	if cursor == nil {
+		logger.Info("Starting audit webhook deliveries")
+		return s.cursors.SaveCursor(ctx, organizationID, models.AuditCursor{CreatedAt: until - 1, ID: uuid.Max})
+	}
+
+	filter := models.OrgAuditFilter{
+		OrganizationID: organizationID,
+		To:             until,
+		Actions:        models.SecurityAuditActions,
+	}
+	for {
+		entries, err := s.auditRepo.ListByOrganization(ctx, filter, cursor.CreatedAt, cursor.ID, s.webhookCfg.BatchSize)
+		if err != nil {
+			logger.WithError(err).Error("Failed to list security events")
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		body, err := json.Marshal(orgAuditWebhookPayload{
+			Type:           orgAuditWebhookType,
+			OrganizationID: endpoint.OrganizationID,
+			Events:         entries,
+		})
+		if err != nil {
+			return err
+		}
+
+		// A retried batch starts with the same event, so it keeps its delivery ID
+		if err := s.webhooks.Post(ctx, endpoint.URL, endpoint.Secret, entries[0].ID.String(), body); err != nil {
+			metrics.OrgAuditWebhookDeliveries.WithLabelValues("failed").Inc()
+			logger.WithError(err).Warn("Failed to deliver security events")
+			return err
+		}
+		metrics.OrgAuditWebhookDeliveries.WithLabelValues("delivered").Inc()
+		metrics.OrgAuditWebhookEvents.Add(float64(len(entries)))
+
+		last := entries[len(entries)-1]
+		cursor = &models.AuditCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		if err := s.cursors.SaveCursor(ctx, organizationID, *cursor); err != nil {
+			logger.WithError(err).Error("Failed to save audit webhook cursor")
+			return err
+		}
+
+		logger.WithField("events", len(entries)).Debug("Delivered security events")
+		if len(entries) < s.webhookCfg.BatchSize {
+			return nil
+		}
+	}
+}
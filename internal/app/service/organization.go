@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationRepository stores organizations
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *models.Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+	GetByName(ctx context.Context, name string) (*models.Organization, error)
+	SetAllowedEmailDomains(ctx context.Context, id uuid.UUID, domains []string) (*models.Organization, error)
+	SetBranding(ctx context.Context, id uuid.UUID, branding models.OrganizationBranding) (*models.Organization, error)
+	SetSessionIdleTimeout(ctx context.Context, id uuid.UUID, timeout time.Duration) (*models.Organization, error)
+}
+
+// OrganizationReader looks up the organizations users register into
+type OrganizationReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+}
+
+// OrganizationService lets admins manage organizations, their email domain restrictions,
+// branding and session policy
+type OrganizationService struct {
+	adminKeys []config.AdminAPIKeyConfig
+	residency config.ResidencyConfig
+	// accessTokenDuration is the shortest session idle timeout, as sessions are only seen
+	// active when they refresh
+	accessTokenDuration time.Duration
+	orgRepo             OrganizationRepository
+	// regionalOrgs copies organizations into the database of their region, where their
+	// members reference them
+	regionalOrgs OrganizationRepository
+	regions      RegionRouter
+}
+
+// NewOrganizationService creates a new OrganizationService instance
+func NewOrganizationService(
+	cfg *config.Config,
+	orgRepo OrganizationRepository,
+	regionalOrgs OrganizationRepository,
+	regions RegionRouter,
+) *OrganizationService {
+	log.Info("Initializing OrganizationService")
+
+	return &OrganizationService{
+		adminKeys:           cfg.Admin.APIKeys,
+		residency:           cfg.Residency,
+		accessTokenDuration: cfg.JWT.AccessTokenDuration,
+		orgRepo:             orgRepo,
+		regionalOrgs:        regionalOrgs,
+		regions:             regions,
+	}
+}
+
+// CreateOrganization creates an organization
+func (s *OrganizationService) CreateOrganization(ctx context.Context, req dto.CreateOrganizationReq) (*models.Organization, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "CreateOrganization",
+		"name":   req.Name,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid organization")
+		return nil, err
+	}
+
+	if req.Residency != "" && !s.residency.IsRegion(req.Residency) {
+		logger.WithField("residency", req.Residency).Warn("Unknown residency region")
+		return nil, errs.ErrUnknownResidencyRegion.WithDetail("region", req.Residency)
+	}
+
+	org, err := models.NewOrganization(req.Name, req.AllowedEmailDomains)
+	if err != nil {
+		return nil, err
+	}
+	org.Residency = req.Residency
+
+	if err := s.orgRepo.Create(ctx, org); err != nil {
+		logger.WithError(err).Error("Failed to create organization")
+		return nil, err
+	}
+
+	if s.regions.HasDatabase(org.Residency) {
+		regionCtx, err := s.regions.WithRegion(ctx, org.Residency)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.regionalOrgs.Create(regionCtx, org); err != nil {
+			logger.WithError(err).WithField("residency", org.Residency).Error("Failed to copy organization to its region")
+			return nil, err
+		}
+	}
+
+	logger.WithField("organization_id", org.ID).Info("Organization created")
+
+	return org, nil
+}
+
+// GetOrganization returns an organization
+func (s *OrganizationService) GetOrganization(ctx context.Context, req dto.GetOrganizationReq) (*models.Organization, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":          "GetOrganization",
+		"organization_id": req.OrganizationID,
+	})
+
+	if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.GetByID(ctx, uuid.MustParse(req.OrganizationID))
+}
+
+// GetOrganizationByName returns the oldest organization with a name
+func (s *OrganizationService) GetOrganizationByName(ctx context.Context, req dto.GetOrganizationByNameReq) (*models.Organization, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "GetOrganizationByName",
+		"name":   req.Name,
+	})
+
+	if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.orgRepo.GetByName(ctx, req.Name)
+}
+
+// SetOrganizationEmailDomains replaces the email domains new staff accounts of an organization must use.
+// Existing members are not affected.
+func (s *OrganizationService) SetOrganizationEmailDomains(
+	ctx context.Context,
+	req dto.SetOrganizationEmailDomainsReq,
+) (*models.Organization, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":          "SetOrganizationEmailDomains",
+		"organization_id": req.OrganizationID,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid organization email domains")
+		return nil, err
+	}
+
+	domains, err := models.NormalizeEmailDomains(req.AllowedEmailDomains)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.SetAllowedEmailDomains(ctx, uuid.MustParse(req.OrganizationID), domains)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to set organization email domains")
+		return nil, err
+	}
+
+	logger.WithField("domains", domains).Info("Organization email domains updated")
+
+	return org, nil
+}
+
+// SetOrganizationBranding replaces the branding of the emails sent to members of an organization.
+// Emails already queued keep the branding they were queued with.
+func (s *OrganizationService) SetOrganizationBranding(
+	ctx context.Context,
+	req dto.SetOrganizationBrandingReq,
+) (*models.Organization, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":          "SetOrganizationBranding",
+		"organization_id": req.OrganizationID,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid organization branding")
+		return nil, err
+	}
+
+	branding, err := models.NewOrganizationBranding(req.Branding.Name, req.Branding.LogoURL, req.Branding.SupportEmail, req.Branding.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.SetBranding(ctx, uuid.MustParse(req.OrganizationID), branding)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to set organization branding")
+		return nil, err
+	}
+
+	logger.Info("Organization branding updated")
+
+	return org, nil
+}
+
+// SetOrganizationSessionPolicy replaces the idle timeout of the sessions of an organization's
+// members. Existing sessions get it on their next refresh.
+func (s *OrganizationService) SetOrganizationSessionPolicy(
+	ctx context.Context,
+	req dto.SetOrganizationSessionPolicyReq,
+) (*models.Organization, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":          "SetOrganizationSessionPolicy",
+		"organization_id": req.OrganizationID,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid organization session policy")
+		return nil, err
+	}
+
+	timeout := time.Duration(req.SessionIdleTimeoutSeconds) * time.Second
+	if timeout > 0 && timeout < s.accessTokenDuration {
+		logger.WithField("idle_timeout", timeout.String()).Warn("Session idle timeout shorter than the access token lifetime")
+		return nil, errs.ErrInvalidSessionIdleTimeout.WithDetail("minimum_seconds", int64(s.accessTokenDuration.Seconds()))
+	}
+
+	org, err := s.orgRepo.SetSessionIdleTimeout(ctx, uuid.MustParse(req.OrganizationID), timeout)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to set organization session policy")
+		return nil, err
+	}
+
+	logger.WithField("idle_timeout", timeout.String()).Info("Organization session policy updated")
+
+	return org, nil
+}
+
+// organizationBranding returns the email branding of an organization, nil for users outside
+// organizations and organizations without branding. Emails of org-scoped flows carry it in
+// their template data.
+func organizationBranding(ctx context.Context, orgRepo OrganizationReader, organizationID uuid.UUID) (*models.OrganizationBranding, error) {
+	if organizationID == uuid.Nil {
+		return nil, nil
+	}
+
+	org, err := orgRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if org.Branding.IsZero() {
+		return nil, nil
+	}
+
+	return &org.Branding, nil
+}
+
+// joinOrganization checks that a new member's email is allowed by the organization and makes
+// the user a member; it returns the organization, nil for users joining none.
+// This is the single enforcement point for every way of joining an organization.
+func joinOrganization(ctx context.Context, orgRepo OrganizationReader, organizationID string, user *models.User) (*models.Organization, error) {
+	if organizationID == "" {
+		return nil, nil
+	}
+
+	org, err := orgRepo.GetByID(ctx, uuid.MustParse(organizationID))
+	if err != nil {
+		return nil, err
+	}
+
+	if !org.AllowsEmail(user.Email) {
+		return nil, errs.ErrEmailDomainNotAllowed.WithDetail("organization_id", organizationID)
+	}
+
+	user.OrganizationID = org.ID
+	return org, nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetTokenRepository stores the tokens of the emailed password reset links
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, resetToken *models.PasswordResetToken, sentBefore int64) (bool, error)
+	Consume(ctx context.Context, tokenHash string, now int64) (uuid.UUID, error)
+	Delete(ctx context.Context, tokenHash string) error
+}
+
+// passwordResetTimeout bounds the background work of a password reset request
+const passwordResetTimeout = 30 * time.Second
+
+// RequestPasswordReset emails a link resetting the password to the user of an email. Emails
+// without an active account, and users sent a link within the resend interval, are answered
+// the same way but get no email, so the method does not reveal which addresses are registered.
+// The link is sent after the method returned, so known and unknown emails are answered
+// equally fast; failures are only logged.
+func (s *UserService) RequestPasswordReset(ctx context.Context, req dto.RequestPasswordResetReq) error {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "RequestPasswordReset",
+		"email":  req.Email,
+	})
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return err
+	}
+
+	// The values of the request, e.g. its region and locale, are kept past its end
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), passwordResetTimeout)
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		defer cancel()
+		// Failures are logged where they happen and leave no token, so the user can ask again
+		_ = s.sendPasswordReset(sendCtx, logger, req.Email)
+	}()
+
+	return nil
+}
+
+// sendPasswordReset emails a password reset link to the active user of an email. A token
+// whose email cannot be submitted is deleted again, so it does not hold back the next request
+// for the resend interval.
+func (s *UserService) sendPasswordReset(ctx context.Context, logger *log.Logger, email string) (err error) {
+	ctx, err = withEmailRegion(ctx, s.regions, s.emailDirectory, email)
+	if err != nil {
+		logger.WithError(err).Error("Failed to route request to the region of the email")
+		return err
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		logger.Info("Password reset requested for an unknown email, no link sent")
+		return nil
+	} else if err != nil {
+		logger.WithError(err).Error("Failed to look up email")
+		return err
+	}
+	logger = logger.WithField("user_id", user.ID.String())
+
+	// Invited users set their first password with the invitation, banned users not at all
+	if user.Status != models.UserStatusActive {
+		logger.WithField("status", string(user.Status)).Info("Password reset requested for an inactive user, no link sent")
+		return nil
+	}
+
+	now := time.Now()
+	resetToken, record, err := models.NewPasswordResetToken(user.ID, s.config.PasswordReset.TokenTTL, now, s.tokens)
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate password reset token")
+		return err
+	}
+
+	stored, err := s.passwordResets.Create(ctx, record, now.Add(-s.config.PasswordReset.ResendInterval).UnixMilli())
+	if err != nil {
+		logger.WithError(err).Error("Failed to store password reset token")
+		return err
+	}
+	if !stored {
+		logger.Info("Password reset link was sent recently, no link sent")
+		return nil
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if deleteErr := s.passwordResets.Delete(ctx, record.TokenHash); deleteErr != nil {
+			logger.WithError(deleteErr).Error("Failed to delete unsent password reset token")
+		}
+	}()
+
+	branding, err := organizationBranding(ctx, s.orgRepo, user.OrganizationID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to look up organization branding")
+		return err
+	}
+
+	payload, err := json.Marshal(dto.SendPasswordResetParams{
+		UserID:    user.ID.String(),
+		Email:     user.Email.String(),
+		Username:  user.Username.String(),
+		ResetURL:  strings.ReplaceAll(s.config.PasswordReset.URL, "{token}", url.QueryEscape(linkToken(resetToken, user))),
+		ExpiresAt: time.UnixMilli(record.ExpiresAt),
+		Locale:    requestLocale(ctx),
+		Branding:  branding,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal password reset payload")
+		return err
+	}
+
+	if err := s.eventPipeline.Submit(ctx, &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(events.PasswordResetRequestedEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	}); err != nil {
+		logger.WithError(err).Error("Failed to submit password reset event")
+		return err
+	}
+
+	logger.Info("Password reset link sent")
+
+	return nil
+}
+
+// ResetPassword sets a new password with the token of a link emailed by RequestPasswordReset
+// and ends every session of the user, as whoever made the user forget the password may hold one.
+// The token is redeemed in the region and organization of the user it names.
+func (s *UserService) ResetPassword(ctx context.Context, req dto.ResetPasswordReq) (err error) {
+	logger := log.FromContext(ctx).WithField("method", "ResetPassword")
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return err
+	}
+
+	ctx, secret, err := withLinkRoute(ctx, s.regions, s.tenants, req.Token, errs.ErrInvalidPasswordReset)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to route password reset to the region of its user")
+		return err
+	}
+
+	// Every attempt past validation is streamed to the SIEM, whatever stops it
+	var userID uuid.UUID
+	defer func() {
+		event := s.securityEvent(ctx, models.SecurityActionPasswordReset, nil, "", "")
+		if userID != uuid.Nil {
+			event.UserID = userID.String()
+		}
+		s.recordSecurityEvent(ctx, event, err)
+	}()
+
+	passwordHash, err := s.passwords.Hash(req.Password)
+	if err != nil {
+		logger.WithError(err).Error("Failed to hash password")
+		return err
+	}
+
+	// The token is consumed and the password set together, so a token is never spent
+	// without changing the password
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		var err error
+		userID, err = s.passwordResets.Consume(txCtx, s.tokens.Hash(secret), time.Now().UnixMilli())
+		if err != nil {
+			return err
+		}
+
+		if err := s.userRepo.SetPassword(txCtx, userID, passwordHash); err != nil {
+			return err
+		}
+
+		event, err := models.NewUserEvent(userID, models.UserEventPasswordReset, models.UserEventActorSelf, struct{}{})
+		if err != nil {
+			return err
+		}
+
+		return s.userEvents.Append(txCtx, event)
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Password reset failed")
+		return err
+	}
+	logger = logger.WithField("user_id", userID.String())
+
+	logger.Debug("Revoking refresh tokens")
+	revoked, err := s.refreshTokenRepo.RevokeAllByUserID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to revoke refresh tokens")
+		return err
+	}
+
+	logger.Debug("Revoking access tokens")
+	if err := s.tokenRevoker.RevokeUser(ctx, userID.String()); err != nil {
+		logger.WithError(err).Error("Failed to revoke access tokens")
+		return err
+	}
+
+	logger.WithField("revoked_refresh_tokens", revoked).Info("Password reset completed successfully")
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to retrieve user for the audit log")
+		return nil
+	}
+
+	metadata := deviceMetadata(ctx)
+	metadata["revoked_refresh_tokens"] = revoked
+	s.recordAudit(ctx, logger, user.ID, user.OrganizationID, models.AuditActionPasswordReset, metadata)
+
+	return nil
+}
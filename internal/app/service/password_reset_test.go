@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/hashing"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type tenantKey struct{}
+
+// taggingTenants puts the organization on the context
+type taggingTenants struct{}
+
+func (taggingTenants) WithTenant(ctx context.Context, organizationID uuid.UUID) (context.Context, error) {
+	return context.WithValue(ctx, tenantKey{}, organizationID), nil
+}
+
+// routeOf names the region and organization a context was routed to
+func routeOf(ctx context.Context) string {
+	region, _ := ctx.Value(regionKey{}).(string)
+	organizationID, _ := ctx.Value(tenantKey{}).(uuid.UUID)
+	return region + "/" + organizationID.String()
+}
+
+// resetTokens keeps the reset tokens in a map per route, like the databases and schemas of
+// the regions and organizations
+type resetTokens struct {
+	PasswordResetTokenRepository
+	tokens  map[string]uuid.UUID
+	created int
+}
+
+func (r *resetTokens) Create(ctx context.Context, token *models.PasswordResetToken, _ int64) (bool, error) {
+	r.tokens[routeOf(ctx)+" "+token.TokenHash] = token.UserID
+	r.created++
+	return true, nil
+}
+
+func (r *resetTokens) Consume(ctx context.Context, tokenHash string, _ int64) (uuid.UUID, error) {
+	key := routeOf(ctx) + " " + tokenHash
+	userID, ok := r.tokens[key]
+	if !ok {
+		return uuid.Nil, errs.ErrInvalidPasswordReset
+	}
+	delete(r.tokens, key)
+	return userID, nil
+}
+
+func (r *resetTokens) Delete(ctx context.Context, tokenHash string) error {
+	delete(r.tokens, routeOf(ctx)+" "+tokenHash)
+	return nil
+}
+
+// routedUsers records the route of the password changes
+type routedUsers struct {
+	benchUsers
+	passwordRoute string
+}
+
+func (r *routedUsers) SetPassword(ctx context.Context, _ uuid.UUID, _ models.PasswordHash) error {
+	r.passwordRoute = routeOf(ctx)
+	return nil
+}
+
+// resetLinks captures the emailed password reset links
+type resetLinks struct {
+	urls []string
+}
+
+func (l *resetLinks) Submit(_ context.Context, event *repository.NotificationEventLog) error {
+	var params dto.SendPasswordResetParams
+	if err := json.Unmarshal(event.Payload, &params); err != nil {
+		return err
+	}
+	l.urls = append(l.urls, params.ResetURL)
+	return nil
+}
+
+// resetRevoker revokes nothing
+type resetRevoker struct{}
+
+func (resetRevoker) RevokeUser(context.Context, string) error           { return nil }
+func (resetRevoker) RevokeJTI(context.Context, string, time.Time) error { return nil }
+
+// resetOrgs knows every organization, without branding
+type resetOrgs struct{}
+
+func (resetOrgs) GetByID(_ context.Context, id uuid.UUID) (*models.Organization, error) {
+	return &models.Organization{ID: id}, nil
+}
+
+// failingSink cannot take notification events
+type failingSink struct{}
+
+func (failingSink) Submit(context.Context, *repository.NotificationEventLog) error {
+	return errors.New("pipeline is closed")
+}
+
+func TestRequestPasswordReset_DeletesUnsentToken(t *testing.T) {
+	user, err := models.NewUser("jane@tickets.example", "hash", "jane_doe")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	resets := &resetTokens{tokens: make(map[string]uuid.UUID)}
+
+	s := &UserService{
+		config: &config.Config{PasswordReset: config.PasswordResetConfig{
+			URL:            "https://tickets.example/reset?token={token}",
+			TokenTTL:       time.Hour,
+			ResendInterval: time.Minute,
+		}},
+		userRepo:       &benchUsers{user: user},
+		passwordResets: resets,
+		eventPipeline:  failingSink{},
+		regions:        benchRegions{},
+		emailDirectory: benchEmails{},
+		tokens:         hashing.NewTokenHasher(),
+	}
+
+	if err := s.RequestPasswordReset(context.Background(), dto.RequestPasswordResetReq{Email: "jane@tickets.example"}); err != nil {
+		t.Fatalf("Expected the request to be answered, got %v", err)
+	}
+	s.Wait()
+
+	if resets.created != 1 || len(resets.tokens) != 0 {
+		t.Errorf("Expected the token of the unsent link to be deleted, got %d of %d tokens", len(resets.tokens), resets.created)
+	}
+}
+
+func TestResetPassword_RoutesToTheRegionOfTheUser(t *testing.T) {
+	user, err := models.NewUser("jane@tickets.example", "hash", "jane_doe")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	user.Residency = "eu"
+	user.OrganizationID = uuid.New()
+	users := &routedUsers{benchUsers: benchUsers{user: user}}
+	resets := &resetTokens{tokens: make(map[string]uuid.UUID)}
+	links := &resetLinks{}
+
+	s := &UserService{
+		config: &config.Config{PasswordReset: config.PasswordResetConfig{
+			URL:            "https://tickets.example/reset?token={token}",
+			TokenTTL:       time.Hour,
+			ResendInterval: time.Minute,
+		}},
+		userRepo:         users,
+		refreshTokenRepo: benchRefreshTokens{},
+		orgRepo:          resetOrgs{},
+		userEvents:       benchSink{},
+		txManager:        benchTx{},
+		passwordResets:   resets,
+		eventPipeline:    links,
+		auditPipeline:    benchAudit{},
+		tokenRevoker:     resetRevoker{},
+		securityEvents:   benchSecurityEvents{},
+		regions:          taggingRegions{},
+		emailDirectory:   directory{"jane@tickets.example": "eu"},
+		tenants:          taggingTenants{},
+		passwords:        hashing.NewPasswordHasher(bcrypt.MinCost),
+		tokens:           hashing.NewTokenHasher(),
+	}
+
+	// The request names the organization, the link page nothing
+	ctx := context.WithValue(context.Background(), tenantKey{}, user.OrganizationID)
+	if err := s.RequestPasswordReset(ctx, dto.RequestPasswordResetReq{Email: "jane@tickets.example"}); err != nil {
+		t.Fatalf("Expected the request to be answered, got %v", err)
+	}
+	s.Wait()
+	if len(links.urls) != 1 {
+		t.Fatalf("Expected a reset link, got %v", links.urls)
+	}
+	link, err := url.Parse(links.urls[0])
+	if err != nil {
+		t.Fatalf("Failed to parse reset link: %v", err)
+	}
+	resetToken := link.Query().Get("token")
+
+	err = s.ResetPassword(context.Background(), dto.ResetPasswordReq{Token: resetToken, Password: "N3w-Passw0rd!"})
+	if err != nil {
+		t.Fatalf("Expected the password to be reset, got %v", err)
+	}
+	if users.passwordRoute != "eu/"+user.OrganizationID.String() {
+		t.Errorf("Expected the password to be set in eu/%s, got %q", user.OrganizationID, users.passwordRoute)
+	}
+	if len(resets.tokens) != 0 {
+		t.Errorf("Expected the token to be consumed, got %v", resets.tokens)
+	}
+
+	// A token moved to another region is not found there
+	tampered := strings.Replace(resetToken, ".eu.", "..", 1)
+	if err := s.ResetPassword(context.Background(), dto.ResetPasswordReq{Token: tampered, Password: "N3w-Passw0rd!"}); !errors.Is(err, errs.ErrInvalidPasswordReset) {
+		t.Errorf("Expected ErrInvalidPasswordReset, got %v", err)
+	}
+}
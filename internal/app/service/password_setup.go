@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/dpop"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// CompletePasswordSetup sets the first password of an invited user with the token from the
+// invitation email, activates the account and logs the user in
+func (s *UserService) CompletePasswordSetup(ctx context.Context, req dto.CompletePasswordSetupReq) (resp *dto.LoginResp, err error) {
+	logger := log.FromContext(ctx).WithField("method", "CompletePasswordSetup")
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return nil, err
+	}
+
+	// Every attempt past validation is streamed to the SIEM, whatever stops it
+	var userID uuid.UUID
+	defer func() {
+		event := s.securityEvent(ctx, models.SecurityActionPasswordSetUp, nil, "", req.ClientID)
+		if userID != uuid.Nil {
+			event.UserID = userID.String()
+		}
+		s.recordSecurityEvent(ctx, event, err)
+	}()
+
+	client, err := s.resolveClient(ctx, req.ClientID, models.GrantTypePassword)
+	if err != nil {
+		logger.WithError(err).Warn("Client is not allowed to log users in")
+		return nil, err
+	}
+
+	passwordHash, err := s.passwords.Hash(req.Password)
+	if err != nil {
+		logger.WithError(err).Error("Failed to hash password")
+		return nil, err
+	}
+
+	// The token is consumed and the password set together, so a token is never spent
+	// without activating the account
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		var err error
+		userID, err = s.setupRepo.Consume(txCtx, s.tokens.Hash(req.Token), time.Now().UnixMilli())
+		if err != nil {
+			return err
+		}
+
+		if err := s.userRepo.Activate(txCtx, userID, passwordHash); err != nil {
+			return err
+		}
+
+		event, err := models.NewUserEvent(userID, models.UserEventPasswordSet, models.UserEventActorSelf, struct{}{})
+		if err != nil {
+			return err
+		}
+
+		// The token came with the invitation email, so the user proved owning the email
+		user, err := s.userRepo.GetByID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		claimed, err := s.claimEmail(txCtx, user, models.EmailVerifiedByInvitation)
+		if err != nil {
+			return err
+		}
+
+		return s.userEvents.Append(txCtx, event, claimed)
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Password setup failed")
+		return nil, err
+	}
+	logger = logger.WithField("user_id", userID.String())
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to retrieve activated user")
+		return nil, err
+	}
+
+	jkt, _ := dpop.FromContext(ctx)
+	accessToken, refreshTokenModel, err := s.createSessionTokens(user, client, jkt, false)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create session tokens")
+		return nil, err
+	}
+
+	if refreshTokenModel != nil {
+		if err := s.refreshTokenRepo.Create(ctx, refreshTokenModel); err != nil {
+			logger.WithError(err).Error("Failed to store refresh token in database")
+			return nil, err
+		}
+	}
+
+	logger.Info("Password setup completed successfully")
+
+	s.sessions.StartSession(ctx, refreshTokenModel)
+	s.recordAudit(ctx, logger, user.ID, user.OrganizationID, models.AuditActionPasswordSetUp, deviceMetadata(ctx))
+
+	return &dto.LoginResp{
+		User:                  user,
+		AccessToken:           accessToken,
+		RefreshToken:          refreshTokenValue(refreshTokenModel),
+		RefreshTokenExpiresAt: refreshTokenExpiry(refreshTokenModel),
+	}, nil
+}
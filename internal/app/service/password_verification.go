@@ -0,0 +1,62 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/metrics"
+)
+
+// passwordVerifications collapses concurrent verifications of the same password for the same
+// account within the instance. Mobile clients sometimes fire identical logins at once; they
+// then share one bcrypt verification instead of each paying for their own. The zero value is
+// ready to use.
+type passwordVerifications struct {
+	mu      sync.Mutex
+	flights map[string]*passwordVerification
+}
+
+// passwordVerification is a verification in flight; valid is set once done is closed
+type passwordVerification struct {
+	done  chan struct{}
+	valid bool
+}
+
+// verify verifies the password against the user's hash with passwords, or waits for the
+// result of an identical verification already in flight. Only the verification is shared:
+// every login still gets its own checks and tokens.
+func (v *passwordVerifications) verify(passwords models.PasswordHasher, user *models.User, password string) bool {
+	// The password is hashed so the key does not keep it in memory in the clear. The stored
+	// hash is part of the key, so a login that read the hash of a concurrent password change
+	// never gets the result of a verification against the previous hash.
+	sum := sha256.Sum256([]byte(password))
+	hashSum := sha256.Sum256([]byte(user.PasswordHash))
+	key := user.Email.String() + ":" + hex.EncodeToString(hashSum[:]) + ":" + hex.EncodeToString(sum[:])
+
+	v.mu.Lock()
+	if flight, ok := v.flights[key]; ok {
+		v.mu.Unlock()
+		<-flight.done
+		metrics.PasswordVerifications.WithLabelValues("shared").Inc()
+		return flight.valid
+	}
+	if v.flights == nil {
+		v.flights = make(map[string]*passwordVerification)
+	}
+	flight := &passwordVerification{done: make(chan struct{})}
+	v.flights[key] = flight
+	v.mu.Unlock()
+
+	defer func() {
+		v.mu.Lock()
+		delete(v.flights, key)
+		v.mu.Unlock()
+		close(flight.done)
+	}()
+
+	flight.valid = passwords.Verify(user.PasswordHash, password)
+	metrics.PasswordVerifications.WithLabelValues("verified").Inc()
+	return flight.valid
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+)
+
+// blockingHasher accepts any password for the "old" hash once released, and none for others
+type blockingHasher struct {
+	models.PasswordHasher
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (h blockingHasher) Verify(hash models.PasswordHash, _ string) bool {
+	if hash != "old" {
+		return false
+	}
+	close(h.entered)
+	<-h.release
+	return true
+}
+
+func TestPasswordVerifications_KeyedByStoredHash(t *testing.T) {
+	var checks passwordVerifications
+	hasher := blockingHasher{entered: make(chan struct{}), release: make(chan struct{})}
+
+	before := &models.User{Email: "jane@example.com", PasswordHash: "old"}
+	after := &models.User{Email: "jane@example.com", PasswordHash: "new"}
+
+	done := make(chan bool)
+	go func() { done <- checks.verify(hasher, before, "Secret123!") }()
+	<-hasher.entered
+
+	// A login that read the hash of a concurrent reset verifies against that hash
+	result := make(chan bool)
+	go func() { result <- checks.verify(hasher, after, "Secret123!") }()
+	select {
+	case valid := <-result:
+		if valid {
+			t.Errorf("Expected the password to be verified against the new hash")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Expected the verification against the new hash not to wait for the old one")
+		close(hasher.release)
+		<-done
+		<-result
+		return
+	}
+
+	close(hasher.release)
+	if !<-done {
+		t.Errorf("Expected the verification against the old hash to succeed")
+	}
+}
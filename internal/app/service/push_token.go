@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// PushTokenRepository stores the push tokens of user devices
+type PushTokenRepository interface {
+	Upsert(ctx context.Context, pushToken *models.PushToken) error
+	DeleteOtherDevices(ctx context.Context, pushToken *models.PushToken) ([]*models.PushToken, error)
+	Delete(ctx context.Context, userID uuid.UUID, deviceID string) (*models.PushToken, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) ([]*models.PushToken, error)
+}
+
+// PushTokenEventRepository writes push token events to the notification outbox
+type PushTokenEventRepository interface {
+	Create(ctx context.Context, event *repository.NotificationEventLog) error
+}
+
+// PushTokenPruner removes the push tokens of users whose sessions were revoked
+type PushTokenPruner interface {
+	PruneUser(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// PushTokenService keeps the registry of the devices users receive push notifications on.
+// Every change is written to the outbox in the same transaction, and published for
+// notification-svc, which sends the push notifications.
+type PushTokenService struct {
+	pushTokenRepo PushTokenRepository
+	eventRepo     PushTokenEventRepository
+	txManager     TxManager
+	tokenMaker    token.TokenMaker
+}
+
+// NewPushTokenService creates a new PushTokenService instance
+func NewPushTokenService(
+	pushTokenRepo PushTokenRepository,
+	eventRepo PushTokenEventRepository,
+	txManager TxManager,
+	tokenMaker token.TokenMaker,
+) *PushTokenService {
+	log.Info("Initializing PushTokenService")
+
+	return &PushTokenService{
+		pushTokenRepo: pushTokenRepo,
+		eventRepo:     eventRepo,
+		txManager:     txManager,
+		tokenMaker:    tokenMaker,
+	}
+}
+
+// RegisterPushToken stores the push token of a device of the caller, replacing the token the
+// device registered before. A token registered by another user or device before is removed
+// from it, so a shared device only receives the notifications of the user signed in last.
+func (s *PushTokenService) RegisterPushToken(ctx context.Context, req dto.RegisterPushTokenReq) (*models.PushToken, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":    "RegisterPushToken",
+		"device_id": req.DeviceID,
+		"platform":  req.Platform,
+	})
+
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+	userID, err := uuid.Parse(caller.UserID)
+	if err != nil {
+		return nil, errs.ErrInvalidAccessToken
+	}
+	logger = logger.WithField("user_id", userID)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid push token registration")
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	pushToken := &models.PushToken{
+		UserID:    userID,
+		DeviceID:  req.DeviceID,
+		Platform:  models.PushPlatform(req.Platform),
+		Token:     req.Token,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	var reassigned []*models.PushToken
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		reassigned, err = s.pushTokenRepo.DeleteOtherDevices(txCtx, pushToken)
+		if err != nil {
+			return err
+		}
+		if err := s.removed(txCtx, reassigned, models.PushTokenReassigned, now); err != nil {
+			return err
+		}
+
+		if err := s.pushTokenRepo.Upsert(txCtx, pushToken); err != nil {
+			return err
+		}
+		return s.publish(txCtx, events.PushTokenRegisteredEventType, pushToken, "", now)
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to register push token")
+		return nil, err
+	}
+
+	logger.WithField("reassigned", len(reassigned)).Info("Push token registered")
+
+	return pushToken, nil
+}
+
+// UnregisterPushToken removes the push token of a device of the caller, e.g. on logout, and
+// reports whether the device had one
+func (s *PushTokenService) UnregisterPushToken(ctx context.Context, req dto.UnregisterPushTokenReq) (bool, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":    "UnregisterPushToken",
+		"device_id": req.DeviceID,
+	})
+
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return false, err
+	}
+	userID, err := uuid.Parse(caller.UserID)
+	if err != nil {
+		return false, errs.ErrInvalidAccessToken
+	}
+	logger = logger.WithField("user_id", userID)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid push token removal")
+		return false, err
+	}
+
+	var removed *models.PushToken
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		removed, err = s.pushTokenRepo.Delete(txCtx, userID, req.DeviceID)
+		if err != nil || removed == nil {
+			return err
+		}
+		return s.publish(txCtx, events.PushTokenUnregisteredEventType, removed, models.PushTokenUnregistered, time.Now().UnixMilli())
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to unregister push token")
+		return false, err
+	}
+
+	logger.WithField("unregistered", removed != nil).Info("Push token unregistered")
+
+	return removed != nil, nil
+}
+
+// PruneUser removes every push token of a user whose sessions were revoked, so their devices
+// stop receiving notifications until they sign in again. It joins the transaction of ctx, if
+// any, so the tokens are only removed along with the sessions.
+func (s *PushTokenService) PruneUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	prune := func(ctx context.Context) (int, error) {
+		removed, err := s.pushTokenRepo.DeleteByUserID(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		return len(removed), s.removed(ctx, removed, models.PushTokenSessionsRevoked, time.Now().UnixMilli())
+	}
+
+	if _, ok := tx.GetTxFromContext(ctx); ok {
+		return prune(ctx)
+	}
+
+	var pruned int
+	err := s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		var err error
+		pruned, err = prune(context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx()))
+		return err
+	})
+	return pruned, err
+}
+
+// removed publishes the removal of push tokens
+func (s *PushTokenService) removed(
+	ctx context.Context,
+	pushTokens []*models.PushToken,
+	reason models.PushTokenRemovalReason,
+	changedAt int64,
+) error {
+	for _, pushToken := range pushTokens {
+		if err := s.publish(ctx, events.PushTokenUnregisteredEventType, pushToken, reason, changedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publish writes a push token event to the outbox
+func (s *PushTokenService) publish(
+	ctx context.Context,
+	eventType events.EventType,
+	pushToken *models.PushToken,
+	reason models.PushTokenRemovalReason,
+	changedAt int64,
+) error {
+	payload, err := json.Marshal(dto.SendPushTokenParams{
+		UserID:    pushToken.UserID.String(),
+		DeviceID:  pushToken.DeviceID,
+		Platform:  string(pushToken.Platform),
+		Token:     pushToken.Token,
+		Reason:    string(reason),
+		ChangedAt: changedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.eventRepo.Create(ctx, &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(eventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	})
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// APIKeyMetadataKey is the incoming metadata key carrying a partner API key
+	APIKeyMetadataKey = "x-api-key"
+	// AuthorizationMetadataKey is the incoming metadata key carrying a bearer access token
+	AuthorizationMetadataKey = "authorization"
+)
+
+type QuotaRepository interface {
+	Increment(ctx context.Context, subject string, methods []string, windowStart int64) (map[string]int64, error)
+	ListBySubject(ctx context.Context, subject string, windowStart int64) ([]*models.QuotaUsage, error)
+}
+
+// quotaSubject identifies who a call is accounted to
+type quotaSubject struct {
+	id    string
+	limit int64
+}
+
+// QuotaService accounts calls per API key or user and enforces their quotas
+type QuotaService struct {
+	config        config.QuotaConfig
+	quotaRepo     QuotaRepository
+	tokenMaker    token.TokenMaker
+	eventPipeline EventPipeline
+	methodLimits  map[string]int64
+	exempt        map[string]struct{}
+	now           func() time.Time
+}
+
+// NewQuotaService creates a new QuotaService instance. Calls to exemptMethods are
+// neither counted nor limited, in addition to the configured exempt methods.
+func NewQuotaService(
+	config config.QuotaConfig,
+	quotaRepo QuotaRepository,
+	tokenMaker token.TokenMaker,
+	eventPipeline EventPipeline,
+	exemptMethods ...string,
+) *QuotaService {
+	log.Info("Initializing QuotaService")
+
+	methodLimits := make(map[string]int64, len(config.MethodLimits))
+	for _, limit := range config.MethodLimits {
+		methodLimits[limit.Method] = limit.Limit
+	}
+
+	exempt := make(map[string]struct{}, len(config.ExemptMethods)+len(exemptMethods))
+	for _, method := range append(config.ExemptMethods, exemptMethods...) {
+		exempt[method] = struct{}{}
+	}
+
+	return &QuotaService{
+		config:        config,
+		quotaRepo:     quotaRepo,
+		tokenMaker:    tokenMaker,
+		eventPipeline: eventPipeline,
+		methodLimits:  methodLimits,
+		exempt:        exempt,
+		now:           time.Now,
+	}
+}
+
+// Consume records a call to method for the caller and rejects it once a quota
+// is exceeded. Rejected calls are counted as well. Anonymous calls are not accounted.
+// The usage returned is the exceeded bucket, or the bucket with the fewest calls left
+// once it reached quota.warning_percent of its limit, for clients to back off; nil otherwise.
+func (s *QuotaService) Consume(ctx context.Context, method string) (*models.QuotaUsage, error) {
+	if _, ok := s.exempt[method]; ok {
+		return nil, nil
+	}
+
+	subject, err := s.resolveSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if subject == nil {
+		return nil, nil
+	}
+
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":     "Consume",
+		"subject":    subject.id,
+		"rpc_method": method,
+	})
+
+	windowStart, windowEnd := models.QuotaWindow(models.QuotaPeriod(s.config.Period), s.now())
+	used, err := s.quotaRepo.Increment(
+		ctx,
+		subject.id,
+		[]string{models.QuotaAllMethods, method},
+		windowStart.UnixMilli(),
+	)
+	if err != nil {
+		metrics.QuotaErrors.Inc()
+		if s.config.FailOpen {
+			logger.WithError(err).Warn("Failed to record quota usage, letting call through")
+			return nil, nil
+		}
+		logger.WithError(err).Error("Failed to record quota usage")
+		return nil, err
+	}
+
+	buckets := []*models.QuotaUsage{
+		{Method: models.QuotaAllMethods, Limit: subject.limit},
+		{Method: method, Limit: s.methodLimits[method]},
+	}
+	for _, bucket := range buckets {
+		bucket.Subject = subject.id
+		bucket.Used = used[bucket.Method]
+		bucket.WindowStart = windowStart.UnixMilli()
+		bucket.WindowEnd = windowEnd.UnixMilli()
+		s.publishThresholds(ctx, logger, bucket)
+	}
+
+	for _, bucket := range buckets {
+		if bucket.Exceeded() {
+			metrics.QuotaRejected.WithLabelValues(method).Inc()
+			logger.WithFields(log.Fields{
+				"bucket": bucket.Method,
+				"used":   bucket.Used,
+				"limit":  bucket.Limit,
+			}).Warn("Quota exceeded")
+			return bucket, errs.ErrQuotaExceeded.
+				WithDetail("method", bucket.Method).
+				WithDetail("limit", bucket.Limit).
+				WithDetail("resets_at", bucket.WindowEnd)
+		}
+	}
+
+	var warning *models.QuotaUsage
+	for _, bucket := range buckets {
+		if bucket.Reached(s.config.WarningPercent) && (warning == nil || bucket.Remaining() < warning.Remaining()) {
+			warning = bucket
+		}
+	}
+
+	return warning, nil
+}
+
+// GetUsage returns the caller's usage in the current quota window
+func (s *QuotaService) GetUsage(ctx context.Context) (*dto.GetQuotaUsageResp, error) {
+	logger := log.FromContext(ctx).WithField("method", "GetUsage")
+
+	if !s.config.Enabled {
+		return nil, errs.ErrQuotaDisabled
+	}
+
+	subject, err := s.resolveSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if subject == nil {
+		return nil, errs.ErrMissingCredentials
+	}
+
+	windowStart, windowEnd := models.QuotaWindow(models.QuotaPeriod(s.config.Period), s.now())
+	usages, err := s.quotaRepo.ListBySubject(ctx, subject.id, windowStart.UnixMilli())
+	if err != nil {
+		logger.WithError(err).WithField("subject", subject.id).Error("Failed to list quota usage")
+		return nil, err
+	}
+
+	// Report every limited bucket, including the ones not used yet in this window
+	byMethod := make(map[string]*models.QuotaUsage, len(usages))
+	for _, usage := range usages {
+		byMethod[usage.Method] = usage
+	}
+	if _, ok := byMethod[models.QuotaAllMethods]; !ok {
+		usages = append([]*models.QuotaUsage{{Subject: subject.id, Method: models.QuotaAllMethods}}, usages...)
+	}
+	for method := range s.methodLimits {
+		if _, ok := byMethod[method]; !ok {
+			usages = append(usages, &models.QuotaUsage{Subject: subject.id, Method: method})
+		}
+	}
+
+	for _, usage := range usages {
+		usage.WindowStart = windowStart.UnixMilli()
+		usage.WindowEnd = windowEnd.UnixMilli()
+		if usage.Method == models.QuotaAllMethods {
+			usage.Limit = subject.limit
+		} else {
+			usage.Limit = s.methodLimits[usage.Method]
+		}
+	}
+
+	return &dto.GetQuotaUsageResp{
+		Subject: subject.id,
+		Usages:  usages,
+	}, nil
+}
+
+// resolveSubject identifies the caller from its API key or bearer token.
+// Unknown API keys are rejected; calls without valid credentials return a nil subject.
+func (s *QuotaService) resolveSubject(ctx context.Context) (*quotaSubject, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	if keys := md.Get(APIKeyMetadataKey); len(keys) > 0 {
+		key, ok := matchKeyHash(keys[0], s.config.APIKeys, func(key config.QuotaAPIKeyConfig) string { return key.KeyHash })
+		if !ok {
+			return nil, errs.ErrInvalidAPIKey
+		}
+		return &quotaSubject{id: "api_key:" + key.ID, limit: key.Limit}, nil
+	}
+
+	if _, ok := bearerToken(ctx); ok {
+		payload, err := authenticate(ctx, s.tokenMaker)
+		if err != nil {
+			return nil, nil
+		}
+		return &quotaSubject{id: "user:" + payload.UserID, limit: s.config.UserLimit}, nil
+	}
+
+	return nil, nil
+}
+
+// publishThresholds submits a billing event for each threshold the last call crossed
+func (s *QuotaService) publishThresholds(ctx context.Context, logger *log.Logger, usage *models.QuotaUsage) {
+	for _, threshold := range models.CrossedThresholds(usage.Used, usage.Limit, s.config.Thresholds) {
+		metrics.QuotaThresholdsReached.WithLabelValues(strconv.Itoa(threshold)).Inc()
+
+		payload, err := json.Marshal(dto.SendQuotaThresholdParams{
+			Subject:     usage.Subject,
+			Method:      usage.Method,
+			Used:        usage.Used,
+			Limit:       usage.Limit,
+			Threshold:   threshold,
+			WindowStart: usage.WindowStart,
+			WindowEnd:   usage.WindowEnd,
+		})
+		if err != nil {
+			logger.WithError(err).Error("Failed to marshal quota threshold payload")
+			continue
+		}
+
+		if err := s.eventPipeline.Submit(ctx, &repository.NotificationEventLog{
+			ID:        uuid.New().String(),
+			EventName: string(events.QuotaThresholdEventType),
+			Payload:   payload,
+			Status:    repository.NotificationEventLogStatusPending,
+		}); err != nil {
+			logger.WithError(err).WithField("threshold", threshold).Warn("Failed to submit quota threshold event")
+		}
+	}
+}
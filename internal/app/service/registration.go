@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// RegistrationCodeRepository stores the codes verifying the email of registrations
+type RegistrationCodeRepository interface {
+	Create(ctx context.Context, code *models.RegistrationCode, sentBefore int64) (bool, error)
+	Attempt(ctx context.Context, email models.Email, now int64, maxAttempts int) (*models.RegistrationCode, error)
+	Delete(ctx context.Context, email models.Email) error
+}
+
+// StartRegistration emails a code verifying the email of a registration, in the email_otp
+// registration mode. Addresses that already have an account are answered the same way but
+// get no email, so the method does not reveal which addresses are registered.
+func (s *UserService) StartRegistration(ctx context.Context, req dto.StartRegistrationReq) (*dto.StartRegistrationResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "StartRegistration",
+		"email":  req.Email,
+	})
+
+	if !s.config.Registration.RequiresEmailOTP() {
+		logger.Warn("Registration with an emailed code is not enabled")
+		return nil, errs.ErrEmailOTPRegistrationDisabled
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return nil, err
+	}
+
+	if err := s.velocity.CheckVelocity(ctx, s.velocityAttempt(ctx, models.VelocityActionRegister, req.Email)); err != nil {
+		logger.WithError(err).Warn("Registration refused by velocity rules")
+		return nil, err
+	}
+
+	if _, err := s.resolveClient(ctx, req.ClientID, models.GrantTypeRegister); err != nil {
+		logger.WithError(err).Warn("Client is not allowed to register users")
+		return nil, err
+	}
+
+	// Invite codes and the daily cap are checked when the registration completes, but no
+	// code is emailed to an address that could not complete it
+	gates, err := s.registrationGates.Get(ctx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get registration gates")
+		return nil, err
+	}
+	if gates.AllowlistOnly && !gates.AllowsEmail(models.Email(req.Email)) {
+		logger.Warn("Registration refused by registration gates")
+		return nil, errs.ErrEmailNotAllowlisted
+	}
+
+	// The email may be registered in any region
+	lookupCtx, err := withEmailRegion(ctx, s.regions, s.emailDirectory, req.Email)
+	if err != nil {
+		logger.WithError(err).Error("Failed to route lookup to the region of the email")
+		return nil, err
+	}
+	registered := true
+	if _, err := s.userRepo.GetByEmail(lookupCtx, req.Email); errors.Is(err, errs.ErrUserNotFound) {
+		registered = false
+	} else if err != nil {
+		logger.WithError(err).Error("Failed to look up email")
+		return nil, err
+	}
+
+	now := time.Now()
+	code, record, err := models.NewRegistrationCode(models.Email(req.Email), s.config.Registration.CodeTTL, now, s.tokens)
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate registration code")
+		return nil, err
+	}
+
+	stored, err := s.registrationCodes.Create(ctx, record, now.Add(-s.config.Registration.ResendInterval).UnixMilli())
+	if err != nil {
+		logger.WithError(err).Error("Failed to store registration code")
+		return nil, err
+	}
+	if !stored {
+		logger.Warn("Registration code was sent recently")
+		return nil, errs.ErrRegistrationCodeRecentlySent
+	}
+
+	resp := &dto.StartRegistrationResp{ExpiresAt: record.ExpiresAt}
+	if registered {
+		logger.Info("Registration started for a registered email, no code sent")
+		return resp, nil
+	}
+
+	payload, err := json.Marshal(dto.SendRegistrationCodeParams{
+		Email:     req.Email,
+		Code:      code,
+		ExpiresAt: time.UnixMilli(record.ExpiresAt),
+		Locale:    requestLocale(ctx),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal registration code payload")
+		return nil, err
+	}
+
+	// Unlike login notifications, the registration cannot go on without the email
+	if err := s.eventPipeline.Submit(ctx, &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(events.RegistrationCodeSentEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	}); err != nil {
+		logger.WithError(err).Error("Failed to submit registration code event")
+		return nil, err
+	}
+
+	logger.Info("Registration code sent")
+
+	return resp, nil
+}
+
+// CompleteRegistration creates the account of a registration with the code emailed by
+// StartRegistration. The code is only spent once the account exists, so a registration
+// refused e.g. for a taken username can be retried with it.
+func (s *UserService) CompleteRegistration(ctx context.Context, req dto.CompleteRegistrationReq) (*dto.RegisterResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "CompleteRegistration",
+		"email":  req.Email,
+	})
+
+	if !s.config.Registration.RequiresEmailOTP() {
+		logger.Warn("Registration with an emailed code is not enabled")
+		return nil, errs.ErrEmailOTPRegistrationDisabled
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return nil, err
+	}
+
+	email := models.Email(req.Email)
+	err := s.verifyRegistrationCode(ctx, email, req.Code)
+	if err != nil {
+		logger.WithError(err).Warn("Registration code rejected")
+		s.recordSecurityEvent(ctx, s.securityEvent(ctx, models.SecurityActionRegister, nil, req.Email, req.ClientID), err)
+		return nil, err
+	}
+
+	resp, err := s.register(ctx, "CompleteRegistration", req.RegisterReq, models.EmailVerifiedByRegistrationCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// A code left behind expires on its own, so failing to delete it does not fail the registration
+	if err := s.registrationCodes.Delete(ctx, email); err != nil {
+		logger.WithError(err).Warn("Failed to delete registration code")
+	}
+
+	return resp, nil
+}
+
+// verifyRegistrationCode checks code against the one emailed to email, counting the attempt
+func (s *UserService) verifyRegistrationCode(ctx context.Context, email models.Email, code string) error {
+	record, err := s.registrationCodes.Attempt(ctx, email, time.Now().UnixMilli(), s.config.Registration.MaxAttempts)
+	if err != nil {
+		return err
+	}
+	if !record.Matches(code, s.tokens) {
+		return errs.ErrInvalidRegistrationCode
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+)
+
+// RegistrationGateChecker applies the registration gates to sign-ups
+type RegistrationGateChecker interface {
+	Get(ctx context.Context) (*models.RegistrationGates, error)
+	ReserveSignup(ctx context.Context, day time.Time, dailyCap int) (bool, error)
+	ReleaseSignup(ctx context.Context, day time.Time) error
+}
+
+// RegistrationGateRepository stores the registration gates and counts the daily sign-ups
+type RegistrationGateRepository interface {
+	Get(ctx context.Context) (*models.RegistrationGates, error)
+	Set(ctx context.Context, gates *models.RegistrationGates) error
+	CountSignups(ctx context.Context, day time.Time) (int, error)
+}
+
+// RegistrationGateService lets admins limit sign-ups at runtime during a soft launch. The
+// gates are read on every registration, so changes apply to every replica at once.
+type RegistrationGateService struct {
+	adminKeys []config.AdminAPIKeyConfig
+	gateRepo  RegistrationGateRepository
+}
+
+// NewRegistrationGateService creates a new RegistrationGateService instance
+func NewRegistrationGateService(cfg *config.Config, gateRepo RegistrationGateRepository) *RegistrationGateService {
+	log.Info("Initializing RegistrationGateService")
+
+	return &RegistrationGateService{
+		adminKeys: cfg.Admin.APIKeys,
+		gateRepo:  gateRepo,
+	}
+}
+
+// GetRegistrationGates returns the registration gates and the sign-ups of the current UTC day
+func (s *RegistrationGateService) GetRegistrationGates(ctx context.Context) (*dto.RegistrationGatesResp, error) {
+	logger := log.FromContext(ctx).WithField("method", "GetRegistrationGates")
+
+	if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+
+	gates, err := s.gateRepo.Get(ctx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get registration gates")
+		return nil, err
+	}
+
+	return s.withSignupsToday(ctx, logger, gates)
+}
+
+// SetRegistrationGates replaces the registration gates; they apply from the next registration
+func (s *RegistrationGateService) SetRegistrationGates(ctx context.Context, req dto.SetRegistrationGatesReq) (*dto.RegistrationGatesResp, error) {
+	logger := log.FromContext(ctx).WithField("method", "SetRegistrationGates")
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	gates, err := req.Gates(adminActor(admin), time.Now())
+	if err != nil {
+		logger.WithError(err).Warn("Invalid registration gates")
+		return nil, err
+	}
+
+	if err := s.gateRepo.Set(ctx, gates); err != nil {
+		logger.WithError(err).Error("Failed to set registration gates")
+		return nil, err
+	}
+
+	logger.WithFields(log.Fields{
+		"invite_code_required": gates.InviteCodeRequired,
+		"invite_codes":         len(gates.InviteCodes),
+		"daily_cap":            gates.DailyCap,
+		"allowlist_only":       gates.AllowlistOnly,
+		"allowlist":            len(gates.Allowlist),
+	}).Info("Registration gates set")
+
+	return s.withSignupsToday(ctx, logger, gates)
+}
+
+func (s *RegistrationGateService) withSignupsToday(ctx context.Context, logger *log.Logger, gates *models.RegistrationGates) (*dto.RegistrationGatesResp, error) {
+	signups, err := s.gateRepo.CountSignups(ctx, time.Now())
+	if err != nil {
+		logger.WithError(err).Error("Failed to count today's sign-ups")
+		return nil, err
+	}
+
+	return &dto.RegistrationGatesResp{Gates: gates, SignupsToday: signups}, nil
+}
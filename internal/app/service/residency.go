@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// RegionRouter routes the queries made with a context to the database of a residency region
+type RegionRouter interface {
+	WithRegion(ctx context.Context, region string) (context.Context, error)
+	// HasDatabase reports whether the region keeps its users' data in a database of its own
+	HasDatabase(region string) bool
+}
+
+// TenantRouter routes the queries made with a context to the schema of an organization; nil
+// while tenant schema isolation is disabled
+type TenantRouter interface {
+	WithTenant(ctx context.Context, organizationID uuid.UUID) (context.Context, error)
+}
+
+// EmailDirectory maps the emails of the users of every region to the region their data is
+// stored in. Emails are only unique per database, so it is kept in the home database, where the
+// registrations of every region meet.
+type EmailDirectory interface {
+	// Claim enters the email of a new user and reports whether no user had it already
+	Claim(ctx context.Context, user *models.User) (bool, error)
+	Release(ctx context.Context, user *models.User) error
+	// Region returns the region of the user with the email, or errs.ErrUserNotFound
+	Region(ctx context.Context, email string) (string, error)
+}
+
+// withEmailRegion routes the queries made with the returned context to the database of the
+// user with the email. Emails missing from the directory keep the region of the request.
+func withEmailRegion(ctx context.Context, regions RegionRouter, directory EmailDirectory, email string) (context.Context, error) {
+	region, err := directory.Region(ctx, email)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		return ctx, nil
+	}
+	if err != nil {
+		return ctx, err
+	}
+	return regions.WithRegion(ctx, region)
+}
+
+// linkRouteSeparator separates the secret of an emailed link token from the region and
+// organization of its user; secret tokens are base64url, which never contains it
+const linkRouteSeparator = "."
+
+// linkToken appends the region and organization of a user to the secret of an emailed link,
+// e.g. a password reset. The page of the link sends nothing but the token, which is stored with
+// the user's data, so the token names the database and schema to redeem it in.
+func linkToken(secret string, user *models.User) string {
+	organization := ""
+	if user.OrganizationID != uuid.Nil {
+		organization = user.OrganizationID.String()
+	}
+	return strings.Join([]string{secret, user.Residency, organization}, linkRouteSeparator)
+}
+
+// withLinkRoute routes the queries made with the returned context to the region and
+// organization of a token of linkToken and returns its secret. Tokens naming an unknown region
+// or a malformed organization fail with invalid; tokens of links sent without a route keep the
+// routing of the request.
+func withLinkRoute(ctx context.Context, regions RegionRouter, tenants TenantRouter, token string, invalid error) (context.Context, string, error) {
+	secret, route, ok := strings.Cut(token, linkRouteSeparator)
+	if !ok {
+		return ctx, token, nil
+	}
+	region, organization, ok := strings.Cut(route, linkRouteSeparator)
+	if !ok || secret == "" {
+		return ctx, "", invalid
+	}
+
+	ctx, err := regions.WithRegion(ctx, region)
+	if errors.Is(err, errs.ErrUnknownResidencyRegion) {
+		return ctx, "", invalid
+	} else if err != nil {
+		return ctx, "", err
+	}
+
+	if organization == "" || tenants == nil {
+		return ctx, secret, nil
+	}
+	organizationID, err := uuid.Parse(organization)
+	if err != nil {
+		return ctx, "", invalid
+	}
+	ctx, err = tenants.WithTenant(ctx, organizationID)
+	if err != nil {
+		return ctx, "", err
+	}
+	return ctx, secret, nil
+}
+
+// withTokenRoute routes the queries made with the returned context to the region and
+// organization in the claims of an access token, for calls carrying the token elsewhere than
+// in the authorization metadata the routing interceptors read
+func withTokenRoute(ctx context.Context, regions RegionRouter, tenants TenantRouter, payload *token.Payload) (context.Context, error) {
+	ctx, err := regions.WithRegion(ctx, payload.Residency)
+	if err != nil {
+		return ctx, err
+	}
+
+	if payload.OrganizationID == "" || tenants == nil {
+		return ctx, nil
+	}
+	organizationID, err := uuid.Parse(payload.OrganizationID)
+	if err != nil {
+		return ctx, err
+	}
+	return tenants.WithTenant(ctx, organizationID)
+}
+
+// residencyOf returns the region the data of a new user is stored in: the region of the
+// user's organization, otherwise the region of the user's country, otherwise the home region.
+// It is "" while residency is disabled.
+func residencyOf(cfg config.ResidencyConfig, org *models.Organization, country string) string {
+	if !cfg.Enabled() {
+		return ""
+	}
+	if org != nil && org.Residency != "" {
+		return org.Residency
+	}
+	return cfg.RegionOfCountry(country)
+}
+
+// requestCountry returns the country of the caller that the edge proxy passes in the
+// metadata key, "" when it passes none
+func requestCountry(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || key == "" {
+		return ""
+	}
+	if values := md.Get(strings.ToLower(key)); len(values) > 0 {
+		return countryCode(values[0])
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+)
+
+type regionKey struct{}
+
+// taggingRegions puts the region on the context
+type taggingRegions struct{}
+
+func (taggingRegions) WithRegion(ctx context.Context, region string) (context.Context, error) {
+	return context.WithValue(ctx, regionKey{}, region), nil
+}
+func (taggingRegions) HasDatabase(string) bool { return true }
+
+// directory is an email directory over a map
+type directory map[string]string
+
+func (d directory) Claim(context.Context, *models.User) (bool, error) { return true, nil }
+func (d directory) Release(context.Context, *models.User) error       { return nil }
+func (d directory) Region(_ context.Context, email string) (string, error) {
+	region, ok := d[email]
+	if !ok {
+		return "", errs.ErrUserNotFound
+	}
+	return region, nil
+}
+
+func TestWithEmailRegion(t *testing.T) {
+	emails := directory{"jane@example.com": "eu", "john@example.com": ""}
+	requestCtx := context.WithValue(context.Background(), regionKey{}, "us")
+
+	tests := []struct {
+		name     string
+		email    string
+		expected string
+	}{
+		{name: "regional user", email: "jane@example.com", expected: "eu"},
+		{name: "untagged user", email: "john@example.com", expected: ""},
+		{name: "unknown email", email: "jim@example.com", expected: "us"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := withEmailRegion(requestCtx, taggingRegions{}, emails, tt.email)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if region := ctx.Value(regionKey{}); region != tt.expected {
+				t.Errorf("Expected region %q, got %q", tt.expected, region)
+			}
+		})
+	}
+
+	failing := failingDirectory{directory: emails}
+	if _, err := withEmailRegion(requestCtx, taggingRegions{}, failing, "jane@example.com"); !errors.Is(err, errFailingDirectory) {
+		t.Errorf("Expected the directory error, got %v", err)
+	}
+}
+
+var errFailingDirectory = errors.New("directory unavailable")
+
+// failingDirectory cannot be read
+type failingDirectory struct {
+	directory
+}
+
+func (failingDirectory) Region(context.Context, string) (string, error) {
+	return "", errFailingDirectory
+}
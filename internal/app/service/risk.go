@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// RiskSignalsScope is the service key scope required to read risk signals
+const RiskSignalsScope = "risk_signals"
+
+// RiskUserRepository looks up the users risk signals are computed for
+type RiskUserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// RiskRepository aggregates fraud signals from the audit trail and session usage
+type RiskRepository interface {
+	CountDevices(ctx context.Context, userID uuid.UUID, since int64) (int64, error)
+	CountRegistrationIPReuse(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountSessionAnomalies(ctx context.Context, userID uuid.UUID, since int64) (int64, error)
+	IsDisposableDomain(ctx context.Context, domains []string) (bool, error)
+}
+
+// RiskService serves aggregated fraud signals to internal services
+type RiskService struct {
+	serviceKeys       []config.ServiceAPIKeyConfig
+	userRepo          RiskUserRepository
+	riskRepo          RiskRepository
+	disposableDomains []string
+	deviceWindow      time.Duration
+	now               func() time.Time
+}
+
+// NewRiskService creates a new RiskService instance
+func NewRiskService(cfg *config.Config, userRepo RiskUserRepository, riskRepo RiskRepository) *RiskService {
+	log.Info("Initializing RiskService")
+
+	disposableDomains := make([]string, 0, len(cfg.Risk.DisposableEmailDomains))
+	for _, domain := range cfg.Risk.DisposableEmailDomains {
+		disposableDomains = append(disposableDomains, strings.ToLower(strings.TrimSpace(domain)))
+	}
+
+	return &RiskService{
+		serviceKeys:       cfg.Services.APIKeys,
+		userRepo:          userRepo,
+		riskRepo:          riskRepo,
+		disposableDomains: disposableDomains,
+		deviceWindow:      cfg.Risk.DeviceWindow,
+		now:               time.Now,
+	}
+}
+
+// GetRiskSignals returns the aggregated fraud signals of a user
+func (s *RiskService) GetRiskSignals(ctx context.Context, req dto.GetRiskSignalsReq) (*models.RiskSignals, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "GetRiskSignals",
+		"user_id": req.UserID,
+	})
+
+	caller, err := authorizeService(ctx, s.serviceKeys, RiskSignalsScope)
+	if err != nil {
+		logger.WithError(err).Warn("Service authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("caller", caller)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid risk signals request")
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, uuid.MustParse(req.UserID))
+	if err != nil {
+		logger.WithError(err).Warn("Failed to get user")
+		return nil, err
+	}
+
+	now := s.now()
+	signals := &models.RiskSignals{
+		UserID:         user.ID.String(),
+		CreatedAt:      user.CreatedAt,
+		AccountAgeDays: models.AccountAgeDays(user.CreatedAt, now),
+		GeneratedAt:    now.UnixMilli(),
+	}
+
+	if signals.DeviceCount, err = s.riskRepo.CountDevices(ctx, user.ID, now.Add(-s.deviceWindow).UnixMilli()); err != nil {
+		logger.WithError(err).Error("Failed to count devices")
+		return nil, err
+	}
+
+	if signals.SessionAnomalyCount, err = s.riskRepo.CountSessionAnomalies(ctx, user.ID, now.Add(-s.deviceWindow).UnixMilli()); err != nil {
+		logger.WithError(err).Error("Failed to count session anomalies")
+		return nil, err
+	}
+
+	if signals.RegistrationIPReuseCount, err = s.riskRepo.CountRegistrationIPReuse(ctx, user.ID); err != nil {
+		logger.WithError(err).Error("Failed to count registration IP reuse")
+		return nil, err
+	}
+
+	if signals.DisposableEmail, err = s.isDisposable(ctx, user.Email.String()); err != nil {
+		logger.WithError(err).Error("Failed to check disposable email domain")
+		return nil, err
+	}
+
+	return signals, nil
+}
+
+// isDisposable checks the email domain and its parent domains against the configured
+// list, then the disposable_email_domains table
+func (s *RiskService) isDisposable(ctx context.Context, email string) (bool, error) {
+	domains := models.EmailDomainCandidates(email)
+	if len(domains) == 0 {
+		return false, nil
+	}
+
+	for _, domain := range domains {
+		if slices.Contains(s.disposableDomains, domain) {
+			return true, nil
+		}
+	}
+
+	return s.riskRepo.IsDisposableDomain(ctx, domains)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/slo"
+
+	"github.com/google/uuid"
+)
+
+// securityEvent describes an attempt of the caller for the SIEM. user is nil when the attempt
+// is not tied to a known user, e.g. a login with an unknown email.
+func (s *UserService) securityEvent(ctx context.Context, action models.SecurityAction, user *models.User, email, clientID string) *models.SecurityEvent {
+	device := deviceMetadata(ctx)
+	event := &models.SecurityEvent{
+		Action:   action,
+		Outcome:  models.SecurityOutcomeSuccess,
+		Severity: models.SecuritySeverityInformational,
+		Email:    email,
+		ClientID: clientID,
+		At:       time.Now().UnixMilli(),
+	}
+	event.IPAddress, _ = device[models.AuditMetadataIPAddress].(string)
+	event.UserAgent, _ = device[models.AuditMetadataUserAgent].(string)
+
+	if user != nil {
+		event.UserID = user.ID.String()
+		event.Email = user.Email.String()
+		if user.OrganizationID != uuid.Nil {
+			event.OrganizationID = user.OrganizationID.String()
+		}
+	}
+	return event
+}
+
+// recordSecurityEvent streams the outcome of an attempt to the SIEM, a success for a nil err
+func (s *UserService) recordSecurityEvent(ctx context.Context, event *models.SecurityEvent, err error) {
+	if err != nil {
+		event.Outcome = models.SecurityOutcomeFailure
+		event.Reason, event.Severity = securityFailure(err)
+	}
+	s.securityEvents.Record(ctx, event)
+}
+
+// recordCanaryAccess streams an access to a canary account to the SIEM as critical event
+func (s *UserService) recordCanaryAccess(ctx context.Context, canary *models.CanaryAccount, access models.CanaryAccess) {
+	reason := "canary account accessed by " + string(access.Kind)
+	if access.PasswordValid {
+		reason += " with the planted password"
+	}
+
+	s.securityEvents.Record(ctx, &models.SecurityEvent{
+		Action:    models.SecurityActionCanaryAccess,
+		Outcome:   models.SecurityOutcomeUnknown,
+		Severity:  models.SecuritySeverityCritical,
+		Reason:    reason,
+		UserID:    canary.UserID.String(),
+		ClientID:  access.ClientID,
+		IPAddress: access.IPAddress,
+		UserAgent: access.UserAgent,
+		At:        access.At,
+	})
+}
+
+// securityFailure returns the reason and severity of a failed attempt. Only the messages of
+// domain errors are kept since internal errors may carry details of the database.
+func securityFailure(err error) (string, models.SecuritySeverity) {
+	wrapper, ok := errs.As(err)
+	if !ok || slo.IsServerError(wrapper.Code) {
+		return "internal error", models.SecuritySeverityInformational
+	}
+
+	switch {
+	case errors.Is(err, errs.ErrUserBanned),
+		errors.Is(err, errs.ErrTooManyAttempts),
+		errors.Is(err, errs.ErrTokenRevoked):
+		// Banned users, attempts over a velocity lock and revoked tokens point at an attacker
+		return wrapper.Message, models.SecuritySeverityMedium
+	}
+	return wrapper.Message, models.SecuritySeverityLow
+}
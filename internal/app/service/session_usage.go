@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/events"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/peer"
+)
+
+// SessionUsageRepository stores the usage of refresh token sessions
+type SessionUsageRepository interface {
+	Get(ctx context.Context, sessionID uuid.UUID) (*models.SessionUsage, error)
+	Upsert(ctx context.Context, usage *models.SessionUsage) error
+	Touch(ctx context.Context, sessionID uuid.UUID, at int64) error
+}
+
+// SessionEventRepository writes session anomalies to the notification outbox
+type SessionEventRepository interface {
+	Create(ctx context.Context, event *repository.NotificationEventLog) error
+}
+
+// maxTrackedActivity bounds the memory used to throttle the recording of session activity
+const maxTrackedActivity = 100000
+
+// SessionTracker records how often and from where sessions are refreshed, and flags
+// refreshes from two countries within minutes or at rates no client needs as metrics and
+// security events. Tracking is best effort and never fails the login or refresh; only
+// CheckIdle, which enforces session idle timeouts, does.
+type SessionTracker struct {
+	countryMetadataKey string
+	rules              models.SessionAnomalyRules
+	activityInterval   time.Duration
+	usageRepo          SessionUsageRepository
+	eventRepo          SessionEventRepository
+
+	mu sync.Mutex
+	// activity is when the activity of a session was last recorded by this replica
+	activity map[uuid.UUID]time.Time
+}
+
+// NewSessionTracker creates a new SessionTracker instance
+func NewSessionTracker(cfg *config.Config, usageRepo SessionUsageRepository, eventRepo SessionEventRepository) *SessionTracker {
+	log.Info("Initializing SessionTracker")
+
+	return &SessionTracker{
+		countryMetadataKey: strings.ToLower(cfg.Risk.CountryMetadataKey),
+		rules: models.SessionAnomalyRules{
+			CountryChangeWindow:   cfg.Risk.CountryChangeWindow,
+			RateWindow:            cfg.Risk.RefreshRateWindow,
+			MaxRefreshesPerWindow: cfg.Risk.MaxRefreshesPerWindow,
+		},
+		activityInterval: cfg.JWT.SessionActivityInterval,
+		usageRepo:        usageRepo,
+		eventRepo:        eventRepo,
+		activity:         make(map[uuid.UUID]time.Time),
+	}
+}
+
+// StartSession starts tracking the session of a refresh token issued by a login; a nil
+// token, of a client without refresh tokens, is ignored
+func (t *SessionTracker) StartSession(ctx context.Context, refreshToken *models.RefreshToken) {
+	if refreshToken == nil {
+		return
+	}
+
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":     "StartSession",
+		"user_id":    refreshToken.UserID.String(),
+		"session_id": refreshToken.Session().String(),
+	})
+
+	usage := models.NewSessionUsage(refreshToken.Session(), refreshToken.UserID, refreshToken.ClientID, t.use(ctx))
+	if err := t.usageRepo.Upsert(ctx, usage); err != nil {
+		logger.WithError(err).Error("Failed to start session usage")
+	}
+}
+
+// CheckIdle returns errs.ErrSessionIdleTimeout when the session of a refresh token went unused,
+// its access tokens unverified, for longer than timeout. It must run before RecordRefresh, which
+// marks the session used.
+// Sessions without usage, started before tracking, are not checked until their next refresh.
+func (t *SessionTracker) CheckIdle(ctx context.Context, refreshToken *models.RefreshToken, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+
+	usage, err := t.usageRepo.Get(ctx, refreshToken.Session())
+	if err != nil {
+		if errors.Is(err, errs.ErrSessionUsageNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if usage.IsIdle(time.Now(), timeout) {
+		metrics.SessionIdleTimeouts.Inc()
+		log.FromContext(ctx).WithFields(log.Fields{
+			"method":         "CheckIdle",
+			"user_id":        usage.UserID.String(),
+			"session_id":     usage.SessionID.String(),
+			"last_used_at":   usage.LastUsedAt,
+			"last_active_at": usage.LastActiveAt,
+			"idle_timeout":   timeout.String(),
+		}).Info("Session idle timeout exceeded")
+		return errs.ErrSessionIdleTimeout
+	}
+
+	return nil
+}
+
+// RecordRefresh counts a refresh of the session of a refresh token and reports the anomalies
+// it shows. Sessions started before tracking are tracked from their first refresh on.
+func (t *SessionTracker) RecordRefresh(ctx context.Context, refreshToken *models.RefreshToken) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":     "RecordRefresh",
+		"user_id":    refreshToken.UserID.String(),
+		"session_id": refreshToken.Session().String(),
+	})
+
+	use := t.use(ctx)
+	usage, err := t.usageRepo.Get(ctx, refreshToken.Session())
+	if err != nil {
+		if !errors.Is(err, errs.ErrSessionUsageNotFound) {
+			logger.WithError(err).Error("Failed to retrieve session usage")
+			return
+		}
+		usage = models.NewSessionUsage(refreshToken.Session(), refreshToken.UserID, refreshToken.ClientID, use)
+	}
+
+	interval, anomalies := usage.RecordRefresh(use, t.rules)
+	metrics.SessionRefreshInterval.Observe(interval.Seconds())
+
+	if err := t.usageRepo.Upsert(ctx, usage); err != nil {
+		logger.WithError(err).Error("Failed to store session usage")
+	}
+
+	for _, anomaly := range anomalies {
+		metrics.SessionAnomalies.WithLabelValues(string(anomaly.Kind)).Inc()
+		logger.WithFields(log.Fields{
+			"kind":             anomaly.Kind,
+			"ip_address":       use.IPAddress,
+			"country":          use.Country,
+			"previous_country": anomaly.PreviousCountry,
+			"refreshes":        anomaly.Refreshes,
+		}).Warn("Session anomaly detected")
+
+		if err := t.publishAnomaly(ctx, usage, use, anomaly); err != nil {
+			logger.WithError(err).WithField("kind", anomaly.Kind).Error("Failed to store session anomaly event")
+		}
+	}
+}
+
+// RecordActivity marks the session of a verified access token active, so a user working on
+// short-lived access tokens is not idled out at the next refresh. Each replica records the
+// activity of a session at most once per jwt.session_activity_interval, so the recorded activity
+// lags by the interval at most. Tokens issued outside of a session, or before tokens named
+// theirs, are ignored.
+func (t *SessionTracker) RecordActivity(ctx context.Context, payload *token.Payload) {
+	sessionID, err := uuid.Parse(payload.SessionID)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	if !t.activityDue(sessionID, now) {
+		return
+	}
+
+	if err := t.usageRepo.Touch(ctx, sessionID, now.UnixMilli()); err != nil {
+		log.FromContext(ctx).WithFields(log.Fields{
+			"method":     "RecordActivity",
+			"user_id":    payload.UserID,
+			"session_id": sessionID.String(),
+		}).WithError(err).Error("Failed to record session activity")
+	}
+}
+
+// activityDue reports whether the activity of a session is to be recorded at now, noting it
+// recorded if so. Sessions recorded longer than the interval ago are forgotten when the map
+// is full, and every session when none is.
+func (t *SessionTracker) activityDue(sessionID uuid.UUID, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if recorded, ok := t.activity[sessionID]; ok && now.Sub(recorded) < t.activityInterval {
+		return false
+	}
+
+	if len(t.activity) >= maxTrackedActivity {
+		for id, recorded := range t.activity {
+			if now.Sub(recorded) >= t.activityInterval {
+				delete(t.activity, id)
+			}
+		}
+		if len(t.activity) >= maxTrackedActivity {
+			clear(t.activity)
+		}
+	}
+	t.activity[sessionID] = now
+
+	return true
+}
+
+func (t *SessionTracker) publishAnomaly(ctx context.Context, usage *models.SessionUsage, use models.SessionUse, anomaly models.SessionAnomaly) error {
+	payload, err := json.Marshal(dto.SendSessionAnomalyParams{
+		UserID:          usage.UserID.String(),
+		SessionID:       usage.SessionID.String(),
+		ClientID:        usage.ClientID,
+		Kind:            string(anomaly.Kind),
+		IPAddress:       use.IPAddress,
+		Country:         use.Country,
+		PreviousCountry: anomaly.PreviousCountry,
+		Refreshes:       anomaly.Refreshes,
+		DetectedAt:      use.At.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return t.eventRepo.Create(ctx, &repository.NotificationEventLog{
+		ID:        uuid.New().String(),
+		EventName: string(events.SessionAnomalyDetectedEventType),
+		Payload:   payload,
+		Status:    repository.NotificationEventLogStatusPending,
+	})
+}
+
+// use describes the current call; the country is only trusted as a two letter code
+func (t *SessionTracker) use(ctx context.Context) models.SessionUse {
+	use := models.SessionUse{At: time.Now()}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		use.IPAddress = p.Addr.String()
+		if host, _, err := net.SplitHostPort(use.IPAddress); err == nil {
+			use.IPAddress = host
+		}
+	}
+
+	use.Country = requestCountry(ctx, t.countryMetadataKey)
+
+	return use
+}
+
+// countryCode returns an ISO 3166 alpha-2 code in upper case, or "" for anything else
+func countryCode(value string) string {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return ""
+	}
+	return code
+}
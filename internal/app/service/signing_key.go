@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/pkg/utils/log"
+)
+
+// KeyRotator promotes the secondary JWT signing key on every replica
+type KeyRotator interface {
+	PromoteSecondary(ctx context.Context) (string, string, error)
+}
+
+// SigningKeyService lets admins rotate the JWT signing secret without invalidating sessions
+type SigningKeyService struct {
+	adminKeys []config.AdminAPIKeyConfig
+	rotator   KeyRotator
+}
+
+// NewSigningKeyService creates a new SigningKeyService instance
+func NewSigningKeyService(cfg *config.Config, rotator KeyRotator) *SigningKeyService {
+	log.Info("Initializing SigningKeyService")
+
+	return &SigningKeyService{
+		adminKeys: cfg.Admin.APIKeys,
+		rotator:   rotator,
+	}
+}
+
+// PromoteSigningKey signs new tokens with the secondary key. Tokens signed with the previous
+// primary key stay valid as long as it is configured as a secondary key.
+func (s *SigningKeyService) PromoteSigningKey(ctx context.Context) (*dto.PromoteSigningKeyResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "PromoteSigningKey",
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	primary, previous, err := s.rotator.PromoteSecondary(ctx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to promote signing key")
+		return nil, err
+	}
+
+	logger.WithFields(log.Fields{
+		"primary_key_id":  primary,
+		"previous_key_id": previous,
+	}).Info("Signing key promoted")
+
+	return &dto.PromoteSigningKeyResp{PrimaryKeyID: primary, PreviousKeyID: previous}, nil
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/archive"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+)
+
+// snapshotBatchSize is the number of rows restored per statement
+const snapshotBatchSize = 500
+
+// SnapshotRepository reads and restores whole snapshot tables
+type SnapshotRepository interface {
+	ScanTable(ctx context.Context, table string, fn func(row json.RawMessage) error) error
+	InsertRows(ctx context.Context, table string, rows []json.RawMessage) (int64, error)
+	HasUsers(ctx context.Context) (bool, error)
+}
+
+// SnapshotService exports consistent, encrypted snapshots of the users with their roles and
+// sessions, and restores them into empty databases, so environments can be cloned and
+// recovery rehearsed without raw database access. A snapshot is gzipped JSON lines: a
+// header, the rows of models.SnapshotTables in order and a trailer with the row counts.
+type SnapshotService struct {
+	adminKeys     []config.AdminAPIKeyConfig
+	repo          SnapshotRepository
+	txManager     TxManager
+	schemaVersion int
+}
+
+// NewSnapshotService creates a new SnapshotService instance for a database at schemaVersion
+func NewSnapshotService(cfg *config.Config, repo SnapshotRepository, txManager TxManager, schemaVersion int) *SnapshotService {
+	log.Info("Initializing SnapshotService")
+
+	return &SnapshotService{
+		adminKeys:     cfg.Admin.APIKeys,
+		repo:          repo,
+		txManager:     txManager,
+		schemaVersion: schemaVersion,
+	}
+}
+
+// ExportSnapshot reads the snapshot tables in one repeatable read transaction and hands the
+// archive encrypted with the passphrase to send in chunks. It stops at the first send error.
+func (s *SnapshotService) ExportSnapshot(ctx context.Context, req dto.ExportSnapshotReq, send func(data []byte) error) error {
+	logger := log.FromContext(ctx).WithField("method", "ExportSnapshot")
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid snapshot export request")
+		return err
+	}
+
+	encrypted, err := archive.NewWriter(sendWriter(send), req.Passphrase)
+	if err != nil {
+		logger.WithError(err).Error("Failed to start snapshot archive")
+		return err
+	}
+	compressed := gzip.NewWriter(encrypted)
+	encoder := json.NewEncoder(compressed)
+
+	rows := make(map[string]int64, len(models.SnapshotTables))
+	err = s.txManager.WithTransactionOptions(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		header := &models.SnapshotHeader{
+			FormatVersion: models.SnapshotFormatVersion,
+			SchemaVersion: s.schemaVersion,
+			CreatedAt:     time.Now().UnixMilli(),
+		}
+		if err := encoder.Encode(models.SnapshotRecord{Header: header}); err != nil {
+			return err
+		}
+
+		for _, table := range models.SnapshotTables {
+			rows[table] = 0
+			err := s.repo.ScanTable(txCtx, table, func(row json.RawMessage) error {
+				rows[table]++
+				return encoder.Encode(models.SnapshotRecord{Table: table, Row: row})
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return encoder.Encode(models.SnapshotRecord{Trailer: &models.SnapshotTrailer{Rows: rows}})
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err == nil {
+		err = compressed.Close()
+	}
+	if err == nil {
+		err = encrypted.Close()
+	}
+	if err != nil {
+		logger.WithError(err).Warn("Snapshot export failed")
+		return err
+	}
+
+	logger.WithField("rows", rows).Info("Snapshot exported")
+
+	return nil
+}
+
+// RestoreSnapshot restores a snapshot received in chunks in one transaction. The database
+// must not have users, and be at the schema version the snapshot was taken at.
+func (s *SnapshotService) RestoreSnapshot(
+	ctx context.Context,
+	recv func() (*dto.RestoreSnapshotChunk, error),
+) (*dto.RestoreSnapshotResp, error) {
+	logger := log.FromContext(ctx).WithField("method", "RestoreSnapshot")
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	first, err := recv()
+	if errors.Is(err, io.EOF) {
+		return nil, errs.ErrInvalidSnapshot
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := first.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid snapshot restore request")
+		return nil, err
+	}
+
+	received := &chunkReader{recv: recv, buf: first.Data}
+	var restored map[string]int64
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		hasUsers, err := s.repo.HasUsers(txCtx)
+		if err != nil {
+			return err
+		}
+		if hasUsers {
+			return errs.ErrSnapshotTargetNotEmpty
+		}
+
+		restored, err = s.restore(txCtx, received, first.Passphrase)
+		return err
+	})
+	if err != nil {
+		var streamErr *chunkStreamError
+		switch {
+		case errors.As(err, &streamErr):
+			logger.WithError(streamErr.err).Warn("Snapshot stream interrupted")
+			return nil, streamErr.err
+		case errors.Is(err, errs.ErrInvalidSnapshot):
+			logger.WithError(err).Warn("Invalid snapshot")
+			return nil, errs.ErrInvalidSnapshot
+		default:
+			logger.WithError(err).Warn("Snapshot restore failed")
+			return nil, err
+		}
+	}
+
+	logger.WithField("rows", restored).Info("Snapshot restored")
+
+	return &dto.RestoreSnapshotResp{
+		Organizations: restored["organizations"],
+		Users:         restored["users"],
+		Sessions:      restored["refresh_tokens"],
+	}, nil
+}
+
+// restore decrypts and inserts the snapshot and returns the rows inserted per table.
+// Malformed snapshots fail with an error wrapping errs.ErrInvalidSnapshot.
+func (s *SnapshotService) restore(ctx context.Context, r io.Reader, passphrase string) (map[string]int64, error) {
+	invalid := func(err error) error {
+		var streamErr *chunkStreamError
+		if errors.As(err, &streamErr) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errs.ErrInvalidSnapshot, err)
+	}
+
+	decrypted, err := archive.NewReader(r, passphrase)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	decompressed, err := gzip.NewReader(decrypted)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	decoder := json.NewDecoder(decompressed)
+
+	var record models.SnapshotRecord
+	if err := decoder.Decode(&record); err != nil {
+		return nil, invalid(err)
+	}
+	if record.Header == nil {
+		return nil, invalid(errors.New("missing header"))
+	}
+	if record.Header.FormatVersion != models.SnapshotFormatVersion || record.Header.SchemaVersion != s.schemaVersion {
+		return nil, errs.ErrSnapshotSchemaMismatch
+	}
+
+	read := make(map[string]int64, len(models.SnapshotTables))
+	restored := make(map[string]int64, len(models.SnapshotTables))
+	var table string
+	var batch []json.RawMessage
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		inserted, err := s.repo.InsertRows(ctx, table, batch)
+		restored[table] += inserted
+		batch = batch[:0]
+		return err
+	}
+
+	for {
+		record = models.SnapshotRecord{}
+		if err := decoder.Decode(&record); err != nil {
+			return nil, invalid(err)
+		}
+		if record.Trailer != nil {
+			break
+		}
+
+		// Tables must come in restore order, so rows never reference rows restored later
+		if record.Table != table {
+			if slices.Index(models.SnapshotTables, record.Table) <= slices.Index(models.SnapshotTables, table) {
+				return nil, invalid(fmt.Errorf("unexpected table %q", record.Table))
+			}
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			table = record.Table
+		}
+		if len(record.Row) == 0 {
+			return nil, invalid(errors.New("empty row"))
+		}
+
+		batch = append(batch, record.Row)
+		read[table]++
+		if len(batch) == snapshotBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	for _, name := range models.SnapshotTables {
+		if read[name] != record.Trailer.Rows[name] {
+			return nil, invalid(fmt.Errorf("%s has %d rows, the trailer %d", name, read[name], record.Trailer.Rows[name]))
+		}
+	}
+	// Reading to the end verifies the final chunk of the archive
+	if err := decoder.Decode(&record); !errors.Is(err, io.EOF) {
+		return nil, invalid(fmt.Errorf("data after the trailer: %v", err))
+	}
+
+	return restored, nil
+}
+
+// sendWriter hands every write to a send function
+type sendWriter func(data []byte) error
+
+func (f sendWriter) Write(p []byte) (int, error) {
+	if err := f(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// chunkReader reads the data of received chunks
+type chunkReader struct {
+	recv func() (*dto.RestoreSnapshotChunk, error)
+	buf  []byte
+}
+
+// chunkStreamError is a receive error, as opposed to a malformed snapshot
+type chunkStreamError struct {
+	err error
+}
+
+func (e *chunkStreamError) Error() string {
+	return e.err.Error()
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.recv()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, &chunkStreamError{err: err}
+		}
+		r.buf = chunk.Data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
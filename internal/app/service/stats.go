@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+)
+
+// StatsRepository computes aggregate statistics for the ops dashboard
+type StatsRepository interface {
+	CountUsers(ctx context.Context) (int64, error)
+	CountActiveSessions(ctx context.Context, now int64) (int64, int64, error)
+	CountRegistrationsByDay(ctx context.Context, from, to int64) ([]*models.DailyCount, error)
+	CountLoginsByDay(ctx context.Context, from, to int64) ([]*models.DailyCount, []*models.DailyCount, error)
+}
+
+// StatsService reports user base statistics to admins
+type StatsService struct {
+	adminKeys []config.AdminAPIKeyConfig
+	statsRepo StatsRepository
+	now       func() time.Time
+}
+
+// NewStatsService creates a new StatsService instance
+func NewStatsService(cfg *config.Config, statsRepo StatsRepository) *StatsService {
+	log.Info("Initializing StatsService")
+
+	return &StatsService{
+		adminKeys: cfg.Admin.APIKeys,
+		statsRepo: statsRepo,
+		now:       time.Now,
+	}
+}
+
+// GetUserStats returns user totals and daily registrations and logins for the requested days
+func (s *StatsService) GetUserStats(ctx context.Context, req dto.GetUserStatsReq) (*models.UserStats, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "GetUserStats",
+		"days":   req.Days,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid user stats request")
+		return nil, err
+	}
+
+	days := req.Days
+	if days == 0 {
+		days = dto.DefaultStatsDays
+	}
+
+	now := s.now()
+	from, to := models.StatsWindow(now, days)
+
+	stats := &models.UserStats{
+		GeneratedAt: now.UnixMilli(),
+		From:        from.UnixMilli(),
+		To:          to.UnixMilli(),
+	}
+
+	if stats.TotalUsers, err = s.statsRepo.CountUsers(ctx); err != nil {
+		logger.WithError(err).Error("Failed to count users")
+		return nil, err
+	}
+
+	if stats.ActiveSessions, stats.UsersWithActiveSessions, err = s.statsRepo.CountActiveSessions(ctx, now.UnixMilli()); err != nil {
+		logger.WithError(err).Error("Failed to count active sessions")
+		return nil, err
+	}
+
+	registrations, err := s.statsRepo.CountRegistrationsByDay(ctx, stats.From, stats.To)
+	if err != nil {
+		logger.WithError(err).Error("Failed to count registrations")
+		return nil, err
+	}
+
+	logins, activeUsers, err := s.statsRepo.CountLoginsByDay(ctx, stats.From, stats.To)
+	if err != nil {
+		logger.WithError(err).Error("Failed to count logins")
+		return nil, err
+	}
+
+	stats.Daily = models.DailyUserStatsBuckets(from, to, registrations, logins, activeUsers)
+
+	return stats, nil
+}
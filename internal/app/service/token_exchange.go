@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// TokenExchangeScope is the service key scope required to exchange tokens
+const TokenExchangeScope = "tokens:exchange"
+
+// TokenExchangeService issues RFC 8693 delegation tokens, so an internal service can call
+// another one on behalf of a user with a token that is only valid there and only for the
+// scopes it needs
+type TokenExchangeService struct {
+	serviceKeys []config.ServiceAPIKeyConfig
+	cfg         config.TokenExchangeConfig
+	tokenMaker  token.TokenMaker
+	now         func() time.Time
+}
+
+// NewTokenExchangeService creates a new TokenExchangeService instance
+func NewTokenExchangeService(cfg *config.Config, tokenMaker token.TokenMaker) *TokenExchangeService {
+	log.Info("Initializing TokenExchangeService")
+
+	return &TokenExchangeService{
+		serviceKeys: cfg.Services.APIKeys,
+		cfg:         cfg.TokenExchange,
+		tokenMaker:  tokenMaker,
+		now:         time.Now,
+	}
+}
+
+// ExchangeToken trades the access token of a user for a delegation token of the calling
+// service. The token carries the user, is restricted to the audience and the requested
+// scopes, and names the caller in its act claim. A delegation token issued to the caller
+// can be exchanged in turn, within its scopes, which nests its act chain. Delegation tokens
+// never outlive the token they were exchanged for.
+func (s *TokenExchangeService) ExchangeToken(ctx context.Context, req dto.ExchangeTokenReq) (*dto.ExchangeTokenResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":   "ExchangeToken",
+		"audience": req.Audience,
+	})
+
+	caller, err := authorizeService(ctx, s.serviceKeys, TokenExchangeScope)
+	if err != nil {
+		logger.WithError(err).Warn("Service authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("caller", caller)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid token exchange request")
+		return nil, err
+	}
+
+	audience := s.cfg.FindAudience(req.Audience)
+	if audience == nil || !slices.Contains(audience.Actors, caller) {
+		logger.Warn("Caller may not obtain tokens for the audience")
+		return nil, errs.ErrAudienceNotAllowed
+	}
+
+	subject, err := s.tokenMaker.VerifyAccessToken(req.SubjectToken)
+	if err != nil {
+		logger.WithError(err).Warn("Invalid subject token")
+		return nil, errs.ErrInvalidSubjectToken
+	}
+	logger = logger.WithField("user_id", subject.UserID)
+
+	// Only the service a delegation token was issued to may delegate it further
+	allowed := audience.Scopes
+	if subject.IsDelegation() {
+		if subject.Audience != caller {
+			logger.WithField("subject_audience", subject.Audience).Warn("Subject token was issued to another service")
+			return nil, errs.ErrInvalidSubjectToken
+		}
+		allowed = slices.DeleteFunc(slices.Clone(allowed), func(scope string) bool {
+			return !slices.Contains(subject.Scopes(), scope)
+		})
+	}
+
+	scopes := req.Scopes
+	if len(scopes) == 0 {
+		scopes = allowed
+	}
+	if len(scopes) == 0 || slices.ContainsFunc(scopes, func(scope string) bool { return !slices.Contains(allowed, scope) }) {
+		logger.WithField("scopes", req.Scopes).Warn("Requested scope is not allowed")
+		return nil, errs.ErrInvalidScope
+	}
+
+	actor := &token.Actor{Subject: caller, Actor: subject.Actor}
+	if actor.Depth() > s.cfg.MaxDelegationDepth {
+		logger.WithField("depth", actor.Depth()).Warn("Delegation chain is too long")
+		return nil, errs.ErrDelegationTooDeep
+	}
+
+	ttl := min(s.cfg.TTL, time.Unix(subject.ExpiredAt, 0).Sub(s.now()))
+	expiresIn := int64(ttl / time.Second)
+	if expiresIn <= 0 {
+		return nil, errs.ErrInvalidSubjectToken
+	}
+
+	organizationID, _ := uuid.Parse(subject.OrganizationID)
+	accessToken, err := s.tokenMaker.CreateAccessToken(subject.UserID, subject.Username, expiresIn,
+		token.WithRole(subject.Role),
+		token.WithOrganization(organizationID),
+		token.WithResidency(subject.Residency),
+		token.WithClient(subject.ClientID),
+		token.WithDelegation(req.Audience, scopes, actor),
+	)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create delegation token")
+		return nil, err
+	}
+
+	logger.WithFields(log.Fields{
+		"scopes": scopes,
+		"depth":  actor.Depth(),
+	}).Info("Delegation token issued")
+
+	return &dto.ExchangeTokenResp{
+		AccessToken:     accessToken,
+		IssuedTokenType: dto.AccessTokenType,
+		TokenType:       "Bearer",
+		ExpiresIn:       expiresIn,
+		Scopes:          scopes,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+)
+
+// TokenVerificationScope is the service key scope required to verify access tokens
+const TokenVerificationScope = "tokens:verify"
+
+// SessionActivityRecorder marks the sessions of verified access tokens active
+type SessionActivityRecorder interface {
+	RecordActivity(ctx context.Context, payload *token.Payload)
+}
+
+// TokenVerificationService verifies access tokens for internal services, so they can
+// authenticate users without holding the signing keys
+type TokenVerificationService struct {
+	serviceKeys []config.ServiceAPIKeyConfig
+	tokenMaker  token.TokenMaker
+	sessions    SessionActivityRecorder
+	regions     RegionRouter
+	tenants     TenantRouter
+}
+
+// NewTokenVerificationService creates a new TokenVerificationService instance
+func NewTokenVerificationService(
+	cfg *config.Config,
+	tokenMaker token.TokenMaker,
+	sessions SessionActivityRecorder,
+	regions RegionRouter,
+	tenants TenantRouter,
+) *TokenVerificationService {
+	log.Info("Initializing TokenVerificationService")
+
+	return &TokenVerificationService{
+		serviceKeys: cfg.Services.APIKeys,
+		tokenMaker:  tokenMaker,
+		sessions:    sessions,
+		regions:     regions,
+		tenants:     tenants,
+	}
+}
+
+// VerifyToken verifies an access token as the service's own interceptors do, revocations
+// included, and returns its claims. Delegation tokens are only accepted from the service
+// they were exchanged for, i.e. whose service key ID is their audience. Verifying a token
+// marks its session active, see SessionTracker.RecordActivity.
+func (s *TokenVerificationService) VerifyToken(ctx context.Context, req dto.VerifyTokenReq) (*token.Payload, error) {
+	logger := log.FromContext(ctx).WithField("method", "VerifyToken")
+
+	caller, err := authorizeService(ctx, s.serviceKeys, TokenVerificationScope)
+	if err != nil {
+		logger.WithError(err).Warn("Service authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("caller", caller)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid token verification request")
+		return nil, err
+	}
+
+	payload, err := verifyAccessToken(s.tokenMaker, req.AccessToken)
+	if err != nil {
+		logger.WithError(err).Debug("Access token rejected")
+		return nil, err
+	}
+
+	if payload.IsDelegation() && payload.Audience != caller {
+		logger.WithField("audience", payload.Audience).Warn("Delegation token presented by another service")
+		return nil, errs.ErrInvalidAccessToken
+	}
+
+	// The token, not the caller's metadata, names the user's region and organization
+	if sessionCtx, err := withTokenRoute(ctx, s.regions, s.tenants, payload); err != nil {
+		logger.WithError(err).WithField("user_id", payload.UserID).Warn("Failed to route session activity")
+	} else {
+		s.sessions.RecordActivity(sessionCtx, payload)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/crypt/token"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// sessionUsages keeps the usage of sessions in a map and records the routes of their activity
+type sessionUsages struct {
+	usages  map[uuid.UUID]*models.SessionUsage
+	touches []string
+}
+
+func (r *sessionUsages) Get(_ context.Context, sessionID uuid.UUID) (*models.SessionUsage, error) {
+	usage, ok := r.usages[sessionID]
+	if !ok {
+		return nil, errs.ErrSessionUsageNotFound
+	}
+	copied := *usage
+	return &copied, nil
+}
+
+func (r *sessionUsages) Upsert(_ context.Context, usage *models.SessionUsage) error {
+	copied := *usage
+	r.usages[usage.SessionID] = &copied
+	return nil
+}
+
+func (r *sessionUsages) Touch(ctx context.Context, sessionID uuid.UUID, at int64) error {
+	r.touches = append(r.touches, routeOf(ctx))
+	if usage, ok := r.usages[sessionID]; ok && usage.LastActiveAt < at {
+		usage.LastActiveAt = at
+	}
+	return nil
+}
+
+func TestVerifyToken_KeepsTheSessionActive(t *testing.T) {
+	const timeout = 30 * time.Minute
+	cfg := &config.Config{
+		JWT: config.JWTConfig{SessionActivityInterval: time.Minute},
+		Services: config.ServicesConfig{APIKeys: []config.ServiceAPIKeyConfig{
+			{ID: "booking", KeyHash: token.HashToken("booking-key"), Scopes: []string{TokenVerificationScope}},
+		}},
+	}
+	maker := token.NewJWTTokenMaker("a-secret-key-of-at-least-32-characters")
+
+	// The session was last refreshed 25 minutes ago, within its idle timeout
+	refreshToken := &models.RefreshToken{ID: uuid.New(), UserID: uuid.New()}
+	usage := models.NewSessionUsage(refreshToken.Session(), refreshToken.UserID, "web", models.SessionUse{At: time.Now().Add(-25 * time.Minute)})
+	usages := &sessionUsages{usages: map[uuid.UUID]*models.SessionUsage{usage.SessionID: usage}}
+	tracker := NewSessionTracker(cfg, usages, nil)
+	s := NewTokenVerificationService(cfg, maker, tracker, taggingRegions{}, taggingTenants{})
+
+	organizationID := uuid.New()
+	accessToken, err := maker.CreateAccessToken(refreshToken.UserID.String(), "jane_doe", 60,
+		token.WithResidency("eu"),
+		token.WithOrganization(organizationID),
+		token.WithSession(refreshToken.Session()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create access token: %v", err)
+	}
+
+	// Ten minutes on, the session would idle out on its last refresh alone
+	later := time.Now().Add(10 * time.Minute)
+	if !usage.IsIdle(later, timeout) {
+		t.Fatal("Expected the session to idle out without activity")
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ServiceKeyMetadataKey, "booking-key"))
+	for range 2 {
+		if _, err := s.VerifyToken(ctx, dto.VerifyTokenReq{AccessToken: accessToken}); err != nil {
+			t.Fatalf("Expected the token to be verified, got %v", err)
+		}
+	}
+
+	// Verifications within the activity interval are recorded once, in the user's data
+	if want := "eu/" + organizationID.String(); len(usages.touches) != 1 || usages.touches[0] != want {
+		t.Errorf("Expected one activity recorded in %s, got %v", want, usages.touches)
+	}
+
+	if stored := usages.usages[usage.SessionID]; stored.IsIdle(later, timeout) {
+		t.Errorf("Expected the verified session to be active 10 minutes on, last used at %d and active at %d", stored.LastUsedAt, stored.LastActiveAt)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"sync"
 	"time"
 
 	"user-svc/internal/app/config"
@@ -12,23 +14,32 @@ import (
 	"user-svc/internal/app/domains/events"
 	"user-svc/internal/app/domains/models"
 	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/crypt/dpop"
 	"user-svc/pkg/utils/crypt/token"
 	"user-svc/pkg/utils/log"
+	metautils "user-svc/pkg/utils/metadata"
+	"user-svc/pkg/utils/metrics"
 	"user-svc/pkg/utils/tx"
 
 	"github.com/google/uuid"
-	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	Activate(ctx context.Context, id uuid.UUID, passwordHash models.PasswordHash) error
+	SetPassword(ctx context.Context, id uuid.UUID, passwordHash models.PasswordHash) error
+	UpgradePasswordHash(ctx context.Context, id uuid.UUID, previous, passwordHash models.PasswordHash) (bool, error)
+	RecordLogin(ctx context.Context, id uuid.UUID, loginAt int64) error
 }
 
 type RefreshTokenRepository interface {
 	Create(ctx context.Context, refreshToken *models.RefreshToken) error
 	GetByToken(ctx context.Context, token string) (*models.RefreshToken, error)
+	Revoke(ctx context.Context, id uuid.UUID) (bool, error)
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 }
 
 type TxManager interface {
@@ -39,20 +50,86 @@ type TxManager interface {
 	WithSerializableTransaction(ctx context.Context, fn func(*tx.TxWrapper) error) error
 	WithRepeatableReadTransaction(ctx context.Context, fn func(*tx.TxWrapper) error) error
 	WithReadUncommittedTransaction(ctx context.Context, fn func(*tx.TxWrapper) error) error
+	WithDryRunTransaction(ctx context.Context, dryRun bool, fn func(*tx.TxWrapper) error) error
 }
 
-type NotificationEventLogRepository interface {
-	Create(ctx context.Context, event *repository.NotificationEventLog) error
+// EventPipeline asynchronously persists notification events off the request path
+type EventPipeline interface {
+	Submit(ctx context.Context, event *repository.NotificationEventLog) error
+}
+
+// AuditPipeline asynchronously persists audit log entries off the request path
+type AuditPipeline interface {
+	Submit(ctx context.Context, entry *models.AuditLog) error
+}
+
+// UserEventAppender records changes in the history of users
+type UserEventAppender interface {
+	Append(ctx context.Context, events ...*models.UserEvent) error
+}
+
+// TokenRevoker revokes access tokens on every replica
+type TokenRevoker interface {
+	RevokeUser(ctx context.Context, userID string) error
+	RevokeJTI(ctx context.Context, jti string, expiresAt time.Time) error
+}
+
+// GlobalCutoff reports whether a global logout revoked the refresh tokens issued at createdAt
+type GlobalCutoff interface {
+	IsBeforeGlobalCutoff(createdAt int64) bool
+}
+
+// SessionRecorder tracks the logins and refreshes of refresh token sessions
+type SessionRecorder interface {
+	StartSession(ctx context.Context, refreshToken *models.RefreshToken)
+	CheckIdle(ctx context.Context, refreshToken *models.RefreshToken, timeout time.Duration) error
+	RecordRefresh(ctx context.Context, refreshToken *models.RefreshToken)
+}
+
+// SecurityEventRecorder streams the security events of authentication attempts to the SIEM
+type SecurityEventRecorder interface {
+	Record(ctx context.Context, event *models.SecurityEvent)
 }
 
 // UserService handles business logic for user operations
 type UserService struct {
-	config                   *config.Config
-	userRepo                 UserRepository
-	refreshTokenRepo         RefreshTokenRepository
-	txManager                TxManager
-	tokenMaker               token.TokenMaker
-	notificationEventLogRepo NotificationEventLogRepository
+	config            *config.Config
+	userRepo          UserRepository
+	refreshTokenRepo  RefreshTokenRepository
+	clientRepo        ClientRepository
+	orgRepo           OrganizationReader
+	setupRepo         PasswordSetupTokenRepository
+	userEvents        UserEventAppender
+	txManager         TxManager
+	tokenMaker        token.TokenMaker
+	eventPipeline     EventPipeline
+	auditPipeline     AuditPipeline
+	tokenRevoker      TokenRevoker
+	pushTokens        PushTokenPruner
+	loginSchedules    LoginScheduleChecker
+	velocity          VelocityChecker
+	canaries          CanaryMonitor
+	globalCutoff      GlobalCutoff
+	sessions          SessionRecorder
+	securityEvents    SecurityEventRecorder
+	regions           RegionRouter
+	registrationCodes RegistrationCodeRepository
+	emailClaims       EmailClaimCreator
+	passwordResets    PasswordResetTokenRepository
+	verifications     EmailVerificationTokenRepository
+	registrationGates RegistrationGateChecker
+	inviteCodes       InviteCodeRepository
+	loginAttempts     LoginAttemptPipeline
+	passwords         models.PasswordHasher
+	tokens            models.TokenHasher
+	identities        UserIdentityRepository
+	idTokens          IDTokenVerifier
+	emailDirectory    EmailDirectory
+	tenants           TenantRouter
+	// passwordChecks collapses the password verifications of concurrent identical logins
+	passwordChecks passwordVerifications
+	// background tracks the work requests leave running after they returned, see Wait
+	background sync.WaitGroup
 }
 
 // NewUserService creates a new UserService instance
@@ -60,22 +137,76 @@ func NewUserService(
 	config *config.Config,
 	userRepo UserRepository,
 	refreshTokenRepo RefreshTokenRepository,
+	clientRepo ClientRepository,
+	orgRepo OrganizationReader,
+	setupRepo PasswordSetupTokenRepository,
+	userEvents UserEventAppender,
 	txManager TxManager,
 	tokenMaker token.TokenMaker,
-	notificationEventLogRepo NotificationEventLogRepository,
+	eventPipeline EventPipeline,
+	auditPipeline AuditPipeline,
+	tokenRevoker TokenRevoker,
+	pushTokens PushTokenPruner,
+	loginSchedules LoginScheduleChecker,
+	velocity VelocityChecker,
+	canaries CanaryMonitor,
+	globalCutoff GlobalCutoff,
+	sessions SessionRecorder,
+	securityEvents SecurityEventRecorder,
+	regions RegionRouter,
+	registrationCodes RegistrationCodeRepository,
+	emailClaims EmailClaimCreator,
+	passwordResets PasswordResetTokenRepository,
+	verifications EmailVerificationTokenRepository,
+	registrationGates RegistrationGateChecker,
+	inviteCodes InviteCodeRepository,
+	loginAttempts LoginAttemptPipeline,
+	passwords models.PasswordHasher,
+	tokens models.TokenHasher,
+	identities UserIdentityRepository,
+	idTokens IDTokenVerifier,
+	emailDirectory EmailDirectory,
+	tenants TenantRouter,
 ) *UserService {
 	log.Info("Initializing UserService")
 
 	service := &UserService{
-		config:                   config,
-		userRepo:                 userRepo,
-		refreshTokenRepo:         refreshTokenRepo,
-		txManager:                txManager,
-		tokenMaker:               tokenMaker,
-		notificationEventLogRepo: notificationEventLogRepo,
+		config:            config,
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		clientRepo:        clientRepo,
+		orgRepo:           orgRepo,
+		setupRepo:         setupRepo,
+		userEvents:        userEvents,
+		txManager:         txManager,
+		tokenMaker:        tokenMaker,
+		eventPipeline:     eventPipeline,
+		auditPipeline:     auditPipeline,
+		tokenRevoker:      tokenRevoker,
+		pushTokens:        pushTokens,
+		loginSchedules:    loginSchedules,
+		velocity:          velocity,
+		canaries:          canaries,
+		globalCutoff:      globalCutoff,
+		sessions:          sessions,
+		securityEvents:    securityEvents,
+		regions:           regions,
+		registrationCodes: registrationCodes,
+		emailClaims:       emailClaims,
+		passwordResets:    passwordResets,
+		verifications:     verifications,
+		registrationGates: registrationGates,
+		inviteCodes:       inviteCodes,
+		loginAttempts:     loginAttempts,
+		passwords:         passwords,
+		tokens:            tokens,
+		identities:        identities,
+		idTokens:          idTokens,
+		emailDirectory:    emailDirectory,
+		tenants:           tenants,
 	}
 
-	log.WithFields(logrus.Fields{
+	log.WithFields(log.Fields{
 		"access_token_duration":  config.JWT.AccessTokenDuration.String(),
 		"refresh_token_duration": config.JWT.RefreshTokenDuration.String(),
 	}).Info("UserService initialized successfully")
@@ -83,36 +214,163 @@ func NewUserService(
 	return service
 }
 
-// Register handles user registration
+// Wait waits for the work requests left running after they returned, e.g. the emails of
+// RequestPasswordReset; shutdown calls it before flushing the pipelines the work submits to
+func (s *UserService) Wait() {
+	s.background.Wait()
+}
+
+// Register handles user registration; in the email_otp registration mode accounts are only
+// created by CompleteRegistration
 func (s *UserService) Register(ctx context.Context, req dto.RegisterReq) (*dto.RegisterResp, error) {
-	logger := log.WithFields(logrus.Fields{
-		"method":   "Register",
+	if s.config.Registration.RequiresEmailOTP() {
+		log.FromContext(ctx).WithField("method", "Register").Warn("Registration without a verified email refused")
+		return nil, errs.ErrEmailVerificationRequired
+	}
+
+	return s.register(ctx, "Register", req, "")
+}
+
+// register creates the account of a registration and starts its session
+func (s *UserService) register(ctx context.Context, method string, req dto.RegisterReq, verifiedBy models.EmailVerificationMethod) (resp *dto.RegisterResp, err error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":   method,
 		"email":    req.Email,
 		"username": req.Username,
 	})
 
-	logger.Info("Starting user registration")
+	logger.Debug("Starting user registration")
 
 	if err := req.Validate(); err != nil {
 		logger.WithError(err).Error("Request validation failed")
 		return nil, err
 	}
 
+	// Every attempt past validation is streamed to the SIEM, whatever stops it; the user of a
+	// failed registration was never stored, so the event is not tied to it
+	var user *models.User
+	defer func() {
+		registered := user
+		if err != nil {
+			registered = nil
+		}
+		s.recordSecurityEvent(ctx, s.securityEvent(ctx, models.SecurityActionRegister, registered, req.Email, req.ClientID), err)
+	}()
+
+	if err := s.velocity.CheckVelocity(ctx, s.velocityAttempt(ctx, models.VelocityActionRegister, req.Email)); err != nil {
+		logger.WithError(err).Warn("Registration refused by velocity rules")
+		return nil, err
+	}
+
+	client, err := s.resolveClient(ctx, req.ClientID, models.GrantTypeRegister)
+	if err != nil {
+		logger.WithError(err).Warn("Client is not allowed to register users")
+		return nil, err
+	}
+
+	gates, err := s.registrationGates.Get(ctx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get registration gates")
+		return nil, err
+	}
+	// An invite code that is none of the shared codes of the gates is the invite code of a user
+	referred := req.InviteCode != "" && !gates.MatchesInviteCode(req.InviteCode)
+	if err := gates.Check(models.Email(req.Email), req.InviteCode, referred); err != nil {
+		logger.WithError(err).Warn("Registration refused by registration gates")
+		return nil, err
+	}
+
+	// The sign-up is counted up front, so concurrent registrations cannot overshoot the daily
+	// cap, and given back if the registration fails
+	signupDay := time.Now()
+	reserved, err := s.registrationGates.ReserveSignup(ctx, signupDay, gates.DailyCap)
+	if err != nil {
+		logger.WithError(err).Error("Failed to count sign-up")
+		return nil, err
+	}
+	if !reserved {
+		logger.WithField("daily_cap", gates.DailyCap).Warn("Daily sign-up cap reached")
+		return nil, errs.ErrDailySignupCapReached
+	}
+	gateCtx := ctx
+	defer func() {
+		if err == nil {
+			return
+		}
+		if releaseErr := s.registrationGates.ReleaseSignup(gateCtx, signupDay); releaseErr != nil {
+			logger.WithError(releaseErr).Error("Failed to release sign-up")
+		}
+	}()
+
 	logger.Debug("Creating new user with password")
-	user, err := models.NewUserWithPassword(req.Email, req.Password, req.Username)
+	user, err = models.NewUserWithPassword(req.Email, req.Password, req.Username, s.passwords)
 	if err != nil {
 		logger.WithError(err).Error("Failed to create user with password")
 		return nil, err
 	}
 
-	logger.WithField("user_id", user.ID.String()).Debug("Creating token pair")
-	accessToken, refreshToken, err := s.tokenMaker.CreateTokenPair(
-		user.ID.String(),
-		user.Username.String(),
-		int64(s.config.JWT.AccessTokenDuration),
-	)
+	// Like the sign-up, the redemption is counted up front and given back if the registration
+	// fails, so concurrent registrations cannot overshoot the redemptions of the code
+	var referral *models.Referral
+	if referred {
+		referral, err = s.redeemInviteCode(ctx, req.InviteCode, user)
+		if err != nil {
+			logger.WithError(err).Warn("Invite code cannot be redeemed")
+			return nil, err
+		}
+		refereeID := user.ID
+		defer func() {
+			if err == nil {
+				return
+			}
+			if unredeemErr := s.inviteCodes.Unredeem(gateCtx, refereeID); unredeemErr != nil {
+				logger.WithError(unredeemErr).Error("Failed to give back invite code redemption")
+			}
+		}()
+	}
+
+	org, err := joinOrganization(ctx, s.orgRepo, req.OrganizationID, user)
+	if err != nil {
+		logger.WithError(err).WithField("organization_id", req.OrganizationID).Warn("User cannot join organization")
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID.String())
+
+	// The user's data, and everything the registration writes with it, goes to the database
+	// of the user's region
+	user.Residency = residencyOf(s.config.Residency, org, requestCountry(ctx, s.config.Risk.CountryMetadataKey))
+	if ctx, err = s.regions.WithRegion(ctx, user.Residency); err != nil {
+		logger.WithError(err).WithField("residency", user.Residency).Error("Failed to route registration to its region")
+		return nil, err
+	}
+
+	// Emails are unique per database only, so the email is claimed in the directory of the home
+	// database, where the registrations of every region meet, and given back if the
+	// registration fails
+	claimed, err := s.emailDirectory.Claim(gateCtx, user)
 	if err != nil {
-		logger.WithError(err).Error("Failed to create token pair")
+		logger.WithError(err).Error("Failed to claim email")
+		return nil, err
+	}
+	if !claimed {
+		logger.Warn("Email is registered already")
+		return nil, errs.ErrUserExists
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if releaseErr := s.emailDirectory.Release(gateCtx, user); releaseErr != nil {
+			logger.WithError(releaseErr).Error("Failed to release email")
+		}
+	}()
+
+	logger.Debug("Creating session tokens")
+	jkt, _ := dpop.FromContext(ctx)
+	// Sign-ups always start a long-lived session; the short option only exists for logins
+	accessToken, refreshTokenModel, err := s.createSessionTokens(user, client, jkt, true)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create session tokens")
 		return nil, err
 	}
 
@@ -127,17 +385,31 @@ func (s *UserService) Register(ctx context.Context, req dto.RegisterReq) (*dto.R
 			return err
 		}
 
-		logger.Debug("Creating refresh token model")
-		refreshTokenModel, err := models.NewRefreshToken(
-			user.ID,
-			refreshToken,
-			time.Now().Add(s.config.JWT.RefreshTokenDuration).UnixMilli(),
-		)
+		event, err := models.NewUserCreatedEvent(user, models.UserEventRegistered, models.UserEventActorSelf)
 		if err != nil {
-			logger.WithError(err).Error("Failed to create refresh token model")
+			return err
+		}
+		events := []*models.UserEvent{event}
+
+		if verifiedBy != "" {
+			claimed, err := s.claimEmail(txCtx, user, verifiedBy)
+			if err != nil {
+				logger.WithError(err).Error("Failed to claim verified email")
+				return err
+			}
+			events = append(events, claimed)
+		}
+
+		if err := s.userEvents.Append(txCtx, events...); err != nil {
+			logger.WithError(err).Error("Failed to record user history")
 			return err
 		}
 
+		if refreshTokenModel == nil {
+			logger.Debug("Client does not use refresh tokens")
+			return nil
+		}
+
 		logger.Debug("Storing refresh token in database")
 		if err := s.refreshTokenRepo.Create(txCtx, refreshTokenModel); err != nil {
 			logger.WithError(err).Error("Failed to store refresh token in database")
@@ -152,57 +424,117 @@ func (s *UserService) Register(ctx context.Context, req dto.RegisterReq) (*dto.R
 		return nil, err
 	}
 
-	logger.WithFields(logrus.Fields{
-		"user_id":  user.ID.String(),
-		"email":    user.Email.String(),
-		"username": user.Username.String(),
-	}).Info("User registration completed successfully")
+	logger.Info("User registration completed successfully")
+
+	s.sessions.StartSession(ctx, refreshTokenModel)
+	s.recordAudit(ctx, logger, user.ID, user.OrganizationID, models.AuditActionUserRegistered, deviceMetadata(ctx))
+	if referral != nil {
+		s.publishReferral(ctx, logger, referral)
+	}
 
 	return &dto.RegisterResp{
-		User:         user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		User:                  user,
+		AccessToken:           accessToken,
+		RefreshToken:          refreshTokenValue(refreshTokenModel),
+		RefreshTokenExpiresAt: refreshTokenExpiry(refreshTokenModel),
 	}, nil
 }
 
 // Login handles user login
-func (s *UserService) Login(ctx context.Context, req dto.LoginReq) (*dto.LoginResp, error) {
-	logger := log.WithFields(logrus.Fields{
-		"method": "Login",
-		"email":  req.Email,
+func (s *UserService) Login(ctx context.Context, req dto.LoginReq) (resp *dto.LoginResp, err error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":      "Login",
+		"email":       req.Email,
+		"remember_me": req.RememberMe,
 	})
 
-	logger.Info("Starting user login")
+	logger.Debug("Starting user login")
 
 	if err := req.Validate(); err != nil {
 		logger.WithError(err).Error("Request validation failed")
 		return nil, err
 	}
 
+	// Every attempt past validation is streamed to the SIEM and kept in the login history,
+	// whatever stops it
+	var user *models.User
+	defer func() {
+		s.recordSecurityEvent(ctx, s.securityEvent(ctx, models.SecurityActionLogin, user, req.Email, req.ClientID), err)
+		s.recordLoginAttempt(ctx, user, req.ClientID, err)
+	}()
+
+	// Checked before the password, so locked attempts cost no password verification
+	if err := s.velocity.CheckVelocity(ctx, s.velocityAttempt(ctx, models.VelocityActionLogin, req.Email)); err != nil {
+		logger.WithError(err).Warn("Login refused by velocity rules")
+		return nil, err
+	}
+
+	client, err := s.resolveClient(ctx, req.ClientID, models.GrantTypePassword)
+	if err != nil {
+		logger.WithError(err).Warn("Client is not allowed to log users in")
+		return nil, err
+	}
+
+	// The user's data is in the database of the user's region, whatever the client sent
+	if ctx, err = withEmailRegion(ctx, s.regions, s.emailDirectory, req.Email); err != nil {
+		logger.WithError(err).Error("Failed to route login to the region of the email")
+		return nil, err
+	}
+
 	logger.Debug("Retrieving user by email")
-	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	user, err = s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		logger.WithError(err).Error("Failed to retrieve user by email")
 		return nil, err
 	}
+	userID := user.ID.String()
+	logger = logger.WithField("user_id", userID)
+
+	// Canary accounts alert on every attempt, but the login then goes on as for any account
+	// so the attacker does not learn the credentials are planted
+	if canary, ok := s.canaries.Lookup(user.ID); ok {
+		access := s.canaryAccess(ctx, models.CanaryAccessLogin, req.ClientID)
+		access.PasswordValid = s.passwordChecks.verify(s.passwords, user, req.Password)
+		s.canaries.Alert(ctx, canary, access)
+		s.recordCanaryAccess(ctx, canary, access)
+		s.canaries.Tarpit(ctx, canary)
+	}
 
-	logger.WithField("user_id", user.ID.String()).Debug("Verifying password")
-	if !user.PasswordHash.VerifyPassword(req.Password) {
-		logger.WithFields(logrus.Fields{
-			"user_id": user.ID.String(),
-			"email":   user.Email.String(),
-		}).Warn("Invalid password provided")
+	if err := user.CheckCredentials(); err != nil {
+		logger.WithField("status", user.Status).Warn("User has not set a password yet")
+		return nil, err
+	}
+
+	logger.Debug("Verifying password")
+	if !s.passwordChecks.verify(s.passwords, user, req.Password) {
+		logger.Warn("Invalid password provided")
 		return nil, errs.ErrInvalidCredentials
 	}
 
-	logger.WithField("user_id", user.ID.String()).Debug("Creating token pair")
-	accessToken, refreshToken, err := s.tokenMaker.CreateTokenPair(
-		user.ID.String(),
-		user.Username.String(),
-		int64(s.config.JWT.AccessTokenDuration),
-	)
+	// Checked after the password, so the ban is only revealed to the account owner
+	if user.Status == models.UserStatusBanned {
+		logger.Warn("Banned user tried to log in")
+		return nil, errs.ErrUserBanned
+	}
+
+	if err := s.loginSchedules.CheckLoginSchedule(ctx, user, time.Now()); err != nil {
+		logger.WithError(err).Warn("Login outside the login schedule")
+		return nil, err
+	}
+
+	if err := s.checkEmailReverification(ctx, logger, user); err != nil {
+		return nil, err
+	}
+
+	if s.passwords.NeedsRehash(user.PasswordHash) {
+		s.upgradePasswordHash(ctx, logger, user, req.Password)
+	}
+
+	logger.Debug("Creating session tokens")
+	jkt, _ := dpop.FromContext(ctx)
+	accessToken, refreshTokenModel, err := s.createSessionTokens(user, client, jkt, req.RememberMe)
 	if err != nil {
-		logger.WithError(err).Error("Failed to create token pair")
+		logger.WithError(err).Error("Failed to create session tokens")
 		return nil, err
 	}
 
@@ -211,17 +543,16 @@ func (s *UserService) Login(ctx context.Context, req dto.LoginReq) (*dto.LoginRe
 		// Create a new context with the transaction
 		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
 
-		logger.Debug("Creating refresh token model")
-		refreshTokenModel, err := models.NewRefreshToken(
-			user.ID,
-			refreshToken,
-			time.Now().Add(s.config.JWT.RefreshTokenDuration).UnixMilli(),
-		)
-		if err != nil {
-			logger.WithError(err).Error("Failed to create refresh token model")
+		if err := s.userRepo.RecordLogin(txCtx, user.ID, time.Now().UnixMilli()); err != nil {
+			logger.WithError(err).Error("Failed to record login")
 			return err
 		}
 
+		if refreshTokenModel == nil {
+			logger.Debug("Client does not use refresh tokens")
+			return nil
+		}
+
 		logger.Debug("Storing refresh token in database")
 		if err := s.refreshTokenRepo.Create(txCtx, refreshTokenModel); err != nil {
 			logger.WithError(err).Error("Failed to store refresh token in database")
@@ -236,42 +567,94 @@ func (s *UserService) Login(ctx context.Context, req dto.LoginReq) (*dto.LoginRe
 		return nil, err
 	}
 
-	logger.WithFields(logrus.Fields{
-		"user_id":  user.ID.String(),
-		"email":    user.Email.String(),
-		"username": user.Username.String(),
-	}).Info("User login completed successfully")
+	logger.Info("User login completed successfully")
+
+	s.sessions.StartSession(ctx, refreshTokenModel)
+
+	device := deviceMetadata(ctx)
+	if err := s.submitLoginNotification(ctx, logger, user, device); err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, logger, user.ID, user.OrganizationID, models.AuditActionUserLoggedIn, device)
+
+	return &dto.LoginResp{
+		User:                  user,
+		AccessToken:           accessToken,
+		RefreshToken:          refreshTokenValue(refreshTokenModel),
+		RefreshTokenExpiresAt: refreshTokenExpiry(refreshTokenModel),
+	}, nil
+}
+
+// submitLoginNotification submits the event notifying the user of a login from the device
+func (s *UserService) submitLoginNotification(ctx context.Context, logger *log.Logger, user *models.User, device map[string]interface{}) error {
+	userAgent, _ := device[models.AuditMetadataUserAgent].(string)
+	ipAddress, _ := device[models.AuditMetadataIPAddress].(string)
 
 	payload, err := json.Marshal(dto.SendLoginNotificationParams{
-		UserID:   user.ID.String(),
-		Email:    user.Email.String(),
-		Username: user.Username.String(),
-		LoginAt:  time.Now(),
+		UserID:    user.ID.String(),
+		Email:     user.Email.String(),
+		Username:  user.Username.String(),
+		LoginAt:   time.Now(),
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+		Locale:    requestLocale(ctx),
+		Residency: user.Residency,
 	})
 	if err != nil {
 		logger.WithError(err).Error("Failed to marshal notification payload")
-		return nil, err
+		return err
 	}
 
-	if err := s.notificationEventLogRepo.Create(ctx, &repository.NotificationEventLog{
+	// Publishing must never fail or stall the login itself, so errors are only logged
+	if err := s.eventPipeline.Submit(ctx, &repository.NotificationEventLog{
 		ID:        uuid.New().String(),
 		EventName: string(events.LoginEventType),
 		Payload:   payload,
 		Status:    repository.NotificationEventLogStatusPending,
 	}); err != nil {
-		logger.WithError(err).Error("Failed to create notification event log")
-		return nil, err
+		logger.WithError(err).Warn("Failed to submit notification event")
 	}
 
-	return &dto.LoginResp{
-		User:         user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	}, nil
+	return nil
+}
+
+// upgradePasswordHash replaces an outdated password hash, e.g. the legacy hash of a migrated
+// user or a hash of a lower bcrypt cost, with a new hash of the password the user just
+// logged in with. A failed upgrade does not fail the login, the hash is upgraded on a later one.
+func (s *UserService) upgradePasswordHash(ctx context.Context, logger *log.Logger, user *models.User, plainPassword string) {
+	legacyFormat := s.passwords.Scheme(user.PasswordHash)
+
+	passwordHash, err := s.passwords.Hash(plainPassword)
+	if err != nil {
+		metrics.LegacyPasswordUpgrades.WithLabelValues("failed").Inc()
+		logger.WithError(err).Error("Failed to hash password for hash upgrade")
+		return
+	}
+
+	upgraded, err := s.userRepo.UpgradePasswordHash(ctx, user.ID, user.PasswordHash, passwordHash)
+	switch {
+	case err != nil:
+		metrics.LegacyPasswordUpgrades.WithLabelValues("failed").Inc()
+		logger.WithError(err).Error("Failed to upgrade password hash")
+		return
+	case !upgraded:
+		// The password was changed since it was verified, its new hash is kept
+		metrics.LegacyPasswordUpgrades.WithLabelValues("superseded").Inc()
+		logger.Info("Password hash was replaced concurrently, not upgraded")
+		return
+	}
+
+	metrics.LegacyPasswordUpgrades.WithLabelValues("upgraded").Inc()
+	logger.WithField("legacy_hash_format", legacyFormat).Info("Password hash upgraded")
+
+	s.recordAudit(ctx, logger, user.ID, user.OrganizationID, models.AuditActionPasswordUpgraded, map[string]interface{}{
+		"legacy_hash_format": legacyFormat,
+	})
 }
 
-func (s *UserService) RefreshToken(ctx context.Context, req dto.RefreshTokenReq) (*dto.RefreshTokenResp, error) {
-	logger := log.WithFields(logrus.Fields{
+func (s *UserService) RefreshToken(ctx context.Context, req dto.RefreshTokenReq) (resp *dto.RefreshTokenResp, err error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
 		"method":       "RefreshToken",
 		"token_length": len(req.RefreshToken),
 	})
@@ -283,10 +666,33 @@ func (s *UserService) RefreshToken(ctx context.Context, req dto.RefreshTokenReq)
 		return nil, err
 	}
 
+	// Every attempt past validation is streamed to the SIEM, whatever stops it
+	var (
+		refreshToken *models.RefreshToken
+		user         *models.User
+	)
+	defer func() {
+		event := s.securityEvent(ctx, models.SecurityActionTokenRefresh, user, "", "")
+		if refreshToken != nil {
+			event.UserID = refreshToken.UserID.String()
+			event.ClientID = refreshToken.ClientID
+		}
+		s.recordSecurityEvent(ctx, event, err)
+	}()
+
+	// Refresh tokens name the region of their user like access tokens; those issued before
+	// they did are routed by the x-residency metadata
+	if payload, verifyErr := s.tokenMaker.VerifyRefreshToken(req.RefreshToken); verifyErr == nil && payload.Residency != "" {
+		if ctx, err = s.regions.WithRegion(ctx, payload.Residency); err != nil {
+			logger.WithError(err).WithField("residency", payload.Residency).Error("Failed to route refresh to its region")
+			return nil, err
+		}
+	}
+
 	logger.Debug("Retrieving refresh token from database")
-	refreshToken, err := s.refreshTokenRepo.GetByToken(ctx, req.RefreshToken)
+	refreshToken, err = s.refreshTokenRepo.GetByToken(ctx, req.RefreshToken)
 	if err != nil {
-		if err == errs.ErrTokenNotFound {
+		if errors.Is(err, errs.ErrTokenNotFound) {
 			logger.Warn("Refresh token not found in database")
 			return nil, errs.ErrTokenNotFound
 		}
@@ -295,7 +701,14 @@ func (s *UserService) RefreshToken(ctx context.Context, req dto.RefreshTokenReq)
 		return nil, err
 	}
 
-	logger.WithFields(logrus.Fields{
+	if canary, ok := s.canaries.Lookup(refreshToken.UserID); ok {
+		access := s.canaryAccess(ctx, models.CanaryAccessRefreshToken, refreshToken.ClientID)
+		s.canaries.Alert(ctx, canary, access)
+		s.recordCanaryAccess(ctx, canary, access)
+		s.canaries.Tarpit(ctx, canary)
+	}
+
+	logger.WithFields(log.Fields{
 		"token_id":   refreshToken.ID.String(),
 		"user_id":    refreshToken.UserID.String(),
 		"expires_at": refreshToken.ExpiresAt,
@@ -303,15 +716,24 @@ func (s *UserService) RefreshToken(ctx context.Context, req dto.RefreshTokenReq)
 	}).Debug("Retrieved refresh token")
 
 	if refreshToken.IsRevoked {
-		logger.WithFields(logrus.Fields{
+		logger.WithFields(log.Fields{
 			"token_id": refreshToken.ID.String(),
 			"user_id":  refreshToken.UserID.String(),
 		}).Warn("Refresh token is revoked")
 		return nil, errs.ErrTokenRevoked
 	}
 
+	// A global logout rejects the token before its row is revoked
+	if s.globalCutoff.IsBeforeGlobalCutoff(refreshToken.CreatedAt) {
+		logger.WithFields(log.Fields{
+			"token_id": refreshToken.ID.String(),
+			"user_id":  refreshToken.UserID.String(),
+		}).Warn("Refresh token was issued before the global logout cutoff")
+		return nil, errs.ErrTokenRevoked
+	}
+
 	if refreshToken.ExpiresAt < time.Now().UnixMilli() {
-		logger.WithFields(logrus.Fields{
+		logger.WithFields(log.Fields{
 			"token_id":     refreshToken.ID.String(),
 			"user_id":      refreshToken.UserID.String(),
 			"expires_at":   refreshToken.ExpiresAt,
@@ -320,32 +742,254 @@ func (s *UserService) RefreshToken(ctx context.Context, req dto.RefreshTokenReq)
 		return nil, errs.ErrTokenExpired
 	}
 
+	jkt, _ := dpop.FromContext(ctx)
+	if err := refreshToken.CheckBinding(jkt); err != nil {
+		logger.WithFields(log.Fields{
+			"token_id": refreshToken.ID.String(),
+			"user_id":  refreshToken.UserID.String(),
+		}).Warn("Refresh token presented without its DPoP key")
+		return nil, err
+	}
+
+	// Policies are looked up on every refresh so changes apply to existing sessions
+	client := legacyClient
+	if refreshToken.ClientID != "" {
+		client, err = s.resolveClient(ctx, refreshToken.ClientID, models.GrantTypeRefreshToken)
+		if err != nil {
+			logger.WithError(err).WithField("client_id", refreshToken.ClientID).Warn("Client may no longer refresh tokens")
+			return nil, err
+		}
+	}
+
 	logger.WithField("user_id", refreshToken.UserID.String()).Debug("Retrieving user by ID")
-	user, err := s.userRepo.GetByID(ctx, refreshToken.UserID)
+	user, err = s.userRepo.GetByID(ctx, refreshToken.UserID)
 	if err != nil {
 		logger.WithError(err).WithField("user_id", refreshToken.UserID.String()).Error("Failed to retrieve user by ID")
 		return nil, err
 	}
 
+	if err := user.CheckCanAuthenticate(); err != nil {
+		logger.WithError(err).WithFields(log.Fields{
+			"user_id": user.ID.String(),
+			"status":  user.Status,
+		}).Warn("User may not refresh a token")
+		return nil, err
+	}
+
+	// Sessions started in a window end with it, once their access token expires
+	if err := s.loginSchedules.CheckLoginSchedule(ctx, user, time.Now()); err != nil {
+		logger.WithError(err).WithField("user_id", user.ID.String()).Warn("Token refresh outside the login schedule")
+		return nil, err
+	}
+
+	// Idle sessions end even though their refresh token has not expired
+	if err := s.checkSessionIdle(ctx, refreshToken, client, user); err != nil {
+		logger.WithError(err).WithField("user_id", user.ID.String()).Warn("Session may not be refreshed")
+		return nil, err
+	}
+
 	logger.WithField("user_id", user.ID.String()).Debug("Creating new access token")
-	accessToken, err := s.tokenMaker.CreateAccessToken(
-		user.ID.String(),
-		user.Username.String(),
-		int64(s.config.JWT.AccessTokenDuration),
-	)
+	accessToken, err := s.createAccessToken(user, client, refreshToken.DPoPJKT, refreshToken.Session())
 	if err != nil {
 		logger.WithError(err).Error("Failed to create access token")
 		return nil, err
 	}
 
-	logger.WithFields(logrus.Fields{
+	var rotated *models.RefreshToken
+	if client.RotateRefreshTokens {
+		logger.WithField("client_id", client.ID).Debug("Rotating refresh token")
+		rotated, err = s.createRefreshToken(user, client, refreshToken.DPoPJKT, refreshToken.RememberMe)
+		if err != nil {
+			logger.WithError(err).Error("Failed to create rotated refresh token")
+			return nil, err
+		}
+		rotated.SessionID = refreshToken.Session()
+
+		err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+			txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+			revoked, err := s.refreshTokenRepo.Revoke(txCtx, refreshToken.ID)
+			if err != nil {
+				return err
+			}
+			if !revoked {
+				// A concurrent refresh already rotated this token
+				return errs.ErrTokenRevoked
+			}
+
+			return s.refreshTokenRepo.Create(txCtx, rotated)
+		})
+		if err != nil {
+			logger.WithError(err).Error("Failed to rotate refresh token")
+			return nil, err
+		}
+	}
+
+	logger.WithFields(log.Fields{
 		"user_id":  user.ID.String(),
 		"email":    user.Email.String(),
 		"username": user.Username.String(),
 		"token_id": refreshToken.ID.String(),
 	}).Info("Token refresh completed successfully")
 
+	s.sessions.RecordRefresh(ctx, refreshToken)
+
 	return &dto.RefreshTokenResp{
-		AccessToken: accessToken,
+		AccessToken:           accessToken,
+		RefreshToken:          refreshTokenValue(rotated),
+		RefreshTokenExpiresAt: refreshTokenExpiry(rotated),
+	}, nil
+}
+
+// checkSessionIdle ends the session of a refresh token that went unused for longer than the
+// strictest idle timeout of the service, the client and the user's organization, revoking the
+// token so it cannot be retried
+func (s *UserService) checkSessionIdle(ctx context.Context, refreshToken *models.RefreshToken, client *models.Client, user *models.User) error {
+	var orgTimeout time.Duration
+	if user.OrganizationID != uuid.Nil {
+		org, err := s.orgRepo.GetByID(ctx, user.OrganizationID)
+		if err != nil {
+			return err
+		}
+		orgTimeout = org.SessionIdleTimeout
+	}
+
+	timeout := models.SessionIdleTimeout(s.config.JWT.SessionIdleTimeout, client.SessionIdleTimeout, orgTimeout)
+	if err := s.sessions.CheckIdle(ctx, refreshToken, timeout); err != nil {
+		if !errors.Is(err, errs.ErrSessionIdleTimeout) {
+			return err
+		}
+		if _, revokeErr := s.refreshTokenRepo.Revoke(ctx, refreshToken.ID); revokeErr != nil {
+			log.FromContext(ctx).WithError(revokeErr).WithField("token_id", refreshToken.ID.String()).Error("Failed to revoke idle refresh token")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// RevokeAllUserTokens revokes every refresh token of the calling user and all access
+// tokens issued to them so far, on every replica, and removes the push tokens of their devices
+func (s *UserService) RevokeAllUserTokens(ctx context.Context, req dto.RevokeAllUserTokensReq) (*dto.RevokeAllUserTokensResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "RevokeAllUserTokens",
+		"user_id": req.UserID,
+	})
+
+	logger.Info("Starting token revocation")
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Error("Request validation failed")
+		return nil, err
+	}
+
+	caller, err := authenticate(ctx, s.tokenMaker)
+	if err != nil {
+		logger.WithError(err).Warn("Caller authentication failed")
+		return nil, err
+	}
+	if caller.UserID != req.UserID {
+		logger.WithField("caller_id", caller.UserID).Warn("Caller may not revoke tokens of another user")
+		return nil, errs.ErrPermissionDenied
+	}
+
+	userID := uuid.MustParse(req.UserID)
+
+	logger.Debug("Revoking refresh tokens")
+	revoked, err := s.refreshTokenRepo.RevokeAllByUserID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to revoke refresh tokens")
+		return nil, err
+	}
+
+	logger.Debug("Removing push tokens")
+	pruned, err := s.pushTokens.PruneUser(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to remove push tokens")
+		return nil, err
+	}
+
+	logger.Debug("Revoking access tokens")
+	if err := s.tokenRevoker.RevokeUser(ctx, req.UserID); err != nil {
+		logger.WithError(err).Error("Failed to revoke access tokens")
+		return nil, err
+	}
+
+	logger.WithFields(log.Fields{
+		"revoked_refresh_tokens": revoked,
+		"removed_push_tokens":    pruned,
+	}).Info("Token revocation completed successfully")
+
+	// Tokens without an organization claim leave it to the user's organization when stored
+	organizationID, _ := uuid.Parse(caller.OrganizationID)
+	s.recordAudit(ctx, logger, userID, organizationID, models.AuditActionTokensRevoked, map[string]interface{}{
+		"revoked_refresh_tokens": revoked,
+	})
+	event := s.securityEvent(ctx, models.SecurityActionTokensRevoked, nil, "", caller.ClientID)
+	event.UserID = req.UserID
+	event.OrganizationID = caller.OrganizationID
+	s.recordSecurityEvent(ctx, event, nil)
+
+	return &dto.RevokeAllUserTokensResp{
+		RevokedRefreshTokens: revoked,
 	}, nil
 }
+
+// recordAudit submits an audit entry to the async audit pipeline, scoped to the user's
+// organization, uuid.Nil for none. Failures are logged and never propagated to the caller.
+func (s *UserService) recordAudit(
+	ctx context.Context,
+	logger *log.Logger,
+	userID uuid.UUID,
+	organizationID uuid.UUID,
+	action models.AuditAction,
+	metadata map[string]interface{},
+) {
+	entry, err := models.NewAuditLog(userID, action, metadata)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to build audit log entry")
+		return
+	}
+	// The pipeline writes on the shared schema, where the users of isolated organizations
+	// are not found, so the organization is set here
+	entry.OrganizationID = organizationID
+
+	if err := s.auditPipeline.Submit(ctx, entry); err != nil {
+		logger.WithError(err).WithField("action", string(action)).Warn("Failed to submit audit log entry")
+	}
+}
+
+// velocityAttempt describes a register or login attempt of the caller for the velocity rules:
+// the peer IP and the device ID and solved captcha the client sent in the abuse metadata keys
+func (s *UserService) velocityAttempt(ctx context.Context, action models.VelocityAction, email string) models.VelocityAttempt {
+	attempt := models.VelocityAttempt{Action: action, Email: email}
+	if ip, ok := deviceMetadata(ctx)[models.AuditMetadataIPAddress].(string); ok {
+		attempt.IP = ip
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if devices := md.Get(s.config.Abuse.DeviceMetadataKey); len(devices) > 0 {
+			// Invalid fingerprints are left out rather than counted under garbage values
+			attempt.Device, _ = metautils.ParseDeviceFingerprint(devices[0])
+		}
+		if captchas := md.Get(s.config.Abuse.CaptchaMetadataKey); len(captchas) > 0 {
+			attempt.CaptchaToken = captchas[0]
+		}
+	}
+	return attempt
+}
+
+// canaryAccess describes an access of the caller to a canary account for the alert
+func (s *UserService) canaryAccess(ctx context.Context, kind models.CanaryAccessKind, clientID string) models.CanaryAccess {
+	device := deviceMetadata(ctx)
+	ipAddress, _ := device[models.AuditMetadataIPAddress].(string)
+	userAgent, _ := device[models.AuditMetadataUserAgent].(string)
+
+	return models.CanaryAccess{
+		Kind:      kind,
+		ClientID:  clientID,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		At:        time.Now().UnixMilli(),
+	}
+}
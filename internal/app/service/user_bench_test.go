@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/hashing"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/repository"
+	"user-svc/pkg/utils/crypt/token"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const benchPassword = "Str0ng!Passw0rd"
+
+type benchUsers struct {
+	user *models.User
+}
+
+func (r *benchUsers) Create(context.Context, *models.User) error { return nil }
+func (r *benchUsers) GetByEmail(context.Context, string) (*models.User, error) {
+	user := *r.user
+	return &user, nil
+}
+func (r *benchUsers) GetByID(context.Context, uuid.UUID) (*models.User, error) {
+	user := *r.user
+	return &user, nil
+}
+func (r *benchUsers) Activate(context.Context, uuid.UUID, models.PasswordHash) error    { return nil }
+func (r *benchUsers) SetPassword(context.Context, uuid.UUID, models.PasswordHash) error { return nil }
+func (r *benchUsers) UpgradePasswordHash(context.Context, uuid.UUID, models.PasswordHash, models.PasswordHash) (bool, error) {
+	return true, nil
+}
+func (r *benchUsers) RecordLogin(context.Context, uuid.UUID, int64) error { return nil }
+
+type benchRefreshTokens struct{}
+
+func (benchRefreshTokens) Create(context.Context, *models.RefreshToken) error { return nil }
+func (benchRefreshTokens) GetByToken(context.Context, string) (*models.RefreshToken, error) {
+	return nil, nil
+}
+func (benchRefreshTokens) Revoke(context.Context, uuid.UUID) (bool, error)             { return false, nil }
+func (benchRefreshTokens) RevokeAllByUserID(context.Context, uuid.UUID) (int64, error) { return 0, nil }
+
+type benchClients struct {
+	client *models.Client
+}
+
+func (r benchClients) GetByID(context.Context, string) (*models.Client, error) {
+	return r.client, nil
+}
+
+// benchTx runs transactions without a database
+type benchTx struct {
+	TxManager
+}
+
+func (benchTx) WithTransaction(_ context.Context, fn func(*tx.TxWrapper) error) error {
+	return fn(tx.NewTxWrapper(nil))
+}
+
+type benchSink struct{}
+
+func (benchSink) Submit(context.Context, *repository.NotificationEventLog) error { return nil }
+func (benchSink) Append(context.Context, ...*models.UserEvent) error             { return nil }
+
+type benchAudit struct{}
+
+func (benchAudit) Submit(context.Context, *models.AuditLog) error { return nil }
+
+type benchLoginAttempts struct{}
+
+func (benchLoginAttempts) Submit(context.Context, *models.LoginAttempt) error { return nil }
+
+// benchSchedules allows every login
+type benchSchedules struct{}
+
+func (benchSchedules) CheckLoginSchedule(context.Context, *models.User, time.Time) error { return nil }
+
+// benchVelocity is an abuse engine without velocity rules
+type benchVelocity struct{}
+
+func (benchVelocity) CheckVelocity(context.Context, models.VelocityAttempt) error { return nil }
+
+// benchCanaries is a service without canary accounts
+type benchCanaries struct{}
+
+func (benchCanaries) Lookup(uuid.UUID) (*models.CanaryAccount, bool)                    { return nil, false }
+func (benchCanaries) Alert(context.Context, *models.CanaryAccount, models.CanaryAccess) {}
+func (benchCanaries) Tarpit(context.Context, *models.CanaryAccount)                     {}
+
+// benchCutoff is a service without global logouts
+type benchCutoff struct{}
+
+func (benchCutoff) IsBeforeGlobalCutoff(int64) bool { return false }
+
+// benchSessions is a service without session tracking
+type benchSessions struct{}
+
+func (benchSessions) StartSession(context.Context, *models.RefreshToken) {}
+func (benchSessions) CheckIdle(context.Context, *models.RefreshToken, time.Duration) error {
+	return nil
+}
+func (benchSessions) RecordRefresh(context.Context, *models.RefreshToken) {}
+
+// benchSecurityEvents is a service without a SIEM
+type benchSecurityEvents struct{}
+
+func (benchSecurityEvents) Record(context.Context, *models.SecurityEvent) {}
+
+// benchRegions is a service without regional databases
+type benchRegions struct{}
+
+func (benchRegions) WithRegion(ctx context.Context, _ string) (context.Context, error) {
+	return ctx, nil
+}
+func (benchRegions) HasDatabase(string) bool { return false }
+
+// benchEmails is an email directory with every email free
+type benchEmails struct{}
+
+func (benchEmails) Claim(context.Context, *models.User) (bool, error) { return true, nil }
+func (benchEmails) Release(context.Context, *models.User) error       { return nil }
+func (benchEmails) Region(context.Context, string) (string, error) {
+	return "", errs.ErrUserNotFound
+}
+
+// benchGates leaves registration open
+type benchGates struct{}
+
+func (benchGates) Get(context.Context) (*models.RegistrationGates, error) {
+	return &models.RegistrationGates{}, nil
+}
+func (benchGates) ReserveSignup(context.Context, time.Time, int) (bool, error) { return true, nil }
+func (benchGates) ReleaseSignup(context.Context, time.Time) error              { return nil }
+
+// newBenchUserService returns a service over in-memory dependencies with a cheap bcrypt cost,
+// so the benchmarks measure the service's own work rather than the database or bcrypt
+func newBenchUserService(b *testing.B) (*UserService, *models.User) {
+	b.Helper()
+
+	logger := log.GetLogger()
+	logger.SetOutput(io.Discard)
+	b.Cleanup(func() { logger.SetOutput(io.Discard) })
+
+	passwords := hashing.NewPasswordHasher(bcrypt.MinCost)
+	hash, err := passwords.Hash(benchPassword)
+	if err != nil {
+		b.Fatal(err)
+	}
+	user, err := models.NewUser("jane@tickets.example", hash.String(), "jane_doe")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	cfg := &config.Config{}
+	cfg.JWT.AccessTokenDuration = 15 * time.Minute
+	cfg.JWT.RefreshTokenDuration = 720 * time.Hour
+	cfg.JWT.ShortRefreshTokenDuration = 24 * time.Hour
+
+	client := &models.Client{
+		ID:                "web",
+		AllowedGrantTypes: []models.GrantType{models.GrantTypePassword, models.GrantTypeRegister, models.GrantTypeRefreshToken},
+	}
+
+	s := NewUserService(
+		cfg,
+		&benchUsers{user: user},
+		benchRefreshTokens{},
+		benchClients{client: client},
+		nil,
+		nil,
+		benchSink{},
+		benchTx{},
+		token.NewJWTTokenMaker("0123456789abcdef0123456789abcdef"),
+		benchSink{},
+		benchAudit{},
+		nil,
+		nil,
+		benchSchedules{},
+		benchVelocity{},
+		benchCanaries{},
+		benchCutoff{},
+		benchSessions{},
+		benchSecurityEvents{},
+		benchRegions{},
+		nil,
+		nil,
+		nil,
+		nil,
+		benchGates{},
+		nil,
+		benchLoginAttempts{},
+		passwords,
+		hashing.NewTokenHasher(),
+		nil,
+		nil,
+		benchEmails{},
+		nil,
+	)
+
+	return s, user
+}
+
+func BenchmarkLogin(b *testing.B) {
+	s, _ := newBenchUserService(b)
+	ctx := context.Background()
+	req := dto.LoginReq{Email: "jane@tickets.example", Password: benchPassword, ClientID: "web"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Login(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRegister(b *testing.B) {
+	s, _ := newBenchUserService(b)
+	ctx := context.Background()
+	req := dto.RegisterReq{Email: "jane@tickets.example", Username: "jane_doe", Password: benchPassword, ClientID: "web"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Register(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
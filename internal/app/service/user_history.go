@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// rebuildBatchSize is the number of user streams RebuildUserProjections loads per page
+const rebuildBatchSize = 500
+
+// maxStreamPage is the number of events read per query while replaying a stream
+const maxStreamPage = 1000
+
+// UserEventReader reads the history of users
+type UserEventReader interface {
+	ListByUser(ctx context.Context, userID uuid.UUID, afterVersion int64, limit int) ([]*models.UserEvent, error)
+	ListUserIDs(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
+
+// UserProjectionRepository compares and overwrites the stored state of users with projections
+type UserProjectionRepository interface {
+	ProjectionDiffers(ctx context.Context, p *models.UserProjection) (bool, error)
+	SaveProjection(ctx context.Context, p *models.UserProjection) (bool, error)
+}
+
+// RebuildReport summarizes a projection rebuild
+type RebuildReport struct {
+	Users int
+	// Drifted users are the ones whose stored state differed from their projection
+	Drifted int
+	// Failed users have a stream that could not be replayed
+	Failed int
+}
+
+// UserHistoryService gives support the timeline of an account and rebuilds the users table
+// from the event streams
+type UserHistoryService struct {
+	adminKeys  []config.AdminAPIKeyConfig
+	userRepo   UserProjectionRepository
+	userEvents UserEventReader
+}
+
+// NewUserHistoryService creates a new UserHistoryService instance
+func NewUserHistoryService(
+	cfg *config.Config,
+	userRepo UserProjectionRepository,
+	userEvents UserEventReader,
+) *UserHistoryService {
+	log.Info("Initializing UserHistoryService")
+
+	return &UserHistoryService{
+		adminKeys:  cfg.Admin.APIKeys,
+		userRepo:   userRepo,
+		userEvents: userEvents,
+	}
+}
+
+// GetUserHistory returns a page of the user's events, oldest first. Deleted users keep their
+// history, so a user that no longer exists still has one.
+func (s *UserHistoryService) GetUserHistory(ctx context.Context, req dto.GetUserHistoryReq) (*dto.GetUserHistoryResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "GetUserHistory",
+		"user_id": req.UserID,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid user history request")
+		return nil, err
+	}
+
+	limit := req.PageLimit()
+	events, err := s.userEvents.ListByUser(ctx, uuid.MustParse(req.UserID), req.AfterVersion, limit)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list user events")
+		return nil, err
+	}
+
+	resp := &dto.GetUserHistoryResp{Events: events}
+	if len(events) == limit {
+		resp.NextAfterVersion = events[len(events)-1].Version
+	}
+
+	return resp, nil
+}
+
+// RebuildUserProjections replays the stream of every user with events and overwrites the
+// stored state of the users that drifted from it. With dryRun the drift is only reported.
+// Streams that cannot be replayed are logged and skipped.
+func (s *UserHistoryService) RebuildUserProjections(ctx context.Context, dryRun bool) (*RebuildReport, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "RebuildUserProjections",
+		"dry_run": dryRun,
+	})
+
+	report := &RebuildReport{}
+	afterID := uuid.Nil
+	for {
+		ids, err := s.userEvents.ListUserIDs(ctx, afterID, rebuildBatchSize)
+		if err != nil {
+			return report, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+		afterID = ids[len(ids)-1]
+
+		for _, id := range ids {
+			report.Users++
+
+			drifted, err := s.rebuildUser(ctx, id, dryRun)
+			if err != nil {
+				if ctx.Err() != nil {
+					return report, ctx.Err()
+				}
+				report.Failed++
+				logger.WithError(err).WithField("user_id", id.String()).Error("Failed to rebuild user")
+				continue
+			}
+			if drifted {
+				report.Drifted++
+				logger.WithField("user_id", id.String()).Warn("User drifted from its event stream")
+			}
+		}
+	}
+
+	logger.WithFields(log.Fields{
+		"users":   report.Users,
+		"drifted": report.Drifted,
+		"failed":  report.Failed,
+	}).Info("User projections rebuilt")
+
+	return report, nil
+}
+
+// rebuildUser replays one stream and reports whether the stored user differed from it.
+// A user deleted since is not recreated.
+func (s *UserHistoryService) rebuildUser(ctx context.Context, id uuid.UUID, dryRun bool) (bool, error) {
+	projection := &models.UserProjection{}
+	for {
+		events, err := s.userEvents.ListByUser(ctx, id, projection.Version, maxStreamPage)
+		if err != nil {
+			return false, err
+		}
+		for _, event := range events {
+			if err := projection.Apply(event); err != nil {
+				return false, err
+			}
+		}
+		if len(events) < maxStreamPage {
+			break
+		}
+	}
+
+	if dryRun {
+		return s.userRepo.ProjectionDiffers(ctx, projection)
+	}
+	return s.userRepo.SaveProjection(ctx, projection)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// ReadUsersScope is the service key scope required to look up users
+const ReadUsersScope = "users:read"
+
+// UserLookupRepository looks up users for internal services
+type UserLookupRepository interface {
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.User, error)
+}
+
+// UserLookupService resolves users for internal services that only know part of an account,
+// such as its login name, or need many users at once
+type UserLookupService struct {
+	serviceKeys []config.ServiceAPIKeyConfig
+	userRepo    UserLookupRepository
+}
+
+// NewUserLookupService creates a new UserLookupService instance
+func NewUserLookupService(cfg *config.Config, userRepo UserLookupRepository) *UserLookupService {
+	log.Info("Initializing UserLookupService")
+
+	return &UserLookupService{
+		serviceKeys: cfg.Services.APIKeys,
+		userRepo:    userRepo,
+	}
+}
+
+// GetUserByUsername returns the user with a username. Usernames are not unique; a username
+// of more than one user is not resolved.
+func (s *UserLookupService) GetUserByUsername(ctx context.Context, req dto.GetUserByUsernameReq) (*models.User, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":   "GetUserByUsername",
+		"username": req.Username,
+	})
+
+	caller, err := authorizeService(ctx, s.serviceKeys, ReadUsersScope)
+	if err != nil {
+		logger.WithError(err).Warn("Service authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("caller", caller)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid username")
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByUsername(ctx, req.Username)
+	if err != nil {
+		logger.WithError(err).Info("Failed to get user by username")
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// BatchGetUsers returns the users with the given IDs in one query, keyed by ID. Users that do
+// not exist are left out rather than failing the batch.
+func (s *UserLookupService) BatchGetUsers(ctx context.Context, req dto.BatchGetUsersReq) (map[uuid.UUID]*models.User, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":   "BatchGetUsers",
+		"user_ids": len(req.UserIDs),
+	})
+
+	caller, err := authorizeService(ctx, s.serviceKeys, ReadUsersScope)
+	if err != nil {
+		logger.WithError(err).Warn("Service authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("caller", caller)
+
+	ids, err := req.Validate()
+	if err != nil {
+		logger.WithError(err).Warn("Invalid user IDs")
+		return nil, err
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get users by IDs")
+		return nil, err
+	}
+
+	logger.WithField("found", len(users)).Debug("Users looked up")
+	return users, nil
+}
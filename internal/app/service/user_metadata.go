@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// MetadataScopePrefix prefixes the service key scope that grants writing a metadata
+// namespace, e.g. metadata:crm for crm.segment
+const MetadataScopePrefix = "metadata:"
+
+// UserMetadataRepository reads and replaces the metadata of users
+type UserMetadataRepository interface {
+	GetMetadataForUpdate(ctx context.Context, id uuid.UUID) (models.UserMetadata, error)
+	SetMetadata(ctx context.Context, id uuid.UUID, metadata models.UserMetadata) error
+}
+
+// UserMetadataService lets internal services attach namespaced metadata to users instead of
+// asking for new columns. Every service only writes the namespaces its key is scoped to.
+type UserMetadataService struct {
+	serviceKeys []config.ServiceAPIKeyConfig
+	limits      config.UserMetadataConfig
+	userRepo    UserMetadataRepository
+	auditRepo   LegalHoldAuditRepository
+	userEvents  UserEventAppender
+	txManager   TxManager
+}
+
+// NewUserMetadataService creates a new UserMetadataService instance
+func NewUserMetadataService(
+	cfg *config.Config,
+	userRepo UserMetadataRepository,
+	auditRepo LegalHoldAuditRepository,
+	userEvents UserEventAppender,
+	txManager TxManager,
+) *UserMetadataService {
+	log.Info("Initializing UserMetadataService")
+
+	return &UserMetadataService{
+		serviceKeys: cfg.Services.APIKeys,
+		limits:      cfg.UserMetadata,
+		userRepo:    userRepo,
+		auditRepo:   auditRepo,
+		userEvents:  userEvents,
+		txManager:   txManager,
+	}
+}
+
+// SetUserMetadata sets and removes metadata keys of a user in one transaction, with a
+// user.metadata_updated event and audit entry, and returns the user's metadata in the
+// namespaces of the request
+func (s *UserMetadataService) SetUserMetadata(ctx context.Context, req dto.SetUserMetadataReq) (models.UserMetadata, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "SetUserMetadata",
+		"user_id": req.UserID,
+	})
+
+	// Validated first, as the keys name the namespaces the caller must be scoped to
+	if err := req.Validate(s.limits.MaxValueBytes); err != nil {
+		logger.WithError(err).Warn("Invalid user metadata request")
+		return nil, err
+	}
+
+	namespaces := req.Namespaces()
+	caller, err := s.authorizeNamespaces(ctx, namespaces)
+	if err != nil {
+		logger.WithError(err).Warn("Service authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("caller", caller)
+
+	userID := uuid.MustParse(req.UserID)
+
+	var metadata models.UserMetadata
+	var changed bool
+	err = s.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		current, err := s.userRepo.GetMetadataForUpdate(txCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		metadata, changed = current.Apply(req.Set, req.Remove)
+		if !changed {
+			return nil
+		}
+		if len(metadata) > s.limits.MaxKeys || metadata.Size() > s.limits.MaxTotalBytes {
+			return errs.ErrMetadataLimitExceeded
+		}
+
+		if err := s.userRepo.SetMetadata(txCtx, userID, metadata); err != nil {
+			return err
+		}
+
+		event, err := models.NewUserEvent(userID, models.UserEventMetadataUpdated, serviceActor(caller), models.MetadataUpdatedData{
+			Set:     req.Set,
+			Removed: req.Remove,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.userEvents.Append(txCtx, event); err != nil {
+			return err
+		}
+
+		keysSet := make([]string, 0, len(req.Set))
+		for key := range req.Set {
+			keysSet = append(keysSet, key)
+		}
+		sort.Strings(keysSet)
+
+		entry, err := models.NewAuditLog(userID, models.AuditActionMetadataUpdated, map[string]interface{}{
+			"service":      caller,
+			"keys_set":     keysSet,
+			"keys_removed": req.Remove,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.auditRepo.CreateBatch(txCtx, []*models.AuditLog{entry})
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Failed to update user metadata")
+		return nil, err
+	}
+
+	logger.WithField("changed", changed).Info("User metadata updated")
+
+	return metadata.Namespaces(namespaces), nil
+}
+
+// authorizeNamespaces checks the caller's service key is scoped to every namespace
+func (s *UserMetadataService) authorizeNamespaces(ctx context.Context, namespaces map[string]struct{}) (string, error) {
+	sorted := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		sorted = append(sorted, namespace)
+	}
+	sort.Strings(sorted)
+
+	var caller string
+	for _, namespace := range sorted {
+		var err error
+		if caller, err = authorizeService(ctx, s.serviceKeys, MetadataScopePrefix+namespace); err != nil {
+			return "", err
+		}
+	}
+
+	return caller, nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+)
+
+// NotedUserRepository looks up the users notes are left on
+type NotedUserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// UserNoteRepository stores the notes support staff leave on users
+type UserNoteRepository interface {
+	Create(ctx context.Context, note *models.UserNote) error
+	ListByUser(
+		ctx context.Context,
+		userID uuid.UUID,
+		visibility models.UserNoteVisibility,
+		cursor models.UserNoteCursor,
+		limit int,
+	) ([]*models.UserNote, error)
+}
+
+// UserNoteService lets support agents leave internal notes on accounts, e.g. "refund promised
+// on 5/3", and read them back
+type UserNoteService struct {
+	adminKeys []config.AdminAPIKeyConfig
+	userRepo  NotedUserRepository
+	noteRepo  UserNoteRepository
+}
+
+// NewUserNoteService creates a new UserNoteService instance
+func NewUserNoteService(cfg *config.Config, userRepo NotedUserRepository, noteRepo UserNoteRepository) *UserNoteService {
+	log.Info("Initializing UserNoteService")
+
+	return &UserNoteService{
+		adminKeys: cfg.Admin.APIKeys,
+		userRepo:  userRepo,
+		noteRepo:  noteRepo,
+	}
+}
+
+// AddUserNote leaves a note on the user. Notes cannot be changed afterwards, so a correction
+// is another note.
+func (s *UserNoteService) AddUserNote(ctx context.Context, req dto.AddUserNoteReq) (*models.UserNote, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "AddUserNote",
+		"user_id": req.UserID,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return nil, err
+	}
+	userID := uuid.MustParse(req.UserID)
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if !errors.Is(err, errs.ErrUserNotFound) {
+			logger.WithError(err).Error("Failed to retrieve user by ID")
+		}
+		return nil, err
+	}
+
+	note := models.NewUserNote(userID, req.Body, req.NoteVisibility(), req.Author, adminActor(admin), time.Now().UnixMilli())
+	if err := s.noteRepo.Create(ctx, note); err != nil {
+		logger.WithError(err).Error("Failed to store user note")
+		return nil, err
+	}
+
+	logger.WithFields(log.Fields{
+		"note_id":    note.ID.String(),
+		"visibility": string(note.Visibility),
+	}).Info("User note added")
+
+	return note, nil
+}
+
+// ListUserNotes returns a page of the notes of the user, newest first
+func (s *UserNoteService) ListUserNotes(ctx context.Context, req dto.ListUserNotesReq) (*dto.ListUserNotesResp, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method":  "ListUserNotes",
+		"user_id": req.UserID,
+	})
+
+	if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Request validation failed")
+		return nil, err
+	}
+
+	cursor, _ := req.Cursor()
+	limit := req.Limit()
+
+	// One extra note tells whether there is a next page
+	notes, err := s.noteRepo.ListByUser(ctx, uuid.MustParse(req.UserID), models.UserNoteVisibility(req.Visibility), cursor, limit+1)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list user notes")
+		return nil, err
+	}
+
+	resp := &dto.ListUserNotesResp{Notes: notes}
+	if len(notes) > limit {
+		resp.Notes = notes[:limit]
+		resp.NextPageToken = dto.UserNotePageToken(resp.Notes[limit-1])
+	}
+
+	return resp, nil
+}
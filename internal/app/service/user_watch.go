@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/userwatch"
+	"user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/google/uuid"
+)
+
+// WatchUsersScope is the service key scope required to watch users
+const WatchUsersScope = "users:watch"
+
+// WatchedUserRepository loads the current state of watched users
+type WatchedUserRepository interface {
+	GetWatched(ctx context.Context, ids []uuid.UUID) ([]*models.WatchedUser, error)
+}
+
+// UserWatchService pushes the state of users to internal services whenever it changes, so
+// their caches stay fresh without polling
+type UserWatchService struct {
+	serviceKeys []config.ServiceAPIKeyConfig
+	maxUsers    int
+	userRepo    WatchedUserRepository
+	hub         *userwatch.Hub
+}
+
+// NewUserWatchService creates a new UserWatchService instance. Without a hub watching is
+// disabled.
+func NewUserWatchService(cfg *config.Config, userRepo WatchedUserRepository, hub *userwatch.Hub) *UserWatchService {
+	log.Info("Initializing UserWatchService")
+
+	return &UserWatchService{
+		serviceKeys: cfg.Services.APIKeys,
+		maxUsers:    cfg.Watch.MaxUsersPerStream,
+		userRepo:    userRepo,
+		hub:         hub,
+	}
+}
+
+// WatchUser sends the current state of every user, in request order and marked initial, and
+// then the state of a user each time its event version advanced, until ctx is done. Users
+// that do not exist are sent without a user. A failed reload ends the stream; the caller
+// resubscribes and gets the initial states again.
+func (s *UserWatchService) WatchUser(
+	ctx context.Context,
+	req dto.WatchUserReq,
+	send func(update *dto.WatchUserUpdate) error,
+) error {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "WatchUser",
+		"users":  len(req.UserIDs),
+	})
+
+	caller, err := authorizeService(ctx, s.serviceKeys, WatchUsersScope)
+	if err != nil {
+		logger.WithError(err).Warn("Service authorization failed")
+		return err
+	}
+	logger = logger.WithField("caller", caller)
+
+	if s.hub == nil {
+		return errs.ErrUserWatchDisabled
+	}
+
+	ids, err := req.Validate(s.maxUsers)
+	if err != nil {
+		logger.WithError(err).Warn("Invalid watch request")
+		return err
+	}
+
+	// Subscribing first, a change committed while the initial states load is sent again
+	sub := s.hub.Subscribe(ids)
+	defer s.hub.Unsubscribe(sub)
+
+	states, err := s.userRepo.GetWatched(ctx, ids)
+	if err != nil {
+		logger.WithError(err).Error("Failed to load watched users")
+		return err
+	}
+
+	versions := make(map[uuid.UUID]int64, len(states))
+	for _, state := range states {
+		if err := send(&dto.WatchUserUpdate{WatchedUser: state, Initial: true}); err != nil {
+			return err
+		}
+		versions[state.UserID] = state.Version
+	}
+	metrics.UserWatchUpdates.WithLabelValues("initial").Add(float64(len(states)))
+	logger.Info("Watching users")
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Stopped watching users")
+			return ctx.Err()
+		case <-sub.Ready():
+		}
+
+		states, err := s.userRepo.GetWatched(ctx, sub.Take())
+		if err != nil {
+			logger.WithError(err).Error("Failed to reload watched users")
+			return err
+		}
+
+		for _, state := range states {
+			// Resyncs and coalesced notifications reload users that did not change
+			if state.Version <= versions[state.UserID] {
+				continue
+			}
+			if err := send(&dto.WatchUserUpdate{WatchedUser: state}); err != nil {
+				return err
+			}
+			versions[state.UserID] = state.Version
+			metrics.UserWatchUpdates.WithLabelValues("update").Inc()
+		}
+	}
+}
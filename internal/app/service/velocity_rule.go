@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/dto"
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/log"
+)
+
+// VelocityRuleRepository stores the velocity rules of the abuse engine
+type VelocityRuleRepository interface {
+	List(ctx context.Context) ([]*models.VelocityRule, error)
+	Upsert(ctx context.Context, rule *models.VelocityRule) error
+	Delete(ctx context.Context, name string) (bool, error)
+}
+
+// VelocityRulePublisher applies changed velocity rules on every replica
+type VelocityRulePublisher interface {
+	PublishRules(ctx context.Context) error
+}
+
+// VelocityChecker evaluates register and login attempts against the velocity rules
+type VelocityChecker interface {
+	CheckVelocity(ctx context.Context, attempt models.VelocityAttempt) error
+}
+
+// VelocityRuleService lets the fraud team tune the velocity rules of the abuse engine during
+// an attack without a deploy
+type VelocityRuleService struct {
+	adminKeys []config.AdminAPIKeyConfig
+	ruleRepo  VelocityRuleRepository
+	publisher VelocityRulePublisher
+}
+
+// NewVelocityRuleService creates a new VelocityRuleService instance
+func NewVelocityRuleService(cfg *config.Config, ruleRepo VelocityRuleRepository, publisher VelocityRulePublisher) *VelocityRuleService {
+	log.Info("Initializing VelocityRuleService")
+
+	return &VelocityRuleService{
+		adminKeys: cfg.Admin.APIKeys,
+		ruleRepo:  ruleRepo,
+		publisher: publisher,
+	}
+}
+
+// SetVelocityRule creates a velocity rule or replaces the rule of the same name. Every replica
+// evaluates the rule within seconds.
+func (s *VelocityRuleService) SetVelocityRule(ctx context.Context, req dto.SetVelocityRuleReq) (*models.VelocityRule, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "SetVelocityRule",
+		"name":   req.Name,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	rule, err := req.Rule(time.Now())
+	if err != nil {
+		logger.WithError(err).Warn("Invalid velocity rule")
+		return nil, err
+	}
+	rule.UpdatedBy = adminActor(admin)
+
+	if err := s.ruleRepo.Upsert(ctx, rule); err != nil {
+		logger.WithError(err).Error("Failed to store velocity rule")
+		return nil, err
+	}
+	s.publish(ctx, logger)
+
+	logger.WithFields(log.Fields{
+		"entity":    rule.Entity,
+		"action":    rule.Action,
+		"threshold": rule.Threshold,
+		"window":    rule.Window.String(),
+		"response":  rule.Response,
+		"enabled":   rule.Enabled,
+	}).Info("Velocity rule set")
+
+	return rule, nil
+}
+
+// ListVelocityRules returns every velocity rule, enabled or not, by name
+func (s *VelocityRuleService) ListVelocityRules(ctx context.Context) ([]*models.VelocityRule, error) {
+	logger := log.FromContext(ctx).WithField("method", "ListVelocityRules")
+
+	if _, err := authorizeAdmin(ctx, s.adminKeys); err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return nil, err
+	}
+
+	rules, err := s.ruleRepo.List(ctx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list velocity rules")
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// DeleteVelocityRule deletes a velocity rule and reports whether there was one
+func (s *VelocityRuleService) DeleteVelocityRule(ctx context.Context, req dto.DeleteVelocityRuleReq) (bool, error) {
+	logger := log.FromContext(ctx).WithFields(log.Fields{
+		"method": "DeleteVelocityRule",
+		"name":   req.Name,
+	})
+
+	admin, err := authorizeAdmin(ctx, s.adminKeys)
+	if err != nil {
+		logger.WithError(err).Warn("Admin authorization failed")
+		return false, err
+	}
+	logger = logger.WithField("admin", admin)
+
+	if err := req.Validate(); err != nil {
+		logger.WithError(err).Warn("Invalid velocity rule name")
+		return false, err
+	}
+
+	deleted, err := s.ruleRepo.Delete(ctx, req.Name)
+	if err != nil {
+		logger.WithError(err).Error("Failed to delete velocity rule")
+		return false, err
+	}
+	if deleted {
+		s.publish(ctx, logger)
+	}
+
+	logger.WithField("deleted", deleted).Info("Velocity rule deleted")
+
+	return deleted, nil
+}
+
+// publish applies the stored rules on every replica. A failure is only logged since the
+// change is stored and replicas pick it up on resync.
+func (s *VelocityRuleService) publish(ctx context.Context, logger *log.Logger) {
+	if err := s.publisher.PublishRules(ctx); err != nil {
+		logger.WithError(err).Warn("Failed to apply velocity rules, replicas will pick them up on resync")
+	}
+}
@@ -0,0 +1,266 @@
+package siem
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+)
+
+// Formatter serializes a security event as one JSON document
+type Formatter func(event *models.SecurityEvent) ([]byte, error)
+
+// Formats are the supported formats by name
+var Formats = map[string]Formatter{
+	"ecs":  FormatECS,
+	"ocsf": FormatOCSF,
+}
+
+// productName names the service in the events
+const productName = "user-svc"
+
+// Versions of the schemas the events follow
+const (
+	ecsVersion  = "8.11.0"
+	ocsfVersion = "1.1.0"
+)
+
+// OCSF classes of the events
+const (
+	ocsfCategoryIAM           = 3
+	ocsfClassAccountChange    = 3001
+	ocsfClassAuthentication   = 3002
+	ocsfActivityOther         = 99
+	ocsfStatusUnknown         = 0
+	ocsfStatusSuccess         = 1
+	ocsfStatusFailure         = 2
+	ocsfAuthLogon             = 1
+	ocsfAuthLogoff            = 2
+	ocsfAuthTicket            = 3
+	ocsfAccountCreate         = 1
+	ocsfAccountPasswordChange = 3
+)
+
+// classification maps an action onto the categories of both schemas
+type classification struct {
+	ecsCategory  string
+	ecsTypes     []string
+	ocsfClass    int
+	ocsfActivity int
+	activityName string
+}
+
+var classifications = map[models.SecurityAction]classification{
+	models.SecurityActionLogin:         {"authentication", []string{"start"}, ocsfClassAuthentication, ocsfAuthLogon, "Logon"},
+	models.SecurityActionIDTokenLogin:  {"authentication", []string{"start"}, ocsfClassAuthentication, ocsfAuthLogon, "Logon"},
+	models.SecurityActionTokenRefresh:  {"authentication", []string{"start"}, ocsfClassAuthentication, ocsfAuthTicket, "Authentication Ticket"},
+	models.SecurityActionTokensRevoked: {"authentication", []string{"end"}, ocsfClassAuthentication, ocsfAuthLogoff, "Logoff"},
+	models.SecurityActionLogout:        {"authentication", []string{"end"}, ocsfClassAuthentication, ocsfAuthLogoff, "Logoff"},
+	models.SecurityActionCanaryAccess:  {"authentication", []string{"indicator"}, ocsfClassAuthentication, ocsfActivityOther, "Other"},
+	models.SecurityActionRegister:      {"iam", []string{"user", "creation"}, ocsfClassAccountChange, ocsfAccountCreate, "Create"},
+	models.SecurityActionPasswordSetUp: {"iam", []string{"user", "change"}, ocsfClassAccountChange, ocsfAccountPasswordChange, "Password Change"},
+	models.SecurityActionPasswordReset: {"iam", []string{"user", "change"}, ocsfClassAccountChange, ocsfAccountPasswordChange, "Password Change"},
+}
+
+func classify(action models.SecurityAction) classification {
+	if c, ok := classifications[action]; ok {
+		return c
+	}
+	return classification{"authentication", []string{"info"}, ocsfClassAuthentication, ocsfActivityOther, "Other"}
+}
+
+// message summarizes an event for the SIEM's event list
+func message(event *models.SecurityEvent) string {
+	if event.Reason != "" {
+		return fmt.Sprintf("%s %s: %s", event.Action, event.Outcome, event.Reason)
+	}
+	return fmt.Sprintf("%s %s", event.Action, event.Outcome)
+}
+
+// ecsEvent is an event in the Elastic Common Schema
+type ecsEvent struct {
+	Timestamp    string            `json:"@timestamp"`
+	Message      string            `json:"message"`
+	ECS          ecsVersionField   `json:"ecs"`
+	Event        ecsEventField     `json:"event"`
+	User         *ecsUser          `json:"user,omitempty"`
+	Source       *ecsSource        `json:"source,omitempty"`
+	UserAgent    *ecsUserAgent     `json:"user_agent,omitempty"`
+	Organization *ecsOrganization  `json:"organization,omitempty"`
+	Service      ecsService        `json:"service"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+type ecsVersionField struct {
+	Version string `json:"version"`
+}
+
+type ecsEventField struct {
+	Kind     string   `json:"kind"`
+	Category []string `json:"category"`
+	Type     []string `json:"type"`
+	Action   string   `json:"action"`
+	Outcome  string   `json:"outcome"`
+	Reason   string   `json:"reason,omitempty"`
+	Severity int      `json:"severity"`
+	Dataset  string   `json:"dataset"`
+	Provider string   `json:"provider"`
+}
+
+type ecsUser struct {
+	ID    string `json:"id,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type ecsSource struct {
+	IP string `json:"ip"`
+}
+
+type ecsUserAgent struct {
+	Original string `json:"original"`
+}
+
+type ecsOrganization struct {
+	ID string `json:"id"`
+}
+
+type ecsService struct {
+	Name string `json:"name"`
+}
+
+// FormatECS formats an event in the Elastic Common Schema, categorized as authentication or
+// iam event
+func FormatECS(event *models.SecurityEvent) ([]byte, error) {
+	c := classify(event.Action)
+	doc := ecsEvent{
+		Timestamp: time.UnixMilli(event.At).UTC().Format(time.RFC3339Nano),
+		Message:   message(event),
+		ECS:       ecsVersionField{Version: ecsVersion},
+		Event: ecsEventField{
+			Kind:     "event",
+			Category: []string{c.ecsCategory},
+			Type:     c.ecsTypes,
+			Action:   string(event.Action),
+			Outcome:  string(event.Outcome),
+			Reason:   event.Reason,
+			Severity: int(event.Severity),
+			Dataset:  "user_svc.security",
+			Provider: productName,
+		},
+		Service: ecsService{Name: productName},
+	}
+	if event.Action == models.SecurityActionCanaryAccess {
+		doc.Event.Kind = "alert"
+	}
+	if event.UserID != "" || event.Email != "" {
+		doc.User = &ecsUser{ID: event.UserID, Email: event.Email}
+	}
+	if event.IPAddress != "" {
+		doc.Source = &ecsSource{IP: event.IPAddress}
+	}
+	if event.UserAgent != "" {
+		doc.UserAgent = &ecsUserAgent{Original: event.UserAgent}
+	}
+	if event.OrganizationID != "" {
+		doc.Organization = &ecsOrganization{ID: event.OrganizationID}
+	}
+	if event.ClientID != "" {
+		doc.Labels = map[string]string{"client_id": event.ClientID}
+	}
+	return json.Marshal(doc)
+}
+
+// ocsfEvent is an event of the Authentication or Account Change class of the Open
+// Cybersecurity Schema Framework
+type ocsfEvent struct {
+	Time         int64             `json:"time"`
+	Message      string            `json:"message"`
+	CategoryUID  int               `json:"category_uid"`
+	ClassUID     int               `json:"class_uid"`
+	ActivityID   int               `json:"activity_id"`
+	ActivityName string            `json:"activity_name"`
+	TypeUID      int               `json:"type_uid"`
+	SeverityID   int               `json:"severity_id"`
+	Severity     string            `json:"severity"`
+	StatusID     int               `json:"status_id"`
+	Status       string            `json:"status"`
+	StatusDetail string            `json:"status_detail,omitempty"`
+	Metadata     ocsfMetadata      `json:"metadata"`
+	User         ocsfUser          `json:"user"`
+	SrcEndpoint  *ocsfEndpoint     `json:"src_endpoint,omitempty"`
+	HTTPRequest  *ocsfHTTPRequest  `json:"http_request,omitempty"`
+	Unmapped     map[string]string `json:"unmapped,omitempty"`
+}
+
+type ocsfMetadata struct {
+	Version string      `json:"version"`
+	Product ocsfProduct `json:"product"`
+}
+
+type ocsfProduct struct {
+	Name       string `json:"name"`
+	VendorName string `json:"vendor_name"`
+}
+
+type ocsfUser struct {
+	UID       string   `json:"uid,omitempty"`
+	EmailAddr string   `json:"email_addr,omitempty"`
+	Org       *ocsfOrg `json:"org,omitempty"`
+}
+
+type ocsfOrg struct {
+	UID string `json:"uid"`
+}
+
+type ocsfEndpoint struct {
+	IP string `json:"ip"`
+}
+
+type ocsfHTTPRequest struct {
+	UserAgent string `json:"user_agent"`
+}
+
+// FormatOCSF formats an event in the Open Cybersecurity Schema Framework
+func FormatOCSF(event *models.SecurityEvent) ([]byte, error) {
+	c := classify(event.Action)
+	doc := ocsfEvent{
+		Time:         event.At,
+		Message:      message(event),
+		CategoryUID:  ocsfCategoryIAM,
+		ClassUID:     c.ocsfClass,
+		ActivityID:   c.ocsfActivity,
+		ActivityName: c.activityName,
+		TypeUID:      c.ocsfClass*100 + c.ocsfActivity,
+		SeverityID:   int(event.Severity),
+		Severity:     event.Severity.String(),
+		StatusID:     ocsfStatusSuccess,
+		Status:       "Success",
+		StatusDetail: event.Reason,
+		Metadata: ocsfMetadata{
+			Version: ocsfVersion,
+			Product: ocsfProduct{Name: productName, VendorName: "booking-tickets-sys"},
+		},
+		User:     ocsfUser{UID: event.UserID, EmailAddr: event.Email},
+		Unmapped: map[string]string{"action": string(event.Action)},
+	}
+	switch event.Outcome {
+	case models.SecurityOutcomeFailure:
+		doc.StatusID, doc.Status = ocsfStatusFailure, "Failure"
+	case models.SecurityOutcomeUnknown:
+		doc.StatusID, doc.Status = ocsfStatusUnknown, "Unknown"
+	}
+	if event.OrganizationID != "" {
+		doc.User.Org = &ocsfOrg{UID: event.OrganizationID}
+	}
+	if event.IPAddress != "" {
+		doc.SrcEndpoint = &ocsfEndpoint{IP: event.IPAddress}
+	}
+	if event.UserAgent != "" {
+		doc.HTTPRequest = &ocsfHTTPRequest{UserAgent: event.UserAgent}
+	}
+	if event.ClientID != "" {
+		doc.Unmapped["client_id"] = event.ClientID
+	}
+	return json.Marshal(doc)
+}
@@ -0,0 +1,123 @@
+// Package siem streams normalized security events, e.g. logins and their failures, to the SIEM
+// of the SOC. Events are formatted as ECS or OCSF documents and written to their own sink, a
+// file, a syslog receiver or an HTTP collector, so the SIEM ingests authentication telemetry
+// without parsing the free-form application logs.
+package siem
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/models"
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/pipeline"
+)
+
+// Stream buffers security events and delivers them to the sink in batches off the request
+// path. A disabled stream drops every event.
+type Stream struct {
+	pipeline *pipeline.Pipeline[*models.SecurityEvent]
+	format   Formatter
+	sink     Sink
+	logger   *logutils.Logger
+}
+
+// NewStream creates the stream of the configured format and sink. Call Start to begin
+// delivering events.
+func NewStream(cfg config.SecurityLogConfig, logger *logutils.Logger) (*Stream, error) {
+	s := &Stream{logger: logger}
+	if !cfg.Enabled {
+		return s, nil
+	}
+
+	format, ok := Formats[cfg.Format]
+	if !ok {
+		return nil, fmt.Errorf("unknown security log format %q", cfg.Format)
+	}
+	s.format = format
+
+	switch cfg.Sink {
+	case "file":
+		sink, err := NewFileSink(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		s.sink = sink
+	case "syslog":
+		s.sink = NewSyslogSink(cfg.Syslog.Network, cfg.Syslog.Address)
+	case "http":
+		s.sink = NewHTTPSink(cfg.HTTP.URL, cfg.HTTP.Token, cfg.HTTP.Timeout)
+	default:
+		return nil, fmt.Errorf("unknown security log sink %q", cfg.Sink)
+	}
+
+	s.pipeline = pipeline.New(pipeline.Config{
+		Name:          "security",
+		BufferSize:    cfg.Pipeline.BufferSize,
+		BatchSize:     cfg.Pipeline.BatchSize,
+		FlushInterval: cfg.Pipeline.FlushInterval,
+		FlushTimeout:  cfg.Pipeline.FlushTimeout,
+		Policy:        pipeline.Policy(cfg.Pipeline.Policy),
+	}, s.deliver, logger)
+	return s, nil
+}
+
+// Record submits an event to the stream. Failures are logged and never propagated, a lost
+// security event must not fail the request it describes.
+func (s *Stream) Record(ctx context.Context, event *models.SecurityEvent) {
+	if s.pipeline == nil {
+		return
+	}
+	if err := s.pipeline.Submit(ctx, event); err != nil {
+		s.logger.WithError(err).WithField("action", event.Action).Warn("Failed to submit security event")
+	}
+}
+
+// Start delivers events until ctx is cancelled, then flushes the buffered ones and closes the
+// sink
+func (s *Stream) Start(ctx context.Context, wg *sync.WaitGroup) {
+	if s.pipeline == nil {
+		return
+	}
+
+	var delivery sync.WaitGroup
+	s.pipeline.Start(ctx, &delivery)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		delivery.Wait()
+		if err := s.sink.Close(); err != nil {
+			s.logger.WithError(err).Warn("Failed to close security event sink")
+		}
+	}()
+}
+
+// Reopen reopens a file sink after its file was rotated. Other sinks need nothing.
+func (s *Stream) Reopen() error {
+	if sink, ok := s.sink.(*FileSink); ok {
+		return sink.Reopen()
+	}
+	return nil
+}
+
+// deliver formats a batch and writes it to the sink. Events that cannot be formatted are
+// skipped so they do not hold up the rest of the batch.
+func (s *Stream) deliver(ctx context.Context, batch []*models.SecurityEvent) error {
+	records := make([]Record, 0, len(batch))
+	for _, event := range batch {
+		data, err := s.format(event)
+		if err != nil {
+			s.logger.WithError(err).WithField("action", event.Action).Warn("Failed to format security event")
+			continue
+		}
+		records = append(records, Record{Severity: event.Severity, At: event.At, Data: data})
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	return s.sink.Write(ctx, records)
+}
@@ -0,0 +1,266 @@
+package siem
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/models"
+	logutils "user-svc/pkg/utils/log"
+)
+
+func testLogger() *logutils.Logger {
+	return logutils.New(io.Discard)
+}
+
+func failedLogin() *models.SecurityEvent {
+	return &models.SecurityEvent{
+		Action:         models.SecurityActionLogin,
+		Outcome:        models.SecurityOutcomeFailure,
+		Severity:       models.SecuritySeverityLow,
+		Reason:         "invalid credentials",
+		UserID:         "6f1c3a52-5d0e-4a37-9a43-1d2f1e0c9b11",
+		Email:          "fan@example.com",
+		OrganizationID: "0b6b1e55-1a5c-4b0e-9f2f-35f0c2a8d4e7",
+		ClientID:       "web",
+		IPAddress:      "203.0.113.7",
+		UserAgent:      "Mozilla/5.0",
+		At:             time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).UnixMilli(),
+	}
+}
+
+// field returns the value at a dotted path of a JSON document
+func field(t *testing.T, data []byte, path string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+func TestFormatECS(t *testing.T) {
+	data, err := FormatECS(failedLogin())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := map[string]interface{}{
+		"@timestamp":          "2026-03-01T12:00:00Z",
+		"ecs.version":         ecsVersion,
+		"event.kind":          "event",
+		"event.action":        "login",
+		"event.outcome":       "failure",
+		"event.reason":        "invalid credentials",
+		"event.severity":      float64(2),
+		"user.id":             "6f1c3a52-5d0e-4a37-9a43-1d2f1e0c9b11",
+		"user.email":          "fan@example.com",
+		"source.ip":           "203.0.113.7",
+		"user_agent.original": "Mozilla/5.0",
+		"organization.id":     "0b6b1e55-1a5c-4b0e-9f2f-35f0c2a8d4e7",
+		"labels.client_id":    "web",
+	}
+	for path, expected := range tests {
+		if got := field(t, data, path); got != expected {
+			t.Errorf("Expected %s to be %v, got %v", path, expected, got)
+		}
+	}
+	if got := field(t, data, "event.category"); len(got.([]interface{})) != 1 || got.([]interface{})[0] != "authentication" {
+		t.Errorf("Expected event.category [authentication], got %v", got)
+	}
+}
+
+func TestFormatOCSF(t *testing.T) {
+	data, err := FormatOCSF(failedLogin())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := map[string]interface{}{
+		"class_uid":       float64(ocsfClassAuthentication),
+		"activity_id":     float64(ocsfAuthLogon),
+		"type_uid":        float64(300201),
+		"status_id":       float64(ocsfStatusFailure),
+		"status_detail":   "invalid credentials",
+		"severity_id":     float64(2),
+		"user.email_addr": "fan@example.com",
+		"user.org.uid":    "0b6b1e55-1a5c-4b0e-9f2f-35f0c2a8d4e7",
+		"src_endpoint.ip": "203.0.113.7",
+		"time":            float64(failedLogin().At),
+	}
+	for path, expected := range tests {
+		if got := field(t, data, path); got != expected {
+			t.Errorf("Expected %s to be %v, got %v", path, expected, got)
+		}
+	}
+
+	// Registrations are account changes
+	data, err = FormatOCSF(&models.SecurityEvent{Action: models.SecurityActionRegister, Outcome: models.SecurityOutcomeSuccess})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := field(t, data, "type_uid"); got != float64(300101) {
+		t.Errorf("Expected type_uid 300101, got %v", got)
+	}
+}
+
+func TestStream_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "security", "events.ndjson")
+	stream, err := NewStream(config.SecurityLogConfig{
+		Enabled:  true,
+		Format:   "ecs",
+		Sink:     "file",
+		File:     path,
+		Pipeline: config.PipelineStreamConfig{FlushInterval: time.Hour},
+	}, testLogger())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	stream.Start(ctx, &wg)
+	stream.Record(ctx, failedLogin())
+	stream.Record(ctx, &models.SecurityEvent{Action: models.SecurityActionLogin, Outcome: models.SecurityOutcomeSuccess})
+
+	// Buffered events are flushed on shutdown
+	cancel()
+	wg.Wait()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected the security log file, got %v", err)
+	}
+	defer file.Close()
+	var outcomes []interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		outcomes = append(outcomes, field(t, scanner.Bytes(), "event.outcome"))
+	}
+	if len(outcomes) != 2 || outcomes[0] != "failure" || outcomes[1] != "success" {
+		t.Errorf("Expected a failure and a success, got %v", outcomes)
+	}
+}
+
+func TestFileSink_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.ndjson")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer sink.Close()
+
+	rotated := filepath.Join(dir, "events.ndjson.1")
+	if err := os.Rename(path, rotated); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := sink.Reopen(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := sink.Write(context.Background(), []Record{{Data: []byte(`{"a":1}`)}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected a new security log file, got %v", err)
+	}
+	if string(data) != "{\"a\":1}\n" {
+		t.Errorf("Expected the event in the new file, got %q", data)
+	}
+}
+
+func TestStream_Disabled(t *testing.T) {
+	stream, err := NewStream(config.SecurityLogConfig{Format: "unknown"}, testLogger())
+	if err != nil {
+		t.Fatalf("Expected a disabled stream to ignore its settings, got %v", err)
+	}
+	var wg sync.WaitGroup
+	stream.Start(context.Background(), &wg)
+	stream.Record(context.Background(), failedLogin())
+	wg.Wait()
+}
+
+func TestHTTPSink(t *testing.T) {
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer collector-token" {
+			t.Errorf("Expected the bearer token, got %q", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/x-ndjson" {
+			t.Errorf("Expected newline-delimited JSON, got %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		lines = strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+		if len(lines) > 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL, "collector-token", time.Second)
+	defer sink.Close()
+
+	if err := sink.Write(context.Background(), []Record{{Data: []byte(`{"a":1}`)}}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(lines) != 1 || lines[0] != `{"a":1}` {
+		t.Errorf("Expected one event, got %v", lines)
+	}
+
+	if err := sink.Write(context.Background(), []Record{{Data: []byte(`{"a":1}`)}, {Data: []byte(`{"b":2}`)}}); err == nil {
+		t.Error("Expected an error for a failed delivery")
+	}
+}
+
+func TestSyslogSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer conn.Close()
+
+	sink := NewSyslogSink("udp", conn.LocalAddr().String())
+	defer sink.Close()
+
+	record := Record{Severity: models.SecuritySeverityCritical, At: failedLogin().At, Data: []byte(`{"a":1}`)}
+	if err := sink.Write(context.Background(), []Record{record}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Expected a syslog message, got %v", err)
+	}
+	message := string(buf[:n])
+
+	// authpriv (10) * 8 + critical (2)
+	if !strings.HasPrefix(message, "<82>1 2026-03-01T12:00:00Z ") {
+		t.Errorf("Expected an RFC 5424 header, got %q", message)
+	}
+	if !strings.HasSuffix(message, ` user-svc `+strconv.Itoa(os.Getpid())+` security - {"a":1}`) {
+		t.Errorf("Expected the event as message, got %q", message)
+	}
+}
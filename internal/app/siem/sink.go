@@ -0,0 +1,247 @@
+package siem
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+)
+
+// Record is a formatted security event
+type Record struct {
+	Severity models.SecuritySeverity
+	// At is a Unix timestamp in milliseconds
+	At   int64
+	Data []byte
+}
+
+// Sink writes batches of formatted events to the SIEM
+type Sink interface {
+	Write(ctx context.Context, records []Record) error
+	Close() error
+}
+
+// FileSink appends events to a file as newline-delimited JSON, for a log shipper to tail
+type FileSink struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens the file for appending, creating it and its directory if needed
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create security log directory: %w", err)
+	}
+	file, err := openSecurityLog(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{path: path, file: file}, nil
+}
+
+func openSecurityLog(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open security log file: %w", err)
+	}
+	return file, nil
+}
+
+// Write appends the records in one write, so lines of concurrent writers never interleave
+func (s *FileSink) Write(_ context.Context, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.file.Write(ndjson(records))
+	return err
+}
+
+// Reopen closes the file and opens it again at its path, so events go to a new file once
+// logrotate moved the old one aside
+func (s *FileSink) Reopen() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := openSecurityLog(s.path)
+	if err != nil {
+		return err
+	}
+	previous := s.file
+	s.file = file
+	return previous.Close()
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// syslogFacilityAuthPriv is the facility of security and authorization messages
+const syslogFacilityAuthPriv = 10
+
+// SyslogSink sends events to a syslog receiver as RFC 5424 messages, one datagram each over
+// UDP and octet-counted over TCP (RFC 6587)
+type SyslogSink struct {
+	network  string
+	address  string
+	hostname string
+	timeout  time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink creates a sink connecting to the receiver on the first write
+func NewSyslogSink(network, address string) *SyslogSink {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &SyslogSink{network: network, address: address, hostname: hostname, timeout: 5 * time.Second}
+}
+
+// Write sends the records, reconnecting after a failed write so a restarted receiver gets the
+// next batch
+func (s *SyslogSink) Write(ctx context.Context, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		dialer := net.Dialer{Timeout: s.timeout}
+		conn, err := dialer.DialContext(ctx, s.network, s.address)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog receiver: %w", err)
+		}
+		s.conn = conn
+	}
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = s.conn.SetWriteDeadline(deadline)
+
+	for _, record := range records {
+		message := s.message(record)
+		if s.network == "tcp" {
+			message = append([]byte(strconv.Itoa(len(message))+" "), message...)
+		}
+		if _, err := s.conn.Write(message); err != nil {
+			_ = s.conn.Close()
+			s.conn = nil
+			return fmt.Errorf("failed to send to syslog receiver: %w", err)
+		}
+	}
+	return nil
+}
+
+// message formats a record as RFC 5424 message with the event as structured JSON payload
+func (s *SyslogSink) message(record Record) []byte {
+	priority := syslogFacilityAuthPriv*8 + syslogSeverity(record.Severity)
+	header := fmt.Sprintf("<%d>1 %s %s %s %d security - ",
+		priority,
+		time.UnixMilli(record.At).UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		productName,
+		os.Getpid(),
+	)
+	return append([]byte(header), record.Data...)
+}
+
+// syslogSeverity maps an event severity onto the syslog severities, from critical (2) to
+// informational (6)
+func syslogSeverity(severity models.SecuritySeverity) int {
+	switch severity {
+	case models.SecuritySeverityCritical:
+		return 2
+	case models.SecuritySeverityHigh:
+		return 3
+	case models.SecuritySeverityMedium:
+		return 4
+	case models.SecuritySeverityLow:
+		return 5
+	}
+	return 6
+}
+
+// Close closes the connection to the receiver
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// maxDrainedBody bounds how much of a response is read so the connection can be reused
+const maxDrainedBody = 64 << 10
+
+// HTTPSink posts batches of events as newline-delimited JSON to a collector
+type HTTPSink struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// NewHTTPSink creates a sink posting to url, with token as bearer token if set
+func NewHTTPSink(url, token string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{url: url, token: token, httpClient: &http.Client{Timeout: timeout}}
+}
+
+// Write posts the records in one request
+func (s *HTTPSink) Write(ctx context.Context, records []Record) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(ndjson(records)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post security events: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("security event collector answered with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close releases idle connections to the collector
+func (s *HTTPSink) Close() error {
+	s.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// ndjson joins the records into newline-delimited JSON
+func ndjson(records []Record) []byte {
+	var buf bytes.Buffer
+	for _, record := range records {
+		buf.Write(record.Data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
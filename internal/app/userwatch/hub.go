@@ -0,0 +1,189 @@
+// Package userwatch fans the notifications of committed user events out to the WatchUser
+// streams of this replica. The user_events_notify trigger notifies the user_events channel
+// on commit, so every replica learns of the changes made on any replica.
+package userwatch
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Channel is the Postgres notification channel of committed user events
+const Channel = "user_events"
+
+// Listener delivers Postgres notifications, e.g. a *pq.Listener. A nil notification
+// follows a reconnect, after which notifications sent meanwhile are lost.
+type Listener interface {
+	NotificationChannel() <-chan *pq.Notification
+	Ping() error
+}
+
+// Hub tracks the users watched by the streams of this replica and marks their subscriptions
+// when one of the users changed
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[uuid.UUID]map[*Subscription]struct{}
+	logger *logutils.Logger
+}
+
+// NewHub creates an empty hub; call Start to feed it notifications
+func NewHub(logger *logutils.Logger) *Hub {
+	return &Hub{
+		subs:   make(map[uuid.UUID]map[*Subscription]struct{}),
+		logger: logger,
+	}
+}
+
+// Subscription collects the watched users that changed until its stream takes them.
+// Changes of a user are coalesced, so a slow stream never holds up the hub.
+type Subscription struct {
+	ids     []uuid.UUID
+	mu      sync.Mutex
+	pending map[uuid.UUID]struct{}
+	ready   chan struct{}
+}
+
+// Ready receives once users changed since the last Take
+func (s *Subscription) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Take returns the users that changed since the last call
+func (s *Subscription) Take() []uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]uuid.UUID, 0, len(s.pending))
+	for id := range s.pending {
+		ids = append(ids, id)
+	}
+	clear(s.pending)
+	return ids
+}
+
+func (s *Subscription) mark(id uuid.UUID) {
+	s.mu.Lock()
+	s.pending[id] = struct{}{}
+	s.mu.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Subscribe watches the users until Unsubscribe is called
+func (h *Hub) Subscribe(ids []uuid.UUID) *Subscription {
+	sub := &Subscription{
+		ids:     ids,
+		pending: make(map[uuid.UUID]struct{}),
+		ready:   make(chan struct{}, 1),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range ids {
+		if h.subs[id] == nil {
+			h.subs[id] = make(map[*Subscription]struct{})
+		}
+		h.subs[id][sub] = struct{}{}
+	}
+	metrics.UserWatchStreams.Inc()
+
+	return sub
+}
+
+// Unsubscribe stops watching the users of the subscription
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range sub.ids {
+		delete(h.subs[id], sub)
+		if len(h.subs[id]) == 0 {
+			delete(h.subs, id)
+		}
+	}
+	metrics.UserWatchStreams.Dec()
+}
+
+// Notify marks the subscriptions watching the user
+func (h *Hub) Notify(id uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs[id] {
+		sub.mark(id)
+	}
+}
+
+// Resync marks every watched user of every subscription, so streams reload them
+func (h *Hub) Resync() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for id, subs := range h.subs {
+		for sub := range subs {
+			sub.mark(id)
+		}
+	}
+}
+
+// Start feeds the hub the notifications of the listener, and resyncs after reconnects and
+// every resyncInterval, until ctx is cancelled
+func (h *Hub) Start(ctx context.Context, wg *sync.WaitGroup, listener Listener, resyncInterval time.Duration) {
+	h.logger.WithField("channel", Channel).Info("Starting user watch hub")
+
+	wg.Add(1)
+	go func() {
+		defer func() {
+			wg.Done()
+			h.logger.Info("User watch hub stopped")
+		}()
+
+		ticker := time.NewTicker(resyncInterval)
+		defer ticker.Stop()
+
+		notifications := listener.NotificationChannel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case notification, ok := <-notifications:
+				if !ok {
+					return
+				}
+				if notification == nil {
+					h.logger.Warn("User event listener reconnected, resyncing watched users")
+					h.Resync()
+					continue
+				}
+				h.handleNotification(notification)
+			case <-ticker.C:
+				// A dead connection is only noticed when used
+				if err := listener.Ping(); err != nil {
+					h.logger.WithError(err).Warn("User event listener is not connected")
+				}
+				h.Resync()
+			}
+		}
+	}()
+}
+
+// handleNotification marks the user of a <user id>:<version> payload. Streams read the
+// version from the database, as notifications may be coalesced or lost.
+func (h *Hub) handleNotification(notification *pq.Notification) {
+	userID, _, _ := strings.Cut(notification.Extra, ":")
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		h.logger.WithField("payload", notification.Extra).Warn("Could not parse user event notification")
+		return
+	}
+
+	h.Notify(id)
+}
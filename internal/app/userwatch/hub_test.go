@@ -0,0 +1,124 @@
+package userwatch
+
+import (
+	"context"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type fakeListener struct {
+	notifications chan *pq.Notification
+}
+
+func (l *fakeListener) NotificationChannel() <-chan *pq.Notification {
+	return l.notifications
+}
+
+func (l *fakeListener) Ping() error {
+	return nil
+}
+
+func newTestHub() *Hub {
+	logger := logutils.New(io.Discard)
+	return NewHub(logger)
+}
+
+func waitReady(t *testing.T, sub *Subscription) {
+	t.Helper()
+	select {
+	case <-sub.Ready():
+	case <-time.After(time.Second):
+		t.Fatal("Expected subscription to be ready")
+	}
+}
+
+func TestHub_NotifyCoalescesChanges(t *testing.T) {
+	hub := newTestHub()
+	watched, other := uuid.New(), uuid.New()
+
+	sub := hub.Subscribe([]uuid.UUID{watched})
+	defer hub.Unsubscribe(sub)
+
+	hub.Notify(other)
+	select {
+	case <-sub.Ready():
+		t.Fatal("Expected no change for an unwatched user")
+	default:
+	}
+
+	hub.Notify(watched)
+	hub.Notify(watched)
+	waitReady(t, sub)
+
+	if ids := sub.Take(); !slices.Equal(ids, []uuid.UUID{watched}) {
+		t.Errorf("Expected [%s], got %v", watched, ids)
+	}
+	if ids := sub.Take(); len(ids) != 0 {
+		t.Errorf("Expected no pending users, got %v", ids)
+	}
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	hub := newTestHub()
+	id := uuid.New()
+
+	first := hub.Subscribe([]uuid.UUID{id})
+	second := hub.Subscribe([]uuid.UUID{id})
+	hub.Unsubscribe(first)
+
+	hub.Notify(id)
+	waitReady(t, second)
+	select {
+	case <-first.Ready():
+		t.Error("Expected no change after unsubscribing")
+	default:
+	}
+
+	hub.Unsubscribe(second)
+	if len(hub.subs) != 0 {
+		t.Errorf("Expected no watched users, got %d", len(hub.subs))
+	}
+}
+
+func TestHub_Start(t *testing.T) {
+	hub := newTestHub()
+	first, second := uuid.New(), uuid.New()
+
+	sub := hub.Subscribe([]uuid.UUID{first, second})
+	defer hub.Unsubscribe(sub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	listener := &fakeListener{notifications: make(chan *pq.Notification)}
+	hub.Start(ctx, &wg, listener, time.Hour)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	listener.notifications <- &pq.Notification{Channel: Channel, Extra: "not-a-user:1"}
+	listener.notifications <- &pq.Notification{Channel: Channel, Extra: first.String() + ":3"}
+	waitReady(t, sub)
+	if ids := sub.Take(); !slices.Equal(ids, []uuid.UUID{first}) {
+		t.Errorf("Expected [%s], got %v", first, ids)
+	}
+
+	// A reconnect may have lost notifications, so every watched user is reloaded
+	listener.notifications <- nil
+	waitReady(t, sub)
+	ids := sub.Take()
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+	expected := []uuid.UUID{first, second}
+	slices.SortFunc(expected, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+	if !slices.Equal(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+}
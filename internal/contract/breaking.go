@@ -0,0 +1,177 @@
+package contract
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Breaking returns the changes from the previous to the current image that break clients
+// built against the previous one, on the wire or in JSON: removed files, messages, enums,
+// services and methods; removed fields and enum values whose number was not reserved; and
+// fields, values and methods whose name, type, cardinality, oneof or streaming changed.
+// Additions are not breaking.
+func Breaking(previous, current *descriptorpb.FileDescriptorSet) ([]string, error) {
+	previousFiles, err := protodesc.NewFiles(previous)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previous image: %w", err)
+	}
+	currentFiles, err := protodesc.NewFiles(current)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current image: %w", err)
+	}
+
+	c := &comparison{current: currentFiles}
+	previousFiles.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		// Well-known types are not ours to break
+		if strings.HasPrefix(file.Path(), "google/protobuf/") {
+			return true
+		}
+		if _, err := currentFiles.FindFileByPath(file.Path()); err != nil {
+			c.breaks("file %s was removed", file.Path())
+			return true
+		}
+		c.messages(file.Messages())
+		c.enums(file.Enums())
+		c.services(file.Services())
+		return true
+	})
+
+	sort.Strings(c.changes)
+	return c.changes, nil
+}
+
+type comparison struct {
+	current *protoregistry.Files
+	changes []string
+}
+
+func (c *comparison) breaks(format string, args ...interface{}) {
+	c.changes = append(c.changes, fmt.Sprintf(format, args...))
+}
+
+func (c *comparison) messages(messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		previous := messages.Get(i)
+		descriptor, err := c.current.FindDescriptorByName(previous.FullName())
+		current, ok := descriptor.(protoreflect.MessageDescriptor)
+		if err != nil || !ok {
+			c.breaks("message %s was removed", previous.FullName())
+			continue
+		}
+
+		fields := previous.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			c.field(fields.Get(j), current)
+		}
+		c.messages(previous.Messages())
+		c.enums(previous.Enums())
+	}
+}
+
+func (c *comparison) field(previous protoreflect.FieldDescriptor, message protoreflect.MessageDescriptor) {
+	current := message.Fields().ByNumber(previous.Number())
+	if current == nil {
+		if !message.ReservedRanges().Has(previous.Number()) {
+			c.breaks("field %s (%d) was removed without reserving its number", previous.FullName(), previous.Number())
+		}
+		return
+	}
+
+	if previous.Name() != current.Name() {
+		c.breaks("field %s (%d) was renamed to %s", previous.FullName(), previous.Number(), current.Name())
+	} else if previous.JSONName() != current.JSONName() {
+		c.breaks("field %s (%d) changed its JSON name from %s to %s", previous.FullName(), previous.Number(), previous.JSONName(), current.JSONName())
+	}
+	if previousType, currentType := fieldType(previous), fieldType(current); previousType != currentType {
+		c.breaks("field %s (%d) changed its type from %s to %s", previous.FullName(), previous.Number(), previousType, currentType)
+	}
+	if previous.Cardinality() != current.Cardinality() {
+		c.breaks("field %s (%d) changed from %s to %s", previous.FullName(), previous.Number(), previous.Cardinality(), current.Cardinality())
+	}
+	if previousOneof, currentOneof := oneofName(previous), oneofName(current); previousOneof != currentOneof {
+		c.breaks("field %s (%d) moved from oneof %q to %q", previous.FullName(), previous.Number(), previousOneof, currentOneof)
+	}
+}
+
+// fieldType names the type of a field, the message or enum for those kinds
+func fieldType(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap():
+		return fmt.Sprintf("map<%s, %s>", fieldType(field.MapKey()), fieldType(field.MapValue()))
+	case field.Message() != nil:
+		return string(field.Message().FullName())
+	case field.Enum() != nil:
+		return string(field.Enum().FullName())
+	}
+	return field.Kind().String()
+}
+
+// oneofName returns the oneof of a field, "" for none; proto3 optional fields are not in one
+func oneofName(field protoreflect.FieldDescriptor) string {
+	if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+		return string(oneof.Name())
+	}
+	return ""
+}
+
+func (c *comparison) enums(enums protoreflect.EnumDescriptors) {
+	for i := 0; i < enums.Len(); i++ {
+		previous := enums.Get(i)
+		descriptor, err := c.current.FindDescriptorByName(previous.FullName())
+		current, ok := descriptor.(protoreflect.EnumDescriptor)
+		if err != nil || !ok {
+			c.breaks("enum %s was removed", previous.FullName())
+			continue
+		}
+
+		values := previous.Values()
+		for j := 0; j < values.Len(); j++ {
+			value := values.Get(j)
+			currentValue := current.Values().ByNumber(value.Number())
+			switch {
+			case currentValue == nil && !current.ReservedRanges().Has(value.Number()):
+				c.breaks("enum value %s (%d) was removed without reserving its number", value.FullName(), value.Number())
+			case currentValue != nil && currentValue.Name() != value.Name():
+				// Enums are named in JSON
+				c.breaks("enum value %s (%d) was renamed to %s", value.FullName(), value.Number(), currentValue.Name())
+			}
+		}
+	}
+}
+
+func (c *comparison) services(services protoreflect.ServiceDescriptors) {
+	for i := 0; i < services.Len(); i++ {
+		previous := services.Get(i)
+		descriptor, err := c.current.FindDescriptorByName(previous.FullName())
+		current, ok := descriptor.(protoreflect.ServiceDescriptor)
+		if err != nil || !ok {
+			c.breaks("service %s was removed", previous.FullName())
+			continue
+		}
+
+		methods := previous.Methods()
+		for j := 0; j < methods.Len(); j++ {
+			method := methods.Get(j)
+			currentMethod := current.Methods().ByName(method.Name())
+			if currentMethod == nil {
+				c.breaks("method %s was removed", method.FullName())
+				continue
+			}
+			if method.Input().FullName() != currentMethod.Input().FullName() {
+				c.breaks("method %s changed its request from %s to %s", method.FullName(), method.Input().FullName(), currentMethod.Input().FullName())
+			}
+			if method.Output().FullName() != currentMethod.Output().FullName() {
+				c.breaks("method %s changed its response from %s to %s", method.FullName(), method.Output().FullName(), currentMethod.Output().FullName())
+			}
+			if method.IsStreamingClient() != currentMethod.IsStreamingClient() || method.IsStreamingServer() != currentMethod.IsStreamingServer() {
+				c.breaks("method %s changed its streaming", method.FullName())
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package contract snapshots the public API of the service, so changes that break existing
+// clients fail the tests instead of surfacing in production: the descriptors of the v1 and v2
+// UserService (field numbers, JSON names, types and methods), the gRPC codes and messages of the
+// domain errors, and recorded request/response fixtures whose wire bytes must keep decoding to
+// the same values.
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+
+	pb "user-svc/api/proto/v1"
+	pbv2 "user-svc/api/proto/v2"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Snapshot files of the contract, written by make contract
+const (
+	ImageFile  = "user-svc.image.json"
+	ErrorsFile = "errors.json"
+)
+
+// Files returns the proto files of the API
+func Files() []protoreflect.FileDescriptor {
+	return []protoreflect.FileDescriptor{
+		pb.File_v1_user_svc_proto,
+		pbv2.File_v2_user_svc_proto,
+	}
+}
+
+// Image returns the API files and their imports as a self-contained FileDescriptorSet,
+// imports first, as buf expects of an image
+func Image() *descriptorpb.FileDescriptorSet {
+	image := &descriptorpb.FileDescriptorSet{}
+	seen := map[string]bool{}
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		image.File = append(image.File, protodesc.ToFileDescriptorProto(file))
+	}
+	for _, file := range Files() {
+		add(file)
+	}
+	return image
+}
+
+// RenderImage renders an image as indented JSON, an image format buf reads
+func RenderImage(image *descriptorpb.FileDescriptorSet) ([]byte, error) {
+	data, err := protojson.Marshal(image)
+	if err != nil {
+		return nil, err
+	}
+	// protojson output is deliberately unstable, so the snapshot is re-indented to diff cleanly
+	return indent(data)
+}
+
+// ParseImage parses an image rendered by RenderImage
+func ParseImage(data []byte) (*descriptorpb.FileDescriptorSet, error) {
+	image := &descriptorpb.FileDescriptorSet{}
+	if err := protojson.Unmarshal(data, image); err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+func indent(data []byte) ([]byte, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
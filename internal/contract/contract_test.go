@@ -0,0 +1,287 @@
+package contract
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	snapshotDir = "../../api/contract"
+	errsDir     = "../app/domains/errs"
+	fixturesDir = "testdata/fixtures"
+)
+
+func loadImage(t *testing.T) *descriptorpb.FileDescriptorSet {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(snapshotDir, ImageFile))
+	if err != nil {
+		t.Fatalf("Failed to read image snapshot, run make contract: %v", err)
+	}
+	image, err := ParseImage(data)
+	if err != nil {
+		t.Fatalf("Failed to parse image snapshot: %v", err)
+	}
+	return image
+}
+
+func TestImage_NoBreakingChanges(t *testing.T) {
+	breaking, err := Breaking(loadImage(t), Image())
+	if err != nil {
+		t.Fatalf("Failed to compare images: %v", err)
+	}
+	for _, change := range breaking {
+		t.Errorf("Breaking API change: %s", change)
+	}
+}
+
+func TestImage_UpToDate(t *testing.T) {
+	if !proto.Equal(loadImage(t), Image()) {
+		t.Errorf("Expected the image snapshot to match the API, run make contract")
+	}
+}
+
+// TestImage_BufBreaking cross-checks Breaking with buf's own WIRE_JSON rules where buf is installed
+func TestImage_BufBreaking(t *testing.T) {
+	buf, err := exec.LookPath("buf")
+	if err != nil {
+		t.Skip("buf is not installed")
+	}
+
+	data, err := RenderImage(Image())
+	if err != nil {
+		t.Fatalf("Failed to render image: %v", err)
+	}
+	current := filepath.Join(t.TempDir(), "current.json")
+	if err := os.WriteFile(current, data, 0644); err != nil {
+		t.Fatalf("Failed to write image: %v", err)
+	}
+
+	cmd := exec.Command(buf, "breaking", current,
+		"--against", filepath.Join(snapshotDir, ImageFile),
+		"--config", `{"version":"v1","breaking":{"use":["WIRE_JSON"]}}`)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("Expected buf to find no breaking changes, got %v:\n%s", err, out)
+	}
+}
+
+func TestBreaking(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(image *descriptorpb.FileDescriptorSet)
+		want   string
+	}{
+		{
+			name: "renumbered field",
+			change: func(image *descriptorpb.FileDescriptorSet) {
+				message(t, image, "LoginRequest").Field[1].Number = proto.Int32(10)
+			},
+			want: "field user.LoginRequest.password (2) was removed without reserving its number",
+		},
+		{
+			name: "renamed field",
+			change: func(image *descriptorpb.FileDescriptorSet) {
+				field := message(t, image, "LoginRequest").Field[0]
+				field.Name = proto.String("email_address")
+				field.JsonName = proto.String("emailAddress")
+			},
+			want: "field user.LoginRequest.email (1) was renamed to email_address",
+		},
+		{
+			name: "changed type",
+			change: func(image *descriptorpb.FileDescriptorSet) {
+				message(t, image, "LoginRequest").Field[3].Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+			},
+			want: "field user.LoginRequest.remember_me (4) changed its type from bool to string",
+		},
+		{
+			name: "repeated field",
+			change: func(image *descriptorpb.FileDescriptorSet) {
+				message(t, image, "LoginRequest").Field[2].Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			},
+			want: "field user.LoginRequest.client_id (3) changed from optional to repeated",
+		},
+		{
+			name: "removed method",
+			change: func(image *descriptorpb.FileDescriptorSet) {
+				service := file(t, image).Service[0]
+				service.Method = service.Method[1:]
+			},
+			want: "method user.UserService.Register was removed",
+		},
+		{
+			name: "streaming method",
+			change: func(image *descriptorpb.FileDescriptorSet) {
+				file(t, image).Service[0].Method[1].ServerStreaming = proto.Bool(true)
+			},
+			want: "method user.UserService.Login changed its streaming",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := Image()
+			tt.change(current)
+
+			breaking, err := Breaking(Image(), current)
+			if err != nil {
+				t.Fatalf("Failed to compare images: %v", err)
+			}
+			if len(breaking) != 1 || breaking[0] != tt.want {
+				t.Errorf("Expected [%s], got %v", tt.want, breaking)
+			}
+		})
+	}
+}
+
+func TestBreaking_ReservedFieldAndAdditions(t *testing.T) {
+	current := Image()
+	login := message(t, current, "LoginRequest")
+	login.Field = login.Field[:3]
+	login.ReservedRange = append(login.ReservedRange, &descriptorpb.DescriptorProto_ReservedRange{
+		Start: proto.Int32(4),
+		End:   proto.Int32(5),
+	})
+	login.Field = append(login.Field, &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("device_id"),
+		JsonName: proto.String("deviceId"),
+		Number:   proto.Int32(5),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+	})
+
+	breaking, err := Breaking(Image(), current)
+	if err != nil {
+		t.Fatalf("Failed to compare images: %v", err)
+	}
+	if len(breaking) != 0 {
+		t.Errorf("Expected no breaking changes, got %v", breaking)
+	}
+}
+
+func file(t *testing.T, image *descriptorpb.FileDescriptorSet) *descriptorpb.FileDescriptorProto {
+	t.Helper()
+	for _, f := range image.File {
+		if f.GetName() == "v1/user-svc.proto" {
+			return f
+		}
+	}
+	t.Fatalf("v1/user-svc.proto not in the image")
+	return nil
+}
+
+func message(t *testing.T, image *descriptorpb.FileDescriptorSet, name string) *descriptorpb.DescriptorProto {
+	t.Helper()
+	for _, m := range file(t, image).MessageType {
+		if m.GetName() == name {
+			return m
+		}
+	}
+	t.Fatalf("%s not in the image", name)
+	return nil
+}
+
+func TestDomainErrors_Unchanged(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join(snapshotDir, ErrorsFile))
+	if err != nil {
+		t.Fatalf("Failed to read errors snapshot, run make contract: %v", err)
+	}
+	previous, err := ParseErrors(data)
+	if err != nil {
+		t.Fatalf("Failed to parse errors snapshot: %v", err)
+	}
+	current, err := DomainErrors(errsDir)
+	if err != nil {
+		t.Fatalf("Failed to read domain errors: %v", err)
+	}
+
+	for _, change := range ChangedErrors(previous, current) {
+		t.Errorf("Breaking error change: %s", change)
+	}
+	if !reflect.DeepEqual(previous, current) {
+		t.Errorf("Expected the errors snapshot to match the errs package, run make contract")
+	}
+}
+
+func TestDomainErrors(t *testing.T) {
+	domainErrors, err := DomainErrors(errsDir)
+	if err != nil {
+		t.Fatalf("Failed to read domain errors: %v", err)
+	}
+
+	want := DomainError{Name: "ErrInvalidCredentials", Code: "Unauthenticated", Message: "invalid credentials"}
+	for _, domainError := range domainErrors {
+		if domainError.Name == want.Name {
+			if domainError != want {
+				t.Errorf("Expected %+v, got %+v", want, domainError)
+			}
+			return
+		}
+	}
+	t.Errorf("Expected %s among %d errors", want.Name, len(domainErrors))
+}
+
+func TestChangedErrors(t *testing.T) {
+	previous := []DomainError{
+		{Name: "ErrA", Code: "NotFound", Message: "a not found"},
+		{Name: "ErrB", Code: "InvalidArgument", Message: "invalid b"},
+		{Name: "ErrC", Code: "Unauthenticated", Message: "c expired"},
+	}
+	current := []DomainError{
+		{Name: "ErrA", Code: "FailedPrecondition", Message: "a not found"},
+		{Name: "ErrB", Code: "InvalidArgument", Message: "b is invalid"},
+		{Name: "ErrD", Code: "Internal", Message: "new"},
+	}
+
+	want := []string{
+		`error ErrA changed its code from NotFound to FailedPrecondition`,
+		`error ErrB changed its message from "invalid b" to "b is invalid"`,
+		`error ErrC was removed`,
+	}
+	if got := ChangedErrors(previous, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	fixtures, err := LoadFixtures(fixturesDir)
+	if err != nil {
+		t.Fatalf("Failed to load fixtures: %v", err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("Expected fixtures in %s", fixturesDir)
+	}
+
+	for name, fixture := range fixtures {
+		t.Run(strings.TrimSuffix(name, ".json"), func(t *testing.T) {
+			if err := fixture.Check(); err != nil {
+				t.Errorf("Expected %s to match the API, got %v; new fixtures are recorded by make contract", name, err)
+			}
+		})
+	}
+}
+
+func TestFixture_CheckDetectsRenamedField(t *testing.T) {
+	fixture := &Fixture{
+		Method:   "user.UserService.RefreshToken",
+		Request:  []byte(`{"refreshToken":"token"}`),
+		Response: []byte(`{"accessToken":"access"}`),
+	}
+	if err := fixture.Record(); err != nil {
+		t.Fatalf("Failed to record fixture: %v", err)
+	}
+	if err := fixture.Check(); err != nil {
+		t.Fatalf("Expected recorded fixture to check, got %v", err)
+	}
+
+	fixture.Request = []byte(`{"token":"token"}`)
+	if err := fixture.Check(); err == nil {
+		t.Errorf("Expected a fixture with an unknown JSON field to fail")
+	}
+}
@@ -0,0 +1,147 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+)
+
+// DomainError is an error the service returns to clients, which match on its code and message
+type DomainError struct {
+	Name    string `json:"name"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// DomainErrors returns the sentinel errors declared in the Go files of the errs package in dir,
+// the package-level variables initialized with NewError(codes.X, "message"), sorted by name.
+// The source is read rather than the package imported, so every sentinel is covered without
+// having to be listed here.
+func DomainErrors(dir string) ([]DomainError, error) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var domainErrors []DomainError
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				found, err := sentinels(spec.(*ast.ValueSpec))
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", fset.Position(spec.Pos()), err)
+				}
+				domainErrors = append(domainErrors, found...)
+			}
+		}
+	}
+
+	sort.Slice(domainErrors, func(i, j int) bool {
+		return domainErrors[i].Name < domainErrors[j].Name
+	})
+	return domainErrors, nil
+}
+
+// sentinels returns the errors of a var spec initialized with NewError
+func sentinels(spec *ast.ValueSpec) ([]DomainError, error) {
+	var found []DomainError
+	for i, value := range spec.Values {
+		call, ok := value.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 || i >= len(spec.Names) {
+			continue
+		}
+		if fn, ok := call.Fun.(*ast.Ident); !ok || fn.Name != "NewError" {
+			continue
+		}
+
+		code, ok := call.Args[0].(*ast.SelectorExpr)
+		if !ok {
+			return nil, fmt.Errorf("%s: code is not a codes constant", spec.Names[i].Name)
+		}
+		if !validCode(code.Sel.Name) {
+			return nil, fmt.Errorf("%s: unknown code %s", spec.Names[i].Name, code.Sel.Name)
+		}
+		literal, ok := call.Args[1].(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return nil, fmt.Errorf("%s: message is not a string literal", spec.Names[i].Name)
+		}
+		message, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", spec.Names[i].Name, err)
+		}
+
+		found = append(found, DomainError{Name: spec.Names[i].Name, Code: code.Sel.Name, Message: message})
+	}
+	return found, nil
+}
+
+// validCode reports whether name is a gRPC code, as spelled in the codes package
+func validCode(name string) bool {
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if code.String() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderErrors renders domain errors as indented JSON
+func RenderErrors(domainErrors []DomainError) ([]byte, error) {
+	data, err := json.MarshalIndent(domainErrors, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// ParseErrors parses domain errors rendered by RenderErrors
+func ParseErrors(data []byte) ([]DomainError, error) {
+	var domainErrors []DomainError
+	if err := json.Unmarshal(data, &domainErrors); err != nil {
+		return nil, err
+	}
+	return domainErrors, nil
+}
+
+// ChangedErrors returns the errors of previous that were removed or whose code or message
+// changed in current; clients match on both, so either breaks them
+func ChangedErrors(previous, current []DomainError) []string {
+	byName := make(map[string]DomainError, len(current))
+	for _, domainError := range current {
+		byName[domainError.Name] = domainError
+	}
+
+	var changes []string
+	for _, before := range previous {
+		after, ok := byName[before.Name]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("error %s was removed", before.Name))
+		case after.Code != before.Code:
+			changes = append(changes, fmt.Sprintf("error %s changed its code from %s to %s", before.Name, before.Code, after.Code))
+		case after.Message != before.Message:
+			changes = append(changes, fmt.Sprintf("error %s changed its message from %q to %q", before.Name, before.Message, after.Message))
+		}
+	}
+	return changes
+}
@@ -0,0 +1,214 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Fixture is a recorded exchange of an RPC: a request and a response in the proto JSON mapping,
+// as captures and logged payloads hold them, and their wire bytes, as gRPC clients built against
+// the recorded API send and read them. Both must keep decoding to the same messages.
+type Fixture struct {
+	// Method is the full name of the RPC, e.g. "user.UserService.Login"
+	Method  string          `json:"method"`
+	Request json.RawMessage `json:"request"`
+	// RequestWire is the base64 wire bytes of the request, nil until recorded; empty
+	// messages have no bytes
+	RequestWire  *string         `json:"request_wire,omitempty"`
+	Response     json.RawMessage `json:"response"`
+	ResponseWire *string         `json:"response_wire,omitempty"`
+}
+
+// Recorded reports whether the wire bytes of a fixture are recorded
+func (f *Fixture) Recorded() bool {
+	return f.RequestWire != nil && f.ResponseWire != nil
+}
+
+// LoadFixtures reads the fixtures of dir, keyed by file name
+func LoadFixtures(dir string) (map[string]*Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	fixtures := make(map[string]*Fixture, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fixture := &Fixture{}
+		if err := json.Unmarshal(data, fixture); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		fixtures[filepath.Base(path)] = fixture
+	}
+	return fixtures, nil
+}
+
+// Render renders a fixture as indented JSON
+func (f *Fixture) Render() ([]byte, error) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return indent(data)
+}
+
+// Record sets the missing wire bytes of a fixture from its JSON messages
+func (f *Fixture) Record() error {
+	method, err := f.method()
+	if err != nil {
+		return err
+	}
+	if f.RequestWire == nil {
+		if f.RequestWire, err = record(method.Input(), f.Request); err != nil {
+			return fmt.Errorf("request: %w", err)
+		}
+	}
+	if f.ResponseWire == nil {
+		if f.ResponseWire, err = record(method.Output(), f.Response); err != nil {
+			return fmt.Errorf("response: %w", err)
+		}
+	}
+	return nil
+}
+
+// Check verifies that the recorded messages of a fixture still decode, with no unknown fields,
+// to the same messages from JSON and from the wire, and that they encode to the recorded bytes
+func (f *Fixture) Check() error {
+	method, err := f.method()
+	if err != nil {
+		return err
+	}
+	if err := check(method.Input(), f.Request, f.RequestWire); err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	if err := check(method.Output(), f.Response, f.ResponseWire); err != nil {
+		return fmt.Errorf("response: %w", err)
+	}
+	return nil
+}
+
+func (f *Fixture) method() (protoreflect.MethodDescriptor, error) {
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(f.Method))
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %w", f.Method, err)
+	}
+	method, ok := descriptor.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a method", f.Method)
+	}
+	return method, nil
+}
+
+func newMessage(descriptor protoreflect.MessageDescriptor) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(descriptor.FullName())
+	if err != nil {
+		return nil, err
+	}
+	return messageType.New().Interface(), nil
+}
+
+// fromJSON decodes a JSON message; unknown fields, e.g. renamed ones, fail it
+func fromJSON(descriptor protoreflect.MessageDescriptor, data json.RawMessage) (proto.Message, error) {
+	message, err := newMessage(descriptor)
+	if err != nil {
+		return nil, err
+	}
+	if err := protojson.Unmarshal(data, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func record(descriptor protoreflect.MessageDescriptor, data json.RawMessage) (*string, error) {
+	message, err := fromJSON(descriptor, data)
+	if err != nil {
+		return nil, err
+	}
+	wire, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(wire)
+	return &encoded, nil
+}
+
+func check(descriptor protoreflect.MessageDescriptor, data json.RawMessage, recorded *string) error {
+	if recorded == nil {
+		return fmt.Errorf("no wire bytes recorded")
+	}
+	encoded := *recorded
+	wire, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid wire bytes: %w", err)
+	}
+
+	fromWire, err := newMessage(descriptor)
+	if err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(wire, fromWire); err != nil {
+		return fmt.Errorf("decoding wire bytes: %w", err)
+	}
+	if unknown := unknownFields(fromWire.ProtoReflect()); len(unknown) > 0 {
+		return fmt.Errorf("wire bytes have unknown fields in %s", strings.Join(unknown, ", "))
+	}
+
+	decoded, err := fromJSON(descriptor, data)
+	if err != nil {
+		return fmt.Errorf("decoding JSON: %w", err)
+	}
+	if !proto.Equal(decoded, fromWire) {
+		return fmt.Errorf("JSON decodes to %v, wire bytes to %v", decoded, fromWire)
+	}
+
+	encodedNow, err := proto.MarshalOptions{Deterministic: true}.Marshal(decoded)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(encodedNow, wire) {
+		return fmt.Errorf("encodes to %s, recorded %s", base64.StdEncoding.EncodeToString(encodedNow), encoded)
+	}
+	return nil
+}
+
+// unknownFields returns the messages of m, m included, holding unknown fields
+func unknownFields(m protoreflect.Message) []string {
+	var unknown []string
+	if len(m.GetUnknown()) > 0 {
+		unknown = append(unknown, string(m.Descriptor().FullName()))
+	}
+	m.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsMap():
+			if field.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					unknown = append(unknown, unknownFields(v.Message())...)
+					return true
+				})
+			}
+		case field.IsList():
+			if field.Message() != nil {
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					unknown = append(unknown, unknownFields(list.Get(i).Message())...)
+				}
+			}
+		case field.Message() != nil:
+			unknown = append(unknown, unknownFields(value.Message())...)
+		}
+		return true
+	})
+	return unknown
+}
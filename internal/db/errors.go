@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/lib/pq"
+)
+
+// PostgreSQL error classes and codes considered transient
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pqClassConnectionException pq.ErrorClass = "08"
+	pqSerializationFailure     pq.ErrorCode  = "40001"
+	pqDeadlockDetected         pq.ErrorCode  = "40P01"
+	pqAdminShutdown            pq.ErrorCode  = "57P01"
+	pqCrashShutdown            pq.ErrorCode  = "57P02"
+	pqCannotConnectNow         pq.ErrorCode  = "57P03"
+	pqReadOnlyTransaction      pq.ErrorCode  = "25006"
+	pqUniqueViolation          pq.ErrorCode  = "23505"
+)
+
+// IsUniqueViolation reports whether err is a unique constraint violation
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation
+}
+
+// IsSerializationFailure reports whether err is a serialization failure or deadlock,
+// meaning the statement (or transaction) was aborted and can safely be retried
+func IsSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqSerializationFailure || pqErr.Code == pqDeadlockDetected
+	}
+	return false
+}
+
+// IsConnectionError reports whether err is caused by a broken connection,
+// a server shutdown or a failover in progress
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case pqAdminShutdown, pqCrashShutdown, pqCannotConnectNow, pqReadOnlyTransaction:
+			// A read-only transaction error on a write means we are talking to a demoted primary
+			return true
+		}
+		return pqErr.Code.Class() == pqClassConnectionException
+	}
+
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsTransient reports whether err is a transient database error worth retrying
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return IsSerializationFailure(err) || IsConnectionError(err)
+}
+
+// ClassifyError converts transient database errors into domain errors carrying
+// a retryable gRPC status. Any other error is returned unchanged.
+func ClassifyError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case IsSerializationFailure(err):
+		return errs.ErrDatabaseConflict.Wrap(err)
+	case IsTransient(err):
+		return errs.ErrDatabaseUnavailable.Wrap(err)
+	default:
+		return err
+	}
+}
@@ -73,4 +73,684 @@ CREATE TRIGGER update_notification_event_logs_updated_at
     EXECUTE FUNCTION update_updated_at_column();
 
 
-CREATE INDEX IF NOT EXISTS idx_notification_event_logs_event_name_status ON notification_event_logs(event_name, status);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_notification_event_logs_event_name_status ON notification_event_logs(event_name, status);
+
+-- Audit trail written asynchronously by the audit pipeline
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_id_created_at ON audit_logs(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action);
+
+-- Call counters per quota subject (API key or user), method and accounting window
+CREATE TABLE IF NOT EXISTS quota_usage (
+    subject VARCHAR(255) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    window_start BIGINT NOT NULL,
+    used BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000),
+    updated_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000),
+    PRIMARY KEY (subject, method, window_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quota_usage_window_start ON quota_usage(window_start);
+
+-- Applied schema versions, verified by the startup preflight against db.SchemaVersion.
+-- Append a new INSERT whenever the schema changes.
+CREATE TABLE IF NOT EXISTS schema_version (
+    version INT PRIMARY KEY,
+    applied_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)
+);
+
+INSERT INTO schema_version (version) VALUES (1) ON CONFLICT DO NOTHING;
+
+-- Token revocations propagated to every replica; user revocations invalidate all
+-- access tokens issued to the user up to revoked_at, jti revocations a single token
+CREATE TABLE IF NOT EXISTS token_revocations (
+    kind VARCHAR(16) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    revoked_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (kind, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_token_revocations_expires_at ON token_revocations(expires_at);
+
+INSERT INTO schema_version (version) VALUES (2) ON CONFLICT DO NOTHING;
+
+-- DPoP key thumbprint a refresh token is bound to; empty for bearer sessions
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS dpop_jkt VARCHAR(64) NOT NULL DEFAULT '';
+
+INSERT INTO schema_version (version) VALUES (3) ON CONFLICT DO NOTHING;
+
+-- Registered clients and the token policy applied to their users; zero TTLs fall back
+-- to the service defaults
+CREATE TABLE IF NOT EXISTS clients (
+    client_id VARCHAR(64) PRIMARY KEY,
+    platform VARCHAR(16) NOT NULL,
+    allowed_grant_types TEXT[] NOT NULL DEFAULT '{}',
+    access_token_ttl_ms BIGINT NOT NULL DEFAULT 0,
+    refresh_token_ttl_ms BIGINT NOT NULL DEFAULT 0,
+    rotate_refresh_tokens BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000),
+    updated_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)
+);
+
+CREATE TRIGGER update_clients_updated_at
+    BEFORE UPDATE ON clients
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO clients (client_id, platform, allowed_grant_types, access_token_ttl_ms, refresh_token_ttl_ms, rotate_refresh_tokens) VALUES
+    ('web', 'web', '{register,password,refresh_token}', 900000, 604800000, TRUE),
+    ('mobile', 'mobile', '{register,password,refresh_token}', 3600000, 2592000000, TRUE),
+    ('kiosk', 'kiosk', '{password}', 300000, 0, FALSE)
+ON CONFLICT (client_id) DO NOTHING;
+
+-- Client a refresh token was issued to; empty for tokens issued before client policies
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS client_id VARCHAR(64) NOT NULL DEFAULT '';
+
+INSERT INTO schema_version (version) VALUES (4) ON CONFLICT DO NOTHING;
+
+-- Whether the session was started with remember_me; sessions from before the option were long-lived
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS remember_me BOOLEAN NOT NULL DEFAULT TRUE;
+
+INSERT INTO schema_version (version) VALUES (5) ON CONFLICT DO NOTHING;
+
+-- Runs of scheduled jobs, one row per job and period; the row claims the run for a
+-- single replica until completed_at is set or the claim's lease expires
+CREATE TABLE IF NOT EXISTS scheduled_job_runs (
+    job VARCHAR(100) NOT NULL,
+    period_start BIGINT NOT NULL,
+    claimed_at BIGINT NOT NULL,
+    completed_at BIGINT,
+    PRIMARY KEY (job, period_start)
+);
+
+INSERT INTO schema_version (version) VALUES (6) ON CONFLICT DO NOTHING;
+
+-- Channels users opted in to or out of per event type; event types use their configured
+-- default channels when a user has no row
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_type VARCHAR(100) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000),
+    PRIMARY KEY (user_id, event_type, channel)
+);
+
+-- Delivery of a notification event on one channel, retried until sent or dead-lettered
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id UUID PRIMARY KEY,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    user_id UUID NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000),
+    updated_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000),
+    UNIQUE (event_id, channel)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due
+    ON notification_deliveries(next_attempt_at)
+    WHERE status IN ('pending', 'failed');
+
+CREATE TRIGGER update_notification_deliveries_updated_at
+    BEFORE UPDATE ON notification_deliveries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO schema_version (version) VALUES (7) ON CONFLICT DO NOTHING;
+
+-- Daily login statistics scan logins by time across all users
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action_created_at ON audit_logs(action, created_at);
+
+INSERT INTO schema_version (version) VALUES (8) ON CONFLICT DO NOTHING;
+
+-- Users under legal hold are kept as they are; retention and deletion must skip them
+ALTER TABLE users ADD COLUMN IF NOT EXISTS legal_hold BOOLEAN NOT NULL DEFAULT FALSE;
+
+INSERT INTO schema_version (version) VALUES (9) ON CONFLICT DO NOTHING;
+
+-- Disposable email domains flagged by the risk signals, maintained alongside risk.disposable_email_domains
+CREATE TABLE IF NOT EXISTS disposable_email_domains (
+    domain VARCHAR(255) PRIMARY KEY,
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)
+);
+
+-- Registration IP reuse looks up other registrations from the same address
+CREATE INDEX IF NOT EXISTS idx_audit_logs_registration_ip ON audit_logs((metadata->>'ip_address'))
+    WHERE action = 'user.registered';
+
+INSERT INTO schema_version (version) VALUES (10) ON CONFLICT DO NOTHING;
+
+-- Organizations group staff accounts and may restrict them to corporate email domains
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    allowed_email_domains TEXT[] NOT NULL DEFAULT '{}',
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000),
+    updated_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)
+);
+
+CREATE TRIGGER update_organizations_updated_at
+    BEFORE UPDATE ON organizations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_users_organization_id ON users(organization_id);
+
+INSERT INTO schema_version (version) VALUES (11) ON CONFLICT DO NOTHING;
+
+-- Invited users are created without a password and must set one before logging in
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+CREATE TABLE IF NOT EXISTS password_setup_tokens (
+    user_id UUID PRIMARY KEY NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at BIGINT NOT NULL,
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO schema_version (version) VALUES (12) ON CONFLICT DO NOTHING;
+
+-- Roles are carried in access tokens; banned users cannot log in
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'customer';
+
+INSERT INTO schema_version (version) VALUES (13) ON CONFLICT DO NOTHING;
+
+-- Append-only history of every change to a user, the source the users projection can be rebuilt from
+CREATE TABLE IF NOT EXISTS user_events (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    version BIGINT NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    actor VARCHAR(255) NOT NULL,
+    occurred_at BIGINT NOT NULL,
+    UNIQUE (user_id, version)
+);
+
+CREATE OR REPLACE FUNCTION reject_user_event_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'user_events is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER user_events_append_only
+    BEFORE UPDATE OR DELETE ON user_events
+    FOR EACH ROW
+    EXECUTE FUNCTION reject_user_event_changes();
+
+INSERT INTO schema_version (version) VALUES (14) ON CONFLICT DO NOTHING;
+
+-- Durable timers of multi-step workflows, fired by the timer worker so pending steps survive restarts
+CREATE TABLE IF NOT EXISTS workflow_timers (
+    id UUID PRIMARY KEY NOT NULL,
+    workflow VARCHAR(100) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    fire_at BIGINT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at BIGINT NOT NULL,
+    UNIQUE (workflow, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_timers_fire_at ON workflow_timers(fire_at);
+
+INSERT INTO schema_version (version) VALUES (15) ON CONFLICT DO NOTHING;
+
+-- Fencing tokens of distributed locks, increased on every acquisition of a lock
+CREATE TABLE IF NOT EXISTS lock_fences (
+    name VARCHAR(255) PRIMARY KEY NOT NULL,
+    token BIGINT NOT NULL
+);
+
+INSERT INTO schema_version (version) VALUES (16) ON CONFLICT DO NOTHING;
+
+-- The JWT signing key promoted by an admin, so every replica signs with it until the
+-- configuration is rotated. A single row, identified by the key ID, never by the secret.
+CREATE TABLE IF NOT EXISTS jwt_signing_key (
+    id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    primary_key_id VARCHAR(16) NOT NULL,
+    promoted_at BIGINT NOT NULL
+);
+
+INSERT INTO schema_version (version) VALUES (17) ON CONFLICT DO NOTHING;
+
+-- Namespaced key/value metadata internal services attach to users, e.g. crm.segment
+ALTER TABLE users ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+
+INSERT INTO schema_version (version) VALUES (18) ON CONFLICT DO NOTHING;
+
+-- Object key of the user's avatar in the storage bucket, empty for none
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_key TEXT NOT NULL DEFAULT '';
+
+INSERT INTO schema_version (version) VALUES (19) ON CONFLICT DO NOTHING;
+
+-- Organizations isolated in their own schema, which holds a copy of the user tables and is
+-- migrated by migrate-tenants up to schema_version; see tenancy.mode
+CREATE TABLE IF NOT EXISTS tenant_schemas (
+    organization_id UUID PRIMARY KEY NOT NULL REFERENCES organizations(id),
+    schema_name VARCHAR(63) UNIQUE NOT NULL,
+    schema_version INT NOT NULL DEFAULT 0,
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)
+);
+
+INSERT INTO schema_version (version) VALUES (20) ON CONFLICT DO NOTHING;
+
+-- Notifies WatchUser streams of every committed user event as <user id>:<version>
+CREATE OR REPLACE FUNCTION notify_user_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('user_events', NEW.user_id::text || ':' || NEW.version);
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER user_events_notify
+    AFTER INSERT ON user_events
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_user_event();
+
+INSERT INTO schema_version (version) VALUES (21) ON CONFLICT DO NOTHING;
+
+-- Finds revoked refresh tokens by when they were revoked for CleanupRefreshTokens
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_revoked_updated_at ON refresh_tokens(updated_at) WHERE is_revoked = TRUE;
+
+INSERT INTO schema_version (version) VALUES (22) ON CONFLICT DO NOTHING;
+
+-- Push notification tokens of user devices, announced to notification-svc through the outbox
+CREATE TABLE IF NOT EXISTS push_tokens (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_id VARCHAR(128) NOT NULL,
+    platform VARCHAR(16) NOT NULL,
+    token VARCHAR(512) NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (user_id, device_id)
+);
+
+-- A token reaches one device, so registering it again moves it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_push_tokens_platform_token ON push_tokens(platform, token);
+
+INSERT INTO schema_version (version) VALUES (23) ON CONFLICT DO NOTHING;
+
+-- Time windows a user, or every user of a role, may log in and refresh tokens in
+CREATE TABLE IF NOT EXISTS login_schedules (
+    user_id UUID UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) UNIQUE,
+    timezone VARCHAR(64) NOT NULL,
+    windows JSONB NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CHECK ((user_id IS NULL) <> (role IS NULL))
+);
+
+INSERT INTO schema_version (version) VALUES (24) ON CONFLICT DO NOTHING;
+
+-- Emergency logouts of every user, recorded before any token is revoked
+CREATE TABLE IF NOT EXISTS global_logouts (
+    id UUID PRIMARY KEY NOT NULL,
+    admin VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    cutoff BIGINT NOT NULL,
+    refresh_tokens_revoked BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL,
+    completed_at BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO schema_version (version) VALUES (25) ON CONFLICT DO NOTHING;
+
+-- Claims embedded in the tokens of a client; empty uses jwt.claim_profile
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS claim_profile VARCHAR(16) NOT NULL DEFAULT ''
+    CHECK (claim_profile IN ('', 'minimal', 'standard', 'internal'));
+
+INSERT INTO schema_version (version) VALUES (26) ON CONFLICT DO NOTHING;
+
+-- Session a refresh token belongs to, kept on rotation; NULL for tokens issued before sessions
+-- were tracked, which start their own
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS session_id UUID;
+
+-- Refresh token usage per session, for anomaly detection, risk signals and session views
+CREATE TABLE IF NOT EXISTS refresh_sessions (
+    session_id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id VARCHAR(64) NOT NULL DEFAULT '',
+    started_at BIGINT NOT NULL,
+    refresh_count BIGINT NOT NULL DEFAULT 0,
+    last_used_at BIGINT NOT NULL,
+    last_ip VARCHAR(64) NOT NULL DEFAULT '',
+    last_country VARCHAR(2) NOT NULL DEFAULT '',
+    window_start BIGINT NOT NULL DEFAULT 0,
+    window_refreshes BIGINT NOT NULL DEFAULT 0,
+    anomaly_count BIGINT NOT NULL DEFAULT 0,
+    last_anomaly VARCHAR(32) NOT NULL DEFAULT '',
+    last_anomaly_at BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_sessions_user_id_last_anomaly_at ON refresh_sessions(user_id, last_anomaly_at);
+
+INSERT INTO schema_version (version) VALUES (27) ON CONFLICT DO NOTHING;
+
+-- Access tokens of the client are encrypted (JWE) with jwt.encryption_key
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS encrypt_access_tokens BOOLEAN NOT NULL DEFAULT FALSE;
+
+INSERT INTO schema_version (version) VALUES (28) ON CONFLICT DO NOTHING;
+
+-- One-time nonces of external callbacks, e.g. OAuth states and SAML assertion IDs, for the
+-- postgres nonce backend; expired rows are purged daily
+CREATE TABLE IF NOT EXISTS nonces (
+    scope VARCHAR(64) NOT NULL,
+    nonce VARCHAR(512) NOT NULL,
+    data TEXT NOT NULL DEFAULT '',
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (scope, nonce)
+);
+
+CREATE INDEX IF NOT EXISTS idx_nonces_expires_at ON nonces(expires_at);
+
+INSERT INTO schema_version (version) VALUES (29) ON CONFLICT DO NOTHING;
+
+-- Organization of the user when the audit entry was written, so an organization's audit
+-- trail keeps the entries of staff who left it. Entries written without one take the
+-- organization of the user, looked up on the search_path of the writing connection.
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_organization_id_created_at ON audit_logs(organization_id, created_at, id)
+    WHERE organization_id IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION set_audit_log_organization()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.organization_id IS NULL THEN
+        SELECT organization_id INTO NEW.organization_id FROM users WHERE id = NEW.user_id;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER audit_logs_set_organization
+    BEFORE INSERT ON audit_logs
+    FOR EACH ROW
+    EXECUTE FUNCTION set_audit_log_organization();
+
+-- Last security event delivered to the webhook of each organization, see org_audit.webhooks
+CREATE TABLE IF NOT EXISTS org_audit_webhook_cursors (
+    organization_id UUID PRIMARY KEY NOT NULL,
+    delivered_created_at BIGINT NOT NULL,
+    delivered_id UUID NOT NULL,
+    updated_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)
+);
+
+INSERT INTO schema_version (version) VALUES (30) ON CONFLICT DO NOTHING;
+
+-- Velocity rules of the abuse engine, managed at runtime through the admin RPCs
+CREATE TABLE IF NOT EXISTS velocity_rules (
+    name VARCHAR(64) PRIMARY KEY NOT NULL,
+    entity VARCHAR(16) NOT NULL CHECK (entity IN ('ip', 'email', 'device')),
+    action VARCHAR(16) NOT NULL CHECK (action IN ('register', 'login')),
+    threshold INTEGER NOT NULL CHECK (threshold > 0),
+    window_ms BIGINT NOT NULL CHECK (window_ms > 0),
+    response VARCHAR(16) NOT NULL CHECK (response IN ('alert', 'captcha', 'lock')),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000),
+    updated_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)
+);
+
+INSERT INTO schema_version (version) VALUES (31) ON CONFLICT DO NOTHING;
+
+-- Canary accounts, which raise an alert on any login or token use, see internal/app/canary
+CREATE TABLE IF NOT EXISTS canary_accounts (
+    user_id UUID PRIMARY KEY NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    tarpit BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at BIGINT DEFAULT (EXTRACT(EPOCH FROM NOW()) * 1000)
+);
+
+INSERT INTO schema_version (version) VALUES (32) ON CONFLICT DO NOTHING;
+
+-- Keyset pagination of ListUsers, ordered by (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users(created_at, id);
+
+INSERT INTO schema_version (version) VALUES (33) ON CONFLICT DO NOTHING;
+
+-- Branding of the emails sent to members of an organization, empty for the service's own
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS brand_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS brand_logo_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS brand_support_email VARCHAR(254) NOT NULL DEFAULT '';
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS brand_color VARCHAR(7) NOT NULL DEFAULT '';
+
+INSERT INTO schema_version (version) VALUES (34) ON CONFLICT DO NOTHING;
+
+-- Residency region of the data of users, tagged at registration, and of the members of organizations
+ALTER TABLE users ADD COLUMN IF NOT EXISTS residency VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS residency VARCHAR(16) NOT NULL DEFAULT '';
+
+INSERT INTO schema_version (version) VALUES (35) ON CONFLICT DO NOTHING;
+
+-- One-time codes verifying the email of a registration before its account is created, see
+-- registration.mode; an address has at most one code, replaced when a new one is emailed
+CREATE TABLE IF NOT EXISTS registration_codes (
+    email VARCHAR(255) PRIMARY KEY NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at BIGINT NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_registration_codes_expires_at ON registration_codes(expires_at);
+
+INSERT INTO schema_version (version) VALUES (36) ON CONFLICT DO NOTHING;
+
+-- Emails whose ownership a user proved when the account was created, with a registration code
+-- or an invitation; guest activity under the email, e.g. bookings, is claimed for the user once
+CREATE TABLE IF NOT EXISTS email_claims (
+    user_id UUID PRIMARY KEY NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    verified_by VARCHAR(32) NOT NULL,
+    verified_at BIGINT NOT NULL,
+    activity_claimed_at BIGINT,
+    activity_claimed_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+INSERT INTO schema_version (version) VALUES (37) ON CONFLICT DO NOTHING;
+
+-- Notes support staff leave on accounts, kept apart from the user row; disclosable notes are
+-- handed out on subject access requests, internal ones are not
+CREATE TABLE IF NOT EXISTS user_notes (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    visibility VARCHAR(16) NOT NULL DEFAULT 'internal' CHECK (visibility IN ('internal', 'disclosable')),
+    author VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_notes_user_id_created_at ON user_notes(user_id, created_at DESC, id DESC);
+
+INSERT INTO schema_version (version) VALUES (38) ON CONFLICT DO NOTHING;
+
+-- Users who forgot their password request a single use reset token by email; only its hash
+-- is stored, and a user has at most one
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    user_id UUID PRIMARY KEY NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at BIGINT NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+INSERT INTO schema_version (version) VALUES (39) ON CONFLICT DO NOTHING;
+
+-- Users created without verifying their email are emailed links verifying it; a new link
+-- voids the previous one, and sends counts the links of the 24 hours since window_started_at
+-- to cap them. Used tokens are cleared rather than deleted, so the counts survive them.
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    user_id UUID PRIMARY KEY NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE,
+    expires_at BIGINT NOT NULL,
+    created_at BIGINT NOT NULL,
+    sends INT NOT NULL DEFAULT 1,
+    window_started_at BIGINT NOT NULL
+);
+
+INSERT INTO schema_version (version) VALUES (40) ON CONFLICT DO NOTHING;
+
+-- The registration gates admins toggle during controlled rollouts, a single row, and the
+-- registrations counted against the daily cap per UTC day
+CREATE TABLE IF NOT EXISTS registration_gates (
+    id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    invite_code_required BOOLEAN NOT NULL DEFAULT FALSE,
+    invite_codes TEXT[] NOT NULL DEFAULT '{}',
+    daily_cap INT NOT NULL DEFAULT 0,
+    allowlist_only BOOLEAN NOT NULL DEFAULT FALSE,
+    allowlist TEXT[] NOT NULL DEFAULT '{}',
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS daily_signups (
+    day DATE PRIMARY KEY NOT NULL,
+    signups INT NOT NULL DEFAULT 0
+);
+
+INSERT INTO schema_version (version) VALUES (41) ON CONFLICT DO NOTHING;
+
+-- Invite codes users hand out to refer others, and who referred whom. Both stay shared like
+-- canary_accounts, without foreign keys to users, as a referee may register in another tenant
+-- schema or region than the referrer
+CREATE TABLE IF NOT EXISTS invite_codes (
+    code VARCHAR(64) PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    email_hash VARCHAR(64) NOT NULL,
+    max_redemptions INT NOT NULL,
+    redemptions INT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_invite_codes_user_id ON invite_codes(user_id);
+
+CREATE TABLE IF NOT EXISTS referrals (
+    referee_id UUID PRIMARY KEY NOT NULL,
+    referrer_id UUID NOT NULL,
+    code VARCHAR(64) NOT NULL REFERENCES invite_codes(code) ON DELETE CASCADE,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_referrals_referrer_id ON referrals(referrer_id);
+
+INSERT INTO schema_version (version) VALUES (42) ON CONFLICT DO NOTHING;
+
+-- Every password login attempt, for users and admins to review the recent activity of an
+-- account. Shared like audit_logs, without a foreign key to users, as attempts with the email
+-- of no user are recorded too; attempts older than login_history.retention are purged
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID,
+    success BOOLEAN NOT NULL,
+    failure_reason VARCHAR(255) NOT NULL DEFAULT '',
+    client_id VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_user_id_created_at ON login_attempts(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_created_at ON login_attempts(created_at);
+
+INSERT INTO schema_version (version) VALUES (43) ON CONFLICT DO NOTHING;
+
+-- Logins stamp last_login_at; the inactive_user_scan job sets inactive_since on customers
+-- who have not logged in for inactivity.after, once per absence, and a login clears it
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS inactive_since BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_users_last_seen_at ON users(GREATEST(last_login_at, created_at)) WHERE inactive_since = 0;
+
+INSERT INTO schema_version (version) VALUES (44) ON CONFLICT DO NOTHING;
+
+-- Sessions whose last use in refresh_sessions is older than the shortest of
+-- jwt.session_idle_timeout, the client's and the organization's idle timeout end on their
+-- next refresh; 0 sets no idle timeout
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS session_idle_timeout_ms BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS session_idle_timeout_ms BIGINT NOT NULL DEFAULT 0;
+
+INSERT INTO schema_version (version) VALUES (45) ON CONFLICT DO NOTHING;
+
+-- Identities at OpenID Connect providers users log in with, see LoginWithIDToken; subject is
+-- the provider's stable ID of the user, unique per provider
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider VARCHAR(64) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
+
+-- The web and mobile apps log in with ID tokens too
+UPDATE clients SET allowed_grant_types = array_append(allowed_grant_types, 'id_token')
+WHERE client_id IN ('web', 'mobile') AND NOT 'id_token' = ANY(allowed_grant_types);
+
+INSERT INTO schema_version (version) VALUES (46) ON CONFLICT DO NOTHING;
+
+-- Rotated and revoked refresh tokens stay until an admin cleanup deletes them, so the tokens
+-- of long-time users are mostly inactive; per-user reads such as ListAuthorizedClients only
+-- visit the active ones
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id_active ON refresh_tokens(user_id, expires_at) WHERE is_revoked = FALSE;
+
+INSERT INTO schema_version (version) VALUES (47) ON CONFLICT DO NOTHING;
+
+-- The region the data of the user with an email is stored in, kept in the home database only.
+-- Emails are unique per database, so registrations and imports in every region claim the
+-- email here first; logins and other lookups by email are routed by it.
+CREATE TABLE IF NOT EXISTS user_email_regions (
+    email VARCHAR(255) PRIMARY KEY,
+    user_id UUID NOT NULL,
+    region VARCHAR(16) NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL
+);
+
+-- Users registered before the directory; in the home database these are all users of the home
+-- region and of regions without a database of their own
+INSERT INTO user_email_regions (email, user_id, region, created_at)
+SELECT email, id, residency, created_at FROM users
+ON CONFLICT (email) DO NOTHING;
+
+INSERT INTO schema_version (version) VALUES (48) ON CONFLICT DO NOTHING;
+
+-- The fencing token of the lock a job run was claimed under, see locks.Lock.Token; a run is
+-- completed only under that token, and claimed again only under a later one
+ALTER TABLE scheduled_job_runs ADD COLUMN IF NOT EXISTS fence BIGINT NOT NULL DEFAULT 0;
+
+INSERT INTO schema_version (version) VALUES (49) ON CONFLICT DO NOTHING;
+
+-- Organizations are looked up by name, e.g. by the seed command to reuse those of earlier runs
+CREATE INDEX IF NOT EXISTS idx_organizations_name ON organizations(name);
+
+INSERT INTO schema_version (version) VALUES (50) ON CONFLICT DO NOTHING;
+
+-- Latest verification of an access token of the session through VerifyToken, recorded at most
+-- once per jwt.session_activity_interval; sessions idle out when both it and last_used_at are
+-- older than their idle timeout. 0 until a token of the session is verified.
+ALTER TABLE refresh_sessions ADD COLUMN IF NOT EXISTS last_active_at BIGINT NOT NULL DEFAULT 0;
+
+INSERT INTO schema_version (version) VALUES (51) ON CONFLICT DO NOTHING;
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"user-svc/internal/app/config"
+	logutils "user-svc/pkg/utils/log"
+
+	"github.com/lib/pq"
+)
+
+// NewListener opens a dedicated connection listening to the notification channel. It
+// reconnects on its own and sends a nil notification once reconnected.
+func NewListener(cfg *config.DatabaseConfig, channel string, logger *logutils.Logger) (*pq.Listener, error) {
+	listener := pq.NewListener(dataSourceName(cfg), time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.WithError(err).WithField("channel", channel).Warn("Database listener connection event")
+		}
+	})
+
+	if err := listener.Listen(channel); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to listen to %s: %w", channel, err)
+	}
+
+	return listener, nil
+}
@@ -0,0 +1,296 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/pkg/utils/metrics"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// LSN is a position in the write-ahead log of the primary, as written by pg_lsn: two
+// hexadecimal numbers separated by a slash, e.g. 16/B374D848
+type LSN uint64
+
+// ParseLSN parses a pg_lsn
+func ParseLSN(s string) (LSN, error) {
+	high, low, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, fmt.Errorf("invalid LSN %q", s)
+	}
+	hi, err := strconv.ParseUint(high, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid LSN %q: %w", s, err)
+	}
+	lo, err := strconv.ParseUint(low, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid LSN %q: %w", s, err)
+	}
+	return LSN(hi<<32 | lo), nil
+}
+
+func (l LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint64(l)>>32, uint64(l)&0xFFFFFFFF)
+}
+
+// lockingClause matches the row locks of a query, which a replica cannot take
+var lockingClause = regexp.MustCompile(`(?i)\bFOR\s+(NO\s+KEY\s+UPDATE|UPDATE|KEY\s+SHARE|SHARE)\b`)
+
+// isReadQuery reports whether a query only reads. Anything but a plain SELECT, e.g. an
+// UPDATE ... RETURNING run with SelectContext or a WITH that may modify data, is a write.
+func isReadQuery(query string) bool {
+	query = strings.TrimLeft(query, " \t\r\n(")
+	if len(query) < len("SELECT") || !strings.EqualFold(query[:len("SELECT")], "SELECT") {
+		return false
+	}
+	return !lockingClause.MatchString(query)
+}
+
+type consistencyContextKey struct{}
+
+// consistency is the read-your-writes state of a request
+type consistency struct {
+	// readOnly requests may read from the replica
+	readOnly bool
+	// primary forces the reads of the request to the primary
+	primary bool
+	// token is the position the replica must have replayed before serving reads
+	token LSN
+	// wrote is set by the first write, after which the request reads its writes from the primary
+	wrote atomic.Bool
+
+	once       sync.Once
+	useReplica bool
+}
+
+// ReplicaRouter is a Store sending the plain reads of read-only requests to a read replica
+// and everything else to the primary. A replica lags behind the primary, so mutating requests
+// get a consistency token, the primary's WAL position after their writes; a later request
+// carrying the token is served by the replica only once it has replayed that position, and
+// by the primary if it does not within the wait timeout. Requests without a consistency set
+// by WithConsistency, e.g. background jobs, and requests routed to a tenant schema always use
+// the primary. DB returns the primary pool.
+type ReplicaRouter struct {
+	primary      Store
+	replica      *sqlx.DB
+	waitTimeout  time.Duration
+	pollInterval time.Duration
+	// replayed is the last known replay position of the replica
+	replayed atomic.Uint64
+}
+
+// NewReplicaRouter connects to the replica of the configured database and routes between it
+// and the primary store
+func NewReplicaRouter(primary Store, cfg *config.DatabaseConfig) (*ReplicaRouter, error) {
+	replicaCfg := *cfg
+	replicaCfg.Host = cfg.Replica.Host
+	replicaCfg.Port = cfg.Replica.Port
+
+	replica, err := sqlx.Connect("postgres", dataSourceName(&replicaCfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replica database: %w", err)
+	}
+
+	return &ReplicaRouter{
+		primary:      primary,
+		replica:      replica,
+		waitTimeout:  cfg.Replica.WaitTimeout,
+		pollInterval: cfg.Replica.PollInterval,
+	}, nil
+}
+
+// WithConsistency sets up the read-your-writes consistency of a request. Reads of read-only
+// requests may be served by the replica once it has replayed token, "" for any position;
+// primary forces them to the primary. Writes of any request are tracked for ConsistencyToken.
+func (r *ReplicaRouter) WithConsistency(ctx context.Context, readOnly bool, token string, primary bool) (context.Context, error) {
+	c := &consistency{readOnly: readOnly, primary: primary}
+	if token != "" {
+		lsn, err := ParseLSN(token)
+		if err != nil {
+			return ctx, errs.ErrInvalidConsistencyToken.Wrap(err)
+		}
+		c.token = lsn
+	}
+	return context.WithValue(ctx, consistencyContextKey{}, c), nil
+}
+
+// ConsistencyToken returns the primary's current WAL position if the request wrote, "" if not
+func (r *ReplicaRouter) ConsistencyToken(ctx context.Context) (string, error) {
+	c, ok := ctx.Value(consistencyContextKey{}).(*consistency)
+	if !ok || !c.wrote.Load() {
+		return "", nil
+	}
+
+	var position string
+	if err := r.primary.GetContext(ctx, &position, `SELECT pg_current_wal_lsn()::text`); err != nil {
+		return "", fmt.Errorf("failed to get WAL position: %w", err)
+	}
+	return position, nil
+}
+
+// read reports whether a query of the request is served by the replica. Queries that write
+// are recorded, so the following reads of the request see them.
+func (r *ReplicaRouter) read(ctx context.Context, query string) bool {
+	if !isReadQuery(query) {
+		r.write(ctx)
+		return false
+	}
+
+	c, ok := ctx.Value(consistencyContextKey{}).(*consistency)
+	if !ok || !c.readOnly || c.primary || c.wrote.Load() || TenantFromContext(ctx) != "" {
+		return false
+	}
+
+	c.once.Do(func() {
+		c.useReplica = r.caughtUp(ctx, c.token)
+	})
+	return c.useReplica
+}
+
+// write records a write of the request
+func (r *ReplicaRouter) write(ctx context.Context) {
+	if c, ok := ctx.Value(consistencyContextKey{}).(*consistency); ok {
+		c.wrote.Store(true)
+	}
+}
+
+// caughtUp waits up to the wait timeout for the replica to replay token
+func (r *ReplicaRouter) caughtUp(ctx context.Context, token LSN) bool {
+	if LSN(r.replayed.Load()) >= token {
+		metrics.ReplicaReads.WithLabelValues("replica").Inc()
+		return true
+	}
+
+	deadline := time.NewTimer(r.waitTimeout)
+	defer deadline.Stop()
+	poll := time.NewTicker(r.pollInterval)
+	defer poll.Stop()
+
+	for {
+		replayed, err := r.replayPosition(ctx)
+		if err != nil {
+			metrics.ReplicaReads.WithLabelValues("primary_error").Inc()
+			return false
+		}
+		if replayed >= token {
+			metrics.ReplicaReads.WithLabelValues("replica").Inc()
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			metrics.ReplicaReads.WithLabelValues("primary_error").Inc()
+			return false
+		case <-deadline.C:
+			metrics.ReplicaReads.WithLabelValues("primary_lag").Inc()
+			return false
+		case <-poll.C:
+		}
+	}
+}
+
+// replayPosition asks the replica how far it has replayed and remembers the answer
+func (r *ReplicaRouter) replayPosition(ctx context.Context) (LSN, error) {
+	var position sql.NullString
+	if err := r.replica.GetContext(ctx, &position, `SELECT pg_last_wal_replay_lsn()::text`); err != nil {
+		return 0, fmt.Errorf("failed to get replay position: %w", err)
+	}
+	if !position.Valid {
+		return 0, errors.New("database is not a replica")
+	}
+
+	lsn, err := ParseLSN(position.String)
+	if err != nil {
+		return 0, err
+	}
+	for {
+		known := r.replayed.Load()
+		if uint64(lsn) <= known || r.replayed.CompareAndSwap(known, uint64(lsn)) {
+			return lsn, nil
+		}
+	}
+}
+
+// Close closes the replica and the primary store
+func (r *ReplicaRouter) Close() error {
+	return errors.Join(r.replica.Close(), r.primary.Close())
+}
+
+// DB returns the primary connection pool
+func (r *ReplicaRouter) DB() *sqlx.DB {
+	return r.primary.DB()
+}
+
+// QueryRowContext executes a query that returns a single row
+func (r *ReplicaRouter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if r.read(ctx, query) {
+		return r.replica.QueryRowContext(ctx, query, args...)
+	}
+	return r.primary.QueryRowContext(ctx, query, args...)
+}
+
+// QueryContext executes a query that returns multiple rows
+func (r *ReplicaRouter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if r.read(ctx, query) {
+		return r.replica.QueryContext(ctx, query, args...)
+	}
+	return r.primary.QueryContext(ctx, query, args...)
+}
+
+// ExecContext executes a query that doesn't return rows
+func (r *ReplicaRouter) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.write(ctx)
+	return r.primary.ExecContext(ctx, query, args...)
+}
+
+// BeginTx starts a new transaction on the primary
+func (r *ReplicaRouter) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	r.write(ctx)
+	return r.primary.BeginTx(ctx, opts)
+}
+
+// BeginTxx starts a new transaction, so the router can back a tx.TransactionManager
+func (r *ReplicaRouter) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return r.BeginTx(ctx, opts)
+}
+
+// GetContext executes a query that returns a single row and scans it into dest
+func (r *ReplicaRouter) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	if r.read(ctx, query) {
+		return r.replica.GetContext(ctx, dest, query, args...)
+	}
+	return r.primary.GetContext(ctx, dest, query, args...)
+}
+
+// SelectContext executes a query that returns multiple rows and scans them into dest
+func (r *ReplicaRouter) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	if r.read(ctx, query) {
+		return r.replica.SelectContext(ctx, dest, query, args...)
+	}
+	return r.primary.SelectContext(ctx, dest, query, args...)
+}
+
+// NamedExecContext executes a named query that doesn't return rows
+func (r *ReplicaRouter) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	r.write(ctx)
+	return r.primary.NamedExecContext(ctx, query, arg)
+}
+
+// NamedQueryContext executes a named query that returns rows
+func (r *ReplicaRouter) NamedQueryContext(ctx context.Context, query string, arg interface{}) (*sqlx.Rows, error) {
+	if r.read(ctx, query) {
+		return r.replica.NamedQueryContext(ctx, query, arg)
+	}
+	return r.primary.NamedQueryContext(ctx, query, arg)
+}
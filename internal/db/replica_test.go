@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseLSN(t *testing.T) {
+	lsn, err := ParseLSN("16/B374D848")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if lsn != LSN(0x16B374D848) {
+		t.Errorf("Expected %X, got %X", uint64(0x16B374D848), uint64(lsn))
+	}
+	if lsn.String() != "16/B374D848" {
+		t.Errorf("Expected 16/B374D848, got %s", lsn)
+	}
+
+	for _, invalid := range []string{"", "16", "16/", "G/1", "1/100000000"} {
+		if _, err := ParseLSN(invalid); err == nil {
+			t.Errorf("Expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestIsReadQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		read  bool
+	}{
+		{query: "\n\t\tSELECT id FROM users WHERE id = $1", read: true},
+		{query: "(SELECT 1) UNION (SELECT 2)", read: true},
+		{query: "select id from users", read: true},
+		{query: "SELECT id FROM users WHERE id = $1 FOR UPDATE"},
+		{query: "SELECT id FROM users FOR NO KEY UPDATE SKIP LOCKED"},
+		{query: "UPDATE users SET role = $1 RETURNING id"},
+		{query: "WITH previous AS (SELECT id FROM users) UPDATE users SET role = $1"},
+		{query: "INSERT INTO users (id) VALUES ($1)"},
+	}
+
+	for _, tt := range tests {
+		if got := isReadQuery(tt.query); got != tt.read {
+			t.Errorf("Expected %q read %v, got %v", tt.query, tt.read, got)
+		}
+	}
+}
+
+func TestReplicaRouter_Read(t *testing.T) {
+	router := &ReplicaRouter{}
+	const query = "SELECT id FROM users"
+
+	if router.read(context.Background(), query) {
+		t.Error("Expected requests without consistency to read from the primary")
+	}
+
+	ctx, _ := router.WithConsistency(context.Background(), false, "", false)
+	if router.read(ctx, query) {
+		t.Error("Expected mutating requests to read from the primary")
+	}
+
+	ctx, _ = router.WithConsistency(context.Background(), true, "", true)
+	if router.read(ctx, query) {
+		t.Error("Expected requests forced to the primary to read from it")
+	}
+
+	ctx, _ = router.WithConsistency(context.Background(), true, "", false)
+	if !router.read(ctx, query) {
+		t.Error("Expected read-only requests to read from the replica")
+	}
+	if router.read(ctx, "UPDATE users SET role = $1 RETURNING id") || router.read(ctx, query) {
+		t.Error("Expected a request to read its writes from the primary")
+	}
+	if token, err := router.ConsistencyToken(context.Background()); token != "" || err != nil {
+		t.Errorf("Expected no token without writes, got %q, %v", token, err)
+	}
+
+	// A replica known to have replayed the token serves the read without waiting
+	router.replayed.Store(0x16B374D848)
+	ctx, _ = router.WithConsistency(context.Background(), true, "16/B374D848", false)
+	if !router.read(ctx, query) {
+		t.Error("Expected a replica that caught up to serve the read")
+	}
+
+	if _, err := router.WithConsistency(context.Background(), true, "not-an-lsn", false); err == nil {
+		t.Error("Expected an invalid token to be refused")
+	}
+}
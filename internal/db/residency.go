@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type regionContextKey struct{}
+
+// RegionFromContext returns the residency region queries with the context run in, "" for the
+// home region
+func RegionFromContext(ctx context.Context) string {
+	region, _ := ctx.Value(regionContextKey{}).(string)
+	return region
+}
+
+// ResidencyRouter is a Store running the queries of users of a region with a database of its
+// own in that database, and everything else in the home store. The region is put on the
+// context by WithRegion. Regional databases hold the full schema, but only the repositories of
+// user data are built on the router, so organizations, clients and other shared data are read
+// from the home store; transactions run wholly in the region of the request. DB returns the
+// home pool.
+type ResidencyRouter struct {
+	home    Store
+	cfg     config.ResidencyConfig
+	regions map[string]Store
+}
+
+// NewResidencyRouter creates a router over the home store, connecting to the database of
+// every configured region
+func NewResidencyRouter(home Store, dbCfg *config.DatabaseConfig, cfg config.ResidencyConfig) (*ResidencyRouter, error) {
+	router := &ResidencyRouter{
+		home:    home,
+		cfg:     cfg,
+		regions: make(map[string]Store, len(cfg.Regions)),
+	}
+
+	for _, region := range cfg.Regions {
+		regionCfg := *dbCfg
+		regionCfg.Host, regionCfg.Port = region.Host, region.Port
+
+		store, err := NewStore(&regionCfg)
+		if err != nil {
+			router.closeRegions()
+			return nil, fmt.Errorf("failed to connect to the database of region %s: %w", region.Name, err)
+		}
+		router.regions[region.Name] = store
+	}
+
+	return router, nil
+}
+
+// WithRegion routes the queries made with the returned context to the database of the
+// region, whatever region ctx was routed to. The home region and "", users tagged before
+// residency was enabled, use the home store; unknown regions are refused.
+func (r *ResidencyRouter) WithRegion(ctx context.Context, region string) (context.Context, error) {
+	if region != "" && !r.cfg.IsRegion(region) {
+		return ctx, errs.ErrUnknownResidencyRegion.WithDetail("region", region)
+	}
+	return context.WithValue(ctx, regionContextKey{}, region), nil
+}
+
+// HasDatabase reports whether the data of users of the region is kept in a database other
+// than the home one
+func (r *ResidencyRouter) HasDatabase(region string) bool {
+	_, ok := r.regions[region]
+	return ok
+}
+
+// CheckSchemaVersions verifies that the database of every region is at SchemaVersion
+func (r *ResidencyRouter) CheckSchemaVersions(ctx context.Context) error {
+	for name, store := range r.regions {
+		if err := CheckSchemaVersion(ctx, store); err != nil {
+			return fmt.Errorf("region %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// store returns the store of the region on the context, or the home store
+func (r *ResidencyRouter) store(ctx context.Context) Store {
+	if store, ok := r.regions[RegionFromContext(ctx)]; ok {
+		return store
+	}
+	return r.home
+}
+
+func (r *ResidencyRouter) closeRegions() []error {
+	var errs []error
+	for name, store := range r.regions {
+		errs = append(errs, store.Close())
+		delete(r.regions, name)
+	}
+	return errs
+}
+
+// Close closes the regional stores and the home store
+func (r *ResidencyRouter) Close() error {
+	errs := r.closeRegions()
+	errs = append(errs, r.home.Close())
+
+	return errors.Join(errs...)
+}
+
+// DB returns the home connection pool
+func (r *ResidencyRouter) DB() *sqlx.DB {
+	return r.home.DB()
+}
+
+// QueryRowContext executes a query that returns a single row
+func (r *ResidencyRouter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.store(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// QueryContext executes a query that returns multiple rows
+func (r *ResidencyRouter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.store(ctx).QueryContext(ctx, query, args...)
+}
+
+// ExecContext executes a query that doesn't return rows
+func (r *ResidencyRouter) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.store(ctx).ExecContext(ctx, query, args...)
+}
+
+// BeginTx starts a new transaction
+func (r *ResidencyRouter) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return r.store(ctx).BeginTx(ctx, opts)
+}
+
+// BeginTxx starts a new transaction, so the router can back a tx.TransactionManager
+func (r *ResidencyRouter) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return r.BeginTx(ctx, opts)
+}
+
+// GetContext executes a query that returns a single row and scans it into dest
+func (r *ResidencyRouter) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return r.store(ctx).GetContext(ctx, dest, query, args...)
+}
+
+// SelectContext executes a query that returns multiple rows and scans them into dest
+func (r *ResidencyRouter) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return r.store(ctx).SelectContext(ctx, dest, query, args...)
+}
+
+// NamedExecContext executes a named query that doesn't return rows
+func (r *ResidencyRouter) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return r.store(ctx).NamedExecContext(ctx, query, arg)
+}
+
+// NamedQueryContext executes a named query that returns rows
+func (r *ResidencyRouter) NamedQueryContext(ctx context.Context, query string, arg interface{}) (*sqlx.Rows, error) {
+	return r.store(ctx).NamedQueryContext(ctx, query, arg)
+}
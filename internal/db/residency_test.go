@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"user-svc/internal/app/config"
+	"user-svc/internal/app/domains/errs"
+)
+
+func TestResidencyRouter_WithRegion(t *testing.T) {
+	router := &ResidencyRouter{cfg: config.ResidencyConfig{
+		HomeRegion: "us",
+		Regions:    []config.ResidencyRegionConfig{{Name: "eu", Countries: []string{"DE"}}},
+	}}
+
+	tests := []struct {
+		name     string
+		region   string
+		expected string
+		err      error
+	}{
+		{name: "regional", region: "eu", expected: "eu"},
+		{name: "home", region: "us", expected: "us"},
+		{name: "untagged", region: "", expected: ""},
+		{name: "unknown", region: "apac", expected: "eu", err: errs.ErrUnknownResidencyRegion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A region found later, e.g. in the email directory, replaces the one of the request
+			requestCtx := context.WithValue(context.Background(), regionContextKey{}, "eu")
+			ctx, err := router.WithRegion(requestCtx, tt.region)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected %v, got %v", tt.err, err)
+			}
+			if region := RegionFromContext(ctx); region != tt.expected {
+				t.Errorf("Expected region %q, got %q", tt.expected, region)
+			}
+		})
+	}
+}
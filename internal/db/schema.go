@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SchemaVersion is the schema version this build expects, see schema_version in init.sql
+const SchemaVersion = 51
+
+// CheckSchemaVersion verifies that the database schema is at least SchemaVersion
+func CheckSchemaVersion(ctx context.Context, store Store) error {
+	var version int
+	if err := store.GetContext(ctx, &version, `SELECT COALESCE(MAX(version), 0) FROM schema_version`); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if version < SchemaVersion {
+		return fmt.Errorf("database schema version %d is older than required version %d", version, SchemaVersion)
+	}
+
+	return nil
+}
+
+// CheckTenantSchemas verifies that every tenant schema has been migrated to SchemaVersion
+func CheckTenantSchemas(ctx context.Context, store Store) error {
+	var outdated []string
+	if err := store.SelectContext(ctx, &outdated,
+		`SELECT schema_name FROM tenant_schemas WHERE schema_version < $1 ORDER BY schema_name`, SchemaVersion); err != nil {
+		return fmt.Errorf("failed to read tenant schema versions: %w", err)
+	}
+
+	if len(outdated) > 0 {
+		return fmt.Errorf("tenant schemas %s are older than required version %d, run migrate-tenants",
+			strings.Join(outdated, ", "), SchemaVersion)
+	}
+
+	return nil
+}
+
+// WarmConnections opens up to n pooled connections so the first requests don't pay for dialing
+func WarmConnections(ctx context.Context, store Store, n int) error {
+	conns := make([]interface{ Close() error }, 0, n)
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		conn, err := store.DB().Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to open connection: %w", err)
+		}
+		conns = append(conns, conn)
+
+		if err := conn.PingContext(ctx); err != nil {
+			return fmt.Errorf("failed to ping connection: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -43,16 +43,7 @@ type store struct {
 
 // NewStore creates a new store
 func NewStore(cfg *config.DatabaseConfig) (Store, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host,
-		cfg.Port,
-		cfg.User,
-		cfg.Password,
-		cfg.DBName,
-		cfg.SSLMode,
-	)
-
-	db, err := sqlx.Connect("postgres", dsn)
+	db, err := sqlx.Connect("postgres", dataSourceName(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -65,6 +56,18 @@ func NewStore(cfg *config.DatabaseConfig) (Store, error) {
 	return &store{db: db}, nil
 }
 
+// dataSourceName is the connection string of the configured database
+func dataSourceName(cfg *config.DatabaseConfig) string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.DBName,
+		cfg.SSLMode,
+	)
+}
+
 // Close closes the database connection
 func (d *store) Close() error {
 	return d.db.Close()
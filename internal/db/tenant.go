@@ -0,0 +1,238 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"user-svc/internal/app/config"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// TenantTables are copied into the schema of every isolated organization. All other tables
+// stay shared in the public schema, where tenant connections find them on their search_path.
+var TenantTables = []string{
+	"users",
+	"refresh_tokens",
+	"password_setup_tokens",
+	"password_reset_tokens",
+	"notification_preferences",
+	"user_events",
+	"push_tokens",
+	"refresh_sessions",
+	"email_claims",
+	"email_verification_tokens",
+	"user_notes",
+}
+
+// maxCachedTenants bounds the memory used by the organization schema cache
+const maxCachedTenants = 10000
+
+// TenantSchemaName names the schema of an organization
+func TenantSchemaName(prefix string, organizationID uuid.UUID) string {
+	return prefix + strings.ReplaceAll(organizationID.String(), "-", "")
+}
+
+type tenantContextKey struct{}
+
+// tenant is the schema a request is routed to, along with the pool of its connections
+type tenant struct {
+	schema string
+	db     *sqlx.DB
+}
+
+// TenantFromContext returns the schema queries with the context run in, "" for the shared schema
+func TenantFromContext(ctx context.Context) string {
+	if t, ok := ctx.Value(tenantContextKey{}).(*tenant); ok {
+		return t.schema
+	}
+	return ""
+}
+
+type cachedSchema struct {
+	schema    string
+	expiresAt time.Time
+}
+
+// TenantRouter is a Store running queries in the schema of the organization put on the
+// context by WithTenant, and in the shared schema otherwise. Every tenant schema has its own
+// connection pool with the schema first on the search_path, so a connection never runs the
+// queries of another tenant. DB returns the shared pool.
+type TenantRouter struct {
+	shared       Store
+	dsn          string
+	maxOpenConns int
+	cacheTTL     time.Duration
+
+	mu      sync.Mutex
+	pools   map[string]*sqlx.DB
+	schemas map[uuid.UUID]cachedSchema
+}
+
+// NewTenantRouter creates a router over the shared store, opening tenant pools on first use
+func NewTenantRouter(shared Store, dbCfg *config.DatabaseConfig, cfg config.TenancyConfig) *TenantRouter {
+	return &TenantRouter{
+		shared:       shared,
+		dsn:          dataSourceName(dbCfg),
+		maxOpenConns: cfg.MaxOpenConnsPerSchema,
+		cacheTTL:     cfg.CacheTTL,
+		pools:        make(map[string]*sqlx.DB),
+		schemas:      make(map[uuid.UUID]cachedSchema),
+	}
+}
+
+// WithTenant routes the queries made with the returned context to the schema of the
+// organization. Organizations without a schema of their own stay in the shared schema.
+func (r *TenantRouter) WithTenant(ctx context.Context, organizationID uuid.UUID) (context.Context, error) {
+	schema, err := r.schemaOf(ctx, organizationID)
+	if err != nil {
+		return ctx, err
+	}
+	if schema == "" {
+		return ctx, nil
+	}
+
+	pool, err := r.pool(schema)
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, tenantContextKey{}, &tenant{schema: schema, db: pool}), nil
+}
+
+// schemaOf looks up the schema registered for an organization, "" for none
+func (r *TenantRouter) schemaOf(ctx context.Context, organizationID uuid.UUID) (string, error) {
+	r.mu.Lock()
+	cached, ok := r.schemas[organizationID]
+	r.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.schema, nil
+	}
+
+	var schema string
+	err := r.shared.GetContext(ctx, &schema,
+		`SELECT schema_name FROM tenant_schemas WHERE organization_id = $1`, organizationID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("failed to look up tenant schema: %w", err)
+	}
+
+	r.mu.Lock()
+	if len(r.schemas) >= maxCachedTenants {
+		r.purgeExpired()
+	}
+	if len(r.schemas) < maxCachedTenants {
+		r.schemas[organizationID] = cachedSchema{schema: schema, expiresAt: time.Now().Add(r.cacheTTL)}
+	}
+	r.mu.Unlock()
+
+	return schema, nil
+}
+
+// purgeExpired drops expired schema lookups. The caller must hold the lock.
+func (r *TenantRouter) purgeExpired() {
+	now := time.Now()
+	for id, cached := range r.schemas {
+		if now.After(cached.expiresAt) {
+			delete(r.schemas, id)
+		}
+	}
+}
+
+// pool returns the connection pool of a tenant schema, opening it on first use
+func (r *TenantRouter) pool(schema string) (*sqlx.DB, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if pool, ok := r.pools[schema]; ok {
+		return pool, nil
+	}
+
+	// Unknown connection parameters are sent to the server as run-time settings
+	pool, err := sqlx.Open("postgres", r.dsn+" search_path="+schema+",public")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tenant database: %w", err)
+	}
+	pool.SetMaxOpenConns(r.maxOpenConns)
+	pool.SetMaxIdleConns(r.maxOpenConns)
+	r.pools[schema] = pool
+
+	return pool, nil
+}
+
+// db returns the pool of the tenant on the context, or the shared pool
+func (r *TenantRouter) db(ctx context.Context) *sqlx.DB {
+	if t, ok := ctx.Value(tenantContextKey{}).(*tenant); ok {
+		return t.db
+	}
+	return r.shared.DB()
+}
+
+// Close closes the tenant pools and the shared store
+func (r *TenantRouter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for schema, pool := range r.pools {
+		errs = append(errs, pool.Close())
+		delete(r.pools, schema)
+	}
+	errs = append(errs, r.shared.Close())
+
+	return errors.Join(errs...)
+}
+
+// DB returns the shared connection pool
+func (r *TenantRouter) DB() *sqlx.DB {
+	return r.shared.DB()
+}
+
+// QueryRowContext executes a query that returns a single row
+func (r *TenantRouter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.db(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// QueryContext executes a query that returns multiple rows
+func (r *TenantRouter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.db(ctx).QueryContext(ctx, query, args...)
+}
+
+// ExecContext executes a query that doesn't return rows
+func (r *TenantRouter) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.db(ctx).ExecContext(ctx, query, args...)
+}
+
+// BeginTx starts a new transaction
+func (r *TenantRouter) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return r.db(ctx).BeginTxx(ctx, opts)
+}
+
+// BeginTxx starts a new transaction, so the router can back a tx.TransactionManager
+func (r *TenantRouter) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return r.db(ctx).BeginTxx(ctx, opts)
+}
+
+// GetContext executes a query that returns a single row and scans it into dest
+func (r *TenantRouter) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return r.db(ctx).GetContext(ctx, dest, query, args...)
+}
+
+// SelectContext executes a query that returns multiple rows and scans them into dest
+func (r *TenantRouter) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return r.db(ctx).SelectContext(ctx, dest, query, args...)
+}
+
+// NamedExecContext executes a named query that doesn't return rows
+func (r *TenantRouter) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return r.db(ctx).NamedExecContext(ctx, query, arg)
+}
+
+// NamedQueryContext executes a named query that returns rows
+func (r *TenantRouter) NamedQueryContext(ctx context.Context, query string, arg interface{}) (*sqlx.Rows, error) {
+	return r.db(ctx).NamedQueryContext(ctx, query, arg)
+}
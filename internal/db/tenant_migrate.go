@@ -0,0 +1,340 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ErrTenantHasSharedUsers is returned when provisioning an organization whose users are
+// in the shared schema, where they would no longer be found
+var ErrTenantHasSharedUsers = errors.New("organization has users in the shared schema")
+
+// TenantMigrator creates and migrates tenant schemas after init.sql has migrated the public
+// schema. Tenant tables follow their public counterparts: missing tables are created like
+// them, and missing columns, indexes, foreign keys and triggers are added. Nothing is ever
+// dropped or altered, so such changes must be applied to every tenant schema by hand.
+type TenantMigrator struct {
+	db     *sqlx.DB
+	prefix string
+}
+
+// NewTenantMigrator creates a migrator naming new schemas with the prefix
+func NewTenantMigrator(db *sqlx.DB, prefix string) *TenantMigrator {
+	return &TenantMigrator{db: db, prefix: prefix}
+}
+
+// Provision creates and registers the schema of an organization and returns the statements
+// run, which are rolled back on a dry run
+func (m *TenantMigrator) Provision(ctx context.Context, organizationID uuid.UUID, dryRun bool) (string, []string, error) {
+	schema := TenantSchemaName(m.prefix, organizationID)
+
+	var statements []string
+	err := m.inTx(ctx, dryRun, func(tx *sqlx.Tx) error {
+		var hasUsers bool
+		if err := tx.GetContext(ctx, &hasUsers,
+			`SELECT EXISTS (SELECT 1 FROM public.users WHERE organization_id = $1)`, organizationID); err != nil {
+			return fmt.Errorf("failed to check organization users: %w", err)
+		}
+		if hasUsers {
+			return ErrTenantHasSharedUsers
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO public.tenant_schemas (organization_id, schema_name) VALUES ($1, $2)`,
+			organizationID, schema); err != nil {
+			return fmt.Errorf("failed to register schema: %w", err)
+		}
+
+		create := "CREATE SCHEMA " + pq.QuoteIdentifier(schema)
+		if _, err := tx.ExecContext(ctx, create); err != nil {
+			return fmt.Errorf("failed to create schema: %w", err)
+		}
+
+		migrated, err := migrateTenant(ctx, tx, schema)
+		statements = append([]string{create}, migrated...)
+		return err
+	})
+
+	return schema, statements, err
+}
+
+// Migrate brings a registered tenant schema up to the public schema and SchemaVersion, and
+// returns the statements run, which are rolled back on a dry run
+func (m *TenantMigrator) Migrate(ctx context.Context, schema string, dryRun bool) ([]string, error) {
+	var statements []string
+	err := m.inTx(ctx, dryRun, func(tx *sqlx.Tx) error {
+		// Locking the registration serializes concurrent migrations of the schema
+		var locked string
+		if err := tx.GetContext(ctx, &locked,
+			`SELECT schema_name FROM public.tenant_schemas WHERE schema_name = $1 FOR UPDATE`, schema); err != nil {
+			return fmt.Errorf("failed to lock schema registration: %w", err)
+		}
+
+		var err error
+		statements, err = migrateTenant(ctx, tx, schema)
+		return err
+	})
+
+	return statements, err
+}
+
+// Schemas lists the registered tenant schemas
+func (m *TenantMigrator) Schemas(ctx context.Context) ([]string, error) {
+	var schemas []string
+	if err := m.db.SelectContext(ctx, &schemas, `SELECT schema_name FROM public.tenant_schemas ORDER BY schema_name`); err != nil {
+		return nil, fmt.Errorf("failed to list tenant schemas: %w", err)
+	}
+	return schemas, nil
+}
+
+// inTx runs fn in a transaction, which is rolled back on a dry run
+func (m *TenantMigrator) inTx(ctx context.Context, dryRun bool, fn func(*sqlx.Tx) error) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if dryRun {
+		return nil
+	}
+	return tx.Commit()
+}
+
+// migrateTenant adds the missing objects to the schema and records it at SchemaVersion
+func migrateTenant(ctx context.Context, tx *sqlx.Tx, schema string) ([]string, error) {
+	public, err := readTenantTables(ctx, tx, "public")
+	if err != nil {
+		return nil, err
+	}
+	current, err := readTenantTables(ctx, tx, schema)
+	if err != nil {
+		return nil, err
+	}
+	statements := planTenantMigration(schema, public, current)
+
+	// Unqualified references, e.g. of foreign keys, resolve to tenant tables first
+	if _, err := tx.ExecContext(ctx, "SET LOCAL search_path TO "+pq.QuoteIdentifier(schema)+", public"); err != nil {
+		return nil, fmt.Errorf("failed to set search path: %w", err)
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return statements, fmt.Errorf("failed to run %q: %w", statement, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE public.tenant_schemas SET schema_version = $1 WHERE schema_name = $2`,
+		SchemaVersion, schema); err != nil {
+		return statements, fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return statements, nil
+}
+
+// tenantTable describes a table as far as tenant migrations copy it
+type tenantTable struct {
+	columns     []tenantColumn
+	indexes     map[string]string
+	foreignKeys map[string]string
+	triggers    map[string]string
+}
+
+type tenantColumn struct {
+	Table   string  `db:"table_name"`
+	Name    string  `db:"name"`
+	Type    string  `db:"type"`
+	NotNull bool    `db:"not_null"`
+	Default *string `db:"default_expr"`
+}
+
+type tenantObject struct {
+	Table      string `db:"table_name"`
+	Name       string `db:"name"`
+	Definition string `db:"definition"`
+}
+
+// readTenantTables reads the tenant tables of a schema from the catalog; missing tables are left out
+func readTenantTables(ctx context.Context, q sqlx.QueryerContext, schema string) (map[string]*tenantTable, error) {
+	tables := pq.Array(TenantTables)
+
+	var columns []tenantColumn
+	if err := sqlx.SelectContext(ctx, q, &columns, `
+		SELECT c.relname AS table_name, a.attname AS name, format_type(a.atttypid, a.atttypmod) AS type,
+			a.attnotnull AS not_null, pg_get_expr(d.adbin, d.adrelid) AS default_expr
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = $1 AND c.relname = ANY($2) AND c.relkind = 'r' AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY c.relname, a.attnum`, schema, tables); err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", schema, err)
+	}
+
+	result := make(map[string]*tenantTable)
+	for _, column := range columns {
+		table, ok := result[column.Table]
+		if !ok {
+			table = &tenantTable{
+				indexes:     make(map[string]string),
+				foreignKeys: make(map[string]string),
+				triggers:    make(map[string]string),
+			}
+			result[column.Table] = table
+		}
+		table.columns = append(table.columns, column)
+	}
+
+	objects := []struct {
+		query string
+		kind  func(*tenantTable) map[string]string
+	}{
+		{`SELECT tablename AS table_name, indexname AS name, indexdef AS definition
+			FROM pg_indexes WHERE schemaname = $1 AND tablename = ANY($2)`,
+			func(t *tenantTable) map[string]string { return t.indexes }},
+		{`SELECT c.relname AS table_name, con.conname AS name, pg_get_constraintdef(con.oid) AS definition
+			FROM pg_constraint con
+			JOIN pg_class c ON c.oid = con.conrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = $1 AND c.relname = ANY($2) AND con.contype = 'f'`,
+			func(t *tenantTable) map[string]string { return t.foreignKeys }},
+		{`SELECT c.relname AS table_name, t.tgname AS name, pg_get_triggerdef(t.oid) AS definition
+			FROM pg_trigger t
+			JOIN pg_class c ON c.oid = t.tgrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = $1 AND c.relname = ANY($2) AND NOT t.tgisinternal`,
+			func(t *tenantTable) map[string]string { return t.triggers }},
+	}
+	for _, kind := range objects {
+		var rows []tenantObject
+		if err := sqlx.SelectContext(ctx, q, &rows, kind.query, schema, tables); err != nil {
+			return nil, fmt.Errorf("failed to read catalog of %s: %w", schema, err)
+		}
+		for _, row := range rows {
+			if table, ok := result[row.Table]; ok {
+				kind.kind(table)[row.Name] = row.Definition
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// planTenantMigration lists the statements adding the public objects missing from a tenant
+// schema. Tables come first, so foreign keys can reference tables created by the same plan.
+func planTenantMigration(schema string, public, tenant map[string]*tenantTable) []string {
+	var statements, constraints []string
+	for _, name := range TenantTables {
+		source, ok := public[name]
+		if !ok {
+			continue
+		}
+		qualified := pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(name)
+
+		target, exists := tenant[name]
+		if !exists {
+			// Copies columns, defaults, checks and indexes, but no foreign keys or triggers
+			statements = append(statements, fmt.Sprintf("CREATE TABLE %s (LIKE public.%s INCLUDING ALL)",
+				qualified, pq.QuoteIdentifier(name)))
+			target = &tenantTable{}
+		} else {
+			statements = append(statements, addedColumns(qualified, source.columns, target.columns)...)
+			for _, index := range missingIndexes(source.indexes, target.indexes) {
+				statements = append(statements, retarget(source.indexes[index], name, qualified))
+			}
+		}
+
+		for _, key := range missing(source.foreignKeys, target.foreignKeys) {
+			// Unqualified, the referenced table resolves to the tenant schema first
+			definition := strings.ReplaceAll(source.foreignKeys[key], "REFERENCES public.", "REFERENCES ")
+			constraints = append(constraints, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s",
+				qualified, pq.QuoteIdentifier(key), definition))
+		}
+		for _, trigger := range missing(source.triggers, target.triggers) {
+			constraints = append(constraints, retarget(source.triggers[trigger], name, qualified))
+		}
+	}
+
+	return append(statements, constraints...)
+}
+
+// addedColumns adds the source columns missing from the target table
+func addedColumns(qualified string, source, target []tenantColumn) []string {
+	existing := make(map[string]struct{}, len(target))
+	for _, column := range target {
+		existing[column.Name] = struct{}{}
+	}
+
+	var statements []string
+	for _, column := range source {
+		if _, ok := existing[column.Name]; ok {
+			continue
+		}
+		statement := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", qualified, pq.QuoteIdentifier(column.Name), column.Type)
+		if column.Default != nil {
+			statement += " DEFAULT " + *column.Default
+		}
+		if column.NotNull {
+			statement += " NOT NULL"
+		}
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+// missing returns the sorted names of source objects the target lacks
+func missing(source, target map[string]string) []string {
+	var names []string
+	for name := range source {
+		if _, ok := target[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// missingIndexes returns the sorted names of source indexes the target has no equivalent of.
+// Indexes are compared by their definition, as tables created LIKE another name their indexes
+// after the columns instead of after the original names.
+func missingIndexes(source, target map[string]string) []string {
+	shapes := make(map[string]struct{}, len(target))
+	for _, definition := range target {
+		shapes[indexShape(definition)] = struct{}{}
+	}
+
+	var names []string
+	for name, definition := range source {
+		if _, ok := shapes[indexShape(definition)]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// indexShape is an index definition without its name and table
+func indexShape(definition string) string {
+	_, method, _ := strings.Cut(definition, " USING ")
+	return fmt.Sprint(strings.HasPrefix(definition, "CREATE UNIQUE "), method)
+}
+
+// retarget moves an index or trigger definition from the public table to the tenant table
+func retarget(definition, table, qualified string) string {
+	for _, name := range []string{"public." + table, table} {
+		if strings.Contains(definition, " ON "+name+" ") {
+			return strings.Replace(definition, " ON "+name+" ", " ON "+qualified+" ", 1)
+		}
+	}
+	return definition
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTenantSchemaName(t *testing.T) {
+	id := uuid.MustParse("0b6a7c1e-4f3d-4a8b-9c2d-1e5f6a7b8c9d")
+
+	if name := TenantSchemaName("tenant_", id); name != "tenant_0b6a7c1e4f3d4a8b9c2d1e5f6a7b8c9d" {
+		t.Errorf("Expected tenant_0b6a7c1e4f3d4a8b9c2d1e5f6a7b8c9d, got %s", name)
+	}
+}
+
+func publicTenantTables() map[string]*tenantTable {
+	emptyMetadata := "'{}'::jsonb"
+	return map[string]*tenantTable{
+		"users": {
+			columns: []tenantColumn{
+				{Name: "id", Type: "uuid", NotNull: true},
+				{Name: "email", Type: "character varying(255)", NotNull: true},
+				{Name: "metadata", Type: "jsonb", NotNull: true, Default: &emptyMetadata},
+			},
+			indexes: map[string]string{
+				"users_pkey":                "CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)",
+				"idx_users_organization_id": "CREATE INDEX idx_users_organization_id ON public.users USING btree (organization_id)",
+			},
+			foreignKeys: map[string]string{
+				"users_organization_id_fkey": "FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE SET NULL",
+			},
+			triggers: map[string]string{
+				"update_users_updated_at": "CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON public.users FOR EACH ROW EXECUTE FUNCTION update_updated_at_column()",
+			},
+		},
+		"refresh_tokens": {
+			columns: []tenantColumn{{Name: "id", Type: "uuid", NotNull: true}},
+			foreignKeys: map[string]string{
+				"refresh_tokens_user_id_fkey": "FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE",
+			},
+		},
+	}
+}
+
+func TestPlanTenantMigration_NewSchema(t *testing.T) {
+	statements := planTenantMigration("tenant_a", publicTenantTables(), nil)
+
+	expected := []string{
+		`CREATE TABLE "tenant_a"."users" (LIKE public."users" INCLUDING ALL)`,
+		`CREATE TABLE "tenant_a"."refresh_tokens" (LIKE public."refresh_tokens" INCLUDING ALL)`,
+		`ALTER TABLE "tenant_a"."users" ADD CONSTRAINT "users_organization_id_fkey" FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE SET NULL`,
+		`CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON "tenant_a"."users" FOR EACH ROW EXECUTE FUNCTION update_updated_at_column()`,
+		`ALTER TABLE "tenant_a"."refresh_tokens" ADD CONSTRAINT "refresh_tokens_user_id_fkey" FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE`,
+	}
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected %q, got %q", expected, statements)
+	}
+}
+
+func TestPlanTenantMigration_AddsMissingObjects(t *testing.T) {
+	public := publicTenantTables()
+	tenant := map[string]*tenantTable{
+		"users": {
+			columns: public["users"].columns[:2],
+			// Created LIKE the public table, so its indexes are named after the columns
+			indexes: map[string]string{
+				"users_pkey":                 "CREATE UNIQUE INDEX users_pkey ON tenant_a.users USING btree (id)",
+				"users_organization_id_idx1": "CREATE INDEX users_organization_id_idx1 ON tenant_a.users USING btree (organization_id)",
+			},
+			foreignKeys: public["users"].foreignKeys,
+			triggers:    public["users"].triggers,
+		},
+		"refresh_tokens": {
+			columns:     public["refresh_tokens"].columns,
+			foreignKeys: public["refresh_tokens"].foreignKeys,
+		},
+	}
+	public["users"].indexes["idx_users_email_lower"] = "CREATE INDEX idx_users_email_lower ON public.users USING btree (lower((email)::text))"
+
+	statements := planTenantMigration("tenant_a", public, tenant)
+
+	expected := []string{
+		`ALTER TABLE "tenant_a"."users" ADD COLUMN "metadata" jsonb DEFAULT '{}'::jsonb NOT NULL`,
+		`CREATE INDEX idx_users_email_lower ON "tenant_a"."users" USING btree (lower((email)::text))`,
+	}
+	if !slices.Equal(statements, expected) {
+		t.Errorf("Expected %q, got %q", expected, statements)
+	}
+}
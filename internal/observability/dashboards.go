@@ -0,0 +1,345 @@
+// Package observability generates the Grafana dashboards of the service from the metrics it
+// exposes, so every environment gets the same dashboards. The generated JSON is checked in
+// under dashboards/ and provisioned from there; run make dashboards after changing a
+// dashboard or a metric it queries.
+package observability
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"user-svc/pkg/utils/slo"
+
+	"google.golang.org/grpc/codes"
+)
+
+// schemaVersion is the Grafana dashboard schema the JSON is written for
+const schemaVersion = 39
+
+// rateInterval is the range of rate queries, picked by Grafana from the scrape interval
+const rateInterval = "$__rate_interval"
+
+var prometheusDatasource = &Datasource{Type: "prometheus", UID: "${datasource}"}
+
+// Dashboards returns the dashboards of the service
+func Dashboards() []Dashboard {
+	return []Dashboard{
+		redDashboard(),
+		authFunnelDashboard(),
+		tokenIssuanceDashboard(),
+	}
+}
+
+// Render returns the JSON of a dashboard as written to its file
+func Render(dashboard Dashboard) ([]byte, error) {
+	data, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render dashboard %s: %w", dashboard.UID, err)
+	}
+	return append(data, '\n'), nil
+}
+
+// FileName returns the name of the file of a dashboard
+func FileName(dashboard Dashboard) string {
+	return dashboard.UID + ".json"
+}
+
+// Write renders every dashboard into dir
+func Write(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create dashboard directory: %w", err)
+	}
+	for _, dashboard := range Dashboards() {
+		data, err := Render(dashboard)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, FileName(dashboard)), data, 0o644); err != nil {
+			return fmt.Errorf("failed to write dashboard %s: %w", dashboard.UID, err)
+		}
+	}
+	return nil
+}
+
+// redDashboard shows rate, errors and duration of every method, with the trace exemplars of
+// the latency histograms
+func redDashboard() Dashboard {
+	methods := `method=~"$method"`
+	serverErrors := methods + `, code=~"` + serverErrorCodes() + `"`
+
+	var b layout
+	b.row("Overview", "")
+	b.add(12, timeseries("Request rate", "reqps",
+		query(fmt.Sprintf(`sum by (method) (rate(user_svc_grpc_requests_total{%s}[%s]))`, methods, rateInterval), "{{method}}"),
+	))
+	b.add(12, timeseries("Server error ratio", "percentunit",
+		query(ratio("method", "user_svc_grpc_requests_total", serverErrors, methods), "{{method}}"),
+	))
+
+	b.row("Admission queue", "")
+	b.add(12, timeseries("Queue wait p99", "s",
+		exemplars(query(quantile(0.99, "user_svc_admission_wait_seconds", "method", methods), "{{method}}")),
+	))
+	b.add(12, timeseries("Queue rejections", "reqps",
+		query(fmt.Sprintf(`sum by (method, reason) (rate(user_svc_admission_rejected_total{%s}[%s]))`, methods, rateInterval), "{{method}} ({{reason}})"),
+	))
+
+	// Repeated rows go last since Grafana moves the panels below them
+	b.row("$method", "method")
+	b.add(8, timeseries("Rate by code", "reqps",
+		query(fmt.Sprintf(`sum by (code) (rate(user_svc_grpc_requests_total{%s}[%s]))`, methods, rateInterval), "{{code}}"),
+	))
+	b.add(8, timeseries("Server errors", "percentunit",
+		query(ratio("", "user_svc_grpc_requests_total", serverErrors, methods), "errors"),
+	))
+	b.add(8, timeseries("Duration", "s",
+		exemplars(query(quantile(0.5, "user_svc_grpc_request_duration_seconds", "", methods), "p50")),
+		exemplars(query(quantile(0.95, "user_svc_grpc_request_duration_seconds", "", methods), "p95")),
+		exemplars(query(quantile(0.99, "user_svc_grpc_request_duration_seconds", "", methods), "p99")),
+	))
+
+	return Dashboard{
+		UID:         "user-svc-red",
+		Title:       "User Service / RED",
+		Description: "Rate, errors and duration per gRPC method. Latency points link to the trace of a request through its exemplar.",
+		Tags:        []string{"user-svc", "red"},
+		Templating: Templating{List: []Variable{
+			datasourceVariable(),
+			queryVariable("method", "Method", "label_values(user_svc_grpc_requests_total, method)"),
+		}},
+		Panels: b.panels,
+	}.withDefaults()
+}
+
+// authFunnelDashboard follows logins from the attempt to the refreshed session and shows
+// what stops them
+func authFunnelDashboard() Dashboard {
+	login := `method=~".*UserService/Login"`
+	register := `method=~".*UserService/Register"`
+	refresh := `method=~".*UserService/RefreshToken"`
+
+	var b layout
+	b.row("Login funnel", "")
+	b.add(12, Panel{
+		Type:        "bargauge",
+		Title:       "Login funnel",
+		Description: "Logins over the time range, from attempts to sessions refreshed later on.",
+		Targets: []Target{
+			instant(query(fmt.Sprintf(`sum(increase(user_svc_grpc_requests_total{%s}[$__range]))`, login), "Attempts")),
+			instant(query(`sum(increase(user_svc_auth_password_verifications_total[$__range]))`, "Passwords checked")),
+			instant(query(fmt.Sprintf(`sum(increase(user_svc_grpc_requests_total{%s, code="OK"}[$__range]))`, login), "Succeeded")),
+			instant(query(fmt.Sprintf(`sum(increase(user_svc_grpc_requests_total{%s, code="OK"}[$__range]))`, refresh), "Sessions refreshed")),
+		},
+		FieldConfig: &FieldConfig{Defaults: FieldDefaults{Unit: "short"}},
+		Options: &Options{
+			Orientation:   "horizontal",
+			DisplayMode:   "gradient",
+			ReduceOptions: ReduceOptions{Calcs: []string{"lastNotNull"}},
+		},
+	})
+	b.add(12, timeseries("Login success ratio", "percentunit",
+		query(ratio("", "user_svc_grpc_requests_total", login+`, code="OK"`, login), "success"),
+	))
+
+	b.row("Failures", "")
+	b.add(12, timeseries("Login failures by code", "reqps",
+		query(fmt.Sprintf(`sum by (code) (rate(user_svc_grpc_requests_total{%s, code!="OK"}[%s]))`, login, rateInterval), "{{code}}"),
+	))
+	b.add(12, timeseries("Registrations by code", "reqps",
+		query(fmt.Sprintf(`sum by (code) (rate(user_svc_grpc_requests_total{%s}[%s]))`, register, rateInterval), "{{code}}"),
+	))
+
+	b.row("Abuse", "")
+	b.add(8, timeseries("Velocity rule triggers", "reqps",
+		query(fmt.Sprintf(`sum by (rule, response) (rate(user_svc_abuse_velocity_rule_triggered_total[%s]))`, rateInterval), "{{rule}} ({{response}})"),
+	))
+	b.add(8, timeseries("Session anomalies", "reqps",
+		query(fmt.Sprintf(`sum by (kind) (rate(user_svc_session_anomalies_total[%s]))`, rateInterval), "{{kind}}"),
+	))
+	b.add(8, timeseries("Canary account accesses", "reqps",
+		query(fmt.Sprintf(`sum by (kind) (rate(user_svc_canary_account_accesses_total[%s]))`, rateInterval), "{{kind}}"),
+	))
+
+	return Dashboard{
+		UID:         "user-svc-auth-funnel",
+		Title:       "User Service / Auth Funnel",
+		Description: "Logins from attempt to refreshed session, their failures and the abuse defenses acting on them.",
+		Tags:        []string{"user-svc", "auth"},
+		Templating:  Templating{List: []Variable{datasourceVariable()}},
+		Panels:      b.panels,
+	}.withDefaults()
+}
+
+// tokenIssuanceDashboard shows the tokens issued by type and client and their revocations
+func tokenIssuanceDashboard() Dashboard {
+	clients := `client=~"$client"`
+
+	var b layout
+	b.row("Issuance", "")
+	b.add(12, timeseries("Tokens issued by type", "reqps",
+		query(fmt.Sprintf(`sum by (type) (rate(user_svc_token_issued_total{%s}[%s]))`, clients, rateInterval), "{{type}}"),
+	))
+	b.add(12, timeseries("Access tokens by client", "reqps",
+		query(fmt.Sprintf(`sum by (client) (rate(user_svc_token_issued_total{type="access", %s}[%s]))`, clients, rateInterval), "{{client}}"),
+	))
+	b.add(12, timeseries("Refresh tokens per access token", "none",
+		query(ratio("", "user_svc_token_issued_total", `type="refresh", `+clients, `type="access", `+clients), "ratio"),
+	))
+	b.add(12, timeseries("Token refreshes by code", "reqps",
+		query(fmt.Sprintf(`sum by (code) (rate(user_svc_grpc_requests_total{method=~".*UserService/RefreshToken"}[%s]))`, rateInterval), "{{code}}"),
+	))
+
+	b.row("Revocation", "")
+	b.add(12, timeseries("Revocations applied", "reqps",
+		query(fmt.Sprintf(`sum by (source) (rate(user_svc_revocation_applied_total[%s]))`, rateInterval), "{{source}}"),
+	))
+	b.add(12, timeseries("Active revocations", "short",
+		query(`max(user_svc_revocation_cache_entries)`, "entries"),
+	))
+
+	return Dashboard{
+		UID:         "user-svc-tokens",
+		Title:       "User Service / Token Issuance",
+		Description: "Access and refresh tokens issued by client, token refreshes and revocations.",
+		Tags:        []string{"user-svc", "tokens"},
+		Templating: Templating{List: []Variable{
+			datasourceVariable(),
+			queryVariable("client", "Client", "label_values(user_svc_token_issued_total, client)"),
+		}},
+		Panels: b.panels,
+	}.withDefaults()
+}
+
+// withDefaults sets the settings shared by every dashboard
+func (d Dashboard) withDefaults() Dashboard {
+	d.Editable = false
+	d.Refresh = "30s"
+	d.SchemaVersion = schemaVersion
+	d.Time = TimeRange{From: "now-6h", To: "now"}
+	return d
+}
+
+// serverErrorCodes matches the status codes burning the error budget of the SLOs
+func serverErrorCodes() string {
+	var names []string
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if slo.IsServerError(code) {
+			names = append(names, code.String())
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// ratio divides the rate of the series of a counter matching selector by the rate of the
+// ones matching of, grouped by the label by if any
+func ratio(by, counter, selector, of string) string {
+	group := "sum"
+	if by != "" {
+		group = "sum by (" + by + ")"
+	}
+	return fmt.Sprintf(`%s (rate(%s{%s}[%s])) / %s (rate(%s{%s}[%s]))`,
+		group, counter, selector, rateInterval, group, counter, of, rateInterval)
+}
+
+// quantile estimates a quantile of a histogram, grouped by the label by if any
+func quantile(q float64, histogram, by, selector string) string {
+	labels := "le"
+	if by != "" {
+		labels += ", " + by
+	}
+	return fmt.Sprintf(`histogram_quantile(%g, sum by (%s) (rate(%s_bucket{%s}[%s])))`, q, labels, histogram, selector, rateInterval)
+}
+
+func query(expr, legend string) Target {
+	return Target{Expr: expr, LegendFormat: legend}
+}
+
+// exemplars shows the trace exemplars of a histogram query
+func exemplars(target Target) Target {
+	target.Exemplar = true
+	return target
+}
+
+// instant evaluates a query once at the end of the time range
+func instant(target Target) Target {
+	target.Instant = true
+	return target
+}
+
+func timeseries(title, unit string, targets ...Target) Panel {
+	return Panel{
+		Type:        "timeseries",
+		Title:       title,
+		Targets:     targets,
+		FieldConfig: &FieldConfig{Defaults: FieldDefaults{Unit: unit}},
+	}
+}
+
+func datasourceVariable() Variable {
+	return Variable{Name: "datasource", Label: "Data source", Type: "datasource", Query: "prometheus"}
+}
+
+// queryVariable selects values of a label, all of them by default
+func queryVariable(name, label, query string) Variable {
+	return Variable{
+		Name:       name,
+		Label:      label,
+		Type:       "query",
+		Query:      query,
+		Datasource: prometheusDatasource,
+		Refresh:    2,
+		IncludeAll: true,
+		AllValue:   ".*",
+		Multi:      true,
+	}
+}
+
+// panelHeight is the height of every panel but rows
+const panelHeight = 8
+
+// layout places panels left to right on the 24 column grid, starting a new line when a panel
+// does not fit, and numbers them
+type layout struct {
+	panels []Panel
+	x, y   int
+}
+
+// row starts a row of panels, repeated for every value of the variable repeat if set
+func (l *layout) row(title, repeat string) {
+	l.newLine()
+	collapsed := false
+	l.panels = append(l.panels, Panel{
+		ID:        len(l.panels) + 1,
+		Type:      "row",
+		Title:     title,
+		GridPos:   GridPos{H: 1, W: 24, X: 0, Y: l.y},
+		Repeat:    repeat,
+		Collapsed: &collapsed,
+	})
+	l.y++
+}
+
+// add places a panel of the given width
+func (l *layout) add(width int, panel Panel) {
+	if l.x+width > 24 {
+		l.newLine()
+	}
+	panel.ID = len(l.panels) + 1
+	panel.GridPos = GridPos{H: panelHeight, W: width, X: l.x, Y: l.y}
+	panel.Datasource = prometheusDatasource
+	for i := range panel.Targets {
+		panel.Targets[i].RefID = string(rune('A' + i))
+	}
+	l.panels = append(l.panels, panel)
+	l.x += width
+}
+
+func (l *layout) newLine() {
+	if l.x > 0 {
+		l.x = 0
+		l.y += panelHeight
+	}
+}
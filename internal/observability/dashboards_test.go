@@ -0,0 +1,52 @@
+package observability
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDashboardsUpToDate(t *testing.T) {
+	for _, dashboard := range Dashboards() {
+		expected, err := Render(dashboard)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		got, err := os.ReadFile(filepath.Join("dashboards", FileName(dashboard)))
+		if err != nil {
+			t.Fatalf("Expected dashboard %s to be generated, got %v (run make dashboards)", dashboard.UID, err)
+		}
+		if !bytes.Equal(got, expected) {
+			t.Errorf("Expected dashboard %s to be up to date, run make dashboards", dashboard.UID)
+		}
+	}
+}
+
+func TestDashboardsLayout(t *testing.T) {
+	for _, dashboard := range Dashboards() {
+		ids := map[int]bool{}
+		for _, panel := range dashboard.Panels {
+			if ids[panel.ID] {
+				t.Errorf("Expected unique panel IDs in %s, got %d twice", dashboard.UID, panel.ID)
+			}
+			ids[panel.ID] = true
+			if panel.GridPos.X+panel.GridPos.W > 24 {
+				t.Errorf("Expected panel %q of %s to fit the grid, got %+v", panel.Title, dashboard.UID, panel.GridPos)
+			}
+			for _, target := range panel.Targets {
+				if !strings.Contains(target.Expr, "user_svc_") {
+					t.Errorf("Expected panel %q of %s to query service metrics, got %s", panel.Title, dashboard.UID, target.Expr)
+				}
+			}
+		}
+	}
+}
+
+func TestServerErrorCodes(t *testing.T) {
+	expected := "Unknown|DeadlineExceeded|Unimplemented|Internal|Unavailable|DataLoss"
+	if got := serverErrorCodes(); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
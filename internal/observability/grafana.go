@@ -0,0 +1,102 @@
+package observability
+
+// The subset of the Grafana dashboard JSON model the generated dashboards use
+
+// Dashboard is a Grafana dashboard
+type Dashboard struct {
+	UID           string     `json:"uid"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Tags          []string   `json:"tags"`
+	Editable      bool       `json:"editable"`
+	Refresh       string     `json:"refresh"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Time          TimeRange  `json:"time"`
+	Templating    Templating `json:"templating"`
+	Panels        []Panel    `json:"panels"`
+}
+
+// TimeRange is the default time range of a dashboard
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Templating holds the variables of a dashboard
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable is a dashboard variable, e.g. the data source or the methods shown
+type Variable struct {
+	Name       string      `json:"name"`
+	Label      string      `json:"label"`
+	Type       string      `json:"type"`
+	Query      string      `json:"query"`
+	Datasource *Datasource `json:"datasource,omitempty"`
+	Refresh    int         `json:"refresh,omitempty"`
+	IncludeAll bool        `json:"includeAll"`
+	AllValue   string      `json:"allValue,omitempty"`
+	Multi      bool        `json:"multi"`
+}
+
+// Datasource references the data source of a panel or variable
+type Datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// Panel is a graph, stat or row of a dashboard
+type Panel struct {
+	ID          int          `json:"id"`
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	GridPos     GridPos      `json:"gridPos"`
+	Datasource  *Datasource  `json:"datasource,omitempty"`
+	Repeat      string       `json:"repeat,omitempty"`
+	Collapsed   *bool        `json:"collapsed,omitempty"`
+	Targets     []Target     `json:"targets,omitempty"`
+	FieldConfig *FieldConfig `json:"fieldConfig,omitempty"`
+	Options     *Options     `json:"options,omitempty"`
+}
+
+// GridPos places a panel on the 24 column grid
+type GridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Target is a Prometheus query of a panel
+type Target struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+	Exemplar     bool   `json:"exemplar"`
+	Instant      bool   `json:"instant,omitempty"`
+}
+
+// FieldConfig sets the unit of the values of a panel
+type FieldConfig struct {
+	Defaults FieldDefaults `json:"defaults"`
+}
+
+// FieldDefaults are the field settings of a panel
+type FieldDefaults struct {
+	Unit string `json:"unit"`
+	Min  *int   `json:"min,omitempty"`
+}
+
+// Options are the display options of bar gauges and stats
+type Options struct {
+	Orientation   string        `json:"orientation,omitempty"`
+	DisplayMode   string        `json:"displayMode,omitempty"`
+	ReduceOptions ReduceOptions `json:"reduceOptions"`
+}
+
+// ReduceOptions reduce a series to the value shown
+type ReduceOptions struct {
+	Calcs []string `json:"calcs"`
+}
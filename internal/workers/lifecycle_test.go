@@ -0,0 +1,59 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/locks"
+	"user-svc/pkg/utils/retry"
+
+	"github.com/google/uuid"
+	"go.uber.org/goleak"
+)
+
+type emptyOutbox struct{}
+
+func (emptyOutbox) FindPendingEvents(context.Context, string, int) ([]*models.NotificationEventLog, error) {
+	return nil, nil
+}
+
+func (emptyOutbox) UpdateStatusSuccess(context.Context, string) error {
+	return nil
+}
+
+// TestWorkersStopOnCancel checks the lifecycle every background worker follows: Start
+// registers its goroutines with the owner's WaitGroup before returning, and they are done
+// once the owner cancels ctx and waits
+func TestWorkersStopOnCancel(t *testing.T) {
+	tests := map[string]func(ctx context.Context, wg *sync.WaitGroup){
+		"scheduler": func(ctx context.Context, wg *sync.WaitGroup) {
+			repo := newFakeJobRuns()
+			job := Job{Name: "test", Period: PreviousMonth, Run: func(context.Context, time.Time, time.Time) error { return nil }}
+			NewScheduler(testLogger(), repo, locks.NewLocalLocker(), wg, time.Millisecond, time.Minute, job).Start(ctx)
+		},
+		"timers": func(ctx context.Context, wg *sync.WaitGroup) {
+			repo := &fakeTimers{timers: map[uuid.UUID]*models.WorkflowTimer{}, errors: map[uuid.UUID]string{}}
+			NewTimerWorker(testLogger(), repo, &fakeTxManager{timers: repo}, wg, time.Millisecond, 10, retry.Policy{}).Start(ctx)
+		},
+		"outbox relay": func(ctx context.Context, wg *sync.WaitGroup) {
+			NewNotificationWorker(testLogger(), nil, nil, emptyOutbox{}, nil, nil, wg, time.Millisecond, 1, 10).Start(ctx)
+		},
+	}
+
+	for name, start := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			var wg sync.WaitGroup
+			start(ctx, &wg)
+			time.Sleep(5 * time.Millisecond)
+
+			cancel()
+			wg.Wait()
+		})
+	}
+}
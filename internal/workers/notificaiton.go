@@ -3,15 +3,19 @@ package workers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"sync"
 	"time"
 	"user-svc/internal/app/domains/dto"
 	"user-svc/internal/app/domains/events"
 	"user-svc/internal/app/domains/models"
+	"user-svc/internal/app/notifier"
+	"user-svc/pkg/utils/breaker"
+	"user-svc/pkg/utils/email"
+	logutils "user-svc/pkg/utils/log"
 
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
-	"github.com/sirupsen/logrus"
 )
 
 type NotificationRepository interface {
@@ -19,11 +23,34 @@ type NotificationRepository interface {
 	UpdateStatusSuccess(ctx context.Context, id string) error
 }
 
+// Templates the notification emails are rendered from
+const (
+	loginNotificationTemplate = "login_notification"
+	securityDigestTemplate    = "security_digest"
+	userInvitationTemplate    = "user_invitation"
+	registrationCodeTemplate  = "registration_code"
+	passwordResetTemplate     = "password_reset"
+	emailVerificationTemplate = "email_verification"
+)
+
+// EmailRenderer renders the localized emails attached to notification tasks
+type EmailRenderer interface {
+	Render(name, locale string, data interface{}) (*email.Message, error)
+}
+
+// Notifier fans user notifications out to the channels each user wants them on
+type Notifier interface {
+	Routes(eventType string) bool
+	Notify(ctx context.Context, notification *notifier.Notification) error
+}
+
 type NotificationWorker struct {
-	logger                   *logrus.Logger
+	logger                   *logutils.Logger
 	asyncQClient             *asynq.Client
+	eventBusBreaker          *breaker.Breaker
 	notificationEventLogRepo NotificationRepository
-	ticker                   *time.Ticker
+	emails                   EmailRenderer
+	notifier                 Notifier
 	wg                       *sync.WaitGroup
 	interval                 time.Duration
 	maxRetries               int
@@ -33,22 +60,25 @@ type NotificationWorker struct {
 }
 
 func NewNotificationWorker(
-	logger *logrus.Logger,
+	logger *logutils.Logger,
 	asyncQClient *asynq.Client,
+	eventBusBreaker *breaker.Breaker,
 	notificationEventLogRepo NotificationRepository,
+	emails EmailRenderer,
+	notifier Notifier,
 	wg *sync.WaitGroup,
 	interval time.Duration,
 	maxRetries int,
 	batchSize int,
 ) *NotificationWorker {
-	ticker := time.NewTicker(interval)
-
 	return &NotificationWorker{
 		logger:                   logger,
 		asyncQClient:             asyncQClient,
+		eventBusBreaker:          eventBusBreaker,
 		notificationEventLogRepo: notificationEventLogRepo,
+		emails:                   emails,
+		notifier:                 notifier,
 		interval:                 interval,
-		ticker:                   ticker,
 		wg:                       wg,
 		maxRetries:               maxRetries,
 		batchSize:                batchSize,
@@ -61,14 +91,15 @@ func (s *NotificationWorker) Start(ctx context.Context) {
 
 	s.wg.Add(1)
 	go func() {
+		ticker := time.NewTicker(s.interval)
 		defer func() {
-			s.ticker.Stop()
+			ticker.Stop()
 			s.wg.Done()
 			s.logger.Info("Notification worker stopped")
 		}()
 
 		// Process events immediately on startup
-		s.processPendingLoginEvents(ctx)
+		s.processPendingEvents(ctx)
 
 		for {
 			select {
@@ -82,8 +113,8 @@ func (s *NotificationWorker) Start(ctx context.Context) {
 				// Process any remaining events before stopping
 				s.processRemainingEvents()
 				return
-			case <-s.ticker.C:
-				s.processPendingLoginEvents(ctx)
+			case <-ticker.C:
+				s.processPendingEvents(ctx)
 			}
 		}
 	}()
@@ -96,15 +127,37 @@ func (s *NotificationWorker) processRemainingEvents() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	s.processPendingLoginEvents(ctx)
+	s.processPendingEvents(ctx)
+}
+
+// processPendingEvents processes pending events of every type the worker publishes
+func (s *NotificationWorker) processPendingEvents(ctx context.Context) {
+	for _, eventType := range []events.EventType{
+		events.LoginEventType,
+		events.QuotaThresholdEventType,
+		events.SecurityDigestEventType,
+		events.UserInvitedEventType,
+		events.PushTokenRegisteredEventType,
+		events.PushTokenUnregisteredEventType,
+		events.SessionAnomalyDetectedEventType,
+		events.ClientAccessRevokedEventType,
+		events.CanaryAccountAccessedEventType,
+		events.RegistrationCodeSentEventType,
+		events.PasswordResetRequestedEventType,
+		events.EmailVerificationRequestedEventType,
+		events.ReferralRedeemedEventType,
+		events.UserInactiveEventType,
+	} {
+		s.processPendingEventsOfType(ctx, eventType)
+	}
 }
 
-func (s *NotificationWorker) processPendingLoginEvents(ctx context.Context) {
-	s.logger.Debug("Processing pending login events")
+func (s *NotificationWorker) processPendingEventsOfType(ctx context.Context, eventType events.EventType) {
+	s.logger.WithField("event_name", eventType).Debug("Processing pending events")
 
 	events, err := s.notificationEventLogRepo.FindPendingEvents(
 		ctx,
-		string(events.LoginEventType),
+		string(eventType),
 		s.batchSize,
 	)
 	if err != nil {
@@ -130,6 +183,11 @@ func (s *NotificationWorker) processPendingLoginEvents(ctx context.Context) {
 		}
 
 		if err := s.processEvent(ctx, event); err != nil {
+			// Events stay pending in the outbox until the event bus recovers
+			if errors.Is(err, breaker.ErrOpen) {
+				s.logger.Warn("Event bus circuit breaker is open, deferring pending events")
+				return
+			}
 			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to process event")
 		}
 	}
@@ -138,16 +196,151 @@ func (s *NotificationWorker) processPendingLoginEvents(ctx context.Context) {
 }
 
 func (s *NotificationWorker) processEvent(ctx context.Context, event *models.NotificationEventLog) error {
-	var params dto.SendLoginNotificationParams
-	if err := json.Unmarshal(event.Payload, &params); err != nil {
-		s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
-		return err
-	}
+	switch events.EventType(event.EventName) {
+	case events.QuotaThresholdEventType:
+		var params dto.SendQuotaThresholdParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
 
-	// Send notification
-	if err := s.SendLoginNotification(ctx, &params); err != nil {
-		s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send login notification")
-		return err
+		if err := s.SendQuotaThresholdNotification(ctx, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send quota threshold notification")
+			return err
+		}
+	case events.SecurityDigestEventType:
+		var params dto.SendSecurityDigestParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendSecurityDigest(ctx, event.ID, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send security digest")
+			return err
+		}
+	case events.UserInvitedEventType:
+		var params dto.SendUserInvitationParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendUserInvitation(ctx, event.ID, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send user invitation")
+			return err
+		}
+	case events.PushTokenRegisteredEventType, events.PushTokenUnregisteredEventType:
+		var params dto.SendPushTokenParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendPushTokenEvent(ctx, events.EventType(event.EventName), &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send push token event")
+			return err
+		}
+	case events.SessionAnomalyDetectedEventType:
+		var params dto.SendSessionAnomalyParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendSessionAnomalyEvent(ctx, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send session anomaly event")
+			return err
+		}
+	case events.ClientAccessRevokedEventType:
+		var params dto.SendClientAccessRevokedParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendClientAccessRevokedEvent(ctx, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send client access revoked event")
+			return err
+		}
+	case events.CanaryAccountAccessedEventType:
+		var params dto.SendCanaryAccessParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendCanaryAccountAccessedEvent(ctx, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send canary account accessed event")
+			return err
+		}
+	case events.RegistrationCodeSentEventType:
+		var params dto.SendRegistrationCodeParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendRegistrationCode(ctx, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send registration code")
+			return err
+		}
+	case events.PasswordResetRequestedEventType:
+		var params dto.SendPasswordResetParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendPasswordReset(ctx, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send password reset")
+			return err
+		}
+	case events.EmailVerificationRequestedEventType:
+		var params dto.SendEmailVerificationParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendEmailVerification(ctx, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send email verification")
+			return err
+		}
+	case events.ReferralRedeemedEventType:
+		var params dto.SendReferralRedeemedParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendReferralRedeemedEvent(ctx, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send referral redeemed event")
+			return err
+		}
+	case events.UserInactiveEventType:
+		var params dto.SendUserInactiveParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		if err := s.SendUserInactiveEvent(ctx, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send user inactive event")
+			return err
+		}
+	default:
+		var params dto.SendLoginNotificationParams
+		if err := json.Unmarshal(event.Payload, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Could not unmarshal payload")
+			return err
+		}
+
+		// Send notification
+		if err := s.SendLoginNotification(ctx, event.ID, &params); err != nil {
+			s.logger.WithError(err).WithField("eventID", event.ID).Error("Failed to send login notification")
+			return err
+		}
 	}
 
 	// Update status to success
@@ -163,6 +356,7 @@ func (s *NotificationWorker) processEvent(ctx context.Context, event *models.Not
 
 func (s *NotificationWorker) SendLoginNotification(
 	ctx context.Context,
+	eventID string,
 	params *dto.SendLoginNotificationParams,
 ) error {
 	loginEvent := events.LoginEvent{
@@ -170,25 +364,315 @@ func (s *NotificationWorker) SendLoginNotification(
 			EventID:   uuid.New().String(),
 			EventName: string(events.LoginEventType),
 		},
+		UserID:    params.UserID,
+		Email:     params.Email,
+		Username:  params.Username,
+		LoginAt:   params.LoginAt,
+		Residency: params.Residency,
+		Message:   s.renderEmail(loginNotificationTemplate, params.Locale, params),
+	}
+
+	if s.notifier != nil && s.notifier.Routes(string(events.LoginEventType)) {
+		return s.notify(ctx, eventID, events.LoginEventType, params.UserID, params.Email, loginEvent.Message, loginEvent)
+	}
+
+	task, err := loginEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+func (s *NotificationWorker) SendQuotaThresholdNotification(
+	ctx context.Context,
+	params *dto.SendQuotaThresholdParams,
+) error {
+	quotaEvent := events.QuotaThresholdEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.QuotaThresholdEventType),
+		},
+		Subject:     params.Subject,
+		Method:      params.Method,
+		Used:        params.Used,
+		Limit:       params.Limit,
+		Threshold:   params.Threshold,
+		WindowStart: params.WindowStart,
+		WindowEnd:   params.WindowEnd,
+	}
+
+	task, err := quotaEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+func (s *NotificationWorker) SendSecurityDigest(
+	ctx context.Context,
+	eventID string,
+	params *dto.SendSecurityDigestParams,
+) error {
+	digestEvent := events.SecurityDigestEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.SecurityDigestEventType),
+		},
 		UserID:   params.UserID,
 		Email:    params.Email,
 		Username: params.Username,
-		LoginAt:  params.LoginAt,
+		Activity: params.Activity,
+		Message:  s.renderEmail(securityDigestTemplate, "", params),
 	}
 
-	task, err := loginEvent.ToTask()
+	if s.notifier != nil && s.notifier.Routes(string(events.SecurityDigestEventType)) {
+		return s.notify(ctx, eventID, events.SecurityDigestEventType, params.UserID, params.Email, digestEvent.Message, digestEvent)
+	}
+
+	task, err := digestEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+func (s *NotificationWorker) SendUserInvitation(
+	ctx context.Context,
+	eventID string,
+	params *dto.SendUserInvitationParams,
+) error {
+	invitedEvent := events.UserInvitedEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.UserInvitedEventType),
+		},
+		UserID:    params.UserID,
+		Email:     params.Email,
+		Username:  params.Username,
+		SetupURL:  params.SetupURL,
+		ExpiresAt: params.ExpiresAt,
+		Reminder:  params.Reminder,
+		Message:   s.renderEmail(userInvitationTemplate, "", params),
+	}
+
+	if s.notifier != nil && s.notifier.Routes(string(events.UserInvitedEventType)) {
+		return s.notify(ctx, eventID, events.UserInvitedEventType, params.UserID, params.Email, invitedEvent.Message, invitedEvent)
+	}
+
+	task, err := invitedEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+// SendRegistrationCode publishes the code verifying the email of a registration. The address
+// has no account yet, so there are no notification preferences to route it by.
+func (s *NotificationWorker) SendRegistrationCode(ctx context.Context, params *dto.SendRegistrationCodeParams) error {
+	codeEvent := events.RegistrationCodeSentEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.RegistrationCodeSentEventType),
+		},
+		Email:     params.Email,
+		Code:      params.Code,
+		ExpiresAt: params.ExpiresAt,
+		Message:   s.renderEmail(registrationCodeTemplate, params.Locale, params),
+	}
+
+	task, err := codeEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+// SendPushTokenEvent publishes a push token registration or removal for notification-svc,
+// which keeps its own registry of the devices it sends push notifications to
+func (s *NotificationWorker) SendPushTokenEvent(
+	ctx context.Context,
+	eventType events.EventType,
+	params *dto.SendPushTokenParams,
+) error {
+	metadata := events.EventMetadata{
+		EventID:   uuid.New().String(),
+		EventName: string(eventType),
+	}
+
+	var (
+		task *asynq.Task
+		err  error
+	)
+	if eventType == events.PushTokenRegisteredEventType {
+		registeredEvent := events.PushTokenRegisteredEvent{
+			EventMetadata: metadata,
+			UserID:        params.UserID,
+			DeviceID:      params.DeviceID,
+			Platform:      params.Platform,
+			Token:         params.Token,
+			ChangedAt:     params.ChangedAt,
+		}
+		task, err = registeredEvent.ToTask()
+	} else {
+		unregisteredEvent := events.PushTokenUnregisteredEvent{
+			EventMetadata: metadata,
+			UserID:        params.UserID,
+			DeviceID:      params.DeviceID,
+			Platform:      params.Platform,
+			Token:         params.Token,
+			Reason:        params.Reason,
+			ChangedAt:     params.ChangedAt,
+		}
+		task, err = unregisteredEvent.ToTask()
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+// SendSessionAnomalyEvent publishes a session anomaly as a security event
+func (s *NotificationWorker) SendSessionAnomalyEvent(ctx context.Context, params *dto.SendSessionAnomalyParams) error {
+	anomalyEvent := events.SessionAnomalyEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.SessionAnomalyDetectedEventType),
+		},
+		UserID:          params.UserID,
+		SessionID:       params.SessionID,
+		ClientID:        params.ClientID,
+		Kind:            params.Kind,
+		IPAddress:       params.IPAddress,
+		Country:         params.Country,
+		PreviousCountry: params.PreviousCountry,
+		Refreshes:       params.Refreshes,
+		DetectedAt:      params.DetectedAt,
+	}
+
+	task, err := anomalyEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+// SendClientAccessRevokedEvent publishes the revocation of a client's access to a user
+func (s *NotificationWorker) SendClientAccessRevokedEvent(ctx context.Context, params *dto.SendClientAccessRevokedParams) error {
+	revokedEvent := events.ClientAccessRevokedEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.ClientAccessRevokedEventType),
+		},
+		UserID:               params.UserID,
+		ClientID:             params.ClientID,
+		RevokedRefreshTokens: params.RevokedRefreshTokens,
+		RevokedAt:            params.RevokedAt,
+	}
+
+	task, err := revokedEvent.ToTask()
 	if err != nil {
 		s.logger.WithError(err).Error("Could not create task")
 		return err
 	}
 
-	info, err := s.asyncQClient.Enqueue(task, asynq.MaxRetry(s.maxRetries))
+	info, err := s.enqueue(ctx, task)
 	if err != nil {
 		s.logger.WithError(err).Error("Could not enqueue task")
 		return err
 	}
 
-	s.logger.WithFields(logrus.Fields{
+	s.logger.WithFields(logutils.Fields{
 		"id":    info.ID,
 		"queue": info.Queue,
 	}).Debug("Enqueued task")
@@ -196,9 +680,251 @@ func (s *NotificationWorker) SendLoginNotification(
 	return nil
 }
 
+// SendCanaryAccountAccessedEvent publishes an access to a canary account as a critical
+// security event
+func (s *NotificationWorker) SendCanaryAccountAccessedEvent(ctx context.Context, params *dto.SendCanaryAccessParams) error {
+	canaryEvent := events.CanaryAccountAccessedEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.CanaryAccountAccessedEventType),
+		},
+		Severity:      events.CanarySeverity,
+		UserID:        params.UserID,
+		Kind:          params.Kind,
+		ClientID:      params.ClientID,
+		PasswordValid: params.PasswordValid,
+		IPAddress:     params.IPAddress,
+		UserAgent:     params.UserAgent,
+		Note:          params.Note,
+		AccessedAt:    params.AccessedAt,
+	}
+
+	task, err := canaryEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+// notify hands a user event to the notifier instead of publishing it on the event bus
+func (s *NotificationWorker) notify(
+	ctx context.Context,
+	eventID string,
+	eventType events.EventType,
+	userID string,
+	emailAddress string,
+	message *email.Message,
+	data interface{},
+) error {
+	recipientID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.notifier.Notify(ctx, &notifier.Notification{
+		EventID:   eventID,
+		EventType: string(eventType),
+		Recipient: notifier.Recipient{UserID: recipientID, Email: emailAddress},
+		Message:   message,
+		Data:      data,
+	})
+}
+
+// renderEmail renders the email of a notification from its parameters. Rendering failures
+// are logged and leave the email out, so the mailer can fall back to its own content.
+func (s *NotificationWorker) renderEmail(name, locale string, params interface{}) *email.Message {
+	if s.emails == nil {
+		return nil
+	}
+
+	data, err := email.Data(params)
+	if err != nil {
+		s.logger.WithError(err).WithField("template", name).Warn("Could not build email template data")
+		return nil
+	}
+
+	msg, err := s.emails.Render(name, locale, data)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logutils.Fields{
+			"template": name,
+			"locale":   locale,
+		}).Warn("Could not render email template")
+		return nil
+	}
+
+	return msg
+}
+
+// enqueue publishes the task to the event bus through its circuit breaker
+func (s *NotificationWorker) enqueue(ctx context.Context, task *asynq.Task) (*asynq.TaskInfo, error) {
+	var info *asynq.TaskInfo
+	err := s.eventBusBreaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		info, err = s.asyncQClient.EnqueueContext(ctx, task, asynq.MaxRetry(s.maxRetries))
+		return err
+	})
+
+	return info, err
+}
+
 // Stop gracefully stops the worker
 func (s *NotificationWorker) Stop() {
 	s.shutdownOnce.Do(func() {
 		close(s.shutdownChan)
 	})
 }
+
+// SendPasswordReset publishes the link a user resets a forgotten password with. It is sent
+// to the email of the account whatever the notification preferences, as the user may not be
+// able to log in to read it anywhere else.
+func (s *NotificationWorker) SendPasswordReset(ctx context.Context, params *dto.SendPasswordResetParams) error {
+	resetEvent := events.PasswordResetRequestedEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.PasswordResetRequestedEventType),
+		},
+		UserID:    params.UserID,
+		Email:     params.Email,
+		Username:  params.Username,
+		ResetURL:  params.ResetURL,
+		ExpiresAt: params.ExpiresAt,
+		Message:   s.renderEmail(passwordResetTemplate, params.Locale, params),
+	}
+
+	task, err := resetEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+// SendEmailVerification publishes the link a user verifies the email of the account with; it
+// is only meaningful on the email, so it is sent there whatever the notification preferences
+func (s *NotificationWorker) SendEmailVerification(ctx context.Context, params *dto.SendEmailVerificationParams) error {
+	verificationEvent := events.EmailVerificationRequestedEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.EmailVerificationRequestedEventType),
+		},
+		UserID:          params.UserID,
+		Email:           params.Email,
+		Username:        params.Username,
+		VerificationURL: params.VerificationURL,
+		ExpiresAt:       params.ExpiresAt,
+		Message:         s.renderEmail(emailVerificationTemplate, params.Locale, params),
+	}
+
+	task, err := verificationEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+// SendReferralRedeemedEvent publishes a registration with a user's invite code to the rewards
+// engine
+func (s *NotificationWorker) SendReferralRedeemedEvent(ctx context.Context, params *dto.SendReferralRedeemedParams) error {
+	referralEvent := events.ReferralRedeemedEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.ReferralRedeemedEventType),
+		},
+		ReferrerID: params.ReferrerID,
+		RefereeID:  params.RefereeID,
+		InviteCode: params.InviteCode,
+		RedeemedAt: params.RedeemedAt,
+	}
+
+	task, err := referralEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
+
+// SendUserInactiveEvent publishes an account flagged inactive to the marketing pipeline
+func (s *NotificationWorker) SendUserInactiveEvent(ctx context.Context, params *dto.SendUserInactiveParams) error {
+	inactiveEvent := events.UserInactiveEvent{
+		EventMetadata: events.EventMetadata{
+			EventID:   uuid.New().String(),
+			EventName: string(events.UserInactiveEventType),
+		},
+		UserID:        params.UserID,
+		Email:         params.Email,
+		Username:      params.Username,
+		LastSeenAt:    params.LastSeenAt,
+		InactiveSince: params.InactiveSince,
+		Residency:     params.Residency,
+	}
+
+	task, err := inactiveEvent.ToTask()
+	if err != nil {
+		s.logger.WithError(err).Error("Could not create task")
+		return err
+	}
+
+	info, err := s.enqueue(ctx, task)
+	if err != nil {
+		s.logger.WithError(err).Error("Could not enqueue task")
+		return err
+	}
+
+	s.logger.WithFields(logutils.Fields{
+		"id":    info.ID,
+		"queue": info.Queue,
+	}).Debug("Enqueued task")
+
+	return nil
+}
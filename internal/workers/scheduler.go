@@ -0,0 +1,181 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"user-svc/pkg/utils/locks"
+	logutils "user-svc/pkg/utils/log"
+)
+
+// JobRunRepository coordinates scheduled job runs across replicas. Runs are claimed and
+// completed under the fencing token of the job's lock, see locks.Lock.Token.
+type JobRunRepository interface {
+	// Claim reports whether the caller may run the job for the period: it was never
+	// claimed, or a previous claim under an earlier token was not completed within the lease
+	Claim(ctx context.Context, job string, periodStart int64, lease time.Duration, fence int64) (bool, error)
+	// Complete reports false if the run was claimed again under a later token meanwhile
+	Complete(ctx context.Context, job string, periodStart int64, fence int64) (bool, error)
+}
+
+// Job is a task run once per period by a single replica
+type Job struct {
+	Name string
+	// Period returns the most recent period that is due at now
+	Period func(now time.Time) (time.Time, time.Time)
+	// Run is called with a context carrying the fencing token of the job's lock, see
+	// locks.FencingToken, that is cancelled if the lock is lost
+	Run func(ctx context.Context, start, end time.Time) error
+}
+
+// PreviousMonth is a job period covering the last full UTC calendar month
+func PreviousMonth(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return end.AddDate(0, -1, 0), end
+}
+
+// PreviousDay is a job period covering the last full UTC day
+func PreviousDay(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return end.AddDate(0, 0, -1), end
+}
+
+// Every returns a job period covering the last full interval of length d, counted from the
+// Unix epoch, e.g. the previous minute
+func Every(d time.Duration) func(now time.Time) (time.Time, time.Time) {
+	return func(now time.Time) (time.Time, time.Time) {
+		end := now.UTC().Truncate(d)
+		return end.Add(-d), end
+	}
+}
+
+// Scheduler periodically runs due jobs, claiming each period in the database so that
+// exactly one replica runs it. A job runs under a distributed lock, so a run taken over
+// after its lease expired never overlaps with the previous one.
+type Scheduler struct {
+	logger   *logutils.Logger
+	repo     JobRunRepository
+	locker   locks.Locker
+	jobs     []Job
+	wg       *sync.WaitGroup
+	interval time.Duration
+	lease    time.Duration
+}
+
+func NewScheduler(
+	logger *logutils.Logger,
+	repo JobRunRepository,
+	locker locks.Locker,
+	wg *sync.WaitGroup,
+	interval time.Duration,
+	lease time.Duration,
+	jobs ...Job,
+) *Scheduler {
+	return &Scheduler{
+		logger:   logger,
+		repo:     repo,
+		locker:   locker,
+		jobs:     jobs,
+		wg:       wg,
+		interval: interval,
+		lease:    lease,
+	}
+}
+
+// Start checks for due jobs every interval until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	s.logger.WithField("jobs", len(s.jobs)).Info("Starting job scheduler")
+
+	s.wg.Add(1)
+	go func() {
+		defer func() {
+			s.wg.Done()
+			s.logger.Info("Job scheduler stopped")
+		}()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.runDue(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runDue(ctx)
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) runDue(ctx context.Context) {
+	for _, job := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		start, end := job.Period(time.Now())
+		logger := s.logger.WithFields(logutils.Fields{
+			"job":          job.Name,
+			"period_start": start,
+			"period_end":   end,
+		})
+
+		s.runJob(ctx, logger, job, start, end)
+	}
+}
+
+// runJob runs the job for the period under its lock, unless another replica holds the lock
+// or already claimed the period
+func (s *Scheduler) runJob(ctx context.Context, logger *logutils.Logger, job Job, start, end time.Time) {
+	lock, err := s.locker.TryAcquire(ctx, "scheduler:"+job.Name)
+	if errors.Is(err, locks.ErrNotAcquired) {
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Error("Could not lock scheduled job")
+		return
+	}
+	defer func() {
+		if err := lock.Release(ctx); err != nil {
+			logger.WithError(err).Warn("Could not release scheduled job lock")
+		}
+	}()
+
+	claimed, err := s.repo.Claim(ctx, job.Name, start.UnixMilli(), s.lease, lock.Token())
+	if err != nil {
+		logger.WithError(err).Error("Could not claim scheduled job")
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	logger = logger.WithField("fencing_token", lock.Token())
+	logger.Info("Running scheduled job")
+	// The job may not outlive its lease, or another replica could start it again
+	lockCtx, unlock := lock.Context(ctx)
+	jobCtx, cancel := context.WithTimeout(lockCtx, s.lease)
+	err = job.Run(jobCtx, start, end)
+	cancel()
+	unlock()
+	if err != nil {
+		logger.WithError(err).Error("Scheduled job failed, it will be retried once the lease expires")
+		return
+	}
+
+	completed, err := s.repo.Complete(ctx, job.Name, start.UnixMilli(), lock.Token())
+	if err != nil {
+		logger.WithError(err).Error("Could not mark scheduled job as completed")
+		return
+	}
+	if !completed {
+		logger.Warn("Scheduled job was taken over under a later fencing token, not marked as completed")
+		return
+	}
+	logger.Info("Scheduled job completed")
+}
@@ -0,0 +1,183 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"user-svc/pkg/utils/locks"
+	logutils "user-svc/pkg/utils/log"
+)
+
+type fakeJobRuns struct {
+	mu        sync.Mutex
+	claimed   map[int64]bool
+	completed map[int64]bool
+	fences    map[int64]int64
+}
+
+func newFakeJobRuns() *fakeJobRuns {
+	return &fakeJobRuns{claimed: map[int64]bool{}, completed: map[int64]bool{}, fences: map[int64]int64{}}
+}
+
+func (f *fakeJobRuns) Claim(_ context.Context, _ string, periodStart int64, _ time.Duration, fence int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.claimed[periodStart] {
+		return false, nil
+	}
+	f.claimed[periodStart] = true
+	f.fences[periodStart] = fence
+	return true, nil
+}
+
+func (f *fakeJobRuns) Complete(_ context.Context, _ string, periodStart int64, fence int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.fences[periodStart] != fence {
+		return false, nil
+	}
+	f.completed[periodStart] = true
+	return true, nil
+}
+
+func testLogger() *logutils.Logger {
+	return logutils.New(io.Discard)
+}
+
+func TestPreviousMonth(t *testing.T) {
+	start, end := PreviousMonth(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+
+	if !start.Equal(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected start of February, got %v", start)
+	}
+	if !end.Equal(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected start of March, got %v", end)
+	}
+
+	start, _ = PreviousMonth(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if !start.Equal(time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected start of December, got %v", start)
+	}
+}
+
+func TestPreviousDay(t *testing.T) {
+	start, end := PreviousDay(time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC))
+
+	if !start.Equal(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected start of February 29, got %v", start)
+	}
+	if !end.Equal(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected start of March 1, got %v", end)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	start, end := Every(time.Minute)(time.Date(2024, time.March, 1, 10, 0, 42, 0, time.UTC))
+
+	if !start.Equal(time.Date(2024, time.March, 1, 9, 59, 0, 0, time.UTC)) {
+		t.Errorf("Expected period start 09:59, got %v", start)
+	}
+	if !end.Equal(time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected period end 10:00, got %v", end)
+	}
+}
+
+func TestSchedulerRunsEachPeriodOnce(t *testing.T) {
+	repo := newFakeJobRuns()
+	runs := 0
+	job := Job{
+		Name:   "test",
+		Period: PreviousMonth,
+		Run: func(context.Context, time.Time, time.Time) error {
+			runs++
+			return nil
+		},
+	}
+
+	scheduler := NewScheduler(testLogger(), repo, locks.NewLocalLocker(), &sync.WaitGroup{}, time.Minute, time.Minute, job)
+	scheduler.runDue(context.Background())
+	scheduler.runDue(context.Background())
+
+	if runs != 1 {
+		t.Errorf("Expected job to run once, ran %d times", runs)
+	}
+	if len(repo.completed) != 1 {
+		t.Errorf("Expected the period to be completed, got %v", repo.completed)
+	}
+}
+
+func TestSchedulerDoesNotCompleteFailedRun(t *testing.T) {
+	repo := newFakeJobRuns()
+	job := Job{
+		Name:   "test",
+		Period: PreviousMonth,
+		Run: func(context.Context, time.Time, time.Time) error {
+			return errors.New("boom")
+		},
+	}
+
+	NewScheduler(testLogger(), repo, locks.NewLocalLocker(), &sync.WaitGroup{}, time.Minute, time.Minute, job).runDue(context.Background())
+
+	if len(repo.completed) != 0 {
+		t.Errorf("Expected failed run to stay uncompleted, got %v", repo.completed)
+	}
+}
+
+func TestSchedulerSkipsJobLockedByAnotherReplica(t *testing.T) {
+	repo := newFakeJobRuns()
+	locker := locks.NewLocalLocker()
+	var token int64
+	job := Job{
+		Name:   "test",
+		Period: PreviousMonth,
+		Run: func(ctx context.Context, _, _ time.Time) error {
+			token, _ = locks.FencingToken(ctx)
+			return nil
+		},
+	}
+	scheduler := NewScheduler(testLogger(), repo, locker, &sync.WaitGroup{}, time.Minute, time.Minute, job)
+
+	held, err := locker.TryAcquire(context.Background(), "scheduler:test")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	scheduler.runDue(context.Background())
+	if len(repo.claimed) != 0 {
+		t.Errorf("Expected the locked job not to be claimed, got %v", repo.claimed)
+	}
+
+	_ = held.Release(context.Background())
+	scheduler.runDue(context.Background())
+	if len(repo.completed) != 1 {
+		t.Errorf("Expected the job to run once the lock is free, got %v", repo.completed)
+	}
+	if token <= held.Token() {
+		t.Errorf("Expected the run to get a fencing token above %d, got %d", held.Token(), token)
+	}
+}
+
+func TestSchedulerDoesNotCompleteRunTakenOver(t *testing.T) {
+	repo := newFakeJobRuns()
+	job := Job{
+		Name:   "test",
+		Period: PreviousMonth,
+		Run: func(ctx context.Context, start, _ time.Time) error {
+			// The lease expired and another replica claimed the period under a later token
+			token, _ := locks.FencingToken(ctx)
+			repo.fences[start.UnixMilli()] = token + 1
+			return nil
+		},
+	}
+
+	NewScheduler(testLogger(), repo, locks.NewLocalLocker(), &sync.WaitGroup{}, time.Minute, time.Minute, job).runDue(context.Background())
+
+	if len(repo.completed) != 0 {
+		t.Errorf("Expected the run taken over not to be completed by its previous owner, got %v", repo.completed)
+	}
+}
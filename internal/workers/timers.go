@@ -0,0 +1,171 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/retry"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// WorkflowTimerRepository stores the durable timers of workflows
+type WorkflowTimerRepository interface {
+	LockDue(ctx context.Context, now int64) (*models.WorkflowTimer, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Retry(ctx context.Context, id uuid.UUID, fireAt int64, lastError string) error
+}
+
+// TxManager runs a function in a database transaction
+type TxManager interface {
+	WithTransaction(ctx context.Context, fn func(*tx.TxWrapper) error) error
+}
+
+// Workflow is a multi-step flow whose next step is fired by a durable timer
+type Workflow struct {
+	Name string
+	// Fire runs the step in the transaction that deletes the timer, so a step that only
+	// writes to the database runs exactly once. Steps with other side effects, e.g. calls
+	// to other services, run at least once.
+	Fire func(ctx context.Context, timer *models.WorkflowTimer) error
+}
+
+// TimerWorker fires due workflow timers. Timers live in the database, so pending steps
+// survive restarts, and every timer is fired by a single replica.
+type TimerWorker struct {
+	logger    *logutils.Logger
+	repo      WorkflowTimerRepository
+	txManager TxManager
+	workflows map[string]Workflow
+	wg        *sync.WaitGroup
+	interval  time.Duration
+	batchSize int
+	backoff   retry.Policy
+	now       func() time.Time
+}
+
+func NewTimerWorker(
+	logger *logutils.Logger,
+	repo WorkflowTimerRepository,
+	txManager TxManager,
+	wg *sync.WaitGroup,
+	interval time.Duration,
+	batchSize int,
+	backoff retry.Policy,
+	workflows ...Workflow,
+) *TimerWorker {
+	byName := make(map[string]Workflow, len(workflows))
+	for _, workflow := range workflows {
+		byName[workflow.Name] = workflow
+	}
+
+	return &TimerWorker{
+		logger:    logger,
+		repo:      repo,
+		txManager: txManager,
+		workflows: byName,
+		wg:        wg,
+		interval:  interval,
+		batchSize: batchSize,
+		backoff:   backoff,
+		now:       time.Now,
+	}
+}
+
+// Start fires due timers every interval until ctx is cancelled
+func (w *TimerWorker) Start(ctx context.Context) {
+	w.logger.WithField("workflows", len(w.workflows)).Info("Starting workflow timer worker")
+
+	w.wg.Add(1)
+	go func() {
+		defer func() {
+			w.wg.Done()
+			w.logger.Info("Workflow timer worker stopped")
+		}()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		w.fireDue(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.fireDue(ctx)
+			}
+		}
+	}()
+}
+
+// fireDue fires up to batchSize due timers, one transaction each
+func (w *TimerWorker) fireDue(ctx context.Context) {
+	for i := 0; i < w.batchSize && ctx.Err() == nil; i++ {
+		fired, err := w.fireNext(ctx)
+		if err != nil {
+			w.logger.WithError(err).Error("Could not fire workflow timer")
+			return
+		}
+		if !fired {
+			return
+		}
+	}
+}
+
+// fireNext fires the earliest due timer and reports whether there was one. A failed step
+// is moved back by the backoff policy and retried until it succeeds.
+func (w *TimerWorker) fireNext(ctx context.Context) (bool, error) {
+	var timer *models.WorkflowTimer
+	var stepErr error
+	err := w.txManager.WithTransaction(ctx, func(txWrapper *tx.TxWrapper) error {
+		txCtx := context.WithValue(ctx, tx.TransactionContextKey, txWrapper.GetTx())
+
+		var err error
+		timer, err = w.repo.LockDue(txCtx, w.now().UnixMilli())
+		if err != nil || timer == nil {
+			return err
+		}
+
+		workflow, ok := w.workflows[timer.Workflow]
+		if !ok {
+			// Replicas running an older build may not know the workflow yet
+			stepErr = fmt.Errorf("unknown workflow %q", timer.Workflow)
+			return stepErr
+		}
+		if stepErr = workflow.Fire(txCtx, timer); stepErr != nil {
+			return stepErr
+		}
+
+		return w.repo.Delete(txCtx, timer.ID)
+	})
+	if timer == nil {
+		return false, err
+	}
+
+	logger := w.logger.WithFields(logutils.Fields{
+		"workflow": timer.Workflow,
+		"key":      timer.Key,
+		"attempts": timer.Attempts,
+	})
+	if err == nil {
+		logger.Info("Workflow timer fired")
+		return true, nil
+	}
+	if stepErr == nil {
+		return false, err
+	}
+
+	fireAt := w.now().Add(w.backoff.Backoff(timer.Attempts))
+	logger.WithError(stepErr).WithField("retry_at", fireAt).Warn("Workflow step failed, it will be retried")
+	if err := w.repo.Retry(ctx, timer.ID, fireAt.UnixMilli(), stepErr.Error()); err != nil {
+		// The timer stays due, stop this round instead of picking it again right away
+		return false, err
+	}
+
+	return true, nil
+}
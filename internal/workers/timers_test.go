@@ -0,0 +1,127 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	"user-svc/pkg/utils/retry"
+	"user-svc/pkg/utils/tx"
+
+	"github.com/google/uuid"
+)
+
+// fakeTimers keeps timers in memory; deletes are only applied once the transaction commits
+type fakeTimers struct {
+	timers  map[uuid.UUID]*models.WorkflowTimer
+	deleted []uuid.UUID
+	errors  map[uuid.UUID]string
+}
+
+func (f *fakeTimers) LockDue(_ context.Context, now int64) (*models.WorkflowTimer, error) {
+	var due *models.WorkflowTimer
+	for _, timer := range f.timers {
+		if timer.FireAt <= now && (due == nil || timer.FireAt < due.FireAt) {
+			due = timer
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeTimers) Delete(_ context.Context, id uuid.UUID) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeTimers) Retry(_ context.Context, id uuid.UUID, fireAt int64, lastError string) error {
+	f.timers[id].Attempts++
+	f.timers[id].FireAt = fireAt
+	f.errors[id] = lastError
+	return nil
+}
+
+type fakeTxManager struct {
+	timers *fakeTimers
+}
+
+func (m *fakeTxManager) WithTransaction(_ context.Context, fn func(*tx.TxWrapper) error) error {
+	m.timers.deleted = nil
+	if err := fn(tx.NewTxWrapper(nil)); err != nil {
+		return err
+	}
+	for _, id := range m.timers.deleted {
+		delete(m.timers.timers, id)
+	}
+	return nil
+}
+
+func newTestTimerWorker(now time.Time, timers ...*models.WorkflowTimer) (*TimerWorker, *fakeTimers, map[string]int) {
+	repo := &fakeTimers{timers: map[uuid.UUID]*models.WorkflowTimer{}, errors: map[uuid.UUID]string{}}
+	for _, timer := range timers {
+		repo.timers[timer.ID] = timer
+	}
+
+	fired := map[string]int{}
+	worker := NewTimerWorker(
+		testLogger(), repo, &fakeTxManager{timers: repo}, &sync.WaitGroup{}, time.Second, 10,
+		retry.Policy{InitialBackoff: time.Minute, MaxBackoff: time.Hour, Multiplier: 2},
+		Workflow{Name: "ok", Fire: func(_ context.Context, timer *models.WorkflowTimer) error {
+			fired[timer.Key]++
+			return nil
+		}},
+		Workflow{Name: "failing", Fire: func(context.Context, *models.WorkflowTimer) error {
+			return errors.New("boom")
+		}},
+	)
+	worker.now = func() time.Time { return now }
+
+	return worker, repo, fired
+}
+
+func TestTimerWorkerFiresDueTimersOnce(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	due, _ := models.NewWorkflowTimer("ok", "due", now.Add(-time.Minute), nil)
+	later, _ := models.NewWorkflowTimer("ok", "later", now.Add(time.Minute), nil)
+
+	worker, repo, fired := newTestTimerWorker(now, due, later)
+	worker.fireDue(context.Background())
+	worker.fireDue(context.Background())
+
+	if fired["due"] != 1 {
+		t.Errorf("Expected the due timer to fire once, fired %d times", fired["due"])
+	}
+	if fired["later"] != 0 {
+		t.Errorf("Expected the timer that is not due to wait")
+	}
+	if _, ok := repo.timers[due.ID]; ok {
+		t.Errorf("Expected the fired timer to be deleted")
+	}
+}
+
+func TestTimerWorkerRetriesFailedSteps(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	failing, _ := models.NewWorkflowTimer("failing", "a", now.Add(-time.Minute), nil)
+	unknown, _ := models.NewWorkflowTimer("unknown", "b", now.Add(-time.Minute), nil)
+
+	worker, repo, _ := newTestTimerWorker(now, failing, unknown)
+	worker.fireDue(context.Background())
+
+	for _, timer := range []*models.WorkflowTimer{failing, unknown} {
+		stored, ok := repo.timers[timer.ID]
+		if !ok {
+			t.Fatalf("Expected the failed timer %s to be kept", timer.Workflow)
+		}
+		if stored.Attempts != 1 {
+			t.Errorf("Expected one failed attempt of %s, got %d", timer.Workflow, stored.Attempts)
+		}
+		if stored.FireAt <= now.UnixMilli() {
+			t.Errorf("Expected %s to be moved back, fires at %d", timer.Workflow, stored.FireAt)
+		}
+		if repo.errors[timer.ID] == "" {
+			t.Errorf("Expected the error of %s to be recorded", timer.Workflow)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package client is the Go client of user-svc. It dials the service with the published
+// service config, so every consumer retries and hedges the same methods the same way, and
+// accepts gzip- and zstd-compressed responses, e.g. of exports.
+package client
+
+import (
+	_ "embed"
+
+	pb "user-svc/api/proto/v1"
+	_ "user-svc/pkg/utils/grpc/zstd" // accepts zstd-compressed responses
+
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/encoding/gzip" // accepts gzip-compressed responses
+)
+
+// ServiceConfig is the gRPC service config of user-svc. Methods marked NO_SIDE_EFFECTS or
+// IDEMPOTENT in the proto are retried on UNAVAILABLE and GetRiskSignals is hedged. Retries
+// are throttled while most calls fail, so clients do not pile onto an outage.
+//
+//go:embed service_config.json
+var ServiceConfig string
+
+// DialOptions returns the dial options that apply ServiceConfig. A service config published
+// by the name resolver, e.g. in DNS, takes precedence.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{grpc.WithDefaultServiceConfig(ServiceConfig)}
+}
+
+// New creates a user-svc client for target with ServiceConfig. opts must at least set the
+// transport credentials. The caller closes the returned connection.
+func New(target string, opts ...grpc.DialOption) (pb.UserServiceClient, *grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(target, append(DialOptions(), opts...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pb.NewUserServiceClient(conn), conn, nil
+}
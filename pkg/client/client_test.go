@@ -0,0 +1,61 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	pb "user-svc/api/proto/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type serviceConfig struct {
+	MethodConfig []struct {
+		Name []struct {
+			Service string `json:"service"`
+			Method  string `json:"method"`
+		} `json:"name"`
+		RetryPolicy   json.RawMessage `json:"retryPolicy"`
+		HedgingPolicy json.RawMessage `json:"hedgingPolicy"`
+	} `json:"methodConfig"`
+}
+
+func TestServiceConfigIsValid(t *testing.T) {
+	_, conn, err := New("passthrough:///user-svc", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Expected the service config to be accepted, got %v", err)
+	}
+	conn.Close()
+}
+
+func TestServiceConfigOnlyRepeatsIdempotentMethods(t *testing.T) {
+	var cfg serviceConfig
+	if err := json.Unmarshal([]byte(ServiceConfig), &cfg); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	service := pb.File_v1_user_svc_proto.Services().ByName("UserService")
+	for _, methodConfig := range cfg.MethodConfig {
+		if methodConfig.RetryPolicy == nil && methodConfig.HedgingPolicy == nil {
+			continue
+		}
+		for _, name := range methodConfig.Name {
+			if name.Service != string(service.FullName()) {
+				t.Errorf("Expected service %s, got %s", service.FullName(), name.Service)
+				continue
+			}
+			method := service.Methods().ByName(protoreflect.Name(name.Method))
+			if method == nil {
+				t.Errorf("Expected method %s to exist", name.Method)
+				continue
+			}
+			level := method.Options().(*descriptorpb.MethodOptions).GetIdempotencyLevel()
+			if level == descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN {
+				t.Errorf("Expected %s to be marked idempotent before it is retried", name.Method)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"user-svc/pkg/utils/metrics"
+)
+
+// ErrQueueFull is returned without waiting when the queue, or the caller's share of it, is full
+var ErrQueueFull = errors.New("admission queue is full")
+
+// Config holds admission queue configuration
+type Config struct {
+	// Capacity is the number of calls admitted at once, e.g. the CPU cores bcrypt can keep busy
+	Capacity int
+	// MaxQueued bounds the calls waiting for admission
+	MaxQueued int
+	// MaxQueuedPerKey bounds the waiting calls of one caller, so a flood from one address
+	// cannot fill the queue
+	MaxQueuedPerKey int
+}
+
+type waiter struct {
+	key      string
+	admitted chan struct{}
+}
+
+// Queue admits a bounded number of calls at once and queues the others per caller key.
+// Waiting callers are admitted round robin across keys, so every caller progresses at the
+// same pace however many calls another caller queued.
+type Queue struct {
+	cfg Config
+
+	mu       sync.Mutex
+	inFlight int
+	queued   int
+	waiters  map[string][]*waiter
+	// keys are the keys with waiters, in the order they are admitted from
+	keys []string
+}
+
+// New creates an empty queue
+func New(cfg Config) *Queue {
+	if cfg.Capacity <= 0 {
+		cfg.Capacity = 1
+	}
+	if cfg.MaxQueuedPerKey <= 0 || cfg.MaxQueuedPerKey > cfg.MaxQueued {
+		cfg.MaxQueuedPerKey = cfg.MaxQueued
+	}
+
+	return &Queue{cfg: cfg, waiters: make(map[string][]*waiter)}
+}
+
+// Acquire waits until the call is admitted or ctx is done. It returns the function releasing
+// the admission, to be called once the call is done, and the approximate position the call
+// was queued at, 0 when it was admitted right away. Calls the queue has no room for fail
+// with ErrQueueFull at the position they would have been queued at.
+func (q *Queue) Acquire(ctx context.Context, key string) (func(), int, error) {
+	q.mu.Lock()
+	if q.inFlight < q.cfg.Capacity && q.queued == 0 {
+		q.inFlight++
+		q.mu.Unlock()
+		return q.releaseFunc(), 0, nil
+	}
+	if q.queued >= q.cfg.MaxQueued || len(q.waiters[key]) >= q.cfg.MaxQueuedPerKey {
+		position := q.queued + 1
+		q.mu.Unlock()
+		return nil, position, ErrQueueFull
+	}
+
+	w := &waiter{key: key, admitted: make(chan struct{})}
+	if len(q.waiters[key]) == 0 {
+		q.keys = append(q.keys, key)
+	}
+	q.waiters[key] = append(q.waiters[key], w)
+	q.queued++
+	position := q.position(len(q.waiters[key]))
+	metrics.AdmissionQueued.Set(float64(q.queued))
+	q.mu.Unlock()
+
+	select {
+	case <-w.admitted:
+		return q.releaseFunc(), position, nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		removed := q.remove(w)
+		q.mu.Unlock()
+		if !removed {
+			// Admitted while giving up, so the slot goes to the next waiter
+			q.release()
+		}
+		return nil, position, ctx.Err()
+	}
+}
+
+// position estimates the position of the n-th waiter of a key: every key gets one admission
+// per round, so it waits for up to n waiters of every key
+func (q *Queue) position(n int) int {
+	position := 0
+	for _, key := range q.keys {
+		position += min(len(q.waiters[key]), n)
+	}
+	return position
+}
+
+func (q *Queue) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(q.release)
+	}
+}
+
+func (q *Queue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.inFlight--
+	for q.inFlight < q.cfg.Capacity && q.queued > 0 {
+		key := q.keys[0]
+		w := q.waiters[key][0]
+		q.waiters[key] = q.waiters[key][1:]
+		q.keys = q.keys[1:]
+		if len(q.waiters[key]) > 0 {
+			q.keys = append(q.keys, key)
+		} else {
+			delete(q.waiters, key)
+		}
+
+		q.queued--
+		q.inFlight++
+		close(w.admitted)
+	}
+	metrics.AdmissionQueued.Set(float64(q.queued))
+}
+
+// remove takes a waiter that gave up out of the queue; false means it was admitted already
+func (q *Queue) remove(w *waiter) bool {
+	waiters := q.waiters[w.key]
+	for i, queued := range waiters {
+		if queued != w {
+			continue
+		}
+
+		q.waiters[w.key] = append(waiters[:i:i], waiters[i+1:]...)
+		if len(q.waiters[w.key]) == 0 {
+			delete(q.waiters, w.key)
+			for j, key := range q.keys {
+				if key == w.key {
+					q.keys = append(q.keys[:j:j], q.keys[j+1:]...)
+					break
+				}
+			}
+		}
+		q.queued--
+		metrics.AdmissionQueued.Set(float64(q.queued))
+		return true
+	}
+	return false
+}
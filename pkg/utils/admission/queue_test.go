@@ -0,0 +1,144 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// acquireAsync queues a call and reports its admission on the returned channel
+func acquireAsync(ctx context.Context, q *Queue, key string) <-chan func() {
+	admitted := make(chan func(), 1)
+	go func() {
+		release, _, err := q.Acquire(ctx, key)
+		if err == nil {
+			admitted <- release
+		}
+	}()
+	return admitted
+}
+
+// waitQueued waits until n calls are queued
+func waitQueued(t *testing.T, q *Queue, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		q.mu.Lock()
+		queued := q.queued
+		q.mu.Unlock()
+		if queued == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Expected %d queued calls", n)
+}
+
+func TestQueue_AdmitsUpToCapacity(t *testing.T) {
+	q := New(Config{Capacity: 2, MaxQueued: 10})
+
+	for i := 0; i < 2; i++ {
+		_, position, err := q.Acquire(context.Background(), "a")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if position != 0 {
+			t.Errorf("Expected position 0, got %d", position)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, position, err := q.Acquire(ctx, "a")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if position != 1 {
+		t.Errorf("Expected position 1, got %d", position)
+	}
+	waitQueued(t, q, 0)
+}
+
+func TestQueue_RoundRobinAcrossKeys(t *testing.T) {
+	q := New(Config{Capacity: 1, MaxQueued: 10})
+	release, _, err := q.Acquire(context.Background(), "busy")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Caller a queues three calls before caller b queues one
+	var order []string
+	var admitted []<-chan func()
+	for _, key := range []string{"a", "a", "a", "b"} {
+		admitted = append(admitted, acquireAsync(context.Background(), q, key))
+		order = append(order, key)
+		waitQueued(t, q, len(order))
+	}
+
+	// b is admitted second, not after all calls of a
+	expected := []int{0, 3, 1, 2}
+	for _, i := range expected {
+		release()
+		select {
+		case release = <-admitted[i]:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected call %d of %s to be admitted", i, order[i])
+		}
+	}
+	release()
+}
+
+func TestQueue_Full(t *testing.T) {
+	q := New(Config{Capacity: 1, MaxQueued: 3, MaxQueuedPerKey: 2})
+	if _, _, err := q.Acquire(context.Background(), "busy"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	acquireAsync(ctx, q, "a")
+	acquireAsync(ctx, q, "a")
+	waitQueued(t, q, 2)
+
+	// Caller a has used its share of the queue
+	if _, _, err := q.Acquire(ctx, "a"); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+
+	acquireAsync(ctx, q, "b")
+	waitQueued(t, q, 3)
+
+	// The queue is full for every caller
+	_, position, err := q.Acquire(ctx, "c")
+	if !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+	if position != 4 {
+		t.Errorf("Expected position 4, got %d", position)
+	}
+}
+
+func TestQueue_CancelledWaiterLeavesQueue(t *testing.T) {
+	q := New(Config{Capacity: 1, MaxQueued: 10})
+	release, _, err := q.Acquire(context.Background(), "busy")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	acquireAsync(ctx, q, "a")
+	admitted := acquireAsync(context.Background(), q, "b")
+	waitQueued(t, q, 2)
+
+	cancel()
+	waitQueued(t, q, 1)
+
+	release()
+	select {
+	case release = <-admitted:
+		release()
+	case <-time.After(time.Second):
+		t.Fatal("Expected the remaining waiter to be admitted")
+	}
+}
@@ -0,0 +1,189 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"user-svc/pkg/utils/metrics"
+)
+
+// ErrOpen is returned without calling the dependency while the breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a circuit breaker
+type State int
+
+const (
+	// StateClosed lets every call through
+	StateClosed State = iota
+	// StateHalfOpen lets a limited number of probe calls through
+	StateHalfOpen
+	// StateOpen rejects every call until the open timeout elapses
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config holds circuit breaker configuration
+type Config struct {
+	// Name identifies the protected dependency in metrics and logs
+	Name string
+	// FailureThreshold is the number of consecutive failures that opens the breaker
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before probing the dependency
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls is the number of successful probes needed to close the breaker
+	HalfOpenMaxCalls int
+}
+
+// Breaker is a consecutive-failure circuit breaker with half-open probing
+type Breaker struct {
+	cfg Config
+	now func() time.Time
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	successes int
+	probes    int
+	openedAt  time.Time
+}
+
+// New creates a closed circuit breaker
+func New(cfg Config) *Breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenMaxCalls <= 0 {
+		cfg.HalfOpenMaxCalls = 1
+	}
+
+	b := &Breaker{cfg: cfg, now: time.Now}
+	metrics.BreakerState.WithLabelValues(cfg.Name).Set(float64(StateClosed))
+
+	return b
+}
+
+// Execute calls fn unless the breaker is open. Context cancellation of the
+// caller is not counted as a dependency failure.
+func (b *Breaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !b.allow() {
+		metrics.BreakerCalls.WithLabelValues(b.cfg.Name, "rejected").Inc()
+		return ErrOpen
+	}
+
+	err := fn(ctx)
+	if err != nil && ctx.Err() != nil {
+		b.release()
+		return err
+	}
+
+	b.record(err == nil)
+	return err
+}
+
+// State returns the current state of the breaker
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// allow reserves a call slot, moving an expired open breaker to half-open
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return false
+		}
+		b.transition(StateHalfOpen)
+		fallthrough
+	case StateHalfOpen:
+		if b.probes >= b.cfg.HalfOpenMaxCalls {
+			return false
+		}
+		b.probes++
+		return true
+	default:
+		return true
+	}
+}
+
+// release gives back a probe slot of a call whose outcome is unknown
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+func (b *Breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		metrics.BreakerCalls.WithLabelValues(b.cfg.Name, "success").Inc()
+	} else {
+		metrics.BreakerCalls.WithLabelValues(b.cfg.Name, "failure").Inc()
+	}
+
+	switch b.state {
+	case StateHalfOpen:
+		if !success {
+			b.open()
+			return
+		}
+		b.successes++
+		if b.successes >= b.cfg.HalfOpenMaxCalls {
+			b.transition(StateClosed)
+		}
+	case StateClosed:
+		if success {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.cfg.FailureThreshold {
+			b.open()
+		}
+	}
+}
+
+func (b *Breaker) open() {
+	b.openedAt = b.now()
+	b.transition(StateOpen)
+}
+
+func (b *Breaker) transition(state State) {
+	b.state = state
+	b.failures = 0
+	b.successes = 0
+	b.probes = 0
+	metrics.BreakerState.WithLabelValues(b.cfg.Name).Set(float64(state))
+	metrics.BreakerTransitions.WithLabelValues(b.cfg.Name, state.String()).Inc()
+}
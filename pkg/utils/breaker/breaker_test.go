@@ -0,0 +1,92 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errDependency = errors.New("dependency failed")
+
+func fail(context.Context) error    { return errDependency }
+func succeed(context.Context) error { return nil }
+
+func newTestBreaker(now *time.Time) *Breaker {
+	b := New(Config{Name: "test", FailureThreshold: 2, OpenTimeout: time.Minute, HalfOpenMaxCalls: 1})
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(&now)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, b.Execute(ctx, fail), errDependency)
+	assert.NoError(t, b.Execute(ctx, succeed))
+	assert.ErrorIs(t, b.Execute(ctx, fail), errDependency)
+	assert.Equal(t, StateClosed, b.State(), "a success resets the failure count")
+
+	assert.ErrorIs(t, b.Execute(ctx, fail), errDependency)
+	assert.Equal(t, StateOpen, b.State())
+
+	called := false
+	err := b.Execute(ctx, func(context.Context) error { called = true; return nil })
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.False(t, called)
+}
+
+func TestBreaker_HalfOpenProbing(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(&now)
+	ctx := context.Background()
+
+	_ = b.Execute(ctx, fail)
+	_ = b.Execute(ctx, fail)
+	assert.Equal(t, StateOpen, b.State())
+
+	// A failed probe re-opens the breaker for another timeout
+	now = now.Add(time.Minute)
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.ErrorIs(t, b.Execute(ctx, fail), errDependency)
+	assert.Equal(t, StateOpen, b.State())
+	assert.ErrorIs(t, b.Execute(ctx, succeed), ErrOpen)
+
+	// A successful probe closes it
+	now = now.Add(time.Minute)
+	assert.NoError(t, b.Execute(ctx, succeed))
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(&now)
+	ctx := context.Background()
+
+	_ = b.Execute(ctx, fail)
+	_ = b.Execute(ctx, fail)
+	now = now.Add(time.Minute)
+
+	err := b.Execute(ctx, func(ctx context.Context) error {
+		// A concurrent call while the probe is in flight is rejected
+		assert.ErrorIs(t, b.Execute(ctx, succeed), ErrOpen)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_IgnoresCallerCancellation(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(&now)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for i := 0; i < 3; i++ {
+		assert.Error(t, b.Execute(ctx, func(ctx context.Context) error { return ctx.Err() }))
+	}
+	assert.Equal(t, StateClosed, b.State())
+}
@@ -0,0 +1,70 @@
+// Package captcha checks the captchas clients solved with the siteverify API that reCAPTCHA,
+// hCaptcha and Cloudflare Turnstile have in common.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxResponseBody bounds how much of a siteverify response is read
+const maxResponseBody = 64 << 10
+
+// Verifier checks solved captchas with the siteverify endpoint of a captcha provider
+type Verifier struct {
+	httpClient *http.Client
+	verifyURL  string
+	secret     string
+}
+
+// NewVerifier creates a verifier posting to verifyURL with httpClient, which should have a timeout
+func NewVerifier(httpClient *http.Client, verifyURL, secret string) *Verifier {
+	return &Verifier{
+		httpClient: httpClient,
+		verifyURL:  verifyURL,
+		secret:     secret,
+	}
+}
+
+// verifyResponse is the part of a siteverify response every provider sends
+type verifyResponse struct {
+	Success bool `json:"success"`
+}
+
+// Verify reports whether the token is a captcha solved by the client at remoteIP, which may
+// be empty. An error means the provider could not be asked, not that the captcha is wrong.
+func (v *Verifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, fmt.Errorf("failed to build siteverify request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("siteverify answered with status %d", resp.StatusCode)
+	}
+	var result verifyResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBody)).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode siteverify response: %w", err)
+	}
+	return result.Success, nil
+}
@@ -0,0 +1,66 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Expected a form, got %v", err)
+		}
+		form = map[string]string{
+			"secret":   r.PostForm.Get("secret"),
+			"response": r.PostForm.Get("response"),
+			"remoteip": r.PostForm.Get("remoteip"),
+		}
+		if r.PostForm.Get("response") == "solved" {
+			_, _ = w.Write([]byte(`{"success":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+	}))
+	defer server.Close()
+
+	verifier := NewVerifier(server.Client(), server.URL, "site-secret")
+	ctx := context.Background()
+
+	ok, err := verifier.Verify(ctx, "solved", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !ok {
+		t.Errorf("Expected the solved captcha to be accepted")
+	}
+	if form["secret"] != "site-secret" || form["remoteip"] != "203.0.113.7" {
+		t.Errorf("Expected the secret and remote IP to be sent, got %v", form)
+	}
+
+	if ok, err := verifier.Verify(ctx, "forged", ""); err != nil || ok {
+		t.Errorf("Expected a forged captcha to be refused without error, got %v, %v", ok, err)
+	}
+
+	form = nil
+	if ok, err := verifier.Verify(ctx, "", ""); err != nil || ok {
+		t.Errorf("Expected a missing captcha to be refused without error, got %v, %v", ok, err)
+	}
+	if form != nil {
+		t.Errorf("Expected a missing captcha not to be sent to the provider")
+	}
+}
+
+func TestVerifier_VerifyUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	verifier := NewVerifier(server.Client(), server.URL, "site-secret")
+	if _, err := verifier.Verify(context.Background(), "solved", ""); err == nil {
+		t.Errorf("Expected an error when the provider is unavailable")
+	}
+}
@@ -0,0 +1,242 @@
+// Package archive encrypts byte streams of unbounded size with a passphrase, so they can be
+// written and read in chunks without holding them in memory.
+//
+// An archive starts with a magic, the salt the AES-256 key is derived from with PBKDF2 and a
+// random nonce prefix. The data follows in AES-GCM sealed chunks, each preceded by a flag
+// marking the final chunk and the length of the sealed chunk. The chunk counter and the flag
+// are part of the nonce, so reordered, dropped and truncated chunks fail to open.
+package archive
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	magic = "USNAPv1\n"
+
+	saltSize        = 16
+	noncePrefixSize = 7
+	keySize         = 32
+	// kdfIterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256
+	kdfIterations = 600000
+
+	// ChunkSize is the plaintext size of every chunk but the final one
+	ChunkSize = 64 * 1024
+
+	finalChunk byte = 1
+)
+
+var (
+	// ErrInvalidArchive is returned for data that is not an archive of this package
+	ErrInvalidArchive = errors.New("not an encrypted archive")
+	// ErrDecrypt is returned when a chunk fails to open: a wrong passphrase, or tampered data
+	ErrDecrypt = errors.New("failed to decrypt archive, wrong passphrase or corrupted data")
+	// ErrTruncated is returned when the archive ends before its final chunk
+	ErrTruncated = errors.New("archive is truncated")
+)
+
+// Writer encrypts the data written to it. Close must be called to write the final chunk.
+type Writer struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	closed  bool
+}
+
+// NewWriter writes the archive header to w and returns a writer encrypting with the passphrase
+func NewWriter(w io.Writer, passphrase string) (*Writer, error) {
+	header := make([]byte, len(magic)+saltSize+noncePrefixSize)
+	copy(header, magic)
+	if _, err := rand.Read(header[len(magic):]); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	salt := header[len(magic) : len(magic)+saltSize]
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		w:      w,
+		aead:   aead,
+		prefix: header[len(magic)+saltSize:],
+		buf:    make([]byte, 0, ChunkSize),
+	}, nil
+}
+
+// Write buffers p and writes every full chunk
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("archive writer is closed")
+	}
+
+	written := 0
+	for len(p) > 0 {
+		n := copy(w.buf[len(w.buf):ChunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+
+		if len(w.buf) == ChunkSize {
+			if err := w.seal(0); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close writes the final chunk; it does not close the underlying writer
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.seal(finalChunk)
+}
+
+func (w *Writer) seal(flag byte) error {
+	if w.counter == ^uint32(0) {
+		return errors.New("archive has too many chunks")
+	}
+
+	sealed := w.aead.Seal(nil, nonce(w.prefix, w.counter, flag), w.buf, nil)
+	w.counter++
+	w.buf = w.buf[:0]
+
+	header := make([]byte, 5)
+	header[0] = flag
+	binary.BigEndian.PutUint32(header[1:], uint32(len(sealed)))
+	if _, err := w.w.Write(append(header, sealed...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Reader decrypts an archive, failing with ErrTruncated if it ends before its final chunk
+type Reader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	done    bool
+}
+
+// NewReader reads the archive header from r and returns a reader decrypting with the passphrase
+func NewReader(r io.Reader, passphrase string) (*Reader, error) {
+	header := make([]byte, len(magic)+saltSize+noncePrefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, ErrInvalidArchive
+		}
+		return nil, err
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, ErrInvalidArchive
+	}
+
+	aead, err := newAEAD(passphrase, header[len(magic):len(magic)+saltSize])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{
+		r:      bufio.NewReader(r),
+		aead:   aead,
+		prefix: header[len(magic)+saltSize:],
+	}, nil
+}
+
+// Read decrypts the next chunk once the previous one has been consumed
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *Reader) open() error {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return ErrTruncated
+		}
+		return err
+	}
+
+	flag, size := header[0], binary.BigEndian.Uint32(header[1:])
+	if flag > finalChunk || size > ChunkSize+uint32(r.aead.Overhead()) {
+		return ErrDecrypt
+	}
+
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(r.r, sealed); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return ErrTruncated
+		}
+		return err
+	}
+
+	plain, err := r.aead.Open(sealed[:0], nonce(r.prefix, r.counter, flag), sealed, nil)
+	if err != nil {
+		return ErrDecrypt
+	}
+	r.counter++
+	r.buf = plain
+
+	if flag == finalChunk {
+		r.done = true
+		// Data after the final chunk was appended to the archive
+		if _, err := r.r.Peek(1); err == nil {
+			return ErrDecrypt
+		} else if !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+	return nil
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, kdfIterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// nonce is the prefix of the archive, the chunk counter and the final chunk flag
+func nonce(prefix []byte, counter uint32, flag byte) []byte {
+	nonce := make([]byte, 0, noncePrefixSize+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	return append(nonce, flag)
+}
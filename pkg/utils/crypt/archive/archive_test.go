@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+const passphrase = "correct horse battery staple"
+
+func seal(t *testing.T, plain []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, passphrase)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	// Uneven writes cross chunk boundaries
+	for len(plain) > 0 {
+		n := min(len(plain), 10000)
+		if _, err := w.Write(plain[:n]); err != nil {
+			t.Fatalf("Failed to write: %v", err)
+		}
+		plain = plain[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func open(archive []byte, passphrase string) ([]byte, error) {
+	r, err := NewReader(bytes.NewReader(archive), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestArchive_RoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, ChunkSize, 3*ChunkSize + 17} {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+
+		opened, err := open(seal(t, plain), passphrase)
+		if err != nil {
+			t.Fatalf("Expected %d bytes to open, got %v", size, err)
+		}
+		if !bytes.Equal(opened, plain) {
+			t.Errorf("Expected %d bytes to round-trip", size)
+		}
+	}
+}
+
+func TestArchive_Rejects(t *testing.T) {
+	plain := make([]byte, 2*ChunkSize+5)
+	archive := seal(t, plain)
+	headerSize := len(magic) + saltSize + noncePrefixSize
+	firstChunk := headerSize + 5 + ChunkSize + 16
+
+	tampered := bytes.Clone(archive)
+	tampered[len(tampered)-1] ^= 1
+
+	// The final flag of the last full chunk, making it look like the end of the archive
+	finalFlag := bytes.Clone(archive[:2*firstChunk-headerSize])
+	finalFlag[firstChunk] = finalChunk
+
+	tests := []struct {
+		name       string
+		archive    []byte
+		passphrase string
+		expected   error
+	}{
+		{name: "wrong passphrase", archive: archive, passphrase: "not the passphrase", expected: ErrDecrypt},
+		{name: "tampered", archive: tampered, passphrase: passphrase, expected: ErrDecrypt},
+		{name: "truncated at a chunk boundary", archive: archive[:firstChunk], passphrase: passphrase, expected: ErrTruncated},
+		{name: "truncated within a chunk", archive: archive[:firstChunk+100], passphrase: passphrase, expected: ErrTruncated},
+		{name: "forged final flag", archive: finalFlag, passphrase: passphrase, expected: ErrDecrypt},
+		{name: "appended data", archive: append(bytes.Clone(archive), 0), passphrase: passphrase, expected: ErrDecrypt},
+		{name: "not an archive", archive: []byte("id,email\n"), passphrase: passphrase, expected: ErrInvalidArchive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := open(tt.archive, tt.passphrase); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package dpop
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the thumbprint of a verified proof key
+func NewContext(ctx context.Context, jkt string) context.Context {
+	return context.WithValue(ctx, contextKey{}, jkt)
+}
+
+// FromContext returns the thumbprint of the proof key presented with the request, if any
+func FromContext(ctx context.Context) (string, bool) {
+	jkt, ok := ctx.Value(contextKey{}).(string)
+	return jkt, ok && jkt != ""
+}
@@ -0,0 +1,164 @@
+package dpop
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ProofType is the JOSE "typ" header every DPoP proof must carry (RFC 9449)
+const ProofType = "dpop+jwt"
+
+// HTTPMethod is the "htm" claim expected for gRPC calls, which are always HTTP/2 POSTs
+const HTTPMethod = "POST"
+
+// maxTrackedProofs bounds the replay cache so a flood of proofs cannot exhaust memory
+const maxTrackedProofs = 100000
+
+var (
+	ErrInvalidProof  = errors.New("invalid DPoP proof")
+	ErrReplayedProof = errors.New("DPoP proof has already been used")
+)
+
+// Proof is a verified DPoP proof
+type Proof struct {
+	// JKT is the base64url SHA-256 JWK thumbprint of the proof key (RFC 7638)
+	JKT      string
+	JTI      string
+	IssuedAt time.Time
+}
+
+// Verifier validates DPoP proofs and rejects proofs that were already presented.
+// Replay tracking is local to the process; proofs are only accepted inside a short
+// freshness window, which bounds what a replica that never saw the proof can accept.
+type Verifier struct {
+	maxAge    time.Duration
+	clockSkew time.Duration
+	now       func() time.Time
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewVerifier creates a verifier accepting proofs issued within maxAge, allowing
+// clockSkew for clients whose clocks run ahead
+func NewVerifier(maxAge, clockSkew time.Duration) *Verifier {
+	return &Verifier{
+		maxAge:    maxAge,
+		clockSkew: clockSkew,
+		now:       time.Now,
+		seen:      make(map[string]time.Time),
+	}
+}
+
+// Verify checks a proof for the given htm/htu pair. When accessToken is not empty the
+// proof must also carry its hash in the "ath" claim.
+func (v *Verifier) Verify(proof, htm, htu, accessToken string) (*Proof, error) {
+	var jkt string
+	keyFunc := func(t *jwt.Token) (interface{}, error) {
+		if typ, _ := t.Header["typ"].(string); !strings.EqualFold(typ, ProofType) {
+			return nil, fmt.Errorf("unexpected typ %q", typ)
+		}
+
+		jwk, ok := t.Header["jwk"].(map[string]interface{})
+		if !ok {
+			return nil, errors.New("missing jwk header")
+		}
+
+		key, thumbprint, err := parseJWK(jwk)
+		if err != nil {
+			return nil, err
+		}
+		jkt = thumbprint
+
+		return key, nil
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(proof, claims, keyFunc,
+		jwt.WithValidMethods(supportedAlgorithms),
+		jwt.WithoutClaimsValidation(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return nil, fmt.Errorf("%w: missing jti", ErrInvalidProof)
+	}
+
+	if got, _ := claims["htm"].(string); got != htm {
+		return nil, fmt.Errorf("%w: htm mismatch", ErrInvalidProof)
+	}
+
+	if got, _ := claims["htu"].(string); got != htu {
+		return nil, fmt.Errorf("%w: htu mismatch", ErrInvalidProof)
+	}
+
+	if accessToken != "" {
+		if got, _ := claims["ath"].(string); got != AccessTokenHash(accessToken) {
+			return nil, fmt.Errorf("%w: ath mismatch", ErrInvalidProof)
+		}
+	}
+
+	iatValue, ok := claims["iat"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing iat", ErrInvalidProof)
+	}
+	sec, frac := math.Modf(iatValue)
+	issuedAt := time.Unix(int64(sec), int64(frac*1e9))
+
+	now := v.now()
+	if issuedAt.After(now.Add(v.clockSkew)) || issuedAt.Before(now.Add(-v.maxAge)) {
+		return nil, fmt.Errorf("%w: iat outside the accepted window", ErrInvalidProof)
+	}
+
+	if !v.remember(jkt+":"+jti, issuedAt.Add(v.maxAge+v.clockSkew), now) {
+		return nil, ErrReplayedProof
+	}
+
+	return &Proof{
+		JKT:      jkt,
+		JTI:      jti,
+		IssuedAt: issuedAt,
+	}, nil
+}
+
+// remember records a proof identifier and reports whether it was seen for the first time
+func (v *Verifier) remember(id string, expiresAt, now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if exp, ok := v.seen[id]; ok && exp.After(now) {
+		return false
+	}
+
+	if len(v.seen) >= maxTrackedProofs {
+		for key, exp := range v.seen {
+			if !exp.After(now) {
+				delete(v.seen, key)
+			}
+		}
+		// Still full of live proofs: refuse rather than forget one that could be replayed
+		if len(v.seen) >= maxTrackedProofs {
+			return false
+		}
+	}
+
+	v.seen[id] = expiresAt
+	return true
+}
+
+// AccessTokenHash returns the "ath" value binding a proof to an access token
+func AccessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,166 @@
+package dpop
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const testMethod = "/user.UserService/RefreshToken"
+
+func ecJWK(key *ecdsa.PrivateKey) map[string]interface{} {
+	pad := func(b []byte) string {
+		out := make([]byte, 32)
+		copy(out[32-len(b):], b)
+		return base64.RawURLEncoding.EncodeToString(out)
+	}
+	return map[string]interface{}{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   pad(key.X.Bytes()),
+		"y":   pad(key.Y.Bytes()),
+	}
+}
+
+func signProof(t *testing.T, key interface{}, jwk map[string]interface{}, method jwt.SigningMethod, claims jwt.MapClaims) string {
+	t.Helper()
+
+	tok := jwt.NewWithClaims(method, claims)
+	tok.Header["typ"] = ProofType
+	tok.Header["jwk"] = jwk
+
+	proof, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign proof: %v", err)
+	}
+	return proof
+}
+
+func proofClaims(htu string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"jti": uuid.NewString(),
+		"htm": HTTPMethod,
+		"htu": htu,
+		"iat": time.Now().Unix(),
+	}
+}
+
+func newECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return key
+}
+
+func TestVerifyValidProof(t *testing.T) {
+	key := newECKey(t)
+	jwk := ecJWK(key)
+	verifier := NewVerifier(time.Minute, 5*time.Second)
+
+	proof, err := verifier.Verify(signProof(t, key, jwk, jwt.SigningMethodES256, proofClaims(testMethod)), HTTPMethod, testMethod, "")
+	if err != nil {
+		t.Fatalf("Expected valid proof, got %v", err)
+	}
+
+	want, err := Thumbprint(jwk)
+	if err != nil {
+		t.Fatalf("Failed to compute thumbprint: %v", err)
+	}
+	if proof.JKT != want {
+		t.Errorf("Expected thumbprint %s, got %s", want, proof.JKT)
+	}
+}
+
+func TestVerifyEd25519Proof(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	jwk := map[string]interface{}{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"x":   base64.RawURLEncoding.EncodeToString(pub),
+	}
+	verifier := NewVerifier(time.Minute, 5*time.Second)
+
+	if _, err := verifier.Verify(signProof(t, priv, jwk, jwt.SigningMethodEdDSA, proofClaims(testMethod)), HTTPMethod, testMethod, ""); err != nil {
+		t.Errorf("Expected valid proof, got %v", err)
+	}
+}
+
+func TestVerifyRejectsReplay(t *testing.T) {
+	key := newECKey(t)
+	verifier := NewVerifier(time.Minute, 5*time.Second)
+	proof := signProof(t, key, ecJWK(key), jwt.SigningMethodES256, proofClaims(testMethod))
+
+	if _, err := verifier.Verify(proof, HTTPMethod, testMethod, ""); err != nil {
+		t.Fatalf("Expected first use to succeed, got %v", err)
+	}
+	if _, err := verifier.Verify(proof, HTTPMethod, testMethod, ""); !errors.Is(err, ErrReplayedProof) {
+		t.Errorf("Expected ErrReplayedProof, got %v", err)
+	}
+}
+
+func TestVerifyRejectsInvalidProofs(t *testing.T) {
+	key := newECKey(t)
+	other := newECKey(t)
+	verifier := NewVerifier(time.Minute, 5*time.Second)
+
+	stale := proofClaims(testMethod)
+	stale["iat"] = time.Now().Add(-2 * time.Minute).Unix()
+
+	future := proofClaims(testMethod)
+	future["iat"] = time.Now().Add(time.Minute).Unix()
+
+	withATH := proofClaims(testMethod)
+	withATH["ath"] = AccessTokenHash("other-token")
+
+	withPrivate := ecJWK(key)
+	withPrivate["d"] = "secret"
+
+	tests := []struct {
+		name        string
+		proof       string
+		htu         string
+		accessToken string
+	}{
+		{"wrong htu", signProof(t, key, ecJWK(key), jwt.SigningMethodES256, proofClaims("/user.UserService/Login")), testMethod, ""},
+		{"stale iat", signProof(t, key, ecJWK(key), jwt.SigningMethodES256, stale), testMethod, ""},
+		{"future iat", signProof(t, key, ecJWK(key), jwt.SigningMethodES256, future), testMethod, ""},
+		{"missing ath", signProof(t, key, ecJWK(key), jwt.SigningMethodES256, proofClaims(testMethod)), testMethod, "access-token"},
+		{"wrong ath", signProof(t, key, ecJWK(key), jwt.SigningMethodES256, withATH), testMethod, "access-token"},
+		{"signed by another key", signProof(t, other, ecJWK(key), jwt.SigningMethodES256, proofClaims(testMethod)), testMethod, ""},
+		{"private key in header", signProof(t, key, withPrivate, jwt.SigningMethodES256, proofClaims(testMethod)), testMethod, ""},
+		{"symmetric algorithm", signProof(t, []byte("shared-secret"), ecJWK(key), jwt.SigningMethodHS256, proofClaims(testMethod)), testMethod, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := verifier.Verify(tt.proof, HTTPMethod, tt.htu, tt.accessToken); !errors.Is(err, ErrInvalidProof) {
+				t.Errorf("Expected ErrInvalidProof, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyAccessTokenHash(t *testing.T) {
+	key := newECKey(t)
+	verifier := NewVerifier(time.Minute, 5*time.Second)
+
+	claims := proofClaims(testMethod)
+	claims["ath"] = AccessTokenHash("access-token")
+
+	if _, err := verifier.Verify(signProof(t, key, ecJWK(key), jwt.SigningMethodES256, claims), HTTPMethod, testMethod, "access-token"); err != nil {
+		t.Errorf("Expected valid proof, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package dpop
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// minRSAKeyBits rejects proof keys too weak to be worth binding a token to
+const minRSAKeyBits = 2048
+
+var supportedAlgorithms = []string{"ES256", "RS256", "PS256", "EdDSA"}
+
+// parseJWK converts a public JWK into a verification key and computes its RFC 7638 thumbprint
+func parseJWK(jwk map[string]interface{}) (interface{}, string, error) {
+	if _, ok := jwk["d"]; ok {
+		return nil, "", errors.New("jwk must not contain private key material")
+	}
+
+	member := func(name string) (string, error) {
+		value, _ := jwk[name].(string)
+		if value == "" {
+			return "", fmt.Errorf("jwk is missing %q", name)
+		}
+		return value, nil
+	}
+
+	kty, err := member("kty")
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch kty {
+	case "EC":
+		crv, err := member("crv")
+		if err != nil {
+			return nil, "", err
+		}
+		if crv != "P-256" {
+			return nil, "", fmt.Errorf("unsupported curve %q", crv)
+		}
+		x, err := member("x")
+		if err != nil {
+			return nil, "", err
+		}
+		y, err := member("y")
+		if err != nil {
+			return nil, "", err
+		}
+		key, err := ecKey(x, y)
+		if err != nil {
+			return nil, "", err
+		}
+		return key, thumbprint(map[string]string{"crv": crv, "kty": kty, "x": x, "y": y}), nil
+
+	case "RSA":
+		n, err := member("n")
+		if err != nil {
+			return nil, "", err
+		}
+		e, err := member("e")
+		if err != nil {
+			return nil, "", err
+		}
+		key, err := rsaKey(n, e)
+		if err != nil {
+			return nil, "", err
+		}
+		return key, thumbprint(map[string]string{"e": e, "kty": kty, "n": n}), nil
+
+	case "OKP":
+		crv, err := member("crv")
+		if err != nil {
+			return nil, "", err
+		}
+		if crv != "Ed25519" {
+			return nil, "", fmt.Errorf("unsupported curve %q", crv)
+		}
+		x, err := member("x")
+		if err != nil {
+			return nil, "", err
+		}
+		raw, err := base64.RawURLEncoding.DecodeString(x)
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			return nil, "", errors.New("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(raw), thumbprint(map[string]string{"crv": crv, "kty": kty, "x": x}), nil
+
+	default:
+		return nil, "", fmt.Errorf("unsupported key type %q", kty)
+	}
+}
+
+func ecKey(x, y string) (*ecdsa.PublicKey, error) {
+	xb, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil || len(xb) != 32 {
+		return nil, errors.New("invalid EC x coordinate")
+	}
+	yb, err := base64.RawURLEncoding.DecodeString(y)
+	if err != nil || len(yb) != 32 {
+		return nil, errors.New("invalid EC y coordinate")
+	}
+
+	// Uncompressed SEC 1 encoding; crypto/ecdh rejects points that are not on the curve
+	point := append([]byte{4}, append(xb, yb...)...)
+	if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+		return nil, errors.New("EC point is not on P-256")
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(xb),
+		Y:     new(big.Int).SetBytes(yb),
+	}, nil
+}
+
+func rsaKey(n, e string) (*rsa.PublicKey, error) {
+	nb, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, errors.New("invalid RSA modulus")
+	}
+	eb, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil || len(eb) == 0 || len(eb) > 4 {
+		return nil, errors.New("invalid RSA exponent")
+	}
+
+	key := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nb),
+		E: int(new(big.Int).SetBytes(eb).Int64()),
+	}
+	if key.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+	}
+
+	return key, nil
+}
+
+// thumbprint hashes the required members of a JWK; encoding/json sorts map keys,
+// which yields the lexicographic member order RFC 7638 asks for
+func thumbprint(members map[string]string) string {
+	canonical, _ := json.Marshal(members)
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Thumbprint computes the RFC 7638 thumbprint of a public JWK
+func Thumbprint(jwk map[string]interface{}) (string, error) {
+	_, jkt, err := parseJWK(jwk)
+	return jkt, err
+}
@@ -0,0 +1,231 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxResponseBody bounds how much of a discovery document or key set is read
+const maxResponseBody = 256 << 10
+
+// minRefreshInterval bounds how often tokens signed with an unknown key refetch the keys, so
+// forged kids cannot flood the provider
+const minRefreshInterval = 30 * time.Second
+
+// minRSAKeyBits rejects signing keys too weak to trust
+const minRSAKeyBits = 2048
+
+// discoveryPath is where the discovery document of an issuer is found
+const discoveryPath = "/.well-known/openid-configuration"
+
+// discoveryDocument is the part of a discovery document the service reads
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// jwk is a public key of a JSON Web Key Set
+type jwk struct {
+	KeyID string `json:"kid"`
+	Type  string `json:"kty"`
+	Use   string `json:"use"`
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+	N     string `json:"n"`
+	E     string `json:"e"`
+}
+
+// keySet caches the signing keys of a provider and refetches them once they are older than
+// the TTL, or when a token is signed with a key it does not know, as after a key rotation
+type keySet struct {
+	httpClient *http.Client
+	provider   Provider
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+func newKeySet(httpClient *http.Client, provider Provider, ttl time.Duration) *keySet {
+	return &keySet{
+		httpClient: httpClient,
+		provider:   provider,
+		ttl:        ttl,
+		now:        time.Now,
+	}
+}
+
+// key returns the key with the given ID. A token without a kid is verified with the only key
+// of a provider publishing one.
+func (s *keySet) key(ctx context.Context, kid string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	age := s.now().Sub(s.fetchedAt)
+	key, known := s.lookup(kid)
+	if s.keys == nil || age >= s.ttl || (!known && age >= minRefreshInterval) {
+		keys, err := s.fetch(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrUnavailable, s.provider.Name, err)
+		}
+		s.keys, s.fetchedAt = keys, s.now()
+		key, known = s.lookup(kid)
+	}
+
+	if !known {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+func (s *keySet) lookup(kid string) (interface{}, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// fetch fetches the keys from the configured JWKS URL, or else from the jwks_uri of the
+// discovery document, whose issuer must be the configured one
+func (s *keySet) fetch(ctx context.Context) (map[string]interface{}, error) {
+	jwksURL := s.provider.JWKSURL
+	if jwksURL == "" {
+		var doc discoveryDocument
+		if err := s.get(ctx, strings.TrimSuffix(s.provider.Issuer, "/")+discoveryPath, &doc); err != nil {
+			return nil, err
+		}
+		if doc.Issuer != s.provider.Issuer {
+			return nil, fmt.Errorf("discovery document is for issuer %q", doc.Issuer)
+		}
+		if doc.JWKSURI == "" {
+			return nil, errors.New("discovery document has no jwks_uri")
+		}
+		jwksURL = doc.JWKSURI
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := s.get(ctx, jwksURL, &set); err != nil {
+		return nil, err
+	}
+
+	// Keys the service cannot use, e.g. encryption keys, are left out rather than failing
+	// the whole set
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if key, err := k.publicKey(); err == nil {
+			keys[k.KeyID] = key
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("key set has no usable signing keys")
+	}
+
+	return keys, nil
+}
+
+func (s *keySet) get(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s answered with status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBody)).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", url, err)
+	}
+	return nil
+}
+
+// publicKey converts an RSA or P-256/P-384 EC key into a verification key
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Type {
+	case "RSA":
+		nb, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errors.New("invalid RSA modulus")
+		}
+		eb, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(eb) == 0 || len(eb) > 4 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+
+		key := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(nb),
+			E: int(new(big.Int).SetBytes(eb).Int64()),
+		}
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+		}
+		return key, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		var checker ecdh.Curve
+		switch k.Curve {
+		case "P-256":
+			curve, checker = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, checker = elliptic.P384(), ecdh.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+
+		size := (curve.Params().BitSize + 7) / 8
+		xb, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(xb) != size {
+			return nil, errors.New("invalid EC x coordinate")
+		}
+		yb, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil || len(yb) != size {
+			return nil, errors.New("invalid EC y coordinate")
+		}
+
+		// Uncompressed SEC 1 encoding; crypto/ecdh rejects points that are not on the curve
+		point := append([]byte{4}, append(xb, yb...)...)
+		if _, err := checker.NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("EC point is not on %s", k.Curve)
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(xb),
+			Y:     new(big.Int).SetBytes(yb),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Type)
+	}
+}
@@ -0,0 +1,206 @@
+// Package oidc verifies the ID tokens of OpenID Connect providers. The signing keys of a
+// provider are found through discovery, so Apple, Google or a corporate SSO is added by
+// configuring its issuer and client IDs.
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"user-svc/pkg/utils/nonces"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrUnknownProvider is returned for ID tokens of a provider that is not configured
+	ErrUnknownProvider = errors.New("identity provider is not configured")
+	// ErrInvalidIDToken is returned for ID tokens that are forged, expired, replayed or meant
+	// for another client
+	ErrInvalidIDToken = errors.New("invalid ID token")
+	// ErrUnavailable is returned when the discovery document or the keys of a provider
+	// cannot be fetched, which says nothing about the token
+	ErrUnavailable = errors.New("identity provider is unavailable")
+)
+
+// replayScope is the nonce scope under which verified ID tokens are remembered
+const replayScope = "oidc_id_token"
+
+// clockSkew is the leeway given to the exp, iat and nbf of ID tokens
+const clockSkew = time.Minute
+
+// signingMethods are the algorithms ID tokens may be signed with; HMAC and "none" are
+// never accepted
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384"}
+
+// Provider is an OpenID Connect provider whose ID tokens are accepted
+type Provider struct {
+	// Name identifies the provider in requests, e.g. "google" or "apple"
+	Name string
+	// Issuer is the iss of the ID tokens, whose discovery document is found under
+	// /.well-known/openid-configuration
+	Issuer string
+	// ClientIDs are the client IDs of the service's apps at the provider, one of which the
+	// aud of an ID token must contain
+	ClientIDs []string
+	// JWKSURL overrides the jwks_uri of the discovery document, for providers without one
+	JWKSURL string
+}
+
+// Claims are the verified claims of an ID token
+type Claims struct {
+	Issuer  string
+	Subject string
+	Email   string
+	// EmailVerified is whether the provider verified the email
+	EmailVerified bool
+	Nonce         string
+	ExpiresAt     time.Time
+}
+
+// idTokenClaims are the claims of an ID token the service reads
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	AuthorizedParty string   `json:"azp,omitempty"`
+	Nonce           string   `json:"nonce,omitempty"`
+	Email           string   `json:"email,omitempty"`
+	EmailVerified   jsonBool `json:"email_verified,omitempty"`
+}
+
+// jsonBool is a boolean claim some providers, such as Apple, send as the string "true"
+type jsonBool bool
+
+func (b *jsonBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `true`, `"true"`:
+		*b = true
+	case `false`, `"false"`, `null`:
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean claim %s", data)
+	}
+	return nil
+}
+
+// Verifier verifies the ID tokens of one provider
+type Verifier struct {
+	provider Provider
+	keys     *keySet
+	now      func() time.Time
+}
+
+// NewVerifier creates a verifier fetching the provider's keys with httpClient, which should
+// have a timeout, and keeping them for cacheTTL
+func NewVerifier(httpClient *http.Client, provider Provider, cacheTTL time.Duration) *Verifier {
+	return &Verifier{
+		provider: provider,
+		keys:     newKeySet(httpClient, provider, cacheTTL),
+		now:      time.Now,
+	}
+}
+
+// Verify verifies the signature, issuer, audience and expiry of an ID token and returns its
+// claims. A non-empty nonce must match the nonce claim, binding the token to the sign-in the
+// client started.
+func (v *Verifier) Verify(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(v.provider.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(v.now),
+	)
+
+	var claims idTokenClaims
+	_, err := parser.ParseWithClaims(rawIDToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.key(ctx, kid)
+	})
+	if err != nil {
+		if errors.Is(err, ErrUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	if !slices.ContainsFunc(claims.Audience, v.isClientID) {
+		return nil, fmt.Errorf("%w: not meant for a configured client", ErrInvalidIDToken)
+	}
+	// A token for several audiences names the client it was issued to
+	if len(claims.Audience) > 1 && !v.isClientID(claims.AuthorizedParty) {
+		return nil, fmt.Errorf("%w: not authorized for a configured client", ErrInvalidIDToken)
+	}
+	if nonce != "" && subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	return &Claims{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Nonce:         claims.Nonce,
+		ExpiresAt:     claims.ExpiresAt.Time,
+	}, nil
+}
+
+func (v *Verifier) isClientID(clientID string) bool {
+	return clientID != "" && slices.Contains(v.provider.ClientIDs, clientID)
+}
+
+// Registry verifies the ID tokens of the configured providers by name
+type Registry struct {
+	verifiers map[string]*Verifier
+	replays   nonces.Store
+}
+
+// NewRegistry creates a registry of the providers. With a replay store, an ID token is
+// accepted once: tokens presented again before they expire fail with ErrInvalidIDToken.
+func NewRegistry(httpClient *http.Client, cacheTTL time.Duration, replays nonces.Store, providers ...Provider) *Registry {
+	verifiers := make(map[string]*Verifier, len(providers))
+	for _, provider := range providers {
+		verifiers[provider.Name] = NewVerifier(httpClient, provider, cacheTTL)
+	}
+
+	return &Registry{
+		verifiers: verifiers,
+		replays:   replays,
+	}
+}
+
+// Verify verifies an ID token of the named provider, see Verifier.Verify
+func (r *Registry) Verify(ctx context.Context, provider, rawIDToken, nonce string) (*Claims, error) {
+	verifier, ok := r.verifiers[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	claims, err := verifier.Verify(ctx, rawIDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.replays != nil {
+		sum := sha256.Sum256([]byte(rawIDToken))
+		err := nonces.Remember(ctx, r.replays, replayScope, provider+":"+hex.EncodeToString(sum[:]), claims.ExpiresAt.Add(clockSkew))
+		if errors.Is(err, nonces.ErrReplayed) || errors.Is(err, nonces.ErrNotFound) {
+			return nil, fmt.Errorf("%w: already used", ErrInvalidIDToken)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to record ID token: %w", err)
+		}
+	}
+
+	return claims, nil
+}
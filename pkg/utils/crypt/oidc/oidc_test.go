@@ -0,0 +1,268 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"user-svc/pkg/utils/nonces"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testClientID = "booking-web"
+
+// testProvider is an identity provider serving its discovery document and key set
+type testProvider struct {
+	server   *httptest.Server
+	keys     []map[string]interface{}
+	fetches  atomic.Int32
+	failKeys atomic.Bool
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+
+	p := &testProvider{}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(discoveryDocument{Issuer: p.server.URL, JWKSURI: p.server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		p.fetches.Add(1)
+		if p.failKeys.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": p.keys})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+func (p *testProvider) provider() Provider {
+	return Provider{Name: "corp", Issuer: p.server.URL, ClientIDs: []string{testClientID}}
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]interface{} {
+	return map[string]interface{}{
+		"kty": "RSA", "kid": kid, "use": "sig",
+		"n": b64(key.N.Bytes()), "e": b64(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]interface{} {
+	pad := func(b []byte) string {
+		out := make([]byte, 32)
+		copy(out[32-len(b):], b)
+		return b64(out)
+	}
+	return map[string]interface{}{"kty": "EC", "kid": kid, "crv": "P-256", "x": pad(key.X.Bytes()), "y": pad(key.Y.Bytes())}
+}
+
+func idTokenClaimsFor(issuer string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            issuer,
+		"sub":            "248289761001",
+		"aud":            testClientID,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          "n-0S6_WzA2Mj",
+		"email":          "jane@example.com",
+		"email_verified": true,
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign ID token: %v", err)
+	}
+	return signed
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	p := newTestProvider(t)
+	p.keys = []map[string]interface{}{rsaJWK("rsa-1", rsaKey), ecJWK("ec-1", ecKey)}
+	verifier := NewVerifier(p.server.Client(), p.provider(), time.Hour)
+	ctx := context.Background()
+
+	claims, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, idTokenClaimsFor(p.server.URL)), "n-0S6_WzA2Mj")
+	if err != nil {
+		t.Fatalf("Expected a valid ID token, got %v", err)
+	}
+	if claims.Subject != "248289761001" || claims.Email != "jane@example.com" || !claims.EmailVerified {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+
+	// Apple sends email_verified as a string
+	apple := idTokenClaimsFor(p.server.URL)
+	apple["email_verified"] = "true"
+	claims, err = verifier.Verify(ctx, sign(t, jwt.SigningMethodES256, "ec-1", ecKey, apple), "")
+	if err != nil {
+		t.Fatalf("Expected a valid ES256 ID token, got %v", err)
+	}
+	if !claims.EmailVerified {
+		t.Errorf("Expected the string email_verified to be read as true")
+	}
+
+	tests := []struct {
+		name   string
+		modify func(claims jwt.MapClaims)
+		nonce  string
+	}{
+		{name: "other issuer", modify: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{name: "other audience", modify: func(c jwt.MapClaims) { c["aud"] = "someone-else" }},
+		{
+			name:   "several audiences without azp",
+			modify: func(c jwt.MapClaims) { c["aud"] = []string{testClientID, "someone-else"} },
+		},
+		{name: "expired", modify: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{name: "without expiry", modify: func(c jwt.MapClaims) { delete(c, "exp") }},
+		{name: "without subject", modify: func(c jwt.MapClaims) { delete(c, "sub") }},
+		{name: "nonce mismatch", modify: func(jwt.MapClaims) {}, nonce: "other-nonce"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := idTokenClaimsFor(p.server.URL)
+			tt.modify(claims)
+
+			_, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims), tt.nonce)
+			if !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("Expected ErrInvalidIDToken, got %v", err)
+			}
+		})
+	}
+
+	severalAudiences := idTokenClaimsFor(p.server.URL)
+	severalAudiences["aud"] = []string{"someone-else", testClientID}
+	severalAudiences["azp"] = testClientID
+	if _, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, severalAudiences), ""); err != nil {
+		t.Errorf("Expected a token authorized for the client to be valid, got %v", err)
+	}
+
+	// Forged with a key the provider does not publish, and HMAC-signed with its public modulus
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if _, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodES256, "ec-1", otherKey, idTokenClaimsFor(p.server.URL)), ""); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("Expected a forged token to be refused, got %v", err)
+	}
+	if _, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodHS256, "rsa-1", rsaKey.N.Bytes(), idTokenClaimsFor(p.server.URL)), ""); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("Expected an HMAC-signed token to be refused, got %v", err)
+	}
+
+	if fetches := p.fetches.Load(); fetches != 1 {
+		t.Errorf("Expected the keys to be fetched once, got %d fetches", fetches)
+	}
+}
+
+func TestVerifier_KeyRotation(t *testing.T) {
+	oldKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	newKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	p := newTestProvider(t)
+	p.keys = []map[string]interface{}{ecJWK("old", oldKey)}
+	verifier := NewVerifier(p.server.Client(), p.provider(), time.Hour)
+	now := time.Now()
+	verifier.keys.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodES256, "old", oldKey, idTokenClaimsFor(p.server.URL)), ""); err != nil {
+		t.Fatalf("Expected a valid ID token, got %v", err)
+	}
+
+	// The provider rotates its key; an unknown kid refetches the keys, but not right away
+	p.keys = []map[string]interface{}{ecJWK("new", newKey)}
+	rotated := sign(t, jwt.SigningMethodES256, "new", newKey, idTokenClaimsFor(p.server.URL))
+	if _, err := verifier.Verify(ctx, rotated, ""); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("Expected the new key to be unknown within the refresh interval, got %v", err)
+	}
+
+	now = now.Add(minRefreshInterval)
+	if _, err := verifier.Verify(ctx, rotated, ""); err != nil {
+		t.Errorf("Expected the new key to be fetched, got %v", err)
+	}
+	if fetches := p.fetches.Load(); fetches != 2 {
+		t.Errorf("Expected 2 fetches, got %d", fetches)
+	}
+
+	// Unavailable keys are not an invalid token
+	p.failKeys.Store(true)
+	now = now.Add(time.Hour)
+	if _, err := verifier.Verify(ctx, rotated, ""); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable, got %v", err)
+	}
+}
+
+func TestRegistry_Verify(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	p := newTestProvider(t)
+	p.keys = []map[string]interface{}{ecJWK("ec-1", key)}
+	registry := NewRegistry(p.server.Client(), time.Hour, nonces.NewLocalStore(), p.provider())
+	ctx := context.Background()
+
+	idToken := sign(t, jwt.SigningMethodES256, "ec-1", key, idTokenClaimsFor(p.server.URL))
+	if _, err := registry.Verify(ctx, "corp", idToken, ""); err != nil {
+		t.Fatalf("Expected a valid ID token, got %v", err)
+	}
+	if _, err := registry.Verify(ctx, "corp", idToken, ""); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("Expected a replayed ID token to be refused, got %v", err)
+	}
+	if _, err := registry.Verify(ctx, "apple", idToken, ""); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("Expected ErrUnknownProvider, got %v", err)
+	}
+}
+
+func TestKeySet_DiscoveryIssuerMismatch(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	p := newTestProvider(t)
+	p.keys = []map[string]interface{}{ecJWK("ec-1", key)}
+	provider := p.provider()
+	provider.Issuer = p.server.URL + "/"
+
+	verifier := NewVerifier(p.server.Client(), provider, time.Hour)
+	claims := idTokenClaimsFor(provider.Issuer)
+	if _, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodES256, "ec-1", key, claims), ""); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected a discovery document of another issuer to be refused, got %v", err)
+	}
+
+	// A configured JWKS URL skips discovery
+	provider.JWKSURL = p.server.URL + "/keys"
+	verifier = NewVerifier(p.server.Client(), provider, time.Hour)
+	if _, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodES256, "ec-1", key, claims), ""); err != nil {
+		t.Errorf("Expected the configured JWKS URL to be used, got %v", err)
+	}
+}
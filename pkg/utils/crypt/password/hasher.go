@@ -1,38 +1,182 @@
 package password
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+// PreHashedPrefix tags bcrypt hashes of the base64-encoded SHA-384 digest of a password
+// instead of the password itself. bcrypt only reads the first 72 bytes of its input, so two
+// long passwords sharing their first 72 bytes would verify against each other's hash; the
+// 64-character digest makes every byte count. Untagged bcrypt hashes of earlier versions
+// still verify.
+const PreHashedPrefix = "sha384-bcrypt$"
+
+// PepperedPrefix tags bcrypt hashes of the HMAC-SHA384 of a password keyed with a pepper, a
+// secret kept out of the database so a leaked table alone cannot be brute-forced. The ID of
+// the pepper follows the prefix, "hmac-sha384-bcrypt$<pepper id>$<bcrypt hash>", so hashes
+// keep verifying with a previous pepper after it was replaced.
+const PepperedPrefix = "hmac-sha384-bcrypt$"
+
+// Schemes of the hashes VerifyPassword accepts, see Scheme; legacy hashes are named by their
+// format, see LegacySHA1SaltPassword
+const (
+	SchemeBcrypt          = "bcrypt"
+	SchemePreHashedBcrypt = "sha384-bcrypt"
+	SchemePepperedBcrypt  = "hmac-sha384-bcrypt"
+)
+
+// pepper is a configured pepper and the ID its hashes are tagged with
+type pepper struct {
+	id  string
+	key []byte
+}
+
 // Hasher provides password hashing and verification functionality
 type Hasher struct {
 	cost int
+	// peppers are the configured peppers, the one new hashes are keyed with first
+	peppers []pepper
 }
 
-// NewHasher creates a new password hasher with the specified cost
-func NewHasher(cost int) *Hasher {
+// NewHasher creates a new password hasher with the specified cost. With peppers, new hashes
+// are keyed with the first one while hashes keyed with the others still verify.
+func NewHasher(cost int, peppers ...string) *Hasher {
 	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
 		cost = bcrypt.DefaultCost
 	}
-	return &Hasher{cost: cost}
+
+	h := &Hasher{cost: cost}
+	for _, key := range peppers {
+		h.peppers = append(h.peppers, pepper{id: PepperID(key), key: []byte(key)})
+	}
+	return h
+}
+
+// PepperID returns the ID hashes keyed with the pepper are tagged with: the first 8 bytes of
+// its SHA-256, hex-encoded, which identify the pepper without revealing it
+func PepperID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
 }
 
-// HashPassword hashes a plain text password using bcrypt
+// HashPassword hashes a plain text password of any length using bcrypt over its HMAC-SHA384
+// keyed with the pepper, tagged with PepperedPrefix, or without a pepper over its SHA-384
+// digest, tagged with PreHashedPrefix
 func (h *Hasher) HashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if len(h.peppers) > 0 {
+		primary := h.peppers[0]
+		hashedBytes, err := bcrypt.GenerateFromPassword(pepperHash(primary.key, password), h.cost)
+		if err != nil {
+			return "", err
+		}
+		return PepperedPrefix + primary.id + "$" + string(hashedBytes), nil
+	}
+
+	hashedBytes, err := bcrypt.GenerateFromPassword(preHash(password), h.cost)
 	if err != nil {
 		return "", err
 	}
-	return string(hashedBytes), nil
+	return PreHashedPrefix + string(hashedBytes), nil
 }
 
-// VerifyPassword verifies a plain text password against a hashed password
+// VerifyPassword verifies a plain text password against a hashed password, either a
+// peppered or pre-hashed bcrypt hash, a bcrypt hash of the password itself or a tagged
+// legacy hash. Peppered hashes fail once their pepper is no longer configured.
 func (h *Hasher) VerifyPassword(hashedPassword, password string) bool {
+	if IsLegacyHash(hashedPassword) {
+		return verifyLegacy(hashedPassword, password)
+	}
+	if rest, ok := strings.CutPrefix(hashedPassword, PepperedPrefix); ok {
+		id, hash, _ := strings.Cut(rest, "$")
+		key, ok := h.pepper(id)
+		if !ok {
+			return false
+		}
+		return bcrypt.CompareHashAndPassword([]byte(hash), pepperHash(key, password)) == nil
+	}
+	if hash, ok := strings.CutPrefix(hashedPassword, PreHashedPrefix); ok {
+		return bcrypt.CompareHashAndPassword([]byte(hash), preHash(password)) == nil
+	}
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
 }
 
+// NeedsRehash reports whether a hash should be replaced with a new hash of the password the
+// next time the user proves it: legacy hashes, bcrypt hashes of a lower cost than the
+// hasher's and, once a pepper is configured, hashes not keyed with it
+func (h *Hasher) NeedsRehash(hashedPassword string) bool {
+	if IsLegacyHash(hashedPassword) {
+		return true
+	}
+
+	hash := hashedPassword
+	if rest, ok := strings.CutPrefix(hashedPassword, PepperedPrefix); ok {
+		var id string
+		id, hash, _ = strings.Cut(rest, "$")
+		if len(h.peppers) == 0 || id != h.peppers[0].id {
+			return true
+		}
+	} else if len(h.peppers) > 0 {
+		return true
+	} else {
+		hash = strings.TrimPrefix(hashedPassword, PreHashedPrefix)
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && cost < h.cost
+}
+
+// Scheme names how a hash was computed: SchemePepperedBcrypt, SchemePreHashedBcrypt,
+// SchemeBcrypt or the format of a legacy hash
+func Scheme(hashedPassword string) string {
+	switch {
+	case IsLegacyHash(hashedPassword):
+		format, _, _ := strings.Cut(hashedPassword, "$")
+		return format
+	case strings.HasPrefix(hashedPassword, PepperedPrefix):
+		return SchemePepperedBcrypt
+	case strings.HasPrefix(hashedPassword, PreHashedPrefix):
+		return SchemePreHashedBcrypt
+	default:
+		return SchemeBcrypt
+	}
+}
+
+// pepper returns the configured pepper with the given ID
+func (h *Hasher) pepper(id string) ([]byte, bool) {
+	for _, p := range h.peppers {
+		if p.id == id {
+			return p.key, true
+		}
+	}
+	return nil, false
+}
+
 // DefaultHasher returns a hasher with default bcrypt cost
 func DefaultHasher() *Hasher {
 	return NewHasher(bcrypt.DefaultCost)
 }
+
+// preHash returns the base64-encoded SHA-384 digest of a password. The raw digest may contain
+// zero bytes, at which the bcrypt of C libraries stops reading, so it is encoded like in the
+// common pre-hash construction; SHA-384 is the longest digest whose encoding, 64 characters,
+// fits bcrypt's 72-byte input.
+func preHash(password string) []byte {
+	sum := sha512.Sum384([]byte(password))
+	return []byte(base64.StdEncoding.EncodeToString(sum[:]))
+}
+
+// pepperHash returns the base64-encoded HMAC-SHA384 of a password keyed with a pepper, which
+// like preHash holds no zero bytes and fits bcrypt's 72-byte input
+func pepperHash(key []byte, password string) []byte {
+	mac := hmac.New(sha512.New384, key)
+	mac.Write([]byte(password))
+	return []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
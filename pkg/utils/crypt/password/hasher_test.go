@@ -1,7 +1,13 @@
 package password
 
 import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
 	"testing"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestHasher_HashPassword(t *testing.T) {
@@ -64,3 +70,156 @@ func TestHasher_DifferentCosts(t *testing.T) {
 		}
 	}
 }
+
+func TestHasher_LongPasswords(t *testing.T) {
+	hasher := NewHasher(4)
+	prefix := strings.Repeat("correct horse battery staple ", 3)
+
+	hashedPassword, err := hasher.HashPassword(prefix + "one")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	if !strings.HasPrefix(hashedPassword, PreHashedPrefix) {
+		t.Errorf("Expected a pre-hashed hash, got %q", hashedPassword)
+	}
+
+	if !hasher.VerifyPassword(hashedPassword, prefix+"one") {
+		t.Error("Password verification should succeed for the long password")
+	}
+	// bcrypt alone would only compare the first 72 bytes, which both passwords share
+	if hasher.VerifyPassword(hashedPassword, prefix+"two") {
+		t.Error("Password verification should fail for a password differing after 72 bytes")
+	}
+}
+
+func TestHasher_PreHashPortable(t *testing.T) {
+	hashedPassword, err := NewHasher(4).HashPassword("testPassword123!")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+
+	// Other bcrypt implementations verify the hash given the encoded digest, which holds no
+	// zero byte they would stop at
+	digest := sha512.Sum384([]byte("testPassword123!"))
+	encoded := base64.StdEncoding.EncodeToString(digest[:])
+	if len(encoded) > 72 || strings.ContainsRune(encoded, 0) {
+		t.Fatalf("Expected a bcrypt input of at most 72 bytes without zero bytes, got %q", encoded)
+	}
+	hash := strings.TrimPrefix(hashedPassword, PreHashedPrefix)
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(encoded)); err != nil {
+		t.Errorf("Expected the bcrypt hash to be of the encoded digest, got %v", err)
+	}
+}
+
+func TestHasher_VerifyUnprefixedBcrypt(t *testing.T) {
+	hasher := NewHasher(4)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("testPassword123!"), 4)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+
+	if !hasher.VerifyPassword(string(hashedPassword), "testPassword123!") {
+		t.Error("Password verification should succeed for a bcrypt hash of the password itself")
+	}
+	if hasher.VerifyPassword(string(hashedPassword), "wrongPassword") {
+		t.Error("Password verification should fail for incorrect password")
+	}
+	if hasher.NeedsRehash(string(hashedPassword)) {
+		t.Error("Expected a bcrypt hash of the password itself not to need a rehash")
+	}
+}
+
+func TestHasher_Pepper(t *testing.T) {
+	const oldPepper, newPepper = "old-pepper-0123456789abcdef0123456789", "new-pepper-0123456789abcdef0123456789"
+
+	hasher := NewHasher(4, oldPepper)
+	hashedPassword, err := hasher.HashPassword("testPassword123!")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	if !strings.HasPrefix(hashedPassword, PepperedPrefix+PepperID(oldPepper)+"$") {
+		t.Errorf("Expected a hash tagged with the pepper ID, got %q", hashedPassword)
+	}
+	if !hasher.VerifyPassword(hashedPassword, "testPassword123!") || hasher.NeedsRehash(hashedPassword) {
+		t.Error("Password verification should succeed without a rehash for the current pepper")
+	}
+
+	// Like the pre-hash, the bcrypt input is the encoded HMAC
+	mac := hmac.New(sha512.New384, []byte(oldPepper))
+	mac.Write([]byte("testPassword123!"))
+	hash := strings.TrimPrefix(hashedPassword, PepperedPrefix+PepperID(oldPepper)+"$")
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))); err != nil {
+		t.Errorf("Expected the bcrypt hash to be of the encoded HMAC, got %v", err)
+	}
+
+	// The hash is useless without the pepper
+	if NewHasher(4).VerifyPassword(hashedPassword, "testPassword123!") {
+		t.Error("Password verification should fail without the pepper")
+	}
+
+	// Once the pepper is replaced, hashes keyed with the previous one verify and are rehashed
+	rotated := NewHasher(4, newPepper, oldPepper)
+	if !rotated.VerifyPassword(hashedPassword, "testPassword123!") {
+		t.Error("Password verification should succeed with the previous pepper")
+	}
+	if rotated.VerifyPassword(hashedPassword, "wrongPassword") {
+		t.Error("Password verification should fail for incorrect password")
+	}
+	if !rotated.NeedsRehash(hashedPassword) {
+		t.Error("Expected a hash keyed with the previous pepper to need a rehash")
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	unpeppered, err := NewHasher(4).HashPassword("testPassword123!")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	costlier, err := NewHasher(bcrypt.MinCost + 1).HashPassword("testPassword123!")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	legacy, err := LegacyHash(LegacySHA1SaltPassword, "salt", "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3")
+	if err != nil {
+		t.Fatalf("Failed to tag legacy hash: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		hasher   *Hasher
+		hash     string
+		expected bool
+	}{
+		{"same cost", NewHasher(4), unpeppered, false},
+		{"lower cost", NewHasher(5), unpeppered, true},
+		{"higher cost", NewHasher(bcrypt.MinCost), costlier, false},
+		{"pepper configured", NewHasher(4, "pepper-0123456789abcdef0123456789ab"), unpeppered, true},
+		{"legacy", NewHasher(4), legacy, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(tt.hash); got != tt.expected {
+				t.Errorf("Expected NeedsRehash %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestScheme(t *testing.T) {
+	peppered, _ := NewHasher(4, "pepper-0123456789abcdef0123456789ab").HashPassword("testPassword123!")
+	preHashed, _ := NewHasher(4).HashPassword("testPassword123!")
+	plain, _ := bcrypt.GenerateFromPassword([]byte("testPassword123!"), 4)
+	legacy, _ := LegacyHash(LegacySHA1PasswordSalt, "salt", "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3")
+
+	for hash, expected := range map[string]string{
+		peppered:      SchemePepperedBcrypt,
+		preHashed:     SchemePreHashedBcrypt,
+		string(plain): SchemeBcrypt,
+		legacy:        LegacySHA1PasswordSalt,
+	} {
+		if got := Scheme(hash); got != expected {
+			t.Errorf("Expected scheme %s, got %s", expected, got)
+		}
+	}
+}
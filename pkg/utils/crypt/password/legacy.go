@@ -0,0 +1,65 @@
+package password
+
+import (
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Hash formats of the legacy ticketing system, which stored a salted SHA-1 of passwords.
+// Imported hashes are stored tagged with their format as "<format>$<salt>$<hex digest>" and
+// are replaced with a bcrypt hash on the first successful login.
+const (
+	// LegacySHA1SaltPassword is the SHA-1 of the salt followed by the password
+	LegacySHA1SaltPassword = "sha1-salt-password"
+	// LegacySHA1PasswordSalt is the SHA-1 of the password followed by the salt
+	LegacySHA1PasswordSalt = "sha1-password-salt"
+)
+
+// LegacyHash tags a legacy salted SHA-1 digest with its format
+func LegacyHash(format, salt, digest string) (string, error) {
+	if format != LegacySHA1SaltPassword && format != LegacySHA1PasswordSalt {
+		return "", fmt.Errorf("unknown legacy hash format %q", format)
+	}
+	raw, err := hex.DecodeString(digest)
+	if err != nil || len(raw) != sha1.Size {
+		return "", fmt.Errorf("legacy hash must be a hex SHA-1 digest")
+	}
+
+	return format + "$" + salt + "$" + hex.EncodeToString(raw), nil
+}
+
+// IsLegacyHash reports whether a hash is a tagged legacy hash
+func IsLegacyHash(hash string) bool {
+	return strings.HasPrefix(hash, LegacySHA1SaltPassword+"$") || strings.HasPrefix(hash, LegacySHA1PasswordSalt+"$")
+}
+
+// verifyLegacy verifies a password against a tagged legacy hash. The salt may contain "$",
+// as the format is the first field and the digest the last.
+func verifyLegacy(hash, password string) bool {
+	format, rest, _ := strings.Cut(hash, "$")
+	i := strings.LastIndexByte(rest, '$')
+	if i < 0 {
+		return false
+	}
+	salt, digest := rest[:i], rest[i+1:]
+
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	var sum [sha1.Size]byte
+	switch format {
+	case LegacySHA1SaltPassword:
+		sum = sha1.Sum([]byte(salt + password))
+	case LegacySHA1PasswordSalt:
+		sum = sha1.Sum([]byte(password + salt))
+	default:
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(sum[:], expected) == 1
+}
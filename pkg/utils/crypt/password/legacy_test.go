@@ -0,0 +1,63 @@
+package password
+
+import (
+	"testing"
+)
+
+func TestHasher_VerifyLegacyPassword(t *testing.T) {
+	hasher := DefaultHasher()
+
+	tests := []struct {
+		name     string
+		format   string
+		salt     string
+		digest   string
+		password string
+	}{
+		// SHA-1 of "abc", upper case as some legacy exports have it
+		{name: "salt before password", format: LegacySHA1SaltPassword, salt: "a", digest: "A9993E364706816ABA3E25717850C26C9CD0D89D", password: "bc"},
+		{name: "salt after password", format: LegacySHA1PasswordSalt, salt: "c", digest: "a9993e364706816aba3e25717850c26c9cd0d89d", password: "ab"},
+		// SHA-1 of "a$bc"
+		{name: "salt containing the separator", format: LegacySHA1SaltPassword, salt: "a$", digest: "2ec76619446fbc323ba57dd7d15cd6fcf71642dc", password: "bc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := LegacyHash(tt.format, tt.salt, tt.digest)
+			if err != nil {
+				t.Fatalf("Failed to tag legacy hash: %v", err)
+			}
+
+			if !hasher.NeedsRehash(hash) {
+				t.Errorf("Expected %q to need a rehash", hash)
+			}
+			if !hasher.VerifyPassword(hash, tt.password) {
+				t.Error("Password verification should succeed for the legacy hash")
+			}
+			if hasher.VerifyPassword(hash, tt.password+"x") {
+				t.Error("Password verification should fail for a wrong password")
+			}
+		})
+	}
+}
+
+func TestLegacyHash_Invalid(t *testing.T) {
+	if _, err := LegacyHash("md5", "a", "a9993e364706816aba3e25717850c26c9cd0d89d"); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+	if _, err := LegacyHash(LegacySHA1SaltPassword, "a", "a9993e36"); err == nil {
+		t.Error("Expected a short digest to be rejected")
+	}
+}
+
+func TestHasher_NeedsRehash_Bcrypt(t *testing.T) {
+	hasher := NewHasher(4)
+	hashedPassword, err := hasher.HashPassword("testPassword123!")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+
+	if hasher.NeedsRehash(hashedPassword) {
+		t.Error("Expected a bcrypt hash not to need a rehash")
+	}
+}
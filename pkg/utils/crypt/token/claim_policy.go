@@ -0,0 +1,148 @@
+package token
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+
+	"user-svc/pkg/utils/metrics"
+)
+
+var ErrClaimPolicyViolation = errors.New("token claims violate the claim policy")
+
+// ClaimsMode is how strictly ClaimPolicy checks the claims of verified tokens
+type ClaimsMode string
+
+const (
+	// ClaimsModeOff accepts any claims the signature covers
+	ClaimsModeOff ClaimsMode = "off"
+	// ClaimsModeReport counts the violations but accepts the tokens, to find out what a
+	// switch to ClaimsModeEnforce would reject
+	ClaimsModeReport ClaimsMode = "report"
+	// ClaimsModeEnforce rejects tokens violating the policy
+	ClaimsModeEnforce ClaimsMode = "enforce"
+)
+
+// ClaimViolation is a way a token fails the claim policy
+type ClaimViolation string
+
+const (
+	ClaimViolationIssuer     ClaimViolation = "issuer"
+	ClaimViolationAudience   ClaimViolation = "audience"
+	ClaimViolationTokenID    ClaimViolation = "jti"
+	ClaimViolationUnexpected ClaimViolation = "unexpected_claim"
+	ClaimViolationCritical   ClaimViolation = "critical"
+)
+
+// knownClaims are the claims the service issues, the JSON names of the fields of Payload
+var knownClaims = func() []string {
+	var names []string
+	payload := reflect.TypeOf(Payload{})
+	for i := range payload.NumField() {
+		if name, _, _ := strings.Cut(payload.Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}()
+
+// ClaimPolicy is the issuer and audience of the tokens the service creates, and how strictly
+// verified tokens must match them: tokens minted by other services that share the secret
+// lack them or carry claims the service never issues
+type ClaimPolicy struct {
+	Issuer string
+	// Audience is the audience of tokens other than delegation tokens, which carry the
+	// service they were exchanged for; "" leaves them without one
+	Audience string
+	Mode     ClaimsMode
+}
+
+// Violations returns the ways the claims of a verified token fail the policy: an issuer or
+// a jti other than its own, a missing audience, a user token meant for another audience,
+// claims the service does not issue and extensions marked critical
+func (policy ClaimPolicy) Violations(payload *Payload) []ClaimViolation {
+	var violations []ClaimViolation
+
+	if payload.Issuer == "" || payload.Issuer != policy.Issuer {
+		violations = append(violations, ClaimViolationIssuer)
+	}
+	if payload.Audience == "" || (!payload.IsDelegation() && payload.Audience != policy.Audience) {
+		violations = append(violations, ClaimViolationAudience)
+	}
+	if payload.JTI == "" || payload.JTI != payload.ID.String() {
+		violations = append(violations, ClaimViolationTokenID)
+	}
+	if slices.ContainsFunc(payload.claims, func(claim string) bool { return !slices.Contains(knownClaims, claim) }) {
+		violations = append(violations, ClaimViolationUnexpected)
+	}
+	if len(payload.critical) > 0 {
+		violations = append(violations, ClaimViolationCritical)
+	}
+
+	return violations
+}
+
+// ClaimPolicyTokenMaker decorates a token maker so that created tokens carry the issuer and
+// audience of the policy and verified tokens are checked against it
+type ClaimPolicyTokenMaker struct {
+	TokenMaker
+	policy ClaimPolicy
+}
+
+func NewClaimPolicyTokenMaker(next TokenMaker, policy ClaimPolicy) *ClaimPolicyTokenMaker {
+	return &ClaimPolicyTokenMaker{
+		TokenMaker: next,
+		policy:     policy,
+	}
+}
+
+// options puts the policy's claims before opts, so WithDelegation still sets the audience
+func (m *ClaimPolicyTokenMaker) options(opts []ClaimOption) []ClaimOption {
+	return append([]ClaimOption{WithIssuer(m.policy.Issuer), WithAudience(m.policy.Audience)}, opts...)
+}
+
+// check counts the violations of a verified token and rejects it in ClaimsModeEnforce
+func (m *ClaimPolicyTokenMaker) check(payload *Payload) (*Payload, error) {
+	if m.policy.Mode == ClaimsModeOff {
+		return payload, nil
+	}
+
+	violations := m.policy.Violations(payload)
+	for _, violation := range violations {
+		metrics.TokenClaimViolations.WithLabelValues(string(violation), string(m.policy.Mode)).Inc()
+	}
+	if len(violations) > 0 && m.policy.Mode == ClaimsModeEnforce {
+		return nil, ErrClaimPolicyViolation
+	}
+
+	return payload, nil
+}
+
+func (m *ClaimPolicyTokenMaker) CreateTokenPair(userID string, username string, duration int64, opts ...ClaimOption) (string, string, error) {
+	return m.TokenMaker.CreateTokenPair(userID, username, duration, m.options(opts)...)
+}
+
+func (m *ClaimPolicyTokenMaker) CreateAccessToken(userID string, username string, duration int64, opts ...ClaimOption) (string, error) {
+	return m.TokenMaker.CreateAccessToken(userID, username, duration, m.options(opts)...)
+}
+
+func (m *ClaimPolicyTokenMaker) CreateRefreshToken(userID string, username string, duration int64, opts ...ClaimOption) (string, error) {
+	return m.TokenMaker.CreateRefreshToken(userID, username, duration, m.options(opts)...)
+}
+
+func (m *ClaimPolicyTokenMaker) VerifyAccessToken(token string) (*Payload, error) {
+	payload, err := m.TokenMaker.VerifyAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return m.check(payload)
+}
+
+func (m *ClaimPolicyTokenMaker) VerifyRefreshToken(token string) (*Payload, error) {
+	payload, err := m.TokenMaker.VerifyRefreshToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return m.check(payload)
+}
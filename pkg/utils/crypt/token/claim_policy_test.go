@@ -0,0 +1,218 @@
+package token
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signClaims signs arbitrary claims with oldSecret, like a sibling service sharing the secret
+func signClaims(t *testing.T, claims jwt.MapClaims, header map[string]interface{}) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	for name, value := range header {
+		token.Header[name] = value
+	}
+	signed, err := token.SignedString([]byte(oldSecret))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func siblingClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"id":         "0b7e5bde-6a2d-4c5e-9f3a-2f1d6c1b9a10",
+		"jti":        "0b7e5bde-6a2d-4c5e-9f3a-2f1d6c1b9a10",
+		"iss":        "user-svc",
+		"aud":        "booking-platform",
+		"user_id":    "user-1",
+		"username":   "jane_doe",
+		"issued_at":  time.Now().Unix(),
+		"expired_at": time.Now().Add(time.Minute).Unix(),
+	}
+}
+
+func TestClaimPolicyTokenMaker_CreatesCompliantTokens(t *testing.T) {
+	policy := ClaimPolicy{Issuer: "user-svc", Audience: "booking-platform", Mode: ClaimsModeEnforce}
+	maker := NewClaimPolicyTokenMaker(NewJWTTokenMaker(oldSecret), policy)
+
+	userToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60, WithClaimProfile(ClaimProfileMinimal))
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	payload, err := maker.VerifyAccessToken(userToken)
+	if err != nil {
+		t.Fatalf("Expected own token to satisfy the policy, got %v", err)
+	}
+	if payload.Issuer != "user-svc" || payload.Audience != "booking-platform" || payload.JTI != payload.ID.String() {
+		t.Errorf("Expected iss, aud and jti to be set, got %+v", payload)
+	}
+
+	// Delegation tokens keep the audience they were exchanged for
+	delegationToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60,
+		WithDelegation("ledger-svc", []string{"payments:read"}, &Actor{Subject: "payment-svc"}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	payload, err = maker.VerifyAccessToken(delegationToken)
+	if err != nil {
+		t.Fatalf("Expected delegation token to satisfy the policy, got %v", err)
+	}
+	if payload.Audience != "ledger-svc" {
+		t.Errorf("Expected delegation audience ledger-svc, got %q", payload.Audience)
+	}
+
+	// Refresh tokens are checked as well
+	_, refreshToken, err := maker.CreateTokenPair("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create tokens: %v", err)
+	}
+	if _, err := maker.VerifyRefreshToken(refreshToken); err != nil {
+		t.Errorf("Expected own refresh token to satisfy the policy, got %v", err)
+	}
+}
+
+func TestClaimPolicy_Violations(t *testing.T) {
+	policy := ClaimPolicy{Issuer: "user-svc", Audience: "booking-platform", Mode: ClaimsModeEnforce}
+
+	tests := []struct {
+		name   string
+		modify func(claims jwt.MapClaims)
+		header map[string]interface{}
+		want   []ClaimViolation
+	}{
+		{name: "compliant", modify: func(jwt.MapClaims) {}},
+		{
+			name:   "missing issuer",
+			modify: func(claims jwt.MapClaims) { delete(claims, "iss") },
+			want:   []ClaimViolation{ClaimViolationIssuer},
+		},
+		{
+			name:   "other issuer",
+			modify: func(claims jwt.MapClaims) { claims["iss"] = "inventory-svc" },
+			want:   []ClaimViolation{ClaimViolationIssuer},
+		},
+		{
+			name:   "missing audience",
+			modify: func(claims jwt.MapClaims) { delete(claims, "aud") },
+			want:   []ClaimViolation{ClaimViolationAudience},
+		},
+		{
+			name:   "user token for another audience",
+			modify: func(claims jwt.MapClaims) { claims["aud"] = "ledger-svc" },
+			want:   []ClaimViolation{ClaimViolationAudience},
+		},
+		{
+			name: "delegation token for another audience",
+			modify: func(claims jwt.MapClaims) {
+				claims["aud"] = "ledger-svc"
+				claims["act"] = map[string]interface{}{"sub": "payment-svc"}
+			},
+		},
+		{
+			name:   "missing jti",
+			modify: func(claims jwt.MapClaims) { delete(claims, "jti") },
+			want:   []ClaimViolation{ClaimViolationTokenID},
+		},
+		{
+			name:   "jti other than the id",
+			modify: func(claims jwt.MapClaims) { claims["jti"] = "4d9c3b1a-0f7e-4a26-8b5d-3e2c1a0f9d87" },
+			want:   []ClaimViolation{ClaimViolationTokenID},
+		},
+		{
+			name:   "unexpected claim",
+			modify: func(claims jwt.MapClaims) { claims["admin"] = true },
+			want:   []ClaimViolation{ClaimViolationUnexpected},
+		},
+		{
+			name:   "critical extension",
+			modify: func(jwt.MapClaims) {},
+			header: map[string]interface{}{"crit": []string{"exp-v2"}, "exp-v2": true},
+			want:   []ClaimViolation{ClaimViolationCritical},
+		},
+		{
+			name: "minted by a sibling service",
+			modify: func(claims jwt.MapClaims) {
+				delete(claims, "iss")
+				delete(claims, "aud")
+				delete(claims, "jti")
+				claims["tenant"] = "acme"
+			},
+			want: []ClaimViolation{ClaimViolationIssuer, ClaimViolationAudience, ClaimViolationTokenID, ClaimViolationUnexpected},
+		},
+	}
+
+	jwtMaker := NewJWTTokenMaker(oldSecret)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := siblingClaims()
+			tt.modify(claims)
+
+			payload, err := jwtMaker.VerifyAccessToken(signClaims(t, claims, tt.header))
+			if err != nil {
+				t.Fatalf("Failed to verify token: %v", err)
+			}
+			if got := policy.Violations(payload); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected violations %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClaimPolicyTokenMaker_Modes(t *testing.T) {
+	claims := siblingClaims()
+	claims["tenant"] = "acme"
+	token := signClaims(t, claims, nil)
+
+	for mode, wantErr := range map[ClaimsMode]error{
+		ClaimsModeOff:     nil,
+		ClaimsModeReport:  nil,
+		ClaimsModeEnforce: ErrClaimPolicyViolation,
+	} {
+		policy := ClaimPolicy{Issuer: "user-svc", Audience: "booking-platform", Mode: mode}
+		maker := NewClaimPolicyTokenMaker(NewJWTTokenMaker(oldSecret), policy)
+
+		if _, err := maker.VerifyAccessToken(token); !errors.Is(err, wantErr) {
+			t.Errorf("Expected %v in mode %s, got %v", wantErr, mode, err)
+		}
+	}
+}
+
+func TestJWTTokenMaker_RejectsMalformedCriticalHeader(t *testing.T) {
+	maker := NewJWTTokenMaker(oldSecret)
+
+	for _, crit := range []interface{}{"exp-v2", []string{}, []interface{}{1}} {
+		token := signClaims(t, siblingClaims(), map[string]interface{}{"crit": crit})
+		if _, err := maker.VerifyAccessToken(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Expected ErrInvalidToken for crit %v, got %v", crit, err)
+		}
+	}
+}
+
+func TestPasetoTokenMaker_ClaimPolicy(t *testing.T) {
+	policy := ClaimPolicy{Issuer: "user-svc", Audience: "booking-platform", Mode: ClaimsModeEnforce}
+
+	compliant := NewClaimPolicyTokenMaker(NewPasetoTokenMaker(oldSecret), policy)
+	token, err := compliant.CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if _, err := compliant.VerifyAccessToken(token); err != nil {
+		t.Errorf("Expected own PASETO token to satisfy the policy, got %v", err)
+	}
+
+	// Tokens created without the policy lack the issuer and audience
+	token, err = NewPasetoTokenMaker(oldSecret).CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if _, err := compliant.VerifyAccessToken(token); !errors.Is(err, ErrClaimPolicyViolation) {
+		t.Errorf("Expected ErrClaimPolicyViolation, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package token
+
+import (
+	"errors"
+	"strings"
+
+	"user-svc/pkg/utils/metrics"
+)
+
+var ErrUnknownTokenFormat = errors.New("token format is not registered")
+
+// TokenFormat is the wire format of a token
+type TokenFormat string
+
+const (
+	// TokenFormatJWT is an HS256 JWT of JWTTokenMaker, JWE-wrapped when encrypted
+	TokenFormatJWT TokenFormat = "jwt"
+	// TokenFormatPASETOv4 is a PASETO v4.public token of PasetoTokenMaker
+	TokenFormatPASETOv4 TokenFormat = "paseto_v4"
+)
+
+// RegisteredMaker is a maker of a CompositeTokenMaker and the format of its tokens
+type RegisteredMaker struct {
+	Format TokenFormat
+	Maker  TokenMaker
+	// Recognizes tells the maker's tokens apart by their shape, without verifying them
+	Recognizes func(token string) bool
+}
+
+// IsJWT reports whether a token looks like a signed or encrypted JWT, without verifying it:
+// a base64url JSON header and three or five parts
+func IsJWT(token string) bool {
+	parts := strings.Count(token, ".") + 1
+	return strings.HasPrefix(token, "eyJ") && (parts == 3 || parts == jweParts)
+}
+
+// promotableMaker is a maker whose signing keys can be promoted, see JWTTokenMaker.SetPrimaryKey
+type promotableMaker interface {
+	PrimaryKeyID() string
+	KeyIDs() []string
+	SetPrimaryKey(id string) error
+}
+
+// CompositeTokenMaker moves tokens from one format to another without a flag day: new tokens
+// are created by the maker of the primary format, while the tokens of every registered maker
+// keep verifying. Verification tries the makers recognizing the token in registration order
+// and counts the verifications by format, so an old format can be retired once its tokens
+// are no longer seen.
+type CompositeTokenMaker struct {
+	primary RegisteredMaker
+	makers  []RegisteredMaker
+}
+
+// NewCompositeTokenMaker creates a maker issuing tokens of the primary format, which must be
+// one of the registered makers
+func NewCompositeTokenMaker(primary TokenFormat, makers ...RegisteredMaker) (*CompositeTokenMaker, error) {
+	for _, maker := range makers {
+		if maker.Format == primary {
+			return &CompositeTokenMaker{primary: maker, makers: makers}, nil
+		}
+	}
+
+	return nil, ErrUnknownTokenFormat
+}
+
+// Format returns the format new tokens are created in
+func (maker *CompositeTokenMaker) Format() TokenFormat {
+	return maker.primary.Format
+}
+
+// create creates a token with the primary maker. Tokens the primary format cannot encrypt
+// are left to the first other maker that can, so encrypting clients keep working.
+func (maker *CompositeTokenMaker) create(create func(TokenMaker) (string, error)) (string, error) {
+	token, err := create(maker.primary.Maker)
+	if !errors.Is(err, ErrEncryptionUnsupported) {
+		return token, err
+	}
+
+	for _, registered := range maker.makers {
+		if registered.Format == maker.primary.Format {
+			continue
+		}
+		token, err = create(registered.Maker)
+		if !errors.Is(err, ErrEncryptionUnsupported) {
+			return token, err
+		}
+	}
+
+	return "", err
+}
+
+// verify verifies the token with the makers recognizing it, in registration order, and
+// counts the verification under the format of the maker that decided it
+func (maker *CompositeTokenMaker) verify(token string, verify func(TokenMaker, string) (*Payload, error)) (*Payload, error) {
+	format, err := "unknown", ErrInvalidToken
+	for _, registered := range maker.makers {
+		if !registered.Recognizes(token) {
+			continue
+		}
+
+		var payload *Payload
+		format = string(registered.Format)
+		payload, err = verify(registered.Maker, token)
+		if err == nil {
+			metrics.TokenVerifications.WithLabelValues(format, "valid").Inc()
+			return payload, nil
+		}
+	}
+
+	result := "invalid"
+	if errors.Is(err, ErrExpiredToken) {
+		result = "expired"
+	}
+	metrics.TokenVerifications.WithLabelValues(format, result).Inc()
+
+	return nil, err
+}
+
+func (maker *CompositeTokenMaker) CreateAccessToken(userID string, username string, duration int64, opts ...ClaimOption) (string, error) {
+	return maker.create(func(m TokenMaker) (string, error) {
+		return m.CreateAccessToken(userID, username, duration, opts...)
+	})
+}
+
+func (maker *CompositeTokenMaker) CreateTokenPair(userID string, username string, duration int64, opts ...ClaimOption) (string, string, error) {
+	accessToken, err := maker.CreateAccessToken(userID, username, duration, opts...)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, err := maker.CreateRefreshToken(userID, username, duration, opts...)
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+func (maker *CompositeTokenMaker) CreateRefreshToken(userID string, username string, duration int64, opts ...ClaimOption) (string, error) {
+	return maker.create(func(m TokenMaker) (string, error) {
+		return m.CreateRefreshToken(userID, username, duration, opts...)
+	})
+}
+
+func (maker *CompositeTokenMaker) VerifyAccessToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenMaker.VerifyAccessToken)
+}
+
+func (maker *CompositeTokenMaker) VerifyRefreshToken(token string) (*Payload, error) {
+	return maker.verify(token, TokenMaker.VerifyRefreshToken)
+}
+
+// PrimaryKeyID returns the ID of the key the primary maker signs with, "" if its keys cannot
+// be promoted
+func (maker *CompositeTokenMaker) PrimaryKeyID() string {
+	if ring, ok := maker.primary.Maker.(promotableMaker); ok {
+		return ring.PrimaryKeyID()
+	}
+	return ""
+}
+
+// KeyIDs returns the IDs of the keys of the primary maker, the primary one first
+func (maker *CompositeTokenMaker) KeyIDs() []string {
+	if ring, ok := maker.primary.Maker.(promotableMaker); ok {
+		return ring.KeyIDs()
+	}
+	return nil
+}
+
+// SetPrimaryKey promotes the key with the given ID in every registered maker whose keys can
+// be promoted, so a later switch of the primary format keeps signing with the promoted key
+func (maker *CompositeTokenMaker) SetPrimaryKey(id string) error {
+	for _, registered := range maker.makers {
+		if ring, ok := registered.Maker.(promotableMaker); ok {
+			if err := ring.SetPrimaryKey(id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
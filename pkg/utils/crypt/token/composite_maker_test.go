@@ -0,0 +1,118 @@
+package token
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestCompositeMaker(t *testing.T, primary TokenFormat, formats ...TokenFormat) *CompositeTokenMaker {
+	t.Helper()
+
+	makers := map[TokenFormat]RegisteredMaker{
+		TokenFormatJWT: {Format: TokenFormatJWT, Maker: NewJWTTokenMaker(oldSecret, newSecret), Recognizes: IsJWT},
+		TokenFormatPASETOv4: {
+			Format: TokenFormatPASETOv4, Maker: NewPasetoTokenMaker(oldSecret, newSecret), Recognizes: IsPasetoV4Public,
+		},
+	}
+
+	var registered []RegisteredMaker
+	for _, format := range formats {
+		registered = append(registered, makers[format])
+	}
+
+	maker, err := NewCompositeTokenMaker(primary, registered...)
+	if err != nil {
+		t.Fatalf("Failed to create composite maker: %v", err)
+	}
+	return maker
+}
+
+func TestCompositeTokenMaker_Migration(t *testing.T) {
+	// Every replica accepts both formats before any issues the new one
+	before := newTestCompositeMaker(t, TokenFormatJWT, TokenFormatJWT, TokenFormatPASETOv4)
+	after := newTestCompositeMaker(t, TokenFormatPASETOv4, TokenFormatPASETOv4, TokenFormatJWT)
+
+	jwtToken, err := before.CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	pasetoToken, err := after.CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if !IsJWT(jwtToken) || !IsPasetoV4Public(pasetoToken) {
+		t.Fatalf("Expected a JWT and a PASETO token, got %s and %s", jwtToken, pasetoToken)
+	}
+
+	for _, maker := range []*CompositeTokenMaker{before, after} {
+		for name, token := range map[string]string{"jwt": jwtToken, "paseto": pasetoToken} {
+			if payload, err := maker.VerifyAccessToken(token); err != nil || payload.UserID != "user-1" {
+				t.Errorf("Expected %s token of user-1 to verify with %s as primary, got %v", name, maker.Format(), err)
+			}
+		}
+	}
+
+	// Once the old format is retired its tokens are rejected
+	retired := newTestCompositeMaker(t, TokenFormatPASETOv4, TokenFormatPASETOv4)
+	if _, err := retired.VerifyAccessToken(jwtToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a retired format, got %v", err)
+	}
+	if _, err := retired.VerifyRefreshToken("not-a-token"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for an unknown format, got %v", err)
+	}
+}
+
+func TestCompositeTokenMaker_EncryptedTokensFallBack(t *testing.T) {
+	jwtMaker := NewJWTTokenMaker(oldSecret)
+	jwtMaker.EnableEncryption("encryption-0123456789abcdef0123456789")
+
+	maker, err := NewCompositeTokenMaker(TokenFormatPASETOv4,
+		RegisteredMaker{Format: TokenFormatPASETOv4, Maker: NewPasetoTokenMaker(oldSecret), Recognizes: IsPasetoV4Public},
+		RegisteredMaker{Format: TokenFormatJWT, Maker: jwtMaker, Recognizes: IsJWT},
+	)
+	if err != nil {
+		t.Fatalf("Failed to create composite maker: %v", err)
+	}
+
+	encrypted, err := maker.CreateAccessToken("user-1", "jane_doe", 60, WithEncryption(true))
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if !isEncrypted(encrypted) {
+		t.Fatalf("Expected a JWE, got %s", encrypted)
+	}
+	if _, err := maker.VerifyAccessToken(encrypted); err != nil {
+		t.Errorf("Expected encrypted token to verify, got %v", err)
+	}
+
+	// Without a maker that encrypts the token cannot be created
+	pasetoOnly := newTestCompositeMaker(t, TokenFormatPASETOv4, TokenFormatPASETOv4)
+	if _, err := pasetoOnly.CreateAccessToken("user-1", "jane_doe", 60, WithEncryption(true)); !errors.Is(err, ErrEncryptionUnsupported) {
+		t.Errorf("Expected ErrEncryptionUnsupported, got %v", err)
+	}
+}
+
+func TestCompositeTokenMaker_SetPrimaryKeyPromotesEveryFormat(t *testing.T) {
+	maker := newTestCompositeMaker(t, TokenFormatJWT, TokenFormatJWT, TokenFormatPASETOv4)
+
+	if err := maker.SetPrimaryKey(SigningKeyID(newSecret)); err != nil {
+		t.Fatalf("Failed to promote key: %v", err)
+	}
+	for _, registered := range maker.makers {
+		if id := registered.Maker.(promotableMaker).PrimaryKeyID(); id != SigningKeyID(newSecret) {
+			t.Errorf("Expected %s to sign with the promoted key, got %s", registered.Format, id)
+		}
+	}
+	if maker.PrimaryKeyID() != SigningKeyID(newSecret) {
+		t.Errorf("Expected primary key %s, got %s", SigningKeyID(newSecret), maker.PrimaryKeyID())
+	}
+}
+
+func TestNewCompositeTokenMaker_RequiresPrimaryFormat(t *testing.T) {
+	_, err := NewCompositeTokenMaker(TokenFormatPASETOv4,
+		RegisteredMaker{Format: TokenFormatJWT, Maker: NewJWTTokenMaker(oldSecret), Recognizes: IsJWT},
+	)
+	if !errors.Is(err, ErrUnknownTokenFormat) {
+		t.Errorf("Expected ErrUnknownTokenFormat, got %v", err)
+	}
+}
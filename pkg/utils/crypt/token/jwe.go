@@ -0,0 +1,188 @@
+package token
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrUnknownEncryptionKey = errors.New("encryption key is not configured")
+
+// jweHeader is the protected header of the RFC 7516 compact serialization: the signed token
+// is encrypted directly ("dir") with AES-256-GCM under a shared key
+type jweHeader struct {
+	Algorithm   string `json:"alg"`
+	Encryption  string `json:"enc"`
+	ContentType string `json:"cty"`
+	KeyID       string `json:"kid"`
+}
+
+const (
+	jweAlgorithm   = "dir"
+	jweEncryption  = "A256GCM"
+	jweContentType = "JWT"
+	jweParts       = 5
+)
+
+// HKDF labels of the AES key and of the key ID derived from an encryption secret. The key ID
+// is sent in the clear, so unlike SigningKeyID it must not be a part of the key's derivation.
+const (
+	jweKeyLabel   = "jwe-key"
+	jweKeyIDLabel = "jwe-kid"
+	jweKeyIDSize  = 8
+)
+
+// encryptionKey is an AES-256 key derived from the configured secret with HKDF, and the ID put
+// in the "kid" header of the tokens it encrypts, derived separately
+type encryptionKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// encryptionRing holds the key tokens are encrypted with and the keys they are decrypted with
+type encryptionRing struct {
+	primary encryptionKey
+	all     []encryptionKey
+}
+
+func newEncryptionKey(secret string) encryptionKey {
+	if len(secret) < minSecretKeySize {
+		panic("invalid encryption key size: must be at least 32 characters")
+	}
+
+	id, key := deriveEncryptionKey(secret)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+
+	return encryptionKey{id: id, aead: aead}
+}
+
+// deriveEncryptionKey derives the key ID and the AES-256 key of an encryption secret
+func deriveEncryptionKey(secret string) (string, []byte) {
+	key, err := hkdf.Key(sha256.New, []byte(secret), nil, jweKeyLabel, 32)
+	if err != nil {
+		panic(err)
+	}
+	id, err := hkdf.Key(sha256.New, []byte(secret), nil, jweKeyIDLabel, jweKeyIDSize)
+	if err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(id), key
+}
+
+// EnableEncryption lets tokens created WithEncryption be encrypted with key; tokens encrypted
+// with the secondary keys are still decrypted, so the key can be rolled like signing keys.
+// It must be called before the maker is used.
+func (maker *JWTTokenMaker) EnableEncryption(key string, secondaryKeys ...string) {
+	var all []encryptionKey
+	for _, secret := range append([]string{key}, secondaryKeys...) {
+		all = append(all, newEncryptionKey(secret))
+	}
+
+	maker.encryption = &encryptionRing{primary: all[0], all: all}
+}
+
+// encrypt wraps a signed token in a JWE, so its claims are opaque to the client holding it
+func (maker *JWTTokenMaker) encrypt(signed string) (string, error) {
+	if maker.encryption == nil {
+		return "", ErrUnknownEncryptionKey
+	}
+	key := maker.encryption.primary
+
+	header, err := json.Marshal(jweHeader{
+		Algorithm:   jweAlgorithm,
+		Encryption:  jweEncryption,
+		ContentType: jweContentType,
+		KeyID:       key.id,
+	})
+	if err != nil {
+		return "", err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(header)
+
+	iv := make([]byte, key.aead.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+	sealed := key.aead.Seal(nil, iv, []byte(signed), []byte(protected))
+	ciphertext, tag := sealed[:len(sealed)-key.aead.Overhead()], sealed[len(sealed)-key.aead.Overhead():]
+
+	// "dir" has no encrypted key, so the second part is empty
+	return strings.Join([]string{
+		protected,
+		"",
+		base64.RawURLEncoding.EncodeToString(iv),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+		base64.RawURLEncoding.EncodeToString(tag),
+	}, "."), nil
+}
+
+// decrypt returns the signed token inside a JWE, still to be verified
+func (maker *JWTTokenMaker) decrypt(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != jweParts || parts[1] != "" || maker.encryption == nil {
+		return "", ErrInvalidToken
+	}
+
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	var header jweHeader
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return "", ErrInvalidToken
+	}
+	if header.Algorithm != jweAlgorithm || header.Encryption != jweEncryption {
+		return "", ErrInvalidToken
+	}
+
+	var key *encryptionKey
+	for i := range maker.encryption.all {
+		if maker.encryption.all[i].id == header.KeyID {
+			key = &maker.encryption.all[i]
+		}
+	}
+	if key == nil {
+		return "", ErrUnknownEncryptionKey
+	}
+
+	iv, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(iv) != key.aead.NonceSize() {
+		return "", ErrInvalidToken
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	tag, err := base64.RawURLEncoding.DecodeString(parts[4])
+	if err != nil || len(tag) != key.aead.Overhead() {
+		return "", ErrInvalidToken
+	}
+
+	signed, err := key.aead.Open(nil, iv, append(ciphertext, tag...), []byte(parts[0]))
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	return string(signed), nil
+}
+
+// isEncrypted reports whether a token is in the JWE compact serialization, which has five
+// parts where a signed token has three
+func isEncrypted(token string) bool {
+	return strings.Count(token, ".") == jweParts-1
+}
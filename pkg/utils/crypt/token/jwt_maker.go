@@ -1,49 +1,169 @@
 package token
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"sync/atomic"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 var (
-	ErrInvalidToken = errors.New("token is invalid")
-	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken      = errors.New("token is invalid")
+	ErrExpiredToken      = errors.New("token has expired")
+	ErrRevokedToken      = errors.New("token has been revoked")
+	ErrUnknownSigningKey = errors.New("signing key is not configured")
 )
 
 const minSecretKeySize = 32
 
+// signingKey is an HMAC secret and the ID put in the "kid" header of the tokens it signs
+type signingKey struct {
+	id     string
+	secret []byte
+}
+
+// keyRing holds the key new tokens are signed with and the keys tokens are verified with
+type keyRing struct {
+	primary signingKey
+	all     []signingKey
+}
+
 type JWTTokenMaker struct {
-	secretKey string
+	keys atomic.Pointer[keyRing]
+	// encryption is nil unless EnableEncryption was called
+	encryption *encryptionRing
+}
+
+// NewJWTTokenMaker creates a maker that signs with secretKey and also verifies tokens signed
+// with the secondary keys, so a new secret can be rolled out before it is promoted with
+// SetPrimaryKey and the old one removed once its tokens expired
+func NewJWTTokenMaker(secretKey string, secondaryKeys ...string) *JWTTokenMaker {
+	var all []signingKey
+	for _, secret := range append([]string{secretKey}, secondaryKeys...) {
+		if len(secret) < minSecretKeySize {
+			panic("invalid secret key size: must be at least 32 characters")
+		}
+		all = append(all, signingKey{id: SigningKeyID(secret), secret: []byte(secret)})
+	}
+
+	maker := &JWTTokenMaker{}
+	maker.keys.Store(&keyRing{primary: all[0], all: all})
+
+	return maker
+}
+
+// SigningKeyID identifies a secret without revealing it: the first 16 hex characters of its SHA-256
+func SigningKeyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+// PrimaryKeyID returns the ID of the key new tokens are signed with
+func (maker *JWTTokenMaker) PrimaryKeyID() string {
+	return maker.keys.Load().primary.id
+}
+
+// KeyIDs returns the IDs of every key tokens are verified with, the primary one first
+func (maker *JWTTokenMaker) KeyIDs() []string {
+	ring := maker.keys.Load()
+
+	ids := []string{ring.primary.id}
+	for _, key := range ring.all {
+		if key.id != ring.primary.id {
+			ids = append(ids, key.id)
+		}
+	}
+	return ids
 }
 
-func NewJWTTokenMaker(secretKey string) *JWTTokenMaker {
-	if len(secretKey) < minSecretKeySize {
-		panic("invalid secret key size: must be at least 32 characters")
+// SetPrimaryKey signs new tokens with the configured key with the given ID. Every configured
+// key keeps verifying tokens, so tokens signed before the switch stay valid.
+func (maker *JWTTokenMaker) SetPrimaryKey(id string) error {
+	ring := maker.keys.Load()
+	for _, key := range ring.all {
+		if key.id == id {
+			maker.keys.Store(&keyRing{primary: key, all: ring.all})
+			return nil
+		}
 	}
 
-	return &JWTTokenMaker{secretKey: secretKey}
+	return ErrUnknownSigningKey
 }
 
-func (maker *JWTTokenMaker) CreateAccessToken(userID string, username string, duration int64) (string, error) {
+// sign signs the payload with the primary key and names the key in the "kid" header, then
+// encrypts the token if the payload asks for it, see WithEncryption
+func (maker *JWTTokenMaker) sign(payload *Payload) (string, error) {
+	key := maker.keys.Load().primary
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	token.Header["kid"] = key.id
+
+	signed, err := token.SignedString(key.secret)
+	if err != nil || !payload.encrypt {
+		return signed, err
+	}
+	return maker.encrypt(signed)
+}
+
+// parse verifies the token with the key named in its header, after decrypting it if it is
+// encrypted. Tokens signed before keys had IDs are tried with every key.
+func (maker *JWTTokenMaker) parse(token string) (*jwt.Token, error) {
+	if isEncrypted(token) {
+		signed, err := maker.decrypt(token)
+		if err != nil {
+			return nil, err
+		}
+		token = signed
+	}
+
+	ring := maker.keys.Load()
+
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		_, ok := token.Method.(*jwt.SigningMethodHMAC)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		for _, key := range ring.all {
+			if key.id == kid {
+				return key.secret, nil
+			}
+		}
+		if kid != "" {
+			return nil, ErrUnknownSigningKey
+		}
+
+		secrets := make([]jwt.VerificationKey, 0, len(ring.all))
+		for _, key := range ring.all {
+			secrets = append(secrets, key.secret)
+		}
+		return jwt.VerificationKeySet{Keys: secrets}, nil
+	}
+
+	return jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+}
+
+func (maker *JWTTokenMaker) CreateAccessToken(userID string, username string, duration int64, opts ...ClaimOption) (string, error) {
 	payload, err := NewPayload(userID, username, duration)
 	if err != nil {
 		return "", err
 	}
+	payload.apply(opts)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
-
-	return token.SignedString([]byte(maker.secretKey))
+	return maker.sign(payload)
 }
 
-func (maker *JWTTokenMaker) CreateTokenPair(userID string, username string, duration int64) (string, string, error) {
+func (maker *JWTTokenMaker) CreateTokenPair(userID string, username string, duration int64, opts ...ClaimOption) (string, string, error) {
 
-	accessToken, err := maker.CreateAccessToken(userID, username, duration)
+	accessToken, err := maker.CreateAccessToken(userID, username, duration, opts...)
 	if err != nil {
 		return "", "", err
 	}
 
-	refreshToken, err := maker.CreateRefreshToken(userID, username, duration)
+	refreshToken, err := maker.CreateRefreshToken(userID, username, duration, opts...)
 	if err != nil {
 		return "", "", err
 	}
@@ -51,28 +171,18 @@ func (maker *JWTTokenMaker) CreateTokenPair(userID string, username string, dura
 	return accessToken, refreshToken, nil
 }
 
-func (maker *JWTTokenMaker) CreateRefreshToken(userID string, username string, duration int64) (string, error) {
+func (maker *JWTTokenMaker) CreateRefreshToken(userID string, username string, duration int64, opts ...ClaimOption) (string, error) {
 	payload, err := NewPayload(userID, username, duration)
 	if err != nil {
 		return "", err
 	}
+	payload.apply(opts)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
-
-	return token.SignedString([]byte(maker.secretKey))
+	return maker.sign(payload)
 }
 
 func (maker *JWTTokenMaker) VerifyAccessToken(token string) (*Payload, error) {
-	keyFunc := func(token *jwt.Token) (interface{}, error) {
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
-			return nil, ErrInvalidToken
-		}
-
-		return []byte(maker.secretKey), nil
-	}
-
-	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	jwtToken, err := maker.parse(token)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			return nil, ErrExpiredToken
@@ -85,9 +195,39 @@ func (maker *JWTTokenMaker) VerifyAccessToken(token string) (*Payload, error) {
 		return nil, ErrInvalidToken
 	}
 
+	payload.critical, err = criticalExtensions(jwtToken.Header)
+	if err != nil {
+		return nil, err
+	}
+
 	return payload, nil
 }
 
+// criticalExtensions returns the header parameters a token marks critical (RFC 7515 4.1.11),
+// which ClaimPolicy rejects as the service understands none; a malformed "crit" makes the
+// token invalid
+func criticalExtensions(header map[string]interface{}) ([]string, error) {
+	raw, ok := header["crit"]
+	if !ok {
+		return nil, nil
+	}
+
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, ErrInvalidToken
+	}
+	names := make([]string, 0, len(list))
+	for _, name := range list {
+		parameter, ok := name.(string)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		names = append(names, parameter)
+	}
+
+	return names, nil
+}
+
 func (maker *JWTTokenMaker) VerifyRefreshToken(token string) (*Payload, error) {
 	payload, err := maker.VerifyAccessToken(token)
 	return payload, err
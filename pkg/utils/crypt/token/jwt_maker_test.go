@@ -0,0 +1,315 @@
+package token
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	oldSecret = "old-secret-0123456789abcdef0123456789"
+	newSecret = "new-secret-0123456789abcdef0123456789"
+)
+
+func TestJWTTokenMaker_SetPrimaryKeyKeepsOldTokensValid(t *testing.T) {
+	maker := NewJWTTokenMaker(oldSecret, newSecret)
+
+	oldToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	if err := maker.SetPrimaryKey(SigningKeyID(newSecret)); err != nil {
+		t.Fatalf("Failed to promote key: %v", err)
+	}
+	if maker.PrimaryKeyID() != SigningKeyID(newSecret) {
+		t.Errorf("Expected primary key %s, got %s", SigningKeyID(newSecret), maker.PrimaryKeyID())
+	}
+
+	newToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+		if _, err := maker.VerifyAccessToken(token); err != nil {
+			t.Errorf("Expected %s token to verify, got %v", name, err)
+		}
+	}
+
+	// Once the old secret is removed, only tokens signed with the new one verify
+	rotated := NewJWTTokenMaker(newSecret)
+	if _, err := rotated.VerifyAccessToken(newToken); err != nil {
+		t.Errorf("Expected new token to verify, got %v", err)
+	}
+	if _, err := rotated.VerifyAccessToken(oldToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestJWTTokenMaker_SetPrimaryKeyRejectsUnknownKey(t *testing.T) {
+	maker := NewJWTTokenMaker(oldSecret)
+
+	if err := maker.SetPrimaryKey(SigningKeyID(newSecret)); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Errorf("Expected ErrUnknownSigningKey, got %v", err)
+	}
+	if maker.PrimaryKeyID() != SigningKeyID(oldSecret) {
+		t.Errorf("Expected primary key to stay %s, got %s", SigningKeyID(oldSecret), maker.PrimaryKeyID())
+	}
+}
+
+func TestJWTTokenMaker_VerifiesTokensWithoutKeyID(t *testing.T) {
+	payload, err := NewPayload("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create payload: %v", err)
+	}
+	// Tokens issued before signing keys had IDs carry no kid header
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, payload).SignedString([]byte(oldSecret))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	maker := NewJWTTokenMaker(newSecret, oldSecret)
+	if _, err := maker.VerifyAccessToken(legacy); err != nil {
+		t.Errorf("Expected legacy token to verify with the secondary key, got %v", err)
+	}
+}
+
+func TestJWTTokenMaker_OrganizationClaim(t *testing.T) {
+	maker := NewJWTTokenMaker(oldSecret)
+	organizationID := uuid.New()
+
+	for expected, id := range map[string]uuid.UUID{organizationID.String(): organizationID, "": uuid.Nil} {
+		accessToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60, WithOrganization(id))
+		if err != nil {
+			t.Fatalf("Failed to create token: %v", err)
+		}
+
+		payload, err := maker.VerifyAccessToken(accessToken)
+		if err != nil {
+			t.Fatalf("Failed to verify token: %v", err)
+		}
+		if payload.OrganizationID != expected {
+			t.Errorf("Expected organization %q, got %q", expected, payload.OrganizationID)
+		}
+	}
+}
+
+func TestJWTTokenMaker_ClaimProfiles(t *testing.T) {
+	maker := NewJWTTokenMaker(oldSecret)
+	organizationID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		profile  ClaimProfile
+		expected Payload
+	}{
+		{profile: ClaimProfileMinimal, expected: Payload{OrganizationID: organizationID.String()}},
+		{profile: "", expected: Payload{Username: "jane_doe", Role: "staff", OrganizationID: organizationID.String()}},
+		{profile: ClaimProfileStandard, expected: Payload{Username: "jane_doe", Role: "staff", OrganizationID: organizationID.String()}},
+		{profile: ClaimProfileInternal, expected: Payload{
+			Username:       "jane_doe",
+			Role:           "staff",
+			OrganizationID: organizationID.String(),
+			Email:          "jane@tickets.example",
+			Status:         "active",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			// The profile applies whatever the order of the options
+			accessToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60,
+				WithClaimProfile(tt.profile),
+				WithRole("staff"),
+				WithOrganization(organizationID),
+				WithEmail("jane@tickets.example"),
+				WithStatus("active"),
+				WithConfirmation("thumbprint"),
+				WithClient("web"),
+				WithSession(sessionID),
+			)
+			if err != nil {
+				t.Fatalf("Failed to create token: %v", err)
+			}
+
+			payload, err := maker.VerifyAccessToken(accessToken)
+			if err != nil {
+				t.Fatalf("Failed to verify token: %v", err)
+			}
+			if payload.UserID != "user-1" || payload.BoundKey() != "thumbprint" {
+				t.Errorf("Expected user-1 bound to thumbprint in every profile, got %q and %q", payload.UserID, payload.BoundKey())
+			}
+			if payload.ClientID != "web" || payload.SessionID != sessionID.String() {
+				t.Errorf("Expected client web and session %s in every profile, got %q and %q", sessionID, payload.ClientID, payload.SessionID)
+			}
+			got := Payload{
+				Username:       payload.Username,
+				Role:           payload.Role,
+				OrganizationID: payload.OrganizationID,
+				Email:          payload.Email,
+				Status:         payload.Status,
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected claims %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestJWTTokenMaker_DelegationClaims(t *testing.T) {
+	maker := NewJWTTokenMaker(oldSecret)
+
+	userToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	payload, err := maker.VerifyAccessToken(userToken)
+	if err != nil {
+		t.Fatalf("Failed to verify token: %v", err)
+	}
+	if payload.IsDelegation() || payload.Actor != nil {
+		t.Errorf("Expected a user token not to be a delegation token, got %+v", payload)
+	}
+
+	actor := &Actor{Subject: "payment-svc", Actor: &Actor{Subject: "booking-svc"}}
+	delegationToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60,
+		WithDelegation("ledger-svc", []string{"payments:read", "payments:create"}, actor),
+		WithClaimProfile(ClaimProfileMinimal),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	payload, err = maker.VerifyAccessToken(delegationToken)
+	if err != nil {
+		t.Fatalf("Failed to verify token: %v", err)
+	}
+	if !payload.IsDelegation() || payload.Audience != "ledger-svc" {
+		t.Errorf("Expected a delegation token for ledger-svc, got audience %q", payload.Audience)
+	}
+	if scopes := payload.Scopes(); len(scopes) != 2 || scopes[0] != "payments:read" || scopes[1] != "payments:create" {
+		t.Errorf("Expected the delegated scopes, got %v", scopes)
+	}
+	if payload.Actor.Depth() != 2 || payload.Actor.Subject != "payment-svc" || payload.Actor.Actor.Subject != "booking-svc" {
+		t.Errorf("Expected the act chain payment-svc, booking-svc, got %+v", payload.Actor)
+	}
+	if audience, _ := payload.GetAudience(); len(audience) != 1 || audience[0] != "ledger-svc" {
+		t.Errorf("Expected the aud claim ledger-svc, got %v", audience)
+	}
+}
+
+func TestJWTTokenMaker_Encryption(t *testing.T) {
+	const (
+		oldEncryptionKey = "old-encryption-0123456789abcdef0123"
+		newEncryptionKey = "new-encryption-0123456789abcdef0123"
+	)
+	maker := NewJWTTokenMaker(oldSecret)
+	maker.EnableEncryption(oldEncryptionKey)
+
+	encrypted, err := maker.CreateAccessToken("user-1", "jane_doe", 60, WithEncryption(true), WithRole("staff"))
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if parts := strings.Split(encrypted, "."); len(parts) != 5 {
+		t.Fatalf("Expected a JWE with 5 parts, got %d", len(parts))
+	}
+	if strings.Contains(encrypted, base64.RawURLEncoding.EncodeToString([]byte(`"user_id"`))) {
+		t.Error("Expected the claims to be opaque")
+	}
+
+	payload, err := maker.VerifyAccessToken(encrypted)
+	if err != nil {
+		t.Fatalf("Failed to verify token: %v", err)
+	}
+	if payload.UserID != "user-1" || payload.Role != "staff" {
+		t.Errorf("Expected user-1 with role staff, got %q and %q", payload.UserID, payload.Role)
+	}
+
+	// Signed tokens keep verifying next to encrypted ones
+	signed, err := maker.CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if _, err := maker.VerifyAccessToken(signed); err != nil {
+		t.Errorf("Expected signed token to verify, got %v", err)
+	}
+
+	// After a key rollover the old key still decrypts
+	rolled := NewJWTTokenMaker(oldSecret)
+	rolled.EnableEncryption(newEncryptionKey, oldEncryptionKey)
+	if _, err := rolled.VerifyAccessToken(encrypted); err != nil {
+		t.Errorf("Expected token encrypted with the secondary key to verify, got %v", err)
+	}
+
+	parts := strings.Split(encrypted, ".")
+	tampered := []byte(parts[3])
+	tampered[0] ^= 1
+	parts[3] = string(tampered)
+	for name, token := range map[string]string{
+		"tampered":    strings.Join(parts, "."),
+		"unknown key": mustEncrypt(t, newEncryptionKey),
+		"malformed":   "a.b.c.d.e",
+	} {
+		if _, err := maker.VerifyAccessToken(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Expected ErrInvalidToken for %s token, got %v", name, err)
+		}
+	}
+
+	// Without encryption keys, encrypted tokens cannot be verified
+	if _, err := NewJWTTokenMaker(oldSecret).VerifyAccessToken(encrypted); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken without encryption keys, got %v", err)
+	}
+}
+
+func TestJWTTokenMaker_EncryptionRequiresKey(t *testing.T) {
+	maker := NewJWTTokenMaker(oldSecret)
+
+	if _, err := maker.CreateAccessToken("user-1", "jane_doe", 60, WithEncryption(true)); !errors.Is(err, ErrUnknownEncryptionKey) {
+		t.Errorf("Expected ErrUnknownEncryptionKey, got %v", err)
+	}
+}
+
+func TestDeriveEncryptionKey_KeyIDRevealsNoKeyBytes(t *testing.T) {
+	const secret = "old-encryption-0123456789abcdef0123"
+
+	id, key := deriveEncryptionKey(secret)
+	rawID, err := hex.DecodeString(id)
+	if err != nil || len(rawID) != jweKeyIDSize {
+		t.Fatalf("Expected a hex key ID of %d bytes, got %q", jweKeyIDSize, id)
+	}
+	if bytes.Contains(key, rawID) {
+		t.Errorf("Expected the key ID to share no bytes with the key")
+	}
+	if sum := sha256.Sum256([]byte(secret)); bytes.Equal(key, sum[:]) || id == SigningKeyID(secret) {
+		t.Errorf("Expected the key and its ID to be derived separately from the secret")
+	}
+
+	// The ID is the one tokens are tagged with
+	header, _, _ := strings.Cut(mustEncrypt(t, secret), ".")
+	rawHeader, _ := base64.RawURLEncoding.DecodeString(header)
+	if !strings.Contains(string(rawHeader), `"kid":"`+id+`"`) {
+		t.Errorf("Expected the kid %q in the header %s", id, rawHeader)
+	}
+}
+
+// mustEncrypt returns a token encrypted with key
+func mustEncrypt(t *testing.T, key string) string {
+	t.Helper()
+
+	maker := NewJWTTokenMaker(oldSecret)
+	maker.EnableEncryption(key)
+	token, err := maker.CreateAccessToken("user-1", "jane_doe", 60, WithEncryption(true))
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	return token
+}
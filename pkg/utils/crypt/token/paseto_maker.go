@@ -0,0 +1,247 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrEncryptionUnsupported = errors.New("token format does not support encryption")
+
+// pasetoV4PublicHeader starts every PASETO v4.public token, whose claims are signed with
+// Ed25519 and readable like those of a JWT
+const pasetoV4PublicHeader = "v4.public."
+
+// pasetoSeedContext separates the Ed25519 keys from the HMAC keys of JWTs signed with the
+// same secret
+const pasetoSeedContext = "user-svc paseto v4.public"
+
+// pasetoKey is an Ed25519 key derived from a configured secret and the ID put in the "kid"
+// of the footer of the tokens it signs; the ID is the one JWTTokenMaker gives the secret
+type pasetoKey struct {
+	id      string
+	private ed25519.PrivateKey
+	public  ed25519.PublicKey
+}
+
+// pasetoKeyRing holds the key new tokens are signed with and the keys tokens are verified with
+type pasetoKeyRing struct {
+	primary pasetoKey
+	all     []pasetoKey
+}
+
+// pasetoFooter is the unencrypted, signed footer of the tokens
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// PasetoTokenMaker creates and verifies PASETO v4.public tokens carrying the same claims as
+// the JWTs of JWTTokenMaker. Its keys are derived from the JWT secrets, so both makers share
+// the key IDs and a promotion applies to both.
+type PasetoTokenMaker struct {
+	keys atomic.Pointer[pasetoKeyRing]
+}
+
+// NewPasetoTokenMaker creates a maker that signs with a key derived from secretKey and also
+// verifies tokens signed with the keys of the secondary secrets, see NewJWTTokenMaker
+func NewPasetoTokenMaker(secretKey string, secondaryKeys ...string) *PasetoTokenMaker {
+	var all []pasetoKey
+	for _, secret := range append([]string{secretKey}, secondaryKeys...) {
+		if len(secret) < minSecretKeySize {
+			panic("invalid secret key size: must be at least 32 characters")
+		}
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(pasetoSeedContext))
+		private := ed25519.NewKeyFromSeed(mac.Sum(nil))
+
+		all = append(all, pasetoKey{id: SigningKeyID(secret), private: private, public: private.Public().(ed25519.PublicKey)})
+	}
+
+	maker := &PasetoTokenMaker{}
+	maker.keys.Store(&pasetoKeyRing{primary: all[0], all: all})
+
+	return maker
+}
+
+// IsPasetoV4Public reports whether a token looks like a PASETO v4.public token, without verifying it
+func IsPasetoV4Public(token string) bool {
+	return strings.HasPrefix(token, pasetoV4PublicHeader)
+}
+
+// PrimaryKeyID returns the ID of the key new tokens are signed with
+func (maker *PasetoTokenMaker) PrimaryKeyID() string {
+	return maker.keys.Load().primary.id
+}
+
+// KeyIDs returns the IDs of every key tokens are verified with, the primary one first
+func (maker *PasetoTokenMaker) KeyIDs() []string {
+	ring := maker.keys.Load()
+
+	ids := []string{ring.primary.id}
+	for _, key := range ring.all {
+		if key.id != ring.primary.id {
+			ids = append(ids, key.id)
+		}
+	}
+	return ids
+}
+
+// SetPrimaryKey signs new tokens with the key of the configured secret with the given ID
+func (maker *PasetoTokenMaker) SetPrimaryKey(id string) error {
+	ring := maker.keys.Load()
+	for _, key := range ring.all {
+		if key.id == id {
+			maker.keys.Store(&pasetoKeyRing{primary: key, all: ring.all})
+			return nil
+		}
+	}
+
+	return ErrUnknownSigningKey
+}
+
+// sign signs the payload with the primary key and names the key in the footer. Tokens
+// created WithEncryption are refused, as v4.public tokens are not encrypted.
+func (maker *PasetoTokenMaker) sign(payload *Payload) (string, error) {
+	if payload.encrypt {
+		return "", ErrEncryptionUnsupported
+	}
+	key := maker.keys.Load().primary
+
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	footer, err := json.Marshal(pasetoFooter{KeyID: key.id})
+	if err != nil {
+		return "", err
+	}
+
+	signature := ed25519.Sign(key.private, pae([]byte(pasetoV4PublicHeader), message, footer, nil))
+
+	return pasetoV4PublicHeader +
+		base64.RawURLEncoding.EncodeToString(append(message, signature...)) + "." +
+		base64.RawURLEncoding.EncodeToString(footer), nil
+}
+
+// parse verifies the token with the key named in its footer and returns its claims, still
+// to be validated
+func (maker *PasetoTokenMaker) parse(token string) (*Payload, error) {
+	if !IsPasetoV4Public(token) {
+		return nil, ErrInvalidToken
+	}
+
+	body, rawFooter, ok := strings.Cut(strings.TrimPrefix(token, pasetoV4PublicHeader), ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	signed, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil || len(signed) < ed25519.SignatureSize {
+		return nil, ErrInvalidToken
+	}
+	footer, err := base64.RawURLEncoding.DecodeString(rawFooter)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var header pasetoFooter
+	if err := json.Unmarshal(footer, &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var key *pasetoKey
+	ring := maker.keys.Load()
+	for i := range ring.all {
+		if ring.all[i].id == header.KeyID {
+			key = &ring.all[i]
+		}
+	}
+	if key == nil {
+		return nil, ErrUnknownSigningKey
+	}
+
+	message, signature := signed[:len(signed)-ed25519.SignatureSize], signed[len(signed)-ed25519.SignatureSize:]
+	if !ed25519.Verify(key.public, pae([]byte(pasetoV4PublicHeader), message, footer, nil), signature) {
+		return nil, ErrInvalidToken
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(message, &payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &payload, nil
+}
+
+// pae is the pre-authentication encoding of PASETO: the number of pieces, then each piece
+// after its length, all lengths as 64-bit little-endian integers
+func pae(pieces ...[]byte) []byte {
+	out := binary.LittleEndian.AppendUint64(nil, uint64(len(pieces)))
+	for _, piece := range pieces {
+		out = binary.LittleEndian.AppendUint64(out, uint64(len(piece)))
+		out = append(out, piece...)
+	}
+	return out
+}
+
+func (maker *PasetoTokenMaker) CreateAccessToken(userID string, username string, duration int64, opts ...ClaimOption) (string, error) {
+	payload, err := NewPayload(userID, username, duration)
+	if err != nil {
+		return "", err
+	}
+	payload.apply(opts)
+
+	return maker.sign(payload)
+}
+
+func (maker *PasetoTokenMaker) CreateTokenPair(userID string, username string, duration int64, opts ...ClaimOption) (string, string, error) {
+	accessToken, err := maker.CreateAccessToken(userID, username, duration, opts...)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, err := maker.CreateRefreshToken(userID, username, duration, opts...)
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+func (maker *PasetoTokenMaker) CreateRefreshToken(userID string, username string, duration int64, opts ...ClaimOption) (string, error) {
+	payload, err := NewPayload(userID, username, duration)
+	if err != nil {
+		return "", err
+	}
+	payload.apply(opts)
+
+	return maker.sign(payload)
+}
+
+func (maker *PasetoTokenMaker) VerifyAccessToken(token string) (*Payload, error) {
+	payload, err := maker.parse(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err := payload.Valid(); err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	return payload, nil
+}
+
+func (maker *PasetoTokenMaker) VerifyRefreshToken(token string) (*Payload, error) {
+	return maker.VerifyAccessToken(token)
+}
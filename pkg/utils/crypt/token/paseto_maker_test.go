@@ -0,0 +1,117 @@
+package token
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPae(t *testing.T) {
+	// Test vectors of the PASETO specification
+	tests := []struct {
+		name     string
+		pieces   [][]byte
+		expected []byte
+	}{
+		{name: "no pieces", expected: []byte("\x00\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "empty piece", pieces: [][]byte{{}}, expected: []byte("\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "one piece", pieces: [][]byte{[]byte("test")}, expected: []byte("\x01\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00test")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if encoded := pae(tt.pieces...); !bytes.Equal(encoded, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, encoded)
+			}
+		})
+	}
+}
+
+func TestPasetoTokenMaker_CreatesAndVerifiesTokens(t *testing.T) {
+	maker := NewPasetoTokenMaker(oldSecret)
+
+	token, err := maker.CreateAccessToken("user-1", "jane_doe", 60, WithRole("staff"), WithClient("web"))
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if !IsPasetoV4Public(token) || IsJWT(token) {
+		t.Fatalf("Expected a v4.public token, got %s", token)
+	}
+
+	payload, err := maker.VerifyAccessToken(token)
+	if err != nil {
+		t.Fatalf("Failed to verify token: %v", err)
+	}
+	if payload.UserID != "user-1" || payload.Username != "jane_doe" || payload.Role != "staff" || payload.ClientID != "web" {
+		t.Errorf("Expected the signed claims, got %+v", payload)
+	}
+
+	// A flipped bit anywhere in the body breaks the signature
+	body, footer, _ := strings.Cut(strings.TrimPrefix(token, pasetoV4PublicHeader), ".")
+	tampered := pasetoV4PublicHeader + body[:10] + string(body[10]^1) + body[11:] + "." + footer
+	if _, err := maker.VerifyAccessToken(tampered); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a tampered token, got %v", err)
+	}
+
+	// The footer naming the key is signed too
+	if _, err := maker.VerifyAccessToken(pasetoV4PublicHeader + body); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken without a footer, got %v", err)
+	}
+
+	expired, err := maker.CreateAccessToken("user-1", "jane_doe", -60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if _, err := maker.VerifyAccessToken(expired); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("Expected ErrExpiredToken, got %v", err)
+	}
+}
+
+func TestPasetoTokenMaker_SetPrimaryKeyKeepsOldTokensValid(t *testing.T) {
+	maker := NewPasetoTokenMaker(oldSecret, newSecret)
+
+	// Keys keep the IDs JWTTokenMaker gives the same secrets, so promotions apply to both
+	jwtMaker := NewJWTTokenMaker(oldSecret, newSecret)
+	if strings.Join(maker.KeyIDs(), ",") != strings.Join(jwtMaker.KeyIDs(), ",") {
+		t.Fatalf("Expected key IDs %v, got %v", jwtMaker.KeyIDs(), maker.KeyIDs())
+	}
+
+	oldToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if err := maker.SetPrimaryKey(SigningKeyID(newSecret)); err != nil {
+		t.Fatalf("Failed to promote key: %v", err)
+	}
+	newToken, err := maker.CreateAccessToken("user-1", "jane_doe", 60)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+		if _, err := maker.VerifyAccessToken(token); err != nil {
+			t.Errorf("Expected %s token to verify, got %v", name, err)
+		}
+	}
+
+	rotated := NewPasetoTokenMaker(newSecret)
+	if _, err := rotated.VerifyAccessToken(newToken); err != nil {
+		t.Errorf("Expected new token to verify, got %v", err)
+	}
+	if _, err := rotated.VerifyAccessToken(oldToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken, got %v", err)
+	}
+
+	if err := maker.SetPrimaryKey("unknown"); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Errorf("Expected ErrUnknownSigningKey, got %v", err)
+	}
+}
+
+func TestPasetoTokenMaker_RefusesEncryption(t *testing.T) {
+	maker := NewPasetoTokenMaker(oldSecret)
+
+	if _, err := maker.CreateAccessToken("user-1", "jane_doe", 60, WithEncryption(true)); !errors.Is(err, ErrEncryptionUnsupported) {
+		t.Errorf("Expected ErrEncryptionUnsupported, got %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package token
 
 import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -8,11 +12,234 @@ import (
 )
 
 type Payload struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    string    `json:"user_id"`
-	Username  string    `json:"username"`
-	ExpiredAt int64     `json:"expired_at"`
-	IssuedAt  int64     `json:"issued_at"`
+	ID uuid.UUID `json:"id"`
+	// JTI repeats ID as the registered "jti" claim
+	JTI string `json:"jti,omitempty"`
+	// Issuer is the service that created the token, see WithIssuer
+	Issuer    string `json:"iss,omitempty"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	ExpiredAt int64  `json:"expired_at"`
+	IssuedAt  int64  `json:"issued_at"`
+	// Role is the user's role, for other services to authorize with, see WithRole
+	Role string `json:"role,omitempty"`
+	// OrganizationID is the organization of the user, see WithOrganization
+	OrganizationID string `json:"org_id,omitempty"`
+	// Residency is the region the user's data is stored in, see WithResidency
+	Residency string `json:"residency,omitempty"`
+	// ClientID is the client the token was issued to, see WithClient
+	ClientID string `json:"client_id,omitempty"`
+	// SessionID is the refresh token session the token was issued in, see WithSession
+	SessionID string `json:"sid,omitempty"`
+	// Email and Status are only embedded by ClaimProfileInternal, see WithEmail and WithStatus
+	Email  string `json:"email,omitempty"`
+	Status string `json:"status,omitempty"`
+
+	// Confirmation binds the token to a proof-of-possession key, see WithConfirmation
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+
+	// Audience is the service a delegation token was exchanged for, see WithDelegation, and
+	// the configured audience on other tokens, see WithAudience
+	Audience string `json:"aud,omitempty"`
+	// Scope and Actor are only set on delegation tokens
+	// Scope is the space-delimited list of scopes granted to the audience
+	Scope string `json:"scope,omitempty"`
+	Actor *Actor `json:"act,omitempty"`
+
+	// profile selects the claims that are embedded, see WithClaimProfile
+	profile ClaimProfile
+	// encrypt wraps the signed token in a JWE, see WithEncryption
+	encrypt bool
+	// claims are the names of the claims of a verified token, and critical the extensions its
+	// header marks critical, which ClaimPolicy checks
+	claims   []string
+	critical []string
+}
+
+// ClaimProfile selects which claims a token carries, so tokens of consumer apps reveal as
+// little as possible while internal services get what they need without a lookup
+type ClaimProfile string
+
+const (
+	// ClaimProfileMinimal embeds the token and user IDs, the issuer and audience, the times,
+	// the key binding, the organization, which routes requests to tenant schemas, and the
+	// residency region
+	ClaimProfileMinimal ClaimProfile = "minimal"
+	// ClaimProfileStandard adds the username and the role; it is the default
+	ClaimProfileStandard ClaimProfile = "standard"
+	// ClaimProfileInternal adds the email address and the account status
+	ClaimProfileInternal ClaimProfile = "internal"
+)
+
+// IsValid reports whether the profile is a known one
+func (p ClaimProfile) IsValid() bool {
+	return p == ClaimProfileMinimal || p == ClaimProfileStandard || p == ClaimProfileInternal
+}
+
+// Confirmation is the RFC 7800 "cnf" claim of a sender-constrained token
+type Confirmation struct {
+	// JKT is the SHA-256 thumbprint of the DPoP key the token is bound to (RFC 9449)
+	JKT string `json:"jkt"`
+}
+
+// Actor is the RFC 8693 "act" claim of a delegation token: the service acting on behalf of
+// the user and, nested, the actors that delegated to it before
+type Actor struct {
+	// Subject is the ID of the acting service
+	Subject string `json:"sub"`
+	Actor   *Actor `json:"act,omitempty"`
+}
+
+// Depth returns the number of actors in the chain
+func (actor *Actor) Depth() int {
+	depth := 0
+	for ; actor != nil; actor = actor.Actor {
+		depth++
+	}
+	return depth
+}
+
+// ClaimOption customizes the claims of a token being created
+type ClaimOption func(*Payload)
+
+// WithConfirmation binds the token to the DPoP key with the given thumbprint
+func WithConfirmation(jkt string) ClaimOption {
+	return func(payload *Payload) {
+		if jkt != "" {
+			payload.Confirmation = &Confirmation{JKT: jkt}
+		}
+	}
+}
+
+// WithIssuer names the service creating the token in the "iss" claim
+func WithIssuer(issuer string) ClaimOption {
+	return func(payload *Payload) {
+		payload.Issuer = issuer
+	}
+}
+
+// WithAudience names the services the token is meant for in the "aud" claim; WithDelegation
+// replaces it with the service a delegation token is exchanged for
+func WithAudience(audience string) ClaimOption {
+	return func(payload *Payload) {
+		payload.Audience = audience
+	}
+}
+
+// WithRole adds the user's role to the token
+func WithRole(role string) ClaimOption {
+	return func(payload *Payload) {
+		payload.Role = role
+	}
+}
+
+// WithOrganization adds the user's organization to the token; uuid.Nil adds none
+func WithOrganization(organizationID uuid.UUID) ClaimOption {
+	return func(payload *Payload) {
+		if organizationID != uuid.Nil {
+			payload.OrganizationID = organizationID.String()
+		}
+	}
+}
+
+// WithResidency adds the region the user's data is stored in, which routes the user's
+// requests to the region's database; every profile embeds it
+func WithResidency(region string) ClaimOption {
+	return func(payload *Payload) {
+		payload.Residency = region
+	}
+}
+
+// WithClient adds the client the token is issued to, so the user can revoke the client's
+// access; every profile embeds it
+func WithClient(clientID string) ClaimOption {
+	return func(payload *Payload) {
+		payload.ClientID = clientID
+	}
+}
+
+// WithSession adds the refresh token session the token is issued in, so verifying the token
+// counts as activity of the session; uuid.Nil adds none. Every profile embeds it.
+func WithSession(sessionID uuid.UUID) ClaimOption {
+	return func(payload *Payload) {
+		if sessionID != uuid.Nil {
+			payload.SessionID = sessionID.String()
+		}
+	}
+}
+
+// WithEmail adds the user's email address to tokens of ClaimProfileInternal
+func WithEmail(email string) ClaimOption {
+	return func(payload *Payload) {
+		payload.Email = email
+	}
+}
+
+// WithStatus adds the user's account status to tokens of ClaimProfileInternal
+func WithStatus(status string) ClaimOption {
+	return func(payload *Payload) {
+		payload.Status = status
+	}
+}
+
+// WithDelegation makes the token a delegation token for the audience, granting the scopes to
+// the actor; every profile embeds it
+func WithDelegation(audience string, scopes []string, actor *Actor) ClaimOption {
+	return func(payload *Payload) {
+		payload.Audience = audience
+		payload.Scope = strings.Join(scopes, " ")
+		payload.Actor = actor
+	}
+}
+
+// WithClaimProfile drops the claims the profile does not embed, whatever the order of the
+// options; an empty profile is ClaimProfileStandard
+func WithClaimProfile(profile ClaimProfile) ClaimOption {
+	return func(payload *Payload) {
+		payload.profile = profile
+	}
+}
+
+// WithEncryption encrypts the signed token, so the client holding it cannot read its claims;
+// the maker fails to create the token unless encryption is enabled
+func WithEncryption(encrypt bool) ClaimOption {
+	return func(payload *Payload) {
+		payload.encrypt = encrypt
+	}
+}
+
+// BoundKey returns the thumbprint of the key the token is bound to, or "" for bearer tokens
+func (payload *Payload) BoundKey() string {
+	if payload.Confirmation == nil {
+		return ""
+	}
+	return payload.Confirmation.JKT
+}
+
+// IsDelegation reports whether the token was issued to a service acting on behalf of the
+// user, see WithDelegation; delegation tokens are only valid at their audience
+func (payload *Payload) IsDelegation() bool {
+	return payload.Actor != nil
+}
+
+// Scopes returns the scopes of a delegation token
+func (payload *Payload) Scopes() []string {
+	return strings.Fields(payload.Scope)
+}
+
+func (payload *Payload) apply(opts []ClaimOption) {
+	for _, opt := range opts {
+		opt(payload)
+	}
+
+	switch payload.profile {
+	case ClaimProfileInternal:
+	case ClaimProfileMinimal:
+		payload.Username, payload.Role = "", ""
+		fallthrough
+	default:
+		payload.Email, payload.Status = "", ""
+	}
 }
 
 func NewPayload(userID string, username string, duration int64) (*Payload, error) {
@@ -23,6 +250,7 @@ func NewPayload(userID string, username string, duration int64) (*Payload, error
 
 	payload := &Payload{
 		ID:        tokenID,
+		JTI:       tokenID.String(),
 		UserID:    userID,
 		Username:  username,
 		IssuedAt:  time.Now().Unix(),
@@ -32,6 +260,23 @@ func NewPayload(userID string, username string, duration int64) (*Payload, error
 	return payload, nil
 }
 
+// UnmarshalJSON decodes the claims and remembers their names, so claims the service does not
+// issue can be told apart
+func (payload *Payload) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	type claims Payload
+	if err := json.Unmarshal(data, (*claims)(payload)); err != nil {
+		return err
+	}
+	payload.claims = slices.Sorted(maps.Keys(raw))
+
+	return nil
+}
+
 func (payload *Payload) Valid() error {
 	if time.Now().Unix() > payload.ExpiredAt {
 		return jwt.ErrTokenExpired
@@ -41,14 +286,11 @@ func (payload *Payload) Valid() error {
 		return jwt.ErrTokenInvalidId
 	}
 
+	// Tokens of ClaimProfileMinimal carry no username
 	if payload.UserID == "" {
 		return jwt.ErrTokenRequiredClaimMissing
 	}
 
-	if payload.Username == "" {
-		return jwt.ErrTokenRequiredClaimMissing
-	}
-
 	return nil
 }
 
@@ -65,7 +307,7 @@ func (payload *Payload) GetIssuedAt() (*jwt.NumericDate, error) {
 }
 
 func (payload *Payload) GetIssuer() (string, error) {
-	return "", nil
+	return payload.Issuer, nil
 }
 
 func (payload *Payload) GetSubject() (string, error) {
@@ -73,5 +315,8 @@ func (payload *Payload) GetSubject() (string, error) {
 }
 
 func (payload *Payload) GetAudience() (jwt.ClaimStrings, error) {
-	return nil, nil
+	if payload.Audience == "" {
+		return nil, nil
+	}
+	return jwt.ClaimStrings{payload.Audience}, nil
 }
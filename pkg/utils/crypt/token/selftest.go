@@ -0,0 +1,28 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SelfTest issues and verifies a short-lived token to prove the signing keys are usable
+func SelfTest(maker TokenMaker) error {
+	userID := uuid.NewString()
+
+	accessToken, err := maker.CreateAccessToken(userID, "preflight", 60)
+	if err != nil {
+		return fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	payload, err := maker.VerifyAccessToken(accessToken)
+	if err != nil {
+		return fmt.Errorf("failed to verify token: %w", err)
+	}
+	if payload.UserID != userID {
+		return errors.New("verified token does not carry the signed claims")
+	}
+
+	return nil
+}
@@ -8,7 +8,10 @@ import (
 // HashToken creates a SHA-256 hash of the token for secure storage
 func HashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
-	return hex.EncodeToString(hash[:])
+	// Encoding into a stack buffer leaves the returned string as the only allocation
+	var buf [sha256.Size * 2]byte
+	hex.Encode(buf[:], hash[:])
+	return string(buf[:])
 }
 
 // ValidateTokenHash validates if a token matches its hash
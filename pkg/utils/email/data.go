@@ -0,0 +1,86 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// dateLayout is how the date function formats timestamps
+const dateLayout = "2006-01-02 15:04 MST"
+
+// funcs are available to every template
+var funcs = map[string]interface{}{
+	"date": formatDate,
+}
+
+// Data converts a value to template data through its JSON encoding, so templates refer to
+// fields by their JSON names and render the same data in previews and in sent emails
+func Data(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseData(raw)
+}
+
+// ParseData decodes a JSON object as template data. Integral numbers are decoded as int64,
+// so large values such as timestamps are not printed in exponent notation.
+func ParseData(raw []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var data map[string]interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	for key, value := range data {
+		data[key] = convertNumbers(value)
+	}
+	return data, nil
+}
+
+func convertNumbers(v interface{}) interface{} {
+	switch value := v.(type) {
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n
+		}
+		f, _ := value.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range value {
+			value[key] = convertNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = convertNumbers(item)
+		}
+	}
+	return v
+}
+
+// formatDate formats a Unix millisecond timestamp or an RFC 3339 string in UTC. Values
+// it cannot read are returned unchanged.
+func formatDate(v interface{}) string {
+	switch value := v.(type) {
+	case time.Time:
+		return value.UTC().Format(dateLayout)
+	case int64:
+		return time.UnixMilli(value).UTC().Format(dateLayout)
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t.UTC().Format(dateLayout)
+		}
+		if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return time.UnixMilli(ms).UTC().Format(dateLayout)
+		}
+		return value
+	default:
+		return fmt.Sprint(v)
+	}
+}
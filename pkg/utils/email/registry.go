@@ -0,0 +1,369 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+)
+
+// Files making up a template variant in <dir>/<name>/<locale>/. The subject and at least
+// one of the bodies are required; a missing plain-text body is derived from the HTML one.
+const (
+	subjectFile  = "subject.tmpl"
+	htmlBodyFile = "body.html.tmpl"
+	textBodyFile = "body.txt.tmpl"
+	// sampleFile holds the data used to preview a template, in <dir>/<name>/
+	sampleFile = "sample.json"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("email template not found")
+	ErrRender           = errors.New("failed to render email template")
+)
+
+// Message is a rendered email
+type Message struct {
+	// Locale is the variant that was rendered, which may differ from the requested one
+	Locale  string `json:"locale"`
+	Subject string `json:"subject"`
+	HTML    string `json:"html,omitempty"`
+	Text    string `json:"text"`
+}
+
+type variant struct {
+	subject *texttemplate.Template
+	html    *htmltemplate.Template
+	text    *texttemplate.Template
+}
+
+type template struct {
+	variants map[string]*variant
+	sample   map[string]interface{}
+}
+
+// Registry holds the email templates found in a directory, one subdirectory per
+// template and one nested subdirectory per locale. Templates are read from disk so
+// they can be edited and translated without a release.
+type Registry struct {
+	dir           string
+	defaultLocale string
+
+	mu        sync.RWMutex
+	templates map[string]*template
+	modTime   time.Time
+}
+
+// NewRegistry loads the templates in dir. Locales a template lacks fall back to defaultLocale.
+func NewRegistry(dir, defaultLocale string) (*Registry, error) {
+	r := &Registry{
+		dir:           dir,
+		defaultLocale: normalizeLocale(defaultLocale),
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload parses the templates again. The registry keeps its templates if any of them is invalid.
+func (r *Registry) Reload() error {
+	modTime, err := latestModTime(r.dir)
+	if err != nil {
+		return err
+	}
+
+	templates, err := load(r.dir)
+	if err != nil {
+		return err
+	}
+
+	for name, tmpl := range templates {
+		if _, ok := tmpl.variants[r.defaultLocale]; !ok {
+			return fmt.Errorf("email template %q has no %q variant", name, r.defaultLocale)
+		}
+	}
+
+	r.mu.Lock()
+	r.templates = templates
+	r.modTime = modTime
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Watch reloads the templates every interval when a file changed, until ctx is cancelled
+func (r *Registry) Watch(ctx context.Context, logger *logutils.Logger, wg *sync.WaitGroup, interval time.Duration) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				modTime, err := latestModTime(r.dir)
+				if err != nil {
+					logger.WithError(err).Warn("Could not check email templates for changes")
+					continue
+				}
+
+				r.mu.RLock()
+				changed := modTime.After(r.modTime)
+				r.mu.RUnlock()
+				if !changed {
+					continue
+				}
+
+				if err := r.Reload(); err != nil {
+					logger.WithError(err).Error("Could not reload email templates, keeping the previous ones")
+					continue
+				}
+				logger.Info("Email templates reloaded")
+			}
+		}
+	}()
+}
+
+// Names returns the names of the loaded templates in alphabetical order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.templates))
+	for name := range r.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Sample returns the preview data of a template, or nil if it has none
+func (r *Registry) Sample(name string) (map[string]interface{}, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tmpl, ok := r.templates[name]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+
+	return tmpl.sample, nil
+}
+
+// Render renders the best variant of a template for the locale: the exact locale, then
+// its base language, then the default locale
+func (r *Registry) Render(name, locale string, data interface{}) (*Message, error) {
+	r.mu.RLock()
+	tmpl, ok := r.templates[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+
+	resolved := r.resolveLocale(tmpl, locale)
+	v := tmpl.variants[resolved]
+
+	subject, err := execute(v.subject, data)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{
+		Locale:  resolved,
+		Subject: strings.TrimSpace(subject),
+	}
+
+	if v.html != nil {
+		if msg.HTML, err = execute(v.html, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if v.text != nil {
+		if msg.Text, err = execute(v.text, data); err != nil {
+			return nil, err
+		}
+	} else {
+		msg.Text = HTMLToText(msg.HTML)
+	}
+
+	return msg, nil
+}
+
+func (r *Registry) resolveLocale(tmpl *template, locale string) string {
+	locale = normalizeLocale(locale)
+	if _, ok := tmpl.variants[locale]; ok {
+		return locale
+	}
+
+	if base, _, found := strings.Cut(locale, "-"); found {
+		if _, ok := tmpl.variants[base]; ok {
+			return base
+		}
+	}
+
+	return r.defaultLocale
+}
+
+// executer is implemented by both text and HTML templates
+type executer interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+func execute(t executer, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRender, err)
+	}
+	return buf.String(), nil
+}
+
+func load(dir string) (map[string]*template, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email templates: %w", err)
+	}
+
+	templates := make(map[string]*template)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		tmpl, err := loadTemplate(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("email template %q: %w", entry.Name(), err)
+		}
+		templates[entry.Name()] = tmpl
+	}
+
+	return templates, nil
+}
+
+func loadTemplate(dir string) (*template, error) {
+	tmpl := &template{variants: make(map[string]*variant)}
+
+	if raw, err := os.ReadFile(filepath.Join(dir, sampleFile)); err == nil {
+		if tmpl.sample, err = ParseData(raw); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", sampleFile, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		v, err := loadVariant(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("locale %q: %w", entry.Name(), err)
+		}
+		tmpl.variants[normalizeLocale(entry.Name())] = v
+	}
+
+	return tmpl, nil
+}
+
+func loadVariant(dir string) (*variant, error) {
+	v := &variant{}
+
+	subject, err := readOptional(filepath.Join(dir, subjectFile))
+	if err != nil {
+		return nil, err
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("missing %s", subjectFile)
+	}
+	if v.subject, err = texttemplate.New(subjectFile).Funcs(texttemplate.FuncMap(funcs)).Option("missingkey=error").Parse(subject); err != nil {
+		return nil, err
+	}
+
+	html, err := readOptional(filepath.Join(dir, htmlBodyFile))
+	if err != nil {
+		return nil, err
+	}
+	if html != "" {
+		if v.html, err = htmltemplate.New(htmlBodyFile).Funcs(htmltemplate.FuncMap(funcs)).Option("missingkey=error").Parse(html); err != nil {
+			return nil, err
+		}
+	}
+
+	text, err := readOptional(filepath.Join(dir, textBodyFile))
+	if err != nil {
+		return nil, err
+	}
+	if text != "" {
+		if v.text, err = texttemplate.New(textBodyFile).Funcs(texttemplate.FuncMap(funcs)).Option("missingkey=error").Parse(text); err != nil {
+			return nil, err
+		}
+	}
+
+	if v.html == nil && v.text == nil {
+		return nil, fmt.Errorf("missing %s or %s", htmlBodyFile, textBodyFile)
+	}
+
+	return v, nil
+}
+
+func readOptional(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	return string(raw), err
+}
+
+// latestModTime returns the most recent modification time of the files under dir
+func latestModTime(dir string) (time.Time, error) {
+	var latest time.Time
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read email templates: %w", err)
+	}
+
+	return latest, nil
+}
+
+// normalizeLocale lowercases a locale and uses "-" as separator, so "pt_BR" matches "pt-br"
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
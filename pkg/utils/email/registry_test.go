@@ -0,0 +1,183 @@
+package email
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemplate(t *testing.T, dir, name, locale string, files map[string]string) {
+	t.Helper()
+
+	path := filepath.Join(dir, name, locale)
+	require.NoError(t, os.MkdirAll(path, 0o755))
+	for file, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(path, file), []byte(content), 0o644))
+	}
+}
+
+func newTestRegistry(t *testing.T) (*Registry, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeTemplate(t, dir, "welcome", "en", map[string]string{
+		subjectFile:  "Welcome {{.username}}\n",
+		htmlBodyFile: "<p>Hello <b>{{.username}}</b> &amp; welcome!</p><ul><li>One</li><li>Two</li></ul>",
+		textBodyFile: "Hello {{.username}}",
+	})
+	writeTemplate(t, dir, "welcome", "pt", map[string]string{
+		subjectFile:  "Bem-vindo {{.username}}",
+		htmlBodyFile: "<p>Olá <b>{{.username}}</b></p><p>Boas compras</p>",
+	})
+
+	registry, err := NewRegistry(dir, "en")
+	require.NoError(t, err)
+
+	return registry, dir
+}
+
+func TestRegistryRenderLocaleFallback(t *testing.T) {
+	registry, _ := newTestRegistry(t)
+	data := map[string]interface{}{"username": "jane"}
+
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{locale: "en", expected: "en"},
+		{locale: "pt", expected: "pt"},
+		{locale: "pt_BR", expected: "pt"},
+		{locale: "fr-FR", expected: "en"},
+		{locale: "", expected: "en"},
+	}
+
+	for _, tt := range tests {
+		msg, err := registry.Render("welcome", tt.locale, data)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, msg.Locale, "locale %q", tt.locale)
+	}
+}
+
+func TestRegistryRenderBodies(t *testing.T) {
+	registry, _ := newTestRegistry(t)
+	data := map[string]interface{}{"username": "<jane>"}
+
+	msg, err := registry.Render("welcome", "en", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome <jane>", msg.Subject)
+	assert.Contains(t, msg.HTML, "<b>&lt;jane&gt;</b>")
+	assert.Equal(t, "Hello <jane>", msg.Text)
+
+	// Variants without a plain-text body get one derived from the HTML
+	msg, err = registry.Render("welcome", "pt", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Olá <jane>\nBoas compras\n", msg.Text)
+}
+
+func TestRegistryRenderErrors(t *testing.T) {
+	registry, _ := newTestRegistry(t)
+
+	_, err := registry.Render("unknown", "en", nil)
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+
+	_, err = registry.Render("welcome", "en", map[string]interface{}{})
+	assert.ErrorIs(t, err, ErrRender)
+}
+
+func TestRegistryReload(t *testing.T) {
+	registry, dir := newTestRegistry(t)
+
+	writeTemplate(t, dir, "welcome", "en", map[string]string{subjectFile: "Hi {{.username}}"})
+	require.NoError(t, registry.Reload())
+
+	msg, err := registry.Render("welcome", "en", map[string]interface{}{"username": "jane"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hi jane", msg.Subject)
+
+	// Invalid templates are rejected and the previous ones are kept
+	writeTemplate(t, dir, "welcome", "en", map[string]string{subjectFile: "Hi {{.username"})
+	assert.Error(t, registry.Reload())
+
+	msg, err = registry.Render("welcome", "en", map[string]interface{}{"username": "jane"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hi jane", msg.Subject)
+}
+
+func TestRegistryRequiresDefaultLocale(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "welcome", "de", map[string]string{
+		subjectFile:  "Willkommen",
+		textBodyFile: "Hallo",
+	})
+
+	_, err := NewRegistry(dir, "en")
+	assert.Error(t, err)
+}
+
+// TestShippedTemplates renders every template shipped with the service with its sample data
+func TestShippedTemplates(t *testing.T) {
+	registry, err := NewRegistry(filepath.Join("..", "..", "..", "templates", "email"), "en")
+	require.NoError(t, err)
+	require.NotEmpty(t, registry.Names())
+
+	for _, name := range registry.Names() {
+		sample, err := registry.Sample(name)
+		require.NoError(t, err)
+		require.NotNil(t, sample, "template %q has no sample data", name)
+
+		for _, locale := range []string{"en", "de"} {
+			msg, err := registry.Render(name, locale, sample)
+			require.NoError(t, err, "template %q", name)
+			assert.NotEmpty(t, msg.Subject)
+			assert.NotEmpty(t, msg.Text)
+		}
+	}
+}
+
+// TestShippedTemplatesBranding renders the emails of org-scoped flows with and without the
+// branding of an organization
+func TestShippedTemplatesBranding(t *testing.T) {
+	registry, err := NewRegistry(filepath.Join("..", "..", "..", "templates", "email"), "en")
+	require.NoError(t, err)
+
+	for _, name := range []string{"user_invitation", "password_reset", "email_verification"} {
+		sample, err := registry.Sample(name)
+		require.NoError(t, err)
+		require.NotNil(t, sample["branding"], "template %q has no sample branding", name)
+
+		msg, err := registry.Render(name, "en", sample)
+		require.NoError(t, err, "template %q", name)
+		assert.True(t, strings.HasPrefix(msg.Subject, "Tickets Inc: "), msg.Subject)
+		assert.Contains(t, msg.HTML, `src="https://cdn.tickets.example/logo.png"`)
+		assert.Contains(t, msg.HTML, `style="color: #1a73e8"`)
+		assert.Contains(t, msg.Text, "help@tickets.example")
+
+		sample["branding"] = nil
+		msg, err = registry.Render(name, "en", sample)
+		require.NoError(t, err, "template %q", name)
+		assert.NotContains(t, msg.Subject, "Tickets Inc")
+		assert.NotContains(t, msg.HTML, "<img")
+		assert.NotContains(t, msg.Text, "help@tickets.example")
+	}
+}
+
+func TestPreferredLocale(t *testing.T) {
+	assert.Equal(t, "de-ch", PreferredLocale("de-CH"))
+	assert.Equal(t, "fr", PreferredLocale("en;q=0.8, fr, de;q=0.9"))
+	assert.Equal(t, "pt-br", PreferredLocale("*, pt_BR;q=0.5"))
+	assert.Equal(t, "", PreferredLocale(""))
+}
+
+func TestFormatDate(t *testing.T) {
+	data, err := Data(map[string]interface{}{"at": int64(1767225600000)})
+	require.NoError(t, err)
+
+	assert.IsType(t, int64(0), data["at"])
+	assert.Equal(t, "2026-01-01 00:00 UTC", formatDate(data["at"]))
+	assert.Equal(t, "2026-01-01 00:00 UTC", formatDate("2026-01-01T00:00:00Z"))
+	assert.Equal(t, "soon", formatDate("soon"))
+}
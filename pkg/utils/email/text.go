@@ -0,0 +1,47 @@
+package email
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	metautils "user-svc/pkg/utils/metadata"
+)
+
+var (
+	// blockEnd matches the tags that end a line of text
+	blockEnd   = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|li|tr|table|ul|ol)>`)
+	listItem   = regexp.MustCompile(`(?i)<li[^>]*>`)
+	nonVisible = regexp.MustCompile(`(?is)<(head|style|script)[^>]*>.*?</(head|style|script)>`)
+	anyTag     = regexp.MustCompile(`(?s)<[^>]*>`)
+	spaces     = regexp.MustCompile(`[ \t]+`)
+)
+
+// HTMLToText derives the plain-text fallback of an HTML body: tags are dropped, block
+// elements end lines, list items are bulleted and entities are decoded
+func HTMLToText(body string) string {
+	body = nonVisible.ReplaceAllString(body, "")
+	body = strings.NewReplacer("\r\n", " ", "\n", " ").Replace(body)
+	body = listItem.ReplaceAllString(body, "- ")
+	body = blockEnd.ReplaceAllString(body, "\n")
+	body = anyTag.ReplaceAllString(body, "")
+	body = html.UnescapeString(body)
+
+	lines := strings.Split(body, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+		// Collapse runs of blank lines into one
+		if line == "" && (len(out) == 0 || out[len(out)-1] == "") {
+			continue
+		}
+		out = append(out, line)
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n")) + "\n"
+}
+
+// PreferredLocale returns the highest weighted language of an Accept-Language header, or "" if it has none
+func PreferredLocale(acceptLanguage string) string {
+	return normalizeLocale(metautils.PreferredLocale(acceptLanguage))
+}
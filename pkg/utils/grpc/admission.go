@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/pkg/utils/admission"
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Response headers of queued calls: the approximate position the call was queued at, how
+// long it waited, and for rejected calls the seconds to wait before retrying
+const (
+	QueuePositionHeader = "x-queue-position"
+	QueueWaitHeader     = "x-queue-wait-ms"
+	RetryAfterHeader    = "retry-after"
+)
+
+// QueueHeaders are the admission queue response headers, e.g. to expose to browsers
+var QueueHeaders = []string{QueuePositionHeader, QueueWaitHeader, RetryAfterHeader}
+
+// AdmissionQueue admits calls fairly across callers, see admission.Queue
+type AdmissionQueue interface {
+	Acquire(ctx context.Context, key string) (func(), int, error)
+}
+
+// AdmissionOptions configures the admission interceptor
+type AdmissionOptions struct {
+	// Methods are the full gRPC method names that are queued
+	Methods []string
+	// MaxWait bounds how long a call waits for admission; the client deadline still applies
+	MaxWait time.Duration
+}
+
+// AdmissionInterceptor is a gRPC interceptor that queues calls to the configured methods,
+// e.g. Login during on-sale spikes, instead of letting them all compete for the CPU. Callers
+// are told their queue position and, when the queue is full or the wait too long, when to
+// retry.
+func AdmissionInterceptor(logger *logutils.Logger, queue AdmissionQueue, opts AdmissionOptions) grpc.UnaryServerInterceptor {
+	methods := make(map[string]struct{}, len(opts.Methods))
+	for _, method := range opts.Methods {
+		methods[method] = struct{}{}
+	}
+	retryAfter := strconv.FormatInt(int64((opts.MaxWait+time.Second-1)/time.Second), 10)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if _, ok := methods[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, opts.MaxWait)
+		start := time.Now()
+		release, position, err := queue.Acquire(waitCtx, callerKey(ctx))
+		cancel()
+		waited := time.Since(start)
+
+		header := metadata.Pairs(QueuePositionHeader, strconv.Itoa(position))
+		if err != nil {
+			reason := "timeout"
+			switch {
+			case errors.Is(err, admission.ErrQueueFull):
+				reason, err = "full", errs.ErrAdmissionQueueFull
+			case ctx.Err() != nil:
+				// The client gave up
+				return nil, status.FromContextError(ctx.Err()).Err()
+			default:
+				err = errs.ErrAdmissionTimeout
+			}
+			metrics.AdmissionRejected.WithLabelValues(info.FullMethod, reason).Inc()
+
+			header.Set(RetryAfterHeader, retryAfter)
+			if err := grpc.SetHeader(ctx, header); err != nil {
+				logger.WithError(err).WithField("method", info.FullMethod).Debug("Failed to set queue headers")
+			}
+			logger.WithFields(logutils.Fields{
+				"method":   info.FullMethod,
+				"position": position,
+				"reason":   reason,
+			}).Debug("gRPC request rejected by admission queue")
+			return nil, err
+		}
+		defer release()
+
+		metrics.ObserveWithTraceID(metrics.AdmissionWait.WithLabelValues(info.FullMethod), waited.Seconds(), TraceID(ctx))
+		if position > 0 {
+			header.Set(QueueWaitHeader, strconv.FormatInt(waited.Milliseconds(), 10))
+			if err := grpc.SetHeader(ctx, header); err != nil {
+				logger.WithError(err).WithField("method", info.FullMethod).Debug("Failed to set queue headers")
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// callerKey identifies the caller a call is queued for by its IP address
+func callerKey(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/pkg/utils/admission"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAdmissionQueue struct {
+	position int
+	err      error
+}
+
+func (q *fakeAdmissionQueue) Acquire(ctx context.Context, key string) (func(), int, error) {
+	if q.err == context.DeadlineExceeded {
+		<-ctx.Done()
+		return nil, q.position, ctx.Err()
+	}
+	if q.err != nil {
+		return nil, q.position, q.err
+	}
+	return func() {}, q.position, nil
+}
+
+func TestAdmissionInterceptor(t *testing.T) {
+	const method = "/grpc.health.v1.Health/Check"
+
+	tests := []struct {
+		name       string
+		queue      *fakeAdmissionQueue
+		status     string
+		position   string
+		retryAfter string
+	}{
+		{name: "admitted", queue: &fakeAdmissionQueue{}, status: "grpc-status: 0\r\n"},
+		{name: "queued", queue: &fakeAdmissionQueue{position: 3}, status: "grpc-status: 0\r\n", position: "3"},
+		{name: "full", queue: &fakeAdmissionQueue{position: 11, err: admission.ErrQueueFull}, status: "grpc-status: 8\r\n", position: "11", retryAfter: "1"},
+		{name: "timeout", queue: &fakeAdmissionQueue{position: 4, err: context.DeadlineExceeded}, status: "grpc-status: 14\r\n", position: "4", retryAfter: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := grpc.NewServer(grpc.UnaryInterceptor(AdmissionInterceptor(testLogger(), tt.queue, AdmissionOptions{
+				Methods: []string{method},
+				MaxWait: 20 * time.Millisecond,
+			})))
+			healthpb.RegisterHealthServer(server, health.NewServer())
+			web := httptest.NewServer(NewWebHandler(server, WebOptions{
+				AllowedOrigins: []string{"https://checkout.tickets.example.com"},
+			}))
+			t.Cleanup(web.Close)
+
+			resp := postGRPCWeb(t, web.URL+method, &healthpb.HealthCheckRequest{})
+			defer resp.Body.Close()
+
+			if got := resp.Header.Get(QueuePositionHeader); got != tt.position {
+				t.Errorf("Expected queue position %q, got %q", tt.position, got)
+			}
+			if got := resp.Header.Get(RetryAfterHeader); got != tt.retryAfter {
+				t.Errorf("Expected retry after %q, got %q", tt.retryAfter, got)
+			}
+			if exposed := resp.Header.Get("Access-Control-Expose-Headers"); !strings.Contains(exposed, QueuePositionHeader) {
+				t.Errorf("Expected the queue headers to be exposed, got %q", exposed)
+			}
+
+			_, trailers := readGRPCWebFrames(t, resp.Body)
+			if !strings.Contains(trailers, tt.status) {
+				t.Errorf("Expected %q in trailers, got %q", tt.status, trailers)
+			}
+		})
+	}
+}
+
+func TestAdmissionInterceptor_OtherMethods(t *testing.T) {
+	queue := &fakeAdmissionQueue{err: admission.ErrQueueFull}
+	interceptor := AdmissionInterceptor(testLogger(), queue, AdmissionOptions{
+		Methods: []string{"/user.UserService/Login"},
+		MaxWait: time.Second,
+	})
+
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Register"}, okHandler); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Login"}, okHandler)
+	if err != errs.ErrAdmissionQueueFull {
+		t.Errorf("Expected ErrAdmissionQueueFull, got %v", status.Convert(err))
+	}
+}
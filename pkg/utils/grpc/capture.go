@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	logutils "user-svc/pkg/utils/log"
+	metautils "user-svc/pkg/utils/metadata"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultCapturedHeaders lists the metadata keys kept in captures. Credentials such as
+// authorization, x-admin-key or dpop are never captured.
+var DefaultCapturedHeaders = []string{
+	metautils.LocaleKey,
+	"user-agent",
+	metautils.ClientIDKey,
+	metautils.RequestIDKey,
+}
+
+// CaptureRecord is a captured unary call, one JSON object per line of a capture file.
+// Request and response are the proto JSON of the messages with sensitive fields set to
+// "[REDACTED]".
+type CaptureRecord struct {
+	Time     time.Time           `json:"time"`
+	Method   string              `json:"method"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+	// Authenticated tells the replayer to send the access token of the replayed session
+	Authenticated bool            `json:"authenticated,omitempty"`
+	Request       json.RawMessage `json:"request,omitempty"`
+	Response      json.RawMessage `json:"response,omitempty"`
+	Code          string          `json:"code"`
+	Error         string          `json:"error,omitempty"`
+	DurationMs    int64           `json:"duration_ms"`
+}
+
+// CaptureWriter appends capture records to a file
+type CaptureWriter struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewCaptureWriter creates a new capture file in dir, named after the start time
+func NewCaptureWriter(dir string) (*CaptureWriter, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create capture directory: %w", err)
+	}
+
+	name := fmt.Sprintf("capture-%s-%d.ndjson", time.Now().UTC().Format("20060102T150405"), os.Getpid())
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create capture file: %w", err)
+	}
+
+	return &CaptureWriter{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+// Path returns the path of the capture file
+func (w *CaptureWriter) Path() string {
+	return w.file.Name()
+}
+
+// Write appends a record as one line
+func (w *CaptureWriter) Write(record *CaptureRecord) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.enc.Encode(record)
+}
+
+// Close closes the capture file
+func (w *CaptureWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.file.Close()
+}
+
+// CaptureOptions configures the capture interceptor
+type CaptureOptions struct {
+	// Methods limits capturing to full method names, e.g. /user.UserService/Register. Empty captures all.
+	Methods []string
+	// RedactedFields lists proto field names masked in the captured messages
+	RedactedFields []string
+	// Headers lists the metadata keys that are captured
+	Headers []string
+}
+
+// CaptureInterceptor records sanitized request/response pairs for replaying them locally with
+// cmd/replay-captures. It is meant for development only: captures hold personal data such as
+// emails even though passwords, tokens and credentials are masked.
+func CaptureInterceptor(logger *logutils.Logger, w *CaptureWriter, opts CaptureOptions) grpc.UnaryServerInterceptor {
+	methods := make(map[string]struct{}, len(opts.Methods))
+	for _, method := range opts.Methods {
+		methods[method] = struct{}{}
+	}
+	redacted := make(map[string]struct{}, len(opts.RedactedFields))
+	for _, field := range opts.RedactedFields {
+		redacted[field] = struct{}{}
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := methods[info.FullMethod]; len(methods) > 0 && !ok {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		record := &CaptureRecord{
+			Time:          start.UTC(),
+			Method:        info.FullMethod,
+			Metadata:      capturedHeaders(ctx, opts.Headers),
+			Authenticated: hasAuthorization(ctx),
+			Request:       rawPayload(redactPayload(req, redacted)),
+			Code:          "OK",
+			DurationMs:    time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			// Errors are converted further up the chain, so the code is the one clients see
+			st := status.Convert(errs.ToGRPCError(err))
+			record.Code, record.Error = st.Code().String(), st.Message()
+		} else {
+			record.Response = rawPayload(redactPayload(resp, redacted))
+		}
+
+		if werr := w.Write(record); werr != nil {
+			logger.WithError(werr).WithField("method", info.FullMethod).Warn("Failed to capture request")
+		}
+
+		return resp, err
+	}
+}
+
+func capturedHeaders(ctx context.Context, headers []string) map[string][]string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	captured := make(map[string][]string)
+	for _, key := range headers {
+		if values := md.Get(strings.ToLower(key)); len(values) > 0 {
+			captured[key] = values
+		}
+	}
+	return captured
+}
+
+func hasAuthorization(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get("authorization")) > 0
+}
+
+func rawPayload(payload string) json.RawMessage {
+	if payload == "" {
+		return nil
+	}
+	return json.RawMessage(payload)
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	pb "user-svc/api/proto/v1"
+	"user-svc/internal/app/domains/errs"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func readCaptureFile(t *testing.T, path string) []*CaptureRecord {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open capture file: %v", err)
+	}
+	defer file.Close()
+
+	var records []*CaptureRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record CaptureRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Failed to decode capture record: %v", err)
+		}
+		records = append(records, &record)
+	}
+	return records
+}
+
+func TestCaptureInterceptor_RecordsSanitizedCalls(t *testing.T) {
+	w, err := NewCaptureWriter(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create capture writer: %v", err)
+	}
+	interceptor := CaptureInterceptor(testLogger(), w, CaptureOptions{
+		RedactedFields: DefaultRedactedFields,
+		Headers:        DefaultCapturedHeaders,
+	})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"user-agent", "tickets-ios/5.2",
+		"x-admin-key", "admin-secret",
+		"authorization", "Bearer access-secret",
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Login"}
+	req := &pb.LoginRequest{Email: "user@example.com", Password: "Secret123!"}
+
+	_, err = interceptor(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+		return &pb.LoginResponse{AccessToken: "access-secret", RefreshToken: "refresh-secret"}, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err = interceptor(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, errs.ErrInvalidCredentials
+	})
+	if err != errs.ErrInvalidCredentials {
+		t.Fatalf("Expected the handler error, got %v", err)
+	}
+	w.Close()
+
+	content, err := os.ReadFile(w.Path())
+	if err != nil {
+		t.Fatalf("Failed to read capture file: %v", err)
+	}
+	for _, secret := range []string{"Secret123!", "access-secret", "refresh-secret", "admin-secret"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("Expected %s not to be captured", secret)
+		}
+	}
+
+	records := readCaptureFile(t, w.Path())
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].Code != "OK" || !records[0].Authenticated || records[0].Metadata["user-agent"][0] != "tickets-ios/5.2" {
+		t.Errorf("Expected an authenticated OK record with the user agent, got %+v", records[0])
+	}
+	if records[1].Code != "Unauthenticated" || records[1].Response != nil {
+		t.Errorf("Expected an Unauthenticated record without response, got %+v", records[1])
+	}
+}
+
+func TestCaptureInterceptor_OnlyCapturesListedMethods(t *testing.T) {
+	w, err := NewCaptureWriter(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create capture writer: %v", err)
+	}
+	interceptor := CaptureInterceptor(testLogger(), w, CaptureOptions{Methods: []string{"/user.UserService/Register"}})
+
+	for _, method := range []string{"/user.UserService/Register", "/user.UserService/Login"} {
+		if _, err := interceptor(context.Background(), &pb.LoginRequest{}, &grpc.UnaryServerInfo{FullMethod: method}, okHandler); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	w.Close()
+
+	records := readCaptureFile(t, w.Path())
+	if len(records) != 1 || records[0].Method != "/user.UserService/Register" {
+		t.Errorf("Expected only the Register call to be captured, got %+v", records)
+	}
+}
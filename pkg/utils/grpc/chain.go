@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"slices"
+
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc"
+)
+
+// Stage positions an interceptor in a chain; interceptors of lower stages run first, i.e.
+// wrap the interceptors of higher stages
+type Stage int
+
+const (
+	// StageMetrics observes every request, including recovered panics and rejected calls
+	StageMetrics Stage = iota
+	// StageRecovery turns panics of the stages below into Internal errors
+	StageRecovery
+	// StageLogging logs the outcome of requests as the client sees it
+	StageLogging
+	// StageErrors converts the domain errors of the stages below to gRPC status errors
+	StageErrors
+	// StageAdmission queues calls until there is capacity to serve them
+	StageAdmission
+	// StageDeadline bounds how long the stages below and the handler may run
+	StageDeadline
+	// StageTransport adapts requests and responses to the transport, e.g. session cookies
+	// of gRPC-Web callers, before anything reads them
+	StageTransport
+	// StageDiagnostics captures and injects faults into requests, in development only
+	StageDiagnostics
+	// StageAuth checks how requests are authorized, e.g. DPoP proofs
+	StageAuth
+	// StageLimits rejects callers over their rate limits or quotas
+	StageLimits
+	// StageRouting routes requests to their data, e.g. tenant schemas, right before the handler
+	StageRouting
+)
+
+// link is an interceptor of a chain
+type link[I any] struct {
+	name        string
+	stage       Stage
+	interceptor I
+}
+
+// Chain collects named interceptors and orders them by stage, so the order no longer depends
+// on the order they are added in; interceptors of the same stage keep their order
+type Chain[I any] struct {
+	links []link[I]
+}
+
+// Use adds an interceptor at a stage
+func (c *Chain[I]) Use(name string, stage Stage, interceptor I) *Chain[I] {
+	c.links = append(c.links, link[I]{name: name, stage: stage, interceptor: interceptor})
+	return c
+}
+
+func (c *Chain[I]) sorted() []link[I] {
+	links := slices.Clone(c.links)
+	slices.SortStableFunc(links, func(a, b link[I]) int {
+		return int(a.stage) - int(b.stage)
+	})
+	return links
+}
+
+// Names returns the names of the interceptors in the order they run
+func (c *Chain[I]) Names() []string {
+	links := c.sorted()
+	names := make([]string, 0, len(links))
+	for _, link := range links {
+		names = append(names, link.name)
+	}
+	return names
+}
+
+// Interceptors returns the interceptors in the order they run
+func (c *Chain[I]) Interceptors() []I {
+	links := c.sorted()
+	interceptors := make([]I, 0, len(links))
+	for _, link := range links {
+		interceptors = append(interceptors, link.interceptor)
+	}
+	return interceptors
+}
+
+// BuiltinInterceptors enables the interceptors every server runs by default
+type BuiltinInterceptors struct {
+	Metrics       bool
+	Recovery      bool
+	Logging       bool
+	ErrorHandling bool
+}
+
+// AllBuiltinInterceptors enables every built-in interceptor
+func AllBuiltinInterceptors() BuiltinInterceptors {
+	return BuiltinInterceptors{Metrics: true, Recovery: true, Logging: true, ErrorHandling: true}
+}
+
+// NewUnaryChain returns a unary chain with the enabled built-in interceptors. Interceptors
+// added later run after error handling, so their domain errors are converted too.
+func NewUnaryChain(
+	logger *logutils.Logger,
+	loggingOpts LoggingOptions,
+	observer RequestObserver,
+	builtins BuiltinInterceptors,
+) *Chain[grpc.UnaryServerInterceptor] {
+	chain := &Chain[grpc.UnaryServerInterceptor]{}
+	if builtins.Metrics {
+		chain.Use("metrics", StageMetrics, MetricsInterceptor(observer))
+	}
+	if builtins.Recovery {
+		chain.Use("recovery", StageRecovery, PanicRecoveryInterceptor(logger))
+	}
+	if builtins.Logging {
+		chain.Use("logging", StageLogging, LoggingInterceptor(logger, loggingOpts))
+	}
+	if builtins.ErrorHandling {
+		chain.Use("error_handling", StageErrors, ErrorHandlingInterceptor(logger))
+	}
+	return chain
+}
+
+// NewStreamChain returns a stream chain with the enabled built-in interceptors; streams have
+// no metrics or error handling interceptors
+func NewStreamChain(logger *logutils.Logger, builtins BuiltinInterceptors) *Chain[grpc.StreamServerInterceptor] {
+	chain := &Chain[grpc.StreamServerInterceptor]{}
+	if builtins.Recovery {
+		chain.Use("recovery", StageRecovery, StreamPanicRecoveryInterceptor(logger))
+	}
+	if builtins.Logging {
+		chain.Use("logging", StageLogging, StreamLoggingInterceptor(logger))
+	}
+	return chain
+}
+
+// ServerOptions installs both chains on a server
+func ServerOptions(unary *Chain[grpc.UnaryServerInterceptor], stream *Chain[grpc.StreamServerInterceptor]) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary.Interceptors()...),
+		grpc.ChainStreamInterceptor(stream.Interceptors()...),
+	}
+}
@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingInterceptor appends its name to calls when it runs
+func recordingInterceptor(name string, calls *[]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		*calls = append(*calls, name)
+		return handler(ctx, req)
+	}
+}
+
+// runChain calls handler through the interceptors like grpc.ChainUnaryInterceptor does
+func runChain(interceptors []grpc.UnaryServerInterceptor, handler grpc.UnaryHandler) (interface{}, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Login"}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	return handler(context.Background(), nil)
+}
+
+func TestChain_OrdersByStage(t *testing.T) {
+	var calls []string
+	chain := &Chain[grpc.UnaryServerInterceptor]{}
+	chain.Use("tenant", StageRouting, recordingInterceptor("tenant", &calls)).
+		Use("quota", StageLimits, recordingInterceptor("quota", &calls)).
+		Use("capture", StageDiagnostics, recordingInterceptor("capture", &calls)).
+		Use("fault_injection", StageDiagnostics, recordingInterceptor("fault_injection", &calls)).
+		Use("metrics", StageMetrics, recordingInterceptor("metrics", &calls))
+
+	expected := []string{"metrics", "capture", "fault_injection", "quota", "tenant"}
+	if names := chain.Names(); !slices.Equal(names, expected) {
+		t.Errorf("Expected names %v, got %v", expected, names)
+	}
+
+	if _, err := runChain(chain.Interceptors(), okHandler); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !slices.Equal(calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestNewUnaryChain_BuiltinInterceptors(t *testing.T) {
+	tests := []struct {
+		name     string
+		builtins BuiltinInterceptors
+		expected []string
+	}{
+		{name: "all", builtins: AllBuiltinInterceptors(), expected: []string{"metrics", "recovery", "logging", "error_handling", "dpop"}},
+		{name: "without logging", builtins: BuiltinInterceptors{Metrics: true, Recovery: true, ErrorHandling: true}, expected: []string{"metrics", "recovery", "error_handling", "dpop"}},
+		{name: "none", builtins: BuiltinInterceptors{}, expected: []string{"dpop"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			chain := NewUnaryChain(testLogger(), DefaultLoggingOptions(), nil, tt.builtins)
+			chain.Use("dpop", StageAuth, recordingInterceptor("dpop", &calls))
+
+			if names := chain.Names(); !slices.Equal(names, tt.expected) {
+				t.Errorf("Expected names %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestNewUnaryChain_RecoversPanicsOfLaterStages(t *testing.T) {
+	chain := NewUnaryChain(testLogger(), DefaultLoggingOptions(), nil, AllBuiltinInterceptors())
+	chain.Use("panicking", StageLimits, func(context.Context, interface{}, *grpc.UnaryServerInfo, grpc.UnaryHandler) (interface{}, error) {
+		panic("quota store unavailable")
+	})
+
+	_, err := runChain(chain.Interceptors(), okHandler)
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+}
+
+func TestNewStreamChain_BuiltinInterceptors(t *testing.T) {
+	chain := NewStreamChain(testLogger(), AllBuiltinInterceptors())
+	chain.Use("tenant", StageRouting, func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	})
+
+	expected := []string{"recovery", "logging", "tenant"}
+	if names := chain.Names(); !slices.Equal(names, expected) {
+		t.Errorf("Expected names %v, got %v", expected, names)
+	}
+	if len(chain.Interceptors()) != len(expected) {
+		t.Errorf("Expected %d interceptors, got %d", len(expected), len(chain.Interceptors()))
+	}
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+
+	_ "user-svc/pkg/utils/grpc/zstd" // registers the zstd compressor
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// CompressionOptions configures which responses are compressed and how
+type CompressionOptions struct {
+	// Algorithms are the compressors in order of preference, e.g. zstd before gzip
+	Algorithms []string
+	// Methods limits compression to these full method names; empty compresses every method
+	Methods []string
+}
+
+// SetGzipLevel sets the level of the gzip compressor. It must be called before the server
+// starts serving, since the level applies to every gzip-compressed message.
+func SetGzipLevel(level int) error {
+	return gzip.SetLevel(level)
+}
+
+// compressor picks the first configured algorithm the client accepts, or none
+func (o CompressionOptions) compressor(ctx context.Context, method string) string {
+	if len(o.Methods) > 0 && !slices.Contains(o.Methods, method) {
+		return ""
+	}
+
+	accepted, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil {
+		return ""
+	}
+	for _, algorithm := range o.Algorithms {
+		if slices.Contains(accepted, algorithm) {
+			return algorithm
+		}
+	}
+	return ""
+}
+
+// CompressionInterceptor compresses the responses of the configured methods with the first
+// algorithm the client accepts, as advertised in grpc-accept-encoding. Clients that accept
+// none of them get uncompressed responses, so compression is safe to enable for any method.
+// Responses to compressed requests are compressed the same way by gRPC unless overridden here.
+func CompressionInterceptor(logger *logutils.Logger, opts CompressionOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		setSendCompressor(ctx, logger, info.FullMethod, opts.compressor(ctx, info.FullMethod))
+
+		return handler(ctx, req)
+	}
+}
+
+// CompressionStreamInterceptor is the stream counterpart of CompressionInterceptor, e.g. for
+// exports streaming thousands of users
+func CompressionStreamInterceptor(logger *logutils.Logger, opts CompressionOptions) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := stream.Context()
+		setSendCompressor(ctx, logger, info.FullMethod, opts.compressor(ctx, info.FullMethod))
+
+		return handler(srv, stream)
+	}
+}
+
+func setSendCompressor(ctx context.Context, logger *logutils.Logger, method, algorithm string) {
+	if algorithm == "" {
+		return
+	}
+	if err := grpc.SetSendCompressor(ctx, algorithm); err != nil {
+		logger.WithError(err).WithFields(logutils.Fields{
+			"method":    method,
+			"algorithm": algorithm,
+		}).Debug("Failed to set response compressor")
+	}
+}
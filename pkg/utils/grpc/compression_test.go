@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"user-svc/pkg/utils/grpc/zstd"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/stats"
+)
+
+// compressionRecorder records the compression of the responses a client receives
+type compressionRecorder struct {
+	mu          sync.Mutex
+	compression string
+}
+
+func (r *compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if header, ok := s.(*stats.InHeader); ok {
+		r.mu.Lock()
+		r.compression = header.Compression
+		r.mu.Unlock()
+	}
+}
+
+func (r *compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+func (r *compressionRecorder) received() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.compression
+}
+
+func TestCompressionInterceptor(t *testing.T) {
+	const method = "/grpc.health.v1.Health/Check"
+
+	tests := []struct {
+		name        string
+		opts        CompressionOptions
+		compression string
+	}{
+		{name: "preferred", opts: CompressionOptions{Algorithms: []string{zstd.Name, gzip.Name}}, compression: zstd.Name},
+		{name: "gzip", opts: CompressionOptions{Algorithms: []string{gzip.Name}, Methods: []string{method}}, compression: gzip.Name},
+		{name: "other method", opts: CompressionOptions{Algorithms: []string{gzip.Name}, Methods: []string{"/user.UserService/ExportUsers"}}},
+		{name: "not accepted", opts: CompressionOptions{Algorithms: []string{"br"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &compressionRecorder{}
+			client := newCompressionTestClient(t, recorder, grpc.UnaryInterceptor(CompressionInterceptor(testLogger(), tt.opts)))
+
+			resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+				t.Errorf("Expected SERVING, got %v", resp.GetStatus())
+			}
+			if got := recorder.received(); got != tt.compression {
+				t.Errorf("Expected compression %q, got %q", tt.compression, got)
+			}
+		})
+	}
+}
+
+func TestCompressionStreamInterceptor(t *testing.T) {
+	recorder := &compressionRecorder{}
+	client := newCompressionTestClient(t, recorder, grpc.StreamInterceptor(CompressionStreamInterceptor(testLogger(), CompressionOptions{
+		Algorithms: []string{zstd.Name},
+		Methods:    []string{"/grpc.health.v1.Health/Watch"},
+	})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Expected a health update, got %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected SERVING, got %v", resp.GetStatus())
+	}
+	if got := recorder.received(); got != zstd.Name {
+		t.Errorf("Expected compression %q, got %q", zstd.Name, got)
+	}
+}
+
+func newCompressionTestClient(t *testing.T, recorder stats.Handler, opt grpc.ServerOption) healthpb.HealthClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer(opt)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(recorder),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// ConsistencyTokenHeader carries the consistency token of a mutating call in its response
+	// headers, and back to the service in the metadata of later calls
+	ConsistencyTokenHeader = "x-consistency-token"
+	// ConsistencyModeHeader set to ConsistencyModePrimary makes a call read from the primary
+	ConsistencyModeHeader = "x-consistency-mode"
+	// ConsistencyModePrimary skips the replica, e.g. for a client that lost its token
+	ConsistencyModePrimary = "primary"
+)
+
+// ConsistencyHeaders are the consistency response headers, e.g. to expose to browsers
+var ConsistencyHeaders = []string{ConsistencyTokenHeader}
+
+// ConsistencyRouter routes the reads of requests between the primary and read replicas so
+// requests read the writes of the calls whose consistency token they carry
+type ConsistencyRouter interface {
+	WithConsistency(ctx context.Context, readOnly bool, token string, primary bool) (context.Context, error)
+	// ConsistencyToken returns a token covering the writes made with ctx, "" without writes
+	ConsistencyToken(ctx context.Context) (string, error)
+}
+
+// ConsistencyInterceptor gives clients read-your-writes consistency over read replicas. A
+// successful call that wrote returns a consistency token in the x-consistency-token header;
+// passing it back in the metadata of a later call, e.g. a read right after Register, makes
+// the call wait for the replica to catch up or read from the primary. Only methods the
+// proto marks NO_SIDE_EFFECTS read from replicas.
+func ConsistencyInterceptor(logger *logutils.Logger, router ConsistencyRouter, methods APIMethods) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx, err := router.WithConsistency(ctx,
+			methods.ReadOnly(info.FullMethod),
+			firstValue(md, ConsistencyTokenHeader),
+			firstValue(md, ConsistencyModeHeader) == ConsistencyModePrimary,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		// The writes are committed, a token that cannot be determined only costs the client
+		// its read-your-writes guarantee
+		token, tokenErr := router.ConsistencyToken(ctx)
+		if tokenErr != nil {
+			logger.WithError(tokenErr).WithField("method", info.FullMethod).Warn("Failed to get consistency token")
+			return resp, nil
+		}
+		if token != "" {
+			if err := grpc.SetHeader(ctx, metadata.Pairs(ConsistencyTokenHeader, token)); err != nil {
+				logger.WithError(err).WithField("method", info.FullMethod).Debug("Failed to set consistency token header")
+			}
+		}
+		return resp, nil
+	}
+}
+
+// firstValue returns the first value of a metadata key, "" if there is none
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "user-svc/api/proto/v1"
+	"user-svc/internal/app/domains/errs"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type consistencyKey struct{}
+
+// fakeConsistencyRouter records how requests are set up and hands out a token after writes
+type fakeConsistencyRouter struct {
+	readOnly bool
+	token    string
+	primary  bool
+}
+
+type fakeConsistency struct {
+	wrote bool
+}
+
+func (r *fakeConsistencyRouter) WithConsistency(ctx context.Context, readOnly bool, token string, primary bool) (context.Context, error) {
+	if token == "invalid" {
+		return ctx, errs.ErrInvalidConsistencyToken
+	}
+	r.readOnly, r.token, r.primary = readOnly, token, primary
+	return context.WithValue(ctx, consistencyKey{}, &fakeConsistency{}), nil
+}
+
+func (r *fakeConsistencyRouter) ConsistencyToken(ctx context.Context) (string, error) {
+	if ctx.Value(consistencyKey{}).(*fakeConsistency).wrote {
+		return "16/B374D848", nil
+	}
+	return "", nil
+}
+
+func TestConsistencyInterceptor(t *testing.T) {
+	methods := NewAPIMethods(pb.File_v1_user_svc_proto.Services().ByName("UserService"))
+	writer := func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx.Value(consistencyKey{}).(*fakeConsistency).wrote = true
+		return "ok", nil
+	}
+	reader := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		md       metadata.MD
+		handler  grpc.UnaryHandler
+		readOnly bool
+		token    string
+		primary  bool
+		header   string
+	}{
+		{name: "write", method: pb.UserService_Register_FullMethodName, handler: writer, header: "16/B374D848"},
+		{
+			name:     "read after write",
+			method:   pb.UserService_GetUserHistory_FullMethodName,
+			md:       metadata.Pairs(ConsistencyTokenHeader, "16/B374D848"),
+			handler:  reader,
+			readOnly: true,
+			token:    "16/B374D848",
+		},
+		{
+			name:     "primary",
+			method:   pb.UserService_GetUserHistory_FullMethodName,
+			md:       metadata.Pairs(ConsistencyModeHeader, ConsistencyModePrimary),
+			handler:  reader,
+			readOnly: true,
+			primary:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := &fakeConsistencyRouter{}
+			stream := &headerStream{}
+			ctx := grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), tt.md), stream)
+
+			interceptor := ConsistencyInterceptor(testLogger(), router, methods)
+			if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, tt.handler); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if router.readOnly != tt.readOnly || router.token != tt.token || router.primary != tt.primary {
+				t.Errorf("Expected read-only %v token %q primary %v, got %v %q %v",
+					tt.readOnly, tt.token, tt.primary, router.readOnly, router.token, router.primary)
+			}
+			if got := firstValue(stream.header, ConsistencyTokenHeader); got != tt.header {
+				t.Errorf("Expected consistency token header %q, got %q", tt.header, got)
+			}
+		})
+	}
+}
+
+func TestConsistencyInterceptor_InvalidToken(t *testing.T) {
+	methods := NewAPIMethods(pb.File_v1_user_svc_proto.Services().ByName("UserService"))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ConsistencyTokenHeader, "invalid"))
+
+	interceptor := ConsistencyInterceptor(testLogger(), &fakeConsistencyRouter{}, methods)
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: pb.UserService_GetUserHistory_FullMethodName}, okHandler)
+	if !errors.Is(err, errs.ErrInvalidConsistencyToken) {
+		t.Errorf("Expected ErrInvalidConsistencyToken, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// AccessTokenCookie holds the access token of browser sessions in session mode
+	AccessTokenCookie = "access_token"
+	// RefreshTokenCookie holds the refresh token of browser sessions; scripts cannot read it
+	RefreshTokenCookie = "refresh_token"
+	// CSRFTokenCookie holds the double-submit token that calls using a token cookie must
+	// repeat in CSRFTokenMetadataKey
+	CSRFTokenCookie = "csrf_token"
+	// CSRFTokenMetadataKey carries the CSRF token; responses setting the cookies send the new
+	// token in this header as well, for web apps on another origin than the gateway
+	CSRFTokenMetadataKey = "x-csrf-token"
+
+	accessTokenField  = "access_token"
+	refreshTokenField = "refresh_token"
+	// nestedTokenField is the token of token messages, e.g. the refresh tokens of v2 responses
+	nestedTokenField  = "token"
+	cookieMetadataKey = "cookie"
+	csrfTokenBytes    = 32
+)
+
+// SessionCookieOptions configures the session cookies of gRPC-Web callers
+type SessionCookieOptions struct {
+	// AccessTokens also moves access tokens into a cookie, which authorizes the calls
+	// without an authorization header
+	AccessTokens bool
+	// AccessTokenMaxAge and RefreshTokenMaxAge are how long browsers keep the token cookies,
+	// the CSRF token cookie lives as long as the refresh token cookie
+	AccessTokenMaxAge  time.Duration
+	RefreshTokenMaxAge time.Duration
+	// Domain, Path and SameSite are the scope of the cookies; an empty domain limits them to
+	// the gateway host and an empty path is "/"
+	Domain   string
+	Path     string
+	SameSite http.SameSite
+}
+
+// SessionCookieInterceptor is a gRPC interceptor that keeps the tokens of gRPC-Web callers
+// out of reach of scripts. A refresh token in a response is moved into an HttpOnly cookie
+// and a new CSRF token is issued with it; with AccessTokens so is the access token. A request
+// with an empty refresh token field uses the refresh token cookie instead, and one without
+// an authorization header the access token cookie, provided the CSRF token header matches
+// the CSRF token cookie. Native gRPC calls are not changed.
+func SessionCookieInterceptor(logger *logutils.Logger, opts SessionCookieOptions) grpc.UnaryServerInterceptor {
+	if opts.Path == "" {
+		opts.Path = "/"
+	}
+	if opts.SameSite == 0 {
+		opts.SameSite = http.SameSiteStrictMode
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if len(md.Get(grpcWebMetadataKey)) == 0 {
+			return handler(ctx, req)
+		}
+
+		cookies := requestCookies(md)
+		refreshMsg, refreshField := stringField(req, refreshTokenField)
+		useRefreshToken := refreshField != nil && refreshMsg.Get(refreshField).String() == "" && cookies[RefreshTokenCookie] != ""
+		useAccessToken := opts.AccessTokens && len(md.Get(authorizationMetadataKey)) == 0 && cookies[AccessTokenCookie] != ""
+		if useRefreshToken || useAccessToken {
+			if !validCSRFToken(md, cookies[CSRFTokenCookie]) {
+				logger.WithField("method", info.FullMethod).Warn("gRPC-Web request rejected by CSRF check")
+				return nil, errs.ErrInvalidCSRFToken
+			}
+		}
+		if useRefreshToken {
+			refreshMsg.Set(refreshField, protoreflect.ValueOfString(cookies[RefreshTokenCookie]))
+		}
+		if useAccessToken {
+			md = md.Copy()
+			md.Set(authorizationMetadataKey, "Bearer "+cookies[AccessTokenCookie])
+			ctx = metadata.NewIncomingContext(ctx, md)
+		}
+
+		resp, err = handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		header := metadata.MD{}
+		var issued []func()
+		if msg, field := stringField(resp, refreshTokenField); field != nil && msg.Get(field).String() != "" {
+			csrfToken, err := newCSRFToken()
+			if err != nil {
+				logger.WithError(err).WithField("method", info.FullMethod).Error("Failed to create CSRF token")
+				return resp, nil
+			}
+			header.Append("set-cookie",
+				opts.cookie(RefreshTokenCookie, msg.Get(field).String(), true, opts.RefreshTokenMaxAge),
+				opts.cookie(CSRFTokenCookie, csrfToken, false, opts.RefreshTokenMaxAge),
+			)
+			header.Set(CSRFTokenMetadataKey, csrfToken)
+			issued = append(issued, func() { msg.Clear(field) })
+		}
+		if msg, field := stringField(resp, accessTokenField); opts.AccessTokens && field != nil && msg.Get(field).String() != "" {
+			header.Append("set-cookie", opts.cookie(AccessTokenCookie, msg.Get(field).String(), true, opts.AccessTokenMaxAge))
+			issued = append(issued, func() { msg.Clear(field) })
+		}
+		if len(issued) == 0 {
+			return resp, nil
+		}
+
+		if err := grpc.SetHeader(ctx, header); err != nil {
+			// The caller keeps the tokens in the response instead
+			logger.WithError(err).WithField("method", info.FullMethod).Warn("Failed to set session cookies")
+			return resp, nil
+		}
+		for _, clear := range issued {
+			clear()
+		}
+
+		return resp, nil
+	}
+}
+
+// stringField returns a singular string field of a message by name, or nil. A message field
+// of the name stands for its token field.
+func stringField(m interface{}, name protoreflect.Name) (protoreflect.Message, protoreflect.FieldDescriptor) {
+	pm, ok := m.(proto.Message)
+	if !ok || pm == nil {
+		return nil, nil
+	}
+
+	msg := pm.ProtoReflect()
+	if !msg.IsValid() {
+		return nil, nil
+	}
+	field := msg.Descriptor().Fields().ByName(name)
+	if field != nil && field.Kind() == protoreflect.MessageKind && !field.IsList() && msg.Has(field) {
+		return stringField(msg.Get(field).Message().Interface(), nestedTokenField)
+	}
+	if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+		return nil, nil
+	}
+	return msg, field
+}
+
+// requestCookies returns the cookies of the cookie headers, the first of each name
+func requestCookies(md metadata.MD) map[string]string {
+	cookies := make(map[string]string)
+	for _, line := range md.Get(cookieMetadataKey) {
+		parsed, err := http.ParseCookie(line)
+		if err != nil {
+			continue
+		}
+		for _, cookie := range parsed {
+			if _, ok := cookies[cookie.Name]; !ok {
+				cookies[cookie.Name] = cookie.Value
+			}
+		}
+	}
+	return cookies
+}
+
+// validCSRFToken reports whether the CSRF token header repeats the CSRF token cookie
+func validCSRFToken(md metadata.MD, cookie string) bool {
+	headers := md.Get(CSRFTokenMetadataKey)
+	if cookie == "" || len(headers) != 1 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(headers[0]), []byte(cookie)) == 1
+}
+
+func newCSRFToken() (string, error) {
+	token := make([]byte, csrfTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// cookie formats the Set-Cookie header of a secure cookie in the scope of the options
+func (opts SessionCookieOptions) cookie(name, value string, httpOnly bool, maxAge time.Duration) string {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Domain:   opts.Domain,
+		Path:     opts.Path,
+		MaxAge:   int(maxAge.Seconds()),
+		Secure:   true,
+		HttpOnly: httpOnly,
+		SameSite: opts.SameSite,
+	}
+	return cookie.String()
+}
@@ -0,0 +1,266 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	pb "user-svc/api/proto/v1"
+	pbv2 "user-svc/api/proto/v2"
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeRefreshServer rotates every refresh token it is given and records the authorization
+// of other calls
+type fakeRefreshServer struct {
+	pb.UnimplementedUserServiceServer
+	received       []string
+	authorizations []string
+}
+
+func (s *fakeRefreshServer) RefreshToken(_ context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	s.received = append(s.received, req.RefreshToken)
+	return &pb.RefreshTokenResponse{AccessToken: "access", RefreshToken: "rotated-" + req.RefreshToken}, nil
+}
+
+func (s *fakeRefreshServer) GetUserStats(ctx context.Context, _ *pb.GetUserStatsRequest) (*pb.GetUserStatsResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.authorizations = append(s.authorizations, md.Get(authorizationMetadataKey)...)
+	return &pb.GetUserStatsResponse{}, nil
+}
+
+func newCookieTestServer(t *testing.T, opts SessionCookieOptions) (*httptest.Server, *fakeRefreshServer) {
+	t.Helper()
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(SessionCookieInterceptor(logutils.New(os.Stderr), opts)))
+	fake := &fakeRefreshServer{}
+	pb.RegisterUserServiceServer(server, fake)
+
+	web := httptest.NewServer(NewWebHandler(server, WebOptions{
+		AllowedOrigins: []string{"https://checkout.tickets.example.com"},
+		Credentials:    true,
+	}))
+	t.Cleanup(web.Close)
+	return web, fake
+}
+
+func TestSessionCookieInterceptor_IssuesCookies(t *testing.T) {
+	web, _ := newCookieTestServer(t, SessionCookieOptions{RefreshTokenMaxAge: time.Hour})
+
+	resp := postGRPCWebWithHeader(t, web.URL+pb.UserService_RefreshToken_FullMethodName, &pb.RefreshTokenRequest{RefreshToken: "token"}, nil)
+	defer resp.Body.Close()
+
+	cookies := make(map[string]*http.Cookie)
+	for _, cookie := range resp.Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	refresh, csrf := cookies[RefreshTokenCookie], cookies[CSRFTokenCookie]
+	if refresh == nil || refresh.Value != "rotated-token" || !refresh.HttpOnly || !refresh.Secure || refresh.SameSite != http.SameSiteStrictMode {
+		t.Fatalf("Expected a secure HttpOnly refresh token cookie, got %v", refresh)
+	}
+	if refresh.MaxAge != 3600 {
+		t.Errorf("Expected a max age of 3600, got %d", refresh.MaxAge)
+	}
+	if csrf == nil || csrf.Value == "" || csrf.HttpOnly {
+		t.Fatalf("Expected a CSRF token cookie, got %v", csrf)
+	}
+	if header := resp.Header.Get(CSRFTokenMetadataKey); header != csrf.Value {
+		t.Errorf("Expected the CSRF token %q in the header, got %q", csrf.Value, header)
+	}
+	if resp.Header.Get("Access-Control-Allow-Credentials") != "true" {
+		t.Error("Expected credentials to be allowed")
+	}
+
+	messages, _ := readGRPCWebFrames(t, resp.Body)
+	var refreshed pb.RefreshTokenResponse
+	if len(messages) != 1 || proto.Unmarshal(messages[0], &refreshed) != nil {
+		t.Fatalf("Expected a response message, got %d", len(messages))
+	}
+	if refreshed.RefreshToken != "" || refreshed.AccessToken != "access" {
+		t.Errorf("Expected only the access token in the response, got %v", &refreshed)
+	}
+}
+
+// fakeRefreshServerV2 rotates every refresh token it is given, with the v2 messages
+type fakeRefreshServerV2 struct {
+	pbv2.UnimplementedUserServiceServer
+}
+
+func (fakeRefreshServerV2) RefreshToken(_ context.Context, req *pbv2.RefreshTokenRequest) (*pbv2.RefreshTokenResponse, error) {
+	return &pbv2.RefreshTokenResponse{
+		AccessToken:  "access",
+		RefreshToken: &pbv2.RefreshToken{Token: "rotated-" + req.RefreshToken, ExpiresAt: 1760616000000},
+	}, nil
+}
+
+func TestSessionCookieInterceptor_IssuesCookiesV2(t *testing.T) {
+	server := grpc.NewServer(grpc.UnaryInterceptor(SessionCookieInterceptor(logutils.New(os.Stderr), SessionCookieOptions{RefreshTokenMaxAge: time.Hour})))
+	pbv2.RegisterUserServiceServer(server, fakeRefreshServerV2{})
+	web := httptest.NewServer(NewWebHandler(server, WebOptions{
+		AllowedOrigins: []string{"https://checkout.tickets.example.com"},
+		Credentials:    true,
+	}))
+	defer web.Close()
+
+	resp := postGRPCWebWithHeader(t, web.URL+pbv2.UserService_RefreshToken_FullMethodName, &pbv2.RefreshTokenRequest{RefreshToken: "token"}, nil)
+	defer resp.Body.Close()
+
+	var refresh *http.Cookie
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == RefreshTokenCookie {
+			refresh = cookie
+		}
+	}
+	if refresh == nil || refresh.Value != "rotated-token" {
+		t.Fatalf("Expected the nested refresh token in a cookie, got %v", refresh)
+	}
+
+	messages, _ := readGRPCWebFrames(t, resp.Body)
+	var refreshed pbv2.RefreshTokenResponse
+	if len(messages) != 1 || proto.Unmarshal(messages[0], &refreshed) != nil {
+		t.Fatalf("Expected a response message, got %d", len(messages))
+	}
+	if refreshed.RefreshToken.GetToken() != "" || refreshed.RefreshToken.GetExpiresAt() != 1760616000000 {
+		t.Errorf("Expected only the expiry of the refresh token in the response, got %v", &refreshed)
+	}
+}
+
+func TestSessionCookieInterceptor_ReadsCookie(t *testing.T) {
+	tests := []struct {
+		name      string
+		csrfToken string
+		status    string
+		received  []string
+	}{
+		{name: "matching CSRF token", csrfToken: "csrf", status: "grpc-status: 0\r\n", received: []string{"cookie-token"}},
+		{name: "wrong CSRF token", csrfToken: "other", status: "grpc-status: 7\r\n"},
+		{name: "missing CSRF token", status: "grpc-status: 7\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			web, fake := newCookieTestServer(t, SessionCookieOptions{RefreshTokenMaxAge: time.Hour})
+
+			header := http.Header{"Cookie": {RefreshTokenCookie + "=cookie-token; " + CSRFTokenCookie + "=csrf"}}
+			if tt.csrfToken != "" {
+				header.Set(CSRFTokenMetadataKey, tt.csrfToken)
+			}
+			resp := postGRPCWebWithHeader(t, web.URL+pb.UserService_RefreshToken_FullMethodName, &pb.RefreshTokenRequest{}, header)
+			defer resp.Body.Close()
+
+			_, trailers := readGRPCWebFrames(t, resp.Body)
+			if !strings.Contains(trailers, tt.status) {
+				t.Errorf("Expected %q in trailers, got %q", tt.status, trailers)
+			}
+			if strings.Join(fake.received, ",") != strings.Join(tt.received, ",") {
+				t.Errorf("Expected refresh tokens %v, got %v", tt.received, fake.received)
+			}
+		})
+	}
+}
+
+func TestSessionCookieInterceptor_NativeCalls(t *testing.T) {
+	interceptor := SessionCookieInterceptor(logutils.New(os.Stderr), SessionCookieOptions{AccessTokens: true, RefreshTokenMaxAge: time.Hour})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(cookieMetadataKey, RefreshTokenCookie+"=cookie-token"))
+	info := &grpc.UnaryServerInfo{FullMethod: pb.UserService_RefreshToken_FullMethodName}
+
+	resp, err := interceptor(ctx, &pb.RefreshTokenRequest{}, info, func(_ context.Context, req interface{}) (interface{}, error) {
+		if token := req.(*pb.RefreshTokenRequest).RefreshToken; token != "" {
+			t.Errorf("Expected no refresh token from the cookie, got %q", token)
+		}
+		return &pb.RefreshTokenResponse{RefreshToken: "issued"}, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if token := resp.(*pb.RefreshTokenResponse).RefreshToken; token != "issued" {
+		t.Errorf("Expected the refresh token in the response, got %q", token)
+	}
+}
+
+func TestSessionCookieInterceptor_SessionMode(t *testing.T) {
+	web, _ := newCookieTestServer(t, SessionCookieOptions{
+		AccessTokens:       true,
+		AccessTokenMaxAge:  15 * time.Minute,
+		RefreshTokenMaxAge: time.Hour,
+		Domain:             "tickets.example.com",
+		Path:               "/api",
+		SameSite:           http.SameSiteLaxMode,
+	})
+
+	resp := postGRPCWebWithHeader(t, web.URL+pb.UserService_RefreshToken_FullMethodName, &pb.RefreshTokenRequest{RefreshToken: "token"}, nil)
+	defer resp.Body.Close()
+
+	cookies := make(map[string]*http.Cookie)
+	for _, cookie := range resp.Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	access := cookies[AccessTokenCookie]
+	if access == nil || access.Value != "access" || !access.HttpOnly || !access.Secure {
+		t.Fatalf("Expected a secure HttpOnly access token cookie, got %v", access)
+	}
+	if access.MaxAge != 900 {
+		t.Errorf("Expected a max age of 900, got %d", access.MaxAge)
+	}
+	for _, name := range []string{AccessTokenCookie, RefreshTokenCookie, CSRFTokenCookie} {
+		cookie := cookies[name]
+		if cookie == nil || cookie.Domain != "tickets.example.com" || cookie.Path != "/api" || cookie.SameSite != http.SameSiteLaxMode {
+			t.Errorf("Expected %s to be scoped by the options, got %v", name, cookie)
+		}
+	}
+
+	messages, _ := readGRPCWebFrames(t, resp.Body)
+	var refreshed pb.RefreshTokenResponse
+	if len(messages) != 1 || proto.Unmarshal(messages[0], &refreshed) != nil {
+		t.Fatalf("Expected a response message, got %d", len(messages))
+	}
+	if refreshed.RefreshToken != "" || refreshed.AccessToken != "" {
+		t.Errorf("Expected no tokens in the response, got %v", &refreshed)
+	}
+}
+
+func TestSessionCookieInterceptor_AuthorizesWithCookie(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorization  string
+		csrfToken      string
+		status         string
+		authorizations []string
+	}{
+		{name: "matching CSRF token", csrfToken: "csrf", status: "grpc-status: 0\r\n", authorizations: []string{"Bearer cookie-token"}},
+		{name: "missing CSRF token", status: "grpc-status: 7\r\n"},
+		{name: "authorization header", authorization: "Bearer header-token", status: "grpc-status: 0\r\n", authorizations: []string{"Bearer header-token"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			web, fake := newCookieTestServer(t, SessionCookieOptions{AccessTokens: true})
+
+			header := http.Header{"Cookie": {AccessTokenCookie + "=cookie-token; " + CSRFTokenCookie + "=csrf"}}
+			if tt.authorization != "" {
+				header.Set("Authorization", tt.authorization)
+			}
+			if tt.csrfToken != "" {
+				header.Set(CSRFTokenMetadataKey, tt.csrfToken)
+			}
+			resp := postGRPCWebWithHeader(t, web.URL+pb.UserService_GetUserStats_FullMethodName, &pb.GetUserStatsRequest{}, header)
+			defer resp.Body.Close()
+
+			_, trailers := readGRPCWebFrames(t, resp.Body)
+			if !strings.Contains(trailers, tt.status) {
+				t.Errorf("Expected %q in trailers, got %q", tt.status, trailers)
+			}
+			if strings.Join(fake.authorizations, ",") != strings.Join(tt.authorizations, ",") {
+				t.Errorf("Expected authorizations %v, got %v", tt.authorizations, fake.authorizations)
+			}
+		})
+	}
+}
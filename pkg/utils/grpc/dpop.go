@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/pkg/utils/crypt/dpop"
+	"user-svc/pkg/utils/crypt/token"
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DPoPMetadataKey is the incoming metadata key carrying a DPoP proof JWT
+	DPoPMetadataKey = "dpop"
+
+	authorizationMetadataKey = "authorization"
+)
+
+// AccessTokenVerifier verifies access tokens presented by callers
+type AccessTokenVerifier interface {
+	VerifyAccessToken(token string) (*token.Payload, error)
+}
+
+// DPoPInterceptor is a gRPC interceptor that validates DPoP proofs (RFC 9449). A valid
+// proof's key thumbprint is put on the context so token-issuing handlers can bind new
+// tokens to it. Access tokens bound to a key are only accepted together with a fresh
+// proof signed by that key, and requiredMethods refuse bearer-only callers altogether.
+// The proof's htu claim is the gRPC full method name.
+func DPoPInterceptor(logger *logutils.Logger, verifier *dpop.Verifier, tokens AccessTokenVerifier, requiredMethods []string) grpc.UnaryServerInterceptor {
+	required := make(map[string]struct{}, len(requiredMethods))
+	for _, method := range requiredMethods {
+		required[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		accessToken := authorizationToken(md)
+		_, mustProve := required[info.FullMethod]
+
+		reject := func(err error, reason string) (interface{}, error) {
+			logger.WithFields(logutils.Fields{
+				"method": info.FullMethod,
+				"reason": reason,
+			}).Warn("gRPC request rejected by DPoP check")
+			return nil, err
+		}
+
+		var proof *dpop.Proof
+		if proofs := md.Get(DPoPMetadataKey); len(proofs) > 0 {
+			if len(proofs) > 1 {
+				return reject(errs.ErrInvalidDPoPProof, "multiple proofs")
+			}
+
+			proof, err = verifier.Verify(proofs[0], dpop.HTTPMethod, info.FullMethod, accessToken)
+			if err != nil {
+				return reject(errs.ErrInvalidDPoPProof, err.Error())
+			}
+			ctx = dpop.NewContext(ctx, proof.JKT)
+		}
+
+		if proof == nil && mustProve {
+			return reject(errs.ErrDPoPProofRequired, "missing proof")
+		}
+
+		if accessToken != "" {
+			// Invalid tokens are left for the handler to reject with the usual errors
+			payload, err := tokens.VerifyAccessToken(accessToken)
+			if err == nil {
+				boundKey := payload.BoundKey()
+				switch {
+				case boundKey == "" && mustProve:
+					return reject(errs.ErrDPoPProofRequired, "bearer token on a DPoP-only method")
+				case boundKey != "" && proof == nil:
+					return reject(errs.ErrDPoPProofRequired, "bound token without proof")
+				case boundKey != "" && boundKey != proof.JKT:
+					return reject(errs.ErrDPoPKeyMismatch, "proof key does not match token")
+				}
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// authorizationToken extracts the access token from either a DPoP or a Bearer authorization
+func authorizationToken(md metadata.MD) string {
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+
+	for _, scheme := range []string{"DPoP ", "Bearer "} {
+		if accessToken, ok := strings.CutPrefix(values[0], scheme); ok {
+			return accessToken
+		}
+	}
+
+	return ""
+}
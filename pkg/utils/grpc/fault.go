@@ -0,0 +1,166 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// FaultAllMethods matches every method in a fault rule
+const FaultAllMethods = "*"
+
+// FaultRule describes a fault injected into a fraction of the calls to a method
+type FaultRule struct {
+	// Method is the full gRPC method name, or FaultAllMethods
+	Method string
+	// Probability is the fraction (0..1) of matching calls the fault is injected into
+	Probability float64
+	// Latency delays the call before the handler runs
+	Latency time.Duration
+	// ErrorCode fails the call with this code instead of running the handler; OK disables it
+	ErrorCode codes.Code
+	// ResetConnection aborts the client's TCP connection instead of running the handler
+	ResetConnection bool
+}
+
+// ParseCode parses a gRPC code name such as "UNAVAILABLE"
+func ParseCode(name string) (codes.Code, error) {
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil {
+		return codes.OK, fmt.Errorf("invalid gRPC code %q: %w", name, err)
+	}
+	return code, nil
+}
+
+// FaultInjectionInterceptor is a gRPC interceptor that injects latency, errors and
+// connection resets for resiliency testing. It must never be enabled in production.
+// Connection resets require the server to listen on a FaultListener.
+func FaultInjectionInterceptor(logger *logutils.Logger, rules []FaultRule, listener *FaultListener) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		for _, rule := range rules {
+			if rule.Method != FaultAllMethods && rule.Method != info.FullMethod {
+				continue
+			}
+			if rule.Probability <= 0 || rand.Float64() >= rule.Probability {
+				continue
+			}
+
+			logger := logger.WithField("method", info.FullMethod)
+
+			if rule.Latency > 0 {
+				metrics.FaultsInjected.WithLabelValues(info.FullMethod, "latency").Inc()
+				logger.WithField("latency", rule.Latency).Debug("Injecting latency")
+
+				timer := time.NewTimer(rule.Latency)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return nil, status.FromContextError(ctx.Err()).Err()
+				}
+			}
+
+			if rule.ResetConnection && listener != nil {
+				if p, ok := peer.FromContext(ctx); ok && listener.Reset(p.Addr) {
+					metrics.FaultsInjected.WithLabelValues(info.FullMethod, "reset").Inc()
+					logger.Debug("Injecting connection reset")
+					return nil, status.Error(codes.Unavailable, "injected fault: connection reset")
+				}
+			}
+
+			if rule.ErrorCode != codes.OK {
+				metrics.FaultsInjected.WithLabelValues(info.FullMethod, "error").Inc()
+				logger.WithField("code", rule.ErrorCode.String()).Debug("Injecting error")
+				return nil, status.Errorf(rule.ErrorCode, "injected fault: %s", rule.ErrorCode.String())
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// FaultListener is a net.Listener that keeps track of accepted connections so
+// that injected faults can reset them
+type FaultListener struct {
+	net.Listener
+
+	mu    sync.Mutex
+	conns map[string]net.Conn
+}
+
+// NewFaultListener creates a fault listener. Call Wrap once the server listens.
+func NewFaultListener() *FaultListener {
+	return &FaultListener{
+		conns: make(map[string]net.Conn),
+	}
+}
+
+// Wrap starts tracking the connections accepted by lis
+func (l *FaultListener) Wrap(lis net.Listener) net.Listener {
+	l.Listener = lis
+	return l
+}
+
+// Accept waits for and returns the next tracked connection
+func (l *FaultListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := &faultConn{Conn: conn, listener: l}
+	l.mu.Lock()
+	l.conns[conn.RemoteAddr().String()] = tracked
+	l.mu.Unlock()
+
+	return tracked, nil
+}
+
+// Reset aborts the connection from the given remote address with a TCP RST.
+// It reports whether such a connection was found.
+func (l *FaultListener) Reset(addr net.Addr) bool {
+	l.mu.Lock()
+	conn, ok := l.conns[addr.String()]
+	l.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	if tcpConn, ok := conn.(*faultConn).Conn.(*net.TCPConn); ok {
+		_ = tcpConn.SetLinger(0)
+	}
+	_ = conn.Close()
+
+	return true
+}
+
+func (l *FaultListener) forget(conn net.Conn) {
+	l.mu.Lock()
+	delete(l.conns, conn.RemoteAddr().String())
+	l.mu.Unlock()
+}
+
+type faultConn struct {
+	net.Conn
+	listener *FaultListener
+	once     sync.Once
+}
+
+func (c *faultConn) Close() error {
+	c.once.Do(func() {
+		c.listener.forget(c.Conn)
+	})
+	return c.Conn.Close()
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func testLogger() *logutils.Logger {
+	return logutils.New(io.Discard)
+}
+
+func okHandler(context.Context, interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func TestFaultInjectionInterceptor_InjectsError(t *testing.T) {
+	interceptor := FaultInjectionInterceptor(testLogger(), []FaultRule{{
+		Method:      "/user.UserService/Login",
+		Probability: 1,
+		ErrorCode:   codes.Unavailable,
+	}}, nil)
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Login"}, okHandler)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Register"}, okHandler)
+	if err != nil || resp != "ok" {
+		t.Errorf("Expected other methods to be untouched, got %v, %v", resp, err)
+	}
+}
+
+func TestFaultInjectionInterceptor_ZeroProbability(t *testing.T) {
+	interceptor := FaultInjectionInterceptor(testLogger(), []FaultRule{{
+		Method:    FaultAllMethods,
+		ErrorCode: codes.Internal,
+	}}, nil)
+
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/x"}, okHandler); err != nil {
+		t.Errorf("Expected no fault with zero probability, got %v", err)
+	}
+}
+
+func TestFaultInjectionInterceptor_InjectsLatency(t *testing.T) {
+	interceptor := FaultInjectionInterceptor(testLogger(), []FaultRule{{
+		Method:      FaultAllMethods,
+		Probability: 1,
+		Latency:     20 * time.Millisecond,
+	}}, nil)
+
+	start := time.Now()
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/x"}, okHandler); err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Expected at least 20ms latency, got %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/x"}, okHandler); status.Code(err) != codes.Canceled {
+		t.Errorf("Expected Canceled for a cancelled context, got %v", err)
+	}
+}
+
+func TestFaultInjectionInterceptor_ResetsConnection(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	faultListener := NewFaultListener()
+	wrapped := faultListener.Wrap(lis)
+	defer wrapped.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := wrapped.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	client, err := net.Dial("tcp", lis.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer client.Close()
+	server := <-accepted
+
+	interceptor := FaultInjectionInterceptor(testLogger(), []FaultRule{{
+		Method:          FaultAllMethods,
+		Probability:     1,
+		ResetConnection: true,
+	}}, faultListener)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: server.RemoteAddr()})
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/x"}, okHandler); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+
+	_ = client.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := client.Read(make([]byte, 1)); err == nil {
+		t.Error("Expected the client connection to be reset")
+	}
+	if faultListener.Reset(server.RemoteAddr()) {
+		t.Error("Expected the reset connection to be forgotten")
+	}
+}
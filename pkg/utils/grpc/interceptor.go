@@ -2,24 +2,25 @@ package grpc
 
 import (
 	"context"
+	"math/rand"
 	"runtime/debug"
 	"time"
 
 	"user-svc/internal/app/domains/errs"
+	logutils "user-svc/pkg/utils/log"
 
-	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // PanicRecoveryInterceptor is a gRPC interceptor that recovers from panics
-func PanicRecoveryInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor {
+func PanicRecoveryInterceptor(logger *logutils.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		defer func() {
 			if r := recover(); r != nil {
 				// Log the panic with stack trace
-				logger.WithFields(logrus.Fields{
+				logger.WithContext(ctx).WithFields(logutils.Fields{
 					"method":      info.FullMethod,
 					"panic":       r,
 					"stack_trace": string(debug.Stack()),
@@ -37,7 +38,7 @@ func PanicRecoveryInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor
 }
 
 // ErrorHandlingInterceptor is a gRPC interceptor that handles errors and converts them to proper gRPC status codes
-func ErrorHandlingInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor {
+func ErrorHandlingInterceptor(logger *logutils.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		// Call the handler
 		resp, err = handler(ctx, req)
@@ -45,32 +46,64 @@ func ErrorHandlingInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor
 		// If there's an error, handle it
 		if err != nil {
 			// Log the error
-			logger.WithFields(logrus.Fields{
+			logger.WithContext(ctx).WithFields(logutils.Fields{
 				"method":    info.FullMethod,
 				"error":     err.Error(),
 				"timestamp": time.Now().UTC(),
 			}).Error("gRPC error occurred")
 
-			// Convert to gRPC error if it's not already
-			if _, ok := status.FromError(err); !ok {
-				err = errs.ToGRPCError(err)
-			}
+			// Convert to gRPC error, unwrapping domain errors wrapped further down the stack
+			err = errs.ToGRPCError(err)
 		}
 
 		return resp, err
 	}
 }
 
+// LoggingOptions configures the logging interceptor
+type LoggingOptions struct {
+	// SuccessSampleRate is the fraction (0..1) of successful requests that are logged
+	SuccessSampleRate float64
+	// ErrorSampleRate is the fraction (0..1) of failed requests that are logged
+	ErrorSampleRate float64
+	// CapturePayloads logs redacted request and response bodies. Never enable in production.
+	CapturePayloads bool
+	// RedactedFields lists proto field names masked in captured payloads
+	RedactedFields []string
+}
+
+// DefaultLoggingOptions logs every request without payloads
+func DefaultLoggingOptions() LoggingOptions {
+	return LoggingOptions{
+		SuccessSampleRate: 1,
+		ErrorSampleRate:   1,
+		RedactedFields:    DefaultRedactedFields,
+	}
+}
+
+// sampled reports whether an event with the given rate should be logged
+func sampled(rate float64) bool {
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
 // LoggingInterceptor is a gRPC interceptor that logs request/response information
-func LoggingInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor {
+func LoggingInterceptor(logger *logutils.Logger, opts LoggingOptions) grpc.UnaryServerInterceptor {
+	redacted := make(map[string]struct{}, len(opts.RedactedFields))
+	for _, field := range opts.RedactedFields {
+		redacted[field] = struct{}{}
+	}
+
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		start := time.Now()
 
-		// Log the incoming request
-		logger.WithFields(logrus.Fields{
-			"method":    info.FullMethod,
-			"timestamp": start.UTC(),
-		}).Info("gRPC request started")
+		// Log the incoming request. Sampling is decided on the outcome, so this is debug only,
+		// and the fields are only built when debug logging is on.
+		if logger.IsLevelEnabled(logutils.DebugLevel) {
+			logger.WithContext(ctx).WithFields(logutils.Fields{
+				"method":    info.FullMethod,
+				"timestamp": start.UTC(),
+			}).Debug("gRPC request started")
+		}
 
 		// Call the handler
 		resp, err = handler(ctx, req)
@@ -78,42 +111,38 @@ func LoggingInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor {
 		// Calculate duration
 		duration := time.Since(start)
 
+		if err != nil && !sampled(opts.ErrorSampleRate) || err == nil && !sampled(opts.SuccessSampleRate) {
+			return resp, err
+		}
+
+		fields := logutils.Fields{
+			"method":    info.FullMethod,
+			"duration":  duration,
+			"timestamp": time.Now().UTC(),
+		}
+		if opts.CapturePayloads {
+			fields["request"] = redactPayload(req, redacted)
+			if err == nil {
+				fields["response"] = redactPayload(resp, redacted)
+			}
+		}
+
 		// Log the response
 		if err != nil {
-			logger.WithFields(logrus.Fields{
-				"method":    info.FullMethod,
-				"duration":  duration,
-				"error":     err.Error(),
-				"timestamp": time.Now().UTC(),
-			}).Error("gRPC request failed")
+			fields["error"] = err.Error()
+			logger.WithContext(ctx).WithFields(fields).Error("gRPC request failed")
 		} else {
-			logger.WithFields(logrus.Fields{
-				"method":    info.FullMethod,
-				"duration":  duration,
-				"timestamp": time.Now().UTC(),
-			}).Info("gRPC request completed")
+			logger.WithContext(ctx).WithFields(fields).Info("gRPC request completed")
 		}
 
 		return resp, err
 	}
 }
 
-// GetUnaryInterceptors returns a single chained unary interceptor as server option
-func GetUnaryInterceptors(logger *logrus.Logger) []grpc.ServerOption {
-	// Chain the interceptors in the desired order
-	chainedInterceptor := grpc.ChainUnaryInterceptor(
-		PanicRecoveryInterceptor(logger),
-		LoggingInterceptor(logger),
-		ErrorHandlingInterceptor(logger),
-	)
-
-	return []grpc.ServerOption{chainedInterceptor}
-}
-
 // CustomErrorHandler provides custom error handling for gRPC streams
-func CustomErrorHandler(logger *logrus.Logger) func(error) {
+func CustomErrorHandler(logger *logutils.Logger) func(error) {
 	return func(err error) {
-		logger.WithFields(logrus.Fields{
+		logger.WithFields(logutils.Fields{
 			"error":     err.Error(),
 			"timestamp": time.Now().UTC(),
 		}).Error("gRPC stream error")
@@ -121,12 +150,12 @@ func CustomErrorHandler(logger *logrus.Logger) func(error) {
 }
 
 // StreamPanicRecoveryInterceptor is a gRPC stream interceptor that recovers from panics
-func StreamPanicRecoveryInterceptor(logger *logrus.Logger) grpc.StreamServerInterceptor {
+func StreamPanicRecoveryInterceptor(logger *logutils.Logger) grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
 		defer func() {
 			if r := recover(); r != nil {
 				// Log the panic with stack trace
-				logger.WithFields(logrus.Fields{
+				logger.WithContext(stream.Context()).WithFields(logutils.Fields{
 					"method":      info.FullMethod,
 					"panic":       r,
 					"stack_trace": string(debug.Stack()),
@@ -144,12 +173,12 @@ func StreamPanicRecoveryInterceptor(logger *logrus.Logger) grpc.StreamServerInte
 }
 
 // StreamLoggingInterceptor is a gRPC stream interceptor that logs stream information
-func StreamLoggingInterceptor(logger *logrus.Logger) grpc.StreamServerInterceptor {
+func StreamLoggingInterceptor(logger *logutils.Logger) grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
 		start := time.Now()
 
 		// Log the incoming stream
-		logger.WithFields(logrus.Fields{
+		logger.WithContext(stream.Context()).WithFields(logutils.Fields{
 			"method":           info.FullMethod,
 			"is_client_stream": info.IsClientStream,
 			"is_server_stream": info.IsServerStream,
@@ -164,14 +193,14 @@ func StreamLoggingInterceptor(logger *logrus.Logger) grpc.StreamServerIntercepto
 
 		// Log the stream completion
 		if err != nil {
-			logger.WithFields(logrus.Fields{
+			logger.WithContext(stream.Context()).WithFields(logutils.Fields{
 				"method":    info.FullMethod,
 				"duration":  duration,
 				"error":     err.Error(),
 				"timestamp": time.Now().UTC(),
 			}).Error("gRPC stream failed")
 		} else {
-			logger.WithFields(logrus.Fields{
+			logger.WithContext(stream.Context()).WithFields(logutils.Fields{
 				"method":    info.FullMethod,
 				"duration":  duration,
 				"timestamp": time.Now().UTC(),
@@ -181,14 +210,3 @@ func StreamLoggingInterceptor(logger *logrus.Logger) grpc.StreamServerIntercepto
 		return err
 	}
 }
-
-// GetStreamInterceptors returns a single chained stream interceptor as server option
-func GetStreamInterceptors(logger *logrus.Logger) []grpc.ServerOption {
-	// Chain the stream interceptors in the desired order
-	chainedInterceptor := grpc.ChainStreamInterceptor(
-		StreamPanicRecoveryInterceptor(logger),
-		StreamLoggingInterceptor(logger),
-	)
-
-	return []grpc.ServerOption{chainedInterceptor}
-}
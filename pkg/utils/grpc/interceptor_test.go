@@ -0,0 +1,22 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func BenchmarkLoggingInterceptor(b *testing.B) {
+	interceptor := LoggingInterceptor(testLogger(), DefaultLoggingOptions())
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Login"}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := interceptor(ctx, nil, info, okHandler); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
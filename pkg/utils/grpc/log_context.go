@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+
+	logutils "user-svc/pkg/utils/log"
+	metautils "user-svc/pkg/utils/metadata"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader is the request ID sent by callers and returned in the response headers,
+// generated if the caller sent none or an invalid one
+const RequestIDHeader = metautils.RequestIDKey
+
+// LogContextInterceptor puts the request ID, method, caller, client and trace ID of each
+// request on its context, so every line logged with log.FromContext is correlated. The caller is taken
+// from a valid access token; tokens may be nil to skip it.
+func LogContextInterceptor(logger *logutils.Logger, tokens AccessTokenVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, requestID := logContext(ctx, tokens, info.FullMethod)
+		if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID)); err != nil {
+			logger.WithError(err).WithField("method", info.FullMethod).Debug("Failed to set request ID header")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// LogContextStreamInterceptor is the stream counterpart of LogContextInterceptor
+func LogContextStreamInterceptor(logger *logutils.Logger, tokens AccessTokenVerifier) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := logContext(stream.Context(), tokens, info.FullMethod)
+		if err := stream.SetHeader(metadata.Pairs(RequestIDHeader, requestID)); err != nil {
+			logger.WithError(err).WithField("method", info.FullMethod).Debug("Failed to set request ID header")
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// logContext returns the context carrying the log fields of a request, and its request ID
+func logContext(ctx context.Context, tokens AccessTokenVerifier, method string) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	// Invalid headers are dropped rather than rejected, the request ID being regenerated
+	headers, _ := metautils.Parse(md)
+	requestID := headers.RequestID
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	var callerID string
+	if accessToken := authorizationToken(md); accessToken != "" && tokens != nil {
+		// Invalid tokens are left for the handler to reject with the usual errors
+		if payload, err := tokens.VerifyAccessToken(accessToken); err == nil {
+			callerID = payload.UserID
+		}
+	}
+
+	return logutils.NewContext(ctx, logutils.Fields{
+		logutils.RequestIDField:  requestID,
+		logutils.GRPCMethodField: method,
+		logutils.CallerIDField:   callerID,
+		logutils.ClientIDField:   headers.ClientID,
+		logutils.TraceIDField:    TraceID(ctx),
+	}), requestID
+}
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	logutils "user-svc/pkg/utils/log"
+	metautils "user-svc/pkg/utils/metadata"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLogContextInterceptor(t *testing.T) {
+	logger := logutils.New(io.Discard)
+	interceptor := LogContextInterceptor(logger, fakeTokens{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/GetUser"}
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		expected logutils.Fields
+	}{
+		{
+			name: "caller request ID, access token, client and trace",
+			md:   metadata.Pairs(RequestIDHeader, "req-1", "authorization", "Bearer valid", metautils.ClientIDKey, "web", TraceParentHeader, traceParent),
+			expected: logutils.Fields{
+				logutils.RequestIDField:  "req-1",
+				logutils.GRPCMethodField: info.FullMethod,
+				logutils.CallerIDField:   "user-1",
+				logutils.ClientIDField:   "web",
+				logutils.TraceIDField:    "4bf92f3577b34da6a3ce929d0e0e4736",
+			},
+		},
+		{
+			name: "invalid access token",
+			md:   metadata.Pairs(RequestIDHeader, "req-2", "authorization", "Bearer expired"),
+			expected: logutils.Fields{
+				logutils.RequestIDField:  "req-2",
+				logutils.GRPCMethodField: info.FullMethod,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			var fields logutils.Fields
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				fields = logutils.ContextFields(ctx)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(fields) != len(tt.expected) {
+				t.Errorf("Expected fields %v, got %v", tt.expected, fields)
+			}
+			for key, value := range tt.expected {
+				if fields[key] != value {
+					t.Errorf("Expected %s to be %v, got %v", key, value, fields[key])
+				}
+			}
+		})
+	}
+}
+
+func TestLogContextInterceptor_GeneratesRequestID(t *testing.T) {
+	logger := logutils.New(io.Discard)
+	interceptor := LogContextInterceptor(logger, nil)
+
+	var requestID interface{}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+	_, _ = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Login"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		requestID = logutils.ContextFields(ctx)[logutils.RequestIDField]
+		return nil, nil
+	})
+	if id, ok := requestID.(string); !ok || len(id) != 36 {
+		t.Errorf("Expected a generated UUID request ID, got %v", requestID)
+	}
+}
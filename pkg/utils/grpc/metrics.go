@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"user-svc/pkg/utils/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestObserver receives the outcome of every handled request
+type RequestObserver interface {
+	Observe(method string, duration time.Duration, code codes.Code)
+}
+
+// MetricsInterceptor is a gRPC interceptor that records request counts and latencies.
+// It must run outside the panic recovery interceptor so recovered panics are counted.
+// Latencies of traced calls carry the trace ID as exemplar. The observer is optional.
+func MetricsInterceptor(observer RequestObserver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		start := time.Now()
+
+		resp, err = handler(ctx, req)
+
+		duration := time.Since(start)
+		code := status.Code(err)
+
+		metrics.GRPCRequests.WithLabelValues(info.FullMethod, code.String()).Inc()
+		metrics.ObserveWithTraceID(metrics.GRPCRequestDuration.WithLabelValues(info.FullMethod), duration.Seconds(), TraceID(ctx))
+		if observer != nil {
+			observer.Observe(info.FullMethod, duration, code)
+		}
+
+		return resp, err
+	}
+}
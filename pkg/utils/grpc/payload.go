@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const redactedValue = "[REDACTED]"
+
+// DefaultRedactedFields lists proto field names that are never written to logs
+var DefaultRedactedFields = []string{
+	"password",
+	"new_password",
+	"access_token",
+	"refresh_token",
+	"token",
+}
+
+// redactPayload renders a proto message as JSON with sensitive string fields masked.
+// Non-proto values are not captured.
+func redactPayload(v interface{}, redacted map[string]struct{}) string {
+	msg, ok := v.(proto.Message)
+	if !ok || msg == nil {
+		return ""
+	}
+
+	clone := proto.Clone(msg)
+	redactMessage(clone.ProtoReflect(), redacted)
+
+	out, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(clone)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// redactMessage masks sensitive fields in place, recursing into nested messages
+func redactMessage(m protoreflect.Message, redacted map[string]struct{}) {
+	// Top-level fields are masked after iterating, as Range does not allow setting fields
+	var masked []protoreflect.FieldDescriptor
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		_, sensitive := redacted[string(fd.Name())]
+
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				if fd.Kind() == protoreflect.MessageKind {
+					redactMessage(list.Get(i).Message(), redacted)
+				} else if sensitive && fd.Kind() == protoreflect.StringKind {
+					list.Set(i, protoreflect.ValueOfString(redactedValue))
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					redactMessage(mv.Message(), redacted)
+					return true
+				})
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			redactMessage(v.Message(), redacted)
+		case sensitive && (fd.Kind() == protoreflect.StringKind || fd.Kind() == protoreflect.BytesKind):
+			masked = append(masked, fd)
+		}
+		return true
+	})
+
+	for _, fd := range masked {
+		if fd.Kind() == protoreflect.StringKind {
+			m.Set(fd, protoreflect.ValueOfString(redactedValue))
+		} else {
+			m.Set(fd, protoreflect.ValueOfBytes([]byte(redactedValue)))
+		}
+	}
+}
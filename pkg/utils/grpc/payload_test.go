@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"strings"
+	"testing"
+
+	pb "user-svc/api/proto/v1"
+)
+
+func TestRedactPayload_MasksSensitiveFields(t *testing.T) {
+	redacted := map[string]struct{}{"password": {}, "access_token": {}, "refresh_token": {}}
+
+	req := &pb.LoginRequest{Email: "user@example.com", Password: "Secret123!"}
+	out := redactPayload(req, redacted)
+
+	if strings.Contains(out, "Secret123!") {
+		t.Errorf("Expected password to be redacted, got %s", out)
+	}
+	if !strings.Contains(out, "user@example.com") {
+		t.Errorf("Expected email to be captured, got %s", out)
+	}
+	if req.Password != "Secret123!" {
+		t.Error("Redaction must not modify the original message")
+	}
+}
+
+func TestRedactPayload_RecursesIntoNestedMessages(t *testing.T) {
+	redacted := map[string]struct{}{"access_token": {}, "refresh_token": {}}
+
+	resp := &pb.LoginResponse{
+		User:         &pb.User{Id: "id-1", Email: "user@example.com", Username: "user"},
+		AccessToken:  "access-secret",
+		RefreshToken: "refresh-secret",
+	}
+	out := redactPayload(resp, redacted)
+
+	if strings.Contains(out, "access-secret") || strings.Contains(out, "refresh-secret") {
+		t.Errorf("Expected tokens to be redacted, got %s", out)
+	}
+	if !strings.Contains(out, "id-1") {
+		t.Errorf("Expected nested user to be captured, got %s", out)
+	}
+}
+
+func TestSampled(t *testing.T) {
+	if !sampled(1) {
+		t.Error("Rate 1 should always sample")
+	}
+	if sampled(0) {
+		t.Error("Rate 0 should never sample")
+	}
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"user-svc/internal/app/domains/models"
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Response headers of the IETF RateLimit header fields draft, sent to callers approaching
+// or over their quota. The reset is in seconds.
+const (
+	RateLimitLimitHeader     = "ratelimit-limit"
+	RateLimitRemainingHeader = "ratelimit-remaining"
+	RateLimitResetHeader     = "ratelimit-reset"
+)
+
+// RateLimitHeaders are the ratelimit response headers, e.g. to expose to browsers
+var RateLimitHeaders = []string{RateLimitLimitHeader, RateLimitRemainingHeader, RateLimitResetHeader}
+
+// QuotaEnforcer records a call and rejects it when the caller is over quota. The usage
+// returned, if any, is reported to the caller.
+type QuotaEnforcer interface {
+	Consume(ctx context.Context, method string) (*models.QuotaUsage, error)
+}
+
+// QuotaInterceptor is a gRPC interceptor that enforces call quotas before the handler runs.
+// Calls the enforcer reports a usage for get the ratelimit headers, rejected ones included.
+func QuotaInterceptor(logger *logutils.Logger, enforcer QuotaEnforcer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		usage, err := enforcer.Consume(ctx, info.FullMethod)
+		if usage != nil {
+			if err := grpc.SetHeader(ctx, rateLimitMetadata(usage, time.Now())); err != nil {
+				logger.WithError(err).WithField("method", info.FullMethod).Debug("Failed to set ratelimit headers")
+			}
+		}
+		if err != nil {
+			logger.WithFields(logutils.Fields{
+				"method": info.FullMethod,
+				"error":  err.Error(),
+			}).Debug("gRPC request rejected by quota")
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitMetadata returns the ratelimit headers of a usage, with the reset rounded up to
+// whole seconds
+func rateLimitMetadata(usage *models.QuotaUsage, now time.Time) metadata.MD {
+	reset := max(time.UnixMilli(usage.WindowEnd).Sub(now), 0)
+	seconds := int64((reset + time.Second - 1) / time.Second)
+
+	return metadata.Pairs(
+		RateLimitLimitHeader, strconv.FormatInt(usage.Limit, 10),
+		RateLimitRemainingHeader, strconv.FormatInt(usage.Remaining(), 10),
+		RateLimitResetHeader, strconv.FormatInt(seconds, 10),
+	)
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"user-svc/internal/app/domains/errs"
+	"user-svc/internal/app/domains/models"
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakeQuotaEnforcer struct {
+	usage *models.QuotaUsage
+	err   error
+}
+
+func (e *fakeQuotaEnforcer) Consume(context.Context, string) (*models.QuotaUsage, error) {
+	return e.usage, e.err
+}
+
+func newQuotaTestServer(t *testing.T, enforcer QuotaEnforcer) *httptest.Server {
+	t.Helper()
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(QuotaInterceptor(logutils.New(os.Stderr), enforcer)))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	web := httptest.NewServer(NewWebHandler(server, WebOptions{
+		AllowedOrigins: []string{"https://checkout.tickets.example.com"},
+	}))
+	t.Cleanup(web.Close)
+	return web
+}
+
+func TestQuotaInterceptor_RateLimitHeaders(t *testing.T) {
+	usage := &models.QuotaUsage{Used: 90, Limit: 100, WindowEnd: time.Now().Add(time.Hour).UnixMilli()}
+
+	tests := []struct {
+		name     string
+		enforcer *fakeQuotaEnforcer
+		status   string
+	}{
+		{name: "warning", enforcer: &fakeQuotaEnforcer{usage: usage}, status: "grpc-status: 0\r\n"},
+		{name: "rejected", enforcer: &fakeQuotaEnforcer{usage: usage, err: errs.ErrQuotaExceeded}, status: "grpc-status: 8\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			web := newQuotaTestServer(t, tt.enforcer)
+
+			resp := postGRPCWeb(t, web.URL+"/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{})
+			defer resp.Body.Close()
+
+			if got := resp.Header.Get(RateLimitLimitHeader); got != "100" {
+				t.Errorf("Expected limit 100, got %q", got)
+			}
+			if got := resp.Header.Get(RateLimitRemainingHeader); got != "10" {
+				t.Errorf("Expected 10 remaining, got %q", got)
+			}
+			if got := resp.Header.Get(RateLimitResetHeader); got != "3600" {
+				t.Errorf("Expected a reset in 3600 seconds, got %q", got)
+			}
+			if exposed := resp.Header.Get("Access-Control-Expose-Headers"); !strings.Contains(exposed, RateLimitRemainingHeader) {
+				t.Errorf("Expected the ratelimit headers to be exposed, got %q", exposed)
+			}
+
+			_, trailers := readGRPCWebFrames(t, resp.Body)
+			if !strings.Contains(trailers, tt.status) {
+				t.Errorf("Expected %q in trailers, got %q", tt.status, trailers)
+			}
+		})
+	}
+}
+
+func TestQuotaInterceptor_NoUsage(t *testing.T) {
+	web := newQuotaTestServer(t, &fakeQuotaEnforcer{})
+
+	resp := postGRPCWeb(t, web.URL+"/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{})
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get(RateLimitRemainingHeader); got != "" {
+		t.Errorf("Expected no ratelimit headers below the warning, got %q", got)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
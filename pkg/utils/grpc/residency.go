@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+
+	"user-svc/internal/app/domains/errs"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ResidencyMetadataKey is the incoming metadata key naming the residency region of callers
+// without an access token, e.g. on login, refresh or admin calls
+const ResidencyMetadataKey = "x-residency"
+
+// RegionResolver routes the queries made with a context to the database of a residency region
+type RegionResolver interface {
+	WithRegion(ctx context.Context, region string) (context.Context, error)
+}
+
+// ResidencyInterceptor routes each request to the database of the region its user's data is
+// stored in, taken from the residency claim of a valid access token, otherwise from the
+// x-residency metadata. Requests without a region use the home database.
+func ResidencyInterceptor(resolver RegionResolver, tokens AccessTokenVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := residencyContext(ctx, resolver, tokens)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ResidencyStreamInterceptor is the stream counterpart of ResidencyInterceptor
+func ResidencyStreamInterceptor(resolver RegionResolver, tokens AccessTokenVerifier) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := residencyContext(stream.Context(), resolver, tokens)
+		if err != nil {
+			return errs.ToGRPCError(err)
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func residencyContext(ctx context.Context, resolver RegionResolver, tokens AccessTokenVerifier) (context.Context, error) {
+	region := requestRegion(ctx, tokens)
+	if region == "" {
+		return ctx, nil
+	}
+	return resolver.WithRegion(ctx, region)
+}
+
+// requestRegion returns the residency region a request belongs to, "" for the home region
+func requestRegion(ctx context.Context, tokens AccessTokenVerifier) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if accessToken := authorizationToken(md); accessToken != "" {
+		// Invalid tokens are left for the handler to reject with the usual errors
+		if payload, err := tokens.VerifyAccessToken(accessToken); err == nil && payload.Residency != "" {
+			return payload.Residency
+		}
+	}
+
+	if values := md.Get(ResidencyMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
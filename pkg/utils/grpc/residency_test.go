@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "user-svc/api/proto/v1"
+	"user-svc/internal/app/domains/errs"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeRegionResolver knows the region "eu"
+type fakeRegionResolver struct {
+	resolved []string
+}
+
+func (r *fakeRegionResolver) WithRegion(ctx context.Context, region string) (context.Context, error) {
+	if region != "eu" {
+		return ctx, errs.ErrUnknownResidencyRegion
+	}
+	r.resolved = append(r.resolved, region)
+	return ctx, nil
+}
+
+func TestResidencyInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		claim    string
+		expected string
+	}{
+		{
+			name:     "token claim wins over metadata",
+			md:       metadata.Pairs("authorization", "Bearer valid", ResidencyMetadataKey, "us"),
+			claim:    "eu",
+			expected: "eu",
+		},
+		{
+			name:     "metadata without a claim",
+			md:       metadata.Pairs("authorization", "Bearer valid", ResidencyMetadataKey, "eu"),
+			expected: "eu",
+		},
+		{
+			name:     "metadata with an invalid token",
+			md:       metadata.Pairs("authorization", "Bearer expired", ResidencyMetadataKey, "eu"),
+			claim:    "us",
+			expected: "eu",
+		},
+		{
+			name: "home region",
+			md:   metadata.MD{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &fakeRegionResolver{}
+			interceptor := ResidencyInterceptor(resolver, fakeTokens{residency: tt.claim})
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			if _, err := interceptor(ctx, &pb.LoginRequest{}, &grpc.UnaryServerInfo{}, okHandler); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			switch {
+			case tt.expected == "" && len(resolver.resolved) > 0:
+				t.Errorf("Expected the home region, got %s", resolver.resolved[0])
+			case tt.expected != "" && (len(resolver.resolved) != 1 || resolver.resolved[0] != tt.expected):
+				t.Errorf("Expected region %s, got %v", tt.expected, resolver.resolved)
+			}
+		})
+	}
+}
+
+func TestResidencyInterceptor_UnknownRegion(t *testing.T) {
+	interceptor := ResidencyInterceptor(&fakeRegionResolver{}, fakeTokens{})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ResidencyMetadataKey, "mars"))
+
+	_, err := interceptor(ctx, &pb.LoginRequest{}, &grpc.UnaryServerInfo{}, okHandler)
+	if !errors.Is(err, errs.ErrUnknownResidencyRegion) {
+		t.Errorf("Expected ErrUnknownResidencyRegion, got %v", err)
+	}
+}
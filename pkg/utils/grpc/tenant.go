@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+
+	"user-svc/internal/app/domains/errs"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// OrganizationMetadataKey is the incoming metadata key naming the organization of callers
+// without an access token, e.g. on login or admin calls
+const OrganizationMetadataKey = "x-organization-id"
+
+// TenantResolver routes the queries made with a context to the data of an organization
+type TenantResolver interface {
+	WithTenant(ctx context.Context, organizationID uuid.UUID) (context.Context, error)
+}
+
+// organizationRequest is implemented by requests carrying an organization, e.g. registrations
+type organizationRequest interface {
+	GetOrganizationId() string
+}
+
+// TenantInterceptor routes each request to the data of its organization, taken from the
+// organization claim of a valid access token, otherwise from the x-organization-id metadata,
+// otherwise from the organization_id field of the request. Requests without an organization
+// use the shared data.
+func TenantInterceptor(resolver TenantResolver, tokens AccessTokenVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := tenantContext(ctx, resolver, tokens, req)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// TenantStreamInterceptor is the stream counterpart of TenantInterceptor. Stream messages
+// are read by the handler, so only the access token and metadata name the organization.
+func TenantStreamInterceptor(resolver TenantResolver, tokens AccessTokenVerifier) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := tenantContext(stream.Context(), resolver, tokens, nil)
+		if err != nil {
+			return errs.ToGRPCError(err)
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// contextStream replaces the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func tenantContext(ctx context.Context, resolver TenantResolver, tokens AccessTokenVerifier, req interface{}) (context.Context, error) {
+	organizationID, err := requestOrganization(ctx, tokens, req)
+	if err != nil || organizationID == uuid.Nil {
+		return ctx, err
+	}
+	return resolver.WithTenant(ctx, organizationID)
+}
+
+// requestOrganization returns the organization a request belongs to, uuid.Nil for none
+func requestOrganization(ctx context.Context, tokens AccessTokenVerifier, req interface{}) (uuid.UUID, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if accessToken := authorizationToken(md); accessToken != "" {
+		// Invalid tokens are left for the handler to reject with the usual errors
+		if payload, err := tokens.VerifyAccessToken(accessToken); err == nil && payload.OrganizationID != "" {
+			return uuid.Parse(payload.OrganizationID)
+		}
+	}
+
+	if values := md.Get(OrganizationMetadataKey); len(values) > 0 {
+		organizationID, err := uuid.Parse(values[0])
+		if err != nil {
+			return uuid.Nil, errs.ErrInvalidOrganizationID
+		}
+		return organizationID, nil
+	}
+
+	// Invalid request fields are left for the handler's validation
+	if withOrganization, ok := req.(organizationRequest); ok {
+		if organizationID, err := uuid.Parse(withOrganization.GetOrganizationId()); err == nil {
+			return organizationID, nil
+		}
+	}
+
+	return uuid.Nil, nil
+}
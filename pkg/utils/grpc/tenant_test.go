@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "user-svc/api/proto/v1"
+	"user-svc/internal/app/domains/errs"
+	"user-svc/pkg/utils/crypt/token"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type fakeTenantResolver struct {
+	resolved []uuid.UUID
+}
+
+func (r *fakeTenantResolver) WithTenant(ctx context.Context, organizationID uuid.UUID) (context.Context, error) {
+	r.resolved = append(r.resolved, organizationID)
+	return ctx, nil
+}
+
+// fakeTokens accepts the token "valid" with the organization and residency claims
+type fakeTokens struct {
+	organizationID string
+	residency      string
+}
+
+func (v fakeTokens) VerifyAccessToken(accessToken string) (*token.Payload, error) {
+	if accessToken != "valid" {
+		return nil, token.ErrInvalidToken
+	}
+	return &token.Payload{UserID: "user-1", OrganizationID: v.organizationID, Residency: v.residency}, nil
+}
+
+func TestTenantInterceptor(t *testing.T) {
+	claimed, header, field := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		req      interface{}
+		expected uuid.UUID
+	}{
+		{
+			name:     "token claim wins over metadata",
+			md:       metadata.Pairs("authorization", "Bearer valid", OrganizationMetadataKey, header.String()),
+			req:      &pb.RegisterRequest{OrganizationId: field.String()},
+			expected: claimed,
+		},
+		{
+			name:     "metadata without a token",
+			md:       metadata.Pairs(OrganizationMetadataKey, header.String()),
+			req:      &pb.RegisterRequest{OrganizationId: field.String()},
+			expected: header,
+		},
+		{
+			name:     "metadata with an invalid token",
+			md:       metadata.Pairs("authorization", "Bearer expired", OrganizationMetadataKey, header.String()),
+			expected: header,
+		},
+		{
+			name:     "request field",
+			md:       metadata.MD{},
+			req:      &pb.RegisterRequest{OrganizationId: field.String()},
+			expected: field,
+		},
+		{
+			name:     "invalid request field is left to the handler",
+			md:       metadata.MD{},
+			req:      &pb.RegisterRequest{OrganizationId: "acme"},
+			expected: uuid.Nil,
+		},
+		{
+			name:     "no organization",
+			md:       metadata.MD{},
+			req:      &pb.LoginRequest{},
+			expected: uuid.Nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &fakeTenantResolver{}
+			interceptor := TenantInterceptor(resolver, fakeTokens{organizationID: claimed.String()})
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			if _, err := interceptor(ctx, tt.req, &grpc.UnaryServerInfo{}, okHandler); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			switch {
+			case tt.expected == uuid.Nil && len(resolver.resolved) > 0:
+				t.Errorf("Expected the shared schema, got organization %s", resolver.resolved[0])
+			case tt.expected != uuid.Nil && (len(resolver.resolved) != 1 || resolver.resolved[0] != tt.expected):
+				t.Errorf("Expected organization %s, got %v", tt.expected, resolver.resolved)
+			}
+		})
+	}
+}
+
+func TestTenantInterceptor_InvalidMetadata(t *testing.T) {
+	resolver := &fakeTenantResolver{}
+	interceptor := TenantInterceptor(resolver, fakeTokens{})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(OrganizationMetadataKey, "acme"))
+
+	_, err := interceptor(ctx, &pb.LoginRequest{}, &grpc.UnaryServerInfo{}, okHandler)
+	if !errors.Is(err, errs.ErrInvalidOrganizationID) {
+		t.Errorf("Expected ErrInvalidOrganizationID, got %v", err)
+	}
+	if len(resolver.resolved) > 0 {
+		t.Errorf("Expected no organization to be resolved, got %v", resolver.resolved)
+	}
+}
@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TimeoutOptions bounds how long unary handlers may run
+type TimeoutOptions struct {
+	// Default applies to methods without an override; 0 leaves them unbounded
+	Default time.Duration
+	// Methods override Default per full gRPC method name; 0 leaves the method unbounded
+	Methods map[string]time.Duration
+}
+
+// timeout returns the timeout of a method
+func (opts TimeoutOptions) timeout(method string) time.Duration {
+	if timeout, ok := opts.Methods[method]; ok {
+		return timeout
+	}
+	return opts.Default
+}
+
+// TimeoutInterceptor is a gRPC interceptor that cancels the context of handlers running
+// longer than their method's timeout; a shorter client deadline still applies. A call whose
+// deadline expired fails with DEADLINE_EXCEEDED, whatever error the handler made of it, and
+// is counted per method.
+func TimeoutInterceptor(logger *logutils.Logger, opts TimeoutOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if timeout := opts.timeout(info.FullMethod); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		resp, err = handler(ctx, req)
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return resp, err
+		}
+
+		metrics.GRPCDeadlineExceeded.WithLabelValues(info.FullMethod).Inc()
+		if err == nil {
+			// The handler finished its work anyway, so the response is still worth sending
+			return resp, nil
+		}
+
+		logger.WithError(err).WithField("method", info.FullMethod).Warn("gRPC request deadline exceeded")
+		return nil, status.Error(codes.DeadlineExceeded, "request deadline exceeded")
+	}
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTimeoutInterceptor(t *testing.T) {
+	opts := TimeoutOptions{
+		Default: time.Second,
+		Methods: map[string]time.Duration{
+			"/user.UserService/Register":     5 * time.Second,
+			"/user.UserService/GlobalLogout": 0,
+		},
+	}
+
+	tests := []struct {
+		method   string
+		expected time.Duration
+	}{
+		{method: "/user.UserService/Login", expected: time.Second},
+		{method: "/user.UserService/Register", expected: 5 * time.Second},
+		{method: "/user.UserService/GlobalLogout", expected: 0},
+	}
+
+	interceptor := TimeoutInterceptor(testLogger(), opts)
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			var timeout time.Duration
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if deadline, ok := ctx.Deadline(); ok {
+					timeout = time.Until(deadline)
+				}
+				return "ok", nil
+			}
+
+			if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if timeout > tt.expected || timeout < tt.expected-100*time.Millisecond {
+				t.Errorf("Expected a timeout of about %v, got %v", tt.expected, timeout)
+			}
+		})
+	}
+}
+
+func TestTimeoutInterceptor_DeadlineExceeded(t *testing.T) {
+	interceptor := TimeoutInterceptor(testLogger(), TimeoutOptions{Default: 10 * time.Millisecond})
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/Login"}
+
+	// Errors the handler made of the expired context are reported as DEADLINE_EXCEEDED
+	slow := func(ctx context.Context, req interface{}) (interface{}, error) {
+		<-ctx.Done()
+		return nil, errors.New("failed to query user: " + ctx.Err().Error())
+	}
+	if _, err := interceptor(context.Background(), nil, info, slow); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	// A handler that finished its work anyway still responds
+	finished := func(ctx context.Context, req interface{}) (interface{}, error) {
+		<-ctx.Done()
+		return "ok", nil
+	}
+	if resp, err := interceptor(context.Background(), nil, info, finished); err != nil || resp != "ok" {
+		t.Errorf("Expected the response, got %v and %v", resp, err)
+	}
+
+	// A shorter client deadline still applies
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	long := TimeoutInterceptor(testLogger(), TimeoutOptions{Default: time.Minute})
+	if _, err := long(ctx, nil, info, slow); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// TraceParentHeader is the W3C trace context header propagated by callers and the mesh,
+// e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+const TraceParentHeader = "traceparent"
+
+// TraceID returns the trace ID of the traceparent of an incoming call, "" if the call has no
+// valid one
+func TraceID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	return parseTraceParent(firstValue(md, TraceParentHeader))
+}
+
+// parseTraceParent returns the trace ID of a traceparent: version, trace ID, parent ID and
+// flags in lowercase hex. Version ff and all-zero IDs are invalid; later versions may append
+// fields.
+func parseTraceParent(traceParent string) string {
+	fields := strings.Split(traceParent, "-")
+	if len(fields) < 4 {
+		return ""
+	}
+	version, traceID, parentID, flags := fields[0], fields[1], fields[2], fields[3]
+	if !isLowerHex(version, 2) || version == "ff" || (version == "00" && len(fields) != 4) {
+		return ""
+	}
+	if !isLowerHex(traceID, 32) || !isLowerHex(parentID, 16) || !isLowerHex(flags, 2) {
+		return ""
+	}
+	if strings.Trim(traceID, "0") == "" || strings.Trim(parentID, "0") == "" {
+		return ""
+	}
+	return traceID
+}
+
+// isLowerHex reports whether s has n lowercase hex digits
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !('0' <= s[i] && s[i] <= '9' || 'a' <= s[i] && s[i] <= 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceParent string
+		expected    string
+	}{
+		{"valid", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"future version with extra fields", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"empty", "", ""},
+		{"version ff", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ""},
+		{"version 00 with extra fields", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", ""},
+		{"zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", ""},
+		{"zero parent ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", ""},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", ""},
+		{"short trace ID", "00-4bf92f3577b34da6-00f067aa0ba902b7-01", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTraceParent(tt.traceParent); got != tt.expected {
+				t.Errorf("Expected trace ID %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMetricsInterceptor_Exemplar(t *testing.T) {
+	const method = "/user.UserService/TraceExemplar"
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(TraceParentHeader, "00-"+traceID+"-00f067aa0ba902b7-01"))
+
+	interceptor := MetricsInterceptor(nil)
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, okHandler); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "user_svc_grpc_request_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metric.GetLabel()[0].GetValue() != method {
+				continue
+			}
+			for _, bucket := range metric.GetHistogram().GetBucket() {
+				exemplar := bucket.GetExemplar()
+				if exemplar == nil {
+					continue
+				}
+				if got := exemplar.GetLabel()[0].GetValue(); got != traceID {
+					t.Errorf("Expected exemplar trace ID %s, got %s", traceID, got)
+				}
+				return
+			}
+		}
+	}
+	t.Error("Expected the request duration to carry an exemplar")
+}
@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"regexp"
+
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DeprecationHeader marks the responses of methods deprecated in the proto, so clients notice
+// before the methods are removed
+const DeprecationHeader = "deprecation"
+
+// DeprecationHeaders are the API versioning response headers, e.g. to expose to browsers
+var DeprecationHeaders = []string{DeprecationHeader}
+
+// defaultAPIVersion is the version of services whose proto package has no version suffix
+const defaultAPIVersion = "v1"
+
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// apiMethod is the version, deprecation and idempotency of a method, as declared in its proto
+type apiMethod struct {
+	version    string
+	deprecated bool
+	readOnly   bool
+}
+
+// APIMethods maps the full gRPC method names of the served services to their API version,
+// taken from the last segment of the proto package (user.v2 is v2, user is v1), to whether
+// the proto marks them deprecated and to whether they are NO_SIDE_EFFECTS
+type APIMethods map[string]apiMethod
+
+// NewAPIMethods collects the methods of the services
+func NewAPIMethods(services ...protoreflect.ServiceDescriptor) APIMethods {
+	methods := make(APIMethods)
+	for _, service := range services {
+		version := defaultAPIVersion
+		if segment := service.ParentFile().Package().Name(); apiVersionPattern.MatchString(string(segment)) {
+			version = string(segment)
+		}
+
+		for i := 0; i < service.Methods().Len(); i++ {
+			method := service.Methods().Get(i)
+			options, _ := method.Options().(*descriptorpb.MethodOptions)
+			methods["/"+string(service.FullName())+"/"+string(method.Name())] = apiMethod{
+				version:    version,
+				deprecated: options.GetDeprecated(),
+				readOnly:   options.GetIdempotencyLevel() == descriptorpb.MethodOptions_NO_SIDE_EFFECTS,
+			}
+		}
+	}
+	return methods
+}
+
+// ReadOnly reports whether the proto marks a method NO_SIDE_EFFECTS
+func (m APIMethods) ReadOnly(fullMethod string) bool {
+	return m[fullMethod].readOnly
+}
+
+// observe counts a request by API version and reports whether its method is deprecated.
+// Methods of other services, e.g. health checks, are not counted.
+func (m APIMethods) observe(fullMethod string) bool {
+	method, ok := m[fullMethod]
+	if !ok {
+		return false
+	}
+
+	metrics.APIVersionRequests.WithLabelValues(method.version).Inc()
+	if method.deprecated {
+		metrics.DeprecatedMethodRequests.WithLabelValues(fullMethod).Inc()
+	}
+	return method.deprecated
+}
+
+// APIVersionInterceptor counts requests by API version, and the requests of deprecated
+// methods by method, so old versions can be removed once nobody calls them anymore. The
+// responses of deprecated methods carry the deprecation header.
+func APIVersionInterceptor(logger *logutils.Logger, methods APIMethods) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if methods.observe(info.FullMethod) {
+			if err := grpc.SetHeader(ctx, metadata.Pairs(DeprecationHeader, "true")); err != nil {
+				logger.WithError(err).WithField("method", info.FullMethod).Debug("Failed to set deprecation header")
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// APIVersionStreamInterceptor is the stream counterpart of APIVersionInterceptor
+func APIVersionStreamInterceptor(logger *logutils.Logger, methods APIMethods) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if methods.observe(info.FullMethod) {
+			if err := stream.SetHeader(metadata.Pairs(DeprecationHeader, "true")); err != nil {
+				logger.WithError(err).WithField("method", info.FullMethod).Debug("Failed to set deprecation header")
+			}
+		}
+
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	pb "user-svc/api/proto/v1"
+	pbv2 "user-svc/api/proto/v2"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// headerStream records the headers set by interceptors
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return "" }
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+func (s *headerStream) SetTrailer(metadata.MD) error    { return nil }
+
+func TestNewAPIMethods(t *testing.T) {
+	methods := NewAPIMethods(
+		pb.File_v1_user_svc_proto.Services().ByName("UserService"),
+		pbv2.File_v2_user_svc_proto.Services().ByName("UserService"),
+	)
+
+	tests := []struct {
+		method     string
+		version    string
+		deprecated bool
+		readOnly   bool
+	}{
+		{method: pb.UserService_Login_FullMethodName, version: "v1", deprecated: true},
+		{method: pb.UserService_GetQuotaUsage_FullMethodName, version: "v1", readOnly: true},
+		{method: pbv2.UserService_Login_FullMethodName, version: "v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			method, ok := methods[tt.method]
+			if !ok {
+				t.Fatalf("Expected %s to be collected", tt.method)
+			}
+			if method.version != tt.version || method.deprecated != tt.deprecated {
+				t.Errorf("Expected version %s deprecated %v, got %s %v", tt.version, tt.deprecated, method.version, method.deprecated)
+			}
+			if methods.ReadOnly(tt.method) != tt.readOnly {
+				t.Errorf("Expected read-only %v, got %v", tt.readOnly, !tt.readOnly)
+			}
+		})
+	}
+}
+
+func TestAPIVersionInterceptor(t *testing.T) {
+	methods := NewAPIMethods(
+		pb.File_v1_user_svc_proto.Services().ByName("UserService"),
+		pbv2.File_v2_user_svc_proto.Services().ByName("UserService"),
+	)
+	interceptor := APIVersionInterceptor(testLogger(), methods)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	tests := []struct {
+		method     string
+		deprecated bool
+	}{
+		{method: pb.UserService_Login_FullMethodName, deprecated: true},
+		{method: pbv2.UserService_Login_FullMethodName},
+		{method: "/grpc.health.v1.Health/Check"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			stream := &headerStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+			if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := len(stream.header.Get(DeprecationHeader)) > 0; got != tt.deprecated {
+				t.Errorf("Expected deprecation header %v, got %v", tt.deprecated, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package grpc
+
+import (
+	"encoding/binary"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	grpcWebContentType = "application/grpc-web"
+	grpcContentType    = "application/grpc"
+	// grpcWebTrailerFlag marks the frame carrying the trailers at the end of a response body
+	grpcWebTrailerFlag = 0x80
+)
+
+// grpcWebMetadataKey is set on every call translated from gRPC-Web
+const grpcWebMetadataKey = "x-grpc-web"
+
+// grpcWebHeaders are the request headers gRPC-Web clients send
+var grpcWebHeaders = []string{"content-type", grpcWebMetadataKey, "x-user-agent", "grpc-timeout"}
+
+// grpcWebTrailers are the trailers of every gRPC response, also exposed to browsers as headers
+// of trailers-only responses
+var grpcWebTrailers = []string{"grpc-status", "grpc-message", "grpc-status-details-bin"}
+
+// grpcWebExposedHeaders are the response headers browsers may read
+var grpcWebExposedHeaders = slices.Concat(grpcWebTrailers, RateLimitHeaders, QueueHeaders, DeprecationHeaders, ConsistencyHeaders, []string{RequestIDHeader})
+
+// WebOptions configures the gRPC-Web handler
+type WebOptions struct {
+	// AllowedOrigins may call from a browser; "*" allows any origin
+	AllowedOrigins []string
+	// AllowedHeaders are request headers browsers may send in addition to the gRPC-Web ones,
+	// e.g. authorization
+	AllowedHeaders []string
+	// MaxAge is how long browsers cache a preflight response
+	MaxAge time.Duration
+	// Credentials lets browsers send and receive the session cookies of
+	// SessionCookieInterceptor, and the CSRF token header guarding them
+	Credentials bool
+}
+
+// WebHandler serves gRPC-Web requests from browsers with the gRPC server, so they pass the
+// same interceptors and reach the same services as native gRPC calls. Requests are
+// translated to gRPC over the HTTP/1.1 or HTTP/2 connection and the trailers are written
+// as the last frame of the response body. Only the binary encoding is supported, as
+// browsers cannot stream requests anyway; use the grpcweb mode of grpc-web or the gRPC-Web
+// transport of connect-web.
+type WebHandler struct {
+	server         http.Handler
+	allowedOrigins map[string]struct{}
+	anyOrigin      bool
+	allowedHeaders string
+	exposedHeaders string
+	maxAge         string
+	credentials    bool
+}
+
+// NewWebHandler wraps a gRPC server, which implements http.Handler
+func NewWebHandler(server http.Handler, opts WebOptions) *WebHandler {
+	origins := make(map[string]struct{}, len(opts.AllowedOrigins))
+	for _, origin := range opts.AllowedOrigins {
+		origins[strings.TrimRight(origin, "/")] = struct{}{}
+	}
+	_, anyOrigin := origins["*"]
+
+	headers := append(slices.Clone(grpcWebHeaders), opts.AllowedHeaders...)
+	exposed := slices.Clone(grpcWebExposedHeaders)
+	if opts.Credentials {
+		headers = append(headers, CSRFTokenMetadataKey)
+		exposed = append(exposed, CSRFTokenMetadataKey)
+	}
+	for i, header := range headers {
+		headers[i] = strings.ToLower(header)
+	}
+
+	return &WebHandler{
+		server:         server,
+		allowedOrigins: origins,
+		anyOrigin:      anyOrigin,
+		allowedHeaders: strings.Join(slices.Compact(slices.Sorted(slices.Values(headers))), ", "),
+		exposedHeaders: strings.Join(exposed, ", "),
+		maxAge:         strconv.Itoa(int(opts.MaxAge.Seconds())),
+		credentials:    opts.Credentials,
+	}
+}
+
+func (h *WebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if !h.allowedOrigin(origin) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		if h.credentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+			w.Header().Set("Access-Control-Allow-Headers", h.allowedHeaders)
+			w.Header().Set("Access-Control-Max-Age", h.maxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Access-Control-Expose-Headers", h.exposedHeaders)
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if r.Method != http.MethodPost {
+		http.Error(w, "gRPC-Web requires POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if contentType != grpcWebContentType && contentType != grpcWebContentType+"+proto" {
+		http.Error(w, "unsupported content type, only application/grpc-web+proto is served", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor, req.Proto = 2, 0, "HTTP/2"
+	req.Header.Set("Content-Type", grpcContentType+strings.TrimPrefix(contentType, grpcWebContentType))
+	req.Header.Del("Content-Length")
+	// Marks the call as coming from a browser for SessionCookieInterceptor
+	req.Header.Set(grpcWebMetadataKey, "1")
+
+	ww := &webResponseWriter{w: w, header: make(http.Header), contentType: contentType}
+	h.server.ServeHTTP(ww, req)
+	ww.finish()
+}
+
+func (h *WebHandler) allowedOrigin(origin string) bool {
+	if h.anyOrigin {
+		return true
+	}
+	_, ok := h.allowedOrigins[origin]
+	return ok
+}
+
+// webResponseWriter turns a gRPC response into a gRPC-Web response
+type webResponseWriter struct {
+	w           http.ResponseWriter
+	header      http.Header
+	contentType string
+	wroteHeader bool
+}
+
+func (w *webResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *webResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	dst := w.w.Header()
+	for key, values := range w.header {
+		if key == "Trailer" || isTrailer(key) {
+			continue
+		}
+		dst[key] = values
+	}
+	dst.Set("Content-Type", w.contentType)
+	w.w.WriteHeader(code)
+}
+
+func (w *webResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.w.Write(b)
+}
+
+func (w *webResponseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+	if f, ok := w.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// finish writes the trailers the gRPC server set after the body as the trailer frame
+func (w *webResponseWriter) finish() {
+	w.WriteHeader(http.StatusOK)
+
+	var trailers strings.Builder
+	for key, values := range w.header {
+		if !isTrailer(key) {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(key, http.TrailerPrefix))
+		for _, value := range values {
+			trailers.WriteString(name + ": " + value + "\r\n")
+		}
+	}
+
+	frame := make([]byte, 5, 5+trailers.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(trailers.Len()))
+	_, _ = w.w.Write(append(frame, trailers.String()...))
+	w.Flush()
+}
+
+// isTrailer reports whether a header set by the gRPC server is one of its trailers
+func isTrailer(key string) bool {
+	return strings.HasPrefix(key, http.TrailerPrefix) || slices.Contains(grpcWebTrailers, strings.ToLower(key))
+}
@@ -0,0 +1,156 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func newWebTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	web := httptest.NewServer(NewWebHandler(server, WebOptions{
+		AllowedOrigins: []string{"https://checkout.tickets.example.com"},
+		AllowedHeaders: []string{"Authorization"},
+	}))
+	t.Cleanup(web.Close)
+	return web
+}
+
+func postGRPCWeb(t *testing.T, url string, msg proto.Message) *http.Response {
+	t.Helper()
+	return postGRPCWebWithHeader(t, url, msg, nil)
+}
+
+func postGRPCWebWithHeader(t *testing.T, url string, msg proto.Message, header http.Header) *http.Response {
+	t.Helper()
+
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	body := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(payload)))
+	body = append(body, payload...)
+
+	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("Origin", "https://checkout.tickets.example.com")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	return resp
+}
+
+// readGRPCWebFrames splits a gRPC-Web response body into its message frames and trailers
+func readGRPCWebFrames(t *testing.T, body io.Reader) ([][]byte, string) {
+	t.Helper()
+
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+
+	var messages [][]byte
+	for len(raw) >= 5 {
+		flag, length := raw[0], binary.BigEndian.Uint32(raw[1:5])
+		frame := raw[5 : 5+length]
+		raw = raw[5+length:]
+		if flag&grpcWebTrailerFlag != 0 {
+			return messages, string(frame)
+		}
+		messages = append(messages, frame)
+	}
+	t.Fatalf("Expected a trailer frame")
+	return nil, ""
+}
+
+func TestWebHandler_Unary(t *testing.T) {
+	web := newWebTestServer(t)
+
+	resp := postGRPCWeb(t, web.URL+"/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{})
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/grpc-web+proto" {
+		t.Errorf("Expected a gRPC-Web content type, got %s", ct)
+	}
+	if origin := resp.Header.Get("Access-Control-Allow-Origin"); origin != "https://checkout.tickets.example.com" {
+		t.Errorf("Expected the origin to be allowed, got %q", origin)
+	}
+
+	messages, trailers := readGRPCWebFrames(t, resp.Body)
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	var health healthpb.HealthCheckResponse
+	if err := proto.Unmarshal(messages[0], &health); err != nil || health.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected SERVING, got %v (%v)", health.Status, err)
+	}
+	if !strings.Contains(trailers, "grpc-status: 0\r\n") {
+		t.Errorf("Expected grpc-status 0 in trailers, got %q", trailers)
+	}
+}
+
+func TestWebHandler_Error(t *testing.T) {
+	web := newWebTestServer(t)
+
+	resp := postGRPCWeb(t, web.URL+"/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{Service: "unknown"})
+	defer resp.Body.Close()
+
+	messages, trailers := readGRPCWebFrames(t, resp.Body)
+	if len(messages) != 0 {
+		t.Errorf("Expected no message, got %d", len(messages))
+	}
+	if !strings.Contains(trailers, "grpc-status: 5\r\n") || !strings.Contains(trailers, "grpc-message: unknown service\r\n") {
+		t.Errorf("Expected NOT_FOUND in trailers, got %q", trailers)
+	}
+}
+
+func TestWebHandler_CORS(t *testing.T) {
+	web := newWebTestServer(t)
+
+	preflight, _ := http.NewRequest(http.MethodOptions, web.URL+"/grpc.health.v1.Health/Check", nil)
+	preflight.Header.Set("Origin", "https://checkout.tickets.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	resp, err := http.DefaultClient.Do(preflight)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", resp.StatusCode)
+	}
+	if headers := resp.Header.Get("Access-Control-Allow-Headers"); !strings.Contains(headers, "authorization") || !strings.Contains(headers, "x-grpc-web") {
+		t.Errorf("Expected the gRPC-Web and configured headers to be allowed, got %q", headers)
+	}
+
+	preflight.Header.Set("Origin", "https://evil.example.com")
+	resp, err = http.DefaultClient.Do(preflight)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403 for a foreign origin, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,102 @@
+// Package zstd registers a zstd compressor with gRPC. Importing it lets servers compress
+// responses with zstd and clients advertise that they accept it, like
+// google.golang.org/grpc/encoding/gzip does for gzip.
+package zstd
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the name the compressor is registered under, as sent in grpc-encoding
+const Name = "zstd"
+
+// MaxMessageSize is the largest message the decoders decompress, gRPC's default receive
+// limit. Servers pass it as grpc.MaxRecvMsgSize, so a frame declaring a larger window, which
+// the decoder would allocate before gRPC sees a byte of the message, is refused up front.
+const MaxMessageSize = 4 << 20
+
+func init() {
+	encoding.RegisterCompressor(&compressor{})
+}
+
+// compressor pools its encoders and decoders, which are expensive to create. Both run
+// without goroutines of their own, since a message is compressed at once anyway.
+type compressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func (c *compressor) Name() string {
+	return Name
+}
+
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	encoder, ok := c.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		encoder, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		encoder.Reset(w)
+	}
+
+	return &writer{Encoder: encoder, pool: &c.encoders}, nil
+}
+
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	decoder, ok := c.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		decoder, err = zstd.NewReader(r,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderLowmem(true),
+			zstd.WithDecoderMaxMemory(MaxMessageSize),
+			zstd.WithDecoderMaxWindow(MaxMessageSize),
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := decoder.Reset(r); err != nil {
+		c.decoders.Put(decoder)
+		return nil, err
+	}
+
+	return &reader{Decoder: decoder, pool: &c.decoders}, nil
+}
+
+// writer returns its encoder to the pool once the message is compressed
+type writer struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *writer) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+	return err
+}
+
+// reader returns its decoder to the pool once the message is read to the end
+type reader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
@@ -0,0 +1,65 @@
+package zstd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestCompressor_RoundTrip(t *testing.T) {
+	compressor := encoding.GetCompressor(Name)
+	if compressor == nil {
+		t.Fatal("Expected the zstd compressor to be registered")
+	}
+	message := []byte(strings.Repeat(`{"email":"fan@example.com","username":"fan"}`, 1000))
+
+	// The second round reuses the pooled encoder and decoder
+	for round := 0; round < 2; round++ {
+		var compressed bytes.Buffer
+		writer, err := compressor.Compress(&compressed)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := writer.Write(message); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if compressed.Len() >= len(message)/10 {
+			t.Errorf("Expected the message to be compressed, got %d of %d bytes", compressed.Len(), len(message))
+		}
+
+		reader, err := compressor.Decompress(&compressed)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		decompressed, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !bytes.Equal(decompressed, message) {
+			t.Errorf("Expected the original message in round %d, got %d bytes", round+1, len(decompressed))
+		}
+	}
+}
+
+func TestCompressor_OversizedWindow(t *testing.T) {
+	compressor := encoding.GetCompressor(Name)
+
+	// A frame declaring a 64 MiB window (log 26), under the decoder default of 512 MiB, followed by an empty last raw block
+	frame := []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00, (26 - 10) << 3, 0x01, 0x00, 0x00}
+
+	reader, err := compressor.Decompress(bytes.NewReader(frame))
+	if err == nil {
+		_, err = io.ReadAll(reader)
+	}
+	if !errors.Is(err, zstd.ErrWindowSizeExceeded) {
+		t.Errorf("Expected ErrWindowSizeExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package locks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNotAcquired is returned when the lock is held by another owner
+var ErrNotAcquired = errors.New("lock is held by another owner")
+
+// Locker acquires named locks held by a single owner across all replicas
+type Locker interface {
+	// TryAcquire takes the lock without waiting and returns ErrNotAcquired if it is held
+	TryAcquire(ctx context.Context, name string) (*Lock, error)
+}
+
+// Lock is a held lock. It is renewed in the background until it is released or lost.
+type Lock struct {
+	name  string
+	token int64
+
+	lost    chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+	release func(ctx context.Context) error
+}
+
+// newLock returns a held lock that calls renew every interval until it is released, and is
+// lost as soon as renew fails
+func newLock(
+	name string,
+	token int64,
+	interval time.Duration,
+	renew func(ctx context.Context) error,
+	release func(ctx context.Context) error,
+) *Lock {
+	l := &Lock{
+		name:    name,
+		token:   token,
+		lost:    make(chan struct{}),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		release: release,
+	}
+
+	go func() {
+		defer close(l.stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-l.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				err := renew(ctx)
+				cancel()
+				if err != nil {
+					close(l.lost)
+					return
+				}
+			}
+		}
+	}()
+
+	return l
+}
+
+// Name returns the name of the lock
+func (l *Lock) Name() string {
+	return l.name
+}
+
+// Token returns the fencing token of this acquisition. Tokens of a lock only increase, so a
+// resource that remembers the highest token it has seen can reject writes of a previous
+// owner that lost the lock without noticing.
+func (l *Lock) Token() int64 {
+	return l.token
+}
+
+// Lost is closed when the lock could not be renewed and may be held by another owner
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Context returns a context carrying the fencing token that is cancelled when the lock is
+// lost, so work done under the lock stops once it is no longer exclusive
+func (l *Lock) Context(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithValue(parent, fencingTokenKey{}, l.token))
+	go func() {
+		select {
+		case <-l.lost:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// Release stops renewing the lock and releases it. Errors releasing a lost lock are ignored.
+func (l *Lock) Release(ctx context.Context) error {
+	var err error
+	l.once.Do(func() {
+		close(l.stop)
+		<-l.stopped
+
+		err = l.release(ctx)
+		select {
+		case <-l.lost:
+			err = nil
+		default:
+		}
+	})
+	return err
+}
+
+type fencingTokenKey struct{}
+
+// FencingToken returns the fencing token of the lock the context was derived from
+func FencingToken(ctx context.Context) (int64, bool) {
+	token, ok := ctx.Value(fencingTokenKey{}).(int64)
+	return token, ok
+}
@@ -0,0 +1,71 @@
+package locks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLocalLocker(t *testing.T) {
+	locker := NewLocalLocker()
+	ctx := context.Background()
+
+	lock, err := locker.TryAcquire(ctx, "job")
+	if err != nil {
+		t.Fatalf("Expected the lock to be acquired, got %v", err)
+	}
+	if _, err := locker.TryAcquire(ctx, "job"); !errors.Is(err, ErrNotAcquired) {
+		t.Errorf("Expected ErrNotAcquired while the lock is held, got %v", err)
+	}
+	if _, err := locker.TryAcquire(ctx, "other"); err != nil {
+		t.Errorf("Expected other locks to be independent, got %v", err)
+	}
+
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	next, err := locker.TryAcquire(ctx, "job")
+	if err != nil {
+		t.Fatalf("Expected the released lock to be acquired again, got %v", err)
+	}
+	if next.Token() <= lock.Token() {
+		t.Errorf("Expected fencing token above %d, got %d", lock.Token(), next.Token())
+	}
+}
+
+func TestLockLostCancelsContext(t *testing.T) {
+	renewals := make(chan struct{}, 1)
+	released := false
+	lock := newLock("job", 7, time.Millisecond, func(context.Context) error {
+		select {
+		case renewals <- struct{}{}:
+			return nil
+		default:
+			return errors.New("expired")
+		}
+	}, func(context.Context) error {
+		released = true
+		return errors.New("not the owner")
+	})
+
+	ctx, cancel := lock.Context(context.Background())
+	defer cancel()
+
+	if token, ok := FencingToken(ctx); !ok || token != 7 {
+		t.Errorf("Expected fencing token 7, got %d", token)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected the context to be cancelled once the lock is lost")
+	}
+
+	if err := lock.Release(context.Background()); err != nil {
+		t.Errorf("Expected releasing a lost lock to succeed, got %v", err)
+	}
+	if !released {
+		t.Errorf("Expected the lost lock to be released")
+	}
+}
@@ -0,0 +1,77 @@
+package locks
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// PostgresLocker holds locks as session advisory locks on a dedicated connection. A lock is
+// held as long as its connection lives, so the lock of a crashed owner is freed when the
+// database notices the connection is gone. Fencing tokens are counted in the lock_fences table.
+type PostgresLocker struct {
+	db        *sql.DB
+	keepalive time.Duration
+}
+
+// NewPostgresLocker creates a locker checking every keepalive that held locks' connections are alive
+func NewPostgresLocker(db *sql.DB, keepalive time.Duration) *PostgresLocker {
+	return &PostgresLocker{
+		db:        db,
+		keepalive: keepalive,
+	}
+}
+
+// TryAcquire takes the advisory lock on a connection taken out of the pool until release
+func (l *PostgresLocker) TryAcquire(ctx context.Context, name string) (*Lock, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtextextended($1, 0))`, name).Scan(&acquired); err != nil {
+		discard(conn)
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return nil, ErrNotAcquired
+	}
+
+	var token int64
+	err = conn.QueryRowContext(ctx, `
+		INSERT INTO lock_fences (name, token) VALUES ($1, 1)
+		ON CONFLICT (name) DO UPDATE SET token = lock_fences.token + 1
+		RETURNING token
+	`, name).Scan(&token)
+	if err != nil {
+		discard(conn)
+		return nil, fmt.Errorf("failed to issue fencing token for lock %s: %w", name, err)
+	}
+
+	renew := func(ctx context.Context) error {
+		return conn.PingContext(ctx)
+	}
+	release := func(ctx context.Context) error {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock(hashtextextended($1, 0))`, name); err != nil {
+			// Closing the session releases the lock as well
+			discard(conn)
+			return fmt.Errorf("failed to release lock %s: %w", name, err)
+		}
+		return conn.Close()
+	}
+
+	return newLock(name, token, l.keepalive, renew, release), nil
+}
+
+// discard closes the connection instead of returning it to the pool, ending the session
+// together with any advisory lock it still holds
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(interface{}) error {
+		return driver.ErrBadConn
+	})
+	_ = conn.Close()
+}
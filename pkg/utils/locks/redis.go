@@ -0,0 +1,101 @@
+package locks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// renewScript extends the lock only if it is still held by the owner
+	renewScript = redis.NewScript(`
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+		end
+		return 0
+	`)
+	// releaseScript deletes the lock only if it is still held by the owner
+	releaseScript = redis.NewScript(`
+		if redis.call("GET", KEYS[1]) == ARGV[1] then
+			return redis.call("DEL", KEYS[1])
+		end
+		return 0
+	`)
+)
+
+// errLockExpired is returned by renewals of a lock that expired or was taken over
+var errLockExpired = errors.New("lock expired")
+
+// RedisLocker holds locks as Redis keys that expire after the TTL unless renewed, so a lock
+// of a crashed owner is freed within the TTL
+type RedisLocker struct {
+	client redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisLocker creates a locker storing locks under keys starting with prefix
+func NewRedisLocker(client redis.UniversalClient, prefix string, ttl time.Duration) *RedisLocker {
+	return &RedisLocker{
+		client: client,
+		prefix: prefix,
+		ttl:    ttl,
+	}
+}
+
+// TryAcquire takes the lock and renews it every third of the TTL
+func (l *RedisLocker) TryAcquire(ctx context.Context, name string) (*Lock, error) {
+	key := l.prefix + name
+	owner, err := newOwnerID()
+	if err != nil {
+		return nil, err
+	}
+
+	acquired, err := l.client.SetNX(ctx, key, owner, l.ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+	if !acquired {
+		return nil, ErrNotAcquired
+	}
+
+	// The fence counter never expires, so tokens keep increasing across owners
+	token, err := l.client.Incr(ctx, key+":fence").Result()
+	if err != nil {
+		_ = releaseScript.Run(ctx, l.client, []string{key}, owner).Err()
+		return nil, fmt.Errorf("failed to issue fencing token for lock %s: %w", name, err)
+	}
+
+	ttl := l.ttl.Milliseconds()
+	renew := func(ctx context.Context) error {
+		renewed, err := renewScript.Run(ctx, l.client, []string{key}, owner, ttl).Int()
+		if err != nil {
+			return err
+		}
+		if renewed == 0 {
+			return errLockExpired
+		}
+		return nil
+	}
+	release := func(ctx context.Context) error {
+		if err := releaseScript.Run(ctx, l.client, []string{key}, owner).Err(); err != nil {
+			return fmt.Errorf("failed to release lock %s: %w", name, err)
+		}
+		return nil
+	}
+
+	return newLock(name, token, l.ttl/3, renew, release), nil
+}
+
+func newOwnerID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate lock owner: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Fields of the request a context belongs to, added to every entry of FromContext
+const (
+	RequestIDField  = "request_id"
+	GRPCMethodField = "grpc_method"
+	CallerIDField   = "caller_user_id"
+	ClientIDField   = "client_id"
+	TraceIDField    = "trace_id"
+)
+
+type fieldsContextKey struct{}
+
+// NewContext returns a context whose entries of FromContext carry fields, in addition to the
+// fields of the parent context. Empty values are skipped.
+func NewContext(ctx context.Context, fields Fields) context.Context {
+	nonEmpty := make(Fields, len(fields))
+	for key, value := range fields {
+		if value != "" {
+			nonEmpty[key] = value
+		}
+	}
+	// The fields are kept as a logger without backend, so FromContext need not copy them
+	parent := &Logger{attrs: contextAttrs(ctx)}
+	return context.WithValue(ctx, fieldsContextKey{}, parent.WithFields(nonEmpty).attrs)
+}
+
+func contextAttrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(fieldsContextKey{}).([]slog.Attr)
+	return attrs
+}
+
+// ContextFields returns the fields a context carries, nil if none
+func ContextFields(ctx context.Context) Fields {
+	attrs := contextAttrs(ctx)
+	if len(attrs) == 0 {
+		return nil
+	}
+	fields := make(Fields, len(attrs))
+	for _, attr := range attrs {
+		fields[attr.Key] = attr.Value.Any()
+	}
+	return fields
+}
+
+// WithContext returns a logger adding the fields of the request ctx belongs to
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	attrs := contextAttrs(ctx)
+	if len(attrs) == 0 {
+		return l
+	}
+	if len(l.attrs) == 0 {
+		return &Logger{backend: l.backend, attrs: attrs}
+	}
+	return l.with(attrs...)
+}
+
+// FromContext returns a logger with the fields of the request ctx belongs to, e.g. its
+// request ID, so every line logged for a request is correlated
+func FromContext(ctx context.Context) *Logger {
+	return GetLogger().WithContext(ctx)
+}
@@ -0,0 +1,64 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileOptions configures writing the logs to a file rotated by size
+type FileOptions struct {
+	Path string
+	// MaxSize is the size in megabytes at which the file is rotated
+	MaxSize int
+	// MaxAge is the number of days rotated files are kept, 0 for no limit
+	MaxAge int
+	// MaxBackups is the number of rotated files kept, 0 for no limit
+	MaxBackups int
+	// Compress gzips rotated files
+	Compress bool
+}
+
+var (
+	fileMu sync.Mutex
+	file   *lumberjack.Logger
+)
+
+// UseFile writes the logs to a file instead of stdout. The file is rotated once it reaches
+// the maximum size, and rotated files past the maximum age or count are removed.
+func UseFile(opts FileOptions) error {
+	if opts.Path == "" {
+		return fmt.Errorf("log file path is required")
+	}
+
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	previous := file
+	file = &lumberjack.Logger{
+		Filename:   opts.Path,
+		MaxSize:    opts.MaxSize,
+		MaxAge:     opts.MaxAge,
+		MaxBackups: opts.MaxBackups,
+		Compress:   opts.Compress,
+	}
+	GetLogger().SetOutput(file)
+
+	if previous != nil {
+		return previous.Close()
+	}
+	return nil
+}
+
+// Reopen closes the log file, so the next entry opens it again at its path. Send SIGHUP after
+// an external tool like logrotate moved the file; without a log file it does nothing.
+func Reopen() error {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+
+	if file == nil {
+		return nil
+	}
+	return file.Close()
+}
@@ -0,0 +1,53 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUseFile_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "user-svc.log")
+	if err := UseFile(FileOptions{Path: path, MaxSize: 1}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer func() {
+		_ = Reopen()
+		GetLogger().SetOutput(os.Stdout)
+		file = nil
+	}()
+
+	Info("before rotation")
+
+	// logrotate moves the file aside, then sends SIGHUP
+	rotated := filepath.Join(dir, "user-svc.log.1")
+	if err := os.Rename(path, rotated); err != nil {
+		t.Fatalf("Expected the log file to exist, got %v", err)
+	}
+	if err := Reopen(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	Info("after rotation")
+
+	tests := map[string]string{
+		rotated: "before rotation",
+		path:    "after rotation",
+	}
+	for name, message := range tests {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Expected %s to exist, got %v", name, err)
+		}
+		if !strings.Contains(string(data), message) || strings.Count(string(data), "\n") != 1 {
+			t.Errorf("Expected %s to hold only %q, got %q", name, message, data)
+		}
+	}
+}
+
+func TestUseFile_RequiresPath(t *testing.T) {
+	if err := UseFile(FileOptions{}); err == nil {
+		t.Error("Expected an error without a path")
+	}
+}
@@ -1,27 +1,291 @@
+// Package log is the logging facade of the service. Entries are structured and written by a
+// slog.Handler, the backend: slog's JSON or text handler by default, or any other handler,
+// e.g. zap's through zapslog, with SetHandler.
 package log
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Fields are the key-value pairs logged with an entry
+type Fields map[string]interface{}
+
+// Level is the severity of an entry
+type Level = slog.Level
 
-	"github.com/sirupsen/logrus"
+// Levels of entries, named as in the logs
+const (
+	DebugLevel Level = slog.LevelDebug
+	InfoLevel  Level = slog.LevelInfo
+	WarnLevel  Level = slog.LevelWarn
+	ErrorLevel Level = slog.LevelError
+	FatalLevel Level = slog.LevelError + 4
+	PanicLevel Level = slog.LevelError + 8
 )
 
-var log *logrus.Logger
+// ParseLevel returns the level of a name of log.level, e.g. "info"
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	case "fatal":
+		return FatalLevel, nil
+	case "panic":
+		return PanicLevel, nil
+	}
+	return InfoLevel, fmt.Errorf("unknown log level %q", name)
+}
 
-// InitLogger initializes the logger with default configuration
-func InitLogger() error {
-	log = logrus.New()
+// levelName names a level in the logs; the names are the ones of the logs before slog
+func levelName(level Level) string {
+	switch level {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warning"
+	case ErrorLevel:
+		return "error"
+	case FatalLevel:
+		return "fatal"
+	case PanicLevel:
+		return "panic"
+	}
+	return strings.ToLower(level.String())
+}
+
+// Logger logs structured entries. Loggers derived with WithField and the like carry more
+// fields and share the backend, level and output of the logger they derive from.
+type Logger struct {
+	backend *backend
+	// attrs are never modified once set, derived loggers copy them
+	attrs []slog.Attr
+}
+
+type backend struct {
+	handler atomic.Pointer[slog.Handler]
+	level   *slog.LevelVar
+	out     *output
+}
+
+// output is a writer that can be swapped while the handler writes to it
+type output struct {
+	w atomic.Pointer[io.Writer]
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	return (*o.w.Load()).Write(p)
+}
+
+// New returns a logger writing JSON entries of the info level and above to out
+func New(out io.Writer) *Logger {
+	b := &backend{level: new(slog.LevelVar), out: &output{}}
+	b.out.w.Store(&out)
+	l := &Logger{backend: b}
+	_ = l.SetFormat("json")
+	return l
+}
+
+// SetFormat switches the default backend to json or text entries
+func (l *Logger) SetFormat(format string) error {
+	opts := &slog.HandlerOptions{Level: l.backend.level, ReplaceAttr: replaceAttr}
+	var handler slog.Handler
+	switch format {
+	case "json":
+		handler = slog.NewJSONHandler(l.backend.out, opts)
+	case "text":
+		handler = slog.NewTextHandler(l.backend.out, opts)
+	default:
+		return fmt.Errorf("unknown log format %q", format)
+	}
+	l.SetHandler(handler)
+	return nil
+}
 
-	// Set default log level
-	log.SetLevel(logrus.InfoLevel)
+// SetHandler replaces the backend. The level and output of the logger only apply to the
+// default backend, another handler decides on its own.
+func (l *Logger) SetHandler(handler slog.Handler) {
+	l.backend.handler.Store(&handler)
+}
 
-	// Set default JSON formatter
-	log.SetFormatter(&logrus.JSONFormatter{
-		TimestampFormat: "2006-01-02T15:04:05.000Z07:00",
+// SetOutput redirects the entries of the default backend to w
+func (l *Logger) SetOutput(w io.Writer) {
+	l.backend.out.w.Store(&w)
+}
+
+// SetLevel sets the minimum level logged by the default backend
+func (l *Logger) SetLevel(level Level) {
+	l.backend.level.Set(level)
+}
+
+// IsLevelEnabled reports whether entries of a level are logged, e.g. to skip building
+// expensive fields
+func (l *Logger) IsLevelEnabled(level Level) bool {
+	return (*l.backend.handler.Load()).Enabled(context.Background(), level)
+}
+
+// replaceAttr names levels as in the logs before slog, e.g. "warning"
+func replaceAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) == 0 && attr.Key == slog.LevelKey {
+		if level, ok := attr.Value.Any().(slog.Level); ok {
+			attr.Value = slog.StringValue(levelName(level))
+		}
+	}
+	return attr
+}
+
+// with returns a logger with more attributes; an attribute replaces the one of the same key
+func (l *Logger) with(attrs ...slog.Attr) *Logger {
+	merged := make([]slog.Attr, len(l.attrs), len(l.attrs)+len(attrs))
+	copy(merged, l.attrs)
+next:
+	for _, attr := range attrs {
+		for i := range merged {
+			if merged[i].Key == attr.Key {
+				merged[i] = attr
+				continue next
+			}
+		}
+		merged = append(merged, attr)
+	}
+	return &Logger{backend: l.backend, attrs: merged}
+}
+
+// WithField returns a logger adding a field to its entries
+func (l *Logger) WithField(key string, value interface{}) *Logger {
+	return l.with(slog.Any(key, value))
+}
+
+// WithFields returns a logger adding fields to its entries, in the order of their keys
+func (l *Logger) WithFields(fields Fields) *Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	attrs := make([]slog.Attr, 0, len(fields))
+	for key, value := range fields {
+		attrs = append(attrs, slog.Any(key, value))
+	}
+	slices.SortFunc(attrs, func(a, b slog.Attr) int {
+		return strings.Compare(a.Key, b.Key)
 	})
+	return l.with(attrs...)
+}
 
-	// Set output to stdout
-	log.SetOutput(os.Stdout)
+// WithError returns a logger adding an error field to its entries
+func (l *Logger) WithError(err error) *Logger {
+	return l.with(slog.Any("error", err))
+}
+
+// log writes an entry if its level is enabled; the message is only formatted then
+func (l *Logger) log(level Level, format string, args []interface{}) {
+	handler := *l.backend.handler.Load()
+	ctx := context.Background()
+	if !handler.Enabled(ctx, level) {
+		return
+	}
+
+	// Most messages are a single string, which needs no formatting
+	var msg string
+	ok := false
+	if format == "" && len(args) == 1 {
+		msg, ok = args[0].(string)
+	}
+	switch {
+	case ok:
+	case format == "":
+		msg = fmt.Sprint(args...)
+	default:
+		msg = fmt.Sprintf(format, args...)
+	}
+	record := slog.NewRecord(time.Now(), level, msg, 0)
+	record.AddAttrs(l.attrs...)
+	_ = handler.Handle(ctx, record)
+}
+
+// Debug logs a debug message
+func (l *Logger) Debug(args ...interface{}) {
+	l.log(DebugLevel, "", args)
+}
+
+// Debugf logs a formatted debug message
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.log(DebugLevel, format, args)
+}
+
+// Info logs an info message
+func (l *Logger) Info(args ...interface{}) {
+	l.log(InfoLevel, "", args)
+}
+
+// Infof logs a formatted info message
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.log(InfoLevel, format, args)
+}
+
+// Warn logs a warning message
+func (l *Logger) Warn(args ...interface{}) {
+	l.log(WarnLevel, "", args)
+}
+
+// Warnf logs a formatted warning message
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.log(WarnLevel, format, args)
+}
+
+// Error logs an error message
+func (l *Logger) Error(args ...interface{}) {
+	l.log(ErrorLevel, "", args)
+}
+
+// Errorf logs a formatted error message
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.log(ErrorLevel, format, args)
+}
+
+// Fatal logs a fatal message and exits
+func (l *Logger) Fatal(args ...interface{}) {
+	l.log(FatalLevel, "", args)
+	os.Exit(1)
+}
+
+// Fatalf logs a formatted fatal message and exits
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.log(FatalLevel, format, args)
+	os.Exit(1)
+}
+
+// Panic logs a panic message and panics
+func (l *Logger) Panic(args ...interface{}) {
+	l.log(PanicLevel, "", args)
+	panic(fmt.Sprint(args...))
+}
+
+// Panicf logs a formatted panic message and panics
+func (l *Logger) Panicf(format string, args ...interface{}) {
+	l.log(PanicLevel, format, args)
+	panic(fmt.Sprintf(format, args...))
+}
+
+var log *Logger
+
+// InitLogger initializes the logger with default configuration
+func InitLogger() error {
+	log = New(os.Stdout)
 
 	// Log initialization
 	log.Info("Logger initialized")
@@ -29,30 +293,40 @@ func InitLogger() error {
 	return nil
 }
 
+// Configure applies log.level and log.format to the logger
+func Configure(level, format string) error {
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	if err := GetLogger().SetFormat(format); err != nil {
+		return err
+	}
+	GetLogger().SetLevel(parsed)
+	return nil
+}
+
 // GetLogger returns the configured logger instance
-func GetLogger() *logrus.Logger {
+func GetLogger() *Logger {
 	if log == nil {
 		// Fallback to default logger if not initialized
-		log = logrus.New()
-		log.SetFormatter(&logrus.JSONFormatter{})
-		log.SetOutput(os.Stdout)
-		log.SetLevel(logrus.InfoLevel)
+		log = New(os.Stdout)
 	}
 	return log
 }
 
 // WithField adds a field to the logger
-func WithField(key string, value interface{}) *logrus.Entry {
+func WithField(key string, value interface{}) *Logger {
 	return GetLogger().WithField(key, value)
 }
 
 // WithFields adds multiple fields to the logger
-func WithFields(fields logrus.Fields) *logrus.Entry {
+func WithFields(fields Fields) *Logger {
 	return GetLogger().WithFields(fields)
 }
 
 // WithError adds an error field to the logger
-func WithError(err error) *logrus.Entry {
+func WithError(err error) *Logger {
 	return GetLogger().WithError(err)
 }
 
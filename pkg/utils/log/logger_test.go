@@ -0,0 +1,117 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLogger_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+
+	logger.WithFields(Fields{"method": "Login", "email": "fan@example.com"}).
+		WithField("method", "Register").
+		WithError(errors.New("boom")).
+		Warn("Registration refused")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a JSON entry, got %q", buf.String())
+	}
+	tests := map[string]interface{}{
+		"level":  "warning",
+		"msg":    "Registration refused",
+		"method": "Register",
+		"email":  "fan@example.com",
+		"error":  "boom",
+	}
+	for key, expected := range tests {
+		if entry[key] != expected {
+			t.Errorf("Expected %s to be %v, got %v", key, expected, entry[key])
+		}
+	}
+	// A replaced field is logged once
+	if n := strings.Count(buf.String(), `"method"`); n != 1 {
+		t.Errorf("Expected one method field, got %d in %q", n, buf.String())
+	}
+}
+
+func TestLogger_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+
+	logger.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug entries to be skipped at info level, got %q", buf.String())
+	}
+
+	logger.SetLevel(DebugLevel)
+	if !logger.IsLevelEnabled(DebugLevel) {
+		t.Error("Expected debug level to be enabled")
+	}
+	logger.WithField("a", 1).Debugf("shown %d", 2)
+	if !strings.Contains(buf.String(), `"msg":"shown 2"`) {
+		t.Errorf("Expected the debug entry, got %q", buf.String())
+	}
+}
+
+func TestLogger_TextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+	if err := logger.SetFormat("text"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	logger.WithField("user_id", "u-1").Info("Login succeeded")
+
+	if line := buf.String(); !strings.Contains(line, `level=info msg="Login succeeded" user_id=u-1`) {
+		t.Errorf("Expected a text entry, got %q", line)
+	}
+	if err := logger.SetFormat("xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+
+	ctx := NewContext(context.Background(), Fields{RequestIDField: "req-1", CallerIDField: ""})
+	ctx = NewContext(ctx, Fields{TraceIDField: "trace-1"})
+	logger.WithContext(ctx).WithField("method", "Login").Info("done")
+
+	for _, expected := range []string{`"request_id":"req-1"`, `"trace_id":"trace-1"`, `"method":"Login"`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in %q", expected, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), CallerIDField) {
+		t.Errorf("Expected empty fields to be skipped, got %q", buf.String())
+	}
+}
+
+// BenchmarkLogger logs what a Login request logs: a logger with the request fields, a
+// skipped debug entry and an info entry
+func BenchmarkLogger(b *testing.B) {
+	logger := New(io.Discard)
+	ctx := NewContext(context.Background(), Fields{
+		RequestIDField:  "5f0c6e1a-3b7d-4f39-9d0e-4b2f1a6c8e21",
+		GRPCMethodField: "/user.UserService/Login",
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		entry := logger.WithContext(ctx).WithFields(Fields{
+			"method":    "Login",
+			"email":     "fan@example.com",
+			"client_id": "web",
+		})
+		entry.Debug("Starting login")
+		entry.WithField("user_id", "6f1c3a52-5d0e-4a37-9a43-1d2f1e0c9b11").Info("User logged in successfully")
+	}
+}
@@ -0,0 +1,250 @@
+// Package metadata defines the gRPC headers the booking services pass to each other: the
+// request ID, client ID, device fingerprint, locale and forwarded IP of the end user's
+// request. Every service parses them with the same validation, so a value accepted at the
+// edge is accepted all the way down, and propagates them to the services it calls.
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// RequestIDKey carries the ID correlating the logs of a request across services
+	RequestIDKey = "x-request-id"
+	// ClientIDKey carries the ID of the client application, e.g. web or kiosk
+	ClientIDKey = "x-client-id"
+	// DeviceFingerprintKey carries the ID of the device of the end user
+	DeviceFingerprintKey = "x-device-id"
+	// LocaleKey carries the languages the end user accepts
+	LocaleKey = "accept-language"
+	// ForwardedForKey carries the IP address of the end user followed by the proxies the
+	// request went through. Only the edge proxy may set it, so it is trusted only behind one.
+	ForwardedForKey = "x-forwarded-for"
+)
+
+const (
+	// MaxRequestIDLength bounds request IDs so they cannot bloat every log line
+	MaxRequestIDLength = 128
+	// MaxClientIDLength bounds client IDs, as the client_id columns do
+	MaxClientIDLength = 64
+	// MaxDeviceFingerprintLength bounds device fingerprints
+	MaxDeviceFingerprintLength = 256
+	// maxLocaleLength bounds locale tags, the longest BCP 47 tags in use being far shorter
+	maxLocaleLength = 35
+)
+
+var (
+	ErrInvalidRequestID         = errors.New("invalid request ID")
+	ErrInvalidClientID          = errors.New("invalid client ID")
+	ErrInvalidDeviceFingerprint = errors.New("invalid device fingerprint")
+	ErrInvalidLocale            = errors.New("invalid locale")
+	ErrInvalidForwardedIP       = errors.New("invalid forwarded IP")
+)
+
+// Headers are the cross-service headers of a call. Headers that were not sent, or were
+// invalid, are left zero.
+type Headers struct {
+	RequestID         string
+	ClientID          string
+	DeviceFingerprint string
+	// Locale is the preferred language of the end user as a lower case tag, e.g. pt-br
+	Locale string
+	// ForwardedIP is the address of the end user the edge proxy forwarded the request for
+	ForwardedIP netip.Addr
+}
+
+// Parse reads the headers from md. Invalid headers are left zero and reported together in
+// the error, so callers may still use the valid ones.
+func Parse(md metadata.MD) (Headers, error) {
+	var h Headers
+	var errs []error
+
+	if value := First(md, RequestIDKey); value != "" {
+		id, err := ParseRequestID(value)
+		h.RequestID, errs = id, append(errs, err)
+	}
+	if value := First(md, ClientIDKey); value != "" {
+		id, err := ParseClientID(value)
+		h.ClientID, errs = id, append(errs, err)
+	}
+	if value := First(md, DeviceFingerprintKey); value != "" {
+		fingerprint, err := ParseDeviceFingerprint(value)
+		h.DeviceFingerprint, errs = fingerprint, append(errs, err)
+	}
+	if value := First(md, LocaleKey); value != "" {
+		locale, err := ParseLocale(value)
+		h.Locale, errs = locale, append(errs, err)
+	}
+	if values := md.Get(ForwardedForKey); len(values) > 0 {
+		ip, err := ParseForwardedIP(strings.Join(values, ","))
+		h.ForwardedIP, errs = ip, append(errs, err)
+	}
+
+	return h, errors.Join(errs...)
+}
+
+// FromIncomingContext parses the headers of the incoming call of ctx, like Parse
+func FromIncomingContext(ctx context.Context) (Headers, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return Parse(md)
+}
+
+// Pairs returns the headers that are set as metadata
+func (h Headers) Pairs() metadata.MD {
+	md := metadata.MD{}
+	if h.RequestID != "" {
+		md.Set(RequestIDKey, h.RequestID)
+	}
+	if h.ClientID != "" {
+		md.Set(ClientIDKey, h.ClientID)
+	}
+	if h.DeviceFingerprint != "" {
+		md.Set(DeviceFingerprintKey, h.DeviceFingerprint)
+	}
+	if h.Locale != "" {
+		md.Set(LocaleKey, h.Locale)
+	}
+	if h.ForwardedIP.IsValid() {
+		md.Set(ForwardedForKey, h.ForwardedIP.String())
+	}
+	return md
+}
+
+// AppendToOutgoingContext returns ctx with the headers added to the metadata of outgoing
+// calls, to propagate them to the services called while handling a request
+func (h Headers) AppendToOutgoingContext(ctx context.Context) context.Context {
+	var kv []string
+	for key, values := range h.Pairs() {
+		kv = append(kv, key, values[0])
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// First returns the first value of key in md, or "" if there is none
+func First(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ParseRequestID validates a request ID: printable ASCII without spaces, at most
+// MaxRequestIDLength long
+func ParseRequestID(value string) (string, error) {
+	if !isToken(value, MaxRequestIDLength) {
+		return "", ErrInvalidRequestID
+	}
+	return value, nil
+}
+
+// ParseClientID validates a client ID: printable ASCII without spaces, at most
+// MaxClientIDLength long
+func ParseClientID(value string) (string, error) {
+	if !isToken(value, MaxClientIDLength) {
+		return "", ErrInvalidClientID
+	}
+	return value, nil
+}
+
+// ParseDeviceFingerprint validates a device fingerprint: printable ASCII without spaces, at
+// most MaxDeviceFingerprintLength long
+func ParseDeviceFingerprint(value string) (string, error) {
+	if !isToken(value, MaxDeviceFingerprintLength) {
+		return "", ErrInvalidDeviceFingerprint
+	}
+	return value, nil
+}
+
+// ParseLocale returns the preferred language of an Accept-Language header, which must be a
+// language tag such as fr or pt-BR, or "" for a header accepting any language
+func ParseLocale(acceptLanguage string) (string, error) {
+	locale := PreferredLocale(acceptLanguage)
+	if locale != "" && !isLocale(locale) {
+		return "", ErrInvalidLocale
+	}
+	return locale, nil
+}
+
+// PreferredLocale returns the highest weighted language of an Accept-Language header as a
+// lower case tag, or "" if it has none. The tag is not validated, see ParseLocale.
+func PreferredLocale(acceptLanguage string) string {
+	best, bestQ := "", -1.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if _, err := fmt.Sscanf(value, "%g", &q); err != nil {
+				continue
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = tag, q
+		}
+	}
+
+	return strings.ToLower(strings.ReplaceAll(best, "_", "-"))
+}
+
+// ParseForwardedIP returns the address of the end user from an X-Forwarded-For header, its
+// first entry
+func ParseForwardedIP(forwardedFor string) (netip.Addr, error) {
+	first, _, _ := strings.Cut(forwardedFor, ",")
+	ip, err := netip.ParseAddr(strings.TrimSpace(first))
+	if err != nil {
+		return netip.Addr{}, ErrInvalidForwardedIP
+	}
+	return ip.Unmap(), nil
+}
+
+// isToken reports whether value is non-empty printable ASCII without spaces, at most max long
+func isToken(value string, max int) bool {
+	if value == "" || len(value) > max {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] <= ' ' || value[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// isLocale reports whether locale looks like a lower case language tag: a language of two
+// or three letters followed by subtags of up to eight letters or digits
+func isLocale(locale string) bool {
+	if len(locale) > maxLocaleLength {
+		return false
+	}
+	language, subtags, _ := strings.Cut(locale, "-")
+	if len(language) < 2 || len(language) > 3 || strings.IndexFunc(language, func(c rune) bool { return c < 'a' || c > 'z' }) >= 0 {
+		return false
+	}
+	if subtags == "" {
+		return true
+	}
+	for _, subtag := range strings.Split(subtags, "-") {
+		if subtag == "" || len(subtag) > 8 || strings.IndexFunc(subtag, func(c rune) bool { return !isAlphanumeric(c) }) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,118 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParse(t *testing.T) {
+	md := metadata.Pairs(
+		RequestIDKey, "req-1",
+		ClientIDKey, "web",
+		DeviceFingerprintKey, "device-1",
+		LocaleKey, "en;q=0.8, pt_BR",
+		ForwardedForKey, "203.0.113.7, 10.0.0.1",
+	)
+
+	headers, err := Parse(md)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := Headers{
+		RequestID:         "req-1",
+		ClientID:          "web",
+		DeviceFingerprint: "device-1",
+		Locale:            "pt-br",
+		ForwardedIP:       netip.MustParseAddr("203.0.113.7"),
+	}
+	if headers != expected {
+		t.Errorf("Expected %+v, got %+v", expected, headers)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	md := metadata.Pairs(
+		RequestIDKey, "req 1",
+		ClientIDKey, strings.Repeat("c", MaxClientIDLength+1),
+		DeviceFingerprintKey, "device-1",
+		LocaleKey, "<script>",
+		ForwardedForKey, "unknown",
+	)
+
+	headers, err := Parse(md)
+	for _, want := range []error{ErrInvalidRequestID, ErrInvalidClientID, ErrInvalidLocale, ErrInvalidForwardedIP} {
+		if !errors.Is(err, want) {
+			t.Errorf("Expected error %v, got %v", want, err)
+		}
+	}
+	if errors.Is(err, ErrInvalidDeviceFingerprint) {
+		t.Errorf("Expected the device fingerprint to be valid, got %v", err)
+	}
+
+	expected := Headers{DeviceFingerprint: "device-1"}
+	if headers != expected {
+		t.Errorf("Expected only the valid headers %+v, got %+v", expected, headers)
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+		err      error
+	}{
+		{header: "fr", expected: "fr"},
+		{header: "de-CH, en;q=0.5", expected: "de-ch"},
+		{header: "*", expected: ""},
+		{header: "english", err: ErrInvalidLocale},
+		{header: "en-" + strings.Repeat("x", 9), err: ErrInvalidLocale},
+	}
+
+	for _, tt := range tests {
+		locale, err := ParseLocale(tt.header)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("Expected error %v for %q, got %v", tt.err, tt.header, err)
+		}
+		if locale != tt.expected {
+			t.Errorf("Expected locale %q for %q, got %q", tt.expected, tt.header, locale)
+		}
+	}
+}
+
+func TestParseForwardedIP(t *testing.T) {
+	ip, err := ParseForwardedIP("::ffff:198.51.100.2, 10.0.0.1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ip != netip.MustParseAddr("198.51.100.2") {
+		t.Errorf("Expected the first address unmapped, got %v", ip)
+	}
+
+	if _, err := ParseForwardedIP("203.0.113.7:443"); !errors.Is(err, ErrInvalidForwardedIP) {
+		t.Errorf("Expected ErrInvalidForwardedIP for an address with a port, got %v", err)
+	}
+}
+
+func TestHeaders_AppendToOutgoingContext(t *testing.T) {
+	headers := Headers{RequestID: "req-1", Locale: "fr", ForwardedIP: netip.MustParseAddr("203.0.113.7")}
+
+	ctx := headers.AppendToOutgoingContext(context.Background())
+	md, _ := metadata.FromOutgoingContext(ctx)
+
+	propagated, err := Parse(md)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if propagated != headers {
+		t.Errorf("Expected %+v to be propagated, got %+v", headers, propagated)
+	}
+	if len(md.Get(ClientIDKey)) != 0 {
+		t.Errorf("Expected unset headers not to be sent, got %v", md)
+	}
+}
@@ -0,0 +1,444 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "user_svc"
+
+// Pipeline metrics
+var (
+	PipelineQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "queue_depth",
+		Help:      "Number of items waiting in an async pipeline buffer.",
+	}, []string{"stream"})
+
+	PipelineDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "dropped_total",
+		Help:      "Number of items dropped by an async pipeline because its buffer was full.",
+	}, []string{"stream"})
+
+	PipelineFlushed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "flushed_total",
+		Help:      "Number of items successfully flushed to a pipeline sink.",
+	}, []string{"stream"})
+
+	PipelineFlushErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pipeline",
+		Name:      "flush_errors_total",
+		Help:      "Number of failed batch flushes to a pipeline sink.",
+	}, []string{"stream"})
+)
+
+// Quota metrics
+var (
+	QuotaRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "quota",
+		Name:      "rejected_total",
+		Help:      "Number of calls rejected because a quota was exceeded.",
+	}, []string{"method"})
+
+	QuotaThresholdsReached = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "quota",
+		Name:      "thresholds_reached_total",
+		Help:      "Number of quota usage thresholds crossed.",
+	}, []string{"threshold"})
+
+	QuotaErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "quota",
+		Name:      "errors_total",
+		Help:      "Number of calls whose quota usage could not be recorded.",
+	})
+)
+
+// Request and SLO metrics
+var (
+	GRPCRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of handled gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	APIVersionRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "api_version_requests_total",
+		Help:      "Number of gRPC requests by API version of the called service (v1, v2).",
+	}, []string{"version"})
+
+	DeprecatedMethodRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "deprecated_method_requests_total",
+		Help:      "Number of gRPC requests of methods deprecated in the proto by method.",
+	}, []string{"method"})
+
+	GRPCRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of handled gRPC requests.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .2, .3, .5, 1, 2.5, 5},
+	}, []string{"method"})
+
+	GRPCDeadlineExceeded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "deadline_exceeded_total",
+		Help:      "Number of unary requests whose server or client deadline expired, by method.",
+	}, []string{"method"})
+
+	SLOAvailability = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "slo",
+		Name:      "availability_ratio",
+		Help:      "Rolling fraction of requests without server-side errors.",
+	}, []string{"method"})
+
+	SLOLatencyCompliance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "slo",
+		Name:      "latency_compliance_ratio",
+		Help:      "Rolling fraction of requests faster than the method's latency threshold.",
+	}, []string{"method"})
+
+	SLOErrorBudgetRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "slo",
+		Name:      "error_budget_remaining_ratio",
+		Help:      "Rolling fraction of the error budget left, negative once overspent.",
+	}, []string{"method", "sli"})
+)
+
+// Fault injection metrics
+var FaultsInjected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "fault",
+	Name:      "injected_total",
+	Help:      "Number of faults injected by the fault injection interceptor.",
+}, []string{"method", "fault"})
+
+// Circuit breaker metrics
+var (
+	BreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "breaker",
+		Name:      "state",
+		Help:      "Circuit breaker state per dependency (0 closed, 1 half-open, 2 open).",
+	}, []string{"dependency"})
+
+	BreakerCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "breaker",
+		Name:      "calls_total",
+		Help:      "Number of calls through a circuit breaker by result (success, failure, rejected).",
+	}, []string{"dependency", "result"})
+
+	BreakerTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "breaker",
+		Name:      "transitions_total",
+		Help:      "Number of circuit breaker state transitions by target state.",
+	}, []string{"dependency", "state"})
+)
+
+// Token revocation metrics
+var (
+	RevocationsApplied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "revocation",
+		Name:      "applied_total",
+		Help:      "Number of token revocations applied to the local cache by source (local, pubsub, resync).",
+	}, []string{"source"})
+
+	RevocationPublishErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "revocation",
+		Name:      "publish_errors_total",
+		Help:      "Number of token revocations that could not be broadcast to other replicas.",
+	})
+
+	RevocationCacheSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "revocation",
+		Name:      "cache_entries",
+		Help:      "Number of active token revocations held in memory.",
+	})
+
+	NotificationDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notifier",
+		Name:      "deliveries_total",
+		Help:      "Number of notification delivery attempts by channel and resulting status (sent, failed, dead_letter, unreachable).",
+	}, []string{"channel", "status"})
+)
+
+// Export metrics
+var ExportedUsers = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "export",
+	Name:      "users_total",
+	Help:      "Number of users streamed by ExportUsers by format.",
+}, []string{"format"})
+
+// Import metrics
+var ImportedUsers = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "import",
+	Name:      "users_total",
+	Help:      "Number of records processed by ImportUsers by outcome.",
+}, []string{"status"})
+
+// PasswordVerifications counts the password verifications of logins, telling apart the ones
+// sharing the result of an identical concurrent login
+var PasswordVerifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "auth",
+	Name:      "password_verifications_total",
+	Help:      "Number of login password verifications by result (verified, shared).",
+}, []string{"result"})
+
+// LegacyPasswordUpgrades tracks the rehashing of outdated password hashes on login: imported
+// legacy hashes and hashes of a lower bcrypt cost or without the current pepper
+var LegacyPasswordUpgrades = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "import",
+	Name:      "legacy_password_upgrades_total",
+	Help:      "Number of outdated password hashes rehashed on login by result (upgraded, superseded, failed).",
+}, []string{"result"})
+
+// UserWatchStreams tracks the WatchUser streams served by this replica
+var UserWatchStreams = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "watch",
+	Name:      "streams",
+	Help:      "Number of open WatchUser streams.",
+})
+
+// UserWatchUpdates counts the user states sent to WatchUser streams
+var UserWatchUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "watch",
+	Name:      "updates_total",
+	Help:      "Number of user states sent to WatchUser streams by kind (initial, update).",
+}, []string{"kind"})
+
+// Admission queue metrics
+var (
+	AdmissionQueued = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "admission",
+		Name:      "queued",
+		Help:      "Number of calls waiting in the admission queue.",
+	})
+
+	AdmissionWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "admission",
+		Name:      "wait_seconds",
+		Help:      "Time admitted calls waited in the admission queue, by method.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"method"})
+
+	AdmissionRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "admission",
+		Name:      "rejected_total",
+		Help:      "Number of calls rejected by the admission queue by method and reason (full, timeout).",
+	}, []string{"method", "reason"})
+)
+
+// Nonce metrics
+var (
+	NonceRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "nonce",
+		Name:      "rejected_total",
+		Help:      "Number of nonces rejected by scope and reason (unknown, replayed).",
+	}, []string{"scope", "reason"})
+)
+
+// Organization audit metrics
+var (
+	OrgAuditWebhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "org_audit",
+		Name:      "webhook_deliveries_total",
+		Help:      "Number of webhook requests to organizations by result (delivered, failed).",
+	}, []string{"result"})
+
+	OrgAuditWebhookEvents = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "org_audit",
+		Name:      "webhook_events_total",
+		Help:      "Number of security events delivered to the webhooks of organizations.",
+	})
+)
+
+// Abuse engine metrics
+var (
+	VelocityRuleTriggered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "abuse",
+		Name:      "velocity_rule_triggered_total",
+		Help:      "Number of attempts over the threshold of a velocity rule by rule and response (alert, captcha, lock).",
+	}, []string{"rule", "response"})
+	VelocityCheckErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "abuse",
+		Name:      "velocity_check_errors_total",
+		Help:      "Number of attempts let through because their velocity counters or captcha could not be checked.",
+	})
+)
+
+// Session refresh metrics
+var (
+	SessionRefreshInterval = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "session",
+		Name:      "refresh_interval_seconds",
+		Help:      "Time between consecutive refreshes of a session.",
+		Buckets:   []float64{1, 10, 60, 300, 900, 1800, 3600, 4 * 3600, 24 * 3600, 7 * 24 * 3600},
+	})
+
+	SessionAnomalies = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "session",
+		Name:      "anomalies_total",
+		Help:      "Number of session refresh anomalies by kind (country_change, refresh_rate).",
+	}, []string{"kind"})
+
+	SessionIdleTimeouts = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "session",
+		Name:      "idle_timeouts_total",
+		Help:      "Number of sessions ended on refresh after going unused for longer than their idle timeout.",
+	})
+)
+
+// Canary account metrics
+var (
+	CanaryAccountAccesses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "canary",
+		Name:      "account_accesses_total",
+		Help:      "Number of accesses to canary accounts by kind (login, refresh_token, access_token).",
+	}, []string{"kind"})
+)
+
+// Read replica metrics
+var (
+	ReplicaReads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "database",
+		Name:      "replica_reads_total",
+		Help:      "Number of reads of read-only methods by target (replica, primary_lag, primary_error).",
+	}, []string{"target"})
+)
+
+// Token metrics
+var (
+	TokensIssued = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "token",
+		Name:      "issued_total",
+		Help:      "Number of tokens issued by type (access, refresh) and client.",
+	}, []string{"type", "client"})
+
+	TokenVerifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "token",
+		Name:      "verifications_total",
+		Help:      "Number of token verifications by format (jwt, paseto_v4, unknown) and result (valid, expired, invalid).",
+	}, []string{"format", "result"})
+
+	TokenClaimViolations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "token",
+		Name:      "claim_violations_total",
+		Help:      "Number of claim policy violations of verified tokens by violation and claims mode (report, enforce).",
+	}, []string{"violation", "mode"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		PipelineQueueDepth,
+		PipelineDropped,
+		PipelineFlushed,
+		PipelineFlushErrors,
+		QuotaRejected,
+		QuotaThresholdsReached,
+		QuotaErrors,
+		GRPCRequests,
+		APIVersionRequests,
+		DeprecatedMethodRequests,
+		GRPCRequestDuration,
+		GRPCDeadlineExceeded,
+		SLOAvailability,
+		SLOLatencyCompliance,
+		SLOErrorBudgetRemaining,
+		FaultsInjected,
+		BreakerState,
+		BreakerCalls,
+		BreakerTransitions,
+		RevocationsApplied,
+		RevocationPublishErrors,
+		RevocationCacheSize,
+		NotificationDeliveries,
+		ExportedUsers,
+		ImportedUsers,
+		LegacyPasswordUpgrades,
+		UserWatchStreams,
+		UserWatchUpdates,
+		AdmissionQueued,
+		AdmissionWait,
+		AdmissionRejected,
+		NonceRejected,
+		OrgAuditWebhookDeliveries,
+		OrgAuditWebhookEvents,
+		VelocityRuleTriggered,
+		VelocityCheckErrors,
+		SessionRefreshInterval,
+		SessionAnomalies,
+		SessionIdleTimeouts,
+		CanaryAccountAccesses,
+		ReplicaReads,
+		PasswordVerifications,
+		TokensIssued,
+		TokenVerifications,
+		TokenClaimViolations,
+	)
+}
+
+// Handler returns the HTTP handler exposing all registered metrics. Scrapers negotiating
+// OpenMetrics also get the exemplars of histograms.
+func Handler() http.Handler {
+	return promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+	}))
+}
+
+// ObserveWithTraceID records a value with the trace ID as exemplar, so a latency outlier on a
+// dashboard links to the trace of a request that caused it. Values without a trace ID are
+// recorded plainly.
+func ObserveWithTraceID(observer prometheus.Observer, value float64, traceID string) {
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && traceID != "" {
+		exemplarObserver.ObserveWithExemplar(value, prometheus.Labels{"trace_id": traceID})
+		return
+	}
+	observer.Observe(value)
+}
@@ -0,0 +1,75 @@
+package nonces
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxLocalNonces bounds the in-memory store so a flood of nonces cannot exhaust memory
+const maxLocalNonces = 100000
+
+type localNonce struct {
+	data      string
+	expiresAt time.Time
+}
+
+// LocalStore records nonces in memory. Other replicas do not see them, so it is only for
+// single replica deployments and tests.
+type LocalStore struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	nonces map[string]localNonce
+}
+
+// NewLocalStore creates an in-process store
+func NewLocalStore() *LocalStore {
+	return &LocalStore{
+		now:    time.Now,
+		nonces: make(map[string]localNonce),
+	}
+}
+
+// Put records the nonce unless a live entry of it exists
+func (s *LocalStore) Put(_ context.Context, scope, nonce, data string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	key := scope + ":" + nonce
+	if entry, ok := s.nonces[key]; ok && entry.expiresAt.After(now) {
+		return ErrReplayed
+	}
+
+	if len(s.nonces) >= maxLocalNonces {
+		for key, entry := range s.nonces {
+			if !entry.expiresAt.After(now) {
+				delete(s.nonces, key)
+			}
+		}
+		// Still full of live nonces: refuse rather than forget one that could be replayed
+		if len(s.nonces) >= maxLocalNonces {
+			return ErrReplayed
+		}
+	}
+
+	s.nonces[key] = localNonce{data: data, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// Consume removes the nonce if it is live
+func (s *LocalStore) Consume(_ context.Context, scope, nonce string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := scope + ":" + nonce
+	entry, ok := s.nonces[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	delete(s.nonces, key)
+	if !entry.expiresAt.After(s.now()) {
+		return "", ErrNotFound
+	}
+	return entry.data, nil
+}
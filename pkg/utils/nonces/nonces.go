@@ -0,0 +1,75 @@
+package nonces
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"user-svc/pkg/utils/metrics"
+)
+
+// nonceBytes is the entropy of issued nonces
+const nonceBytes = 32
+
+var (
+	// ErrNotFound is returned for nonces that were never issued, expired or were consumed already
+	ErrNotFound = errors.New("nonce not found")
+	// ErrReplayed is returned for nonces that are recorded already
+	ErrReplayed = errors.New("nonce has already been used")
+)
+
+// Store records one-time nonces shared by all replicas, so a callback or assertion accepted
+// by one replica is refused by every other. Nonces are grouped in scopes, e.g. "oauth_state"
+// or "saml_assertion", so the nonces of different flows never collide.
+type Store interface {
+	// Put records a nonce with its data until ttl has passed, and fails with ErrReplayed if
+	// the nonce is recorded and not expired
+	Put(ctx context.Context, scope, nonce, data string, ttl time.Duration) error
+	// Consume removes a nonce and returns its data, or ErrNotFound; of concurrent consumers of
+	// a nonce exactly one succeeds
+	Consume(ctx context.Context, scope, nonce string) (string, error)
+}
+
+// Issue generates a random nonce, e.g. the OAuth state of a redirect to an identity provider,
+// and records it with data until ttl has passed
+func Issue(ctx context.Context, store Store, scope, data string, ttl time.Duration) (string, error) {
+	b := make([]byte, nonceBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+
+	if err := store.Put(ctx, scope, nonce, data, ttl); err != nil {
+		return "", err
+	}
+	return nonce, nil
+}
+
+// Redeem consumes a nonce created by Issue and returns its data. A replayed or forged
+// callback fails with ErrNotFound.
+func Redeem(ctx context.Context, store Store, scope, nonce string) (string, error) {
+	data, err := store.Consume(ctx, scope, nonce)
+	if errors.Is(err, ErrNotFound) {
+		metrics.NonceRejected.WithLabelValues(scope, "unknown").Inc()
+	}
+	return data, err
+}
+
+// Remember records the ID of an external assertion, e.g. a SAML assertion ID or a webhook
+// delivery ID, until the assertion expires. An assertion presented again fails with
+// ErrReplayed; expired assertions must be refused by the caller.
+func Remember(ctx context.Context, store Store, scope, id string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return ErrNotFound
+	}
+
+	err := store.Put(ctx, scope, id, "", ttl)
+	if errors.Is(err, ErrReplayed) {
+		metrics.NonceRejected.WithLabelValues(scope, "replayed").Inc()
+	}
+	return err
+}
@@ -0,0 +1,103 @@
+package nonces
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIssueAndRedeem(t *testing.T) {
+	store := NewLocalStore()
+	ctx := context.Background()
+
+	nonce, err := Issue(ctx, store, "oauth_state", "https://tickets.example.com/account", time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(nonce) != 43 {
+		t.Errorf("Expected a 43 character nonce, got %q", nonce)
+	}
+
+	if _, err := Redeem(ctx, store, "saml_relay_state", nonce); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected nonces of other scopes to be unknown, got %v", err)
+	}
+	data, err := Redeem(ctx, store, "oauth_state", nonce)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data != "https://tickets.example.com/account" {
+		t.Errorf("Expected the issued data, got %q", data)
+	}
+	if _, err := Redeem(ctx, store, "oauth_state", nonce); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a replayed nonce to be unknown, got %v", err)
+	}
+}
+
+func TestRedeemConcurrently(t *testing.T) {
+	store := NewLocalStore()
+	ctx := context.Background()
+	nonce, err := Issue(ctx, store, "oauth_state", "", time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	redeemed := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := Redeem(ctx, store, "oauth_state", nonce); err == nil {
+				mu.Lock()
+				redeemed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if redeemed != 1 {
+		t.Errorf("Expected the nonce to be redeemed once, got %d", redeemed)
+	}
+}
+
+func TestRemember(t *testing.T) {
+	store := NewLocalStore()
+	now := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := Remember(ctx, store, "saml_assertion", "_a1", time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := Remember(ctx, store, "saml_assertion", "_a1", time.Now().Add(time.Minute)); !errors.Is(err, ErrReplayed) {
+		t.Errorf("Expected ErrReplayed, got %v", err)
+	}
+	if err := Remember(ctx, store, "saml_assertion", "_a2", time.Now().Add(-time.Second)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected an expired assertion to be refused, got %v", err)
+	}
+
+	// Once the assertion expired its ID may be recorded again
+	now = now.Add(2 * time.Minute)
+	if err := Remember(ctx, store, "saml_assertion", "_a1", time.Now().Add(time.Minute)); err != nil {
+		t.Errorf("Expected an expired nonce to be recorded again, got %v", err)
+	}
+}
+
+func TestLocalStore_ExpiredNonce(t *testing.T) {
+	store := NewLocalStore()
+	now := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "oauth_state", "abc", "", time.Minute); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	now = now.Add(time.Minute)
+	if _, err := store.Consume(ctx, "oauth_state", "abc"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected an expired nonce to be unknown, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package nonces
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PostgresStore records nonces in the nonces table. Expired rows are ignored, and deleted by
+// Purge or when their nonce is recorded again.
+type PostgresStore struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// NewPostgresStore creates a store on the nonces table
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// Put inserts the nonce, replacing only an expired row of it
+func (s *PostgresStore) Put(ctx context.Context, scope, nonce, data string, ttl time.Duration) error {
+	now := s.now()
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO nonces (scope, nonce, data, expires_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (scope, nonce) DO UPDATE SET data = EXCLUDED.data, expires_at = EXCLUDED.expires_at
+		WHERE nonces.expires_at <= $5
+	`, scope, nonce, data, now.Add(ttl).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to record %s nonce: %w", scope, err)
+	}
+
+	recorded, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to record %s nonce: %w", scope, err)
+	}
+	if recorded == 0 {
+		return ErrReplayed
+	}
+	return nil
+}
+
+// Consume deletes the nonce row, which only one of concurrent transactions can do
+func (s *PostgresStore) Consume(ctx context.Context, scope, nonce string) (string, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `
+		DELETE FROM nonces WHERE scope = $1 AND nonce = $2 AND expires_at > $3
+		RETURNING data
+	`, scope, nonce, s.now().UnixMilli()).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to consume %s nonce: %w", scope, err)
+	}
+	return data, nil
+}
+
+// Purge deletes the rows of nonces that expired before end; it has the signature of a
+// scheduled job
+func (s *PostgresStore) Purge(ctx context.Context, _, end time.Time) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM nonces WHERE expires_at <= $1`, end.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to purge expired nonces: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package nonces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore records nonces as Redis keys expiring with the nonce
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore creates a store keeping nonces under keys starting with prefix
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Put sets the nonce key only if it does not exist
+func (s *RedisStore) Put(ctx context.Context, scope, nonce, data string, ttl time.Duration) error {
+	recorded, err := s.client.SetNX(ctx, s.key(scope, nonce), data, ttl).Result()
+	if err != nil {
+		return fmt.Errorf("failed to record %s nonce: %w", scope, err)
+	}
+	if !recorded {
+		return ErrReplayed
+	}
+	return nil
+}
+
+// Consume gets and deletes the nonce key in one command
+func (s *RedisStore) Consume(ctx context.Context, scope, nonce string) (string, error) {
+	data, err := s.client.GetDel(ctx, s.key(scope, nonce)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to consume %s nonce: %w", scope, err)
+	}
+	return data, nil
+}
+
+func (s *RedisStore) key(scope, nonce string) string {
+	return s.prefix + scope + ":" + nonce
+}
@@ -0,0 +1,223 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+	"user-svc/pkg/utils/metrics"
+)
+
+var (
+	ErrPipelineFull   = errors.New("pipeline buffer is full")
+	ErrPipelineClosed = errors.New("pipeline is closed")
+)
+
+// Policy defines what happens when an item is submitted to a full buffer
+type Policy string
+
+const (
+	// PolicyBlock waits for free capacity until the caller's context is done
+	PolicyBlock Policy = "block"
+	// PolicyDropNewest rejects the submitted item
+	PolicyDropNewest Policy = "drop_newest"
+	// PolicyDropOldest evicts the oldest buffered item to make room
+	PolicyDropOldest Policy = "drop_oldest"
+)
+
+// Sink persists or publishes a batch of items
+type Sink[T any] func(ctx context.Context, batch []T) error
+
+// Config holds pipeline configuration
+type Config struct {
+	Name          string
+	BufferSize    int
+	BatchSize     int
+	FlushInterval time.Duration
+	FlushTimeout  time.Duration
+	Policy        Policy
+}
+
+// Pipeline is a bounded asynchronous buffer that delivers items to a sink in batches
+type Pipeline[T any] struct {
+	cfg    Config
+	sink   Sink[T]
+	logger *logutils.Logger
+	items  chan T
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+	once   sync.Once
+}
+
+// New creates a new pipeline. Call Start to begin delivering items.
+func New[T any](cfg Config, sink Sink[T], logger *logutils.Logger) *Pipeline[T] {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 1024
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	if cfg.FlushTimeout <= 0 {
+		cfg.FlushTimeout = 10 * time.Second
+	}
+	if cfg.Policy == "" {
+		cfg.Policy = PolicyBlock
+	}
+
+	return &Pipeline[T]{
+		cfg:    cfg,
+		sink:   sink,
+		logger: logger,
+		items:  make(chan T, cfg.BufferSize),
+		done:   make(chan struct{}),
+	}
+}
+
+// Submit enqueues an item according to the pipeline's backpressure policy
+func (p *Pipeline[T]) Submit(ctx context.Context, item T) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrPipelineClosed
+	}
+
+	// Fast path: there is room in the buffer
+	select {
+	case p.items <- item:
+		p.updateDepth()
+		return nil
+	default:
+	}
+
+	switch p.cfg.Policy {
+	case PolicyDropNewest:
+		metrics.PipelineDropped.WithLabelValues(p.cfg.Name).Inc()
+		return ErrPipelineFull
+	case PolicyDropOldest:
+		for {
+			select {
+			case p.items <- item:
+				p.updateDepth()
+				return nil
+			default:
+			}
+			select {
+			case <-p.items:
+				metrics.PipelineDropped.WithLabelValues(p.cfg.Name).Inc()
+			default:
+			}
+		}
+	default:
+		select {
+		case p.items <- item:
+			p.updateDepth()
+			return nil
+		case <-p.done:
+			return ErrPipelineClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// QueueDepth returns the number of buffered items
+func (p *Pipeline[T]) QueueDepth() int {
+	return len(p.items)
+}
+
+// Start runs the delivery loop until ctx is cancelled, then flushes the remaining items
+func (p *Pipeline[T]) Start(ctx context.Context, wg *sync.WaitGroup) {
+	logger := p.logger.WithField("stream", p.cfg.Name)
+	logger.Info("Starting async pipeline")
+
+	wg.Add(1)
+	go func() {
+		defer func() {
+			wg.Done()
+			logger.Info("Async pipeline stopped")
+		}()
+
+		ticker := time.NewTicker(p.cfg.FlushInterval)
+		defer ticker.Stop()
+
+		batch := make([]T, 0, p.cfg.BatchSize)
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Stopping async pipeline (context cancelled)")
+				p.close()
+				batch = p.drain(batch)
+				p.flush(batch)
+				return
+			case item := <-p.items:
+				batch = append(batch, item)
+				if len(batch) >= p.cfg.BatchSize {
+					batch = p.flush(batch)
+				}
+			case <-ticker.C:
+				batch = p.flush(batch)
+			}
+		}
+	}()
+}
+
+// close rejects new submissions and wakes up any blocked submitters
+func (p *Pipeline[T]) close() {
+	p.once.Do(func() {
+		close(p.done)
+		p.mu.Lock()
+		p.closed = true
+		p.mu.Unlock()
+	})
+}
+
+// drain moves every buffered item into the batch, flushing full batches along the way
+func (p *Pipeline[T]) drain(batch []T) []T {
+	for {
+		select {
+		case item := <-p.items:
+			batch = append(batch, item)
+			if len(batch) >= p.cfg.BatchSize {
+				batch = p.flush(batch)
+			}
+		default:
+			return batch
+		}
+	}
+}
+
+// flush delivers the batch to the sink and returns an empty batch for reuse
+func (p *Pipeline[T]) flush(batch []T) []T {
+	p.updateDepth()
+	if len(batch) == 0 {
+		return batch
+	}
+
+	// Use a fresh context so in-flight batches survive application shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), p.cfg.FlushTimeout)
+	defer cancel()
+
+	if err := p.sink(ctx, batch); err != nil {
+		metrics.PipelineFlushErrors.WithLabelValues(p.cfg.Name).Inc()
+		p.logger.WithError(err).WithFields(logutils.Fields{
+			"stream": p.cfg.Name,
+			"count":  len(batch),
+		}).Error("Failed to flush pipeline batch")
+	} else {
+		metrics.PipelineFlushed.WithLabelValues(p.cfg.Name).Add(float64(len(batch)))
+	}
+
+	return batch[:0]
+}
+
+func (p *Pipeline[T]) updateDepth() {
+	metrics.PipelineQueueDepth.WithLabelValues(p.cfg.Name).Set(float64(len(p.items)))
+}
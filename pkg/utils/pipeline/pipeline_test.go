@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+type collector struct {
+	mu      sync.Mutex
+	items   []int
+	batches int
+}
+
+func (c *collector) sink(_ context.Context, batch []int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = append(c.items, batch...)
+	c.batches++
+	return nil
+}
+
+func (c *collector) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+func TestPipeline_FlushOnShutdown(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	c := &collector{}
+	p := New(Config{Name: "test", BufferSize: 100, BatchSize: 10, FlushInterval: time.Hour}, c.sink, logutils.New(os.Stderr))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	p.Start(ctx, &wg)
+
+	for i := 0; i < 25; i++ {
+		assert.NoError(t, p.Submit(context.Background(), i))
+	}
+
+	cancel()
+	wg.Wait()
+
+	assert.Equal(t, 25, c.count(), "all submitted items should be flushed on shutdown")
+	assert.ErrorIs(t, p.Submit(context.Background(), 99), ErrPipelineClosed)
+}
+
+func TestPipeline_FlushInterval(t *testing.T) {
+	c := &collector{}
+	p := New(Config{Name: "test", BufferSize: 100, BatchSize: 1000, FlushInterval: 10 * time.Millisecond}, c.sink, logutils.New(os.Stderr))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	p.Start(ctx, &wg)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	assert.NoError(t, p.Submit(context.Background(), 1))
+	assert.Eventually(t, func() bool { return c.count() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestPipeline_DropNewest(t *testing.T) {
+	p := New(Config{Name: "test", BufferSize: 2, Policy: PolicyDropNewest}, (&collector{}).sink, logutils.New(os.Stderr))
+
+	assert.NoError(t, p.Submit(context.Background(), 1))
+	assert.NoError(t, p.Submit(context.Background(), 2))
+	assert.ErrorIs(t, p.Submit(context.Background(), 3), ErrPipelineFull)
+	assert.Equal(t, 2, p.QueueDepth())
+}
+
+func TestPipeline_DropOldest(t *testing.T) {
+	p := New(Config{Name: "test", BufferSize: 2, Policy: PolicyDropOldest}, (&collector{}).sink, logutils.New(os.Stderr))
+
+	assert.NoError(t, p.Submit(context.Background(), 1))
+	assert.NoError(t, p.Submit(context.Background(), 2))
+	assert.NoError(t, p.Submit(context.Background(), 3))
+
+	assert.Equal(t, 2, <-p.items)
+	assert.Equal(t, 3, <-p.items)
+}
+
+func TestPipeline_BlockRespectsContext(t *testing.T) {
+	p := New(Config{Name: "test", BufferSize: 1, Policy: PolicyBlock}, (&collector{}).sink, logutils.New(os.Stderr))
+
+	assert.NoError(t, p.Submit(context.Background(), 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.Submit(ctx, 2), context.DeadlineExceeded)
+}
@@ -0,0 +1,41 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+)
+
+// Check is a single startup verification or warm-up step
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Run executes the checks in order and stops at the first failure.
+// The whole phase is bounded by timeout.
+func Run(ctx context.Context, logger *logutils.Logger, timeout time.Duration, checks ...Check) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	logger.WithField("checks", len(checks)).Info("Running preflight checks")
+	start := time.Now()
+
+	for _, check := range checks {
+		checkStart := time.Now()
+		if err := check.Run(ctx); err != nil {
+			logger.WithError(err).WithField("check", check.Name).Error("Preflight check failed")
+			return fmt.Errorf("preflight check %s failed: %w", check.Name, err)
+		}
+
+		logger.WithFields(logutils.Fields{
+			"check":    check.Name,
+			"duration": time.Since(checkStart),
+		}).Debug("Preflight check passed")
+	}
+
+	logger.WithField("duration", time.Since(start)).Info("Preflight checks passed")
+	return nil
+}
@@ -0,0 +1,43 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testLogger() *logutils.Logger {
+	return logutils.New(io.Discard)
+}
+
+func TestRun_StopsAtFirstFailure(t *testing.T) {
+	errBroken := errors.New("broken")
+	var ran []string
+
+	err := Run(context.Background(), testLogger(), time.Second,
+		Check{Name: "first", Run: func(context.Context) error { ran = append(ran, "first"); return nil }},
+		Check{Name: "second", Run: func(context.Context) error { ran = append(ran, "second"); return errBroken }},
+		Check{Name: "third", Run: func(context.Context) error { ran = append(ran, "third"); return nil }},
+	)
+
+	assert.ErrorIs(t, err, errBroken)
+	assert.Contains(t, err.Error(), "second")
+	assert.Equal(t, []string{"first", "second"}, ran)
+}
+
+func TestRun_Timeout(t *testing.T) {
+	err := Run(context.Background(), testLogger(), 10*time.Millisecond,
+		Check{Name: "slow", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
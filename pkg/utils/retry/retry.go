@@ -0,0 +1,77 @@
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Policy describes how an operation is retried
+type Policy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// DefaultPolicy returns a conservative policy suitable for database reads
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:    3,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+}
+
+// Do runs fn until it succeeds, returns a non-retryable error, the attempts are
+// exhausted or ctx is done. The last error returned by fn is returned.
+func Do(ctx context.Context, policy Policy, retryable func(error) bool, fn func(ctx context.Context) error) error {
+	attempts := policy.MaxAttempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+
+		if !retryable(err) || attempt == attempts-1 {
+			return err
+		}
+
+		timer := time.NewTimer(policy.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+
+	return err
+}
+
+// Backoff returns the jittered delay before the retry following the given attempt (0-based).
+// Full jitter is used so that replicas recovering from the same failover do not retry in lockstep.
+func (p Policy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff)
+	for i := 0; i < attempt; i++ {
+		backoff *= multiplier
+	}
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(backoff)) + 1)
+}
@@ -0,0 +1,78 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+func isTransient(err error) bool { return errors.Is(err, errTransient) }
+
+func TestDo_RetriesTransientErrors(t *testing.T) {
+	policy := Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	calls := 0
+	err := Do(context.Background(), policy, isTransient, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errTransient
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestDo_DoesNotRetryPermanentErrors(t *testing.T) {
+	policy := Policy{MaxAttempts: 5, InitialBackoff: time.Millisecond}
+	permanent := errors.New("permanent")
+
+	calls := 0
+	err := Do(context.Background(), policy, isTransient, func(context.Context) error {
+		calls++
+		return permanent
+	})
+
+	if !errors.Is(err, permanent) {
+		t.Errorf("Expected permanent error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestDo_StopsWhenAttemptsExhausted(t *testing.T) {
+	policy := Policy{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+
+	calls := 0
+	err := Do(context.Background(), policy, isTransient, func(context.Context) error {
+		calls++
+		return errTransient
+	})
+
+	if !errors.Is(err, errTransient) {
+		t.Errorf("Expected transient error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestPolicy_BackoffIsBounded(t *testing.T) {
+	policy := Policy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 40 * time.Millisecond, Multiplier: 2}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		backoff := policy.Backoff(attempt)
+		if backoff <= 0 || backoff > 40*time.Millisecond {
+			t.Errorf("Backoff for attempt %d out of range: %v", attempt, backoff)
+		}
+	}
+}
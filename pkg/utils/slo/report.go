@@ -0,0 +1,28 @@
+package slo
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"user-svc/pkg/utils/metrics"
+)
+
+// Handler serves the current report as JSON for dashboards
+func Handler(t *Tracker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(t.Report()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// publish exports the report as gauges on the metrics endpoint
+func publish(report *Report) {
+	for _, status := range report.Statuses {
+		metrics.SLOAvailability.WithLabelValues(status.Method).Set(status.Availability)
+		metrics.SLOLatencyCompliance.WithLabelValues(status.Method).Set(status.LatencyCompliance)
+		metrics.SLOErrorBudgetRemaining.WithLabelValues(status.Method, "availability").Set(status.AvailabilityErrorBudgetRemaining)
+		metrics.SLOErrorBudgetRemaining.WithLabelValues(status.Method, "latency").Set(status.LatencyErrorBudgetRemaining)
+	}
+}
@@ -0,0 +1,224 @@
+package slo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	logutils "user-svc/pkg/utils/log"
+
+	"google.golang.org/grpc/codes"
+)
+
+// numBuckets is the number of time buckets a rolling window is split into
+const numBuckets = 60
+
+// Target defines the service level objectives of a single method
+type Target struct {
+	Method string
+	// LatencyThreshold is the duration under which a request counts as fast
+	LatencyThreshold time.Duration
+	// LatencyObjective is the fraction (0..1) of requests that must be fast
+	LatencyObjective float64
+	// AvailabilityObjective is the fraction (0..1) of requests that must not fail server-side
+	AvailabilityObjective float64
+}
+
+// Status is the rolling compliance of a single method
+type Status struct {
+	Method                           string        `json:"method"`
+	TotalRequests                    int64         `json:"total_requests"`
+	FailedRequests                   int64         `json:"failed_requests"`
+	SlowRequests                     int64         `json:"slow_requests"`
+	Availability                     float64       `json:"availability"`
+	AvailabilityObjective            float64       `json:"availability_objective"`
+	AvailabilityErrorBudgetRemaining float64       `json:"availability_error_budget_remaining"`
+	LatencyCompliance                float64       `json:"latency_compliance"`
+	LatencyObjective                 float64       `json:"latency_objective"`
+	LatencyThreshold                 time.Duration `json:"latency_threshold_ns"`
+	LatencyErrorBudgetRemaining      float64       `json:"latency_error_budget_remaining"`
+	Met                              bool          `json:"met"`
+}
+
+// Report is a point-in-time snapshot of every tracked method
+type Report struct {
+	Window      time.Duration `json:"window_ns"`
+	GeneratedAt time.Time     `json:"generated_at"`
+	Statuses    []Status      `json:"statuses"`
+}
+
+type bucket struct {
+	start  int64
+	total  int64
+	failed int64
+	slow   int64
+}
+
+type series struct {
+	target  Target
+	buckets [numBuckets]bucket
+}
+
+// Tracker keeps rolling request counters for methods with SLO targets
+type Tracker struct {
+	window     time.Duration
+	bucketSize time.Duration
+	now        func() time.Time
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// NewTracker creates a tracker computing compliance over the given rolling window
+func NewTracker(window time.Duration, targets []Target) *Tracker {
+	bucketSize := window / numBuckets
+	if bucketSize <= 0 {
+		bucketSize = time.Second
+	}
+
+	t := &Tracker{
+		window:     window,
+		bucketSize: bucketSize,
+		now:        time.Now,
+		series:     make(map[string]*series, len(targets)),
+	}
+	for _, target := range targets {
+		t.series[target.Method] = &series{target: target}
+	}
+
+	return t
+}
+
+// Observe records a finished request. Methods without a target are ignored.
+func (t *Tracker) Observe(method string, duration time.Duration, code codes.Code) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.series[method]
+	if !ok {
+		return
+	}
+
+	slot := t.now().UnixNano() / int64(t.bucketSize)
+	b := &s.buckets[slot%numBuckets]
+	if b.start != slot {
+		*b = bucket{start: slot}
+	}
+
+	b.total++
+	if IsServerError(code) {
+		b.failed++
+	}
+	if duration > s.target.LatencyThreshold {
+		b.slow++
+	}
+}
+
+// Report computes the current compliance of every tracked method
+func (t *Tracker) Report() *Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	oldest := now.UnixNano()/int64(t.bucketSize) - numBuckets + 1
+
+	statuses := make([]Status, 0, len(t.series))
+	for _, s := range t.series {
+		var total, failed, slow int64
+		for _, b := range s.buckets {
+			if b.start >= oldest {
+				total += b.total
+				failed += b.failed
+				slow += b.slow
+			}
+		}
+		statuses = append(statuses, newStatus(s.target, total, failed, slow))
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Method < statuses[j].Method
+	})
+
+	return &Report{
+		Window:      t.window,
+		GeneratedAt: now,
+		Statuses:    statuses,
+	}
+}
+
+// Start periodically publishes the report as metrics until ctx is cancelled
+func (t *Tracker) Start(ctx context.Context, wg *sync.WaitGroup, interval time.Duration, logger *logutils.Logger) {
+	logger.Info("Starting SLO reporter")
+
+	wg.Add(1)
+	go func() {
+		defer func() {
+			wg.Done()
+			logger.Info("SLO reporter stopped")
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				publish(t.Report())
+			}
+		}
+	}()
+}
+
+// IsServerError reports whether a status code counts against availability.
+// Client errors such as InvalidArgument or NotFound do not burn the error budget.
+func IsServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded, codes.Unimplemented:
+		return true
+	default:
+		return false
+	}
+}
+
+func newStatus(target Target, total, failed, slow int64) Status {
+	status := Status{
+		Method:                           target.Method,
+		TotalRequests:                    total,
+		FailedRequests:                   failed,
+		SlowRequests:                     slow,
+		Availability:                     1,
+		AvailabilityObjective:            target.AvailabilityObjective,
+		AvailabilityErrorBudgetRemaining: 1,
+		LatencyCompliance:                1,
+		LatencyObjective:                 target.LatencyObjective,
+		LatencyThreshold:                 target.LatencyThreshold,
+		LatencyErrorBudgetRemaining:      1,
+	}
+
+	if total > 0 {
+		status.Availability = 1 - float64(failed)/float64(total)
+		status.LatencyCompliance = 1 - float64(slow)/float64(total)
+		status.AvailabilityErrorBudgetRemaining = budgetRemaining(failed, total, target.AvailabilityObjective)
+		status.LatencyErrorBudgetRemaining = budgetRemaining(slow, total, target.LatencyObjective)
+	}
+
+	status.Met = status.Availability >= target.AvailabilityObjective &&
+		status.LatencyCompliance >= target.LatencyObjective
+
+	return status
+}
+
+// budgetRemaining returns the fraction of the error budget left; negative once overspent
+func budgetRemaining(bad, total int64, objective float64) float64 {
+	budget := (1 - objective) * float64(total)
+	if budget <= 0 {
+		if bad == 0 {
+			return 1
+		}
+		return 0
+	}
+	return 1 - float64(bad)/budget
+}
@@ -0,0 +1,72 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+const loginMethod = "/user.UserService/Login"
+
+func newTestTracker(now *time.Time) *Tracker {
+	tracker := NewTracker(time.Minute, []Target{{
+		Method:                loginMethod,
+		LatencyThreshold:      300 * time.Millisecond,
+		LatencyObjective:      0.9,
+		AvailabilityObjective: 0.9,
+	}})
+	tracker.now = func() time.Time { return *now }
+	return tracker
+}
+
+func TestTrackerReport(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tracker := newTestTracker(&now)
+
+	for i := 0; i < 18; i++ {
+		tracker.Observe(loginMethod, 10*time.Millisecond, codes.OK)
+	}
+	tracker.Observe(loginMethod, time.Second, codes.OK)
+	tracker.Observe(loginMethod, 10*time.Millisecond, codes.Internal)
+	// Client errors and untracked methods do not count against the SLO
+	tracker.Observe(loginMethod, 10*time.Millisecond, codes.InvalidArgument)
+	tracker.Observe("/user.UserService/Register", time.Second, codes.Internal)
+
+	report := tracker.Report()
+	assert.Len(t, report.Statuses, 1)
+
+	status := report.Statuses[0]
+	assert.Equal(t, int64(21), status.TotalRequests)
+	assert.Equal(t, int64(1), status.FailedRequests)
+	assert.Equal(t, int64(1), status.SlowRequests)
+	assert.InDelta(t, 20.0/21.0, status.Availability, 1e-9)
+	assert.InDelta(t, 1-1/(0.1*21), status.AvailabilityErrorBudgetRemaining, 1e-9)
+	assert.True(t, status.Met)
+}
+
+func TestTrackerRollingWindow(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tracker := newTestTracker(&now)
+
+	tracker.Observe(loginMethod, time.Second, codes.Unavailable)
+	assert.False(t, tracker.Report().Statuses[0].Met)
+
+	now = now.Add(30 * time.Second)
+	tracker.Observe(loginMethod, 10*time.Millisecond, codes.OK)
+	assert.Equal(t, int64(2), tracker.Report().Statuses[0].TotalRequests)
+
+	// The first request falls out of the one minute window
+	now = now.Add(45 * time.Second)
+	status := tracker.Report().Statuses[0]
+	assert.Equal(t, int64(1), status.TotalRequests)
+	assert.Equal(t, int64(0), status.FailedRequests)
+	assert.True(t, status.Met)
+}
+
+func TestBudgetRemaining(t *testing.T) {
+	assert.InDelta(t, 1.0, budgetRemaining(0, 1000, 0.999), 1e-9)
+	assert.InDelta(t, 0.5, budgetRemaining(1, 2000, 0.999), 1e-9)
+	assert.InDelta(t, -1.0, budgetRemaining(2, 1000, 0.999), 1e-9)
+}
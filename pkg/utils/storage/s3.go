@@ -0,0 +1,285 @@
+// Package storage is a minimal client for S3-compatible object storage: Amazon S3, Google
+// Cloud Storage through its XML API with HMAC keys, and MinIO. It only pre-signs uploads and
+// inspects and deletes objects, so requests are signed with AWS Signature Version 4 directly
+// instead of pulling in a cloud SDK.
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	// maxPresignTTL is the longest validity SigV4 allows for a pre-signed URL
+	maxPresignTTL = 7 * 24 * time.Hour
+)
+
+// emptyPayloadHash is the SHA-256 of an empty body
+var emptyPayloadHash = hex.EncodeToString(sha256.New().Sum(nil))
+
+// ErrObjectNotFound is returned for objects that do not exist
+var ErrObjectNotFound = errors.New("object not found")
+
+// Config configures the bucket a Client works on
+type Config struct {
+	// Endpoint is the storage API, e.g. https://s3.eu-central-1.amazonaws.com or
+	// https://storage.googleapis.com
+	Endpoint string
+	// Region signs requests; GCS accepts "auto"
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle addresses the bucket in the path instead of the host name, e.g. for MinIO
+	PathStyle bool
+}
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+}
+
+// Client signs and sends object requests to one bucket
+type Client struct {
+	cfg        Config
+	endpoint   *url.URL
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewClient creates a client for the configured bucket
+func NewClient(cfg Config, httpClient *http.Client) (*Client, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "https" && endpoint.Scheme != "http") {
+		return nil, fmt.Errorf("invalid storage endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" || cfg.Region == "" {
+		return nil, fmt.Errorf("storage bucket and region are required")
+	}
+
+	return &Client{
+		cfg:        cfg,
+		endpoint:   endpoint,
+		httpClient: httpClient,
+		now:        time.Now,
+	}, nil
+}
+
+// PresignPut returns a URL that uploads the object with a PUT until ttl passes. The upload
+// must send the given Content-Type header, which is part of the signature.
+func (c *Client) PresignPut(key, contentType string, ttl time.Duration) (string, error) {
+	if ttl <= 0 || ttl > maxPresignTTL {
+		return "", fmt.Errorf("pre-signed URL validity must be between 1s and %s", maxPresignTTL)
+	}
+
+	return c.presign(http.MethodPut, key, map[string]string{"content-type": contentType}, ttl, c.now().UTC()), nil
+}
+
+// Head returns the size and content type of an object, ErrObjectNotFound if there is none
+func (c *Client) Head(ctx context.Context, key string) (*ObjectInfo, error) {
+	resp, err := c.do(ctx, http.MethodHead, key)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrObjectNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to inspect object: %s", resp.Status)
+	}
+
+	return &ObjectInfo{
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+	}, nil
+}
+
+// Delete deletes an object; deleting a missing object succeeds
+func (c *Client) Delete(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete object: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, key string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.sign(req, emptyPayloadHash, c.now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// objectURL addresses the object virtual-hosted style, or path style if configured
+func (c *Client) objectURL(key string) *url.URL {
+	u := *c.endpoint
+	if c.cfg.PathStyle {
+		u.Path = "/" + c.cfg.Bucket + "/" + key
+	} else {
+		u.Host = c.cfg.Bucket + "." + c.endpoint.Host
+		u.Path = "/" + key
+	}
+	u.RawPath = escapePath(u.Path)
+	return &u
+}
+
+// presign signs the request in the query string with the headers the client must send
+func (c *Client) presign(method, key string, headers map[string]string, ttl time.Duration, now time.Time) string {
+	u := c.objectURL(key)
+	headers["host"] = u.Host
+
+	signedHeaders := sortedKeys(headers)
+	query := map[string]string{
+		"X-Amz-Algorithm":     signingAlgorithm,
+		"X-Amz-Credential":    c.cfg.AccessKeyID + "/" + c.scope(now),
+		"X-Amz-Date":          now.Format(amzDateFormat),
+		"X-Amz-Expires":       strconv.Itoa(int(ttl.Seconds())),
+		"X-Amz-SignedHeaders": strings.Join(signedHeaders, ";"),
+	}
+	canonicalQuery := canonicalQueryString(query)
+
+	// The body of a pre-signed upload is not known when signing
+	request := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		canonicalQuery,
+		canonicalHeaders(headers, signedHeaders),
+		strings.Join(signedHeaders, ";"),
+		unsignedPayload,
+	}, "\n")
+
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + c.signature(request, now)
+	return u.String()
+}
+
+// sign adds the Authorization header for the request headers and the payload hash
+func (c *Client) sign(req *http.Request, payloadHash string, now time.Time) {
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+	signedHeaders := sortedKeys(headers)
+
+	request := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQueryString(nil),
+		canonicalHeaders(headers, signedHeaders),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, c.cfg.AccessKeyID, c.scope(now), strings.Join(signedHeaders, ";"), c.signature(request, now)))
+}
+
+func (c *Client) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + c.cfg.Region + "/s3/aws4_request"
+}
+
+func (c *Client) signature(canonicalRequest string, now time.Time) string {
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		now.Format(amzDateFormat),
+		c.scope(now),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.cfg.SecretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, c.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalHeaders(headers map[string]string, names []string) string {
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(strings.TrimSpace(headers[name]))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func canonicalQueryString(query map[string]string) string {
+	pairs := make([]string, 0, len(query))
+	for _, name := range sortedKeys(query) {
+		pairs = append(pairs, escape(name)+"="+escape(query[name]))
+	}
+	return strings.Join(pairs, "&")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePath escapes every path segment the way SigV4 canonicalizes it
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escape percent-encodes everything but the unreserved characters of RFC 3986
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ('A' <= ch && ch <= 'Z') || ('a' <= ch && ch <= 'z') || ('0' <= ch && ch <= '9') ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' {
+			b.WriteByte(ch)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", ch)
+	}
+	return b.String()
+}
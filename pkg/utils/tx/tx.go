@@ -33,13 +33,18 @@ func GetTxFromContext(ctx context.Context) (*sqlx.Tx, bool) {
 	return tx, ok
 }
 
+// Beginner starts transactions, e.g. a *sqlx.DB or a router picking the database of the caller
+type Beginner interface {
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+}
+
 // TransactionManager manages database transactions
 type TransactionManager struct {
-	db *sqlx.DB
+	db Beginner
 }
 
 // NewTransactionManager creates a new transaction manager
-func NewTransactionManager(db *sqlx.DB) *TransactionManager {
+func NewTransactionManager(db Beginner) *TransactionManager {
 	return &TransactionManager{db: db}
 }
 
@@ -53,6 +58,21 @@ func (tm *TransactionManager) WithTransaction(ctx context.Context, fn func(*TxWr
 
 // WithTransactionOptions executes a function within a database transaction with custom options
 func (tm *TransactionManager) WithTransactionOptions(ctx context.Context, fn func(*TxWrapper) error, opts *sql.TxOptions) error {
+	return tm.run(ctx, fn, opts, false)
+}
+
+// WithDryRunTransaction executes a function within a database transaction like
+// WithTransaction, but rolls the transaction back instead of committing it if dryRun is set,
+// so the function can report what it would change without changing anything
+func (tm *TransactionManager) WithDryRunTransaction(ctx context.Context, dryRun bool, fn func(*TxWrapper) error) error {
+	return tm.run(ctx, fn, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+		ReadOnly:  false,
+	}, dryRun)
+}
+
+// run executes fn in a transaction and commits it, unless fn fails or rollback is set
+func (tm *TransactionManager) run(ctx context.Context, fn func(*TxWrapper) error, opts *sql.TxOptions, rollback bool) error {
 	tx, err := tm.db.BeginTxx(ctx, opts)
 	if err != nil {
 		return err
@@ -71,6 +91,11 @@ func (tm *TransactionManager) WithTransactionOptions(ctx context.Context, fn fun
 		return err
 	}
 
+	// Roll back a successful dry run
+	if rollback {
+		return tx.Rollback()
+	}
+
 	// Commit on success
 	return tx.Commit()
 }
@@ -0,0 +1,86 @@
+// Package webhook delivers signed JSON payloads to the HTTP endpoints of third parties. The
+// headers and signature follow the Standard Webhooks specification, so receivers can verify
+// deliveries with its libraries.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers of a delivery
+const (
+	IDHeader        = "Webhook-Id"
+	TimestampHeader = "Webhook-Timestamp"
+	SignatureHeader = "Webhook-Signature"
+)
+
+// maxDrainedBody bounds how much of a response is read so the connection can be reused
+const maxDrainedBody = 64 << 10
+
+// StatusError is returned when the endpoint answers with a status other than 2xx
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook endpoint answered with status %d", e.StatusCode)
+}
+
+// Sign returns the signature of a delivery: the base64 HMAC-SHA256 of "<id>.<timestamp>.<body>"
+// keyed with the secret, prefixed with its version "v1,"
+func Sign(secret, id string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id + "." + strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Client posts signed deliveries
+type Client struct {
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewClient creates a client sending requests with httpClient, which should have a timeout
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+		now:        time.Now,
+	}
+}
+
+// Post delivers the JSON body to url, signed with secret. A delivery that is retried keeps
+// its id, so receivers can drop duplicates.
+func (c *Client) Post(ctx context.Context, url, secret, id string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	timestamp := c.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IDHeader, id)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(secret, id, timestamp, body))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
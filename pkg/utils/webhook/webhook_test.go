@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(`delivery-1.1709287200.{"events":[]}`))
+	expected := "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if signature := Sign("secret", "delivery-1", 1709287200, []byte(`{"events":[]}`)); signature != expected {
+		t.Errorf("Expected %s, got %s", expected, signature)
+	}
+	if Sign("secret", "delivery-2", 1709287200, []byte(`{"events":[]}`)) == expected {
+		t.Errorf("Expected the delivery ID to be signed")
+	}
+}
+
+func TestClient_Post(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.now = func() time.Time { return now }
+
+	if err := client.Post(context.Background(), server.URL, "secret", "delivery-1", []byte(`{"events":[]}`)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if received.Header.Get(IDHeader) != "delivery-1" {
+		t.Errorf("Expected the delivery ID, got %q", received.Header.Get(IDHeader))
+	}
+	if received.Header.Get(TimestampHeader) != strconv.FormatInt(now.Unix(), 10) {
+		t.Errorf("Expected the delivery timestamp, got %q", received.Header.Get(TimestampHeader))
+	}
+	if received.Header.Get(SignatureHeader) != Sign("secret", "delivery-1", now.Unix(), body) {
+		t.Errorf("Expected the body to be signed, got %q", received.Header.Get(SignatureHeader))
+	}
+}
+
+func TestClient_PostRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewClient(server.Client()).Post(context.Background(), server.URL, "secret", "delivery-1", []byte(`{}`))
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected a status error with 503, got %v", err)
+	}
+}
@@ -27,14 +27,16 @@ echo "Request:"
 echo '{
   "email": "test@example.com",
   "username": "testuser",
-  "password": "password123"
+  "password": "password123",
+  "client_id": "web"
 }'
 echo ""
 echo "Response:"
 grpcurl -plaintext -d '{
   "email": "test@example.com",
   "username": "testuser",
-  "password": "password123"
+  "password": "password123",
+  "client_id": "web"
 }' localhost:50051 user.UserService/Register
 
 echo ""
@@ -46,14 +48,16 @@ echo "Request:"
 echo '{
   "email": "alice@example.com",
   "username": "alice",
-  "password": "securepass456"
+  "password": "securepass456",
+  "client_id": "web"
 }'
 echo ""
 echo "Response:"
 grpcurl -plaintext -d '{
   "email": "alice@example.com",
   "username": "alice",
-  "password": "securepass456"
+  "password": "securepass456",
+  "client_id": "web"
 }' localhost:50051 user.UserService/Register
 
 echo ""
@@ -65,14 +69,16 @@ echo "Request:"
 echo '{
   "email": "admin@example.com",
   "username": "admin",
-  "password": "admin123"
+  "password": "admin123",
+  "client_id": "web"
 }'
 echo ""
 echo "Response:"
 grpcurl -plaintext -d '{
   "email": "admin@example.com",
   "username": "admin",
-  "password": "admin123"
+  "password": "admin123",
+  "client_id": "web"
 }' localhost:50051 user.UserService/Register
 
 echo ""
@@ -89,13 +95,15 @@ echo "🧪 Test 1: Login with test user"
 echo "Request:"
 echo '{
   "email": "test@example.com",
-  "password": "password123"
+  "password": "password123",
+  "client_id": "web"
 }'
 echo ""
 echo "Response:"
 grpcurl -plaintext -d '{
   "email": "test@example.com",
-  "password": "password123"
+  "password": "password123",
+  "client_id": "web"
 }' localhost:50051 user.UserService/Login
 
 echo ""
@@ -106,13 +114,15 @@ echo "🧪 Test 2: Login with alice user"
 echo "Request:"
 echo '{
   "email": "alice@example.com",
-  "password": "securepass456"
+  "password": "securepass456",
+  "client_id": "web"
 }'
 echo ""
 echo "Response:"
 grpcurl -plaintext -d '{
   "email": "alice@example.com",
-  "password": "securepass456"
+  "password": "securepass456",
+  "client_id": "web"
 }' localhost:50051 user.UserService/Login
 
 echo ""
@@ -123,13 +133,15 @@ echo "🧪 Test 3: Login with admin user"
 echo "Request:"
 echo '{
   "email": "admin@example.com",
-  "password": "admin123"
+  "password": "admin123",
+  "client_id": "web"
 }'
 echo ""
 echo "Response:"
 grpcurl -plaintext -d '{
   "email": "admin@example.com",
-  "password": "admin123"
+  "password": "admin123",
+  "client_id": "web"
 }' localhost:50051 user.UserService/Login
 
 echo ""
@@ -140,13 +152,15 @@ echo "🧪 Test 4: Login with non-existent user"
 echo "Request:"
 echo '{
   "email": "nonexistent@example.com",
-  "password": "wrongpassword"
+  "password": "wrongpassword",
+  "client_id": "web"
 }'
 echo ""
 echo "Response:"
 grpcurl -plaintext -d '{
   "email": "nonexistent@example.com",
-  "password": "wrongpassword"
+  "password": "wrongpassword",
+  "client_id": "web"
 }' localhost:50051 user.UserService/Login
 
 echo ""